@@ -0,0 +1,197 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apimanagement
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+)
+
+// The API Management trait registers the HTTP endpoints exposed by the integration service, and its
+// Open-API specification, with an API gateway, so that the API is managed by the gateway.
+//
+// The gateway is selected with the `provider` option, among:
+//
+// * `3scale`: the service is labelled and annotated, so that it's discovered by 3scale,
+// * `gloo`: a Gloo `Upstream`, whose functions are discovered from the Open-API specification, and a `VirtualService` routing to it are created,
+// * `kong`: an `<integration>-kong` `Ingress`, bound to the Kong ingress class, is created, and the service is annotated with the Kong protocol,
+// * `apisix`: an Apache APISIX `ApisixRoute` is created.
+//
+// The 3scale trait is superseded by this trait with the `3scale` provider.
+//
+// The API Management trait is disabled by default.
+//
+// +camel-k:trait=api-management
+type apiManagementTrait struct {
+	trait.BaseTrait `property:",squash"`
+	// The API gateway the API is registered with, either `3scale`, `gloo`, `kong` or `apisix` (default `3scale`).
+	Provider string `property:"provider" json:"provider,omitempty"`
+	// Enables automatic configuration of the trait.
+	Auto *bool `property:"auto" json:"auto,omitempty"`
+	// The scheme to use to contact the service (default `http`)
+	Scheme string `property:"scheme" json:"scheme,omitempty"`
+	// The path where the API is published (default `/`)
+	Path string `property:"path" json:"path,omitempty"`
+	// The port where the service is exposed (default `80`)
+	Port int `property:"port" json:"port,omitempty"`
+	// The path where the Open-API specification is published (default `/openapi.json`)
+	DescriptionPath *string `property:"description-path" json:"descriptionPath,omitempty"`
+	// The host the gateway exposes the API on, that's not used by the `3scale` provider (default all hosts).
+	Host string `property:"host" json:"host,omitempty"`
+	// The ingress class of the gateway, used by the `kong` and `apisix` providers (default `kong` for the `kong` provider).
+	IngressClass string `property:"ingress-class" json:"ingressClass,omitempty"`
+}
+
+const (
+	defaultScheme          = "http"
+	defaultPath            = "/"
+	defaultPort            = 80
+	defaultDescriptionPath = "/openapi.json"
+)
+
+// Provider registers the API exposed by the integration service with an API gateway
+type Provider interface {
+	// ID returns the name of the provider, as set with the `provider` option of the trait
+	ID() string
+	// Register registers the API exposed by the given service with the gateway
+	Register(e *trait.Environment, api API, service *corev1.Service) error
+}
+
+// API describes the API exposed by the integration service
+type API struct {
+	// Scheme is the scheme to use to contact the service
+	Scheme string
+	// Path is the path where the API is published
+	Path string
+	// Port is the port where the service is exposed
+	Port int
+	// DescriptionPath is the path where the Open-API specification is published, if any
+	DescriptionPath string
+	// Host is the host the gateway exposes the API on, if any
+	Host string
+	// IngressClass is the ingress class of the gateway, if any
+	IngressClass string
+}
+
+var providers = make(map[string]Provider)
+
+// RegisterProvider registers the given API gateway provider
+func RegisterProvider(provider Provider) {
+	providers[provider.ID()] = provider
+}
+
+func init() {
+	RegisterProvider(threeScaleProvider{})
+	RegisterProvider(glooProvider{})
+	RegisterProvider(kongProvider{})
+	RegisterProvider(apisixProvider{})
+}
+
+// NewAPIManagementTrait --
+func NewAPIManagementTrait() trait.Trait {
+	return &apiManagementTrait{
+		BaseTrait: trait.NewBaseTrait("api-management", trait.TraitOrderPostProcessResources),
+	}
+}
+
+func (t *apiManagementTrait) Configure(e *trait.Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		// disabled by default
+		return false, nil
+	}
+
+	if _, err := t.getProvider(); err != nil {
+		return false, err
+	}
+
+	if !e.IntegrationInRunningPhases() {
+		return false, nil
+	}
+
+	if t.Auto == nil || *t.Auto {
+		if t.Scheme == "" {
+			t.Scheme = defaultScheme
+		}
+		if t.Path == "" {
+			t.Path = defaultPath
+		}
+		if t.Port == 0 {
+			t.Port = defaultPort
+		}
+		if t.DescriptionPath == nil {
+			openAPI := defaultDescriptionPath
+			t.DescriptionPath = &openAPI
+		}
+	}
+	return true, nil
+}
+
+func (t *apiManagementTrait) Apply(e *trait.Environment) error {
+	svc := e.Resources.GetServiceForIntegration(e.Integration)
+	if svc == nil {
+		return nil
+	}
+
+	provider, err := t.getProvider()
+	if err != nil {
+		return err
+	}
+
+	api := API{
+		Scheme:       t.Scheme,
+		Path:         t.Path,
+		Port:         t.Port,
+		Host:         t.Host,
+		IngressClass: t.IngressClass,
+	}
+	if t.DescriptionPath != nil {
+		api.DescriptionPath = *t.DescriptionPath
+	}
+
+	return provider.Register(e, api, svc)
+}
+
+func (t *apiManagementTrait) getProvider() (Provider, error) {
+	id := strings.ToLower(t.Provider)
+	if id == "" {
+		id = threeScaleProviderID
+	}
+	if provider, ok := providers[id]; ok {
+		return provider, nil
+	}
+
+	ids := make([]string, 0, len(providers))
+	for id := range providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return nil, fmt.Errorf("unsupported API management provider: %s, must be one of %s", t.Provider, strings.Join(ids, ", "))
+}
+
+// newResourceLabels returns the labels of the resources created for the given integration
+func newResourceLabels(it *v1.Integration) map[string]string {
+	return map[string]string{
+		v1.IntegrationLabel: it.Name,
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apimanagement
+
+import (
+	"testing"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAPIManagementDisabled(t *testing.T) {
+	_, e := createEnvironment()
+
+	apiManagement := NewAPIManagementTrait()
+	enabled, err := apiManagement.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+}
+
+func TestAPIManagementUnsupportedProvider(t *testing.T) {
+	_, e := createEnvironment()
+
+	apiManagement := createAPIManagementTrait("tyk")
+	_, err := apiManagement.Configure(e)
+	assert.EqualError(t, err, "unsupported API management provider: tyk, must be one of 3scale, apisix, gloo, kong")
+}
+
+func TestAPIManagementThreeScale(t *testing.T) {
+	svc, e := createEnvironment()
+
+	apiManagement := createAPIManagementTrait("")
+	ok, err := apiManagement.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = apiManagement.Apply(e)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "true", svc.Labels["discovery.3scale.net"])
+	assert.Equal(t, "http", svc.Annotations["discovery.3scale.net/scheme"])
+	assert.Equal(t, "/", svc.Annotations["discovery.3scale.net/path"])
+	assert.Equal(t, "80", svc.Annotations["discovery.3scale.net/port"])
+	assert.Equal(t, "/openapi.json", svc.Annotations["discovery.3scale.net/description-path"])
+}
+
+func TestAPIManagementGloo(t *testing.T) {
+	_, e := createEnvironment()
+
+	apiManagement := createAPIManagementTrait("gloo")
+	apiManagement.Path = "/api"
+	apiManagement.Host = "api.example.com"
+	ok, err := apiManagement.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = apiManagement.Apply(e)
+	assert.Nil(t, err)
+
+	upstream := getResource(e, "Upstream")
+	assert.NotNil(t, upstream)
+	assert.Equal(t, "test", upstream.GetLabels()[v1.IntegrationLabel])
+	url, _, _ := unstructured.NestedString(upstream.Object, "spec", "kube", "serviceSpec", "rest", "swaggerInfo", "url")
+	assert.Equal(t, "http://test.ns.svc:80/openapi.json", url)
+	port, _, _ := unstructured.NestedInt64(upstream.Object, "spec", "kube", "servicePort")
+	assert.Equal(t, int64(80), port)
+
+	virtualService := getResource(e, "VirtualService")
+	assert.NotNil(t, virtualService)
+	domains, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "virtualHost", "domains")
+	assert.Equal(t, []string{"api.example.com"}, domains)
+	routes, _, _ := unstructured.NestedSlice(virtualService.Object, "spec", "virtualHost", "routes")
+	assert.Len(t, routes, 2)
+}
+
+func TestAPIManagementKong(t *testing.T) {
+	svc, e := createEnvironment()
+
+	apiManagement := createAPIManagementTrait("kong")
+	noPath := ""
+	apiManagement.DescriptionPath = &noPath
+	ok, err := apiManagement.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = apiManagement.Apply(e)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "http", svc.Annotations["konghq.com/protocol"])
+
+	var ingress *networking.Ingress
+	for _, r := range e.Resources.Items() {
+		if i, ok := r.(*networking.Ingress); ok {
+			ingress = i
+		}
+	}
+	assert.NotNil(t, ingress)
+	assert.Equal(t, "test-kong", ingress.Name)
+	assert.Equal(t, "kong", *ingress.Spec.IngressClassName)
+	assert.Equal(t, "false", ingress.Annotations["konghq.com/strip-path"])
+	paths := ingress.Spec.Rules[0].HTTP.Paths
+	assert.Len(t, paths, 1)
+	assert.Equal(t, "/", paths[0].Path)
+	assert.Equal(t, "test", paths[0].Backend.Service.Name)
+	assert.Equal(t, int32(80), paths[0].Backend.Service.Port.Number)
+}
+
+func TestAPIManagementAPISIX(t *testing.T) {
+	_, e := createEnvironment()
+
+	apiManagement := createAPIManagementTrait("apisix")
+	apiManagement.Path = "/api/"
+	apiManagement.IngressClass = "apisix"
+	ok, err := apiManagement.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = apiManagement.Apply(e)
+	assert.Nil(t, err)
+
+	route := getResource(e, "ApisixRoute")
+	assert.NotNil(t, route)
+	ingressClass, _, _ := unstructured.NestedString(route.Object, "spec", "ingressClassName")
+	assert.Equal(t, "apisix", ingressClass)
+	routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "http")
+	assert.Len(t, routes, 2)
+	paths, _, _ := unstructured.NestedStringSlice(routes[0].(map[string]interface{}), "match", "paths")
+	assert.Equal(t, []string{"/api/*"}, paths)
+	paths, _, _ = unstructured.NestedStringSlice(routes[1].(map[string]interface{}), "match", "paths")
+	assert.Equal(t, []string{"/openapi.json"}, paths)
+}
+
+func createAPIManagementTrait(provider string) *apiManagementTrait {
+	apiManagement := NewAPIManagementTrait().(*apiManagementTrait)
+	enabled := true
+	apiManagement.Enabled = &enabled
+	apiManagement.Provider = provider
+	return apiManagement
+}
+
+func getResource(e *trait.Environment, kind string) *unstructured.Unstructured {
+	for _, r := range e.Resources.Items() {
+		if u, ok := r.(*unstructured.Unstructured); ok && u.GetKind() == kind {
+			return u
+		}
+	}
+	return nil
+}
+
+func createEnvironment() (*corev1.Service, *trait.Environment) {
+	svc := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "ns",
+			Labels: map[string]string{
+				v1.IntegrationLabel: "test",
+			},
+		},
+	}
+
+	e := trait.Environment{
+		Resources: kubernetes.NewCollection(&svc),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+	}
+	return &svc, &e
+}
@@ -0,0 +1,281 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apimanagement
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/apache/camel-k/addons/threescale"
+	"github.com/apache/camel-k/pkg/trait"
+)
+
+const (
+	threeScaleProviderID = "3scale"
+	glooProviderID       = "gloo"
+	kongProviderID       = "kong"
+	apisixProviderID     = "apisix"
+
+	kongDefaultIngressClass      = "kong"
+	kongProtocolAnnotation       = "konghq.com/protocol"
+	kongStripPathAnnotation      = "konghq.com/strip-path"
+	kongIngressSuffix            = "-kong"
+	glooUpstreamAPIVersion       = "gloo.solo.io/v1"
+	glooVirtualServiceAPIVersion = "gateway.solo.io/v1"
+	apisixRouteAPIVersion        = "apisix.apache.org/v2beta3"
+)
+
+// threeScaleProvider labels and annotates the service, so that it's discovered by 3scale
+type threeScaleProvider struct{}
+
+func (p threeScaleProvider) ID() string {
+	return threeScaleProviderID
+}
+
+func (p threeScaleProvider) Register(_ *trait.Environment, api API, service *corev1.Service) error {
+	if service.Labels == nil {
+		service.Labels = make(map[string]string)
+	}
+	service.Labels[threescale.ThreeScaleDiscoveryLabel] = threescale.ThreeScaleDiscoveryLabelEnabled
+
+	if service.Annotations == nil {
+		service.Annotations = make(map[string]string)
+	}
+	if api.Scheme != "" {
+		service.Annotations[threescale.ThreeScaleSchemeAnnotation] = api.Scheme
+	}
+	if api.Path != "" {
+		service.Annotations[threescale.ThreeScalePathAnnotation] = api.Path
+	}
+	if api.Port != 0 {
+		service.Annotations[threescale.ThreeScalePortAnnotation] = strconv.Itoa(api.Port)
+	}
+	if api.DescriptionPath != "" {
+		service.Annotations[threescale.ThreeScaleDescriptionPathAnnotation] = api.DescriptionPath
+	}
+	return nil
+}
+
+// glooProvider creates a Gloo Upstream, whose functions are discovered from the Open-API specification,
+// and a VirtualService that routes the API requests to it
+type glooProvider struct{}
+
+func (p glooProvider) ID() string {
+	return glooProviderID
+}
+
+func (p glooProvider) Register(e *trait.Environment, api API, service *corev1.Service) error {
+	kube := map[string]interface{}{
+		"serviceName":      service.Name,
+		"serviceNamespace": e.Integration.Namespace,
+		"servicePort":      int64(api.Port),
+	}
+	if api.DescriptionPath != "" {
+		url := fmt.Sprintf("%s://%s.%s.svc:%d%s", api.Scheme, service.Name, e.Integration.Namespace, api.Port, api.DescriptionPath)
+		kube["serviceSpec"] = map[string]interface{}{
+			"rest": map[string]interface{}{
+				"swaggerInfo": map[string]interface{}{
+					"url": url,
+				},
+			},
+		}
+	}
+	upstream := newResource(e, glooUpstreamAPIVersion, "Upstream")
+	if err := unstructured.SetNestedMap(upstream.Object, kube, "spec", "kube"); err != nil {
+		return err
+	}
+
+	domain := api.Host
+	if domain == "" {
+		domain = "*"
+	}
+	destination := map[string]interface{}{
+		"single": map[string]interface{}{
+			"upstream": map[string]interface{}{
+				"name":      upstream.GetName(),
+				"namespace": upstream.GetNamespace(),
+			},
+		},
+	}
+	routes := []interface{}{
+		map[string]interface{}{
+			"matchers":    []interface{}{map[string]interface{}{"prefix": api.Path}},
+			"routeAction": destination,
+		},
+	}
+	if api.DescriptionPath != "" {
+		routes = append(routes, map[string]interface{}{
+			"matchers":    []interface{}{map[string]interface{}{"exact": api.DescriptionPath}},
+			"routeAction": destination,
+		})
+	}
+	virtualService := newResource(e, glooVirtualServiceAPIVersion, "VirtualService")
+	if err := unstructured.SetNestedMap(virtualService.Object, map[string]interface{}{
+		"domains": []interface{}{domain},
+		"routes":  routes,
+	}, "spec", "virtualHost"); err != nil {
+		return err
+	}
+
+	e.Resources.Add(upstream)
+	e.Resources.Add(virtualService)
+	return nil
+}
+
+// kongProvider creates an Ingress, bound to the Kong ingress class, and annotates the service with the Kong protocol.
+// The Ingress is suffixed, not to collide with the one created by the ingress trait.
+type kongProvider struct{}
+
+func (p kongProvider) ID() string {
+	return kongProviderID
+}
+
+func (p kongProvider) Register(e *trait.Environment, api API, service *corev1.Service) error {
+	if service.Annotations == nil {
+		service.Annotations = make(map[string]string)
+	}
+	service.Annotations[kongProtocolAnnotation] = api.Scheme
+
+	ingressClass := api.IngressClass
+	if ingressClass == "" {
+		ingressClass = kongDefaultIngressClass
+	}
+	backend := networking.IngressBackend{
+		Service: &networking.IngressServiceBackend{
+			Name: service.Name,
+			Port: networking.ServiceBackendPort{
+				Number: int32(api.Port),
+			},
+		},
+	}
+	prefix := networking.PathTypePrefix
+	exact := networking.PathTypeExact
+	paths := []networking.HTTPIngressPath{
+		{
+			Path:     api.Path,
+			PathType: &prefix,
+			Backend:  backend,
+		},
+	}
+	if api.DescriptionPath != "" {
+		paths = append(paths, networking.HTTPIngressPath{
+			Path:     api.DescriptionPath,
+			PathType: &exact,
+			Backend:  backend,
+		})
+	}
+
+	ingress := networking.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: networking.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name + kongIngressSuffix,
+			Namespace: e.Integration.Namespace,
+			Labels:    newResourceLabels(e.Integration),
+			Annotations: map[string]string{
+				// The requests are forwarded to the service with the API path
+				kongStripPathAnnotation: "false",
+			},
+		},
+		Spec: networking.IngressSpec{
+			IngressClassName: &ingressClass,
+			Rules: []networking.IngressRule{
+				{
+					Host: api.Host,
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: paths,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	e.Resources.Add(&ingress)
+	return nil
+}
+
+// apisixProvider creates an Apache APISIX ApisixRoute, that routes the API requests to the service
+type apisixProvider struct{}
+
+func (p apisixProvider) ID() string {
+	return apisixProviderID
+}
+
+func (p apisixProvider) Register(e *trait.Environment, api API, service *corev1.Service) error {
+	backends := []interface{}{
+		map[string]interface{}{
+			"serviceName": service.Name,
+			"servicePort": int64(api.Port),
+		},
+	}
+	newRoute := func(name string, path string) map[string]interface{} {
+		match := map[string]interface{}{
+			"paths": []interface{}{path},
+		}
+		if api.Host != "" {
+			match["hosts"] = []interface{}{api.Host}
+		}
+		return map[string]interface{}{
+			"name":     name,
+			"match":    match,
+			"backends": backends,
+		}
+	}
+
+	routes := []interface{}{
+		newRoute("api", strings.TrimSuffix(api.Path, "/")+"/*"),
+	}
+	if api.DescriptionPath != "" {
+		routes = append(routes, newRoute("api-description", api.DescriptionPath))
+	}
+	spec := map[string]interface{}{
+		"http": routes,
+	}
+	if api.IngressClass != "" {
+		spec["ingressClassName"] = api.IngressClass
+	}
+
+	route := newResource(e, apisixRouteAPIVersion, "ApisixRoute")
+	if err := unstructured.SetNestedMap(route.Object, spec, "spec"); err != nil {
+		return err
+	}
+
+	e.Resources.Add(route)
+	return nil
+}
+
+// newResource returns a gateway custom resource for the integration
+func newResource(e *trait.Environment, apiVersion string, kind string) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(apiVersion)
+	resource.SetKind(kind)
+	resource.SetName(e.Integration.Name)
+	resource.SetNamespace(e.Integration.Namespace)
+	resource.SetLabels(newResourceLabels(e.Integration))
+	return resource
+}
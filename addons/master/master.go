@@ -67,7 +67,8 @@ type masterTrait struct {
 // NewMasterTrait --
 func NewMasterTrait() trait.Trait {
 	return &masterTrait{
-		BaseTrait: trait.NewBaseTrait("master", trait.TraitOrderBeforeControllerCreation),
+		BaseTrait: trait.NewBaseTrait("master", trait.TraitOrderBeforeControllerCreation,
+			trait.ExecuteBefore("cron", "deployment", "knative-service")),
 	}
 }
 
@@ -167,7 +168,7 @@ func (t *masterTrait) Apply(e *trait.Environment) error {
 		}
 
 	} else if e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning) {
-		serviceAccount := e.Integration.Spec.ServiceAccountName
+		serviceAccount := e.GetIntegrationServiceAccountName()
 		if serviceAccount == "" {
 			serviceAccount = "default"
 		}
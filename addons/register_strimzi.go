@@ -19,9 +19,11 @@ package addons
 
 import (
 	"github.com/apache/camel-k/addons/strimzi"
+	"github.com/apache/camel-k/pkg/trait"
 	"github.com/apache/camel-k/pkg/util/bindings"
 )
 
 func init() {
 	bindings.RegisterBindingProvider(strimzi.StrimziBindingProvider{})
+	trait.AddToTraits(strimzi.NewKafkaTrait)
 }
@@ -25,10 +25,18 @@ const (
 	StrimziVersion          = "v1beta2"
 	StrimziKindTopic        = "KafkaTopic"
 	StrimziKindKafkaCluster = "Kafka"
+	StrimziKindUser         = "KafkaUser"
 
 	StrimziKafkaClusterLabel = "strimzi.io/cluster"
 
 	StrimziListenerTypePlain = "plain"
+	StrimziListenerTypeTLS   = "tls"
+
+	StrimziAuthenticationTypeTLS         = "tls"
+	StrimziAuthenticationTypeTLSExternal = "tls-external"
+	StrimziAuthenticationTypeScramSha512 = "scram-sha-512"
+
+	StrimziAuthorizationTypeSimple = "simple"
 )
 
 // +genclient
@@ -40,6 +48,15 @@ const (
 type KafkaTopic struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KafkaTopicSpec `json:"spec,omitempty"`
+}
+
+// KafkaTopicSpec contains the relevant info of the KafkaTopic spec
+type KafkaTopicSpec struct {
+	TopicName  string `json:"topicName,omitempty"`
+	Partitions int32  `json:"partitions,omitempty"`
+	Replicas   int32  `json:"replicas,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -71,8 +88,9 @@ type KafkaStatus struct {
 
 // KafkaStatusListener contains listener information
 type KafkaStatusListener struct {
-	BootstrapServers string `json:"bootstrapServers,omitempty"`
-	Type             string `json:"type,omitempty"`
+	BootstrapServers string   `json:"bootstrapServers,omitempty"`
+	Type             string   `json:"type,omitempty"`
+	Certificates     []string `json:"certificates,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -83,3 +101,59 @@ type KafkaList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Kafka `json:"items"`
 }
+
+// +kubebuilder:object:root=true
+
+// KafkaUser is the duck of a KafkaUser
+type KafkaUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaUserSpec   `json:"spec,omitempty"`
+	Status KafkaUserStatus `json:"status,omitempty"`
+}
+
+// KafkaUserSpec contains the relevant info of the KafkaUser spec
+type KafkaUserSpec struct {
+	Authentication *KafkaUserAuthentication `json:"authentication,omitempty"`
+	Authorization  *KafkaUserAuthorization  `json:"authorization,omitempty"`
+}
+
+// KafkaUserAuthentication contains the authentication mechanism of the KafkaUser
+type KafkaUserAuthentication struct {
+	Type string `json:"type,omitempty"`
+}
+
+// KafkaUserAuthorization contains the authorization rules of the KafkaUser
+type KafkaUserAuthorization struct {
+	Type string         `json:"type,omitempty"`
+	ACLs []KafkaUserACL `json:"acls,omitempty"`
+}
+
+// KafkaUserACL contains the operations granted to the KafkaUser on a resource
+type KafkaUserACL struct {
+	Resource   KafkaUserACLResource `json:"resource"`
+	Operations []string             `json:"operations,omitempty"`
+}
+
+// KafkaUserACLResource contains the resource an ACL rule applies to
+type KafkaUserACLResource struct {
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	PatternType string `json:"patternType,omitempty"`
+}
+
+// KafkaUserStatus contains the relevant info of the KafkaUser status
+type KafkaUserStatus struct {
+	Username string `json:"username,omitempty"`
+	Secret   string `json:"secret,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KafkaUserList contains a list of KafkaUser
+type KafkaUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaUser `json:"items"`
+}
@@ -72,7 +72,9 @@ func (in *KafkaStatus) DeepCopyInto(out *KafkaStatus) {
 	if in.Listeners != nil {
 		in, out := &in.Listeners, &out.Listeners
 		*out = make([]KafkaStatusListener, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -89,6 +91,11 @@ func (in *KafkaStatus) DeepCopy() *KafkaStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaStatusListener) DeepCopyInto(out *KafkaStatusListener) {
 	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaStatusListener.
@@ -106,6 +113,7 @@ func (in *KafkaTopic) DeepCopyInto(out *KafkaTopic) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopic.
@@ -157,3 +165,190 @@ func (in *KafkaTopicList) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicSpec) DeepCopyInto(out *KafkaTopicSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSpec.
+func (in *KafkaTopicSpec) DeepCopy() *KafkaTopicSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUser) DeepCopyInto(out *KafkaUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUser.
+func (in *KafkaUser) DeepCopy() *KafkaUser {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserACL) DeepCopyInto(out *KafkaUserACL) {
+	*out = *in
+	out.Resource = in.Resource
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserACL.
+func (in *KafkaUserACL) DeepCopy() *KafkaUserACL {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserACL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserACLResource) DeepCopyInto(out *KafkaUserACLResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserACLResource.
+func (in *KafkaUserACLResource) DeepCopy() *KafkaUserACLResource {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserACLResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserAuthentication) DeepCopyInto(out *KafkaUserAuthentication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserAuthentication.
+func (in *KafkaUserAuthentication) DeepCopy() *KafkaUserAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserAuthorization) DeepCopyInto(out *KafkaUserAuthorization) {
+	*out = *in
+	if in.ACLs != nil {
+		in, out := &in.ACLs, &out.ACLs
+		*out = make([]KafkaUserACL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserAuthorization.
+func (in *KafkaUserAuthorization) DeepCopy() *KafkaUserAuthorization {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserAuthorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserList) DeepCopyInto(out *KafkaUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserList.
+func (in *KafkaUserList) DeepCopy() *KafkaUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserSpec) DeepCopyInto(out *KafkaUserSpec) {
+	*out = *in
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(KafkaUserAuthentication)
+		**out = **in
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(KafkaUserAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserSpec.
+func (in *KafkaUserSpec) DeepCopy() *KafkaUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserStatus) DeepCopyInto(out *KafkaUserStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserStatus.
+func (in *KafkaUserStatus) DeepCopy() *KafkaUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,302 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strimzi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/addons/strimzi/duck/v1beta2"
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+)
+
+// The Kafka trait configures the integration to connect to an Apache Kafka cluster managed by Strimzi.
+//
+// It looks up the Strimzi `Kafka`, `KafkaUser` and `KafkaTopic` resources referenced by the trait configuration,
+// and configures the Camel Kafka component with the cluster bootstrap servers, the cluster CA certificate
+// when connecting to a TLS listener, and the user credentials, for both the `tls` and `scram-sha-512` authentication types.
+//
+// The integration pods are restarted when Strimzi rotates the cluster CA certificate or the user credentials.
+//
+// The trait can also provision the missing topics, and a user only granted access to the topics of the integration.
+// The provisioned resources are owned by the integration, and deleted along with it.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=kafka
+type kafkaTrait struct {
+	trait.BaseTrait `property:",squash"`
+	// The name of the Strimzi `Kafka` cluster. Defaults to the cluster the referenced topics belong to.
+	Cluster string `property:"cluster" json:"cluster,omitempty"`
+	// The name of the Strimzi `KafkaUser` the integration authenticates as.
+	User string `property:"user" json:"user,omitempty"`
+	// The names of the Strimzi `KafkaTopic` resources the integration uses. They must belong to the same cluster.
+	Topics []string `property:"topics" json:"topics,omitempty"`
+	// The type of the cluster listener to connect to (default `tls` when a user is set, `plain` otherwise).
+	Listener string `property:"listener" json:"listener,omitempty"`
+	// Create the missing `KafkaTopic` resources, for the topics set with the `topics` property and the ones
+	// the Kafka endpoints of the integration use. The cluster must be set when none of the topics exists.
+	AutoCreateTopics *bool `property:"auto-create-topics" json:"autoCreateTopics,omitempty"`
+	// The number of partitions of the created topics (default `1`).
+	TopicPartitions int32 `property:"topic-partitions" json:"topicPartitions,omitempty"`
+	// The number of replicas of the created topics (default `1`).
+	TopicReplicas int32 `property:"topic-replicas" json:"topicReplicas,omitempty"`
+	// Create a `KafkaUser` named after the integration, when no user is set. It authenticates with `scram-sha-512`,
+	// and is only granted access to the topics of the integration.
+	AutoCreateUser *bool `property:"auto-create-user" json:"autoCreateUser,omitempty"`
+}
+
+const (
+	kafkaMountPath = "/etc/camel/conf.d/_kafka"
+
+	kafkaDigestAnnotation = "camel.apache.org/kafka.digest"
+
+	kafkaKeystorePasswordEnvVar = "CAMEL_K_KAFKA_KEYSTORE_PASSWORD"
+	kafkaSaslJaasConfigEnvVar   = "CAMEL_K_KAFKA_SASL_JAAS_CONFIG"
+)
+
+// NewKafkaTrait --
+func NewKafkaTrait() trait.Trait {
+	return &kafkaTrait{
+		BaseTrait: trait.NewBaseTrait("kafka", trait.TraitOrderBeforeControllerCreation),
+	}
+}
+
+func (t *kafkaTrait) Configure(e *trait.Environment) (bool, error) {
+	if trait.IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.Cluster == "" && len(t.Topics) == 0 && trait.IsNilOrFalse(t.AutoCreateTopics) {
+		return false, errors.New("no Kafka cluster nor topic was provided")
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *kafkaTrait) Apply(e *trait.Environment) error {
+	topics, err := t.resolveTopics(e)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := t.resolveCluster(e, topics)
+	if err != nil {
+		return err
+	}
+
+	if trait.IsTrue(t.AutoCreateTopics) {
+		if err := t.createMissingTopics(e, cluster.Name, topics); err != nil {
+			return err
+		}
+	}
+
+	userName := t.User
+	if userName == "" && trait.IsTrue(t.AutoCreateUser) {
+		if userName, err = t.provisionUser(e, cluster.Name, topics); err != nil {
+			return err
+		}
+	}
+
+	listenerType := t.Listener
+	if listenerType == "" {
+		if userName != "" {
+			listenerType = v1beta2.StrimziListenerTypeTLS
+		} else {
+			listenerType = v1beta2.StrimziListenerTypePlain
+		}
+	}
+	var listener *v1beta2.KafkaStatusListener
+	for i, l := range cluster.Status.Listeners {
+		if l.Type == listenerType {
+			listener = &cluster.Status.Listeners[i]
+			break
+		}
+	}
+	if listener == nil {
+		return fmt.Errorf("cluster %q has no listeners of type %q", cluster.Name, listenerType)
+	}
+	if listener.BootstrapServers == "" {
+		return fmt.Errorf("cluster %q has no bootstrap servers in %q listener", cluster.Name, listenerType)
+	}
+
+	if e.ApplicationProperties == nil {
+		e.ApplicationProperties = make(map[string]string)
+	}
+	e.ApplicationProperties["camel.component.kafka.brokers"] = listener.BootstrapServers
+
+	// The secrets the configuration depends on, whose changes trigger a rollout
+	var secrets []string
+
+	tls := len(listener.Certificates) > 0
+	if tls {
+		caSecret := cluster.Name + "-cluster-ca-cert"
+		t.mountSecret(e, caSecret)
+		secrets = append(secrets, caSecret)
+
+		e.ApplicationProperties["camel.component.kafka.ssl-truststore-location"] = path.Join(kafkaMountPath, caSecret, "ca.crt")
+		e.ApplicationProperties["camel.component.kafka.ssl-truststore-type"] = "PEM"
+	}
+
+	securityProtocol := "PLAINTEXT"
+	if tls {
+		securityProtocol = "SSL"
+	}
+
+	if userName != "" {
+		user := v1beta2.KafkaUser{}
+		if err := t.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: userName}, &user); err != nil {
+			return errors.Wrapf(err, "unable to find Kafka user %q", userName)
+		}
+		userSecret := user.Status.Secret
+		if userSecret == "" {
+			userSecret = user.Name
+		}
+
+		authentication := ""
+		if user.Spec.Authentication != nil {
+			authentication = user.Spec.Authentication.Type
+		}
+
+		switch authentication {
+		case v1beta2.StrimziAuthenticationTypeTLS:
+			if !tls {
+				return fmt.Errorf("user %q requires a TLS listener, but listener %q is not", user.Name, listenerType)
+			}
+			t.mountSecret(e, userSecret)
+			e.ApplicationProperties["camel.component.kafka.ssl-keystore-location"] = path.Join(kafkaMountPath, userSecret, "user.p12")
+			e.ApplicationProperties["camel.component.kafka.ssl-keystore-type"] = "PKCS12"
+			e.ApplicationProperties["camel.component.kafka.ssl-keystore-password"] = "${" + kafkaKeystorePasswordEnvVar + "}"
+			e.EnvVars = append(e.EnvVars, secretKeyEnvVar(kafkaKeystorePasswordEnvVar, userSecret, "user.password"))
+		case v1beta2.StrimziAuthenticationTypeScramSha512:
+			securityProtocol = "SASL_" + securityProtocol
+			e.ApplicationProperties["camel.component.kafka.sasl-mechanism"] = "SCRAM-SHA-512"
+			e.ApplicationProperties["camel.component.kafka.sasl-jaas-config"] = "${" + kafkaSaslJaasConfigEnvVar + "}"
+			e.EnvVars = append(e.EnvVars, secretKeyEnvVar(kafkaSaslJaasConfigEnvVar, userSecret, "sasl.jaas.config"))
+		default:
+			return fmt.Errorf("unsupported authentication type %q for user %q", authentication, user.Name)
+		}
+		secrets = append(secrets, userSecret)
+	}
+
+	e.ApplicationProperties["camel.component.kafka.security-protocol"] = securityProtocol
+
+	if len(secrets) > 0 {
+		digest, err := t.computeSecretsDigest(e, secrets)
+		if err != nil {
+			return err
+		}
+		// Roll out the integration pods when the certificates or credentials are rotated
+		e.PostProcessors = append(e.PostProcessors, func(env *trait.Environment) error {
+			env.Resources.VisitPodTemplateMeta(func(meta *metav1.ObjectMeta) {
+				if meta.Annotations == nil {
+					meta.Annotations = make(map[string]string)
+				}
+				meta.Annotations[kafkaDigestAnnotation] = digest
+			})
+			return nil
+		})
+	}
+
+	return nil
+}
+
+func (t *kafkaTrait) resolveCluster(e *trait.Environment, topics []kafkaTopic) (*v1beta2.Kafka, error) {
+	clusterName := t.Cluster
+	for _, topic := range topics {
+		if topic.existing == nil {
+			continue
+		}
+		topicCluster := topic.existing.Labels[v1beta2.StrimziKafkaClusterLabel]
+		if topicCluster == "" {
+			return nil, fmt.Errorf("no %q label defined on topic %s", v1beta2.StrimziKafkaClusterLabel, topic.resource)
+		}
+		if clusterName == "" {
+			clusterName = topicCluster
+		} else if clusterName != topicCluster {
+			return nil, fmt.Errorf("topic %s belongs to cluster %q instead of %q", topic.resource, topicCluster, clusterName)
+		}
+	}
+	if clusterName == "" {
+		return nil, errors.New("no Kafka cluster was provided, nor could it be determined from the existing topics")
+	}
+
+	cluster := v1beta2.Kafka{}
+	if err := t.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: clusterName}, &cluster); err != nil {
+		return nil, errors.Wrapf(err, "unable to find Kafka cluster %q", clusterName)
+	}
+
+	return &cluster, nil
+}
+
+func (t *kafkaTrait) mountSecret(e *trait.Environment, name string) {
+	e.Integration.Status.AddConfigurationsIfMissing(v1.ConfigurationSpec{
+		Type:               "secret",
+		Value:              name,
+		ResourceMountPoint: path.Join(kafkaMountPath, name),
+	})
+}
+
+func (t *kafkaTrait) computeSecretsDigest(e *trait.Environment, names []string) (string, error) {
+	hash := sha256.New()
+	for _, name := range names {
+		secret := corev1.Secret{}
+		if err := t.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: name}, &secret); err != nil {
+			return "", errors.Wrapf(err, "unable to find secret %q", name)
+		}
+		keys := make([]string, 0, len(secret.Data))
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, err := hash.Write([]byte(name + "/" + k)); err != nil {
+				return "", err
+			}
+			if _, err := hash.Write(secret.Data[k]); err != nil {
+				return "", err
+			}
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+func secretKeyEnvVar(name string, secret string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secret,
+				},
+				Key: key,
+			},
+		},
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strimzi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/addons/strimzi/duck/v1beta2"
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// kafkaTopic is a topic the integration uses
+type kafkaTopic struct {
+	// The name of the KafkaTopic resource
+	resource string
+	// The name of the topic in the Kafka cluster
+	name string
+	// The KafkaTopic resource, if it exists
+	existing *v1beta2.KafkaTopic
+}
+
+var topicNameReplacer = strings.NewReplacer(".", "-", "_", "-")
+
+// resolveTopics returns the topics set in the trait configuration, along with the ones the Kafka endpoints
+// of the integration use when the missing topics are created
+func (t *kafkaTrait) resolveTopics(e *trait.Environment) ([]kafkaTopic, error) {
+	autoCreate := trait.IsTrue(t.AutoCreateTopics)
+
+	topics := make([]kafkaTopic, 0, len(t.Topics))
+	for _, name := range t.Topics {
+		topics = append(topics, kafkaTopic{resource: name, name: name})
+	}
+	if autoCreate {
+		names, err := t.getEndpointTopics(e)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			topics = append(topics, kafkaTopic{resource: kubernetes.SanitizeLabel(topicNameReplacer.Replace(name)), name: name})
+		}
+	}
+
+	resolved := make([]kafkaTopic, 0, len(topics))
+	visited := make(map[string]bool)
+	for _, topic := range topics {
+		if visited[topic.resource] {
+			continue
+		}
+		visited[topic.resource] = true
+
+		existing := v1beta2.KafkaTopic{}
+		err := t.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: topic.resource}, &existing)
+		switch {
+		case err == nil:
+			topic.existing = &existing
+			if existing.Spec.TopicName != "" {
+				topic.name = existing.Spec.TopicName
+			}
+		case k8serrors.IsNotFound(err) && autoCreate:
+			// The topic is created afterwards
+		default:
+			return nil, errors.Wrapf(err, "unable to find Kafka topic %q", topic.resource)
+		}
+		resolved = append(resolved, topic)
+	}
+
+	return resolved, nil
+}
+
+// getEndpointTopics returns the topics of the Kafka endpoints of the integration
+func (t *kafkaTrait) getEndpointTopics(e *trait.Environment) ([]string, error) {
+	if e.CamelCatalog == nil {
+		return nil, nil
+	}
+	sources, err := kubernetes.ResolveIntegrationSources(e.Ctx, t.Client, e.Integration, e.Resources)
+	if err != nil {
+		return nil, err
+	}
+	var topics []string
+	metadata.Each(e.CamelCatalog, sources, func(_ int, meta metadata.IntegrationMetadata) bool {
+		for _, endpoint := range meta.FromURIs {
+			topics = append(topics, getKafkaTopics(endpoint)...)
+		}
+		for _, endpoint := range meta.ToURIs {
+			topics = append(topics, getKafkaTopics(endpoint)...)
+		}
+		return true
+	})
+	return topics, nil
+}
+
+// getKafkaTopics returns the topics of the endpoint, if it's a Kafka endpoint
+func getKafkaTopics(endpoint string) []string {
+	if !strings.HasPrefix(endpoint, "kafka:") {
+		return nil
+	}
+	path := strings.TrimPrefix(endpoint, "kafka:")
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	var topics []string
+	for _, topic := range strings.Split(path, ",") {
+		topic = strings.TrimSpace(topic)
+		// The topics resolved at runtime, e.g. from property placeholders, cannot be provisioned
+		if topic == "" || strings.ContainsAny(topic, "{}$") {
+			continue
+		}
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+func (t *kafkaTrait) createMissingTopics(e *trait.Environment, cluster string, topics []kafkaTopic) error {
+	partitions := t.TopicPartitions
+	if partitions <= 0 {
+		partitions = 1
+	}
+	replicas := t.TopicReplicas
+	if replicas <= 0 {
+		replicas = 1
+	}
+
+	for _, topic := range topics {
+		if topic.existing != nil {
+			continue
+		}
+		kt := v1beta2.KafkaTopic{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1beta2.SchemeGroupVersion.String(),
+				Kind:       v1beta2.StrimziKindTopic,
+			},
+			ObjectMeta: t.provisionedObjectMeta(e, topic.resource, cluster),
+			Spec: v1beta2.KafkaTopicSpec{
+				Partitions: partitions,
+				Replicas:   replicas,
+			},
+		}
+		if topic.name != topic.resource {
+			kt.Spec.TopicName = topic.name
+		}
+		if err := t.Client.Create(e.Ctx, &kt); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "unable to create Kafka topic %q", topic.resource)
+		}
+	}
+
+	return nil
+}
+
+// provisionUser creates the KafkaUser of the integration, or updates its ACLs with the topics of the integration.
+// An existing user that's not owned by the integration is left untouched.
+func (t *kafkaTrait) provisionUser(e *trait.Environment, cluster string, topics []kafkaTopic) (string, error) {
+	name := e.Integration.Name
+
+	authorization := &v1beta2.KafkaUserAuthorization{
+		Type: v1beta2.StrimziAuthorizationTypeSimple,
+		ACLs: []v1beta2.KafkaUserACL{
+			{
+				// The consumer group names are set by the Camel Kafka component
+				Resource: v1beta2.KafkaUserACLResource{
+					Type:        "group",
+					Name:        "*",
+					PatternType: "literal",
+				},
+				Operations: []string{"Read"},
+			},
+		},
+	}
+	for _, topic := range topics {
+		authorization.ACLs = append(authorization.ACLs, v1beta2.KafkaUserACL{
+			Resource: v1beta2.KafkaUserACLResource{
+				Type:        "topic",
+				Name:        topic.name,
+				PatternType: "literal",
+			},
+			Operations: []string{"Describe", "Read", "Write"},
+		})
+	}
+
+	user := v1beta2.KafkaUser{}
+	err := t.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: name}, &user)
+	if k8serrors.IsNotFound(err) {
+		user = v1beta2.KafkaUser{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1beta2.SchemeGroupVersion.String(),
+				Kind:       v1beta2.StrimziKindUser,
+			},
+			ObjectMeta: t.provisionedObjectMeta(e, name, cluster),
+			Spec: v1beta2.KafkaUserSpec{
+				Authentication: &v1beta2.KafkaUserAuthentication{
+					Type: v1beta2.StrimziAuthenticationTypeScramSha512,
+				},
+				Authorization: authorization,
+			},
+		}
+		if err := t.Client.Create(e.Ctx, &user); err != nil {
+			return "", errors.Wrapf(err, "unable to create Kafka user %q", name)
+		}
+		return name, nil
+	} else if err != nil {
+		return "", errors.Wrapf(err, "unable to find Kafka user %q", name)
+	}
+
+	if metav1.IsControlledBy(&user, e.Integration) && !reflect.DeepEqual(user.Spec.Authorization, authorization) {
+		user.Spec.Authorization = authorization
+		if err := t.Client.Update(e.Ctx, &user); err != nil {
+			return "", errors.Wrapf(err, "unable to update Kafka user %q", name)
+		}
+	}
+
+	return name, nil
+}
+
+// provisionedObjectMeta returns the metadata of a resource provisioned for the integration, that's deleted along with it.
+// The resource is not labelled with the integration, so that it's not pruned by the gc trait, as it's not part of the
+// deployed resources.
+func (t *kafkaTrait) provisionedObjectMeta(e *trait.Environment, name string, cluster string) metav1.ObjectMeta {
+	controller := true
+	blockOwnerDeletion := true
+	return metav1.ObjectMeta{
+		Namespace: e.Integration.Namespace,
+		Name:      name,
+		Labels: map[string]string{
+			v1beta2.StrimziKafkaClusterLabel: cluster,
+		},
+		OwnerReferences: []metav1.OwnerReference{
+			{
+				APIVersion:         v1.SchemeGroupVersion.String(),
+				Kind:               v1.IntegrationKind,
+				Name:               e.Integration.Name,
+				UID:                e.Integration.UID,
+				Controller:         &controller,
+				BlockOwnerDeletion: &blockOwnerDeletion,
+			},
+		},
+	}
+}
@@ -0,0 +1,373 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strimzi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/addons/strimzi/duck/v1beta2"
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestKafkaTraitDisabledByDefault(t *testing.T) {
+	e := createKafkaTestEnvironment()
+	kafka := NewKafkaTrait()
+
+	configured, err := kafka.Configure(e)
+	assert.NoError(t, err)
+	assert.False(t, configured)
+}
+
+func TestKafkaTraitPlainListener(t *testing.T) {
+	e := createKafkaTestEnvironment()
+	kafka := createKafkaTrait(t, createKafkaTestObjects()...)
+	kafka.Topics = []string{"my-topic"}
+
+	configured, err := kafka.Configure(e)
+	assert.NoError(t, err)
+	assert.True(t, configured)
+
+	err = kafka.Apply(e)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9092", e.ApplicationProperties["camel.component.kafka.brokers"])
+	assert.Equal(t, "PLAINTEXT", e.ApplicationProperties["camel.component.kafka.security-protocol"])
+	assert.Empty(t, e.Integration.Status.Configuration)
+	assert.Empty(t, e.EnvVars)
+	assert.Empty(t, e.PostProcessors)
+}
+
+func TestKafkaTraitTLSListenerWithScramUser(t *testing.T) {
+	e := createKafkaTestEnvironment()
+	kafka := createKafkaTrait(t, createKafkaTestObjects()...)
+	kafka.Cluster = "my-cluster"
+	kafka.User = "my-scram-user"
+
+	err := kafka.Apply(e)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9093", e.ApplicationProperties["camel.component.kafka.brokers"])
+	assert.Equal(t, "SASL_SSL", e.ApplicationProperties["camel.component.kafka.security-protocol"])
+	assert.Equal(t, "/etc/camel/conf.d/_kafka/my-cluster-cluster-ca-cert/ca.crt", e.ApplicationProperties["camel.component.kafka.ssl-truststore-location"])
+	assert.Equal(t, "PEM", e.ApplicationProperties["camel.component.kafka.ssl-truststore-type"])
+	assert.Equal(t, "SCRAM-SHA-512", e.ApplicationProperties["camel.component.kafka.sasl-mechanism"])
+	assert.Equal(t, "${CAMEL_K_KAFKA_SASL_JAAS_CONFIG}", e.ApplicationProperties["camel.component.kafka.sasl-jaas-config"])
+
+	assert.Equal(t, []v1.ConfigurationSpec{
+		{
+			Type:               "secret",
+			Value:              "my-cluster-cluster-ca-cert",
+			ResourceMountPoint: "/etc/camel/conf.d/_kafka/my-cluster-cluster-ca-cert",
+		},
+	}, e.Integration.Status.Configuration)
+
+	assert.Len(t, e.EnvVars, 1)
+	assert.Equal(t, "CAMEL_K_KAFKA_SASL_JAAS_CONFIG", e.EnvVars[0].Name)
+	assert.Equal(t, "my-scram-user", e.EnvVars[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "sasl.jaas.config", e.EnvVars[0].ValueFrom.SecretKeyRef.Key)
+
+	deployment := &appsv1.Deployment{}
+	e.Resources.Add(deployment)
+	for _, processor := range e.PostProcessors {
+		assert.NoError(t, processor(e))
+	}
+	assert.NotEmpty(t, deployment.Spec.Template.Annotations[kafkaDigestAnnotation])
+}
+
+func TestKafkaTraitTLSUser(t *testing.T) {
+	e := createKafkaTestEnvironment()
+	kafka := createKafkaTrait(t, createKafkaTestObjects()...)
+	kafka.Cluster = "my-cluster"
+	kafka.User = "my-tls-user"
+
+	err := kafka.Apply(e)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SSL", e.ApplicationProperties["camel.component.kafka.security-protocol"])
+	assert.Equal(t, "/etc/camel/conf.d/_kafka/my-tls-user/user.p12", e.ApplicationProperties["camel.component.kafka.ssl-keystore-location"])
+	assert.Equal(t, "PKCS12", e.ApplicationProperties["camel.component.kafka.ssl-keystore-type"])
+	assert.Equal(t, "${CAMEL_K_KAFKA_KEYSTORE_PASSWORD}", e.ApplicationProperties["camel.component.kafka.ssl-keystore-password"])
+	assert.Len(t, e.Integration.Status.Configuration, 2)
+	assert.Len(t, e.EnvVars, 1)
+	assert.Equal(t, "user.password", e.EnvVars[0].ValueFrom.SecretKeyRef.Key)
+}
+
+func TestKafkaTraitCredentialsRotation(t *testing.T) {
+	objects := createKafkaTestObjects()
+
+	e := createKafkaTestEnvironment()
+	kafka := createKafkaTrait(t, objects...)
+	kafka.Cluster = "my-cluster"
+	kafka.User = "my-tls-user"
+	assert.NoError(t, kafka.Apply(e))
+	digest := applyPostProcessors(t, e)
+
+	e = createKafkaTestEnvironment()
+	kafka = createKafkaTrait(t, objects...)
+	kafka.Cluster = "my-cluster"
+	kafka.User = "my-tls-user"
+	assert.NoError(t, kafka.Apply(e))
+	assert.Equal(t, digest, applyPostProcessors(t, e))
+
+	for _, o := range objects {
+		if s, ok := o.(*corev1.Secret); ok && s.Name == "my-tls-user" {
+			s.Data["user.p12"] = []byte("rotated")
+		}
+	}
+
+	e = createKafkaTestEnvironment()
+	kafka = createKafkaTrait(t, objects...)
+	kafka.Cluster = "my-cluster"
+	kafka.User = "my-tls-user"
+	assert.NoError(t, kafka.Apply(e))
+	assert.NotEqual(t, digest, applyPostProcessors(t, e))
+}
+
+func TestKafkaTraitInvalidConfiguration(t *testing.T) {
+	e := createKafkaTestEnvironment()
+	kafka := createKafkaTrait(t, createKafkaTestObjects()...)
+	_, err := kafka.Configure(e)
+	assert.Error(t, err)
+
+	kafka = createKafkaTrait(t, createKafkaTestObjects()...)
+	kafka.Cluster = "another-cluster"
+	kafka.Topics = []string{"my-topic"}
+	assert.Error(t, kafka.Apply(e))
+
+	kafka = createKafkaTrait(t, createKafkaTestObjects()...)
+	kafka.Cluster = "my-cluster"
+	kafka.User = "my-tls-user"
+	kafka.Listener = "plain"
+	assert.Error(t, kafka.Apply(e))
+}
+
+func TestKafkaTraitAutoCreateTopics(t *testing.T) {
+	e := createKafkaTestEnvironment()
+	kafka := createKafkaTrait(t, createKafkaTestObjects()...)
+	kafka.Topics = []string{"my-topic", "new-topic"}
+	kafka.AutoCreateTopics = trait.BoolP(true)
+	kafka.TopicPartitions = 3
+
+	err := kafka.Apply(e)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster-kafka-bootstrap:9092", e.ApplicationProperties["camel.component.kafka.brokers"])
+
+	topic := v1beta2.KafkaTopic{}
+	assert.NoError(t, kafka.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: "test", Name: "new-topic"}, &topic))
+	assert.Equal(t, "my-cluster", topic.Labels[v1beta2.StrimziKafkaClusterLabel])
+	assert.Equal(t, int32(3), topic.Spec.Partitions)
+	assert.Equal(t, int32(1), topic.Spec.Replicas)
+	assert.Len(t, topic.OwnerReferences, 1)
+	assert.Equal(t, "my-integration", topic.OwnerReferences[0].Name)
+
+	existing := v1beta2.KafkaTopic{}
+	assert.NoError(t, kafka.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: "test", Name: "my-topic"}, &existing))
+	assert.Empty(t, existing.OwnerReferences)
+
+	kafka = createKafkaTrait(t, createKafkaTestObjects()...)
+	kafka.Topics = []string{"new-topic"}
+	kafka.AutoCreateTopics = trait.BoolP(true)
+	assert.Error(t, kafka.Apply(e))
+}
+
+func TestKafkaTraitAutoCreateUser(t *testing.T) {
+	e := createKafkaTestEnvironment()
+	kafka := createKafkaTrait(t, createKafkaTestObjects()...)
+	kafka.Topics = []string{"my-topic"}
+	kafka.AutoCreateUser = trait.BoolP(true)
+
+	// The user credentials are not available until Strimzi reconciles the user
+	assert.Error(t, kafka.Apply(e))
+
+	user := v1beta2.KafkaUser{}
+	assert.NoError(t, kafka.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: "test", Name: "my-integration"}, &user))
+	assert.Equal(t, v1beta2.StrimziAuthenticationTypeScramSha512, user.Spec.Authentication.Type)
+	assert.Equal(t, v1beta2.StrimziAuthorizationTypeSimple, user.Spec.Authorization.Type)
+	assert.Len(t, user.Spec.Authorization.ACLs, 2)
+	assert.Equal(t, "my-topic", user.Spec.Authorization.ACLs[1].Resource.Name)
+	assert.Len(t, user.OwnerReferences, 1)
+
+	assert.NoError(t, kafka.Client.Create(e.Ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "my-integration",
+		},
+		Data: map[string][]byte{
+			"sasl.jaas.config": []byte("config"),
+		},
+	}))
+
+	kafka.Topics = []string{"my-topic", "another-topic"}
+	kafka.AutoCreateTopics = trait.BoolP(true)
+	assert.NoError(t, kafka.Apply(e))
+	assert.Equal(t, "SASL_SSL", e.ApplicationProperties["camel.component.kafka.security-protocol"])
+	assert.Equal(t, "my-integration", e.EnvVars[0].ValueFrom.SecretKeyRef.Name)
+
+	assert.NoError(t, kafka.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: "test", Name: "my-integration"}, &user))
+	assert.Len(t, user.Spec.Authorization.ACLs, 3)
+	assert.Equal(t, "another-topic", user.Spec.Authorization.ACLs[2].Resource.Name)
+}
+
+func TestGetKafkaTopics(t *testing.T) {
+	assert.Equal(t, []string{"orders"}, getKafkaTopics("kafka:orders?brokers=localhost:9092"))
+	assert.Equal(t, []string{"orders", "orders.v2"}, getKafkaTopics("kafka:orders, orders.v2"))
+	assert.Empty(t, getKafkaTopics("kafka:{{topic}}"))
+	assert.Empty(t, getKafkaTopics("timer:tick"))
+}
+
+func applyPostProcessors(t *testing.T, e *trait.Environment) string {
+	t.Helper()
+
+	deployment := &appsv1.Deployment{}
+	e.Resources.Add(deployment)
+	for _, processor := range e.PostProcessors {
+		assert.NoError(t, processor(e))
+	}
+	return deployment.Spec.Template.Annotations[kafkaDigestAnnotation]
+}
+
+func createKafkaTrait(t *testing.T, objects ...runtime.Object) *kafkaTrait {
+	t.Helper()
+
+	client, err := test.NewFakeClient(objects...)
+	assert.NoError(t, err)
+
+	kafka := NewKafkaTrait().(*kafkaTrait)
+	kafka.Enabled = trait.BoolP(true)
+	kafka.Client = client
+
+	return kafka
+}
+
+func createKafkaTestEnvironment() *trait.Environment {
+	return &trait.Environment{
+		Ctx: context.Background(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "my-integration",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		ApplicationProperties: make(map[string]string),
+		Resources:             kubernetes.NewCollection(),
+	}
+}
+
+func createKafkaTestObjects() []runtime.Object {
+	return []runtime.Object{
+		&v1beta2.Kafka{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "my-cluster",
+			},
+			Status: v1beta2.KafkaStatus{
+				Listeners: []v1beta2.KafkaStatusListener{
+					{
+						BootstrapServers: "my-cluster-kafka-bootstrap:9092",
+						Type:             "plain",
+					},
+					{
+						BootstrapServers: "my-cluster-kafka-bootstrap:9093",
+						Type:             "tls",
+						Certificates:     []string{"-----BEGIN CERTIFICATE-----"},
+					},
+				},
+			},
+		},
+		&v1beta2.KafkaTopic{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "my-topic",
+				Labels: map[string]string{
+					v1beta2.StrimziKafkaClusterLabel: "my-cluster",
+				},
+			},
+		},
+		&v1beta2.KafkaUser{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "my-scram-user",
+			},
+			Spec: v1beta2.KafkaUserSpec{
+				Authentication: &v1beta2.KafkaUserAuthentication{
+					Type: v1beta2.StrimziAuthenticationTypeScramSha512,
+				},
+			},
+		},
+		&v1beta2.KafkaUser{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "my-tls-user",
+			},
+			Spec: v1beta2.KafkaUserSpec{
+				Authentication: &v1beta2.KafkaUserAuthentication{
+					Type: v1beta2.StrimziAuthenticationTypeTLS,
+				},
+			},
+			Status: v1beta2.KafkaUserStatus{
+				Username: "CN=my-tls-user",
+				Secret:   "my-tls-user",
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "my-cluster-cluster-ca-cert",
+			},
+			Data: map[string][]byte{
+				"ca.crt": []byte("ca"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "my-scram-user",
+			},
+			Data: map[string][]byte{
+				"password":         []byte("password"),
+				"sasl.jaas.config": []byte("config"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "my-tls-user",
+			},
+			Data: map[string][]byte{
+				"user.p12":      []byte("keystore"),
+				"user.password": []byte("password"),
+			},
+		},
+	}
+}
@@ -19,10 +19,12 @@ limitations under the License.
 package strimzi
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/apache/camel-k/addons/strimzi/duck/client/internalclientset"
 	"github.com/apache/camel-k/addons/strimzi/duck/v1beta2"
+	camelv1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/util/bindings"
 	"github.com/apache/camel-k/pkg/util/uri"
@@ -30,7 +32,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// StrimziBindingProvider allows to connect to a Kafka topic via KameletBinding
+const (
+	// topicProperty is the name of the topic a Kafka cluster reference is bound to
+	topicProperty = "topic"
+	// userProperty is the name of the Strimzi KafkaUser the binding authenticates as
+	userProperty = "user"
+	// listenerProperty is the type of the cluster listener the binding connects to
+	listenerProperty = "listener"
+)
+
+// StrimziBindingProvider allows to connect to a Kafka topic via KameletBinding, by referencing either a Strimzi
+// KafkaTopic, or a Strimzi Kafka cluster along with the `topic` property.
+//
+// The endpoint URI is configured with the bootstrap servers of the plain listener of the cluster. When the `user`
+// property is set, or a TLS listener is selected with the `listener` property, the connection is delegated to the
+// kafka trait, that configures the cluster CA certificate and the user credentials from the Strimzi resources.
 type StrimziBindingProvider struct {
 	Client internalclientset.Interface
 }
@@ -49,8 +65,9 @@ func (s StrimziBindingProvider) Translate(ctx bindings.BindingContext, _ binding
 		return nil, err
 	}
 
-	if gv.Group != v1beta2.StrimziGroup || endpoint.Ref.Kind != v1beta2.StrimziKindTopic {
-		// Only operates on Strimzi Topics
+	if gv.Group != v1beta2.StrimziGroup ||
+		(endpoint.Ref.Kind != v1beta2.StrimziKindTopic && endpoint.Ref.Kind != v1beta2.StrimziKindKafkaCluster) {
+		// Only operates on Strimzi Topics and Clusters
 		return nil, nil
 	}
 
@@ -61,6 +78,19 @@ func (s StrimziBindingProvider) Translate(ctx bindings.BindingContext, _ binding
 	if props == nil {
 		props = make(map[string]string)
 	}
+	userName := props[userProperty]
+	listenerType := props[listenerProperty]
+	delete(props, userProperty)
+	delete(props, listenerProperty)
+
+	topicName := endpoint.Ref.Name
+	if endpoint.Ref.Kind == v1beta2.StrimziKindKafkaCluster {
+		topicName = props[topicProperty]
+		if topicName == "" {
+			return nil, fmt.Errorf("no %q property defined for Kafka cluster %s", topicProperty, endpoint.Ref.Name)
+		}
+		delete(props, topicProperty)
+	}
 
 	if props["brokers"] == "" {
 		// build the client if needed
@@ -72,26 +102,67 @@ func (s StrimziBindingProvider) Translate(ctx bindings.BindingContext, _ binding
 			s.Client = kafkaClient
 		}
 
-		// look them up
-		topic, err := s.Client.KafkaV1beta2().KafkaTopics(ctx.Namespace).Get(ctx.Ctx, endpoint.Ref.Name, v1.GetOptions{})
-		if err != nil {
-			return nil, err
+		clusterName := endpoint.Ref.Name
+		if endpoint.Ref.Kind == v1beta2.StrimziKindTopic {
+			// look them up
+			topic, err := s.Client.KafkaV1beta2().KafkaTopics(ctx.Namespace).Get(ctx.Ctx, endpoint.Ref.Name, v1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			if topic.Spec.TopicName != "" {
+				topicName = topic.Spec.TopicName
+			}
+
+			clusterName = topic.Labels[v1beta2.StrimziKafkaClusterLabel]
+			if clusterName == "" {
+				return nil, fmt.Errorf("no %q label defined on topic %s", v1beta2.StrimziKafkaClusterLabel, endpoint.Ref.Name)
+			}
 		}
 
-		clusterName := topic.Labels[v1beta2.StrimziKafkaClusterLabel]
-		if clusterName == "" {
-			return nil, fmt.Errorf("no %q label defined on topic %s", v1beta2.StrimziKafkaClusterLabel, endpoint.Ref.Name)
+		if listenerType == "" {
+			if userName != "" {
+				listenerType = v1beta2.StrimziListenerTypeTLS
+			} else {
+				listenerType = v1beta2.StrimziListenerTypePlain
+			}
 		}
 
-		bootstrapServers, err := s.getBootstrapServers(ctx, clusterName)
+		listener, err := s.getListener(ctx, clusterName, listenerType)
 		if err != nil {
 			return nil, err
 		}
 
-		props["brokers"] = bootstrapServers
+		if userName != "" || len(listener.Certificates) > 0 {
+			// The kafka trait configures the brokers, along with the TLS and SASL settings
+			kafkaConfig := map[string]interface{}{
+				"enabled":  true,
+				"cluster":  clusterName,
+				"listener": listenerType,
+			}
+			if userName != "" {
+				kafkaConfig["user"] = userName
+			}
+			kafkaConfigJSON, err := json.Marshal(kafkaConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			return &bindings.Binding{
+				URI: uri.AppendParameters(fmt.Sprintf("kafka:%s", topicName), props),
+				Traits: map[string]camelv1.TraitSpec{
+					"kafka": {
+						Configuration: camelv1.TraitConfiguration{
+							RawMessage: kafkaConfigJSON,
+						},
+					},
+				},
+			}, nil
+		}
+
+		props["brokers"] = listener.BootstrapServers
 	}
 
-	kafkaURI := fmt.Sprintf("kafka:%s", endpoint.Ref.Name)
+	kafkaURI := fmt.Sprintf("kafka:%s", topicName)
 	kafkaURI = uri.AppendParameters(kafkaURI, props)
 
 	return &bindings.Binding{
@@ -99,28 +170,28 @@ func (s StrimziBindingProvider) Translate(ctx bindings.BindingContext, _ binding
 	}, nil
 }
 
-func (s StrimziBindingProvider) getBootstrapServers(ctx bindings.BindingContext, clusterName string) (string, error) {
+func (s StrimziBindingProvider) getListener(ctx bindings.BindingContext, clusterName string, listenerType string) (*v1beta2.KafkaStatusListener, error) {
 	cluster, err := s.Client.KafkaV1beta2().Kafkas(ctx.Namespace).Get(ctx.Ctx, clusterName, v1.GetOptions{})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var listener *v1beta2.KafkaStatusListener
-	for _, l := range cluster.Status.Listeners {
-		if l.Type == v1beta2.StrimziListenerTypePlain {
-			listener = &l
+	for i, l := range cluster.Status.Listeners {
+		if l.Type == listenerType {
+			listener = &cluster.Status.Listeners[i]
 			break
 		}
 	}
 
 	if listener == nil {
-		return "", fmt.Errorf("cluster %q has no listeners of type %q", clusterName, v1beta2.StrimziListenerTypePlain)
+		return nil, fmt.Errorf("cluster %q has no listeners of type %q", clusterName, listenerType)
 	}
 	if listener.BootstrapServers == "" {
-		return "", fmt.Errorf("cluster %q has no bootstrap servers in %q listener", clusterName, v1beta2.StrimziListenerTypePlain)
+		return nil, fmt.Errorf("cluster %q has no bootstrap servers in %q listener", clusterName, listenerType)
 	}
 
-	return listener.BootstrapServers, nil
+	return listener, nil
 }
 
 func (s StrimziBindingProvider) Order() int {
@@ -127,6 +127,127 @@ func TestStrimziLookup(t *testing.T) {
 	assert.Nil(t, binding.Traits)
 }
 
+func TestStrimziClusterLookup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cluster := v1beta2.Kafka{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "myclusterx",
+		},
+		Status: v1beta2.KafkaStatus{
+			Listeners: []v1beta2.KafkaStatusListener{
+				{
+					BootstrapServers: "my-clusterx-kafka-bootstrap:9092",
+					Type:             "plain",
+				},
+			},
+		},
+	}
+
+	provider := StrimziBindingProvider{
+		Client: fake.NewSimpleClientset(&cluster),
+	}
+
+	bindingContext := bindings.BindingContext{
+		Ctx:       ctx,
+		Namespace: "test",
+		Profile:   camelv1.TraitProfileKubernetes,
+	}
+
+	endpoint := v1alpha1.Endpoint{
+		Ref: &v1.ObjectReference{
+			Kind:       "Kafka",
+			Name:       "myclusterx",
+			APIVersion: "kafka.strimzi.io/v1beta2",
+		},
+		Properties: asEndpointProperties(map[string]string{
+			"topic":   "mytopicz",
+			"groupId": "mygroup",
+		}),
+	}
+
+	binding, err := provider.Translate(bindingContext, bindings.EndpointContext{
+		Type: v1alpha1.EndpointTypeSource,
+	}, endpoint)
+	assert.NoError(t, err)
+	assert.NotNil(t, binding)
+	assert.Equal(t, "kafka:mytopicz?brokers=my-clusterx-kafka-bootstrap%3A9092&groupId=mygroup", binding.URI)
+	assert.Nil(t, binding.Traits)
+
+	endpoint.Properties = nil
+	_, err = provider.Translate(bindingContext, bindings.EndpointContext{
+		Type: v1alpha1.EndpointTypeSource,
+	}, endpoint)
+	assert.EqualError(t, err, `no "topic" property defined for Kafka cluster myclusterx`)
+}
+
+func TestStrimziSecuredLookup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cluster := v1beta2.Kafka{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "myclusterx",
+		},
+		Status: v1beta2.KafkaStatus{
+			Listeners: []v1beta2.KafkaStatusListener{
+				{
+					BootstrapServers: "my-clusterx-kafka-bootstrap:9093",
+					Type:             "tls",
+					Certificates:     []string{"-----BEGIN CERTIFICATE-----"},
+				},
+			},
+		},
+	}
+
+	topic := v1beta2.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "mytopicy",
+			Labels: map[string]string{
+				v1beta2.StrimziKafkaClusterLabel: "myclusterx",
+			},
+		},
+		Spec: v1beta2.KafkaTopicSpec{
+			TopicName: "my.topic.y",
+		},
+	}
+
+	provider := StrimziBindingProvider{
+		Client: fake.NewSimpleClientset(&cluster, &topic),
+	}
+
+	bindingContext := bindings.BindingContext{
+		Ctx:       ctx,
+		Namespace: "test",
+		Profile:   camelv1.TraitProfileKubernetes,
+	}
+
+	endpoint := v1alpha1.Endpoint{
+		Ref: &v1.ObjectReference{
+			Kind:       "KafkaTopic",
+			Name:       "mytopicy",
+			APIVersion: "kafka.strimzi.io/v1beta2",
+		},
+		Properties: asEndpointProperties(map[string]string{
+			"user": "myuser",
+		}),
+	}
+
+	binding, err := provider.Translate(bindingContext, bindings.EndpointContext{
+		Type: v1alpha1.EndpointTypeSink,
+	}, endpoint)
+	assert.NoError(t, err)
+	assert.NotNil(t, binding)
+	assert.Equal(t, "kafka:my.topic.y", binding.URI)
+	assert.Contains(t, binding.Traits, "kafka")
+	assert.JSONEq(t, `{"enabled":true,"cluster":"myclusterx","listener":"tls","user":"myuser"}`,
+		string(binding.Traits["kafka"].Configuration.RawMessage))
+}
+
 func asEndpointProperties(props map[string]string) *v1alpha1.EndpointProperties {
 	serialized, err := json.Marshal(props)
 	if err != nil {
@@ -28,7 +28,7 @@ import (
 // The 3scale trait can be used to automatically create annotations that allow
 // 3scale to discover the generated service and make it available for API management.
 //
-// The 3scale trait is disabled by default.
+// The 3scale trait is disabled by default, and is superseded by the `api-management` trait, with the `3scale` provider.
 //
 // +camel-k:trait=3scale
 type threeScaleTrait struct {
@@ -51,14 +51,14 @@ func TestRunDevMode(t *testing.T) {
 			kamelRun := KamelWithContext(ctx, "run", "-n", ns, file, "--dev")
 			kamelRun.SetOut(pipew)
 
-			logScanner := util.NewLogScanner(ctx, piper, `integration "yaml" in phase Running`, "Magicstring!", "Magicjordan!")
+			logScanner := util.NewLogScanner(ctx, piper, `Integration "yaml" is deployed`, "Magicstring!", "Magicjordan!")
 
 			args := os.Args
 			defer func() { os.Args = args }()
 			os.Args = []string{"kamel", "run", "-n", ns, file, "--dev"}
 			go kamelRun.Execute()
 
-			Eventually(logScanner.IsFound(`integration "yaml" in phase Running`), TestTimeoutMedium).Should(BeTrue())
+			Eventually(logScanner.IsFound(`Integration "yaml" is deployed`), TestTimeoutMedium).Should(BeTrue())
 			Eventually(logScanner.IsFound("Magicstring!"), TestTimeoutMedium).Should(BeTrue())
 			Expect(logScanner.IsFound("Magicjordan!")()).To(BeFalse())
 
@@ -20,6 +20,7 @@ package apis
 import (
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	sourcesv1beta2 "knative.dev/eventing/pkg/apis/sources/v1beta2"
@@ -29,6 +30,7 @@ func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
 	AddToSchemes = append(AddToSchemes, eventingv1beta1.AddToScheme)
 	AddToSchemes = append(AddToSchemes, eventingv1.AddToScheme)
+	AddToSchemes = append(AddToSchemes, flowsv1.AddToScheme)
 	AddToSchemes = append(AddToSchemes, messagingv1.AddToScheme)
 	AddToSchemes = append(AddToSchemes, sourcesv1.AddToScheme)
 	AddToSchemes = append(AddToSchemes, sourcesv1beta2.AddToScheme)
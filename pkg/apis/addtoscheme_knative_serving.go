@@ -19,9 +19,10 @@ package apis
 
 import (
 	serving "knative.dev/serving/pkg/apis/serving/v1"
+	servingv1alpha1 "knative.dev/serving/pkg/apis/serving/v1alpha1"
 )
 
 func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
-	AddToSchemes = append(AddToSchemes, serving.AddToScheme)
+	AddToSchemes = append(AddToSchemes, serving.AddToScheme, servingv1alpha1.AddToScheme)
 }
@@ -43,6 +43,8 @@ type Task struct {
 	Kaniko   *KanikoTask   `json:"kaniko,omitempty"`
 	Spectrum *SpectrumTask `json:"spectrum,omitempty"`
 	S2i      *S2iTask      `json:"s2i,omitempty"`
+	Tekton   *TektonTask   `json:"tekton,omitempty"`
+	Local    *LocalTask    `json:"local,omitempty"`
 }
 
 // BaseTask --
@@ -61,6 +63,15 @@ type BuilderTask struct {
 	Steps        []string          `json:"steps,omitempty"`
 	Maven        MavenSpec         `json:"maven,omitempty"`
 	BuildDir     string            `json:"buildDir,omitempty"`
+	// The dependencies, in the form groupId:artifactId, that are excluded from the build,
+	// including when they are transitive dependencies
+	Exclusions []string `json:"exclusions,omitempty"`
+	// The dependencies, in the form mvn:groupId:artifactId:version, whose versions are pinned in the build
+	LockedDependencies []string `json:"lockedDependencies,omitempty"`
+	// The image the task is run in, with the pod build strategy, instead of the operator image
+	Image string `json:"image,omitempty"`
+	// The Java and Groovy unit tests run during the build, that fails when a test fails
+	Tests []SourceSpec `json:"tests,omitempty"`
 }
 
 // PublishTask --
@@ -77,6 +88,8 @@ type BuildahTask struct {
 	PublishTask     `json:",inline"`
 	Verbose         *bool  `json:"verbose,omitempty"`
 	HttpProxySecret string `json:"httpProxySecret,omitempty"`
+	// The architectures the image is built for. A multi-architecture image is built when several are declared.
+	Architectures []string `json:"architectures,omitempty"`
 }
 
 // KanikoTask --
@@ -107,21 +120,79 @@ type S2iTask struct {
 	Tag        string `json:"tag,omitempty"`
 }
 
+// TektonTask delegates the build and the publication of the image to a Tekton PipelineRun
+type TektonTask struct {
+	BaseTask    `json:",inline"`
+	PublishTask `json:",inline"`
+	// The PipelineRun created to build the image, to which the build parameters and workspace are added
+	PipelineRunTemplate *PipelineRunTemplate `json:"pipelineRunTemplate,omitempty"`
+	// The name of the Pipeline workspace the generated Maven project is bound to
+	Workspace string `json:"workspace,omitempty"`
+	// The claim of the volume the generated Maven project is shared through with the PipelineRun
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+}
+
+// LocalTask builds the image with the container engine of the node the build pod runs on, so that it's directly
+// available to the node container runtime, without being pushed to a registry
+type LocalTask struct {
+	BaseTask    `json:",inline"`
+	PublishTask `json:",inline"`
+	// The container engine the image is built with
+	Engine IntegrationPlatformLocalEngine `json:"engine,omitempty"`
+	// The address of the container engine daemon
+	Address string `json:"address,omitempty"`
+	// The image of the container engine client
+	ClientImage string `json:"clientImage,omitempty"`
+	Verbose     *bool  `json:"verbose,omitempty"`
+}
+
 // BuildStatus defines the observed state of Build
 type BuildStatus struct {
-	Phase      BuildPhase       `json:"phase,omitempty"`
-	Image      string           `json:"image,omitempty"`
-	Digest     string           `json:"digest,omitempty"`
-	BaseImage  string           `json:"baseImage,omitempty"`
-	Artifacts  []Artifact       `json:"artifacts,omitempty"`
-	Error      string           `json:"error,omitempty"`
-	Failure    *Failure         `json:"failure,omitempty"`
-	StartedAt  *metav1.Time     `json:"startedAt,omitempty"`
-	Platform   string           `json:"platform,omitempty"`
-	Conditions []BuildCondition `json:"conditions,omitempty"`
+	// ObservedGeneration is the most recent generation observed for this Build
+	ObservedGeneration int64            `json:"observedGeneration,omitempty"`
+	Phase              BuildPhase       `json:"phase,omitempty"`
+	Image              string           `json:"image,omitempty"`
+	Digest             string           `json:"digest,omitempty"`
+	BaseImage          string           `json:"baseImage,omitempty"`
+	Artifacts          []Artifact       `json:"artifacts,omitempty"`
+	Error              string           `json:"error,omitempty"`
+	Failure            *Failure         `json:"failure,omitempty"`
+	StartedAt          *metav1.Time     `json:"startedAt,omitempty"`
+	Platform           string           `json:"platform,omitempty"`
+	Conditions         []BuildCondition `json:"conditions,omitempty"`
 	// Change to Duration / ISO 8601 when CRD uses OpenAPI spec v3
 	// https://github.com/OAI/OpenAPI-Specification/issues/845
 	Duration string `json:"duration,omitempty"`
+	// The architectures the image has been built for
+	Architectures []string `json:"architectures,omitempty"`
+	// The Maven configuration the build is executed with
+	Maven *MavenBuildStatus `json:"maven,omitempty"`
+	// The results of the unit tests run during the build
+	Tests *BuildTestsStatus `json:"tests,omitempty"`
+}
+
+// BuildTestsStatus reports the results of the unit tests run during a Build
+type BuildTestsStatus struct {
+	// The number of tests run
+	Tests int32 `json:"tests"`
+	// The number of tests that failed an assertion
+	Failures int32 `json:"failures,omitempty"`
+	// The number of tests that errored
+	Errors int32 `json:"errors,omitempty"`
+	// The number of skipped tests
+	Skipped int32 `json:"skipped,omitempty"`
+	// The excerpts of the reports of the tests that failed or errored, in the form `class.method: message`
+	Reports []string `json:"reports,omitempty"`
+}
+
+// MavenBuildStatus records the Maven configuration a Build is executed with, on top of the Maven settings
+type MavenBuildStatus struct {
+	// The Maven profiles activated for the build
+	Profiles []string `json:"profiles,omitempty"`
+	// The Maven repositories the dependencies are resolved from
+	Repositories []Repository `json:"repositories,omitempty"`
+	// The mirrors added to the Maven settings
+	Mirrors []Mirror `json:"mirrors,omitempty"`
 }
 
 // BuildPhase --
@@ -159,6 +230,24 @@ const (
 	BuildConditionPlatformAvailable BuildConditionType = "IntegrationPlatformAvailable"
 	// BuildConditionPlatformAvailableReason --
 	BuildConditionPlatformAvailableReason string = "IntegrationPlatformAvailable"
+	// BuildConditionReady --
+	BuildConditionReady BuildConditionType = "Ready"
+	// BuildConditionProgressing --
+	BuildConditionProgressing BuildConditionType = "Progressing"
+	// BuildConditionDegraded --
+	BuildConditionDegraded BuildConditionType = "Degraded"
+	// BuildConditionQuotaExceeded reports the Build waits to be scheduled because the quota of running builds
+	// of its namespace is reached
+	BuildConditionQuotaExceeded BuildConditionType = "QuotaExceeded"
+	// BuildConditionQuotaExceededReason --
+	BuildConditionQuotaExceededReason string = "QuotaExceeded"
+	// BuildConditionPreempted reports the Build waits to be scheduled because a queued Build with a higher priority
+	// is scheduled first
+	BuildConditionPreempted BuildConditionType = "Preempted"
+	// BuildConditionPreemptedReason --
+	BuildConditionPreemptedReason string = "Preempted"
+	// BuildConditionPipelineRunSucceeded reports the status of the Tekton PipelineRun the image build is delegated to
+	BuildConditionPipelineRunSucceeded BuildConditionType = "PipelineRunSucceeded"
 )
 
 // +genclient
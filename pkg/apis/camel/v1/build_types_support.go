@@ -126,6 +126,22 @@ func (in *BuildStatus) SetConditions(conditions ...BuildCondition) {
 	}
 }
 
+// SetPhaseConditions sets the Ready, Progressing and Degraded conditions according to the phase of the build.
+// A failed build is still progressing, until it is recovered or it errors.
+func (in *BuildStatus) SetPhaseConditions() {
+	reason := PhaseConditionReason(string(in.Phase))
+	failed := in.Phase == BuildPhaseFailed || in.Phase == BuildPhaseError || in.Phase == BuildPhaseInterrupted
+	in.SetCondition(BuildConditionReady, conditionStatus(in.Phase == BuildPhaseSucceeded), reason, "")
+	in.SetCondition(BuildConditionProgressing,
+		conditionStatus(in.Phase != BuildPhaseSucceeded && in.Phase != BuildPhaseError && in.Phase != BuildPhaseInterrupted), reason, "")
+
+	message := ""
+	if failed {
+		message = in.Error
+	}
+	in.SetCondition(BuildConditionDegraded, conditionStatus(failed), reason, message)
+}
+
 // RemoveCondition removes the resource condition with the provided type.
 func (in *BuildStatus) RemoveCondition(condType BuildConditionType) {
 	newConditions := in.Conditions[:0]
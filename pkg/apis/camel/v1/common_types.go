@@ -105,9 +105,17 @@ type MavenSpec struct {
 	// and configured to be used as a trusted certificate(s) by the Maven commands.
 	// Note that the root CA certificates are also imported into the created keystore.
 	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+	// The Secret name and key, containing the Maven settings security, i.e., the content
+	// of the settings-security.xml file, with the encrypted master password used to decrypt
+	// the encrypted passwords of the Maven settings.
+	SettingsSecurity *corev1.SecretKeySelector `json:"settingsSecurity,omitempty"`
 	// Deprecated: use IntegrationPlatform.Spec.Build.Timeout instead
 	Timeout      *metav1.Duration `json:"timeout,omitempty"`
 	Repositories []Repository     `json:"repositories,omitempty"`
+	// The mirrors added to the Maven settings, ahead of the ones they declare.
+	Mirrors []Mirror `json:"mirrors,omitempty"`
+	// The Maven profiles activated for the build.
+	Profiles []string `json:"profiles,omitempty"`
 	// Maven build extensions https://maven.apache.org/guides/mini/guide-using-extensions.html
 	Extension []MavenArtifact `json:"extension,omitempty"`
 }
@@ -238,16 +246,28 @@ const (
 
 // DataSpec --
 type DataSpec struct {
-	Name        string `json:"name,omitempty"`
-	Path        string `json:"path,omitempty"`
-	Content     string `json:"content,omitempty"`
-	RawContent  []byte `json:"rawContent,omitempty"`
-	ContentRef  string `json:"contentRef,omitempty"`
-	ContentKey  string `json:"contentKey,omitempty"`
-	ContentType string `json:"contentType,omitempty"`
-	Compression bool   `json:"compression,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Content    string `json:"content,omitempty"`
+	RawContent []byte `json:"rawContent,omitempty"`
+	ContentRef string `json:"contentRef,omitempty"`
+	// ContentRefKind is the kind of the resource the content is referenced from, either `configmap`, the default,
+	// or `secret`
+	ContentRefKind string `json:"contentRefKind,omitempty"`
+	ContentKey     string `json:"contentKey,omitempty"`
+	ContentType    string `json:"contentType,omitempty"`
+	Compression    bool   `json:"compression,omitempty"`
 }
 
+const (
+	// ContentRefKindConfigMap --
+	ContentRefKindConfigMap = "configmap"
+	// ContentRefKindSecret --
+	ContentRefKindSecret = "secret"
+	// DefaultContentKey is the key the referenced content is read from when no key is set
+	DefaultContentKey = "content"
+)
+
 // Language --
 type Language string
 
@@ -21,6 +21,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 func (in *Artifact) String() string {
@@ -69,3 +72,19 @@ func (m *RawMessage) UnmarshalJSON(data []byte) error {
 
 var _ json.Marshaler = (*RawMessage)(nil)
 var _ json.Unmarshaler = (*RawMessage)(nil)
+
+// PhaseConditionReason returns the reason of the conditions that are derived from the given phase,
+// e.g., BuildingKit for the Building Kit phase
+func PhaseConditionReason(phase string) string {
+	if phase == "" {
+		return "Pending"
+	}
+	return strings.ReplaceAll(phase, " ", "")
+}
+
+func conditionStatus(value bool) corev1.ConditionStatus {
+	if value {
+		return corev1.ConditionTrue
+	}
+	return corev1.ConditionFalse
+}
@@ -41,15 +41,58 @@ type IntegrationSpec struct {
 	Configuration      []ConfigurationSpec     `json:"configuration,omitempty"`
 	Repositories       []string                `json:"repositories,omitempty"`
 	ServiceAccountName string                  `json:"serviceAccountName,omitempty"`
+	// The lock of the resolved dependencies, used to make the builds reproducible
+	DependencyLock *DependencyLock `json:"dependencyLock,omitempty"`
+	// Tests are the smoke tests run against the Integration once it is rolled out,
+	// that must pass for the Integration to become ready
+	Tests []IntegrationTest `json:"tests,omitempty"`
+}
+
+// IntegrationTest is a smoke test run against a deployed Integration
+type IntegrationTest struct {
+	// Name identifies the test in the status of the Integration
+	Name string `json:"name"`
+	// HTTP sends a request to the Integration Service, and checks the response
+	HTTP *IntegrationTestHTTP `json:"http,omitempty"`
+}
+
+// IntegrationTestHTTP sends a request to the Integration Service, e.g., to probe an endpoint or to check
+// the round-trip of a message through the Integration
+type IntegrationTestHTTP struct {
+	// Port is the port of the Service, 80 by default
+	Port int32 `json:"port,omitempty"`
+	// Path is the path of the request, `/` by default
+	Path string `json:"path,omitempty"`
+	// Method is the method of the request, `GET` by default, or `POST` when a body is set
+	Method string `json:"method,omitempty"`
+	// Headers are the headers of the request
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is the body of the request
+	Body string `json:"body,omitempty"`
+	// ExpectedStatus is the expected status code of the response, 200 by default
+	ExpectedStatus int32 `json:"expectedStatus,omitempty"`
+	// ExpectedBody is a string the body of the response must contain
+	ExpectedBody string `json:"expectedBody,omitempty"`
+}
+
+// DependencyLock pins the runtime version and the versions of the Maven dependencies of an integration,
+// so that its builds remain the same across catalog upgrades
+type DependencyLock struct {
+	// The Camel K runtime version the dependencies have been resolved with
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	// The resolved dependencies, including the transitive ones, in the form mvn:groupId:artifactId:version
+	Dependencies []string `json:"dependencies,omitempty"`
 }
 
 // IntegrationStatus defines the observed state of Integration
 type IntegrationStatus struct {
-	Phase        IntegrationPhase `json:"phase,omitempty"`
-	Digest       string           `json:"digest,omitempty"`
-	Image        string           `json:"image,omitempty"`
-	Dependencies []string         `json:"dependencies,omitempty"`
-	Profile      TraitProfile     `json:"profile,omitempty"`
+	// ObservedGeneration is the most recent generation observed for this Integration
+	ObservedGeneration int64            `json:"observedGeneration,omitempty"`
+	Phase              IntegrationPhase `json:"phase,omitempty"`
+	Digest             string           `json:"digest,omitempty"`
+	Image              string           `json:"image,omitempty"`
+	Dependencies       []string         `json:"dependencies,omitempty"`
+	Profile            TraitProfile     `json:"profile,omitempty"`
 	// Deprecated: use the IntegrationKit field
 	Kit                string                  `json:"kit,omitempty"`
 	IntegrationKit     *corev1.ObjectReference `json:"integrationKit,omitempty"`
@@ -66,6 +109,24 @@ type IntegrationStatus struct {
 	Capabilities       []string                `json:"capabilities,omitempty"`
 	// The timestamp representing the last time when this integration was initialized.
 	InitializationTimestamp *metav1.Time `json:"lastInitTimestamp,omitempty"`
+	// The value of the trigger annotation the last out of schedule Job has been run for
+	LastTrigger string `json:"lastTrigger,omitempty"`
+	// The resources recommendations of the Vertical Pod Autoscaler for the containers of the integration
+	Recommendations []ResourceRecommendation `json:"recommendations,omitempty"`
+}
+
+// ResourceRecommendation is the recommendation of the Vertical Pod Autoscaler for the resources of a container
+type ResourceRecommendation struct {
+	// The name of the container
+	Container string `json:"container"`
+	// The recommended resource requests
+	Target corev1.ResourceList `json:"target,omitempty"`
+	// The minimum recommended resource requests
+	LowerBound corev1.ResourceList `json:"lowerBound,omitempty"`
+	// The maximum recommended resource requests
+	UpperBound corev1.ResourceList `json:"upperBound,omitempty"`
+	// The resource requests applied on the last deployment of the integration, within the bounds of the vpa trait
+	Applied corev1.ResourceList `json:"applied,omitempty"`
 }
 
 // +genclient
@@ -129,12 +190,16 @@ const (
 	IntegrationConditionPlatformAvailable IntegrationConditionType = "IntegrationPlatformAvailable"
 	// IntegrationConditionDeploymentAvailable --
 	IntegrationConditionDeploymentAvailable IntegrationConditionType = "DeploymentAvailable"
+	// IntegrationConditionStatefulSetAvailable --
+	IntegrationConditionStatefulSetAvailable IntegrationConditionType = "StatefulSetAvailable"
 	// IntegrationConditionServiceAvailable --
 	IntegrationConditionServiceAvailable IntegrationConditionType = "ServiceAvailable"
 	// IntegrationConditionKnativeServiceAvailable --
 	IntegrationConditionKnativeServiceAvailable IntegrationConditionType = "KnativeServiceAvailable"
 	// IntegrationConditionCronJobAvailable --
 	IntegrationConditionCronJobAvailable IntegrationConditionType = "CronJobAvailable"
+	// IntegrationConditionLastJobSucceeded reports the result of the last Job of an Integration materialized as a CronJob
+	IntegrationConditionLastJobSucceeded IntegrationConditionType = "LastJobSucceeded"
 	// IntegrationConditionExposureAvailable --
 	IntegrationConditionExposureAvailable IntegrationConditionType = "ExposureAvailable"
 	// IntegrationConditionPrometheusAvailable --
@@ -143,8 +208,18 @@ const (
 	IntegrationConditionJolokiaAvailable IntegrationConditionType = "JolokiaAvailable"
 	// IntegrationConditionProbesAvailable --
 	IntegrationConditionProbesAvailable IntegrationConditionType = "ProbesAvailable"
+	// IntegrationConditionHorizontalPodAutoscalerAvailable reports the conditions of the HorizontalPodAutoscaler of the Integration
+	IntegrationConditionHorizontalPodAutoscalerAvailable IntegrationConditionType = "HorizontalPodAutoscalerAvailable"
 	// IntegrationConditionReady --
 	IntegrationConditionReady IntegrationConditionType = "Ready"
+	// IntegrationConditionProgressing --
+	IntegrationConditionProgressing IntegrationConditionType = "Progressing"
+	// IntegrationConditionDegraded --
+	IntegrationConditionDegraded IntegrationConditionType = "Degraded"
+	// IntegrationConditionResourcesInSync --
+	IntegrationConditionResourcesInSync IntegrationConditionType = "ResourcesInSync"
+	// IntegrationConditionControllerStrategySelected explains the kind of controller selected to run the Integration pods
+	IntegrationConditionControllerStrategySelected IntegrationConditionType = "ControllerStrategySelected"
 
 	// IntegrationConditionKitAvailableReason --
 	IntegrationConditionKitAvailableReason string = "IntegrationKitAvailable"
@@ -154,6 +229,8 @@ const (
 	IntegrationConditionDeploymentAvailableReason string = "DeploymentAvailable"
 	// IntegrationConditionDeploymentNotAvailableReason --
 	IntegrationConditionDeploymentNotAvailableReason string = "DeploymentNotAvailable"
+	// IntegrationConditionStatefulSetAvailableReason --
+	IntegrationConditionStatefulSetAvailableReason string = "StatefulSetAvailable"
 	// IntegrationConditionServiceAvailableReason --
 	IntegrationConditionServiceAvailableReason string = "ServiceAvailable"
 	// IntegrationConditionServiceNotAvailableReason --
@@ -176,6 +253,12 @@ const (
 	IntegrationConditionCronJobAvailableReason string = "CronJobAvailableReason"
 	// IntegrationConditionCronJobNotAvailableReason --
 	IntegrationConditionCronJobNotAvailableReason string = "CronJobNotAvailableReason"
+	// IntegrationConditionJobSucceededReason --
+	IntegrationConditionJobSucceededReason string = "JobSucceeded"
+	// IntegrationConditionJobFailedReason --
+	IntegrationConditionJobFailedReason string = "JobFailed"
+	// IntegrationConditionJobRunningReason --
+	IntegrationConditionJobRunningReason string = "JobRunning"
 	// IntegrationConditionPrometheusAvailableReason --
 	IntegrationConditionPrometheusAvailableReason string = "PrometheusAvailable"
 	// IntegrationConditionJolokiaAvailableReason --
@@ -190,8 +273,33 @@ const (
 	IntegrationConditionReplicaSetReadyReason string = "ReplicaSetReady"
 	// IntegrationConditionReplicaSetNotReadyReason --
 	IntegrationConditionReplicaSetNotReadyReason string = "ReplicaSetNotReady"
+	// IntegrationConditionStatefulSetReadyReason --
+	IntegrationConditionStatefulSetReadyReason string = "StatefulSetReady"
+	// IntegrationConditionStatefulSetNotReadyReason --
+	IntegrationConditionStatefulSetNotReadyReason string = "StatefulSetNotReady"
 	// IntegrationConditionUnsupportedLanguageReason --
 	IntegrationConditionUnsupportedLanguageReason string = "UnsupportedLanguage"
+	// IntegrationConditionResourcesInSyncReason --
+	IntegrationConditionResourcesInSyncReason string = "ResourcesInSync"
+	// IntegrationConditionResourcesDriftReason --
+	IntegrationConditionResourcesDriftReason string = "ResourcesDrift"
+	// IntegrationConditionControllerStrategyExplicitReason --
+	IntegrationConditionControllerStrategyExplicitReason string = "ExplicitKind"
+	// IntegrationConditionControllerStrategyTraitReason --
+	IntegrationConditionControllerStrategyTraitReason string = "TraitConfiguration"
+	// IntegrationConditionControllerStrategyHTTPServicesReason --
+	IntegrationConditionControllerStrategyHTTPServicesReason string = "HTTPServicesExposed"
+	// IntegrationConditionControllerStrategyScheduleReason --
+	IntegrationConditionControllerStrategyScheduleReason string = "Scheduled"
+	// IntegrationConditionControllerStrategyDefaultReason --
+	IntegrationConditionControllerStrategyDefaultReason string = "Default"
+	// IntegrationConditionControllerStrategyInvalidReason --
+	IntegrationConditionControllerStrategyInvalidReason string = "InvalidKind"
+	// IntegrationConditionRedeployDeferred reports the redeploy of the Integration that is deferred to its maintenance window
+	IntegrationConditionRedeployDeferred IntegrationConditionType = "RedeployDeferred"
+	// IntegrationConditionMaintenanceWindowReason --
+	IntegrationConditionMaintenanceWindowReason string = "MaintenanceWindow"
+
 
 	// IntegrationConditionKameletsAvailable --
 	IntegrationConditionKameletsAvailable IntegrationConditionType = "KameletsAvailable"
@@ -199,6 +307,54 @@ const (
 	IntegrationConditionKameletsAvailableReason string = "KameletsAvailable"
 	// IntegrationConditionKameletsNotAvailableReason --
 	IntegrationConditionKameletsNotAvailableReason string = "KameletsNotAvailable"
+
+	// IntegrationConditionSourcesValid --
+	IntegrationConditionSourcesValid IntegrationConditionType = "SourcesValid"
+	// IntegrationConditionSourcesValidReason --
+	IntegrationConditionSourcesValidReason string = "SourcesValid"
+	// IntegrationConditionSourcesNotValidReason --
+	IntegrationConditionSourcesNotValidReason string = "SourcesNotValid"
+
+	// IntegrationConditionPolicyCompliant --
+	IntegrationConditionPolicyCompliant IntegrationConditionType = "PolicyCompliant"
+	// IntegrationConditionPolicyCompliantReason --
+	IntegrationConditionPolicyCompliantReason string = "PolicyCompliant"
+	// IntegrationConditionPolicyViolatedReason --
+	IntegrationConditionPolicyViolatedReason string = "PolicyViolated"
+
+	// IntegrationConditionTestsPassed reports the result of the Integration smoke tests
+	IntegrationConditionTestsPassed IntegrationConditionType = "TestsPassed"
+	// IntegrationConditionTestsPassedReason --
+	IntegrationConditionTestsPassedReason string = "TestsPassed"
+	// IntegrationConditionTestsFailedReason --
+	IntegrationConditionTestsFailedReason string = "TestsFailed"
+
+	// IntegrationConditionUpgradeAvailable reports that the Integration has been built by a former version of the operator,
+	// and whether it can be upgraded to the current one
+	IntegrationConditionUpgradeAvailable IntegrationConditionType = "UpgradeAvailable"
+	// IntegrationConditionUpgradePendingReason reports that the Integration is compatible with the current version,
+	// but is not upgraded automatically
+	IntegrationConditionUpgradePendingReason string = "UpgradePending"
+	// IntegrationConditionUpgradeScheduledReason reports that the Integration is waiting for its turn to be upgraded
+	IntegrationConditionUpgradeScheduledReason string = "UpgradeScheduled"
+	// IntegrationConditionUpgradeDeferredReason reports that the upgrade of the Integration is deferred to its maintenance window
+	IntegrationConditionUpgradeDeferredReason string = "UpgradeDeferred"
+	// IntegrationConditionUpgradeBlockedReason reports that the Integration is not compatible with the current version
+	IntegrationConditionUpgradeBlockedReason string = "UpgradeBlocked"
+
+	// IntegrationConditionHibernated reports whether the Integration has been scaled to zero because it is idle
+	IntegrationConditionHibernated IntegrationConditionType = "Hibernated"
+	// IntegrationConditionIdleReason reports that the Integration has not processed any exchange during the idle period
+	IntegrationConditionIdleReason string = "Idle"
+	// IntegrationConditionAwakenedReason reports that the Integration has been scaled back since it was hibernated
+	IntegrationConditionAwakenedReason string = "Awakened"
+
+	// IntegrationConditionServiceBindingAvailable reports how the bindings of the services are projected into the Integration
+	IntegrationConditionServiceBindingAvailable IntegrationConditionType = "ServiceBindingAvailable"
+	// IntegrationConditionServiceBindingOperatorReason reports that the bindings are projected with the Service Binding Operator logic
+	IntegrationConditionServiceBindingOperatorReason string = "ServiceBindingOperator"
+	// IntegrationConditionServiceBindingNativeReason reports that the bindings are projected natively by the operator
+	IntegrationConditionServiceBindingNativeReason string = "NativeProjection"
 )
 
 // IntegrationCondition describes the state of a resource at a certain point.
@@ -19,6 +19,7 @@ package v1
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,6 +29,42 @@ import (
 
 const IntegrationLabel = "camel.apache.org/integration"
 
+// PropertyReferencesAnnotation lists the ConfigMaps and Secrets, as kind/name pairs, the resolved properties
+// of an Integration are read from
+const PropertyReferencesAnnotation = "camel.apache.org/property.references"
+
+// PauseReconciliationAnnotation can be set to "true" on a resource owned by an Integration, so that the changes
+// made by other field managers, e.g., a manual hotfix, are reported instead of being overwritten by the operator
+const PauseReconciliationAnnotation = "camel.apache.org/pause-reconciliation"
+
+// TriggerAnnotation can be set on an Integration materialized as a CronJob, so that a Job is run immediately,
+// out of schedule. A new Job is run every time the value of the annotation changes.
+const TriggerAnnotation = "camel.apache.org/trigger"
+
+// BuildPriorityAnnotation sets the priority of the builds of an Integration, as an integer defaulting to 0. The queued
+// builds with the highest priority are scheduled first, e.g., so that production redeploys take precedence over
+// development experiments.
+const BuildPriorityAnnotation = "camel.apache.org/build.priority"
+
+// DigestExcludedTraitsAnnotation lists, comma-separated, the traits, or the trait properties as `<trait>.<property>`,
+// whose configuration is excluded from the digest of an Integration, in addition to the ones excluded by the platform
+const DigestExcludedTraitsAnnotation = "camel.apache.org/digest.excluded-traits"
+
+// MaintenanceWindowAnnotation overrides the cron expression of the maintenance window of the platform for an Integration,
+// the `none` value disabling the deferral of its redeploys
+const MaintenanceWindowAnnotation = "camel.apache.org/maintenance-window"
+
+const (
+	// RequesterAnnotation records the user that has last created or updated a resource, as authenticated
+	// by the admission webhooks
+	RequesterAnnotation = "camel.apache.org/requester"
+	// RequesterGroupsAnnotation records the comma-separated groups of the user that has last created or updated a resource
+	RequesterGroupsAnnotation = "camel.apache.org/requester.groups"
+	// RequesterSignatureAnnotation records the signature of the requester by the admission webhooks, so that
+	// the operator can check the requester annotations have not been set by the user
+	RequesterSignatureAnnotation = "camel.apache.org/requester.signature"
+)
+
 // NewIntegration --
 func NewIntegration(namespace string, name string) Integration {
 	return Integration{
@@ -284,6 +321,22 @@ func (in *SourceSpec) InferLanguage() Language {
 	return ""
 }
 
+// GetContentRefKind returns the kind of the resource the content is referenced from, `configmap` by default
+func (in *DataSpec) GetContentRefKind() string {
+	if in.ContentRefKind != "" {
+		return in.ContentRefKind
+	}
+	return ContentRefKindConfigMap
+}
+
+// GetContentKey returns the key of the referenced resource the content is read from, `content` by default
+func (in *DataSpec) GetContentKey() string {
+	if in.ContentKey != "" {
+		return in.ContentKey
+	}
+	return DefaultContentKey
+}
+
 // SetIntegrationPlatform --
 func (in *Integration) SetIntegrationPlatform(platform *IntegrationPlatform) {
 	cs := corev1.ConditionTrue
@@ -321,6 +374,15 @@ func (in *Integration) SetIntegrationKit(kit *IntegrationKit) {
 	in.Status.Image = image
 }
 
+// GetBuildPriority returns the priority of the builds of the integration, set with the BuildPriorityAnnotation
+// annotation, an invalid value being ignored
+func (in *Integration) GetBuildPriority() int {
+	if priority, err := strconv.Atoi(in.Annotations[BuildPriorityAnnotation]); err == nil {
+		return priority
+	}
+	return 0
+}
+
 // GetIntegrationKitNamespace --
 func (in *Integration) GetIntegrationKitNamespace(p *IntegrationPlatform) string {
 	if in.Status.IntegrationKit != nil && in.Status.IntegrationKit.Namespace != "" {
@@ -409,6 +471,35 @@ func (in *IntegrationStatus) SetConditions(conditions ...IntegrationCondition) {
 	}
 }
 
+// SetPhaseConditions sets the Progressing and Degraded conditions according to the phase of the integration.
+// The integration is still progressing while running, until its Ready condition is true, and is degraded
+// either in error, or when its resources have drifted from the ones computed by the traits.
+func (in *IntegrationStatus) SetPhaseConditions() {
+	reason := PhaseConditionReason(string(in.Phase))
+	ready := in.GetCondition(IntegrationConditionReady)
+	progressing := in.Phase != IntegrationPhaseError &&
+		(in.Phase != IntegrationPhaseRunning || ready == nil || ready.Status != corev1.ConditionTrue)
+	in.SetCondition(IntegrationConditionProgressing, conditionStatus(progressing), reason, "")
+
+	message := ""
+	if in.Phase == IntegrationPhaseError {
+		for _, c := range in.Conditions {
+			if c.Status == corev1.ConditionFalse && c.Message != "" && c.Type != IntegrationConditionProgressing && c.Type != IntegrationConditionDegraded {
+				message = c.Message
+				break
+			}
+		}
+	}
+	degraded := in.Phase == IntegrationPhaseError
+	// The integration is also degraded when its resources have been changed externally
+	if drift := in.GetCondition(IntegrationConditionResourcesInSync); !degraded && drift != nil && drift.Status == corev1.ConditionFalse {
+		degraded = true
+		reason = drift.Reason
+		message = drift.Message
+	}
+	in.SetCondition(IntegrationConditionDegraded, conditionStatus(degraded), reason, message)
+}
+
 // RemoveCondition removes the resource condition with the provided type.
 func (in *IntegrationStatus) RemoveCondition(condType IntegrationConditionType) {
 	newConditions := in.Conditions[:0]
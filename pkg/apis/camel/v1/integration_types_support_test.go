@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestAllLanguages(t *testing.T) {
@@ -98,3 +100,79 @@ func TestGetConfigurationProperty(t *testing.T) {
 	v6 := integration.GetConfigurationProperty("key6")
 	assert.Equal(t, "", v6)
 }
+
+func TestIntegrationPhaseConditions(t *testing.T) {
+	status := IntegrationStatus{Phase: IntegrationPhaseBuildingKit}
+	status.SetPhaseConditions()
+	assert.Equal(t, corev1.ConditionTrue, status.GetCondition(IntegrationConditionProgressing).Status)
+	assert.Equal(t, "BuildingKit", status.GetCondition(IntegrationConditionProgressing).Reason)
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(IntegrationConditionDegraded).Status)
+
+	status.Phase = IntegrationPhaseRunning
+	status.SetCondition(IntegrationConditionReady, corev1.ConditionFalse, IntegrationConditionDeploymentNotAvailableReason, "")
+	status.SetPhaseConditions()
+	assert.Equal(t, corev1.ConditionTrue, status.GetCondition(IntegrationConditionProgressing).Status)
+
+	status.SetCondition(IntegrationConditionReady, corev1.ConditionTrue, IntegrationConditionDeploymentAvailableReason, "")
+	status.SetPhaseConditions()
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(IntegrationConditionProgressing).Status)
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(IntegrationConditionDegraded).Status)
+
+	status.Phase = IntegrationPhaseError
+	status.SetCondition(IntegrationConditionReady, corev1.ConditionFalse, IntegrationConditionErrorReason, "pods in error")
+	status.SetPhaseConditions()
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(IntegrationConditionProgressing).Status)
+	degraded := status.GetCondition(IntegrationConditionDegraded)
+	assert.Equal(t, corev1.ConditionTrue, degraded.Status)
+	assert.Equal(t, "Error", degraded.Reason)
+	assert.Equal(t, "pods in error", degraded.Message)
+}
+
+func TestBuildPhaseConditions(t *testing.T) {
+	status := BuildStatus{Phase: BuildPhaseFailed, Error: "pod failed"}
+	status.SetPhaseConditions()
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(BuildConditionReady).Status)
+	assert.Equal(t, corev1.ConditionTrue, status.GetCondition(BuildConditionProgressing).Status)
+	assert.Equal(t, corev1.ConditionTrue, status.GetCondition(BuildConditionDegraded).Status)
+	assert.Equal(t, "pod failed", status.GetCondition(BuildConditionDegraded).Message)
+
+	status = BuildStatus{Phase: BuildPhaseSucceeded}
+	status.SetPhaseConditions()
+	assert.Equal(t, corev1.ConditionTrue, status.GetCondition(BuildConditionReady).Status)
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(BuildConditionProgressing).Status)
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(BuildConditionDegraded).Status)
+}
+
+func TestPhaseConditionReason(t *testing.T) {
+	assert.Equal(t, "Pending", PhaseConditionReason(""))
+	assert.Equal(t, "WaitingForPlatform", PhaseConditionReason(string(IntegrationPhaseWaitingForPlatform)))
+}
+
+func TestGetBuildPriority(t *testing.T) {
+	it := NewIntegration("ns", "it")
+	assert.Equal(t, 0, it.GetBuildPriority())
+
+	it.Annotations = map[string]string{BuildPriorityAnnotation: "100"}
+	assert.Equal(t, 100, it.GetBuildPriority())
+
+	it.Annotations[BuildPriorityAnnotation] = "-10"
+	assert.Equal(t, -10, it.GetBuildPriority())
+
+	it.Annotations[BuildPriorityAnnotation] = "high"
+	assert.Equal(t, 0, it.GetBuildPriority())
+}
+
+func TestIntegrationPhaseConditionsWithResourcesDrift(t *testing.T) {
+	status := IntegrationStatus{Phase: IntegrationPhaseRunning}
+	status.SetCondition(IntegrationConditionReady, corev1.ConditionTrue, IntegrationConditionDeploymentAvailableReason, "")
+	status.SetCondition(IntegrationConditionResourcesInSync, corev1.ConditionFalse, IntegrationConditionResourcesDriftReason, "container integration: env FOO added")
+	status.SetPhaseConditions()
+	degraded := status.GetCondition(IntegrationConditionDegraded)
+	assert.Equal(t, corev1.ConditionTrue, degraded.Status)
+	assert.Equal(t, IntegrationConditionResourcesDriftReason, degraded.Reason)
+	assert.Equal(t, "container integration: env FOO added", degraded.Message)
+
+	status.SetCondition(IntegrationConditionResourcesInSync, corev1.ConditionTrue, IntegrationConditionResourcesInSyncReason, "")
+	status.SetPhaseConditions()
+	assert.Equal(t, corev1.ConditionFalse, status.GetCondition(IntegrationConditionDegraded).Status)
+}
@@ -33,21 +33,39 @@ type IntegrationKitSpec struct {
 	Traits        map[string]TraitSpec `json:"traits,omitempty"`
 	Configuration []ConfigurationSpec  `json:"configuration,omitempty"`
 	Repositories  []string             `json:"repositories,omitempty"`
+	// The dependencies, in the form mvn:groupId:artifactId:version, whose versions are pinned in the build
+	LockedDependencies []string `json:"lockedDependencies,omitempty"`
 }
 
 // IntegrationKitStatus defines the observed state of IntegrationKit
 type IntegrationKitStatus struct {
-	Phase           IntegrationKitPhase       `json:"phase,omitempty"`
-	BaseImage       string                    `json:"baseImage,omitempty"`
-	Image           string                    `json:"image,omitempty"`
-	Digest          string                    `json:"digest,omitempty"`
-	Artifacts       []Artifact                `json:"artifacts,omitempty"`
-	Failure         *Failure                  `json:"failure,omitempty"`
-	RuntimeVersion  string                    `json:"runtimeVersion,omitempty"`
-	RuntimeProvider RuntimeProvider           `json:"runtimeProvider,omitempty"`
-	Platform        string                    `json:"platform,omitempty"`
-	Conditions      []IntegrationKitCondition `json:"conditions,omitempty"`
-	Version         string                    `json:"version,omitempty"`
+	// ObservedGeneration is the most recent generation observed for this IntegrationKit
+	ObservedGeneration int64                     `json:"observedGeneration,omitempty"`
+	Phase              IntegrationKitPhase       `json:"phase,omitempty"`
+	BaseImage          string                    `json:"baseImage,omitempty"`
+	Image              string                    `json:"image,omitempty"`
+	Digest             string                    `json:"digest,omitempty"`
+	Artifacts          []Artifact                `json:"artifacts,omitempty"`
+	Failure            *Failure                  `json:"failure,omitempty"`
+	RuntimeVersion     string                    `json:"runtimeVersion,omitempty"`
+	RuntimeProvider    RuntimeProvider           `json:"runtimeProvider,omitempty"`
+	Platform           string                    `json:"platform,omitempty"`
+	Conditions         []IntegrationKitCondition `json:"conditions,omitempty"`
+	Version            string                    `json:"version,omitempty"`
+	// The architectures the kit image is available for, e.g., `amd64` or `arm64`
+	Architectures []string `json:"architectures,omitempty"`
+	// The provenance attestation of the kit image, recorded when the image is built
+	Provenance *IntegrationKitProvenance `json:"provenance,omitempty"`
+}
+
+// IntegrationKitProvenance references the in-toto SLSA provenance attestation of the kit image
+type IntegrationKitProvenance struct {
+	// The ConfigMap the attestation is stored into, in the namespace of the kit
+	ConfigMap string `json:"configMap,omitempty"`
+	// The reference the attestation is published to, alongside the image, when the image is pushed to a registry
+	Image string `json:"image,omitempty"`
+	// The digest of the attestation
+	Digest string `json:"digest,omitempty"`
 }
 
 // +genclient
@@ -96,6 +114,8 @@ const (
 	IntegrationKitTypeUser = "user"
 	// IntegrationKitTypeExternal --
 	IntegrationKitTypeExternal = "external"
+	// IntegrationKitTypeBase labels the base kit of a platform, that the images of the platform kits are layered on
+	IntegrationKitTypeBase = "base"
 
 	// IntegrationKitLayoutLabel labels the kit layout
 	IntegrationKitLayoutLabel = "camel.apache.org/kit.layout"
@@ -104,6 +124,8 @@ const (
 	IntegrationKitLayoutFastJar = "fast-jar"
 	// IntegrationKitLayoutNative labels a kit using the Quarkus native packaging
 	IntegrationKitLayoutNative = "native"
+	// IntegrationKitLayoutMulti labels a kit using both the Quarkus fast-jar and native packagings
+	IntegrationKitLayoutMulti = "multi"
 
 	// IntegrationKitPriorityLabel labels the kit priority
 	IntegrationKitPriorityLabel = "camel.apache.org/kit.priority"
@@ -127,6 +149,18 @@ const (
 	IntegrationKitConditionPlatformAvailable IntegrationKitConditionType = "IntegrationPlatformAvailable"
 	// IntegrationKitConditionPlatformAvailableReason --
 	IntegrationKitConditionPlatformAvailableReason string = "IntegrationPlatformAvailable"
+	// IntegrationKitConditionReady --
+	IntegrationKitConditionReady IntegrationKitConditionType = "Ready"
+	// IntegrationKitConditionProgressing --
+	IntegrationKitConditionProgressing IntegrationKitConditionType = "Progressing"
+	// IntegrationKitConditionDegraded --
+	IntegrationKitConditionDegraded IntegrationKitConditionType = "Degraded"
+	// IntegrationKitConditionProvenancePublished reports whether the provenance attestation is published alongside the image
+	IntegrationKitConditionProvenancePublished IntegrationKitConditionType = "ProvenancePublished"
+	// IntegrationKitConditionProvenancePublishedReason --
+	IntegrationKitConditionProvenancePublishedReason string = "ProvenancePublished"
+	// IntegrationKitConditionProvenanceNotPublishedReason --
+	IntegrationKitConditionProvenanceNotPublishedReason string = "ProvenanceNotPublished"
 )
 
 // IntegrationKitCondition describes the state of a resource at a certain point.
@@ -155,6 +155,20 @@ func (in *IntegrationKitStatus) SetConditions(conditions ...IntegrationKitCondit
 	}
 }
 
+// SetPhaseConditions sets the Ready, Progressing and Degraded conditions according to the phase of the kit
+func (in *IntegrationKitStatus) SetPhaseConditions() {
+	reason := PhaseConditionReason(string(in.Phase))
+	in.SetCondition(IntegrationKitConditionReady, conditionStatus(in.Phase == IntegrationKitPhaseReady), reason, "")
+	in.SetCondition(IntegrationKitConditionProgressing,
+		conditionStatus(in.Phase != IntegrationKitPhaseReady && in.Phase != IntegrationKitPhaseError), reason, "")
+
+	message := ""
+	if in.Phase == IntegrationKitPhaseError && in.Failure != nil {
+		message = in.Failure.Reason
+	}
+	in.SetCondition(IntegrationKitConditionDegraded, conditionStatus(in.Phase == IntegrationKitPhaseError), reason, message)
+}
+
 // RemoveCondition removes the resource condition with the provided type.
 func (in *IntegrationKitStatus) RemoveCondition(condType IntegrationKitConditionType) {
 	newConditions := in.Conditions[:0]
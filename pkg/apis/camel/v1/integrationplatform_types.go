@@ -34,6 +34,28 @@ type IntegrationPlatformSpec struct {
 	Traits        map[string]TraitSpec             `json:"traits,omitempty"`
 	Configuration []ConfigurationSpec              `json:"configuration,omitempty"`
 	Kamelet       IntegrationPlatformKameletSpec   `json:"kamelet,omitempty"`
+	// Policy defines the constraints the Integrations of the platform must comply with
+	Policy IntegrationPlatformPolicySpec `json:"policy,omitempty"`
+	// Quota limits the number of Integrations and builds, and the resources requested by the Integrations,
+	// in each namespace of the platform
+	Quota IntegrationPlatformQuotaSpec `json:"quota,omitempty"`
+	// CostAllocation stamps the resources generated for the Integrations with cost-allocation labels
+	CostAllocation IntegrationPlatformCostAllocationSpec `json:"costAllocation,omitempty"`
+	// FIPS enables the FIPS mode, that builds the Integrations from a FIPS-validated base image, with
+	// FIPS-validated crypto providers, and refuses the components that are not FIPS compliant
+	FIPS bool `json:"fips,omitempty"`
+	// Upgrade configures how the Integrations built by a former version of the operator are upgraded
+	Upgrade IntegrationPlatformUpgradeSpec `json:"upgrade,omitempty"`
+	// Hibernation configures the scaling to zero of the Integrations that are idle
+	Hibernation IntegrationPlatformHibernationSpec `json:"hibernation,omitempty"`
+	// Digest configures the computation of the digest of the Integrations, whose changes trigger their rebuild
+	// and redeployment
+	Digest IntegrationPlatformDigestSpec `json:"digest,omitempty"`
+	// Maintenance configures the maintenance window the redeploys of the Integrations that are not initiated by
+	// a change of their specification are deferred to
+	Maintenance IntegrationPlatformMaintenanceSpec `json:"maintenance,omitempty"`
+	// OAuth2 configures the acquisition of the OAuth2 access tokens by the operator
+	OAuth2 IntegrationPlatformOAuth2Spec `json:"oauth2,omitempty"`
 }
 
 // IntegrationPlatformResourcesSpec contains platform related resources
@@ -44,9 +66,11 @@ type IntegrationPlatformResourcesSpec struct {
 type IntegrationPlatformStatus struct {
 	IntegrationPlatformSpec `json:",inline"`
 
-	Phase      IntegrationPlatformPhase       `json:"phase,omitempty"`
-	Conditions []IntegrationPlatformCondition `json:"conditions,omitempty"`
-	Version    string                         `json:"version,omitempty"`
+	// ObservedGeneration is the most recent generation observed for this IntegrationPlatform
+	ObservedGeneration int64                          `json:"observedGeneration,omitempty"`
+	Phase              IntegrationPlatformPhase       `json:"phase,omitempty"`
+	Conditions         []IntegrationPlatformCondition `json:"conditions,omitempty"`
+	Version            string                         `json:"version,omitempty"`
 }
 
 // +genclient
@@ -117,6 +141,74 @@ type IntegrationPlatformBuildSpec struct {
 	Maven                 MavenSpec                               `json:"maven,omitempty"`
 	HTTPProxySecret       string                                  `json:"httpProxySecret,omitempty"`
 	KanikoBuildCache      *bool                                   `json:"kanikoBuildCache,omitempty"`
+	// The Linux architectures the IntegrationKit images are built for by default, among `amd64`, `arm64`, `ppc64le` and `s390x`.
+	// A multi-architecture image is built when several are declared.
+	Architectures []string `json:"architectures,omitempty"`
+	// Tekton configures the PipelineRun the images are built with, when using the Tekton publish strategy
+	Tekton *IntegrationPlatformTektonSpec `json:"tekton,omitempty"`
+	// Local configures the container engine the images are built with, when using the Local publish strategy
+	Local *IntegrationPlatformLocalSpec `json:"local,omitempty"`
+
+	// BaseKit configures the base kit, built once for each runtime version, that the images of the
+	// IntegrationKits are layered on, so that they only add the dependencies of the Integrations
+	BaseKit *IntegrationPlatformBaseKitSpec `json:"baseKit,omitempty"`
+	// BuilderImage is a custom image, that provides the Maven and JDK toolchain, and the CA certificates,
+	// of the organization, the Maven builds are run in, instead of the operator image and its bundled Maven.
+	// It defaults the build strategy to `pod`.
+	BuilderImage string `json:"builderImage,omitempty"`
+}
+
+// IntegrationPlatformBaseKitSpec configures the base kit, that contains the runtime and the dependencies used by most
+// of the Integrations of the platform
+type IntegrationPlatformBaseKitSpec struct {
+	// Dependencies are the dependencies added to the base kit, on top of the runtime ones, e.g., `camel:http`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// IntegrationPlatformLocalSpec configures the container engine of the node the images are built with, so that they are
+// directly available to the node container runtime, without being pushed to a registry
+type IntegrationPlatformLocalSpec struct {
+	// Engine is the container engine the images are built with, one of `docker`, `podman` or `buildkit`, `docker` by default
+	Engine IntegrationPlatformLocalEngine `json:"engine,omitempty"`
+	// Address is the address of the container engine daemon, either a Unix socket of the node, e.g. `unix:///var/run/docker.sock`,
+	// that's mounted into the build pod, or a TCP address, e.g. `tcp://buildkitd:1234`. It defaults to the engine standard socket.
+	Address string `json:"address,omitempty"`
+	// ClientImage is the image of the container engine client, the images are built with
+	ClientImage string `json:"clientImage,omitempty"`
+}
+
+// IntegrationPlatformLocalEngine enumerates the container engines supported by the Local publish strategy
+type IntegrationPlatformLocalEngine string
+
+const (
+	// IntegrationPlatformLocalEngineDocker builds the images with the Docker daemon of the node
+	IntegrationPlatformLocalEngineDocker IntegrationPlatformLocalEngine = "docker"
+	// IntegrationPlatformLocalEnginePodman builds the images with the Podman service of the node
+	IntegrationPlatformLocalEnginePodman IntegrationPlatformLocalEngine = "podman"
+	// IntegrationPlatformLocalEngineBuildkit builds the images with a BuildKit daemon, backed by the containerd runtime of the node
+	IntegrationPlatformLocalEngineBuildkit IntegrationPlatformLocalEngine = "buildkit"
+)
+
+// IntegrationPlatformLocalEngines --
+var IntegrationPlatformLocalEngines = []IntegrationPlatformLocalEngine{
+	IntegrationPlatformLocalEngineDocker,
+	IntegrationPlatformLocalEnginePodman,
+	IntegrationPlatformLocalEngineBuildkit,
+}
+
+// IntegrationPlatformTektonSpec configures the Tekton PipelineRun the image builds are delegated to
+type IntegrationPlatformTektonSpec struct {
+	// PipelineRunTemplate is the PipelineRun created for each build, usually referencing the Pipeline that builds
+	// and pushes the image. The `IMAGE`, `BASE_IMAGE` and `CONTEXT_DIR` parameters, and the workspace holding
+	// the generated Maven project, are added to it.
+	PipelineRunTemplate *PipelineRunTemplate `json:"pipelineRunTemplate,omitempty"`
+	// Workspace is the name of the Pipeline workspace the generated Maven project is bound to, `source` by default
+	Workspace string `json:"workspace,omitempty"`
+}
+
+// PipelineRunTemplate is an unstructured Tekton PipelineRun
+type PipelineRunTemplate struct {
+	RawMessage `json:",inline"`
 }
 
 // IntegrationPlatformRegistrySpec --
@@ -126,11 +218,37 @@ type IntegrationPlatformRegistrySpec struct {
 	Secret       string `json:"secret,omitempty"`
 	CA           string `json:"ca,omitempty"`
 	Organization string `json:"organization,omitempty"`
+	// CredentialsProvider is the provider of the short-lived credentials, that are periodically refreshed into the registry secret
+	CredentialsProvider RegistryCredentialsProvider `json:"credentialsProvider,omitempty"`
+}
+
+// RegistryCredentialsProvider enumerates the providers of short-lived registry credentials
+type RegistryCredentialsProvider string
+
+const (
+	// RegistryCredentialsProviderECR retrieves the Amazon ECR authorization tokens, that are valid for 12 hours
+	RegistryCredentialsProviderECR RegistryCredentialsProvider = "ECR"
+	// RegistryCredentialsProviderGCR retrieves the Google Cloud access tokens, from the workload identity of the operator
+	RegistryCredentialsProviderGCR RegistryCredentialsProvider = "GCR"
+	// RegistryCredentialsProviderACR retrieves the Azure Container Registry refresh tokens, from the workload identity of the operator
+	RegistryCredentialsProviderACR RegistryCredentialsProvider = "ACR"
+	// RegistryCredentialsProviderIBM retrieves the IBM Cloud IAM access tokens, from the API key of the operator
+	RegistryCredentialsProviderIBM RegistryCredentialsProvider = "IBM"
+)
+
+// RegistryCredentialsProviders --
+var RegistryCredentialsProviders = []RegistryCredentialsProvider{
+	RegistryCredentialsProviderECR,
+	RegistryCredentialsProviderGCR,
+	RegistryCredentialsProviderACR,
+	RegistryCredentialsProviderIBM,
 }
 
 // IntegrationPlatformKameletSpec --
 type IntegrationPlatformKameletSpec struct {
 	Repositories []IntegrationPlatformKameletRepositorySpec `json:"repositories,omitempty"`
+	// Bundles are the Kamelet bundles, packaged as OCI artifacts, that are installed into the platform namespace
+	Bundles []IntegrationPlatformKameletBundleSpec `json:"bundles,omitempty"`
 }
 
 // IntegrationPlatformKameletRepositorySpec --
@@ -138,6 +256,150 @@ type IntegrationPlatformKameletRepositorySpec struct {
 	URI string `json:"uri,omitempty"`
 }
 
+// IntegrationPlatformKameletBundleSpec references a Kamelet bundle packaged as an OCI artifact
+type IntegrationPlatformKameletBundleSpec struct {
+	// Image is the reference of the bundle, e.g., `quay.io/acme/kamelets:1.0.0`
+	Image string `json:"image,omitempty"`
+	// Insecure allows pulling the bundle from a registry served over plain HTTP, or with a self-signed certificate
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// IntegrationPlatformPolicySpec defines the constraints enforced on the Integrations by the platform
+type IntegrationPlatformPolicySpec struct {
+	// ForbiddenComponents lists the Camel components the Integrations must not use, either by scheme
+	// or by artifact, e.g., `exec` or `camel-exec`
+	ForbiddenComponents []string `json:"forbiddenComponents,omitempty"`
+	// AllowedComponents restricts the Camel components the Integrations can use, either by scheme
+	// or by artifact, e.g., `timer` or `camel-timer`
+	AllowedComponents []string `json:"allowedComponents,omitempty"`
+	// AllowedLanguages restricts the languages the Integrations can use, i.e., the languages of their sources,
+	// e.g., `yaml`, and the Camel expression languages, e.g., `jsonpath`. The Camel core languages, like `simple`,
+	// are always allowed.
+	AllowedLanguages []string `json:"allowedLanguages,omitempty"`
+	// ForbiddenLanguages lists the languages the Integrations must not use, either the languages of their sources,
+	// e.g., `groovy`, or the Camel expression languages, e.g., `ognl`
+	ForbiddenLanguages []string `json:"forbiddenLanguages,omitempty"`
+	// AllowedKamelets restricts the Kamelets the Integrations can reference
+	AllowedKamelets []string `json:"allowedKamelets,omitempty"`
+	// ForbiddenKamelets lists the Kamelets the Integrations must not reference
+	ForbiddenKamelets []string `json:"forbiddenKamelets,omitempty"`
+	// RequireResourceLimits requires the Integrations to set the CPU and memory limits of their container
+	RequireResourceLimits bool `json:"requireResourceLimits,omitempty"`
+	// AllowedRegistries restricts the registries, optionally followed by a repository path prefix,
+	// the container images of the Integrations can be pulled from
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+	// MaxReplicas caps the number of replicas of the Integrations
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// RestrictedTraits lists the traits, or the trait properties as `<trait>.<property>`, that only the users
+	// authorized to configure them can set on the Integrations
+	RestrictedTraits []string `json:"restrictedTraits,omitempty"`
+	// Rules are custom expressions evaluated against the Integrations, that must hold true
+	Rules []IntegrationPlatformPolicyRule `json:"rules,omitempty"`
+}
+
+// IntegrationPlatformPolicyRule is a custom policy rule, expressed in a policy language
+type IntegrationPlatformPolicyRule struct {
+	// Name identifies the rule in the violations
+	Name string `json:"name"`
+	// Language is the language of the expression
+	Language PolicyRuleLanguage `json:"language,omitempty"`
+	// Expression is evaluated with the Integration bound to the `integration` variable,
+	// and must evaluate to true for the Integration to comply with the rule
+	Expression string `json:"expression"`
+	// Message is reported when the Integration does not comply with the rule
+	Message string `json:"message,omitempty"`
+}
+
+// PolicyRuleLanguage enumerates the languages of the policy rules
+type PolicyRuleLanguage string
+
+const (
+	// PolicyRuleLanguageCEL is the Common Expression Language
+	PolicyRuleLanguageCEL PolicyRuleLanguage = "CEL"
+	// PolicyRuleLanguageRego is the Open Policy Agent policy language
+	PolicyRuleLanguageRego PolicyRuleLanguage = "Rego"
+)
+
+// PolicyRuleLanguages --
+var PolicyRuleLanguages = []PolicyRuleLanguage{
+	PolicyRuleLanguageCEL,
+	PolicyRuleLanguageRego,
+}
+
+// IntegrationPlatformQuotaSpec defines the quotas of each namespace of the platform
+type IntegrationPlatformQuotaSpec struct {
+	// MaxIntegrations caps the number of Integrations of the namespace
+	MaxIntegrations *int32 `json:"maxIntegrations,omitempty"`
+	// MaxRunningBuilds caps the number of builds running concurrently in the namespace,
+	// the other ones waiting to be scheduled
+	MaxRunningBuilds *int32 `json:"maxRunningBuilds,omitempty"`
+	// RequestsCPU caps the total CPU requested by the Integration pods of the namespace, e.g., `4` or `4000m`
+	RequestsCPU string `json:"requestsCPU,omitempty"`
+	// RequestsMemory caps the total memory requested by the Integration pods of the namespace, e.g., `8Gi`
+	RequestsMemory string `json:"requestsMemory,omitempty"`
+}
+
+// IntegrationPlatformCostAllocationSpec defines the labels the cost of the Integrations is attributed with
+type IntegrationPlatformCostAllocationSpec struct {
+	// Enabled stamps the resources generated for the Integrations with the cost-allocation labels
+	Enabled bool `json:"enabled,omitempty"`
+	// Labels maps the cost-allocation labels to the Integration annotations their values are taken from.
+	// It defaults to the `team`, `env` and `app` labels, taken from the `camel.apache.org/team`, `camel.apache.org/env`
+	// and `camel.apache.org/app` annotations, the `app` label defaulting to the Integration name.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IntegrationPlatformDigestSpec configures the computation of the digest of the Integrations
+type IntegrationPlatformDigestSpec struct {
+	// ExcludedTraits lists the traits, or the trait properties as `<trait>.<property>`, whose configuration is excluded
+	// from the digest, either set in the spec or with annotations, so that changing them, e.g., by other controllers,
+	// does not rebuild nor redeploy the Integrations
+	ExcludedTraits []string `json:"excludedTraits,omitempty"`
+}
+
+// IntegrationPlatformUpgradeSpec configures the upgrade of the Integrations built by a former version of the operator
+type IntegrationPlatformUpgradeSpec struct {
+	// AutoUpgrade rebuilds the Integrations of the platform with the current version of the operator, as long as they are
+	// compatible with its catalog. It can be overridden for each Integration with the `camel.auto-upgrade` trait property.
+	AutoUpgrade bool `json:"autoUpgrade,omitempty"`
+	// MaxConcurrent is the maximum number of Integrations that are upgraded at the same time, the next ones being
+	// upgraded once the previous ones are running. It defaults to 3.
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+}
+
+// IntegrationPlatformMaintenanceSpec configures the maintenance window of the Integrations. The redeploys that are not
+// initiated by a change of their specification, i.e., the switch to a kit of higher priority, and the upgrade to the
+// current version of the operator and its catalog, are deferred to the window, while the changes to their specification
+// are still rolled out immediately.
+type IntegrationPlatformMaintenanceSpec struct {
+	// Window is the cron expression of the start of the maintenance window, in UTC unless prefixed with `CRON_TZ=<zone>`,
+	// e.g., `0 2 * * 6` for every Saturday at 2am. The redeploys are not deferred when it is not set. It can be overridden
+	// for each Integration with the `camel.apache.org/maintenance-window` annotation.
+	Window string `json:"window,omitempty"`
+	// Duration is the duration of the maintenance window. It defaults to 1 hour.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// IntegrationPlatformOAuth2Spec configures the acquisition of the OAuth2 access tokens by the operator
+type IntegrationPlatformOAuth2Spec struct {
+	// AllowedTokenURLs lists the prefixes of the `https` token URLs that can be set with the `token-url` key of the
+	// OAuth2 client Secrets, e.g., `https://login.acme.com/oauth2/`. The operator only requests the token URLs of the
+	// well-known providers otherwise.
+	AllowedTokenURLs []string `json:"allowedTokenURLs,omitempty"`
+}
+
+// IntegrationPlatformHibernationSpec configures the hibernation of the Integrations, that are scaled to zero once they
+// have not processed any exchange for a period of time, according to the metrics scraped by Prometheus
+type IntegrationPlatformHibernationSpec struct {
+	// Enabled scales the idle Integrations of the platform to zero. It can be disabled for each Integration,
+	// by setting the `camel.apache.org/hibernation` annotation to `false`.
+	Enabled bool `json:"enabled,omitempty"`
+	// PrometheusURL is the URL of the Prometheus server the exchange metrics of the Integrations are queried from
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+	// IdlePeriod is the period without any exchange after which an Integration is scaled to zero. It defaults to 30 minutes.
+	IdlePeriod *metav1.Duration `json:"idlePeriod,omitempty"`
+}
+
 // IntegrationPlatformBuildStrategy enumerates all implemented build strategies
 type IntegrationPlatformBuildStrategy string
 
@@ -166,6 +428,10 @@ const (
 	IntegrationPlatformBuildPublishStrategyS2I IntegrationPlatformBuildPublishStrategy = "S2I"
 	// IntegrationPlatformBuildPublishStrategySpectrum --
 	IntegrationPlatformBuildPublishStrategySpectrum IntegrationPlatformBuildPublishStrategy = "Spectrum"
+	// IntegrationPlatformBuildPublishStrategyTekton delegates the image build to a Tekton PipelineRun
+	IntegrationPlatformBuildPublishStrategyTekton IntegrationPlatformBuildPublishStrategy = "Tekton"
+	// IntegrationPlatformBuildPublishStrategyLocal builds the images with the container engine of the node, without pushing them to a registry
+	IntegrationPlatformBuildPublishStrategyLocal IntegrationPlatformBuildPublishStrategy = "Local"
 )
 
 // IntegrationPlatformBuildPublishStrategies --
@@ -174,6 +440,8 @@ var IntegrationPlatformBuildPublishStrategies = []IntegrationPlatformBuildPublis
 	IntegrationPlatformBuildPublishStrategyKaniko,
 	IntegrationPlatformBuildPublishStrategyS2I,
 	IntegrationPlatformBuildPublishStrategySpectrum,
+	IntegrationPlatformBuildPublishStrategyTekton,
+	IntegrationPlatformBuildPublishStrategyLocal,
 }
 
 // IntegrationPlatformPhase --
@@ -198,6 +466,13 @@ const (
 	IntegrationPlatformPhaseError IntegrationPlatformPhase = "Error"
 	// IntegrationPlatformPhaseDuplicate --
 	IntegrationPlatformPhaseDuplicate IntegrationPlatformPhase = "Duplicate"
+
+	// IntegrationPlatformConditionReady --
+	IntegrationPlatformConditionReady IntegrationPlatformConditionType = "Ready"
+	// IntegrationPlatformConditionProgressing --
+	IntegrationPlatformConditionProgressing IntegrationPlatformConditionType = "Progressing"
+	// IntegrationPlatformConditionDegraded --
+	IntegrationPlatformConditionDegraded IntegrationPlatformConditionType = "Degraded"
 )
 
 // IntegrationPlatformCondition describes the state of a resource at a certain point.
@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // NewIntegrationPlatformList --
@@ -58,6 +59,16 @@ func TraitProfileByName(name string) TraitProfile {
 	return ""
 }
 
+// IntegrationPlatformLocalEngineSupported returns whether the container engine is supported by the Local publish strategy
+func IntegrationPlatformLocalEngineSupported(engine IntegrationPlatformLocalEngine) bool {
+	for _, e := range IntegrationPlatformLocalEngines {
+		if e == engine {
+			return true
+		}
+	}
+	return false
+}
+
 // Configurations --
 func (in *IntegrationPlatformSpec) Configurations() []ConfigurationSpec {
 	if in == nil {
@@ -166,6 +177,16 @@ func (in *IntegrationPlatformStatus) SetConditions(conditions ...IntegrationPlat
 	}
 }
 
+// SetPhaseConditions sets the Ready, Progressing and Degraded conditions according to the phase of the platform
+func (in *IntegrationPlatformStatus) SetPhaseConditions() {
+	reason := PhaseConditionReason(string(in.Phase))
+	degraded := in.Phase == IntegrationPlatformPhaseError || in.Phase == IntegrationPlatformPhaseDuplicate
+	in.SetCondition(IntegrationPlatformConditionReady, conditionStatus(in.Phase == IntegrationPlatformPhaseReady), reason, "")
+	in.SetCondition(IntegrationPlatformConditionProgressing,
+		conditionStatus(in.Phase != IntegrationPlatformPhaseReady && !degraded), reason, "")
+	in.SetCondition(IntegrationPlatformConditionDegraded, conditionStatus(degraded), reason, "")
+}
+
 // RemoveCondition removes the resource condition with the provided type.
 func (in *IntegrationPlatformStatus) RemoveCondition(condType IntegrationPlatformConditionType) {
 	newConditions := in.Conditions[:0]
@@ -195,6 +216,49 @@ func (b IntegrationPlatformBuildSpec) GetTimeout() metav1.Duration {
 	return *b.Timeout
 }
 
+// IsEmpty returns whether the policy does not define any constraint
+func (p IntegrationPlatformPolicySpec) IsEmpty() bool {
+	return len(p.ForbiddenComponents) == 0 && len(p.AllowedComponents) == 0 && len(p.AllowedLanguages) == 0 &&
+		len(p.ForbiddenLanguages) == 0 && len(p.AllowedKamelets) == 0 && len(p.ForbiddenKamelets) == 0 && !p.RequireResourceLimits && len(p.AllowedRegistries) == 0 &&
+		p.MaxReplicas == nil && len(p.RestrictedTraits) == 0 && len(p.Rules) == 0
+}
+
+// IsEmpty returns whether the quota does not limit anything
+func (q IntegrationPlatformQuotaSpec) IsEmpty() bool {
+	return q.MaxIntegrations == nil && q.MaxRunningBuilds == nil && q.RequestsCPU == "" && q.RequestsMemory == ""
+}
+
+// CostAllocationAppLabel is the cost-allocation label that defaults to the Integration name
+const CostAllocationAppLabel = "app"
+
+// GetLabels returns the cost-allocation labels, keyed by the Integration annotations their values are taken from
+func (c IntegrationPlatformCostAllocationSpec) GetLabels() map[string]string {
+	if len(c.Labels) > 0 {
+		return c.Labels
+	}
+	return map[string]string{
+		"team":                 "camel.apache.org/team",
+		"env":                  "camel.apache.org/env",
+		CostAllocationAppLabel: "camel.apache.org/app",
+	}
+}
+
+// LabelsFor returns the cost-allocation labels of the given Integration. The annotations whose values
+// are not valid label values are ignored.
+func (c IntegrationPlatformCostAllocationSpec) LabelsFor(it *Integration) map[string]string {
+	labels := make(map[string]string)
+	for label, annotation := range c.GetLabels() {
+		value := it.Annotations[annotation]
+		if value == "" && label == CostAllocationAppLabel {
+			value = it.Name
+		}
+		if value != "" && len(validation.IsValidLabelValue(value)) == 0 {
+			labels[label] = value
+		}
+	}
+	return labels
+}
+
 var _ ResourceCondition = IntegrationPlatformCondition{}
 
 // GetConditions --
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+// Important: Run "make generate-deepcopy" to regenerate code after modifying this file
+
+// IntegrationTemplateSpec defines the integrations instantiated from the template
+type IntegrationTemplateSpec struct {
+	// Parameters are the parameters the template is instantiated with
+	Parameters []IntegrationTemplateParameter `json:"parameters,omitempty"`
+	// Integration is the spec of the instances, where the `$(params.<name>)` references
+	// are substituted with the values of the parameters
+	Integration IntegrationSpec `json:"integration,omitempty"`
+	// RollOut updates the instances when the template changes
+	RollOut bool `json:"rollOut,omitempty"`
+}
+
+// IntegrationTemplateParameter is a parameter of an integration template
+type IntegrationTemplateParameter struct {
+	// Name is the name the parameter is referenced by
+	Name string `json:"name"`
+	// Description is a human readable description of the parameter
+	Description string `json:"description,omitempty"`
+	// Default is the value of the parameter when it's not provided, the parameter is required when not set
+	Default *string `json:"default,omitempty"`
+}
+
+// IntegrationTemplateStatus defines the observed state of IntegrationTemplate
+type IntegrationTemplateStatus struct {
+	// ObservedGeneration is the most recent generation observed for this IntegrationTemplate
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Instances is the number of integrations instantiated from the template
+	Instances int32 `json:"instances,omitempty"`
+	// UpdatedInstances is the number of instances up to date with the template
+	UpdatedInstances int32 `json:"updatedInstances,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=integrationtemplates,scope=Namespaced,shortName=itt,categories=kamel;camel
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Roll Out",type=boolean,JSONPath=`.spec.rollOut`,description="Whether the instances follow the template updates"
+// +kubebuilder:printcolumn:name="Instances",type=integer,JSONPath=`.status.instances`,description="The number of instances"
+// +kubebuilder:printcolumn:name="Updated",type=integer,JSONPath=`.status.updatedInstances`,description="The number of instances up to date with the template"
+
+// IntegrationTemplate is the Schema for the integrationtemplates API
+type IntegrationTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IntegrationTemplateSpec   `json:"spec,omitempty"`
+	Status IntegrationTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IntegrationTemplateList contains a list of IntegrationTemplate
+type IntegrationTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IntegrationTemplate `json:"items"`
+}
+
+const (
+	// IntegrationTemplateKind --
+	IntegrationTemplateKind string = "IntegrationTemplate"
+
+	// IntegrationTemplateLabel is set on the integrations instantiated from a template, to the name of the template
+	IntegrationTemplateLabel = "camel.apache.org/integration-template"
+	// IntegrationTemplateParametersAnnotation holds the parameter values an integration has been instantiated with, as JSON
+	IntegrationTemplateParametersAnnotation = "camel.apache.org/integration-template.parameters"
+	// IntegrationTemplateGenerationAnnotation holds the generation of the template an integration has been instantiated from
+	IntegrationTemplateGenerationAnnotation = "camel.apache.org/integration-template.generation"
+	// IntegrationTemplateSpecDigestAnnotation holds the SHA-256 digest of the spec rendered from the template an integration
+	// has been instantiated from. The spec itself is stored, as JSON, in the IntegrationTemplateSpecKey key of the ConfigMap
+	// named by IntegrationTemplateSpecConfigMapName, as it may not fit in the size limit of the annotations, so that the
+	// changes made to the integration since then are kept when the template updates are rolled out.
+	IntegrationTemplateSpecDigestAnnotation = "camel.apache.org/integration-template.spec-digest"
+	// IntegrationTemplateSpecKey is the key of the ConfigMap the rendered spec of an integration is stored in
+	IntegrationTemplateSpecKey = "spec.json"
+)
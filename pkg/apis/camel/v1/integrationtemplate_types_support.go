@@ -0,0 +1,152 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// templateParameterRegexp matches the references to the parameters in an integration template
+var templateParameterRegexp = regexp.MustCompile(`\$\(params\.([A-Za-z0-9_.-]+)\)`)
+
+func NewIntegrationTemplate(namespace string, name string) IntegrationTemplate {
+	return IntegrationTemplate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       IntegrationTemplateKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+}
+
+func NewIntegrationTemplateList() IntegrationTemplateList {
+	return IntegrationTemplateList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       IntegrationTemplateKind,
+		},
+	}
+}
+
+// ResolveParameters returns the values of all the parameters of the template, the given values taking
+// precedence over the defaults
+func (in *IntegrationTemplate) ResolveParameters(values map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(in.Spec.Parameters))
+	for _, p := range in.Spec.Parameters {
+		if v, ok := values[p.Name]; ok {
+			resolved[p.Name] = v
+		} else if p.Default != nil {
+			resolved[p.Name] = *p.Default
+		} else {
+			return nil, fmt.Errorf("parameter %s of integration template %s is required", p.Name, in.Name)
+		}
+	}
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			return nil, fmt.Errorf("integration template %s has no parameter %s", in.Name, name)
+		}
+	}
+	return resolved, nil
+}
+
+// Instantiate returns the integration with the given name instantiated from the template, where the references
+// to the parameters are substituted with their values
+func (in *IntegrationTemplate) Instantiate(name string, values map[string]string) (*Integration, error) {
+	resolved, err := in.ResolveParameters(values)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(in.Spec.Integration)
+	if err != nil {
+		return nil, err
+	}
+	var unknown []string
+	data = templateParameterRegexp.ReplaceAllFunc(data, func(ref []byte) []byte {
+		parameter := string(templateParameterRegexp.FindSubmatch(ref)[1])
+		v, ok := resolved[parameter]
+		if !ok {
+			unknown = append(unknown, parameter)
+			return ref
+		}
+		// the reference is within a JSON string
+		quoted, _ := json.Marshal(v)
+		return quoted[1 : len(quoted)-1]
+	})
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("integration template %s references undeclared parameters %v", in.Name, unknown)
+	}
+
+	it := NewIntegration(in.Namespace, name)
+	if err := json.Unmarshal(data, &it.Spec); err != nil {
+		return nil, err
+	}
+
+	if values == nil {
+		values = make(map[string]string)
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := json.Marshal(it.Spec)
+	if err != nil {
+		return nil, err
+	}
+	it.Labels = map[string]string{
+		IntegrationTemplateLabel: in.Name,
+	}
+	it.Annotations = map[string]string{
+		IntegrationTemplateParametersAnnotation: string(encoded),
+		IntegrationTemplateGenerationAnnotation: strconv.FormatInt(in.Generation, 10),
+		IntegrationTemplateSpecDigestAnnotation: IntegrationTemplateSpecDigest(rendered),
+	}
+	return &it, nil
+}
+
+// IntegrationTemplateSpecConfigMapName returns the name of the ConfigMap the spec rendered from the template
+// the given integration has been instantiated from is stored in
+func IntegrationTemplateSpecConfigMapName(integration string) string {
+	return integration + "-template-spec"
+}
+
+// IntegrationTemplateSpecDigest returns the digest of the given rendered spec, as set in the
+// IntegrationTemplateSpecDigestAnnotation annotation
+func IntegrationTemplateSpecDigest(rendered []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(rendered))
+}
+
+// GetTemplateParameters returns the parameter values the integration has been instantiated with
+func (in *Integration) GetTemplateParameters() (map[string]string, error) {
+	values := make(map[string]string)
+	if data, ok := in.Annotations[IntegrationTemplateParametersAnnotation]; ok {
+		if err := json.Unmarshal([]byte(data), &values); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation on integration %s: %w", IntegrationTemplateParametersAnnotation, in.Name, err)
+		}
+	}
+	return values, nil
+}
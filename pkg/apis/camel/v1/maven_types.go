@@ -26,6 +26,14 @@ type Repository struct {
 	Releases  RepositoryPolicy `xml:"releases,omitempty" json:"releases,omitempty"`
 }
 
+// Mirror --
+type Mirror struct {
+	ID       string `xml:"id" json:"id"`
+	Name     string `xml:"name,omitempty" json:"name,omitempty"`
+	URL      string `xml:"url" json:"url"`
+	MirrorOf string `xml:"mirrorOf" json:"mirrorOf"`
+}
+
 // RepositoryPolicy --
 type RepositoryPolicy struct {
 	Enabled        bool   `xml:"enabled" json:"enabled"`
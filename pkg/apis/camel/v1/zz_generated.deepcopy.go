@@ -163,6 +163,21 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Maven != nil {
+		in, out := &in.Maven, &out.Maven
+		*out = new(MavenBuildStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = new(BuildTestsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildStatus.
@@ -175,6 +190,26 @@ func (in *BuildStatus) DeepCopy() *BuildStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildTestsStatus) DeepCopyInto(out *BuildTestsStatus) {
+	*out = *in
+	if in.Reports != nil {
+		in, out := &in.Reports, &out.Reports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildTestsStatus.
+func (in *BuildTestsStatus) DeepCopy() *BuildTestsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildTestsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildahTask) DeepCopyInto(out *BuildahTask) {
 	*out = *in
@@ -185,6 +220,11 @@ func (in *BuildahTask) DeepCopyInto(out *BuildahTask) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildahTask.
@@ -227,6 +267,23 @@ func (in *BuilderTask) DeepCopyInto(out *BuilderTask) {
 		copy(*out, *in)
 	}
 	in.Maven.DeepCopyInto(&out.Maven)
+	if in.Exclusions != nil {
+		in, out := &in.Exclusions, &out.Exclusions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LockedDependencies != nil {
+		in, out := &in.LockedDependencies, &out.LockedDependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]SourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderTask.
@@ -438,6 +495,13 @@ func (in *CamelLoader) DeepCopyInto(out *CamelLoader) {
 		*out = make([]MavenArtifact, len(*in))
 		copy(*out, *in)
 	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CamelLoader.
@@ -551,6 +615,26 @@ func (in *DataSpec) DeepCopy() *DataSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyLock) DeepCopyInto(out *DependencyLock) {
+	*out = *in
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyLock.
+func (in *DependencyLock) DeepCopy() *DependencyLock {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyLock)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Failure) DeepCopyInto(out *Failure) {
 	*out = *in
@@ -728,6 +812,21 @@ func (in *IntegrationKitList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationKitProvenance) DeepCopyInto(out *IntegrationKitProvenance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationKitProvenance.
+func (in *IntegrationKitProvenance) DeepCopy() *IntegrationKitProvenance {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationKitProvenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationKitSpec) DeepCopyInto(out *IntegrationKitSpec) {
 	*out = *in
@@ -753,6 +852,11 @@ func (in *IntegrationKitSpec) DeepCopyInto(out *IntegrationKitSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LockedDependencies != nil {
+		in, out := &in.LockedDependencies, &out.LockedDependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationKitSpec.
@@ -785,6 +889,16 @@ func (in *IntegrationKitStatus) DeepCopyInto(out *IntegrationKitStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(IntegrationKitProvenance)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationKitStatus.
@@ -856,6 +970,26 @@ func (in *IntegrationPlatform) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformBaseKitSpec) DeepCopyInto(out *IntegrationPlatformBaseKitSpec) {
+	*out = *in
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformBaseKitSpec.
+func (in *IntegrationPlatformBaseKitSpec) DeepCopy() *IntegrationPlatformBaseKitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformBaseKitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationPlatformBuildSpec) DeepCopyInto(out *IntegrationPlatformBuildSpec) {
 	*out = *in
@@ -871,6 +1005,26 @@ func (in *IntegrationPlatformBuildSpec) DeepCopyInto(out *IntegrationPlatformBui
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tekton != nil {
+		in, out := &in.Tekton, &out.Tekton
+		*out = new(IntegrationPlatformTektonSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Local != nil {
+		in, out := &in.Local, &out.Local
+		*out = new(IntegrationPlatformLocalSpec)
+		**out = **in
+	}
+	if in.BaseKit != nil {
+		in, out := &in.BaseKit, &out.BaseKit
+		*out = new(IntegrationPlatformBaseKitSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformBuildSpec.
@@ -900,6 +1054,83 @@ func (in *IntegrationPlatformCondition) DeepCopy() *IntegrationPlatformCondition
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformCostAllocationSpec) DeepCopyInto(out *IntegrationPlatformCostAllocationSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformCostAllocationSpec.
+func (in *IntegrationPlatformCostAllocationSpec) DeepCopy() *IntegrationPlatformCostAllocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformCostAllocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformDigestSpec) DeepCopyInto(out *IntegrationPlatformDigestSpec) {
+	*out = *in
+	if in.ExcludedTraits != nil {
+		in, out := &in.ExcludedTraits, &out.ExcludedTraits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformDigestSpec.
+func (in *IntegrationPlatformDigestSpec) DeepCopy() *IntegrationPlatformDigestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformDigestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformHibernationSpec) DeepCopyInto(out *IntegrationPlatformHibernationSpec) {
+	*out = *in
+	if in.IdlePeriod != nil {
+		in, out := &in.IdlePeriod, &out.IdlePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformHibernationSpec.
+func (in *IntegrationPlatformHibernationSpec) DeepCopy() *IntegrationPlatformHibernationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformHibernationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformKameletBundleSpec) DeepCopyInto(out *IntegrationPlatformKameletBundleSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformKameletBundleSpec.
+func (in *IntegrationPlatformKameletBundleSpec) DeepCopy() *IntegrationPlatformKameletBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformKameletBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationPlatformKameletRepositorySpec) DeepCopyInto(out *IntegrationPlatformKameletRepositorySpec) {
 	*out = *in
@@ -923,6 +1154,11 @@ func (in *IntegrationPlatformKameletSpec) DeepCopyInto(out *IntegrationPlatformK
 		*out = make([]IntegrationPlatformKameletRepositorySpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.Bundles != nil {
+		in, out := &in.Bundles, &out.Bundles
+		*out = make([]IntegrationPlatformKameletBundleSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformKameletSpec.
@@ -968,138 +1204,340 @@ func (in *IntegrationPlatformList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationPlatformRegistrySpec) DeepCopyInto(out *IntegrationPlatformRegistrySpec) {
+func (in *IntegrationPlatformLocalSpec) DeepCopyInto(out *IntegrationPlatformLocalSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformRegistrySpec.
-func (in *IntegrationPlatformRegistrySpec) DeepCopy() *IntegrationPlatformRegistrySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformLocalSpec.
+func (in *IntegrationPlatformLocalSpec) DeepCopy() *IntegrationPlatformLocalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationPlatformRegistrySpec)
+	out := new(IntegrationPlatformLocalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationPlatformResourcesSpec) DeepCopyInto(out *IntegrationPlatformResourcesSpec) {
+func (in *IntegrationPlatformOAuth2Spec) DeepCopyInto(out *IntegrationPlatformOAuth2Spec) {
 	*out = *in
+	if in.AllowedTokenURLs != nil {
+		in, out := &in.AllowedTokenURLs, &out.AllowedTokenURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformResourcesSpec.
-func (in *IntegrationPlatformResourcesSpec) DeepCopy() *IntegrationPlatformResourcesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformOAuth2Spec.
+func (in *IntegrationPlatformOAuth2Spec) DeepCopy() *IntegrationPlatformOAuth2Spec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationPlatformResourcesSpec)
+	out := new(IntegrationPlatformOAuth2Spec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationPlatformSpec) DeepCopyInto(out *IntegrationPlatformSpec) {
+func (in *IntegrationPlatformPolicyRule) DeepCopyInto(out *IntegrationPlatformPolicyRule) {
 	*out = *in
-	in.Build.DeepCopyInto(&out.Build)
-	out.Resources = in.Resources
-	if in.Traits != nil {
-		in, out := &in.Traits, &out.Traits
-		*out = make(map[string]TraitSpec, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
-	if in.Configuration != nil {
-		in, out := &in.Configuration, &out.Configuration
-		*out = make([]ConfigurationSpec, len(*in))
-		copy(*out, *in)
-	}
-	in.Kamelet.DeepCopyInto(&out.Kamelet)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformSpec.
-func (in *IntegrationPlatformSpec) DeepCopy() *IntegrationPlatformSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformPolicyRule.
+func (in *IntegrationPlatformPolicyRule) DeepCopy() *IntegrationPlatformPolicyRule {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationPlatformSpec)
+	out := new(IntegrationPlatformPolicyRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationPlatformStatus) DeepCopyInto(out *IntegrationPlatformStatus) {
+func (in *IntegrationPlatformMaintenanceSpec) DeepCopyInto(out *IntegrationPlatformMaintenanceSpec) {
 	*out = *in
-	in.IntegrationPlatformSpec.DeepCopyInto(&out.IntegrationPlatformSpec)
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]IntegrationPlatformCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformStatus.
-func (in *IntegrationPlatformStatus) DeepCopy() *IntegrationPlatformStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformMaintenanceSpec.
+func (in *IntegrationPlatformMaintenanceSpec) DeepCopy() *IntegrationPlatformMaintenanceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationPlatformStatus)
+	out := new(IntegrationPlatformMaintenanceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
+func (in *IntegrationPlatformPolicySpec) DeepCopyInto(out *IntegrationPlatformPolicySpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Sources != nil {
-		in, out := &in.Sources, &out.Sources
-		*out = make([]SourceSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ForbiddenComponents != nil {
+		in, out := &in.ForbiddenComponents, &out.ForbiddenComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Flows != nil {
-		in, out := &in.Flows, &out.Flows
-		*out = make([]Flow, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.AllowedComponents != nil {
+		in, out := &in.AllowedComponents, &out.AllowedComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]ResourceSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.AllowedLanguages != nil {
+		in, out := &in.AllowedLanguages, &out.AllowedLanguages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.IntegrationKit != nil {
-		in, out := &in.IntegrationKit, &out.IntegrationKit
-		*out = new(corev1.ObjectReference)
-		**out = **in
+	if in.ForbiddenLanguages != nil {
+		in, out := &in.ForbiddenLanguages, &out.ForbiddenLanguages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Dependencies != nil {
-		in, out := &in.Dependencies, &out.Dependencies
+	if in.AllowedKamelets != nil {
+		in, out := &in.AllowedKamelets, &out.AllowedKamelets
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Traits != nil {
-		in, out := &in.Traits, &out.Traits
-		*out = make(map[string]TraitSpec, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.ForbiddenKamelets != nil {
+		in, out := &in.ForbiddenKamelets, &out.ForbiddenKamelets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.PodTemplate != nil {
-		in, out := &in.PodTemplate, &out.PodTemplate
-		*out = new(PodSpecTemplate)
-		(*in).DeepCopyInto(*out)
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RestrictedTraits != nil {
+		in, out := &in.RestrictedTraits, &out.RestrictedTraits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]IntegrationPlatformPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformPolicySpec.
+func (in *IntegrationPlatformPolicySpec) DeepCopy() *IntegrationPlatformPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformQuotaSpec) DeepCopyInto(out *IntegrationPlatformQuotaSpec) {
+	*out = *in
+	if in.MaxIntegrations != nil {
+		in, out := &in.MaxIntegrations, &out.MaxIntegrations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRunningBuilds != nil {
+		in, out := &in.MaxRunningBuilds, &out.MaxRunningBuilds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformQuotaSpec.
+func (in *IntegrationPlatformQuotaSpec) DeepCopy() *IntegrationPlatformQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformRegistrySpec) DeepCopyInto(out *IntegrationPlatformRegistrySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformRegistrySpec.
+func (in *IntegrationPlatformRegistrySpec) DeepCopy() *IntegrationPlatformRegistrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformRegistrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformResourcesSpec) DeepCopyInto(out *IntegrationPlatformResourcesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformResourcesSpec.
+func (in *IntegrationPlatformResourcesSpec) DeepCopy() *IntegrationPlatformResourcesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformResourcesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformSpec) DeepCopyInto(out *IntegrationPlatformSpec) {
+	*out = *in
+	in.Build.DeepCopyInto(&out.Build)
+	out.Resources = in.Resources
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = make(map[string]TraitSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = make([]ConfigurationSpec, len(*in))
+		copy(*out, *in)
+	}
+	in.Kamelet.DeepCopyInto(&out.Kamelet)
+	in.Policy.DeepCopyInto(&out.Policy)
+	in.Quota.DeepCopyInto(&out.Quota)
+	in.CostAllocation.DeepCopyInto(&out.CostAllocation)
+	out.Upgrade = in.Upgrade
+	in.Hibernation.DeepCopyInto(&out.Hibernation)
+	in.Digest.DeepCopyInto(&out.Digest)
+	in.Maintenance.DeepCopyInto(&out.Maintenance)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformSpec.
+func (in *IntegrationPlatformSpec) DeepCopy() *IntegrationPlatformSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformStatus) DeepCopyInto(out *IntegrationPlatformStatus) {
+	*out = *in
+	in.IntegrationPlatformSpec.DeepCopyInto(&out.IntegrationPlatformSpec)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]IntegrationPlatformCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformStatus.
+func (in *IntegrationPlatformStatus) DeepCopy() *IntegrationPlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformTektonSpec) DeepCopyInto(out *IntegrationPlatformTektonSpec) {
+	*out = *in
+	if in.PipelineRunTemplate != nil {
+		in, out := &in.PipelineRunTemplate, &out.PipelineRunTemplate
+		*out = new(PipelineRunTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformTektonSpec.
+func (in *IntegrationPlatformTektonSpec) DeepCopy() *IntegrationPlatformTektonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformTektonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformUpgradeSpec) DeepCopyInto(out *IntegrationPlatformUpgradeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformUpgradeSpec.
+func (in *IntegrationPlatformUpgradeSpec) DeepCopy() *IntegrationPlatformUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Flows != nil {
+		in, out := &in.Flows, &out.Flows
+		*out = make([]Flow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IntegrationKit != nil {
+		in, out := &in.IntegrationKit, &out.IntegrationKit
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = make(map[string]TraitSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(PodSpecTemplate)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Configuration != nil {
 		in, out := &in.Configuration, &out.Configuration
@@ -1111,6 +1549,18 @@ func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependencyLock != nil {
+		in, out := &in.DependencyLock, &out.DependencyLock
+		*out = new(DependencyLock)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]IntegrationTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationSpec.
@@ -1176,6 +1626,13 @@ func (in *IntegrationStatus) DeepCopyInto(out *IntegrationStatus) {
 		in, out := &in.InitializationTimestamp, &out.InitializationTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = make([]ResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationStatus.
@@ -1188,6 +1645,165 @@ func (in *IntegrationStatus) DeepCopy() *IntegrationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTemplate) DeepCopyInto(out *IntegrationTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTemplate.
+func (in *IntegrationTemplate) DeepCopy() *IntegrationTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IntegrationTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTemplateList) DeepCopyInto(out *IntegrationTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IntegrationTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTemplateList.
+func (in *IntegrationTemplateList) DeepCopy() *IntegrationTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IntegrationTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTemplateParameter) DeepCopyInto(out *IntegrationTemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTemplateParameter.
+func (in *IntegrationTemplateParameter) DeepCopy() *IntegrationTemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTemplateSpec) DeepCopyInto(out *IntegrationTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]IntegrationTemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Integration.DeepCopyInto(&out.Integration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTemplateSpec.
+func (in *IntegrationTemplateSpec) DeepCopy() *IntegrationTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTemplateStatus) DeepCopyInto(out *IntegrationTemplateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTemplateStatus.
+func (in *IntegrationTemplateStatus) DeepCopy() *IntegrationTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTest) DeepCopyInto(out *IntegrationTest) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(IntegrationTestHTTP)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTest.
+func (in *IntegrationTest) DeepCopy() *IntegrationTest {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTestHTTP) DeepCopyInto(out *IntegrationTestHTTP) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTestHTTP.
+func (in *IntegrationTestHTTP) DeepCopy() *IntegrationTestHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTestHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanikoTask) DeepCopyInto(out *KanikoTask) {
 	*out = *in
@@ -1231,6 +1847,28 @@ func (in *KanikoTaskCache) DeepCopy() *KanikoTaskCache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalTask) DeepCopyInto(out *LocalTask) {
+	*out = *in
+	out.BaseTask = in.BaseTask
+	out.PublishTask = in.PublishTask
+	if in.Verbose != nil {
+		in, out := &in.Verbose, &out.Verbose
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalTask.
+func (in *LocalTask) DeepCopy() *LocalTask {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MavenArtifact) DeepCopyInto(out *MavenArtifact) {
 	*out = *in
@@ -1246,6 +1884,36 @@ func (in *MavenArtifact) DeepCopy() *MavenArtifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenBuildStatus) DeepCopyInto(out *MavenBuildStatus) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]Repository, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]Mirror, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenBuildStatus.
+func (in *MavenBuildStatus) DeepCopy() *MavenBuildStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenBuildStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 	*out = *in
@@ -1262,6 +1930,11 @@ func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SettingsSecurity != nil {
+		in, out := &in.SettingsSecurity, &out.SettingsSecurity
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
 		*out = new(metav1.Duration)
@@ -1272,6 +1945,16 @@ func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 		*out = make([]Repository, len(*in))
 		copy(*out, *in)
 	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]Mirror, len(*in))
+		copy(*out, *in)
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Extension != nil {
 		in, out := &in.Extension, &out.Extension
 		*out = make([]MavenArtifact, len(*in))
@@ -1289,6 +1972,41 @@ func (in *MavenSpec) DeepCopy() *MavenSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mirror) DeepCopyInto(out *Mirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mirror.
+func (in *Mirror) DeepCopy() *Mirror {
+	if in == nil {
+		return nil
+	}
+	out := new(Mirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunTemplate) DeepCopyInto(out *PipelineRunTemplate) {
+	*out = *in
+	if in.RawMessage != nil {
+		in, out := &in.RawMessage, &out.RawMessage
+		*out = make(RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTemplate.
+func (in *PipelineRunTemplate) DeepCopy() *PipelineRunTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSpec) DeepCopyInto(out *PodSpec) {
 	*out = *in
@@ -1439,6 +2157,49 @@ func (in *RepositoryPolicy) DeepCopy() *RepositoryPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendation) DeepCopyInto(out *ResourceRecommendation) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.LowerBound != nil {
+		in, out := &in.LowerBound, &out.LowerBound
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.UpperBound != nil {
+		in, out := &in.UpperBound, &out.UpperBound
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendation.
+func (in *ResourceRecommendation) DeepCopy() *ResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSpec) DeepCopyInto(out *ResourceSpec) {
 	*out = *in
@@ -1576,6 +2337,16 @@ func (in *Task) DeepCopyInto(out *Task) {
 		*out = new(S2iTask)
 		**out = **in
 	}
+	if in.Tekton != nil {
+		in, out := &in.Tekton, &out.Tekton
+		*out = new(TektonTask)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Local != nil {
+		in, out := &in.Local, &out.Local
+		*out = new(LocalTask)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Task.
@@ -1588,6 +2359,28 @@ func (in *Task) DeepCopy() *Task {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonTask) DeepCopyInto(out *TektonTask) {
+	*out = *in
+	out.BaseTask = in.BaseTask
+	out.PublishTask = in.PublishTask
+	if in.PipelineRunTemplate != nil {
+		in, out := &in.PipelineRunTemplate, &out.PipelineRunTemplate
+		*out = new(PipelineRunTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonTask.
+func (in *TektonTask) DeepCopy() *TektonTask {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Template) DeepCopyInto(out *Template) {
 	*out = *in
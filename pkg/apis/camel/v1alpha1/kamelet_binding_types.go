@@ -52,6 +52,17 @@ type Endpoint struct {
 	Types map[EventSlot]EventTypeSpec `json:"types,omitempty"`
 }
 
+const (
+	// KameletBindingKnativeFlowAnnotation selects the Knative flow a binding with steps is materialized as,
+	// when the knative profile is active, each step being run by a separate integration
+	KameletBindingKnativeFlowAnnotation = "camel.apache.org/knative.flow"
+
+	// KameletBindingKnativeFlowSequence wires the steps one after the other with a Knative Sequence
+	KameletBindingKnativeFlowSequence = "sequence"
+	// KameletBindingKnativeFlowParallel sends the events to all the steps with a Knative Parallel
+	KameletBindingKnativeFlowParallel = "parallel"
+)
+
 type EndpointType string
 
 const (
@@ -68,6 +79,8 @@ type EndpointProperties struct {
 
 // KameletBindingStatus --
 type KameletBindingStatus struct {
+	// ObservedGeneration is the most recent generation observed for this KameletBinding
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Phase --
 	Phase KameletBindingPhase `json:"phase,omitempty"`
 	// Conditions --
@@ -99,6 +112,10 @@ type KameletBindingConditionType string
 const (
 	// KameletBindingConditionReady --
 	KameletBindingConditionReady KameletBindingConditionType = "Ready"
+	// KameletBindingConditionProgressing --
+	KameletBindingConditionProgressing KameletBindingConditionType = "Progressing"
+	// KameletBindingConditionDegraded --
+	KameletBindingConditionDegraded KameletBindingConditionType = "Degraded"
 )
 
 type KameletBindingPhase string
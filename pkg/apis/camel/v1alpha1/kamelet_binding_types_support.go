@@ -129,6 +129,28 @@ func (in *KameletBindingStatus) SetConditions(conditions ...KameletBindingCondit
 	}
 }
 
+// SetPhaseConditions sets the Progressing and Degraded conditions according to the phase of the binding.
+// The binding is still progressing, until its Ready condition, that reflects the one of its integration, is true.
+func (in *KameletBindingStatus) SetPhaseConditions() {
+	reason := v1.PhaseConditionReason(string(in.Phase))
+	ready := in.GetCondition(KameletBindingConditionReady)
+	progressing := corev1.ConditionTrue
+	if in.Phase == KameletBindingPhaseError || ready != nil && ready.Status == corev1.ConditionTrue {
+		progressing = corev1.ConditionFalse
+	}
+	in.SetCondition(KameletBindingConditionProgressing, progressing, reason, "")
+
+	degraded := corev1.ConditionFalse
+	message := ""
+	if in.Phase == KameletBindingPhaseError {
+		degraded = corev1.ConditionTrue
+		if ready != nil {
+			message = ready.Message
+		}
+	}
+	in.SetCondition(KameletBindingConditionDegraded, degraded, reason, message)
+}
+
 // RemoveCondition removes the resource condition with the provided type.
 func (in *KameletBindingStatus) RemoveCondition(condType KameletBindingConditionType) {
 	newConditions := in.Conditions[:0]
@@ -0,0 +1,329 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+const (
+	// addonsKey is the JSON name of the addon traits
+	addonsKey = "addons"
+
+	// V1TraitsAnnotation keeps the v1 trait configurations that the typed traits cannot represent, i.e., that have
+	// unknown properties or properties of the wrong type, so that they are restored when converted back to v1
+	V1TraitsAnnotation = "camel.apache.org/v1.traits"
+	// V1KitAnnotation keeps the deprecated v1 kit field, so that it is restored when converted back to v1
+	V1KitAnnotation = "camel.apache.org/v1.kit"
+)
+
+// typedTraits are the indexes of the Traits fields, keyed by trait ID
+var typedTraits = func() map[string]int {
+	ids := make(map[string]int)
+	t := reflect.TypeOf(Traits{})
+	for i := 0; i < t.NumField(); i++ {
+		if id := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; id != addonsKey {
+			ids[id] = i
+		}
+	}
+	return ids
+}()
+
+// ConvertTo converts this Integration to the given v1 Integration, v1 being the storage version
+func (in *Integration) ConvertTo(dst *v1.Integration) error {
+	traits, err := in.Spec.Traits.ToV1()
+	if err != nil {
+		return err
+	}
+
+	meta := in.ObjectMeta.DeepCopy()
+	kit := meta.Annotations[V1KitAnnotation]
+	if data, ok := meta.Annotations[V1TraitsAnnotation]; ok {
+		preserved := make(map[string]json.RawMessage)
+		if err := json.Unmarshal([]byte(data), &preserved); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", V1TraitsAnnotation, err)
+		}
+		if traits, err = restoreTraits(traits, preserved); err != nil {
+			return err
+		}
+	}
+	delete(meta.Annotations, V1TraitsAnnotation)
+	delete(meta.Annotations, V1KitAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	dst.TypeMeta = metav1.TypeMeta{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       v1.IntegrationKind,
+	}
+	dst.ObjectMeta = *meta
+	dst.Spec = v1.IntegrationSpec{
+		Replicas:           in.Spec.Replicas,
+		Sources:            in.Spec.Sources,
+		Flows:              in.Spec.Flows,
+		Resources:          in.Spec.Resources,
+		IntegrationKit:     in.Spec.IntegrationKit,
+		Dependencies:       in.Spec.Dependencies,
+		Profile:            in.Spec.Profile,
+		Traits:             traits,
+		PodTemplate:        in.Spec.PodTemplate,
+		Configuration:      in.Spec.Configuration,
+		Repositories:       in.Spec.Repositories,
+		ServiceAccountName: in.Spec.ServiceAccountName,
+		DependencyLock:     in.Spec.DependencyLock,
+		Tests:              in.Spec.Tests,
+	}
+	if kit != "" {
+		dst.Spec.Kit = kit
+		// The kit reference derived from the deprecated kit field is dropped, unless it has been changed
+		if reflect.DeepEqual(dst.Spec.IntegrationKit, kitReference(in.Namespace, kit)) {
+			dst.Spec.IntegrationKit = nil
+		}
+	}
+	dst.Status = in.Status
+
+	return nil
+}
+
+// ConvertFrom converts the given v1 Integration to this Integration
+func (in *Integration) ConvertFrom(src *v1.Integration) error {
+	traits, preserved, err := TraitsFromV1(src.Spec.Traits)
+	if err != nil {
+		return err
+	}
+
+	in.TypeMeta = metav1.TypeMeta{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       v1.IntegrationKind,
+	}
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if len(preserved) > 0 {
+		data, err := json.Marshal(preserved)
+		if err != nil {
+			return err
+		}
+		in.setAnnotation(V1TraitsAnnotation, string(data))
+	}
+	in.Spec = IntegrationSpec{
+		Replicas:           src.Spec.Replicas,
+		Sources:            src.Spec.Sources,
+		Flows:              src.Spec.Flows,
+		Resources:          src.Spec.Resources,
+		IntegrationKit:     src.Spec.IntegrationKit,
+		Dependencies:       src.Spec.Dependencies,
+		Profile:            src.Spec.Profile,
+		Traits:             traits,
+		PodTemplate:        src.Spec.PodTemplate,
+		Configuration:      src.Spec.Configuration,
+		Repositories:       src.Spec.Repositories,
+		ServiceAccountName: src.Spec.ServiceAccountName,
+		DependencyLock:     src.Spec.DependencyLock,
+		Tests:              src.Spec.Tests,
+	}
+	if src.Spec.Kit != "" {
+		in.setAnnotation(V1KitAnnotation, src.Spec.Kit)
+		// The deprecated kit field is replaced with the kit reference, as done by the operator
+		if in.Spec.IntegrationKit == nil {
+			in.Spec.IntegrationKit = kitReference(src.Namespace, src.Spec.Kit)
+		}
+	}
+	in.Status = src.Status
+
+	return nil
+}
+
+func (in *Integration) setAnnotation(name string, value string) {
+	if in.Annotations == nil {
+		in.Annotations = make(map[string]string)
+	}
+	in.Annotations[name] = value
+}
+
+func kitReference(namespace string, name string) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// ToV1 returns the v1 trait specs, keyed by trait ID, equivalent to these Traits
+func (in *Traits) ToV1() (map[string]v1.TraitSpec, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	configurations := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &configurations); err != nil {
+		return nil, err
+	}
+	delete(configurations, addonsKey)
+
+	if len(configurations) == 0 && len(in.Addons) == 0 {
+		return nil, nil
+	}
+
+	traits := make(map[string]v1.TraitSpec, len(configurations)+len(in.Addons))
+	for id, spec := range in.Addons {
+		traits[id] = spec
+	}
+	for id, configuration := range configurations {
+		traits[id] = v1TraitSpec(configuration)
+	}
+
+	return traits, nil
+}
+
+// TraitsFromV1 returns the typed Traits equivalent to the given v1 trait specs. The traits that are not typed,
+// e.g., the addon traits, are kept as is in the addons. The configurations of the typed traits that cannot be
+// represented as is, i.e., that have unknown properties or properties of the wrong type, are also returned, keyed
+// by trait ID, so that they can be restored. The properties of the wrong type are not set in the typed traits.
+func TraitsFromV1(specs map[string]v1.TraitSpec) (Traits, map[string]json.RawMessage, error) {
+	traits := Traits{}
+	var preserved map[string]json.RawMessage
+	value := reflect.ValueOf(&traits).Elem()
+	for id, spec := range specs {
+		index, ok := typedTraits[id]
+		if !ok {
+			if traits.Addons == nil {
+				traits.Addons = make(map[string]v1.TraitSpec)
+			}
+			traits.Addons[id] = spec
+			continue
+		}
+		data, err := json.Marshal(&spec.Configuration)
+		if err != nil {
+			return traits, nil, err
+		}
+		typed, err := typedTrait(index, data)
+		if err != nil {
+			return traits, nil, err
+		}
+		value.Field(index).Set(typed)
+		if converted, err := json.Marshal(typed.Interface()); err != nil {
+			return traits, nil, err
+		} else if !jsonEqual(data, converted) {
+			if preserved == nil {
+				preserved = make(map[string]json.RawMessage)
+			}
+			preserved[id] = data
+		}
+	}
+
+	return traits, preserved, nil
+}
+
+// restoreTraits restores the given preserved v1 trait configurations, if the typed traits have not been changed
+// since they have been converted, or merges their unknown properties into the typed traits otherwise
+func restoreTraits(traits map[string]v1.TraitSpec, preserved map[string]json.RawMessage) (map[string]v1.TraitSpec, error) {
+	for id, original := range preserved {
+		index, ok := typedTraits[id]
+		if !ok {
+			continue
+		}
+		current, ok := traits[id]
+		if !ok {
+			// The trait has been removed
+			continue
+		}
+		typed, err := typedTrait(index, original)
+		if err != nil {
+			return nil, err
+		}
+		converted, err := json.Marshal(typed.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		if jsonEqual(converted, current.Configuration.RawMessage) {
+			traits[id] = v1TraitSpec(original)
+		} else {
+			merged, err := mergeUnknownProperties(original, converted, current.Configuration.RawMessage)
+			if err != nil {
+				return nil, err
+			}
+			traits[id] = v1TraitSpec(merged)
+		}
+	}
+
+	return traits, nil
+}
+
+// mergeUnknownProperties adds the properties of the original configuration that neither the converted configuration
+// nor the current configuration have to the current configuration
+func mergeUnknownProperties(original []byte, converted []byte, current []byte) ([]byte, error) {
+	var originalProperties, convertedProperties, currentProperties map[string]interface{}
+	if json.Unmarshal(original, &originalProperties) != nil {
+		return current, nil
+	}
+	if err := json.Unmarshal(converted, &convertedProperties); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(current, &currentProperties); err != nil {
+		return nil, err
+	}
+	if currentProperties == nil {
+		currentProperties = make(map[string]interface{})
+	}
+	for name, property := range originalProperties {
+		_, converted := convertedProperties[name]
+		_, set := currentProperties[name]
+		if !converted && !set {
+			currentProperties[name] = property
+		}
+	}
+	return json.Marshal(currentProperties)
+}
+
+// typedTrait returns the typed trait, at the given index of the Traits fields, decoded from the given JSON
+// configuration, the properties of the wrong type being skipped
+func typedTrait(index int, data []byte) (reflect.Value, error) {
+	typed := reflect.New(reflect.TypeOf(Traits{}).Field(index).Type.Elem())
+	if err := json.Unmarshal(data, typed.Interface()); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return typed, fmt.Errorf("cannot convert the trait configuration: %w", err)
+		}
+	}
+	return typed, nil
+}
+
+func jsonEqual(a []byte, b []byte) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func v1TraitSpec(configuration []byte) v1.TraitSpec {
+	return v1.TraitSpec{
+		Configuration: v1.TraitConfiguration{
+			RawMessage: v1.RawMessage(configuration),
+		},
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestConvertFromV1(t *testing.T) {
+	src := v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Kit:          "my-kit",
+			Dependencies: []string{"camel:log"},
+			Traits: map[string]v1.TraitSpec{
+				"container": traitSpec(t, map[string]interface{}{"port": 8081, "imagePullPolicy": "Always"}),
+				"quarkus":   traitSpec(t, map[string]interface{}{"packageTypes": []string{"native"}}),
+				"master":    traitSpec(t, map[string]interface{}{"enabled": true}),
+			},
+		},
+		Status: v1.IntegrationStatus{Phase: v1.IntegrationPhaseRunning},
+	}
+
+	dst := Integration{}
+	assert.NoError(t, dst.ConvertFrom(&src))
+
+	assert.Equal(t, SchemeGroupVersion.String(), dst.APIVersion)
+	assert.Equal(t, "my-integration", dst.Name)
+	assert.Equal(t, "my-kit", dst.Spec.IntegrationKit.Name)
+	assert.Equal(t, "ns", dst.Spec.IntegrationKit.Namespace)
+	assert.Equal(t, []string{"camel:log"}, dst.Spec.Dependencies)
+	assert.Equal(t, 8081, dst.Spec.Traits.Container.Port)
+	assert.Equal(t, "Always", string(dst.Spec.Traits.Container.ImagePullPolicy))
+	assert.Equal(t, []QuarkusPackageType{"native"}, dst.Spec.Traits.Quarkus.PackageTypes)
+	assert.Nil(t, dst.Spec.Traits.JVM)
+	assert.Contains(t, dst.Spec.Traits.Addons, "master")
+	assert.Equal(t, v1.IntegrationPhaseRunning, dst.Status.Phase)
+}
+
+func TestConvertRoundTripFromV1WithUnknownProperties(t *testing.T) {
+	src := v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"container": traitSpec(t, map[string]interface{}{"port": 8081, "unknown": "value"}),
+				"jvm":       traitSpec(t, map[string]interface{}{"debug": true}),
+			},
+		},
+	}
+
+	dst := Integration{}
+	assert.NoError(t, dst.ConvertFrom(&src))
+	assert.Equal(t, 8081, dst.Spec.Traits.Container.Port)
+	assert.JSONEq(t, `{"container":{"port":8081,"unknown":"value"}}`, dst.Annotations[V1TraitsAnnotation])
+	assert.Nil(t, src.Annotations)
+
+	hub := v1.Integration{}
+	assert.NoError(t, dst.ConvertTo(&hub))
+	assert.Equal(t, src.ObjectMeta, hub.ObjectMeta)
+	assert.Equal(t, src.Spec, hub.Spec)
+
+	// The unknown properties are kept when the typed trait is updated
+	dst.Spec.Traits.Container.Port = 8082
+	hub = v1.Integration{}
+	assert.NoError(t, dst.ConvertTo(&hub))
+	assert.JSONEq(t, `{"port":8082,"unknown":"value"}`, string(hub.Spec.Traits["container"].Configuration.RawMessage))
+}
+
+func TestConvertRoundTripFromV1WithInvalidTrait(t *testing.T) {
+	src := v1.Integration{
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"container": traitSpec(t, map[string]interface{}{"port": "http", "imagePullPolicy": "Always"}),
+			},
+		},
+	}
+
+	dst := Integration{}
+	assert.NoError(t, dst.ConvertFrom(&src))
+	assert.Equal(t, 0, dst.Spec.Traits.Container.Port)
+	assert.Equal(t, "Always", string(dst.Spec.Traits.Container.ImagePullPolicy))
+	assert.JSONEq(t, `{"container":{"port":"http","imagePullPolicy":"Always"}}`, dst.Annotations[V1TraitsAnnotation])
+
+	hub := v1.Integration{}
+	assert.NoError(t, dst.ConvertTo(&hub))
+	assert.Equal(t, src.ObjectMeta, hub.ObjectMeta)
+	assert.Equal(t, src.Spec, hub.Spec)
+
+	// The property of the wrong type is replaced when it is set in the typed trait
+	dst.Spec.Traits.Container.Port = 8081
+	hub = v1.Integration{}
+	assert.NoError(t, dst.ConvertTo(&hub))
+	assert.JSONEq(t, `{"port":8081,"imagePullPolicy":"Always"}`, string(hub.Spec.Traits["container"].Configuration.RawMessage))
+}
+
+func TestConvertRoundTripFromV1WithKit(t *testing.T) {
+	src := v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "my-integration",
+			Annotations: map[string]string{"my-annotation": "value"},
+		},
+		Spec: v1.IntegrationSpec{
+			Kit: "my-kit",
+		},
+	}
+
+	dst := Integration{}
+	assert.NoError(t, dst.ConvertFrom(&src))
+	assert.Equal(t, "my-kit", dst.Spec.IntegrationKit.Name)
+	assert.Equal(t, map[string]string{"my-annotation": "value", V1KitAnnotation: "my-kit"}, dst.Annotations)
+	assert.Equal(t, map[string]string{"my-annotation": "value"}, src.Annotations)
+
+	hub := v1.Integration{}
+	assert.NoError(t, dst.ConvertTo(&hub))
+	assert.Equal(t, src.ObjectMeta, hub.ObjectMeta)
+	assert.Equal(t, src.Spec, hub.Spec)
+
+	// The kit reference is kept when it is updated
+	dst.Spec.IntegrationKit.Name = "other-kit"
+	hub = v1.Integration{}
+	assert.NoError(t, dst.ConvertTo(&hub))
+	assert.Equal(t, "my-kit", hub.Spec.Kit)
+	assert.Equal(t, "other-kit", hub.Spec.IntegrationKit.Name)
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	enabled := false
+	src := Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: IntegrationSpec{
+			Traits: Traits{
+				Container: &ContainerTrait{Port: 8081},
+				JVM:       &JVMTrait{Trait: Trait{Enabled: &enabled}},
+				Addons: map[string]v1.TraitSpec{
+					"tracing": traitSpec(t, map[string]interface{}{"auto": true}),
+				},
+			},
+		},
+	}
+
+	hub := v1.Integration{}
+	assert.NoError(t, src.ConvertTo(&hub))
+	assert.Equal(t, v1.SchemeGroupVersion.String(), hub.APIVersion)
+	assert.Len(t, hub.Spec.Traits, 3)
+	assert.JSONEq(t, `{"port":8081}`, string(hub.Spec.Traits["container"].Configuration.RawMessage))
+	assert.JSONEq(t, `{"enabled":false}`, string(hub.Spec.Traits["jvm"].Configuration.RawMessage))
+	assert.JSONEq(t, `{"auto":true}`, string(hub.Spec.Traits["tracing"].Configuration.RawMessage))
+
+	dst := Integration{}
+	assert.NoError(t, dst.ConvertFrom(&hub))
+	assert.Equal(t, src.Spec, dst.Spec)
+}
+
+func TestConvertWithoutTraits(t *testing.T) {
+	src := Integration{}
+
+	hub := v1.Integration{}
+	assert.NoError(t, src.ConvertTo(&hub))
+	assert.Nil(t, hub.Spec.Traits)
+}
+
+func traitSpec(t *testing.T, configuration map[string]interface{}) v1.TraitSpec {
+	t.Helper()
+
+	data, err := json.Marshal(configuration)
+	assert.NoError(t, err)
+
+	return v1.TraitSpec{
+		Configuration: v1.TraitConfiguration{
+			RawMessage: data,
+		},
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+// Important: Run "make generate-deepcopy" to regenerate code after modifying this file
+
+// IntegrationSpec defines the desired state of Integration
+type IntegrationSpec struct {
+	Replicas       *int32                  `json:"replicas,omitempty"`
+	Sources        []v1.SourceSpec         `json:"sources,omitempty"`
+	Flows          []v1.Flow               `json:"flows,omitempty"`
+	Resources      []v1.ResourceSpec       `json:"resources,omitempty"`
+	IntegrationKit *corev1.ObjectReference `json:"integrationKit,omitempty"`
+	Dependencies   []string                `json:"dependencies,omitempty"`
+	Profile        v1.TraitProfile         `json:"profile,omitempty"`
+	// The configuration of the traits
+	Traits             Traits                 `json:"traits,omitempty"`
+	PodTemplate        *v1.PodSpecTemplate    `json:"template,omitempty"`
+	Configuration      []v1.ConfigurationSpec `json:"configuration,omitempty"`
+	Repositories       []string               `json:"repositories,omitempty"`
+	ServiceAccountName string                 `json:"serviceAccountName,omitempty"`
+	// The lock of the resolved dependencies, used to make the builds reproducible
+	DependencyLock *v1.DependencyLock `json:"dependencyLock,omitempty"`
+	// The smoke tests run against the Integration once it is rolled out
+	Tests []v1.IntegrationTest `json:"tests,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=integrations,scope=Namespaced,shortName=it,categories=kamel;camel
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="The integration phase"
+// +kubebuilder:printcolumn:name="Kit",type=string,JSONPath=`.status.integrationKit.name`,description="The integration kit"
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`,description="The number of pods"
+
+// Integration is the Schema for the integrations API
+type Integration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IntegrationSpec      `json:"spec,omitempty"`
+	Status v1.IntegrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IntegrationList contains a list of Integration
+type IntegrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Integration `json:"items"`
+}
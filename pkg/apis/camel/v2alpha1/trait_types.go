@@ -0,0 +1,1039 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// Traits is the typed configuration of the traits of an Integration
+type Traits struct {
+	// The configuration of the affinity trait
+	Affinity *AffinityTrait `json:"affinity,omitempty"`
+	// The configuration of the builder trait
+	Builder *BuilderTrait `json:"builder,omitempty"`
+	// The configuration of the camel trait
+	Camel *CamelTrait `json:"camel,omitempty"`
+	// The configuration of the chaos trait
+	Chaos *ChaosTrait `json:"chaos,omitempty"`
+	// The configuration of the container trait
+	Container *ContainerTrait `json:"container,omitempty"`
+	// The configuration of the cron trait
+	Cron *CronTrait `json:"cron,omitempty"`
+	// The configuration of the dependencies trait
+	Dependencies *DependenciesTrait `json:"dependencies,omitempty"`
+	// The configuration of the deployer trait
+	Deployer *DeployerTrait `json:"deployer,omitempty"`
+	// The configuration of the deployment trait
+	Deployment *DeploymentTrait `json:"deployment,omitempty"`
+	// The configuration of the dns trait
+	DNS *DNSTrait `json:"dns,omitempty"`
+	// The configuration of the environment trait
+	Environment *EnvironmentTrait `json:"environment,omitempty"`
+	// The configuration of the error-handler trait
+	ErrorHandler *ErrorHandlerTrait `json:"error-handler,omitempty"`
+	// The configuration of the feature-flags trait
+	FeatureFlags *FeatureFlagsTrait `json:"feature-flags,omitempty"`
+	// The configuration of the gc trait
+	GC *GCTrait `json:"gc,omitempty"`
+	// The configuration of the grafana trait
+	Grafana *GrafanaTrait `json:"grafana,omitempty"`
+	// The configuration of the hpa trait
+	HPA *HPATrait `json:"hpa,omitempty"`
+	// The configuration of the ingress trait
+	Ingress *IngressTrait `json:"ingress,omitempty"`
+	// The configuration of the istio trait
+	Istio *IstioTrait `json:"istio,omitempty"`
+	// The configuration of the jdbc trait
+	JDBC *JDBCTrait `json:"jdbc,omitempty"`
+	// The configuration of the jolokia trait
+	Jolokia *JolokiaTrait `json:"jolokia,omitempty"`
+	// The configuration of the jvm trait
+	JVM *JVMTrait `json:"jvm,omitempty"`
+	// The configuration of the kamelets trait
+	Kamelets *KameletsTrait `json:"kamelets,omitempty"`
+	// The configuration of the knative trait
+	Knative *KnativeTrait `json:"knative,omitempty"`
+	// The configuration of the knative-service trait
+	KnativeService *KnativeServiceTrait `json:"knative-service,omitempty"`
+	// The configuration of the logging trait
+	Logging *LoggingTrait `json:"logging,omitempty"`
+	// The configuration of the mount trait
+	Mount *MountTrait `json:"mount,omitempty"`
+	// The configuration of the node trait
+	Node *NodeTrait `json:"node,omitempty"`
+	// The configuration of the openapi trait
+	Openapi *OpenapiTrait `json:"openapi,omitempty"`
+	// The configuration of the owner trait
+	Owner *OwnerTrait `json:"owner,omitempty"`
+	// The configuration of the patch trait
+	Patch *PatchTrait `json:"patch,omitempty"`
+	// The configuration of the pdb trait
+	PDB *PDBTrait `json:"pdb,omitempty"`
+	// The configuration of the persistent-state trait
+	PersistentState *PersistentStateTrait `json:"persistent-state,omitempty"`
+	// The configuration of the platform trait
+	Platform *PlatformTrait `json:"platform,omitempty"`
+	// The configuration of the pod trait
+	Pod *PodTrait `json:"pod,omitempty"`
+	// The configuration of the preflight trait
+	Preflight *PreflightTrait `json:"preflight,omitempty"`
+	// The configuration of the prometheus trait
+	Prometheus *PrometheusTrait `json:"prometheus,omitempty"`
+	// The configuration of the pull-secret trait
+	PullSecret *PullSecretTrait `json:"pull-secret,omitempty"`
+	// The configuration of the quarkus trait
+	Quarkus *QuarkusTrait `json:"quarkus,omitempty"`
+	// The configuration of the route trait
+	Route *RouteTrait `json:"route,omitempty"`
+	// The configuration of the service trait
+	Service *ServiceTrait `json:"service,omitempty"`
+	// The configuration of the service-account trait
+	ServiceAccount *ServiceAccountTrait `json:"service-account,omitempty"`
+	// The configuration of the service-binding trait
+	ServiceBinding *ServiceBindingTrait `json:"service-binding,omitempty"`
+	// The configuration of the toleration trait
+	Toleration *TolerationTrait `json:"toleration,omitempty"`
+	// The configuration of the vpa trait
+	VPA *VPATrait `json:"vpa,omitempty"`
+
+	// The configuration of the addon traits, e.g., master or tracing, keyed by trait ID
+	Addons map[string]v1.TraitSpec `json:"addons,omitempty"`
+}
+
+// Trait is the base type of all the traits
+type Trait struct {
+	// Can be used to enable or disable a trait. All traits share this common property.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// QuarkusPackageType is the type of package built by the Quarkus trait
+type QuarkusPackageType string
+
+// DiscoveryCacheType is the type of discovery cache used by the GC trait
+type DiscoveryCacheType string
+
+// AffinityTrait is the typed configuration of the affinity trait
+type AffinityTrait struct {
+	Trait `json:",inline"`
+	// Always co-locates multiple replicas of the integration in the same node (default *false*).
+	PodAffinity *bool `json:"podAffinity,omitempty"`
+	// Never co-locates multiple replicas of the integration in the same node (default *false*).
+	PodAntiAffinity *bool `json:"podAntiAffinity,omitempty"`
+	// Defines a set of nodes the integration pod(s) are eligible to be scheduled on, based on labels on the node.
+	NodeAffinityLabels []string `json:"nodeAffinityLabels,omitempty"`
+	// Defines a set of pods (namely those matching the label selector, relative to the given namespace) that the
+	// integration pod(s) should be co-located with.
+	PodAffinityLabels []string `json:"podAffinityLabels,omitempty"`
+	// Defines a set of pods (namely those matching the label selector, relative to the given namespace) that the
+	// integration pod(s) should not be co-located with.
+	PodAntiAffinityLabels []string `json:"podAntiAffinityLabels,omitempty"`
+	// Defines how the integration pod(s) are spread across topology domains, in the form
+	// `topology-key[:max-skew[:when-unsatisfiable]]`, e.g. `topology.kubernetes.io/zone:1:DoNotSchedule`.
+	// The maximum skew defaults to `1`, and the pods are scheduled anyway when the constraint can't be satisfied by default.
+	TopologySpreadConstraints []string `json:"topologySpreadConstraints,omitempty"`
+	// The scheduling presets to apply: `spread-across-zones`, `spread-across-nodes` or `one-per-node`.
+	Presets []string `json:"presets,omitempty"`
+}
+
+// BuilderTrait is the typed configuration of the builder trait
+type BuilderTrait struct {
+	Trait `json:",inline"`
+	// Enable verbose logging on build components that support it (e.g. Kaniko build pod).
+	Verbose *bool `json:"verbose,omitempty"`
+	// A list of properties to be provided to the build task
+	Properties []string `json:"properties,omitempty"`
+	// The Linux architectures the kit image is built for, among `amd64`, `arm64`, `ppc64le` and `s390x`, that default to the ones of the platform
+	Architectures []string `json:"architectures,omitempty"`
+	// The Maven profiles activated for the build, in addition to the ones of the platform
+	Profiles []string `json:"mavenProfiles,omitempty"`
+	// The Maven repositories the build resolves the dependencies from, in addition to the ones of the platform,
+	// e.g., `https://repo.acme.com/maven2@id=acme@snapshots`
+	Repositories []string `json:"mavenRepositories,omitempty"`
+	// The Maven mirrors the build is configured with, ahead of the ones of the platform,
+	// e.g., `https://nexus.acme.com/repository/maven-public@id=acme@mirrorOf=central`
+	Mirrors []string `json:"mavenMirrors,omitempty"`
+	// The ConfigMaps holding the unit tests run during the build, in the form `name[/key]`. All the Java and Groovy
+	// entries of a ConfigMap are run when no key is set.
+	TestConfigMaps []string `json:"testConfigMaps,omitempty"`
+}
+
+// CamelTrait is the typed configuration of the camel trait
+type CamelTrait struct {
+	Trait `json:",inline"`
+	// The camel-k-runtime version to use for the integration. It overrides the default version set in the Integration Platform.
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	// A list of properties to be provided to the Integration runtime
+	Properties []string `json:"properties,omitempty"`
+	// Rebuild the integration once the operator is upgraded, as long as it is compatible with the catalog of the new version.
+	// It overrides the `upgrade.autoUpgrade` setting of the Integration Platform.
+	AutoUpgrade *bool `json:"autoUpgrade,omitempty"`
+}
+
+// ChaosTrait is the typed configuration of the chaos trait
+type ChaosTrait struct {
+	Trait `json:",inline"`
+	// The probability, in percent, that the integration container is killed at each interval.
+	KillProbability int `json:"killProbability,omitempty"`
+	// The interval, in seconds, between the kill attempts (default `60`).
+	Interval int `json:"interval,omitempty"`
+	// The services proxied by the Toxiproxy sidecar, in the form `port=host:port`, e.g. `5432=my-database:5432`.
+	// The integration must connect to the proxied services at `localhost:<port>`.
+	Proxies []string `json:"proxies,omitempty"`
+	// The latency, in milliseconds, added to the proxied connections.
+	Latency int `json:"latency,omitempty"`
+	// The jitter, in milliseconds, of the latency added to the proxied connections.
+	Jitter int `json:"jitter,omitempty"`
+	// The probability, in percent, that an exchange fails with an exception.
+	ExceptionProbability int `json:"exceptionProbability,omitempty"`
+	// The image of the sidecar container, that kills the integration container and configures the proxies,
+	// and that must provide the `kill`, `grep`, `tr`, `od` and `wget` commands (default `busybox`).
+	Image string `json:"image,omitempty"`
+	// The image of the Toxiproxy sidecar container.
+	ProxyImage string `json:"proxyImage,omitempty"`
+}
+
+// ContainerTrait is the typed configuration of the container trait
+type ContainerTrait struct {
+	Trait `json:",inline"`
+	Auto  *bool `json:"auto,omitempty"`
+	// The minimum amount of CPU required.
+	RequestCPU string `json:"requestCPU,omitempty"`
+	// The minimum amount of memory required.
+	RequestMemory string `json:"requestMemory,omitempty"`
+	// The maximum amount of CPU required.
+	LimitCPU string `json:"limitCPU,omitempty"`
+	// The maximum amount of memory required.
+	LimitMemory string `json:"limitMemory,omitempty"`
+	// Can be used to enable/disable exposure via kubernetes Service.
+	Expose *bool `json:"expose,omitempty"`
+	// To configure a different port exposed by the container (default `8080`).
+	Port int `json:"port,omitempty"`
+	// To configure a different port name for the port exposed by the container (default `http`).
+	PortName string `json:"portName,omitempty"`
+	// The application protocol of the port exposed by the container: `http` (default), `h2c` for HTTP/2 over cleartext, or `grpc`.
+	//
+	// With `h2c` and `grpc`, the port is named `h2c` on Knative so that HTTP/2 is used end-to-end,
+	// and the Service port declares the matching application protocol.
+	// With `grpc`, the probes check that the port accepts TCP connections.
+	PortProtocol string `json:"portProtocol,omitempty"`
+	// To configure under which service port the container port is to be exposed (default `80`).
+	ServicePort int `json:"servicePort,omitempty"`
+	// To configure under which service port name the container port is to be exposed (default `http`).
+	ServicePortName string `json:"servicePortName,omitempty"`
+	// The main container name. It's named `integration` by default.
+	Name string `json:"name,omitempty"`
+	// The main container image
+	Image string `json:"image,omitempty"`
+	// The pull policy: Always|Never|IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ProbesEnabled enable/disable probes on the container (default `false`)
+	ProbesEnabled *bool `json:"probesEnabled,omitempty"`
+	// Scheme to use when connecting. Defaults to HTTP. Applies to the liveness probe.
+	LivenessScheme string `json:"livenessScheme,omitempty"`
+	// Number of seconds after the container has started before liveness probes are initiated.
+	LivenessInitialDelay int32 `json:"livenessInitialDelay,omitempty"`
+	// Number of seconds after which the probe times out. Applies to the liveness probe.
+	LivenessTimeout int32 `json:"livenessTimeout,omitempty"`
+	// How often to perform the probe. Applies to the liveness probe.
+	LivenessPeriod int32 `json:"livenessPeriod,omitempty"`
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Applies to the liveness probe.
+	LivenessSuccessThreshold int32 `json:"livenessSuccessThreshold,omitempty"`
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// Applies to the liveness probe.
+	LivenessFailureThreshold int32 `json:"livenessFailureThreshold,omitempty"`
+	// Scheme to use when connecting. Defaults to HTTP. Applies to the readiness probe.
+	ReadinessScheme string `json:"readinessScheme,omitempty"`
+	// Number of seconds after the container has started before readiness probes are initiated.
+	ReadinessInitialDelay int32 `json:"readinessInitialDelay,omitempty"`
+	// Number of seconds after which the probe times out. Applies to the readiness probe.
+	ReadinessTimeout int32 `json:"readinessTimeout,omitempty"`
+	// How often to perform the probe. Applies to the readiness probe.
+	ReadinessPeriod int32 `json:"readinessPeriod,omitempty"`
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Applies to the readiness probe.
+	ReadinessSuccessThreshold int32 `json:"readinessSuccessThreshold,omitempty"`
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// Applies to the readiness probe.
+	ReadinessFailureThreshold int32 `json:"readinessFailureThreshold,omitempty"`
+	// The path of the liveness probe (default `/q/health`).
+	LivenessPath string `json:"livenessPath,omitempty"`
+	// The port of the liveness probe, that defaults to the container port.
+	// It's ignored on Knative, that only allows probing the container port.
+	LivenessPort int `json:"livenessPort,omitempty"`
+	// The path of the readiness probe (default `/q/health`).
+	ReadinessPath string `json:"readinessPath,omitempty"`
+	// The port of the readiness probe, that defaults to the container port.
+	// It's ignored on Knative, that only allows probing the container port.
+	ReadinessPort int `json:"readinessPort,omitempty"`
+	// Enable/disable the startup probe, that holds off the liveness and readiness probes until the integration
+	// has started, when probes are enabled (default `false`). It's not supported on Knative.
+	StartupProbeEnabled *bool `json:"startupProbeEnabled,omitempty"`
+	// Scheme to use when connecting. Defaults to HTTP. Applies to the startup probe.
+	StartupScheme string `json:"startupScheme,omitempty"`
+	// The path of the startup probe (default `/q/health`).
+	StartupPath string `json:"startupPath,omitempty"`
+	// The port of the startup probe, that defaults to the container port.
+	StartupPort int `json:"startupPort,omitempty"`
+	// Number of seconds after the container has started before startup probes are initiated.
+	StartupInitialDelay int32 `json:"startupInitialDelay,omitempty"`
+	// Number of seconds after which the probe times out. Applies to the startup probe.
+	StartupTimeout int32 `json:"startupTimeout,omitempty"`
+	// How often to perform the probe. Applies to the startup probe.
+	StartupPeriod int32 `json:"startupPeriod,omitempty"`
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Applies to the startup probe.
+	StartupSuccessThreshold int32 `json:"startupSuccessThreshold,omitempty"`
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// Applies to the startup probe, and can be increased to give slow starting integrations more time to start.
+	StartupFailureThreshold int32 `json:"startupFailureThreshold,omitempty"`
+}
+
+// CronTrait is the typed configuration of the cron trait
+type CronTrait struct {
+	Trait `json:",inline"`
+	// The CronJob schedule for the whole integration. If multiple routes are declared, they must have the same schedule for this
+	// mechanism to work correctly.
+	Schedule string `json:"schedule,omitempty"`
+	// A comma separated list of the Camel components that need to be customized in order for them to work when the schedule is triggered externally by Kubernetes.
+	// A specific customizer is activated for each specified component. E.g. for the `timer` component, the `cron-timer` customizer is
+	// activated (it's present in the `org.apache.camel.k:camel-k-cron` library).
+	//
+	// Supported components are currently: `cron`, `timer` and `quartz`.
+	Components string `json:"components,omitempty"`
+	// Use the default Camel implementation of the `cron` endpoint (`quartz`) instead of trying to materialize the integration
+	// as Kubernetes CronJob.
+	Fallback *bool `json:"fallback,omitempty"`
+	// Specifies how to treat concurrent executions of a Job.
+	// Valid values are:
+	// - "Allow": allows CronJobs to run concurrently;
+	// - "Forbid" (default): forbids concurrent runs, skipping next run if previous run hasn't finished yet;
+	// - "Replace": cancels currently running job and replaces it with a new one
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+	// Automatically deploy the integration as CronJob when all routes are
+	// either starting from a periodic consumer (only `cron`, `timer` and `quartz` are supported) or a passive consumer (e.g. `direct` is a passive consumer).
+	//
+	// It's required that all periodic consumers have the same period and it can be expressed as cron schedule (e.g. `1m` can be expressed as `0/1 * * * *`,
+	// while `35m` or `50s` cannot).
+	Auto *bool `json:"auto,omitempty"`
+	// Optional deadline in seconds for starting the job if it misses scheduled
+	// time for any reason.  Missed jobs executions will be counted as failed ones.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// The time zone the schedule is interpreted in, e.g. `Europe/Rome` (default to the time zone of the Kubernetes controller manager).
+	TimeZone string `json:"timeZone,omitempty"`
+	// The number of successful finished jobs to retain.
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// The number of failed finished jobs to retain.
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+}
+
+// DependenciesTrait is the typed configuration of the dependencies trait
+type DependenciesTrait struct {
+	Trait `json:",inline"`
+}
+
+// DeployerTrait is the typed configuration of the deployer trait
+type DeployerTrait struct {
+	Trait `json:",inline"`
+	// Allows to explicitly select the desired deployment kind between `deployment`, `stateful-set`, `cron-job` or `knative-service` when creating the resources for running the integration.
+	// The explicitly selected kind takes precedence over the controller selected by the other traits.
+	Kind string `json:"kind,omitempty"`
+}
+
+// DeploymentTrait is the typed configuration of the deployment trait
+type DeploymentTrait struct {
+	Trait `json:",inline"`
+}
+
+// DNSTrait is the typed configuration of the dns trait
+type DNSTrait struct {
+	Trait `json:",inline"`
+	// The DNS policy of the integration pods, either `ClusterFirst`, `ClusterFirstWithHostNet`, `Default` or `None`.
+	// The `None` policy requires the name servers to be provided.
+	Policy string `json:"policy,omitempty"`
+	// The IP addresses of the name servers used by the integration pods.
+	Nameservers []string `json:"nameservers,omitempty"`
+	// The DNS search domains for host names lookup.
+	Searches []string `json:"searches,omitempty"`
+	// The DNS resolver options, in the form of `name[=value]`, e.g. `ndots=2`.
+	Options []string `json:"options,omitempty"`
+	// The entries added to the pods `/etc/hosts` file, in the form of `ip=hostname[,hostname...]`,
+	// e.g. `10.0.0.10=db.example.com,db`.
+	HostAliases []string `json:"hostAliases,omitempty"`
+}
+
+// EnvironmentTrait is the typed configuration of the environment trait
+type EnvironmentTrait struct {
+	Trait `json:",inline"`
+	// Enables injection of `NAMESPACE` and `POD_NAME` environment variables (default `true`)
+	ContainerMeta *bool `json:"containerMeta,omitempty"`
+	// A list of variables to be created on the Pod. Must have KEY=VALUE syntax (ie, MY_VAR="my value").
+	Vars []string `json:"vars,omitempty"`
+	// A list of ConfigMaps and Secrets, whose entries are all created as variables on the Pod.
+	// Must have `configmap:<name>` or `secret:<name>` syntax, optionally with a prefix for the variable names
+	// (ie, configmap:my-config?prefix=APP_).
+	EnvFrom []string `json:"envFrom,omitempty"`
+	// A list of variables set from the Pod fields. Must have KEY=FIELD_PATH syntax (ie, NODE_NAME=spec.nodeName).
+	FieldRefs []string `json:"fieldRefs,omitempty"`
+	// A list of variables set from the container resources. Must have KEY=RESOURCE[/DIVISOR] syntax (ie, MEMORY_LIMIT=limits.memory/1Mi).
+	ResourceFieldRefs []string `json:"resourceFieldRefs,omitempty"`
+}
+
+// ErrorHandlerTrait is the typed configuration of the error-handler trait
+type ErrorHandlerTrait struct {
+	Trait `json:",inline"`
+	// The error handler ref name provided or found in application properties
+	ErrorHandlerRef string `json:"ref,omitempty"`
+	// The type of the default error handler, either `none`, `log` or `dead-letter-channel`.
+	// It cannot be combined with a `ref` other than `defaultErrorHandler`.
+	Type string `json:"type,omitempty"`
+	// The URI of the endpoint the failed messages are sent to, required by the `dead-letter-channel` error handler,
+	// e.g. `kamelet:my-sink` or `kafka:errors`
+	DeadLetterURI string `json:"deadLetterUri,omitempty"`
+	// The maximum number of redelivery attempts, `-1` for redelivering forever
+	MaximumRedeliveries *int `json:"maximumRedeliveries,omitempty"`
+	// The initial delay between the redelivery attempts, in milliseconds
+	RedeliveryDelay *int64 `json:"redeliveryDelay,omitempty"`
+	// Whether the delay between the redelivery attempts grows exponentially
+	UseExponentialBackOff *bool `json:"useExponentialBackOff,omitempty"`
+	// Additional parameters of the error handler, in the form of `name=value`, e.g. `retryAttemptedLogLevel=WARN`
+	Parameters []string `json:"parameters,omitempty"`
+}
+
+// FeatureFlagsTrait is the typed configuration of the feature-flags trait
+type FeatureFlagsTrait struct {
+	Trait `json:",inline"`
+	// The flags set as headers on each exchange, in the form `name=default`, e.g. `new-checkout=false`. The type of the flag,
+	// i.e. boolean, integer, double or string, is inferred from its default value.
+	Flags []string `json:"flags,omitempty"`
+	// The address, in the form `host:port`, of the remote flagd service the flags are evaluated with.
+	// The flagd sidecar container is not run when it's set.
+	Endpoint string `json:"endpoint,omitempty"`
+	// The ConfigMaps holding the flag definitions read by the flagd sidecar, in the form `name[/key]`,
+	// the key defaulting to `flags.json`.
+	ConfigMaps []string `json:"configmaps,omitempty"`
+	// The additional sources of flag definitions read by the flagd sidecar, as flagd URIs,
+	// e.g. `https://acme.com/flags.json` or `core.openfeature.dev/my-namespace/my-flags`.
+	Sources []string `json:"sources,omitempty"`
+	// The image of the flagd sidecar container.
+	Image string `json:"image,omitempty"`
+	// The port the flagd sidecar container listens on (default `8013`).
+	Port int `json:"port,omitempty"`
+}
+
+// GCTrait is the typed configuration of the gc trait
+type GCTrait struct {
+	Trait `json:",inline"`
+	// Discovery client cache to be used, either `disabled`, `disk` or `memory` (default `memory`)
+	DiscoveryCache *DiscoveryCacheType `json:"discoveryCache,omitempty"`
+	// How the drift of the integration resources is handled, either `correct` or `report` (default `correct`)
+	Drift string `json:"drift,omitempty"`
+}
+
+// GrafanaTrait is the typed configuration of the grafana trait
+type GrafanaTrait struct {
+	Trait `json:",inline"`
+	// The kind of resource the dashboard is provisioned with, either `ConfigMap` or `GrafanaDashboard` (default `ConfigMap`).
+	Kind string `json:"kind,omitempty"`
+	// The labels of the dashboard resource, used by Grafana to discover it.
+	// Defaults to `grafana_dashboard=1` for a ConfigMap, and `app=grafana` for a `GrafanaDashboard` resource.
+	Labels []string `json:"labels,omitempty"`
+	// The Grafana folder the dashboard is added to.
+	Folder string `json:"folder,omitempty"`
+	// The name of the Grafana Prometheus datasource the dashboard queries (default `Prometheus`).
+	Datasource string `json:"datasource,omitempty"`
+}
+
+// HPATrait is the typed configuration of the hpa trait
+type HPATrait struct {
+	Trait `json:",inline"`
+	// The lower limit for the number of replicas to which the autoscaler can scale down (default `1`).
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// The upper limit for the number of replicas to which the autoscaler can scale up (default `10`).
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// The target average CPU utilization of the pods, as a percentage of the requested CPU.
+	// It defaults to `80` when no other metric is set.
+	CPU *int32 `json:"cpu,omitempty"`
+	// The target average memory utilization of the pods, as a percentage of the requested memory.
+	Memory *int32 `json:"memory,omitempty"`
+	// The custom metrics targets, in the form `pods:<metric>=<average value>` for a metric of the pods,
+	// or `external:<metric>=<average value>` for a metric external to the cluster.
+	Metrics []string `json:"metrics,omitempty"`
+	// The Camel metrics targets, in the form `<metric>=<average value>`, e.g. `inflight-exchanges=20`.
+	// The metric is either `inflight-exchanges`, the number of exchanges being processed by the Camel context,
+	// or the name of any other metric exposed by the Integration, like a gauge of the depth of a SEDA queue.
+	// The metrics are selected with the `namespace` and `integration` labels, added by the Prometheus trait,
+	// that must be enabled, and must enrich the metrics.
+	CamelMetrics []string `json:"camelMetrics,omitempty"`
+	// The provider of the Camel metrics, either `prometheus-adapter`, that serves them as external metrics,
+	// and whose rule exposing them must be configured beforehand, as it's not generated by the trait,
+	// or `keda`, that scales the Integration with a KEDA `ScaledObject` instead (default `prometheus-adapter`).
+	MetricsProvider string `json:"metricsProvider,omitempty"`
+	// The URL of the Prometheus server KEDA queries the Camel metrics from.
+	// It defaults to the Prometheus URL configured for the hibernation of the Integrations by the platform.
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+}
+
+// IngressTrait is the typed configuration of the ingress trait
+type IngressTrait struct {
+	Trait `json:",inline"`
+	// **Required**. To configure the host exposed by the ingress.
+	Host string `json:"host,omitempty"`
+	// To configure additional hosts exposed by the ingress.
+	Hosts []string `json:"hosts,omitempty"`
+	// To configure the path exposed by the ingress, e.g. `/{{ .Name }}`.
+	Path string `json:"path,omitempty"`
+	// To configure additional paths exposed by the ingress, for each of its hosts.
+	Paths []string `json:"paths,omitempty"`
+	// The type of the path exposed by the ingress: `Exact`, `Prefix` or `ImplementationSpecific` (default `Prefix`).
+	PathType string `json:"pathType,omitempty"`
+	// The name of the IngressClass the ingress is bound to.
+	IngressClassName string `json:"ingressClassName,omitempty"`
+	// The name of the secret holding the TLS certificate and key for the exposed hosts.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// The name of the cert-manager Issuer to request the TLS certificate from.
+	// The certificate is stored in the secret set with `tls-secret-name`, or `<integration>-tls` by default.
+	CertManagerIssuer string `json:"certManagerIssuer,omitempty"`
+	// The name of the cert-manager ClusterIssuer to request the TLS certificate from.
+	// The certificate is stored in the secret set with `tls-secret-name`, or `<integration>-tls` by default.
+	CertManagerClusterIssuer string `json:"certManagerClusterIssuer,omitempty"`
+	// The ingress controller implementation, used to compute controller specific annotations: `nginx` or `traefik`.
+	// It defaults to the ingress class name when it matches one of them, `nginx` otherwise.
+	Controller string `json:"controller,omitempty"`
+	// To rewrite the path of the requests forwarded to the service, e.g. `/`.
+	RewriteTarget string `json:"rewriteTarget,omitempty"`
+	// The annotations added to the ingress, in the form of `key=value`.
+	Annotations []string `json:"annotations,omitempty"`
+	// To automatically add an ingress whenever the integration uses a HTTP endpoint consumer.
+	Auto *bool `json:"auto,omitempty"`
+}
+
+// IstioTrait is the typed configuration of the istio trait
+type IstioTrait struct {
+	Trait `json:",inline"`
+	// Configures a (comma-separated) list of CIDR subnets that should not be intercepted by the Istio proxy (`10.0.0.0/8,172.16.0.0/12,192.168.0.0/16` by default).
+	Allow string `json:"allow,omitempty"`
+	// Forces the value for labels `sidecar.istio.io/inject`. By default the label is set to `true` on deployment and not set on Knative Service.
+	Inject *bool `json:"inject,omitempty"`
+}
+
+// JDBCTrait is the typed configuration of the jdbc trait
+type JDBCTrait struct {
+	Trait `json:",inline"`
+	// The type of the database, one of `postgresql`, `mysql`, `mariadb`, `mssql`, `oracle`, `db2`, `h2` or `derby`.
+	Type string `json:"type,omitempty"`
+	// The JDBC URL of the database.
+	URL string `json:"url,omitempty"`
+	// The name of the Kubernetes Service exposing the database, used to compute the JDBC URL.
+	Service string `json:"service,omitempty"`
+	// The port of the database service (defaults to the standard port of the database type).
+	Port int `json:"port,omitempty"`
+	// The name of the database, used to compute the JDBC URL.
+	Database string `json:"database,omitempty"`
+	// The name of the Secret holding the `username` and `password` of the database, and optionally its `url`.
+	Secret string `json:"secret,omitempty"`
+	// The minimum size of the connection pool.
+	MinPoolSize *int `json:"minPoolSize,omitempty"`
+	// The maximum size of the connection pool.
+	MaxPoolSize *int `json:"maxPoolSize,omitempty"`
+}
+
+// JolokiaTrait is the typed configuration of the jolokia trait
+type JolokiaTrait struct {
+	Trait `json:",inline"`
+	// The PEM encoded CA certification file path, used to verify client certificates,
+	// applicable when `protocol` is `https` and `use-ssl-client-authentication` is `true`
+	// (default `/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt` for OpenShift).
+	CaCert *string `json:"CACert,omitempty"`
+	// The principal(s) which must be given in a client certificate to allow access to the Jolokia endpoint,
+	// applicable when `protocol` is `https` and `use-ssl-client-authentication` is `true`
+	// (default `clientPrincipal=cn=system:master-proxy`, `cn=hawtio-online.hawtio.svc` and `cn=fuse-console.fuse.svc` for OpenShift).
+	ClientPrincipal []string `json:"clientPrincipal,omitempty"`
+	// Listen for multicast requests (default `false`)
+	DiscoveryEnabled *bool `json:"discoveryEnabled,omitempty"`
+	// Mandate the client certificate contains a client flag in the extended key usage section,
+	// applicable when `protocol` is `https` and `use-ssl-client-authentication` is `true`
+	// (default `true` for OpenShift).
+	ExtendedClientCheck *bool `json:"extendedClientCheck,omitempty"`
+	// The Host address to which the Jolokia agent should bind to. If `"\*"` or `"0.0.0.0"` is given,
+	// the servers binds to every network interface (default `"*"`).
+	Host *string `json:"host,omitempty"`
+	// The password used for authentication, applicable when the `user` option is set.
+	Password *string `json:"password,omitempty"`
+	// The Jolokia endpoint port (default `8778`).
+	Port int `json:"port,omitempty"`
+	// The protocol to use, either `http` or `https` (default `https` for OpenShift)
+	Protocol *string `json:"protocol,omitempty"`
+	// The user to be used for authentication
+	User *string `json:"user,omitempty"`
+	// Whether client certificates should be used for authentication (default `true` for OpenShift).
+	UseSslClientAuthentication *bool `json:"useSSLClientAuthentication,omitempty"`
+	// A list of additional Jolokia options as defined
+	// in https://jolokia.org/reference/html/agents.html#agent-jvm-config[JVM agent configuration options]
+	Options []string `json:"options,omitempty"`
+	// The Secret containing the PEM encoded CA certificate used to verify client certificates, in the form `name[/key]`
+	// (default key `ca.crt`). It takes precedence over `ca-cert`.
+	CaCertSecret string `json:"CACertSecret,omitempty"`
+	// The `kubernetes.io/tls` Secret containing the certificate and the private key the Jolokia endpoint
+	// is served with, applicable when `protocol` is `https`.
+	ServerCertSecret string `json:"serverCertSecret,omitempty"`
+	// Whether the read-only access policy, that only allows the `read`, `list`, `search` and `version` requests,
+	// is enforced (default `true`).
+	ReadOnly *bool `json:"readOnly,omitempty"`
+	// The ConfigMap containing a custom `jolokia-access.xml` access policy file. It takes precedence over `read-only`.
+	PolicyConfigMap string `json:"policyConfigMap,omitempty"`
+	// Whether the integration pods are annotated to be discovered by Hawtio Online (default `true`).
+	Hawtio *bool `json:"hawtio,omitempty"`
+}
+
+// JVMTrait is the typed configuration of the jvm trait
+type JVMTrait struct {
+	Trait `json:",inline"`
+	// Activates remote debugging, so that a debugger can be attached to the JVM, e.g., using port-forwarding
+	Debug *bool `json:"debug,omitempty"`
+	// Suspends the target JVM immediately before the main class is loaded
+	DebugSuspend *bool `json:"debugSuspend,omitempty"`
+	// Prints the command used the start the JVM in the container logs (default `true`)
+	PrintCommand *bool `json:"printCommand,omitempty"`
+	// Transport address at which to listen for the newly launched JVM (default `*:5005`)
+	DebugAddress string `json:"debugAddress,omitempty"`
+	// A list of JVM options
+	Options []string `json:"options,omitempty"`
+	// Additional JVM classpath (use `Linux` classpath separator)
+	Classpath string `json:"classpath,omitempty"`
+}
+
+// KameletsTrait is the typed configuration of the kamelets trait
+type KameletsTrait struct {
+	Trait `json:",inline"`
+}
+
+// KnativeTrait is the typed configuration of the knative trait
+type KnativeTrait struct {
+	Trait `json:",inline"`
+	// Can be used to inject a Knative complete configuration in JSON format.
+	Configuration string `json:"configuration,omitempty"`
+	// List of channels used as source of integration routes.
+	// Can contain simple channel names or full Camel URIs.
+	ChannelSources []string `json:"channelSources,omitempty"`
+	// List of channels used as destination of integration routes.
+	// Can contain simple channel names or full Camel URIs.
+	ChannelSinks []string `json:"channelSinks,omitempty"`
+	// List of channels used as source of integration routes.
+	EndpointSources []string `json:"endpointSources,omitempty"`
+	// List of endpoints used as destination of integration routes.
+	// Can contain simple endpoint names or full Camel URIs.
+	EndpointSinks []string `json:"endpointSinks,omitempty"`
+	// List of event types that the integration will be subscribed to.
+	// Can contain simple event types or full Camel URIs (to use a specific broker different from "default").
+	EventSources []string `json:"eventSources,omitempty"`
+	// List of event types that the integration will produce.
+	// Can contain simple event types or full Camel URIs (to use a specific broker).
+	EventSinks []string `json:"eventSinks,omitempty"`
+	// Enables filtering on events based on the header "ce-knativehistory". Since this header has been removed in newer versions of
+	// Knative, filtering is disabled by default.
+	FilterSourceChannels *bool `json:"filterSourceChannels,omitempty"`
+	// Allows binding the integration to a sink via a Knative SinkBinding resource.
+	// This can be used when the integration targets a single sink.
+	// It's enabled by default when the integration targets a single sink
+	// (except when the integration is owned by a Knative source).
+	SinkBinding *bool `json:"sinkBinding,omitempty"`
+	// Enable automatic discovery of all trait properties.
+	Auto *bool `json:"auto,omitempty"`
+}
+
+// KnativeServiceTrait is the typed configuration of the knative-service trait
+type KnativeServiceTrait struct {
+	Trait `json:",inline"`
+	// Configures the Knative autoscaling class property (e.g. to set `hpa.autoscaling.knative.dev` or `kpa.autoscaling.knative.dev` autoscaling).
+	//
+	// Refer to the Knative documentation for more information.
+	Class string `json:"class,omitempty"`
+	// Configures the Knative autoscaling metric property (e.g. to set `concurrency` based or `cpu` based autoscaling).
+	//
+	// Refer to the Knative documentation for more information.
+	Metric string `json:"autoscalingMetric,omitempty"`
+	// Sets the allowed concurrency level or CPU percentage (depending on the autoscaling metric) for each Pod.
+	//
+	// Refer to the Knative documentation for more information.
+	Target *int `json:"autoscalingTarget,omitempty"`
+	// The minimum number of Pods that should be running at any time for the integration. It's **zero** by default, meaning that
+	// the integration is scaled down to zero when not used for a configured amount of time.
+	//
+	// Refer to the Knative documentation for more information.
+	MinScale *int `json:"minScale,omitempty"`
+	// An upper bound for the number of Pods that can be running in parallel for the integration.
+	// Knative has its own cap value that depends on the installation.
+	//
+	// Refer to the Knative documentation for more information.
+	MaxScale *int `json:"maxScale,omitempty"`
+	// Enables to gradually shift traffic to the latest Revision and sets the rollout duration.
+	// It's disabled by default and must be expressed as a Golang `time.Duration` string representation,
+	// rounded to a second precision.
+	RolloutDuration string `json:"rolloutDuration,omitempty"`
+	// Setting `cluster-local`, the Knative service becomes a private service, only reachable from within the cluster.
+	// Specifically, this option applies the `networking.knative.dev/visibility` label to the Knative service.
+	//
+	// Refer to the Knative documentation for more information.
+	Visibility string `json:"visibility,omitempty"`
+	// The custom domains the integration is exposed on. A Knative DomainMapping is created for each of the domains,
+	// that routes the traffic to the Knative service.
+	//
+	// Refer to the Knative documentation for more information.
+	Domains []string `json:"domains,omitempty"`
+	// The name of the Secret, of type `kubernetes.io/tls`, containing the certificate the custom domains
+	// terminate TLS traffic with. It's only relevant when custom domains are configured.
+	TLSSecret string `json:"tlsSecret,omitempty"`
+	// Automatically deploy the integration as Knative service when all conditions hold:
+	//
+	// * Integration is using the Knative profile
+	// * All routes are either starting from a HTTP based consumer or a passive consumer (e.g. `direct` is a passive consumer)
+	Auto *bool `json:"auto,omitempty"`
+}
+
+// LoggingTrait is the typed configuration of the logging trait
+type LoggingTrait struct {
+	Trait `json:",inline"`
+	// Colorize the log output
+	Color *bool `json:"color,omitempty"`
+	// Logs message format
+	Format string `json:"format,omitempty"`
+	// Adjust the logging level (defaults to INFO)
+	Level string `json:"level,omitempty"`
+	// Output the logs in JSON
+	Json *bool `json:"json,omitempty"`
+	// Enable "pretty printing" of the JSON logs
+	JsonPrettyPrint *bool `json:"jsonPrettyPrint,omitempty"`
+}
+
+// MountTrait is the typed configuration of the mount trait
+type MountTrait struct {
+	Trait `json:",inline"`
+	// The list of mounts, e.g. `secret:my-tls@/etc/tls?items=tls.crt,tls.key&mode=0400` or `emptydir@/tmp/scratch?sizeLimit=500Mi`.
+	Volumes []string `json:"volumes,omitempty"`
+	// The list of properties, resolved from ConfigMaps or Secrets, that are reloaded by the running integration
+	// when the referenced resources change, instead of rolling out the integration.
+	HotReload []string `json:"hotReload,omitempty"`
+}
+
+// NodeTrait is the typed configuration of the node trait
+type NodeTrait struct {
+	Trait `json:",inline"`
+	// The name of the RuntimeClass used to run the integration pods.
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+	// The name of the PriorityClass of the integration pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// The labels of the nodes the integration pods can be scheduled on, in the form of `key=value`.
+	NodeSelector []string `json:"nodeSelector,omitempty"`
+	// The name of the dedicated node pool to schedule the integration pods on.
+	NodePool string `json:"nodePool,omitempty"`
+	// The key of the label and taint identifying the dedicated node pool (default `camel.apache.org/node-pool`).
+	NodePoolLabel string `json:"nodePoolLabel,omitempty"`
+}
+
+// OpenapiTrait is the typed configuration of the openapi trait
+type OpenapiTrait struct {
+	Trait `json:",inline"`
+	// The list of ConfigMaps containing the OpenAPI specifications, one per data entry.
+	Configmaps []string `json:"configmaps,omitempty"`
+	// The HTTP path the effective OpenAPI document is served from at runtime, e.g. `/openapi.json`.
+	ServePath string `json:"servePath,omitempty"`
+	// Enables the validation of the incoming requests against the OpenAPI specification.
+	Validation *bool `json:"validation,omitempty"`
+}
+
+// OwnerTrait is the typed configuration of the owner trait
+type OwnerTrait struct {
+	Trait `json:",inline"`
+	// The set of annotations to be transferred
+	TargetAnnotations []string `json:"targetAnnotations,omitempty"`
+	// The set of labels to be transferred
+	TargetLabels []string `json:"targetLabels,omitempty"`
+	// The labels added to all the owned resources, e.g., `cost-center=finance`
+	Labels []string `json:"labels,omitempty"`
+	// The annotations added to all the owned resources, e.g., `company.com/team=integration`
+	Annotations []string `json:"annotations,omitempty"`
+	// Annotate the owned resources for GitOps controllers, e.g., Argo CD or Flux
+	GitOps *bool `json:"gitops,omitempty"`
+}
+
+// PatchTrait is the typed configuration of the patch trait
+type PatchTrait struct {
+	Trait `json:",inline"`
+	// The patches applied to the generated resources, in the form `<kind>[/<name>]=<patch>`.
+	Patches []string `json:"patches,omitempty"`
+}
+
+// PDBTrait is the typed configuration of the pdb trait
+type PDBTrait struct {
+	Trait `json:",inline"`
+	// The number of pods for the Integration that must still be available after an eviction.
+	// It can be either an absolute number or a percentage.
+	// Only one of `min-available` and `max-unavailable` can be specified.
+	MinAvailable string `json:"minAvailable,omitempty"`
+	// The number of pods for the Integration that can be unavailable after an eviction.
+	// It can be either an absolute number or a percentage (default `1` if `min-available` is also not set).
+	// Only one of `max-unavailable` and `min-available` can be specified.
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+}
+
+// PersistentStateTrait is the typed configuration of the persistent-state trait
+type PersistentStateTrait struct {
+	Trait `json:",inline"`
+	// Deploys the integration as a StatefulSet, so that each replica gets its own persistent volume.
+	StatefulSet *bool `json:"statefulSet,omitempty"`
+	// The name of an existing PersistentVolumeClaim to use, instead of provisioning a new one.
+	// It cannot be used in combination with `stateful-set`.
+	ClaimName string `json:"claimName,omitempty"`
+	// The StorageClass used to provision the persistent volume (the cluster default one is used otherwise).
+	StorageClass string `json:"storageClass,omitempty"`
+	// The size of the persistent volume (default `1Gi`).
+	Size string `json:"size,omitempty"`
+	// The access modes of the persistent volume (default `ReadWriteOnce`).
+	AccessModes []string `json:"accessModes,omitempty"`
+	// The path where the persistent volume is mounted into the integration container (default `/var/lib/camel/state`).
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// PlatformTrait is the typed configuration of the platform trait
+type PlatformTrait struct {
+	Trait `json:",inline"`
+	// To create a default (empty) platform when the platform is missing.
+	CreateDefault *bool `json:"createDefault,omitempty"`
+	// Indicates if the platform should be created globally in the case of global operator (default true).
+	Global *bool `json:"global,omitempty"`
+	// To automatically detect from the environment if a default platform can be created (it will be created on OpenShift only).
+	Auto *bool `json:"auto,omitempty"`
+}
+
+// PodTrait is the typed configuration of the pod trait
+type PodTrait struct {
+	Trait `json:",inline"`
+	// The pod spec template layers, applied in order before the Integration `.spec.podTemplate` field.
+	Templates []string `json:"templates,omitempty"`
+}
+
+// PreflightTrait is the typed configuration of the preflight trait
+type PreflightTrait struct {
+	Trait `json:",inline"`
+	// The list of checks, either TCP addresses in the form `host:port`, or HTTP URLs, e.g. `http://my-service:8080/health`.
+	Checks []string `json:"checks,omitempty"`
+	// Automatically derive the checks from the integration configuration (default `true`).
+	Auto *bool `json:"auto,omitempty"`
+	// The names of the Secrets that must exist for the integration to be deployed.
+	Secrets []string `json:"secrets,omitempty"`
+	// The image of the init containers, that must provide the `nc` and `wget` commands (default `busybox`).
+	Image string `json:"image,omitempty"`
+	// The time in seconds each check waits for its target to become reachable (default `60`).
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// PrometheusTrait is the typed configuration of the prometheus trait
+type PrometheusTrait struct {
+	Trait `json:",inline"`
+	// Whether a `PodMonitor` resource is created (default `true`).
+	PodMonitor *bool `json:"podMonitor,omitempty"`
+	// The `PodMonitor` resource labels, applicable when `pod-monitor` is `true`.
+	// They also apply to the `ServiceMonitor` resource, when `service-monitor` is `true`.
+	PodMonitorLabels []string `json:"podMonitorLabels,omitempty"`
+	// Whether a `ServiceMonitor` resource, targeting the integration Service, is created instead of the `PodMonitor` resource.
+	ServiceMonitor *bool `json:"serviceMonitor,omitempty"`
+	// The interval at which the metrics are scraped, e.g. `30s`.
+	Interval string `json:"interval,omitempty"`
+	// The timeout of the scrape requests, e.g. `10s`.
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// The HTTP path of the metrics endpoint (default `/q/metrics`).
+	Path string `json:"path,omitempty"`
+	// The name of the port the metrics are scraped from. Defaults to the integration container port,
+	// or to the Service port when `service-monitor` is `true`.
+	Port string `json:"port,omitempty"`
+	// Relabelings applied to the scraped targets, in the form `target-label=source-label`.
+	Relabelings []string `json:"relabelings,omitempty"`
+	// Whether the scraped metrics are enriched with the `namespace` and `integration` labels (default `true`).
+	Enrich *bool `json:"enrich,omitempty"`
+}
+
+// PullSecretTrait is the typed configuration of the pull-secret trait
+type PullSecretTrait struct {
+	Trait `json:",inline"`
+	// The pull secret name to set on the Pod. If left empty this is automatically taken from the `IntegrationPlatform` registry configuration.
+	SecretName string `json:"secretName,omitempty"`
+	// When using a global operator with a shared platform, this enables delegation of the `system:image-puller` cluster role on the operator namespace to the integration service account.
+	ImagePullerDelegation *bool `json:"imagePullerDelegation,omitempty"`
+	// Automatically configures the platform registry secret on the pod if it is of type `kubernetes.io/dockerconfigjson`.
+	Auto *bool `json:"auto,omitempty"`
+	// When using a global operator with a shared platform, this enables the replication of the platform registry secret into the integration namespace (disabled by default).
+	Replicate *bool `json:"replicate,omitempty"`
+}
+
+// QuarkusTrait is the typed configuration of the quarkus trait
+type QuarkusTrait struct {
+	Trait `json:",inline"`
+	// The Quarkus package types, either `fast-jar` or `native` (default `fast-jar`).
+	// In case both `fast-jar` and `native` are specified, two `IntegrationKit` resources are created,
+	// with the `native` kit having precedence over the `fast-jar` one once ready.
+	// The order influences the resolution of the current kit for the integration.
+	// The kit corresponding to the first package type will be assigned to the
+	// integration in case no existing kit that matches the integration exists.
+	PackageTypes []QuarkusPackageType `json:"packageTypes,omitempty"`
+	// Build a single `IntegrationKit`, that contains both the `fast-jar` and `native` packages,
+	// rather than one kit per package type, when both package types are specified.
+	// The integration then chooses the package it runs with, using the `runtime-package-type` option.
+	MultiMode *bool `json:"multiMode,omitempty"`
+	// The package type the integration runs with, when its kit contains both the `fast-jar` and `native` packages,
+	// either `fast-jar` or `native` (default `native`).
+	RuntimePackageType QuarkusPackageType `json:"runtimePackageType,omitempty"`
+	// The build-time properties, in the form of `key=value`, that are passed to the Maven build of the integration kit,
+	// e.g. `quarkus.native.additional-build-args=-H:+ReportExceptionStackTraces`.
+	BuildProperties []string `json:"buildProperties,omitempty"`
+	// The extensions that are excluded from the integration kit, including when they are transitive dependencies,
+	// either in the form of `groupId:artifactId`, or `camel:<name>` for Camel Quarkus extensions,
+	// e.g. `io.quarkus:quarkus-smallrye-health`.
+	ExcludedExtensions []string `json:"excludedExtensions,omitempty"`
+}
+
+// RouteTrait is the typed configuration of the route trait
+type RouteTrait struct {
+	Trait `json:",inline"`
+	// To configure the host exposed by the route.
+	Host string `json:"host,omitempty"`
+	// The TLS termination type, like `edge`, `passthrough` or `reencrypt`.
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSTermination string `json:"tlsTermination,omitempty"`
+	// The TLS certificate contents.
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSCertificate string `json:"tlsCertificate,omitempty"`
+	// The secret name and key reference to the TLS certificate. The format is "secret-name[/key-name]", the value represents the secret name, if there is only one key in the secret it will be read, otherwise you can set a key name separated with a "/".
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSCertificateSecret string `json:"tlsCertificateSecret,omitempty"`
+	// The TLS certificate key contents.
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSKey string `json:"tlsKey,omitempty"`
+	// The secret name and key reference to the TLS certificate key. The format is "secret-name[/key-name]", the value represents the secret name, if there is only one key in the secret it will be read, otherwise you can set a key name separated with a "/".
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSKeySecret string `json:"tlsKeySecret,omitempty"`
+	// The TLS CA certificate contents.
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSCACertificate string `json:"tlsCACertificate,omitempty"`
+	// The secret name and key reference to the TLS CA certificate. The format is "secret-name[/key-name]", the value represents the secret name, if there is only one key in the secret it will be read, otherwise you can set a key name separated with a "/".
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSCACertificateSecret string `json:"tlsCACertificateSecret,omitempty"`
+	// The destination CA certificate provides the contents of the ca certificate of the final destination.  When using reencrypt
+	// termination this file should be provided in order to have routers use it for health checks on the secure connection.
+	// If this field is not specified, the router may provide its own destination CA and perform hostname validation using
+	// the short service name (service.namespace.svc), which allows infrastructure generated certificates to automatically
+	// verify.
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSDestinationCACertificate string `json:"tlsDestinationCACertificate,omitempty"`
+	// The secret name and key reference to the destination CA certificate. The format is "secret-name[/key-name]", the value represents the secret name, if there is only one key in the secret it will be read, otherwise you can set a key name separated with a "/".
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSDestinationCACertificateSecret string `json:"tlsDestinationCACertificateSecret,omitempty"`
+	// To configure how to deal with insecure traffic, e.g. `Allow`, `Disable` or `Redirect` traffic.
+	//
+	// Refer to the OpenShift route documentation for additional information.
+	TLSInsecureEdgeTerminationPolicy string `json:"tlsInsecureEdgeTerminationPolicy,omitempty"`
+	// The secret name and key reference to the certificate served by the integration, when it terminates TLS,
+	// i.e. with `passthrough` or `reencrypt` termination. The format is "secret-name[/key-name]".
+	TLSServerCertificateSecret string `json:"tlsServerCertificateSecret,omitempty"`
+	// The secret name and key reference to the key of the certificate served by the integration, when it terminates TLS,
+	// i.e. with `passthrough` or `reencrypt` termination. The format is "secret-name[/key-name]".
+	TLSServerKeySecret string `json:"tlsServerKeySecret,omitempty"`
+	// The client certificate authentication mode of the integration, when it terminates TLS: `none`, `request` or `required`.
+	TLSClientAuth string `json:"tlsClientAuth,omitempty"`
+	// The secret name and key reference to the PEM encoded CA certificates used to validate the client certificates.
+	// The format is "secret-name[/key-name]".
+	TLSClientCACertificateSecret string `json:"tlsClientCACertificateSecret,omitempty"`
+}
+
+// ServiceTrait is the typed configuration of the service trait
+type ServiceTrait struct {
+	Trait `json:",inline"`
+	// To automatically detect from the code if a Service needs to be created.
+	Auto *bool `json:"auto,omitempty"`
+	// Enable Service to be exposed as NodePort
+	NodePort *bool `json:"nodePort,omitempty"`
+	// The type of the Service: `ClusterIP`, `NodePort` or `LoadBalancer`. It takes precedence over `node-port`.
+	Type string `json:"type,omitempty"`
+	// Additional ports exposed by the Service, in the form `name:service-port[:container-port][/protocol]`, e.g. `grpc:9000:9000/TCP`.
+	// When the container port is omitted, the Service targets the container port with the given name, that is expected
+	// to be declared by another trait, e.g. `jolokia:8778` for the port added by the `jolokia` trait.
+	Ports []string `json:"ports,omitempty"`
+	// The application protocols of the Service ports, in the form `port-name=app-protocol`, e.g. `grpc=kubernetes.io/h2c`.
+	AppProtocols []string `json:"appProtocols,omitempty"`
+	// The annotations added to the Service, in the form of `key=value`, e.g. to configure load balancers.
+	Annotations []string `json:"annotations,omitempty"`
+	// Create a headless Service, that directly resolves to the integration pods addresses, including not ready ones.
+	// It can be used for peer discovery by clustered components, like Hazelcast or Infinispan.
+	Headless *bool `json:"headless,omitempty"`
+}
+
+// ServiceAccountTrait is the typed configuration of the service-account trait
+type ServiceAccountTrait struct {
+	Trait `json:",inline"`
+	// Grant the ServiceAccount the permissions the Camel Kubernetes components used by the integration require (default `true`).
+	ComponentPermissions *bool `json:"componentPermissions,omitempty"`
+}
+
+// ServiceBindingTrait is the typed configuration of the service-binding trait
+type ServiceBindingTrait struct {
+	Trait `json:",inline"`
+	// List of Services in the form [[apigroup/]version:]kind:[namespace/]name
+	Services []string `json:"services,omitempty"`
+	// List of Secrets, from the integration namespace, that are directly bound to the integration.
+	// Each Secret must follow the Service Binding specification, i.e. provide at least the `type` entry.
+	Secrets []string `json:"secrets,omitempty"`
+	// How the bindings of the Services are projected into the integration, either `operator`, to rely on the
+	// Service Binding Operator logic, `native`, to project them natively, or `auto` (default), to project them
+	// natively only when the Service Binding Operator is not installed. How the bindings are projected is reported
+	// with the `ServiceBindingAvailable` condition of the integration.
+	Mode string `json:"mode,omitempty"`
+}
+
+// TolerationTrait is the typed configuration of the toleration trait
+type TolerationTrait struct {
+	Trait `json:",inline"`
+	// The list of taints to tolerate, in the form `Key[=Value]:Effect[:Seconds]`
+	Taints []string `json:"taints,omitempty"`
+}
+
+// VPATrait is the typed configuration of the vpa trait
+type VPATrait struct {
+	Trait `json:",inline"`
+	// Apply the recommended resource requests to the integration container on its next deployment.
+	AutoApply *bool `json:"autoApply,omitempty"`
+	// The minimum CPU request that is recommended, or applied, e.g. `100m`.
+	MinCPU string `json:"minCPU,omitempty"`
+	// The maximum CPU request that is recommended, or applied, e.g. `2`.
+	MaxCPU string `json:"maxCPU,omitempty"`
+	// The minimum memory request that is recommended, or applied, e.g. `256Mi`.
+	MinMemory string `json:"minMemory,omitempty"`
+	// The maximum memory request that is recommended, or applied, e.g. `2Gi`.
+	MaxMemory string `json:"maxMemory,omitempty"`
+}
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// SupportedArchitectures are the architectures the kit images can be built for
+var SupportedArchitectures = []string{"amd64", "arm64", "ppc64le", "s390x"}
+
+// ValidateArchitectures checks that the images can be built for each of the given architectures, that are Linux ones.
+// The operating system must not be part of the architectures, e.g., `linux/arm64`.
+func ValidateArchitectures(architectures []string) error {
+	for i, arch := range architectures {
+		if !util.StringSliceExists(SupportedArchitectures, arch) {
+			return fmt.Errorf("unsupported architecture %q: only Linux images can be built, for the %s architectures",
+				arch, strings.Join(SupportedArchitectures, ", "))
+		}
+		if util.StringSliceExists(architectures[:i], arch) {
+			return fmt.Errorf("duplicate architecture %q", arch)
+		}
+	}
+	return nil
+}
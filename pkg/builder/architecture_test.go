@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateArchitectures(t *testing.T) {
+	assert.Nil(t, ValidateArchitectures(nil))
+	assert.Nil(t, ValidateArchitectures([]string{"amd64", "arm64", "ppc64le", "s390x"}))
+
+	for _, arch := range []string{"windows/amd64", "linux/arm64", "darwin", "386", "arm", "riscv64", "AMD64", "", "amd64 --os=windows"} {
+		assert.EqualError(t, ValidateArchitectures([]string{"amd64", arch}),
+			`unsupported architecture "`+arch+`": only Linux images can be built, for the amd64, arm64, ppc64le, s390x architectures`)
+	}
+
+	assert.EqualError(t, ValidateArchitectures([]string{"arm64", "amd64", "arm64"}), `duplicate architecture "arm64"`)
+}
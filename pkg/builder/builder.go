@@ -136,6 +136,7 @@ steps:
 	}
 
 	result.BaseImage = c.BaseImage
+	result.Tests = c.Tests
 	result.Artifacts = make([]v1.Artifact, 0, len(c.Artifacts))
 	result.Artifacts = append(result.Artifacts, c.Artifacts...)
 
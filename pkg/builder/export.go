@@ -0,0 +1,294 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+// ExportFormat is the build tool of a project exported from an integration
+type ExportFormat string
+
+const (
+	// ExportFormatMaven exports a Maven project
+	ExportFormatMaven ExportFormat = "maven"
+	// ExportFormatGradle exports a Gradle project, using the Quarkus Gradle plugin
+	ExportFormatGradle ExportFormat = "gradle"
+	// ExportFormatJBang exports a JBang script
+	ExportFormatJBang ExportFormat = "jbang"
+)
+
+// ExportFormats lists the supported export formats
+var ExportFormats = []ExportFormat{
+	ExportFormatMaven,
+	ExportFormatGradle,
+	ExportFormatJBang,
+}
+
+const (
+	exportRoutesDir   = "routes"
+	jbangScriptName   = "Integration.java"
+	quarkusPluginID   = "quarkus-maven-plugin"
+	applicationConfig = "src/main/resources/application.properties"
+)
+
+// ExportProject generates the files of a project, in the given format, that runs the given sources
+// with the dependencies of the given Maven project, as it is generated for the integration build.
+// The files are returned indexed by their path, relative to the project directory.
+func ExportProject(format ExportFormat, project maven.Project, sources []v1.SourceSpec) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	switch format {
+	case ExportFormatMaven:
+		pom, err := project.MarshalBytes()
+		if err != nil {
+			return nil, err
+		}
+		files["pom.xml"] = pom
+		files[applicationConfig] = []byte(formatProperties(sourcesProperties(sources)))
+		for _, s := range sources {
+			files[path.Join("src/main/resources", exportRoutesDir, s.Name)] = []byte(s.Content)
+		}
+	case ExportFormatGradle:
+		files["settings.gradle"] = []byte(fmt.Sprintf("rootProject.name = '%s'\n", project.ArtifactID))
+		files["build.gradle"] = []byte(generateGradleBuild(project))
+		// The Quarkus Gradle plugin reads the build properties from the application configuration
+		properties := sourcesProperties(sources)
+		for k, v := range project.Properties {
+			if strings.HasPrefix(k, "quarkus.") {
+				properties[k] = v
+			}
+		}
+		files[applicationConfig] = []byte(formatProperties(properties))
+		for _, s := range sources {
+			files[path.Join("src/main/resources", exportRoutesDir, s.Name)] = []byte(s.Content)
+		}
+	case ExportFormatJBang:
+		files[jbangScriptName] = []byte(generateJBangScript(project, sources))
+		for _, s := range sources {
+			files[path.Join(exportRoutesDir, s.Name)] = []byte(s.Content)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	return files, nil
+}
+
+// sourcesProperties returns the Camel K runtime configuration that loads the given sources from the classpath
+func sourcesProperties(sources []v1.SourceSpec) map[string]string {
+	properties := make(map[string]string)
+	for i, s := range sources {
+		properties[fmt.Sprintf("camel.k.sources[%d].location", i)] = "classpath:" + path.Join(exportRoutesDir, s.Name)
+		properties[fmt.Sprintf("camel.k.sources[%d].name", i)] = strings.TrimSuffix(s.Name, path.Ext(s.Name))
+		properties[fmt.Sprintf("camel.k.sources[%d].language", i)] = string(s.InferLanguage())
+	}
+	return properties
+}
+
+func formatProperties(properties map[string]string) string {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "%s=%s\n", k, properties[k])
+	}
+	return sb.String()
+}
+
+func generateGradleBuild(project maven.Project) string {
+	var sb strings.Builder
+
+	sb.WriteString("plugins {\n")
+	sb.WriteString("    id 'java'\n")
+	if version := quarkusPluginVersion(project); version != "" {
+		fmt.Fprintf(&sb, "    id 'io.quarkus' version '%s'\n", version)
+	}
+	sb.WriteString("}\n\n")
+
+	fmt.Fprintf(&sb, "group = '%s'\n", project.GroupID)
+	fmt.Fprintf(&sb, "version = '%s'\n\n", project.Version)
+
+	sb.WriteString("repositories {\n")
+	sb.WriteString("    mavenCentral()\n")
+	for _, r := range project.Repositories {
+		fmt.Fprintf(&sb, "    maven {\n        url '%s'\n    }\n", r.URL)
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("dependencies {\n")
+	var constraints []maven.Dependency
+	if project.DependencyManagement != nil {
+		for _, d := range project.DependencyManagement.Dependencies {
+			if d.Type == "pom" && d.Scope == "import" {
+				fmt.Fprintf(&sb, "    implementation enforcedPlatform('%s:%s:%s')\n", d.GroupID, d.ArtifactID, d.Version)
+			} else {
+				constraints = append(constraints, d)
+			}
+		}
+	}
+	for _, d := range project.Dependencies {
+		configuration := gradleConfiguration(d.Scope)
+		if d.Exclusions == nil || len(*d.Exclusions) == 0 {
+			fmt.Fprintf(&sb, "    %s %s\n", configuration, gradleNotation(d))
+			continue
+		}
+		fmt.Fprintf(&sb, "    %s(%s) {\n", configuration, gradleNotation(d))
+		for _, e := range *d.Exclusions {
+			fmt.Fprintf(&sb, "        exclude group: '%s', module: '%s'\n", e.GroupID, e.ArtifactID)
+		}
+		sb.WriteString("    }\n")
+	}
+	if len(constraints) > 0 {
+		sb.WriteString("    constraints {\n")
+		for _, d := range constraints {
+			fmt.Fprintf(&sb, "        implementation %s\n", gradleNotation(d))
+		}
+		sb.WriteString("    }\n")
+	}
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+func gradleConfiguration(scope string) string {
+	switch scope {
+	case "provided":
+		return "compileOnly"
+	case "runtime":
+		return "runtimeOnly"
+	case "test":
+		return "testImplementation"
+	default:
+		return "implementation"
+	}
+}
+
+func gradleNotation(d maven.Dependency) string {
+	if d.Classifier == "" && (d.Type == "" || d.Type == "jar") {
+		if d.Version == "" {
+			return fmt.Sprintf("'%s:%s'", d.GroupID, d.ArtifactID)
+		}
+		return fmt.Sprintf("'%s:%s:%s'", d.GroupID, d.ArtifactID, d.Version)
+	}
+
+	notation := fmt.Sprintf("group: '%s', name: '%s'", d.GroupID, d.ArtifactID)
+	if d.Version != "" {
+		notation += fmt.Sprintf(", version: '%s'", d.Version)
+	}
+	if d.Classifier != "" {
+		notation += fmt.Sprintf(", classifier: '%s'", d.Classifier)
+	}
+	if d.Type != "" && d.Type != "jar" {
+		notation += fmt.Sprintf(", ext: '%s'", d.Type)
+	}
+	return notation
+}
+
+func generateJBangScript(project maven.Project, sources []v1.SourceSpec) string {
+	var sb strings.Builder
+
+	sb.WriteString("///usr/bin/env jbang \"$0\" \"$@\" ; exit $?\n")
+
+	if len(project.Repositories) > 0 {
+		repositories := []string{"mavencentral"}
+		for _, r := range project.Repositories {
+			repositories = append(repositories, fmt.Sprintf("%s=%s", r.ID, r.URL))
+		}
+		fmt.Fprintf(&sb, "//REPOS %s\n", strings.Join(repositories, ","))
+	}
+
+	// JBang does not support dependency constraints, so the versions of the managed dependencies,
+	// e.g. the locked ones, are set on the matching direct dependencies
+	managed := make(map[string]string)
+	if project.DependencyManagement != nil {
+		for _, d := range project.DependencyManagement.Dependencies {
+			if d.Type == "pom" && d.Scope == "import" {
+				fmt.Fprintf(&sb, "//DEPS %s:%s:%s@pom\n", d.GroupID, d.ArtifactID, d.Version)
+			} else {
+				managed[d.GroupID+":"+d.ArtifactID] = d.Version
+			}
+		}
+	}
+	for _, d := range project.Dependencies {
+		version := d.Version
+		if v, ok := managed[d.GroupID+":"+d.ArtifactID]; ok && version == "" {
+			version = v
+		}
+		gav := d.GroupID + ":" + d.ArtifactID
+		if version != "" {
+			gav += ":" + version
+		}
+		if d.Classifier != "" {
+			gav += ":" + d.Classifier
+		}
+		if d.Type != "" && d.Type != "jar" {
+			gav += "@" + d.Type
+		}
+		fmt.Fprintf(&sb, "//DEPS %s\n", gav)
+		if d.Exclusions != nil {
+			for _, e := range *d.Exclusions {
+				fmt.Fprintf(&sb, "// The exclusion of %s:%s from %s:%s is not supported by JBang\n", e.GroupID, e.ArtifactID, d.GroupID, d.ArtifactID)
+			}
+		}
+	}
+
+	for _, s := range sources {
+		fmt.Fprintf(&sb, "//FILES %s\n", path.Join(exportRoutesDir, s.Name))
+	}
+
+	properties := sourcesProperties(sources)
+	for k, v := range project.Properties {
+		if strings.HasPrefix(k, "quarkus.") {
+			properties[k] = v
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(formatProperties(properties)), "\n") {
+		if line != "" {
+			fmt.Fprintf(&sb, "//Q:CONFIG %s\n", line)
+		}
+	}
+
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "public class %s {\n", strings.TrimSuffix(jbangScriptName, ".java"))
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+func quarkusPluginVersion(project maven.Project) string {
+	if project.Build == nil {
+		return ""
+	}
+	for _, p := range project.Build.Plugins {
+		if p.GroupID == "io.quarkus" && p.ArtifactID == quarkusPluginID {
+			return p.Version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+func TestExportProject(t *testing.T) {
+	project := GenerateQuarkusProjectCommon("2.2.0", "1.9.0", "2.2.3.Final")
+	project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-log", "")
+	project.AddDependencyGAV("org.jolokia", "jolokia-jvm", "1.7.1")
+	project.AddDependencyExclusion(maven.NewDependency("org.jolokia", "jolokia-jvm", "1.7.1"), maven.Exclusion{GroupID: "com.sun", ArtifactID: "tools"})
+	project.AddManagedDependency(maven.NewDependency("org.apache.camel.quarkus", "camel-quarkus-log", "2.2.1"))
+	project.Repositories = append(project.Repositories, v1.Repository{ID: "my-repo", URL: "https://repo.example.com/maven2"})
+
+	sources := []v1.SourceSpec{
+		{
+			DataSpec: v1.DataSpec{
+				Name:    "routes.groovy",
+				Content: `from("timer:tick").to("log:info")`,
+			},
+		},
+	}
+
+	files, err := ExportProject(ExportFormatMaven, project, sources)
+	assert.Nil(t, err)
+	assert.Contains(t, string(files["pom.xml"]), "<artifactId>camel-quarkus-log</artifactId>")
+	assert.Equal(t, `from("timer:tick").to("log:info")`, string(files["src/main/resources/routes/routes.groovy"]))
+	assert.Equal(t, "camel.k.sources[0].language=groovy\n"+
+		"camel.k.sources[0].location=classpath:routes/routes.groovy\n"+
+		"camel.k.sources[0].name=routes\n", string(files["src/main/resources/application.properties"]))
+
+	files, err = ExportProject(ExportFormatGradle, project, sources)
+	assert.Nil(t, err)
+	assert.Equal(t, "rootProject.name = 'camel-k-integration'\n", string(files["settings.gradle"]))
+	build := string(files["build.gradle"])
+	assert.Contains(t, build, "    id 'io.quarkus' version '2.2.3.Final'\n")
+	assert.Contains(t, build, "    maven {\n        url 'https://repo.example.com/maven2'\n    }\n")
+	assert.Contains(t, build, "    implementation enforcedPlatform('org.apache.camel.quarkus:camel-quarkus-bom:2.2.0')\n")
+	assert.Contains(t, build, "    implementation enforcedPlatform('org.apache.camel.k:camel-k-runtime-bom:1.9.0')\n")
+	assert.Contains(t, build, "    implementation 'org.apache.camel.quarkus:camel-quarkus-log'\n")
+	assert.Contains(t, build, "    implementation('org.jolokia:jolokia-jvm:1.7.1') {\n        exclude group: 'com.sun', module: 'tools'\n    }\n")
+	assert.Contains(t, build, "    constraints {\n        implementation 'org.apache.camel.quarkus:camel-quarkus-log:2.2.1'\n    }\n")
+	assert.Contains(t, string(files["src/main/resources/application.properties"]), "quarkus.package.type=fast-jar\n")
+	assert.Contains(t, files, "src/main/resources/routes/routes.groovy")
+
+	files, err = ExportProject(ExportFormatJBang, project, sources)
+	assert.Nil(t, err)
+	script := string(files["Integration.java"])
+	assert.Contains(t, script, "//REPOS mavencentral,my-repo=https://repo.example.com/maven2\n")
+	assert.Contains(t, script, "//DEPS org.apache.camel.quarkus:camel-quarkus-bom:2.2.0@pom\n")
+	assert.Contains(t, script, "//DEPS org.apache.camel.quarkus:camel-quarkus-log:2.2.1\n")
+	assert.Contains(t, script, "//DEPS org.jolokia:jolokia-jvm:1.7.1\n")
+	assert.Contains(t, script, "//FILES routes/routes.groovy\n")
+	assert.Contains(t, script, "//Q:CONFIG camel.k.sources[0].location=classpath:routes/routes.groovy\n")
+	assert.Contains(t, script, "public class Integration {\n}\n")
+	assert.Contains(t, files, "routes/routes.groovy")
+
+	_, err = ExportProject("ant", project, sources)
+	assert.EqualError(t, err, "unsupported export format: ant")
+}
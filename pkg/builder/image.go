@@ -37,6 +37,9 @@ const (
 	ContextDir      = "context"
 	DeploymentDir   = "/deployments"
 	DependenciesDir = "dependencies"
+	// FIPSSecurityFile is the file, relative to the deployment directory, that overrides the JVM security
+	// properties so that only the FIPS validated crypto providers are used
+	FIPSSecurityFile = "fips.java.security"
 )
 
 func init() {
@@ -49,6 +52,7 @@ type imageSteps struct {
 	StandardImageContext    Step
 	ExecutableDockerfile    Step
 	JvmDockerfile           Step
+	FIPSSecurity            Step
 }
 
 var Image = imageSteps{
@@ -57,6 +61,7 @@ var Image = imageSteps{
 	StandardImageContext:    NewStep(ApplicationPackagePhase, standardImageContext),
 	ExecutableDockerfile:    NewStep(ApplicationPackagePhase+1, executableDockerfile),
 	JvmDockerfile:           NewStep(ApplicationPackagePhase+1, jvmDockerfile),
+	FIPSSecurity:            NewStep(ApplicationPackagePhase+1, fipsSecurity),
 }
 
 type artifactsSelector func(ctx *builderContext) error
@@ -120,6 +125,19 @@ func jvmDockerfile(ctx *builderContext) error {
 	return nil
 }
 
+// fipsSecurity adds the security properties that restrict the JVM to the Bouncy Castle FIPS providers to the image context
+func fipsSecurity(ctx *builderContext) error {
+	security := []byte(`security.provider.1=org.bouncycastle.jcajce.provider.BouncyCastleFipsProvider
+security.provider.2=org.bouncycastle.jsse.provider.BouncyCastleJsseProvider fips:BCFIPS
+security.provider.3=SUN
+securerandom.strongAlgorithms=DEFAULT:BCFIPS
+ssl.KeyManagerFactory.algorithm=PKIX
+ssl.TrustManagerFactory.algorithm=PKIX
+`)
+
+	return ioutil.WriteFile(path.Join(ctx.Path, ContextDir, FIPSSecurityFile), security, 0644)
+}
+
 func incrementalImageContext(ctx *builderContext) error {
 	if ctx.Build.BaseImage != "" {
 		// If the build requires a specific image, don't try to determine the
@@ -131,11 +149,19 @@ func incrementalImageContext(ctx *builderContext) error {
 	if err != nil {
 		return err
 	}
+	baseImages, err := listBaseImages(ctx)
+	if err != nil {
+		return err
+	}
 
 	return imageContext(ctx, func(ctx *builderContext) error {
 		ctx.SelectedArtifacts = ctx.Artifacts
 
 		bestImage, commonLibs := findBestImage(images, ctx.Artifacts)
+		if bestImage.Image == "" {
+			// Layer the image on the base kit, so that only the integration specific artifacts are added
+			bestImage, commonLibs = findBaseImage(baseImages, ctx.Artifacts)
+		}
 		if bestImage.Image != "" {
 			ctx.BaseImage = bestImage.Image
 			ctx.SelectedArtifacts = make([]v1.Artifact, 0)
@@ -188,8 +214,9 @@ func imageContext(ctx *builderContext, selector artifactsSelector) error {
 }
 
 func listPublishedImages(context *builderContext) ([]v1.IntegrationKitStatus, error) {
-	excludeNativeImages, err := labels.NewRequirement(v1.IntegrationKitLayoutLabel, selection.NotEquals, []string{
+	excludeNativeImages, err := labels.NewRequirement(v1.IntegrationKitLayoutLabel, selection.NotIn, []string{
 		v1.IntegrationKitLayoutNative,
+		v1.IntegrationKitLayoutMulti,
 	})
 	if err != nil {
 		return nil, err
@@ -226,6 +253,58 @@ func listPublishedImages(context *builderContext) ([]v1.IntegrationKitStatus, er
 	return images, nil
 }
 
+// listBaseImages returns the base kit images of the platform, that are available for the runtime of the build
+func listBaseImages(context *builderContext) ([]v1.IntegrationKitStatus, error) {
+	list := v1.NewIntegrationKitList()
+	err := context.Client.List(context.C, &list,
+		ctrl.InNamespace(context.Namespace),
+		ctrl.MatchingLabels{
+			v1.IntegrationKitTypeLabel:          v1.IntegrationKitTypeBase,
+			"camel.apache.org/runtime.version":  context.Catalog.Runtime.Version,
+			"camel.apache.org/runtime.provider": string(context.Catalog.Runtime.Provider),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]v1.IntegrationKitStatus, 0)
+	for _, kit := range list.Items {
+		if kit.Status.Phase == v1.IntegrationKitPhaseReady {
+			images = append(images, kit.Status)
+		}
+	}
+	return images, nil
+}
+
+// findBaseImage returns the base image sharing the most artifacts with the given ones. Contrary to findBestImage,
+// the base images are expected to contain artifacts that are not required, i.e., the dependencies shared by most of
+// the integrations of the platform.
+func findBaseImage(images []v1.IntegrationKitStatus, artifacts []v1.Artifact) (v1.IntegrationKitStatus, map[string]bool) {
+	var baseImage v1.IntegrationKitStatus
+	baseImageCommonLibs := make(map[string]bool)
+
+	requiredLibs := make(map[string]string, len(artifacts))
+	for _, entry := range artifacts {
+		requiredLibs[entry.ID] = entry.Checksum
+	}
+
+	for _, image := range images {
+		common := make(map[string]bool)
+		for _, artifact := range image.Artifacts {
+			if artifact.Checksum != "" && requiredLibs[artifact.ID] == artifact.Checksum {
+				common[artifact.ID] = true
+			}
+		}
+		if len(common) > len(baseImageCommonLibs) {
+			baseImage = image
+			baseImageCommonLibs = common
+		}
+	}
+
+	return baseImage, baseImageCommonLibs
+}
+
 func findBestImage(images []v1.IntegrationKitStatus, artifacts []v1.Artifact) (v1.IntegrationKitStatus, map[string]bool) {
 	var bestImage v1.IntegrationKitStatus
 
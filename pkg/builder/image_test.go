@@ -92,3 +92,45 @@ func TestListPublishedImages(t *testing.T) {
 	assert.Len(t, i, 1)
 	assert.Equal(t, "image-2", i[0].Image)
 }
+
+func TestFindBaseImage(t *testing.T) {
+	artifacts := []v1.Artifact{
+		{ID: "camel-core.jar", Checksum: "sha1:core"},
+		{ID: "camel-http.jar", Checksum: "sha1:http"},
+		{ID: "camel-kafka.jar", Checksum: "sha1:kafka"},
+	}
+	images := []v1.IntegrationKitStatus{
+		{
+			Image: "base-1",
+			Artifacts: []v1.Artifact{
+				{ID: "camel-core.jar", Checksum: "sha1:core"},
+				{ID: "camel-jms.jar", Checksum: "sha1:jms"},
+				{ID: "camel-mail.jar", Checksum: "sha1:mail"},
+				{ID: "camel-sql.jar", Checksum: "sha1:sql"},
+			},
+		},
+		{
+			Image: "base-2",
+			Artifacts: []v1.Artifact{
+				{ID: "camel-core.jar", Checksum: "sha1:core"},
+				{ID: "camel-http.jar", Checksum: "sha1:http"},
+				{ID: "camel-jms.jar", Checksum: "sha1:jms"},
+				{ID: "camel-mail.jar", Checksum: "sha1:mail"},
+				{ID: "camel-sql.jar", Checksum: "sha1:sql"},
+			},
+		},
+	}
+
+	// The surplus artifacts of the base images do not prevent them from being selected
+	image, common := findBestImage(images, artifacts)
+	assert.Equal(t, "", image.Image)
+	assert.Empty(t, common)
+
+	image, common = findBaseImage(images, artifacts)
+	assert.Equal(t, "base-2", image.Image)
+	assert.Equal(t, map[string]bool{"camel-core.jar": true, "camel-http.jar": true}, common)
+
+	image, common = findBaseImage(images, []v1.Artifact{{ID: "camel-core.jar", Checksum: "sha1:other"}})
+	assert.Equal(t, "", image.Image)
+	assert.Empty(t, common)
+}
@@ -19,10 +19,14 @@ package builder
 
 import (
 	"os"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/jvm"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/maven"
 )
 
 func init() {
@@ -43,6 +47,7 @@ type projectSteps struct {
 	GenerateProjectSettings Step
 	InjectDependencies      Step
 	SanitizeDependencies    Step
+	InjectTests             Step
 
 	CommonSteps []Step
 }
@@ -53,6 +58,7 @@ var Project = projectSteps{
 	GenerateProjectSettings: NewStep(ProjectGenerationPhase+1, generateProjectSettings),
 	InjectDependencies:      NewStep(ProjectGenerationPhase+2, injectDependencies),
 	SanitizeDependencies:    NewStep(ProjectGenerationPhase+3, sanitizeDependencies),
+	InjectTests:             NewStep(ProjectGenerationPhase+4, injectTests),
 }
 
 func cleanUpBuildDir(ctx *builderContext) error {
@@ -93,12 +99,52 @@ func generateProjectSettings(ctx *builderContext) error {
 		ctx.Maven.SettingsData = []byte(val)
 	}
 
+	// Add the mirrors configured for the build on top of the settings ones
+	if len(ctx.Build.Maven.Mirrors) > 0 {
+		data, err := maven.AddMirrors(ctx.Maven.SettingsData, ctx.Build.Maven.Mirrors)
+		if err != nil {
+			return err
+		}
+		ctx.Maven.SettingsData = data
+	}
+
+	if ctx.Build.Maven.SettingsSecurity != nil {
+		data, err := kubernetes.GetSecretRefData(ctx.C, ctx.Client, ctx.Namespace, ctx.Build.Maven.SettingsSecurity)
+		if err != nil {
+			return err
+		}
+		ctx.Maven.SettingsSecurityData = data
+	}
+
 	return nil
 }
 
 func injectDependencies(ctx *builderContext) error {
 	// Add dependencies from build
-	return camel.ManageIntegrationDependencies(&ctx.Maven.Project, ctx.Build.Dependencies, ctx.Catalog)
+	err := camel.ManageIntegrationDependencies(&ctx.Maven.Project, ctx.Build.Dependencies, ctx.Catalog)
+	if err != nil {
+		return err
+	}
+
+	// Remove the excluded dependencies, including the transitive ones
+	for _, exclusion := range ctx.Build.Exclusions {
+		d, err := maven.ParseGAV(exclusion)
+		if err != nil {
+			return errors.Wrapf(err, "invalid dependency exclusion %s", exclusion)
+		}
+		ctx.Maven.Project.ExcludeDependency(d.GroupID, d.ArtifactID)
+	}
+
+	// Enforce the versions of the locked dependencies, including the transitive ones
+	for _, dependency := range ctx.Build.LockedDependencies {
+		d, err := maven.ParseGAV(strings.TrimPrefix(dependency, "mvn:"))
+		if err != nil {
+			return errors.Wrapf(err, "invalid locked dependency %s", dependency)
+		}
+		ctx.Maven.Project.AddManagedDependency(d)
+	}
+
+	return nil
 }
 
 func sanitizeDependencies(ctx *builderContext) error {
@@ -27,6 +27,7 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/maven"
 	"github.com/apache/camel-k/pkg/util/test"
 )
 
@@ -123,3 +124,79 @@ func TestMavenSettingsFromSecret(t *testing.T) {
 
 	assert.Equal(t, []byte("setting-data"), ctx.Maven.SettingsData)
 }
+
+func TestMavenSettingsSecurityFromSecret(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	c, err := test.NewFakeClient(
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "maven-settings-security",
+			},
+			Data: map[string][]byte{
+				"settings-security.xml": []byte("settings-security-data"),
+			},
+		},
+	)
+
+	assert.Nil(t, err)
+
+	ctx := builderContext{
+		Catalog:   catalog,
+		Client:    c,
+		Namespace: "ns",
+		Build: v1.BuilderTask{
+			Runtime: catalog.Runtime,
+			Maven: v1.MavenSpec{
+				SettingsSecurity: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "maven-settings-security",
+					},
+					Key: "settings-security.xml",
+				},
+			},
+		},
+	}
+
+	err = Project.GenerateProjectSettings.execute(&ctx)
+	assert.Nil(t, err)
+
+	assert.Nil(t, ctx.Maven.SettingsData)
+	assert.Equal(t, []byte("settings-security-data"), ctx.Maven.SettingsSecurityData)
+}
+
+func TestInjectLockedDependencies(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	ctx := builderContext{
+		Catalog: catalog,
+		Build: v1.BuilderTask{
+			Runtime:      catalog.Runtime,
+			Dependencies: []string{"camel:log"},
+			LockedDependencies: []string{
+				"mvn:org.apache.camel:camel-log:3.11.1",
+				"mvn:org.apache.camel:camel-support:3.11.1",
+			},
+		},
+	}
+	ctx.Maven.Project = GenerateQuarkusProjectCommon("2.2.0", "1.9.0", "2.2.3.Final")
+
+	err = Project.InjectDependencies.execute(&ctx)
+	assert.Nil(t, err)
+
+	managed := ctx.Maven.Project.DependencyManagement.Dependencies
+	assert.Contains(t, managed, maven.NewDependency("org.apache.camel", "camel-log", "3.11.1"))
+	assert.Contains(t, managed, maven.NewDependency("org.apache.camel", "camel-support", "3.11.1"))
+	assert.NotNil(t, ctx.Maven.Project.LookupDependency(maven.NewDependency("org.apache.camel.quarkus", "camel-quarkus-log", "")))
+
+	ctx.Build.LockedDependencies = []string{"mvn:camel-log"}
+	err = Project.InjectDependencies.execute(&ctx)
+	assert.NotNil(t, err)
+}
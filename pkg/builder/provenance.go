@@ -0,0 +1,373 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	ociv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+const (
+	// InTotoStatementType is the type of the in-toto attestation statements
+	InTotoStatementType = "https://in-toto.io/Statement/v0.1"
+	// SLSAProvenancePredicateType is the type of the SLSA provenance predicates
+	SLSAProvenancePredicateType = "https://slsa.dev/provenance/v0.2"
+	// ProvenanceBuildType identifies the builds of the kit images, performed by the operator
+	ProvenanceBuildType = "https://camel.apache.org/camel-k/build/v1"
+	// ProvenanceMediaType is the media type of the provenance attestations
+	ProvenanceMediaType types.MediaType = "application/vnd.in-toto+json"
+	// ProvenanceTagSuffix is the suffix of the tag the attestation is published to, alongside the image,
+	// the tag being derived from the digest of the image
+	ProvenanceTagSuffix = ".att"
+)
+
+// Statement is an in-toto attestation statement, about the image built for a kit
+type Statement struct {
+	Type          string     `json:"_type"`
+	PredicateType string     `json:"predicateType"`
+	Subject       []Subject  `json:"subject"`
+	Predicate     Provenance `json:"predicate"`
+}
+
+// Subject is an artifact the statement is about
+type Subject struct {
+	Name   string    `json:"name"`
+	Digest DigestSet `json:"digest"`
+}
+
+// DigestSet maps the digest algorithms to the hex-encoded digests of an artifact
+type DigestSet map[string]string
+
+// Provenance is the SLSA provenance predicate, describing how the image has been built
+type Provenance struct {
+	Builder    ProvenanceBuilder    `json:"builder"`
+	BuildType  string               `json:"buildType"`
+	Invocation ProvenanceInvocation `json:"invocation"`
+	Metadata   ProvenanceMetadata   `json:"metadata"`
+	Materials  []Material           `json:"materials,omitempty"`
+}
+
+// ProvenanceBuilder identifies the builder the image has been built with
+type ProvenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+// ProvenanceInvocation records the parameters the build has been executed with
+type ProvenanceInvocation struct {
+	Parameters  ProvenanceParameters `json:"parameters"`
+	Environment map[string]string    `json:"environment,omitempty"`
+}
+
+// ProvenanceParameters are the parameters of the build that influence the content of the image
+type ProvenanceParameters struct {
+	Runtime         v1.RuntimeSpec `json:"runtime"`
+	Dependencies    []string       `json:"dependencies,omitempty"`
+	Exclusions      []string       `json:"exclusions,omitempty"`
+	MavenProfiles   []string       `json:"mavenProfiles,omitempty"`
+	PublishStrategy string         `json:"publishStrategy,omitempty"`
+}
+
+// ProvenanceMetadata records the timestamps of the build
+type ProvenanceMetadata struct {
+	BuildInvocationID string                 `json:"buildInvocationId,omitempty"`
+	BuildStartedOn    *time.Time             `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *time.Time             `json:"buildFinishedOn,omitempty"`
+	Completeness      ProvenanceCompleteness `json:"completeness"`
+	Reproducible      bool                   `json:"reproducible"`
+}
+
+// ProvenanceCompleteness tells which parts of the provenance are complete
+type ProvenanceCompleteness struct {
+	Parameters  bool `json:"parameters"`
+	Environment bool `json:"environment"`
+	Materials   bool `json:"materials"`
+}
+
+// Material is an artifact the image has been built from
+type Material struct {
+	URI    string    `json:"uri"`
+	Digest DigestSet `json:"digest,omitempty"`
+}
+
+// NewProvenance returns the provenance of the image of the given succeeded build, that has been executed
+// with the given builder image. The materials are the base image, the sources and resources of the build,
+// the Maven repositories the dependencies are resolved from, and the dependencies packaged into the image.
+func NewProvenance(build *v1.Build, builderImage string) (*Statement, error) {
+	if build.Status.Phase != v1.BuildPhaseSucceeded {
+		return nil, errors.Errorf("build %s has not succeeded", build.Name)
+	}
+	digest, err := parseDigest(build.Status.Digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid digest of the image of build %s", build.Name)
+	}
+
+	statement := Statement{
+		Type:          InTotoStatementType,
+		PredicateType: SLSAProvenancePredicateType,
+		Subject: []Subject{
+			{
+				Name:   imageRepository(build.Status.Image),
+				Digest: digest,
+			},
+		},
+		Predicate: Provenance{
+			Builder: ProvenanceBuilder{
+				ID: builderImage,
+			},
+			BuildType: ProvenanceBuildType,
+			Invocation: ProvenanceInvocation{
+				Environment: map[string]string{
+					"namespace": build.Namespace,
+					"build":     build.Name,
+				},
+			},
+			Metadata: ProvenanceMetadata{
+				BuildInvocationID: string(build.UID),
+				Completeness: ProvenanceCompleteness{
+					Parameters: true,
+				},
+			},
+		},
+	}
+
+	if build.Status.StartedAt != nil {
+		startedOn := build.Status.StartedAt.UTC()
+		statement.Predicate.Metadata.BuildStartedOn = &startedOn
+		if duration, err := time.ParseDuration(build.Status.Duration); err == nil {
+			finishedOn := startedOn.Add(duration)
+			statement.Predicate.Metadata.BuildFinishedOn = &finishedOn
+		}
+	}
+
+	materials := make([]Material, 0)
+	if baseImage := build.Status.BaseImage; baseImage != "" {
+		materials = append(materials, imageMaterial(baseImage))
+	}
+	repositories := make(map[string]bool)
+	for _, task := range build.Spec.Tasks {
+		if t := task.Builder; t != nil {
+			statement.Predicate.Invocation.Parameters.Runtime = t.Runtime
+			statement.Predicate.Invocation.Parameters.Dependencies = t.Dependencies
+			statement.Predicate.Invocation.Parameters.Exclusions = t.Exclusions
+			statement.Predicate.Invocation.Parameters.MavenProfiles = t.Maven.Profiles
+			if build.Status.BaseImage == "" && t.BaseImage != "" {
+				materials = append(materials, imageMaterial(t.BaseImage))
+			}
+			for _, s := range t.Sources {
+				materials = append(materials, dataMaterial("source", s.DataSpec))
+			}
+			for _, r := range t.Resources {
+				materials = append(materials, dataMaterial("resource", r.DataSpec))
+			}
+			for _, r := range t.Maven.Repositories {
+				repositories[r.URL] = true
+			}
+			for _, m := range t.Maven.Mirrors {
+				repositories[m.URL] = true
+			}
+		} else {
+			statement.Predicate.Invocation.Parameters.PublishStrategy = publishStrategy(task)
+		}
+	}
+	if build.Status.Maven != nil {
+		for _, r := range build.Status.Maven.Repositories {
+			repositories[r.URL] = true
+		}
+		for _, m := range build.Status.Maven.Mirrors {
+			repositories[m.URL] = true
+		}
+	}
+	urls := make([]string, 0, len(repositories))
+	for url := range repositories {
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	sort.Strings(urls)
+	for _, url := range urls {
+		materials = append(materials, Material{URI: url})
+	}
+	for _, a := range build.Status.Artifacts {
+		material := Material{URI: a.Target}
+		if algorithm, value, ok := splitDigest(a.Checksum); ok {
+			material.Digest = DigestSet{algorithm: value}
+		}
+		materials = append(materials, material)
+	}
+	statement.Predicate.Materials = materials
+
+	return &statement, nil
+}
+
+// Digest returns the digest of the serialized statement
+func (s *Statement) Digest() (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// ProvenanceReference returns the reference the attestation of the given image is published to, i.e., the tag
+// of the image repository derived from the image digest, following the convention of the Sigstore tooling
+func ProvenanceReference(image string, digest string) string {
+	return imageRepository(image) + ":" + strings.Replace(digest, ":", "-", 1) + ProvenanceTagSuffix
+}
+
+// ProvenanceImage packages the given statement into an OCI artifact, to be published alongside the image
+func ProvenanceImage(statement *Statement) (ociv1.Image, error) {
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+	img, err := mutate.AppendLayers(empty.Image, provenanceLayer{data: data})
+	if err != nil {
+		return nil, err
+	}
+	return mutate.MediaType(img, types.OCIManifestSchema1), nil
+}
+
+// provenanceLayer is an uncompressed layer, whose content is the JSON serialized attestation
+type provenanceLayer struct {
+	data []byte
+}
+
+func (l provenanceLayer) Digest() (ociv1.Hash, error) {
+	h, _, err := ociv1.SHA256(bytes.NewReader(l.data))
+	return h, err
+}
+
+func (l provenanceLayer) DiffID() (ociv1.Hash, error) {
+	return l.Digest()
+}
+
+func (l provenanceLayer) Compressed() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(l.data)), nil
+}
+
+func (l provenanceLayer) Uncompressed() (io.ReadCloser, error) {
+	return l.Compressed()
+}
+
+func (l provenanceLayer) Size() (int64, error) {
+	return int64(len(l.data)), nil
+}
+
+func (l provenanceLayer) MediaType() (types.MediaType, error) {
+	return ProvenanceMediaType, nil
+}
+
+// imageRepository returns the repository of the given image reference, without its tag or digest
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// imageMaterial returns the material of the given image, whose digest is recorded when the image is
+// referenced by digest
+func imageMaterial(image string) Material {
+	material := Material{URI: "docker://" + image}
+	if i := strings.Index(image, "@"); i >= 0 {
+		if digest, err := parseDigest(image[i+1:]); err == nil {
+			material.Digest = digest
+		}
+	}
+	return material
+}
+
+// dataMaterial returns the material of the given source or resource, whose digest is computed from its content,
+// unless it's referenced from a ConfigMap or a Secret
+func dataMaterial(kind string, data v1.DataSpec) Material {
+	if data.ContentRef != "" {
+		refKind := data.ContentRefKind
+		if refKind == "" {
+			refKind = v1.ContentRefKindConfigMap
+		}
+		key := data.ContentKey
+		if key == "" {
+			key = v1.DefaultContentKey
+		}
+		return Material{URI: refKind + "://" + data.ContentRef + "/" + key}
+	}
+	content := data.RawContent
+	if len(content) == 0 {
+		content = []byte(data.Content)
+	}
+	sum := sha256.Sum256(content)
+	return Material{
+		URI:    kind + ":" + data.Name,
+		Digest: DigestSet{"sha256": hex.EncodeToString(sum[:])},
+	}
+}
+
+// publishStrategy returns the strategy the image is published with by the given task
+func publishStrategy(task v1.Task) string {
+	switch {
+	case task.Buildah != nil:
+		return string(v1.IntegrationPlatformBuildPublishStrategyBuildah)
+	case task.Kaniko != nil:
+		return string(v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	case task.Spectrum != nil:
+		return string(v1.IntegrationPlatformBuildPublishStrategySpectrum)
+	case task.S2i != nil:
+		return string(v1.IntegrationPlatformBuildPublishStrategyS2I)
+	case task.Tekton != nil:
+		return string(v1.IntegrationPlatformBuildPublishStrategyTekton)
+	case task.Local != nil:
+		return string(v1.IntegrationPlatformBuildPublishStrategyLocal)
+	}
+	return ""
+}
+
+func parseDigest(digest string) (DigestSet, error) {
+	algorithm, value, ok := splitDigest(digest)
+	if !ok {
+		return nil, errors.Errorf("malformed digest: %q", digest)
+	}
+	return DigestSet{algorithm: value}, nil
+}
+
+// splitDigest splits the given digest, in the `algorithm:hex` form, into its algorithm and value
+func splitDigest(digest string) (string, string, bool) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
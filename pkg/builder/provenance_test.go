@@ -0,0 +1,161 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func newProvenanceBuild() *v1.Build {
+	startedAt := metav1.NewTime(time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC))
+	return &v1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "kit-123",
+			UID:       "0b6bd5d1",
+		},
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask:     v1.BaseTask{Name: "builder"},
+						Runtime:      v1.RuntimeSpec{Version: "1.9.0", Provider: v1.RuntimeProviderQuarkus, Metadata: map[string]string{"camel.version": "3.11.1"}},
+						Dependencies: []string{"camel:log"},
+						Sources: []v1.SourceSpec{
+							{DataSpec: v1.DataSpec{Name: "routes.groovy", Content: `from("timer:tick").to("log:info")`}},
+							{DataSpec: v1.DataSpec{Name: "large.groovy", ContentRef: "large-source"}},
+						},
+						Maven: v1.MavenSpec{
+							Repositories: []v1.Repository{{ID: "acme", URL: "https://repo.acme.com/maven2"}},
+						},
+					},
+				},
+				{
+					Spectrum: &v1.SpectrumTask{
+						BaseTask: v1.BaseTask{Name: "spectrum"},
+					},
+				},
+			},
+		},
+		Status: v1.BuildStatus{
+			Phase:     v1.BuildPhaseSucceeded,
+			Image:     "registry.acme.com/ns/camel-k-kit-123:12345",
+			Digest:    "sha256:2f2a0d2e",
+			BaseImage: "adoptopenjdk/openjdk11:slim@sha256:8d5bc1d2",
+			StartedAt: &startedAt,
+			Duration:  "1m30s",
+			Artifacts: []v1.Artifact{
+				{ID: "org.apache.camel.camel-core-3.11.1.jar", Target: "dependencies/lib/main/org.apache.camel.camel-core-3.11.1.jar", Checksum: "sha1:a3b4"},
+			},
+			Maven: &v1.MavenBuildStatus{
+				Mirrors: []v1.Mirror{{ID: "central", URL: "https://nexus.acme.com/repository/maven-public", MirrorOf: "central"}},
+			},
+		},
+	}
+}
+
+func TestNewProvenance(t *testing.T) {
+	statement, err := NewProvenance(newProvenanceBuild(), "docker.io/apache/camel-k:1.7.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, InTotoStatementType, statement.Type)
+	assert.Equal(t, SLSAProvenancePredicateType, statement.PredicateType)
+	assert.Equal(t, []Subject{
+		{Name: "registry.acme.com/ns/camel-k-kit-123", Digest: DigestSet{"sha256": "2f2a0d2e"}},
+	}, statement.Subject)
+
+	predicate := statement.Predicate
+	assert.Equal(t, "docker.io/apache/camel-k:1.7.0", predicate.Builder.ID)
+	assert.Equal(t, ProvenanceBuildType, predicate.BuildType)
+	assert.Equal(t, "1.9.0", predicate.Invocation.Parameters.Runtime.Version)
+	assert.Equal(t, "3.11.1", predicate.Invocation.Parameters.Runtime.Metadata["camel.version"])
+	assert.Equal(t, []string{"camel:log"}, predicate.Invocation.Parameters.Dependencies)
+	assert.Equal(t, "Spectrum", predicate.Invocation.Parameters.PublishStrategy)
+	assert.Equal(t, "0b6bd5d1", predicate.Metadata.BuildInvocationID)
+	assert.Equal(t, time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC), *predicate.Metadata.BuildStartedOn)
+	assert.Equal(t, time.Date(2021, 10, 1, 12, 1, 30, 0, time.UTC), *predicate.Metadata.BuildFinishedOn)
+
+	sum := sha256.Sum256([]byte(`from("timer:tick").to("log:info")`))
+	assert.Equal(t, []Material{
+		{URI: "docker://adoptopenjdk/openjdk11:slim@sha256:8d5bc1d2", Digest: DigestSet{"sha256": "8d5bc1d2"}},
+		{URI: "source:routes.groovy", Digest: DigestSet{"sha256": hex.EncodeToString(sum[:])}},
+		{URI: "configmap://large-source/content"},
+		{URI: "https://nexus.acme.com/repository/maven-public"},
+		{URI: "https://repo.acme.com/maven2"},
+		{URI: "dependencies/lib/main/org.apache.camel.camel-core-3.11.1.jar", Digest: DigestSet{"sha1": "a3b4"}},
+	}, predicate.Materials)
+}
+
+func TestNewProvenanceWithoutDigest(t *testing.T) {
+	build := newProvenanceBuild()
+	build.Status.Digest = ""
+	_, err := NewProvenance(build, "docker.io/apache/camel-k:1.7.0")
+	assert.Error(t, err)
+
+	build = newProvenanceBuild()
+	build.Status.Phase = v1.BuildPhaseFailed
+	_, err = NewProvenance(build, "docker.io/apache/camel-k:1.7.0")
+	assert.Error(t, err)
+}
+
+func TestProvenanceReference(t *testing.T) {
+	assert.Equal(t, "registry.acme.com/ns/camel-k-kit-123:sha256-2f2a0d2e.att",
+		ProvenanceReference("registry.acme.com/ns/camel-k-kit-123:12345", "sha256:2f2a0d2e"))
+	assert.Equal(t, "localhost:5000/camel-k-kit-123:sha256-2f2a0d2e.att",
+		ProvenanceReference("localhost:5000/camel-k-kit-123@sha256:2f2a0d2e", "sha256:2f2a0d2e"))
+}
+
+func TestProvenanceImage(t *testing.T) {
+	statement, err := NewProvenance(newProvenanceBuild(), "docker.io/apache/camel-k:1.7.0")
+	require.NoError(t, err)
+
+	img, err := ProvenanceImage(statement)
+	require.NoError(t, err)
+	_, err = img.Digest()
+	require.NoError(t, err)
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+
+	mediaType, err := layers[0].MediaType()
+	require.NoError(t, err)
+	assert.Equal(t, ProvenanceMediaType, mediaType)
+
+	layerDigest, err := layers[0].Digest()
+	require.NoError(t, err)
+	digest, err := statement.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, digest, layerDigest.String())
+
+	content, err := layers[0].Uncompressed()
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(content)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"predicateType":"https://slsa.dev/provenance/v0.2"`)
+}
@@ -34,6 +34,8 @@ import (
 	"github.com/apache/camel-k/pkg/util/maven"
 )
 
+const nativeOutputDir = "native"
+
 func init() {
 	registerSteps(Quarkus)
 
@@ -49,6 +51,7 @@ type quarkusSteps struct {
 	GenerateQuarkusProject     Step
 	BuildQuarkusRunner         Step
 	ComputeQuarkusDependencies Step
+	BuildQuarkusNativeRunner   Step
 
 	CommonSteps []Step
 }
@@ -58,6 +61,7 @@ var Quarkus = quarkusSteps{
 	GenerateQuarkusProject:     NewStep(ProjectGenerationPhase, generateQuarkusProject),
 	BuildQuarkusRunner:         NewStep(ProjectBuildPhase, buildQuarkusRunner),
 	ComputeQuarkusDependencies: NewStep(ProjectBuildPhase+1, computeQuarkusDependencies),
+	BuildQuarkusNativeRunner:   NewStep(ProjectBuildPhase+2, buildQuarkusNativeRunner),
 }
 
 func loadCamelQuarkusCatalog(ctx *builderContext) error {
@@ -150,10 +154,66 @@ func GenerateQuarkusProjectCommon(camelQuarkusVersion string, runtimeVersion str
 }
 
 func buildQuarkusRunner(ctx *builderContext) error {
+	mc := newQuarkusMavenContext(ctx)
+
+	err := BuildQuarkusRunnerCommon(ctx.C, mc, ctx.Maven.Project)
+
+	if len(ctx.Build.Tests) > 0 {
+		// The unit tests are run when the project is packaged, and fail the build when they fail
+		tests, reportErr := readTestReports(path.Join(mc.Path, "target", "surefire-reports"))
+		if reportErr != nil && err == nil {
+			return reportErr
+		}
+		ctx.Tests = tests
+		if testsErr := testsFailed(tests); testsErr != nil {
+			return testsErr
+		}
+	}
+
+	return err
+}
+
+// buildQuarkusNativeRunner builds the native executable, in addition to the fast-jar package,
+// and adds it to the artifacts, for the kits that contain both packages
+func buildQuarkusNativeRunner(ctx *builderContext) error {
+	mc := newQuarkusMavenContext(ctx)
+	// The native executable is built into its own directory, not to override the fast-jar package
+	mc.AddSystemProperty("quarkus.package.type", "native")
+	mc.AddSystemProperty("quarkus.package.output-directory", nativeOutputDir)
+	// The unit tests have already been run when the fast-jar package has been built
+	mc.AddSystemProperty("skipTests", "true")
+
+	err := BuildQuarkusRunnerCommon(ctx.C, mc, ctx.Maven.Project)
+	if err != nil {
+		return err
+	}
+
+	runner := "camel-k-integration-" + defaults.Version + "-runner"
+	location := path.Join(mc.Path, "target", nativeOutputDir, runner)
+	sha1, err := digest.ComputeSHA1(location)
+	if err != nil {
+		return err
+	}
+	ctx.Artifacts = append(ctx.Artifacts, v1.Artifact{
+		ID:       runner,
+		Location: location,
+		Target:   runner,
+		Checksum: "sha1:" + sha1,
+	})
+
+	return nil
+}
+
+func newQuarkusMavenContext(ctx *builderContext) maven.Context {
 	mc := maven.NewContext(path.Join(ctx.Path, "maven"))
 	mc.SettingsContent = ctx.Maven.SettingsData
+	mc.SettingsSecurityContent = ctx.Maven.SettingsSecurityData
 	mc.LocalRepository = ctx.Build.Maven.LocalRepository
 
+	if len(ctx.Build.Maven.Profiles) > 0 {
+		mc.AddArgument("-P" + strings.Join(ctx.Build.Maven.Profiles, ","))
+	}
+
 	if ctx.Maven.TrustStoreName != "" {
 		mc.ExtraMavenOpts = append(mc.ExtraMavenOpts,
 			"-Djavax.net.ssl.trustStore="+path.Join(ctx.Path, ctx.Maven.TrustStoreName),
@@ -161,12 +221,7 @@ func buildQuarkusRunner(ctx *builderContext) error {
 		)
 	}
 
-	err := BuildQuarkusRunnerCommon(ctx.C, mc, ctx.Maven.Project)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return mc
 }
 
 func BuildQuarkusRunnerCommon(ctx context.Context, mc maven.Context, project maven.Project) error {
@@ -197,6 +252,7 @@ func BuildQuarkusRunnerCommon(ctx context.Context, mc maven.Context, project mav
 func computeQuarkusDependencies(ctx *builderContext) error {
 	mc := maven.NewContext(path.Join(ctx.Path, "maven"))
 	mc.SettingsContent = ctx.Maven.SettingsData
+	mc.SettingsSecurityContent = ctx.Maven.SettingsSecurityData
 	mc.LocalRepository = ctx.Build.Maven.LocalRepository
 
 	// Process artifacts list and add it to existing artifacts
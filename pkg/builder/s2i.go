@@ -95,7 +95,7 @@ func (t *s2iTask) Do(ctx context.Context) v1.BuildStatus {
 	}
 
 	// Set the build controller as owner reference
-	owner := t.getControllerReference()
+	owner := getControllerReference(t.build)
 	if owner == nil {
 		// Default to the Build if no controller reference is present
 		owner = t.build
@@ -231,12 +231,12 @@ func (t *s2iTask) Do(ctx context.Context) v1.BuildStatus {
 	return status
 }
 
-func (t *s2iTask) getControllerReference() metav1.Object {
+func getControllerReference(build *v1.Build) metav1.Object {
 	var owner metav1.Object
-	for _, ref := range t.build.GetOwnerReferences() {
+	for _, ref := range build.GetOwnerReferences() {
 		if ref.Controller != nil && *ref.Controller {
 			o := &unstructured.Unstructured{}
-			o.SetNamespace(t.build.Namespace)
+			o.SetNamespace(build.Namespace)
 			o.SetName(ref.Name)
 			o.SetUID(ref.UID)
 			o.SetAPIVersion(ref.APIVersion)
@@ -61,6 +61,17 @@ func (b *Build) Task(task v1.Task) Task {
 			build: b.build,
 			task:  task.S2i,
 		}
+	} else if task.Tekton != nil {
+		return &tektonTask{
+			c:     b.builder.client,
+			build: b.build,
+			task:  task.Tekton,
+		}
+	} else if task.Local != nil {
+		return &unsupportedTask{
+			build: b.build,
+			name:  task.Local.Name,
+		}
 	}
 	return &emptyTask{
 		build: b.build,
@@ -135,6 +146,17 @@ func (b *Build) TaskByName(name string) Task {
 				build: b.build,
 				task:  task.S2i,
 			}
+		} else if task.Tekton != nil && task.Tekton.Name == name {
+			return &tektonTask{
+				c:     b.builder.client,
+				build: b.build,
+				task:  task.Tekton,
+			}
+		} else if task.Local != nil && task.Local.Name == name {
+			return &unsupportedTask{
+				build: b.build,
+				name:  task.Local.Name,
+			}
 		}
 	}
 	return &missingTask{
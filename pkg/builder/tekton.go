@@ -0,0 +1,311 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+const (
+	tektonAPIVersion      = "tekton.dev/v1beta1"
+	tektonPipelineRunKind = "PipelineRun"
+)
+
+type tektonTask struct {
+	c     client.Client
+	build *v1.Build
+	task  *v1.TektonTask
+}
+
+var _ Task = &tektonTask{}
+
+func (t *tektonTask) Do(ctx context.Context) v1.BuildStatus {
+	status := v1.BuildStatus{}
+
+	if t.task.ContextDir == "" {
+		// The generated Maven project has been shared with the PipelineRun through the working directory,
+		// that is the build directory on the persistent volume, and can be released once the image is built
+		defer t.cleanBuildDir()
+	}
+
+	pipelineRun, err := t.newPipelineRun()
+	if err != nil {
+		return status.Failed(err)
+	}
+
+	err = t.c.Delete(ctx, pipelineRun)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return status.Failed(errors.Wrap(err, "cannot delete pipeline run"))
+	}
+
+	// Set the build controller as owner reference
+	owner := getControllerReference(t.build)
+	if owner == nil {
+		// Default to the Build if no controller reference is present
+		owner = t.build
+	}
+
+	if err := ctrlutil.SetOwnerReference(owner, pipelineRun, t.c.GetScheme()); err != nil {
+		return status.Failed(errors.Wrapf(err, "cannot set owner reference on PipelineRun: %s", pipelineRun.GetName()))
+	}
+
+	err = t.c.Create(ctx, pipelineRun)
+	if err != nil {
+		return status.Failed(errors.Wrap(err, "cannot create pipeline run"))
+	}
+
+	err = t.waitForPipelineRunCompletion(ctx, pipelineRun)
+	if err != nil {
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			if err := t.cancelPipelineRun(context.Background(), pipelineRun); err != nil {
+				log.Errorf(err, "cannot cancel PipelineRun: %s/%s", pipelineRun.GetNamespace(), pipelineRun.GetName())
+			}
+		}
+		return status.Failed(err)
+	}
+
+	results := pipelineRunResults(pipelineRun)
+	status.Image = t.task.Image
+	if image, ok := results["IMAGE_URL"]; ok && image != "" {
+		status.Image = image
+	}
+	status.Digest = results["IMAGE_DIGEST"]
+
+	return status
+}
+
+// newPipelineRun creates the PipelineRun from the template, with the build parameters and the workspace
+// the generated Maven project is bound to
+func (t *tektonTask) newPipelineRun() (*unstructured.Unstructured, error) {
+	if t.task.PipelineRunTemplate == nil || len(t.task.PipelineRunTemplate.RawMessage) == 0 {
+		return nil, errors.New("no PipelineRun template configured")
+	}
+
+	pipelineRun := &unstructured.Unstructured{}
+	if err := json.Unmarshal(t.task.PipelineRunTemplate.RawMessage, &pipelineRun.Object); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal PipelineRun template")
+	}
+	if pipelineRun.Object == nil {
+		pipelineRun.Object = make(map[string]interface{})
+	}
+
+	if pipelineRun.GetAPIVersion() == "" {
+		pipelineRun.SetAPIVersion(tektonAPIVersion)
+	}
+	if kind := pipelineRun.GetKind(); kind == "" {
+		pipelineRun.SetKind(tektonPipelineRunKind)
+	} else if kind != tektonPipelineRunKind {
+		return nil, fmt.Errorf("unsupported PipelineRun template kind: %s", kind)
+	}
+	pipelineRun.SetNamespace(t.build.Namespace)
+	pipelineRun.SetName("camel-k-" + t.build.Name)
+	pipelineRun.SetGenerateName("")
+
+	labels := pipelineRun.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	for k, v := range t.build.Labels {
+		labels[k] = v
+	}
+	labels["camel.apache.org/build"] = t.build.Name
+	pipelineRun.SetLabels(labels)
+
+	baseImage := t.build.Status.BaseImage
+	if baseImage == "" {
+		baseImage = t.task.BaseImage
+	}
+	params := map[string]interface{}{
+		"IMAGE":       t.task.Image,
+		"BASE_IMAGE":  baseImage,
+		"CONTEXT_DIR": ContextDir,
+	}
+	for _, name := range []string{"IMAGE", "BASE_IMAGE", "CONTEXT_DIR"} {
+		if err := setNamedEntry(pipelineRun, "params", map[string]interface{}{
+			"name":  name,
+			"value": params[name],
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setNamedEntry(pipelineRun, "workspaces", map[string]interface{}{
+		"name": t.task.Workspace,
+		"persistentVolumeClaim": map[string]interface{}{
+			"claimName": t.task.PersistentVolumeClaim,
+		},
+		"subPath": t.build.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	return pipelineRun, nil
+}
+
+func (t *tektonTask) waitForPipelineRunCompletion(ctx context.Context, pipelineRun *unstructured.Unstructured) error {
+	key := ctrl.ObjectKeyFromObject(pipelineRun)
+	var reported *v1.BuildCondition
+	for {
+		select {
+
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-time.After(1 * time.Second):
+			err := t.c.Get(ctx, key, pipelineRun)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+
+			condition := pipelineRunCondition(pipelineRun)
+			if condition == nil {
+				continue
+			}
+			if reported == nil || reported.Status != condition.Status || reported.Reason != condition.Reason || reported.Message != condition.Message {
+				if err := t.reportCondition(ctx, *condition); err != nil {
+					log.Errorf(err, "cannot report PipelineRun status to Build: %s/%s", t.build.Namespace, t.build.Name)
+				}
+				reported = condition
+			}
+
+			switch condition.Status {
+			case corev1.ConditionTrue:
+				return nil
+			case corev1.ConditionFalse:
+				return fmt.Errorf("pipeline run %s failed: %s", pipelineRun.GetName(), condition.Message)
+			}
+		}
+	}
+}
+
+// reportCondition tracks the status of the PipelineRun into the Build conditions
+func (t *tektonTask) reportCondition(ctx context.Context, condition v1.BuildCondition) error {
+	build := &v1.Build{}
+	if err := t.c.Get(ctx, ctrl.ObjectKeyFromObject(t.build), build); err != nil {
+		return err
+	}
+	target := build.DeepCopy()
+	target.Status.SetCondition(condition.Type, condition.Status, condition.Reason, condition.Message)
+	return t.c.Status().Patch(ctx, target, ctrl.MergeFrom(build))
+}
+
+func (t *tektonTask) cancelPipelineRun(ctx context.Context, pipelineRun *unstructured.Unstructured) error {
+	target := pipelineRun.DeepCopy()
+	if err := unstructured.SetNestedField(target.Object, "Cancelled", "spec", "status"); err != nil {
+		return err
+	}
+	return t.c.Patch(ctx, target, ctrl.MergeFrom(pipelineRun))
+}
+
+func (t *tektonTask) cleanBuildDir() {
+	pwd, err := os.Getwd()
+	if err != nil {
+		log.Errorf(err, "cannot clean build directory")
+		return
+	}
+	files, err := ioutil.ReadDir(pwd)
+	if err != nil {
+		log.Errorf(err, "cannot clean build directory: %s", pwd)
+		return
+	}
+	// The working directory is the mount point of the volume, so only its content can be removed
+	for _, file := range files {
+		if err := os.RemoveAll(path.Join(pwd, file.Name())); err != nil {
+			log.Errorf(err, "cannot clean build directory: %s", pwd)
+		}
+	}
+}
+
+// pipelineRunCondition returns the Succeeded condition of the PipelineRun, as a Build condition
+func pipelineRunCondition(pipelineRun *unstructured.Unstructured) *v1.BuildCondition {
+	conditions, _, _ := unstructured.NestedSlice(pipelineRun.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Succeeded" {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		return &v1.BuildCondition{
+			Type:    v1.BuildConditionPipelineRunSucceeded,
+			Status:  corev1.ConditionStatus(status),
+			Reason:  reason,
+			Message: fmt.Sprintf("%s: %s", pipelineRun.GetName(), message),
+		}
+	}
+	return nil
+}
+
+// pipelineRunResults returns the results of the PipelineRun, from either the v1beta1 or the v1 status field
+func pipelineRunResults(pipelineRun *unstructured.Unstructured) map[string]string {
+	results := make(map[string]string)
+	for _, field := range []string{"pipelineResults", "results"} {
+		entries, _, _ := unstructured.NestedSlice(pipelineRun.Object, "status", field)
+		for _, e := range entries {
+			if entry, ok := e.(map[string]interface{}); ok {
+				name, _ := entry["name"].(string)
+				if value, ok := entry["value"].(string); ok {
+					results[name] = value
+				}
+			}
+		}
+	}
+	return results
+}
+
+// setNamedEntry sets the entry into the spec list with the given field name, replacing the entry with the same name
+func setNamedEntry(pipelineRun *unstructured.Unstructured, field string, entry map[string]interface{}) error {
+	entries, _, err := unstructured.NestedSlice(pipelineRun.Object, "spec", field)
+	if err != nil {
+		return errors.Wrapf(err, "invalid PipelineRun template %s", field)
+	}
+	replaced := false
+	for i, e := range entries {
+		if existing, ok := e.(map[string]interface{}); ok && existing["name"] == entry["name"] {
+			entries[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		entries = append(entries, entry)
+	}
+	return unstructured.SetNestedSlice(pipelineRun.Object, entries, "spec", field)
+}
@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestTektonPipelineRun(t *testing.T) {
+	task := tektonTask{
+		build: newTektonTestBuild(),
+		task:  newTektonTestTask(`{"metadata":{"generateName":"build-"},"spec":{"pipelineRef":{"name":"build"},"params":[{"name":"IMAGE","value":"other"},{"name":"LOG_LEVEL","value":"debug"}]}}`),
+	}
+
+	pipelineRun, err := task.newPipelineRun()
+	assert.Nil(t, err)
+
+	assert.Equal(t, "tekton.dev/v1beta1", pipelineRun.GetAPIVersion())
+	assert.Equal(t, "PipelineRun", pipelineRun.GetKind())
+	assert.Equal(t, "ns", pipelineRun.GetNamespace())
+	assert.Equal(t, "camel-k-my-build", pipelineRun.GetName())
+	assert.Equal(t, "", pipelineRun.GetGenerateName())
+	assert.Equal(t, "my-build", pipelineRun.GetLabels()["camel.apache.org/build"])
+	assert.Equal(t, "my-kit", pipelineRun.GetLabels()["camel.apache.org/kit"])
+
+	params, _, _ := unstructured.NestedSlice(pipelineRun.Object, "spec", "params")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "IMAGE", "value": "registry/ns/camel-k-my-kit:1"},
+		map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+		map[string]interface{}{"name": "BASE_IMAGE", "value": "adoptopenjdk/openjdk11:slim"},
+		map[string]interface{}{"name": "CONTEXT_DIR", "value": "context"},
+	}, params)
+
+	workspaces, _, _ := unstructured.NestedSlice(pipelineRun.Object, "spec", "workspaces")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":                  "source",
+			"persistentVolumeClaim": map[string]interface{}{"claimName": "camel-k"},
+			"subPath":               "my-build",
+		},
+	}, workspaces)
+}
+
+func TestTektonPipelineRunWithInvalidTemplate(t *testing.T) {
+	task := tektonTask{
+		build: newTektonTestBuild(),
+		task:  newTektonTestTask(`{"apiVersion":"tekton.dev/v1beta1","kind":"TaskRun"}`),
+	}
+	_, err := task.newPipelineRun()
+	assert.EqualError(t, err, "unsupported PipelineRun template kind: TaskRun")
+
+	task.task.PipelineRunTemplate = nil
+	_, err = task.newPipelineRun()
+	assert.EqualError(t, err, "no PipelineRun template configured")
+}
+
+func TestTektonTask(t *testing.T) {
+	build := newTektonTestBuild()
+	c, err := test.NewFakeClient(build)
+	assert.Nil(t, err)
+
+	task := tektonTask{
+		c:     c,
+		build: build,
+		task:  newTektonTestTask(`{"spec":{"pipelineRef":{"name":"build"}}}`),
+	}
+	task.task.ContextDir = "context"
+
+	go func() {
+		key := ctrl.ObjectKey{Namespace: "ns", Name: "camel-k-my-build"}
+		for {
+			pipelineRun := &unstructured.Unstructured{}
+			pipelineRun.SetAPIVersion(tektonAPIVersion)
+			pipelineRun.SetKind(tektonPipelineRunKind)
+			if err := c.Get(context.TODO(), key, pipelineRun); err != nil {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			_ = unstructured.SetNestedSlice(pipelineRun.Object, []interface{}{
+				map[string]interface{}{"type": "Succeeded", "status": "True", "reason": "Succeeded", "message": "Tasks Completed: 1"},
+			}, "status", "conditions")
+			_ = unstructured.SetNestedSlice(pipelineRun.Object, []interface{}{
+				map[string]interface{}{"name": "IMAGE_DIGEST", "value": "sha256:0123"},
+			}, "status", "pipelineResults")
+			_ = c.Update(context.TODO(), pipelineRun)
+			return
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	status := task.Do(ctx)
+
+	assert.Equal(t, v1.BuildPhaseNone, status.Phase, status.Error)
+	assert.Equal(t, "registry/ns/camel-k-my-kit:1", status.Image)
+	assert.Equal(t, "sha256:0123", status.Digest)
+
+	err = c.Get(context.TODO(), ctrl.ObjectKeyFromObject(build), build)
+	assert.Nil(t, err)
+	condition := build.Status.GetCondition(v1.BuildConditionPipelineRunSucceeded)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "Succeeded", condition.Reason)
+	assert.Equal(t, "camel-k-my-build: Tasks Completed: 1", condition.Message)
+}
+
+func newTektonTestBuild() *v1.Build {
+	return &v1.Build{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.BuildKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-build",
+			Labels: map[string]string{
+				"camel.apache.org/kit": "my-kit",
+			},
+		},
+	}
+}
+
+func newTektonTestTask(template string) *v1.TektonTask {
+	return &v1.TektonTask{
+		BaseTask: v1.BaseTask{
+			Name: "tekton",
+		},
+		PublishTask: v1.PublishTask{
+			BaseImage: "adoptopenjdk/openjdk11:slim",
+			Image:     "registry/ns/camel-k-my-kit:1",
+		},
+		PipelineRunTemplate:   &v1.PipelineRunTemplate{RawMessage: []byte(template)},
+		Workspace:             "source",
+		PersistentVolumeClaim: "camel-k",
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+const (
+	surefirePluginVersion   = "3.0.0-M7"
+	gmavenPlusPluginVersion = "1.13.1"
+
+	// maxTestReports is the maximum number of failed tests reported in the Build status
+	maxTestReports = 10
+	// maxTestReportLength is the maximum length of the excerpts of the reports of the failed tests
+	maxTestReportLength = 512
+)
+
+// injectTests adds the unit tests of the build to the Maven project, along with the dependencies and plugins required
+// to compile and run them, when the project is packaged
+func injectTests(ctx *builderContext) error {
+	if len(ctx.Build.Tests) == 0 {
+		return nil
+	}
+
+	groovy := false
+	for _, test := range ctx.Build.Tests {
+		var dir string
+		switch test.InferLanguage() {
+		case v1.LanguageJavaSource:
+			dir = "java"
+		case v1.LanguageGroovy:
+			dir = "groovy"
+			groovy = true
+		default:
+			return fmt.Errorf("unsupported test %s: only Java and Groovy tests can be run", test.Name)
+		}
+		testsPath := path.Join(ctx.Path, "maven", "src", "test", dir)
+		if err := os.MkdirAll(testsPath, os.ModePerm); err != nil {
+			return errors.Wrap(err, "failure while creating tests folder")
+		}
+		if err := ioutil.WriteFile(path.Join(testsPath, path.Base(test.Name)), []byte(test.Content), 0644); err != nil {
+			return errors.Wrapf(err, "failure while writing test %s", test.Name)
+		}
+	}
+
+	project := &ctx.Maven.Project
+	project.AddDependency(maven.Dependency{
+		GroupID:    "org.apache.camel.quarkus",
+		ArtifactID: "camel-quarkus-junit5",
+		Scope:      "test",
+	})
+	// JUnit 5 is only supported by the recent versions of the Surefire plugin
+	project.Build.Plugins = append(project.Build.Plugins, maven.Plugin{
+		GroupID:    "org.apache.maven.plugins",
+		ArtifactID: "maven-surefire-plugin",
+		Version:    surefirePluginVersion,
+	})
+	if groovy {
+		project.AddDependency(maven.Dependency{
+			GroupID:    "org.apache.camel.quarkus",
+			ArtifactID: "camel-quarkus-groovy",
+			Scope:      "test",
+		})
+		project.Build.Plugins = append(project.Build.Plugins, maven.Plugin{
+			GroupID:    "org.codehaus.gmavenplus",
+			ArtifactID: "gmavenplus-plugin",
+			Version:    gmavenPlusPluginVersion,
+			Executions: []maven.Execution{
+				{
+					Goals: []string{
+						"addTestSources",
+						"compileTests",
+					},
+				},
+			},
+		})
+	}
+
+	return nil
+}
+
+// testSuite is the report of a test class, written by the Surefire plugin
+type testSuite struct {
+	Tests     int32      `xml:"tests,attr"`
+	Failures  int32      `xml:"failures,attr"`
+	Errors    int32      `xml:"errors,attr"`
+	Skipped   int32      `xml:"skipped,attr"`
+	TestCases []testCase `xml:"testcase"`
+}
+
+type testCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Failure   *testFailure `xml:"failure"`
+	Error     *testFailure `xml:"error"`
+}
+
+type testFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+// readTestReports sums up the Surefire reports of the given directory, and returns excerpts of the reports
+// of the tests that failed or errored
+func readTestReports(dir string) (*v1.BuildTestsStatus, error) {
+	files, err := filepath.Glob(path.Join(dir, "TEST-*.xml"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	status := v1.BuildTestsStatus{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		suite := testSuite{}
+		if err := xml.Unmarshal(data, &suite); err != nil {
+			return nil, errors.Wrapf(err, "invalid test report %s", path.Base(file))
+		}
+		status.Tests += suite.Tests
+		status.Failures += suite.Failures
+		status.Errors += suite.Errors
+		status.Skipped += suite.Skipped
+
+		for _, test := range suite.TestCases {
+			failure := test.Failure
+			if failure == nil {
+				failure = test.Error
+			}
+			if failure == nil || len(status.Reports) >= maxTestReports {
+				continue
+			}
+			message := failure.Message
+			if message == "" {
+				message = failure.Type
+			}
+			report := fmt.Sprintf("%s.%s: %s", test.ClassName, test.Name, strings.TrimSpace(message))
+			if len(report) > maxTestReportLength {
+				report = report[:maxTestReportLength] + "..."
+			}
+			status.Reports = append(status.Reports, report)
+		}
+	}
+
+	return &status, nil
+}
+
+// testsFailed returns the error failing the build, when some of the unit tests failed or errored
+func testsFailed(status *v1.BuildTestsStatus) error {
+	if status == nil || status.Failures+status.Errors == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d tests failed: %s", status.Failures+status.Errors, status.Tests,
+		strings.Join(status.Reports, "; "))
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+func TestInjectTests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-tests-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := builderContext{
+		Path: dir,
+		Build: v1.BuilderTask{
+			Tests: []v1.SourceSpec{
+				{DataSpec: v1.DataSpec{Name: "RoutesTest.java", Content: "public class RoutesTest {}"}},
+				{DataSpec: v1.DataSpec{Name: "BeansTest.groovy", Content: "class BeansTest {}"}},
+			},
+		},
+	}
+	ctx.Maven.Project = GenerateQuarkusProjectCommon("2.2.0", "1.9.0", "2.2.3.Final")
+
+	err = Project.InjectTests.execute(&ctx)
+	assert.Nil(t, err)
+
+	content, err := ioutil.ReadFile(path.Join(dir, "maven", "src", "test", "java", "RoutesTest.java"))
+	assert.Nil(t, err)
+	assert.Equal(t, "public class RoutesTest {}", string(content))
+	content, err = ioutil.ReadFile(path.Join(dir, "maven", "src", "test", "groovy", "BeansTest.groovy"))
+	assert.Nil(t, err)
+	assert.Equal(t, "class BeansTest {}", string(content))
+
+	assert.Contains(t, ctx.Maven.Project.Dependencies, maven.Dependency{
+		GroupID:    "org.apache.camel.quarkus",
+		ArtifactID: "camel-quarkus-junit5",
+		Scope:      "test",
+	})
+	assert.Contains(t, ctx.Maven.Project.Dependencies, maven.Dependency{
+		GroupID:    "org.apache.camel.quarkus",
+		ArtifactID: "camel-quarkus-groovy",
+		Scope:      "test",
+	})
+	plugins := make([]string, 0)
+	for _, p := range ctx.Maven.Project.Build.Plugins {
+		plugins = append(plugins, p.ArtifactID)
+	}
+	assert.Contains(t, plugins, "maven-surefire-plugin")
+	assert.Contains(t, plugins, "gmavenplus-plugin")
+
+	ctx.Build.Tests = []v1.SourceSpec{{DataSpec: v1.DataSpec{Name: "routes.yaml"}}}
+	err = Project.InjectTests.execute(&ctx)
+	assert.EqualError(t, err, "unsupported test routes.yaml: only Java and Groovy tests can be run")
+}
+
+func TestReadTestReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-tests-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	status, err := readTestReports(dir)
+	assert.Nil(t, err)
+	assert.Nil(t, status)
+
+	assert.Nil(t, ioutil.WriteFile(path.Join(dir, "TEST-RoutesTest.xml"), []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="RoutesTest" tests="3" failures="1" errors="0" skipped="1">
+  <testcase name="testRoute" classname="RoutesTest"/>
+  <testcase name="testFailure" classname="RoutesTest">
+    <failure message="expected: &lt;1&gt; but was: &lt;2&gt;" type="org.opentest4j.AssertionFailedError">stack trace</failure>
+  </testcase>
+  <testcase name="testSkipped" classname="RoutesTest">
+    <skipped/>
+  </testcase>
+</testsuite>`), 0644))
+	assert.Nil(t, ioutil.WriteFile(path.Join(dir, "TEST-BeansTest.xml"), []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="BeansTest" tests="1" failures="0" errors="1" skipped="0">
+  <testcase name="testBean" classname="BeansTest">
+    <error type="java.lang.NullPointerException">stack trace</error>
+  </testcase>
+</testsuite>`), 0644))
+
+	status, err = readTestReports(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, &v1.BuildTestsStatus{
+		Tests:    4,
+		Failures: 1,
+		Errors:   1,
+		Skipped:  1,
+		Reports: []string{
+			"BeansTest.testBean: java.lang.NullPointerException",
+			"RoutesTest.testFailure: expected: <1> but was: <2>",
+		},
+	}, status)
+	assert.EqualError(t, testsFailed(status),
+		"2 of 4 tests failed: BeansTest.testBean: java.lang.NullPointerException; RoutesTest.testFailure: expected: <1> but was: <2>")
+
+	assert.Nil(t, testsFailed(&v1.BuildTestsStatus{Tests: 4, Skipped: 1}))
+}
@@ -83,10 +83,12 @@ type builderContext struct {
 	Artifacts         []v1.Artifact
 	SelectedArtifacts []v1.Artifact
 	Resources         []resource
+	Tests             *v1.BuildTestsStatus
 	Maven             struct {
-		Project        maven.Project
-		SettingsData   []byte
-		TrustStoreName string
-		TrustStorePass string
+		Project              maven.Project
+		SettingsData         []byte
+		SettingsSecurityData []byte
+		TrustStoreName       string
+		TrustStorePass       string
 	}
 }
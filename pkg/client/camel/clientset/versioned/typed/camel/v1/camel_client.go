@@ -32,6 +32,7 @@ type CamelV1Interface interface {
 	IntegrationsGetter
 	IntegrationKitsGetter
 	IntegrationPlatformsGetter
+	IntegrationTemplatesGetter
 }
 
 // CamelV1Client is used to interact with features provided by the camel.apache.org group.
@@ -59,6 +60,10 @@ func (c *CamelV1Client) IntegrationPlatforms(namespace string) IntegrationPlatfo
 	return newIntegrationPlatforms(c, namespace)
 }
 
+func (c *CamelV1Client) IntegrationTemplates(namespace string) IntegrationTemplateInterface {
+	return newIntegrationTemplates(c, namespace)
+}
+
 // NewForConfig creates a new CamelV1Client for the given config.
 func NewForConfig(c *rest.Config) (*CamelV1Client, error) {
 	config := *c
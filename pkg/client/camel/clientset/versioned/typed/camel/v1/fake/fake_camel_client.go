@@ -49,6 +49,10 @@ func (c *FakeCamelV1) IntegrationPlatforms(namespace string) v1.IntegrationPlatf
 	return &FakeIntegrationPlatforms{c, namespace}
 }
 
+func (c *FakeCamelV1) IntegrationTemplates(namespace string) v1.IntegrationTemplateInterface {
+	return &FakeIntegrationTemplates{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCamelV1) RESTClient() rest.Interface {
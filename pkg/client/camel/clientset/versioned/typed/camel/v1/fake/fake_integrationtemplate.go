@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	camelv1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeIntegrationTemplates implements IntegrationTemplateInterface
+type FakeIntegrationTemplates struct {
+	Fake *FakeCamelV1
+	ns   string
+}
+
+var integrationtemplatesResource = schema.GroupVersionResource{Group: "camel.apache.org", Version: "v1", Resource: "integrationtemplates"}
+
+var integrationtemplatesKind = schema.GroupVersionKind{Group: "camel.apache.org", Version: "v1", Kind: "IntegrationTemplate"}
+
+// Get takes name of the integrationTemplate, and returns the corresponding integrationTemplate object, and an error if there is any.
+func (c *FakeIntegrationTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *camelv1.IntegrationTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(integrationtemplatesResource, c.ns, name), &camelv1.IntegrationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*camelv1.IntegrationTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of IntegrationTemplates that match those selectors.
+func (c *FakeIntegrationTemplates) List(ctx context.Context, opts v1.ListOptions) (result *camelv1.IntegrationTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(integrationtemplatesResource, integrationtemplatesKind, c.ns, opts), &camelv1.IntegrationTemplateList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &camelv1.IntegrationTemplateList{ListMeta: obj.(*camelv1.IntegrationTemplateList).ListMeta}
+	for _, item := range obj.(*camelv1.IntegrationTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested integrationTemplates.
+func (c *FakeIntegrationTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(integrationtemplatesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a integrationTemplate and creates it.  Returns the server's representation of the integrationTemplate, and an error, if there is any.
+func (c *FakeIntegrationTemplates) Create(ctx context.Context, integrationTemplate *camelv1.IntegrationTemplate, opts v1.CreateOptions) (result *camelv1.IntegrationTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(integrationtemplatesResource, c.ns, integrationTemplate), &camelv1.IntegrationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*camelv1.IntegrationTemplate), err
+}
+
+// Update takes the representation of a integrationTemplate and updates it. Returns the server's representation of the integrationTemplate, and an error, if there is any.
+func (c *FakeIntegrationTemplates) Update(ctx context.Context, integrationTemplate *camelv1.IntegrationTemplate, opts v1.UpdateOptions) (result *camelv1.IntegrationTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(integrationtemplatesResource, c.ns, integrationTemplate), &camelv1.IntegrationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*camelv1.IntegrationTemplate), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeIntegrationTemplates) UpdateStatus(ctx context.Context, integrationTemplate *camelv1.IntegrationTemplate, opts v1.UpdateOptions) (*camelv1.IntegrationTemplate, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(integrationtemplatesResource, "status", c.ns, integrationTemplate), &camelv1.IntegrationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*camelv1.IntegrationTemplate), err
+}
+
+// Delete takes name of the integrationTemplate and deletes it. Returns an error if one occurs.
+func (c *FakeIntegrationTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(integrationtemplatesResource, c.ns, name), &camelv1.IntegrationTemplate{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeIntegrationTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(integrationtemplatesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &camelv1.IntegrationTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched integrationTemplate.
+func (c *FakeIntegrationTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *camelv1.IntegrationTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(integrationtemplatesResource, c.ns, name, pt, data, subresources...), &camelv1.IntegrationTemplate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*camelv1.IntegrationTemplate), err
+}
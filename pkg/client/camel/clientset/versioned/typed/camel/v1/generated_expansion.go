@@ -28,3 +28,5 @@ type IntegrationExpansion interface{}
 type IntegrationKitExpansion interface{}
 
 type IntegrationPlatformExpansion interface{}
+
+type IntegrationTemplateExpansion interface{}
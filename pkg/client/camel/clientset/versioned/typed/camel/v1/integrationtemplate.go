@@ -0,0 +1,196 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	scheme "github.com/apache/camel-k/pkg/client/camel/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// IntegrationTemplatesGetter has a method to return a IntegrationTemplateInterface.
+// A group's client should implement this interface.
+type IntegrationTemplatesGetter interface {
+	IntegrationTemplates(namespace string) IntegrationTemplateInterface
+}
+
+// IntegrationTemplateInterface has methods to work with IntegrationTemplate resources.
+type IntegrationTemplateInterface interface {
+	Create(ctx context.Context, integrationTemplate *v1.IntegrationTemplate, opts metav1.CreateOptions) (*v1.IntegrationTemplate, error)
+	Update(ctx context.Context, integrationTemplate *v1.IntegrationTemplate, opts metav1.UpdateOptions) (*v1.IntegrationTemplate, error)
+	UpdateStatus(ctx context.Context, integrationTemplate *v1.IntegrationTemplate, opts metav1.UpdateOptions) (*v1.IntegrationTemplate, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.IntegrationTemplate, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.IntegrationTemplateList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.IntegrationTemplate, err error)
+	IntegrationTemplateExpansion
+}
+
+// integrationTemplates implements IntegrationTemplateInterface
+type integrationTemplates struct {
+	client rest.Interface
+	ns     string
+}
+
+// newIntegrationTemplates returns a IntegrationTemplates
+func newIntegrationTemplates(c *CamelV1Client, namespace string) *integrationTemplates {
+	return &integrationTemplates{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the integrationTemplate, and returns the corresponding integrationTemplate object, and an error if there is any.
+func (c *integrationTemplates) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.IntegrationTemplate, err error) {
+	result = &v1.IntegrationTemplate{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of IntegrationTemplates that match those selectors.
+func (c *integrationTemplates) List(ctx context.Context, opts metav1.ListOptions) (result *v1.IntegrationTemplateList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.IntegrationTemplateList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested integrationTemplates.
+func (c *integrationTemplates) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a integrationTemplate and creates it.  Returns the server's representation of the integrationTemplate, and an error, if there is any.
+func (c *integrationTemplates) Create(ctx context.Context, integrationTemplate *v1.IntegrationTemplate, opts metav1.CreateOptions) (result *v1.IntegrationTemplate, err error) {
+	result = &v1.IntegrationTemplate{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(integrationTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a integrationTemplate and updates it. Returns the server's representation of the integrationTemplate, and an error, if there is any.
+func (c *integrationTemplates) Update(ctx context.Context, integrationTemplate *v1.IntegrationTemplate, opts metav1.UpdateOptions) (result *v1.IntegrationTemplate, err error) {
+	result = &v1.IntegrationTemplate{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		Name(integrationTemplate.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(integrationTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *integrationTemplates) UpdateStatus(ctx context.Context, integrationTemplate *v1.IntegrationTemplate, opts metav1.UpdateOptions) (result *v1.IntegrationTemplate, err error) {
+	result = &v1.IntegrationTemplate{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		Name(integrationTemplate.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(integrationTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the integrationTemplate and deletes it. Returns an error if one occurs.
+func (c *integrationTemplates) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *integrationTemplates) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched integrationTemplate.
+func (c *integrationTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.IntegrationTemplate, err error) {
+	result = &v1.IntegrationTemplate{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("integrationtemplates").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
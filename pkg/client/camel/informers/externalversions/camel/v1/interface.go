@@ -35,6 +35,8 @@ type Interface interface {
 	IntegrationKits() IntegrationKitInformer
 	// IntegrationPlatforms returns a IntegrationPlatformInformer.
 	IntegrationPlatforms() IntegrationPlatformInformer
+	// IntegrationTemplates returns a IntegrationTemplateInformer.
+	IntegrationTemplates() IntegrationTemplateInformer
 }
 
 type version struct {
@@ -72,3 +74,8 @@ func (v *version) IntegrationKits() IntegrationKitInformer {
 func (v *version) IntegrationPlatforms() IntegrationPlatformInformer {
 	return &integrationPlatformInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// IntegrationTemplates returns a IntegrationTemplateInformer.
+func (v *version) IntegrationTemplates() IntegrationTemplateInformer {
+	return &integrationTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -65,6 +65,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Camel().V1().IntegrationKits().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("integrationplatforms"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Camel().V1().IntegrationPlatforms().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("integrationtemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Camel().V1().IntegrationTemplates().Informer()}, nil
 
 		// Group=camel.apache.org, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("kamelets"):
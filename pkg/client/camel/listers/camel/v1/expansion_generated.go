@@ -58,3 +58,11 @@ type IntegrationPlatformListerExpansion interface{}
 // IntegrationPlatformNamespaceListerExpansion allows custom methods to be added to
 // IntegrationPlatformNamespaceLister.
 type IntegrationPlatformNamespaceListerExpansion interface{}
+
+// IntegrationTemplateListerExpansion allows custom methods to be added to
+// IntegrationTemplateLister.
+type IntegrationTemplateListerExpansion interface{}
+
+// IntegrationTemplateNamespaceListerExpansion allows custom methods to be added to
+// IntegrationTemplateNamespaceLister.
+type IntegrationTemplateNamespaceListerExpansion interface{}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// IntegrationTemplateLister helps list IntegrationTemplates.
+// All objects returned here must be treated as read-only.
+type IntegrationTemplateLister interface {
+	// List lists all IntegrationTemplates in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.IntegrationTemplate, err error)
+	// IntegrationTemplates returns an object that can list and get IntegrationTemplates.
+	IntegrationTemplates(namespace string) IntegrationTemplateNamespaceLister
+	IntegrationTemplateListerExpansion
+}
+
+// integrationTemplateLister implements the IntegrationTemplateLister interface.
+type integrationTemplateLister struct {
+	indexer cache.Indexer
+}
+
+// NewIntegrationTemplateLister returns a new IntegrationTemplateLister.
+func NewIntegrationTemplateLister(indexer cache.Indexer) IntegrationTemplateLister {
+	return &integrationTemplateLister{indexer: indexer}
+}
+
+// List lists all IntegrationTemplates in the indexer.
+func (s *integrationTemplateLister) List(selector labels.Selector) (ret []*v1.IntegrationTemplate, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.IntegrationTemplate))
+	})
+	return ret, err
+}
+
+// IntegrationTemplates returns an object that can list and get IntegrationTemplates.
+func (s *integrationTemplateLister) IntegrationTemplates(namespace string) IntegrationTemplateNamespaceLister {
+	return integrationTemplateNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// IntegrationTemplateNamespaceLister helps list and get IntegrationTemplates.
+// All objects returned here must be treated as read-only.
+type IntegrationTemplateNamespaceLister interface {
+	// List lists all IntegrationTemplates in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.IntegrationTemplate, err error)
+	// Get retrieves the IntegrationTemplate from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.IntegrationTemplate, error)
+	IntegrationTemplateNamespaceListerExpansion
+}
+
+// integrationTemplateNamespaceLister implements the IntegrationTemplateNamespaceLister
+// interface.
+type integrationTemplateNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all IntegrationTemplates in the indexer for a given namespace.
+func (s integrationTemplateNamespaceLister) List(selector labels.Selector) (ret []*v1.IntegrationTemplate, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.IntegrationTemplate))
+	})
+	return ret, err
+}
+
+// Get retrieves the IntegrationTemplate from the indexer for a given namespace and name.
+func (s integrationTemplateNamespaceLister) Get(name string) (*v1.IntegrationTemplate, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("integrationtemplate"), name)
+	}
+	return obj.(*v1.IntegrationTemplate), nil
+}
@@ -38,6 +38,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+	"k8s.io/client-go/tools/record"
 
 	controller "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -45,6 +46,7 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis"
 	camel "github.com/apache/camel-k/pkg/client/camel/clientset/versioned"
+	"github.com/apache/camel-k/pkg/util/audit"
 )
 
 const (
@@ -61,6 +63,8 @@ type Client interface {
 	GetScheme() *runtime.Scheme
 	GetConfig() *rest.Config
 	GetCurrentNamespace(kubeConfig string) (string, error)
+	// GetAPIReader returns a reader that reads directly from the API server, bypassing the cache of the operator
+	GetAPIReader() controller.Reader
 }
 
 // Injectable identifies objects that can receive a Client
@@ -79,6 +83,7 @@ type defaultClient struct {
 	camel  camel.Interface
 	scheme *runtime.Scheme
 	config *rest.Config
+	reader controller.Reader
 }
 
 // Check interface compliance
@@ -92,6 +97,10 @@ func (c *defaultClient) CamelV1alpha1() camelv1alpha1.CamelV1alpha1Interface {
 	return c.camel.CamelV1alpha1()
 }
 
+func (c *defaultClient) GetAPIReader() controller.Reader {
+	return c.reader
+}
+
 func (c *defaultClient) GetScheme() *runtime.Scheme {
 	return c.scheme
 }
@@ -111,6 +120,18 @@ func NewOutOfClusterClient(kubeconfig string) (Client, error) {
 	return NewClient(true)
 }
 
+// NewOutOfClusterClientForContext creates a new k8s client, for the given context of the kube config,
+// that can be used from outside the cluster
+func NewOutOfClusterClientForContext(kubeconfig string, kubecontext string) (Client, error) {
+	initialize(kubeconfig)
+	cfg, err := config.GetConfigWithContext(kubecontext)
+	if err != nil {
+		return nil, err
+	}
+	// using fast discovery from outside the cluster
+	return newClientForConfig(cfg, true)
+}
+
 // NewClient creates a new k8s client that can be used from outside or in the cluster
 func NewClient(fastDiscovery bool) (Client, error) {
 	// Get a config to talk to the apiserver
@@ -119,6 +140,10 @@ func NewClient(fastDiscovery bool) (Client, error) {
 		return nil, err
 	}
 
+	return newClientForConfig(cfg, fastDiscovery)
+}
+
+func newClientForConfig(cfg *rest.Config, fastDiscovery bool) (Client, error) {
 	scheme := clientscheme.Scheme
 
 	// Setup Scheme for all resources
@@ -126,6 +151,7 @@ func NewClient(fastDiscovery bool) (Client, error) {
 		return nil, err
 	}
 
+	var err error
 	var clientset kubernetes.Interface
 	if clientset, err = kubernetes.NewForConfig(cfg); err != nil {
 		return nil, err
@@ -157,6 +183,7 @@ func NewClient(fastDiscovery bool) (Client, error) {
 		camel:     camelClientset,
 		scheme:    clientOptions.Scheme,
 		config:    cfg,
+		reader:    dynClient,
 	}, nil
 }
 
@@ -171,12 +198,18 @@ func FromManager(manager manager.Manager) (Client, error) {
 	if camelClientset, err = camel.NewForConfig(manager.GetConfig()); err != nil {
 		return nil, err
 	}
+	// The mutations performed by the operator are audited, and optionally published as Events
+	var recorder record.EventRecorder
+	if audit.EventsEnabled() {
+		recorder = manager.GetEventRecorderFor("camel-k-audit")
+	}
 	return &defaultClient{
-		Client:    manager.GetClient(),
+		Client:    audit.NewClient(manager.GetClient(), recorder),
 		Interface: clientset,
 		camel:     camelClientset,
 		scheme:    manager.GetScheme(),
 		config:    manager.GetConfig(),
+		reader:    manager.GetAPIReader(),
 	}, nil
 }
 
@@ -208,7 +241,13 @@ func getDefaultKubeConfigFile() (string, error) {
 
 // GetCurrentNamespace --
 func GetCurrentNamespace(kubeconfig string) (string, error) {
-	if kubeconfig == "" {
+	return GetCurrentNamespaceForContext(kubeconfig, "")
+}
+
+// GetCurrentNamespaceForContext returns the namespace of the given context of the kube config,
+// or of its current context when the given context is empty
+func GetCurrentNamespaceForContext(kubeconfig string, kubecontext string) (string, error) {
+	if kubeconfig == "" && kubecontext == "" {
 		kubeContainer, err := shouldUseContainerMode()
 		if err != nil {
 			return "", err
@@ -244,7 +283,7 @@ func GetCurrentNamespace(kubeconfig string) (string, error) {
 
 	clientcmdconfig := decoded.(*clientcmdapi.Config)
 
-	cc := clientcmd.NewDefaultClientConfig(*clientcmdconfig, &clientcmd.ConfigOverrides{})
+	cc := clientcmd.NewDefaultClientConfig(*clientcmdconfig, &clientcmd.ConfigOverrides{CurrentContext: kubecontext})
 	ns, _, err := cc.Namespace()
 	return ns, err
 }
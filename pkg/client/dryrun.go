@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	controller "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewDryRunClient returns a Client that sends the write requests issued with the controller-runtime client in
+// dry-run mode, so that they are validated by the API server without being persisted
+func NewDryRunClient(c Client) Client {
+	return &dryRunClient{
+		Client:     c,
+		controller: controller.NewDryRunClient(c),
+	}
+}
+
+type dryRunClient struct {
+	Client
+	controller controller.Client
+}
+
+// Check interface compliance
+var _ Client = &dryRunClient{}
+
+func (c *dryRunClient) Create(ctx context.Context, obj controller.Object, opts ...controller.CreateOption) error {
+	return c.controller.Create(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj controller.Object, opts ...controller.UpdateOption) error {
+	return c.controller.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj controller.Object, opts ...controller.DeleteOption) error {
+	return c.controller.Delete(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) DeleteAllOf(ctx context.Context, obj controller.Object, opts ...controller.DeleteAllOfOption) error {
+	return c.controller.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj controller.Object, patch controller.Patch, opts ...controller.PatchOption) error {
+	return c.controller.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *dryRunClient) Status() controller.StatusWriter {
+	return c.controller.Status()
+}
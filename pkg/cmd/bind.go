@@ -25,6 +25,8 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	camelclient "github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/controller/kameletbinding"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/reference"
 	"github.com/apache/camel-k/pkg/util/uri"
@@ -57,6 +59,7 @@ func newCmdBind(rootCmdOptions *RootCmdOptions) (*cobra.Command, *bindCmdOptions
 		},
 	}
 
+	cmd.Flags().Bool("dry-run", false, "Print the binding, and the integration the operator generates for it, without creating anything. The output format defaults to yaml")
 	cmd.Flags().String("error-handler", "", `Add error handler (none|log|dlc:<endpoint>|bean:<type>|ref:<registry-ref>). DLC endpoints are expected in the format "[[apigroup/]version:]kind:[namespace/]name", plain Camel URIs or Kamelet name.`)
 	cmd.Flags().String("name", "", "Name for the binding")
 	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
@@ -76,6 +79,7 @@ const (
 
 type bindCmdOptions struct {
 	*RootCmdOptions
+	DryRun       bool     `mapstructure:"dry-run" yaml:",omitempty"`
 	ErrorHandler string   `mapstructure:"error-handler" yaml:",omitempty"`
 	Name         string   `mapstructure:"name" yaml:",omitempty"`
 	OutputFormat string   `mapstructure:"output" yaml:",omitempty"`
@@ -143,6 +147,10 @@ func (o *bindCmdOptions) run(cmd *cobra.Command, args []string) error {
 	name := o.nameFor(source, sink)
 
 	binding := v1alpha1.KameletBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.KameletBindingKind,
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: o.Namespace,
 			Name:      name,
@@ -178,6 +186,10 @@ func (o *bindCmdOptions) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if o.DryRun {
+		return o.printDryRun(cmd, client, &binding)
+	}
+
 	if o.OutputFormat != "" {
 		return showOutput(cmd, &binding, o.OutputFormat, client.GetScheme())
 	}
@@ -200,6 +212,35 @@ func (o *bindCmdOptions) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printDryRun prints the binding, along with the integration the operator generates for it, as it is resolved
+// by the operator, without persisting anything
+func (o *bindCmdOptions) printDryRun(cmd *cobra.Command, c camelclient.Client, binding *v1alpha1.KameletBinding) error {
+	it, err := kameletbinding.DryRun(o.Context, c, binding)
+	if err != nil {
+		return err
+	}
+
+	lst := kubernetes.NewCollection(binding, it).AsKubernetesList()
+	switch o.OutputFormat {
+	case "", "yaml":
+		data, err := kubernetes.ToYAML(lst)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), string(data))
+	case "json":
+		data, err := kubernetes.ToJSON(lst)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), string(data))
+	default:
+		return fmt.Errorf("invalid output format option '%s', should be one of: yaml|json", o.OutputFormat)
+	}
+
+	return nil
+}
+
 func showOutput(cmd *cobra.Command, binding *v1alpha1.KameletBinding, outputFormat string, scheme *runtime.Scheme) error {
 	printer := printers.NewTypeSetter(scheme)
 	printer.Delegate = &kubernetes.CLIPrinter{
@@ -18,11 +18,15 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"testing"
 
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/test"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const cmdBind = "bind"
@@ -158,3 +162,46 @@ spec:
 status: {}
 `, output)
 }
+
+func TestBindDryRun(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	pl := v1.NewIntegrationPlatform("default", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Profile = v1.TraitProfileKubernetes
+	pl.Status.Build.RuntimeVersion = catalog.Runtime.Version
+	pl.Status.Build.PublishStrategy = v1.IntegrationPlatformBuildPublishStrategySpectrum
+	pl.Status.Build.Registry.Address = "registry"
+	pl.Status.Cluster = v1.IntegrationPlatformClusterKubernetes
+	// The garbage collection relies on the discovery API that is not available with the fake client
+	pl.Status.Traits = map[string]v1.TraitSpec{
+		"gc": test.TraitSpecFromMap(t, map[string]interface{}{"enabled": false}),
+	}
+	c, err := test.NewFakeClient(&pl, &v1.CamelCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "camel-catalog"},
+		Spec:       catalog.CamelCatalogSpec,
+	})
+	assert.Nil(t, err)
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	options._client = c
+	options.Namespace = "default"
+	bindCmdOptions := addTestBindCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	output, err := test.ExecuteCommand(rootCmd, cmdBind, "timer:tick", "log:info", "--dry-run")
+	assert.True(t, bindCmdOptions.DryRun)
+
+	assert.Nil(t, err)
+	assert.Contains(t, output, "kind: KameletBinding")
+	assert.Contains(t, output, "kind: Integration")
+	assert.Contains(t, output, "uri: timer:tick")
+	assert.Contains(t, output, "- camel:timer")
+	assert.NotContains(t, output, "phase:")
+
+	// Nothing is persisted
+	integrations := v1.NewIntegrationList()
+	assert.Nil(t, c.List(context.TODO(), &integrations))
+	assert.Empty(t, integrations.Items)
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func newCmdCatalog(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "catalog",
+		Short: "Author and validate Camel catalogs",
+		Long:  `Create, validate and compare the Camel catalogs, e.g., the ones of custom runtimes.`,
+	}
+
+	cmd.AddCommand(cmdOnly(newCatalogCreateCmd(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newCatalogValidateCmd(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newCatalogDiffCmd(rootCmdOptions)))
+
+	return &cmd
+}
+
+// localCatalogs returns true if all the given catalogs are local files, so that they can be loaded
+// without connecting to the cluster
+func localCatalogs(catalogs []string) bool {
+	for _, catalog := range catalogs {
+		if ok, err := isLocalAndFileExists(catalog); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// loadCatalog loads the catalog either from a local file, e.g., as it is printed by the create command,
+// or from the CamelCatalog resource with the given name in the current namespace
+func loadCatalog(command *RootCmdOptions, catalog string) (*v1.CamelCatalog, error) {
+	ok, err := isLocalAndFileExists(catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		data, err := ioutil.ReadFile(catalog)
+		if err != nil {
+			return nil, err
+		}
+		jsonData, err := yaml.ToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		cx := v1.CamelCatalog{}
+		if err := json.Unmarshal(jsonData, &cx); err != nil {
+			return nil, errors.Wrapf(err, "invalid catalog %s", catalog)
+		}
+		return &cx, nil
+	}
+
+	c, err := command.GetCmdClient()
+	if err != nil {
+		return nil, err
+	}
+	cx := v1.NewCamelCatalog(command.Namespace, catalog)
+	if err := c.Get(command.Context, ctrl.ObjectKeyFromObject(&cx), &cx); err != nil {
+		return nil, errors.Wrapf(err, "cannot load catalog %s", catalog)
+	}
+	return &cx, nil
+}
@@ -0,0 +1,149 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+func newCatalogCreateCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *catalogCreateCommandOptions) {
+	options := catalogCreateCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "create",
+		Short: "Create a Camel catalog",
+		Long: `Generate the Camel catalog of a runtime version, validate it, and create the CamelCatalog resource in the current namespace.
+The catalog of a custom runtime, e.g., one that is based on its own BOM, can be generated by adding
+the Maven artifacts that contribute to the catalog with the --dependency option.
+When --output is set, the catalog is printed instead, so that it can be reviewed before being created.`,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: decode(&options),
+		PreRunE:           options.preRunE,
+		RunE:              options.run,
+		Annotations:       make(map[string]string),
+	}
+
+	cmd.Flags().String("runtime-version", defaults.DefaultRuntimeVersion, "The version of the Camel K runtime")
+	cmd.Flags().String("name", "", "The name of the catalog, defaults to one derived from the runtime version")
+	cmd.Flags().StringArrayP("dependency", "d", nil, "A Maven artifact that contributes to the catalog, e.g., mvn:org.acme:acme-catalog:1.0.0")
+	cmd.Flags().StringArray("maven-repository", nil, "Use a maven repository")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
+
+	return &cmd, &options
+}
+
+type catalogCreateCommandOptions struct {
+	*RootCmdOptions
+	RuntimeVersion    string   `mapstructure:"runtime-version"`
+	Name              string   `mapstructure:"name"`
+	Dependencies      []string `mapstructure:"dependencies"`
+	MavenRepositories []string `mapstructure:"maven-repositories"`
+	OutputFormat      string   `mapstructure:"output"`
+}
+
+func (command *catalogCreateCommandOptions) preRunE(cmd *cobra.Command, args []string) error {
+	if command.OutputFormat != "" {
+		// the catalog is only printed, so that the command can work in offline mode
+		cmd.Annotations[offlineCommandLabel] = "true"
+	}
+	return command.RootCmdOptions.preRun(cmd, args)
+}
+
+func (command *catalogCreateCommandOptions) validate() error {
+	if command.RuntimeVersion == "" {
+		return errors.New("the runtime version is required")
+	}
+	for _, d := range command.Dependencies {
+		if !strings.HasPrefix(d, "mvn:") {
+			return fmt.Errorf("unsupported catalog dependency %s, only Maven artifacts, e.g., mvn:org.acme:acme-catalog:1.0.0, are supported", d)
+		}
+	}
+	if command.OutputFormat != "" && command.OutputFormat != "yaml" && command.OutputFormat != "json" {
+		return errors.New("unknown output format: " + command.OutputFormat)
+	}
+	return nil
+}
+
+func (command *catalogCreateCommandOptions) run(cmd *cobra.Command, _ []string) error {
+	if err := command.validate(); err != nil {
+		return err
+	}
+
+	dependencies := make([]maven.Dependency, 0, len(command.Dependencies))
+	for _, d := range command.Dependencies {
+		dependency, err := maven.ParseGAV(strings.TrimPrefix(d, "mvn:"))
+		if err != nil {
+			return errors.Wrapf(err, "invalid catalog dependency %s", d)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	settings, err := newMavenSettings(command.MavenRepositories)
+	if err != nil {
+		return err
+	}
+
+	runtime := v1.RuntimeSpec{
+		Version:  command.RuntimeVersion,
+		Provider: v1.RuntimeProviderQuarkus,
+	}
+	catalog, err := camel.GenerateCatalogCommon(command.Context, string(settings), nil, nil, v1.MavenSpec{}, runtime, dependencies)
+	if err != nil {
+		return errors.Wrap(err, "cannot generate the catalog")
+	}
+	if err := camel.ValidateCatalog(catalog.CamelCatalogSpec); err != nil {
+		return errors.Wrap(err, "invalid catalog")
+	}
+
+	name := command.Name
+	if name == "" {
+		name = "camel-catalog-" + strings.ToLower(runtime.Version) + "-" + string(runtime.Provider)
+	}
+	cx := v1.NewCamelCatalogWithSpecs(command.Namespace, name, catalog.CamelCatalogSpec)
+	cx.Labels = map[string]string{
+		"app":                               "camel-k",
+		"camel.apache.org/runtime.version":  runtime.Version,
+		"camel.apache.org/runtime.provider": string(runtime.Provider),
+	}
+
+	if command.OutputFormat != "" {
+		return printObject(cmd.OutOrStdout(), command.OutputFormat, cx)
+	}
+
+	c, err := command.GetCmdClient()
+	if err != nil {
+		return err
+	}
+	if err := c.Create(command.Context, &cx); err != nil {
+		return errors.Wrapf(err, "cannot create catalog %s", name)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "camel catalog \"%s\" created\n", name)
+
+	return nil
+}
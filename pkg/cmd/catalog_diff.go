@@ -0,0 +1,186 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+func newCatalogDiffCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *catalogDiffCommandOptions) {
+	options := catalogDiffCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "diff <from> <to>",
+		Short: "Compare two Camel catalogs",
+		Long: `Compare two Camel catalogs, that are either local files or CamelCatalog resources in the current namespace.
+The changed runtime metadata, the added, removed and changed component artifacts, and the removed schemes
+and languages are reported.
+When --integrations is enabled, the Integrations of the current namespace that use the source catalog,
+and depend on artifacts that are removed, or whose coordinates change, in the target catalog are reported as well.`,
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: decode(&options),
+		PreRunE:           options.preRunE,
+		RunE:              options.run,
+		Annotations:       make(map[string]string),
+	}
+
+	cmd.Flags().Bool("integrations", false, "Report the Integrations that would be broken by the changes")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
+
+	return &cmd, &options
+}
+
+type catalogDiffCommandOptions struct {
+	*RootCmdOptions
+	Integrations bool   `mapstructure:"integrations"`
+	OutputFormat string `mapstructure:"output"`
+}
+
+// catalogDiffReport holds the differences between two catalogs, along with the Integrations they break
+type catalogDiffReport struct {
+	camel.CatalogDiff
+	BrokenIntegrations []brokenIntegration `json:"brokenIntegrations,omitempty"`
+}
+
+// brokenIntegration holds the dependencies of an Integration that are no longer provided by the target catalog
+type brokenIntegration struct {
+	Name         string   `json:"name"`
+	Dependencies []string `json:"dependencies"`
+}
+
+func (command *catalogDiffCommandOptions) preRunE(cmd *cobra.Command, args []string) error {
+	if !command.Integrations && localCatalogs(args) {
+		cmd.Annotations[offlineCommandLabel] = "true"
+	}
+	return command.RootCmdOptions.preRun(cmd, args)
+}
+
+func (command *catalogDiffCommandOptions) validate() error {
+	if command.OutputFormat != "" && command.OutputFormat != "yaml" && command.OutputFormat != "json" {
+		return errors.New("unknown output format: " + command.OutputFormat)
+	}
+	return nil
+}
+
+func (command *catalogDiffCommandOptions) run(cmd *cobra.Command, args []string) error {
+	if err := command.validate(); err != nil {
+		return err
+	}
+
+	from, err := loadCatalog(command.RootCmdOptions, args[0])
+	if err != nil {
+		return err
+	}
+	to, err := loadCatalog(command.RootCmdOptions, args[1])
+	if err != nil {
+		return err
+	}
+
+	report := catalogDiffReport{
+		CatalogDiff: camel.DiffCatalogs(from.Spec, to.Spec),
+	}
+
+	if command.Integrations {
+		c, err := command.GetCmdClient()
+		if err != nil {
+			return err
+		}
+		integrations := v1.NewIntegrationList()
+		if err := c.List(command.Context, &integrations, ctrl.InNamespace(command.Namespace)); err != nil {
+			return err
+		}
+		for _, it := range integrations.Items {
+			// Only the Integrations that are built with the source catalog are impacted
+			if it.Status.RuntimeVersion != from.Spec.Runtime.Version {
+				continue
+			}
+			if broken := report.BrokenDependencies(it.Status.Dependencies); len(broken) > 0 {
+				report.BrokenIntegrations = append(report.BrokenIntegrations, brokenIntegration{
+					Name:         it.Name,
+					Dependencies: broken,
+				})
+			}
+		}
+	}
+
+	if command.OutputFormat != "" {
+		return printObject(cmd.OutOrStdout(), command.OutputFormat, report)
+	}
+	printCatalogDiff(cmd.OutOrStdout(), report)
+
+	return nil
+}
+
+func printCatalogDiff(out io.Writer, report catalogDiffReport) {
+	if report.IsEmpty() && len(report.BrokenIntegrations) == 0 {
+		fmt.Fprintln(out, "No differences")
+		return
+	}
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(out, "%s:\n", title)
+		for _, line := range lines {
+			fmt.Fprintf(out, "  %s\n", line)
+		}
+	}
+
+	if report.FromRuntimeVersion != report.ToRuntimeVersion {
+		fmt.Fprintf(out, "Runtime version: %s -> %s\n", report.FromRuntimeVersion, report.ToRuntimeVersion)
+	}
+	metadata := make([]string, 0, len(report.ChangedMetadata))
+	for _, m := range report.ChangedMetadata {
+		metadata = append(metadata, fmt.Sprintf("%s: %s -> %s", m.Key, m.From, m.To))
+	}
+	section("Changed metadata", metadata)
+	section("Added artifacts", report.AddedArtifacts)
+	section("Removed artifacts", report.RemovedArtifacts)
+	artifacts := make([]string, 0, len(report.ChangedArtifacts))
+	for _, a := range report.ChangedArtifacts {
+		artifacts = append(artifacts, fmt.Sprintf("%s: %s -> %s", a.ID, formatArtifact(a.From), formatArtifact(a.To)))
+	}
+	section("Changed artifacts", artifacts)
+	section("Removed schemes", report.RemovedSchemes)
+	section("Removed languages", report.RemovedLanguages)
+	integrations := make([]string, 0, len(report.BrokenIntegrations))
+	for _, it := range report.BrokenIntegrations {
+		integrations = append(integrations, fmt.Sprintf("%s: %s", it.Name, strings.Join(it.Dependencies, ", ")))
+	}
+	section("Broken integrations", integrations)
+}
+
+func formatArtifact(artifact v1.MavenArtifact) string {
+	if artifact.Version == "" {
+		return artifact.GroupID + ":" + artifact.ArtifactID
+	}
+	return artifact.GroupID + ":" + artifact.ArtifactID + ":" + artifact.Version
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func initializeCatalogCmd(t *testing.T, objects ...runtime.Object) *cobra.Command {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	c, err := test.NewFakeClient(objects...)
+	assert.Nil(t, err)
+	options._client = c
+	rootCmd.AddCommand(newCmdCatalog(options))
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return rootCmd
+}
+
+func writeCatalogFile(t *testing.T, catalog v1.CamelCatalog) string {
+	t.Helper()
+
+	var data bytes.Buffer
+	assert.Nil(t, printObject(&data, "yaml", catalog))
+	file := path.Join(t.TempDir(), catalog.Name+".yaml")
+	assert.Nil(t, ioutil.WriteFile(file, data.Bytes(), os.ModePerm))
+	return file
+}
+
+func TestCatalogValidate(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+	valid := writeCatalogFile(t, v1.NewCamelCatalogWithSpecs("", "valid", catalog.CamelCatalogSpec))
+
+	spec := catalog.CamelCatalogSpec.DeepCopy()
+	spec.Runtime.ApplicationClass = ""
+	invalid := writeCatalogFile(t, v1.NewCamelCatalogWithSpecs("", "invalid", *spec))
+
+	output, err := test.ExecuteCommand(initializeCatalogCmd(t), "catalog", "validate", valid)
+	assert.Nil(t, err)
+	assert.Equal(t, "camel catalog \""+valid+"\" is valid\n", output)
+
+	output, err = test.ExecuteCommand(initializeCatalogCmd(t), "catalog", "validate", valid, invalid)
+	assert.EqualError(t, err, "some of the catalogs are invalid")
+	assert.Contains(t, output, "camel catalog \""+invalid+"\" is invalid: runtime application class is missing\n")
+}
+
+func TestCatalogDiff(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+	from := writeCatalogFile(t, v1.NewCamelCatalogWithSpecs("", "from", catalog.CamelCatalogSpec))
+
+	spec := catalog.CamelCatalogSpec.DeepCopy()
+	spec.Runtime.Version = "1.9.0-acme"
+	delete(spec.Artifacts, "camel-quarkus-log")
+	timer := spec.Artifacts["camel-quarkus-timer"]
+	timer.Version = "2.2.0-acme"
+	spec.Artifacts["camel-quarkus-timer"] = timer
+	to := v1.NewCamelCatalogWithSpecs("default", "acme", *spec)
+
+	integration := v1.NewIntegration("default", "my-integration")
+	integration.Status.RuntimeVersion = catalog.Runtime.Version
+	integration.Status.Dependencies = []string{"camel:log", "camel:timer", "mvn:org.acme:acme:1.0.0"}
+
+	rootCmd := initializeCatalogCmd(t, &to, &integration)
+	output, err := test.ExecuteCommand(rootCmd, "catalog", "diff", from, "acme", "--integrations", "-n", "default")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "Runtime version: "+catalog.Runtime.Version+" -> 1.9.0-acme\n")
+	assert.Contains(t, output, "Removed artifacts:\n  camel-quarkus-log\n")
+	assert.Contains(t, output, "Changed artifacts:\n  camel-quarkus-timer: "+
+		"org.apache.camel.quarkus:camel-quarkus-timer -> org.apache.camel.quarkus:camel-quarkus-timer:2.2.0-acme\n")
+	assert.Contains(t, output, "Removed schemes:\n  log\n")
+	assert.Contains(t, output, "Broken integrations:\n  my-integration: camel:log\n")
+
+	output, err = test.ExecuteCommand(initializeCatalogCmd(t), "catalog", "diff", from, from)
+	assert.Nil(t, err)
+	assert.Equal(t, "No differences\n", output)
+}
+
+func TestCatalogCreateValidation(t *testing.T) {
+	options := catalogCreateCommandOptions{RuntimeVersion: "1.9.0", Dependencies: []string{"camel:log"}}
+	assert.EqualError(t, options.validate(),
+		"unsupported catalog dependency camel:log, only Maven artifacts, e.g., mvn:org.acme:acme-catalog:1.0.0, are supported")
+
+	options = catalogCreateCommandOptions{RuntimeVersion: "1.9.0", OutputFormat: "xml"}
+	assert.EqualError(t, options.validate(), "unknown output format: xml")
+
+	options = catalogCreateCommandOptions{RuntimeVersion: "1.9.0", Dependencies: []string{"mvn:org.acme:acme-catalog:1.0.0"}}
+	assert.Nil(t, options.validate())
+}
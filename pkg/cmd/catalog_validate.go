@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+func newCatalogValidateCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *catalogValidateCommandOptions) {
+	options := catalogValidateCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "validate [catalog files or names]",
+		Short: "Validate Camel catalogs",
+		Long: `Validate Camel catalogs, that are either local files or CamelCatalog resources in the current namespace,
+e.g., before a custom catalog is used by the operator.`,
+		Args:              cobra.MinimumNArgs(1),
+		PersistentPreRunE: decode(&options),
+		PreRunE:           options.preRunE,
+		RunE:              options.run,
+		Annotations:       make(map[string]string),
+	}
+
+	return &cmd, &options
+}
+
+type catalogValidateCommandOptions struct {
+	*RootCmdOptions
+}
+
+func (command *catalogValidateCommandOptions) preRunE(cmd *cobra.Command, args []string) error {
+	if localCatalogs(args) {
+		cmd.Annotations[offlineCommandLabel] = "true"
+	}
+	return command.RootCmdOptions.preRun(cmd, args)
+}
+
+func (command *catalogValidateCommandOptions) run(cmd *cobra.Command, args []string) error {
+	valid := true
+	for _, arg := range args {
+		catalog, err := loadCatalog(command.RootCmdOptions, arg)
+		if err != nil {
+			return err
+		}
+		if err := camel.ValidateCatalog(catalog.Spec); err != nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "camel catalog \"%s\" is invalid: %s\n", arg, err.Error())
+			valid = false
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "camel catalog \"%s\" is valid\n", arg)
+		}
+	}
+
+	if !valid {
+		return errors.New("some of the catalogs are invalid")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// contextsConfigNode is the node of the kamel configuration the contexts are stored in
+	contextsConfigNode = "kamel.contexts"
+	// currentContextConfigKey is the key of the current context in the kamel node of the kamel configuration
+	currentContextConfigKey = "current-context"
+)
+
+// kamelContext is a named combination of the kube config, its context and the namespace the commands are executed against
+type kamelContext struct {
+	KubeConfig  string `mapstructure:"kube-config" json:"kubeConfig,omitempty"`
+	KubeContext string `mapstructure:"kube-context" json:"kubeContext,omitempty"`
+	Namespace   string `mapstructure:"namespace" json:"namespace,omitempty"`
+}
+
+func newCmdContext(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "context",
+		Short: "Manage the kamel contexts",
+		Long: `Manage the kamel contexts, that are named combinations of the kube config, its context and the namespace,
+the commands are executed against. The current context is used by all the commands, unless another one is selected
+with the --context flag, or the KAMEL_CONTEXT environment variable.`,
+	}
+
+	cmd.AddCommand(cmdOnly(newContextSetCmd(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newContextUseCmd(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newContextGetCmd(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newContextDeleteCmd(rootCmdOptions)))
+
+	return &cmd
+}
+
+// applyContext sets the kube config, its context and the namespace of the selected kamel context, or of the current one,
+// unless they are explicitly set with their flags
+func (command *RootCmdOptions) applyContext(cmd *cobra.Command) error {
+	cfg, err := LoadConfiguration()
+	if err != nil {
+		return err
+	}
+	name := command.ContextName
+	if name == "" {
+		name = getCurrentContext(cfg)
+	}
+	if name == "" {
+		return nil
+	}
+	kctx, err := getContext(cfg, name)
+	if err != nil {
+		return err
+	}
+
+	if kctx.KubeConfig != "" && !cmd.Flag("kube-config").Changed {
+		if err := cmd.Flag("kube-config").Value.Set(kctx.KubeConfig); err != nil {
+			return err
+		}
+	}
+	command.KubeContext = kctx.KubeContext
+	if kctx.Namespace != "" && !cmd.Flag("namespace").Changed {
+		if err := cmd.Flag("namespace").Value.Set(kctx.Namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getContexts returns the contexts stored in the kamel configuration
+func getContexts(cfg *Config) (map[string]kamelContext, error) {
+	contexts := make(map[string]kamelContext)
+	node := cfg.navigate(cfg.content, contextsConfigNode, false)
+	for name, value := range node {
+		if m, ok := value.(map[interface{}]interface{}); ok {
+			value = cfg.convert(m)
+		}
+		kctx := kamelContext{}
+		if err := mapstructure.Decode(value, &kctx); err != nil {
+			return nil, fmt.Errorf("invalid context %s: %v", name, err)
+		}
+		contexts[name] = kctx
+	}
+	return contexts, nil
+}
+
+// getContext returns the context with the given name stored in the kamel configuration
+func getContext(cfg *Config, name string) (kamelContext, error) {
+	contexts, err := getContexts(cfg)
+	if err != nil {
+		return kamelContext{}, err
+	}
+	kctx, ok := contexts[name]
+	if !ok {
+		return kamelContext{}, fmt.Errorf("context %s not found", name)
+	}
+	return kctx, nil
+}
+
+// getContextNames returns the sorted names of the contexts stored in the kamel configuration
+func getContextNames(contexts map[string]kamelContext) []string {
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getCurrentContext returns the name of the current context, or an empty string when no context is in use
+func getCurrentContext(cfg *Config) string {
+	if node := cfg.navigate(cfg.content, "kamel", false); node != nil {
+		if name, ok := node[currentContextConfigKey].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// setCurrentContext sets the name of the current context, that is unset when the name is empty
+func setCurrentContext(cfg *Config, name string) {
+	node := cfg.navigate(cfg.content, "kamel", true)
+	if name == "" {
+		delete(node, currentContextConfigKey)
+	} else {
+		node[currentContextConfigKey] = name
+	}
+}
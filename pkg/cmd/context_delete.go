@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newContextDeleteCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *contextDeleteCommandOptions) {
+	options := contextDeleteCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:     "delete <name>",
+		Short:   "Delete a kamel context",
+		Long:    `Delete a kamel context. The current context is unset when it's deleted.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: decode(&options),
+		RunE:    options.run,
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	return &cmd, &options
+}
+
+type contextDeleteCommandOptions struct {
+	*RootCmdOptions
+}
+
+func (command *contextDeleteCommandOptions) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := LoadConfiguration()
+	if err != nil {
+		return err
+	}
+	if _, err := getContext(cfg, name); err != nil {
+		return err
+	}
+
+	delete(cfg.navigate(cfg.content, contextsConfigNode, false), name)
+	if getCurrentContext(cfg) == name {
+		setCurrentContext(cfg, "")
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Context %s deleted\n", name)
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func newContextGetCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *contextGetCommandOptions) {
+	options := contextGetCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "get [name]",
+		Short: "Get the kamel contexts",
+		Long:  `Get the kamel contexts, or the one with the given name. The current context is marked with a star.`,
+		Example: `  kamel context get
+  kamel context get prod -o yaml`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: decode(&options),
+		RunE:    options.run,
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format. One of: table|json|yaml")
+
+	return &cmd, &options
+}
+
+type contextGetCommandOptions struct {
+	*RootCmdOptions
+	OutputFormat string `mapstructure:"output"`
+}
+
+// namedKamelContext is the output representation of a kamel context
+type namedKamelContext struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current,omitempty"`
+	kamelContext
+}
+
+func (command *contextGetCommandOptions) run(cmd *cobra.Command, args []string) error {
+	if command.OutputFormat != "table" && command.OutputFormat != "json" && command.OutputFormat != "yaml" {
+		return errors.New("unknown output format: " + command.OutputFormat)
+	}
+
+	cfg, err := LoadConfiguration()
+	if err != nil {
+		return err
+	}
+	contexts, err := getContexts(cfg)
+	if err != nil {
+		return err
+	}
+	current := getCurrentContext(cfg)
+
+	names := getContextNames(contexts)
+	if len(args) > 0 {
+		if _, ok := contexts[args[0]]; !ok {
+			return fmt.Errorf("context %s not found", args[0])
+		}
+		names = args
+	}
+	result := make([]namedKamelContext, 0, len(names))
+	for _, name := range names {
+		result = append(result, namedKamelContext{Name: name, Current: name == current, kamelContext: contexts[name]})
+	}
+
+	if command.OutputFormat != "table" {
+		return printObject(cmd.OutOrStdout(), command.OutputFormat, result)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tKUBE CONFIG\tKUBE CONTEXT\tNAMESPACE")
+	for _, c := range result {
+		marker := ""
+		if c.Current {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", marker, c.Name, c.KubeConfig, c.KubeContext, c.Namespace)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newContextSetCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *contextSetCommandOptions) {
+	options := contextSetCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "set <name>",
+		Short: "Create or update a kamel context",
+		Long: `Create or update a kamel context, with the kube config, its context and the namespace set with the
+--kube-config, --kube-context and --namespace flags. Only the explicitly set flags are updated.`,
+		Example: `  kamel context set dev --kube-context kind-dev --namespace camel-dev
+  kamel context set prod --kube-config ~/.kube/prod --namespace camel-prod`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: decode(&options),
+		RunE:    options.run,
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().String("kube-context", "", "The context of the kube config to use")
+	cmd.Flags().Bool("use", false, "Use the context as the current one")
+
+	return &cmd, &options
+}
+
+type contextSetCommandOptions struct {
+	*RootCmdOptions
+	KubeContextName string `mapstructure:"kube-context"`
+	Use             bool   `mapstructure:"use"`
+}
+
+func (command *contextSetCommandOptions) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if name == "" {
+		return errors.New("the context name must not be empty")
+	}
+
+	cfg, err := LoadConfiguration()
+	if err != nil {
+		return err
+	}
+	contexts, err := getContexts(cfg)
+	if err != nil {
+		return err
+	}
+
+	kctx, exists := contexts[name]
+	if cmd.Flag("kube-config").Changed {
+		kctx.KubeConfig = command.KubeConfig
+	}
+	if cmd.Flag("kube-context").Changed {
+		kctx.KubeContext = command.KubeContextName
+	}
+	if cmd.Flag("namespace").Changed {
+		kctx.Namespace = command.Namespace
+	}
+
+	values := make(map[string]interface{})
+	if kctx.KubeConfig != "" {
+		values["kube-config"] = kctx.KubeConfig
+	}
+	if kctx.KubeContext != "" {
+		values["kube-context"] = kctx.KubeContext
+	}
+	if kctx.Namespace != "" {
+		values["namespace"] = kctx.Namespace
+	}
+	cfg.SetNode(contextsConfigNode+"."+name, values)
+	if command.Use {
+		setCurrentContext(cfg, name)
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if exists {
+		fmt.Fprintf(cmd.OutOrStdout(), "Context %s updated\n", name)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "Context %s created\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+// newContextTestConfig returns the location of a kamel configuration the context commands are tested against
+func newContextTestConfig(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "context-")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+		viper.Reset()
+	})
+
+	return path.Join(dir, DefaultConfigLocation)
+}
+
+// newContextTestCmd returns a new kamel command using the given kamel configuration, so that the flags are reset
+// between the executions
+func newContextTestCmd(t *testing.T, config string) (*RootCmdOptions, *cobra.Command) {
+	t.Helper()
+
+	viper.Reset()
+	options, rootCmd := kamelTestPreAddCommandInit()
+	rootCmd.AddCommand(newCmdContext(options))
+	kamelTestPostAddCommandInit(t, rootCmd)
+	viper.SetConfigFile(config)
+
+	return options, rootCmd
+}
+
+func executeContextCmd(t *testing.T, config string, args ...string) (string, error) {
+	t.Helper()
+
+	_, rootCmd := newContextTestCmd(t, config)
+	return test.ExecuteCommand(rootCmd, append([]string{"context"}, args...)...)
+}
+
+func TestContextSetAndGet(t *testing.T) {
+	config := newContextTestConfig(t)
+
+	_, err := executeContextCmd(t, config, "set", "dev", "--kube-context", "kind-dev", "-n", "camel-dev")
+	require.NoError(t, err)
+	_, err = executeContextCmd(t, config, "set", "prod", "--kube-config", "/tmp/prod", "--use")
+	require.NoError(t, err)
+	// Only the explicitly set flags are updated
+	_, err = executeContextCmd(t, config, "set", "dev", "-n", "camel-test")
+	require.NoError(t, err)
+
+	output, err := executeContextCmd(t, config, "get", "-o", "json")
+	require.NoError(t, err)
+	var contexts []namedKamelContext
+	require.NoError(t, json.Unmarshal([]byte(output), &contexts))
+	assert.Equal(t, []namedKamelContext{
+		{Name: "dev", kamelContext: kamelContext{KubeContext: "kind-dev", Namespace: "camel-test"}},
+		{Name: "prod", Current: true, kamelContext: kamelContext{KubeConfig: "/tmp/prod"}},
+	}, contexts)
+
+	output, err = executeContextCmd(t, config, "get")
+	require.NoError(t, err)
+	assert.Contains(t, output, "CURRENT")
+	assert.Contains(t, output, "kind-dev")
+
+	_, err = executeContextCmd(t, config, "get", "missing")
+	assert.EqualError(t, err, "context missing not found")
+}
+
+func TestContextUseAndDelete(t *testing.T) {
+	config := newContextTestConfig(t)
+
+	_, err := executeContextCmd(t, config, "set", "dev", "-n", "camel-dev")
+	require.NoError(t, err)
+
+	_, err = executeContextCmd(t, config, "use", "missing")
+	assert.EqualError(t, err, "context missing not found")
+	_, err = executeContextCmd(t, config, "use")
+	assert.Error(t, err)
+
+	_, err = executeContextCmd(t, config, "use", "dev")
+	require.NoError(t, err)
+	cfg, err := LoadConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, "dev", getCurrentContext(cfg))
+
+	_, err = executeContextCmd(t, config, "delete", "dev")
+	require.NoError(t, err)
+	cfg, err = LoadConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, "", getCurrentContext(cfg))
+	contexts, err := getContexts(cfg)
+	require.NoError(t, err)
+	assert.Empty(t, contexts)
+
+	_, err = executeContextCmd(t, config, "delete", "dev")
+	assert.EqualError(t, err, "context dev not found")
+}
+
+func TestApplyContext(t *testing.T) {
+	config := newContextTestConfig(t)
+
+	_, err := executeContextCmd(t, config, "set", "dev", "--kube-config", "/tmp/dev",
+		"--kube-context", "kind-dev", "-n", "camel-dev", "--use")
+	require.NoError(t, err)
+	options, rootCmd := newContextTestCmd(t, config)
+
+	require.NoError(t, options.applyContext(rootCmd))
+	assert.Equal(t, "/tmp/dev", options.KubeConfig)
+	assert.Equal(t, "kind-dev", options.KubeContext)
+	assert.Equal(t, "camel-dev", options.Namespace)
+
+	// The explicitly set flags take precedence
+	require.NoError(t, rootCmd.Flag("namespace").Value.Set("other"))
+	rootCmd.Flag("namespace").Changed = true
+	require.NoError(t, options.applyContext(rootCmd))
+	assert.Equal(t, "other", options.Namespace)
+
+	options.ContextName = "missing"
+	assert.EqualError(t, options.applyContext(rootCmd), "context missing not found")
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newContextUseCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *contextUseCommandOptions) {
+	options := contextUseCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "use [name]",
+		Short: "Use a kamel context as the current one",
+		Long: `Use a kamel context as the current one, that is used by all the commands, unless another one is selected
+with the --context flag. When --unset is enabled, the commands use the current context of the kube config instead.`,
+		Example: `  kamel context use prod
+  kamel context use --unset`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: decode(&options),
+		RunE:    options.run,
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().Bool("unset", false, "Unset the current context")
+
+	return &cmd, &options
+}
+
+type contextUseCommandOptions struct {
+	*RootCmdOptions
+	Unset bool `mapstructure:"unset"`
+}
+
+func (command *contextUseCommandOptions) validate(args []string) error {
+	if command.Unset && len(args) > 0 {
+		return errors.New("invalid combination: both unset flag and context name are set")
+	}
+	if !command.Unset && len(args) == 0 {
+		return errors.New("invalid combination: neither unset flag nor context name are set")
+	}
+	return nil
+}
+
+func (command *contextUseCommandOptions) run(cmd *cobra.Command, args []string) error {
+	if err := command.validate(args); err != nil {
+		return err
+	}
+
+	cfg, err := LoadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	if command.Unset {
+		setCurrentContext(cfg, "")
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "Current context unset")
+		return nil
+	}
+
+	name := args[0]
+	if _, err := getContext(cfg, name); err != nil {
+		return err
+	}
+	setCurrentContext(cfg, name)
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %s\n", name)
+	return nil
+}
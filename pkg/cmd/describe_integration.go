@@ -25,6 +25,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	corev1 "k8s.io/api/core/v1"
+
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -56,13 +58,15 @@ func newDescribeIntegrationCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command,
 	}
 
 	cmd.Flags().BoolVar(&options.showSourceContent, "show-source-content", false, "Print source content")
+	cmd.Flags().BoolVar(&options.showRecommendations, "recommendations", false, "Print the resources recommendations of the Vertical Pod Autoscaler, reported by the vpa trait")
 
 	return &cmd, &options
 }
 
 type describeIntegrationCommandOptions struct {
 	*RootCmdOptions
-	showSourceContent bool `mapstructure:"show-source-content"`
+	showSourceContent   bool `mapstructure:"show-source-content"`
+	showRecommendations bool `mapstructure:"recommendations"`
 }
 
 func (command *describeIntegrationCommandOptions) validate(_ *cobra.Command, args []string) error {
@@ -187,6 +191,41 @@ func (command *describeIntegrationCommandOptions) describeIntegration(cmd *cobra
 			}
 		}
 
+		if command.showRecommendations {
+			describeRecommendations(w, i.Status.Recommendations)
+		}
+
 		return describeTraits(w, i.Spec.Traits)
 	})
 }
+
+func describeRecommendations(w *indentedwriter.Writer, recommendations []v1.ResourceRecommendation) {
+	if len(recommendations) == 0 {
+		w.Write(0, "Recommendations:\tnone\n")
+		return
+	}
+
+	w.Write(0, "Recommendations:\n")
+	w.Write(1, "Container\tResource\tLower Bound\tTarget\tUpper Bound\tApplied\n")
+	for _, r := range recommendations {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			target, ok := r.Target[name]
+			if !ok {
+				continue
+			}
+			applied := ""
+			if q, ok := r.Applied[name]; ok {
+				applied = q.String()
+			}
+			lowerBound := r.LowerBound[name]
+			upperBound := r.UpperBound[name]
+			w.Write(1, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				r.Container,
+				name,
+				lowerBound.String(),
+				target.String(),
+				upperBound.String(),
+				applied)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/magiconair/properties"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/dsl"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func newCmdExport(rootCmdOptions *RootCmdOptions) (*cobra.Command, *exportCmdOptions) {
+	options := exportCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "export [integration name]",
+		Short: "Export an integration as a Camel JBang project",
+		Long: `Export an integration deployed on the cluster as a Camel JBang project, i.e., a directory containing its routes
+and an application.properties file with its properties and dependencies, that can be run locally with "camel run *".
+The project can be deployed back with "kamel run <directory>".`,
+		Example: `  kamel export my-integration --directory my-integration`,
+		Args:    options.validateArgs,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().String("directory", "", "Directory where the project is exported. It must not exist or be empty. Defaults to the integration name.")
+
+	// completion support
+	configureKnownCompletions(&cmd)
+
+	return &cmd, &options
+}
+
+type exportCmdOptions struct {
+	*RootCmdOptions
+	Directory string `mapstructure:"directory"`
+}
+
+func (o *exportCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("export expects an integration name argument")
+	}
+	return nil
+}
+
+func (o *exportCmdOptions) validate(args []string) error {
+	if o.Directory == "" {
+		o.Directory = args[0]
+	}
+	files, err := ioutil.ReadDir(o.Directory)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(files) > 0 {
+		return fmt.Errorf("the directory %s is not empty", o.Directory)
+	}
+	return nil
+}
+
+func (o *exportCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	it := v1.NewIntegration(o.Namespace, args[0])
+	if err := c.Get(o.Context, ctrl.ObjectKeyFromObject(&it), &it); err != nil {
+		return errors.Wrapf(err, "cannot get integration %s", args[0])
+	}
+
+	files, warnings, err := exportJBangProject(o.Context, c, &it)
+	if err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := util.WriteFileWithContent(o.Directory, name, content); err != nil {
+			return err
+		}
+	}
+
+	for _, w := range warnings {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", w)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Integration %s exported as a Camel JBang project in %s\n", it.Name, o.Directory)
+	return nil
+}
+
+// exportJBangProject generates the files of a Camel JBang project that runs the given integration, indexed by their
+// path relative to the project directory. It also returns the parts of the integration that cannot be exported.
+func exportJBangProject(ctx context.Context, c ctrl.Reader, it *v1.Integration) (map[string][]byte, []string, error) {
+	files := make(map[string][]byte)
+	warnings := make([]string, 0)
+
+	sources, err := kubernetes.ResolveIntegrationSources(ctx, c, it, kubernetes.NewCollection())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, s := range sources {
+		name := s.Name
+		if language := s.InferLanguage(); language != "" && path.Ext(name) == "" {
+			name += "." + string(language)
+		}
+		files[name] = []byte(s.Content)
+	}
+
+	if len(it.Spec.Flows) > 0 {
+		flows, err := dsl.ToYamlDSL(it.Spec.Flows)
+		if err != nil {
+			return nil, nil, err
+		}
+		name := it.Name + ".yaml"
+		if _, ok := files[name]; ok {
+			name = it.Name + "-flows.yaml"
+		}
+		files[name] = flows
+	}
+
+	props := properties.NewProperties()
+	camelProperties, err := integrationCamelProperties(it)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, cp := range camelProperties {
+		p, err := keyValueProps(cp)
+		if err != nil {
+			return nil, nil, err
+		}
+		props.Merge(p)
+	}
+
+	dependencies := make([]string, 0, len(it.Spec.Dependencies))
+	for _, d := range it.Spec.Dependencies {
+		dependencies = append(dependencies, toJBangDependency(d))
+	}
+	if len(dependencies) > 0 {
+		if _, _, err := props.Set(jbangDependenciesProperty, strings.Join(dependencies, ",")); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if props.Len() > 0 {
+		var buf bytes.Buffer
+		if _, err := props.Write(&buf, properties.UTF8); err != nil {
+			return nil, nil, err
+		}
+		files[projectPropertiesFileName] = buf.Bytes()
+	}
+
+	for _, cs := range it.Spec.Configuration {
+		if cs.Type != "property" {
+			warnings = append(warnings, fmt.Sprintf("the %s configuration %s is not exported", cs.Type, cs.Value))
+		}
+	}
+	for _, r := range it.Spec.Resources {
+		warnings = append(warnings, fmt.Sprintf("the resource %s is not exported", r.Name))
+	}
+	traits := make([]string, 0, len(it.Spec.Traits))
+	for name := range it.Spec.Traits {
+		if name != "camel" {
+			traits = append(traits, name)
+		}
+	}
+	if len(traits) > 0 {
+		sort.Strings(traits)
+		warnings = append(warnings, fmt.Sprintf("the configuration of the traits %s is not exported", strings.Join(traits, ", ")))
+	}
+
+	return files, warnings, nil
+}
+
+// integrationCamelProperties returns the runtime properties of the integration, set either with the camel trait
+// or, for older integrations, as property configuration
+func integrationCamelProperties(it *v1.Integration) ([]string, error) {
+	camelProperties := make([]string, 0)
+	for _, cs := range it.Spec.Configuration {
+		if cs.Type == "property" {
+			camelProperties = append(camelProperties, cs.Value)
+		}
+	}
+
+	if traitSpec, ok := it.Spec.Traits["camel"]; ok && len(traitSpec.Configuration.RawMessage) > 0 {
+		config := struct {
+			Properties []string `json:"properties"`
+		}{}
+		if err := json.Unmarshal(traitSpec.Configuration.RawMessage, &config); err != nil {
+			return nil, err
+		}
+		camelProperties = append(camelProperties, config.Properties...)
+	}
+
+	return camelProperties, nil
+}
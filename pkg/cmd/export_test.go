@@ -0,0 +1,152 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/dsl"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+const cmdExport = "export"
+
+func initializeExportCmdOptions(t *testing.T, initObjs ...runtime.Object) (*exportCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	c, err := test.NewFakeClient(initObjs...)
+	assert.Nil(t, err)
+	options._client = c
+	exportCmd, exportOptions := newCmdExport(options)
+	rootCmd.AddCommand(exportCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return exportOptions, rootCmd, *options
+}
+
+func newExportedIntegration(t *testing.T) *v1.Integration {
+	t.Helper()
+
+	flows, err := dsl.FromYamlDSLString("- from:\n    uri: timer:tick\n    steps:\n    - to: log:info\n")
+	assert.Nil(t, err)
+
+	it := v1.NewIntegration("default", "my-integration")
+	it.Spec = v1.IntegrationSpec{
+		Sources: []v1.SourceSpec{
+			v1.NewSourceSpec("Greeter.java", "public class Greeter {}", v1.LanguageJavaSource),
+			v1.NewSourceSpec("routes", `from("timer:tick").to("log:info")`, v1.LanguageGroovy),
+		},
+		Flows:        flows,
+		Dependencies: []string{"camel:kafka", "mvn:org.acme:foo:1.0"},
+		Configuration: []v1.ConfigurationSpec{
+			{Type: "property", Value: "greeting=hello"},
+			{Type: "configmap", Value: "my-configmap"},
+		},
+		Traits: map[string]v1.TraitSpec{
+			"camel": test.TraitSpecFromMap(t, map[string]interface{}{
+				"properties": []string{"name=world"},
+			}),
+			"container": test.TraitSpecFromMap(t, map[string]interface{}{
+				"port": 8081,
+			}),
+		},
+	}
+	return &it
+}
+
+func TestExportNonExistingFlag(t *testing.T) {
+	_, rootCmd, _ := initializeExportCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdExport, "my-integration", "--nonExistingFlag")
+	assert.NotNil(t, err)
+}
+
+func TestExportDirectoryNotEmpty(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, ioutil.WriteFile(path.Join(dir, "routes.yaml"), []byte("[]"), os.ModePerm))
+
+	_, rootCmd, _ := initializeExportCmdOptions(t, newExportedIntegration(t))
+	_, err := test.ExecuteCommand(rootCmd, cmdExport, "my-integration", "--directory", dir)
+	assert.EqualError(t, err, "the directory "+dir+" is not empty")
+}
+
+func TestExport(t *testing.T) {
+	dir := path.Join(t.TempDir(), "project")
+
+	exportCmdOptions, rootCmd, _ := initializeExportCmdOptions(t, newExportedIntegration(t))
+	output, err := test.ExecuteCommand(rootCmd, cmdExport, "my-integration", "--directory", dir, "-n", "default")
+	assert.Nil(t, err)
+	assert.Equal(t, dir, exportCmdOptions.Directory)
+	assert.Contains(t, output, "Integration my-integration exported as a Camel JBang project in "+dir)
+	assert.Contains(t, output, "Warning: the configmap configuration my-configmap is not exported")
+	assert.Contains(t, output, "Warning: the configuration of the traits container is not exported")
+
+	java, err := ioutil.ReadFile(path.Join(dir, "Greeter.java"))
+	assert.Nil(t, err)
+	assert.Equal(t, "public class Greeter {}", string(java))
+	groovy, err := ioutil.ReadFile(path.Join(dir, "routes.groovy"))
+	assert.Nil(t, err)
+	assert.Equal(t, `from("timer:tick").to("log:info")`, string(groovy))
+	flows, err := ioutil.ReadFile(path.Join(dir, "my-integration.yaml"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(flows), "uri: timer:tick")
+
+	props, err := loadPropertyFile(path.Join(dir, "application.properties"))
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", props.GetString("greeting", ""))
+	assert.Equal(t, "world", props.GetString("name", ""))
+	assert.Equal(t, "camel-kafka,org.acme:foo:1.0", props.GetString("camel.jbang.dependencies", ""))
+}
+
+func TestExportAndRunJBangProject(t *testing.T) {
+	c, err := test.NewFakeClient(newExportedIntegration(t))
+	assert.Nil(t, err)
+
+	exported := v1.NewIntegration("default", "my-integration")
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&exported), &exported))
+	files, _, err := exportJBangProject(context.TODO(), c, &exported)
+	assert.Nil(t, err)
+
+	dir := path.Join(t.TempDir(), "my-project")
+	for name, content := range files {
+		assert.Nil(t, util.WriteFileWithContent(dir, name, content))
+	}
+
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	runCmdOptions.Context = context.TODO()
+	runCmdOptions.Namespace = "default"
+	runCmdOptions.UseFlows = true
+	it, err := runCmdOptions.createOrUpdateIntegration(rootCmd, c, []string{dir}, trait.NewCatalog(c))
+	assert.Nil(t, err)
+	assert.Equal(t, "my-project", it.Name)
+	assert.Len(t, it.Spec.Sources, 2)
+	assert.Equal(t, exported.Spec.Flows, it.Spec.Flows)
+	assert.Equal(t, exported.Spec.Dependencies, it.Spec.Dependencies)
+}
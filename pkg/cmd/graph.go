@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/apache/camel-k/pkg/graph"
+)
+
+func newCmdGraph(rootCmdOptions *RootCmdOptions) (*cobra.Command, *graphCmdOptions) {
+	options := graphCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "graph [integration...]",
+		Short: "Print the graph of the resources the integrations depend on",
+		Long: `Print the graph of the resources the integrations of the current namespace depend on, i.e., their kit, build,
+Camel catalog and platform, as well as the Kamelets, ConfigMaps and Secrets they use.
+When --impact is set, only the resources depending on the given one are printed, e.g., to determine
+the integrations that are impacted by an upgrade of a Camel catalog.`,
+		Example: `  kamel graph | dot -Tsvg > graph.svg
+  kamel graph --impact CamelCatalog/camel-catalog-1.9.0-quarkus -o json`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "dot", "Output format. One of: dot|json")
+	cmd.Flags().String("impact", "", "Only print the resources depending on the given one, as <kind>/<name> or <kind>/<namespace>/<name>")
+
+	return &cmd, &options
+}
+
+type graphCmdOptions struct {
+	*RootCmdOptions
+	OutputFormat string `mapstructure:"output"`
+	Impact       string `mapstructure:"impact"`
+}
+
+func (o *graphCmdOptions) validate() error {
+	if o.OutputFormat != "dot" && o.OutputFormat != "json" {
+		return errors.New("unknown output format: " + o.OutputFormat)
+	}
+	return nil
+}
+
+func (o *graphCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	g, err := graph.Build(o.Context, c, o.Namespace, args...)
+	if err != nil {
+		return err
+	}
+	if o.Impact != "" {
+		node, ok := g.Find(o.Impact, o.Namespace)
+		if !ok {
+			return errors.Errorf("resource %s not found in the graph", o.Impact)
+		}
+		g = g.Dependents(node.ID())
+	}
+
+	if o.OutputFormat == "json" {
+		return g.WriteJSON(cmd.OutOrStdout())
+	}
+	return g.WriteDOT(cmd.OutOrStdout())
+}
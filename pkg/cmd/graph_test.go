@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+const cmdGraph = "graph"
+
+func initializeGraphCmdOptions(t *testing.T) (*graphCmdOptions, *cobra.Command, RootCmdOptions) {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	graphCmdOptions := addTestGraphCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return graphCmdOptions, rootCmd, *options
+}
+
+func addTestGraphCmd(options RootCmdOptions, rootCmd *cobra.Command) *graphCmdOptions {
+	//add a testing version of graph Command
+	graphCmd, graphOptions := newCmdGraph(&options)
+	graphCmd.RunE = func(c *cobra.Command, args []string) error {
+		return graphOptions.validate()
+	}
+	graphCmd.Args = test.ArbitraryArgs
+	rootCmd.AddCommand(graphCmd)
+	return graphOptions
+}
+
+func TestGraphNonExistingFlag(t *testing.T) {
+	_, rootCmd, _ := initializeGraphCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdGraph, "--nonExistingFlag")
+	assert.NotNil(t, err)
+}
+
+func TestGraphDefaultFlags(t *testing.T) {
+	graphCmdOptions, rootCmd, _ := initializeGraphCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdGraph)
+	assert.Nil(t, err)
+	assert.Equal(t, "dot", graphCmdOptions.OutputFormat)
+	assert.Equal(t, "", graphCmdOptions.Impact)
+}
+
+func TestGraphFlags(t *testing.T) {
+	graphCmdOptions, rootCmd, _ := initializeGraphCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdGraph, "-o", "json", "--impact", "Kamelet/my-source")
+	assert.Nil(t, err)
+	assert.Equal(t, "json", graphCmdOptions.OutputFormat)
+	assert.Equal(t, "Kamelet/my-source", graphCmdOptions.Impact)
+}
+
+func TestGraphUnknownOutputFormat(t *testing.T) {
+	_, rootCmd, _ := initializeGraphCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdGraph, "-o", "yaml")
+	assert.EqualError(t, err, "unknown output format: yaml")
+}
@@ -0,0 +1,238 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/kamelet/repository"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+func newCmdInspectDependencies(rootCmdOptions *RootCmdOptions) (*cobra.Command, *inspectDependenciesCmdOptions) {
+	options := inspectDependenciesCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "dependencies [files to inspect]",
+		Short: "Compute the dependencies of integration files.",
+		Long: `Output the dependencies of a list of integration files, as they are computed by the operator.
+When --explain is enabled, the reasons why each dependency is added are printed as well.
+When --lock is enabled, the dependencies, including the transitive ones, are resolved by calling Maven,
+and the resulting lock is printed, so that it can be used with the --dependency-lock option of the run command.`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			if !options.Lock {
+				return options.run(cmd, args)
+			}
+			if err := createMavenWorkingDirectory(); err != nil {
+				return err
+			}
+			defer func() {
+				if err := deleteMavenWorkingDirectory(); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+				}
+			}()
+			return options.run(cmd, args)
+		},
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().Bool("explain", false, "Print the reasons why each dependency is added")
+	cmd.Flags().Bool("lock", false, "Resolve the transitive dependencies and print the dependency lock")
+	cmd.Flags().StringArrayP("dependency", "d", nil, additionalDependencyUsageMessage)
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
+	cmd.Flags().StringArray("maven-repository", nil, "Use a maven repository")
+	cmd.Flags().StringArray("kamelet-repository", nil, "Look up the Kamelets in the given repository, e.g., github:apache/camel-kamelets/kamelets")
+
+	return &cmd, &options
+}
+
+type inspectDependenciesCmdOptions struct {
+	*RootCmdOptions
+	Explain                bool     `mapstructure:"explain"`
+	Lock                   bool     `mapstructure:"lock"`
+	OutputFormat           string   `mapstructure:"output"`
+	AdditionalDependencies []string `mapstructure:"dependencies"`
+	MavenRepositories      []string `mapstructure:"maven-repositories"`
+	KameletRepositories    []string `mapstructure:"kamelet-repositories"`
+}
+
+// dependencyExplanation holds the reasons why a dependency is added
+type dependencyExplanation struct {
+	Dependency string   `json:"dependency"`
+	Reasons    []string `json:"reasons"`
+}
+
+func (command *inspectDependenciesCmdOptions) validate(args []string) error {
+	if err := validateIntegrationFiles(args); err != nil {
+		return err
+	}
+	if err := validateAdditionalDependencies(command.AdditionalDependencies); err != nil {
+		return err
+	}
+	if command.Explain && command.Lock {
+		return errors.New("the --explain and --lock options cannot be used together")
+	}
+	if command.OutputFormat != "" && command.OutputFormat != "yaml" && command.OutputFormat != "json" {
+		return errors.New("unknown output format: " + command.OutputFormat)
+	}
+	return nil
+}
+
+func (command *inspectDependenciesCmdOptions) run(cmd *cobra.Command, args []string) error {
+	catalog, err := createCamelCatalog(command.Context)
+	if err != nil {
+		return err
+	}
+
+	reasons, err := command.explainDependencies(cmd, catalog, args)
+	if err != nil {
+		return err
+	}
+
+	dependencies := make([]string, 0, len(reasons))
+	for dependency := range reasons {
+		dependencies = append(dependencies, dependency)
+	}
+	sort.Strings(dependencies)
+
+	switch {
+	case command.Lock:
+		lock, err := getDependencyLock(command.Context, catalog, dependencies, command.MavenRepositories)
+		if err != nil {
+			return err
+		}
+		return printObject(cmd.OutOrStdout(), command.OutputFormat, lock)
+	case command.Explain:
+		explanations := make([]dependencyExplanation, 0, len(dependencies))
+		for _, dependency := range dependencies {
+			explanations = append(explanations, dependencyExplanation{Dependency: dependency, Reasons: reasons[dependency]})
+		}
+		if command.OutputFormat != "" {
+			return printObject(cmd.OutOrStdout(), command.OutputFormat, map[string]interface{}{"dependencies": explanations})
+		}
+		for _, e := range explanations {
+			fmt.Fprintln(cmd.OutOrStdout(), e.Dependency)
+			for _, reason := range e.Reasons {
+				fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", reason)
+			}
+		}
+		return nil
+	default:
+		return outputDependencies(dependencies, command.OutputFormat)
+	}
+}
+
+// explainDependencies computes the dependencies of the given integration files, the same way the
+// dependencies and kamelets traits do, along with the reasons why they are added
+func (command *inspectDependenciesCmdOptions) explainDependencies(cmd *cobra.Command, catalog *camel.RuntimeCatalog, args []string) (map[string][]string, error) {
+	reasons := make(map[string][]string)
+	explain := func(dependency string, reason string) {
+		r := reasons[dependency]
+		util.StringSliceUniqueAdd(&r, reason)
+		reasons[dependency] = r
+	}
+
+	for _, dependency := range command.AdditionalDependencies {
+		explain(dependency, "explicitly required with the --dependency option")
+	}
+
+	for _, d := range catalog.Runtime.Dependencies {
+		explain(d.GetDependencyID(), fmt.Sprintf("required by the %s runtime", catalog.Runtime.Provider))
+	}
+
+	kamelets := make([]string, 0)
+	for _, source := range args {
+		data, _, _, err := loadTextContent(source, false)
+		if err != nil {
+			return nil, err
+		}
+		sourceSpec := v1.SourceSpec{
+			DataSpec: v1.DataSpec{
+				Name:    path.Base(source),
+				Content: data,
+			},
+		}
+
+		for dependency, r := range trait.ExplainSourceDependencies(sourceSpec, catalog) {
+			for _, reason := range r {
+				explain(dependency, reason)
+			}
+		}
+		for _, kamelet := range metadata.Extract(catalog, sourceSpec).Kamelets {
+			util.StringSliceUniqueAdd(&kamelets, kamelet)
+		}
+	}
+
+	if len(kamelets) == 0 {
+		return reasons, nil
+	}
+
+	repo, err := repository.NewStandalone(command.KameletRepositories...)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range kamelets {
+		kamelet, err := repo.Get(command.Context, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot look up kamelet %s", name)
+		}
+		if kamelet == nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Kamelet %s not found, its dependencies are not included (see the --kamelet-repository option)\n", name)
+			continue
+		}
+		for _, dependency := range kamelet.Spec.Dependencies {
+			explain(dependency, fmt.Sprintf("required by kamelet %s", name))
+		}
+	}
+
+	return reasons, nil
+}
+
+// printObject prints the given object in the given format, defaulting to YAML
+func printObject(out io.Writer, format string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if format != "json" {
+		if data, err = util.JSONToYAML(data); err != nil {
+			return err
+		}
+	}
+	fmt.Fprint(out, string(data))
+	return nil
+}
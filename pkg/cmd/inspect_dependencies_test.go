@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func initializeInspectDependenciesCmdOptions(t *testing.T) (*inspectDependenciesCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	inspectCmd := &cobra.Command{Use: "inspect"}
+	dependenciesCmd, dependenciesOptions := newCmdInspectDependencies(options)
+	inspectCmd.AddCommand(dependenciesCmd)
+	rootCmd.AddCommand(inspectCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return dependenciesOptions, rootCmd, *options
+}
+
+func TestInspectDependenciesExplain(t *testing.T) {
+	dir := t.TempDir()
+	source := path.Join(dir, "routes.groovy")
+	assert.Nil(t, ioutil.WriteFile(source, []byte(`from("timer:tick?period=1000").to("log:info")`), os.ModePerm))
+
+	options, rootCmd, _ := initializeInspectDependenciesCmdOptions(t)
+	output, err := test.ExecuteCommand(rootCmd, "inspect", "dependencies", "--explain", "-d", "camel:mail", source)
+	assert.Nil(t, err)
+	assert.True(t, options.Explain)
+	assert.Equal(t, []string{"camel:mail"}, options.AdditionalDependencies)
+
+	assert.Contains(t, output, "camel:mail\n  - explicitly required with the --dependency option\n")
+	assert.Contains(t, output, "camel:timer\n  - component used by endpoint timer:tick in source routes.groovy\n")
+	assert.Contains(t, output, "camel:log\n  - component used by endpoint log:info in source routes.groovy\n")
+	assert.Contains(t, output, "  - loader for the groovy language of source routes.groovy\n")
+	assert.Contains(t, output, "  - required by the quarkus runtime\n")
+}
+
+func TestInspectDependenciesValidation(t *testing.T) {
+	dir := t.TempDir()
+	source := path.Join(dir, "routes.groovy")
+	assert.Nil(t, ioutil.WriteFile(source, []byte(`from("timer:tick").to("log:info")`), os.ModePerm))
+
+	options := inspectDependenciesCmdOptions{Explain: true, Lock: true}
+	assert.EqualError(t, options.validate([]string{source}), "the --explain and --lock options cannot be used together")
+
+	options = inspectDependenciesCmdOptions{OutputFormat: "xml"}
+	assert.EqualError(t, options.validate([]string{source}), "unknown output format: xml")
+
+	options = inspectDependenciesCmdOptions{}
+	assert.EqualError(t, options.validate(nil), "no integration files have been provided")
+}
+
+func TestParseDependencyList(t *testing.T) {
+	list := `
+The following files have been resolved:
+   org.apache.camel:camel-log:jar:3.11.1:compile
+   org.apache.camel:camel-support:jar:3.11.1:compile -- module org.apache.camel.support [auto]
+   io.netty:netty-transport-native-epoll:jar:linux-x86_64:4.1.66.Final:compile
+   org.apache.camel:camel-log:jar:3.11.1:compile
+
+`
+	assert.Equal(t, []string{
+		"mvn:io.netty:netty-transport-native-epoll:jar:linux-x86_64:4.1.66.Final",
+		"mvn:org.apache.camel:camel-log:3.11.1",
+		"mvn:org.apache.camel:camel-support:3.11.1",
+	}, parseDependencyList(list))
+}
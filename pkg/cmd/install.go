@@ -37,6 +37,7 @@ import (
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/install"
+	platformutil "github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/olm"
 	"github.com/apache/camel-k/pkg/util/registry"
@@ -45,6 +46,14 @@ import (
 
 const installCommand = "install"
 
+const (
+	argoCDSyncWaveAnnotation = "argocd.argoproj.io/sync-wave"
+	// The cluster-wide resources and the operator are synced first
+	operatorSyncWave = "-2"
+	// The platform is synced before the integrations, that are in the default wave
+	platformSyncWave = "-1"
+)
+
 func newCmdInstall(rootCmdOptions *RootCmdOptions) (*cobra.Command, *installCmdOptions) {
 	options := installCmdOptions{
 		RootCmdOptions: rootCmdOptions,
@@ -79,21 +88,30 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) (*cobra.Command, *installCmdO
 	cmd.Flags().Bool("global", false, "Configure the operator to watch all namespaces. No integration platform is created. You can run integrations in a namespace by installing an integration platform: 'kamel install --skip-operator-setup -n my-namespace'")
 	cmd.Flags().Bool("force", false, "Force replacement of configuration resources when already present.")
 	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
+	cmd.Flags().Bool("sync-waves", false, "Annotate the output resources with Argo CD sync waves, so that the cluster-wide resources and the operator "+
+		"are synced before the platform, itself synced before the integrations")
 	cmd.Flags().String("organization", "", "A organization on the Docker registry that can be used to publish images")
 	cmd.Flags().String("registry", "", "A Docker registry that can be used to publish images")
 	cmd.Flags().String("registry-secret", "", "A secret used to push/pull images to the Docker registry")
 	cmd.Flags().Bool("registry-insecure", false, "Configure to configure registry access in insecure mode or not")
+	cmd.Flags().String("registry-credentials-provider", "", "The provider of the short-lived registry credentials, that are periodically refreshed into the registry secret, one of ECR, GCR, ACR or IBM")
 	cmd.Flags().String("registry-auth-file", "", "A docker registry configuration file containing authorization tokens for pushing and pulling images")
 	cmd.Flags().String("registry-auth-server", "", "The docker registry authentication server")
 	cmd.Flags().String("registry-auth-username", "", "The docker registry authentication username")
 	cmd.Flags().String("registry-auth-password", "", "The docker registry authentication password")
+	cmd.Flags().Bool("local-registry", false, "Deploy a container image registry in the cluster, to publish images on local development clusters, e.g. kind, Minikube or k3s")
+	cmd.Flags().Bool("local-registry-tls", false, "Serve the local registry over TLS, with a self-signed certificate")
+	cmd.Flags().String("local-registry-proxy", "", "The URL of an upstream registry, e.g. https://registry-1.docker.io, cached by a pull-through proxy deployed along with the local registry")
 	cmd.Flags().StringArrayP("property", "p", nil, "Add a camel property")
 	cmd.Flags().String("runtime-version", "", "Set the camel-k runtime version")
 	cmd.Flags().String("base-image", "", "Set the base Image used to run integrations")
+	cmd.Flags().String("builder-image", "", "Set a custom builder Image, providing the Maven and JDK toolchain, the builds are run in")
 	cmd.Flags().String("operator-image", "", "Set the operator Image used for the operator deployment")
 	cmd.Flags().String("operator-image-pull-policy", "", "Set the operator ImagePullPolicy used for the operator deployment")
 	cmd.Flags().String("build-strategy", "", "Set the build strategy")
 	cmd.Flags().String("build-publish-strategy", "", "Set the build publish strategy")
+	cmd.Flags().String("local-engine", "", "The container engine of the node the images are built with, one of docker, podman or buildkit, when using the Local build publish strategy")
+	cmd.Flags().String("local-engine-address", "", "The address of the container engine daemon, e.g. unix:///var/run/docker.sock, when using the Local build publish strategy")
 	cmd.Flags().String("build-timeout", "", "Set how long the build process can last")
 	cmd.Flags().String("trait-profile", "", "The profile to use for traits")
 	cmd.Flags().Bool("kaniko-build-cache", false, "To enable or disable the Kaniko cache")
@@ -119,6 +137,7 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) (*cobra.Command, *installCmdO
 	cmd.Flags().String("maven-settings", "", "Configure the source of the Maven settings (configmap|secret:name[/key])")
 	cmd.Flags().StringArray("maven-repository", nil, "Add a Maven repository")
 	cmd.Flags().String("maven-ca-secret", "", "Configure the secret key containing the Maven CA certificates (secret/key)")
+	cmd.Flags().String("maven-settings-security", "", "Configure the secret key containing the Maven settings security, to decrypt the encrypted passwords (secret/key)")
 
 	// health
 	cmd.Flags().Int("health-port", 8081, "The port of the health endpoint")
@@ -132,6 +151,10 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) (*cobra.Command, *installCmdO
 	cmd.Flags().StringArray("node-selector", nil, "Add a NodeSelector to the operator Pod")
 	cmd.Flags().StringArray("operator-resources", nil, "Define the resources requests and limits assigned to the operator Pod as <requestType.requestResource=value> (i.e., limits.memory=256Mi)")
 	cmd.Flags().StringArray("operator-env-vars", nil, "Add an environment variable to set in the operator Pod(s), as <name=value>")
+	cmd.Flags().Int32("operator-replicas", 1, "The number of operator replicas, among which a leader is elected, the others being standby replicas")
+	cmd.Flags().Duration("leader-election-lease-duration", 0, "The duration the operator standby replicas wait before acquiring the leadership, when the leader stops renewing it")
+	cmd.Flags().Duration("leader-election-renew-deadline", 0, "The duration the operator leader retries renewing the leadership before giving it up")
+	cmd.Flags().Duration("leader-election-retry-period", 0, "The duration the operator replicas wait between tries of acquiring or renewing the leadership")
 
 	// save
 	cmd.Flags().Bool("save", false, "Save the install parameters into the default kamel configuration file (kamel-config.yaml)")
@@ -163,12 +186,16 @@ type installCmdOptions struct {
 	Olm                     bool     `mapstructure:"olm"`
 	ClusterType             string   `mapstructure:"cluster-type"`
 	OutputFormat            string   `mapstructure:"output"`
+	SyncWaves               bool     `mapstructure:"sync-waves"`
 	RuntimeVersion          string   `mapstructure:"runtime-version"`
 	BaseImage               string   `mapstructure:"base-image"`
+	BuilderImage            string   `mapstructure:"builder-image"`
 	OperatorImage           string   `mapstructure:"operator-image"`
 	OperatorImagePullPolicy string   `mapstructure:"operator-image-pull-policy"`
 	BuildStrategy           string   `mapstructure:"build-strategy"`
 	BuildPublishStrategy    string   `mapstructure:"build-publish-strategy"`
+	LocalEngine             string   `mapstructure:"local-engine"`
+	LocalEngineAddress      string   `mapstructure:"local-engine-address"`
 	BuildTimeout            string   `mapstructure:"build-timeout"`
 	MavenExtensions         []string `mapstructure:"maven-extensions"`
 	MavenLocalRepository    string   `mapstructure:"maven-local-repository"`
@@ -176,6 +203,7 @@ type installCmdOptions struct {
 	MavenRepositories       []string `mapstructure:"maven-repositories"`
 	MavenSettings           string   `mapstructure:"maven-settings"`
 	MavenCASecret           string   `mapstructure:"maven-ca-secret"`
+	MavenSettingsSecurity   string   `mapstructure:"maven-settings-security"`
 	HealthPort              int32    `mapstructure:"health-port"`
 	Monitoring              bool     `mapstructure:"monitoring"`
 	MonitoringPort          int32    `mapstructure:"monitoring-port"`
@@ -185,11 +213,20 @@ type installCmdOptions struct {
 	HTTPProxySecret         string   `mapstructure:"http-proxy-secret"`
 	ResourcesRequirements   []string `mapstructure:"operator-resources"`
 	EnvVars                 []string `mapstructure:"operator-env-vars"`
+	OperatorReplicas        int32    `mapstructure:"operator-replicas"`
+
+	LeaderElectionLeaseDuration time.Duration `mapstructure:"leader-election-lease-duration"`
+	LeaderElectionRenewDeadline time.Duration `mapstructure:"leader-election-renew-deadline"`
+	LeaderElectionRetryPeriod   time.Duration `mapstructure:"leader-election-retry-period"`
 
 	registry         v1.IntegrationPlatformRegistrySpec
 	registryAuth     registry.Auth
 	RegistryAuthFile string `mapstructure:"registry-auth-file"`
 
+	LocalRegistry      bool   `mapstructure:"local-registry"`
+	LocalRegistryTLS   bool   `mapstructure:"local-registry-tls"`
+	LocalRegistryProxy string `mapstructure:"local-registry-proxy"`
+
 	olmOptions olm.Options
 }
 
@@ -282,6 +319,12 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 					Enabled: o.Monitoring,
 					Port:    o.MonitoringPort,
 				},
+				Replicas: o.OperatorReplicas,
+				LeaderElection: install.OperatorLeaderElectionConfiguration{
+					LeaseDuration: o.LeaderElectionLeaseDuration,
+					RenewDeadline: o.LeaderElectionRenewDeadline,
+					RetryPeriod:   o.LeaderElectionRetryPeriod,
+				},
 				Tolerations:           o.Tolerations,
 				NodeSelectors:         o.NodeSelectors,
 				ResourcesRequirements: o.ResourcesRequirements,
@@ -298,6 +341,24 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 		generatedSecretName := ""
 
 		if !o.SkipRegistrySetup {
+			if o.LocalRegistry {
+				spec := install.LocalRegistrySpec{
+					TLS:            o.LocalRegistryTLS,
+					ProxyRemoteURL: o.LocalRegistryProxy,
+				}
+				localRegistry, err := install.LocalRegistryOrError(o.Context, c, namespace, spec, o.Force)
+				if err != nil {
+					return err
+				}
+				o.registry.Address = localRegistry.Address
+				o.registry.Insecure = true
+				o.registry.CA = localRegistry.CA
+				if o.BuildPublishStrategy == "" {
+					o.BuildPublishStrategy = string(v1.IntegrationPlatformBuildPublishStrategySpectrum)
+				}
+				fmt.Fprintf(cobraCmd.OutOrStdout(), "Camel K local registry deployed at %s\n", localRegistry.Address)
+				fmt.Fprint(cobraCmd.OutOrStdout(), install.LocalRegistryNodeHints(localRegistry))
+			}
 			if o.registryAuth.IsSet() {
 				regData := o.registryAuth
 				regData.Registry = o.registry.Address
@@ -315,6 +376,11 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 			fmt.Fprintln(cobraCmd.OutOrStdout(), "Camel K operator registry setup skipped")
 		}
 
+		if o.BuildPublishStrategy == string(v1.IntegrationPlatformBuildPublishStrategyLocal) && o.registry.Address == "" {
+			// The images are built into the node container runtime, so there is no registry to look for
+			o.registry.Address = platformutil.LocalRegistryAddress
+		}
+
 		platform, err := install.PlatformOrCollect(o.Context, c, o.ClusterType, namespace, o.SkipRegistrySetup, o.registry, collection)
 		if err != nil {
 			return err
@@ -362,12 +428,21 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 		if o.BaseImage != "" {
 			platform.Spec.Build.BaseImage = o.BaseImage
 		}
+		if o.BuilderImage != "" {
+			platform.Spec.Build.BuilderImage = o.BuilderImage
+		}
 		if o.BuildStrategy != "" {
 			platform.Spec.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategy(o.BuildStrategy)
 		}
 		if o.BuildPublishStrategy != "" {
 			platform.Spec.Build.PublishStrategy = v1.IntegrationPlatformBuildPublishStrategy(o.BuildPublishStrategy)
 		}
+		if o.LocalEngine != "" || o.LocalEngineAddress != "" {
+			platform.Spec.Build.Local = &v1.IntegrationPlatformLocalSpec{
+				Engine:  v1.IntegrationPlatformLocalEngine(o.LocalEngine),
+				Address: o.LocalEngineAddress,
+			}
+		}
 		if o.BuildTimeout != "" {
 			d, err := time.ParseDuration(o.BuildTimeout)
 			if err != nil {
@@ -404,6 +479,14 @@ func (o *installCmdOptions) install(cobraCmd *cobra.Command, _ []string) error {
 			platform.Spec.Build.Maven.CASecret = secret
 		}
 
+		if o.MavenSettingsSecurity != "" {
+			secret, err := decodeSecretKeySelector(o.MavenSettingsSecurity)
+			if err != nil {
+				return err
+			}
+			platform.Spec.Build.Maven.SettingsSecurity = secret
+		}
+
 		if o.HTTPProxySecret != "" {
 			platform.Spec.Build.HTTPProxySecret = o.HTTPProxySecret
 		}
@@ -477,6 +560,9 @@ func (o *installCmdOptions) postRun(cmd *cobra.Command, _ []string) error {
 }
 
 func (o *installCmdOptions) printOutput(collection *kubernetes.Collection) error {
+	if o.SyncWaves {
+		setSyncWaves(collection)
+	}
 	lst := collection.AsKubernetesList()
 	switch o.OutputFormat {
 	case "yaml":
@@ -497,6 +583,28 @@ func (o *installCmdOptions) printOutput(collection *kubernetes.Collection) error
 	return nil
 }
 
+// setSyncWaves annotates the resources with the Argo CD sync wave they are synced in
+func setSyncWaves(collection *kubernetes.Collection) {
+	for _, res := range collection.Items() {
+		var wave string
+		switch res.GetObjectKind().GroupVersionKind().Kind {
+		case v1.IntegrationKind:
+			// The example integration is synced in the default wave
+			continue
+		case v1.IntegrationPlatformKind:
+			wave = platformSyncWave
+		default:
+			wave = operatorSyncWave
+		}
+		annotations := res.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[argoCDSyncWaveAnnotation] = wave
+		res.SetAnnotations(annotations)
+	}
+}
+
 // nolint:errcheck
 func (o *installCmdOptions) waitForPlatformReady(cmd *cobra.Command, platform *v1.IntegrationPlatform) error {
 	handler := func(i *v1.IntegrationPlatform) bool {
@@ -525,6 +633,7 @@ func (o *installCmdOptions) decode(cmd *cobra.Command, _ []string) error {
 	o.registry.Organization = viper.GetString(path + ".organization")
 	o.registry.Secret = viper.GetString(path + ".registry-secret")
 	o.registry.Insecure = viper.GetBool(path + ".registry-insecure")
+	o.registry.CredentialsProvider = v1.RegistryCredentialsProvider(viper.GetString(path + ".registry-credentials-provider"))
 	o.registryAuth.Username = viper.GetString(path + ".registry-auth-username")
 	o.registryAuth.Password = viper.GetString(path + ".registry-auth-password")
 	o.registryAuth.Server = viper.GetString(path + ".registry-auth-server")
@@ -561,6 +670,51 @@ func (o *installCmdOptions) validate(_ *cobra.Command, _ []string) error {
 		result = multierr.Append(result, err)
 	}
 
+	if o.registry.CredentialsProvider != "" {
+		if o.registryAuth.IsSet() || o.RegistryAuthFile != "" {
+			err := fmt.Errorf("incompatible options combinations: you cannot set both registry-credentials-provider and registry-auth-[*] settings")
+			result = multierr.Append(result, err)
+		}
+		found := false
+		for _, p := range v1.RegistryCredentialsProviders {
+			if p == o.registry.CredentialsProvider {
+				found = true
+				break
+			}
+		}
+		if !found {
+			err := fmt.Errorf("unknown registry credentials provider: %s", o.registry.CredentialsProvider)
+			result = multierr.Append(result, err)
+		}
+	}
+
+	if o.LocalRegistry {
+		if o.registry.Address != "" {
+			err := fmt.Errorf("incompatible options combinations: you cannot set both local-registry and registry")
+			result = multierr.Append(result, err)
+		}
+		if o.SkipRegistrySetup {
+			err := fmt.Errorf("incompatible options combinations: you cannot set both local-registry and skip-registry-setup")
+			result = multierr.Append(result, err)
+		}
+		if o.OutputFormat != "" {
+			err := fmt.Errorf("incompatible options combinations: you cannot set both local-registry and output")
+			result = multierr.Append(result, err)
+		}
+		if strings.EqualFold(o.ClusterType, string(v1.IntegrationPlatformClusterOpenShift)) {
+			err := fmt.Errorf("the local registry is not supported on OpenShift, that provides its own registry")
+			result = multierr.Append(result, err)
+		}
+		if o.BuildPublishStrategy == string(v1.IntegrationPlatformBuildPublishStrategyLocal) {
+			err := fmt.Errorf("incompatible options combinations: the %s build publish strategy does not push images to the local registry",
+				v1.IntegrationPlatformBuildPublishStrategyLocal)
+			result = multierr.Append(result, err)
+		}
+	} else if o.LocalRegistryTLS || o.LocalRegistryProxy != "" {
+		err := fmt.Errorf("the local-registry-tls and local-registry-proxy options require the local-registry option")
+		result = multierr.Append(result, err)
+	}
+
 	if o.registryAuth.IsSet() && o.RegistryAuthFile != "" {
 		err := fmt.Errorf("incompatible options combinations: you cannot set registry-auth-file with other registry-auth-[*] settings")
 		result = multierr.Append(result, err)
@@ -575,6 +729,16 @@ func (o *installCmdOptions) validate(_ *cobra.Command, _ []string) error {
 		}
 	}
 
+	if o.SyncWaves && o.OutputFormat == "" {
+		err := fmt.Errorf("the sync-waves option requires the output option")
+		result = multierr.Append(result, err)
+	}
+
+	if o.OperatorReplicas < 1 {
+		err := fmt.Errorf("the number of operator replicas must be at least 1: %d", o.OperatorReplicas)
+		result = multierr.Append(result, err)
+	}
+
 	if o.BuildStrategy != "" {
 		found := false
 		for _, s := range v1.IntegrationPlatformBuildStrategies {
@@ -609,6 +773,20 @@ func (o *installCmdOptions) validate(_ *cobra.Command, _ []string) error {
 		}
 	}
 
+	if o.LocalEngine != "" || o.LocalEngineAddress != "" {
+		if o.BuildPublishStrategy != string(v1.IntegrationPlatformBuildPublishStrategyLocal) {
+			return fmt.Errorf("the local-engine and local-engine-address options require the %s build publish strategy",
+				v1.IntegrationPlatformBuildPublishStrategyLocal)
+		}
+		if o.LocalEngine != "" && !v1.IntegrationPlatformLocalEngineSupported(v1.IntegrationPlatformLocalEngine(o.LocalEngine)) {
+			var engines []string
+			for _, e := range v1.IntegrationPlatformLocalEngines {
+				engines = append(engines, string(e))
+			}
+			return fmt.Errorf("unknown local engine: %s. One of [%s] is expected", o.LocalEngine, strings.Join(engines, ", "))
+		}
+	}
+
 	return result
 }
 
@@ -19,11 +19,17 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 
+	appsv1 "k8s.io/api/apps/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/olm"
 	"github.com/apache/camel-k/pkg/util/test"
 )
@@ -76,6 +82,7 @@ func TestInstallNoFlag(t *testing.T) {
 	assert.Equal(t, int32(8081), installCmdOptions.HealthPort)
 	assert.Equal(t, false, installCmdOptions.Monitoring)
 	assert.Equal(t, int32(8080), installCmdOptions.MonitoringPort)
+	assert.Equal(t, int32(1), installCmdOptions.OperatorReplicas)
 }
 
 func TestInstallNonExistingFlag(t *testing.T) {
@@ -91,6 +98,13 @@ func TestInstallBaseImageFlag(t *testing.T) {
 	assert.Equal(t, "someString", installCmdOptions.BaseImage)
 }
 
+func TestInstallBuilderImageFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--builder-image", "my-registry/my-builder:1.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "my-registry/my-builder:1.0", installCmdOptions.BuilderImage)
+}
+
 func TestInstallBuildPublishStrategyFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--build-publish-strategy", "someString")
@@ -98,6 +112,37 @@ func TestInstallBuildPublishStrategyFlag(t *testing.T) {
 	assert.Equal(t, "someString", installCmdOptions.BuildPublishStrategy)
 }
 
+func TestInstallLocalEngineFlags(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--build-publish-strategy", "Local",
+		"--local-engine", "podman",
+		"--local-engine-address", "unix:///run/podman/podman.sock")
+	assert.Nil(t, err)
+	assert.Equal(t, "podman", installCmdOptions.LocalEngine)
+	assert.Equal(t, "unix:///run/podman/podman.sock", installCmdOptions.LocalEngineAddress)
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+}
+
+func TestInstallLocalEngineFlagInvalid(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--build-publish-strategy", "Local",
+		"--local-engine", "containerd")
+	assert.Nil(t, err)
+	err = installCmdOptions.validate(nil, nil)
+	assert.EqualError(t, err, "unknown local engine: containerd. One of [docker, podman, buildkit] is expected")
+}
+
+func TestInstallLocalEngineFlagWithoutLocalStrategy(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--local-engine", "docker")
+	assert.Nil(t, err)
+	err = installCmdOptions.validate(nil, nil)
+	assert.EqualError(t, err, "the local-engine and local-engine-address options require the Local build publish strategy")
+}
+
 func TestInstallBuildStrategyFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--build-strategy", "someString")
@@ -153,6 +198,26 @@ func TestInstallHealthFlag(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, int32(7777), installCmdOptions.HealthPort)
 }
+
+func TestInstallOperatorReplicasFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--operator-replicas", "2")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), installCmdOptions.OperatorReplicas)
+}
+
+func TestInstallLeaderElectionFlags(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--leader-election-lease-duration", "8s",
+		"--leader-election-renew-deadline", "6s",
+		"--leader-election-retry-period", "1s")
+	assert.Nil(t, err)
+	assert.Equal(t, 8*time.Second, installCmdOptions.LeaderElectionLeaseDuration)
+	assert.Equal(t, 6*time.Second, installCmdOptions.LeaderElectionRenewDeadline)
+	assert.Equal(t, 1*time.Second, installCmdOptions.LeaderElectionRetryPeriod)
+}
+
 func TestInstallHttpProxySecretFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--http-proxy-secret", "someString")
@@ -191,6 +256,13 @@ func TestInstallMavenSettingsFlag(t *testing.T) {
 	assert.Equal(t, "someString", installCmdOptions.MavenSettings)
 }
 
+func TestInstallMavenSettingsSecurityFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--maven-settings-security", "maven-settings-security/settings-security.xml")
+	assert.Nil(t, err)
+	assert.Equal(t, "maven-settings-security/settings-security.xml", installCmdOptions.MavenSettingsSecurity)
+}
+
 func TestInstallMonitoringFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
@@ -250,6 +322,41 @@ func TestInstallOutputFlag(t *testing.T) {
 	assert.Equal(t, "yaml", installCmdOptions.OutputFormat)
 }
 
+func TestInstallSyncWavesFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--sync-waves")
+	assert.Nil(t, err)
+	assert.True(t, installCmdOptions.SyncWaves)
+	assert.EqualError(t, installCmdOptions.validate(nil, nil), "the sync-waves option requires the output option")
+
+	installCmdOptions.OutputFormat = "yaml"
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+}
+
+func TestInstallSetSyncWaves(t *testing.T) {
+	crd := apiextensionsv1.CustomResourceDefinition{
+		TypeMeta:   metav1.TypeMeta{Kind: "CustomResourceDefinition"},
+		ObjectMeta: metav1.ObjectMeta{Name: "integrations.camel.apache.org"},
+	}
+	operator := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "camel-k-operator",
+			Annotations: map[string]string{"app": "camel-k"},
+		},
+	}
+	platform := v1.NewIntegrationPlatform("ns", "camel-k")
+	integration := v1.NewIntegration("ns", "example")
+
+	collection := kubernetes.NewCollection(&crd, &operator, &platform, &integration)
+	setSyncWaves(collection)
+
+	assert.Equal(t, "-2", crd.Annotations["argocd.argoproj.io/sync-wave"])
+	assert.Equal(t, map[string]string{"app": "camel-k", "argocd.argoproj.io/sync-wave": "-2"}, operator.Annotations)
+	assert.Equal(t, "-1", platform.Annotations["argocd.argoproj.io/sync-wave"])
+	assert.NotContains(t, integration.Annotations, "argocd.argoproj.io/sync-wave")
+}
+
 func TestInstallPropertyFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
@@ -274,6 +381,24 @@ func TestInstallRegistryFlag(t *testing.T) {
 	assert.Equal(t, "secret", installCmdOptions.registry.Secret)
 }
 
+func TestInstallRegistryCredentialsProviderFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--registry", "123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+		"--registry-credentials-provider", "ECR")
+	assert.Nil(t, err)
+	assert.Equal(t, v1.RegistryCredentialsProviderECR, installCmdOptions.registry.CredentialsProvider)
+}
+
+func TestInstallRegistryCredentialsProviderFlagInvalid(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--registry-credentials-provider", "Quay")
+	assert.Nil(t, err)
+	err = installCmdOptions.validate(nil, nil)
+	assert.EqualError(t, err, "unknown registry credentials provider: Quay")
+}
+
 func TestInstallRegistryWithAuthFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
@@ -286,6 +411,38 @@ func TestInstallRegistryWithAuthFlag(t *testing.T) {
 	assert.Equal(t, "authUsername", installCmdOptions.registryAuth.Username)
 }
 
+func TestInstallLocalRegistryFlag(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--local-registry",
+		"--local-registry-tls",
+		"--local-registry-proxy", "https://registry-1.docker.io")
+	assert.Nil(t, err)
+	assert.True(t, installCmdOptions.LocalRegistry)
+	assert.True(t, installCmdOptions.LocalRegistryTLS)
+	assert.Equal(t, "https://registry-1.docker.io", installCmdOptions.LocalRegistryProxy)
+	assert.Nil(t, installCmdOptions.validate(nil, nil))
+}
+
+func TestInstallLocalRegistryFlagIncompatible(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--local-registry",
+		"--registry", "registry")
+	assert.Nil(t, err)
+	err = installCmdOptions.validate(nil, nil)
+	assert.EqualError(t, err, "incompatible options combinations: you cannot set both local-registry and registry")
+}
+
+func TestInstallLocalRegistryTLSFlagWithoutLocalRegistry(t *testing.T) {
+	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdInstall,
+		"--local-registry-tls")
+	assert.Nil(t, err)
+	err = installCmdOptions.validate(nil, nil)
+	assert.EqualError(t, err, "the local-registry-tls and local-registry-proxy options require the local-registry option")
+}
+
 func TestInstallRuntimeVersionFlag(t *testing.T) {
 	installCmdOptions, rootCmd, _ := initializeInstallCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdInstall, "--runtime-version", "1.3.0")
@@ -30,6 +30,8 @@ func newCmdKamelet(rootCmdOptions *RootCmdOptions) *cobra.Command {
 
 	cmd.AddCommand(cmdOnly(newKameletGetCmd(rootCmdOptions)))
 	cmd.AddCommand(cmdOnly(newKameletDeleteCmd(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newKameletPushCmd(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newKameletPullCmd(rootCmdOptions)))
 
 	return &cmd
 }
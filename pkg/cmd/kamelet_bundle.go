@@ -0,0 +1,277 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/kamelet/bundle"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func newKameletPushCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *kameletPushCommandOptions) {
+	options := kameletPushCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "push <image> <kamelet>...",
+		Short: "Package Kamelets into a bundle, and push it to a registry",
+		Long: `Package Kamelets, along with their JSON schemas and icons, into a bundle, and push it as an OCI artifact to a registry.
+The Kamelets are either local files, directories containing *.kamelet.yaml files, or Kamelets of the current namespace.
+The registry credentials are read from the Docker configuration.`,
+		Example: `  kamel kamelet push quay.io/acme/kamelets:1.0.0 ./kamelets
+  kamel kamelet push quay.io/acme/kamelets:1.0.0 acme-source acme-sink`,
+		Args:              cobra.MinimumNArgs(2),
+		PersistentPreRunE: decode(&options),
+		PreRunE:           options.preRunE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return options.run(cmd, args)
+		},
+		Annotations: make(map[string]string),
+	}
+
+	cmd.Flags().Bool("insecure", false, "Allow registries served over plain HTTP, or with a self-signed certificate")
+
+	return &cmd, &options
+}
+
+type kameletPushCommandOptions struct {
+	*RootCmdOptions
+	Insecure bool `mapstructure:"insecure"`
+}
+
+func (command *kameletPushCommandOptions) preRunE(cmd *cobra.Command, args []string) error {
+	offline := true
+	for _, source := range args[1:] {
+		if _, err := os.Stat(source); err != nil {
+			offline = false
+		}
+	}
+	if offline {
+		cmd.Annotations[offlineCommandLabel] = "true"
+	}
+	return command.RootCmdOptions.preRun(cmd, args)
+}
+
+func (command *kameletPushCommandOptions) run(cmd *cobra.Command, args []string) error {
+	kamelets, err := command.loadKamelets(args[1:])
+	if err != nil {
+		return err
+	}
+
+	img, err := bundle.Package(kamelets)
+	if err != nil {
+		return err
+	}
+	digest, err := bundle.Push(args[0], img, bundle.Options{Insecure: command.Insecure})
+	if err != nil {
+		return errors.Wrapf(err, "cannot push Kamelet bundle %s", args[0])
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Kamelet bundle %s@%s pushed with %d Kamelets\n", args[0], digest, len(kamelets))
+	return nil
+}
+
+// loadKamelets loads the Kamelets from the given local files and directories, or from the current namespace
+func (command *kameletPushCommandOptions) loadKamelets(sources []string) ([]v1alpha1.Kamelet, error) {
+	kamelets := make([]v1alpha1.Kamelet, 0, len(sources))
+	for _, source := range sources {
+		info, err := os.Stat(source)
+		switch {
+		case err == nil && info.IsDir():
+			files, err := filepath.Glob(filepath.Join(source, "*.kamelet.yaml"))
+			if err != nil {
+				return nil, err
+			}
+			if len(files) == 0 {
+				return nil, fmt.Errorf("no *.kamelet.yaml file in directory %s", source)
+			}
+			for _, file := range files {
+				kamelet, err := loadKameletFile(file)
+				if err != nil {
+					return nil, err
+				}
+				kamelets = append(kamelets, *kamelet)
+			}
+		case err == nil:
+			kamelet, err := loadKameletFile(source)
+			if err != nil {
+				return nil, err
+			}
+			kamelets = append(kamelets, *kamelet)
+		case os.IsNotExist(err):
+			c, err := command.GetCmdClient()
+			if err != nil {
+				return nil, err
+			}
+			kamelet := v1alpha1.NewKamelet(command.Namespace, source)
+			if err := c.Get(command.Context, k8sclient.ObjectKeyFromObject(&kamelet), &kamelet); err != nil {
+				return nil, errors.Wrapf(err, "cannot find Kamelet %s", source)
+			}
+			kamelets = append(kamelets, kamelet)
+		default:
+			return nil, err
+		}
+	}
+	return kamelets, nil
+}
+
+// loadKameletFile loads a Kamelet from a local file. The JSON schema and the icon of the Kamelet can be
+// provided by the <name>.schema.json and <name>.svg files of the same directory, when it does not define them.
+func loadKameletFile(file string) (*v1alpha1.Kamelet, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	kamelet := v1alpha1.Kamelet{}
+	if err := json.Unmarshal(jsonData, &kamelet); err != nil || kamelet.Kind != v1alpha1.KameletKind {
+		return nil, fmt.Errorf("file %s is not a valid Kamelet", file)
+	}
+
+	base := filepath.Join(filepath.Dir(file), kamelet.Name)
+	if kamelet.Spec.Definition == nil {
+		if schema, err := ioutil.ReadFile(base + ".schema.json"); err == nil {
+			definition := v1alpha1.JSONSchemaProps{}
+			if err := json.Unmarshal(schema, &definition); err != nil {
+				return nil, errors.Wrapf(err, "invalid JSON schema of Kamelet %s", kamelet.Name)
+			}
+			kamelet.Spec.Definition = &definition
+		}
+	}
+	if kamelet.Annotations[v1alpha1.AnnotationIcon] == "" {
+		if icon, err := ioutil.ReadFile(base + ".svg"); err == nil {
+			if kamelet.Annotations == nil {
+				kamelet.Annotations = make(map[string]string)
+			}
+			kamelet.Annotations[v1alpha1.AnnotationIcon] = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(icon)
+		}
+	}
+	return &kamelet, nil
+}
+
+func newKameletPullCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *kameletPullCommandOptions) {
+	options := kameletPullCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "pull <image>",
+		Short: "Pull a Kamelet bundle from a registry, and install its Kamelets",
+		Long: `Pull a Kamelet bundle, packaged as an OCI artifact, from a registry, and install its Kamelets into the current namespace,
+or write them into a local directory.
+The existing Kamelets, that are not installed from a bundle, are only replaced with the --force flag.
+The registry credentials are read from the Docker configuration.`,
+		Example: `  kamel kamelet pull quay.io/acme/kamelets:1.0.0
+  kamel kamelet pull quay.io/acme/kamelets:1.0.0 --output-dir ./kamelets`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: decode(&options),
+		PreRunE:           options.preRunE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return options.run(cmd, args)
+		},
+		Annotations: make(map[string]string),
+	}
+
+	cmd.Flags().Bool("insecure", false, "Allow registries served over plain HTTP, or with a self-signed certificate")
+	cmd.Flags().Bool("force", false, "Replace the existing Kamelets that are not installed from a bundle")
+	cmd.Flags().StringP("output-dir", "d", "", "Write the Kamelets into the given directory, instead of installing them")
+
+	return &cmd, &options
+}
+
+type kameletPullCommandOptions struct {
+	*RootCmdOptions
+	Insecure  bool   `mapstructure:"insecure"`
+	Force     bool   `mapstructure:"force"`
+	OutputDir string `mapstructure:"output-dir"`
+}
+
+func (command *kameletPullCommandOptions) preRunE(cmd *cobra.Command, args []string) error {
+	if command.OutputDir != "" {
+		cmd.Annotations[offlineCommandLabel] = "true"
+	}
+	return command.RootCmdOptions.preRun(cmd, args)
+}
+
+func (command *kameletPullCommandOptions) run(cmd *cobra.Command, args []string) error {
+	img, err := bundle.Pull(args[0], bundle.Options{Insecure: command.Insecure})
+	if err != nil {
+		return errors.Wrapf(err, "cannot pull Kamelet bundle %s", args[0])
+	}
+	kamelets, err := bundle.Unpack(img)
+	if err != nil {
+		return errors.Wrapf(err, "invalid Kamelet bundle %s", args[0])
+	}
+
+	if command.OutputDir != "" {
+		if err := os.MkdirAll(command.OutputDir, 0755); err != nil {
+			return err
+		}
+		for i := range kamelets {
+			data, err := kubernetes.ToYAML(&kamelets[i])
+			if err != nil {
+				return err
+			}
+			file := filepath.Join(command.OutputDir, kamelets[i].Name+".kamelet.yaml")
+			if err := ioutil.WriteFile(file, data, 0644); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%d Kamelets written into %s\n", len(kamelets), command.OutputDir)
+		return nil
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return err
+	}
+	c, err := command.GetCmdClient()
+	if err != nil {
+		return err
+	}
+	result, err := bundle.Install(command.Context, c, command.Namespace, args[0], digest.String(), kamelets, command.Force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Kamelets installed: %s\n", strings.Join(result.Installed, ", "))
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "Kamelets skipped, as they are not installed from a bundle (use --force to replace them): %s\n",
+			strings.Join(result.Skipped, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/kamelet/bundle"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+const cmdKamelet = "kamelet"
+
+const timerSourceKamelet = `apiVersion: camel.apache.org/v1alpha1
+kind: Kamelet
+metadata:
+  name: timer-source
+  labels:
+    camel.apache.org/kamelet.type: source
+spec:
+  template:
+    from:
+      uri: timer:tick
+      steps:
+      - to: kamelet:sink
+`
+
+const timerSourceSchema = `{"title":"Timer Source","properties":{"message":{"type":"string"}}}`
+
+func initializeKameletBundleCmdOptions(t *testing.T) (*kameletPushCommandOptions, *kameletPullCommandOptions, *cobra.Command) {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	kameletCmd := cobra.Command{Use: cmdKamelet}
+	pushCmd, pushOptions := newKameletPushCmd(options)
+	pullCmd, pullOptions := newKameletPullCmd(options)
+	kameletCmd.AddCommand(pushCmd, pullCmd)
+	rootCmd.AddCommand(&kameletCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return pushOptions, pullOptions, rootCmd
+}
+
+func TestKameletPushAndPull(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(ioutil.Discard, "", 0))))
+	defer server.Close()
+	reference := strings.TrimPrefix(server.URL, "http://") + "/acme/kamelets:1.0.0"
+
+	dir, err := ioutil.TempDir("", "camel-k-kamelets-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "timer-source.kamelet.yaml"), []byte(timerSourceKamelet), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "timer-source.schema.json"), []byte(timerSourceSchema), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "timer-source.svg"), []byte("<svg/>"), 0644))
+
+	_, pullOptions, rootCmd := initializeKameletBundleCmdOptions(t)
+	output, err := test.ExecuteCommand(rootCmd, cmdKamelet, "push", reference, dir, "--insecure")
+	require.NoError(t, err)
+	assert.Contains(t, output, "pushed with 1 Kamelets")
+
+	outputDir := filepath.Join(dir, "pulled")
+	_, pullOptions, rootCmd = initializeKameletBundleCmdOptions(t)
+	output, err = test.ExecuteCommand(rootCmd, cmdKamelet, "pull", reference, "--insecure", "-d", outputDir)
+	require.NoError(t, err)
+	assert.Contains(t, output, "1 Kamelets written into "+outputDir)
+	kamelet, err := loadKameletFile(filepath.Join(outputDir, "timer-source.kamelet.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "Timer Source", kamelet.Spec.Definition.Title)
+	assert.Equal(t, "data:image/svg+xml;base64,PHN2Zy8+", kamelet.Annotations[v1alpha1.AnnotationIcon])
+
+	custom := v1alpha1.NewKamelet("default", "timer-source")
+	c, err := test.NewFakeClient(&custom)
+	require.NoError(t, err)
+	_, pullOptions, rootCmd = initializeKameletBundleCmdOptions(t)
+	pullOptions._client = c
+	output, err = test.ExecuteCommand(rootCmd, cmdKamelet, "pull", reference, "--insecure", "-n", "default")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Kamelets skipped, as they are not installed from a bundle (use --force to replace them): timer-source")
+
+	_, pullOptions, rootCmd = initializeKameletBundleCmdOptions(t)
+	pullOptions._client = c
+	output, err = test.ExecuteCommand(rootCmd, cmdKamelet, "pull", reference, "--insecure", "-n", "default", "--force")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Kamelets installed: timer-source")
+	require.NoError(t, c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(&custom), &custom))
+	assert.Equal(t, reference, custom.Annotations[bundle.BundleAnnotation])
+}
+
+func TestKameletPushMissingKamelets(t *testing.T) {
+	_, _, rootCmd := initializeKameletBundleCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdKamelet, "push", "quay.io/acme/kamelets:1.0.0")
+	assert.EqualError(t, err, "requires at least 2 arg(s), only received 1")
+}
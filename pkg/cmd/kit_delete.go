@@ -124,10 +124,10 @@ func (command *kitDeleteCommandOptions) delete(name string) error {
 		return err
 	}
 
-	// check that it is not a platform one which is supposed to be "read only"
+	// check that it is not a platform or base one which is supposed to be "read only"
 	// thus not managed by the end user
-	if kit.Labels[v1.IntegrationKitTypeLabel] == v1.IntegrationKitTypePlatform {
-		// skip platform Kits while deleting all Kits
+	if t := kit.Labels[v1.IntegrationKitTypeLabel]; t == v1.IntegrationKitTypePlatform || t == v1.IntegrationKitTypeBase {
+		// skip platform and base Kits while deleting all Kits
 		if command.All {
 			return nil
 		}
@@ -53,6 +53,7 @@ func newKitGetCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *kitGetComman
 	cmd.Flags().Bool(v1.IntegrationKitTypeUser, true, "Includes user Kits")
 	cmd.Flags().Bool(v1.IntegrationKitTypeExternal, true, "Includes external Kits")
 	cmd.Flags().Bool(v1.IntegrationKitTypePlatform, true, "Includes platform Kits")
+	cmd.Flags().Bool(v1.IntegrationKitTypeBase, true, "Includes base Kits")
 
 	return &cmd, &options
 }
@@ -62,6 +63,7 @@ type kitGetCommandOptions struct {
 	User     bool `mapstructure:"user"`
 	External bool `mapstructure:"external"`
 	Platform bool `mapstructure:"platform"`
+	Base     bool `mapstructure:"base"`
 }
 
 func (command *kitGetCommandOptions) validate(cmd *cobra.Command, args []string) error {
@@ -85,8 +87,9 @@ func (command *kitGetCommandOptions) run(cmd *cobra.Command) error {
 		u := command.User && t == v1.IntegrationKitTypeUser
 		e := command.External && t == v1.IntegrationKitTypeExternal
 		p := command.Platform && t == v1.IntegrationKitTypePlatform
+		b := command.Base && t == v1.IntegrationKitTypeBase
 
-		if u || e || p {
+		if u || e || p || b {
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ctx.Name, string(ctx.Status.Phase), t, ctx.Status.Image)
 		}
 	}
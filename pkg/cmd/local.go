@@ -50,6 +50,7 @@ func addLocalSubCommands(cmd *cobra.Command, options *RootCmdOptions) error {
 	}
 
 	localCmd.AddCommand(cmdOnly(newCmdLocalBuild(options)))
+	localCmd.AddCommand(cmdOnly(newCmdLocalExport(options)))
 	localCmd.AddCommand(cmdOnly(newCmdLocalInspect(options)))
 	localCmd.AddCommand(cmdOnly(newCmdLocalRun(options)))
 
@@ -0,0 +1,207 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+func newCmdLocalExport(rootCmdOptions *RootCmdOptions) (*cobra.Command, *localExportCmdOptions) {
+	options := localExportCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "export [integration files]",
+		Short: "Export integration files as a Maven, Gradle or JBang project.",
+		Long: `Export integration files as a project, that runs the integration with the same dependencies as the ones
+resolved by the operator, so that it can be iterated on locally with the selected build tool.`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().String("format", string(builder.ExportFormatMaven), fmt.Sprintf("Format of the exported project. One of: %s", exportFormatsUsage()))
+	cmd.Flags().String("directory", "", "Directory where the project is exported. It must not exist or be empty.")
+	cmd.Flags().StringArrayP("dependency", "d", nil, additionalDependencyUsageMessage)
+	cmd.Flags().StringArray("maven-repository", nil, "Add a maven repository to the exported project")
+	cmd.Flags().String("dependency-lock", "", "The path of the YAML file containing the dependency lock, used to pin the versions of the dependencies")
+
+	return &cmd, &options
+}
+
+type localExportCmdOptions struct {
+	*RootCmdOptions
+	Format                 string   `mapstructure:"format"`
+	Directory              string   `mapstructure:"directory"`
+	AdditionalDependencies []string `mapstructure:"dependencies"`
+	MavenRepositories      []string `mapstructure:"maven-repositories"`
+	DependencyLock         string   `mapstructure:"dependency-lock"`
+}
+
+func (command *localExportCmdOptions) validate(args []string) error {
+	if err := validateIntegrationFiles(args); err != nil {
+		return err
+	}
+	if err := validateAdditionalDependencies(command.AdditionalDependencies); err != nil {
+		return err
+	}
+
+	valid := false
+	for _, f := range builder.ExportFormats {
+		if string(f) == command.Format {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unsupported export format: %s, must be one of %s", command.Format, exportFormatsUsage())
+	}
+
+	if command.Directory == "" {
+		return errors.New("the directory where the project is exported must be set with the --directory option")
+	}
+	files, err := ioutil.ReadDir(command.Directory)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(files) > 0 {
+		return fmt.Errorf("the directory %s is not empty", command.Directory)
+	}
+
+	return nil
+}
+
+func (command *localExportCmdOptions) run(cmd *cobra.Command, args []string) error {
+	catalog, err := createCamelCatalog(command.Context)
+	if err != nil {
+		return err
+	}
+
+	project, err := command.generateProject(catalog, args)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]v1.SourceSpec, 0, len(args))
+	for _, source := range args {
+		data, _, _, err := loadTextContent(source, false)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, v1.SourceSpec{
+			DataSpec: v1.DataSpec{
+				Name:    path.Base(source),
+				Content: data,
+			},
+		})
+	}
+
+	files, err := builder.ExportProject(builder.ExportFormat(command.Format), project, sources)
+	if err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := util.WriteFileWithContent(command.Directory, name, content); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Integration exported as a %s project in %s\n", command.Format, command.Directory)
+	return nil
+}
+
+// generateProject generates the Maven project of the integration, with the same dependencies as the ones
+// the operator adds to the integration build
+func (command *localExportCmdOptions) generateProject(catalog *camel.RuntimeCatalog, args []string) (maven.Project, error) {
+	dependencies, err := getTopLevelDependencies(catalog, args)
+	if err != nil {
+		return maven.Project{}, err
+	}
+	dependencies = append(dependencies, command.AdditionalDependencies...)
+	for _, d := range catalog.Runtime.Dependencies {
+		util.StringSliceUniqueAdd(&dependencies, d.GetDependencyID())
+	}
+	sort.Strings(dependencies)
+
+	spec := v1.IntegrationSpec{}
+	if err := resolveDependencyLock(command.DependencyLock, &spec); err != nil {
+		return maven.Project{}, err
+	}
+	runtimeVersion := catalog.Runtime.Version
+	if spec.DependencyLock != nil && spec.DependencyLock.RuntimeVersion != "" {
+		runtimeVersion = spec.DependencyLock.RuntimeVersion
+	}
+
+	project := builder.GenerateQuarkusProjectCommon(
+		catalog.CamelCatalogSpec.Runtime.Metadata["camel-quarkus.version"],
+		runtimeVersion,
+		catalog.CamelCatalogSpec.Runtime.Metadata["quarkus.version"],
+	)
+	if err := camel.ManageIntegrationDependencies(&project, dependencies, catalog); err != nil {
+		return maven.Project{}, err
+	}
+
+	if spec.DependencyLock != nil {
+		for _, dependency := range spec.DependencyLock.Dependencies {
+			d, err := maven.ParseGAV(strings.TrimPrefix(dependency, "mvn:"))
+			if err != nil {
+				return maven.Project{}, errors.Wrapf(err, "invalid locked dependency %s", dependency)
+			}
+			project.AddManagedDependency(d)
+		}
+	}
+
+	for i, repo := range command.MavenRepositories {
+		repository := maven.NewRepository(repo)
+		if repository.ID == "" {
+			repository.ID = fmt.Sprintf("repository-%03d", i)
+		}
+		project.Repositories = append(project.Repositories, repository)
+	}
+
+	return project, nil
+}
+
+func exportFormatsUsage() string {
+	formats := make([]string, 0, len(builder.ExportFormats))
+	for _, f := range builder.ExportFormats {
+		formats = append(formats, string(f))
+	}
+	return strings.Join(formats, ", ")
+}
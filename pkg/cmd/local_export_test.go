@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func initializeLocalExportCmdOptions(t *testing.T) (*localExportCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	localCmd := &cobra.Command{Use: "local"}
+	exportCmd, exportOptions := newCmdLocalExport(options)
+	localCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(localCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return exportOptions, rootCmd, *options
+}
+
+func TestLocalExportGradle(t *testing.T) {
+	dir := t.TempDir()
+	source := path.Join(dir, "routes.groovy")
+	assert.Nil(t, ioutil.WriteFile(source, []byte(`from("timer:tick").to("log:info")`), os.ModePerm))
+	output := path.Join(dir, "project")
+
+	options, rootCmd, _ := initializeLocalExportCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, "local", "export", "--format", "gradle", "--directory", output, "-d", "camel:mail", source)
+	assert.Nil(t, err)
+	assert.Equal(t, "gradle", options.Format)
+
+	build, err := ioutil.ReadFile(path.Join(output, "build.gradle"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(build), "implementation 'org.apache.camel.quarkus:camel-quarkus-timer'\n")
+	assert.Contains(t, string(build), "implementation 'org.apache.camel.quarkus:camel-quarkus-log'\n")
+	assert.Contains(t, string(build), "implementation 'org.apache.camel.quarkus:camel-quarkus-mail'\n")
+	assert.Contains(t, string(build), "implementation 'org.apache.camel.k:camel-k-runtime'\n")
+
+	routes, err := ioutil.ReadFile(path.Join(output, "src/main/resources/routes/routes.groovy"))
+	assert.Nil(t, err)
+	assert.Equal(t, `from("timer:tick").to("log:info")`, string(routes))
+}
+
+func TestLocalExportValidation(t *testing.T) {
+	dir := t.TempDir()
+	source := path.Join(dir, "routes.groovy")
+	assert.Nil(t, ioutil.WriteFile(source, []byte(`from("timer:tick").to("log:info")`), os.ModePerm))
+
+	options := localExportCmdOptions{Format: "ant", Directory: path.Join(dir, "project")}
+	assert.EqualError(t, options.validate([]string{source}), "unsupported export format: ant, must be one of maven, gradle, jbang")
+
+	options = localExportCmdOptions{Format: "maven"}
+	assert.EqualError(t, options.validate([]string{source}), "the directory where the project is exported must be set with the --directory option")
+
+	options = localExportCmdOptions{Format: "maven", Directory: dir}
+	assert.EqualError(t, options.validate([]string{source}), "the directory "+dir+" is not empty")
+
+	options = localExportCmdOptions{Format: "jbang", Directory: path.Join(dir, "project")}
+	assert.Nil(t, options.validate([]string{source}))
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/apache/camel-k/pkg/cmd/operator"
@@ -40,16 +42,45 @@ func newCmdOperator() (*cobra.Command, *operatorCmdOptions) {
 	cmd.Flags().Int32("health-port", 8081, "The port of the health endpoint")
 	cmd.Flags().Int32("monitoring-port", 8080, "The port of the metrics endpoint")
 	cmd.Flags().Bool("leader-election", true, "Use leader election")
+	cmd.Flags().Duration("leader-election-lease-duration", 0, "The duration the standby replicas wait before acquiring the leadership, when the leader stops renewing it (defaults to 15s)")
+	cmd.Flags().Duration("leader-election-renew-deadline", 0, "The duration the leader retries renewing the leadership before giving it up (defaults to 10s)")
+	cmd.Flags().Duration("leader-election-retry-period", 0, "The duration the replicas wait between tries of acquiring or renewing the leadership (defaults to 2s)")
+	cmd.Flags().StringArray("cache-namespace", nil, "Restrict the cache of an operator watching all namespaces to the given namespace, can be repeated")
+	cmd.Flags().StringArray("cache-selector", nil, "Restrict the cache of the resources of the given kind to the ones matching the label selector, as <kind>=<selector>, "+
+		"where the kind is one of ConfigMap, Deployment, Pod, ReplicaSet or Secret")
+	cmd.Flags().Duration("cache-resync-period", 0, "The period at which all the cached resources are reconciled again (defaults to 10h)")
+	cmd.Flags().Float32("client-qps", 0, "The maximum rate, per second, of the requests to the API server (defaults to 20)")
+	cmd.Flags().Int("client-burst", 0, "The maximum burst of the requests to the API server (defaults to 30)")
 
 	return &cmd, &options
 }
 
 type operatorCmdOptions struct {
-	HealthPort     int32 `mapstructure:"health-port"`
-	MonitoringPort int32 `mapstructure:"monitoring-port"`
-	LeaderElection bool  `mapstructure:"leader-election"`
+	HealthPort                  int32         `mapstructure:"health-port"`
+	MonitoringPort              int32         `mapstructure:"monitoring-port"`
+	LeaderElection              bool          `mapstructure:"leader-election"`
+	LeaderElectionLeaseDuration time.Duration `mapstructure:"leader-election-lease-duration"`
+	LeaderElectionRenewDeadline time.Duration `mapstructure:"leader-election-renew-deadline"`
+	LeaderElectionRetryPeriod   time.Duration `mapstructure:"leader-election-retry-period"`
+	CacheNamespaces             []string      `mapstructure:"cache-namespaces"`
+	CacheSelectors              []string      `mapstructure:"cache-selectors"`
+	CacheResyncPeriod           time.Duration `mapstructure:"cache-resync-period"`
+	ClientQPS                   float32       `mapstructure:"client-qps"`
+	ClientBurst                 int           `mapstructure:"client-burst"`
 }
 
 func (o *operatorCmdOptions) run(_ *cobra.Command, _ []string) {
-	operator.Run(o.HealthPort, o.MonitoringPort, o.LeaderElection)
+	operator.Run(o.HealthPort, o.MonitoringPort, operator.LeaderElectionOptions{
+		Enabled:       o.LeaderElection,
+		LeaseDuration: o.LeaderElectionLeaseDuration,
+		RenewDeadline: o.LeaderElectionRenewDeadline,
+		RetryPeriod:   o.LeaderElectionRetryPeriod,
+	}, operator.CacheOptions{
+		Namespaces:   o.CacheNamespaces,
+		Selectors:    o.CacheSelectors,
+		ResyncPeriod: o.CacheResyncPeriod,
+	}, operator.ClientOptions{
+		QPS:   o.ClientQPS,
+		Burst: o.ClientBurst,
+	})
 }
@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// CacheOptions configures the informers cache of the resources read by the operator
+type CacheOptions struct {
+	// The namespaces the cache of a global operator is restricted to
+	Namespaces []string
+	// The label selectors restricting the cached resources, as <kind>=<selector>
+	Selectors []string
+	// The period at which the cached resources are reconciled again
+	ResyncPeriod time.Duration
+}
+
+// ClientOptions configures the rate of the requests made by the operator to the API server
+type ClientOptions struct {
+	QPS   float32
+	Burst int
+}
+
+// cachedKinds are the kinds of the resources whose cache can be restricted with a label selector
+var cachedKinds = map[string]func() ctrl.Object{
+	"Pod":        func() ctrl.Object { return &corev1.Pod{} },
+	"ReplicaSet": func() ctrl.Object { return &appsv1.ReplicaSet{} },
+	"Deployment": func() ctrl.Object { return &appsv1.Deployment{} },
+	"ConfigMap":  func() ctrl.Object { return &corev1.ConfigMap{} },
+	"Secret":     func() ctrl.Object { return &corev1.Secret{} },
+}
+
+// apply configures the cache of the manager. The operator namespace is always cached, as it hosts the
+// global integration platform.
+func (o CacheOptions) apply(options *manager.Options, watchNamespace string, operatorNamespace string) error {
+	selectors, err := o.selectors()
+	if err != nil {
+		return err
+	}
+
+	var namespaces []string
+	if len(o.Namespaces) > 0 {
+		if watchNamespace != "" {
+			return fmt.Errorf("the cache namespaces can only be set when the operator watches all namespaces")
+		}
+		namespaces = append(namespaces, o.Namespaces...)
+		if operatorNamespace != "" && !util.StringSliceExists(namespaces, operatorNamespace) {
+			namespaces = append(namespaces, operatorNamespace)
+		}
+	}
+
+	options.NewCache = func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		opts.SelectorsByObject = selectors
+		if len(namespaces) > 0 {
+			return cache.MultiNamespacedCacheBuilder(namespaces)(config, opts)
+		}
+		return cache.New(config, opts)
+	}
+	if o.ResyncPeriod > 0 {
+		options.SyncPeriod = &o.ResyncPeriod
+	}
+
+	return nil
+}
+
+// selectors returns the label selectors of the cached resources. The integration Pods are always selected,
+// so that the Pods of other workloads are not cached.
+func (o CacheOptions) selectors() (cache.SelectorsByObject, error) {
+	podSelector, err := labels.NewRequirement(v1.IntegrationLabel, selection.Exists, []string{})
+	if err != nil {
+		return nil, err
+	}
+	selectors := map[string]labels.Selector{
+		"Pod": labels.NewSelector().Add(*podSelector),
+	}
+
+	for _, s := range o.Selectors {
+		kind, selector, ok := splitSelector(s)
+		if !ok {
+			return nil, fmt.Errorf("invalid cache selector %q, expected <kind>=<selector>", s)
+		}
+		if _, ok := cachedKinds[kind]; !ok {
+			return nil, fmt.Errorf("invalid cache selector %q, the kind must be one of %s", s, strings.Join(kinds(), ", "))
+		}
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache selector %q: %w", s, err)
+		}
+		if requirements, ok := parsed.Requirements(); ok {
+			if existing, ok := selectors[kind]; ok {
+				parsed = existing.Add(requirements...)
+			}
+		}
+		selectors[kind] = parsed
+	}
+
+	objects := cache.SelectorsByObject{}
+	for kind, selector := range selectors {
+		// The selector type is internal to the cache package, so it can only be instantiated with a composite literal
+		for object, s := range (cache.SelectorsByObject{cachedKinds[kind](): {Label: selector}}) {
+			objects[object] = s
+		}
+	}
+	return objects, nil
+}
+
+func (o ClientOptions) apply(config *rest.Config) *rest.Config {
+	config = rest.CopyConfig(config)
+	if o.QPS > 0 {
+		config.QPS = o.QPS
+	}
+	if o.Burst > 0 {
+		config.Burst = o.Burst
+	}
+	return config
+}
+
+func splitSelector(s string) (string, string, bool) {
+	i := strings.Index(s, "=")
+	if i < 0 {
+		return "", "", false
+	}
+	return s[:i], s[i+1:], true
+}
+
+func kinds() []string {
+	kinds := make([]string, 0, len(cachedKinds))
+	for kind := range cachedKinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+const (
+	leaderIdentityKey   = "identity"
+	leaderAcquiredAtKey = "acquiredAt"
+)
+
+var leader = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "camel_k_operator_leader",
+		Help: "Camel K operator instance leadership, 1 for the leader and 0 for the standby replicas",
+	},
+	[]string{
+		leaderIdentityKey,
+	},
+)
+
+func init() {
+	metrics.Registry.MustRegister(leader)
+}
+
+// LeaderElectionOptions configures the election of the operator instance that reconciles the resources,
+// among the replicas of the operator
+type LeaderElectionOptions struct {
+	Enabled       bool
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+func (o LeaderElectionOptions) apply(options *manager.Options) {
+	options.LeaderElection = o.Enabled
+	if o.LeaseDuration > 0 {
+		options.LeaseDuration = &o.LeaseDuration
+	}
+	if o.RenewDeadline > 0 {
+		options.RenewDeadline = &o.RenewDeadline
+	}
+	if o.RetryPeriod > 0 {
+		options.RetryPeriod = &o.RetryPeriod
+	}
+}
+
+// leaderRecorder is started once the operator instance is elected leader, and publishes its identity
+// with the camel_k_operator_leader metric, and into the operator leader ConfigMap
+type leaderRecorder struct {
+	client    client.Client
+	namespace string
+	identity  string
+}
+
+var _ manager.LeaderElectionRunnable = &leaderRecorder{}
+
+func newLeaderRecorder(c client.Client, namespace string, identity string) *leaderRecorder {
+	leader.WithLabelValues(identity).Set(0)
+
+	return &leaderRecorder{
+		client:    c,
+		namespace: namespace,
+		identity:  identity,
+	}
+}
+
+func (r *leaderRecorder) NeedLeaderElection() bool {
+	return true
+}
+
+func (r *leaderRecorder) Start(ctx context.Context) error {
+	leader.WithLabelValues(r.identity).Set(1)
+	log.Info("Elected leader", "identity", r.identity)
+
+	if r.namespace == "" {
+		return nil
+	}
+
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.namespace,
+			Name:      platform.OperatorLeaderConfigMapName,
+			Labels: map[string]string{
+				"app": "camel-k",
+			},
+		},
+		Data: map[string]string{
+			leaderIdentityKey:   r.identity,
+			leaderAcquiredAtKey: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	if err := kubernetes.ReplaceResource(ctx, r.client, &cm); err != nil {
+		// Failing to publish the leader identity must not prevent the operator from reconciling resources
+		log.Error(err, "cannot record the operator leader identity")
+	}
+
+	return nil
+}
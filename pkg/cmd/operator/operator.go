@@ -30,13 +30,10 @@ import (
 	coordination "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
-	"sigs.k8s.io/controller-runtime/pkg/cache"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -46,13 +43,22 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis"
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/controller"
 	"github.com/apache/camel-k/pkg/event"
+	"github.com/apache/camel-k/pkg/graph"
+	"github.com/apache/camel-k/pkg/hibernation"
+	"github.com/apache/camel-k/pkg/ide"
 	"github.com/apache/camel-k/pkg/install"
 	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/upgrade"
+	"github.com/apache/camel-k/pkg/usage"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/telemetry"
+	"github.com/apache/camel-k/pkg/webhook"
 )
 
 var log = logf.Log.WithName("cmd")
@@ -70,7 +76,7 @@ func printVersion() {
 }
 
 // Run starts the Camel K operator
-func Run(healthPort, monitoringPort int32, leaderElection bool) {
+func Run(healthPort, monitoringPort int32, leaderElection LeaderElectionOptions, cacheOptions CacheOptions, clientOptions ClientOptions) {
 	rand.Seed(time.Now().UTC().UnixNano())
 
 	flag.Parse()
@@ -87,6 +93,17 @@ func Run(healthPort, monitoringPort int32, leaderElection bool) {
 
 	printVersion()
 
+	shutdownTracing, err := telemetry.Setup(context.TODO())
+	exitOnError(err, "cannot set up the operator tracing")
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error(err, "cannot flush the operator spans")
+		}
+	}()
+	if telemetry.Enabled() {
+		log.Info("Exporting the operator spans over OTLP")
+	}
+
 	watchNamespace, err := getWatchNamespace()
 	exitOnError(err, "failed to get watch namespace")
 
@@ -114,48 +131,52 @@ func Run(healthPort, monitoringPort int32, leaderElection bool) {
 		// in which case it's not possible to determine a namespace.
 		operatorNamespace = watchNamespace
 		if operatorNamespace == "" {
-			leaderElection = false
+			leaderElection.Enabled = false
 			log.Info("unable to determine namespace for leader election")
 		}
 	}
 
+	// Register the declarative traits before the traits catalogs are created
+	if name, ok := os.LookupEnv(trait.DeclarativeTraitsEnvVariable); ok && name != "" {
+		definitions, err := trait.LoadDeclarativeTraits(context.TODO(), c, operatorNamespace, name)
+		exitOnError(err, "cannot load the declarative traits")
+		exitOnError(trait.RegisterDeclarativeTraits(definitions), "cannot register the declarative traits")
+		log.Info("Registered declarative traits", "configmap", name, "count", len(definitions))
+	}
+
 	// Set the operator container image if it runs in-container
 	platform.OperatorImage, err = getOperatorImage(context.TODO(), c)
 	exitOnError(err, "cannot get operator container image")
 
 	if ok, err := kubernetes.CheckPermission(context.TODO(), c, coordination.GroupName, "leases", operatorNamespace, "", "create"); err != nil || !ok {
-		leaderElection = false
+		leaderElection.Enabled = false
 		exitOnError(err, "cannot check permissions for creating Leases")
 		log.Info("The operator is not granted permissions to create Leases")
 	}
 
-	if !leaderElection {
+	if !leaderElection.Enabled {
 		log.Info("Leader election is disabled!")
 	}
 
-	podLabelSelector, err := labels.NewRequirement(v1.IntegrationLabel, selection.Exists, []string{})
-	exitOnError(err, "cannot create Pod labels selector")
-
-	mgr, err := manager.New(c.GetConfig(), manager.Options{
+	options := manager.Options{
 		Namespace:                     watchNamespace,
 		EventBroadcaster:              broadcaster,
-		LeaderElection:                leaderElection,
 		LeaderElectionNamespace:       operatorNamespace,
 		LeaderElectionID:              platform.OperatorLockName,
 		LeaderElectionResourceLock:    resourcelock.LeasesResourceLock,
 		LeaderElectionReleaseOnCancel: true,
 		HealthProbeBindAddress:        ":" + strconv.Itoa(int(healthPort)),
 		MetricsBindAddress:            ":" + strconv.Itoa(int(monitoringPort)),
-		NewCache: cache.BuilderWithOptions(
-			cache.Options{
-				SelectorsByObject: cache.SelectorsByObject{
-					&corev1.Pod{}: {
-						Label: labels.NewSelector().Add(*podLabelSelector),
-					},
-				},
-			},
-		),
-	})
+	}
+	leaderElection.apply(&options)
+	exitOnError(cacheOptions.apply(&options, watchNamespace, operatorNamespace), "invalid cache options")
+
+	config := clientOptions.apply(c.GetConfig())
+	if telemetry.Enabled() {
+		// Record the API requests issued by the controllers within the reconcile spans
+		config = telemetry.WrapConfig(config)
+	}
+	mgr, err := manager.New(config, options)
 	exitOnError(err, "")
 
 	exitOnError(
@@ -168,8 +189,31 @@ func Run(healthPort, monitoringPort int32, leaderElection bool) {
 
 	log.Info("Configuring manager")
 	exitOnError(mgr.AddHealthzCheck("health-probe", healthz.Ping), "Unable add liveness check")
+	exitOnError(mgr.AddMetricsExtraHandler("/graph", graph.NewHandler(c)), "unable to register the graph endpoint")
+	exitOnError(mgr.AddMetricsExtraHandler("/usage", usage.NewHandler(c)), "unable to register the usage endpoint")
+	exitOnError(mgr.AddMetricsExtraHandler("/activate", hibernation.NewHandler(c)), "unable to register the activator endpoint")
+	exitOnError(mgr.AddMetricsExtraHandler("/traits", trait.NewDescribeHandler(c)), "unable to register the traits endpoint")
+	exitOnError(mgr.AddMetricsExtraHandler("/ide", ide.NewHandler(c)), "unable to register the IDE endpoint")
 	exitOnError(apis.AddToScheme(mgr.GetScheme()), "")
 	exitOnError(controller.AddToManager(mgr), "")
+	if webhook.Enabled() {
+		log.Info("Registering admission webhooks")
+		exitOnError(webhook.AddToManager(mgr), "unable to register admission webhooks")
+	}
+	exitOnError(mgr.Add(newLeaderRecorder(c, operatorNamespace, getOperatorIdentity())), "")
+	exitOnError(mgr.Add(upgrade.NewCoordinator(c, watchNamespace)), "unable to register the upgrade coordinator")
+	exitOnError(mgr.Add(hibernation.NewController(c, watchNamespace)), "unable to register the hibernation controller")
+
+	// Register the informers of the primary resources before the manager starts, so that their caches
+	// are synced while the instance is a standby replica, and reconciliation resumes as soon as it is
+	// elected leader, without listing all the resources first
+	for _, obj := range []ctrl.Object{
+		&v1.Integration{}, &v1.IntegrationKit{}, &v1.IntegrationPlatform{}, &v1.Build{},
+		&v1alpha1.Kamelet{}, &v1alpha1.KameletBinding{}, &corev1.Pod{},
+	} {
+		_, err := mgr.GetCache().GetInformer(context.TODO(), obj)
+		exitOnError(err, "unable to set up informers")
+	}
 
 	log.Info("Installing operator resources")
 	installCtx, installCancel := context.WithTimeout(context.TODO(), 1*time.Minute)
@@ -180,6 +224,16 @@ func Run(healthPort, monitoringPort int32, leaderElection bool) {
 	exitOnError(mgr.Start(signals.SetupSignalHandler()), "manager exited non-zero")
 }
 
+// getOperatorIdentity returns the identity of the operator instance, that is the name of its Pod when it runs in-cluster
+func getOperatorIdentity() string {
+	if name := platform.GetOperatorPodName(); name != "" {
+		return name
+	}
+	hostname, err := os.Hostname()
+	exitOnError(err, "cannot determine the operator identity")
+	return hostname
+}
+
 // getWatchNamespace returns the Namespace the operator should be watching for changes
 func getWatchNamespace() (string, error) {
 	ns, found := os.LookupEnv(platform.OperatorWatchNamespaceEnvVariable)
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/apache/camel-k/pkg/util/test"
 	"github.com/spf13/cobra"
@@ -78,3 +79,33 @@ func TestOperatorMonitoringPortFlag(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, int32(7172), operatorCmdOptions.MonitoringPort)
 }
+
+func TestOperatorLeaderElectionFlags(t *testing.T) {
+	operatorCmdOptions, rootCmd, _ := initializeOperatorCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdOperator,
+		"--leader-election-lease-duration", "8s",
+		"--leader-election-renew-deadline", "6s",
+		"--leader-election-retry-period", "1s")
+	assert.Nil(t, err)
+	assert.Equal(t, true, operatorCmdOptions.LeaderElection)
+	assert.Equal(t, 8*time.Second, operatorCmdOptions.LeaderElectionLeaseDuration)
+	assert.Equal(t, 6*time.Second, operatorCmdOptions.LeaderElectionRenewDeadline)
+	assert.Equal(t, 1*time.Second, operatorCmdOptions.LeaderElectionRetryPeriod)
+}
+
+func TestOperatorCacheFlags(t *testing.T) {
+	operatorCmdOptions, rootCmd, _ := initializeOperatorCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdOperator,
+		"--cache-namespace", "ns1",
+		"--cache-namespace", "ns2",
+		"--cache-selector", "Secret=app=camel",
+		"--cache-resync-period", "1h",
+		"--client-qps", "50",
+		"--client-burst", "100")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ns1", "ns2"}, operatorCmdOptions.CacheNamespaces)
+	assert.Equal(t, []string{"Secret=app=camel"}, operatorCmdOptions.CacheSelectors)
+	assert.Equal(t, time.Hour, operatorCmdOptions.CacheResyncPeriod)
+	assert.Equal(t, float32(50), operatorCmdOptions.ClientQPS)
+	assert.Equal(t, 100, operatorCmdOptions.ClientBurst)
+}
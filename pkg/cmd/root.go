@@ -46,6 +46,10 @@ type RootCmdOptions struct {
 	_client       client.Client      `mapstructure:"-"`
 	KubeConfig    string             `mapstructure:"kube-config"`
 	Namespace     string             `mapstructure:"namespace"`
+	// The kamel context the commands are executed against
+	ContextName string `mapstructure:"context"`
+	// The context of the kube config, set by the kamel context
+	KubeContext string `mapstructure:"-"`
 }
 
 // NewKamelCommand --
@@ -86,6 +90,7 @@ func kamelPreAddCommandInit(options *RootCmdOptions) *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(&options.KubeConfig, "kube-config", os.Getenv("KUBECONFIG"), "Path to the kube config file to use for CLI requests")
 	cmd.PersistentFlags().StringVarP(&options.Namespace, "namespace", "n", "", "Namespace to use for all operations")
+	cmd.PersistentFlags().StringVar(&options.ContextName, "context", os.Getenv("KAMEL_CONTEXT"), "The kamel context to use for CLI requests, instead of the current one")
 
 	return &cmd
 }
@@ -141,14 +146,23 @@ func addKamelSubcommands(cmd *cobra.Command, options *RootCmdOptions) {
 	cmd.AddCommand(cmdOnly(newCmdReset(options)))
 	cmd.AddCommand(newCmdDescribe(options))
 	cmd.AddCommand(cmdOnly(newCmdRebuild(options)))
+	cmd.AddCommand(cmdOnly(newCmdRunOnce(options)))
 	cmd.AddCommand(cmdOnly(newCmdOperator()))
 	cmd.AddCommand(cmdOnly(newCmdBuilder(options)))
 	cmd.AddCommand(cmdOnly(newCmdInit(options)))
 	cmd.AddCommand(cmdOnly(newCmdDebug(options)))
 	cmd.AddCommand(cmdOnly(newCmdDump(options)))
 	cmd.AddCommand(newCmdLocal(options))
+	cmd.AddCommand(newCmdInspect(options))
+	cmd.AddCommand(cmdOnly(newCmdGraph(options)))
+	cmd.AddCommand(cmdOnly(newCmdUsage(options)))
+	cmd.AddCommand(cmdOnly(newCmdExport(options)))
+	cmd.AddCommand(newCmdCatalog(options))
 	cmd.AddCommand(cmdOnly(newCmdBind(options)))
 	cmd.AddCommand(newCmdKamelet(options))
+	cmd.AddCommand(newCmdUpgrade(options))
+	cmd.AddCommand(newCmdTrait(options))
+	cmd.AddCommand(newCmdContext(options))
 }
 
 func addHelpSubCommands(cmd *cobra.Command, options *RootCmdOptions) error {
@@ -174,12 +188,20 @@ func addHelpSubCommands(cmd *cobra.Command, options *RootCmdOptions) error {
 
 func (command *RootCmdOptions) preRun(cmd *cobra.Command, _ []string) error {
 	if !isOfflineCommand(cmd) {
+		if err := command.applyContext(cmd); err != nil {
+			return err
+		}
 		c, err := command.GetCmdClient()
 		if err != nil {
 			return errors.Wrap(err, "cannot get command client")
 		}
 		if command.Namespace == "" {
-			current, err := c.GetCurrentNamespace(command.KubeConfig)
+			var current string
+			if command.KubeContext != "" {
+				current, err = client.GetCurrentNamespaceForContext(command.KubeConfig, command.KubeContext)
+			} else {
+				current, err = c.GetCurrentNamespace(command.KubeConfig)
+			}
 			if err != nil {
 				return errors.Wrap(err, "cannot get current namespace")
 			}
@@ -239,5 +261,8 @@ func (command *RootCmdOptions) GetCamelCmdClient() (*camelv1.CamelV1Client, erro
 
 // NewCmdClient returns a new client that can be used from command line tools
 func (command *RootCmdOptions) NewCmdClient() (client.Client, error) {
+	if command.KubeContext != "" {
+		return client.NewOutOfClusterClientForContext(command.KubeConfig, command.KubeContext)
+	}
 	return client.NewOutOfClusterClient(command.KubeConfig)
 }
@@ -25,6 +25,7 @@ import (
 	"os"
 	"os/signal"
 	"path"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -38,6 +39,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
@@ -45,6 +47,8 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/controller/integration"
+	"github.com/apache/camel-k/pkg/controller/integrationtemplate"
 	"github.com/apache/camel-k/pkg/trait"
 	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/dsl"
@@ -65,9 +69,11 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions)
 	}
 
 	cmd := cobra.Command{
-		Use:      "run [file to run]",
-		Short:    "Run a integration on Kubernetes",
-		Long:     `Deploys and execute a integration pod on Kubernetes.`,
+		Use:   "run [file to run]",
+		Short: "Run a integration on Kubernetes",
+		Long: `Deploys and execute a integration pod on Kubernetes.
+A Camel JBang project directory can be given instead of files: its routes are added as sources,
+and its application.properties as runtime properties.`,
 		Args:     options.validateArgs,
 		PreRunE:  options.decode,
 		RunE:     options.run,
@@ -93,14 +99,20 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions)
 	cmd.Flags().String("profile", "", "Trait profile used for deployment")
 	cmd.Flags().StringArrayP("trait", "t", nil, "Configure a trait. E.g. \"-t service.enabled=false\"")
 	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
+	cmd.Flags().Bool("server-dry-run", false, "Print the resources that would be created for the integration, without persisting anything. The output format defaults to yaml")
 	cmd.Flags().Bool("compression", false, "Enable storage of sources and resources as a compressed binary blobs")
-	cmd.Flags().StringArray("open-api", nil, "Add an OpenAPI v2 spec")
+	cmd.Flags().String("max-inline-size", "256Ki", "The maximum size of the content of each source and resource stored in the integration. Larger contents are compressed, and moved to ConfigMaps when they are still larger. Set to 0 to disable")
+	cmd.Flags().StringArray("open-api", nil, "Add an OpenAPI spec (Swagger 2.0, OpenAPI 3.0 or 3.1), either from a file or from a ConfigMap (syntax: configmap:name)")
+	cmd.Flags().StringArray("test", nil, "Add a Java or Groovy unit test, run when the integration is built, either from a file or from a ConfigMap (syntax: configmap:name[/key])")
 	cmd.Flags().StringArrayP("volume", "v", nil, "Mount a volume into the integration container. E.g \"-v pvcname:/container/path\"")
 	cmd.Flags().StringArrayP("env", "e", nil, "Set an environment variable in the integration container. E.g \"-e MY_VAR=my-value\"")
 	cmd.Flags().StringArray("property-file", nil, "[Deprecated] Bind a property file to the integration. E.g. \"--property-file integration.properties\"")
 	cmd.Flags().StringArray("label", nil, "Add a label to the integration. E.g. \"--label my.company=hello\"")
 	cmd.Flags().StringArray("source", nil, "Add source file to your integration, this is added to the list of files listed as arguments of the command")
 	cmd.Flags().String("pod-template", "", "The path of the YAML file containing a PodSpec template to be used for the Integration pods")
+	cmd.Flags().String("from-template", "", "The name of the IntegrationTemplate the integration is instantiated from")
+	cmd.Flags().StringArray("template-param", nil, "Set the value of a parameter of the IntegrationTemplate. E.g. \"--template-param message=hello\"")
+	cmd.Flags().String("dependency-lock", "", "The path of the YAML file containing the dependency lock, generated with \"kamel inspect dependencies --lock\", used to make the builds reproducible")
 
 	cmd.Flags().Bool("save", false, "Save the run parameters into the default kamel configuration file (kamel-config.yaml)")
 
@@ -113,6 +125,7 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions)
 type runCmdOptions struct {
 	*RootCmdOptions `json:"-"`
 	Compression     bool     `mapstructure:"compression" yaml:",omitempty"`
+	MaxInlineSize   string   `mapstructure:"max-inline-size" yaml:",omitempty"`
 	Wait            bool     `mapstructure:"wait" yaml:",omitempty"`
 	Logs            bool     `mapstructure:"logs" yaml:",omitempty"`
 	Sync            bool     `mapstructure:"sync" yaml:",omitempty"`
@@ -123,10 +136,15 @@ type runCmdOptions struct {
 	IntegrationName string   `mapstructure:"name" yaml:",omitempty"`
 	Profile         string   `mapstructure:"profile" yaml:",omitempty"`
 	OutputFormat    string   `mapstructure:"output" yaml:",omitempty"`
+	ServerDryRun    bool     `mapstructure:"server-dry-run" yaml:",omitempty" kamel:"omitsave"`
 	PodTemplate     string   `mapstructure:"pod-template" yaml:",omitempty"`
+	DependencyLock  string   `mapstructure:"dependency-lock" yaml:",omitempty"`
+	FromTemplate    string   `mapstructure:"from-template" yaml:",omitempty"`
+	TemplateParams  []string `mapstructure:"template-params" yaml:",omitempty"`
 	Connects        []string `mapstructure:"connects" yaml:",omitempty"`
 	Resources       []string `mapstructure:"resources" yaml:",omitempty"`
 	OpenAPIs        []string `mapstructure:"open-apis" yaml:",omitempty"`
+	Tests           []string `mapstructure:"tests" yaml:",omitempty"`
 	Dependencies    []string `mapstructure:"dependencies" yaml:",omitempty"`
 	Properties      []string `mapstructure:"properties" yaml:",omitempty"`
 	BuildProperties []string `mapstructure:"build-properties" yaml:",omitempty"`
@@ -206,8 +224,12 @@ func (o *runCmdOptions) decode(cmd *cobra.Command, args []string) error {
 	return o.validate()
 }
 
-func (o *runCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
+func (o *runCmdOptions) validateArgs(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
+		if template, _ := cmd.Flags().GetString("from-template"); template != "" {
+			// the sources are provided by the integration template
+			return nil
+		}
 		return errors.New("run expects at least 1 argument, received 0")
 	}
 
@@ -249,6 +271,10 @@ func (o *runCmdOptions) validate() error {
 		return err
 	}
 
+	if o.ServerDryRun && (o.Dev || o.Sync || o.Logs || o.Wait) {
+		return errors.New("--server-dry-run cannot be used with --dev, --sync, --logs or --wait")
+	}
+
 	for _, label := range o.Labels {
 		parts := strings.Split(label, "=")
 		if len(parts) != 2 {
@@ -256,9 +282,25 @@ func (o *runCmdOptions) validate() error {
 		}
 	}
 
+	if _, err := o.maxInlineSize(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// maxInlineSize returns the maximum size, in bytes, of the content of each source and resource stored in the integration
+func (o *runCmdOptions) maxInlineSize() (int64, error) {
+	if o.MaxInlineSize == "" {
+		return 0, nil
+	}
+	size, err := resource.ParseQuantity(o.MaxInlineSize)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid max inline size %s", o.MaxInlineSize)
+	}
+	return size.Value(), nil
+}
+
 func filterBuildPropertyFiles(maybePropertyFiles []string) []string {
 	var propertyFiles []string
 	for _, maybePropertyFile := range maybePropertyFiles {
@@ -291,6 +333,10 @@ func (o *runCmdOptions) run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if integration == nil {
+		// The integration has only been printed, or dry-run on the server
+		return nil
+	}
 
 	if o.Dev {
 		cs := make(chan os.Signal)
@@ -317,16 +363,21 @@ func (o *runCmdOptions) run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 	}
+	var timeline *progressTimeline
 	if o.Logs || o.Dev || o.Wait {
+		timeline = newProgressTimeline(cmd.OutOrStdout(), integration)
 		// nolint: errcheck
 		go watch.HandleIntegrationEvents(o.Context, integration, func(event *corev1.Event) bool {
-			fmt.Fprintln(cmd.OutOrStdout(), event.Message)
+			// The progress events are rendered as a timeline, the other events only when the logs are followed
+			if !timeline.render(event) && (o.Logs || o.Dev) {
+				fmt.Fprintln(cmd.OutOrStdout(), event.Message)
+			}
 			return true
 		})
 	}
 	if o.Wait || o.Dev {
 		for {
-			integrationPhase, err := o.waitForIntegrationReady(cmd, integration)
+			integrationPhase, err := o.waitForIntegrationReady(cmd, integration, timeline)
 			if err != nil {
 				return err
 			}
@@ -362,6 +413,35 @@ func (o *runCmdOptions) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printDryRunResources prints the resources generated by the traits for the integration, as the operator would
+// create them, without persisting anything
+func (o *runCmdOptions) printDryRunResources(cmd *cobra.Command, c client.Client, it *v1.Integration) error {
+	env, err := integration.DryRun(o.Context, c, it)
+	if err != nil {
+		return err
+	}
+
+	lst := env.Resources.AsKubernetesList()
+	switch o.OutputFormat {
+	case "", "yaml":
+		data, err := kubernetes.ToYAML(lst)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), string(data))
+	case "json":
+		data, err := kubernetes.ToJSON(lst)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), string(data))
+	default:
+		return fmt.Errorf("invalid output format option '%s', should be one of: yaml|json", o.OutputFormat)
+	}
+
+	return nil
+}
+
 func (o *runCmdOptions) postRun(cmd *cobra.Command, args []string) error {
 	if o.Save {
 		rootKey := pathToRoot(cmd)
@@ -384,13 +464,13 @@ func (o *runCmdOptions) postRun(cmd *cobra.Command, args []string) error {
 }
 
 // nolint:errcheck
-func (o *runCmdOptions) waitForIntegrationReady(cmd *cobra.Command, integration *v1.Integration) (*v1.IntegrationPhase, error) {
+func (o *runCmdOptions) waitForIntegrationReady(cmd *cobra.Command, integration *v1.Integration, timeline *progressTimeline) (*v1.IntegrationPhase, error) {
 	handler := func(i *v1.Integration) bool {
 		//
 		// TODO when we add health checks, we should Wait until they are passed
 		//
-		if i.Status.Phase != "" {
-			// TODO remove this log when we make sure that events are always created
+		if i.Status.Phase != "" && !timeline.isStreaming() {
+			// Fall back to the phases when the operator does not report progress events
 			fmt.Fprintf(cmd.OutOrStdout(), "Progress: integration %q in phase %s\n", integration.Name, string(i.Status.Phase))
 		}
 		if i.Status.Phase == v1.IntegrationPhaseRunning || i.Status.Phase == v1.IntegrationPhaseError {
@@ -406,7 +486,17 @@ func (o *runCmdOptions) waitForIntegrationReady(cmd *cobra.Command, integration
 func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sources []string, catalog *trait.Catalog) error {
 	// Let's watch all relevant files when in dev mode
 	var files []string
-	files = append(files, sources...)
+	for _, s := range sources {
+		if isLocalDirectory(s) {
+			projectFiles, err := projectSourceFiles(s)
+			if err != nil {
+				return err
+			}
+			files = append(files, projectFiles...)
+		} else {
+			files = append(files, s)
+		}
+	}
 	files = append(files, filterFileLocation(o.Resources)...)
 	files = append(files, filterFileLocation(o.Configs)...)
 	files = append(files, filterFileLocation(o.Properties)...)
@@ -468,6 +558,9 @@ func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sou
 func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.Client, sources []string, catalog *trait.Catalog) (*v1.Integration, error) {
 	namespace := o.Namespace
 	name := o.GetIntegrationName(sources)
+	if name == "" && o.FromTemplate != "" {
+		name = kubernetes.SanitizeName(o.FromTemplate)
+	}
 
 	if name == "" {
 		return nil, errors.New("unable to determine integration name")
@@ -502,12 +595,43 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		}
 	}
 
-	integration.Spec = v1.IntegrationSpec{
-		Dependencies:   make([]string, 0, len(o.Dependencies)),
-		IntegrationKit: integrationKit,
-		Configuration:  make([]v1.ConfigurationSpec, 0),
-		Repositories:   o.Repositories,
-		Profile:        v1.TraitProfileByName(o.Profile),
+	var templateSpec *corev1.ConfigMap
+	if o.FromTemplate != "" {
+		instance, err := o.instantiateTemplate(c, name, existing)
+		if err != nil {
+			return nil, err
+		}
+		if templateSpec, err = integrationtemplate.NewSpecConfigMap(instance); err != nil {
+			return nil, err
+		}
+		integration.Spec = instance.Spec
+		if integrationKit != nil {
+			integration.Spec.IntegrationKit = integrationKit
+		}
+		integration.Spec.Repositories = append(integration.Spec.Repositories, o.Repositories...)
+		if o.Profile != "" {
+			integration.Spec.Profile = v1.TraitProfileByName(o.Profile)
+		}
+		if integration.Labels == nil {
+			integration.Labels = make(map[string]string)
+		}
+		for k, v := range instance.Labels {
+			integration.Labels[k] = v
+		}
+		if integration.Annotations == nil {
+			integration.Annotations = make(map[string]string)
+		}
+		for k, v := range instance.Annotations {
+			integration.Annotations[k] = v
+		}
+	} else {
+		integration.Spec = v1.IntegrationSpec{
+			Dependencies:   make([]string, 0, len(o.Dependencies)),
+			IntegrationKit: integrationKit,
+			Configuration:  make([]v1.ConfigurationSpec, 0),
+			Repositories:   o.Repositories,
+			Profile:        v1.TraitProfileByName(o.Profile),
+		}
 	}
 
 	for _, label := range o.Labels {
@@ -529,8 +653,35 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		return nil, err
 	}
 
+	// The options are copied, as the integration is created again on each change of the sources in dev mode
+	dependencies := append([]string(nil), o.Dependencies...)
+	properties := append([]string(nil), o.Properties...)
+	traits := append([]string(nil), o.Traits...)
+
+	// the configuration of Camel JBang projects is added as runtime properties
+	for _, src := range srcs {
+		if !isLocalDirectory(src) {
+			continue
+		}
+		if file := projectPropertiesFile(src); file != "" {
+			props, err := loadPropertyFile(file)
+			if err != nil {
+				return nil, err
+			}
+			dependencies = append(dependencies, projectDependencies(props)...)
+			properties = append(properties, "file:"+file)
+		}
+	}
+
+	maxInlineSize, err := o.maxInlineSize()
+	if err != nil {
+		return nil, err
+	}
+
 	for _, source := range resolvedSources {
-		if o.UseFlows && !o.Compression && (strings.HasSuffix(source.Name, ".yaml") || strings.HasSuffix(source.Name, ".yml")) {
+		// The yaml sources larger than the max inline size are kept as sources, so that they can be offloaded
+		if o.UseFlows && !o.Compression && (strings.HasSuffix(source.Name, ".yaml") || strings.HasSuffix(source.Name, ".yml")) &&
+			(maxInlineSize <= 0 || int64(len(source.Content)) <= maxInlineSize) {
 			flows, err := dsl.FromYamlDSLString(source.Content)
 			if err != nil {
 				return nil, err
@@ -552,6 +703,11 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		return nil, err
 	}
 
+	err = resolveDependencyLock(o.DependencyLock, &integration.Spec)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, resource := range o.Resources {
 		if config, parseErr := ParseResourceOption(resource); parseErr == nil {
 			if applyResourceOptionErr := ApplyResourceOption(config, &integration.Spec, c, namespace, o.Compression); applyResourceOptionErr != nil {
@@ -563,20 +719,30 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 	}
 
 	for _, resource := range o.OpenAPIs {
+		if strings.HasPrefix(resource, "configmap:") {
+			traits = append(traits, "openapi.configmaps="+strings.TrimPrefix(resource, "configmap:"))
+			continue
+		}
 		if err = addResource(resource, &integration.Spec, o.Compression, v1.ResourceTypeOpenAPI); err != nil {
 			return nil, err
 		}
 	}
 
-	for _, item := range o.Dependencies {
+	testsTraits, testsConfigMap, err := o.configureTests(integration)
+	if err != nil {
+		return nil, err
+	}
+	traits = append(traits, testsTraits...)
+
+	for _, item := range dependencies {
 		integration.Spec.AddDependency(item)
 	}
 	for _, item := range o.PropertyFiles {
 		// Deprecated: making it compatible with newer mechanism
-		o.Properties = append(o.Properties, "file:"+item)
+		properties = append(properties, "file:"+item)
 	}
 
-	props, err := mergePropertiesWithPrecedence(o.Properties)
+	props, err := mergePropertiesWithPrecedence(properties)
 	if err != nil {
 		return nil, err
 	}
@@ -585,7 +751,7 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		if propsTraits, err := convertToTraitParameter(kv, "camel.properties"); err != nil {
 			return nil, err
 		} else {
-			o.Traits = append(o.Traits, propsTraits...)
+			traits = append(traits, propsTraits...)
 		}
 	}
 
@@ -599,7 +765,7 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		if buildPropsTraits, err := convertToTraitParameter(kv, "builder.properties"); err != nil {
 			return nil, err
 		} else {
-			o.Traits = append(o.Traits, buildPropsTraits...)
+			traits = append(traits, buildPropsTraits...)
 		}
 	}
 
@@ -622,12 +788,31 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		integration.Spec.AddConfiguration("volume", item)
 	}
 	for _, item := range o.EnvVars {
-		o.Traits = append(o.Traits, fmt.Sprintf("environment.vars=%s", item))
+		traits = append(traits, fmt.Sprintf("environment.vars=%s", item))
+	}
+
+	if err := o.configureTraits(integration, traits, catalog); err != nil {
+		return nil, err
 	}
 
-	if err := o.configureTraits(integration, o.Traits, catalog); err != nil {
+	// The content is only offloaded to ConfigMaps when the integration is persisted, it's compressed otherwise
+	offloaded, err := inlineContent(integration, maxInlineSize, o.OutputFormat == "" && !o.ServerDryRun)
+	if err != nil {
 		return nil, err
 	}
+	if testsConfigMap != nil {
+		// The ConfigMap is owned by the integration, as its offloaded content
+		offloaded = append(offloaded, testsConfigMap)
+	}
+	if templateSpec != nil {
+		// The ConfigMap is owned by the integration, but not labelled as offloaded content, so that it's kept
+		// when the integration is updated without the template
+		offloaded = append(offloaded, templateSpec)
+	}
+
+	if o.ServerDryRun {
+		return nil, o.printDryRunResources(cmd, c, integration)
+	}
 
 	switch o.OutputFormat {
 	case "":
@@ -652,6 +837,13 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		return nil, fmt.Errorf("invalid output format option '%s', should be one of: yaml|json", o.OutputFormat)
 	}
 
+	// The ConfigMaps are created first, so that the content is available when the integration gets initialized
+	for _, cm := range offloaded {
+		if err := kubernetes.ReplaceResource(o.Context, c, cm); err != nil {
+			return nil, err
+		}
+	}
+
 	if existing == nil {
 		err = c.Create(o.Context, integration)
 	} else {
@@ -662,6 +854,10 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		return nil, err
 	}
 
+	if err := ownOffloadedContent(o.Context, c, integration, offloaded); err != nil {
+		return nil, err
+	}
+
 	if existing == nil {
 		fmt.Printf("Integration \"%s\" created\n", name)
 	} else {
@@ -670,6 +866,85 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 	return integration, nil
 }
 
+// configureTests returns the builder trait properties to run the unit tests, and the ConfigMap the test files
+// are stored in, if any
+func (o *runCmdOptions) configureTests(it *v1.Integration) ([]string, *corev1.ConfigMap, error) {
+	var traits []string
+	var cm *corev1.ConfigMap
+	for _, t := range o.Tests {
+		if strings.HasPrefix(t, "configmap:") {
+			traits = append(traits, "builder.test-configmaps="+strings.TrimPrefix(t, "configmap:"))
+			continue
+		}
+		if o.OutputFormat != "" || o.ServerDryRun {
+			return nil, nil, fmt.Errorf("the test %s must be set from a ConfigMap (syntax: configmap:name[/key]) when the integration is not created", t)
+		}
+		key := path.Base(t)
+		if !strings.HasSuffix(key, ".java") && !strings.HasSuffix(key, ".groovy") {
+			return nil, nil, fmt.Errorf("unsupported test %s: only Java and Groovy tests can be run", t)
+		}
+		content, err := ioutil.ReadFile(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cm == nil {
+			cm = &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ConfigMap",
+					APIVersion: corev1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: it.Namespace,
+					Name:      it.Name + "-tests",
+					Labels: map[string]string{
+						v1.IntegrationLabel:   it.Name,
+						offloadedContentLabel: "true",
+					},
+				},
+				Data: make(map[string]string),
+			}
+			traits = append(traits, "builder.test-configmaps="+cm.Name)
+		}
+		if _, ok := cm.Data[key]; ok {
+			return nil, nil, fmt.Errorf("duplicated test %s", key)
+		}
+		cm.Data[key] = string(content)
+	}
+	return traits, cm, nil
+}
+
+// instantiateTemplate instantiates the integration template with the given parameters. When the integration
+// is already an instance of the template, the parameters it has been instantiated with are used as defaults.
+func (o *runCmdOptions) instantiateTemplate(c client.Client, name string, existing *v1.Integration) (*v1.Integration, error) {
+	template := v1.NewIntegrationTemplate(o.Namespace, o.FromTemplate)
+	if err := c.Get(o.Context, ctrl.ObjectKeyFromObject(&template), &template); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("integration template %s not found in namespace %s", o.FromTemplate, o.Namespace)
+		}
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	if existing != nil && existing.Labels[v1.IntegrationTemplateLabel] == template.Name {
+		parameters, err := existing.GetTemplateParameters()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range parameters {
+			values[k] = v
+		}
+	}
+	for _, param := range o.TemplateParams {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("template parameter '%s' is invalid, it should be in the format: name=value", param)
+		}
+		values[kv[0]] = kv[1]
+	}
+
+	return template.Instantiate(name, values)
+}
+
 func addResource(resourceLocation string, integrationSpec *v1.IntegrationSpec, enableCompression bool, resourceType v1.ResourceType) error {
 	if data, _, compressed, err := loadTextContent(resourceLocation, enableCompression); err == nil {
 		integrationSpec.AddResources(v1.ResourceSpec{
@@ -715,7 +990,14 @@ func (o *runCmdOptions) GetIntegrationName(sources []string) string {
 		name = o.IntegrationName
 		name = kubernetes.SanitizeName(name)
 	} else if len(sources) == 1 {
-		name = kubernetes.SanitizeName(sources[0])
+		source := sources[0]
+		if isLocalDirectory(source) {
+			// a Camel JBang project is named after its directory
+			if dir, err := filepath.Abs(source); err == nil {
+				source = dir
+			}
+		}
+		name = kubernetes.SanitizeName(source)
 	}
 	return name
 }
@@ -731,7 +1013,35 @@ func (o *runCmdOptions) configureTraits(integration *v1.Integration, options []s
 		return err
 	}
 
-	integration.Spec.Traits = traits
+	if integration.Spec.Traits == nil {
+		integration.Spec.Traits = traits
+		return nil
+	}
+
+	// the traits inherited from an integration template are overridden property by property
+	for id, spec := range traits {
+		current, ok := integration.Spec.Traits[id]
+		if !ok {
+			integration.Spec.Traits[id] = spec
+			continue
+		}
+		config := make(map[string]interface{})
+		if err := json.Unmarshal(current.Configuration.RawMessage, &config); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(spec.Configuration.RawMessage, &config); err != nil {
+			return err
+		}
+		data, err := json.Marshal(config)
+		if err != nil {
+			return err
+		}
+		integration.Spec.Traits[id] = v1.TraitSpec{
+			Configuration: v1.TraitConfiguration{
+				RawMessage: data,
+			},
+		}
+	}
 
 	return nil
 }
@@ -779,6 +1089,28 @@ func resolvePodTemplate(ctx context.Context, templateSrc string, spec *v1.Integr
 	return err
 }
 
+func resolveDependencyLock(lockFile string, spec *v1.IntegrationSpec) error {
+	if lockFile == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(lockFile)
+	if err != nil {
+		return err
+	}
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return err
+	}
+	var lock v1.DependencyLock
+	if err := json.Unmarshal(jsonData, &lock); err != nil {
+		return errors.Wrapf(err, "invalid dependency lock %s", lockFile)
+	}
+	spec.DependencyLock = &lock
+
+	return nil
+}
+
 func configureTraits(options []string, catalog *trait.Catalog) (map[string]v1.TraitSpec, error) {
 	traits := make(map[string]map[string]interface{})
 
@@ -0,0 +1,149 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+)
+
+// offloadedContentLabel marks the ConfigMaps the content of the Integration sources and resources is offloaded to
+const offloadedContentLabel = "camel.apache.org/offloaded-content"
+
+// inlineContent keeps the content of the sources and resources of the given Integration within the given maximum size,
+// by compressing the larger sources, and moving the larger contents to ConfigMaps, that are returned, when offload is true.
+// The resources are not compressed, as they're mounted as is into the Integration pods.
+func inlineContent(it *v1.Integration, maxSize int64, offload bool) ([]*corev1.ConfigMap, error) {
+	maps := make([]*corev1.ConfigMap, 0)
+	if maxSize <= 0 {
+		return maps, nil
+	}
+
+	move := func(kind string, index int, data *v1.DataSpec, compress bool) error {
+		if data.ContentRef != "" || int64(len(data.Content)+len(data.RawContent)) <= maxSize {
+			return nil
+		}
+		if compress && data.RawContent == nil && !data.Compression {
+			content, err := compressToString([]byte(data.Content))
+			if err != nil {
+				return err
+			}
+			data.Content = content
+			data.Compression = true
+			if int64(len(content)) <= maxSize {
+				return nil
+			}
+		}
+		if !offload {
+			return nil
+		}
+
+		cm := corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: it.Namespace,
+				Name:      fmt.Sprintf("%s-offloaded-%s-%03d", it.Name, kind, index),
+				Labels: map[string]string{
+					v1.IntegrationLabel:   it.Name,
+					offloadedContentLabel: "true",
+				},
+			},
+		}
+		// The key is kept, as it's the name of the file the resources are mounted into
+		key := data.GetContentKey()
+		if data.RawContent != nil {
+			cm.BinaryData = map[string][]byte{key: data.RawContent}
+		} else {
+			cm.Data = map[string]string{key: data.Content}
+		}
+		maps = append(maps, &cm)
+
+		data.Content = ""
+		data.RawContent = nil
+		data.ContentRef = cm.Name
+		data.ContentRefKind = ""
+		data.ContentKey = key
+		return nil
+	}
+
+	for i := range it.Spec.Sources {
+		if err := move("source", i, &it.Spec.Sources[i].DataSpec, true); err != nil {
+			return nil, err
+		}
+	}
+	for i := range it.Spec.Resources {
+		// The OpenAPI specifications are read from the integration by the operator
+		if it.Spec.Resources[i].Type == v1.ResourceTypeOpenAPI {
+			continue
+		}
+		if err := move("resource", i, &it.Spec.Resources[i].DataSpec, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return maps, nil
+}
+
+// ownOffloadedContent makes the given Integration own the ConfigMaps its content is offloaded to,
+// so that they're deleted along with it, and deletes the ones it doesn't reference anymore
+func ownOffloadedContent(ctx context.Context, c client.Client, it *v1.Integration, maps []*corev1.ConfigMap) error {
+	used := make(map[string]bool)
+	for _, cm := range maps {
+		used[cm.Name] = true
+		cm.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKind,
+				Name:       it.Name,
+				UID:        it.UID,
+			},
+		}
+		// The ConfigMap has been created before the Integration
+		if err := c.Update(ctx, cm); err != nil {
+			return err
+		}
+	}
+
+	list := corev1.ConfigMapList{}
+	if err := c.List(ctx, &list, ctrl.InNamespace(it.Namespace), ctrl.MatchingLabels{
+		v1.IntegrationLabel:   it.Name,
+		offloadedContentLabel: "true",
+	}); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		if cm := &list.Items[i]; !used[cm.Name] {
+			if err := c.Delete(ctx, cm); ctrl.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func newCmdRunOnce(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runOnceCmdOptions) {
+	options := runOnceCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "run-once <integration>",
+		Short: "Run an integration scheduled as a CronJob immediately",
+		Long: `Run an integration scheduled as a CronJob immediately, out of its schedule. The result of the run
+is reported by the LastJobSucceeded condition of the integration.`,
+		Example: `  kamel run-once my-cron-integration`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	return &cmd, &options
+}
+
+type runOnceCmdOptions struct {
+	*RootCmdOptions
+}
+
+func (o *runOnceCmdOptions) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("run-once expects exactly one integration name argument")
+	}
+	return nil
+}
+
+func (o *runOnceCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	it := v1.NewIntegration(o.Namespace, args[0])
+	if err := c.Get(o.Context, k8sclient.ObjectKeyFromObject(&it), &it); err != nil {
+		return errors.Wrapf(err, "could not find integration %s in namespace %s", it.Name, o.Namespace)
+	}
+	if !kubernetes.IsConditionTrue(&it, v1.IntegrationConditionCronJobAvailable) {
+		return errors.Errorf("integration %s is not scheduled as a CronJob", it.Name)
+	}
+
+	if it.Annotations == nil {
+		it.Annotations = make(map[string]string)
+	}
+	it.Annotations[v1.TriggerAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+	if err := c.Update(o.Context, &it); err != nil {
+		return errors.Wrapf(err, "could not trigger integration %s", it.Name)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Integration %s triggered\n", it.Name)
+	return nil
+}
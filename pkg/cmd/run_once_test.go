@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+const cmdRunOnce = "run-once"
+
+func initializeRunOnceCmdOptions(t *testing.T) (*runOnceCmdOptions, *cobra.Command, RootCmdOptions) {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	runOnceCmdOptions := addTestRunOnceCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return runOnceCmdOptions, rootCmd, *options
+}
+
+func addTestRunOnceCmd(options RootCmdOptions, rootCmd *cobra.Command) *runOnceCmdOptions {
+	//add a testing version of run-once Command
+	runOnceCmd, runOnceOptions := newCmdRunOnce(&options)
+	runOnceCmd.Args = test.ArbitraryArgs
+	rootCmd.AddCommand(runOnceCmd)
+	return runOnceOptions
+}
+
+func TestRunOnceWithoutIntegration(t *testing.T) {
+	_, rootCmd, _ := initializeRunOnceCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdRunOnce)
+	assert.EqualError(t, err, "run-once expects exactly one integration name argument")
+}
+
+func TestRunOnce(t *testing.T) {
+	runOnceCmdOptions, rootCmd, _ := initializeRunOnceCmdOptions(t)
+
+	it := v1.NewIntegration("default", "my-cron")
+	it.Status.SetCondition(v1.IntegrationConditionCronJobAvailable, corev1.ConditionTrue, v1.IntegrationConditionCronJobAvailableReason, "")
+	c, err := test.NewFakeClient(&it)
+	assert.Nil(t, err)
+	runOnceCmdOptions._client = c
+	runOnceCmdOptions.Namespace = "default"
+
+	output, err := test.ExecuteCommand(rootCmd, cmdRunOnce, "my-cron")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "Integration my-cron triggered")
+
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(&it), &it))
+	assert.NotEmpty(t, it.Annotations[v1.TriggerAnnotation])
+}
+
+func TestRunOnceNotScheduled(t *testing.T) {
+	runOnceCmdOptions, rootCmd, _ := initializeRunOnceCmdOptions(t)
+
+	it := v1.NewIntegration("default", "my-integration")
+	c, err := test.NewFakeClient(&it)
+	assert.Nil(t, err)
+	runOnceCmdOptions._client = c
+	runOnceCmdOptions.Namespace = "default"
+
+	_, err = test.ExecuteCommand(rootCmd, cmdRunOnce, "my-integration")
+	assert.EqualError(t, err, "integration my-integration is not scheduled as a CronJob")
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/event"
+)
+
+// progressTimeline renders the progress events the operator reports for an integration as a timeline,
+// each line giving the time elapsed since the integration creation, the lifecycle step and its status
+type progressTimeline struct {
+	out   io.Writer
+	start time.Time
+	lock  sync.Mutex
+	// streaming is set once the first progress event is received, as older operators do not report them
+	streaming bool
+}
+
+func newProgressTimeline(out io.Writer, integration *v1.Integration) *progressTimeline {
+	return &progressTimeline{
+		out:   out,
+		start: integration.CreationTimestamp.Time,
+	}
+}
+
+// render renders the given event into the timeline, and returns false if it's not a progress event
+func (p *progressTimeline) render(evt *corev1.Event) bool {
+	progress, ok := event.ProgressOf(evt)
+	if !ok {
+		return false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	timestamp := eventTime(evt)
+	if p.start.IsZero() || timestamp.Before(p.start) {
+		p.start = timestamp
+	}
+	p.streaming = true
+
+	elapsed := timestamp.Sub(p.start).Round(time.Second)
+	fmt.Fprintf(p.out, "%6s  %-14s  %-10s  %s\n", elapsed, progress.Step, progress.Status, progress.Message)
+
+	return true
+}
+
+// isStreaming returns true when the operator has reported progress events for the integration
+func (p *progressTimeline) isStreaming() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.streaming
+}
+
+func eventTime(evt *corev1.Event) time.Time {
+	switch {
+	case !evt.LastTimestamp.IsZero():
+		return evt.LastTimestamp.Time
+	case !evt.EventTime.IsZero():
+		return evt.EventTime.Time
+	default:
+		return evt.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/event"
+)
+
+func newProgressEvent(step event.ProgressStep, status event.ProgressStatus, message string, timestamp time.Time) *corev1.Event {
+	evt := corev1.Event{
+		Reason:        event.ReasonIntegrationProgress,
+		Message:       message,
+		LastTimestamp: metav1.NewTime(timestamp),
+	}
+	evt.Annotations = map[string]string{
+		event.ProgressStepAnnotation:   string(step),
+		event.ProgressStatusAnnotation: string(status),
+	}
+	return &evt
+}
+
+func TestProgressTimeline(t *testing.T) {
+	created := time.Date(2021, time.October, 1, 10, 0, 0, 0, time.UTC)
+	it := v1.NewIntegration("ns", "my-integration")
+	it.CreationTimestamp = metav1.NewTime(created)
+
+	out := bytes.Buffer{}
+	timeline := newProgressTimeline(&out, &it)
+	assert.False(t, timeline.isStreaming())
+
+	assert.False(t, timeline.render(&corev1.Event{
+		Reason:  event.ReasonIntegrationPhaseUpdated,
+		Message: `Integration "my-integration" in phase "Initialization"`,
+	}))
+	assert.False(t, timeline.isStreaming())
+
+	assert.True(t, timeline.render(newProgressEvent(event.ProgressStepInitialization, event.ProgressStatusStarted,
+		`Integration "my-integration" is initializing`, created)))
+	assert.True(t, timeline.render(newProgressEvent(event.ProgressStepBuild, event.ProgressStatusInProgress,
+		`Build "my-kit" in phase "Running"`, created.Add(12*time.Second))))
+	assert.True(t, timeline.render(newProgressEvent(event.ProgressStepReady, event.ProgressStatusCompleted,
+		`Integration "my-integration" is ready`, created.Add(95*time.Second))))
+	assert.True(t, timeline.isStreaming())
+
+	assert.Equal(t, ""+
+		"    0s  Initialization  Started     Integration \"my-integration\" is initializing\n"+
+		"   12s  Build           InProgress  Build \"my-kit\" in phase \"Running\"\n"+
+		" 1m35s  Ready           Completed   Integration \"my-integration\" is ready\n",
+		out.String())
+}
@@ -19,12 +19,24 @@ package cmd
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path"
+	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/gzip"
 	"github.com/apache/camel-k/pkg/util/test"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -201,6 +213,40 @@ func TestRunOutputFlag(t *testing.T) {
 	assert.Equal(t, "yaml", runCmdOptions.OutputFormat)
 }
 
+func TestRunOutputWithWait(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	source := path.Join(dir, "routes.groovy")
+	assert.Nil(t, ioutil.WriteFile(source, []byte(`from("timer:tick").log("hello")`), 0o600))
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	runCmdOptions._client = c
+	runCmdOptions.Context = context.Background()
+	runCmdOptions.Namespace = "default"
+	runCmdOptions.OutputFormat = "yaml"
+	runCmdOptions.Wait = true
+
+	// The integration is only printed, so there is nothing to wait for
+	assert.Nil(t, runCmdOptions.run(rootCmd, []string{source}))
+}
+
+func TestRunServerDryRunFlag(t *testing.T) {
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdRun, "--server-dry-run", "-o", "json", integrationSource)
+	assert.Nil(t, err)
+	assert.True(t, runCmdOptions.ServerDryRun)
+	assert.Equal(t, "json", runCmdOptions.OutputFormat)
+}
+
+func TestRunServerDryRunWithDevFlag(t *testing.T) {
+	_, rootCmd, _ := initializeRunCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdRun, "--server-dry-run", "--dev", integrationSource)
+	assert.EqualError(t, err, "--server-dry-run cannot be used with --dev, --sync, --logs or --wait")
+}
+
 func TestRunProfileFlag(t *testing.T) {
 	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
 	_, err := test.ExecuteCommand(rootCmd, cmdRun, "--profile", "myProfile", integrationSource)
@@ -559,6 +605,33 @@ func TestResolveJsonPodTemplate(t *testing.T) {
 	assert.Equal(t, 2, len(integrationSpec.PodTemplate.Spec.Containers))
 }
 
+func TestResolveDependencyLock(t *testing.T) {
+	lockFile, err := ioutil.TempFile("", "camel-k-*.yaml")
+	assert.Nil(t, err)
+	defer os.Remove(lockFile.Name())
+	assert.Nil(t, lockFile.Close())
+	assert.Nil(t, ioutil.WriteFile(lockFile.Name(), []byte(`
+runtimeVersion: 1.9.0
+dependencies:
+- mvn:org.apache.camel:camel-log:3.11.1
+- mvn:org.apache.camel:camel-timer:3.11.1
+`), 0644))
+
+	integrationSpec := v1.IntegrationSpec{}
+	err = resolveDependencyLock(lockFile.Name(), &integrationSpec)
+	assert.Nil(t, err)
+	assert.Equal(t, &v1.DependencyLock{
+		RuntimeVersion: "1.9.0",
+		Dependencies: []string{
+			"mvn:org.apache.camel:camel-log:3.11.1",
+			"mvn:org.apache.camel:camel-timer:3.11.1",
+		},
+	}, integrationSpec.DependencyLock)
+
+	err = resolveDependencyLock("/tmp/not-found.yaml", &integrationSpec)
+	assert.NotNil(t, err)
+}
+
 func TestFilterBuildPropertyFiles(t *testing.T) {
 	inputValues := []string{"file:/tmp/test", "key=val"}
 	outputValues := filterBuildPropertyFiles(inputValues)
@@ -566,3 +639,240 @@ func TestFilterBuildPropertyFiles(t *testing.T) {
 	assert.Equal(t, len(outputValues), 1)
 	assert.Equal(t, outputValues[0], "/tmp/test")
 }
+
+func TestRunJBangProject(t *testing.T) {
+	dir := path.Join(t.TempDir(), "my-project")
+	assert.Nil(t, os.Mkdir(dir, 0o700))
+
+	files := map[string]string{
+		"routes.yaml":            "- from:\n    uri: timer:tick\n    steps:\n      - to: log:info\n",
+		"Greeter.java":           "public class Greeter {}",
+		"my.kamelet.yaml":        "kind: Kamelet",
+		"README.md":              "# My project",
+		"application.properties": "greeting=hello\ncamel.jbang.dependencies=camel-kafka,org.acme:foo:1.0\n",
+	}
+	for name, content := range files {
+		assert.Nil(t, ioutil.WriteFile(path.Join(dir, name), []byte(content), 0o600))
+	}
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	runCmdOptions.Context = context.Background()
+	runCmdOptions.Namespace = "default"
+	runCmdOptions.Properties = []string{"greeting=hi"}
+	runCmdOptions.UseFlows = true
+
+	assert.Nil(t, runCmdOptions.validateArgs(rootCmd, []string{dir}))
+	assert.Equal(t, "my-project", runCmdOptions.GetIntegrationName([]string{dir}))
+
+	it, err := runCmdOptions.createOrUpdateIntegration(rootCmd, c, []string{dir}, trait.NewCatalog(c))
+	assert.Nil(t, err)
+	assert.Len(t, it.Spec.Sources, 1)
+	assert.Equal(t, "Greeter.java", it.Spec.Sources[0].Name)
+	assert.Len(t, it.Spec.Flows, 1)
+	assert.Equal(t, []string{"camel:kafka", "mvn:org.acme:foo:1.0"}, it.Spec.Dependencies)
+	camelTrait, err := json.Marshal(it.Spec.Traits["camel"].Configuration)
+	assert.Nil(t, err)
+	assert.Contains(t, string(camelTrait), `"greeting = hi"`)
+	assert.Contains(t, string(camelTrait), `"camel.jbang.dependencies = camel-kafka,org.acme:foo:1.0"`)
+
+	// The options are left untouched, as the integration is updated on each change in dev mode
+	assert.Empty(t, runCmdOptions.Dependencies)
+	assert.Equal(t, []string{"greeting=hi"}, runCmdOptions.Properties)
+	assert.Empty(t, runCmdOptions.Traits)
+	c, err = test.NewFakeClient()
+	assert.Nil(t, err)
+	it, err = runCmdOptions.createOrUpdateIntegration(rootCmd, c, []string{dir}, trait.NewCatalog(c))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"camel:kafka", "mvn:org.acme:foo:1.0"}, it.Spec.Dependencies)
+	assert.Len(t, it.Spec.Traits["camel"].Configuration.RawMessage, len(camelTrait))
+}
+
+func TestRunFromTemplateFlag(t *testing.T) {
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdRun,
+		"--from-template", "greeter",
+		"--template-param", "message=hello",
+		"--template-param", "period=1000")
+	assert.Nil(t, err)
+	assert.Equal(t, "greeter", runCmdOptions.FromTemplate)
+	assert.Equal(t, []string{"message=hello", "period=1000"}, runCmdOptions.TemplateParams)
+}
+
+func TestRunFromTemplate(t *testing.T) {
+	template := v1.NewIntegrationTemplate("default", "greeter")
+	template.Spec.Parameters = []v1.IntegrationTemplateParameter{{Name: "message"}}
+	template.Spec.Integration.Sources = []v1.SourceSpec{
+		v1.NewSourceSpec("greeter.yaml", `- from: "timer:tick?message=$(params.message)"`, v1.LanguageYaml),
+	}
+	template.Spec.Integration.Traits = map[string]v1.TraitSpec{
+		"container": {Configuration: v1.TraitConfiguration{RawMessage: []byte(`{"name":"greeter","probesEnabled":true}`)}},
+	}
+
+	c, err := test.NewFakeClient(&template)
+	assert.Nil(t, err)
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	runCmdOptions.Context = context.Background()
+	runCmdOptions.Namespace = "default"
+	runCmdOptions.FromTemplate = "greeter"
+	runCmdOptions.TemplateParams = []string{"message=hello"}
+	runCmdOptions.Traits = []string{"container.probes-enabled=false"}
+
+	runCmd, _, err := rootCmd.Find([]string{cmdRun})
+	assert.Nil(t, err)
+	assert.NotNil(t, runCmdOptions.validateArgs(runCmd, []string{}))
+	assert.Nil(t, runCmd.Flags().Set("from-template", "greeter"))
+	assert.Nil(t, runCmdOptions.validateArgs(runCmd, []string{}))
+
+	it, err := runCmdOptions.createOrUpdateIntegration(rootCmd, c, []string{}, trait.NewCatalog(c))
+	assert.Nil(t, err)
+	assert.Equal(t, "greeter", it.Name)
+	assert.Equal(t, "greeter", it.Labels[v1.IntegrationTemplateLabel])
+	assert.Equal(t, `{"message":"hello"}`, it.Annotations[v1.IntegrationTemplateParametersAnnotation])
+	assert.Equal(t, `- from: "timer:tick?message=hello"`, it.Spec.Sources[0].Content)
+	assertTraitConfiguration(t, it.Spec.Traits, "container", `{"name":"greeter","probesEnabled":false}`)
+
+	// The spec rendered from the template is stored in a ConfigMap owned by the integration
+	spec := corev1.ConfigMap{}
+	assert.Nil(t, c.Get(context.Background(), ctrl.ObjectKey{Namespace: "default", Name: "greeter-template-spec"}, &spec))
+	assert.Equal(t, it.Annotations[v1.IntegrationTemplateSpecDigestAnnotation],
+		v1.IntegrationTemplateSpecDigest([]byte(spec.Data[v1.IntegrationTemplateSpecKey])))
+	assert.Contains(t, spec.Data[v1.IntegrationTemplateSpecKey], `"probesEnabled":true`)
+	assert.Equal(t, v1.IntegrationKind, spec.OwnerReferences[0].Kind)
+
+	runCmdOptions.TemplateParams = []string{"unknown=value"}
+	_, err = runCmdOptions.createOrUpdateIntegration(rootCmd, c, []string{}, trait.NewCatalog(c))
+	assert.EqualError(t, err, "integration template greeter has no parameter unknown")
+}
+
+func TestRunOffloadsLargeSources(t *testing.T) {
+	dir := t.TempDir()
+	random := make([]byte, 4096)
+	_, err := rand.Read(random)
+	assert.Nil(t, err)
+	large := path.Join(dir, "large.groovy")
+	assert.Nil(t, ioutil.WriteFile(large, []byte("// "+hex.EncodeToString(random)+"\nfrom('timer:tick').to('log:info')\n"), 0o600))
+	compressible := path.Join(dir, "compressible.groovy")
+	assert.Nil(t, ioutil.WriteFile(compressible, []byte(strings.Repeat("// padding\n", 500)+"from('timer:tock').to('log:info')\n"), 0o600))
+	small := path.Join(dir, "small.groovy")
+	assert.Nil(t, ioutil.WriteFile(small, []byte("from('timer:tick').to('log:info')\n"), 0o600))
+
+	// The content offloaded by a former run that is not referenced anymore
+	stale := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-integration-offloaded-source-003",
+			Labels: map[string]string{
+				v1.IntegrationLabel:   "my-integration",
+				offloadedContentLabel: "true",
+			},
+		},
+	}
+	c, err := test.NewFakeClient(&stale)
+	assert.Nil(t, err)
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	runCmdOptions.Context = context.Background()
+	runCmdOptions.Namespace = "default"
+	runCmdOptions.IntegrationName = "my-integration"
+	runCmdOptions.MaxInlineSize = "1Ki"
+
+	it, err := runCmdOptions.createOrUpdateIntegration(rootCmd, c, []string{large, compressible, small}, trait.NewCatalog(c))
+	assert.Nil(t, err)
+	assert.Len(t, it.Spec.Sources, 3)
+
+	assert.Equal(t, "my-integration-offloaded-source-000", it.Spec.Sources[0].ContentRef)
+	assert.Equal(t, v1.DefaultContentKey, it.Spec.Sources[0].ContentKey)
+	assert.Empty(t, it.Spec.Sources[0].Content)
+	assert.True(t, it.Spec.Sources[0].Compression)
+
+	assert.Empty(t, it.Spec.Sources[1].ContentRef)
+	assert.True(t, it.Spec.Sources[1].Compression)
+	assert.Less(t, len(it.Spec.Sources[1].Content), 1024)
+
+	assert.Empty(t, it.Spec.Sources[2].ContentRef)
+	assert.False(t, it.Spec.Sources[2].Compression)
+
+	cm := corev1.ConfigMap{}
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "default", Name: "my-integration-offloaded-source-000"}, &cm))
+	assert.Equal(t, "my-integration", cm.Labels[v1.IntegrationLabel])
+	assert.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, v1.IntegrationKind, cm.OwnerReferences[0].Kind)
+	content, err := gzip.UncompressBase64([]byte(cm.Data[v1.DefaultContentKey]))
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "from('timer:tick')")
+
+	err = c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&stale), &cm)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestRunTests(t *testing.T) {
+	dir := t.TempDir()
+	test1 := path.Join(dir, "RoutesTest.java")
+	assert.Nil(t, ioutil.WriteFile(test1, []byte("public class RoutesTest {}"), 0o600))
+	test2 := path.Join(dir, "BeansTest.groovy")
+	assert.Nil(t, ioutil.WriteFile(test2, []byte("class BeansTest {}"), 0o600))
+	source := path.Join(dir, "routes.groovy")
+	assert.Nil(t, ioutil.WriteFile(source, []byte("from('timer:tick').to('log:info')\n"), 0o600))
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	runCmdOptions.Context = context.Background()
+	runCmdOptions.Namespace = "default"
+	runCmdOptions.IntegrationName = "my-integration"
+	runCmdOptions.Tests = []string{test1, test2, "configmap:more-tests/ProcessorTest.java"}
+
+	it, err := runCmdOptions.createOrUpdateIntegration(rootCmd, c, []string{source}, trait.NewCatalog(c))
+	assert.Nil(t, err)
+	assertTraitConfiguration(t, it.Spec.Traits, "builder", `{"testConfigMaps":["my-integration-tests","more-tests/ProcessorTest.java"]}`)
+
+	cm := corev1.ConfigMap{}
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "default", Name: "my-integration-tests"}, &cm))
+	assert.Equal(t, map[string]string{
+		"RoutesTest.java":  "public class RoutesTest {}",
+		"BeansTest.groovy": "class BeansTest {}",
+	}, cm.Data)
+	assert.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, v1.IntegrationKind, cm.OwnerReferences[0].Kind)
+
+	runCmdOptions.Tests = []string{path.Join(dir, "routes.yaml")}
+	_, err = runCmdOptions.createOrUpdateIntegration(rootCmd, c, []string{source}, trait.NewCatalog(c))
+	assert.EqualError(t, err, "unsupported test "+path.Join(dir, "routes.yaml")+": only Java and Groovy tests can be run")
+}
+
+func TestRunCompressesLargeSourcesWithoutOffload(t *testing.T) {
+	random := make([]byte, 4096)
+	_, err := rand.Read(random)
+	assert.Nil(t, err)
+	it := v1.NewIntegration("default", "my-integration")
+	it.Spec.AddSources(v1.NewSourceSpec("large.groovy", hex.EncodeToString(random), v1.LanguageGroovy))
+	it.Spec.AddResources(v1.ResourceSpec{
+		DataSpec: v1.DataSpec{Name: "data.txt", ContentKey: "data.txt", Content: hex.EncodeToString(random)},
+		Type:     v1.ResourceTypeData,
+	})
+
+	maps, err := inlineContent(&it, 1024, false)
+	assert.Nil(t, err)
+	assert.Empty(t, maps)
+	assert.True(t, it.Spec.Sources[0].Compression)
+	assert.Empty(t, it.Spec.Sources[0].ContentRef)
+	assert.False(t, it.Spec.Resources[0].Compression)
+
+	maps, err = inlineContent(&it, 1024, true)
+	assert.Nil(t, err)
+	assert.Len(t, maps, 2)
+	assert.Equal(t, "my-integration-offloaded-resource-000", it.Spec.Resources[0].ContentRef)
+	assert.Equal(t, "data.txt", it.Spec.Resources[0].ContentKey)
+	assert.Equal(t, hex.EncodeToString(random), maps[1].Data["data.txt"])
+}
+
+func TestRunMaxInlineSizeFlag(t *testing.T) {
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdRun, integrationSource, "--max-inline-size", "1Mi")
+	assert.Nil(t, err)
+	assert.Equal(t, "1Mi", runCmdOptions.MaxInlineSize)
+
+	_, err = test.ExecuteCommand(rootCmd, cmdRun, integrationSource, "--max-inline-size", "big")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/apache/camel-k/pkg/trait"
+)
+
+func newCmdTrait(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "trait",
+		Short: "Introspect the trait catalog",
+		Long: `List and describe the traits of the catalog, along with their properties, types, defaults and profiles,
+in a machine-readable format, e.g., to complete and validate the trait configuration in IDEs and UIs.`,
+	}
+
+	cmd.AddCommand(cmdOnly(newTraitListCmd(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newTraitDescribeCmd(rootCmdOptions)))
+
+	return &cmd
+}
+
+// traitDescriptions returns the descriptions of the traits with the given IDs, or of all the traits, marked
+// with the restrictions of the platform of the current namespace when requested
+func traitDescriptions(command *RootCmdOptions, restrictions bool, ids ...trait.ID) ([]trait.Description, error) {
+	descriptions, err := trait.NewCatalog(nil).Describe(ids...)
+	if err != nil {
+		return nil, err
+	}
+	if restrictions {
+		c, err := command.GetCmdClient()
+		if err != nil {
+			return nil, err
+		}
+		if err := trait.RestrictDescriptions(command.Context, c, command.Namespace, descriptions); err != nil {
+			return nil, err
+		}
+	}
+	return descriptions, nil
+}
@@ -0,0 +1,138 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/indentedwriter"
+)
+
+func newTraitDescribeCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *traitDescribeCommandOptions) {
+	options := traitDescribeCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "describe <trait>...",
+		Short: "Describe traits of the catalog",
+		Long: `Describe the given traits of the catalog, along with their properties, types and defaults.
+When --restrictions is enabled, the traits and properties restricted by the platform of the current namespace
+are marked as such.`,
+		Example: `  kamel trait describe container
+  kamel trait describe container prometheus -o json`,
+		Args:              cobra.MinimumNArgs(1),
+		PersistentPreRunE: decode(&options),
+		PreRunE:           options.preRunE,
+		RunE:              options.run,
+		Annotations:       make(map[string]string),
+	}
+
+	cmd.Flags().Bool("restrictions", false, "Mark the traits and properties restricted by the platform of the current namespace")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
+
+	return &cmd, &options
+}
+
+type traitDescribeCommandOptions struct {
+	*RootCmdOptions
+	Restrictions bool   `mapstructure:"restrictions"`
+	OutputFormat string `mapstructure:"output"`
+}
+
+func (command *traitDescribeCommandOptions) preRunE(cmd *cobra.Command, args []string) error {
+	if !command.Restrictions {
+		cmd.Annotations[offlineCommandLabel] = "true"
+	}
+	return command.RootCmdOptions.preRun(cmd, args)
+}
+
+func (command *traitDescribeCommandOptions) validate() error {
+	if command.OutputFormat != "" && command.OutputFormat != "json" && command.OutputFormat != "yaml" {
+		return errors.New("unknown output format: " + command.OutputFormat)
+	}
+	return nil
+}
+
+func (command *traitDescribeCommandOptions) run(cmd *cobra.Command, args []string) error {
+	if err := command.validate(); err != nil {
+		return err
+	}
+
+	ids := make([]trait.ID, 0, len(args))
+	for _, arg := range args {
+		ids = append(ids, trait.ID(arg))
+	}
+	descriptions, err := traitDescriptions(command.RootCmdOptions, command.Restrictions, ids...)
+	if err != nil {
+		return err
+	}
+
+	if command.OutputFormat != "" {
+		return printObject(cmd.OutOrStdout(), command.OutputFormat, descriptions)
+	}
+
+	res, err := outputTraitDescriptions(descriptions)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), res)
+	return nil
+}
+
+func outputTraitDescriptions(descriptions []trait.Description) (string, error) {
+	return indentedwriter.IndentedString(func(out io.Writer) error {
+		w := indentedwriter.NewWriter(out)
+
+		for _, d := range descriptions {
+			w.Write(0, "Name:\t%s\n", d.Name)
+			w.Write(0, "Profiles:\t%s\n", strings.Join(d.Profiles, ","))
+			w.Write(0, "Platform:\t%t\n", d.Platform)
+			if d.Restricted {
+				w.Write(0, "Restricted:\t%t\n", d.Restricted)
+			}
+			w.Write(0, "Description:\n")
+			for _, line := range strings.Split(strings.TrimSpace(d.Description), "\n") {
+				w.Write(1, "%s\n", line)
+			}
+			w.Write(0, "Properties:\n")
+			for _, p := range d.Properties {
+				w.Write(1, "%s:\n", p.Name)
+				w.Write(2, "Type:\t%s\n", p.TypeName)
+				if p.DefaultValue != nil {
+					w.Write(2, "Default Value:\t%v\n", p.DefaultValue)
+				}
+				if p.Restricted {
+					w.Write(2, "Restricted:\t%t\n", p.Restricted)
+				}
+				if p.Description != "" {
+					w.Write(2, "Description:\t%s\n", p.Description)
+				}
+			}
+			w.Writeln(0, "")
+		}
+
+		return nil
+	})
+}
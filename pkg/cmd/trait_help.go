@@ -22,15 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"reflect"
 	"strings"
 
-	"github.com/fatih/structs"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 
-	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
-	"github.com/apache/camel-k/pkg/resources"
 	"github.com/apache/camel-k/pkg/trait"
 	"github.com/apache/camel-k/pkg/util/indentedwriter"
 )
@@ -68,25 +64,6 @@ type traitHelpCommandOptions struct {
 	OutputFormat string `mapstructure:"output"`
 }
 
-type traitDescription struct {
-	Name        trait.ID                   `json:"name" yaml:"name"`
-	Platform    bool                       `json:"platform" yaml:"platform"`
-	Profiles    []string                   `json:"profiles" yaml:"profiles"`
-	Properties  []traitPropertyDescription `json:"properties" yaml:"properties"`
-	Description string                     `json:"description" yaml:"description"`
-}
-
-type traitPropertyDescription struct {
-	Name         string      `json:"name" yaml:"name"`
-	TypeName     string      `json:"type" yaml:"type"`
-	DefaultValue interface{} `json:"defaultValue,omitempty" yaml:"defaultValue,omitempty"`
-	Description  string      `json:"description" yaml:"description"`
-}
-
-type traitMetaData struct {
-	Traits []traitDescription `yaml:"traits"`
-}
-
 func (command *traitHelpCommandOptions) validate(args []string) error {
 	if command.IncludeAll && len(args) > 0 {
 		return errors.New("invalid combination: both all flag and a named trait is set")
@@ -98,65 +75,31 @@ func (command *traitHelpCommandOptions) validate(args []string) error {
 }
 
 func (command *traitHelpCommandOptions) run(cmd *cobra.Command, args []string) error {
-	var traitDescriptions []*traitDescription
-	var catalog = trait.NewCatalog(nil)
+	ids := make([]trait.ID, 0, len(args))
+	for _, arg := range args {
+		ids = append(ids, trait.ID(arg))
+	}
 
-	var traitMetaData = &traitMetaData{}
-	err := yaml.Unmarshal(resources.Resource("/traits.yaml"), traitMetaData)
+	descriptions, err := trait.NewCatalog(nil).Describe(ids...)
 	if err != nil {
 		return err
 	}
 
-	for _, tp := range v1.AllTraitProfiles {
-		traits := catalog.TraitsForProfile(tp)
-		for _, t := range traits {
-			if len(args) == 1 && trait.ID(args[0]) != t.ID() {
-				continue
-			}
-
-			td := findTraitDescription(t.ID(), traitDescriptions)
-			if td == nil {
-				td = &traitDescription{
-					Name:     t.ID(),
-					Platform: t.IsPlatformTrait(),
-					Profiles: make([]string, 0),
-				}
-
-				var targetTrait *traitDescription
-				for _, item := range traitMetaData.Traits {
-					item := item
-					if item.Name == t.ID() {
-						targetTrait = &item
-						td.Description = item.Description
-						break
-					}
-				}
-				computeTraitProperties(structs.Fields(t), &td.Properties, targetTrait)
-				traitDescriptions = append(traitDescriptions, td)
-			}
-			td.addProfile(string(tp))
-		}
-	}
-
-	if len(args) == 1 && len(traitDescriptions) == 0 {
-		return fmt.Errorf("no trait named '%s' exists", args[0])
-	}
-
 	switch strings.ToUpper(command.OutputFormat) {
 	case "JSON":
-		res, err := json.Marshal(traitDescriptions)
+		res, err := json.Marshal(descriptions)
 		if err != nil {
 			return err
 		}
 		fmt.Fprintln(cmd.OutOrStdout(), string(res))
 	case "YAML":
-		res, err := yaml.Marshal(traitDescriptions)
+		res, err := yaml.Marshal(descriptions)
 		if err != nil {
 			return err
 		}
 		fmt.Fprintln(cmd.OutOrStdout(), string(res))
 	default:
-		res, err := outputTraits(traitDescriptions)
+		res, err := outputTraits(descriptions)
 		if err != nil {
 			return err
 		}
@@ -166,75 +109,7 @@ func (command *traitHelpCommandOptions) run(cmd *cobra.Command, args []string) e
 	return nil
 }
 
-func (td *traitDescription) addProfile(tp string) {
-	for _, p := range td.Profiles {
-		if p == tp {
-			return
-		}
-	}
-	td.Profiles = append(td.Profiles, tp)
-}
-
-func findTraitDescription(id trait.ID, traitDescriptions []*traitDescription) *traitDescription {
-	for _, td := range traitDescriptions {
-		if td.Name == id {
-			return td
-		}
-	}
-	return nil
-}
-
-func computeTraitProperties(fields []*structs.Field, properties *[]traitPropertyDescription, targetTrait *traitDescription) {
-	for _, f := range fields {
-		if f.IsEmbedded() && f.IsExported() && f.Kind() == reflect.Struct {
-			computeTraitProperties(f.Fields(), properties, targetTrait)
-		}
-
-		if !f.IsExported() || f.IsEmbedded() {
-			continue
-		}
-
-		property := f.Tag("property")
-		if property == "" {
-			continue
-		}
-
-		tp := traitPropertyDescription{}
-		tp.Name = property
-
-		switch f.Kind() {
-		case reflect.Ptr:
-			tp.TypeName = reflect.TypeOf(f.Value()).Elem().String()
-		case reflect.Slice:
-			tp.TypeName = fmt.Sprintf("slice:%s", reflect.TypeOf(f.Value()).Elem().String())
-		default:
-			tp.TypeName = f.Kind().String()
-		}
-
-		if f.IsZero() {
-			if tp.TypeName == "bool" {
-				tp.DefaultValue = false
-			} else {
-				tp.DefaultValue = nil
-			}
-		} else {
-			tp.DefaultValue = f.Value()
-		}
-
-		// apply the description from metadata
-		if targetTrait != nil {
-			for _, item := range targetTrait.Properties {
-				if item.Name == tp.Name {
-					tp.Description = item.Description
-				}
-			}
-		}
-
-		*properties = append(*properties, tp)
-	}
-}
-
-func outputTraits(descriptions []*traitDescription) (string, error) {
+func outputTraits(descriptions []trait.Description) (string, error) {
 	return indentedwriter.IndentedString(func(out io.Writer) error {
 		w := indentedwriter.NewWriter(out)
 
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+)
+
+func newTraitListCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *traitListCommandOptions) {
+	options := traitListCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "list",
+		Short: "List the traits of the catalog",
+		Long: `List the traits of the catalog, along with the profiles they apply to.
+When --restrictions is enabled, the traits restricted by the platform of the current namespace are marked as such.`,
+		Example: `  kamel trait list
+  kamel trait list --profile knative -o json`,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: decode(&options),
+		PreRunE:           options.preRunE,
+		RunE:              options.run,
+		Annotations:       make(map[string]string),
+	}
+
+	cmd.Flags().String("profile", "", "Only list the traits that apply to the given profile")
+	cmd.Flags().Bool("restrictions", false, "Mark the traits restricted by the platform of the current namespace")
+	cmd.Flags().StringP("output", "o", "table", "Output format. One of: table|json|yaml")
+
+	return &cmd, &options
+}
+
+type traitListCommandOptions struct {
+	*RootCmdOptions
+	Profile      string `mapstructure:"profile"`
+	Restrictions bool   `mapstructure:"restrictions"`
+	OutputFormat string `mapstructure:"output"`
+}
+
+func (command *traitListCommandOptions) preRunE(cmd *cobra.Command, args []string) error {
+	if !command.Restrictions {
+		cmd.Annotations[offlineCommandLabel] = "true"
+	}
+	return command.RootCmdOptions.preRun(cmd, args)
+}
+
+func (command *traitListCommandOptions) validate() error {
+	if command.OutputFormat != "table" && command.OutputFormat != "json" && command.OutputFormat != "yaml" {
+		return errors.New("unknown output format: " + command.OutputFormat)
+	}
+	if command.Profile != "" && v1.TraitProfileByName(command.Profile) == "" {
+		return errors.New("unknown profile: " + command.Profile)
+	}
+	return nil
+}
+
+func (command *traitListCommandOptions) run(cmd *cobra.Command, _ []string) error {
+	if err := command.validate(); err != nil {
+		return err
+	}
+
+	all, err := traitDescriptions(command.RootCmdOptions, command.Restrictions)
+	if err != nil {
+		return err
+	}
+	descriptions := make([]trait.Description, 0, len(all))
+	for _, d := range all {
+		if command.Profile == "" || d.HasProfile(v1.TraitProfileByName(command.Profile)) {
+			descriptions = append(descriptions, d)
+		}
+	}
+
+	if command.OutputFormat != "table" {
+		return printObject(cmd.OutOrStdout(), command.OutputFormat, descriptions)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "NAME\tPLATFORM\tPROFILES\tPROPERTIES\tRESTRICTED")
+	for _, d := range descriptions {
+		fmt.Fprintf(w, "%s\t%t\t%s\t%d\t%t\n", d.Name, d.Platform, strings.Join(d.Profiles, ","), len(d.Properties),
+			d.Restricted)
+	}
+	return w.Flush()
+}
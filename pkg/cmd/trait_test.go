@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func initializeTraitCmd(t *testing.T, objects ...runtime.Object) *cobra.Command {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	c, err := test.NewFakeClient(objects...)
+	require.NoError(t, err)
+	options._client = c
+	options.Namespace = "ns"
+	rootCmd.AddCommand(newCmdTrait(options))
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return rootCmd
+}
+
+func TestTraitList(t *testing.T) {
+	output, err := test.ExecuteCommand(initializeTraitCmd(t), "trait", "list", "--profile", "knative", "-o", "json")
+	require.NoError(t, err)
+
+	var descriptions []trait.Description
+	require.NoError(t, json.Unmarshal([]byte(output), &descriptions))
+	names := make([]trait.ID, 0, len(descriptions))
+	for _, d := range descriptions {
+		assert.Contains(t, d.Profiles, string(v1.TraitProfileKnative))
+		names = append(names, d.Name)
+	}
+	assert.Contains(t, names, trait.ID("knative-service"))
+	assert.NotContains(t, names, trait.ID("route"))
+
+	_, err = test.ExecuteCommand(initializeTraitCmd(t), "trait", "list", "--profile", "unknown")
+	assert.EqualError(t, err, "unknown profile: unknown")
+
+	_, err = test.ExecuteCommand(initializeTraitCmd(t), "trait", "list", "-o", "wide")
+	assert.EqualError(t, err, "unknown output format: wide")
+}
+
+func TestTraitDescribe(t *testing.T) {
+	output, err := test.ExecuteCommand(initializeTraitCmd(t), "trait", "describe", "container")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Name:")
+	assert.Contains(t, output, "image:")
+	assert.NotContains(t, output, "Restricted:")
+
+	_, err = test.ExecuteCommand(initializeTraitCmd(t), "trait", "describe", "foobar")
+	assert.EqualError(t, err, "no trait named 'foobar' exists")
+}
+
+func TestTraitDescribeWithRestrictions(t *testing.T) {
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Policy.RestrictedTraits = []string{"istio", "container.image"}
+
+	output, err := test.ExecuteCommand(initializeTraitCmd(t, &pl), "trait", "describe", "container", "istio",
+		"--restrictions", "-o", "json")
+	require.NoError(t, err)
+
+	var descriptions []trait.Description
+	require.NoError(t, json.Unmarshal([]byte(output), &descriptions))
+	require.Len(t, descriptions, 2)
+	for _, d := range descriptions {
+		switch d.Name {
+		case "container":
+			assert.False(t, d.Restricted)
+			for _, p := range d.Properties {
+				assert.Equal(t, p.Name == "image", p.Restricted, p.Name)
+			}
+		case "istio":
+			assert.True(t, d.Restricted)
+			for _, p := range d.Properties {
+				assert.True(t, p.Restricted, p.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/apache/camel-k/pkg/upgrade"
+)
+
+func newCmdUpgrade(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "upgrade",
+		Short: "Inspect the upgrade of the integrations built by a former version of the operator",
+		Long:  `Inspect the upgrade of the integrations and kits built by a former version of the operator.`,
+	}
+
+	cmd.AddCommand(cmdOnly(newUpgradeReportCmd(rootCmdOptions)))
+
+	return &cmd
+}
+
+func newUpgradeReportCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *upgradeReportCmdOptions) {
+	options := upgradeReportCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "report",
+		Short: "Report the upgrade plan of the integrations and kits",
+		Long: `Report the upgrade plan of the integrations and kits built by a former version of the operator.
+Each integration is either upgraded automatically, when it is compatible with the catalog of the current
+version and opted in with the camel.auto-upgrade trait property or the upgrade.autoUpgrade platform setting,
+held until it is rebuilt explicitly, or blocked when it depends on artifacts the current catalog no longer provides.`,
+		Example: `  kamel upgrade report
+  kamel upgrade report --all-namespaces -o yaml`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd)
+		},
+	}
+
+	cmd.Flags().BoolP("all-namespaces", "A", false, "Report the integrations and kits of all the namespaces")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
+
+	return &cmd, &options
+}
+
+type upgradeReportCmdOptions struct {
+	*RootCmdOptions
+	AllNamespaces bool   `mapstructure:"all-namespaces"`
+	OutputFormat  string `mapstructure:"output"`
+}
+
+func (o *upgradeReportCmdOptions) validate() error {
+	if o.OutputFormat != "" && o.OutputFormat != "yaml" && o.OutputFormat != "json" {
+		return errors.New("unknown output format: " + o.OutputFormat)
+	}
+	return nil
+}
+
+func (o *upgradeReportCmdOptions) run(cmd *cobra.Command) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = ""
+	}
+	plan, err := upgrade.ComputePlan(o.Context, c, namespace)
+	if err != nil {
+		return err
+	}
+
+	if o.OutputFormat != "" {
+		return printObject(cmd.OutOrStdout(), o.OutputFormat, plan)
+	}
+	printUpgradePlan(cmd.OutOrStdout(), plan)
+
+	return nil
+}
+
+func printUpgradePlan(out io.Writer, plan *upgrade.Plan) {
+	if len(plan.Items) == 0 {
+		fmt.Fprintln(out, "All integrations and kits are up to date")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tVERSION\tRUNTIME\tACTION\tMESSAGE")
+	for _, item := range plan.Items {
+		version := item.FromVersion
+		if item.ToVersion != "" {
+			version += " -> " + item.ToVersion
+		}
+		runtime := item.FromRuntimeVersion
+		if item.ToRuntimeVersion != "" && item.ToRuntimeVersion != item.FromRuntimeVersion {
+			runtime += " -> " + item.ToRuntimeVersion
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			item.Kind, item.Namespace, item.Name, version, runtime, item.Action, item.Message)
+	}
+	w.Flush()
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+const cmdUpgrade = "upgrade"
+
+func initializeUpgradeReportCmdOptions(t *testing.T) (*upgradeReportCmdOptions, *cobra.Command, RootCmdOptions) {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	upgradeReportCmdOptions := addTestUpgradeReportCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return upgradeReportCmdOptions, rootCmd, *options
+}
+
+func addTestUpgradeReportCmd(options RootCmdOptions, rootCmd *cobra.Command) *upgradeReportCmdOptions {
+	//add a testing version of upgrade report Command
+	upgradeCmd := cobra.Command{Use: cmdUpgrade}
+	reportCmd, reportOptions := newUpgradeReportCmd(&options)
+	reportCmd.Args = test.ArbitraryArgs
+	upgradeCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(&upgradeCmd)
+	return reportOptions
+}
+
+func TestUpgradeReportNonExistingFlag(t *testing.T) {
+	_, rootCmd, _ := initializeUpgradeReportCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdUpgrade, "report", "--nonExistingFlag")
+	assert.NotNil(t, err)
+}
+
+func TestUpgradeReportUnknownOutputFormat(t *testing.T) {
+	_, rootCmd, _ := initializeUpgradeReportCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdUpgrade, "report", "-o", "wide")
+	assert.EqualError(t, err, "unknown output format: wide")
+}
+
+func TestUpgradeReport(t *testing.T) {
+	upgradeReportCmdOptions, rootCmd, _ := initializeUpgradeReportCmdOptions(t)
+
+	pl := v1.NewIntegrationPlatform("default", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Version = "1.8.0"
+	pl.Status.Build.RuntimeVersion = "0.0.1"
+	integration := v1.NewIntegration("default", "my-integration")
+	integration.Status.Version = "1.7.0"
+	integration.Status.RuntimeVersion = "1.5.0"
+	c, err := test.NewFakeClient(&pl, &integration)
+	assert.Nil(t, err)
+	upgradeReportCmdOptions._client = c
+
+	output, err := test.ExecuteCommand(rootCmd, cmdUpgrade, "report", "-n", "default")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "ACTION")
+	assert.Regexp(t, `Integration\s+default\s+my-integration\s+1.7.0 -> 1.8.0\s+1.5.0 -> 0.0.1\s+Blocked`, output)
+}
+
+func TestUpgradeReportUpToDate(t *testing.T) {
+	upgradeReportCmdOptions, rootCmd, _ := initializeUpgradeReportCmdOptions(t)
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+	upgradeReportCmdOptions._client = c
+
+	output, err := test.ExecuteCommand(rootCmd, cmdUpgrade, "report", "-n", "default")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "All integrations and kits are up to date")
+}
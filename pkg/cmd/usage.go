@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/apache/camel-k/pkg/usage"
+)
+
+func newCmdUsage(rootCmdOptions *RootCmdOptions) (*cobra.Command, *usageCmdOptions) {
+	options := usageCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "usage [integration...]",
+		Short: "Summarize the resources consumed by the integrations, for chargeback",
+		Long: `Summarize the resources consumed by the integrations of the current namespace, i.e., the CPU and memory
+requested by their pods, and the minutes spent building their kits, along with their cost-allocation labels,
+when the cost allocation is enabled on the platform.`,
+		Example: `  kamel usage
+  kamel usage my-integration -o json`,
+		PreRunE: decode(&options),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format. One of: table|json")
+
+	return &cmd, &options
+}
+
+type usageCmdOptions struct {
+	*RootCmdOptions
+	OutputFormat string `mapstructure:"output"`
+}
+
+func (o *usageCmdOptions) validate() error {
+	if o.OutputFormat != "table" && o.OutputFormat != "json" {
+		return errors.New("unknown output format: " + o.OutputFormat)
+	}
+	return nil
+}
+
+func (o *usageCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	report, err := usage.Build(o.Context, c, o.Namespace, args...)
+	if err != nil {
+		return err
+	}
+
+	if o.OutputFormat == "json" {
+		return report.WriteJSON(cmd.OutOrStdout())
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "NAME\tPODS\tCPU\tMEMORY\tBUILDS\tBUILD MINUTES\tLABELS")
+	for _, u := range report.Integrations {
+		labels := make([]string, 0, len(u.Labels))
+		for k, v := range u.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%d\t%.1f\t%s\n", u.Name, u.Pods, u.RequestsCPU.String(), u.RequestsMemory.String(),
+			u.Builds, u.BuildMinutes, strings.Join(labels, ","))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+const cmdUsage = "usage"
+
+func initializeUsageCmdOptions(t *testing.T) (*usageCmdOptions, *cobra.Command, RootCmdOptions) {
+	options, rootCmd := kamelTestPreAddCommandInit()
+	usageCmdOptions := addTestUsageCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd)
+
+	return usageCmdOptions, rootCmd, *options
+}
+
+func addTestUsageCmd(options RootCmdOptions, rootCmd *cobra.Command) *usageCmdOptions {
+	//add a testing version of usage Command
+	usageCmd, usageOptions := newCmdUsage(&options)
+	usageCmd.Args = test.ArbitraryArgs
+	rootCmd.AddCommand(usageCmd)
+	return usageOptions
+}
+
+func TestUsageNonExistingFlag(t *testing.T) {
+	_, rootCmd, _ := initializeUsageCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdUsage, "--nonExistingFlag")
+	assert.NotNil(t, err)
+}
+
+func TestUsageUnknownOutputFormat(t *testing.T) {
+	_, rootCmd, _ := initializeUsageCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdUsage, "-o", "yaml")
+	assert.EqualError(t, err, "unknown output format: yaml")
+}
+
+func TestUsage(t *testing.T) {
+	usageCmdOptions, rootCmd, _ := initializeUsageCmdOptions(t)
+
+	integration := v1.NewIntegration("default", "my-integration")
+	build := v1.NewBuild("default", "my-kit")
+	build.Labels = map[string]string{
+		kubernetes.CamelCreatorLabelKind: v1.IntegrationKind,
+		kubernetes.CamelCreatorLabelName: "my-integration",
+	}
+	build.Status.Duration = "2m30s"
+	c, err := test.NewFakeClient(&integration, build)
+	assert.Nil(t, err)
+	usageCmdOptions._client = c
+
+	output, err := test.ExecuteCommand(rootCmd, cmdUsage, "-n", "default")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "NAME")
+	assert.Regexp(t, `my-integration\s+0\s+0\s+0\s+1\s+2.5`, output)
+}
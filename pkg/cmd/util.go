@@ -50,6 +50,9 @@ const (
 	githubScheme = "github"
 	httpScheme   = "http"
 	httpsScheme  = "https"
+
+	// The configuration file of a Camel JBang project
+	projectPropertiesFileName = "application.properties"
 )
 
 // DeleteIntegration --
@@ -273,6 +276,15 @@ func isLocalAndFileExists(uri string) (bool, error) {
 	return !info.IsDir(), nil
 }
 
+// isLocalDirectory returns true if the uri is an existing local directory, e.g., a Camel JBang project
+func isLocalDirectory(uri string) bool {
+	if hasSupportedScheme(uri) {
+		return false
+	}
+	info, err := os.Stat(uri)
+	return err == nil && info.IsDir()
+}
+
 func hasSupportedScheme(uri string) bool {
 	if strings.HasPrefix(strings.ToLower(uri), gistScheme+":") ||
 		strings.HasPrefix(strings.ToLower(uri), githubScheme+":") ||
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -114,34 +115,9 @@ func getTransitiveDependencies(ctx context.Context, catalog *camel.RuntimeCatalo
 		return nil, err
 	}
 
-	mc := maven.NewContext(util.MavenWorkingDirectory)
-	mc.LocalRepository = ""
-
-	if len(repositories) > 0 {
-		var repoList []v1.Repository
-		var mirrors []maven.Mirror
-		for i, repo := range repositories {
-			if strings.Contains(repo, "@mirrorOf=") {
-				mirror := maven.NewMirror(repo)
-				if mirror.ID == "" {
-					mirror.ID = fmt.Sprintf("mirror-%03d", i)
-				}
-				mirrors = append(mirrors, mirror)
-			} else {
-				repository := maven.NewRepository(repo)
-				if repository.ID == "" {
-					repository.ID = fmt.Sprintf("repository-%03d", i)
-				}
-				repoList = append(repoList, repository)
-			}
-		}
-
-		settings := maven.NewDefaultSettings(repoList, mirrors)
-		settingsData, err := util.EncodeXML(settings)
-		if err != nil {
-			return nil, err
-		}
-		mc.SettingsContent = settingsData
+	mc, err := newMavenContext(repositories)
+	if err != nil {
+		return nil, err
 	}
 
 	// Make maven command less verbose
@@ -166,6 +142,120 @@ func getTransitiveDependencies(ctx context.Context, catalog *camel.RuntimeCatalo
 	return transitiveDependencies, nil
 }
 
+// getDependencyLock resolves the given dependencies with Maven, including the transitive ones,
+// and returns the lock pinning their versions
+func getDependencyLock(ctx context.Context, catalog *camel.RuntimeCatalog, dependencies []string, repositories []string) (*v1.DependencyLock, error) {
+	project := builder.GenerateQuarkusProjectCommon(
+		catalog.CamelCatalogSpec.Runtime.Metadata["camel-quarkus.version"],
+		catalog.Runtime.Version,
+		catalog.CamelCatalogSpec.Runtime.Metadata["quarkus.version"],
+	)
+
+	err := camel.ManageIntegrationDependencies(&project, dependencies, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	mc, err := newMavenContext(repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make maven command less verbose
+	mc.AdditionalArguments = append(mc.AdditionalArguments, "-q")
+
+	outputFile := path.Join(mc.Path, "target", "dependencies.txt")
+	mc.AddArgument("dependency:list")
+	mc.AddSystemProperty("includeScope", "runtime")
+	mc.AddSystemProperty("outputFile", outputFile)
+
+	if err := project.Command(mc).Do(ctx); err != nil {
+		return nil, errors.Wrap(err, "failure while resolving dependencies")
+	}
+
+	data, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.DependencyLock{
+		RuntimeVersion: catalog.Runtime.Version,
+		Dependencies:   parseDependencyList(string(data)),
+	}, nil
+}
+
+// parseDependencyList converts the output of the Maven dependency:list goal into the list of
+// the resolved dependencies, in the form mvn:groupId:artifactId[:type[:classifier]]:version
+func parseDependencyList(list string) []string {
+	dependencies := make([]string, 0)
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// <groupId>:<artifactId>:<type>[:<classifier>]:<version>:<scope>
+		gav := strings.Split(fields[0], ":")
+		var dependency string
+		switch {
+		case len(gav) == 5 && gav[2] == "jar":
+			dependency = fmt.Sprintf("mvn:%s:%s:%s", gav[0], gav[1], gav[3])
+		case len(gav) == 5:
+			dependency = fmt.Sprintf("mvn:%s:%s:%s:%s", gav[0], gav[1], gav[2], gav[3])
+		case len(gav) == 6:
+			dependency = fmt.Sprintf("mvn:%s:%s:%s:%s:%s", gav[0], gav[1], gav[2], gav[3], gav[4])
+		default:
+			continue
+		}
+		util.StringSliceUniqueAdd(&dependencies, dependency)
+	}
+	sort.Strings(dependencies)
+
+	return dependencies
+}
+
+func newMavenContext(repositories []string) (maven.Context, error) {
+	mc := maven.NewContext(util.MavenWorkingDirectory)
+	mc.LocalRepository = ""
+
+	settings, err := newMavenSettings(repositories)
+	if err != nil {
+		return maven.Context{}, err
+	}
+	mc.SettingsContent = settings
+
+	return mc, nil
+}
+
+// newMavenSettings returns the Maven settings that configure the given repositories and mirrors,
+// or nil when there are none
+func newMavenSettings(repositories []string) ([]byte, error) {
+	if len(repositories) == 0 {
+		return nil, nil
+	}
+
+	var repoList []v1.Repository
+	var mirrors []v1.Mirror
+	for i, repo := range repositories {
+		if strings.Contains(repo, "@mirrorOf=") {
+			mirror := maven.NewMirror(repo)
+			if mirror.ID == "" {
+				mirror.ID = fmt.Sprintf("mirror-%03d", i)
+			}
+			mirrors = append(mirrors, mirror)
+		} else {
+			repository := maven.NewRepository(repo)
+			if repository.ID == "" {
+				repository.ID = fmt.Sprintf("repository-%03d", i)
+			}
+			repoList = append(repoList, repository)
+		}
+	}
+
+	settings := maven.NewDefaultSettings(repoList, mirrors)
+	settings.Proxies = maven.NewProxies(repositories)
+	return util.EncodeXML(settings)
+}
+
 func getRegularFilesInDir(directory string) ([]string, error) {
 	var dirFiles []string
 	files, err := ioutil.ReadDir(directory)
@@ -221,7 +311,7 @@ func generateCatalog(ctx context.Context) (*camel.RuntimeCatalog, error) {
 	}
 	var providerDependencies []maven.Dependency
 	var caCert []byte
-	catalog, err := camel.GenerateCatalogCommon(ctx, settings, caCert, mvn, runtime, providerDependencies)
+	catalog, err := camel.GenerateCatalogCommon(ctx, settings, nil, caCert, mvn, runtime, providerDependencies)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/magiconair/properties"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The property listing the dependencies of a Camel JBang project
+const jbangDependenciesProperty = "camel.jbang.dependencies"
+
+// projectSourceFiles returns the sorted paths of the route files of a Camel JBang project directory.
+// The Kamelets the project may define are not routes, so they are skipped.
+func projectSourceFiles(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0)
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(e.Name(), ".kamelet.yaml") {
+			continue
+		}
+		source := v1.SourceSpec{DataSpec: v1.DataSpec{Name: e.Name()}}
+		if source.InferLanguage() != "" {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// projectPropertiesFile returns the path of the application.properties file of a Camel JBang
+// project directory, or an empty string if the project has none
+func projectPropertiesFile(dir string) string {
+	file := filepath.Join(dir, projectPropertiesFileName)
+	if info, err := os.Stat(file); err == nil && !info.IsDir() {
+		return file
+	}
+	return ""
+}
+
+// projectDependencies returns the dependencies declared in the given Camel JBang configuration,
+// converted to the integration dependency format
+func projectDependencies(props *properties.Properties) []string {
+	dependencies := make([]string, 0)
+	for _, d := range strings.Split(props.GetString(jbangDependenciesProperty, ""), ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			dependencies = append(dependencies, fromJBangDependency(d))
+		}
+	}
+	return dependencies
+}
+
+// fromJBangDependency converts a Camel JBang Maven dependency, e.g., org.acme:foo:1.0, to the integration
+// dependency format, e.g., mvn:org.acme:foo:1.0. Camel components, e.g., camel-kafka, are converted
+// when they are added to the integration.
+func fromJBangDependency(dependency string) string {
+	for _, prefix := range []string{"mvn:", "github:", "camel:", "camel-quarkus:", "camel-k:"} {
+		if strings.HasPrefix(dependency, prefix) {
+			return dependency
+		}
+	}
+	if strings.Count(dependency, ":") >= 2 {
+		return "mvn:" + dependency
+	}
+	return dependency
+}
+
+// toJBangDependency converts an integration dependency to the Camel JBang dependency format.
+// It is the inverse of fromJBangDependency.
+func toJBangDependency(dependency string) string {
+	switch {
+	case strings.HasPrefix(dependency, "camel:"):
+		return "camel-" + strings.TrimPrefix(dependency, "camel:")
+	case strings.HasPrefix(dependency, "mvn:"):
+		return strings.TrimPrefix(dependency, "mvn:")
+	default:
+		return dependency
+	}
+}
@@ -65,6 +65,16 @@ func ResolveSources(ctx context.Context, locations []string, compress bool) ([]S
 	sources := make([]Source, 0, len(locations))
 
 	for _, location := range locations {
+		if isLocalDirectory(location) {
+			answer, err := resolveProjectSources(location, compress)
+			if err != nil {
+				return sources, err
+			}
+
+			sources = append(sources, answer...)
+			continue
+		}
+
 		ok, err := isLocalAndFileExists(location)
 		if err != nil {
 			return sources, err
@@ -216,3 +226,26 @@ func ResolveLocalSource(location string, compress bool) (Source, error) {
 
 	return answer, nil
 }
+
+// resolveProjectSources resolves the routes of a Camel JBang project directory, i.e., the files
+// of the directory written in one of the supported languages
+func resolveProjectSources(dir string, compress bool) ([]Source, error) {
+	files, err := projectSourceFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no integration sources found in directory %s", dir)
+	}
+
+	sources := make([]Source, 0, len(files))
+	for _, f := range files {
+		answer, err := ResolveLocalSource(f, compress)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, answer)
+	}
+
+	return sources, nil
+}
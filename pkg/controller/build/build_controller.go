@@ -28,16 +28,22 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
 	camelevent "github.com/apache/camel-k/pkg/event"
 	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util/monitoring"
+	"github.com/apache/camel-k/pkg/util/ratelimit"
+	"github.com/apache/camel-k/pkg/util/telemetry"
 )
 
 // Add creates a new Build Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -47,6 +53,9 @@ func Add(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
+	if err := metrics.Registry.Register(newBuildCollector(c)); err != nil {
+		return err
+	}
 	return add(mgr, newReconciler(mgr, c))
 }
 
@@ -67,20 +76,39 @@ func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
 }
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	limiter := ratelimit.NewRateLimiter("build-controller", ratelimit.OptionsFromEnv())
+
 	return builder.ControllerManagedBy(mgr).
 		Named("build-controller").
+		WithOptions(controller.Options{RateLimiter: limiter}).
 		// Watch for changes to primary resource Build
 		For(&v1.Build{}, builder.WithPredicates(
 			predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					// Ignore updates to the build status in which case metadata.Generation does not change
+					return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+				},
+			})).
+		// Watch for the build phase transitions, as they're used to transition from one phase to another.
+		// They are throttled, so that the builds created in a namespace do not delay the other namespaces.
+		Watches(&source.Kind{Type: &v1.Build{}},
+			ratelimit.Throttle(&handler.EnqueueRequestForObject{}, limiter),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					return false
+				},
 				UpdateFunc: func(e event.UpdateEvent) bool {
 					oldBuild := e.ObjectOld.(*v1.Build)
 					newBuild := e.ObjectNew.(*v1.Build)
-					// Ignore updates to the build status in which case metadata.Generation does not change,
-					// or except when the build phase changes as it's used to transition from one phase
-					// to another
-					return oldBuild.Generation != newBuild.Generation ||
+					return oldBuild.Generation == newBuild.Generation &&
 						oldBuild.Status.Phase != newBuild.Status.Phase
 				},
+				DeleteFunc: func(e event.DeleteEvent) bool {
+					return false
+				},
+				GenericFunc: func(e event.GenericEvent) bool {
+					return false
+				},
 			})).
 		Complete(r)
 }
@@ -185,7 +213,9 @@ func (r *reconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		if a.CanHandle(target) {
 			targetLog.Infof("Invoking action %s", a.Name())
 
-			newTarget, err := a.Handle(ctx, target)
+			actionCtx, span := telemetry.StartActionSpan(ctx, "Build", a.Name(), string(target.Status.Phase))
+			newTarget, err := a.Handle(actionCtx, target)
+			telemetry.EndSpan(span, err)
 			if err != nil {
 				camelevent.NotifyBuildError(ctx, r.client, r.recorder, &instance, newTarget, err)
 				return reconcile.Result{}, err
@@ -230,6 +260,9 @@ func (r *reconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 }
 
 func (r *reconcileBuild) update(ctx context.Context, base *v1.Build, target *v1.Build) (reconcile.Result, error) {
+	target.Status.ObservedGeneration = base.Generation
+	target.Status.SetPhaseConditions()
+
 	err := r.client.Status().Patch(ctx, target, ctrl.MergeFrom(base))
 
 	return reconcile.Result{}, err
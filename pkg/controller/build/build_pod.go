@@ -42,6 +42,8 @@ import (
 const (
 	builderDir    = "/builder"
 	builderVolume = "camel-k-builder"
+	kamelDir      = "/camel-k/bin"
+	kamelVolume   = "camel-k-bin"
 )
 
 type registryConfigMap struct {
@@ -135,9 +137,28 @@ func newBuildPod(ctx context.Context, c ctrl.Reader, build *v1.Build) (*corev1.P
 
 	pod.Labels = kubernetes.MergeCamelCreatorLabels(build.Labels, pod.Labels)
 
+	for _, task := range build.Spec.Tasks {
+		if task.Tekton != nil {
+			// The build state is shared with the Tekton PipelineRun, that runs in other pods
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+				Name: builderVolume,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: task.Tekton.PersistentVolumeClaim,
+					},
+				},
+			})
+		}
+	}
+
 	for _, task := range build.Spec.Tasks {
 		if task.Builder != nil {
-			err := addBuildTaskToPod(build, task.Builder.Name, pod)
+			var err error
+			if task.Builder.Image != "" {
+				err = addCustomBuildTaskToPod(build, task.Builder.Name, task.Builder.Image, pod)
+			} else {
+				err = addBuildTaskToPod(build, task.Builder.Name, pod)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -161,6 +182,13 @@ func newBuildPod(ctx context.Context, c ctrl.Reader, build *v1.Build) (*corev1.P
 			if err != nil {
 				return nil, err
 			}
+		} else if task.Tekton != nil {
+			err := addBuildTaskToPod(build, task.Tekton.Name, pod)
+			if err != nil {
+				return nil, err
+			}
+		} else if task.Local != nil {
+			addLocalTaskToPod(build, task.Local, pod)
 		}
 	}
 
@@ -209,6 +237,57 @@ func buildPodName(build *v1.Build) string {
 }
 
 func addBuildTaskToPod(build *v1.Build, taskName string, pod *corev1.Pod) error {
+	addBuilderVolume(pod)
+
+	container := corev1.Container{
+		Name:            taskName,
+		Image:           operatorImage(),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         builderCommand(build, "kamel", taskName),
+		WorkingDir:      path.Join(builderDir, build.Name),
+	}
+
+	addContainerToPod(build, container, pod)
+	return nil
+}
+
+// addCustomBuildTaskToPod adds the task run in the given custom builder image, that provides the build toolchain,
+// the kamel binary being copied from the operator image by an init container
+func addCustomBuildTaskToPod(build *v1.Build, taskName string, image string, pod *corev1.Pod) error {
+	addBuilderVolume(pod)
+
+	mount := corev1.VolumeMount{
+		Name:      kamelVolume,
+		MountPath: kamelDir,
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: kamelVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+		Name:            taskName + "-kamel",
+		Image:           operatorImage(),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"cp", "/usr/local/bin/kamel", path.Join(kamelDir, "kamel")},
+		VolumeMounts:    []corev1.VolumeMount{mount},
+	})
+
+	container := corev1.Container{
+		Name:            taskName,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         builderCommand(build, path.Join(kamelDir, "kamel"), taskName),
+		WorkingDir:      path.Join(builderDir, build.Name),
+		VolumeMounts:    []corev1.VolumeMount{mount},
+	}
+
+	addContainerToPod(build, container, pod)
+	return nil
+}
+
+func addBuilderVolume(pod *corev1.Pod) {
 	if !hasBuilderVolume(pod) {
 		// Add the EmptyDir volume used to share the build state across tasks
 		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
@@ -218,34 +297,34 @@ func addBuildTaskToPod(build *v1.Build, taskName string, pod *corev1.Pod) error
 			},
 		})
 	}
+}
 
-	operatorImage := platform.OperatorImage
-	if operatorImage == "" {
-		operatorImage = defaults.ImageName + ":" + defaults.Version
+func operatorImage() string {
+	if platform.OperatorImage != "" {
+		return platform.OperatorImage
 	}
+	return defaults.ImageName + ":" + defaults.Version
+}
 
-	container := corev1.Container{
-		Name:            taskName,
-		Image:           operatorImage,
-		ImagePullPolicy: corev1.PullIfNotPresent,
-		Command: []string{
-			"kamel",
-			"builder",
-			"--namespace",
-			build.Namespace,
-			"--build-name",
-			build.Name,
-			"--task-name",
-			taskName,
-		},
-		WorkingDir: path.Join(builderDir, build.Name),
+func builderCommand(build *v1.Build, kamel string, taskName string) []string {
+	return []string{
+		kamel,
+		"builder",
+		"--namespace",
+		build.Namespace,
+		"--build-name",
+		build.Name,
+		"--task-name",
+		taskName,
 	}
-
-	addContainerToPod(build, container, pod)
-	return nil
 }
 
 func addBuildahTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, task *v1.BuildahTask, pod *corev1.Pod) error {
+	// The architectures are part of the build commands
+	if err := builder.ValidateArchitectures(task.Architectures); err != nil {
+		return err
+	}
+
 	bud := []string{
 		"buildah",
 		"bud",
@@ -311,9 +390,20 @@ func addBuildahTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, ta
 
 	env = append(env, proxySecretEnvVars(task.HttpProxySecret)...)
 
-	args := []string{
-		strings.Join(bud, " "),
-		strings.Join(push, " "),
+	var args []string
+	switch len(task.Architectures) {
+	case 0:
+		args = []string{
+			strings.Join(bud, " "),
+			strings.Join(push, " "),
+		}
+	case 1:
+		args = []string{
+			strings.Join(buildahBudForArchitecture(bud, task.Architectures[0], task.Image), " "),
+			strings.Join(push, " "),
+		}
+	default:
+		args = buildahMultiArchitectureCommands(task, bud, push)
 	}
 	if auth != "" {
 		args = append([]string{auth}, args...)
@@ -337,6 +427,32 @@ func addBuildahTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, ta
 	return nil
 }
 
+// buildahBudForArchitecture returns the given bud command, that builds the given image for the given architecture
+func buildahBudForArchitecture(bud []string, arch string, image string) []string {
+	cmd := append([]string{bud[0], bud[1], "--os=linux", "--arch=" + arch}, bud[2:]...)
+	// The image is the second to last argument, followed by the context directory
+	cmd[len(cmd)-2] = image
+	return cmd
+}
+
+// buildahMultiArchitectureCommands returns the commands that build the image for each of the task architectures,
+// and push them as a manifest list
+func buildahMultiArchitectureCommands(task *v1.BuildahTask, bud []string, push []string) []string {
+	commands := []string{
+		strings.Join([]string{"buildah", "manifest", "create", "--storage-driver=vfs", task.Image}, " "),
+	}
+	for _, arch := range task.Architectures {
+		image := task.Image + "-" + arch
+		commands = append(commands,
+			strings.Join(buildahBudForArchitecture(bud, arch, image), " "),
+			strings.Join([]string{"buildah", "manifest", "add", "--storage-driver=vfs", task.Image, "containers-storage:" + image}, " "),
+		)
+	}
+	// Push the manifest list along with the images it references, with the same options as the single image push
+	manifestPush := append([]string{"buildah", "manifest", "push", "--all"}, push[2:]...)
+	return append(commands, strings.Join(manifestPush, " "))
+}
+
 func addKanikoTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, task *v1.KanikoTask, pod *corev1.Pod) error {
 	cache := false
 	if task.Cache.Enabled != nil && *task.Cache.Enabled {
@@ -450,24 +566,102 @@ func addKanikoTaskToPod(ctx context.Context, c ctrl.Reader, build *v1.Build, tas
 	return nil
 }
 
+// addLocalTaskToPod adds the container that builds the image with the container engine of the node, so that the image
+// is stored into the node container runtime, rather than pushed to a registry
+func addLocalTaskToPod(build *v1.Build, task *v1.LocalTask, pod *corev1.Pod) {
+	verbose := task.Verbose != nil && *task.Verbose
+
+	var command []string
+	var env []corev1.EnvVar
+	switch task.Engine {
+	case v1.IntegrationPlatformLocalEnginePodman:
+		command = []string{"podman", "--remote", "--url", task.Address}
+		if verbose {
+			command = append(command, "--log-level=debug")
+		}
+		command = append(command, "build", "-f", "Dockerfile", "-t", task.Image, ".")
+	case v1.IntegrationPlatformLocalEngineBuildkit:
+		command = []string{"buildctl", "--addr", task.Address}
+		if verbose {
+			command = append(command, "--debug")
+		}
+		// The image is unpacked into the containerd image store, that's shared with the node container runtime
+		command = append(command, "build",
+			"--frontend", "dockerfile.v0",
+			"--local", "context=.",
+			"--local", "dockerfile=.",
+			"--output", "type=image,name="+task.Image+",unpack=true")
+	default:
+		command = []string{"docker"}
+		if verbose {
+			command = append(command, "--debug")
+		}
+		command = append(command, "build", "-f", "Dockerfile", "-t", task.Image, ".")
+		env = append(env, corev1.EnvVar{
+			Name:  "DOCKER_HOST",
+			Value: task.Address,
+		})
+	}
+
+	var volumeMounts []corev1.VolumeMount
+	if strings.HasPrefix(task.Address, "unix://") {
+		socket := strings.TrimPrefix(task.Address, "unix://")
+		socketType := corev1.HostPathSocket
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: "container-engine",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: socket,
+					Type: &socketType,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "container-engine",
+			MountPath: socket,
+		})
+	}
+
+	container := corev1.Container{
+		Name:            task.Name,
+		Image:           task.ClientImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         command,
+		Env:             env,
+		WorkingDir:      path.Join(builderDir, build.Name, builder.ContextDir),
+		VolumeMounts:    volumeMounts,
+	}
+
+	addContainerToPod(build, container, pod)
+}
+
 func addContainerToPod(build *v1.Build, container corev1.Container, pod *corev1.Pod) {
-	if hasBuilderVolume(pod) {
-		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+	if volume := getBuilderVolume(pod); volume != nil {
+		mount := corev1.VolumeMount{
 			Name:      builderVolume,
 			MountPath: path.Join(builderDir, build.Name),
-		})
+		}
+		if volume.PersistentVolumeClaim != nil {
+			// Isolate the builds sharing the persistent volume
+			mount.SubPath = build.Name
+		}
+		container.VolumeMounts = append(container.VolumeMounts, mount)
 	}
 
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
 }
 
 func hasBuilderVolume(pod *corev1.Pod) bool {
-	for _, volume := range pod.Spec.Volumes {
-		if volume.Name == builderVolume {
-			return true
+	return getBuilderVolume(pod) != nil
+}
+
+func getBuilderVolume(pod *corev1.Pod) *corev1.Volume {
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].Name == builderVolume {
+			return &pod.Spec.Volumes[i]
 		}
 	}
-	return false
+	return nil
 }
 
 func getRegistryConfigMap(ctx context.Context, c ctrl.Reader, ns, name string, registryConfigMaps []registryConfigMap) (registryConfigMap, error) {
@@ -18,9 +18,11 @@ limitations under the License.
 package build
 
 import (
+	"context"
 	"math"
 	"time"
 
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -29,8 +31,12 @@ import (
 )
 
 const (
-	buildResultLabel = "result"
-	buildTypeLabel   = "type"
+	buildResultLabel    = "result"
+	buildTypeLabel      = "type"
+	buildPhaseLabel     = "phase"
+	buildNamespaceLabel = "namespace"
+
+	collectTimeout = 10 * time.Second
 )
 
 var (
@@ -83,6 +89,50 @@ var (
 	)
 )
 
+var buildsDesc = prometheus.NewDesc(
+	"camel_k_builds",
+	"Camel K builds per phase, the queued builds being in the Scheduling phase",
+	[]string{
+		buildNamespaceLabel,
+		buildPhaseLabel,
+		buildTypeLabel,
+	},
+	nil,
+)
+
+// buildCollector counts the Builds per namespace, phase and type at scrape time,
+// so that the count is consistent with the Builds that have been deleted meanwhile
+type buildCollector struct {
+	reader ctrl.Reader
+}
+
+func newBuildCollector(reader ctrl.Reader) prometheus.Collector {
+	return &buildCollector{reader: reader}
+}
+
+func (c *buildCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- buildsDesc
+}
+
+func (c *buildCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	builds := v1.NewBuildList()
+	if err := c.reader.List(ctx, &builds); err != nil {
+		ch <- prometheus.NewInvalidMetric(buildsDesc, err)
+		return
+	}
+
+	counts := make(map[[3]string]int)
+	for _, build := range builds.Items {
+		counts[[3]string{build.Namespace, string(build.Status.Phase), build.Labels[v1.IntegrationKitLayoutLabel]}]++
+	}
+	for labels, count := range counts {
+		ch <- prometheus.MustNewConstMetric(buildsDesc, prometheus.GaugeValue, float64(count), labels[:]...)
+	}
+}
+
 func init() {
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(buildDuration, buildRecovery, queueDuration)
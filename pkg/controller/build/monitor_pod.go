@@ -127,10 +127,14 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *v1.Build) (*v
 		for _, task := range build.Spec.Tasks {
 			if t := task.Buildah; t != nil {
 				build.Status.Image = t.Image
+				build.Status.Architectures = t.Architectures
 				break
 			} else if t := task.Kaniko; t != nil {
 				build.Status.Image = t.Image
 				break
+			} else if t := task.Local; t != nil {
+				build.Status.Image = t.Image
+				break
 			}
 		}
 		// Reconcile image digest from build container status if available
@@ -180,6 +180,16 @@ func (action *monitorRoutineAction) updateBuildStatus(ctx context.Context, build
 	target.Status = status
 	// Copy the failure field from the build to persist recovery state
 	target.Status.Failure = build.Status.Failure
+	// The status reported by the builder does not carry the conditions
+	target.Status.Conditions = build.Status.Conditions
+	// Nor the Maven configuration recorded when the build is scheduled
+	target.Status.Maven = build.Status.Maven
+	// The results of the unit tests are only reported by the builder task
+	if target.Status.Tests == nil {
+		target.Status.Tests = build.Status.Tests
+	}
+	target.Status.ObservedGeneration = build.Generation
+	target.Status.SetPhaseConditions()
 	// Patch the build status with the result
 	p, err := patch.PositiveMergePatch(build, target)
 	if err != nil {
@@ -19,8 +19,10 @@ package build
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -29,6 +31,8 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/event"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
 func newScheduleAction(reader ctrl.Reader) Action {
@@ -59,13 +63,42 @@ func (action *scheduleAction) Handle(ctx context.Context, build *v1.Build) (*v1.
 	action.lock.Lock()
 	defer action.lock.Unlock()
 
+	p, err := platform.GetOrFind(ctx, action.client, build.Namespace, build.Status.Platform, true)
+	if err != nil {
+		return nil, err
+	}
+	quota := p.Status.Quota.MaxRunningBuilds
+
+	if ok, err := action.checkQuota(ctx, build, quota); err != nil || !ok {
+		return nil, err
+	}
+
 	layout := build.Labels[v1.IntegrationKitLayoutLabel]
 
 	// Native builds can be run in parallel, as incremental images is not applicable.
-	if layout == v1.IntegrationKitLayoutNative {
+	if layout == v1.IntegrationKitLayoutNative && quota == nil {
 		// Reset the Build status, and transition it to pending phase.
 		// This must be done in the critical section, rather than delegated to the controller.
-		return nil, action.toPendingPhase(ctx, build)
+		return nil, action.toPendingPhase(ctx, build, 0)
+	}
+
+	// The queued builds competing with the Build are the ones sharing the quota of running builds, if any,
+	// or the ones the Build is serialized with otherwise
+	competing := &v1.BuildList{}
+	options := []ctrl.ListOption{ctrl.InNamespace(build.Namespace)}
+	if quota == nil {
+		options = append(options, ctrl.MatchingLabels{v1.IntegrationKitLayoutLabel: layout})
+	}
+	if err := action.reader.List(ctx, competing, options...); err != nil {
+		return nil, err
+	}
+	overtaken, ok, err := action.checkPriority(ctx, build, competing.Items)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	if layout == v1.IntegrationKitLayoutNative {
+		return nil, action.toPendingPhase(ctx, build, overtaken)
 	}
 
 	// We assume incremental images is only applicable across images whose layout is identical
@@ -88,8 +121,7 @@ func (action *scheduleAction) Handle(ctx context.Context, build *v1.Build) (*v1.
 
 	// Emulate a serialized working queue to only allow one build to run at a given time.
 	// This is currently necessary for the incremental build to work as expected.
-	// We may want to explicitly manage build priority as opposed to relying on
-	// the reconciliation loop to handle the queuing.
+	// The queued builds are ordered by priority, see checkPriority.
 	for _, b := range builds.Items {
 		if b.Status.Phase == v1.BuildPhasePending || b.Status.Phase == v1.BuildPhaseRunning {
 			// Let's requeue the build in case one is already running
@@ -99,10 +131,89 @@ func (action *scheduleAction) Handle(ctx context.Context, build *v1.Build) (*v1.
 
 	// Reset the Build status, and transition it to pending phase.
 	// This must be done in the critical section, rather than delegated to the controller.
-	return nil, action.toPendingPhase(ctx, build)
+	return nil, action.toPendingPhase(ctx, build, overtaken)
 }
 
-func (action *scheduleAction) toPendingPhase(ctx context.Context, build *v1.Build) error {
+// checkQuota returns whether the Build can be scheduled without exceeding the quota of running builds
+// of its namespace, reporting it in the Build conditions otherwise
+func (action *scheduleAction) checkQuota(ctx context.Context, build *v1.Build, max *int32) (bool, error) {
+	if max == nil {
+		return true, nil
+	}
+
+	builds := &v1.BuildList{}
+	if err := action.reader.List(ctx, builds, ctrl.InNamespace(build.Namespace)); err != nil {
+		return false, err
+	}
+	running := 0
+	for _, b := range builds.Items {
+		if b.Status.Phase == v1.BuildPhasePending || b.Status.Phase == v1.BuildPhaseRunning {
+			running++
+		}
+	}
+	if running < int(*max) {
+		return true, nil
+	}
+
+	message := fmt.Sprintf("%d builds are running in namespace %s, that has a quota of %d running builds", running, build.Namespace, *max)
+	if c := build.Status.GetCondition(v1.BuildConditionQuotaExceeded); c != nil && c.Message == message {
+		return false, nil
+	}
+	action.L.Info("Build quota exceeded", "running", running, "quota", *max)
+	return false, action.patchBuildStatus(ctx, build, func(b *v1.Build) {
+		b.Status.SetCondition(v1.BuildConditionQuotaExceeded, corev1.ConditionTrue, v1.BuildConditionQuotaExceededReason, message)
+	})
+}
+
+// checkPriority returns whether no queued Build competing with the Build has a higher priority, reporting
+// the preemption in the Build conditions otherwise. The number of queued builds with a lower priority,
+// the Build is scheduled ahead of, is returned as well.
+func (action *scheduleAction) checkPriority(ctx context.Context, build *v1.Build, competing []v1.Build) (int, bool, error) {
+	priority := action.priority(ctx, build)
+	overtaken := 0
+	var preempting *v1.Build
+	preemptingPriority := priority
+	for i := range competing {
+		b := &competing[i]
+		if b.Name == build.Name || b.Status.Phase != v1.BuildPhaseScheduling {
+			continue
+		}
+		p := action.priority(ctx, b)
+		if p < priority {
+			overtaken++
+		} else if p > preemptingPriority {
+			preempting = b
+			preemptingPriority = p
+		}
+	}
+	if preempting == nil {
+		return overtaken, true, nil
+	}
+
+	message := fmt.Sprintf("build %s with priority %d is scheduled before this build with priority %d", preempting.Name, preemptingPriority, priority)
+	if c := build.Status.GetCondition(v1.BuildConditionPreempted); c != nil && c.Message == message {
+		return 0, false, nil
+	}
+	action.L.Info("Build preempted", "preempting", preempting.Name, "priority", priority, "preempting-priority", preemptingPriority)
+	return 0, false, action.patchBuildStatus(ctx, build, func(b *v1.Build) {
+		b.Status.SetCondition(v1.BuildConditionPreempted, corev1.ConditionTrue, v1.BuildConditionPreemptedReason, message)
+	})
+}
+
+// priority returns the priority of the Build, set on the Integration it originates from
+func (action *scheduleAction) priority(ctx context.Context, build *v1.Build) int {
+	ref := kubernetes.GetCamelCreator(build)
+	if ref == nil || ref.Kind != v1.IntegrationKind {
+		return 0
+	}
+	it := v1.NewIntegration(ref.Namespace, ref.Name)
+	if err := action.client.Get(ctx, ctrl.ObjectKeyFromObject(&it), &it); err != nil {
+		return 0
+	}
+	return it.GetBuildPriority()
+}
+
+func (action *scheduleAction) toPendingPhase(ctx context.Context, build *v1.Build, overtaken int) error {
 	err := action.patchBuildStatus(ctx, build, func(b *v1.Build) {
 		now := metav1.Now()
 		b.Status = v1.BuildStatus{
@@ -111,21 +222,45 @@ func (action *scheduleAction) toPendingPhase(ctx context.Context, build *v1.Buil
 			Failure:    b.Status.Failure,
 			Platform:   b.Status.Platform,
 			Conditions: b.Status.Conditions,
+			Maven:      mavenBuildStatus(b),
 		}
+		b.Status.RemoveCondition(v1.BuildConditionQuotaExceeded)
+		b.Status.RemoveCondition(v1.BuildConditionPreempted)
 	})
 	if err != nil {
 		return err
 	}
 
+	if overtaken > 0 {
+		action.recorder.Eventf(build, corev1.EventTypeNormal, event.ReasonBuildPrioritized,
+			"Build %s scheduled ahead of %d queued builds with a lower priority", build.Name, overtaken)
+	}
+
 	// Report the duration the Build has been waiting in the build queue
 	observeBuildQueueDuration(build)
 
 	return nil
 }
 
+// mavenBuildStatus returns the Maven configuration of the builder task of the Build, if any
+func mavenBuildStatus(build *v1.Build) *v1.MavenBuildStatus {
+	for _, task := range build.Spec.Tasks {
+		if t := task.Builder; t != nil {
+			return &v1.MavenBuildStatus{
+				Profiles:     t.Maven.Profiles,
+				Repositories: t.Maven.Repositories,
+				Mirrors:      t.Maven.Mirrors,
+			}
+		}
+	}
+	return nil
+}
+
 func (action *scheduleAction) patchBuildStatus(ctx context.Context, build *v1.Build, mutate func(b *v1.Build)) error {
 	target := build.DeepCopy()
 	mutate(target)
+	target.Status.ObservedGeneration = build.Generation
+	target.Status.SetPhaseConditions()
 	if err := action.client.Status().Patch(ctx, target, ctrl.MergeFrom(build)); err != nil {
 		return err
 	}
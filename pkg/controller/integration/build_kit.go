@@ -24,7 +24,6 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/trait"
-	"github.com/apache/camel-k/pkg/util/digest"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
@@ -48,7 +47,7 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1.Integr
 	// TODO: we may need to add a timeout strategy, i.e give up after some time in case of an unrecoverable error.
 
 	// Check if the Integration has changed and requires a rebuild
-	hash, err := digest.ComputeForIntegration(integration)
+	hash, err := computeDigest(ctx, action.client, integration)
 	if err != nil {
 		return nil, err
 	}
@@ -118,12 +117,14 @@ kits:
 					integrationKit.Status.Phase == v1.IntegrationKitPhaseReady && k.Status.Phase == v1.IntegrationKitPhaseReady && k.HasHigherPriorityThan(integrationKit) {
 					integrationKit = &existingKits[i]
 				}
+				observeKitLookup(&k, true)
 				continue kits
 			}
 		}
 		if err := action.client.Create(ctx, &kit); err != nil {
 			return nil, err
 		}
+		observeKitLookup(&kit, false)
 		if integrationKit == nil {
 			integrationKit = &kit
 		}
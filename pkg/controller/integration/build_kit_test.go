@@ -107,6 +107,99 @@ func TestLookupKitForIntegration_DiscardKitsInError(t *testing.T) {
 	assert.Equal(t, "my-kit-2", kits[0].Name)
 }
 
+func TestLookupKitForIntegration_DiscardKitsWithMissingArchitectures(t *testing.T) {
+	c, err := test.NewFakeClient(
+		&v1.IntegrationPlatform{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationPlatformKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "camel-k",
+			},
+			Status: v1.IntegrationPlatformStatus{
+				IntegrationPlatformSpec: v1.IntegrationPlatformSpec{
+					Build: v1.IntegrationPlatformBuildSpec{
+						Architectures: []string{"amd64", "arm64"},
+					},
+				},
+				Phase: v1.IntegrationPlatformPhaseReady,
+			},
+		},
+		// Should be discarded because it is not available for the arm64 architecture
+		&v1.IntegrationKit{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKitKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "my-kit-1",
+				Labels: map[string]string{
+					v1.IntegrationKitTypeLabel: v1.IntegrationKitTypePlatform,
+				},
+			},
+			Spec: v1.IntegrationKitSpec{
+				Dependencies: []string{
+					"camel-core",
+					"camel-irc",
+				},
+			},
+			Status: v1.IntegrationKitStatus{
+				Phase:         v1.IntegrationKitPhaseReady,
+				Architectures: []string{"amd64"},
+			},
+		},
+		&v1.IntegrationKit{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKitKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "my-kit-2",
+				Labels: map[string]string{
+					v1.IntegrationKitTypeLabel: v1.IntegrationKitTypePlatform,
+				},
+			},
+			Spec: v1.IntegrationKitSpec{
+				Dependencies: []string{
+					"camel-core",
+					"camel-irc",
+				},
+			},
+			Status: v1.IntegrationKitStatus{
+				Phase:         v1.IntegrationKitPhaseReady,
+				Architectures: []string{"amd64", "arm64"},
+			},
+		},
+	)
+
+	assert.Nil(t, err)
+
+	kits, err := lookupKitsForIntegration(context.TODO(), c, &v1.Integration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Status: v1.IntegrationStatus{
+			Dependencies: []string{
+				"camel-core",
+				"camel-irc",
+			},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, kits, 1)
+	assert.Equal(t, "my-kit-2", kits[0].Name)
+}
+
 func TestLookupKitForIntegration_DiscardKitsWithIncompatibleTraits(t *testing.T) {
 	c, err := test.NewFakeClient(
 		// Should be discarded because it does not contain the required traits
@@ -325,3 +418,50 @@ func TestHasMatchingTraits_KitSameTraitShouldBePicked(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, ok)
 }
+
+func TestIntegrationMatches_LockedDependencies(t *testing.T) {
+	integration := &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Spec: v1.IntegrationSpec{
+			DependencyLock: &v1.DependencyLock{
+				Dependencies: []string{
+					"mvn:org.apache.camel:camel-log:3.11.1",
+					"mvn:org.apache.camel:camel-timer:3.11.1",
+				},
+			},
+		},
+		Status: v1.IntegrationStatus{
+			Dependencies: []string{"camel:log", "camel:timer"},
+		},
+	}
+
+	kit := &v1.IntegrationKit{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-kit",
+		},
+		Spec: v1.IntegrationKitSpec{
+			Dependencies: []string{"camel:log", "camel:timer"},
+		},
+	}
+
+	ok, err := integrationMatches(integration, kit)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	kit.Spec.LockedDependencies = []string{
+		"mvn:org.apache.camel:camel-timer:3.11.1",
+		"mvn:org.apache.camel:camel-log:3.11.1",
+	}
+	ok, err = integrationMatches(integration, kit)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	integration.Spec.DependencyLock = nil
+	ok, err = integrationMatches(integration, kit)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
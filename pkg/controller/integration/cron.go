@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/batch/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// triggerCronJob runs a Job out of schedule, from the template of the CronJob of the Integration, whenever
+// the value of its trigger annotation changes
+func (action *monitorAction) triggerCronJob(ctx context.Context, integration *v1.Integration) error {
+	trigger := integration.Annotations[v1.TriggerAnnotation]
+	if trigger == "" || trigger == integration.Status.LastTrigger {
+		return nil
+	}
+	if !kubernetes.IsConditionTrue(integration, v1.IntegrationConditionCronJobAvailable) {
+		return nil
+	}
+
+	cronJob := v1beta1.CronJob{}
+	if err := action.client.Get(ctx, ctrl.ObjectKey{Namespace: integration.Namespace, Name: integration.Name}, &cronJob); err != nil {
+		return err
+	}
+
+	job := newManualJob(&cronJob, trigger)
+	// The Job name is derived from the trigger, so that it's only run once
+	if err := action.client.Create(ctx, job); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	action.L.Infof("Job %s triggered out of schedule", job.Name)
+	integration.Status.LastTrigger = trigger
+
+	return nil
+}
+
+// newManualJob returns a Job created from the template of the given CronJob, as `kubectl create job --from` does
+func newManualJob(cronJob *v1beta1.CronJob, trigger string) *batchv1.Job {
+	name := cronJob.Name
+	// The Job name must be a valid label value, as it's used to label its pods
+	if len(name) > 46 {
+		name = name[:46]
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(trigger)))
+	name = fmt.Sprintf("%s-manual-%s", name, hash[:8])
+
+	labels := make(map[string]string)
+	for k, v := range cronJob.Spec.JobTemplate.Labels {
+		labels[k] = v
+	}
+	annotations := map[string]string{
+		"cronjob.kubernetes.io/instantiate": "manual",
+	}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   cronJob.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+			// The Job is owned by the CronJob, so that it's cleaned up according to its history limits
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, v1beta1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
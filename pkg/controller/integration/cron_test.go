@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestTriggerCronJob(t *testing.T) {
+	integration := newCronIntegration()
+	c, err := test.NewFakeClient(newCronJob())
+	assert.Nil(t, err)
+	action := newCronAction(c)
+
+	// Not triggered
+	assert.Nil(t, action.triggerCronJob(context.TODO(), integration))
+	assert.Empty(t, listJobs(t, c))
+
+	integration.Annotations = map[string]string{v1.TriggerAnnotation: "2021-10-01T10:00:00Z"}
+	assert.Nil(t, action.triggerCronJob(context.TODO(), integration))
+	assert.Equal(t, "2021-10-01T10:00:00Z", integration.Status.LastTrigger)
+
+	jobs := listJobs(t, c)
+	assert.Len(t, jobs, 1)
+	assert.Regexp(t, "^my-cron-manual-[0-9a-f]{8}$", jobs[0].Name)
+	assert.Equal(t, "my-cron", jobs[0].Labels[v1.IntegrationLabel])
+	assert.Equal(t, "manual", jobs[0].Annotations["cronjob.kubernetes.io/instantiate"])
+	assert.Equal(t, "CronJob", jobs[0].OwnerReferences[0].Kind)
+	assert.Equal(t, "my-cron", jobs[0].OwnerReferences[0].Name)
+	assert.Equal(t, "my-image", jobs[0].Spec.Template.Spec.Containers[0].Image)
+
+	// The same trigger does not run another Job
+	assert.Nil(t, action.triggerCronJob(context.TODO(), integration))
+	integration.Status.LastTrigger = ""
+	assert.Nil(t, action.triggerCronJob(context.TODO(), integration))
+	assert.Len(t, listJobs(t, c), 1)
+
+	integration.Annotations[v1.TriggerAnnotation] = "2021-10-01T11:00:00Z"
+	assert.Nil(t, action.triggerCronJob(context.TODO(), integration))
+	assert.Len(t, listJobs(t, c), 2)
+}
+
+func TestTriggerWithoutCronJob(t *testing.T) {
+	integration := newCronIntegration()
+	integration.Status.Conditions = nil
+	integration.Annotations = map[string]string{v1.TriggerAnnotation: "now"}
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	assert.Nil(t, newCronAction(c).triggerCronJob(context.TODO(), integration))
+	assert.Empty(t, integration.Status.LastTrigger)
+	assert.Empty(t, listJobs(t, c))
+}
+
+func TestMirrorLastJobCondition(t *testing.T) {
+	scheduled := metav1.NewTime(time.Date(2021, 10, 1, 10, 0, 0, 0, time.UTC))
+	succeeded := metav1.NewTime(time.Date(2021, 10, 1, 10, 1, 0, 0, time.UTC))
+	failed := metav1.NewTime(time.Date(2021, 10, 1, 11, 1, 0, 0, time.UTC))
+
+	cronJob := newCronJob()
+	cronJob.Status.LastScheduleTime = &scheduled
+	cronJob.Status.LastSuccessfulTime = &succeeded
+
+	job := func(name string, start metav1.Time, condition *batchv1.JobCondition) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				Labels:    map[string]string{v1.IntegrationLabel: "my-cron"},
+			},
+			Status: batchv1.JobStatus{StartTime: &start},
+		}
+		if condition != nil {
+			job.Status.Conditions = []batchv1.JobCondition{*condition}
+		}
+		return job
+	}
+
+	integration := newCronIntegration()
+	c, err := test.NewFakeClient(cronJob,
+		job("my-cron-1", scheduled, &batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: succeeded}),
+	)
+	assert.Nil(t, err)
+	kubernetes.MirrorReadyCondition(context.TODO(), c, integration)
+
+	condition := integration.Status.GetCondition(v1.IntegrationConditionLastJobSucceeded)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionJobSucceededReason, condition.Reason)
+	assert.Equal(t, "job my-cron-1 completed at 2021-10-01T10:01:00Z (last scheduled at 2021-10-01T10:00:00Z, last successful at 2021-10-01T10:01:00Z)", condition.Message)
+
+	assert.Nil(t, c.Create(context.TODO(), job("my-cron-2", metav1.NewTime(scheduled.Add(time.Hour)),
+		&batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: failed, Message: "Job has reached the specified backoff limit"})))
+	kubernetes.MirrorReadyCondition(context.TODO(), c, integration)
+
+	condition = integration.Status.GetCondition(v1.IntegrationConditionLastJobSucceeded)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionJobFailedReason, condition.Reason)
+	assert.Contains(t, condition.Message, "job my-cron-2 failed at 2021-10-01T11:01:00Z: Job has reached the specified backoff limit")
+
+	assert.Nil(t, c.Create(context.TODO(), job("my-cron-manual-0123abcd", metav1.NewTime(scheduled.Add(2*time.Hour)), nil)))
+	kubernetes.MirrorReadyCondition(context.TODO(), c, integration)
+
+	condition = integration.Status.GetCondition(v1.IntegrationConditionLastJobSucceeded)
+	assert.Equal(t, corev1.ConditionUnknown, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionJobRunningReason, condition.Reason)
+	assert.Contains(t, condition.Message, "job my-cron-manual-0123abcd is running")
+}
+
+func newCronAction(c client.Client) *monitorAction {
+	a := monitorAction{}
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+	return &a
+}
+
+func newCronIntegration() *v1.Integration {
+	integration := v1.NewIntegration("ns", "my-cron")
+	integration.Status.Phase = v1.IntegrationPhaseRunning
+	integration.Status.SetCondition(v1.IntegrationConditionCronJobAvailable, corev1.ConditionTrue,
+		v1.IntegrationConditionCronJobAvailableReason, "CronJob name is my-cron")
+	return &integration
+}
+
+func newCronJob() *v1beta1.CronJob {
+	return &v1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-cron",
+			UID:       "uid",
+		},
+		Spec: v1beta1.CronJobSpec{
+			Schedule: "0 * * * *",
+			JobTemplate: v1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{v1.IntegrationLabel: "my-cron"},
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "integration", Image: "my-image"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func listJobs(t *testing.T, c ctrl.Reader) []batchv1.Job {
+	t.Helper()
+
+	jobs := batchv1.JobList{}
+	assert.Nil(t, c.List(context.TODO(), &jobs, ctrl.InNamespace("ns")))
+	return jobs.Items
+}
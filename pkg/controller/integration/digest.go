@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/digest"
+	"github.com/apache/camel-k/pkg/util/telemetry"
+)
+
+// computeDigest returns the digest of the given Integration, leaving out the traits excluded by its platform
+func computeDigest(ctx context.Context, c ctrl.Reader, integration *v1.Integration) (hash string, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Compute Integration digest")
+	defer func() {
+		telemetry.EndSpan(span, err)
+	}()
+
+	var excluded []string
+	pl, err := platform.GetOrFind(ctx, c, integration.Namespace, integration.Status.Platform, false)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", err
+	} else if pl != nil {
+		excluded = pl.Status.Digest.ExcludedTraits
+	}
+
+	return digest.ComputeForIntegration(integration, excluded...)
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/digest"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestComputeDigestExcludesPlatformTraits(t *testing.T) {
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Digest.ExcludedTraits = []string{"owner.target-annotations"}
+
+	it := v1.NewIntegration("ns", "my-integration")
+	it.Status.Platform = "camel-k"
+	base, err := digest.ComputeForIntegration(&it)
+	assert.Nil(t, err)
+
+	it.Annotations = map[string]string{
+		v1.TraitAnnotationPrefix + "owner.target-annotations": "argocd.argoproj.io/sync-wave",
+	}
+
+	c, err := test.NewFakeClient(&pl)
+	assert.Nil(t, err)
+	d, err := computeDigest(context.TODO(), c, &it)
+	assert.Nil(t, err)
+	assert.Equal(t, base, d)
+
+	// Without platform, the trait properties are relevant
+	c, err = test.NewFakeClient()
+	assert.Nil(t, err)
+	d, err = computeDigest(context.TODO(), c, &it)
+	assert.Nil(t, err)
+	assert.NotEqual(t, base, d)
+}
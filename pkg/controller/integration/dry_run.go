@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// DryRun runs the traits against the given Integration, through the phases the operator goes through to deploy it,
+// and returns the environment holding the resources that would be created. The write requests are sent to the API
+// server in dry-run mode, so that nothing gets persisted.
+//
+// When no kit is available for the Integration, the kit that would get built is assumed to be ready, and the image
+// of the generated resources is left empty.
+func DryRun(ctx context.Context, c client.Client, integration *v1.Integration) (*trait.Environment, error) {
+	c = client.NewDryRunClient(c)
+
+	target := integration.DeepCopy()
+	target.Initialize()
+	hash, err := computeDigest(ctx, c, target)
+	if err != nil {
+		return nil, err
+	}
+	target.Status.Digest = hash
+
+	action := NewInitializeAction()
+	action.InjectClient(c)
+	action.InjectLogger(Log)
+	if target, err = action.Handle(ctx, target); err != nil {
+		return nil, err
+	} else if target.Status.Phase == v1.IntegrationPhaseError {
+		return nil, dryRunError(target)
+	}
+
+	kit, err := dryRunKit(ctx, c, target)
+	if err != nil {
+		return nil, err
+	} else if target.Status.Phase == v1.IntegrationPhaseError {
+		return nil, dryRunError(target)
+	}
+
+	target.SetIntegrationKit(kit)
+	target.Status.Phase = v1.IntegrationPhaseDeploying
+
+	return trait.Apply(ctx, c, target, kit)
+}
+
+// dryRunKit returns the kit the Integration would run with, either an existing kit, or the one that would get built
+func dryRunKit(ctx context.Context, c client.Client, integration *v1.Integration) (*v1.IntegrationKit, error) {
+	if ref := integration.Status.IntegrationKit; ref != nil {
+		kit, err := kubernetes.GetIntegrationKit(ctx, c, ref.Name, ref.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to find integration kit %s/%s", ref.Namespace, ref.Name)
+		}
+		return kit, nil
+	}
+
+	existingKits, err := lookupKitsForIntegration(ctx, c, integration)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := trait.Apply(ctx, c, integration, nil)
+	if err != nil {
+		return nil, err
+	} else if len(env.IntegrationKits) == 0 {
+		return nil, errors.Errorf("no kit computed for integration %s", integration.Name)
+	}
+
+	for _, kit := range env.IntegrationKits {
+		kit := kit
+		for i, k := range existingKits {
+			if k.Status.Phase != v1.IntegrationKitPhaseReady {
+				continue
+			}
+			if match, err := kitMatches(&kit, &k); err != nil {
+				return nil, err
+			} else if match {
+				return &existingKits[i], nil
+			}
+		}
+	}
+
+	kit := env.IntegrationKits[0]
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	return &kit, nil
+}
+
+func dryRunError(integration *v1.Integration) error {
+	messages := make([]string, 0)
+	for _, condition := range integration.Status.Conditions {
+		if condition.Status == corev1.ConditionFalse && condition.Message != "" {
+			messages = append(messages, condition.Message)
+		}
+	}
+	return errors.Errorf("integration %s is not valid: %s", integration.Name, strings.Join(messages, "; "))
+}
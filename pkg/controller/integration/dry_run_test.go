@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestDryRun(t *testing.T) {
+	integration := newSourcesIntegration("- from:\n    uri: \"timer:tick\"\n    steps:\n      - to: \"log:info\"\n")
+	integration.Status = v1.IntegrationStatus{}
+	// The garbage collection relies on the discovery API that is not available with the fake client
+	integration.Spec.Traits = map[string]v1.TraitSpec{
+		"gc": test.TraitSpecFromMap(t, map[string]interface{}{"enabled": false}),
+	}
+
+	c := newDryRunFakeClient(t)
+
+	env, err := DryRun(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.NotNil(t, env)
+
+	deployment := env.Resources.GetDeployment(func(d *appsv1.Deployment) bool {
+		return d.Name == "my-integration"
+	})
+	assert.NotNil(t, deployment)
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	// The kit is not built yet
+	assert.Empty(t, deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []string{"/bin/sh", "-c"}, deployment.Spec.Template.Spec.Containers[0].Command)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Args[0], "java")
+
+	// Nothing is persisted
+	kits := v1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &kits))
+	assert.Empty(t, kits.Items)
+	assert.Empty(t, integration.Status.Phase)
+}
+
+func TestDryRunWithInvalidSources(t *testing.T) {
+	integration := newSourcesIntegration("- from:\n    uri: \"timer:tick\"\n   steps: []\n")
+	integration.Status = v1.IntegrationStatus{}
+
+	c := newDryRunFakeClient(t)
+
+	_, err := DryRun(context.TODO(), c, &integration)
+	assert.EqualError(t, err, "integration my-integration is not valid: invalid source routes.yaml: yaml: line 2: did not find expected key")
+}
+
+func newDryRunFakeClient(t *testing.T) client.Client {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Build.RuntimeVersion = catalog.Runtime.Version
+	pl.Status.Build.PublishStrategy = v1.IntegrationPlatformBuildPublishStrategySpectrum
+	pl.Status.Build.Registry.Address = "registry"
+	pl.Status.Cluster = v1.IntegrationPlatformClusterKubernetes
+
+	c, err := test.NewFakeClient(&pl, &v1.CamelCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "camel-catalog"},
+		Spec:       catalog.CamelCatalogSpec,
+	})
+	assert.Nil(t, err)
+
+	return c
+}
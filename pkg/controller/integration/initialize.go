@@ -19,6 +19,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -26,8 +27,12 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/policy"
 	"github.com/apache/camel-k/pkg/trait"
 	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/source"
+	"github.com/apache/camel-k/pkg/webhook"
 )
 
 // NewInitializeAction creates a new initialize action
@@ -51,10 +56,23 @@ func (action *initializeAction) CanHandle(integration *v1.Integration) bool {
 
 // Handle handles the integrations
 func (action *initializeAction) Handle(ctx context.Context, integration *v1.Integration) (*v1.Integration, error) {
-	if _, err := trait.Apply(ctx, action.client, integration, nil); err != nil {
+	env, err := trait.Apply(ctx, action.client, integration, nil)
+	if err != nil {
 		return nil, err
 	}
 
+	if valid, err := action.validateSources(ctx, env, integration); err != nil {
+		return nil, err
+	} else if !valid {
+		return integration, nil
+	}
+
+	if compliant, err := action.checkPolicy(ctx, env, integration); err != nil {
+		return nil, err
+	} else if !compliant {
+		return integration, nil
+	}
+
 	if integration.Status.IntegrationKit == nil {
 		if integration.Spec.IntegrationKit == nil && integration.Spec.Kit != "" {
 			// TODO: temporary fallback until deprecated field gets removed
@@ -85,6 +103,8 @@ func (action *initializeAction) Handle(ctx context.Context, integration *v1.Inte
 
 	integration.Status.Phase = v1.IntegrationPhaseBuildingKit
 	integration.Status.Version = defaults.Version
+	// The Integration is built with the current version of the operator
+	integration.Status.RemoveCondition(v1.IntegrationConditionUpgradeAvailable)
 	if timestamp := integration.Status.InitializationTimestamp; timestamp == nil || timestamp.IsZero() {
 		now := metav1.Now()
 		integration.Status.InitializationTimestamp = &now
@@ -92,3 +112,80 @@ func (action *initializeAction) Handle(ctx context.Context, integration *v1.Inte
 
 	return integration, nil
 }
+
+// validateSources checks the YAML and XML sources, so that the Integration fails before its kit gets built
+// if they are not valid
+func (action *initializeAction) validateSources(ctx context.Context, env *trait.Environment, integration *v1.Integration) (bool, error) {
+	if env.CamelCatalog == nil {
+		return true, nil
+	}
+
+	sources, err := kubernetes.ResolveIntegrationSources(ctx, action.client, integration, env.Resources)
+	if err != nil {
+		return false, err
+	}
+
+	validated := false
+	for _, s := range sources {
+		if language := s.InferLanguage(); language != v1.LanguageYaml && language != v1.LanguageXML {
+			continue
+		}
+		validated = true
+		if err := source.Validate(env.CamelCatalog, s); err != nil {
+			action.L.Infof("Integration sources are not valid: %s", err.Error())
+			integration.Status.Phase = v1.IntegrationPhaseError
+			integration.Status.SetErrorCondition(
+				v1.IntegrationConditionSourcesValid,
+				v1.IntegrationConditionSourcesNotValidReason,
+				err)
+			return false, nil
+		}
+	}
+
+	if validated {
+		integration.Status.SetCondition(
+			v1.IntegrationConditionSourcesValid,
+			corev1.ConditionTrue,
+			v1.IntegrationConditionSourcesValidReason,
+			"")
+	}
+
+	return true, nil
+}
+
+// checkPolicy enforces the policy of the platform, so that the Integration fails before its kit gets built
+// if it does not comply with it
+func (action *initializeAction) checkPolicy(ctx context.Context, env *trait.Environment, integration *v1.Integration) (bool, error) {
+	if !policy.Enabled(env.Platform) {
+		return true, nil
+	}
+
+	subject, err := trait.NewPolicySubject(env)
+	if err != nil {
+		return false, err
+	}
+
+	subject.Requester = webhook.Requester(integration)
+
+	err = policy.CheckPlatform(ctx, action.client, env.Platform, subject)
+	var violations policy.Violations
+	if errors.As(err, &violations) {
+		action.L.Infof("Integration does not comply with the platform policy: %s", err.Error())
+		integration.Status.Phase = v1.IntegrationPhaseError
+		integration.Status.SetErrorCondition(
+			v1.IntegrationConditionPolicyCompliant,
+			v1.IntegrationConditionPolicyViolatedReason,
+			err)
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	integration.Status.SetCondition(
+		v1.IntegrationConditionPolicyCompliant,
+		corev1.ConditionTrue,
+		v1.IntegrationConditionPolicyCompliantReason,
+		"")
+
+	return true, nil
+}
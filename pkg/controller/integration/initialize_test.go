@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestValidateSources(t *testing.T) {
+	integration := newSourcesIntegration("- from:\n    uri: \"timer:tick\"\n    steps:\n      - to: \"log:info\"\n")
+
+	valid, err := validateIntegrationSources(t, &integration)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, v1.IntegrationPhaseInitialization, integration.Status.Phase)
+
+	condition := integration.Status.GetCondition(v1.IntegrationConditionSourcesValid)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+}
+
+func TestValidateSourcesWithSyntaxError(t *testing.T) {
+	integration := newSourcesIntegration("- from:\n    uri: \"timer:tick\"\n   steps: []\n")
+
+	valid, err := validateIntegrationSources(t, &integration)
+	assert.Nil(t, err)
+	assert.False(t, valid)
+	assert.Equal(t, v1.IntegrationPhaseError, integration.Status.Phase)
+
+	condition := integration.Status.GetCondition(v1.IntegrationConditionSourcesValid)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionSourcesNotValidReason, condition.Reason)
+	assert.Equal(t, "invalid source routes.yaml: yaml: line 2: did not find expected key", condition.Message)
+}
+
+func newSourcesIntegration(content string) v1.Integration {
+	integration := v1.Integration{}
+	integration.Namespace = "ns"
+	integration.Name = "my-integration"
+	integration.Spec.Sources = []v1.SourceSpec{
+		{
+			DataSpec: v1.DataSpec{
+				Name:    "routes.yaml",
+				Content: content,
+			},
+		},
+	}
+	integration.Status.Phase = v1.IntegrationPhaseInitialization
+	return integration
+}
+
+func validateIntegrationSources(t *testing.T, integration *v1.Integration) (bool, error) {
+	t.Helper()
+
+	c, err := test.NewFakeClient(integration)
+	assert.Nil(t, err)
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	a := initializeAction{}
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+
+	env := trait.Environment{
+		CamelCatalog: catalog,
+		Resources:    kubernetes.NewCollection(),
+	}
+
+	return a.validateSources(context.TODO(), &env, integration)
+}
+
+func TestCheckPolicy(t *testing.T) {
+	integration := newSourcesIntegration("- from:\n    uri: \"exec:ls\"\n    steps:\n      - to: \"log:info\"\n")
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	p := v1.NewIntegrationPlatform("ns", "camel-k")
+	p.Status.Policy = v1.IntegrationPlatformPolicySpec{
+		ForbiddenComponents: []string{"camel-exec"},
+	}
+
+	a := initializeAction{}
+	a.InjectLogger(log.Log)
+
+	env := trait.Environment{
+		CamelCatalog: catalog,
+		Platform:     &p,
+		Integration:  &integration,
+	}
+
+	compliant, err := a.checkPolicy(context.TODO(), &env, &integration)
+	assert.Nil(t, err)
+	assert.False(t, compliant)
+	assert.Equal(t, v1.IntegrationPhaseError, integration.Status.Phase)
+
+	condition := integration.Status.GetCondition(v1.IntegrationConditionPolicyCompliant)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionPolicyViolatedReason, condition.Reason)
+	assert.Equal(t, "integration does not comply with the platform policy: "+
+		"component exec is forbidden, remove the endpoints and dependencies using it", condition.Message)
+}
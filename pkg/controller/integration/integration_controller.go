@@ -19,9 +19,12 @@ package integration
 
 import (
 	"context"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,9 +32,11 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -40,9 +45,12 @@ import (
 	"github.com/apache/camel-k/pkg/client"
 	camelevent "github.com/apache/camel-k/pkg/event"
 	"github.com/apache/camel-k/pkg/platform"
-	"github.com/apache/camel-k/pkg/util/digest"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/audit"
 	"github.com/apache/camel-k/pkg/util/log"
 	"github.com/apache/camel-k/pkg/util/monitoring"
+	"github.com/apache/camel-k/pkg/util/ratelimit"
+	"github.com/apache/camel-k/pkg/util/telemetry"
 )
 
 func Add(mgr manager.Manager) error {
@@ -50,6 +58,9 @@ func Add(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
+	if err := metrics.Registry.Register(newIntegrationCollector(c)); err != nil {
+		return err
+	}
 	return add(mgr, newReconciler(mgr, c))
 }
 
@@ -68,31 +79,83 @@ func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
 	)
 }
 
+// referencesIndex is the field index of the Integrations by the ConfigMaps and Secrets they reference, as
+// <kind>/<name>, so that the changes of these resources are mapped to the Integrations without listing them all
+const referencesIndex = "references"
+
+// integrationReferences returns the ConfigMaps and Secrets referenced by the given Integration, i.e., the Secrets
+// of its configuration, and the resources its sources and resources are referenced from
+func integrationReferences(obj ctrl.Object) []string {
+	integration := obj.(*v1.Integration)
+	var references []string
+	for _, c := range integration.Status.Configuration {
+		if c.Type == "secret" {
+			util.StringSliceUniqueAdd(&references, v1.ContentRefKindSecret+"/"+c.Value)
+		}
+	}
+	for _, s := range integration.Spec.Sources {
+		if s.ContentRef != "" {
+			util.StringSliceUniqueAdd(&references, s.GetContentRefKind()+"/"+s.ContentRef)
+		}
+	}
+	for _, r := range integration.Spec.Resources {
+		if r.ContentRef != "" {
+			util.StringSliceUniqueAdd(&references, r.GetContentRefKind()+"/"+r.ContentRef)
+		}
+	}
+	return references
+}
+
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	limiter := ratelimit.NewRateLimiter("integration-controller", ratelimit.OptionsFromEnv())
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1.Integration{}, referencesIndex, integrationReferences); err != nil {
+		return err
+	}
+
 	return builder.ControllerManagedBy(mgr).
 		Named("integration-controller").
-		// Watch for changes to primary resource Integration
+		WithOptions(controller.Options{RateLimiter: limiter}).
+		// Watch for changes to primary resource Integration. The changes made by the users are
+		// processed right away, while the other requests are throttled.
 		For(&v1.Integration{}, builder.WithPredicates(
 			predicate.Funcs{
 				UpdateFunc: func(e event.UpdateEvent) bool {
-					oldIntegration := e.ObjectOld.(*v1.Integration)
-					newIntegration := e.ObjectNew.(*v1.Integration)
 					// Ignore updates to the integration status in which case metadata.Generation does not change,
-					// or except when the integration phase changes as it's used to transition from one phase
-					// to another.
-					return oldIntegration.Generation != newIntegration.Generation ||
-						oldIntegration.Status.Phase != newIntegration.Status.Phase
+					// unless a Job is triggered out of schedule
+					return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+						e.ObjectOld.GetAnnotations()[v1.TriggerAnnotation] != e.ObjectNew.GetAnnotations()[v1.TriggerAnnotation]
 				},
 				DeleteFunc: func(e event.DeleteEvent) bool {
 					// Evaluates to false if the object has been confirmed deleted
 					return !e.DeleteStateUnknown
 				},
 			})).
+		// Watch for the integration phase transitions, as they're used to transition from one phase to another
+		Watches(&source.Kind{Type: &v1.Integration{}},
+			ratelimit.Throttle(&handler.EnqueueRequestForObject{}, limiter),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					return false
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldIntegration := e.ObjectOld.(*v1.Integration)
+					newIntegration := e.ObjectNew.(*v1.Integration)
+					return oldIntegration.Generation == newIntegration.Generation &&
+						oldIntegration.Status.Phase != newIntegration.Status.Phase
+				},
+				DeleteFunc: func(e event.DeleteEvent) bool {
+					return false
+				},
+				GenericFunc: func(e event.GenericEvent) bool {
+					return false
+				},
+			})).
 		// Watch for IntegrationKit phase transitioning to ready or error, and
 		// enqueue requests for any integration that matches the kit, in building
 		// or running phase.
 		Watches(&source.Kind{Type: &v1.IntegrationKit{}},
-			handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
+			ratelimit.Throttle(handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
 				kit := a.(*v1.IntegrationKit)
 				var requests []reconcile.Request
 
@@ -131,11 +194,11 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 				}
 
 				return requests
-			})).
+			}), limiter)).
 		// Watch for IntegrationPlatform phase transitioning to ready and enqueue
 		// requests for any integrations that are in phase waiting for platform
 		Watches(&source.Kind{Type: &v1.IntegrationPlatform{}},
-			handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
+			ratelimit.Throttle(handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
 				p := a.(*v1.IntegrationPlatform)
 				var requests []reconcile.Request
 
@@ -167,10 +230,58 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 				}
 
 				return requests
-			})).
+			}), limiter)).
+		// Watch for the Secrets mounted by running integrations, so that the traits depending
+		// on their content (e.g. credentials rotation) are re-applied when they change. The Secrets and
+		// the ConfigMaps are read from the cache by the traits already, that can be restricted with the
+		// cache selectors of the operator, and only the Integrations that reference them are looked up.
+		Watches(&source.Kind{Type: &corev1.Secret{}},
+			ratelimit.Throttle(handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
+				secret := a.(*corev1.Secret)
+				var requests []reconcile.Request
+
+				list := &v1.IntegrationList{}
+				if err := mgr.GetClient().List(context.Background(), list,
+					ctrl.InNamespace(secret.Namespace),
+					ctrl.MatchingFields{referencesIndex: v1.ContentRefKindSecret + "/" + secret.Name},
+				); err != nil {
+					log.Error(err, "Failed to list integrations")
+					return requests
+				}
+
+				for _, integration := range list.Items {
+					if integration.Status.Phase != v1.IntegrationPhaseRunning {
+						continue
+					}
+					for _, c := range integration.Status.Configuration {
+						if c.Type == "secret" && c.Value == secret.Name {
+							log.Infof("Secret %s changed, notify integration: %s", secret.Name, integration.Name)
+							requests = append(requests, reconcile.Request{
+								NamespacedName: types.NamespacedName{
+									Namespace: integration.Namespace,
+									Name:      integration.Name,
+								},
+							})
+							break
+						}
+					}
+				}
+
+				requests = append(requests, propertyReferencesRequests(mgr.GetClient(), "secret", secret)...)
+				return append(requests, contentReferencesRequests(mgr.GetClient(), v1.ContentRefKindSecret, secret)...)
+			}), limiter),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		// Watch for the ConfigMaps the integration properties are resolved from, and the integration sources
+		// and resources are referenced from
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}},
+			ratelimit.Throttle(handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
+				requests := propertyReferencesRequests(mgr.GetClient(), "configmap", a)
+				return append(requests, contentReferencesRequests(mgr.GetClient(), v1.ContentRefKindConfigMap, a)...)
+			}), limiter),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
 		// Watch for the Integration Pods
 		Watches(&source.Kind{Type: &corev1.Pod{}},
-			handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
+			ratelimit.Throttle(handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
 				pod := a.(*corev1.Pod)
 				return []reconcile.Request{
 					{
@@ -180,10 +291,110 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 						},
 					},
 				}
-			})).
+			}), limiter)).
 		Complete(r)
 }
 
+// propertyReferencesRequests returns the requests for the Integrations whose properties are resolved from
+// the given ConfigMap or Secret, as tracked by the annotation of their resolved, or reloadable, properties Secrets
+func propertyReferencesRequests(c ctrl.Reader, kind string, object ctrl.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	selector, err := labels.Parse("camel.apache.org/properties.type in (resolved,reloadable)")
+	if err != nil {
+		log.Error(err, "Failed to parse the resolved properties selector")
+		return requests
+	}
+
+	// The SecretList type is also registered by the OpenShift image API, so the kind is set explicitly
+	list := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{
+			"apiVersion": corev1.SchemeGroupVersion.String(),
+			"kind":       "SecretList",
+		},
+	}
+	if err := c.List(context.Background(), list,
+		ctrl.InNamespace(object.GetNamespace()),
+		ctrl.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		log.Error(err, "Failed to list resolved properties")
+		return requests
+	}
+
+	reference := kind + "/" + object.GetName()
+	for _, secret := range list.Items {
+		integration := secret.GetLabels()[v1.IntegrationLabel]
+		if integration == "" {
+			continue
+		}
+		for _, r := range strings.Split(secret.GetAnnotations()[v1.PropertyReferencesAnnotation], ",") {
+			if r == reference {
+				log.Infof("%s changed, notify integration: %s", reference, integration)
+				request := reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: secret.GetNamespace(),
+						Name:      integration,
+					},
+				}
+				if !containsRequest(requests, request) {
+					requests = append(requests, request)
+				}
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// contentReferencesRequests returns the requests for the Integrations whose sources or resources are referenced
+// from the given ConfigMap or Secret, so that they are rolled out when its content changes
+func contentReferencesRequests(c ctrl.Reader, kind string, object ctrl.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	list := &v1.IntegrationList{}
+	if err := c.List(context.Background(), list,
+		ctrl.InNamespace(object.GetNamespace()),
+		ctrl.MatchingFields{referencesIndex: kind + "/" + object.GetName()},
+	); err != nil {
+		log.Error(err, "Failed to list integrations")
+		return requests
+	}
+
+	for _, integration := range list.Items {
+		data := make([]v1.DataSpec, 0, len(integration.Spec.Sources)+len(integration.Spec.Resources))
+		for _, s := range integration.Spec.Sources {
+			data = append(data, s.DataSpec)
+		}
+		for _, r := range integration.Spec.Resources {
+			data = append(data, r.DataSpec)
+		}
+		for _, d := range data {
+			if d.ContentRef == object.GetName() && d.GetContentRefKind() == kind {
+				log.Infof("%s/%s changed, notify integration: %s", kind, object.GetName(), integration.Name)
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: integration.Namespace,
+						Name:      integration.Name,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+func containsRequest(requests []reconcile.Request, request reconcile.Request) bool {
+	for _, r := range requests {
+		if r == request {
+			return true
+		}
+	}
+	return false
+}
+
 var _ reconcile.Reconciler = &reconcileIntegration{}
 
 // reconcileIntegration reconciles an Integration object
@@ -226,6 +437,9 @@ func (r *reconcileIntegration) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	// The mutations performed while reconciling the integration are audited as triggered by it
+	ctx = audit.WithTrigger(ctx, &instance)
+
 	target := instance.DeepCopy()
 	targetLog := rlog.ForIntegration(target)
 
@@ -243,7 +457,9 @@ func (r *reconcileIntegration) Reconcile(ctx context.Context, request reconcile.
 		if a.CanHandle(target) {
 			targetLog.Infof("Invoking action %s", a.Name())
 
-			newTarget, err := a.Handle(ctx, target)
+			actionCtx, span := telemetry.StartActionSpan(ctx, "Integration", a.Name(), string(target.Status.Phase))
+			newTarget, err := a.Handle(actionCtx, target)
+			telemetry.EndSpan(span, err)
 			if err != nil {
 				camelevent.NotifyIntegrationError(ctx, r.client, r.recorder, &instance, newTarget, err)
 				return reconcile.Result{}, err
@@ -267,20 +483,40 @@ func (r *reconcileIntegration) Reconcile(ctx context.Context, request reconcile.
 			// handle one action at time so the resource
 			// is always at its latest state
 			camelevent.NotifyIntegrationUpdated(ctx, r.client, r.recorder, &instance, newTarget)
+
+			if newTarget != nil {
+				target = newTarget
+			}
 			break
 		}
 	}
 
+	if c := target.Status.GetCondition(v1.IntegrationConditionTestsPassed); c != nil && c.Status == corev1.ConditionFalse {
+		// Run the failing tests again, as no change to the Integration may trigger a reconciliation
+		return reconcile.Result{RequeueAfter: testsRetryPeriod}, nil
+	}
+
+	if c := target.Status.GetCondition(v1.IntegrationConditionRedeployDeferred); c != nil && c.Status == corev1.ConditionTrue {
+		// Redeploy the Integration once its maintenance window starts
+		period, err := redeployDeferredPeriod(ctx, r.client, target)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: period}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
 func (r *reconcileIntegration) update(ctx context.Context, base *v1.Integration, target *v1.Integration) (reconcile.Result, error) {
-	d, err := digest.ComputeForIntegration(target)
+	d, err := computeDigest(ctx, r.client, target)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	target.Status.Digest = d
+	target.Status.ObservedGeneration = base.Generation
+	target.Status.SetPhaseConditions()
 
 	err = r.client.Status().Patch(ctx, target, ctrl.MergeFrom(base))
 
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestPropertyReferencesRequests(t *testing.T) {
+	c, err := test.NewFakeClient(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "my-integration-resolved-properties",
+				Labels: map[string]string{
+					v1.IntegrationLabel:                "my-integration",
+					"camel.apache.org/properties.type": "resolved",
+				},
+				Annotations: map[string]string{
+					v1.PropertyReferencesAnnotation: "configmap/db,secret/db-credentials",
+				},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "my-integration-reloadable-properties",
+				Labels: map[string]string{
+					v1.IntegrationLabel:                "my-integration",
+					"camel.apache.org/properties.type": "reloadable",
+				},
+				Annotations: map[string]string{
+					v1.PropertyReferencesAnnotation: "configmap/tuning,secret/db-credentials",
+				},
+			},
+		},
+	)
+	assert.Nil(t, err)
+
+	secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "db-credentials"}}
+	requests := propertyReferencesRequests(c, "secret", &secret)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "ns", requests[0].Namespace)
+	assert.Equal(t, "my-integration", requests[0].Name)
+
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "db-credentials"}}
+	assert.Empty(t, propertyReferencesRequests(c, "configmap", &cm))
+
+	cm = corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tuning"}}
+	requests = propertyReferencesRequests(c, "configmap", &cm)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "my-integration", requests[0].Name)
+
+	cm = corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "db"}}
+	assert.Empty(t, propertyReferencesRequests(c, "configmap", &cm))
+}
+
+func TestContentReferencesRequests(t *testing.T) {
+	it := v1.NewIntegration("ns", "my-integration")
+	it.Spec.Sources = []v1.SourceSpec{
+		{DataSpec: v1.DataSpec{Name: "routes.yaml", ContentRef: "routes", ContentKey: "routes.yaml"}},
+		{DataSpec: v1.DataSpec{Name: "Secured.java", ContentRef: "routes", ContentKey: "Secured.java", ContentRefKind: v1.ContentRefKindSecret}},
+	}
+	it.Spec.Resources = []v1.ResourceSpec{
+		{DataSpec: v1.DataSpec{Name: "schema.json", ContentRef: "schemas"}},
+	}
+	c, err := test.NewFakeClient(&it)
+	assert.Nil(t, err)
+
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "routes"}}
+	requests := contentReferencesRequests(c, v1.ContentRefKindConfigMap, &cm)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "ns", requests[0].Namespace)
+	assert.Equal(t, "my-integration", requests[0].Name)
+
+	secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "routes"}}
+	assert.Len(t, contentReferencesRequests(c, v1.ContentRefKindSecret, &secret), 1)
+
+	cm = corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "schemas"}}
+	assert.Len(t, contentReferencesRequests(c, v1.ContentRefKindConfigMap, &cm), 1)
+
+	secret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "schemas"}}
+	assert.Empty(t, contentReferencesRequests(c, v1.ContentRefKindSecret, &secret))
+
+	cm = corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "routes"}}
+	assert.Empty(t, contentReferencesRequests(c, v1.ContentRefKindConfigMap, &cm))
+}
+
+func TestIntegrationReferences(t *testing.T) {
+	it := v1.NewIntegration("ns", "my-integration")
+	it.Spec.Sources = []v1.SourceSpec{
+		{DataSpec: v1.DataSpec{Name: "routes.yaml", ContentRef: "routes", ContentKey: "routes.yaml"}},
+		{DataSpec: v1.DataSpec{Name: "Secured.java", ContentRef: "routes", ContentKey: "Secured.java", ContentRefKind: v1.ContentRefKindSecret}},
+		{DataSpec: v1.DataSpec{Name: "Inline.java", Content: "content"}},
+	}
+	it.Spec.Resources = []v1.ResourceSpec{
+		{DataSpec: v1.DataSpec{Name: "schema.json", ContentRef: "routes"}},
+	}
+	it.Status.Configuration = []v1.ConfigurationSpec{
+		{Type: "secret", Value: "db-credentials"},
+		{Type: "configmap", Value: "tuning"},
+		{Type: "property", Value: "key=value"},
+	}
+
+	assert.ElementsMatch(t, []string{"secret/db-credentials", "configmap/routes", "secret/routes"}, integrationReferences(&it))
+}
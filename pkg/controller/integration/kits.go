@@ -22,6 +22,8 @@ import (
 	"encoding/json"
 	"reflect"
 
+	"github.com/scylladb/go-set/strset"
+
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -66,6 +68,11 @@ func lookupKitsForIntegration(ctx context.Context, c ctrl.Reader, integration *v
 		return nil, err
 	}
 
+	architectures, err := requiredArchitectures(integration, pl)
+	if err != nil {
+		return nil, err
+	}
+
 	kits := make([]v1.IntegrationKit, 0)
 	for _, kit := range list.Items {
 		match, err := integrationMatches(integration, &kit)
@@ -74,12 +81,39 @@ func lookupKitsForIntegration(ctx context.Context, c ctrl.Reader, integration *v
 		} else if !match {
 			continue
 		}
+		if kit.Status.Phase == v1.IntegrationKitPhaseReady && !util.StringSliceContains(kit.Status.Architectures, architectures) {
+			continue
+		}
 		kits = append(kits, kit)
 	}
 
 	return kits, nil
 }
 
+// requiredArchitectures returns the architectures the kit of the given integration must be available for,
+// either configured with the builder trait, or defaulting to the ones of the platform
+func requiredArchitectures(integration *v1.Integration, pl *v1.IntegrationPlatform) ([]string, error) {
+	if spec, ok := integration.Spec.Traits["builder"]; ok {
+		builder := struct {
+			Architectures []string `json:"architectures,omitempty"`
+		}{}
+		data, err := json.Marshal(spec.Configuration)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &builder); err != nil {
+			return nil, err
+		}
+		if len(builder.Architectures) > 0 {
+			return builder.Architectures, nil
+		}
+	}
+	if pl != nil {
+		return pl.Status.Build.Architectures, nil
+	}
+	return nil, nil
+}
+
 // integrationMatches returns whether the v1.IntegrationKit meets the requirements of the v1.Integration
 func integrationMatches(integration *v1.Integration, kit *v1.IntegrationKit) (bool, error) {
 	if kit.Status.Phase == v1.IntegrationKitPhaseError {
@@ -113,6 +147,14 @@ func integrationMatches(integration *v1.Integration, kit *v1.IntegrationKit) (bo
 	if !util.StringSliceContains(kit.Spec.Dependencies, integration.Status.Dependencies) {
 		return false, nil
 	}
+	// A kit built with locked dependencies can only be used by an integration with the same lock
+	var locked []string
+	if integration.Spec.DependencyLock != nil {
+		locked = integration.Spec.DependencyLock.Dependencies
+	}
+	if !strset.New(locked...).IsEqual(strset.New(kit.Spec.LockedDependencies...)) {
+		return false, nil
+	}
 	return true, nil
 }
 
@@ -135,6 +177,9 @@ func kitMatches(kit1 *v1.IntegrationKit, kit2 *v1.IntegrationKit) (bool, error)
 	if !util.StringSliceContains(kit1.Spec.Dependencies, kit2.Spec.Dependencies) {
 		return false, nil
 	}
+	if !strset.New(kit1.Spec.LockedDependencies...).IsEqual(strset.New(kit2.Spec.LockedDependencies...)) {
+		return false, nil
+	}
 	return true, nil
 }
 
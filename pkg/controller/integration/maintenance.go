@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/platform"
+)
+
+// deferRedeploy returns whether the given redeploy of the Integration, that is not initiated by a change of its
+// specification, is deferred to its maintenance window, in which case it is reported with the RedeployDeferred condition
+func deferRedeploy(ctx context.Context, c ctrl.Reader, integration *v1.Integration, change string) (bool, error) {
+	window, err := platform.LookupMaintenanceWindow(ctx, c, integration)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	if window == nil || window.Contains(now) {
+		integration.Status.RemoveCondition(v1.IntegrationConditionRedeployDeferred)
+		return false, nil
+	}
+
+	integration.Status.SetCondition(v1.IntegrationConditionRedeployDeferred, corev1.ConditionTrue,
+		v1.IntegrationConditionMaintenanceWindowReason,
+		fmt.Sprintf("%s is deferred to the maintenance window starting at %s", change, window.Next(now).Format(time.RFC3339)))
+	return true, nil
+}
+
+// redeployDeferredPeriod returns the period after which the Integration, whose redeploy is deferred,
+// is reconciled again, i.e., at the start of its next maintenance window
+func redeployDeferredPeriod(ctx context.Context, c ctrl.Reader, integration *v1.Integration) (time.Duration, error) {
+	window, err := platform.LookupMaintenanceWindow(ctx, c, integration)
+	if err != nil {
+		return 0, err
+	}
+	period := time.Second
+	if now := time.Now(); window != nil && !window.Contains(now) {
+		period += window.Next(now).Sub(now)
+	}
+	return period, nil
+}
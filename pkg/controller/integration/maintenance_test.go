@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestDeferRedeploy(t *testing.T) {
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	// A daily window that starts 12 hours from now
+	pl.Status.Maintenance.Window = fmt.Sprintf("0 %d * * *", (time.Now().UTC().Hour()+12)%24)
+
+	c, err := test.NewFakeClient(&pl)
+	require.NoError(t, err)
+
+	it := v1.NewIntegration("ns", "my-integration")
+	it.Status.Platform = "camel-k"
+
+	deferred, err := deferRedeploy(context.TODO(), c, &it, "the switch to the kit kit-2 of higher priority")
+	require.NoError(t, err)
+	assert.True(t, deferred)
+	condition := it.Status.GetCondition(v1.IntegrationConditionRedeployDeferred)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionMaintenanceWindowReason, condition.Reason)
+	assert.Contains(t, condition.Message, "the switch to the kit kit-2 of higher priority is deferred to the maintenance window starting at")
+
+	period, err := redeployDeferredPeriod(context.TODO(), c, &it)
+	require.NoError(t, err)
+	assert.True(t, period > 11*time.Hour && period <= 12*time.Hour+time.Second, period)
+
+	// The Integration opts out of the maintenance window
+	it.Annotations = map[string]string{v1.MaintenanceWindowAnnotation: "none"}
+	deferred, err = deferRedeploy(context.TODO(), c, &it, "the switch to the kit kit-2 of higher priority")
+	require.NoError(t, err)
+	assert.False(t, deferred)
+	assert.Nil(t, it.Status.GetCondition(v1.IntegrationConditionRedeployDeferred))
+
+	// The redeploy is not deferred within the window
+	it.Annotations[v1.MaintenanceWindowAnnotation] = "* * * * *"
+	deferred, err = deferRedeploy(context.TODO(), c, &it, "the switch to the kit kit-2 of higher priority")
+	require.NoError(t, err)
+	assert.False(t, deferred)
+}
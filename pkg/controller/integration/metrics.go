@@ -18,11 +18,27 @@ limitations under the License.
 package integration
 
 import (
+	"context"
 	"time"
 
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+const (
+	kitResultLabel        = "result"
+	kitTypeLabel          = "type"
+	integrationPhaseLabel = "phase"
+	namespaceLabel        = "namespace"
+
+	kitResultReused  = "reused"
+	kitResultCreated = "created"
+
+	collectTimeout = 10 * time.Second
 )
 
 var (
@@ -39,9 +55,70 @@ var (
 			},
 		},
 	)
+
+	kitLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "camel_k_integration_kit_lookups_total",
+			Help: "Camel K integration kits reused or created for the integrations",
+		},
+		[]string{
+			kitResultLabel,
+			kitTypeLabel,
+		},
+	)
+)
+
+var integrationsDesc = prometheus.NewDesc(
+	"camel_k_integrations",
+	"Camel K integrations per phase",
+	[]string{
+		namespaceLabel,
+		integrationPhaseLabel,
+	},
+	nil,
 )
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(timeToFirstReadiness)
+	metrics.Registry.MustRegister(timeToFirstReadiness, kitLookups)
+}
+
+func observeKitLookup(kit *v1.IntegrationKit, reused bool) {
+	result := kitResultCreated
+	if reused {
+		result = kitResultReused
+	}
+	kitLookups.WithLabelValues(result, kit.Labels[v1.IntegrationKitLayoutLabel]).Inc()
+}
+
+// integrationCollector counts the Integrations per namespace and phase at scrape time
+type integrationCollector struct {
+	reader ctrl.Reader
+}
+
+func newIntegrationCollector(reader ctrl.Reader) prometheus.Collector {
+	return &integrationCollector{reader: reader}
+}
+
+func (c *integrationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- integrationsDesc
+}
+
+func (c *integrationCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	integrations := v1.NewIntegrationList()
+	if err := c.reader.List(ctx, &integrations); err != nil {
+		ch <- prometheus.NewInvalidMetric(integrationsDesc, err)
+		return
+	}
+
+	counts := make(map[[2]string]int)
+	for _, integration := range integrations.Items {
+		counts[[2]string{integration.Namespace, string(integration.Status.Phase)}]++
+	}
+	for labels, count := range counts {
+		ch <- prometheus.MustNewConstMetric(integrationsDesc, prometheus.GaugeValue, float64(count), labels[:]...)
+	}
 }
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestIntegrationCollector(t *testing.T) {
+	integration := func(namespace, name string, phase v1.IntegrationPhase) *v1.Integration {
+		return &v1.Integration{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Status: v1.IntegrationStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	c, err := test.NewFakeClient(
+		integration("ns1", "a", v1.IntegrationPhaseRunning),
+		integration("ns1", "b", v1.IntegrationPhaseRunning),
+		integration("ns1", "c", v1.IntegrationPhaseError),
+		integration("ns2", "a", v1.IntegrationPhaseBuildingKit),
+	)
+	assert.Nil(t, err)
+
+	expected := `
+# HELP camel_k_integrations Camel K integrations per phase
+# TYPE camel_k_integrations gauge
+camel_k_integrations{namespace="ns1",phase="Error"} 1
+camel_k_integrations{namespace="ns1",phase="Running"} 2
+camel_k_integrations{namespace="ns2",phase="Building Kit"} 1
+`
+	assert.Nil(t, testutil.CollectAndCompare(newIntegrationCollector(c), strings.NewReader(expected)))
+}
+
+func TestObserveKitLookup(t *testing.T) {
+	kit := v1.NewIntegrationKit("ns", "my-kit")
+	kit.Labels = map[string]string{v1.IntegrationKitLayoutLabel: v1.IntegrationKitLayoutFastJar}
+
+	reused := kitLookups.WithLabelValues(kitResultReused, v1.IntegrationKitLayoutFastJar)
+	created := kitLookups.WithLabelValues(kitResultCreated, v1.IntegrationKitLayoutFastJar)
+	reusedCount, createdCount := testutil.ToFloat64(reused), testutil.ToFloat64(created)
+
+	observeKitLookup(kit, true)
+	observeKitLookup(kit, false)
+	observeKitLookup(kit, false)
+
+	assert.Equal(t, reusedCount+1, testutil.ToFloat64(reused))
+	assert.Equal(t, createdCount+2, testutil.ToFloat64(created))
+}
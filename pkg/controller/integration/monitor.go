@@ -19,6 +19,7 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -32,7 +33,6 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/trait"
-	"github.com/apache/camel-k/pkg/util/digest"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
@@ -55,13 +55,8 @@ func (action *monitorAction) CanHandle(integration *v1.Integration) bool {
 }
 
 func (action *monitorAction) Handle(ctx context.Context, integration *v1.Integration) (*v1.Integration, error) {
-	// At that staged the Integration must have a Kit
-	if integration.Status.IntegrationKit == nil {
-		return nil, errors.Errorf("no kit set on integration %s", integration.Name)
-	}
-
 	// Check if the Integration requires a rebuild
-	hash, err := digest.ComputeForIntegration(integration)
+	hash, err := computeDigest(ctx, action.client, integration)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +70,17 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1.Integra
 		return integration, nil
 	}
 
+	// The Integration may have failed before its kit is set, e.g., when its sources are not valid,
+	// in which case it remains in error until it is updated
+	if integration.Status.IntegrationKit == nil && integration.Status.Phase == v1.IntegrationPhaseError {
+		return nil, nil
+	}
+
+	// At that staged the Integration must have a Kit
+	if integration.Status.IntegrationKit == nil {
+		return nil, errors.Errorf("no kit set on integration %s", integration.Name)
+	}
+
 	kit, err := kubernetes.GetIntegrationKit(ctx, action.client, integration.Status.IntegrationKit.Name, integration.Status.IntegrationKit.Namespace)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to find integration kit %s/%s, %s", integration.Status.IntegrationKit.Namespace, integration.Status.IntegrationKit.Name, err)
@@ -100,7 +106,17 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1.Integra
 		return nil, err
 	}
 	if priorityReadyKit != nil {
-		integration.SetIntegrationKit(priorityReadyKit)
+		// The switch to the kit is deferred to the maintenance window, as it is not initiated by a change of the Integration
+		deferred, err := deferRedeploy(ctx, action.client, integration,
+			fmt.Sprintf("the switch to the kit %s of higher priority", priorityReadyKit.Name))
+		if err != nil {
+			return nil, err
+		}
+		if !deferred {
+			integration.SetIntegrationKit(priorityReadyKit)
+		}
+	} else {
+		integration.Status.RemoveCondition(v1.IntegrationConditionRedeployDeferred)
 	}
 
 	// Run traits that are enabled for the phase
@@ -109,6 +125,10 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1.Integra
 		return nil, err
 	}
 
+	if err := action.triggerCronJob(ctx, integration); err != nil {
+		return nil, err
+	}
+
 	// Enforce the scale sub-resource label selector.
 	// It is used by the HPA that queries the scale sub-resource endpoint,
 	// to list the pods owned by the integration.
@@ -150,6 +170,8 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1.Integra
 	// into the owning integration
 	previous := integration.Status.GetCondition(v1.IntegrationConditionReady)
 	kubernetes.MirrorReadyCondition(ctx, action.client, integration)
+	// The Integration is only ready once its tests pass
+	action.runTests(ctx, integration)
 
 	if next := integration.Status.GetCondition(v1.IntegrationConditionReady); (previous == nil || previous.FirstTruthyTime == nil || previous.FirstTruthyTime.IsZero()) &&
 		next != nil && next.Status == corev1.ConditionTrue && !(next.FirstTruthyTime == nil || next.FirstTruthyTime.IsZero()) {
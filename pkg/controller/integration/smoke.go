@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// testsRetryPeriod is the delay after which the failing tests of an Integration are run again
+const testsRetryPeriod = 10 * time.Second
+
+var testHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// testURL returns the URL of the Integration Service, that the HTTP tests send their requests to
+var testURL = func(integration *v1.Integration, port int32, path string) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d%s", integration.Name, integration.Namespace, port, path)
+}
+
+// runTests runs the tests of the Integration once it is ready, and holds its readiness until they pass
+func (action *monitorAction) runTests(ctx context.Context, integration *v1.Integration) {
+	if len(integration.Spec.Tests) == 0 {
+		return
+	}
+	if ready := integration.Status.GetCondition(v1.IntegrationConditionReady); ready == nil || ready.Status != corev1.ConditionTrue {
+		return
+	}
+	if passed := integration.Status.GetCondition(v1.IntegrationConditionTestsPassed); passed != nil && passed.Status == corev1.ConditionTrue {
+		return
+	}
+
+	failures := make([]string, 0)
+	for _, test := range integration.Spec.Tests {
+		if err := runTest(ctx, integration, test); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", test.Name, err.Error()))
+		}
+	}
+
+	if len(failures) > 0 {
+		message := strings.Join(failures, "; ")
+		action.L.Infof("Integration tests failed: %s", message)
+		integration.Status.SetCondition(v1.IntegrationConditionTestsPassed, corev1.ConditionFalse,
+			v1.IntegrationConditionTestsFailedReason, message)
+		integration.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionFalse,
+			v1.IntegrationConditionTestsFailedReason, "The integration tests failed: "+message)
+		return
+	}
+
+	integration.Status.SetCondition(v1.IntegrationConditionTestsPassed, corev1.ConditionTrue,
+		v1.IntegrationConditionTestsPassedReason, fmt.Sprintf("%d test(s) passed", len(integration.Spec.Tests)))
+}
+
+func runTest(ctx context.Context, integration *v1.Integration, test v1.IntegrationTest) error {
+	if test.HTTP == nil {
+		return errors.New("no test defined")
+	}
+
+	port := test.HTTP.Port
+	if port == 0 {
+		port = 80
+	}
+	path := test.HTTP.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	method := test.HTTP.Method
+	if method == "" {
+		if test.HTTP.Body != "" {
+			method = http.MethodPost
+		} else {
+			method = http.MethodGet
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, testURL(integration, port, path), strings.NewReader(test.HTTP.Body))
+	if err != nil {
+		return err
+	}
+	for name, value := range test.HTTP.Headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := testHTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	expectedStatus := int(test.HTTP.ExpectedStatus)
+	if expectedStatus == 0 {
+		expectedStatus = http.StatusOK
+	}
+	if response.StatusCode != expectedStatus {
+		return fmt.Errorf("expected status %d, got %d", expectedStatus, response.StatusCode)
+	}
+
+	if test.HTTP.ExpectedBody != "" {
+		body, err := ioutil.ReadAll(io.LimitReader(response.Body, 1<<20))
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(body), test.HTTP.ExpectedBody) {
+			return fmt.Errorf("the response body does not contain %q", test.HTTP.ExpectedBody)
+		}
+	}
+
+	return nil
+}
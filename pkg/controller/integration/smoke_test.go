@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+func TestRunTests(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	integration := newTestsIntegration(
+		v1.IntegrationTest{
+			Name: "health",
+			HTTP: &v1.IntegrationTestHTTP{Path: "health"},
+		},
+		v1.IntegrationTest{
+			Name: "echo",
+			HTTP: &v1.IntegrationTestHTTP{
+				Path:         "/echo",
+				Headers:      map[string]string{"Content-Type": "text/plain"},
+				Body:         "hello",
+				ExpectedBody: "HELLO",
+			},
+		},
+	)
+
+	newTestsAction().runTests(context.TODO(), integration)
+
+	condition := integration.Status.GetCondition(v1.IntegrationConditionTestsPassed)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "2 test(s) passed", condition.Message)
+	assert.Equal(t, corev1.ConditionTrue, integration.Status.GetCondition(v1.IntegrationConditionReady).Status)
+}
+
+func TestRunFailingTests(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	integration := newTestsIntegration(
+		v1.IntegrationTest{
+			Name: "health",
+			HTTP: &v1.IntegrationTestHTTP{Path: "/health", ExpectedStatus: http.StatusNoContent},
+		},
+		v1.IntegrationTest{
+			Name: "echo",
+			HTTP: &v1.IntegrationTestHTTP{Path: "/echo", Body: "hello", ExpectedBody: "WORLD"},
+		},
+	)
+
+	newTestsAction().runTests(context.TODO(), integration)
+
+	condition := integration.Status.GetCondition(v1.IntegrationConditionTestsPassed)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionTestsFailedReason, condition.Reason)
+	assert.Equal(t, `health: expected status 204, got 200; echo: the response body does not contain "WORLD"`, condition.Message)
+
+	ready := integration.Status.GetCondition(v1.IntegrationConditionReady)
+	assert.Equal(t, corev1.ConditionFalse, ready.Status)
+	assert.Equal(t, v1.IntegrationConditionTestsFailedReason, ready.Reason)
+}
+
+func TestRunTestsWhenNotReady(t *testing.T) {
+	integration := newTestsIntegration(v1.IntegrationTest{
+		Name: "health",
+		HTTP: &v1.IntegrationTestHTTP{Path: "/health"},
+	})
+	integration.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionFalse, v1.IntegrationConditionReplicaSetNotReadyReason, "")
+
+	newTestsAction().runTests(context.TODO(), integration)
+
+	assert.Nil(t, integration.Status.GetCondition(v1.IntegrationConditionTestsPassed))
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.WriteHeader(http.StatusOK)
+		case "/echo":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			_, _ = w.Write([]byte(strings.ToUpper(string(body))))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	url := testURL
+	testURL = func(_ *v1.Integration, _ int32, path string) string {
+		return server.URL + path
+	}
+	t.Cleanup(func() {
+		testURL = url
+	})
+
+	return server
+}
+
+func newTestsIntegration(tests ...v1.IntegrationTest) *v1.Integration {
+	integration := v1.NewIntegration("ns", "my-integration")
+	integration.Spec.Tests = tests
+	integration.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionTrue, v1.IntegrationConditionReplicaSetReadyReason, "")
+	return &integration
+}
+
+func newTestsAction() *monitorAction {
+	a := monitorAction{}
+	a.InjectLogger(log.Log)
+	return &a
+}
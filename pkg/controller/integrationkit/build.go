@@ -86,7 +86,7 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1.Int
 		labels := kubernetes.FilterCamelCreatorLabels(kit.Labels)
 		labels[v1.IntegrationKitLayoutLabel] = kit.Labels[v1.IntegrationKitLayoutLabel]
 		timeout := env.Platform.Status.Build.GetTimeout()
-		if layout := labels[v1.IntegrationKitLayoutLabel]; env.Platform.Spec.Build.Timeout == nil && layout == v1.IntegrationKitLayoutNative {
+		if layout := labels[v1.IntegrationKitLayoutLabel]; env.Platform.Spec.Build.Timeout == nil && (layout == v1.IntegrationKitLayoutNative || layout == v1.IntegrationKitLayoutMulti) {
 			// Increase the timeout to a sensible default
 			timeout = metav1.Duration{
 				Duration: 10 * time.Minute,
@@ -154,6 +154,7 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1.Integ
 
 		kit.Status.BaseImage = build.Status.BaseImage
 		kit.Status.Image = build.Status.Image
+		kit.Status.Architectures = build.Status.Architectures
 
 		// Address the image by repository digest instead of tag if possible
 		if build.Status.Digest != "" {
@@ -168,6 +169,12 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1.Integ
 			kit.Status.Image = build.Status.Image
 		}
 
+		provenance, err := action.recordProvenance(ctx, kit, build)
+		if err != nil {
+			return nil, err
+		}
+		kit.Status.Provenance = provenance
+
 		kit.Status.Phase = v1.IntegrationKitPhaseReady
 		kit.Status.Artifacts = make([]v1.Artifact, 0, len(build.Status.Artifacts))
 
@@ -0,0 +1,35 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationkit
+
+import (
+	"context"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/digest"
+	"github.com/apache/camel-k/pkg/util/telemetry"
+)
+
+// computeDigest returns the digest of the given IntegrationKit
+func computeDigest(ctx context.Context, kit *v1.IntegrationKit) (string, error) {
+	_, span := telemetry.StartSpan(ctx, "Compute IntegrationKit digest")
+	hash, err := digest.ComputeForIntegrationKit(kit)
+	telemetry.EndSpan(span, err)
+
+	return hash, err
+}
@@ -21,7 +21,6 @@ import (
 	"context"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
-	"github.com/apache/camel-k/pkg/util/digest"
 )
 
 // NewErrorAction creates a new error handling action for the kit
@@ -42,7 +41,7 @@ func (action *errorAction) CanHandle(kit *v1.IntegrationKit) bool {
 }
 
 func (action *errorAction) Handle(ctx context.Context, kit *v1.IntegrationKit) (*v1.IntegrationKit, error) {
-	hash, err := digest.ComputeForIntegrationKit(kit)
+	hash, err := computeDigest(ctx, kit)
 	if err != nil {
 		return nil, err
 	}
@@ -39,9 +39,10 @@ import (
 	"github.com/apache/camel-k/pkg/client"
 	camelevent "github.com/apache/camel-k/pkg/event"
 	"github.com/apache/camel-k/pkg/platform"
-	"github.com/apache/camel-k/pkg/util/digest"
+	"github.com/apache/camel-k/pkg/util/audit"
 	"github.com/apache/camel-k/pkg/util/log"
 	"github.com/apache/camel-k/pkg/util/monitoring"
+	"github.com/apache/camel-k/pkg/util/telemetry"
 )
 
 // Add creates a new IntegrationKit Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -202,6 +203,8 @@ func (r *reconcileIntegrationKit) Reconcile(ctx context.Context, request reconci
 		return reconcile.Result{}, err
 	}
 
+	ctx = audit.WithTrigger(ctx, &instance)
+
 	target := instance.DeepCopy()
 	targetLog := rlog.ForIntegrationKit(target)
 
@@ -247,7 +250,9 @@ func (r *reconcileIntegrationKit) Reconcile(ctx context.Context, request reconci
 		if a.CanHandle(target) {
 			targetLog.Infof("Invoking action %s", a.Name())
 
-			newTarget, err := a.Handle(ctx, target)
+			actionCtx, span := telemetry.StartActionSpan(ctx, "IntegrationKit", a.Name(), string(target.Status.Phase))
+			newTarget, err := a.Handle(actionCtx, target)
+			telemetry.EndSpan(span, err)
 			if err != nil {
 				camelevent.NotifyIntegrationKitError(ctx, r.client, r.recorder, &instance, newTarget, err)
 				return reconcile.Result{}, err
@@ -279,12 +284,14 @@ func (r *reconcileIntegrationKit) Reconcile(ctx context.Context, request reconci
 }
 
 func (r *reconcileIntegrationKit) update(ctx context.Context, base *v1.IntegrationKit, target *v1.IntegrationKit) (reconcile.Result, error) {
-	dgst, err := digest.ComputeForIntegrationKit(target)
+	dgst, err := computeDigest(ctx, target)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	target.Status.Digest = dgst
+	target.Status.ObservedGeneration = base.Generation
+	target.Status.SetPhaseConditions()
 
 	err = r.client.Status().Patch(ctx, target, ctrl.MergeFrom(base))
 
@@ -0,0 +1,184 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/kamelet/bundle"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+const (
+	// provenanceKey is the key of the ConfigMap entry the provenance attestation is stored into
+	provenanceKey = "provenance.json"
+	// provenancePublishTimeout bounds the publication of the provenance attestation, that's best-effort
+	provenancePublishTimeout = 30 * time.Second
+)
+
+// recordProvenance stores the provenance attestation of the image built for the kit into a ConfigMap owned
+// by the kit, and publishes it alongside the image, when the image is pushed to a registry, which is reported
+// by the ProvenancePublished condition of the kit. No attestation is recorded when the build does not report
+// the digest of the image.
+func (action *buildAction) recordProvenance(ctx context.Context, kit *v1.IntegrationKit, build *v1.Build) (*v1.IntegrationKitProvenance, error) {
+	if build.Status.Digest == "" {
+		return nil, nil
+	}
+
+	statement, err := builder.NewProvenance(build, builderImage(build))
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := statement.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: kit.Namespace,
+			Name:      kit.Name + "-provenance",
+			Labels:    kubernetes.FilterCamelCreatorLabels(kit.Labels),
+		},
+		Data: map[string]string{
+			provenanceKey: string(data),
+		},
+	}
+	if err := controllerutil.SetControllerReference(kit, cm, action.client.GetScheme()); err != nil {
+		return nil, err
+	}
+	if err := kubernetes.ReplaceResource(ctx, action.client, cm); err != nil {
+		return nil, errors.Wrap(err, "cannot store the provenance attestation")
+	}
+
+	provenance := v1.IntegrationKitProvenance{
+		ConfigMap: cm.Name,
+		Digest:    digest,
+	}
+
+	// The attestation failing to be published does not fail, nor holds, the kit, as it's stored into the ConfigMap anyway
+	if registry := publishRegistry(build); registry != nil && registry.Address != "" {
+		reference := builder.ProvenanceReference(build.Status.Image, build.Status.Digest)
+		publishCtx, cancel := context.WithTimeout(ctx, provenancePublishTimeout)
+		defer cancel()
+		if err := publishProvenance(publishCtx, action.client, build.Namespace, *registry, reference, statement); err != nil {
+			action.L.Error(err, "Cannot publish the provenance attestation", "reference", reference)
+			kit.Status.SetCondition(v1.IntegrationKitConditionProvenancePublished, corev1.ConditionFalse,
+				v1.IntegrationKitConditionProvenanceNotPublishedReason,
+				fmt.Sprintf("cannot publish the provenance attestation to %s: %v", reference, err))
+		} else {
+			provenance.Image = reference
+			kit.Status.SetCondition(v1.IntegrationKitConditionProvenancePublished, corev1.ConditionTrue,
+				v1.IntegrationKitConditionProvenancePublishedReason,
+				fmt.Sprintf("provenance attestation published to %s", reference))
+		}
+	}
+
+	return &provenance, nil
+}
+
+// publishProvenance pushes the given attestation to the given reference, with the credentials of the registry
+func publishProvenance(ctx context.Context, c ctrl.Reader, namespace string, registry v1.IntegrationPlatformRegistrySpec, reference string, statement *builder.Statement) error {
+	img, err := builder.ProvenanceImage(statement)
+	if err != nil {
+		return err
+	}
+
+	options := bundle.Options{
+		Insecure: registry.Insecure,
+		Context:  ctx,
+	}
+	if registry.Secret != "" {
+		secret := corev1.Secret{}
+		if err := c.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: registry.Secret}, &secret); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		address, err := bundle.Registry(reference)
+		if err != nil {
+			return err
+		}
+		for _, key := range []string{corev1.DockerConfigJsonKey, "config.json"} {
+			if data, ok := secret.Data[key]; ok {
+				auth, err := bundle.AuthFromDockerConfig(data, address)
+				if err != nil {
+					return errors.Wrapf(err, "invalid registry secret %s", registry.Secret)
+				}
+				options.Auth = auth
+				break
+			}
+		}
+	}
+
+	_, err = bundle.Push(reference, img, options)
+	return err
+}
+
+// publishRegistry returns the registry the image of the given build is pushed to, if any
+func publishRegistry(build *v1.Build) *v1.IntegrationPlatformRegistrySpec {
+	for _, task := range build.Spec.Tasks {
+		switch {
+		case task.Buildah != nil:
+			return &task.Buildah.Registry
+		case task.Kaniko != nil:
+			return &task.Kaniko.Registry
+		case task.Spectrum != nil:
+			return &task.Spectrum.Registry
+		case task.Tekton != nil:
+			return &task.Tekton.Registry
+		}
+	}
+	return nil
+}
+
+// builderImage returns the image the build is executed with, i.e., the operator image, unless
+// the builder task is run in a custom image
+func builderImage(build *v1.Build) string {
+	for _, task := range build.Spec.Tasks {
+		if t := task.Builder; t != nil && t.Image != "" {
+			return t.Image
+		}
+	}
+	if platform.OperatorImage != "" {
+		return platform.OperatorImage
+	}
+	return defaults.ImageName + ":" + defaults.Version
+}
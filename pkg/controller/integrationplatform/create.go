@@ -60,6 +60,11 @@ func (action *createAction) Handle(ctx context.Context, platform *v1.Integration
 		}
 	}
 
+	// The registry credentials must be available before the platform is ready to build kits
+	if err := refreshRegistryCredentials(ctx, action.client, platform); err != nil {
+		return nil, err
+	}
+
 	platform.Status.Phase = v1.IntegrationPlatformPhaseReady
 
 	return platform, nil
@@ -88,6 +88,14 @@ func (action *initializeAction) Handle(ctx context.Context, platform *v1.Integra
 			// Skip the warmer pod creation
 			platform.Status.Phase = v1.IntegrationPlatformPhaseCreating
 		}
+	} else if platform.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyTekton {
+		// Create the persistent volume claim the builds share the generated Maven projects through
+		action.L.Info("Create persistent volume claim")
+		err := createPersistentVolumeClaim(ctx, action.client, platform)
+		if err != nil {
+			return nil, err
+		}
+		platform.Status.Phase = v1.IntegrationPlatformPhaseCreating
 	} else {
 		platform.Status.Phase = v1.IntegrationPlatformPhaseCreating
 	}
@@ -19,8 +19,10 @@ package integrationplatform
 
 import (
 	"context"
+	"reflect"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -100,6 +102,48 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to the platform registry secrets, so that their replicas are kept in sync
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
+			var requests []reconcile.Request
+
+			list := &v1.IntegrationPlatformList{}
+			if err := mgr.GetClient().List(context.Background(), list, ctrl.InNamespace(a.GetNamespace())); err != nil {
+				Log.Error(err, "Failed to list integration platforms")
+				return requests
+			}
+			for _, p := range list.Items {
+				if p.Status.Build.Registry.Secret == a.GetName() {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: ctrl.ObjectKey{Namespace: p.Namespace, Name: p.Name},
+					})
+				}
+			}
+
+			return requests
+		}),
+		predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool {
+				return false
+			},
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldSecret := e.ObjectOld.(*corev1.Secret)
+				newSecret := e.ObjectNew.(*corev1.Secret)
+				// The replicas are not watched, to not enqueue the platform once per replica update
+				return newSecret.Labels[platform.PullSecretReplicaLabel] == "" && !reflect.DeepEqual(oldSecret.Data, newSecret.Data)
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return false
+			},
+			GenericFunc: func(e event.GenericEvent) bool {
+				return false
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -178,6 +222,9 @@ func (r *reconcileIntegrationPlatform) Reconcile(ctx context.Context, request re
 			}
 
 			if target != nil {
+				target.Status.ObservedGeneration = instance.Generation
+				target.Status.SetPhaseConditions()
+
 				if err := r.client.Status().Patch(ctx, target, ctrl.MergeFrom(&instance)); err != nil {
 					camelevent.NotifyIntegrationPlatformError(ctx, r.client, r.recorder, &instance, target, err)
 					return reconcile.Result{}, err
@@ -202,6 +249,12 @@ func (r *reconcileIntegrationPlatform) Reconcile(ctx context.Context, request re
 	}
 
 	if targetPhase == v1.IntegrationPlatformPhaseReady {
+		if target.Status.Build.Registry.CredentialsProvider != "" {
+			// Check the expiration of the registry credentials periodically
+			return reconcile.Result{
+				RequeueAfter: registryCredentialsCheckPeriod,
+			}, nil
+		}
 		return reconcile.Result{}, nil
 	}
 
@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/kamelet/bundle"
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+// installKameletBundles installs the Kamelet bundles of the platform into its namespace, when the Kamelets
+// they contain have not been installed from the current digest of the bundle yet
+func installKameletBundles(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform, logger log.Logger) error {
+	for _, b := range platform.Status.Kamelet.Bundles {
+		if b.Image == "" {
+			continue
+		}
+		options, err := kameletBundleOptions(ctx, c, platform, b)
+		if err != nil {
+			return err
+		}
+		img, err := bundle.Pull(b.Image, options)
+		if err != nil {
+			return errors.Wrapf(err, "cannot pull Kamelet bundle %s", b.Image)
+		}
+		digest, err := img.Digest()
+		if err != nil {
+			return errors.Wrapf(err, "cannot pull Kamelet bundle %s", b.Image)
+		}
+		names, err := bundle.KameletNames(img)
+		if err != nil {
+			return errors.Wrapf(err, "invalid Kamelet bundle %s", b.Image)
+		}
+		installed, err := bundle.IsInstalled(ctx, c, platform.Namespace, b.Image, digest.String(), names)
+		if err != nil {
+			return err
+		} else if installed {
+			continue
+		}
+
+		kamelets, err := bundle.Unpack(img)
+		if err != nil {
+			return errors.Wrapf(err, "invalid Kamelet bundle %s", b.Image)
+		}
+		result, err := bundle.Install(ctx, c, platform.Namespace, b.Image, digest.String(), kamelets, false)
+		if err != nil {
+			return errors.Wrapf(err, "cannot install Kamelet bundle %s", b.Image)
+		}
+		logger.Info("Kamelet bundle installed", "bundle", b.Image, "digest", digest.String(), "kamelets", result.Installed)
+		if len(result.Skipped) > 0 {
+			logger.Info("Kamelets of the bundle skipped, as they are not installed from a bundle", "bundle", b.Image, "kamelets", result.Skipped)
+		}
+	}
+	return nil
+}
+
+// kameletBundleOptions returns the options to pull the bundle, that is pulled with the platform registry
+// credentials when it is stored into the platform registry, and anonymously otherwise
+func kameletBundleOptions(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform, b v1.IntegrationPlatformKameletBundleSpec) (bundle.Options, error) {
+	options := bundle.Options{
+		Insecure: b.Insecure,
+		Auth:     authn.Anonymous,
+	}
+
+	spec := platform.Status.Build.Registry
+	registry, err := bundle.Registry(b.Image)
+	if err != nil {
+		return options, err
+	}
+	if spec.Address == "" || registry != spec.Address {
+		return options, nil
+	}
+	options.Insecure = options.Insecure || spec.Insecure
+	if spec.Secret == "" {
+		return options, nil
+	}
+
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, ctrl.ObjectKey{Namespace: platform.Namespace, Name: spec.Secret}, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return options, nil
+		}
+		return options, err
+	}
+	for _, key := range []string{corev1.DockerConfigJsonKey, "config.json"} {
+		if data, ok := secret.Data[key]; ok {
+			auth, err := bundle.AuthFromDockerConfig(data, registry)
+			if err != nil {
+				return options, errors.Wrapf(err, "invalid registry secret %s", spec.Secret)
+			}
+			if auth != nil {
+				options.Auth = auth
+			}
+			break
+		}
+	}
+	return options, nil
+}
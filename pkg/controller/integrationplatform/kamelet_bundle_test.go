@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	stdlog "log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/kamelet/bundle"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestInstallKameletBundles(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(stdlog.New(ioutil.Discard, "", 0))))
+	defer server.Close()
+	reference := strings.TrimPrefix(server.URL, "http://") + "/acme/kamelets:1.0.0"
+
+	img, err := bundle.Package([]v1alpha1.Kamelet{v1alpha1.NewKamelet("", "timer-source")})
+	require.NoError(t, err)
+	digest, err := bundle.Push(reference, img, bundle.Options{Insecure: true})
+	require.NoError(t, err)
+
+	ip := v1.IntegrationPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "camel-k",
+		},
+	}
+	ip.Status.Kamelet.Bundles = []v1.IntegrationPlatformKameletBundleSpec{{Image: reference, Insecure: true}}
+
+	c, err := test.NewFakeClient(&ip)
+	require.NoError(t, err)
+
+	require.NoError(t, installKameletBundles(context.TODO(), c, &ip, log.Log))
+
+	kamelet := v1alpha1.NewKamelet("ns", "timer-source")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&kamelet), &kamelet))
+	assert.Equal(t, reference, kamelet.Annotations[bundle.BundleAnnotation])
+	assert.Equal(t, digest, kamelet.Annotations[bundle.BundleDigestAnnotation])
+
+	// The bundle is not installed again as long as its digest does not change
+	resourceVersion := kamelet.ResourceVersion
+	require.NoError(t, installKameletBundles(context.TODO(), c, &ip, log.Log))
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&kamelet), &kamelet))
+	assert.Equal(t, resourceVersion, kamelet.ResourceVersion)
+
+	// The bundles following an installed bundle are still installed
+	other := strings.TrimPrefix(server.URL, "http://") + "/acme/other-kamelets:1.0.0"
+	img, err = bundle.Package([]v1alpha1.Kamelet{v1alpha1.NewKamelet("", "log-sink")})
+	require.NoError(t, err)
+	_, err = bundle.Push(other, img, bundle.Options{Insecure: true})
+	require.NoError(t, err)
+	ip.Status.Kamelet.Bundles = append(ip.Status.Kamelet.Bundles, v1.IntegrationPlatformKameletBundleSpec{Image: other, Insecure: true})
+
+	require.NoError(t, installKameletBundles(context.TODO(), c, &ip, log.Log))
+	sink := v1alpha1.NewKamelet("ns", "log-sink")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&sink), &sink))
+	assert.Equal(t, other, sink.Annotations[bundle.BundleAnnotation])
+}
+
+func TestKameletBundleOptions(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	ip := v1.IntegrationPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "camel-k",
+		},
+	}
+	ip.Status.Build.Registry.Address = "registry.acme.com"
+	ip.Status.Build.Registry.Insecure = true
+	ip.Status.Build.Registry.Secret = "registry"
+
+	c, err := test.NewFakeClient(&ip, newPullSecret("ns", "registry", `{"auths":{"registry.acme.com":{"auth":"`+auth+`"}}}`, nil))
+	require.NoError(t, err)
+
+	options, err := kameletBundleOptions(context.TODO(), c, &ip, v1.IntegrationPlatformKameletBundleSpec{Image: "registry.acme.com/kamelets:1.0.0"})
+	require.NoError(t, err)
+	assert.True(t, options.Insecure)
+	config, err := options.Auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "user", config.Username)
+
+	options, err = kameletBundleOptions(context.TODO(), c, &ip, v1.IntegrationPlatformKameletBundleSpec{Image: "quay.io/acme/kamelets:1.0.0"})
+	require.NoError(t, err)
+	assert.False(t, options.Insecure)
+	config, err = options.Auth.Authorization()
+	require.NoError(t, err)
+	assert.Empty(t, config.Username)
+}
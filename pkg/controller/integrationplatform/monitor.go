@@ -54,5 +54,18 @@ func (action *monitorAction) Handle(ctx context.Context, platform *v1.Integratio
 		return nil, err
 	}
 
+	if err := refreshRegistryCredentials(ctx, action.client, platform); err != nil {
+		return nil, err
+	}
+
+	if err := syncPullSecretReplicas(ctx, action.client, platform); err != nil {
+		return nil, err
+	}
+
+	// The Kamelet bundles that cannot be installed must not prevent the platform from being reconciled
+	if err := installKameletBundles(ctx, action.client, platform, action.L); err != nil {
+		action.L.Error(err, "Cannot install the Kamelet bundles")
+	}
+
 	return platform, nil
 }
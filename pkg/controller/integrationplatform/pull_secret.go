@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	platformutils "github.com/apache/camel-k/pkg/platform"
+)
+
+// syncPullSecretReplicas updates the replicas of the platform registry secret, that the pull-secret trait creates
+// into the namespaces of the integrations when the operator is global
+func syncPullSecretReplicas(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform) error {
+	name := platform.Status.Build.Registry.Secret
+	if name == "" || !platformutils.IsCurrentOperatorGlobal() {
+		return nil
+	}
+
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, ctrl.ObjectKey{Namespace: platform.Namespace, Name: name}, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return platformutils.SyncPullSecretReplicas(ctx, c, &secret)
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	platformutils "github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestSyncPullSecretReplicas(t *testing.T) {
+	ip := v1.IntegrationPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator",
+			Name:      "camel-k",
+		},
+	}
+	ip.Status.Build.Registry.Secret = "registry"
+
+	source := newPullSecret("operator", "registry", "new", nil)
+	replica := newPullSecret("ns1", "registry", "old", map[string]string{platformutils.PullSecretReplicaLabel: "operator"})
+	other := newPullSecret("ns2", "registry", "old", nil)
+
+	c, err := test.NewFakeClient(&ip, source, replica, other)
+	assert.Nil(t, err)
+
+	assert.Nil(t, syncPullSecretReplicas(context.TODO(), c, &ip))
+
+	updated := corev1.Secret{}
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns1", Name: "registry"}, &updated))
+	assert.Equal(t, "new", string(updated.Data[corev1.DockerConfigJsonKey]))
+
+	untouched := corev1.Secret{}
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns2", Name: "registry"}, &untouched))
+	assert.Equal(t, "old", string(untouched.Data[corev1.DockerConfigJsonKey]))
+}
+
+func newPullSecret(namespace string, name string, content string, labels map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(content),
+		},
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/registry"
+)
+
+const (
+	registryCredentialsExpirationAnnotation = "camel.apache.org/registry-credentials-expiration"
+	// registryCredentialsRefreshMargin is how long before their expiration the registry credentials are refreshed
+	registryCredentialsRefreshMargin = 30 * time.Minute
+	// registryCredentialsCheckPeriod is how often the expiration of the registry credentials is checked
+	registryCredentialsCheckPeriod = 5 * time.Minute
+)
+
+// newRegistryCredentialsProvider can be overridden in tests
+var newRegistryCredentialsProvider = registry.NewCredentialsProvider
+
+// refreshRegistryCredentials creates or updates the registry secret with short-lived credentials,
+// retrieved from the platform registry credentials provider, when they are about to expire.
+// The secret is used to push the kit images, and to pull the integration images with the pull-secret trait.
+func refreshRegistryCredentials(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform) error {
+	spec := platform.Status.Build.Registry
+	if spec.CredentialsProvider == "" {
+		return nil
+	}
+
+	secret := corev1.Secret{}
+	key := ctrl.ObjectKey{Namespace: platform.Namespace, Name: spec.Secret}
+	err := c.Get(ctx, key, &secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if exists {
+		if secret.Type != corev1.SecretTypeDockerConfigJson {
+			return fmt.Errorf("the registry secret %s must be of type %s, to be refreshed with the %s credentials provider",
+				spec.Secret, corev1.SecretTypeDockerConfigJson, spec.CredentialsProvider)
+		}
+		if expiration, err := time.Parse(time.RFC3339, secret.Annotations[registryCredentialsExpirationAnnotation]); err == nil &&
+			time.Until(expiration) > registryCredentialsRefreshMargin {
+			return nil
+		}
+	}
+
+	provider, err := newRegistryCredentialsProvider(spec.CredentialsProvider)
+	if err != nil {
+		return err
+	}
+	auth, expiration, err := provider.Credentials(ctx, spec.Address)
+	if err != nil {
+		return errors.Wrapf(err, "cannot retrieve the %s registry credentials", spec.CredentialsProvider)
+	}
+	config, err := auth.GenerateDockerConfig()
+	if err != nil {
+		return err
+	}
+
+	secret.Namespace = platform.Namespace
+	secret.Name = spec.Secret
+	secret.Type = corev1.SecretTypeDockerConfigJson
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[registryCredentialsExpirationAnnotation] = expiration.UTC().Format(time.RFC3339)
+	secret.Data = map[string][]byte{
+		corev1.DockerConfigJsonKey: config,
+	}
+
+	if exists {
+		return c.Update(ctx, &secret)
+	}
+	secret.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(platform, v1.SchemeGroupVersion.WithKind(v1.IntegrationPlatformKind)),
+	}
+	return c.Create(ctx, &secret)
+}
@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/registry"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+type fakeCredentialsProvider struct {
+	calls      int
+	expiration time.Time
+}
+
+func (p *fakeCredentialsProvider) Credentials(_ context.Context, address string) (registry.Auth, time.Time, error) {
+	p.calls++
+	return registry.Auth{
+		Registry: address,
+		Username: "AWS",
+		Password: "token",
+	}, p.expiration, nil
+}
+
+func TestRefreshRegistryCredentials(t *testing.T) {
+	provider := &fakeCredentialsProvider{expiration: time.Now().Add(12 * time.Hour)}
+	withCredentialsProvider(t, provider)
+
+	ip := newRegistryCredentialsPlatform()
+	c, err := test.NewFakeClient(&ip)
+	assert.Nil(t, err)
+
+	// The secret is created
+	assert.Nil(t, refreshRegistryCredentials(context.TODO(), c, &ip))
+	assert.Equal(t, 1, provider.calls)
+
+	secret := corev1.Secret{}
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "camel-k-registry-credentials"}, &secret))
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+	assert.Equal(t, `{"auths":{"123456789012.dkr.ecr.eu-west-1.amazonaws.com":{"auth":"QVdTOnRva2Vu"}}}`,
+		string(secret.Data[corev1.DockerConfigJsonKey]))
+	assert.Equal(t, provider.expiration.UTC().Format(time.RFC3339), secret.Annotations[registryCredentialsExpirationAnnotation])
+	assert.Equal(t, "camel-k", secret.OwnerReferences[0].Name)
+
+	// The credentials are not refreshed until they are about to expire
+	assert.Nil(t, refreshRegistryCredentials(context.TODO(), c, &ip))
+	assert.Equal(t, 1, provider.calls)
+
+	secret.Annotations[registryCredentialsExpirationAnnotation] = time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339)
+	assert.Nil(t, c.Update(context.TODO(), &secret))
+
+	assert.Nil(t, refreshRegistryCredentials(context.TODO(), c, &ip))
+	assert.Equal(t, 2, provider.calls)
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "camel-k-registry-credentials"}, &secret))
+	assert.Equal(t, provider.expiration.UTC().Format(time.RFC3339), secret.Annotations[registryCredentialsExpirationAnnotation])
+}
+
+func TestRefreshRegistryCredentialsInvalidSecret(t *testing.T) {
+	withCredentialsProvider(t, &fakeCredentialsProvider{expiration: time.Now().Add(12 * time.Hour)})
+
+	ip := newRegistryCredentialsPlatform()
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "camel-k-registry-credentials",
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	c, err := test.NewFakeClient(&ip, &secret)
+	assert.Nil(t, err)
+
+	err = refreshRegistryCredentials(context.TODO(), c, &ip)
+	assert.NotNil(t, err)
+}
+
+func newRegistryCredentialsPlatform() v1.IntegrationPlatform {
+	ip := v1.IntegrationPlatform{}
+	ip.Namespace = "ns"
+	ip.Name = "camel-k"
+	ip.Status.Build.Registry = v1.IntegrationPlatformRegistrySpec{
+		Address:             "123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+		Secret:              "camel-k-registry-credentials",
+		CredentialsProvider: v1.RegistryCredentialsProviderECR,
+	}
+	return ip
+}
+
+func withCredentialsProvider(t *testing.T, provider registry.CredentialsProvider) {
+	t.Helper()
+
+	newProvider := newRegistryCredentialsProvider
+	newRegistryCredentialsProvider = func(v1.RegistryCredentialsProvider) (registry.CredentialsProvider, error) {
+		return provider, nil
+	}
+	t.Cleanup(func() {
+		newRegistryCredentialsProvider = newProvider
+	})
+}
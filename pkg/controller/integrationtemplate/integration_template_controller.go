@@ -0,0 +1,304 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	camelevent "github.com/apache/camel-k/pkg/event"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// Add creates a new IntegrationTemplate Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, c))
+}
+
+func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
+	return &reconcileIntegrationTemplate{
+		client:   c,
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("camel-k-integration-template-controller"),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	return builder.ControllerManagedBy(mgr).
+		Named("integration-template-controller").
+		// Watch for changes to primary resource IntegrationTemplate
+		For(&v1.IntegrationTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Watch for the instances of the templates, so that they are counted in the status of the templates
+		Watches(&source.Kind{Type: &v1.Integration{}},
+			handler.EnqueueRequestsFromMapFunc(func(a ctrl.Object) []reconcile.Request {
+				name, ok := a.GetLabels()[v1.IntegrationTemplateLabel]
+				if !ok {
+					return nil
+				}
+				return []reconcile.Request{
+					{
+						NamespacedName: types.NamespacedName{
+							Namespace: a.GetNamespace(),
+							Name:      name,
+						},
+					},
+				}
+			}),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return e.ObjectOld.GetLabels()[v1.IntegrationTemplateLabel] != e.ObjectNew.GetLabels()[v1.IntegrationTemplateLabel] ||
+						e.ObjectOld.GetAnnotations()[v1.IntegrationTemplateGenerationAnnotation] != e.ObjectNew.GetAnnotations()[v1.IntegrationTemplateGenerationAnnotation]
+				},
+			})).
+		Complete(r)
+}
+
+var _ reconcile.Reconciler = &reconcileIntegrationTemplate{}
+
+// reconcileIntegrationTemplate reconciles an IntegrationTemplate object
+type reconcileIntegrationTemplate struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the API server
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile rolls the updates of the IntegrationTemplate out to its instances, when enabled,
+// and reports the number of instances in its status
+func (r *reconcileIntegrationTemplate) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	rlog := Log.WithValues("request-namespace", request.Namespace, "request-name", request.Name)
+	rlog.Info("Reconciling IntegrationTemplate")
+
+	// Make sure the operator is allowed to act on namespace
+	if ok, err := platform.IsOperatorAllowedOnNamespace(ctx, r.client, request.Namespace); err != nil {
+		return reconcile.Result{}, err
+	} else if !ok {
+		rlog.Info("Ignoring request because namespace is locked")
+		return reconcile.Result{}, nil
+	}
+
+	var template v1.IntegrationTemplate
+	if err := r.client.Get(ctx, request.NamespacedName, &template); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// The instances are left untouched
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	integrations := v1.NewIntegrationList()
+	if err := r.client.List(ctx, &integrations,
+		ctrl.InNamespace(template.Namespace),
+		ctrl.MatchingLabels{v1.IntegrationTemplateLabel: template.Name},
+	); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	generation := strconv.FormatInt(template.Generation, 10)
+	updated := int32(0)
+	for i := range integrations.Items {
+		it := &integrations.Items[i]
+		if it.Annotations[v1.IntegrationTemplateGenerationAnnotation] == generation {
+			updated++
+			continue
+		}
+		if !template.Spec.RollOut {
+			continue
+		}
+		rlog.Info("Rolling out template update", "integration", it.Name)
+		if err := r.rollOut(ctx, &template, it); err != nil {
+			r.recorder.Eventf(&template, corev1.EventTypeWarning, camelevent.ReasonIntegrationTemplateRollOutFailed,
+				"Cannot roll out integration template update to integration %s: %v", it.Name, err)
+			continue
+		}
+		updated++
+	}
+
+	target := template.DeepCopy()
+	target.Status.ObservedGeneration = template.Generation
+	target.Status.Instances = int32(len(integrations.Items))
+	target.Status.UpdatedInstances = updated
+	if !equality.Semantic.DeepEqual(target.Status, template.Status) {
+		if err := r.client.Status().Patch(ctx, target, ctrl.MergeFrom(&template)); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// rollOut instantiates the template again, with the parameter values of the integration, and applies the changes
+// made to the integration since it has been instantiated on top of it
+func (r *reconcileIntegrationTemplate) rollOut(ctx context.Context, template *v1.IntegrationTemplate, it *v1.Integration) error {
+	values, err := it.GetTemplateParameters()
+	if err != nil {
+		return err
+	}
+	instance, err := template.Instantiate(it.Name, values)
+	if err != nil {
+		return err
+	}
+	rendered, err := r.renderedSpec(ctx, it)
+	if err != nil {
+		return err
+	}
+
+	target := it.DeepCopy()
+	if target.Spec, err = applyOverrides(it, rendered, instance); err != nil {
+		return err
+	}
+	if target.Annotations == nil {
+		target.Annotations = make(map[string]string)
+	}
+	for k, v := range instance.Annotations {
+		target.Annotations[k] = v
+	}
+	if err := r.client.Update(ctx, target); err != nil {
+		return err
+	}
+
+	cm, err := NewSpecConfigMap(instance)
+	if err != nil {
+		return err
+	}
+	cm.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKind,
+			Name:       target.Name,
+			UID:        target.UID,
+		},
+	}
+	return kubernetes.ReplaceResource(ctx, r.client, cm)
+}
+
+// renderedSpec returns the spec the given integration has been rendered with, as stored in its ConfigMap,
+// or nil when it's not available, or does not match the digest recorded in the annotations of the integration
+func (r *reconcileIntegrationTemplate) renderedSpec(ctx context.Context, it *v1.Integration) ([]byte, error) {
+	digest, ok := it.Annotations[v1.IntegrationTemplateSpecDigestAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	cm := corev1.ConfigMap{}
+	key := ctrl.ObjectKey{Namespace: it.Namespace, Name: v1.IntegrationTemplateSpecConfigMapName(it.Name)}
+	if err := r.client.Get(ctx, key, &cm); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rendered := []byte(cm.Data[v1.IntegrationTemplateSpecKey])
+	if v1.IntegrationTemplateSpecDigest(rendered) != digest {
+		Log.Infof("Ignoring the rendered spec of integration %s, as it does not match its digest", it.Name)
+		return nil, nil
+	}
+	return rendered, nil
+}
+
+// NewSpecConfigMap returns the ConfigMap the spec of the given integration, as rendered from its template,
+// is stored in, so that the changes made to the integration since then are kept when the template updates are
+// rolled out. The ConfigMap must be owned by the integration.
+func NewSpecConfigMap(instance *v1.Integration) (*corev1.ConfigMap, error) {
+	rendered, err := json.Marshal(instance.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      v1.IntegrationTemplateSpecConfigMapName(instance.Name),
+			Labels: map[string]string{
+				v1.IntegrationLabel:         instance.Name,
+				v1.IntegrationTemplateLabel: instance.Labels[v1.IntegrationTemplateLabel],
+			},
+		},
+		Data: map[string]string{
+			v1.IntegrationTemplateSpecKey: string(rendered),
+		},
+	}, nil
+}
+
+// applyOverrides returns the spec of the instance, with the differences between the spec of the integration and the spec
+// it has been rendered with, e.g., the sources or the traits added with `kamel run --from-template`, merged into it.
+//
+// Note the differences are computed as a JSON merge patch, that replaces the arrays as a whole: once a source has been
+// added to the integration, its sources override the ones of the template, so that the later changes of the template
+// to its sources are not rolled out to the integration anymore.
+func applyOverrides(it *v1.Integration, rendered []byte, instance *v1.Integration) (v1.IntegrationSpec, error) {
+	if rendered == nil {
+		// The changes made to the integration cannot be told apart from the template
+		return instance.Spec, nil
+	}
+
+	current, err := json.Marshal(it.Spec)
+	if err != nil {
+		return v1.IntegrationSpec{}, err
+	}
+	overrides, err := jsonpatch.CreateMergePatch(rendered, current)
+	if err != nil {
+		return v1.IntegrationSpec{}, errors.Wrapf(err, "invalid rendered spec of integration %s", it.Name)
+	}
+	updated, err := json.Marshal(instance.Spec)
+	if err != nil {
+		return v1.IntegrationSpec{}, err
+	}
+	merged, err := jsonpatch.MergePatch(updated, overrides)
+	if err != nil {
+		return v1.IntegrationSpec{}, err
+	}
+
+	var spec v1.IntegrationSpec
+	if err := json.Unmarshal(merged, &spec); err != nil {
+		return v1.IntegrationSpec{}, err
+	}
+	return spec, nil
+}
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationtemplate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestReconcileIntegrationTemplate(t *testing.T) {
+	for _, rollOut := range []bool{false, true} {
+		template := v1.NewIntegrationTemplate("ns", "greeter")
+		template.Generation = 1
+		template.Spec.Parameters = []v1.IntegrationTemplateParameter{{Name: "message"}}
+		template.Spec.Integration.Sources = []v1.SourceSpec{
+			v1.NewSourceSpec("greeter.yaml", `- from: "timer:tick?message=$(params.message)"`, v1.LanguageYaml),
+		}
+		template.Spec.RollOut = rollOut
+
+		outdated, err := template.Instantiate("outdated", map[string]string{"message": "hello"})
+		assert.Nil(t, err)
+		spec, err := NewSpecConfigMap(outdated)
+		assert.Nil(t, err)
+		// The changes made to the instance are kept
+		outdated.Spec.Dependencies = []string{"camel:kafka"}
+		outdated.Spec.Profile = v1.TraitProfileKnative
+		template.Generation = 2
+		updated, err := template.Instantiate("updated", map[string]string{"message": "hi"})
+		assert.Nil(t, err)
+		template.Spec.Integration.Sources[0].Content = `- from: "timer:tock?message=$(params.message)"`
+
+		c, err := test.NewFakeClient(&template, outdated, updated, spec)
+		assert.Nil(t, err)
+		recorder := record.NewFakeRecorder(10)
+		r := reconcileIntegrationTemplate{client: c, recorder: recorder}
+
+		_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "greeter"}})
+		assert.Nil(t, err)
+		assert.Len(t, recorder.Events, 0)
+
+		assert.Nil(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "greeter"}, &template))
+		assert.Equal(t, int64(2), template.Status.ObservedGeneration)
+		assert.Equal(t, int32(2), template.Status.Instances)
+
+		var it v1.Integration
+		assert.Nil(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "outdated"}, &it))
+		if rollOut {
+			assert.Equal(t, int32(2), template.Status.UpdatedInstances)
+			assert.Equal(t, "2", it.Annotations[v1.IntegrationTemplateGenerationAnnotation])
+			assert.Equal(t, `- from: "timer:tock?message=hello"`, it.Spec.Sources[0].Content)
+			assert.Equal(t, []string{"camel:kafka"}, it.Spec.Dependencies)
+			assert.Equal(t, v1.TraitProfileKnative, it.Spec.Profile)
+
+			// The spec rendered from the updated template is stored
+			assert.Nil(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "outdated-template-spec"}, spec))
+			assert.Equal(t, it.Annotations[v1.IntegrationTemplateSpecDigestAnnotation],
+				v1.IntegrationTemplateSpecDigest([]byte(spec.Data[v1.IntegrationTemplateSpecKey])))
+			assert.Equal(t, "outdated", spec.OwnerReferences[0].Name)
+		} else {
+			assert.Equal(t, int32(1), template.Status.UpdatedInstances)
+			assert.Equal(t, "1", it.Annotations[v1.IntegrationTemplateGenerationAnnotation])
+			assert.Equal(t, `- from: "timer:tick?message=hello"`, it.Spec.Sources[0].Content)
+		}
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	template := v1.NewIntegrationTemplate("ns", "greeter")
+	template.Spec.Integration.Dependencies = []string{"camel:timer"}
+	template.Spec.Integration.Sources = []v1.SourceSpec{
+		v1.NewSourceSpec("greeter.yaml", `- from: "timer:tick"`, v1.LanguageYaml),
+	}
+
+	it, err := template.Instantiate("greeter", nil)
+	assert.Nil(t, err)
+	spec, err := NewSpecConfigMap(it)
+	assert.Nil(t, err)
+	rendered := []byte(spec.Data[v1.IntegrationTemplateSpecKey])
+	assert.Equal(t, it.Annotations[v1.IntegrationTemplateSpecDigestAnnotation], v1.IntegrationTemplateSpecDigest(rendered))
+	it.Spec.Profile = v1.TraitProfileKnative
+	it.Spec.Sources = append(it.Spec.Sources, v1.NewSourceSpec("extra.yaml", `- from: "timer:extra"`, v1.LanguageYaml))
+
+	template.Spec.Integration.Dependencies = []string{"camel:timer", "camel:log"}
+	template.Spec.Integration.Sources[0].Content = `- from: "timer:tock"`
+	instance, err := template.Instantiate("greeter", nil)
+	assert.Nil(t, err)
+
+	merged, err := applyOverrides(it, rendered, instance)
+	assert.Nil(t, err)
+	assert.Equal(t, v1.TraitProfileKnative, merged.Profile)
+	assert.Equal(t, []string{"camel:timer", "camel:log"}, merged.Dependencies)
+	// The sources of the integration replace the ones of the template as a whole
+	assert.Len(t, merged.Sources, 2)
+	assert.Equal(t, `- from: "timer:tick"`, merged.Sources[0].Content)
+
+	// Without the rendered spec, the changes made to the integration are discarded
+	merged, err = applyOverrides(it, nil, instance)
+	assert.Nil(t, err)
+	assert.Equal(t, instance.Spec, merged)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/apache/camel-k/pkg/util/knative"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/property"
+	"github.com/apache/camel-k/pkg/webhook"
 	"github.com/pkg/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,38 +41,7 @@ var endpointTypeSourceContext = bindings.EndpointContext{Type: v1alpha1.Endpoint
 var endpointTypeSinkContext = bindings.EndpointContext{Type: v1alpha1.EndpointTypeSink}
 
 func createIntegrationFor(ctx context.Context, c client.Client, kameletbinding *v1alpha1.KameletBinding) (*v1.Integration, error) {
-	controller := true
-	blockOwnerDeletion := true
-	it := v1.Integration{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace:   kameletbinding.Namespace,
-			Name:        kameletbinding.Name,
-			Annotations: util.CopyMap(kameletbinding.Annotations),
-			Labels:      util.CopyMap(kameletbinding.Labels),
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion:         kameletbinding.APIVersion,
-					Kind:               kameletbinding.Kind,
-					Name:               kameletbinding.Name,
-					UID:                kameletbinding.UID,
-					Controller:         &controller,
-					BlockOwnerDeletion: &blockOwnerDeletion,
-				},
-			},
-		},
-	}
-
-	// creator labels
-	if it.GetLabels() == nil {
-		it.SetLabels(make(map[string]string))
-	}
-	it.GetLabels()[kubernetes.CamelCreatorLabelKind] = kameletbinding.Kind
-	it.GetLabels()[kubernetes.CamelCreatorLabelName] = kameletbinding.Name
-
-	// start from the integration spec defined in the binding
-	if kameletbinding.Spec.Integration != nil {
-		it.Spec = *kameletbinding.Spec.Integration.DeepCopy()
-	}
+	it := newIntegrationFor(kameletbinding, kameletbinding.Name)
 
 	// Set replicas (or override podspecable value) if present
 	if kameletbinding.Spec.Replicas != nil {
@@ -131,6 +101,16 @@ func createIntegrationFor(ctx context.Context, c client.Client, kameletbinding *
 		}
 	}
 
+	flow, err := knativeFlowFor(kameletbinding, profile)
+	if err != nil {
+		return nil, err
+	}
+	if flow != "" {
+		// the steps and the sink are run by the integrations of the Knative flow
+		to = knativeFlowBinding(kameletbinding, flow)
+		steps = nil
+	}
+
 	if err := configureBinding(&it, from); err != nil {
 		return nil, err
 	}
@@ -147,17 +127,7 @@ func createIntegrationFor(ctx context.Context, c client.Client, kameletbinding *
 		return nil, err
 	}
 
-	if it.Spec.Configuration != nil {
-		sort.SliceStable(it.Spec.Configuration, func(i, j int) bool {
-			mi, mj := it.Spec.Configuration[i], it.Spec.Configuration[j]
-			switch {
-			case mi.Type != mj.Type:
-				return mi.Type < mj.Type
-			default:
-				return mi.Value < mj.Value
-			}
-		})
-	}
+	sortConfiguration(&it)
 
 	dslSteps := make([]map[string]interface{}, 0)
 	for _, step := range steps {
@@ -192,9 +162,55 @@ func createIntegrationFor(ctx context.Context, c client.Client, kameletbinding *
 	}
 	it.Spec.Flows = append(it.Spec.Flows, v1.Flow{RawMessage: encodedFrom})
 
+	if err := webhook.PropagateRequester(kameletbinding, &it); err != nil {
+		return nil, errors.Wrap(err, "could not sign the requester of the integration")
+	}
+
 	return &it, nil
 }
 
+// newIntegrationFor returns an integration owned by the binding, with the given name
+func newIntegrationFor(kameletbinding *v1alpha1.KameletBinding, name string) v1.Integration {
+	controller := true
+	blockOwnerDeletion := true
+	it := v1.Integration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   kameletbinding.Namespace,
+			Name:        name,
+			Annotations: util.CopyMap(kameletbinding.Annotations),
+			Labels:      util.CopyMap(kameletbinding.Labels),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         kameletbinding.APIVersion,
+					Kind:               kameletbinding.Kind,
+					Name:               kameletbinding.Name,
+					UID:                kameletbinding.UID,
+					Controller:         &controller,
+					BlockOwnerDeletion: &blockOwnerDeletion,
+				},
+			},
+		},
+	}
+
+	// creator labels
+	if it.GetLabels() == nil {
+		it.SetLabels(make(map[string]string))
+	}
+	it.GetLabels()[kubernetes.CamelCreatorLabelKind] = kameletbinding.Kind
+	it.GetLabels()[kubernetes.CamelCreatorLabelName] = kameletbinding.Name
+
+	// start from the integration spec defined in the binding
+	if kameletbinding.Spec.Integration != nil {
+		it.Spec = *kameletbinding.Spec.Integration.DeepCopy()
+	}
+
+	return it
+}
+
 func configureBinding(integration *v1.Integration, bindings ...*bindings.Binding) error {
 	for _, b := range bindings {
 		if b == nil {
@@ -224,6 +240,20 @@ func configureBinding(integration *v1.Integration, bindings ...*bindings.Binding
 	return nil
 }
 
+func sortConfiguration(integration *v1.Integration) {
+	if integration.Spec.Configuration != nil {
+		sort.SliceStable(integration.Spec.Configuration, func(i, j int) bool {
+			mi, mj := integration.Spec.Configuration[i], integration.Spec.Configuration[j]
+			switch {
+			case mi.Type != mj.Type:
+				return mi.Type < mj.Type
+			default:
+				return mi.Value < mj.Value
+			}
+		})
+	}
+}
+
 func determineProfile(ctx context.Context, c client.Client, binding *v1alpha1.KameletBinding) (v1.TraitProfile, error) {
 	if binding.Spec.Integration != nil && binding.Spec.Integration.Profile != "" {
 		return binding.Spec.Integration.Profile, nil
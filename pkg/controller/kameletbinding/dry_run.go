@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/controller/integration"
+)
+
+// DryRun returns the Integration the operator generates for the given binding, as it is resolved by the operator,
+// i.e., with its endpoint URIs, traits and dependencies, without persisting anything. The status of the returned
+// Integration only holds the outcome of the resolution, e.g., the dependencies and the runtime.
+//
+// The Integrations of the Knative flow of the binding, if any, are not included.
+func DryRun(ctx context.Context, c client.Client, binding *v1alpha1.KameletBinding) (*v1.Integration, error) {
+	c = client.NewDryRunClient(c)
+
+	it, err := createIntegrationFor(ctx, c, binding)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := integration.DryRun(ctx, c, it)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the outcome of the resolution is retained, as the status reflects the phases the Integration is run through
+	resolved := env.Integration
+	resolved.Status = v1.IntegrationStatus{
+		Dependencies:     resolved.Status.Dependencies,
+		Profile:          resolved.Status.Profile,
+		Platform:         resolved.Status.Platform,
+		GeneratedSources: resolved.Status.GeneratedSources,
+		RuntimeVersion:   resolved.Status.RuntimeVersion,
+		RuntimeProvider:  resolved.Status.RuntimeProvider,
+		Configuration:    resolved.Status.Configuration,
+		Capabilities:     resolved.Status.Capabilities,
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestDryRun(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	require.NoError(t, err)
+
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Build.RuntimeVersion = catalog.Runtime.Version
+	pl.Status.Build.PublishStrategy = v1.IntegrationPlatformBuildPublishStrategySpectrum
+	pl.Status.Build.Registry.Address = "registry"
+	pl.Status.Cluster = v1.IntegrationPlatformClusterKubernetes
+
+	c, err := test.NewFakeClient(&pl, &v1.CamelCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "camel-catalog"},
+		Spec:       catalog.CamelCatalogSpec,
+	})
+	require.NoError(t, err)
+
+	source := "timer:tick"
+	sink := "log:info"
+	binding := v1alpha1.KameletBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.KameletBindingKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-binding",
+		},
+		Spec: v1alpha1.KameletBindingSpec{
+			Integration: &v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				// The garbage collection relies on the discovery API that is not available with the fake client
+				Traits: map[string]v1.TraitSpec{
+					"gc": test.TraitSpecFromMap(t, map[string]interface{}{"enabled": false}),
+				},
+			},
+			Source: v1alpha1.Endpoint{URI: &source},
+			Sink:   v1alpha1.Endpoint{URI: &sink},
+		},
+	}
+
+	it, err := DryRun(context.TODO(), c, &binding)
+	require.NoError(t, err)
+	assert.Equal(t, "my-binding", it.Name)
+	assert.Equal(t, v1.TraitProfileKubernetes, it.Status.Profile)
+	require.Len(t, it.Spec.Flows, 1)
+	assert.Contains(t, string(it.Spec.Flows[0].RawMessage), `"uri":"timer:tick"`)
+	assert.Contains(t, it.Status.Dependencies, "camel:timer")
+	assert.Contains(t, it.Status.Dependencies, "camel:log")
+
+	// Nothing is persisted
+	integrations := v1.NewIntegrationList()
+	require.NoError(t, c.List(context.TODO(), &integrations))
+	assert.Empty(t, integrations.Items)
+}
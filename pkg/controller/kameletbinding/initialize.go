@@ -55,6 +55,19 @@ func (action *initializeAction) Handle(ctx context.Context, kameletbinding *v1al
 		return nil, err
 	}
 
+	flow, err := createKnativeFlowFor(ctx, action.client, kameletbinding)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range flow {
+		if err := kubernetes.ReplaceResource(ctx, action.client, r); err != nil {
+			return nil, errors.Wrapf(err, "could not create %s %s for kamelet binding", r.GetObjectKind().GroupVersionKind().Kind, r.GetName())
+		}
+	}
+	if err := deleteStaleKnativeFlowResources(ctx, action.client, kameletbinding, flow); err != nil {
+		return nil, err
+	}
+
 	if err := kubernetes.ReplaceResource(ctx, action.client, it); err != nil {
 		return nil, errors.Wrap(err, "could not create integration for kamelet binding")
 	}
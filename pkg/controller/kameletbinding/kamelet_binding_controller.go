@@ -40,6 +40,7 @@ import (
 	"github.com/apache/camel-k/pkg/client"
 	camelevent "github.com/apache/camel-k/pkg/event"
 	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/audit"
 	"github.com/apache/camel-k/pkg/util/monitoring"
 )
 
@@ -154,6 +155,8 @@ func (r *ReconcileKameletBinding) Reconcile(ctx context.Context, request reconci
 		return reconcile.Result{}, err
 	}
 
+	ctx = audit.WithTrigger(ctx, &instance)
+
 	actions := []Action{
 		NewInitializeAction(),
 		NewMonitorAction(),
@@ -181,6 +184,9 @@ func (r *ReconcileKameletBinding) Reconcile(ctx context.Context, request reconci
 			}
 
 			if target != nil {
+				target.Status.ObservedGeneration = instance.Generation
+				target.Status.SetPhaseConditions()
+
 				if err := r.client.Status().Patch(ctx, target, ctrl.MergeFrom(&instance)); err != nil {
 					camelevent.NotifyKameletBindingError(ctx, r.client, r.recorder, &instance, target, err)
 					return reconcile.Result{}, err
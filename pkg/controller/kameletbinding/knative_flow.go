@@ -0,0 +1,294 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	serving "knative.dev/serving/pkg/apis/serving/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/bindings"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/uri"
+	"github.com/apache/camel-k/pkg/webhook"
+)
+
+// knativeFlowEndpoint is the endpoint the integrations of a Knative flow receive the events from
+const knativeFlowEndpoint = "knative:endpoint/default"
+
+// knativeFlowFor returns the Knative flow the steps of the binding are materialized as,
+// or an empty string when they are run by the binding integration
+func knativeFlowFor(kameletbinding *v1alpha1.KameletBinding, profile v1.TraitProfile) (string, error) {
+	flow, ok := kameletbinding.Annotations[v1alpha1.KameletBindingKnativeFlowAnnotation]
+	if !ok || profile != v1.TraitProfileKnative || len(kameletbinding.Spec.Steps) == 0 {
+		return "", nil
+	}
+
+	switch flow {
+	case v1alpha1.KameletBindingKnativeFlowSequence, v1alpha1.KameletBindingKnativeFlowParallel:
+		return flow, nil
+	default:
+		return "", errors.Errorf("unsupported Knative flow %q in annotation %s, expected %q or %q", flow,
+			v1alpha1.KameletBindingKnativeFlowAnnotation, v1alpha1.KameletBindingKnativeFlowSequence, v1alpha1.KameletBindingKnativeFlowParallel)
+	}
+}
+
+func knativeFlowKind(flow string) string {
+	if flow == v1alpha1.KameletBindingKnativeFlowParallel {
+		return "Parallel"
+	}
+	return "Sequence"
+}
+
+// knativeFlowBinding returns the binding used by the binding integration to send the events to the Knative flow
+func knativeFlowBinding(kameletbinding *v1alpha1.KameletBinding, flow string) *bindings.Binding {
+	return &bindings.Binding{
+		URI: uri.AppendParameters(fmt.Sprintf("knative:endpoint/%s", url.PathEscape(kameletbinding.Name)), map[string]string{
+			"apiVersion": flowsv1.SchemeGroupVersion.String(),
+			"kind":       knativeFlowKind(flow),
+		}),
+	}
+}
+
+// createKnativeFlowFor returns the resources materializing the steps and the sink of the binding as a Knative flow:
+// an integration per step, an integration for the sink unless it references an addressable resource,
+// and the Knative Sequence or Parallel wiring them. It returns no resources when the binding is not materialized
+// as a Knative flow.
+func createKnativeFlowFor(ctx context.Context, c client.Client, kameletbinding *v1alpha1.KameletBinding) ([]ctrl.Object, error) {
+	profile, err := determineProfile(ctx, c, kameletbinding)
+	if err != nil {
+		return nil, err
+	}
+	flow, err := knativeFlowFor(kameletbinding, profile)
+	if err != nil || flow == "" {
+		return nil, err
+	}
+
+	bindingContext := bindings.BindingContext{
+		Ctx:       ctx,
+		Client:    c,
+		Namespace: kameletbinding.Namespace,
+		Profile:   profile,
+	}
+	errorHandler, err := maybeErrorHandler(kameletbinding.Spec.ErrorHandler, bindingContext)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine error handler")
+	}
+
+	resources := make([]ctrl.Object, 0, len(kameletbinding.Spec.Steps)+2)
+	destinations := make([]duckv1.Destination, 0, len(kameletbinding.Spec.Steps))
+	for idx, step := range kameletbinding.Spec.Steps {
+		position := idx
+		stepBinding, err := bindings.Translate(bindingContext, bindings.EndpointContext{
+			Type:     v1alpha1.EndpointTypeAction,
+			Position: &position,
+		}, step)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not determine URI for step %d", idx)
+		}
+		if stepBinding.Step == nil && stepBinding.URI == "" {
+			return nil, errors.Errorf("illegal step definition for step %d: either Step or URI should be provided", idx)
+		}
+
+		it, err := newKnativeFlowIntegration(kameletbinding, fmt.Sprintf("%s-step-%d", kameletbinding.Name, idx), profile, stepBinding, errorHandler)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, it)
+		destinations = append(destinations, knativeServiceDestination(it.Name))
+	}
+
+	var reply duckv1.Destination
+	if ref := kameletbinding.Spec.Sink.Ref; ref != nil && !isKameletReference(ref) {
+		reply = duckv1.Destination{
+			Ref: &duckv1.KReference{
+				APIVersion: ref.APIVersion,
+				Kind:       ref.Kind,
+				Namespace:  ref.Namespace,
+				Name:       ref.Name,
+			},
+		}
+	} else {
+		to, err := bindings.Translate(bindingContext, endpointTypeSinkContext, kameletbinding.Spec.Sink)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not determine sink URI")
+		}
+		if to.Step == nil && to.URI == "" {
+			return nil, errors.Errorf("illegal step definition for sink step: either Step or URI should be provided")
+		}
+
+		it, err := newKnativeFlowIntegration(kameletbinding, fmt.Sprintf("%s-sink", kameletbinding.Name), profile, to, errorHandler)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, it)
+		reply = knativeServiceDestination(it.Name)
+	}
+
+	meta := newIntegrationFor(kameletbinding, kameletbinding.Name).ObjectMeta
+	switch flow {
+	case v1alpha1.KameletBindingKnativeFlowParallel:
+		parallel := flowsv1.Parallel{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: flowsv1.SchemeGroupVersion.String(),
+				Kind:       "Parallel",
+			},
+			ObjectMeta: meta,
+			Spec: flowsv1.ParallelSpec{
+				Reply: &reply,
+			},
+		}
+		for _, d := range destinations {
+			parallel.Spec.Branches = append(parallel.Spec.Branches, flowsv1.ParallelBranch{
+				Subscriber: d,
+			})
+		}
+		resources = append(resources, &parallel)
+	default:
+		sequence := flowsv1.Sequence{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: flowsv1.SchemeGroupVersion.String(),
+				Kind:       "Sequence",
+			},
+			ObjectMeta: meta,
+			Spec: flowsv1.SequenceSpec{
+				Reply: &reply,
+			},
+		}
+		for _, d := range destinations {
+			sequence.Spec.Steps = append(sequence.Spec.Steps, flowsv1.SequenceStep{
+				Destination: d,
+			})
+		}
+		resources = append(resources, &sequence)
+	}
+
+	return resources, nil
+}
+
+// newKnativeFlowIntegration returns an integration running the given binding on the events it receives from the Knative flow
+func newKnativeFlowIntegration(kameletbinding *v1alpha1.KameletBinding, name string, profile v1.TraitProfile,
+	binding *bindings.Binding, errorHandler *bindings.Binding) (*v1.Integration, error) {
+	it := newIntegrationFor(kameletbinding, name)
+	it.Spec.Profile = profile
+
+	if err := configureBinding(&it, binding, errorHandler); err != nil {
+		return nil, err
+	}
+	sortConfiguration(&it)
+
+	s := binding.Step
+	if s == nil {
+		s = map[string]interface{}{
+			"to": binding.URI,
+		}
+	}
+	flowFrom := map[string]interface{}{
+		"from": map[string]interface{}{
+			"uri":   knativeFlowEndpoint,
+			"steps": []map[string]interface{}{s},
+		},
+	}
+	encodedFrom, err := json.Marshal(flowFrom)
+	if err != nil {
+		return nil, err
+	}
+	it.Spec.Flows = append(it.Spec.Flows, v1.Flow{RawMessage: encodedFrom})
+
+	if err := webhook.PropagateRequester(kameletbinding, &it); err != nil {
+		return nil, errors.Wrap(err, "could not sign the requester of the integration")
+	}
+
+	return &it, nil
+}
+
+func knativeServiceDestination(name string) duckv1.Destination {
+	return duckv1.Destination{
+		Ref: &duckv1.KReference{
+			APIVersion: serving.SchemeGroupVersion.String(),
+			Kind:       "Service",
+			Name:       name,
+		},
+	}
+}
+
+func isKameletReference(ref *corev1.ObjectReference) bool {
+	return ref.Kind == "Kamelet" && strings.HasPrefix(ref.APIVersion, "camel.apache.org/")
+}
+
+// deleteStaleKnativeFlowResources deletes the resources of a Knative flow previously created for the binding
+// that are not expected anymore, e.g. when the number of steps decreased or the annotation has been removed
+func deleteStaleKnativeFlowResources(ctx context.Context, c client.Client, kameletbinding *v1alpha1.KameletBinding, expected []ctrl.Object) error {
+	expectedNames := make(map[string]bool)
+	for _, r := range expected {
+		expectedNames[r.GetObjectKind().GroupVersionKind().Kind+"/"+r.GetName()] = true
+	}
+
+	integrations := v1.NewIntegrationList()
+	if err := c.List(ctx, &integrations,
+		ctrl.InNamespace(kameletbinding.Namespace),
+		ctrl.MatchingLabels{
+			kubernetes.CamelCreatorLabelKind: v1alpha1.KameletBindingKind,
+			kubernetes.CamelCreatorLabelName: kameletbinding.Name,
+		}); err != nil {
+		return err
+	}
+	for i := range integrations.Items {
+		it := &integrations.Items[i]
+		if it.Name == kameletbinding.Name || expectedNames[v1.IntegrationKind+"/"+it.Name] {
+			continue
+		}
+		if err := c.Delete(ctx, it); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "could not delete integration %s", it.Name)
+		}
+	}
+
+	meta := metav1.ObjectMeta{
+		Namespace: kameletbinding.Namespace,
+		Name:      kameletbinding.Name,
+	}
+	flows := map[string]ctrl.Object{
+		"Sequence": &flowsv1.Sequence{ObjectMeta: meta},
+		"Parallel": &flowsv1.Parallel{ObjectMeta: meta},
+	}
+	for kind, r := range flows {
+		if expectedNames[kind+"/"+kameletbinding.Name] {
+			continue
+		}
+		if err := c.Delete(ctx, r); err != nil && !k8serrors.IsNotFound(err) && !kubernetes.IsUnknownAPIError(err) {
+			return errors.Wrapf(err, "could not delete Knative %s %s", kind, kameletbinding.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func newKnativeFlowBinding(flow string) *v1alpha1.KameletBinding {
+	source := "timer:tick"
+	step0 := "log:step0"
+	step1 := "log:step1"
+	return &v1alpha1.KameletBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.KameletBindingKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-binding",
+			Annotations: map[string]string{
+				v1alpha1.KameletBindingKnativeFlowAnnotation: flow,
+			},
+		},
+		Spec: v1alpha1.KameletBindingSpec{
+			Integration: &v1.IntegrationSpec{
+				Profile: v1.TraitProfileKnative,
+			},
+			Source: v1alpha1.Endpoint{
+				URI: &source,
+			},
+			Steps: []v1alpha1.Endpoint{
+				{URI: &step0},
+				{URI: &step1},
+			},
+			Sink: v1alpha1.Endpoint{
+				Ref: &corev1.ObjectReference{
+					APIVersion: "serving.knative.dev/v1",
+					Kind:       "Service",
+					Name:       "my-sink",
+				},
+			},
+		},
+	}
+}
+
+func TestKnativeSequence(t *testing.T) {
+	binding := newKnativeFlowBinding(v1alpha1.KameletBindingKnativeFlowSequence)
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	it, err := createIntegrationFor(context.TODO(), c, binding)
+	assert.Nil(t, err)
+	assert.Len(t, it.Spec.Flows, 1)
+	assert.Contains(t, string(it.Spec.Flows[0].RawMessage), `"to":"knative:endpoint/my-binding?apiVersion=flows.knative.dev%2Fv1\u0026kind=Sequence"`)
+	assert.NotContains(t, string(it.Spec.Flows[0].RawMessage), "log:step0")
+
+	resources, err := createKnativeFlowFor(context.TODO(), c, binding)
+	assert.Nil(t, err)
+	assert.Len(t, resources, 3)
+
+	step0, ok := resources[0].(*v1.Integration)
+	assert.True(t, ok)
+	assert.Equal(t, "my-binding-step-0", step0.Name)
+	assert.Equal(t, v1.TraitProfileKnative, step0.Spec.Profile)
+	assert.Equal(t, `{"from":{"steps":[{"to":"log:step0"}],"uri":"knative:endpoint/default"}}`, string(step0.Spec.Flows[0].RawMessage))
+	assert.Equal(t, "my-binding-step-1", resources[1].GetName())
+
+	sequence, ok := resources[2].(*flowsv1.Sequence)
+	assert.True(t, ok)
+	assert.Equal(t, "my-binding", sequence.Name)
+	assert.Equal(t, binding.Name, sequence.OwnerReferences[0].Name)
+	assert.Len(t, sequence.Spec.Steps, 2)
+	assert.Equal(t, "Service", sequence.Spec.Steps[0].Ref.Kind)
+	assert.Equal(t, "my-binding-step-0", sequence.Spec.Steps[0].Ref.Name)
+	assert.Equal(t, "my-sink", sequence.Spec.Reply.Ref.Name)
+}
+
+func TestKnativeParallelWithSinkIntegration(t *testing.T) {
+	binding := newKnativeFlowBinding(v1alpha1.KameletBindingKnativeFlowParallel)
+	sink := "log:sink"
+	binding.Spec.Sink = v1alpha1.Endpoint{URI: &sink}
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	resources, err := createKnativeFlowFor(context.TODO(), c, binding)
+	assert.Nil(t, err)
+	assert.Len(t, resources, 4)
+
+	assert.Equal(t, "my-binding-sink", resources[2].GetName())
+	parallel, ok := resources[3].(*flowsv1.Parallel)
+	assert.True(t, ok)
+	assert.Len(t, parallel.Spec.Branches, 2)
+	assert.Equal(t, "my-binding-step-1", parallel.Spec.Branches[1].Subscriber.Ref.Name)
+	assert.Equal(t, "my-binding-sink", parallel.Spec.Reply.Ref.Name)
+}
+
+func TestKnativeFlowRequiresKnativeProfile(t *testing.T) {
+	binding := newKnativeFlowBinding(v1alpha1.KameletBindingKnativeFlowSequence)
+	binding.Spec.Integration.Profile = v1.TraitProfileKubernetes
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	resources, err := createKnativeFlowFor(context.TODO(), c, binding)
+	assert.Nil(t, err)
+	assert.Empty(t, resources)
+
+	it, err := createIntegrationFor(context.TODO(), c, binding)
+	assert.Nil(t, err)
+	assert.Contains(t, string(it.Spec.Flows[0].RawMessage), "log:step0")
+}
+
+func TestKnativeFlowUnsupported(t *testing.T) {
+	binding := newKnativeFlowBinding("fork")
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	_, err = createKnativeFlowFor(context.TODO(), c, binding)
+	assert.EqualError(t, err, `unsupported Knative flow "fork" in annotation camel.apache.org/knative.flow, expected "sequence" or "parallel"`)
+}
+
+func TestDeleteStaleKnativeFlowResources(t *testing.T) {
+	binding := newKnativeFlowBinding(v1alpha1.KameletBindingKnativeFlowSequence)
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	resources, err := createKnativeFlowFor(context.TODO(), c, binding)
+	assert.Nil(t, err)
+	for _, r := range resources {
+		assert.Nil(t, kubernetes.ReplaceResource(context.TODO(), c, r))
+	}
+
+	// one step less
+	binding.Spec.Steps = binding.Spec.Steps[:1]
+	resources, err = createKnativeFlowFor(context.TODO(), c, binding)
+	assert.Nil(t, err)
+	assert.Nil(t, deleteStaleKnativeFlowResources(context.TODO(), c, binding, resources))
+
+	err = c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "my-binding-step-1"}, &v1.Integration{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "my-binding-step-0"}, &v1.Integration{}))
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "my-binding"}, &flowsv1.Sequence{}))
+
+	// no Knative flow anymore
+	delete(binding.Annotations, v1alpha1.KameletBindingKnativeFlowAnnotation)
+	assert.Nil(t, deleteStaleKnativeFlowResources(context.TODO(), c, binding, nil))
+
+	err = c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "my-binding-step-0"}, &v1.Integration{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	err = c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "my-binding"}, &flowsv1.Sequence{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
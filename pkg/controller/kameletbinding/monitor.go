@@ -75,7 +75,16 @@ func (action *monitorAction) Handle(ctx context.Context, kameletbinding *v1alpha
 		return nil, err
 	}
 
-	if !equality.Semantic.DeepDerivative(expected.Spec, it.Spec) {
+	flow, err := createKnativeFlowFor(ctx, action.client, kameletbinding)
+	if err != nil {
+		return nil, err
+	}
+	phase, upToDate, err := action.knativeFlowPhase(ctx, flow, it.Status.Phase)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load the integrations of the Knative flow for KameletBinding %q", kameletbinding.Name)
+	}
+
+	if !upToDate || !equality.Semantic.DeepDerivative(expected.Spec, it.Spec) {
 		// KameletBinding has changed and needs rebuild
 		target := kameletbinding.DeepCopy()
 		// Rebuild the integration
@@ -91,7 +100,7 @@ func (action *monitorAction) Handle(ctx context.Context, kameletbinding *v1alpha
 
 	// Map integration phases to KameletBinding phases
 	target := kameletbinding.DeepCopy()
-	if it.Status.Phase == v1.IntegrationPhaseRunning {
+	if phase == v1.IntegrationPhaseRunning {
 		target.Status.Phase = v1alpha1.KameletBindingPhaseReady
 		target.Status.SetCondition(
 			v1alpha1.KameletBindingConditionReady,
@@ -99,7 +108,7 @@ func (action *monitorAction) Handle(ctx context.Context, kameletbinding *v1alpha
 			"",
 			"",
 		)
-	} else if it.Status.Phase == v1.IntegrationPhaseError {
+	} else if phase == v1.IntegrationPhaseError {
 		target.Status.Phase = v1alpha1.KameletBindingPhaseError
 		target.Status.SetCondition(
 			v1alpha1.KameletBindingConditionReady,
@@ -123,3 +132,32 @@ func (action *monitorAction) Handle(ctx context.Context, kameletbinding *v1alpha
 
 	return target, nil
 }
+
+// knativeFlowPhase aggregates the phase of the binding integration with the phases of the integrations of the Knative flow,
+// and reports whether these integrations match the expected ones
+func (action *monitorAction) knativeFlowPhase(ctx context.Context, flow []client.Object, phase v1.IntegrationPhase) (v1.IntegrationPhase, bool, error) {
+	for _, r := range flow {
+		expected, ok := r.(*v1.Integration)
+		if !ok {
+			continue
+		}
+		it := v1.Integration{}
+		if err := action.client.Get(ctx, client.ObjectKeyFromObject(expected), &it); err != nil && k8serrors.IsNotFound(err) {
+			return phase, false, nil
+		} else if err != nil {
+			return phase, false, err
+		}
+		if !equality.Semantic.DeepDerivative(expected.Spec, it.Spec) {
+			return phase, false, nil
+		}
+
+		switch {
+		case phase == v1.IntegrationPhaseError:
+		case it.Status.Phase == v1.IntegrationPhaseError:
+			phase = v1.IntegrationPhaseError
+		case phase == v1.IntegrationPhaseRunning:
+			phase = it.Status.Phase
+		}
+	}
+	return phase, true, nil
+}
@@ -0,0 +1,181 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenmanager
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/apache/camel-k/pkg/client"
+	camelevent "github.com/apache/camel-k/pkg/event"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/oauth2"
+)
+
+// minRefreshInterval is the minimum interval between two acquisitions of the access token of a client
+const minRefreshInterval = 30 * time.Second
+
+// Add creates a new token manager Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, c))
+}
+
+func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
+	return &reconcileTokenManager{
+		client:   c,
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("camel-k-token-manager-controller"),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	return builder.ControllerManagedBy(mgr).
+		Named("token-manager-controller").
+		// Watch for changes to the Secrets of the OAuth2 clients
+		For(&corev1.Secret{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(func(obj ctrl.Object) bool {
+				return obj.GetLabels()[oauth2.ClientLabel] == "true"
+			}),
+		)).
+		// Watch for changes to the Secrets holding the access tokens, so that they are restored when deleted
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
+
+var _ reconcile.Reconciler = &reconcileTokenManager{}
+
+// reconcileTokenManager acquires the access tokens of the OAuth2 clients, and refreshes them before they expire
+type reconcileTokenManager struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the API server
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile acquires an access token for the OAuth2 client Secret, when the token Secret does not exist,
+// has been acquired with another version of the client Secret, or is about to expire. The request is
+// requeued so that the access token is refreshed before it expires.
+func (r *reconcileTokenManager) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	rlog := Log.WithValues("request-namespace", request.Namespace, "request-name", request.Name)
+	rlog.Info("Reconciling OAuth2 client Secret")
+
+	// Make sure the operator is allowed to act on namespace
+	if ok, err := platform.IsOperatorAllowedOnNamespace(ctx, r.client, request.Namespace); err != nil {
+		return reconcile.Result{}, err
+	} else if !ok {
+		rlog.Info("Ignoring request because namespace is locked")
+		return reconcile.Result{}, nil
+	}
+
+	var secret corev1.Secret
+	if err := r.client.Get(ctx, request.NamespacedName, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// The token Secret is garbage collected
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if secret.Labels[oauth2.ClientLabel] != "true" {
+		return reconcile.Result{}, nil
+	}
+
+	var allowedTokenURLs []string
+	pl, err := platform.GetOrFind(ctx, r.client, secret.Namespace, "", true)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if pl != nil {
+		allowedTokenURLs = pl.Status.OAuth2.AllowedTokenURLs
+	}
+
+	credentials, err := oauth2.CredentialsFromSecret(&secret, allowedTokenURLs)
+	if err != nil {
+		// Wait for the Secret to be fixed
+		r.recorder.Event(&secret, corev1.EventTypeWarning, camelevent.ReasonOAuth2TokenError, err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	var token corev1.Secret
+	err = r.client.Get(ctx, ctrl.ObjectKey{Namespace: secret.Namespace, Name: oauth2.TokenSecretName(&secret)}, &token)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if err == nil && oauth2.IsAcquiredWith(&secret, &token) {
+		refreshTime, err := oauth2.RefreshTime(&secret, &token)
+		if err != nil {
+			r.recorder.Event(&secret, corev1.EventTypeWarning, camelevent.ReasonOAuth2TokenError, err.Error())
+			return reconcile.Result{}, nil
+		}
+		if refreshTime.IsZero() {
+			// The access token does not expire
+			return reconcile.Result{}, nil
+		}
+		if wait := time.Until(refreshTime); wait > 0 {
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+		// Use the refresh token rotated by the provider
+		if refreshToken, ok := token.Data[oauth2.RefreshTokenKey]; ok {
+			credentials.RefreshToken = string(refreshToken)
+		}
+	}
+
+	rlog.Info("Acquiring OAuth2 access token")
+	accessToken, err := credentials.Token(ctx)
+	if err != nil {
+		r.recorder.Eventf(&secret, corev1.EventTypeWarning, camelevent.ReasonOAuth2TokenError, "Cannot acquire OAuth2 access token: %v", err)
+		return reconcile.Result{}, err
+	}
+	tokenSecret := oauth2.NewTokenSecret(&secret, accessToken)
+	if err := kubernetes.ReplaceResource(ctx, r.client, tokenSecret); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(&secret, corev1.EventTypeNormal, camelevent.ReasonOAuth2TokenAcquired, "OAuth2 access token stored in secret %s", tokenSecret.Name)
+
+	refreshTime, err := oauth2.RefreshTime(&secret, tokenSecret)
+	if err != nil {
+		r.recorder.Event(&secret, corev1.EventTypeWarning, camelevent.ReasonOAuth2TokenError, err.Error())
+		return reconcile.Result{}, nil
+	}
+	if refreshTime.IsZero() {
+		return reconcile.Result{}, nil
+	}
+	wait := time.Until(refreshTime)
+	if wait < minRefreshInterval {
+		// The access token expires sooner than it's meant to be refreshed
+		wait = minRefreshInterval
+	}
+	return reconcile.Result{RequeueAfter: wait}, nil
+}
@@ -0,0 +1,163 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenmanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	xoauth2 "golang.org/x/oauth2"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/oauth2"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func newPlatform(allowedTokenURLs ...string) *v1.IntegrationPlatform {
+	p := v1.NewIntegrationPlatform("ns", "camel-k")
+	p.Spec.OAuth2.AllowedTokenURLs = allowedTokenURLs
+	p.ResyncStatusFullConfig()
+	p.Status.Phase = v1.IntegrationPlatformPhaseReady
+	return &p
+}
+
+func TestReconcileTokenManager(t *testing.T) {
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, requests)
+	}))
+	defer server.Close()
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "google",
+			Labels: map[string]string{
+				oauth2.ClientLabel: "true",
+			},
+		},
+		Data: map[string][]byte{
+			oauth2.TokenURLKey:     []byte(server.URL),
+			oauth2.ClientIDKey:     []byte("id"),
+			oauth2.ClientSecretKey: []byte("secret"),
+		},
+	}
+	c, err := test.NewFakeClient(&secret, newPlatform(server.URL+"/"))
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(10)
+	r := reconcileTokenManager{client: c, recorder: recorder}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "google"}}
+	ctx := context.WithValue(context.TODO(), xoauth2.HTTPClient, server.Client())
+
+	result, err := r.Reconcile(ctx, request)
+	assert.Nil(t, err)
+	assert.InDelta(t, (time.Hour - oauth2.DefaultRefreshBefore).Seconds(), result.RequeueAfter.Seconds(), 5)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, "Normal OAuth2TokenAcquired OAuth2 access token stored in secret google-token", <-recorder.Events)
+
+	var token corev1.Secret
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "google-token"}, &token))
+	assert.Equal(t, "token-1", string(token.Data[oauth2.AccessTokenKey]))
+
+	// The access token is still valid
+	result, err = r.Reconcile(ctx, request)
+	assert.Nil(t, err)
+	assert.True(t, result.RequeueAfter > 0)
+	assert.Equal(t, 1, requests)
+
+	// The access token is about to expire
+	token.Data[oauth2.ExpiryKey] = []byte(time.Now().Add(time.Minute).UTC().Format(time.RFC3339))
+	assert.Nil(t, c.Update(context.TODO(), &token))
+	_, err = r.Reconcile(ctx, request)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Nil(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "google-token"}, &token))
+	assert.Equal(t, "token-2", string(token.Data[oauth2.AccessTokenKey]))
+}
+
+func TestReconcileTokenManagerInvalidClient(t *testing.T) {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "salesforce",
+			Labels: map[string]string{
+				oauth2.ClientLabel: "true",
+			},
+		},
+		Data: map[string][]byte{
+			oauth2.ProviderKey: []byte("salesforce"),
+		},
+	}
+	c, err := test.NewFakeClient(&secret)
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(10)
+	r := reconcileTokenManager{client: c, recorder: recorder}
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "salesforce"}})
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, "Warning OAuth2TokenError the client-id key must be set in secret salesforce", <-recorder.Events)
+}
+
+func TestReconcileTokenManagerDisallowedTokenURL(t *testing.T) {
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "internal",
+			Labels: map[string]string{
+				oauth2.ClientLabel: "true",
+			},
+		},
+		Data: map[string][]byte{
+			oauth2.TokenURLKey: []byte(server.URL + "/token"),
+			oauth2.ClientIDKey: []byte("id"),
+		},
+	}
+	c, err := test.NewFakeClient(&secret, newPlatform("https://login.acme.com/"))
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(10)
+	r := reconcileTokenManager{client: c, recorder: recorder}
+	ctx := context.WithValue(context.TODO(), xoauth2.HTTPClient, server.Client())
+
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "internal"}})
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, 0, requests)
+	assert.Equal(t, fmt.Sprintf("Warning OAuth2TokenError invalid token-url key in secret internal: the token URL %s/token is not allowed by the integration platform", server.URL), <-recorder.Events)
+}
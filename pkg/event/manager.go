@@ -41,6 +41,19 @@ const (
 	ReasonIntegrationConditionChanged = "IntegrationConditionChanged"
 	// ReasonIntegrationError --
 	ReasonIntegrationError = "IntegrationError"
+	// ReasonIntegrationRebuildTriggered --
+	ReasonIntegrationRebuildTriggered = "RebuildTriggered"
+	// ReasonIntegrationKitSwitched --
+	ReasonIntegrationKitSwitched = "KitSwitched"
+	// ReasonIntegrationRolloutFailed --
+	ReasonIntegrationRolloutFailed = "RolloutFailed"
+	// ReasonIntegrationKitBuildFailed --
+	ReasonIntegrationKitBuildFailed = "KitBuildFailed"
+	// ReasonIntegrationDriftDetected --
+	ReasonIntegrationDriftDetected = "DriftDetected"
+
+	// ReasonIntegrationTemplateRollOutFailed --
+	ReasonIntegrationTemplateRollOutFailed = "IntegrationTemplateRollOutFailed"
 
 	// ReasonIntegrationKitPhaseUpdated --
 	ReasonIntegrationKitPhaseUpdated = "IntegrationKitPhaseUpdated"
@@ -62,6 +75,10 @@ const (
 	ReasonBuildConditionChanged = "BuildConditionChanged"
 	// ReasonBuildError --
 	ReasonBuildError = "BuildError"
+	// ReasonBuildFailed --
+	ReasonBuildFailed = "BuildFailed"
+	// ReasonBuildPrioritized --
+	ReasonBuildPrioritized = "BuildPrioritized"
 
 	// ReasonKameletError --
 	ReasonKameletError = "KameletError"
@@ -77,6 +94,11 @@ const (
 	// ReasonKameletBindingPhaseUpdated --
 	ReasonKameletBindingPhaseUpdated = "KameletBindingPhaseUpdated"
 
+	// ReasonOAuth2TokenAcquired --
+	ReasonOAuth2TokenAcquired = "OAuth2TokenAcquired"
+	// ReasonOAuth2TokenError --
+	ReasonOAuth2TokenError = "OAuth2TokenError"
+
 	// ReasonRelatedObjectChanged --
 	ReasonRelatedObjectChanged = "ReasonRelatedObjectChanged"
 )
@@ -108,6 +130,49 @@ func NotifyIntegrationUpdated(ctx context.Context, c client.Client, recorder rec
 		notifyIfConditionUpdated(recorder, new, oldConditions, new.Status.GetConditions(), "Integration", new.Name, ReasonIntegrationConditionChanged)
 	}
 	notifyIfPhaseUpdated(ctx, c, recorder, new, oldPhase, string(new.Status.Phase), "Integration", new.Name, ReasonIntegrationPhaseUpdated, "")
+	if old != nil {
+		notifyIntegrationTransitions(recorder, old, new)
+	}
+	notifyIntegrationProgress(recorder, old, new)
+}
+
+// notifyIntegrationTransitions generates the events of the integration lifecycle transitions that
+// cannot be told from the phase changes only
+func notifyIntegrationTransitions(recorder record.EventRecorder, old, new *v1.Integration) {
+	if old.Status.Digest != "" && new.Status.Digest != "" && old.Status.Digest != new.Status.Digest {
+		recorder.Eventf(new, corev1.EventTypeNormal, ReasonIntegrationRebuildTriggered, "Integration %q is rebuilt as its specification has changed", new.Name)
+	}
+
+	if oldKit, newKit := kitName(old), kitName(new); oldKit != "" && newKit != "" && oldKit != newKit {
+		recorder.Eventf(new, corev1.EventTypeNormal, ReasonIntegrationKitSwitched, "Integration %q switched from kit %q to kit %q", new.Name, oldKit, newKit)
+	}
+
+	if drift := new.Status.GetCondition(v1.IntegrationConditionResourcesInSync); drift != nil && drift.Status == corev1.ConditionFalse {
+		if previous := old.Status.GetCondition(v1.IntegrationConditionResourcesInSync); previous == nil || previous.Status != drift.Status || previous.Message != drift.Message {
+			recorder.Eventf(new, corev1.EventTypeWarning, ReasonIntegrationDriftDetected, "Integration %q resources have been changed externally: %s", new.Name, drift.Message)
+		}
+	}
+
+	if new.Status.Phase != v1.IntegrationPhaseError || old.Status.Phase == v1.IntegrationPhaseError {
+		return
+	}
+	switch old.Status.Phase {
+	case v1.IntegrationPhaseBuildingKit:
+		recorder.Eventf(new, corev1.EventTypeWarning, ReasonIntegrationKitBuildFailed, "Integration %q cannot run as its kit %q has failed to build", new.Name, kitName(new))
+	case v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning:
+		message := ""
+		if ready := new.Status.GetCondition(v1.IntegrationConditionReady); ready != nil && ready.Message != "" {
+			message = fmt.Sprintf(": %s", ready.Message)
+		}
+		recorder.Eventf(new, corev1.EventTypeWarning, ReasonIntegrationRolloutFailed, "Integration %q rollout failed%s", new.Name, message)
+	}
+}
+
+func kitName(integration *v1.Integration) string {
+	if integration.Status.IntegrationKit == nil {
+		return ""
+	}
+	return integration.Status.IntegrationKit.Name
 }
 
 // NotifyIntegrationKitUpdated automatically generates events when an integration kit changes
@@ -125,6 +190,13 @@ func NotifyIntegrationKitUpdated(ctx context.Context, c client.Client, recorder
 		notifyIfConditionUpdated(recorder, new, oldConditions, new.Status.GetConditions(), "Integration Kit", new.Name, ReasonIntegrationKitConditionChanged)
 	}
 	notifyIfPhaseUpdated(ctx, c, recorder, new, oldPhase, string(new.Status.Phase), "Integration Kit", new.Name, ReasonIntegrationKitPhaseUpdated, "")
+	if new.Status.Phase == v1.IntegrationKitPhaseError && oldPhase != string(v1.IntegrationKitPhaseError) {
+		reason := ""
+		if new.Status.Failure != nil {
+			reason = fmt.Sprintf(": %s", new.Status.Failure.Reason)
+		}
+		recorder.Eventf(new, corev1.EventTypeWarning, ReasonIntegrationKitBuildFailed, "Integration Kit %q has failed to build%s", new.Name, reason)
+	}
 }
 
 // NotifyIntegrationKitError automatically generates error events when the integration kit reconcile cycle phase has an error
@@ -247,6 +319,16 @@ func NotifyBuildUpdated(ctx context.Context, c client.Client, recorder record.Ev
 		info = fmt.Sprintf(" (recovery %d of %d)", attempt, attemptMax)
 	}
 	notifyIfPhaseUpdated(ctx, c, recorder, new, oldPhase, string(new.Status.Phase), "Build", new.Name, ReasonBuildPhaseUpdated, info)
+	if oldPhase != string(new.Status.Phase) {
+		notifyBuildProgress(ctx, c, recorder, new, info)
+	}
+	if (new.Status.Phase == v1.BuildPhaseFailed || new.Status.Phase == v1.BuildPhaseError) && oldPhase != string(new.Status.Phase) {
+		reason := ""
+		if new.Status.Error != "" {
+			reason = fmt.Sprintf(": %s", new.Status.Error)
+		}
+		recorder.Eventf(new, corev1.EventTypeWarning, ReasonBuildFailed, "Build %q has failed%s%s", new.Name, info, reason)
+	}
 }
 
 // NotifyBuildError automatically generates error events when the build reconcile cycle phase has an error
@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestNotifyIntegrationTransitions(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	old := v1.NewIntegration("ns", "my-integration")
+	old.Status.Phase = v1.IntegrationPhaseRunning
+	old.Status.Digest = "v1"
+	old.SetIntegrationKit(v1.NewIntegrationKit("ns", "kit-1"))
+
+	new := old.DeepCopy()
+	new.SetIntegrationKit(v1.NewIntegrationKit("ns", "kit-2"))
+	new.Status.Phase = v1.IntegrationPhaseError
+	new.Status.SetConditions(v1.IntegrationCondition{
+		Type:    v1.IntegrationConditionReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  v1.IntegrationConditionErrorReason,
+		Message: "pods in error",
+	})
+
+	recorder := record.NewFakeRecorder(10)
+	NotifyIntegrationUpdated(context.TODO(), c, recorder, &old, new)
+
+	events := drain(recorder)
+	assert.Contains(t, events, `Normal KitSwitched Integration "my-integration" switched from kit "kit-1" to kit "kit-2"`)
+	assert.Contains(t, events, `Warning RolloutFailed Integration "my-integration" rollout failed: pods in error`)
+
+	old = *new
+	new = old.DeepCopy()
+	new.Initialize()
+	new.Status.Digest = "v2"
+
+	NotifyIntegrationUpdated(context.TODO(), c, recorder, &old, new)
+	assert.Contains(t, drain(recorder), `Normal RebuildTriggered Integration "my-integration" is rebuilt as its specification has changed`)
+}
+
+func TestNotifyIntegrationDriftDetected(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	old := v1.NewIntegration("ns", "my-integration")
+	old.Status.Phase = v1.IntegrationPhaseRunning
+
+	new := old.DeepCopy()
+	new.Status.SetCondition(v1.IntegrationConditionResourcesInSync, corev1.ConditionFalse,
+		v1.IntegrationConditionResourcesDriftReason, "Deployment my-integration: container integration env FOO added")
+
+	recorder := record.NewFakeRecorder(10)
+	NotifyIntegrationUpdated(context.TODO(), c, recorder, &old, new)
+	assert.Contains(t, drain(recorder), `Warning DriftDetected Integration "my-integration" resources have been changed externally: Deployment my-integration: container integration env FOO added`)
+
+	// The drift is only reported once
+	old = *new
+	NotifyIntegrationUpdated(context.TODO(), c, recorder, &old, old.DeepCopy())
+	assert.Empty(t, drain(recorder))
+}
+
+func TestNotifyIntegrationKitBuildFailed(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	old := v1.NewIntegration("ns", "my-integration")
+	old.Status.Phase = v1.IntegrationPhaseBuildingKit
+	old.SetIntegrationKit(v1.NewIntegrationKit("ns", "my-kit"))
+
+	new := old.DeepCopy()
+	new.Status.Phase = v1.IntegrationPhaseError
+
+	recorder := record.NewFakeRecorder(10)
+	NotifyIntegrationUpdated(context.TODO(), c, recorder, &old, new)
+	assert.Contains(t, drain(recorder), `Warning KitBuildFailed Integration "my-integration" cannot run as its kit "my-kit" has failed to build`)
+
+	oldKit := v1.NewIntegrationKit("ns", "my-kit")
+	oldKit.Status.Phase = v1.IntegrationKitPhaseBuildRunning
+	newKit := oldKit.DeepCopy()
+	newKit.Status.Phase = v1.IntegrationKitPhaseError
+	newKit.Status.Failure = &v1.Failure{Reason: "maven build failed"}
+
+	NotifyIntegrationKitUpdated(context.TODO(), c, recorder, oldKit, newKit)
+	assert.Contains(t, drain(recorder), `Warning KitBuildFailed Integration Kit "my-kit" has failed to build: maven build failed`)
+}
+
+func TestNotifyBuildFailed(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	old := v1.Build{}
+	old.Name = "my-build"
+	old.Status.Phase = v1.BuildPhaseRunning
+
+	new := old.DeepCopy()
+	new.Status.Phase = v1.BuildPhaseFailed
+	new.Status.Error = "pod failed"
+
+	recorder := record.NewFakeRecorder(10)
+	NotifyBuildUpdated(context.TODO(), c, recorder, &old, new)
+	assert.Contains(t, drain(recorder), `Warning BuildFailed Build "my-build" has failed: pod failed`)
+}
+
+func drain(recorder *record.FakeRecorder) []string {
+	events := make([]string, 0)
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
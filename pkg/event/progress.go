@@ -0,0 +1,203 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+)
+
+const (
+	// ReasonIntegrationProgress --
+	ReasonIntegrationProgress = "Progress"
+
+	// ProgressStepAnnotation is the annotation of the progress events holding the lifecycle step of the integration
+	ProgressStepAnnotation = "camel.apache.org/progress.step"
+	// ProgressStatusAnnotation is the annotation of the progress events holding the status of the lifecycle step
+	ProgressStatusAnnotation = "camel.apache.org/progress.status"
+)
+
+// ProgressStep is a step of the integration lifecycle, from its initialization to its readiness
+type ProgressStep string
+
+const (
+	// ProgressStepInitialization --
+	ProgressStepInitialization ProgressStep = "Initialization"
+	// ProgressStepKit --
+	ProgressStepKit ProgressStep = "Kit"
+	// ProgressStepBuild --
+	ProgressStepBuild ProgressStep = "Build"
+	// ProgressStepDeploy --
+	ProgressStepDeploy ProgressStep = "Deploy"
+	// ProgressStepReady --
+	ProgressStepReady ProgressStep = "Ready"
+)
+
+// ProgressStatus is the status of a step of the integration lifecycle
+type ProgressStatus string
+
+const (
+	// ProgressStatusStarted --
+	ProgressStatusStarted ProgressStatus = "Started"
+	// ProgressStatusInProgress --
+	ProgressStatusInProgress ProgressStatus = "InProgress"
+	// ProgressStatusCompleted --
+	ProgressStatusCompleted ProgressStatus = "Completed"
+	// ProgressStatusFailed --
+	ProgressStatusFailed ProgressStatus = "Failed"
+)
+
+// Progress is the structured content of the progress events, that the clients can render as a timeline
+// instead of the raw event messages
+type Progress struct {
+	Step    ProgressStep
+	Status  ProgressStatus
+	Message string
+}
+
+// ProgressOf returns the progress reported by the given event, if it's a progress event
+func ProgressOf(event *corev1.Event) (Progress, bool) {
+	if event.Reason != ReasonIntegrationProgress {
+		return Progress{}, false
+	}
+	step, status := event.Annotations[ProgressStepAnnotation], event.Annotations[ProgressStatusAnnotation]
+	if step == "" || status == "" {
+		return Progress{}, false
+	}
+	return Progress{
+		Step:    ProgressStep(step),
+		Status:  ProgressStatus(status),
+		Message: event.Message,
+	}, true
+}
+
+func notifyProgress(recorder record.EventRecorder, it *v1.Integration, progress Progress) {
+	annotations := map[string]string{
+		ProgressStepAnnotation:   string(progress.Step),
+		ProgressStatusAnnotation: string(progress.Status),
+	}
+	eventType := corev1.EventTypeNormal
+	if progress.Status == ProgressStatusFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	recorder.AnnotatedEventf(it, annotations, eventType, ReasonIntegrationProgress, "%s", progress.Message)
+}
+
+// notifyIntegrationProgress generates the progress events of the integration lifecycle steps
+func notifyIntegrationProgress(recorder record.EventRecorder, old, new *v1.Integration) {
+	for _, progress := range integrationProgress(old, new) {
+		notifyProgress(recorder, new, progress)
+	}
+}
+
+// integrationProgress returns the progress of the integration lifecycle steps between the two versions
+// of the integration
+func integrationProgress(old, new *v1.Integration) []Progress {
+	oldPhase := v1.IntegrationPhaseNone
+	oldKit := ""
+	oldReady := false
+	if old != nil {
+		oldPhase = old.Status.Phase
+		oldKit = kitName(old)
+		oldReady = isReady(old)
+	}
+
+	progress := make([]Progress, 0)
+	if new.Status.Phase != oldPhase {
+		switch new.Status.Phase {
+		case v1.IntegrationPhaseInitialization:
+			progress = append(progress, Progress{ProgressStepInitialization, ProgressStatusStarted,
+				fmt.Sprintf("Integration %q is initializing", new.Name)})
+		case v1.IntegrationPhaseBuildingKit:
+			progress = append(progress, Progress{ProgressStepInitialization, ProgressStatusCompleted,
+				fmt.Sprintf("Integration %q is initialized", new.Name)})
+		case v1.IntegrationPhaseError:
+			progress = append(progress, Progress{failedStep(oldPhase), ProgressStatusFailed, failureMessage(new)})
+		}
+	}
+
+	if newKit := kitName(new); newKit != "" && newKit != oldKit {
+		progress = append(progress, Progress{ProgressStepKit, ProgressStatusCompleted,
+			fmt.Sprintf("Integration kit %q selected", newKit)})
+	}
+
+	if new.Status.Phase != oldPhase {
+		switch new.Status.Phase {
+		case v1.IntegrationPhaseBuildingKit:
+			progress = append(progress, Progress{ProgressStepBuild, ProgressStatusStarted,
+				fmt.Sprintf("Waiting for integration kit %q to be built", kitName(new))})
+		case v1.IntegrationPhaseDeploying:
+			if oldPhase == v1.IntegrationPhaseBuildingKit {
+				progress = append(progress, Progress{ProgressStepBuild, ProgressStatusCompleted,
+					fmt.Sprintf("Integration kit %q is ready", kitName(new))})
+			}
+			progress = append(progress, Progress{ProgressStepDeploy, ProgressStatusStarted,
+				fmt.Sprintf("Integration %q is deploying", new.Name)})
+		case v1.IntegrationPhaseRunning:
+			progress = append(progress, Progress{ProgressStepDeploy, ProgressStatusCompleted,
+				fmt.Sprintf("Integration %q is deployed", new.Name)})
+		}
+	}
+
+	if new.Status.Phase == v1.IntegrationPhaseRunning && isReady(new) && !oldReady {
+		progress = append(progress, Progress{ProgressStepReady, ProgressStatusCompleted,
+			fmt.Sprintf("Integration %q is ready", new.Name)})
+	}
+
+	return progress
+}
+
+// notifyBuildProgress generates the progress events of the build phases for the integration the build is created for
+func notifyBuildProgress(ctx context.Context, c client.Client, recorder record.EventRecorder, build *v1.Build, info string) {
+	_, creator := getCreatorObject(ctx, c, build)
+	if it, ok := creator.(*v1.Integration); ok {
+		notifyProgress(recorder, it, Progress{ProgressStepBuild, ProgressStatusInProgress,
+			fmt.Sprintf("Build %q in phase %q%s", build.Name, build.Status.Phase, info)})
+	}
+}
+
+func failedStep(phase v1.IntegrationPhase) ProgressStep {
+	switch phase {
+	case v1.IntegrationPhaseBuildingKit:
+		return ProgressStepBuild
+	case v1.IntegrationPhaseDeploying:
+		return ProgressStepDeploy
+	case v1.IntegrationPhaseRunning:
+		return ProgressStepReady
+	default:
+		return ProgressStepInitialization
+	}
+}
+
+func failureMessage(it *v1.Integration) string {
+	if ready := it.Status.GetCondition(v1.IntegrationConditionReady); ready != nil && ready.Message != "" {
+		return fmt.Sprintf("Integration %q has failed: %s", it.Name, ready.Message)
+	}
+	return fmt.Sprintf("Integration %q has failed", it.Name)
+}
+
+func isReady(it *v1.Integration) bool {
+	ready := it.Status.GetCondition(v1.IntegrationConditionReady)
+	return ready != nil && ready.Status == corev1.ConditionTrue
+}
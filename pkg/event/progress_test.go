@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestIntegrationProgress(t *testing.T) {
+	it := v1.NewIntegration("ns", "my-integration")
+	it.Status.Phase = v1.IntegrationPhaseInitialization
+
+	assert.Equal(t, []Progress{
+		{ProgressStepInitialization, ProgressStatusStarted, `Integration "my-integration" is initializing`},
+	}, integrationProgress(nil, &it))
+
+	building := it.DeepCopy()
+	building.Status.Phase = v1.IntegrationPhaseBuildingKit
+	building.SetIntegrationKit(v1.NewIntegrationKit("ns", "my-kit"))
+	assert.Equal(t, []Progress{
+		{ProgressStepInitialization, ProgressStatusCompleted, `Integration "my-integration" is initialized`},
+		{ProgressStepKit, ProgressStatusCompleted, `Integration kit "my-kit" selected`},
+		{ProgressStepBuild, ProgressStatusStarted, `Waiting for integration kit "my-kit" to be built`},
+	}, integrationProgress(&it, building))
+
+	deploying := building.DeepCopy()
+	deploying.Status.Phase = v1.IntegrationPhaseDeploying
+	assert.Equal(t, []Progress{
+		{ProgressStepBuild, ProgressStatusCompleted, `Integration kit "my-kit" is ready`},
+		{ProgressStepDeploy, ProgressStatusStarted, `Integration "my-integration" is deploying`},
+	}, integrationProgress(building, deploying))
+
+	running := deploying.DeepCopy()
+	running.Status.Phase = v1.IntegrationPhaseRunning
+	assert.Equal(t, []Progress{
+		{ProgressStepDeploy, ProgressStatusCompleted, `Integration "my-integration" is deployed`},
+	}, integrationProgress(deploying, running))
+
+	ready := running.DeepCopy()
+	ready.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionTrue, v1.IntegrationConditionReplicaSetReadyReason, "")
+	assert.Equal(t, []Progress{
+		{ProgressStepReady, ProgressStatusCompleted, `Integration "my-integration" is ready`},
+	}, integrationProgress(running, ready))
+	assert.Empty(t, integrationProgress(ready, ready.DeepCopy()))
+}
+
+func TestIntegrationProgressFailed(t *testing.T) {
+	it := v1.NewIntegration("ns", "my-integration")
+	it.Status.Phase = v1.IntegrationPhaseDeploying
+	it.SetIntegrationKit(v1.NewIntegrationKit("ns", "my-kit"))
+
+	failed := it.DeepCopy()
+	failed.Status.Phase = v1.IntegrationPhaseError
+	failed.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionFalse, v1.IntegrationConditionErrorReason, "pods in error")
+	assert.Equal(t, []Progress{
+		{ProgressStepDeploy, ProgressStatusFailed, `Integration "my-integration" has failed: pods in error`},
+	}, integrationProgress(&it, failed))
+}
+
+func TestNotifyIntegrationProgress(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	it := v1.NewIntegration("ns", "my-integration")
+	it.Status.Phase = v1.IntegrationPhaseInitialization
+
+	recorder := record.NewFakeRecorder(10)
+	NotifyIntegrationUpdated(context.TODO(), c, recorder, nil, &it)
+	assert.Contains(t, drain(recorder), `Normal Progress Integration "my-integration" is initializing`)
+}
+
+func TestProgressOf(t *testing.T) {
+	progress, ok := ProgressOf(&corev1.Event{
+		Reason:  ReasonIntegrationProgress,
+		Message: `Build "my-kit" in phase "Running"`,
+	})
+	assert.False(t, ok)
+	assert.Equal(t, Progress{}, progress)
+
+	evt := corev1.Event{
+		Reason:  ReasonIntegrationProgress,
+		Message: `Build "my-kit" in phase "Running"`,
+	}
+	evt.Annotations = map[string]string{
+		ProgressStepAnnotation:   string(ProgressStepBuild),
+		ProgressStatusAnnotation: string(ProgressStatusInProgress),
+	}
+	progress, ok = ProgressOf(&evt)
+	assert.True(t, ok)
+	assert.Equal(t, Progress{ProgressStepBuild, ProgressStatusInProgress, `Build "my-kit" in phase "Running"`}, progress)
+
+	evt.Reason = ReasonIntegrationPhaseUpdated
+	_, ok = ProgressOf(&evt)
+	assert.False(t, ok)
+}
@@ -0,0 +1,338 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/kamelet/repository"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// Build computes the graph of the resources the Integrations and KameletBindings of the given namespace depend on,
+// i.e., their kit, build, Camel catalog and platform, as well as the Kamelets, ConfigMaps and Secrets they use.
+// When names are given, only the Integrations and KameletBindings with these names are included.
+func Build(ctx context.Context, c client.Client, namespace string, names ...string) (*Graph, error) {
+	b := builder{
+		ctx:      ctx,
+		client:   c,
+		graph:    New(),
+		catalogs: make(map[string]*v1.CamelCatalog),
+	}
+
+	integrations := v1.NewIntegrationList()
+	if err := c.List(ctx, &integrations, ctrl.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	bindings := v1alpha1.NewKameletBindingList()
+	if err := c.List(ctx, &bindings, ctrl.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool)
+	for i := range integrations.Items {
+		integration := &integrations.Items[i]
+		if len(names) > 0 && !util.StringSliceExists(names, integration.Name) {
+			continue
+		}
+		found[integration.Name] = true
+		if err := b.addIntegration(integration); err != nil {
+			return nil, err
+		}
+	}
+	for i := range bindings.Items {
+		binding := &bindings.Items[i]
+		if len(names) > 0 && !util.StringSliceExists(names, binding.Name) {
+			continue
+		}
+		found[binding.Name] = true
+		if err := b.addKameletBinding(binding); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range names {
+		if !found[name] {
+			return nil, errors.Errorf("integration %s not found in namespace %s", name, namespace)
+		}
+	}
+
+	b.graph.Sort()
+	return b.graph, nil
+}
+
+type builder struct {
+	ctx    context.Context
+	client client.Client
+	graph  *Graph
+	// The Camel catalogs of the kits, builds and catalogs, by node identifier
+	catalogs map[string]*v1.CamelCatalog
+}
+
+func (b *builder) addIntegration(integration *v1.Integration) error {
+	id := b.graph.AddNode(Node{Kind: v1.IntegrationKind, Namespace: integration.Namespace, Name: integration.Name})
+
+	pl, err := platform.GetCurrent(b.ctx, b.client, integration.Namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	var catalog *v1.CamelCatalog
+	if ref := integration.Status.IntegrationKit; ref != nil && ref.Name != "" {
+		kitID, err := b.addKit(integration.GetIntegrationKitNamespace(pl), ref.Name)
+		if err != nil {
+			return err
+		}
+		b.graph.AddEdge(id, kitID)
+		catalog = b.catalogs[kitID]
+	}
+
+	configurations := append([]v1.ConfigurationSpec{}, integration.Spec.Configuration...)
+	configurations = append(configurations, integration.Status.Configuration...)
+	for _, configuration := range configurations {
+		var kind string
+		switch configuration.Type {
+		case "configmap":
+			kind = "ConfigMap"
+		case "secret":
+			kind = "Secret"
+		default:
+			continue
+		}
+		b.graph.AddEdge(id, b.graph.AddNode(Node{Kind: kind, Namespace: integration.Namespace, Name: configuration.Value}))
+	}
+
+	kamelets, err := b.kamelets(integration, catalog)
+	if err != nil {
+		return err
+	}
+	if len(kamelets) == 0 {
+		return nil
+	}
+	repo, err := repository.NewForPlatform(b.ctx, b.client, pl, integration.Namespace, platform.GetOperatorNamespace())
+	if err != nil {
+		return err
+	}
+	for _, name := range kamelets {
+		kameletID, err := b.addKamelet(repo, integration.Namespace, name)
+		if err != nil {
+			return err
+		}
+		b.graph.AddEdge(id, kameletID)
+	}
+
+	return nil
+}
+
+func (b *builder) addKameletBinding(binding *v1alpha1.KameletBinding) error {
+	id := b.graph.AddNode(Node{Kind: v1alpha1.KameletBindingKind, Namespace: binding.Namespace, Name: binding.Name})
+
+	integration := v1.NewIntegration(binding.Namespace, binding.Name)
+	node := Node{Kind: v1.IntegrationKind, Namespace: integration.Namespace, Name: integration.Name}
+	if _, ok := b.graph.Get(node.ID()); ok {
+		b.graph.AddEdge(id, node.ID())
+	} else if err := b.client.Get(b.ctx, ctrl.ObjectKeyFromObject(&integration), &integration); err == nil {
+		b.graph.AddEdge(id, b.graph.AddNode(node))
+		if err := b.addIntegration(&integration); err != nil {
+			return err
+		}
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	endpoints := append([]v1alpha1.Endpoint{binding.Spec.Source, binding.Spec.Sink}, binding.Spec.Steps...)
+	for _, endpoint := range endpoints {
+		ref := endpoint.Ref
+		if ref == nil || ref.Kind != v1alpha1.KameletKind || !strings.HasPrefix(ref.APIVersion, v1alpha1.SchemeGroupVersion.Group+"/") {
+			continue
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = binding.Namespace
+		}
+		node := Node{Kind: v1alpha1.KameletKind, Namespace: namespace, Name: ref.Name}
+		kamelet := v1alpha1.NewKamelet(namespace, ref.Name)
+		if err := b.client.Get(b.ctx, ctrl.ObjectKeyFromObject(&kamelet), &kamelet); k8serrors.IsNotFound(err) {
+			node.Missing = true
+		} else if err != nil {
+			return err
+		}
+		b.graph.AddEdge(id, b.graph.AddNode(node))
+	}
+
+	return nil
+}
+
+func (b *builder) addKit(namespace, name string) (string, error) {
+	node := Node{Kind: v1.IntegrationKitKind, Namespace: namespace, Name: name}
+	if _, ok := b.graph.Get(node.ID()); ok {
+		return node.ID(), nil
+	}
+
+	kit, err := kubernetes.GetIntegrationKit(b.ctx, b.client, name, namespace)
+	if k8serrors.IsNotFound(err) {
+		node.Missing = true
+		return b.graph.AddNode(node), nil
+	} else if err != nil {
+		return "", err
+	}
+	id := b.graph.AddNode(node)
+
+	// The build of a kit has the same name
+	build := v1.NewBuild(namespace, name)
+	if err := b.client.Get(b.ctx, ctrl.ObjectKeyFromObject(build), build); err == nil {
+		buildID := b.graph.AddNode(Node{Kind: v1.BuildKind, Namespace: namespace, Name: name})
+		b.graph.AddEdge(id, buildID)
+		for _, task := range build.Spec.Tasks {
+			if task.Builder == nil {
+				continue
+			}
+			catalogID, err := b.addCatalog(namespace, task.Builder.Runtime)
+			if err != nil {
+				return "", err
+			}
+			if catalogID != "" {
+				b.graph.AddEdge(buildID, catalogID)
+				b.catalogs[id] = b.catalogs[catalogID]
+			}
+		}
+		return id, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return "", err
+	}
+
+	// The kits that are not built by the operator, e.g., external kits, depend directly on the catalog
+	catalogID, err := b.addCatalog(namespace, v1.RuntimeSpec{Version: kit.Status.RuntimeVersion, Provider: kit.Status.RuntimeProvider})
+	if err != nil {
+		return "", err
+	}
+	if catalogID != "" {
+		b.graph.AddEdge(id, catalogID)
+		b.catalogs[id] = b.catalogs[catalogID]
+	}
+
+	return id, nil
+}
+
+func (b *builder) addCatalog(namespace string, runtime v1.RuntimeSpec) (string, error) {
+	if runtime.Version == "" {
+		return "", nil
+	}
+
+	catalogs := v1.NewCamelCatalogList()
+	if err := b.client.List(b.ctx, &catalogs, ctrl.InNamespace(namespace)); err != nil {
+		return "", err
+	}
+	for i := range catalogs.Items {
+		catalog := &catalogs.Items[i]
+		if catalog.Spec.Runtime.Version != runtime.Version || catalog.Spec.Runtime.Provider != runtime.Provider {
+			continue
+		}
+		node := Node{Kind: v1.CamelCatalogKind, Namespace: namespace, Name: catalog.Name}
+		if _, ok := b.graph.Get(node.ID()); ok {
+			return node.ID(), nil
+		}
+		id := b.graph.AddNode(node)
+		b.catalogs[id] = catalog
+
+		pl, err := platform.GetCurrent(b.ctx, b.client, namespace)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return "", err
+		}
+		if pl != nil {
+			b.graph.AddEdge(id, b.graph.AddNode(Node{Kind: v1.IntegrationPlatformKind, Namespace: pl.Namespace, Name: pl.Name}))
+		}
+		return id, nil
+	}
+
+	// The catalog has been deleted, or not created yet
+	return b.graph.AddNode(Node{
+		Kind:      v1.CamelCatalogKind,
+		Namespace: namespace,
+		Name:      "camel-catalog-" + strings.ToLower(runtime.Version) + "-" + string(runtime.Provider),
+		Missing:   true,
+	}), nil
+}
+
+func (b *builder) addKamelet(repo repository.KameletRepository, namespace, name string) (string, error) {
+	kamelet, err := repo.Get(b.ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if kamelet == nil {
+		return b.graph.AddNode(Node{Kind: v1alpha1.KameletKind, Namespace: namespace, Name: name, Missing: true}), nil
+	}
+	return b.graph.AddNode(Node{Kind: v1alpha1.KameletKind, Namespace: kamelet.Namespace, Name: kamelet.Name}), nil
+}
+
+// kamelets returns the names of the Kamelets used by the Integration, either configured with the kamelets trait,
+// or referenced from its sources
+func (b *builder) kamelets(integration *v1.Integration, catalog *v1.CamelCatalog) ([]string, error) {
+	kamelets := make([]string, 0)
+
+	if spec, ok := integration.Spec.Traits["kamelets"]; ok {
+		config := struct {
+			List string `json:"list"`
+		}{}
+		if err := json.Unmarshal(spec.Configuration.RawMessage, &config); err != nil {
+			return nil, err
+		}
+		for _, key := range strings.Split(config.List, ",") {
+			// The key may hold a configuration identifier, as <name>/<id>
+			if name := strings.SplitN(strings.TrimSpace(key), "/", 2)[0]; name != "" {
+				util.StringSliceUniqueAdd(&kamelets, name)
+			}
+		}
+	}
+
+	var runtimeCatalog *camel.RuntimeCatalog
+	if catalog != nil {
+		runtimeCatalog = camel.NewRuntimeCatalog(catalog.Spec)
+	} else {
+		var err error
+		if runtimeCatalog, err = camel.DefaultCatalog(); err != nil {
+			return nil, err
+		}
+	}
+	sources, err := kubernetes.ResolveIntegrationSources(b.ctx, b.client, integration, kubernetes.NewCollection())
+	if err != nil {
+		return nil, err
+	}
+	metadata.Each(runtimeCatalog, sources, func(_ int, meta metadata.IntegrationMetadata) bool {
+		for _, key := range meta.Kamelets {
+			util.StringSliceUniqueAdd(&kamelets, strings.SplitN(key, "/", 2)[0])
+		}
+		return true
+	})
+
+	return kamelets, nil
+}
@@ -0,0 +1,199 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Node is a resource of the graph
+type Node struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Missing is set when the resource is referenced, but does not exist
+	Missing bool `json:"missing,omitempty"`
+}
+
+// ID returns the identifier of the node, as <kind>/<namespace>/<name>
+func (n Node) ID() string {
+	return n.Kind + "/" + n.Namespace + "/" + n.Name
+}
+
+// Edge is the dependency of a resource on another one
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph holds resources and their dependencies
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+
+	nodes map[string]int
+	edges map[Edge]bool
+}
+
+// New creates an empty Graph
+func New() *Graph {
+	return &Graph{
+		Nodes: make([]Node, 0),
+		Edges: make([]Edge, 0),
+		nodes: make(map[string]int),
+		edges: make(map[Edge]bool),
+	}
+}
+
+// AddNode adds the node to the graph, unless it already exists, and returns its identifier
+func (g *Graph) AddNode(node Node) string {
+	id := node.ID()
+	if _, ok := g.nodes[id]; !ok {
+		g.nodes[id] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, node)
+	}
+	return id
+}
+
+// AddEdge records that the node identified with from depends on the node identified with to
+func (g *Graph) AddEdge(from, to string) {
+	edge := Edge{From: from, To: to}
+	if !g.edges[edge] {
+		g.edges[edge] = true
+		g.Edges = append(g.Edges, edge)
+	}
+}
+
+// Get returns the node with the given identifier
+func (g *Graph) Get(id string) (Node, bool) {
+	if i, ok := g.nodes[id]; ok {
+		return g.Nodes[i], true
+	}
+	return Node{}, false
+}
+
+// Find returns the node referenced either as <kind>/<name>, in the given namespace, or as <kind>/<namespace>/<name>.
+// The kind is case-insensitive.
+func (g *Graph) Find(ref string, namespace string) (Node, bool) {
+	parts := strings.Split(ref, "/")
+	switch len(parts) {
+	case 2:
+		parts = []string{parts[0], namespace, parts[1]}
+	case 3:
+	default:
+		return Node{}, false
+	}
+	for _, node := range g.Nodes {
+		if strings.EqualFold(node.Kind, parts[0]) && node.Namespace == parts[1] && node.Name == parts[2] {
+			return node, true
+		}
+	}
+	return Node{}, false
+}
+
+// Dependents returns the sub-graph of the node with the given identifier, and of all the nodes that depend on it,
+// directly or transitively, e.g., to determine the Integrations impacted by a change to the node
+func (g *Graph) Dependents(id string) *Graph {
+	dependents := make(map[string][]string)
+	for _, edge := range g.Edges {
+		dependents[edge.To] = append(dependents[edge.To], edge.From)
+	}
+
+	selected := make(map[string]bool)
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if selected[current] {
+			continue
+		}
+		if _, ok := g.nodes[current]; !ok {
+			continue
+		}
+		selected[current] = true
+		queue = append(queue, dependents[current]...)
+	}
+
+	sub := New()
+	for _, node := range g.Nodes {
+		if selected[node.ID()] {
+			sub.AddNode(node)
+		}
+	}
+	for _, edge := range g.Edges {
+		if selected[edge.From] && selected[edge.To] {
+			sub.AddEdge(edge.From, edge.To)
+		}
+	}
+	return sub
+}
+
+// Sort orders the nodes and the edges, so that the graph renders deterministically
+func (g *Graph) Sort() {
+	sort.SliceStable(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID() < g.Nodes[j].ID()
+	})
+	for i, node := range g.Nodes {
+		g.nodes[node.ID()] = i
+	}
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+}
+
+// WriteJSON renders the graph as JSON
+func (g *Graph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g)
+}
+
+// WriteDOT renders the graph in the DOT language, so that it can be drawn with Graphviz.
+// The missing resources are drawn with dashed lines.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph camelk {\n  rankdir=LR;\n  node [shape=box];"); err != nil {
+		return err
+	}
+	for _, node := range g.Nodes {
+		label := node.Kind + "\\n" + node.Name
+		if node.Namespace != "" {
+			label = node.Kind + "\\n" + node.Namespace + "/" + node.Name
+		}
+		style := ""
+		if node.Missing {
+			style = ", style=dashed"
+		}
+		if _, err := fmt.Fprintf(w, "  %q [label=\"%s\"%s];\n", node.ID(), label, style); err != nil {
+			return err
+		}
+	}
+	for _, edge := range g.Edges {
+		if _, err := fmt.Fprintf(w, "  %q -> %q;\n", edge.From, edge.To); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
@@ -0,0 +1,201 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestBuild(t *testing.T) {
+	c := newFakeClient(t)
+
+	g, err := Build(context.TODO(), c, "ns")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []Node{
+		{Kind: "Build", Namespace: "ns", Name: "my-kit"},
+		{Kind: "CamelCatalog", Namespace: "ns", Name: "camel-catalog-1.9.0-quarkus"},
+		{Kind: "ConfigMap", Namespace: "ns", Name: "my-configmap"},
+		{Kind: "Integration", Namespace: "ns", Name: "my-binding"},
+		{Kind: "Integration", Namespace: "ns", Name: "my-integration"},
+		{Kind: "IntegrationKit", Namespace: "ns", Name: "my-kit"},
+		{Kind: "IntegrationKit", Namespace: "ns", Name: "other-kit", Missing: true},
+		{Kind: "IntegrationPlatform", Namespace: "ns", Name: "camel-k"},
+		{Kind: "Kamelet", Namespace: "ns", Name: "my-sink", Missing: true},
+		{Kind: "Kamelet", Namespace: "ns", Name: "my-source"},
+		{Kind: "KameletBinding", Namespace: "ns", Name: "my-binding"},
+	}, g.Nodes)
+	assert.Equal(t, []Edge{
+		{From: "Build/ns/my-kit", To: "CamelCatalog/ns/camel-catalog-1.9.0-quarkus"},
+		{From: "CamelCatalog/ns/camel-catalog-1.9.0-quarkus", To: "IntegrationPlatform/ns/camel-k"},
+		{From: "Integration/ns/my-binding", To: "IntegrationKit/ns/other-kit"},
+		{From: "Integration/ns/my-integration", To: "ConfigMap/ns/my-configmap"},
+		{From: "Integration/ns/my-integration", To: "IntegrationKit/ns/my-kit"},
+		{From: "Integration/ns/my-integration", To: "Kamelet/ns/my-source"},
+		{From: "IntegrationKit/ns/my-kit", To: "Build/ns/my-kit"},
+		{From: "KameletBinding/ns/my-binding", To: "Integration/ns/my-binding"},
+		{From: "KameletBinding/ns/my-binding", To: "Kamelet/ns/my-sink"},
+		{From: "KameletBinding/ns/my-binding", To: "Kamelet/ns/my-source"},
+	}, g.Edges)
+}
+
+func TestBuildWithNames(t *testing.T) {
+	c := newFakeClient(t)
+
+	g, err := Build(context.TODO(), c, "ns", "my-binding")
+	assert.Nil(t, err)
+	_, ok := g.Find("integration/my-integration", "ns")
+	assert.False(t, ok)
+	_, ok = g.Find("Integration/ns/my-binding", "")
+	assert.True(t, ok)
+
+	_, err = Build(context.TODO(), c, "ns", "unknown")
+	assert.EqualError(t, err, "integration unknown not found in namespace ns")
+}
+
+func TestDependents(t *testing.T) {
+	c := newFakeClient(t)
+
+	g, err := Build(context.TODO(), c, "ns")
+	assert.Nil(t, err)
+
+	node, ok := g.Find("CamelCatalog/camel-catalog-1.9.0-quarkus", "ns")
+	assert.True(t, ok)
+	impact := g.Dependents(node.ID())
+	ids := make([]string, 0)
+	for _, n := range impact.Nodes {
+		ids = append(ids, n.ID())
+	}
+	assert.Equal(t, []string{
+		"Build/ns/my-kit",
+		"CamelCatalog/ns/camel-catalog-1.9.0-quarkus",
+		"Integration/ns/my-integration",
+		"IntegrationKit/ns/my-kit",
+	}, ids)
+	assert.Len(t, impact.Edges, 3)
+
+	node, ok = g.Find("Kamelet/my-source", "ns")
+	assert.True(t, ok)
+	impact = g.Dependents(node.ID())
+	assert.Len(t, impact.Nodes, 3)
+}
+
+func TestWriteDOT(t *testing.T) {
+	g := New()
+	integration := g.AddNode(Node{Kind: "Integration", Namespace: "ns", Name: "my-integration"})
+	g.AddEdge(integration, g.AddNode(Node{Kind: "IntegrationKit", Namespace: "ns", Name: "my-kit", Missing: true}))
+
+	var out bytes.Buffer
+	assert.Nil(t, g.WriteDOT(&out))
+	assert.Equal(t, `digraph camelk {
+  rankdir=LR;
+  node [shape=box];
+  "Integration/ns/my-integration" [label="Integration\nns/my-integration"];
+  "IntegrationKit/ns/my-kit" [label="IntegrationKit\nns/my-kit", style=dashed];
+  "Integration/ns/my-integration" -> "IntegrationKit/ns/my-kit";
+}
+`, out.String())
+}
+
+func TestHandler(t *testing.T) {
+	c := newFakeClient(t)
+	// alice is authorized to list the integrations of the ns namespace, bob is not
+	test.AuthorizeUsers(c, func(user string, attributes authorizationv1.ResourceAttributes) bool {
+		return user == "alice" && attributes.Resource == "integrations" && attributes.Verb == "list" && attributes.Namespace == "ns"
+	}, "alice", "bob")
+	handler := NewHandler(c)
+	serve := func(target string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := serve("/graph?namespace=ns&format=dot&impact=Kamelet/my-sink", "alice-token")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/vnd.graphviz", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `"KameletBinding/ns/my-binding" -> "Kamelet/ns/my-sink";`)
+
+	assert.Equal(t, http.StatusBadRequest, serve("/graph", "alice-token").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("/graph?namespace=ns", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("/graph?namespace=ns", "unknown-token").Code)
+	assert.Equal(t, http.StatusForbidden, serve("/graph?namespace=ns", "bob-token").Code)
+	assert.Equal(t, http.StatusForbidden, serve("/graph?namespace=other", "alice-token").Code)
+}
+
+func newFakeClient(t *testing.T) client.Client {
+	t.Helper()
+
+	runtime := v1.RuntimeSpec{Version: "1.9.0", Provider: v1.RuntimeProviderQuarkus}
+
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+
+	catalog := v1.NewCamelCatalog("ns", "camel-catalog-1.9.0-quarkus")
+	catalog.Spec.Runtime = runtime
+
+	kit := v1.NewIntegrationKit("ns", "my-kit")
+	kit.Status.RuntimeVersion = runtime.Version
+	kit.Status.RuntimeProvider = runtime.Provider
+
+	build := v1.NewBuild("ns", "my-kit")
+	build.Spec.Tasks = []v1.Task{{Builder: &v1.BuilderTask{Runtime: runtime}}}
+
+	integration := v1.NewIntegration("ns", "my-integration")
+	integration.Spec.Configuration = []v1.ConfigurationSpec{
+		{Type: "configmap", Value: "my-configmap"},
+		{Type: "property", Value: "my.property=value"},
+	}
+	integration.Spec.Traits = map[string]v1.TraitSpec{
+		"kamelets": test.TraitSpecFromMap(t, map[string]interface{}{"list": "my-source/my-config"}),
+	}
+	integration.Status.IntegrationKit = &corev1.ObjectReference{Namespace: "ns", Name: "my-kit"}
+
+	bindingIntegration := v1.NewIntegration("ns", "my-binding")
+	bindingIntegration.Status.IntegrationKit = &corev1.ObjectReference{Namespace: "ns", Name: "other-kit"}
+
+	kamelet := v1alpha1.NewKamelet("ns", "my-source")
+
+	binding := v1alpha1.NewKameletBinding("ns", "my-binding")
+	binding.Spec.Source.Ref = &corev1.ObjectReference{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Kamelet", Name: "my-source"}
+	binding.Spec.Sink.Ref = &corev1.ObjectReference{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Kamelet", Name: "my-sink"}
+
+	c, err := test.NewFakeClient(&pl, &catalog, kit, build, &integration, &bindingIntegration, &kamelet, &binding,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-configmap"}})
+	assert.Nil(t, err)
+
+	return c
+}
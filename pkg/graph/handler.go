@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"net/http"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// NewHandler returns an HTTP handler that renders the graph of the Integrations of the namespace given with the
+// `namespace` query parameter. The graph can be restricted to the Integrations given with the `integration` query
+// parameter, that can be repeated, and to the resources depending on the one given with the `impact` query parameter.
+// The format is either `json`, the default, or `dot`, given with the `format` query parameter. The requests must be
+// authenticated with a bearer token, whose user is authorized to list the Integrations of the namespace.
+func NewHandler(c client.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		namespace := query.Get("namespace")
+		if namespace == "" {
+			http.Error(w, "the namespace query parameter is required", http.StatusBadRequest)
+			return
+		}
+		format := query.Get("format")
+		if format != "" && format != "json" && format != "dot" {
+			http.Error(w, "unsupported format: "+format+", must be either json or dot", http.StatusBadRequest)
+			return
+		}
+
+		if !kubernetes.AuthorizeRequest(w, r, c, v1.SchemeGroupVersion.Group, "integrations", namespace, "", "list") {
+			return
+		}
+
+		g, err := Build(r.Context(), c, namespace, query["integration"]...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if impact := query.Get("impact"); impact != "" {
+			node, ok := g.Find(impact, namespace)
+			if !ok {
+				http.Error(w, "resource not found in the graph: "+impact, http.StatusNotFound)
+				return
+			}
+			g = g.Dependents(node.ID())
+		}
+
+		if format == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			err = g.WriteDOT(w)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			err = g.WriteJSON(w)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
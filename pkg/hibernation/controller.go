@@ -0,0 +1,238 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+var logger = log.WithName("hibernation")
+
+const (
+	// HibernationAnnotation can be set to false to prevent an Integration from being hibernated
+	HibernationAnnotation = "camel.apache.org/hibernation"
+	// HibernatedReplicasAnnotation records the replicas of a hibernated Integration, that are restored once it is awakened
+	HibernatedReplicasAnnotation = "camel.apache.org/hibernated-replicas"
+)
+
+// DefaultIdlePeriod is the default period without any exchange after which an Integration is hibernated
+const DefaultIdlePeriod = 30 * time.Minute
+
+// DefaultInterval is the period the activity of the Integrations is checked with
+const DefaultInterval = 1 * time.Minute
+
+// Controller is started once the operator instance is elected leader. It scales to zero the running Integrations
+// that have not processed any exchange during the idle period of their platform, and reports the ones that have
+// been scaled back since, either by the activator endpoint, or by an autoscaler like KEDA.
+type Controller struct {
+	client    client.Client
+	namespace string
+	interval  time.Duration
+}
+
+var _ manager.LeaderElectionRunnable = &Controller{}
+
+// NewController returns a Controller for the Integrations of the given namespace, or of all the namespaces if it is empty
+func NewController(c client.Client, namespace string) *Controller {
+	return &Controller{
+		client:    c,
+		namespace: namespace,
+		interval:  DefaultInterval,
+	}
+}
+
+// NeedLeaderElection --
+func (r *Controller) NeedLeaderElection() bool {
+	return true
+}
+
+// Start --
+func (r *Controller) Start(ctx context.Context) error {
+	for {
+		if err := r.Reconcile(ctx); err != nil {
+			logger.Error(err, "cannot reconcile the hibernation of the integrations")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// Reconcile hibernates the idle Integrations, and acknowledges the ones that have been awakened
+func (r *Controller) Reconcile(ctx context.Context) error {
+	integrations := v1.NewIntegrationList()
+	if err := r.client.List(ctx, &integrations, k8sclient.InNamespace(r.namespace)); err != nil {
+		return err
+	}
+
+	platforms := make(map[string]*v1.IntegrationPlatform)
+	for i := range integrations.Items {
+		it := &integrations.Items[i]
+
+		key := it.Namespace + "/" + it.Status.Platform
+		pl, ok := platforms[key]
+		if !ok {
+			var err error
+			if pl, err = platform.GetOrFind(ctx, r.client, it.Namespace, it.Status.Platform, true); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			platforms[key] = pl
+		}
+
+		var err error
+		if _, hibernated := it.Annotations[HibernatedReplicasAnnotation]; hibernated {
+			if !isEnabled(pl, it) || (it.Spec.Replicas != nil && *it.Spec.Replicas > 0) {
+				// Scaled back by an autoscaler, or hibernation has been disabled since
+				err = Awaken(ctx, r.client, it)
+			}
+		} else if isEnabled(pl, it) {
+			err = r.reconcileActivity(ctx, it, pl)
+		}
+		if err != nil && !k8serrors.IsConflict(err) {
+			return err
+		}
+		// Conflicting updates are retried on the next reconciliation
+	}
+
+	return nil
+}
+
+// reconcileActivity hibernates the Integration if it has been running, and has not processed any exchange,
+// for the idle period
+func (r *Controller) reconcileActivity(ctx context.Context, it *v1.Integration, pl *v1.IntegrationPlatform) error {
+	if it.Status.Phase != v1.IntegrationPhaseRunning || (it.Spec.Replicas != nil && *it.Spec.Replicas == 0) {
+		return nil
+	}
+	period := idlePeriod(pl)
+	since, ok := activeSince(it)
+	if !ok || time.Since(since) < period {
+		return nil
+	}
+
+	exchanges, found, err := CountExchanges(ctx, pl.Status.Hibernation.PrometheusURL, it, period)
+	if err != nil {
+		// Prometheus may be unavailable, do not prevent the other Integrations from being reconciled
+		logger.ForIntegration(it).Error(err, "cannot check the activity of the integration")
+		return nil
+	}
+	if !found || exchanges > 0 {
+		return nil
+	}
+
+	return hibernate(ctx, r.client, it, period)
+}
+
+// hibernate scales the Integration to zero, and records its replicas so that they are restored once it is awakened
+func hibernate(ctx context.Context, c client.Client, it *v1.Integration, period time.Duration) error {
+	replicas := ""
+	if it.Spec.Replicas != nil {
+		replicas = strconv.Itoa(int(*it.Spec.Replicas))
+	}
+	if it.Annotations == nil {
+		it.Annotations = make(map[string]string)
+	}
+	it.Annotations[HibernatedReplicasAnnotation] = replicas
+	zero := int32(0)
+	it.Spec.Replicas = &zero
+	if err := c.Update(ctx, it); err != nil {
+		return err
+	}
+
+	logger.ForIntegration(it).Infof("Hibernating integration idle for %s", period)
+	it.Status.SetCondition(v1.IntegrationConditionHibernated, corev1.ConditionTrue, v1.IntegrationConditionIdleReason,
+		fmt.Sprintf("the integration has not processed any exchange for %s", period))
+	return c.Status().Update(ctx, it)
+}
+
+// Awaken scales back a hibernated Integration to the replicas it had before, unless it has already been scaled
+// back, e.g., by an autoscaler
+func Awaken(ctx context.Context, c client.Client, it *v1.Integration) error {
+	replicas, ok := it.Annotations[HibernatedReplicasAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(it.Annotations, HibernatedReplicasAnnotation)
+	if it.Spec.Replicas == nil || *it.Spec.Replicas == 0 {
+		it.Spec.Replicas = nil
+		if replicas != "" {
+			n, err := strconv.Atoi(replicas)
+			if err != nil {
+				return errors.Wrapf(err, "invalid %s annotation value %q", HibernatedReplicasAnnotation, replicas)
+			}
+			r := int32(n)
+			it.Spec.Replicas = &r
+		}
+	}
+	if err := c.Update(ctx, it); err != nil {
+		return err
+	}
+
+	logger.ForIntegration(it).Info("Awakening hibernated integration")
+	it.Status.SetCondition(v1.IntegrationConditionHibernated, corev1.ConditionFalse, v1.IntegrationConditionAwakenedReason,
+		"the integration has been scaled back")
+	return c.Status().Update(ctx, it)
+}
+
+// isEnabled returns whether the Integration can be hibernated by its platform
+func isEnabled(pl *v1.IntegrationPlatform, it *v1.Integration) bool {
+	if pl == nil || !pl.Status.Hibernation.Enabled || pl.Status.Hibernation.PrometheusURL == "" {
+		return false
+	}
+	return it.Annotations[HibernationAnnotation] != "false"
+}
+
+func idlePeriod(pl *v1.IntegrationPlatform) time.Duration {
+	if p := pl.Status.Hibernation.IdlePeriod; p != nil && p.Duration > 0 {
+		return p.Duration
+	}
+	return DefaultIdlePeriod
+}
+
+// activeSince returns the time since which the Integration has been ready, or awakened if it is more recent,
+// so that it is not hibernated before it has had the chance to process exchanges for the idle period
+func activeSince(it *v1.Integration) (time.Time, bool) {
+	ready := it.Status.GetCondition(v1.IntegrationConditionReady)
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		return time.Time{}, false
+	}
+	since := ready.LastTransitionTime.Time
+	if hibernated := it.Status.GetCondition(v1.IntegrationConditionHibernated); hibernated != nil &&
+		hibernated.LastTransitionTime.After(since) {
+		since = hibernated.LastTransitionTime.Time
+	}
+	return since, true
+}
@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+// newPrometheus returns a Prometheus server reporting the given number of exchanges for each Integration
+func newPrometheus(t *testing.T, exchanges map[string]int) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		result := "[]"
+		for name, count := range exchanges {
+			if strings.Contains(r.Form.Get("query"), fmt.Sprintf("integration=%q", name)) {
+				result = fmt.Sprintf(`[{"metric":{},"value":[%d,"%d"]}]`, time.Now().Unix(), count)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":%s}}`, result)
+	}))
+}
+
+func newPlatform(url string) *v1.IntegrationPlatform {
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Hibernation = v1.IntegrationPlatformHibernationSpec{
+		Enabled:       true,
+		PrometheusURL: url,
+		IdlePeriod:    &metav1.Duration{Duration: 10 * time.Minute},
+	}
+	return &pl
+}
+
+func newIntegration(name string, readySince time.Duration) *v1.Integration {
+	it := v1.NewIntegration("ns", name)
+	it.Status.Phase = v1.IntegrationPhaseRunning
+	it.Status.Platform = "camel-k"
+	it.Status.Conditions = []v1.IntegrationCondition{
+		{
+			Type:               v1.IntegrationConditionReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-readySince)),
+		},
+	}
+	return &it
+}
+
+func getIntegration(t *testing.T, c client.Client, name string) *v1.Integration {
+	t.Helper()
+
+	it := v1.NewIntegration("ns", name)
+	require.NoError(t, c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(&it), &it))
+	return &it
+}
+
+func TestControllerReconcile(t *testing.T) {
+	prometheus := newPrometheus(t, map[string]int{"idle": 0, "busy": 12, "recent": 0, "opted-out": 0})
+	defer prometheus.Close()
+
+	replicas := int32(2)
+	idle := newIntegration("idle", time.Hour)
+	idle.Spec.Replicas = &replicas
+	optedOut := newIntegration("opted-out", time.Hour)
+	optedOut.Annotations = map[string]string{HibernationAnnotation: "false"}
+
+	c, err := test.NewFakeClient(newPlatform(prometheus.URL),
+		idle,
+		newIntegration("busy", time.Hour),
+		newIntegration("recent", time.Minute),
+		newIntegration("unmonitored", time.Hour),
+		optedOut,
+	)
+	require.NoError(t, err)
+
+	controller := NewController(c, "ns")
+	require.NoError(t, controller.Reconcile(context.TODO()))
+
+	idle = getIntegration(t, c, "idle")
+	require.NotNil(t, idle.Spec.Replicas)
+	assert.Equal(t, int32(0), *idle.Spec.Replicas)
+	assert.Equal(t, "2", idle.Annotations[HibernatedReplicasAnnotation])
+	condition := idle.Status.GetCondition(v1.IntegrationConditionHibernated)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionIdleReason, condition.Reason)
+
+	for _, name := range []string{"busy", "recent", "unmonitored", "opted-out"} {
+		it := getIntegration(t, c, name)
+		assert.Nil(t, it.Spec.Replicas, name)
+		assert.NotContains(t, it.Annotations, HibernatedReplicasAnnotation, name)
+	}
+
+	// The hibernated Integration is scaled back by an autoscaler
+	replicas = 1
+	idle.Spec.Replicas = &replicas
+	require.NoError(t, c.Update(context.TODO(), idle))
+	require.NoError(t, controller.Reconcile(context.TODO()))
+
+	idle = getIntegration(t, c, "idle")
+	require.NotNil(t, idle.Spec.Replicas)
+	assert.Equal(t, int32(1), *idle.Spec.Replicas)
+	assert.NotContains(t, idle.Annotations, HibernatedReplicasAnnotation)
+	condition = idle.Status.GetCondition(v1.IntegrationConditionHibernated)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionAwakenedReason, condition.Reason)
+
+	// The awakened Integration is given the idle period to process exchanges again
+	require.NoError(t, controller.Reconcile(context.TODO()))
+	assert.NotContains(t, getIntegration(t, c, "idle").Annotations, HibernatedReplicasAnnotation)
+}
+
+func TestActivator(t *testing.T) {
+	prometheus := newPrometheus(t, map[string]int{"idle": 0})
+	defer prometheus.Close()
+
+	c, err := test.NewFakeClient(newPlatform(prometheus.URL), newIntegration("idle", time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, NewController(c, "ns").Reconcile(context.TODO()))
+	require.Equal(t, int32(0), *getIntegration(t, c, "idle").Spec.Replicas)
+
+	// alice is only authorized to patch the idle Integration
+	test.AuthorizeUsers(c, func(user string, attributes authorizationv1.ResourceAttributes) bool {
+		return user == "alice" && attributes.Group == "camel.apache.org" && attributes.Resource == "integrations" &&
+			attributes.Verb == "patch" && attributes.Namespace == "ns" && attributes.Name == "idle"
+	}, "alice", "bob")
+	handler := NewHandler(c)
+	activate := func(method string, integration string, token string) int {
+		req := httptest.NewRequest(method, "/activate?namespace=ns&integration="+integration, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusMethodNotAllowed, activate(http.MethodGet, "idle", "alice-token"))
+	assert.Equal(t, http.StatusUnauthorized, activate(http.MethodPost, "idle", ""))
+	assert.Equal(t, http.StatusForbidden, activate(http.MethodPost, "idle", "bob-token"))
+	assert.Equal(t, http.StatusForbidden, activate(http.MethodPost, "missing", "alice-token"))
+	assert.Equal(t, int32(0), *getIntegration(t, c, "idle").Spec.Replicas)
+	assert.Equal(t, http.StatusNoContent, activate(http.MethodPost, "idle", "alice-token"))
+
+	it := getIntegration(t, c, "idle")
+	assert.Nil(t, it.Spec.Replicas)
+	assert.NotContains(t, it.Annotations, HibernatedReplicasAnnotation)
+	condition := it.Status.GetCondition(v1.IntegrationConditionHibernated)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.IntegrationConditionAwakenedReason, condition.Reason)
+}
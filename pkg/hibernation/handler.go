@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"net/http"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// NewHandler returns the HTTP activator, that awakens the hibernated Integration given with the `namespace`
+// and `integration` query parameters, when it receives a POST request. The requests must be authenticated with
+// a bearer token, whose user is authorized to patch the Integration.
+func NewHandler(c client.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "only the POST method is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		namespace, name := query.Get("namespace"), query.Get("integration")
+		if namespace == "" || name == "" {
+			http.Error(w, "the namespace and integration query parameters are required", http.StatusBadRequest)
+			return
+		}
+		if !kubernetes.AuthorizeRequest(w, r, c, v1.SchemeGroupVersion.Group, "integrations", namespace, name, "patch") {
+			return
+		}
+
+		it := v1.NewIntegration(namespace, name)
+		if err := c.Get(r.Context(), k8sclient.ObjectKeyFromObject(&it), &it); err != nil {
+			if k8serrors.IsNotFound(err) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := Awaken(r.Context(), c, &it); err != nil {
+			if k8serrors.IsConflict(err) {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
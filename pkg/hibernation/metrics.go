@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// exchangesMetric is the counter of the exchanges processed by the Camel context of an Integration. The series
+// are identified with the namespace and integration labels, that are added by the prometheus trait.
+const exchangesMetric = "application_camel_context_exchanges_total"
+
+// exchangesQuery returns the PromQL query of the number of exchanges processed by the Integration during the period
+func exchangesQuery(it *v1.Integration, period time.Duration) string {
+	return fmt.Sprintf(`sum(increase(%s{namespace=%q,integration=%q}[%s]))`,
+		exchangesMetric, it.Namespace, it.Name, model.Duration(period))
+}
+
+// CountExchanges queries the Prometheus server for the number of exchanges the Integration has processed
+// during the period. It reports false when no metrics are found, e.g., when the prometheus trait is disabled.
+func CountExchanges(ctx context.Context, url string, it *v1.Integration, period time.Duration) (float64, bool, error) {
+	client, err := api.NewClient(api.Config{Address: url})
+	if err != nil {
+		return 0, false, err
+	}
+
+	value, _, err := promv1.NewAPI(client).Query(ctx, exchangesQuery(it, period), time.Now())
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "cannot query the exchanges of integration %s/%s", it.Namespace, it.Name)
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected result type %s for the exchanges of integration %s/%s", value.Type(), it.Namespace, it.Name)
+	}
+	if len(vector) == 0 {
+		return 0, false, nil
+	}
+	return float64(vector[0].Value), true, nil
+}
@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ide
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+// newClient returns a client that authenticates the alice and bob tokens, alice only being authorized to get
+// the catalogs of the ns namespace
+func newClient(t *testing.T, objects ...runtime.Object) client.Client {
+	t.Helper()
+
+	c, err := test.NewFakeClient(objects...)
+	require.NoError(t, err)
+	test.AuthorizeUsers(c, func(user string, attributes authorizationv1.ResourceAttributes) bool {
+		return user == "alice" && attributes.Group == "camel.apache.org" && attributes.Resource == "camelcatalogs" &&
+			attributes.Verb == "get" && attributes.Namespace == "ns"
+	}, "alice", "bob")
+	return c
+}
+
+func serve(c client.Client, token string, namespace string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/ide?namespace="+namespace, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	NewHandler(c).ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestHandler(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	require.NoError(t, err)
+	cc := v1.NewCamelCatalogWithSpecs("ns", "camel-catalog", catalog.CamelCatalogSpec)
+
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Build.RuntimeVersion = catalog.Runtime.Version
+	pl.Status.Kamelet.Repositories = []v1.IntegrationPlatformKameletRepositorySpec{{URI: "none"}}
+
+	kamelet := v1alpha1.NewKamelet("ns", "my-source")
+	kamelet.Labels = map[string]string{v1alpha1.KameletTypeLabel: v1alpha1.KameletTypeSource}
+	kamelet.Spec.Definition = &v1alpha1.JSONSchemaProps{
+		Title:    "My Source",
+		Required: []string{"topic"},
+		Properties: map[string]v1alpha1.JSONSchemaProp{
+			"topic": {Type: "string", Description: "The topic"},
+		},
+	}
+	kamelet.Spec.Dependencies = []string{"camel:kafka"}
+
+	c := newClient(t, &cc, &pl, &kamelet)
+
+	recorder := serve(c, "alice-token", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = serve(c, "", "ns")
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+
+	recorder = serve(c, "unknown-token", "ns")
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	recorder = serve(c, "bob-token", "ns")
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = serve(c, "alice-token", "ns")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var m Metadata
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &m))
+	assert.Equal(t, "camel-k", m.Platform)
+	assert.Equal(t, catalog.Runtime.Version, m.Runtime.Version)
+	yamlLoader := catalog.Loaders["yaml"]
+	assert.Contains(t, m.Loaders, Loader{Language: "yaml", Dependency: yamlLoader.GetDependencyID()})
+	assert.Contains(t, m.Components, Component{Scheme: "timer", Dependency: "camel:timer"})
+	assert.Contains(t, m.DataFormats, Artifact{Name: "json-jackson", Dependency: "camel:jackson"})
+	assert.Contains(t, m.Languages, Artifact{Name: "jsonpath", Dependency: "camel:jsonpath"})
+	assert.Equal(t, []Kamelet{
+		{
+			Name:         "my-source",
+			Type:         v1alpha1.KameletTypeSource,
+			Title:        "My Source",
+			Required:     []string{"topic"},
+			Properties:   map[string]v1alpha1.JSONSchemaProp{"topic": {Type: "string", Description: "The topic"}},
+			Dependencies: []string{"camel:kafka"},
+		},
+	}, m.Kamelets)
+}
+
+func TestHandlerWithoutPlatform(t *testing.T) {
+	c := newClient(t)
+
+	recorder := serve(c, "alice-token", "ns")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
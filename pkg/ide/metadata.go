@@ -0,0 +1,206 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ide
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/kamelet/repository"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+// Metadata is the information IDEs require to complete, and validate, the integration sources, as they are resolved
+// by the operator with the catalog of the runtime of the active platform
+type Metadata struct {
+	Platform    string         `json:"platform"`
+	Runtime     v1.RuntimeSpec `json:"runtime"`
+	Loaders     []Loader       `json:"loaders"`
+	Components  []Component    `json:"components"`
+	Languages   []Artifact     `json:"languages"`
+	DataFormats []Artifact     `json:"dataFormats"`
+	Kamelets    []Kamelet      `json:"kamelets"`
+}
+
+// Loader is the dependency that loads the sources of a language
+type Loader struct {
+	Language     string   `json:"language"`
+	Dependency   string   `json:"dependency"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// Component is a Camel component scheme, along with the dependencies the endpoints using it are resolved to
+type Component struct {
+	Scheme               string   `json:"scheme"`
+	Dependency           string   `json:"dependency"`
+	Passive              bool     `json:"passive,omitempty"`
+	HTTP                 bool     `json:"http,omitempty"`
+	ConsumerDependencies []string `json:"consumerDependencies,omitempty"`
+	ProducerDependencies []string `json:"producerDependencies,omitempty"`
+}
+
+// Artifact is a Camel language or data format, along with the dependency it is resolved to
+type Artifact struct {
+	Name       string `json:"name"`
+	Dependency string `json:"dependency"`
+}
+
+// Kamelet is a Kamelet that can be referenced by the integrations, along with the schema of its properties
+type Kamelet struct {
+	Name         string                             `json:"name"`
+	Type         string                             `json:"type,omitempty"`
+	Title        string                             `json:"title,omitempty"`
+	Description  string                             `json:"description,omitempty"`
+	Required     []string                           `json:"required,omitempty"`
+	Properties   map[string]v1alpha1.JSONSchemaProp `json:"properties,omitempty"`
+	Dependencies []string                           `json:"dependencies,omitempty"`
+}
+
+// Build returns the Metadata of the active platform of the given namespace
+func Build(ctx context.Context, c client.Client, namespace string) (*Metadata, error) {
+	p, err := platform.GetOrFind(ctx, c, namespace, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime := v1.RuntimeSpec{
+		Version:  p.Status.Build.RuntimeVersion,
+		Provider: v1.RuntimeProviderQuarkus,
+	}
+	catalog, err := camel.LoadCatalog(ctx, c, p.Namespace, runtime)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("unable to find the catalog of the runtime version %s of platform %s", runtime.Version, p.Name)
+	}
+
+	m := Metadata{
+		Platform:    p.Name,
+		Runtime:     catalog.Runtime,
+		Loaders:     make([]Loader, 0, len(catalog.Loaders)),
+		Components:  make([]Component, 0),
+		Languages:   make([]Artifact, 0),
+		DataFormats: make([]Artifact, 0),
+		Kamelets:    make([]Kamelet, 0),
+	}
+
+	for language, loader := range catalog.Loaders {
+		l := Loader{
+			Language:   language,
+			Dependency: loader.GetDependencyID(),
+		}
+		for _, d := range loader.Dependencies {
+			l.Dependencies = append(l.Dependencies, d.GetDependencyID())
+		}
+		m.Loaders = append(m.Loaders, l)
+	}
+
+	catalog.VisitSchemes(func(id string, scheme v1.CamelScheme) bool {
+		artifact := catalog.GetArtifactByScheme(id)
+		m.Components = append(m.Components, Component{
+			Scheme:               id,
+			Dependency:           artifact.GetDependencyID(),
+			Passive:              scheme.Passive,
+			HTTP:                 scheme.HTTP,
+			ConsumerDependencies: artifact.GetConsumerDependencyIDs(id),
+			ProducerDependencies: artifact.GetProducerDependencyIDs(id),
+		})
+		return true
+	})
+
+	languages := make(map[string]bool)
+	dataFormats := make(map[string]bool)
+	catalog.VisitArtifacts(func(_ string, artifact v1.CamelArtifact) bool {
+		for _, language := range artifact.Languages {
+			languages[language] = true
+		}
+		for _, dataFormat := range artifact.DataFormats {
+			dataFormats[dataFormat] = true
+		}
+		return true
+	})
+	for language := range languages {
+		// The languages of the common dependencies are always available to the integrations
+		if dependency, ok := catalog.GetLanguageDependency(language); ok {
+			m.Languages = append(m.Languages, Artifact{Name: language, Dependency: dependency})
+		}
+	}
+	for dataFormat := range dataFormats {
+		m.DataFormats = append(m.DataFormats, Artifact{Name: dataFormat, Dependency: catalog.GetArtifactByDataFormat(dataFormat).GetDependencyID()})
+	}
+
+	if m.Kamelets, err = kamelets(ctx, c, p, namespace); err != nil {
+		return nil, err
+	}
+
+	m.sort()
+
+	return &m, nil
+}
+
+// kamelets returns the Kamelets the integrations of the given namespace can reference
+func kamelets(ctx context.Context, c client.Client, p *v1.IntegrationPlatform, namespace string) ([]Kamelet, error) {
+	repo, err := repository.NewForPlatform(ctx, c, p, namespace, platform.GetOperatorNamespace())
+	if err != nil {
+		return nil, err
+	}
+	names, err := repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kamelets := make([]Kamelet, 0, len(names))
+	for _, name := range names {
+		kamelet, err := repo.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if kamelet == nil {
+			continue
+		}
+
+		k := Kamelet{
+			Name:         kamelet.Name,
+			Type:         kamelet.Labels[v1alpha1.KameletTypeLabel],
+			Dependencies: kamelet.Spec.Dependencies,
+		}
+		if d := kamelet.Spec.Definition; d != nil {
+			k.Title = d.Title
+			k.Description = d.Description
+			k.Required = d.Required
+			k.Properties = d.Properties
+		}
+		kamelets = append(kamelets, k)
+	}
+	return kamelets, nil
+}
+
+// sort orders the metadata by name, as the catalog is made of maps
+func (m *Metadata) sort() {
+	sort.Slice(m.Loaders, func(i, j int) bool { return m.Loaders[i].Language < m.Loaders[j].Language })
+	sort.Slice(m.Components, func(i, j int) bool { return m.Components[i].Scheme < m.Components[j].Scheme })
+	sort.Slice(m.Languages, func(i, j int) bool { return m.Languages[i].Name < m.Languages[j].Name })
+	sort.Slice(m.DataFormats, func(i, j int) bool { return m.DataFormats[i].Name < m.DataFormats[j].Name })
+	sort.Slice(m.Kamelets, func(i, j int) bool { return m.Kamelets[i].Name < m.Kamelets[j].Name })
+}
@@ -123,6 +123,11 @@ func SetupClusterWideResourcesOrCollect(ctx context.Context, clientProvider clie
 		return err
 	}
 
+	// Install CRD for Integration Template (if needed)
+	if err := installCRD(ctx, c, "IntegrationTemplate", "v1", "camel.apache.org_integrationtemplates.yaml", downgradeToCRDv1beta1, collection, force); err != nil {
+		return err
+	}
+
 	// Install CRD for Camel Catalog (if needed)
 	if err := installCRD(ctx, c, "CamelCatalog", "v1", "camel.apache.org_camelcatalogs.yaml", downgradeToCRDv1beta1, collection, force); err != nil {
 		return err
@@ -173,6 +178,16 @@ func SetupClusterWideResourcesOrCollect(ctx context.Context, clientProvider clie
 		}
 	}
 
+	ok, err = isClusterRoleInstalled(ctx, c, "camel-k-operator-auth-reviews")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := installResource(ctx, c, collection, "/rbac/operator-cluster-role-auth-reviews.yaml"); err != nil {
+			return err
+		}
+	}
+
 	isOpenShift, err := isOpenShift(c, clusterType)
 	if err != nil {
 		return err
@@ -231,6 +246,11 @@ func areAllCrdInstalled(ctx context.Context, c client.Client) (bool, error) {
 	} else if !ok {
 		return false, nil
 	}
+	if ok, err := isCrdInstalled(ctx, c, "IntegrationTemplate", "v1"); err != nil {
+		return ok, err
+	} else if !ok {
+		return false, nil
+	}
 	if ok, err := isCrdInstalled(ctx, c, "CamelCatalog", "v1"); err != nil {
 		return ok, err
 	} else if !ok {
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -54,6 +55,8 @@ type OperatorConfiguration struct {
 	ClusterType           string
 	Health                OperatorHealthConfiguration
 	Monitoring            OperatorMonitoringConfiguration
+	Replicas              int32
+	LeaderElection        OperatorLeaderElectionConfiguration
 	Tolerations           []string
 	NodeSelectors         []string
 	ResourcesRequirements []string
@@ -69,6 +72,12 @@ type OperatorMonitoringConfiguration struct {
 	Port    int32
 }
 
+type OperatorLeaderElectionConfiguration struct {
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
 // OperatorOrCollect installs the operator resources or adds them to the collector if present
 func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfiguration, collection *kubernetes.Collection, force bool) error {
 	isOpenShift, err := isOpenShift(c, cfg.ClusterType)
@@ -158,6 +167,29 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 			}
 		}
 
+		if cfg.Replicas > 0 {
+			if d, ok := o.(*appsv1.Deployment); ok {
+				if d.Labels["camel.apache.org/component"] == "operator" {
+					configureOperatorReplicas(d, cfg.Replicas)
+				}
+			}
+		}
+
+		if d, ok := o.(*appsv1.Deployment); ok {
+			if d.Labels["camel.apache.org/component"] == "operator" {
+				args := &d.Spec.Template.Spec.Containers[0].Args
+				if cfg.LeaderElection.LeaseDuration > 0 {
+					*args = append(*args, fmt.Sprintf("--leader-election-lease-duration=%s", cfg.LeaderElection.LeaseDuration))
+				}
+				if cfg.LeaderElection.RenewDeadline > 0 {
+					*args = append(*args, fmt.Sprintf("--leader-election-renew-deadline=%s", cfg.LeaderElection.RenewDeadline))
+				}
+				if cfg.LeaderElection.RetryPeriod > 0 {
+					*args = append(*args, fmt.Sprintf("--leader-election-retry-period=%s", cfg.LeaderElection.RetryPeriod))
+				}
+			}
+		}
+
 		if cfg.Global {
 			if d, ok := o.(*appsv1.Deployment); ok {
 				if d.Labels["camel.apache.org/component"] == "operator" {
@@ -281,6 +313,10 @@ func OperatorOrCollect(ctx context.Context, c client.Client, cfg OperatorConfigu
 		fmt.Println("Warning: the operator will not be able to get CustomResourceDefinitions resources and the service-binding trait will fail if used. Try installing the operator as cluster-admin.")
 	}
 
+	if errmtr := installClusterRoleBinding(ctx, c, collection, cfg.Namespace, "camel-k-operator-auth-reviews", "/rbac/operator-cluster-role-binding-auth-reviews.yaml"); errmtr != nil {
+		fmt.Println("Warning: the operator will not be able to authenticate the users of the IDE endpoint, nor to authorize the restricted traits. Try installing the operator as cluster-admin.")
+	}
+
 	if cfg.Monitoring.Enabled {
 		if err := installMonitoringResources(ctx, c, cfg.Namespace, customizer, collection, force); err != nil {
 			if k8serrors.IsForbidden(err) {
@@ -405,6 +441,33 @@ func installStrimziBindings(ctx context.Context, c client.Client, namespace stri
 	)
 }
 
+// configureOperatorReplicas sets the number of operator replicas. When the operator runs with standby replicas,
+// they are spread across nodes, and rolled out one at a time, so that a leader is always available.
+func configureOperatorReplicas(d *appsv1.Deployment, replicas int32) {
+	d.Spec.Replicas = &replicas
+	if replicas < 2 {
+		return
+	}
+	d.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+	}
+	d.Spec.Template.Spec.Affinity = &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: d.Spec.Selector.MatchLabels,
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
 func installMonitoringResources(ctx context.Context, c client.Client, namespace string, customizer ResourceCustomizer, collection *kubernetes.Collection, force bool) error {
 	return ResourcesOrCollect(ctx, c, namespace, collection, force, customizer,
 		"/prometheus/operator-pod-monitor.yaml",
@@ -444,7 +507,7 @@ func PlatformOrCollect(ctx context.Context, c client.Client, clusterType string,
 				return nil, err
 			}
 			if address == nil {
-				return nil, errors.New("cannot find automatically a registry where to push images")
+				return nil, errors.New("cannot find automatically a registry where to push images, use the --local-registry option to deploy one in the cluster")
 			}
 
 			pl.Spec.Build.Registry.Address = *address
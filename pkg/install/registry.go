@@ -0,0 +1,422 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/client"
+)
+
+const (
+	localRegistryName      = "camel-k-registry"
+	localRegistryProxyName = "camel-k-registry-proxy"
+	localRegistryImage     = "docker.io/library/registry:2"
+	localRegistryPort      = 5000
+	localRegistryTLSSecret = localRegistryName + "-tls"
+	localRegistryCAName    = localRegistryName + "-ca"
+	localRegistryCertsPath = "/certs"
+)
+
+// Local cluster distributions, the node configuration hints are tailored to
+const (
+	LocalClusterKind     = "kind"
+	LocalClusterMinikube = "minikube"
+	LocalClusterK3s      = "k3s"
+)
+
+// LocalRegistrySpec configures the registry deployed in the cluster, for local development clusters
+type LocalRegistrySpec struct {
+	// TLS serves the registry over TLS, with a self-signed certificate
+	TLS bool
+	// ProxyRemoteURL is the URL of an upstream registry, e.g. https://registry-1.docker.io, that's
+	// cached by a pull-through proxy registry deployed along with the local registry
+	ProxyRemoteURL string
+}
+
+// LocalRegistry reports the registry deployed in the cluster
+type LocalRegistry struct {
+	// Address is the address the images are pushed to, and pulled from, by the cluster nodes
+	Address string
+	// CA is the name of the ConfigMap holding the registry certificate, when served over TLS
+	CA string
+	// Certificate is the PEM encoded registry certificate, when served over TLS
+	Certificate []byte
+	// ProxyAddress is the address of the pull-through proxy registry, if any
+	ProxyAddress string
+	// Cluster is the detected local cluster distribution, if any
+	Cluster string
+}
+
+// LocalRegistryOrError deploys a container image registry in the namespace, that's meant for local development clusters,
+// e.g. kind, Minikube or k3s. The registry is exposed with a ClusterIP Service, as its address must be reachable from both the
+// builder pods, that push the images, and the cluster nodes, that pull them.
+func LocalRegistryOrError(ctx context.Context, c client.Client, namespace string, spec LocalRegistrySpec, force bool) (*LocalRegistry, error) {
+	registry := LocalRegistry{}
+
+	cluster, err := DetectLocalCluster(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	registry.Cluster = cluster
+
+	svc, err := localRegistryService(ctx, c, namespace, localRegistryName, force)
+	if err != nil {
+		return nil, err
+	}
+	registry.Address = fmt.Sprintf("%s:%d", svc.Spec.ClusterIP, localRegistryPort)
+
+	env := []corev1.EnvVar{
+		{Name: "REGISTRY_STORAGE_DELETE_ENABLED", Value: "true"},
+	}
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	if spec.TLS {
+		certificate, err := localRegistryCertificate(ctx, c, namespace, svc, force)
+		if err != nil {
+			return nil, err
+		}
+		registry.Certificate = certificate
+		registry.CA = localRegistryCAName
+
+		env = append(env,
+			corev1.EnvVar{Name: "REGISTRY_HTTP_TLS_CERTIFICATE", Value: localRegistryCertsPath + "/" + corev1.TLSCertKey},
+			corev1.EnvVar{Name: "REGISTRY_HTTP_TLS_KEY", Value: localRegistryCertsPath + "/" + corev1.TLSPrivateKeyKey},
+		)
+		volumes = append(volumes, corev1.Volume{
+			Name: "certs",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: localRegistryTLSSecret,
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "certs",
+			MountPath: localRegistryCertsPath,
+			ReadOnly:  true,
+		})
+	}
+	if err := createOrReplace(ctx, c, force, localRegistryDeployment(namespace, localRegistryName, env, volumes, mounts)); err != nil {
+		return nil, err
+	}
+
+	if spec.ProxyRemoteURL != "" {
+		proxy, err := localRegistryService(ctx, c, namespace, localRegistryProxyName, force)
+		if err != nil {
+			return nil, err
+		}
+		registry.ProxyAddress = fmt.Sprintf("%s:%d", proxy.Spec.ClusterIP, localRegistryPort)
+
+		env := []corev1.EnvVar{
+			{Name: "REGISTRY_PROXY_REMOTEURL", Value: spec.ProxyRemoteURL},
+		}
+		if err := createOrReplace(ctx, c, force, localRegistryDeployment(namespace, localRegistryProxyName, env, nil, nil)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &registry, nil
+}
+
+// DetectLocalCluster returns the local cluster distribution, i.e. kind, Minikube or k3s, from the nodes of the cluster,
+// or an empty string otherwise
+func DetectLocalCluster(ctx context.Context, c client.Client) (string, error) {
+	nodes := corev1.NodeList{}
+	if err := c.List(ctx, &nodes); err != nil {
+		if k8serrors.IsForbidden(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	for _, node := range nodes.Items {
+		switch {
+		case strings.HasPrefix(node.Spec.ProviderID, "kind://"):
+			return LocalClusterKind, nil
+		case node.Labels["minikube.k8s.io/name"] != "":
+			return LocalClusterMinikube, nil
+		case strings.Contains(node.Status.NodeInfo.KubeletVersion, "+k3s"):
+			return LocalClusterK3s, nil
+		}
+	}
+	return "", nil
+}
+
+// LocalRegistryNodeHints returns the instructions to configure the container runtime of the cluster nodes,
+// so that they pull the images from the local registry
+func LocalRegistryNodeHints(registry *LocalRegistry) string {
+	scheme := "http"
+	if registry.CA != "" {
+		scheme = "https"
+	}
+
+	hints := strings.Builder{}
+	switch registry.Cluster {
+	case LocalClusterMinikube:
+		fmt.Fprintf(&hints, "Start Minikube with the --insecure-registry=%s option, so that the nodes can pull the images from the registry, e.g.:\n", registry.Address)
+		fmt.Fprintf(&hints, "  minikube start --insecure-registry=%s\n", registry.Address)
+	case LocalClusterK3s:
+		fmt.Fprintf(&hints, "Add the registry to the /etc/rancher/k3s/registries.yaml file of each node, and restart k3s:\n")
+		fmt.Fprintf(&hints, "  mirrors:\n    \"%s\":\n      endpoint:\n      - \"%s://%s\"\n", registry.Address, scheme, registry.Address)
+		fmt.Fprintf(&hints, "  configs:\n    \"%s\":\n      tls:\n        insecure_skip_verify: true\n", registry.Address)
+		if registry.ProxyAddress != "" {
+			fmt.Fprintf(&hints, "  mirrors:\n    \"docker.io\":\n      endpoint:\n      - \"http://%s\"\n", registry.ProxyAddress)
+		}
+	default:
+		dir := "/etc/containerd/certs.d/" + registry.Address
+		if registry.Cluster == LocalClusterKind {
+			fmt.Fprintf(&hints, "Configure the containerd runtime of each kind node, e.g. with docker exec <node> sh -c '...', to pull the images from the registry:\n")
+		} else {
+			fmt.Fprintf(&hints, "Configure the containerd runtime of each node, whose config_path must be set to /etc/containerd/certs.d, to pull the images from the registry:\n")
+		}
+		fmt.Fprintf(&hints, "  mkdir -p %s && cat > %s/hosts.toml <<EOF\n", dir, dir)
+		fmt.Fprintf(&hints, "  [host.\"%s://%s\"]\n    capabilities = [\"pull\", \"resolve\"]\n    skip_verify = true\n  EOF\n", scheme, registry.Address)
+		if registry.ProxyAddress != "" {
+			fmt.Fprintf(&hints, "Use the pull-through proxy as a mirror of Docker Hub:\n")
+			fmt.Fprintf(&hints, "  mkdir -p /etc/containerd/certs.d/docker.io && cat > /etc/containerd/certs.d/docker.io/hosts.toml <<EOF\n")
+			fmt.Fprintf(&hints, "  [host.\"http://%s\"]\n    capabilities = [\"pull\", \"resolve\"]\n  EOF\n", registry.ProxyAddress)
+		}
+	}
+	return hints.String()
+}
+
+func localRegistryService(ctx context.Context, c client.Client, namespace string, name string, force bool) (*corev1.Service, error) {
+	svc := corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    localRegistryLabels(name),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: localRegistryLabels(name),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "registry",
+					Port:       localRegistryPort,
+					TargetPort: intstr.FromInt(localRegistryPort),
+				},
+			},
+		},
+	}
+
+	// The Service is never replaced, so that its address, the images are pulled from, remains stable
+	if err := c.Create(ctx, &svc); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	if err := c.Get(ctx, ctrl.ObjectKeyFromObject(&svc), &svc); err != nil {
+		return nil, err
+	}
+	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return nil, fmt.Errorf("service %s has no cluster IP", name)
+	}
+	return &svc, nil
+}
+
+func localRegistryDeployment(namespace string, name string, env []corev1.EnvVar, volumes []corev1.Volume, mounts []corev1.VolumeMount) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    localRegistryLabels(name),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: localRegistryLabels(name),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: localRegistryLabels(name),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "registry",
+							Image: localRegistryImage,
+							Env:   env,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "registry",
+									ContainerPort: localRegistryPort,
+								},
+							},
+							VolumeMounts: append([]corev1.VolumeMount{
+								{
+									Name:      "data",
+									MountPath: "/var/lib/registry",
+								},
+							}, mounts...),
+						},
+					},
+					Volumes: append([]corev1.Volume{
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					}, volumes...),
+				},
+			},
+		},
+	}
+}
+
+// localRegistryCertificate returns the certificate of the registry, generating a self-signed one for its address
+// when it does not exist yet
+func localRegistryCertificate(ctx context.Context, c client.Client, namespace string, svc *corev1.Service, force bool) ([]byte, error) {
+	secret := corev1.Secret{}
+	err := c.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: localRegistryTLSSecret}, &secret)
+	if err == nil && !force && len(secret.Data[corev1.TLSCertKey]) > 0 {
+		return secret.Data[corev1.TLSCertKey], nil
+	} else if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	certificate, key, err := generateSelfSignedCertificate(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	secret = corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      localRegistryTLSSecret,
+			Labels:    localRegistryLabels(localRegistryName),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certificate,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}
+	if err := createOrReplace(ctx, c, true, &secret); err != nil {
+		return nil, err
+	}
+
+	// The builder trusts the registry certificate from the service-ca.crt key
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      localRegistryCAName,
+			Labels:    localRegistryLabels(localRegistryName),
+		},
+		Data: map[string]string{
+			"service-ca.crt": string(certificate),
+		},
+	}
+	if err := createOrReplace(ctx, c, true, &cm); err != nil {
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+func generateSelfSignedCertificate(svc *corev1.Service) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName: svc.Name,
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP(svc.Spec.ClusterIP)},
+		DNSNames: []string{
+			svc.Name,
+			svc.Name + "." + svc.Namespace,
+			svc.Name + "." + svc.Namespace + ".svc",
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		nil
+}
+
+func createOrReplace(ctx context.Context, c client.Client, force bool, obj ctrl.Object) error {
+	if err := ObjectOrCollect(ctx, c, obj.GetNamespace(), nil, force, obj); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func localRegistryLabels(name string) map[string]string {
+	return map[string]string{
+		"app":                    "camel-k",
+		"app.kubernetes.io/name": name,
+	}
+}
@@ -0,0 +1,321 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	ociv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+const (
+	// KameletsLabel is the label of the bundle configuration that lists the names of the Kamelets it contains
+	KameletsLabel = "org.apache.camel.kamelets"
+
+	kameletsDir = "kamelets"
+	schemasDir  = "schemas"
+	iconsDir    = "icons"
+
+	kameletSuffix = ".kamelet.yaml"
+	schemaSuffix  = ".schema.json"
+
+	// lastAppliedConfigAnnotation is set by kubectl apply, and is not packaged
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// iconExtensions maps the media types of the Kamelet icons to the extension of the files they are packaged into
+var iconExtensions = map[string]string{
+	"image/svg+xml": "svg",
+	"image/png":     "png",
+	"image/jpeg":    "jpg",
+}
+
+// Package packages the given Kamelets into an OCI artifact. Each Kamelet is packaged with its JSON schema,
+// i.e., its definition, and its icon, when it is a base64 data URI, as separate files.
+func Package(kamelets []v1alpha1.Kamelet) (ociv1.Image, error) {
+	if len(kamelets) == 0 {
+		return nil, errors.New("no Kamelet to package")
+	}
+
+	sorted := make([]v1alpha1.Kamelet, len(kamelets))
+	copy(sorted, kamelets)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var content bytes.Buffer
+	tw := tar.NewWriter(&content)
+	names := make([]string, 0, len(sorted))
+	for i := range sorted {
+		if err := writeKamelet(tw, &sorted[i]); err != nil {
+			return nil, err
+		}
+		names = append(names, sorted[i].Name)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	data := content.Bytes()
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := mutate.Config(empty.Image, ociv1.Config{
+		Labels: map[string]string{
+			KameletsLabel: strings.Join(names, ","),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	img, err = mutate.AppendLayers(img, ociLayer{layer})
+	if err != nil {
+		return nil, err
+	}
+	return mutate.MediaType(img, types.OCIManifestSchema1), nil
+}
+
+// KameletNames returns the names of the Kamelets packaged into the given artifact, without pulling its content
+func KameletNames(img ociv1.Image) ([]string, error) {
+	config, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	names := config.Config.Labels[KameletsLabel]
+	if names == "" {
+		return nil, errors.New("the image is not a Kamelet bundle")
+	}
+	return strings.Split(names, ","), nil
+}
+
+// Unpack returns the Kamelets packaged into the given OCI artifact, along with their JSON schemas and icons
+func Unpack(img ociv1.Image) ([]v1alpha1.Kamelet, error) {
+	if _, err := KameletNames(img); err != nil {
+		return nil, err
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+
+	kamelets := make(map[string]*v1alpha1.Kamelet)
+	schemas := make(map[string][]byte)
+	icons := make(map[string]string)
+	for _, layer := range layers {
+		rc, err := layer.Uncompressed()
+		if err != nil {
+			return nil, err
+		}
+		err = readEntries(rc, func(name string, data []byte) error {
+			dir, file := path.Split(name)
+			switch {
+			case dir == kameletsDir+"/" && strings.HasSuffix(file, kameletSuffix):
+				kamelet, err := parseKamelet(data)
+				if err != nil {
+					return errors.Wrapf(err, "invalid Kamelet %s", name)
+				}
+				kamelets[kamelet.Name] = kamelet
+			case dir == schemasDir+"/" && strings.HasSuffix(file, schemaSuffix):
+				schemas[strings.TrimSuffix(file, schemaSuffix)] = data
+			case dir == iconsDir+"/":
+				ext := path.Ext(file)
+				for mediaType, e := range iconExtensions {
+					if "."+e == ext {
+						icons[strings.TrimSuffix(file, ext)] = "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data)
+					}
+				}
+			}
+			return nil
+		})
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]v1alpha1.Kamelet, 0, len(kamelets))
+	for name, kamelet := range kamelets {
+		if schema, ok := schemas[name]; ok && kamelet.Spec.Definition == nil {
+			definition := v1alpha1.JSONSchemaProps{}
+			if err := json.Unmarshal(schema, &definition); err != nil {
+				return nil, errors.Wrapf(err, "invalid JSON schema of Kamelet %s", name)
+			}
+			kamelet.Spec.Definition = &definition
+		}
+		if icon, ok := icons[name]; ok && kamelet.Annotations[v1alpha1.AnnotationIcon] == "" {
+			if kamelet.Annotations == nil {
+				kamelet.Annotations = make(map[string]string)
+			}
+			kamelet.Annotations[v1alpha1.AnnotationIcon] = icon
+		}
+		result = append(result, *kamelet)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// writeKamelet writes the Kamelet, stripped from its cluster specific metadata, its JSON schema and its icon
+func writeKamelet(tw *tar.Writer, source *v1alpha1.Kamelet) error {
+	if source.Name == "" {
+		return errors.New("cannot package a Kamelet without name")
+	}
+
+	kamelet := v1alpha1.NewKamelet("", source.Name)
+	kamelet.Labels = source.Labels
+	kamelet.Spec = *source.Spec.DeepCopy()
+	for k, v := range source.Annotations {
+		if k == lastAppliedConfigAnnotation {
+			continue
+		}
+		if kamelet.Annotations == nil {
+			kamelet.Annotations = make(map[string]string)
+		}
+		kamelet.Annotations[k] = v
+	}
+
+	if kamelet.Spec.Definition != nil {
+		schema, err := json.MarshalIndent(kamelet.Spec.Definition, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := writeEntry(tw, path.Join(schemasDir, kamelet.Name+schemaSuffix), schema); err != nil {
+			return err
+		}
+		kamelet.Spec.Definition = nil
+	}
+
+	if mediaType, icon, ok := decodeDataURI(kamelet.Annotations[v1alpha1.AnnotationIcon]); ok {
+		if ext, supported := iconExtensions[mediaType]; supported {
+			if err := writeEntry(tw, path.Join(iconsDir, kamelet.Name+"."+ext), icon); err != nil {
+				return err
+			}
+			delete(kamelet.Annotations, v1alpha1.AnnotationIcon)
+		}
+	}
+
+	data, err := kubernetes.ToYAML(&kamelet)
+	if err != nil {
+		return err
+	}
+	return writeEntry(tw, path.Join(kameletsDir, kamelet.Name+kameletSuffix), data)
+}
+
+func parseKamelet(data []byte) (*v1alpha1.Kamelet, error) {
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	kamelet := v1alpha1.Kamelet{}
+	if err := json.Unmarshal(jsonData, &kamelet); err != nil {
+		return nil, err
+	}
+	if kamelet.Kind != v1alpha1.KameletKind {
+		return nil, fmt.Errorf("unexpected kind %q", kamelet.Kind)
+	}
+	if kamelet.Name == "" {
+		return nil, errors.New("missing name")
+	}
+	return &kamelet, nil
+}
+
+// decodeDataURI returns the media type and the content of a base64 data URI
+func decodeDataURI(uri string) (string, []byte, bool) {
+	if !strings.HasPrefix(uri, "data:") {
+		return "", nil, false
+	}
+	i := strings.Index(uri, ";base64,")
+	if i < 0 {
+		return "", nil, false
+	}
+	data, err := base64.StdEncoding.DecodeString(uri[i+len(";base64,"):])
+	if err != nil {
+		return "", nil, false
+	}
+	return uri[len("data:"):i], data, true
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func readEntries(r io.Reader, consume func(name string, data []byte) error) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := consume(path.Clean(header.Name), data); err != nil {
+			return err
+		}
+	}
+}
+
+// ociLayer overrides the media type of the layers, that default to the Docker one
+type ociLayer struct {
+	ociv1.Layer
+}
+
+func (l ociLayer) MediaType() (types.MediaType, error) {
+	return types.OCILayer, nil
+}
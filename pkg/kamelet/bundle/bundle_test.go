@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+const svgIcon = `<svg xmlns="http://www.w3.org/2000/svg"/>`
+
+func newKamelet(name string) v1alpha1.Kamelet {
+	kamelet := v1alpha1.NewKamelet("default", name)
+	kamelet.ResourceVersion = "42"
+	kamelet.Labels = map[string]string{
+		v1alpha1.KameletTypeLabel: "source",
+	}
+	kamelet.Annotations = map[string]string{
+		v1alpha1.AnnotationIcon:     "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(svgIcon)),
+		lastAppliedConfigAnnotation: "{}",
+	}
+	kamelet.Spec.Definition = &v1alpha1.JSONSchemaProps{
+		Title:    name,
+		Required: []string{"message"},
+		Properties: map[string]v1alpha1.JSONSchemaProp{
+			"message": {Type: "string"},
+		},
+	}
+	kamelet.Spec.Template = &v1.Template{
+		RawMessage: []byte(`{"from":{"uri":"timer:tick","steps":[{"to":"kamelet:sink"}]}}`),
+	}
+	kamelet.Status.Phase = v1alpha1.KameletPhaseReady
+	return kamelet
+}
+
+func TestPackageAndUnpack(t *testing.T) {
+	img, err := Package([]v1alpha1.Kamelet{newKamelet("timer-source"), newKamelet("cron-source")})
+	require.NoError(t, err)
+
+	names, err := KameletNames(img)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cron-source", "timer-source"}, names)
+
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+	files := make(map[string][]byte)
+	rc, err := layers[0].Uncompressed()
+	require.NoError(t, err)
+	require.NoError(t, readEntries(rc, func(name string, data []byte) error {
+		files[name] = data
+		return nil
+	}))
+	assert.Contains(t, files, "kamelets/timer-source.kamelet.yaml")
+	assert.Contains(t, files, "schemas/timer-source.schema.json")
+	assert.Equal(t, svgIcon, string(files["icons/timer-source.svg"]))
+	assert.NotContains(t, string(files["kamelets/timer-source.kamelet.yaml"]), "resourceVersion")
+	assert.NotContains(t, string(files["kamelets/timer-source.kamelet.yaml"]), "definition")
+
+	kamelets, err := Unpack(img)
+	require.NoError(t, err)
+	require.Len(t, kamelets, 2)
+
+	expected := newKamelet("timer-source")
+	kamelet := kamelets[1]
+	assert.Equal(t, "timer-source", kamelet.Name)
+	assert.Empty(t, kamelet.Namespace)
+	assert.Empty(t, kamelet.ResourceVersion)
+	assert.Empty(t, kamelet.Status)
+	assert.Equal(t, expected.Labels, kamelet.Labels)
+	assert.Equal(t, expected.Annotations[v1alpha1.AnnotationIcon], kamelet.Annotations[v1alpha1.AnnotationIcon])
+	assert.NotContains(t, kamelet.Annotations, lastAppliedConfigAnnotation)
+	assert.Equal(t, expected.Spec.Definition, kamelet.Spec.Definition)
+	assert.JSONEq(t, string(expected.Spec.Template.RawMessage), string(kamelet.Spec.Template.RawMessage))
+}
+
+func TestPackageIsReproducible(t *testing.T) {
+	first, err := Package([]v1alpha1.Kamelet{newKamelet("timer-source"), newKamelet("cron-source")})
+	require.NoError(t, err)
+	second, err := Package([]v1alpha1.Kamelet{newKamelet("cron-source"), newKamelet("timer-source")})
+	require.NoError(t, err)
+
+	firstDigest, err := first.Digest()
+	require.NoError(t, err)
+	secondDigest, err := second.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, firstDigest, secondDigest)
+}
+
+func TestPackageWithoutKamelet(t *testing.T) {
+	_, err := Package(nil)
+	assert.EqualError(t, err, "no Kamelet to package")
+}
+
+func TestPackageKeepsUnsupportedIcon(t *testing.T) {
+	kamelet := newKamelet("timer-source")
+	kamelet.Annotations[v1alpha1.AnnotationIcon] = "https://example.com/icon.svg"
+
+	img, err := Package([]v1alpha1.Kamelet{kamelet})
+	require.NoError(t, err)
+	kamelets, err := Unpack(img)
+	require.NoError(t, err)
+	require.Len(t, kamelets, 1)
+	assert.Equal(t, "https://example.com/icon.svg", kamelets[0].Annotations[v1alpha1.AnnotationIcon])
+}
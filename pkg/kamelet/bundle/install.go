@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+const (
+	// BundleAnnotation records the reference of the bundle a Kamelet has been installed from
+	BundleAnnotation = "camel.apache.org/kamelet.bundle"
+	// BundleDigestAnnotation records the digest of the bundle a Kamelet has been installed from
+	BundleDigestAnnotation = "camel.apache.org/kamelet.bundle.digest"
+)
+
+// InstallResult reports the Kamelets of a bundle that are installed, and the ones that are skipped because
+// Kamelets with the same names, that are not installed from a bundle, already exist
+type InstallResult struct {
+	Installed []string
+	Skipped   []string
+}
+
+// Install creates or updates the Kamelets of the bundle with the given reference and digest into the namespace.
+// The existing Kamelets that are not installed from a bundle are only replaced when force is true.
+func Install(ctx context.Context, c ctrl.Client, namespace string, reference string, digest string, kamelets []v1alpha1.Kamelet, force bool) (InstallResult, error) {
+	result := InstallResult{}
+	for i := range kamelets {
+		kamelet := kamelets[i].DeepCopy()
+		kamelet.Namespace = namespace
+		kamelet.ResourceVersion = ""
+		if kamelet.Annotations == nil {
+			kamelet.Annotations = make(map[string]string)
+		}
+		kamelet.Annotations[BundleAnnotation] = reference
+		kamelet.Annotations[BundleDigestAnnotation] = digest
+
+		existing := v1alpha1.NewKamelet(namespace, kamelet.Name)
+		err := c.Get(ctx, ctrl.ObjectKeyFromObject(&existing), &existing)
+		switch {
+		case k8serrors.IsNotFound(err):
+			if err := c.Create(ctx, kamelet); err != nil {
+				return result, err
+			}
+		case err != nil:
+			return result, err
+		case existing.Annotations[BundleAnnotation] == "" && !force:
+			result.Skipped = append(result.Skipped, kamelet.Name)
+			continue
+		default:
+			kamelet.ResourceVersion = existing.ResourceVersion
+			if err := c.Update(ctx, kamelet); err != nil {
+				return result, err
+			}
+		}
+		result.Installed = append(result.Installed, kamelet.Name)
+	}
+	return result, nil
+}
+
+// IsInstalled returns whether the Kamelets with the given names have been installed from the bundle
+// with the given reference and digest into the namespace
+func IsInstalled(ctx context.Context, c ctrl.Reader, namespace string, reference string, digest string, names []string) (bool, error) {
+	for _, name := range names {
+		kamelet := v1alpha1.NewKamelet(namespace, name)
+		if err := c.Get(ctx, ctrl.ObjectKeyFromObject(&kamelet), &kamelet); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if kamelet.Annotations[BundleDigestAnnotation] != digest {
+			// Kamelets that are not installed from a bundle are not replaced, unless forced
+			if kamelet.Annotations[BundleAnnotation] == "" {
+				continue
+			}
+			return false, nil
+		}
+		if kamelet.Annotations[BundleAnnotation] != reference {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestInstall(t *testing.T) {
+	custom := v1alpha1.NewKamelet("ns", "cron-source")
+	previous := v1alpha1.NewKamelet("ns", "log-sink")
+	previous.Annotations = map[string]string{
+		BundleAnnotation:       "quay.io/acme/kamelets:0.9.0",
+		BundleDigestAnnotation: "sha256:previous",
+	}
+	c, err := test.NewFakeClient(&custom, &previous)
+	require.NoError(t, err)
+
+	kamelets := []v1alpha1.Kamelet{newKamelet("cron-source"), newKamelet("log-sink"), newKamelet("timer-source")}
+	names := []string{"cron-source", "log-sink", "timer-source"}
+	reference := "quay.io/acme/kamelets:1.0.0"
+
+	installed, err := IsInstalled(context.TODO(), c, "ns", reference, "sha256:current", names)
+	require.NoError(t, err)
+	assert.False(t, installed)
+
+	result, err := Install(context.TODO(), c, "ns", reference, "sha256:current", kamelets, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log-sink", "timer-source"}, result.Installed)
+	assert.Equal(t, []string{"cron-source"}, result.Skipped)
+
+	kamelet := v1alpha1.NewKamelet("ns", "log-sink")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&kamelet), &kamelet))
+	assert.Equal(t, reference, kamelet.Annotations[BundleAnnotation])
+	assert.Equal(t, "sha256:current", kamelet.Annotations[BundleDigestAnnotation])
+	assert.NotNil(t, kamelet.Spec.Definition)
+
+	kamelet = v1alpha1.NewKamelet("ns", "cron-source")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&kamelet), &kamelet))
+	assert.NotContains(t, kamelet.Annotations, BundleAnnotation)
+
+	installed, err = IsInstalled(context.TODO(), c, "ns", reference, "sha256:current", names)
+	require.NoError(t, err)
+	assert.True(t, installed)
+
+	result, err = Install(context.TODO(), c, "ns", reference, "sha256:current", kamelets, true)
+	require.NoError(t, err)
+	assert.Equal(t, names, result.Installed)
+	assert.Empty(t, result.Skipped)
+}
@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ociv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Options configures the access to the registry the bundles are pushed to, and pulled from
+type Options struct {
+	// Insecure allows registries served over plain HTTP, or with a self-signed certificate
+	Insecure bool
+	// Auth are the registry credentials, that default to the ones of the Docker configuration
+	Auth authn.Authenticator
+	// Context bounds the requests to the registry, e.g., with a deadline, when set
+	Context context.Context
+}
+
+// Push pushes the bundle to the given reference, and returns its digest
+func Push(reference string, img ociv1.Image, options Options) (string, error) {
+	ref, err := options.parse(reference)
+	if err != nil {
+		return "", err
+	}
+	if err := remote.Write(ref, img, options.remote()...); err != nil {
+		return "", err
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
+
+// Pull pulls the bundle with the given reference. Its content is only fetched once it is unpacked.
+func Pull(reference string, options Options) (ociv1.Image, error) {
+	ref, err := options.parse(reference)
+	if err != nil {
+		return nil, err
+	}
+	return remote.Image(ref, options.remote()...)
+}
+
+func (o Options) parse(reference string) (name.Reference, error) {
+	if o.Insecure {
+		return name.ParseReference(reference, name.Insecure)
+	}
+	return name.ParseReference(reference)
+}
+
+func (o Options) remote() []remote.Option {
+	options := make([]remote.Option, 0, 2)
+	if o.Auth != nil {
+		options = append(options, remote.WithAuth(o.Auth))
+	} else {
+		options = append(options, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+	var transport http.RoundTripper = http.DefaultTransport
+	if o.Insecure {
+		insecure := http.DefaultTransport.(*http.Transport).Clone()
+		// nolint: gosec
+		insecure.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		transport = insecure
+	}
+	if o.Context != nil {
+		transport = contextTransport{ctx: o.Context, delegate: transport}
+	}
+	if transport != http.DefaultTransport {
+		options = append(options, remote.WithTransport(transport))
+	}
+	return options
+}
+
+// contextTransport issues the requests with the given context, so that they're cancelled with it
+type contextTransport struct {
+	ctx      context.Context
+	delegate http.RoundTripper
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.delegate.RoundTrip(req.WithContext(t.ctx))
+}
+
+// Registry returns the address of the registry of the given bundle reference
+func Registry(reference string) (string, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return "", err
+	}
+	return ref.Context().RegistryStr(), nil
+}
+
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth,omitempty"`
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
+	} `json:"auths"`
+}
+
+// AuthFromDockerConfig returns the credentials for the given registry, from the content of a Docker configuration
+// file, e.g., the `.dockerconfigjson` entry of a registry secret, or nil if it has no credentials for that registry
+func AuthFromDockerConfig(data []byte, registry string) (authn.Authenticator, error) {
+	config := dockerConfig{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	for server, auth := range config.Auths {
+		if host(server) != host(registry) {
+			continue
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, err
+			}
+			credentials := strings.SplitN(string(decoded), ":", 2)
+			if len(credentials) == 2 {
+				return &authn.Basic{Username: credentials[0], Password: credentials[1]}, nil
+			}
+		}
+		if auth.Username != "" {
+			return &authn.Basic{Username: auth.Username, Password: auth.Password}, nil
+		}
+	}
+	return nil, nil
+}
+
+// host returns the host of a registry server, that may be a URL
+func host(server string) string {
+	h := strings.SplitN(server, "/", 2)[0]
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		h = u.Host
+	}
+	if h == "docker.io" {
+		return name.DefaultRegistry
+	}
+	return h
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+func TestPushAndPull(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(ioutil.Discard, "", 0))))
+	defer server.Close()
+	reference := strings.TrimPrefix(server.URL, "http://") + "/acme/kamelets:1.0.0"
+
+	img, err := Package([]v1alpha1.Kamelet{newKamelet("timer-source")})
+	require.NoError(t, err)
+	digest, err := Push(reference, img, Options{Insecure: true})
+	require.NoError(t, err)
+
+	pulled, err := Pull(reference, Options{Insecure: true})
+	require.NoError(t, err)
+	pulledDigest, err := pulled.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, digest, pulledDigest.String())
+
+	kamelets, err := Unpack(pulled)
+	require.NoError(t, err)
+	require.Len(t, kamelets, 1)
+	assert.Equal(t, "timer-source", kamelets[0].Name)
+}
+
+func TestPushWithContext(t *testing.T) {
+	// The registry never responds
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	reference := strings.TrimPrefix(server.URL, "http://") + "/acme/kamelets:1.0.0"
+
+	img, err := Package([]v1alpha1.Kamelet{newKamelet("timer-source")})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = Push(reference, img, Options{Insecure: true, Context: ctx})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), err.Error())
+}
+
+func TestAuthFromDockerConfig(t *testing.T) {
+	config, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{
+			"https://index.docker.io/v1/": map[string]string{
+				"auth": base64.StdEncoding.EncodeToString([]byte("user:secret")),
+			},
+			"quay.io": map[string]string{
+				"username": "robot",
+				"password": "token",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	auth, err := AuthFromDockerConfig(config, "index.docker.io")
+	require.NoError(t, err)
+	require.NotNil(t, auth)
+	authConfig, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "user", authConfig.Username)
+	assert.Equal(t, "secret", authConfig.Password)
+
+	auth, err = AuthFromDockerConfig(config, "quay.io")
+	require.NoError(t, err)
+	require.NotNil(t, auth)
+	authConfig, err = auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "robot", authConfig.Username)
+
+	auth, err = AuthFromDockerConfig(config, "ghcr.io")
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/base64"
+	"sync"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	src "github.com/apache/camel-k/pkg/util/source"
+)
+
+// cacheSize is the maximum number of source metadata kept in the cache
+const cacheSize = 1024
+
+// sourceCache holds the metadata extracted from the sources, indexed by the digest of their content,
+// language and catalog, so that unchanged sources are not inspected again by each trait and reconciliation.
+var sourceCache = newMetadataCache(cacheSize)
+
+type cacheEntry struct {
+	key  string
+	meta src.Metadata
+}
+
+// metadataCache is a LRU cache of source metadata, safe for concurrent use
+type metadataCache struct {
+	lock    sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newMetadataCache(size int) *metadataCache {
+	return &metadataCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *metadataCache) get(key string) (src.Metadata, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return src.Metadata{}, false
+	}
+	c.order.MoveToFront(element)
+	return copyMetadata(element.Value.(*cacheEntry).meta), true
+}
+
+func (c *metadataCache) put(key string, meta src.Metadata) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*cacheEntry).meta = copyMetadata(meta)
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, meta: copyMetadata(meta)})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *metadataCache) len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.order.Len()
+}
+
+// cacheKey returns the key of the metadata of the given source, or an empty string if it cannot be cached
+func cacheKey(catalog *camel.RuntimeCatalog, language v1.Language, source v1.SourceSpec) string {
+	if catalog == nil || catalog.Digest() == "" {
+		return ""
+	}
+	hash := sha256.New()
+	hash.Write([]byte(catalog.Digest()))
+	hash.Write([]byte(language))
+	hash.Write([]byte(source.Content))
+	return base64.RawURLEncoding.EncodeToString(hash.Sum(nil))
+}
+
+// copyMetadata returns a deep copy of the given metadata, as the callers are free to modify what they get
+func copyMetadata(meta src.Metadata) src.Metadata {
+	c := meta
+	c.FromURIs = append(make([]string, 0, len(meta.FromURIs)), meta.FromURIs...)
+	c.ToURIs = append(make([]string, 0, len(meta.ToURIs)), meta.ToURIs...)
+	if meta.Kamelets != nil {
+		c.Kamelets = append(make([]string, 0, len(meta.Kamelets)), meta.Kamelets...)
+	}
+	if meta.Dependencies != nil {
+		c.Dependencies = meta.Dependencies.Copy()
+	}
+	if meta.RequiredCapabilities != nil {
+		c.RequiredCapabilities = meta.RequiredCapabilities.Copy()
+	}
+	return c
+}
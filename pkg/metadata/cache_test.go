@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	src "github.com/apache/camel-k/pkg/util/source"
+)
+
+func TestExtractIsCached(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, catalog.Digest())
+
+	code := v1.SourceSpec{
+		DataSpec: v1.DataSpec{
+			Name:    "routes.yaml",
+			Content: `- from: { uri: "timer:cached", steps: [ { to: "log:cached" } ] }`,
+		},
+	}
+
+	key := cacheKey(catalog, v1.LanguageYaml, code)
+	_, ok := sourceCache.get(key)
+	assert.False(t, ok)
+
+	meta := Extract(catalog, code)
+	assert.ElementsMatch(t, []string{"timer:cached"}, meta.FromURIs)
+
+	cached, ok := sourceCache.get(key)
+	assert.True(t, ok)
+	assert.ElementsMatch(t, meta.FromURIs, cached.FromURIs)
+	assert.ElementsMatch(t, meta.Dependencies.List(), cached.Dependencies.List())
+
+	// The returned metadata can be modified without altering the cache
+	meta.Dependencies.Add("camel:other")
+	meta.FromURIs[0] = "direct:other"
+	again := Extract(catalog, code)
+	assert.ElementsMatch(t, []string{"timer:cached"}, again.FromURIs)
+	assert.False(t, again.Dependencies.Has("camel:other"))
+
+	// The source name does not matter, as long as the language is the same
+	code.Name = "other.yaml"
+	assert.Equal(t, key, cacheKey(catalog, code.InferLanguage(), code))
+
+	code.Content += "\n"
+	assert.NotEqual(t, key, cacheKey(catalog, code.InferLanguage(), code))
+}
+
+func TestCacheKeyDependsOnCatalog(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	code := v1.SourceSpec{DataSpec: v1.DataSpec{Content: `from("timer:tick").to("log:info")`}}
+
+	other := camel.NewRuntimeCatalog(catalog.CamelCatalogSpec)
+	assert.Equal(t, cacheKey(catalog, v1.LanguageJavaSource, code), cacheKey(other, v1.LanguageJavaSource, code))
+
+	spec := catalog.CamelCatalogSpec.DeepCopy()
+	spec.Runtime.Version = "0.0.0"
+	other = camel.NewRuntimeCatalog(*spec)
+	assert.NotEqual(t, cacheKey(catalog, v1.LanguageJavaSource, code), cacheKey(other, v1.LanguageJavaSource, code))
+
+	assert.Empty(t, cacheKey(nil, v1.LanguageJavaSource, code))
+	assert.Empty(t, cacheKey(&camel.RuntimeCatalog{}, v1.LanguageJavaSource, code))
+}
+
+func TestMetadataCacheEviction(t *testing.T) {
+	cache := newMetadataCache(2)
+
+	cache.put("a", src.NewMetadata())
+	cache.put("b", src.NewMetadata())
+	_, ok := cache.get("a")
+	assert.True(t, ok)
+
+	cache.put("c", src.NewMetadata())
+	assert.Equal(t, 2, cache.len())
+
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+	_, ok = cache.get("a")
+	assert.True(t, ok)
+	_, ok = cache.get("c")
+	assert.True(t, ok)
+}
+
+func TestExtractAllMergesInOrder(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	sources := make([]v1.SourceSpec, 0)
+	from := make([]string, 0)
+	for i := 0; i < 32; i++ {
+		sources = append(sources, v1.SourceSpec{
+			DataSpec: v1.DataSpec{
+				Name:    fmt.Sprintf("route%d.yaml", i),
+				Content: fmt.Sprintf(`- from: { uri: "timer:tick-%d", steps: [ { to: "log:info" } ] }`, i),
+			},
+		})
+		from = append(from, fmt.Sprintf("timer:tick-%d", i))
+	}
+	sources = append(sources, v1.SourceSpec{
+		DataSpec: v1.DataSpec{
+			Name:    "Rest.java",
+			Content: `rest().get("/").to("direct:get");`,
+		},
+	})
+
+	meta := ExtractAll(catalog, sources)
+
+	assert.Equal(t, from, meta.FromURIs)
+	assert.ElementsMatch(t, []string{
+		"camel:log",
+		"camel:timer",
+		"camel:direct",
+		"mvn:org.apache.camel.quarkus:camel-quarkus-platform-http",
+		"mvn:org.apache.camel.quarkus:camel-quarkus-rest",
+	}, meta.Dependencies.List())
+	assert.True(t, meta.ExposesHTTPServices)
+	assert.False(t, meta.PassiveEndpoints)
+}
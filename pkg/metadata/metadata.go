@@ -18,6 +18,9 @@ limitations under the License.
 package metadata
 
 import (
+	"runtime"
+	"sync"
+
 	"github.com/scylladb/go-set/strset"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -25,15 +28,33 @@ import (
 	src "github.com/apache/camel-k/pkg/util/source"
 )
 
-// ExtractAll returns metadata information from all listed source codes
+// ExtractAll returns metadata information from all listed source codes.
+// The sources are inspected in parallel, and their metadata merged in order.
 func ExtractAll(catalog *camel.RuntimeCatalog, sources []v1.SourceSpec) IntegrationMetadata {
+	extracted := make([]IntegrationMetadata, len(sources))
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i := range sources {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			extracted[i] = Extract(catalog, sources[i])
+		}(i)
+	}
+	wg.Wait()
+
 	// neutral metadata
 	meta := src.NewMetadata()
 	meta.PassiveEndpoints = true
 	meta.ExposesHTTPServices = false
 
-	for _, source := range sources {
-		meta = merge(meta, Extract(catalog, source).Metadata)
+	for _, m := range extracted {
+		meta = merge(meta, m.Metadata)
 	}
 	return IntegrationMetadata{
 		Metadata: meta,
@@ -59,7 +80,8 @@ func merge(m1 src.Metadata, m2 src.Metadata) src.Metadata {
 	}
 }
 
-// Extract returns metadata information from the source code
+// Extract returns metadata information from the source code.
+// The metadata is cached, so that a source is inspected again only when its content changes.
 func Extract(catalog *camel.RuntimeCatalog, source v1.SourceSpec) IntegrationMetadata {
 	if source.ContentRef != "" {
 		panic("source must be dereferenced before calling this method")
@@ -70,12 +92,23 @@ func Extract(catalog *camel.RuntimeCatalog, source v1.SourceSpec) IntegrationMet
 
 	language := source.InferLanguage()
 
+	key := cacheKey(catalog, language, source)
+	if key != "" {
+		if meta, ok := sourceCache.get(key); ok {
+			return IntegrationMetadata{
+				Metadata: meta,
+			}
+		}
+	}
+
 	meta := src.NewMetadata()
 	meta.PassiveEndpoints = true
 	meta.ExposesHTTPServices = false
 
-	// TODO: handle error
-	_ = src.InspectorForLanguage(catalog, language).Extract(source, &meta)
+	// TODO: handle error, the metadata is only cached when the extraction succeeds
+	if err := src.InspectorForLanguage(catalog, language).Extract(source, &meta); err == nil && key != "" {
+		sourceCache.put(key, meta)
+	}
 
 	return IntegrationMetadata{
 		Metadata: meta,
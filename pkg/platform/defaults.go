@@ -76,11 +76,15 @@ func ConfigureDefaults(ctx context.Context, c client.Client, p *v1.IntegrationPl
 	}
 
 	if p.Status.Build.BuildStrategy == "" {
-		// Use the fastest strategy that they support (routine when possible)
-		if p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyS2I ||
-			p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategySpectrum {
+		switch {
+		case p.Status.Build.BuilderImage != "":
+			// The builds are run in the custom builder image
+			p.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyPod
+		case p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyS2I ||
+			p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategySpectrum:
+			// Use the fastest strategy that they support (routine when possible)
 			p.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyRoutine
-		} else {
+		default:
 			// The build output has to be shared via a volume
 			p.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyPod
 		}
@@ -106,6 +110,10 @@ func ConfigureDefaults(ctx context.Context, c client.Client, p *v1.IntegrationPl
 		log.Log.Info("No registry specified for publishing images")
 	}
 
+	if verbose && p.Status.Build.BuilderImage != "" && p.Status.Build.BuildStrategy != v1.IntegrationPlatformBuildStrategyPod {
+		log.Log.Infof("Builder image %s ignored by the %s build strategy", p.Status.Build.BuilderImage, p.Status.Build.BuildStrategy)
+	}
+
 	if verbose && p.Status.Build.GetTimeout().Duration != 0 {
 		log.Log.Infof("Maven Timeout set to %s", p.Status.Build.GetTimeout().Duration)
 	}
@@ -114,6 +122,11 @@ func ConfigureDefaults(ctx context.Context, c client.Client, p *v1.IntegrationPl
 }
 
 func configureRegistry(ctx context.Context, c client.Client, p *v1.IntegrationPlatform) error {
+	if p.Status.Build.Registry.CredentialsProvider != "" && p.Status.Build.Registry.Secret == "" {
+		// The secret is created, and refreshed, by the platform controller
+		p.Status.Build.Registry.Secret = p.Name + "-registry-credentials"
+	}
+
 	if p.Status.Cluster == v1.IntegrationPlatformClusterOpenShift &&
 		p.Status.Build.PublishStrategy != v1.IntegrationPlatformBuildPublishStrategyS2I &&
 		p.Status.Build.Registry.Address == "" {
@@ -153,12 +166,52 @@ func configureRegistry(ctx context.Context, c client.Client, p *v1.IntegrationPl
 	return nil
 }
 
+// LocalRegistryAddress is the registry host of the images built with the Local publish strategy, that are never pushed
+const LocalRegistryAddress = "localhost"
+
+var (
+	localEngineAddresses = map[v1.IntegrationPlatformLocalEngine]string{
+		v1.IntegrationPlatformLocalEngineDocker:   "unix:///var/run/docker.sock",
+		v1.IntegrationPlatformLocalEnginePodman:   "unix:///run/podman/podman.sock",
+		v1.IntegrationPlatformLocalEngineBuildkit: "unix:///run/buildkit/buildkitd.sock",
+	}
+	localEngineClientImages = map[v1.IntegrationPlatformLocalEngine]string{
+		v1.IntegrationPlatformLocalEngineDocker:   "docker.io/library/docker:20.10-cli",
+		v1.IntegrationPlatformLocalEnginePodman:   "quay.io/podman/stable:v3.4",
+		v1.IntegrationPlatformLocalEngineBuildkit: "docker.io/moby/buildkit:v0.9.3",
+	}
+)
+
+func setLocalDefaults(p *v1.IntegrationPlatform) {
+	if p.Status.Build.Local == nil {
+		p.Status.Build.Local = &v1.IntegrationPlatformLocalSpec{}
+	}
+	local := p.Status.Build.Local
+	if local.Engine == "" {
+		local.Engine = v1.IntegrationPlatformLocalEngineDocker
+	}
+	if local.Address == "" {
+		local.Address = localEngineAddresses[local.Engine]
+	}
+	if local.ClientImage == "" {
+		local.ClientImage = localEngineClientImages[local.Engine]
+	}
+	// The images are only known to the node container runtime, so the registry address is merely part of their names
+	if p.Status.Build.Registry.Address == "" {
+		p.Status.Build.Registry.Address = LocalRegistryAddress
+	}
+}
+
 func setPlatformDefaults(ctx context.Context, c client.Client, p *v1.IntegrationPlatform, verbose bool) error {
 	if p.Status.Build.RuntimeVersion == "" {
 		p.Status.Build.RuntimeVersion = defaults.DefaultRuntimeVersion
 	}
 	if p.Status.Build.BaseImage == "" {
-		p.Status.Build.BaseImage = defaults.BaseImage()
+		if p.Status.FIPS {
+			p.Status.Build.BaseImage = defaults.FIPSBaseImage()
+		} else {
+			p.Status.Build.BaseImage = defaults.BaseImage()
+		}
 	}
 	if p.Status.Build.Maven.LocalRepository == "" {
 		p.Status.Build.Maven.LocalRepository = defaults.LocalRepository
@@ -186,9 +239,11 @@ func setPlatformDefaults(ctx context.Context, c client.Client, p *v1.Integration
 
 	if p.Status.Build.Maven.Settings.ConfigMapKeyRef == nil && p.Status.Build.Maven.Settings.SecretKeyRef == nil {
 		var repositories []v1.Repository
-		var mirrors []maven.Mirror
+		var mirrors []v1.Mirror
+		var values []string
 		for i, c := range p.Status.Configuration {
 			if c.Type == "repository" {
+				values = append(values, c.Value)
 				if strings.Contains(c.Value, "@mirrorOf=") {
 					mirror := maven.NewMirror(c.Value)
 					if mirror.ID == "" {
@@ -206,6 +261,7 @@ func setPlatformDefaults(ctx context.Context, c client.Client, p *v1.Integration
 		}
 
 		settings := maven.NewDefaultSettings(repositories, mirrors)
+		settings.Proxies = maven.NewProxies(values)
 
 		err := createDefaultMavenSettingsConfigMap(ctx, c, p, settings)
 		if err != nil {
@@ -231,6 +287,19 @@ func setPlatformDefaults(ctx context.Context, c client.Client, p *v1.Integration
 		}
 	}
 
+	if p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyTekton {
+		if p.Status.Build.Tekton == nil {
+			p.Status.Build.Tekton = &v1.IntegrationPlatformTektonSpec{}
+		}
+		if p.Status.Build.Tekton.Workspace == "" {
+			p.Status.Build.Tekton.Workspace = "source"
+		}
+	}
+
+	if p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyLocal {
+		setLocalDefaults(p)
+	}
+
 	if len(p.Status.Kamelet.Repositories) == 0 {
 		p.Status.Kamelet.Repositories = append(p.Status.Kamelet.Repositories, v1.IntegrationPlatformKameletRepositorySpec{
 			URI: repository.DefaultRemoteRepository,
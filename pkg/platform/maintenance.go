@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// DefaultMaintenanceWindowDuration is the default duration of the maintenance window
+const DefaultMaintenanceWindowDuration = 1 * time.Hour
+
+// maintenanceWindowNone disables the maintenance window of an Integration
+const maintenanceWindowNone = "none"
+
+// MaintenanceWindow is the recurring window the redeploys of an Integration, that are not initiated
+// by a change of its specification, are deferred to
+type MaintenanceWindow struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// GetMaintenanceWindow returns the maintenance window of the Integration, either set with its annotation,
+// or by the platform, or nil when its redeploys are not deferred
+func GetMaintenanceWindow(it *v1.Integration, pl *v1.IntegrationPlatform) (*MaintenanceWindow, error) {
+	expression := ""
+	duration := DefaultMaintenanceWindowDuration
+	if pl != nil {
+		expression = pl.Status.Maintenance.Window
+		if pl.Status.Maintenance.Duration != nil {
+			duration = pl.Status.Maintenance.Duration.Duration
+		}
+	}
+	if annotation, ok := it.Annotations[v1.MaintenanceWindowAnnotation]; ok {
+		expression = annotation
+	}
+	if expression == "" || expression == maintenanceWindowNone {
+		return nil, nil
+	}
+	if duration <= 0 {
+		return nil, errors.Errorf("invalid maintenance window duration %s", duration)
+	}
+
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid maintenance window %q", expression)
+	}
+	return &MaintenanceWindow{
+		schedule: schedule,
+		duration: duration,
+	}, nil
+}
+
+// LookupMaintenanceWindow returns the maintenance window of the Integration, according to its platform if any
+func LookupMaintenanceWindow(ctx context.Context, c k8sclient.Reader, it *v1.Integration) (*MaintenanceWindow, error) {
+	pl, err := GetOrFind(ctx, c, it.Namespace, it.Status.Platform, true)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+	return GetMaintenanceWindow(it, pl)
+}
+
+// Contains returns whether the given time is within the maintenance window. The time is taken in UTC,
+// unless the time zone is set in the cron expression.
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	start := w.schedule.Next(t.UTC().Add(-w.duration))
+	return !start.After(t)
+}
+
+// Next returns the start of the next maintenance window after the given time
+func (w *MaintenanceWindow) Next(t time.Time) time.Time {
+	return w.schedule.Next(t.UTC())
+}
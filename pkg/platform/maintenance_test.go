@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Maintenance.Window = "0 2 * * 6"
+	pl.Status.Maintenance.Duration = &metav1.Duration{Duration: 2 * time.Hour}
+	it := v1.NewIntegration("ns", "my-integration")
+
+	window, err := GetMaintenanceWindow(&it, &pl)
+	require.NoError(t, err)
+	require.NotNil(t, window)
+
+	// Saturday 2021-10-02
+	assert.False(t, window.Contains(time.Date(2021, 10, 2, 1, 59, 0, 0, time.UTC)))
+	assert.True(t, window.Contains(time.Date(2021, 10, 2, 2, 0, 0, 0, time.UTC)))
+	assert.True(t, window.Contains(time.Date(2021, 10, 2, 3, 59, 0, 0, time.UTC)))
+	assert.False(t, window.Contains(time.Date(2021, 10, 2, 4, 0, 0, 0, time.UTC)))
+	assert.False(t, window.Contains(time.Date(2021, 10, 4, 2, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2021, 10, 9, 2, 0, 0, 0, time.UTC), window.Next(time.Date(2021, 10, 2, 2, 30, 0, 0, time.UTC)))
+
+	// The time zone of the given time does not matter
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	assert.True(t, window.Contains(time.Date(2021, 10, 2, 4, 30, 0, 0, paris)))
+}
+
+func TestMaintenanceWindowAnnotation(t *testing.T) {
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Maintenance.Window = "0 2 * * 6"
+	it := v1.NewIntegration("ns", "my-integration")
+
+	it.Annotations = map[string]string{v1.MaintenanceWindowAnnotation: "CRON_TZ=Europe/Paris 0 22 * * *"}
+	window, err := GetMaintenanceWindow(&it, &pl)
+	require.NoError(t, err)
+	require.NotNil(t, window)
+	assert.True(t, window.Contains(time.Date(2021, 10, 4, 20, 30, 0, 0, time.UTC)))
+	assert.False(t, window.Contains(time.Date(2021, 10, 4, 21, 30, 0, 0, time.UTC)))
+
+	it.Annotations[v1.MaintenanceWindowAnnotation] = "none"
+	window, err = GetMaintenanceWindow(&it, &pl)
+	require.NoError(t, err)
+	assert.Nil(t, window)
+
+	it.Annotations[v1.MaintenanceWindowAnnotation] = "every saturday"
+	_, err = GetMaintenanceWindow(&it, &pl)
+	assert.Error(t, err)
+
+	// No window is set by default
+	window, err = GetMaintenanceWindow(&v1.Integration{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, window)
+}
@@ -34,6 +34,9 @@ const operatorPodNameEnvVariable = "POD_NAME"
 
 const OperatorLockName = "camel-k-lock"
 
+// OperatorLeaderConfigMapName is the name of the ConfigMap holding the identity of the operator leader
+const OperatorLeaderConfigMapName = "camel-k-operator-leader"
+
 var OperatorImage string
 
 // IsCurrentOperatorGlobal returns true if the operator is configured to watch all namespaces
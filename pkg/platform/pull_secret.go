@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/apache/camel-k/pkg/client"
+)
+
+// PullSecretReplicaLabel marks the replicas of the platform registry secret, with the namespace of the platform as value
+const PullSecretReplicaLabel = "camel.apache.org/pull-secret.replica-of"
+
+// ReplicatePullSecret creates, or updates, the replica of the source pull secret into the namespace, and adds the owner
+// to its owner references, so that the replica is garbage collected once all the objects using it are deleted.
+// A secret with the same name, that's not a replica, is left untouched.
+func ReplicatePullSecret(ctx context.Context, c client.Client, source *corev1.Secret, namespace string, owner ctrl.Object) error {
+	replica := corev1.Secret{}
+	err := c.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: source.Name}, &replica)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	if k8serrors.IsNotFound(err) {
+		replica = corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      source.Name,
+				Labels: map[string]string{
+					PullSecretReplicaLabel: source.Namespace,
+				},
+			},
+			Type: source.Type,
+			Data: source.Data,
+		}
+		if err := controllerutil.SetOwnerReference(owner, &replica, c.GetScheme()); err != nil {
+			return err
+		}
+		return c.Create(ctx, &replica)
+	}
+
+	if replica.Labels[PullSecretReplicaLabel] != source.Namespace {
+		return nil
+	}
+
+	target := replica.DeepCopy()
+	target.Data = source.Data
+	if err := controllerutil.SetOwnerReference(owner, target, c.GetScheme()); err != nil {
+		return err
+	}
+	if reflect.DeepEqual(target, &replica) {
+		return nil
+	}
+	return c.Update(ctx, target)
+}
+
+// SyncPullSecretReplicas updates the replicas of the source pull secret, across all the namespaces, with its content
+func SyncPullSecretReplicas(ctx context.Context, c client.Client, source *corev1.Secret) error {
+	// The SecretList type is also registered by the OpenShift image API, so the kind is set explicitly
+	replicas := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{
+			"apiVersion": corev1.SchemeGroupVersion.String(),
+			"kind":       "SecretList",
+		},
+	}
+	if err := c.List(ctx, replicas, ctrl.MatchingLabels{PullSecretReplicaLabel: source.Namespace}); err != nil {
+		return err
+	}
+
+	for _, item := range replicas.Items {
+		if item.GetName() != source.Name || item.GetNamespace() == source.Namespace {
+			continue
+		}
+		replica := corev1.Secret{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &replica); err != nil {
+			return err
+		}
+		if reflect.DeepEqual(replica.Data, source.Data) {
+			continue
+		}
+		replica.Data = source.Data
+		if err := c.Update(ctx, &replica); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// Evaluator evaluates the expressions of the policy rules written in a given language
+type Evaluator interface {
+	// Evaluate returns whether the given expression holds true, with the given variables bound
+	Evaluate(ctx context.Context, expression string, variables map[string]interface{}) (bool, error)
+}
+
+var (
+	evaluatorsMutex sync.RWMutex
+	evaluators      = make(map[v1.PolicyRuleLanguage]Evaluator)
+)
+
+func init() {
+	RegisterEvaluator(v1.PolicyRuleLanguageCEL, celEvaluator{})
+}
+
+// RegisterEvaluator makes the given evaluator available to the policy rules of the given language
+func RegisterEvaluator(language v1.PolicyRuleLanguage, evaluator Evaluator) {
+	evaluatorsMutex.Lock()
+	defer evaluatorsMutex.Unlock()
+
+	evaluators[language] = evaluator
+}
+
+func evaluate(ctx context.Context, rule v1.IntegrationPlatformPolicyRule, integration *v1.Integration) (bool, error) {
+	language := rule.Language
+	if language == "" {
+		language = v1.PolicyRuleLanguageCEL
+	}
+
+	evaluatorsMutex.RLock()
+	evaluator, ok := evaluators[language]
+	evaluatorsMutex.RUnlock()
+	if !ok {
+		return false, fmt.Errorf("cannot evaluate policy rule %s: no evaluator available for language %s", rule.Name, language)
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(integration)
+	if err != nil {
+		return false, err
+	}
+	ok, err = evaluator.Evaluate(ctx, rule.Expression, map[string]interface{}{"integration": object})
+	if err != nil {
+		return false, fmt.Errorf("cannot evaluate policy rule %s: %w", rule.Name, err)
+	}
+	return ok, nil
+}
+
+// celEvaluator is the built-in evaluator of the policy rules written in the Common Expression Language (CEL),
+// with the variables declared as dynamically typed values
+type celEvaluator struct{}
+
+func (celEvaluator) Evaluate(_ context.Context, expression string, variables map[string]interface{}) (bool, error) {
+	declarations := make([]*exprpb.Decl, 0, len(variables))
+	for name := range variables {
+		declarations = append(declarations, decls.NewVar(name, decls.Dyn))
+	}
+	env, err := cel.NewEnv(cel.Declarations(declarations...))
+	if err != nil {
+		return false, err
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return false, issues.Err()
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return false, err
+	}
+	value, _, err := program.Eval(variables)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluates to %v, not to a boolean", value.Value())
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// fipsNonCompliantComponents are the components that rely on cryptographic implementations
+// that are not FIPS validated
+var fipsNonCompliantComponents = []string{
+	"as2",
+	"crypto",
+	"crypto-pgp",
+	"jasypt",
+	"jsch",
+	"ssh",
+}
+
+// Enabled returns whether the given platform sets constraints on its Integrations,
+// either with a policy, a quota, or because it runs in FIPS mode
+func Enabled(p *v1.IntegrationPlatform) bool {
+	return p != nil && (p.Status.FIPS || !p.Status.Policy.IsEmpty() || !p.Status.Quota.IsEmpty())
+}
+
+// CheckPlatform returns the Violations of the constraints the given platform sets on the given subject, if any,
+// or an error if they cannot be evaluated
+func CheckPlatform(ctx context.Context, c client.Client, p *v1.IntegrationPlatform, s Subject) error {
+	violations := make(Violations, 0)
+
+	if p.Status.FIPS {
+		violations = append(violations, checkFIPSComponents(s.Dependencies)...)
+	}
+
+	err := Check(ctx, c, p.Status.Policy, s)
+	var policyViolations Violations
+	if errors.As(err, &policyViolations) {
+		violations = append(violations, policyViolations...)
+	} else if err != nil {
+		return err
+	}
+
+	quotaViolations, err := checkQuota(ctx, c, p.Status.Quota, s)
+	if err != nil {
+		return err
+	}
+	violations = append(violations, quotaViolations...)
+
+	if len(violations) > 0 {
+		return violations
+	}
+	return nil
+}
+
+func checkFIPSComponents(dependencies []string) []string {
+	found := make([]string, 0)
+	for _, d := range dependencies {
+		if name := componentName(d); util.StringSliceExists(fipsNonCompliantComponents, name) {
+			util.StringSliceUniqueAdd(&found, name)
+		}
+	}
+	sort.Strings(found)
+
+	violations := make([]string, 0, len(found))
+	for _, name := range found {
+		violations = append(violations, fmt.Sprintf("component %s is not FIPS compliant, remove the endpoints and dependencies using it", name))
+	}
+	return violations
+}
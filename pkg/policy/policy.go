@@ -0,0 +1,242 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy enforces the constraints configured by the platform admins on the Integrations
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// Subject gathers the facts about an Integration the platform policy is enforced on
+type Subject struct {
+	Integration *v1.Integration
+	// Dependencies are the dependencies of the Integration, including the ones of the components used by its sources
+	Dependencies []string
+	// Components are the names of the Camel components provided by the dependencies of the Integration
+	Components []string
+	// Languages are the languages of the sources of the Integration, and the Camel expression languages
+	// provided by its dependencies
+	Languages []string
+	// Kamelets are the Kamelets referenced by the Integration
+	Kamelets []string
+	// Image is the container image set explicitly on the Integration, if any
+	Image       string
+	LimitCPU    string
+	LimitMemory string
+	// RequestCPU and RequestMemory are the resources requested by each pod of the Integration
+	RequestCPU    string
+	RequestMemory string
+	// TraitProperties are the trait properties, as <trait>.<property>, configured by the Integration itself
+	TraitProperties []string
+	// Requester is the user that has configured the Integration, if known
+	Requester *authenticationv1.UserInfo
+}
+
+// Violations reports the constraints of the policy an Integration does not comply with
+type Violations []string
+
+func (v Violations) Error() string {
+	return fmt.Sprintf("integration does not comply with the platform policy: %s", strings.Join(v, "; "))
+}
+
+// Check returns the Violations of the given policy by the given subject, if any,
+// or an error if the policy cannot be evaluated
+func Check(ctx context.Context, c client.Client, policy v1.IntegrationPlatformPolicySpec, s Subject) error {
+	violations := make(Violations, 0)
+
+	violations = append(violations, checkComponents(policy.ForbiddenComponents, s.Dependencies)...)
+	allowedComponents := make([]string, 0, len(policy.AllowedComponents))
+	for _, c := range policy.AllowedComponents {
+		allowedComponents = append(allowedComponents, componentName(c))
+	}
+	violations = append(violations, checkAllowed("component", allowedComponents, s.Components)...)
+	violations = append(violations, checkAllowed("language", policy.AllowedLanguages, s.Languages)...)
+	violations = append(violations, checkForbidden("language", policy.ForbiddenLanguages, s.Languages,
+		"remove the sources and expressions using it")...)
+	violations = append(violations, checkAllowed("kamelet", policy.AllowedKamelets, s.Kamelets)...)
+	violations = append(violations, checkForbidden("kamelet", policy.ForbiddenKamelets, s.Kamelets,
+		"remove the endpoints referencing it")...)
+
+	traitViolations, err := checkTraits(ctx, c, policy.RestrictedTraits, s)
+	if err != nil {
+		return err
+	}
+	violations = append(violations, traitViolations...)
+
+	if policy.RequireResourceLimits {
+		missing := make([]string, 0)
+		if s.LimitCPU == "" {
+			missing = append(missing, "container.limit-cpu")
+		}
+		if s.LimitMemory == "" {
+			missing = append(missing, "container.limit-memory")
+		}
+		if len(missing) > 0 {
+			violations = append(violations, fmt.Sprintf("resource limits are required, set the %s trait properties, e.g., -t %s=...",
+				strings.Join(missing, " and "), missing[0]))
+		}
+	}
+
+	if s.Image != "" && len(policy.AllowedRegistries) > 0 && !allowedImage(policy.AllowedRegistries, s.Image) {
+		violations = append(violations, fmt.Sprintf("image %s is not pulled from an allowed registry, use an image from %s",
+			s.Image, strings.Join(policy.AllowedRegistries, ", ")))
+	}
+
+	if policy.MaxReplicas != nil && s.Integration.Spec.Replicas != nil && *s.Integration.Spec.Replicas > *policy.MaxReplicas {
+		violations = append(violations, fmt.Sprintf("%d replicas exceed the maximum of %d, scale the integration down to %d replicas at most",
+			*s.Integration.Spec.Replicas, *policy.MaxReplicas, *policy.MaxReplicas))
+	}
+
+	for _, rule := range policy.Rules {
+		ok, err := evaluate(ctx, rule, s.Integration)
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+		message := rule.Message
+		if message == "" {
+			message = fmt.Sprintf("expression %q is not satisfied", rule.Expression)
+		}
+		violations = append(violations, fmt.Sprintf("rule %s: %s", rule.Name, message))
+	}
+
+	if len(violations) > 0 {
+		return violations
+	}
+	return nil
+}
+
+func checkComponents(forbidden []string, dependencies []string) []string {
+	if len(forbidden) == 0 {
+		return nil
+	}
+	used := make(map[string]bool)
+	for _, d := range dependencies {
+		if name := componentName(d); name != "" {
+			used[name] = true
+		}
+	}
+
+	found := make([]string, 0)
+	for _, f := range forbidden {
+		if name := componentName(f); used[name] {
+			util.StringSliceUniqueAdd(&found, name)
+		}
+	}
+	sort.Strings(found)
+
+	violations := make([]string, 0, len(found))
+	for _, name := range found {
+		violations = append(violations, fmt.Sprintf("component %s is forbidden, remove the endpoints and dependencies using it", name))
+	}
+	return violations
+}
+
+// checkAllowed reports the used elements of the given kind that are not in the allowed ones, if any is set
+func checkAllowed(kind string, allowed []string, used []string) []string {
+	if len(allowed) == 0 {
+		return nil
+	}
+	violations := make([]string, 0)
+	for _, u := range sortedUnique(used) {
+		if !util.StringSliceExists(allowed, u) {
+			violations = append(violations, fmt.Sprintf("%s %s is not allowed, only the %ss %s can be used",
+				kind, u, kind, strings.Join(allowed, ", ")))
+		}
+	}
+	return violations
+}
+
+// checkForbidden reports the used elements of the given kind that are forbidden, along with the given remediation
+func checkForbidden(kind string, forbidden []string, used []string, remediation string) []string {
+	if len(forbidden) == 0 {
+		return nil
+	}
+	violations := make([]string, 0)
+	for _, u := range sortedUnique(used) {
+		if util.StringSliceExists(forbidden, u) {
+			violations = append(violations, fmt.Sprintf("%s %s is forbidden, %s", kind, u, remediation))
+		}
+	}
+	return violations
+}
+
+func sortedUnique(values []string) []string {
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		util.StringSliceUniqueAdd(&unique, v)
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// componentName returns the name of the Camel component of the given dependency, e.g., exec for camel:exec,
+// mvn:org.apache.camel.quarkus:camel-quarkus-exec or camel-exec
+func componentName(dependency string) string {
+	switch {
+	case strings.HasPrefix(dependency, "camel:"):
+		return strings.TrimPrefix(dependency, "camel:")
+	case strings.HasPrefix(dependency, "camel-quarkus:"):
+		return strings.TrimPrefix(dependency, "camel-quarkus:")
+	case strings.HasPrefix(dependency, "mvn:"):
+		gav := strings.Split(strings.TrimPrefix(dependency, "mvn:"), ":")
+		if len(gav) < 2 || (gav[0] != "org.apache.camel" && gav[0] != "org.apache.camel.quarkus") {
+			return ""
+		}
+		dependency = gav[1]
+	case strings.Contains(dependency, ":"):
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(dependency, "camel-quarkus-"), "camel-")
+}
+
+// allowedImage returns whether the given image is pulled from one of the given registries,
+// optionally followed by a repository path prefix
+func allowedImage(registries []string, image string) bool {
+	image = qualifiedImage(image)
+	for _, r := range registries {
+		r = strings.TrimSuffix(r, "/")
+		if image == r || strings.HasPrefix(image, r+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedImage makes the registry of the given image explicit, defaulting to Docker Hub
+func qualifiedImage(image string) string {
+	i := strings.Index(image, "/")
+	if i < 0 {
+		return "docker.io/library/" + image
+	}
+	if host := image[:i]; host != "localhost" && !strings.ContainsAny(host, ".:") {
+		return "docker.io/" + image
+	}
+	return image
+}
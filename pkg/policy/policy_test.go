@@ -0,0 +1,273 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestCheck(t *testing.T) {
+	maxReplicas := int32(2)
+	replicas := int32(3)
+	policy := v1.IntegrationPlatformPolicySpec{
+		ForbiddenComponents:   []string{"camel-exec", "docker"},
+		RequireResourceLimits: true,
+		AllowedRegistries:     []string{"quay.io/my-org"},
+		MaxReplicas:           &maxReplicas,
+	}
+
+	s := Subject{
+		Integration:  &v1.Integration{Spec: v1.IntegrationSpec{Replicas: &replicas}},
+		Dependencies: []string{"camel:exec", "mvn:org.apache.camel.quarkus:camel-quarkus-docker", "camel:log"},
+		Image:        "quay.io/other-org/my-image:1.0",
+		LimitCPU:     "500m",
+	}
+
+	err := Check(context.TODO(), nil, policy, s)
+	var violations Violations
+	assert.True(t, errors.As(err, &violations))
+	assert.Equal(t, Violations{
+		"component docker is forbidden, remove the endpoints and dependencies using it",
+		"component exec is forbidden, remove the endpoints and dependencies using it",
+		"resource limits are required, set the container.limit-memory trait properties, e.g., -t container.limit-memory=...",
+		"image quay.io/other-org/my-image:1.0 is not pulled from an allowed registry, use an image from quay.io/my-org",
+		"3 replicas exceed the maximum of 2, scale the integration down to 2 replicas at most",
+	}, violations)
+
+	s = Subject{
+		Integration:  &v1.Integration{},
+		Dependencies: []string{"camel:log", "camel-k:runtime"},
+		Image:        "quay.io/my-org/my-image:1.0",
+		LimitCPU:     "500m",
+		LimitMemory:  "512Mi",
+	}
+	assert.Nil(t, Check(context.TODO(), nil, policy, s))
+}
+
+func TestCheckAllowedAndForbiddenElements(t *testing.T) {
+	policy := v1.IntegrationPlatformPolicySpec{
+		AllowedComponents:  []string{"camel-timer", "log", "kamelet"},
+		ForbiddenLanguages: []string{"groovy", "ognl"},
+		AllowedKamelets:    []string{"timer-source", "ssh-source"},
+		ForbiddenKamelets:  []string{"ssh-source"},
+	}
+
+	s := Subject{
+		Integration: &v1.Integration{},
+		Components:  []string{"timer", "ssh", "log", "kamelet", "exec"},
+		Languages:   []string{"yaml", "groovy", "ognl"},
+		Kamelets:    []string{"timer-source", "ssh-source", "aws-s3-sink"},
+	}
+
+	err := Check(context.TODO(), nil, policy, s)
+	var violations Violations
+	assert.True(t, errors.As(err, &violations))
+	assert.Equal(t, Violations{
+		"component exec is not allowed, only the components timer, log, kamelet can be used",
+		"component ssh is not allowed, only the components timer, log, kamelet can be used",
+		"language groovy is forbidden, remove the sources and expressions using it",
+		"language ognl is forbidden, remove the sources and expressions using it",
+		"kamelet aws-s3-sink is not allowed, only the kamelets timer-source, ssh-source can be used",
+		"kamelet ssh-source is forbidden, remove the endpoints referencing it",
+	}, violations)
+
+	policy.AllowedLanguages = []string{"yaml"}
+	s = Subject{
+		Integration: &v1.Integration{},
+		Components:  []string{"timer", "log"},
+		Languages:   []string{"yaml", "jsonpath"},
+		Kamelets:    []string{"timer-source"},
+	}
+	err = Check(context.TODO(), nil, policy, s)
+	assert.True(t, errors.As(err, &violations))
+	assert.Equal(t, Violations{
+		"language jsonpath is not allowed, only the languages yaml can be used",
+	}, violations)
+
+	s.Languages = []string{"yaml"}
+	assert.Nil(t, Check(context.TODO(), nil, policy, s))
+}
+
+func TestCheckPlatformFIPS(t *testing.T) {
+	p := v1.IntegrationPlatform{}
+	assert.False(t, Enabled(&p))
+	p.Status.FIPS = true
+	assert.True(t, Enabled(&p))
+
+	p.Status.Policy.ForbiddenComponents = []string{"exec"}
+	s := Subject{
+		Integration:  &v1.Integration{},
+		Dependencies: []string{"camel:crypto", "mvn:org.apache.camel.quarkus:camel-quarkus-ssh", "camel:exec", "camel:log"},
+	}
+
+	err := CheckPlatform(context.TODO(), nil, &p, s)
+	var violations Violations
+	assert.True(t, errors.As(err, &violations))
+	assert.Equal(t, Violations{
+		"component crypto is not FIPS compliant, remove the endpoints and dependencies using it",
+		"component ssh is not FIPS compliant, remove the endpoints and dependencies using it",
+		"component exec is forbidden, remove the endpoints and dependencies using it",
+	}, violations)
+
+	p.Status.FIPS = false
+	err = CheckPlatform(context.TODO(), nil, &p, s)
+	assert.True(t, errors.As(err, &violations))
+	assert.Len(t, violations, 1)
+}
+
+func TestComponentName(t *testing.T) {
+	assert.Equal(t, "exec", componentName("exec"))
+	assert.Equal(t, "exec", componentName("camel-exec"))
+	assert.Equal(t, "exec", componentName("camel:exec"))
+	assert.Equal(t, "exec", componentName("camel-quarkus:exec"))
+	assert.Equal(t, "exec", componentName("mvn:org.apache.camel.quarkus:camel-quarkus-exec"))
+	assert.Equal(t, "exec", componentName("mvn:org.apache.camel:camel-exec:3.11.1"))
+	assert.Equal(t, "", componentName("mvn:org.acme:camel-exec"))
+	assert.Equal(t, "", componentName("camel-k:runtime"))
+}
+
+func TestAllowedImage(t *testing.T) {
+	assert.True(t, allowedImage([]string{"docker.io"}, "alpine"))
+	assert.True(t, allowedImage([]string{"docker.io/library/"}, "alpine:3"))
+	assert.True(t, allowedImage([]string{"docker.io/acme"}, "acme/my-image"))
+	assert.True(t, allowedImage([]string{"localhost:5000"}, "localhost:5000/my-image"))
+	assert.False(t, allowedImage([]string{"quay.io/acme"}, "quay.io/acme-other/my-image"))
+	assert.False(t, allowedImage([]string{"quay.io"}, "my-image"))
+}
+
+type evaluatorFunc func(expression string, variables map[string]interface{}) (bool, error)
+
+func (f evaluatorFunc) Evaluate(_ context.Context, expression string, variables map[string]interface{}) (bool, error) {
+	return f(expression, variables)
+}
+
+func TestRules(t *testing.T) {
+	policy := v1.IntegrationPlatformPolicySpec{
+		Rules: []v1.IntegrationPlatformPolicyRule{
+			{
+				Name:       "team-label",
+				Language:   "Test",
+				Expression: "has-team-label",
+				Message:    "the integration must have a team label",
+			},
+		},
+	}
+	integration := v1.Integration{}
+	integration.Name = "my-integration"
+
+	err := Check(context.TODO(), nil, policy, Subject{Integration: &integration})
+	assert.EqualError(t, err, "cannot evaluate policy rule team-label: no evaluator available for language Test")
+
+	RegisterEvaluator("Test", evaluatorFunc(func(expression string, variables map[string]interface{}) (bool, error) {
+		assert.Equal(t, "has-team-label", expression)
+		metadata := variables["integration"].(map[string]interface{})["metadata"].(map[string]interface{})
+		assert.Equal(t, "my-integration", metadata["name"])
+		_, ok := metadata["labels"]
+		return ok, nil
+	}))
+
+	err = Check(context.TODO(), nil, policy, Subject{Integration: &integration})
+	assert.EqualError(t, err, "integration does not comply with the platform policy: rule team-label: the integration must have a team label")
+
+	integration.Labels = map[string]string{"team": "a"}
+	assert.Nil(t, Check(context.TODO(), nil, policy, Subject{Integration: &integration}))
+}
+
+func TestCheckPlatformWithCELRules(t *testing.T) {
+	p := v1.IntegrationPlatform{}
+	p.Status.Policy.Rules = []v1.IntegrationPlatformPolicyRule{
+		{
+			Name:       "team-label",
+			Expression: "has(integration.metadata.labels) && 'team' in integration.metadata.labels",
+			Message:    "the integration must have a team label",
+		},
+		{
+			Name:       "max-replicas",
+			Language:   v1.PolicyRuleLanguageCEL,
+			Expression: "!has(integration.spec.replicas) || integration.spec.replicas <= 2",
+			Message:    "the integration must not have more than 2 replicas",
+		},
+	}
+	replicas := int32(3)
+	integration := v1.Integration{Spec: v1.IntegrationSpec{Replicas: &replicas}}
+	integration.Name = "my-integration"
+
+	err := CheckPlatform(context.TODO(), nil, &p, Subject{Integration: &integration})
+	var violations Violations
+	assert.True(t, errors.As(err, &violations))
+	assert.Equal(t, Violations{
+		"rule team-label: the integration must have a team label",
+		"rule max-replicas: the integration must not have more than 2 replicas",
+	}, violations)
+
+	replicas = 2
+	integration.Labels = map[string]string{"team": "a"}
+	assert.Nil(t, CheckPlatform(context.TODO(), nil, &p, Subject{Integration: &integration}))
+}
+
+func TestCheckPlatformWithInvalidCELRules(t *testing.T) {
+	integration := v1.Integration{}
+	integration.Name = "my-integration"
+
+	for expression, expected := range map[string]string{
+		"integration.metadata.name":     "expression evaluates to my-integration, not to a boolean",
+		"integration.metadata.name ==":  "Syntax error",
+		"unknown == 'a'":                "undeclared reference to 'unknown'",
+		"integration.spec.replicas > 2": "no such key: replicas",
+	} {
+		p := v1.IntegrationPlatform{}
+		p.Status.Policy.Rules = []v1.IntegrationPlatformPolicyRule{{Name: "invalid", Expression: expression}}
+
+		err := CheckPlatform(context.TODO(), nil, &p, Subject{Integration: &integration})
+		assert.NotNil(t, err, expression)
+		assert.Contains(t, err.Error(), "cannot evaluate policy rule invalid: ", expression)
+		assert.Contains(t, err.Error(), expected, expression)
+	}
+}
+
+func TestRestrictedTraitsWithUnknownRequester(t *testing.T) {
+	policy := v1.IntegrationPlatformPolicySpec{
+		RestrictedTraits: []string{"istio", "container.image"},
+	}
+	s := Subject{
+		Integration:     &v1.Integration{},
+		TraitProperties: []string{"container.limit-cpu", "istio.enabled"},
+	}
+
+	err := Check(context.TODO(), nil, policy, s)
+	assert.EqualError(t, err, "integration does not comply with the platform policy: "+
+		"trait property istio.enabled is restricted, and the user that has configured it is unknown, "+
+		"the integration must be created or updated with the admission webhooks enabled")
+}
+
+func TestRequesterFromAnnotations(t *testing.T) {
+	assert.Nil(t, RequesterFromAnnotations(nil))
+	assert.Equal(t, &authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev", "system:authenticated"}},
+		RequesterFromAnnotations(map[string]string{
+			v1.RequesterAnnotation:       "alice",
+			v1.RequesterGroupsAnnotation: "dev,system:authenticated",
+		}))
+}
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+)
+
+// checkQuota returns the violations of the given quota by the given subject, along with the other Integrations
+// of its namespace. The Integrations are admitted in the order of their creation, so that lowering a quota does
+// not prevent the oldest Integrations from being updated.
+func checkQuota(ctx context.Context, c client.Client, quota v1.IntegrationPlatformQuotaSpec, s Subject) ([]string, error) {
+	violations := make([]string, 0)
+	it := s.Integration
+
+	if quota.MaxIntegrations != nil {
+		count, err := countPrecedingIntegrations(ctx, c, it)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int(*quota.MaxIntegrations) {
+			violations = append(violations, fmt.Sprintf("namespace %s has reached its quota of %d integrations, delete an integration before creating a new one",
+				it.Namespace, *quota.MaxIntegrations))
+		}
+	}
+
+	if quota.RequestsCPU == "" && quota.RequestsMemory == "" {
+		return violations, nil
+	}
+
+	requested, err := requestedResources(ctx, c, it)
+	if err != nil {
+		return nil, err
+	}
+	replicas := int32(1)
+	if it.Spec.Replicas != nil {
+		replicas = *it.Spec.Replicas
+	}
+	for _, q := range []struct {
+		name     corev1.ResourceName
+		quota    string
+		request  string
+		property string
+	}{
+		{corev1.ResourceCPU, quota.RequestsCPU, s.RequestCPU, "container.request-cpu"},
+		{corev1.ResourceMemory, quota.RequestsMemory, s.RequestMemory, "container.request-memory"},
+	} {
+		if q.quota == "" {
+			continue
+		}
+		max, err := resource.ParseQuantity(q.quota)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s quota", q.name)
+		}
+		if q.request == "" {
+			violations = append(violations, fmt.Sprintf("namespace %s has a %s quota, set the %s trait property, e.g., -t %s=...",
+				it.Namespace, q.name, q.property, q.property))
+			continue
+		}
+		request, err := resource.ParseQuantity(q.request)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid %s trait property: %s", q.property, err.Error()))
+			continue
+		}
+
+		used := requested[q.name]
+		total := used.DeepCopy()
+		for i := int32(0); i < replicas; i++ {
+			total.Add(request)
+		}
+		if total.Cmp(max) > 0 {
+			violations = append(violations, fmt.Sprintf("%d replicas requesting %s of %s each exceed the quota of %s of namespace %s, where %s are already requested by the other integrations",
+				replicas, request.String(), q.name, max.String(), it.Namespace, used.String()))
+		}
+	}
+
+	return violations, nil
+}
+
+// countPrecedingIntegrations returns the number of Integrations of the namespace of the given one,
+// that have been created before it
+func countPrecedingIntegrations(ctx context.Context, c client.Client, it *v1.Integration) (int, error) {
+	list := v1.NewIntegrationList()
+	if err := c.List(ctx, &list, ctrl.InNamespace(it.Namespace)); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, other := range list.Items {
+		if other.Name == it.Name {
+			continue
+		}
+		created := it.CreationTimestamp
+		if created.IsZero() || other.CreationTimestamp.Before(&created) ||
+			(other.CreationTimestamp.Equal(&created) && other.Name < it.Name) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// requestedResources returns the resources requested by the active pods of the other Integrations
+// of the namespace of the given one
+func requestedResources(ctx context.Context, c client.Client, it *v1.Integration) (corev1.ResourceList, error) {
+	pods := corev1.PodList{}
+	if err := c.List(ctx, &pods, ctrl.InNamespace(it.Namespace), ctrl.HasLabels{v1.IntegrationLabel}); err != nil {
+		return nil, err
+	}
+
+	requested := corev1.ResourceList{}
+	for _, pod := range pods.Items {
+		if pod.Labels[v1.IntegrationLabel] == it.Name || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			for name, quantity := range container.Resources.Requests {
+				total := requested[name]
+				total.Add(quantity)
+				requested[name] = total
+			}
+		}
+	}
+	return requested, nil
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestCheckQuotaIntegrations(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	newIntegration := func(name string, created time.Time) *v1.Integration {
+		it := v1.NewIntegration("ns", name)
+		it.CreationTimestamp = metav1.NewTime(created)
+		return &it
+	}
+	first := newIntegration("first", now.Add(-2*time.Hour))
+	second := newIntegration("second", now.Add(-time.Hour))
+	third := newIntegration("third", now)
+
+	c, err := test.NewFakeClient(first, second, third)
+	assert.Nil(t, err)
+
+	maxIntegrations := int32(2)
+	quota := v1.IntegrationPlatformQuotaSpec{MaxIntegrations: &maxIntegrations}
+
+	for _, it := range []*v1.Integration{first, second} {
+		violations, err := checkQuota(context.TODO(), c, quota, Subject{Integration: it})
+		assert.Nil(t, err)
+		assert.Empty(t, violations)
+	}
+	expected := []string{"namespace ns has reached its quota of 2 integrations, delete an integration before creating a new one"}
+	violations, err := checkQuota(context.TODO(), c, quota, Subject{Integration: third})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, violations)
+	violations, err = checkQuota(context.TODO(), c, quota, Subject{Integration: newIntegration("new", time.Time{})})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, violations)
+}
+
+func TestCheckQuotaRequestsMemory(t *testing.T) {
+	newPod := func(name string, integration string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				Labels:    map[string]string{v1.IntegrationLabel: integration},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "integration",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	c, err := test.NewFakeClient(
+		newPod("running", "other", corev1.PodRunning),
+		newPod("completed", "other", corev1.PodSucceeded),
+		newPod("own", "my-integration", corev1.PodRunning),
+	)
+	assert.Nil(t, err)
+
+	it := v1.NewIntegration("ns", "my-integration")
+	quota := v1.IntegrationPlatformQuotaSpec{RequestsMemory: "2Gi"}
+
+	violations, err := checkQuota(context.TODO(), c, quota, Subject{Integration: &it, RequestMemory: "1Gi"})
+	assert.Nil(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = checkQuota(context.TODO(), c, quota, Subject{Integration: &it, RequestMemory: "1.5Gi"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1 replicas requesting 1536Mi of memory each exceed the quota of 2Gi of namespace ns, " +
+		"where 1Gi are already requested by the other integrations"}, violations)
+
+	_, err = checkQuota(context.TODO(), c, v1.IntegrationPlatformQuotaSpec{RequestsMemory: "lots"}, Subject{Integration: &it, RequestMemory: "1Gi"})
+	assert.EqualError(t, err, "invalid memory quota: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'")
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// The users are authorized to configure the restricted traits, or trait properties, with the configure verb
+// on the traits virtual resource, named after the trait or the trait property, e.g.:
+//
+//   - apiGroups: ["trait.camel.apache.org"]
+//     resources: ["traits"]
+//     resourceNames: ["istio", "container.image"]
+//     verbs: ["configure"]
+//
+// The resource lives in its own API group, so that it is not granted by the roles of the camel.apache.org group.
+const (
+	TraitsGroup    = "trait.camel.apache.org"
+	TraitsResource = "traits"
+	TraitsVerb     = "configure"
+)
+
+// RequesterFromAnnotations returns the user recorded in the given annotations by the admission webhooks, if any
+func RequesterFromAnnotations(annotations map[string]string) *authenticationv1.UserInfo {
+	name := annotations[v1.RequesterAnnotation]
+	if name == "" {
+		return nil
+	}
+	user := authenticationv1.UserInfo{Username: name}
+	if groups := annotations[v1.RequesterGroupsAnnotation]; groups != "" {
+		user.Groups = strings.Split(groups, ",")
+	}
+	return &user
+}
+
+func checkTraits(ctx context.Context, c client.Client, restricted []string, s Subject) ([]string, error) {
+	violations := make([]string, 0)
+	if len(restricted) == 0 {
+		return violations, nil
+	}
+
+	authorized := make(map[string]bool)
+	isAuthorized := func(name string) (bool, error) {
+		if allowed, ok := authorized[name]; ok {
+			return allowed, nil
+		}
+		allowed, err := kubernetes.CheckUserPermission(ctx, c, *s.Requester, TraitsGroup, TraitsResource, s.Integration.Namespace, name, TraitsVerb)
+		if err != nil {
+			return false, err
+		}
+		authorized[name] = allowed
+		return allowed, nil
+	}
+
+	for _, property := range s.TraitProperties {
+		if !IsRestricted(restricted, property) {
+			continue
+		}
+		if s.Requester == nil {
+			violations = append(violations, fmt.Sprintf("trait property %s is restricted, and the user that has configured it is unknown, "+
+				"the integration must be created or updated with the admission webhooks enabled", property))
+			continue
+		}
+
+		id := strings.SplitN(property, ".", 2)[0]
+		allowed, err := isAuthorized(id)
+		if err == nil && !allowed {
+			allowed, err = isAuthorized(property)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			violations = append(violations, fmt.Sprintf("user %s is not authorized to configure the restricted trait property %s, "+
+				"the %s verb on the %s.%s resource named %s or %s must be granted",
+				s.Requester.Username, property, TraitsVerb, TraitsResource, TraitsGroup, id, property))
+		}
+	}
+
+	return violations, nil
+}
+
+// IsRestricted returns true if the given trait, or trait property, is in the given restricted ones, or is a property
+// of a restricted trait
+func IsRestricted(restricted []string, property string) bool {
+	for _, r := range restricted {
+		if property == r || strings.HasPrefix(property, r+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,8 @@ package trait
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +34,13 @@ import (
 // Allows constraining which nodes the integration pod(s) are eligible to be scheduled on, based on labels on the node,
 // or with inter-pod affinity and anti-affinity, based on labels on pods that are already running on the nodes.
 //
+// It also allows spreading the integration pods across topology domains, such as zones or nodes,
+// with topology spread constraints, either explicitly or using one of the following presets:
+//
+// - `spread-across-zones`: spreads the pods evenly across zones, when possible
+// - `spread-across-nodes`: spreads the pods evenly across nodes, when possible
+// - `one-per-node`: never co-locates multiple replicas of the integration in the same node
+//
 // It's disabled by default.
 //
 // +camel-k:trait=affinity
@@ -49,8 +58,25 @@ type affinityTrait struct {
 	// Defines a set of pods (namely those matching the label selector, relative to the given namespace) that the
 	// integration pod(s) should not be co-located with.
 	PodAntiAffinityLabels []string `property:"pod-anti-affinity-labels" json:"podAntiAffinityLabels,omitempty"`
+	// Defines how the integration pod(s) are spread across topology domains, in the form
+	// `topology-key[:max-skew[:when-unsatisfiable]]`, e.g. `topology.kubernetes.io/zone:1:DoNotSchedule`.
+	// The maximum skew defaults to `1`, and the pods are scheduled anyway when the constraint can't be satisfied by default.
+	TopologySpreadConstraints []string `property:"topology-spread-constraints" json:"topologySpreadConstraints,omitempty"`
+	// The scheduling presets to apply: `spread-across-zones`, `spread-across-nodes` or `one-per-node`.
+	Presets []string `property:"presets" json:"presets,omitempty"`
 }
 
+const (
+	affinityPresetSpreadAcrossZones = "spread-across-zones"
+	affinityPresetSpreadAcrossNodes = "spread-across-nodes"
+	affinityPresetOnePerNode        = "one-per-node"
+
+	topologyKeyHostname = "kubernetes.io/hostname"
+	topologyKeyZone     = "topology.kubernetes.io/zone"
+)
+
+var topologySpreadConstraintRegexp = regexp.MustCompile(`^([\w.\-/]+)(:(\d+)(:(DoNotSchedule|ScheduleAnyway))?)?$`)
+
 func newAffinityTrait() Trait {
 	return &affinityTrait{
 		BaseTrait:       NewBaseTrait("affinity", 1500),
@@ -64,10 +90,26 @@ func (t *affinityTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	for _, preset := range t.Presets {
+		switch preset {
+		case affinityPresetSpreadAcrossZones, affinityPresetSpreadAcrossNodes:
+		case affinityPresetOnePerNode:
+			t.PodAntiAffinity = BoolP(true)
+		default:
+			return false, fmt.Errorf("unsupported affinity preset: %s", preset)
+		}
+	}
+
 	if IsTrue(t.PodAffinity) && IsTrue(t.PodAntiAffinity) {
 		return false, fmt.Errorf("both pod affinity and pod anti-affinity can't be set simultaneously")
 	}
 
+	for _, constraint := range t.TopologySpreadConstraints {
+		if !topologySpreadConstraintRegexp.MatchString(constraint) {
+			return false, fmt.Errorf("unable to parse topology spread constraint: %s", constraint)
+		}
+	}
+
 	return e.IntegrationInRunningPhases(), nil
 }
 
@@ -89,9 +131,48 @@ func (t *affinityTrait) Apply(e *Environment) (err error) {
 	if err := t.addPodAntiAffinity(e, podSpec); err != nil {
 		return err
 	}
+	t.addTopologySpreadConstraints(e, podSpec)
 	return nil
 }
 
+func (t *affinityTrait) addTopologySpreadConstraints(e *Environment, podSpec *corev1.PodSpec) {
+	constraints := make([]string, 0, len(t.TopologySpreadConstraints)+len(t.Presets))
+	for _, preset := range t.Presets {
+		switch preset {
+		case affinityPresetSpreadAcrossZones:
+			constraints = append(constraints, topologyKeyZone)
+		case affinityPresetSpreadAcrossNodes:
+			constraints = append(constraints, topologyKeyHostname)
+		}
+	}
+	constraints = append(constraints, t.TopologySpreadConstraints...)
+
+	for _, constraint := range constraints {
+		match := topologySpreadConstraintRegexp.FindStringSubmatch(constraint)
+
+		topologySpreadConstraint := corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       match[1],
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					v1.IntegrationLabel: e.Integration.Name,
+				},
+			},
+		}
+		if match[3] != "" {
+			// the regular expression guarantees a valid number
+			maxSkew, _ := strconv.ParseInt(match[3], 10, 32)
+			topologySpreadConstraint.MaxSkew = int32(maxSkew)
+		}
+		if match[5] != "" {
+			topologySpreadConstraint.WhenUnsatisfiable = corev1.UnsatisfiableConstraintAction(match[5])
+		}
+
+		podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, topologySpreadConstraint)
+	}
+}
+
 func (t *affinityTrait) addNodeAffinity(_ *Environment, podSpec *corev1.PodSpec) error {
 	if len(t.NodeAffinityLabels) == 0 {
 		return nil
@@ -172,7 +253,7 @@ func (t *affinityTrait) addPodAffinity(e *Environment, podSpec *corev1.PodSpec)
 				LabelSelector: &metav1.LabelSelector{
 					MatchExpressions: labelSelectorRequirements,
 				},
-				TopologyKey: "kubernetes.io/hostname",
+				TopologyKey: topologyKeyHostname,
 			},
 		},
 	}
@@ -223,7 +304,7 @@ func (t *affinityTrait) addPodAntiAffinity(e *Environment, podSpec *corev1.PodSp
 				LabelSelector: &metav1.LabelSelector{
 					MatchExpressions: labelSelectorRequirements,
 				},
-				TopologyKey: "kubernetes.io/hostname",
+				TopologyKey: topologyKeyHostname,
 			},
 		},
 	}
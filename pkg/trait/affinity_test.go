@@ -179,6 +179,83 @@ func testApplyPodAffinityLabelsDoesSucceed(t *testing.T, trait *affinityTrait, e
 	assert.ElementsMatch(t, [1]string{"integration-name"}, integrationRequirement.Values)
 }
 
+func TestConfigureAffinityTraitWithPresets(t *testing.T) {
+	affinityTrait := createNominalAffinityTest()
+	affinityTrait.Presets = []string{"one-per-node"}
+	environment, _ := createNominalDeploymentTraitTest()
+	configured, err := affinityTrait.Configure(environment)
+
+	assert.True(t, configured)
+	assert.Nil(t, err)
+	assert.True(t, *affinityTrait.PodAntiAffinity)
+
+	affinityTrait = createNominalAffinityTest()
+	affinityTrait.Presets = []string{"one-per-node"}
+	affinityTrait.PodAffinity = BoolP(true)
+	configured, err = affinityTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+
+	affinityTrait = createNominalAffinityTest()
+	affinityTrait.Presets = []string{"one-per-zone"}
+	configured, err = affinityTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Equal(t, "unsupported affinity preset: one-per-zone", err.Error())
+}
+
+func TestConfigureAffinityTraitWithInvalidTopologySpreadConstraintFails(t *testing.T) {
+	affinityTrait := createNominalAffinityTest()
+	affinityTrait.TopologySpreadConstraints = []string{"topology.kubernetes.io/zone:1:Never"}
+	environment, _ := createNominalDeploymentTraitTest()
+	configured, err := affinityTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+}
+
+func TestApplyTopologySpreadConstraintsDoesSucceed(t *testing.T) {
+	affinityTrait := createNominalAffinityTest()
+	affinityTrait.Presets = []string{"spread-across-zones"}
+	affinityTrait.TopologySpreadConstraints = []string{"example.com/rack:2:DoNotSchedule"}
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	testApplyTopologySpreadConstraintsDoesSucceed(t, affinityTrait, environment, &deployment.Spec.Template.Spec)
+
+	environment, knativeService := createNominalKnativeServiceTraitTest()
+	testApplyTopologySpreadConstraintsDoesSucceed(t, affinityTrait, environment, &knativeService.Spec.Template.Spec.PodSpec)
+}
+
+func testApplyTopologySpreadConstraintsDoesSucceed(t *testing.T, trait *affinityTrait, environment *Environment, podSpec *corev1.PodSpec) {
+	configured, err := trait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			v1.IntegrationLabel: "integration-name",
+		},
+	}
+	assert.Equal(t, []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     selector,
+		},
+		{
+			MaxSkew:           2,
+			TopologyKey:       "example.com/rack",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     selector,
+		},
+	}, podSpec.TopologySpreadConstraints)
+}
+
 func createNominalAffinityTest() *affinityTrait {
 	trait := newAffinityTrait().(*affinityTrait)
 	trait.Enabled = BoolP(true)
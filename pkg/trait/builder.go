@@ -20,11 +20,14 @@ package trait
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
 	mvn "github.com/apache/camel-k/pkg/util/maven"
 	"github.com/apache/camel-k/pkg/util/property"
 )
@@ -32,6 +35,20 @@ import (
 // The builder trait is internally used to determine the best strategy to
 // build and configure IntegrationKits.
 //
+// The Maven profiles, repositories and mirrors of the build can be configured for each integration, on top of the ones
+// of the platform. They are merged with the ones of the platform and of the integration kit, those set on the trait
+// prevailing over the others that have the same ID, and the trait mirrors being declared first. The resulting Maven
+// configuration is recorded in the Build status.
+//
+// The kit images can be built for other architectures than the one of the build pod, e.g., to run the integrations
+// on ARM64 node pools, or for several architectures, in which case a multi-architecture image, i.e., a manifest list,
+// is built. This requires the Buildah publish strategy, and is not supported for native executables.
+//
+// Java and Groovy unit tests can be run during the build, from the entries of the ConfigMaps set with the
+// `test-configmaps` option, e.g. `RoutesTest.java`. They are compiled and run against the dependencies of the kit,
+// with JUnit 5 and `camel-quarkus-junit5`, and the build fails when a test fails, the excerpts of the reports of the
+// failed tests being recorded in the Build status. The kit is not rebuilt when the content of the ConfigMaps changes.
+//
 // +camel-k:trait=builder
 type builderTrait struct {
 	BaseTrait `property:",squash"`
@@ -39,6 +56,20 @@ type builderTrait struct {
 	Verbose *bool `property:"verbose" json:"verbose,omitempty"`
 	// A list of properties to be provided to the build task
 	Properties []string `property:"properties" json:"properties,omitempty"`
+	// The Linux architectures the kit image is built for, among `amd64`, `arm64`, `ppc64le` and `s390x`, that default to the ones of the platform
+	Architectures []string `property:"architectures" json:"architectures,omitempty"`
+
+	// The Maven profiles activated for the build, in addition to the ones of the platform
+	Profiles []string `property:"maven-profiles" json:"mavenProfiles,omitempty"`
+	// The Maven repositories the build resolves the dependencies from, in addition to the ones of the platform,
+	// e.g., `https://repo.acme.com/maven2@id=acme@snapshots`
+	Repositories []string `property:"maven-repositories" json:"mavenRepositories,omitempty"`
+	// The Maven mirrors the build is configured with, ahead of the ones of the platform,
+	// e.g., `https://nexus.acme.com/repository/maven-public@id=acme@mirrorOf=central`
+	Mirrors []string `property:"maven-mirrors" json:"mavenMirrors,omitempty"`
+	// The ConfigMaps holding the unit tests run during the build, in the form `name[/key]`. All the Java and Groovy
+	// entries of a ConfigMap are run when no key is set.
+	TestConfigMaps []string `property:"test-configmaps" json:"testConfigMaps,omitempty"`
 }
 
 func newBuilderTrait() Trait {
@@ -68,13 +99,27 @@ func (t *builderTrait) Configure(e *Environment) (bool, error) {
 func (t *builderTrait) Apply(e *Environment) error {
 	builderTask, err := t.builderTask(e)
 	if err != nil {
-		e.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseError
-		e.IntegrationKit.Status.SetCondition("IntegrationKitPropertiesFormatValid", corev1.ConditionFalse,
-			"IntegrationKitPropertiesFormatValid", fmt.Sprintf("One or more properties where not formatted as expected: %s", err.Error()))
-		if err := e.Client.Status().Update(e.Ctx, e.IntegrationKit); err != nil {
-			return err
-		}
-		return nil
+		return t.failKit(e, "IntegrationKitPropertiesFormatValid",
+			fmt.Sprintf("One or more properties where not formatted as expected: %s", err.Error()))
+	}
+
+	architectures := t.Architectures
+	if len(architectures) == 0 {
+		architectures = e.Platform.Status.Build.Architectures
+	}
+	if err := t.validateArchitectures(e, architectures); err != nil {
+		return t.failKit(e, "IntegrationKitArchitecturesSupported", err.Error())
+	}
+	if err := t.validatePublishStrategy(e); err != nil {
+		return t.failKit(e, "IntegrationKitPublishStrategyConfigured", err.Error())
+	}
+	tests, err := t.tests(e)
+	if err != nil {
+		return t.failKit(e, "IntegrationKitTestsAvailable", err.Error())
+	}
+	if len(tests) > 0 {
+		builderTask.Tests = tests
+		builderTask.Steps = append(builderTask.Steps, builder.StepIDsFor(builder.Project.InjectTests)...)
 	}
 
 	e.BuildTasks = append(e.BuildTasks, v1.Task{Builder: builderTask})
@@ -111,6 +156,7 @@ func (t *builderTrait) Apply(e *Environment) error {
 			},
 			HttpProxySecret: e.Platform.Status.Build.HTTPProxySecret,
 			Verbose:         t.Verbose,
+			Architectures:   architectures,
 		}})
 
 	case v1.IntegrationPlatformBuildPublishStrategyKaniko:
@@ -129,25 +175,202 @@ func (t *builderTrait) Apply(e *Environment) error {
 			HttpProxySecret: e.Platform.Status.Build.HTTPProxySecret,
 			Verbose:         t.Verbose,
 		}})
+
+	case v1.IntegrationPlatformBuildPublishStrategyTekton:
+		tekton := e.Platform.Status.Build.Tekton
+		e.BuildTasks = append(e.BuildTasks, v1.Task{Tekton: &v1.TektonTask{
+			BaseTask: v1.BaseTask{
+				Name: "tekton",
+			},
+			PublishTask: v1.PublishTask{
+				BaseImage: e.Platform.Status.Build.BaseImage,
+				Image:     getImageName(e),
+				Registry:  e.Platform.Status.Build.Registry,
+			},
+			PipelineRunTemplate:   tekton.PipelineRunTemplate,
+			Workspace:             tekton.Workspace,
+			PersistentVolumeClaim: e.Platform.Status.Build.PersistentVolumeClaim,
+		}})
+
+	case v1.IntegrationPlatformBuildPublishStrategyLocal:
+		local := e.Platform.Status.Build.Local
+		e.BuildTasks = append(e.BuildTasks, v1.Task{Local: &v1.LocalTask{
+			BaseTask: v1.BaseTask{
+				Name: "local",
+			},
+			PublishTask: v1.PublishTask{
+				Image:    getImageName(e),
+				Registry: e.Platform.Status.Build.Registry,
+			},
+			Engine:      local.Engine,
+			Address:     local.Address,
+			ClientImage: local.ClientImage,
+			Verbose:     t.Verbose,
+		}})
 	}
 
 	return nil
 }
 
+// failKit sets the kit in error, with the given condition explaining why it cannot be built
+func (t *builderTrait) failKit(e *Environment, condition v1.IntegrationKitConditionType, message string) error {
+	e.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseError
+	e.IntegrationKit.Status.SetCondition(condition, corev1.ConditionFalse, string(condition), message)
+	return e.Client.Status().Update(e.Ctx, e.IntegrationKit)
+}
+
+func (t *builderTrait) validateArchitectures(e *Environment, architectures []string) error {
+	if len(architectures) == 0 {
+		return nil
+	}
+	if err := builder.ValidateArchitectures(architectures); err != nil {
+		return err
+	}
+	if e.Platform.Status.Build.PublishStrategy != v1.IntegrationPlatformBuildPublishStrategyBuildah {
+		return fmt.Errorf("building images for the %s architectures requires the %s publish strategy",
+			strings.Join(architectures, ", "), v1.IntegrationPlatformBuildPublishStrategyBuildah)
+	}
+	if q, ok := e.Catalog.GetTrait("quarkus").(*quarkusTrait); ok && containsPackageType(q.PackageTypes, nativePackageType) {
+		return fmt.Errorf("native executables can only be built for the architecture of the build pod")
+	}
+	return nil
+}
+
+func (t *builderTrait) validatePublishStrategy(e *Environment) error {
+	if e.Platform.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyLocal {
+		return t.validateLocalPublishStrategy(e)
+	}
+	if e.Platform.Status.Build.PublishStrategy != v1.IntegrationPlatformBuildPublishStrategyTekton {
+		return nil
+	}
+	if tekton := e.Platform.Status.Build.Tekton; tekton == nil || tekton.PipelineRunTemplate == nil {
+		return fmt.Errorf("the %s publish strategy requires a PipelineRun template to be configured on the integration platform",
+			v1.IntegrationPlatformBuildPublishStrategyTekton)
+	}
+	if e.Platform.Status.Build.BuildStrategy != v1.IntegrationPlatformBuildStrategyPod {
+		// The generated Maven project is shared with the PipelineRun through the volume of the build pod
+		return fmt.Errorf("the %s publish strategy requires the %s build strategy",
+			v1.IntegrationPlatformBuildPublishStrategyTekton, v1.IntegrationPlatformBuildStrategyPod)
+	}
+	return nil
+}
+
+func (t *builderTrait) validateLocalPublishStrategy(e *Environment) error {
+	local := e.Platform.Status.Build.Local
+	if local == nil || !v1.IntegrationPlatformLocalEngineSupported(local.Engine) {
+		return fmt.Errorf("the %s publish strategy requires one of the %s container engines to be configured on the integration platform",
+			v1.IntegrationPlatformBuildPublishStrategyLocal, localEngineNames())
+	}
+	if e.Platform.Status.Build.BuildStrategy != v1.IntegrationPlatformBuildStrategyPod {
+		// The container engine socket of the node is mounted into the build pod
+		return fmt.Errorf("the %s publish strategy requires the %s build strategy",
+			v1.IntegrationPlatformBuildPublishStrategyLocal, v1.IntegrationPlatformBuildStrategyPod)
+	}
+	return nil
+}
+
+func localEngineNames() string {
+	names := make([]string, 0, len(v1.IntegrationPlatformLocalEngines))
+	for _, engine := range v1.IntegrationPlatformLocalEngines {
+		names = append(names, string(engine))
+	}
+	return strings.Join(names, ", ")
+}
+
+// tests returns the unit tests read from the ConfigMaps, sorted by ConfigMap and key
+func (t *builderTrait) tests(e *Environment) ([]v1.SourceSpec, error) {
+	tests := make([]v1.SourceSpec, 0)
+	for _, ref := range t.TestConfigMaps {
+		name, key, _ := splitOnce(ref, "/")
+		cm, err := kubernetes.GetConfigMap(e.Ctx, e.Client, name, e.IntegrationKit.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the tests of the %s ConfigMap: %v", name, err)
+		}
+		keys := make([]string, 0, len(cm.Data))
+		if key != "" {
+			if _, ok := cm.Data[key]; !ok {
+				return nil, fmt.Errorf("the %s ConfigMap has no %s test", name, key)
+			}
+			keys = append(keys, key)
+		} else {
+			for k := range cm.Data {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+		}
+		for _, k := range keys {
+			test := v1.SourceSpec{
+				DataSpec: v1.DataSpec{
+					Name:    k,
+					Content: cm.Data[k],
+				},
+			}
+			switch test.InferLanguage() {
+			case v1.LanguageJavaSource, v1.LanguageGroovy:
+				test.Language = test.InferLanguage()
+				tests = append(tests, test)
+			default:
+				if key != "" {
+					return nil, fmt.Errorf("unsupported test %s: only Java and Groovy tests can be run", k)
+				}
+			}
+		}
+	}
+	return tests, nil
+}
+
 func (t *builderTrait) builderTask(e *Environment) (*v1.BuilderTask, error) {
 	maven := e.Platform.Status.Build.Maven
 
-	// Add Maven repositories defined in the IntegrationKit
+	// Add Maven repositories defined in the IntegrationKit, then in the trait
+	repositories := make([]v1.Repository, 0, len(maven.Repositories))
+	repositories = append(repositories, maven.Repositories...)
 	for _, repo := range e.IntegrationKit.Spec.Repositories {
-		maven.Repositories = append(maven.Repositories, mvn.NewRepository(repo))
+		repositories = append(repositories, mvn.NewRepository(repo))
+	}
+	for i, repo := range t.Repositories {
+		repository := mvn.NewRepository(repo)
+		if repository.ID == "" {
+			repository.ID = fmt.Sprintf("builder-repository-%03d", i)
+		}
+		repositories = append(repositories, repository)
 	}
+	maven.Repositories = mergeRepositories(repositories)
+
+	// Add Maven mirrors defined in the trait, ahead of the platform ones
+	mirrors := make([]v1.Mirror, 0, len(maven.Mirrors)+len(t.Mirrors))
+	for i, m := range t.Mirrors {
+		mirror := mvn.NewMirror(m)
+		if mirror.URL == "" || mirror.MirrorOf == "" {
+			return nil, fmt.Errorf("maven mirror must have url@mirrorOf=repositories format, it was %v", m)
+		}
+		if mirror.ID == "" {
+			mirror.ID = fmt.Sprintf("builder-mirror-%03d", i)
+		}
+		mirrors = append(mirrors, mirror)
+	}
+	mirrors = append(mirrors, maven.Mirrors...)
+	maven.Mirrors = mergeMirrors(mirrors)
+
+	// Add Maven profiles defined in the trait
+	var profiles []string
+	for _, profile := range append(append([]string{}, maven.Profiles...), t.Profiles...) {
+		util.StringSliceUniqueAdd(&profiles, profile)
+	}
+	maven.Profiles = profiles
+
 	task := &v1.BuilderTask{
 		BaseTask: v1.BaseTask{
 			Name: "builder",
 		},
-		Runtime:      e.CamelCatalog.Runtime,
-		Dependencies: e.IntegrationKit.Spec.Dependencies,
-		Maven:        maven,
+		Runtime:            e.CamelCatalog.Runtime,
+		Dependencies:       e.IntegrationKit.Spec.Dependencies,
+		LockedDependencies: e.IntegrationKit.Spec.LockedDependencies,
+		Maven:              maven,
+	}
+
+	if e.Platform.Status.Build.BuildStrategy == v1.IntegrationPlatformBuildStrategyPod {
+		task.Image = e.Platform.Status.Build.BuilderImage
 	}
 
 	if task.Maven.Properties == nil {
@@ -178,6 +401,37 @@ func (t *builderTrait) builderTask(e *Environment) (*v1.BuilderTask, error) {
 	return task, nil
 }
 
+// mergeRepositories removes the duplicated repositories, the later ones prevailing over the earlier ones
+// that have the same ID, at the position of the first ones
+func mergeRepositories(repositories []v1.Repository) []v1.Repository {
+	merged := make([]v1.Repository, 0, len(repositories))
+	positions := make(map[string]int)
+	for _, repository := range repositories {
+		if i, ok := positions[repository.ID]; ok && repository.ID != "" {
+			merged[i] = repository
+			continue
+		}
+		positions[repository.ID] = len(merged)
+		merged = append(merged, repository)
+	}
+	return merged
+}
+
+// mergeMirrors removes the duplicated mirrors, the earlier ones prevailing over the later ones
+// that have the same ID
+func mergeMirrors(mirrors []v1.Mirror) []v1.Mirror {
+	merged := make([]v1.Mirror, 0, len(mirrors))
+	ids := make(map[string]bool)
+	for _, mirror := range mirrors {
+		if ids[mirror.ID] {
+			continue
+		}
+		ids[mirror.ID] = true
+		merged = append(merged, mirror)
+	}
+	return merged
+}
+
 func getImageName(e *Environment) string {
 	organization := e.Platform.Status.Build.Registry.Organization
 	if organization == "" {
@@ -19,6 +19,7 @@ package trait
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,9 +28,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	mvn "github.com/apache/camel-k/pkg/util/maven"
+	"github.com/apache/camel-k/pkg/util/test"
 )
 
 func TestBuilderTraitNotAppliedBecauseOfNilKit(t *testing.T) {
@@ -168,3 +172,286 @@ func createNominalBuilderTraitTest() *builderTrait {
 
 	return builderTrait
 }
+
+func TestBuildahBuilderTraitWithArchitectures(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyBuildah)
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.Architectures = []string{"amd64", "arm64"}
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.NotNil(t, env.BuildTasks[1].Buildah)
+	assert.Equal(t, []string{"amd64", "arm64"}, env.BuildTasks[1].Buildah.Architectures)
+}
+
+func TestBuildahBuilderTraitWithPlatformArchitectures(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyBuildah)
+	env.Platform.Status.Build.Architectures = []string{"arm64"}
+
+	err := createNominalBuilderTraitTest().Apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.Equal(t, []string{"arm64"}, env.BuildTasks[1].Buildah.Architectures)
+}
+
+func TestKanikoBuilderTraitWithArchitectures(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.IntegrationKit.Name = "my-kit"
+	env.IntegrationKit.Namespace = "ns"
+	c, err := test.NewFakeClient(env.IntegrationKit)
+	assert.Nil(t, err)
+	env.Client = c
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.Architectures = []string{"arm64"}
+
+	err = builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Empty(t, env.BuildTasks)
+	assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+	assert.Equal(t, corev1.ConditionFalse, env.IntegrationKit.Status.GetCondition("IntegrationKitArchitecturesSupported").Status)
+}
+
+func TestBuilderTraitWithUnsupportedArchitecture(t *testing.T) {
+	for _, arch := range []string{"windows/amd64", "linux/arm64", "386", "riscv64", "amd64 --os=windows"} {
+		for _, platformDefault := range []bool{false, true} {
+			env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyBuildah)
+			env.IntegrationKit.Name = "my-kit"
+			env.IntegrationKit.Namespace = "ns"
+			c, err := test.NewFakeClient(env.IntegrationKit)
+			assert.Nil(t, err)
+			env.Client = c
+			builderTrait := createNominalBuilderTraitTest()
+			if platformDefault {
+				env.Platform.Status.Build.Architectures = []string{"amd64", arch}
+			} else {
+				builderTrait.Architectures = []string{arch}
+			}
+
+			err = builderTrait.Apply(env)
+
+			assert.Nil(t, err)
+			assert.Empty(t, env.BuildTasks)
+			assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+			condition := env.IntegrationKit.Status.GetCondition("IntegrationKitArchitecturesSupported")
+			assert.Equal(t, corev1.ConditionFalse, condition.Status)
+			assert.Contains(t, condition.Message, fmt.Sprintf("unsupported architecture %q", arch))
+		}
+	}
+}
+
+func TestTektonBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyTekton)
+	env.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyPod
+	env.Platform.Status.Build.PersistentVolumeClaim = "camel-k"
+	env.Platform.Status.Build.Tekton = &v1.IntegrationPlatformTektonSpec{
+		PipelineRunTemplate: &v1.PipelineRunTemplate{RawMessage: []byte(`{"spec":{"pipelineRef":{"name":"build"}}}`)},
+		Workspace:           "source",
+	}
+
+	err := createNominalBuilderTraitTest().Apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	assert.NotNil(t, env.BuildTasks[1].Tekton)
+	assert.Equal(t, "source", env.BuildTasks[1].Tekton.Workspace)
+	assert.Equal(t, "camel-k", env.BuildTasks[1].Tekton.PersistentVolumeClaim)
+	assert.Equal(t, env.Platform.Status.Build.Tekton.PipelineRunTemplate, env.BuildTasks[1].Tekton.PipelineRunTemplate)
+}
+
+func TestTektonBuilderTraitWithoutPipelineRunTemplate(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyTekton)
+	env.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyPod
+	env.IntegrationKit.Name = "my-kit"
+	env.IntegrationKit.Namespace = "ns"
+	c, err := test.NewFakeClient(env.IntegrationKit)
+	assert.Nil(t, err)
+	env.Client = c
+
+	err = createNominalBuilderTraitTest().Apply(env)
+
+	assert.Nil(t, err)
+	assert.Empty(t, env.BuildTasks)
+	assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+	assert.Equal(t, corev1.ConditionFalse, env.IntegrationKit.Status.GetCondition("IntegrationKitPublishStrategyConfigured").Status)
+}
+
+func TestLocalBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyLocal)
+	env.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyPod
+	env.Platform.Status.Build.Registry.Address = "localhost"
+	env.Platform.Status.Build.Local = &v1.IntegrationPlatformLocalSpec{
+		Engine:      v1.IntegrationPlatformLocalEngineBuildkit,
+		Address:     "tcp://buildkitd:1234",
+		ClientImage: "docker.io/moby/buildkit:v0.9.3",
+	}
+
+	err := createNominalBuilderTraitTest().Apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	assert.NotNil(t, env.BuildTasks[1].Local)
+	assert.Equal(t, v1.IntegrationPlatformLocalEngineBuildkit, env.BuildTasks[1].Local.Engine)
+	assert.Equal(t, "tcp://buildkitd:1234", env.BuildTasks[1].Local.Address)
+	assert.Equal(t, "docker.io/moby/buildkit:v0.9.3", env.BuildTasks[1].Local.ClientImage)
+	assert.Regexp(t, "^localhost/", env.BuildTasks[1].Local.Image)
+}
+
+func TestLocalBuilderTraitWithRoutineBuildStrategy(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyLocal)
+	env.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyRoutine
+	env.Platform.Status.Build.Local = &v1.IntegrationPlatformLocalSpec{
+		Engine: v1.IntegrationPlatformLocalEngineDocker,
+	}
+	env.IntegrationKit.Name = "my-kit"
+	env.IntegrationKit.Namespace = "ns"
+	c, err := test.NewFakeClient(env.IntegrationKit)
+	assert.Nil(t, err)
+	env.Client = c
+
+	err = createNominalBuilderTraitTest().Apply(env)
+
+	assert.Nil(t, err)
+	assert.Empty(t, env.BuildTasks)
+	assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+	assert.Equal(t, "the Local publish strategy requires the pod build strategy",
+		env.IntegrationKit.Status.GetCondition("IntegrationKitPublishStrategyConfigured").Message)
+}
+
+func TestMavenConfigurationBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Status.Build.Maven.Profiles = []string{"platform", "shared"}
+	env.Platform.Status.Build.Maven.Repositories = []v1.Repository{
+		mvn.NewRepository("https://repo.platform.org/maven2@id=platform"),
+		mvn.NewRepository("https://repo.acme.com/old@id=acme"),
+	}
+	env.Platform.Status.Build.Maven.Mirrors = []v1.Mirror{
+		mvn.NewMirror("https://mirror.platform.org/maven2@id=platform-mirror@mirrorOf=*"),
+		mvn.NewMirror("https://mirror.acme.com/old@id=acme-mirror@mirrorOf=*"),
+	}
+	env.IntegrationKit.Spec.Repositories = []string{"https://repo.kit.org/maven2@id=kit"}
+
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.Profiles = []string{"shared", "integration"}
+	builderTrait.Repositories = []string{"https://repo.acme.com/maven2@id=acme", "https://repo.foo.org/maven2"}
+	builderTrait.Mirrors = []string{"https://mirror.acme.com/maven2@id=acme-mirror@mirrorOf=central"}
+
+	err := builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	maven := env.BuildTasks[0].Builder.Maven
+	assert.Equal(t, []string{"platform", "shared", "integration"}, maven.Profiles)
+	assert.Equal(t, []string{"platform", "acme", "kit", "builder-repository-001"}, repositoryIDs(maven.Repositories))
+	assert.Equal(t, "https://repo.acme.com/maven2", maven.Repositories[1].URL)
+	assert.Equal(t, []v1.Mirror{
+		{ID: "acme-mirror", URL: "https://mirror.acme.com/maven2", MirrorOf: "central"},
+		{ID: "platform-mirror", URL: "https://mirror.platform.org/maven2", MirrorOf: "*"},
+	}, maven.Mirrors)
+	// The platform configuration is left untouched
+	assert.Len(t, env.Platform.Status.Build.Maven.Repositories, 2)
+	assert.Equal(t, "https://repo.acme.com/old", env.Platform.Status.Build.Maven.Repositories[1].URL)
+}
+
+func TestInvalidMavenMirrorBuilderTrait(t *testing.T) {
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.Mirrors = []string{"https://mirror.acme.com/maven2@id=acme-mirror"}
+
+	_, err := builderTrait.builderTask(createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes,
+		v1.IntegrationPlatformBuildPublishStrategyKaniko))
+
+	assert.NotNil(t, err)
+}
+
+func repositoryIDs(repositories []v1.Repository) []string {
+	ids := make([]string, 0, len(repositories))
+	for _, repository := range repositories {
+		ids = append(ids, repository.ID)
+	}
+	return ids
+}
+
+func TestCustomBuilderImageBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyPod
+	env.Platform.Status.Build.BuilderImage = "my-registry/my-builder:1.0"
+
+	err := createNominalBuilderTraitTest().Apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.BuildTasks, 2)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	assert.Equal(t, "my-registry/my-builder:1.0", env.BuildTasks[0].Builder.Image)
+
+	// The custom builder image is ignored when the builds are run by the operator
+	env = createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategySpectrum)
+	env.Platform.Status.Build.BuildStrategy = v1.IntegrationPlatformBuildStrategyRoutine
+	env.Platform.Status.Build.BuilderImage = "my-registry/my-builder:1.0"
+
+	err = createNominalBuilderTraitTest().Apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	assert.Empty(t, env.BuildTasks[0].Builder.Image)
+}
+
+func TestTestsBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.IntegrationKit.Name = "my-kit"
+	env.IntegrationKit.Namespace = "ns"
+	c, err := test.NewFakeClient(env.IntegrationKit,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tests"},
+			Data: map[string]string{
+				"RoutesTest.java":  "public class RoutesTest {}",
+				"BeansTest.groovy": "class BeansTest {}",
+				"README.md":        "The tests of the routes",
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "more-tests"},
+			Data: map[string]string{
+				"ProcessorTest.java": "public class ProcessorTest {}",
+				"OtherTest.java":     "public class OtherTest {}",
+			},
+		},
+	)
+	assert.Nil(t, err)
+	env.Client = c
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.TestConfigMaps = []string{"tests", "more-tests/ProcessorTest.java"}
+
+	err = builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.BuildTasks[0].Builder)
+	task := env.BuildTasks[0].Builder
+	assert.Equal(t, []v1.SourceSpec{
+		{DataSpec: v1.DataSpec{Name: "BeansTest.groovy", Content: "class BeansTest {}"}, Language: v1.LanguageGroovy},
+		{DataSpec: v1.DataSpec{Name: "RoutesTest.java", Content: "public class RoutesTest {}"}, Language: v1.LanguageJavaSource},
+		{DataSpec: v1.DataSpec{Name: "ProcessorTest.java", Content: "public class ProcessorTest {}"}, Language: v1.LanguageJavaSource},
+	}, task.Tests)
+	assert.Contains(t, task.Steps, builder.StepIDsFor(builder.Project.InjectTests)[0])
+}
+
+func TestMissingTestsBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.IntegrationKit.Name = "my-kit"
+	env.IntegrationKit.Namespace = "ns"
+	c, err := test.NewFakeClient(env.IntegrationKit)
+	assert.Nil(t, err)
+	env.Client = c
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.TestConfigMaps = []string{"tests"}
+
+	err = builderTrait.Apply(env)
+
+	assert.Nil(t, err)
+	assert.Empty(t, env.BuildTasks)
+	assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+	assert.Equal(t, corev1.ConditionFalse, env.IntegrationKit.Status.GetCondition("IntegrationKitTestsAvailable").Status)
+}
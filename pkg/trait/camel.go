@@ -19,21 +19,43 @@ package trait
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/maven"
 	"github.com/apache/camel-k/pkg/util/property"
 )
 
+const (
+	resolvedPropertiesType             = "resolved"
+	reloadablePropertiesType           = "reloadable"
+	resolvedPropertiesDigestAnnotation = "camel.apache.org/resolved-properties.digest"
+	contentReferencesDigestAnnotation  = "camel.apache.org/content-references.digest"
+)
+
+var (
+	// resourcePlaceholderRegexp matches the {{configmap:name/key}} and {{secret:name/key}} property placeholders,
+	// optionally followed by a default value
+	resourcePlaceholderRegexp = regexp.MustCompile(`{{(configmap|secret):([a-z0-9](?:[-a-z0-9.]*[a-z0-9])?)/([-._a-zA-Z0-9]+)(:[^}]*)?}}`)
+
+	propertyValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+)
+
 // The Camel trait can be used to configure versions of Apache Camel K runtime and related libraries, it cannot be disabled.
 //
 // +camel-k:trait=camel
@@ -43,6 +65,9 @@ type camelTrait struct {
 	RuntimeVersion string `property:"runtime-version" json:"runtimeVersion,omitempty"`
 	// A list of properties to be provided to the Integration runtime
 	Properties []string `property:"properties" json:"properties,omitempty"`
+	// Rebuild the integration once the operator is upgraded, as long as it is compatible with the catalog of the new version.
+	// It overrides the `upgrade.autoUpgrade` setting of the Integration Platform.
+	AutoUpgrade *bool `property:"auto-upgrade" json:"autoUpgrade,omitempty"`
 }
 
 func newCamelTrait() Trait {
@@ -88,6 +113,13 @@ func (t *camelTrait) Apply(e *Environment) error {
 			maps = append(maps, t.computeUserProperties(e)...)
 		}
 		e.Resources.AddAll(maps)
+
+		if err := t.computeResolvedProperties(e); err != nil {
+			return err
+		}
+		if err := t.computeContentReferences(e); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -147,6 +179,13 @@ func (t *camelTrait) loadOrCreateCatalog(e *Environment, runtimeVersion string)
 			runtime.Provider)
 	}
 
+	// Catalogs may be authored for custom runtimes, so they are checked before being used
+	if err := camel.ValidateCatalog(catalog.CamelCatalogSpec); err != nil {
+		return errors.Wrapf(err, "invalid catalog runtime=%s, provider=%s",
+			runtime.Version,
+			runtime.Provider)
+	}
+
 	e.CamelCatalog = catalog
 
 	return nil
@@ -156,6 +195,9 @@ func (t *camelTrait) determineRuntimeVersion(e *Environment) string {
 	if t.RuntimeVersion != "" {
 		return t.RuntimeVersion
 	}
+	if e.Integration != nil && e.Integration.Spec.DependencyLock != nil && e.Integration.Spec.DependencyLock.RuntimeVersion != "" {
+		return e.Integration.Spec.DependencyLock.RuntimeVersion
+	}
 	if e.Integration != nil && e.Integration.Status.RuntimeVersion != "" {
 		return e.Integration.Status.RuntimeVersion
 	}
@@ -179,6 +221,10 @@ func (t *camelTrait) computeConfigMaps(e *Environment) []ctrl.Object {
 	userProperties := ""
 
 	for _, prop := range e.collectConfigurationPairs("property") {
+		if resourcePlaceholderRegexp.MatchString(prop.Value) {
+			// resolved from the referenced resources by computeResolvedProperties
+			continue
+		}
 		// properties in resource configuration are expected to be pre-encoded using properties format
 		userProperties += fmt.Sprintf("%s=%s\n", prop.Name, prop.Value)
 	}
@@ -297,6 +343,10 @@ func (t *camelTrait) computeUserProperties(e *Environment) []ctrl.Object {
 
 	for _, prop := range t.Properties {
 		k, v := property.SplitPropertyFileEntry(prop)
+		if resourcePlaceholderRegexp.MatchString(v) {
+			// resolved from the referenced resources by computeResolvedProperties
+			continue
+		}
 		userProperties += fmt.Sprintf("%s=%s\n", k, v)
 	}
 
@@ -325,3 +375,234 @@ func (t *camelTrait) computeUserProperties(e *Environment) []ctrl.Object {
 
 	return maps
 }
+
+// computeResolvedProperties resolves the properties that reference the content of ConfigMaps and Secrets,
+// with the {{configmap:name/key}} and {{secret:name/key}} placeholders, optionally followed by a default value,
+// e.g. {{secret:name/key:default}}. The resolved properties are stored into a Secret, mounted in the runtime
+// configuration directory, along with the references, so that the integration is rolled out when they change.
+// The properties opted in for hot-reload with the mount trait are stored into a distinct Secret instead, that
+// is reloaded by the running Camel context, without rolling out the integration.
+func (t *camelTrait) computeResolvedProperties(e *Environment) error {
+	properties := make(map[string]string)
+	for _, prop := range e.collectConfigurationPairs("property") {
+		properties[prop.Name] = prop.Value
+	}
+	for _, prop := range t.Properties {
+		k, v := property.SplitPropertyFileEntry(prop)
+		properties[k] = v
+	}
+
+	var reloadable []string
+	if m, ok := e.Catalog.GetTrait("mount").(*mountTrait); ok {
+		reloadable = m.hotReloadProperties()
+	}
+
+	keys := make([]string, 0, len(properties))
+	reloadableKeys := make([]string, 0)
+	for k, v := range properties {
+		if !resourcePlaceholderRegexp.MatchString(v) {
+			continue
+		}
+		if util.StringSliceExists(reloadable, k) {
+			reloadableKeys = append(reloadableKeys, k)
+		} else {
+			keys = append(keys, k)
+		}
+	}
+
+	if len(keys) > 0 {
+		content, references, err := t.resolveProperties(e, properties, keys)
+		if err != nil {
+			return err
+		}
+		e.Resources.Add(newResolvedPropertiesSecret(e, e.Integration.Name+"-resolved-properties",
+			resolvedPropertiesType, content, references))
+
+		// Roll out the integration pods when the referenced resources change
+		hash := sha256.Sum256([]byte(content))
+		digest := base64.RawURLEncoding.EncodeToString(hash[:])
+		e.PostProcessors = append(e.PostProcessors, func(env *Environment) error {
+			env.Resources.VisitPodTemplateMeta(func(meta *metav1.ObjectMeta) {
+				if meta.Annotations == nil {
+					meta.Annotations = make(map[string]string)
+				}
+				meta.Annotations[resolvedPropertiesDigestAnnotation] = digest
+			})
+			return nil
+		})
+	}
+
+	if len(reloadableKeys) > 0 {
+		content, references, err := t.resolveProperties(e, properties, reloadableKeys)
+		if err != nil {
+			return err
+		}
+		e.Resources.Add(newResolvedPropertiesSecret(e, e.Integration.Name+"-reloadable-properties",
+			reloadablePropertiesType, content, references))
+
+		// The Secret is mounted as a directory by the mount trait, as the files mounted with a sub-path
+		// are not updated by the kubelet, and watched by the Camel context for changes
+		e.ApplicationProperties["camel.main.routes-reload-enabled"] = True
+		e.ApplicationProperties["camel.main.routes-reload-directory"] = reloadablePropertiesMountPath
+		e.ApplicationProperties["camel.main.routes-reload-pattern"] = "*.properties"
+	}
+
+	return nil
+}
+
+// computeContentReferences rolls out the integration pods when the content of the ConfigMaps and Secrets the
+// sources and the resources of the integration are referenced from changes, as they are mounted with a sub-path,
+// that is not updated by the kubelet
+func (t *camelTrait) computeContentReferences(e *Environment) error {
+	referenced := false
+	for _, s := range e.Integration.Spec.Sources {
+		referenced = referenced || s.ContentRef != ""
+	}
+	for _, r := range e.Integration.Spec.Resources {
+		referenced = referenced || r.ContentRef != ""
+	}
+	if !referenced {
+		return nil
+	}
+
+	content := ""
+	sources, err := kubernetes.ResolveIntegrationSources(e.Ctx, e.Client, e.Integration, e.Resources)
+	if err != nil {
+		return err
+	}
+	for i, s := range e.Integration.Spec.Sources {
+		if s.ContentRef != "" {
+			content += fmt.Sprintf("%s/%s/%s\n%s\n", s.GetContentRefKind(), s.ContentRef, s.GetContentKey(), sources[i].Content)
+		}
+	}
+	resources, err := kubernetes.ResolveIntegrationResources(e.Ctx, e.Client, e.Integration, e.Resources)
+	if err != nil {
+		return err
+	}
+	for i, r := range e.Integration.Spec.Resources {
+		if r.ContentRef != "" {
+			content += fmt.Sprintf("%s/%s/%s\n%s\n", r.GetContentRefKind(), r.ContentRef, r.GetContentKey(), resources[i].Content)
+		}
+	}
+
+	hash := sha256.Sum256([]byte(content))
+	digest := base64.RawURLEncoding.EncodeToString(hash[:])
+	e.PostProcessors = append(e.PostProcessors, func(env *Environment) error {
+		env.Resources.VisitPodTemplateMeta(func(meta *metav1.ObjectMeta) {
+			if meta.Annotations == nil {
+				meta.Annotations = make(map[string]string)
+			}
+			meta.Annotations[contentReferencesDigestAnnotation] = digest
+		})
+		return nil
+	})
+
+	return nil
+}
+
+// resolveProperties returns the content of the properties file with the given keys resolved,
+// along with the sorted references of the ConfigMaps and Secrets they are resolved from
+func (t *camelTrait) resolveProperties(e *Environment, properties map[string]string, keys []string) (string, []string, error) {
+	sort.Strings(keys)
+
+	references := make([]string, 0)
+	content := ""
+	for _, k := range keys {
+		value, err := t.resolveResourcePlaceholders(e, properties[k], &references)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "unable to resolve property %s", k)
+		}
+		content += fmt.Sprintf("%s=%s\n", k, value)
+	}
+	sort.Strings(references)
+
+	return content, references, nil
+}
+
+func newResolvedPropertiesSecret(e *Environment, name string, propertiesType string, content string, references []string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel:                e.Integration.Name,
+				"camel.apache.org/properties.type": propertiesType,
+			},
+			Annotations: map[string]string{
+				v1.PropertyReferencesAnnotation: strings.Join(references, ","),
+			},
+		},
+		Data: map[string][]byte{
+			"application.properties": []byte(content),
+		},
+	}
+}
+
+// resolveResourcePlaceholders replaces the resource placeholders of the given encoded property value
+// with the content of the referenced ConfigMap or Secret keys, that are added to the references
+func (t *camelTrait) resolveResourcePlaceholders(e *Environment, value string, references *[]string) (string, error) {
+	var err error
+	resolved := resourcePlaceholderRegexp.ReplaceAllStringFunc(value, func(placeholder string) string {
+		if err != nil {
+			return placeholder
+		}
+		match := resourcePlaceholderRegexp.FindStringSubmatch(placeholder)
+		kind, name, key := match[1], match[2], match[3]
+		util.StringSliceUniqueAdd(references, kind+"/"+name)
+
+		var data string
+		var found bool
+		data, found, err = t.lookupResourceKey(e, kind, name, key)
+		if err != nil {
+			return placeholder
+		}
+		if !found {
+			if !strings.HasPrefix(match[4], ":") {
+				err = fmt.Errorf("key %s not found in %s %s", key, kind, name)
+				return placeholder
+			}
+			return strings.TrimPrefix(match[4], ":")
+		}
+		return propertyValueEscaper.Replace(data)
+	})
+
+	return resolved, err
+}
+
+func (t *camelTrait) lookupResourceKey(e *Environment, kind string, name string, key string) (string, bool, error) {
+	objectKey := ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: name}
+
+	switch kind {
+	case "configmap":
+		cm := corev1.ConfigMap{}
+		if err := e.Client.Get(e.Ctx, objectKey, &cm); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return "", false, nil
+			}
+			return "", false, err
+		}
+		if v, ok := cm.Data[key]; ok {
+			return v, true, nil
+		}
+		if v, ok := cm.BinaryData[key]; ok {
+			return string(v), true, nil
+		}
+	case "secret":
+		secret := corev1.Secret{}
+		if err := e.Client.Get(e.Ctx, objectKey, &secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return "", false, nil
+			}
+			return "", false, err
+		}
+		if v, ok := secret.Data[key]; ok {
+			return string(v), true, nil
+		}
+	}
+
+	return "", false, nil
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -70,6 +71,29 @@ func TestApplyCamelTraitWithoutEnvironmentCatalogAndUnmatchableVersionFails(t *t
 	assert.Equal(t, "unable to find catalog matching version requirement: runtime=Unmatchable version, provider=quarkus", err.Error())
 }
 
+func TestApplyCamelTraitWithInvalidCatalogFails(t *testing.T) {
+	trait, environment := createNominalCamelTest()
+	catalog := v1.NewCamelCatalogWithSpecs("namespace", "camel-catalog-1.8.0-acme-quarkus", v1.CamelCatalogSpec{
+		Runtime: v1.RuntimeSpec{
+			Version:  "1.8.0-acme",
+			Provider: v1.RuntimeProviderQuarkus,
+			Metadata: map[string]string{
+				"camel-quarkus.version": "2.2.0",
+				"quarkus.version":       "2.2.0.Final",
+			},
+		},
+	})
+	client, err := test.NewFakeClient(&catalog)
+	assert.Nil(t, err)
+	environment.Client = client
+	environment.CamelCatalog = nil
+	environment.Integration.Status.RuntimeVersion = "1.8.0-acme"
+
+	err = trait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid catalog runtime=1.8.0-acme, provider=quarkus: runtime application class is missing", err.Error())
+}
+
 func createNominalCamelTest() (*camelTrait, *Environment) {
 	client, _ := test.NewFakeClient()
 
@@ -131,3 +155,197 @@ func TestApplyCamelTraitWithProperties(t *testing.T) {
 		"application.properties": "a=b\nc=d\n",
 	}, userPropertiesCm.Data)
 }
+
+func TestApplyCamelTraitWithResolvedProperties(t *testing.T) {
+	camelTrait, environment := createNominalCamelTest()
+	environment.Integration.Name = "my-integration"
+	client, err := test.NewFakeClient(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "db"},
+			Data:       map[string]string{"host": "postgres"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "db-credentials"},
+			Data:       map[string][]byte{"password": []byte("pa\\ss\nword")},
+		},
+	)
+	assert.Nil(t, err)
+	environment.Client = client
+
+	camelTrait.Properties = []string{
+		"a=b",
+		"db.url=jdbc:postgresql://{{configmap:db/host}}:{{configmap:db/port:5432}}/db",
+		"db.password={{secret:db-credentials/password}}",
+	}
+	err = camelTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	userPropertiesCm := environment.Resources.GetConfigMap(func(cm *corev1.ConfigMap) bool {
+		return cm.Labels["camel.apache.org/properties.type"] == "user"
+	})
+	assert.NotNil(t, userPropertiesCm)
+	assert.Equal(t, "a=b\n", userPropertiesCm.Data["application.properties"])
+
+	var resolved *corev1.Secret
+	environment.Resources.VisitSecret(func(secret *corev1.Secret) {
+		resolved = secret
+	})
+	assert.NotNil(t, resolved)
+	assert.Equal(t, "my-integration-resolved-properties", resolved.Name)
+	assert.Equal(t, "configmap/db,secret/db-credentials", resolved.Annotations[v1.PropertyReferencesAnnotation])
+	assert.Equal(t, "db.password=pa\\\\ss\\nword\ndb.url=jdbc:postgresql://postgres:5432/db\n",
+		string(resolved.Data["application.properties"]))
+	assert.Len(t, environment.PostProcessors, 1)
+
+	volumes := make([]corev1.Volume, 0)
+	mounts := make([]corev1.VolumeMount, 0)
+	environment.configureVolumesAndMounts(&volumes, &mounts)
+	assert.Contains(t, mounts, corev1.VolumeMount{
+		Name:      "resolved-properties",
+		MountPath: "/etc/camel/conf.d/resolved.properties",
+		ReadOnly:  true,
+		SubPath:   "resolved.properties",
+	})
+}
+
+func TestApplyCamelTraitWithReloadableProperties(t *testing.T) {
+	camelTrait, environment := createNominalCamelTest()
+	environment.Integration.Name = "my-integration"
+	client, err := test.NewFakeClient(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "tuning"},
+			Data:       map[string]string{"period": "1000", "level": "DEBUG"},
+		},
+	)
+	assert.Nil(t, err)
+	environment.Client = client
+	mountTrait, ok := environment.Catalog.GetTrait("mount").(*mountTrait)
+	assert.True(t, ok)
+	mountTrait.HotReload = []string{"timer.period", "not.resolved"}
+
+	camelTrait.Properties = []string{
+		"not.resolved=value",
+		"timer.period={{configmap:tuning/period}}",
+		"quarkus.log.level={{configmap:tuning/level}}",
+	}
+	err = camelTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	secrets := make(map[string]*corev1.Secret)
+	environment.Resources.VisitSecret(func(secret *corev1.Secret) {
+		secrets[secret.Labels["camel.apache.org/properties.type"]] = secret
+	})
+	assert.Len(t, secrets, 2)
+	assert.Equal(t, "my-integration-resolved-properties", secrets["resolved"].Name)
+	assert.Equal(t, "quarkus.log.level=DEBUG\n", string(secrets["resolved"].Data["application.properties"]))
+	assert.Equal(t, "my-integration-reloadable-properties", secrets["reloadable"].Name)
+	assert.Equal(t, "configmap/tuning", secrets["reloadable"].Annotations[v1.PropertyReferencesAnnotation])
+	assert.Equal(t, "timer.period=1000\n", string(secrets["reloadable"].Data["application.properties"]))
+	assert.Len(t, environment.PostProcessors, 1)
+
+	assert.Equal(t, "true", environment.ApplicationProperties["camel.main.routes-reload-enabled"])
+	assert.Equal(t, "/etc/camel/conf.d/_reloadable", environment.ApplicationProperties["camel.main.routes-reload-directory"])
+	assert.Equal(t, "*.properties", environment.ApplicationProperties["camel.main.routes-reload-pattern"])
+
+	// The reloadable properties are mounted by the mount trait
+	volumes := make([]corev1.Volume, 0)
+	mounts := make([]corev1.VolumeMount, 0)
+	environment.configureVolumesAndMounts(&volumes, &mounts)
+	for _, m := range mounts {
+		assert.NotEqual(t, "reloadable-properties", m.Name)
+	}
+}
+
+func TestApplyCamelTraitWithMissingResolvedPropertyFails(t *testing.T) {
+	camelTrait, environment := createNominalCamelTest()
+	camelTrait.Properties = []string{"db.password={{secret:db-credentials/password}}"}
+
+	err := camelTrait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "unable to resolve property db.password: key password not found in secret db-credentials", err.Error())
+}
+
+func TestApplyCamelTraitWithContentReferences(t *testing.T) {
+	camelTrait, environment := createNominalCamelTest()
+	environment.Integration.Name = "my-integration"
+	environment.Integration.Spec.Sources = []v1.SourceSpec{
+		{DataSpec: v1.DataSpec{Name: "routes.yaml", ContentRef: "routes", ContentKey: "routes.yaml"}},
+		{DataSpec: v1.DataSpec{Name: "secured.yaml", ContentRef: "routes", ContentKey: "secured.yaml", ContentRefKind: v1.ContentRefKindSecret}},
+	}
+	routes := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "routes"},
+		Data:       map[string]string{"routes.yaml": "- from:\n    uri: timer:tick"},
+	}
+	client, err := test.NewFakeClient(
+		routes,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "routes"},
+			Data:       map[string][]byte{"secured.yaml": []byte("- from:\n    uri: timer:secured")},
+		},
+	)
+	assert.Nil(t, err)
+	environment.Client = client
+
+	digest := func() string {
+		environment.Resources = kubernetes.NewCollection()
+		environment.PostProcessors = nil
+		assert.Nil(t, camelTrait.Apply(environment))
+		assert.Len(t, environment.PostProcessors, 1)
+
+		meta := metav1.ObjectMeta{}
+		environment.Resources.Add(&appsv1.Deployment{})
+		assert.Nil(t, environment.PostProcessors[0](environment))
+		environment.Resources.VisitPodTemplateMeta(func(m *metav1.ObjectMeta) {
+			meta = *m
+		})
+		return meta.Annotations[contentReferencesDigestAnnotation]
+	}
+
+	initial := digest()
+	assert.NotEmpty(t, initial)
+	assert.Equal(t, initial, digest())
+
+	// The pods are rolled out when the referenced content changes
+	routes.Data["routes.yaml"] = "- from:\n    uri: timer:tock"
+	assert.Nil(t, client.Update(context.TODO(), routes))
+	assert.NotEqual(t, initial, digest())
+
+	volumes := make([]corev1.Volume, 0)
+	mounts := make([]corev1.VolumeMount, 0)
+	environment.configureVolumesAndMounts(&volumes, &mounts)
+	assert.Contains(t, volumes, corev1.Volume{
+		Name: "i-source-000",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "routes"},
+				Items:                []corev1.KeyToPath{{Key: "routes.yaml", Path: "routes.yaml"}},
+			},
+		},
+	})
+	assert.Contains(t, volumes, corev1.Volume{
+		Name: "i-source-001",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "routes",
+				Items:      []corev1.KeyToPath{{Key: "secured.yaml", Path: "secured.yaml"}},
+			},
+		},
+	})
+}
+
+func TestApplyCamelTraitWithMissingContentKeyFails(t *testing.T) {
+	camelTrait, environment := createNominalCamelTest()
+	environment.Integration.Spec.Sources = []v1.SourceSpec{
+		{DataSpec: v1.DataSpec{Name: "routes.yaml", ContentRef: "routes"}},
+	}
+	client, err := test.NewFakeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "routes"},
+		Data:       map[string]string{"routes.yaml": "- from:\n    uri: timer:tick"},
+	})
+	assert.Nil(t, err)
+	environment.Client = client
+
+	err = camelTrait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "unable to find key content in ConfigMap routes", err.Error())
+}
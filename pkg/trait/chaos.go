@@ -0,0 +1,333 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// The Chaos trait injects faults into the integration, to test its resilience in development or staging environments:
+//
+// * the integration container is randomly killed, so that it's restarted, with the `kill-probability` option,
+// * the latency of the connections to the services the integration depends on is increased, with the `proxies`
+// and `latency` options, that run a Toxiproxy sidecar container, the integration connects to instead of the services,
+// * the exchanges randomly fail with an exception, with the `exception-probability` option, that's exposed
+// to the integration as the `camel.k.chaos.exceptionProbability` property, so that it can be tuned at runtime.
+//
+// The trait is rejected for the integrations running in production, i.e. when the integration or the integration
+// platform is labeled with `camel.apache.org/environment=production`.
+//
+// The kills and the proxies are skipped for the integrations run as a CronJob, whose jobs would never complete
+// with the sidecar containers.
+//
+// The sidecar container finds the integration process from its command line, that's readable by all the users,
+// and runs as the same user as the integration container, so that it's allowed to kill it without any additional
+// capability, e.g., with the restricted security context constraints or Pod security standard.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=chaos
+type chaosTrait struct {
+	BaseTrait `property:",squash"`
+	// The probability, in percent, that the integration container is killed at each interval.
+	KillProbability int `property:"kill-probability" json:"killProbability,omitempty"`
+	// The interval, in seconds, between the kill attempts (default `60`).
+	Interval int `property:"interval" json:"interval,omitempty"`
+	// The services proxied by the Toxiproxy sidecar, in the form `port=host:port`, e.g. `5432=my-database:5432`.
+	// The integration must connect to the proxied services at `localhost:<port>`.
+	Proxies []string `property:"proxies" json:"proxies,omitempty"`
+	// The latency, in milliseconds, added to the proxied connections.
+	Latency int `property:"latency" json:"latency,omitempty"`
+	// The jitter, in milliseconds, of the latency added to the proxied connections.
+	Jitter int `property:"jitter" json:"jitter,omitempty"`
+	// The probability, in percent, that an exchange fails with an exception.
+	ExceptionProbability int `property:"exception-probability" json:"exceptionProbability,omitempty"`
+	// The image of the sidecar container, that kills the integration container and configures the proxies,
+	// and that must provide the `kill`, `grep`, `tr`, `od` and `wget` commands (default `busybox`).
+	Image string `property:"image" json:"image,omitempty"`
+	// The image of the Toxiproxy sidecar container.
+	ProxyImage string `property:"proxy-image" json:"proxyImage,omitempty"`
+}
+
+const (
+	chaosEnvironmentLabel        = "camel.apache.org/environment"
+	chaosProductionEnvironment   = "production"
+	chaosExceptionProbabilityKey = "camel.k.chaos.exceptionProbability"
+	chaosSourceName              = "camel-k-embedded-chaos.yaml"
+	chaosContainerName           = "chaos"
+	chaosProxyContainerName      = "chaos-proxy"
+	defaultChaosImage            = "docker.io/library/busybox:1.34"
+	defaultChaosProxyImage       = "ghcr.io/shopify/toxiproxy:2.4.0"
+	defaultChaosInterval         = 60
+	chaosProxyAPI                = "http://localhost:8474"
+	chaosExceptionType           = "java.lang.IllegalStateException"
+	chaosExceptionMessage        = "Exception injected by the chaos trait"
+	// The system property marking the command line of the integration processes, whether they're run
+	// with the JVM or as a native executable
+	chaosTargetProperty = "-Dcamel.k.chaos.target"
+)
+
+// chaosProcDir is the directory the sidecar container lists the processes of the pod from
+var chaosProcDir = "/proc"
+
+func newChaosTrait() Trait {
+	return &chaosTrait{
+		BaseTrait: NewBaseTrait("chaos", 1630),
+	}
+}
+
+func (t *chaosTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.isProduction(e) {
+		return false, fmt.Errorf("the chaos trait cannot be enabled for integration %s, that runs in production", e.Integration.Name)
+	}
+
+	for name, p := range map[string]int{"kill": t.KillProbability, "exception": t.ExceptionProbability} {
+		if p < 0 || p > 100 {
+			return false, fmt.Errorf("invalid %s probability: %d, must be between 0 and 100", name, p)
+		}
+	}
+	if t.Interval < 0 || t.Latency < 0 || t.Jitter < 0 {
+		return false, fmt.Errorf("the chaos interval, latency and jitter must be positive")
+	}
+	if _, err := t.getProxies(); err != nil {
+		return false, err
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization) || e.IntegrationInRunningPhases(), nil
+}
+
+func (t *chaosTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		if t.ExceptionProbability > 0 {
+			return t.addExceptionsSource(e)
+		}
+		return nil
+	}
+
+	if t.ExceptionProbability > 0 {
+		e.ApplicationProperties[chaosExceptionProbabilityKey] = strconv.Itoa(t.ExceptionProbability)
+	}
+
+	proxies, err := t.getProxies()
+	if err != nil {
+		return err
+	}
+	if t.KillProbability == 0 && len(proxies) == 0 {
+		return nil
+	}
+
+	strategy, err := e.DetermineControllerStrategy()
+	if err != nil {
+		return err
+	}
+	if strategy == ControllerStrategyCronJob {
+		t.L.Infof("Skipping the chaos kills and proxies of integration %s, that runs as a CronJob", e.Integration.Name)
+		return nil
+	}
+
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("unable to find the pod spec of integration %s", e.Integration.Name)
+	}
+
+	// The processes can only be killed by the same user, without the CAP_KILL capability
+	var securityContext *corev1.SecurityContext
+	if t.KillProbability > 0 {
+		container := e.getIntegrationContainer()
+		if container == nil {
+			return fmt.Errorf("unable to find integration container: %s", e.Integration.Name)
+		}
+		// The sidecar container must see the integration processes to kill them, that are marked with
+		// a system property, as the arguments contributed before the jvm and quarkus traits are kept
+		podSpec.ShareProcessNamespace = BoolP(true)
+		target := chaosTargetProperty + "=" + e.Integration.Name
+		if !util.StringSliceExists(container.Args, target) {
+			container.Args = append(container.Args, target)
+		}
+		securityContext = container.SecurityContext
+	}
+	if len(proxies) > 0 {
+		image := t.ProxyImage
+		if image == "" {
+			image = defaultChaosProxyImage
+		}
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{
+			Name:  chaosProxyContainerName,
+			Image: image,
+		})
+	}
+
+	image := t.Image
+	if image == "" {
+		image = defaultChaosImage
+	}
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:            chaosContainerName,
+		Image:           image,
+		Command:         []string{"/bin/sh", "-c", t.script(proxies)},
+		SecurityContext: securityContext.DeepCopy(),
+	})
+
+	return nil
+}
+
+// isProduction returns whether the integration, or the integration platform, is labeled as running in production
+func (t *chaosTrait) isProduction(e *Environment) bool {
+	labels := []map[string]string{e.Integration.Labels}
+	if e.Platform != nil {
+		labels = append(labels, e.Platform.Labels)
+	}
+	for _, l := range labels {
+		if strings.EqualFold(l[chaosEnvironmentLabel], chaosProductionEnvironment) {
+			return true
+		}
+	}
+	return false
+}
+
+type chaosProxy struct {
+	port     int
+	upstream string
+}
+
+// getProxies parses the proxies in the form port=host:port
+func (t *chaosTrait) getProxies() ([]chaosProxy, error) {
+	proxies := make([]chaosProxy, 0, len(t.Proxies))
+	for _, p := range t.Proxies {
+		port, upstream, _ := splitOnce(p, "=")
+		n, err := strconv.Atoi(port)
+		if err != nil || n <= 0 || n > 65535 {
+			return nil, fmt.Errorf("invalid chaos proxy: %s, must be port=host:port", p)
+		}
+		if _, _, err := net.SplitHostPort(upstream); err != nil {
+			return nil, fmt.Errorf("invalid chaos proxy: %s, must be port=host:port", p)
+		}
+		proxies = append(proxies, chaosProxy{port: n, upstream: upstream})
+	}
+	return proxies, nil
+}
+
+// script returns the script of the sidecar container, that configures the proxies with the Toxiproxy API,
+// then kills the integration process at random
+func (t *chaosTrait) script(proxies []chaosProxy) string {
+	post := func(url string, data string) string {
+		return fmt.Sprintf("wget -q -O /dev/null --header 'Content-Type: application/json' --post-data '%s' %s", data, url)
+	}
+
+	var sb strings.Builder
+	if len(proxies) > 0 {
+		sb.WriteString(fmt.Sprintf("until wget -q -O /dev/null %s/version; do sleep 1; done\n", chaosProxyAPI))
+		for _, p := range proxies {
+			name := fmt.Sprintf("proxy-%d", p.port)
+			sb.WriteString(post(chaosProxyAPI+"/proxies",
+				fmt.Sprintf(`{"name":"%s","listen":"127.0.0.1:%d","upstream":"%s"}`, name, p.port, p.upstream)))
+			sb.WriteString("\n")
+			if t.Latency > 0 {
+				sb.WriteString(post(fmt.Sprintf("%s/proxies/%s/toxics", chaosProxyAPI, name),
+					fmt.Sprintf(`{"type":"latency","attributes":{"latency":%d,"jitter":%d}}`, t.Latency, t.Jitter)))
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	interval := t.Interval
+	if interval == 0 {
+		interval = defaultChaosInterval
+	}
+	sb.WriteString("while true; do\n")
+	sb.WriteString(fmt.Sprintf("  sleep %d\n", interval))
+	if t.KillProbability > 0 {
+		sb.WriteString(fmt.Sprintf("  if [ $(( $(od -An -N2 -tu2 /dev/urandom) %% 100 )) -lt %d ]; then\n", t.KillProbability))
+		sb.WriteString("    echo \"Killing the integration\"\n")
+		sb.WriteString(killScript())
+		sb.WriteString("  fi\n")
+	}
+	sb.WriteString("done")
+
+	return sb.String()
+}
+
+// killScript returns the script that kills the processes whose command line is marked as the integration one
+func killScript() string {
+	var sb strings.Builder
+	sb.WriteString("    killed=0\n")
+	sb.WriteString(fmt.Sprintf("    for p in %s/[0-9]*; do\n", chaosProcDir))
+	sb.WriteString(fmt.Sprintf("      if tr '\\0' '\\n' 2>/dev/null < $p/cmdline | grep -q -e '^%s='; then kill ${p##*/} && killed=$((killed + 1)); fi\n", chaosTargetProperty))
+	sb.WriteString("    done\n")
+	sb.WriteString("    if [ $killed -eq 0 ]; then echo \"No integration process found\"; fi\n")
+	return sb.String()
+}
+
+// addExceptionsSource adds the source that randomly throws an exception from the exchanges of all the routes
+func (t *chaosTrait) addExceptionsSource(e *Environment) error {
+	flow := map[string]interface{}{
+		"intercept-from": map[string]interface{}{
+			"steps": []map[string]interface{}{
+				{
+					"filter": map[string]interface{}{
+						"simple": fmt.Sprintf("${random(100)} < {{%s}}", chaosExceptionProbabilityKey),
+						"steps": []map[string]interface{}{
+							{
+								"throw-exception": map[string]string{
+									"exception-type": chaosExceptionType,
+									"message":        chaosExceptionMessage,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	content, err := yaml.Marshal([]map[string]interface{}{flow})
+	if err != nil {
+		return err
+	}
+	source := v1.SourceSpec{
+		DataSpec: v1.DataSpec{
+			Name:    chaosSourceName,
+			Content: string(content),
+		},
+		Language: v1.LanguageYaml,
+	}
+	e.Integration.Status.AddOrReplaceGeneratedSources(source)
+
+	// The dependencies trait has already been executed
+	if e.CamelCatalog != nil {
+		AddSourceDependencies(source, e.CamelCatalog).Each(func(dependency string) bool {
+			util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, dependency)
+			return true
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+func TestConfigureChaosTraitDisabledByDefault(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	chaosTrait := newChaosTrait().(*chaosTrait)
+
+	configured, err := chaosTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureChaosTraitInvalidConfiguration(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+
+	chaosTrait := createNominalChaosTrait()
+	chaosTrait.KillProbability = 120
+	_, err := chaosTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	chaosTrait = createNominalChaosTrait()
+	chaosTrait.Latency = -1
+	_, err = chaosTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	chaosTrait = createNominalChaosTrait()
+	chaosTrait.Proxies = []string{"my-database:5432"}
+	_, err = chaosTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestConfigureChaosTraitInProduction(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	chaosTrait := createNominalChaosTrait()
+
+	environment.Integration.Labels = map[string]string{chaosEnvironmentLabel: "production"}
+	_, err := chaosTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	environment.Integration.Labels = nil
+	environment.Platform = &v1.IntegrationPlatform{}
+	environment.Platform.Labels = map[string]string{chaosEnvironmentLabel: "Production"}
+	_, err = chaosTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	environment.Platform.Labels = map[string]string{chaosEnvironmentLabel: "staging"}
+	configured, err := chaosTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+}
+
+func TestApplyChaosTraitWithKillsAndProxies(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	environment.Catalog = NewCatalog(nil)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: defaultContainerName},
+	}
+	chaosTrait := createNominalChaosTrait()
+	chaosTrait.KillProbability = 20
+	chaosTrait.Interval = 30
+	chaosTrait.Proxies = []string{"5432=my-database:5432"}
+	chaosTrait.Latency = 500
+	chaosTrait.Jitter = 100
+
+	err := chaosTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.True(t, *podSpec.ShareProcessNamespace)
+	proxy := findContainer(podSpec.Containers, chaosProxyContainerName)
+	assert.NotNil(t, proxy)
+	assert.Equal(t, defaultChaosProxyImage, proxy.Image)
+	chaos := findContainer(podSpec.Containers, chaosContainerName)
+	assert.NotNil(t, chaos)
+	assert.Equal(t, defaultChaosImage, chaos.Image)
+	script := chaos.Command[2]
+	assert.Contains(t, script, `{"name":"proxy-5432","listen":"127.0.0.1:5432","upstream":"my-database:5432"}`)
+	assert.Contains(t, script, `{"type":"latency","attributes":{"latency":500,"jitter":100}}`)
+	assert.Contains(t, script, "sleep 30")
+	assert.Contains(t, script, "-lt 20 ]")
+	assert.Contains(t, script, "< $p/cmdline | grep -q -e '^-Dcamel.k.chaos.target='; then kill ${p##*/}")
+	assert.NotContains(t, script, "environ")
+	integration := findContainer(podSpec.Containers, defaultContainerName)
+	assert.NotNil(t, integration)
+	assert.Equal(t, []string{"-Dcamel.k.chaos.target=integration-name"}, integration.Args)
+	assert.Empty(t, integration.Env)
+}
+
+func TestApplyChaosTraitRunsAsIntegrationUser(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	environment.Catalog = NewCatalog(nil)
+	user := int64(1000680000)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: defaultContainerName, SecurityContext: &corev1.SecurityContext{RunAsUser: &user}},
+	}
+	chaosTrait := createNominalChaosTrait()
+	chaosTrait.KillProbability = 20
+
+	assert.Nil(t, chaosTrait.Apply(environment))
+	chaos := findContainer(deployment.Spec.Template.Spec.Containers, chaosContainerName)
+	assert.NotNil(t, chaos)
+	assert.Equal(t, &user, chaos.SecurityContext.RunAsUser)
+}
+
+func TestChaosKillScript(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+
+	defer func(dir string) {
+		chaosProcDir = dir
+	}(chaosProcDir)
+	chaosProcDir = t.TempDir()
+
+	process := func(pid string, args ...string) {
+		assert.Nil(t, os.Mkdir(path.Join(chaosProcDir, pid), 0o700))
+		assert.Nil(t, ioutil.WriteFile(path.Join(chaosProcDir, pid, "cmdline"), []byte(strings.Join(args, "\x00")+"\x00"), 0o600))
+	}
+	run := func() string {
+		// The kill builtin is replaced with a function, so that no process is actually killed
+		out, err := exec.Command(sh, "-c", "kill() { echo \"kill $1\"; }\n"+killScript()).CombinedOutput()
+		assert.Nil(t, err)
+		return string(out)
+	}
+
+	process("1", "/pause")
+	process("7", "/bin/sh", "-c", "while true; do grep -q -e '^-Dcamel.k.chaos.target='; done")
+	assert.Equal(t, "No integration process found\n", run())
+
+	process("12", "java", "-Dcamel.k.chaos.target=integration-name", "-cp", "./resources", "io.quarkus.bootstrap.runner.QuarkusEntryPoint")
+	assert.Equal(t, "kill 12\n", run())
+}
+
+func TestApplyChaosTraitWithCronJob(t *testing.T) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	environment.ConfiguredTraits = []Trait{&deployerTrait{Kind: string(ControllerStrategyCronJob)}}
+	chaosTrait := createNominalChaosTrait()
+	chaosTrait.KillProbability = 20
+	chaosTrait.Proxies = []string{"5432=my-database:5432"}
+
+	err := chaosTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Nil(t, podSpec.ShareProcessNamespace)
+	assert.Nil(t, findContainer(podSpec.Containers, chaosContainerName))
+	assert.Nil(t, findContainer(podSpec.Containers, chaosProxyContainerName))
+}
+
+func TestApplyChaosTraitWithExceptions(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	environment.CamelCatalog = catalog
+	environment.ApplicationProperties = make(map[string]string)
+	chaosTrait := createNominalChaosTrait()
+	chaosTrait.ExceptionProbability = 5
+
+	environment.Integration.Status.Phase = v1.IntegrationPhaseInitialization
+	configured, err := chaosTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+	err = chaosTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Len(t, environment.Integration.Status.GeneratedSources, 1)
+	source := environment.Integration.Status.GeneratedSources[0]
+	assert.Equal(t, chaosSourceName, source.Name)
+	assert.Contains(t, source.Content, "intercept-from:")
+	assert.Contains(t, source.Content, "${random(100)} < {{camel.k.chaos.exceptionProbability}}")
+	assert.Contains(t, source.Content, "exception-type: java.lang.IllegalStateException")
+
+	environment.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	err = chaosTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "5", environment.ApplicationProperties[chaosExceptionProbabilityKey])
+	assert.Nil(t, findContainer(deployment.Spec.Template.Spec.Containers, chaosContainerName))
+}
+
+func createNominalChaosTrait() *chaosTrait {
+	chaosTrait := newChaosTrait().(*chaosTrait)
+	chaosTrait.Enabled = BoolP(true)
+
+	return chaosTrait
+}
+
+func findContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
@@ -43,6 +43,13 @@ const (
 	defaultServicePort       = 80
 	defaultProbePath         = "/q/health"
 	containerTraitID         = "container"
+
+	containerPortProtocolHTTP = "http"
+	containerPortProtocolH2C  = "h2c"
+	containerPortProtocolGRPC = "grpc"
+
+	// knativeH2CPortName is the port name Knative requires to serve HTTP/2 over cleartext
+	knativeH2CPortName = "h2c"
 )
 
 // The Container trait can be used to configure properties of the container where the integration will run.
@@ -70,6 +77,12 @@ type containerTrait struct {
 	Port int `property:"port" json:"port,omitempty"`
 	// To configure a different port name for the port exposed by the container (default `http`).
 	PortName string `property:"port-name" json:"portName,omitempty"`
+	// The application protocol of the port exposed by the container: `http` (default), `h2c` for HTTP/2 over cleartext, or `grpc`.
+	//
+	// With `h2c` and `grpc`, the port is named `h2c` on Knative so that HTTP/2 is used end-to-end,
+	// and the Service port declares the matching application protocol.
+	// With `grpc`, the probes check that the port accepts TCP connections.
+	PortProtocol string `property:"port-protocol" json:"portProtocol,omitempty"`
 	// To configure under which service port the container port is to be exposed (default `80`).
 	ServicePort int `property:"service-port" json:"servicePort,omitempty"`
 	// To configure under which service port name the container port is to be exposed (default `http`).
@@ -110,11 +123,54 @@ type containerTrait struct {
 	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
 	// Applies to the readiness probe.
 	ReadinessFailureThreshold int32 `property:"readiness-failure-threshold" json:"readinessFailureThreshold,omitempty"`
+	// The path of the liveness probe (default `/q/health`).
+	LivenessPath string `property:"liveness-path" json:"livenessPath,omitempty"`
+	// The port of the liveness probe, that defaults to the container port.
+	// It's ignored on Knative, that only allows probing the container port.
+	LivenessPort int `property:"liveness-port" json:"livenessPort,omitempty"`
+	// The path of the readiness probe (default `/q/health`).
+	ReadinessPath string `property:"readiness-path" json:"readinessPath,omitempty"`
+	// The port of the readiness probe, that defaults to the container port.
+	// It's ignored on Knative, that only allows probing the container port.
+	ReadinessPort int `property:"readiness-port" json:"readinessPort,omitempty"`
+	// Enable/disable the startup probe, that holds off the liveness and readiness probes until the integration
+	// has started, when probes are enabled (default `false`). It's not supported on Knative.
+	StartupProbeEnabled *bool `property:"startup-probe-enabled" json:"startupProbeEnabled,omitempty"`
+	// Scheme to use when connecting. Defaults to HTTP. Applies to the startup probe.
+	StartupScheme string `property:"startup-scheme" json:"startupScheme,omitempty"`
+	// The path of the startup probe (default `/q/health`).
+	StartupPath string `property:"startup-path" json:"startupPath,omitempty"`
+	// The port of the startup probe, that defaults to the container port.
+	StartupPort int `property:"startup-port" json:"startupPort,omitempty"`
+	// Number of seconds after the container has started before startup probes are initiated.
+	StartupInitialDelay int32 `property:"startup-initial-delay" json:"startupInitialDelay,omitempty"`
+	// Number of seconds after which the probe times out. Applies to the startup probe.
+	StartupTimeout int32 `property:"startup-timeout" json:"startupTimeout,omitempty"`
+	// How often to perform the probe. Applies to the startup probe.
+	StartupPeriod int32 `property:"startup-period" json:"startupPeriod,omitempty"`
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Applies to the startup probe.
+	StartupSuccessThreshold int32 `property:"startup-success-threshold" json:"startupSuccessThreshold,omitempty"`
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// Applies to the startup probe, and can be increased to give slow starting integrations more time to start.
+	StartupFailureThreshold int32 `property:"startup-failure-threshold" json:"startupFailureThreshold,omitempty"`
+}
+
+// probe is the configuration of one of the container probes
+type probe struct {
+	scheme           string
+	path             string
+	port             int
+	initialDelay     int32
+	timeout          int32
+	period           int32
+	successThreshold int32
+	failureThreshold int32
 }
 
 func newContainerTrait() Trait {
 	return &containerTrait{
-		BaseTrait:       NewBaseTrait(containerTraitID, 1600),
+		BaseTrait:       NewBaseTrait(containerTraitID, 1600, ExecuteAfter("cron", "deployment", "knative-service", serviceTraitID)),
 		Port:            defaultContainerPort,
 		ServicePort:     defaultServicePort,
 		ServicePortName: defaultContainerPortName,
@@ -122,6 +178,7 @@ func newContainerTrait() Trait {
 		ProbesEnabled:   BoolP(false),
 		LivenessScheme:  string(corev1.URISchemeHTTP),
 		ReadinessScheme: string(corev1.URISchemeHTTP),
+		StartupScheme:   string(corev1.URISchemeHTTP),
 	}
 }
 
@@ -145,9 +202,22 @@ func (t *containerTrait) Configure(e *Environment) (bool, error) {
 		return false, fmt.Errorf("unsupported pull policy %s", t.ImagePullPolicy)
 	}
 
+	if !isValidPortProtocol(t.PortProtocol) {
+		return false, fmt.Errorf("unsupported port protocol %s", t.PortProtocol)
+	}
+
 	return true, nil
 }
 
+func isValidPortProtocol(protocol string) bool {
+	return protocol == "" || protocol == containerPortProtocolHTTP || protocol == containerPortProtocolH2C || protocol == containerPortProtocolGRPC
+}
+
+// isHTTP2 returns true if the container port serves HTTP/2 over cleartext, that's the case for gRPC
+func (t *containerTrait) isHTTP2() bool {
+	return t.PortProtocol == containerPortProtocolH2C || t.PortProtocol == containerPortProtocolGRPC
+}
+
 func isValidPullPolicy(policy corev1.PullPolicy) bool {
 	return policy == "" || policy == corev1.PullAlways || policy == corev1.PullIfNotPresent || policy == corev1.PullNever
 }
@@ -229,9 +299,10 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 	}
 
 	container := corev1.Container{
-		Name:  t.Name,
-		Image: e.Integration.Status.Image,
-		Env:   make([]corev1.EnvVar, 0),
+		Name:    t.Name,
+		Image:   e.Integration.Status.Image,
+		Env:     make([]corev1.EnvVar, 0),
+		EnvFrom: e.EnvFrom,
 	}
 
 	if t.ImagePullPolicy != "" {
@@ -262,8 +333,8 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 	}
 	// Deployment
 	if err := e.Resources.VisitDeploymentE(func(deployment *appsv1.Deployment) error {
-		if IsTrue(t.ProbesEnabled) && portName == defaultContainerPortName {
-			t.configureProbes(&container, t.Port, defaultProbePath)
+		if IsTrue(t.ProbesEnabled) && (portName == defaultContainerPortName || t.PortProtocol == containerPortProtocolGRPC) {
+			t.configureProbes(&container, false)
 		}
 
 		for _, envVar := range e.EnvVars {
@@ -287,11 +358,45 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 		return err
 	}
 
+	// StatefulSet
+	if err := e.Resources.VisitStatefulSetE(func(statefulSet *appsv1.StatefulSet) error {
+		if IsTrue(t.ProbesEnabled) && (portName == defaultContainerPortName || t.PortProtocol == containerPortProtocolGRPC) {
+			t.configureProbes(&container, false)
+		}
+
+		for _, envVar := range e.EnvVars {
+			envvar.SetVar(&container.Env, envVar)
+		}
+		if props, err := e.computeApplicationProperties(); err != nil {
+			return err
+		} else if props != nil {
+			e.Resources.Add(props)
+		}
+
+		e.configureVolumesAndMounts(
+			&statefulSet.Spec.Template.Spec.Volumes,
+			&container.VolumeMounts,
+		)
+
+		statefulSet.Spec.Template.Spec.Containers = append(statefulSet.Spec.Template.Spec.Containers, container)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
 	// Knative Service
 	if err := e.Resources.VisitKnativeServiceE(func(service *serving.Service) error {
-		if IsTrue(t.ProbesEnabled) && portName == defaultContainerPortName {
-			// don't set the port on Knative service as it is not allowed.
-			t.configureProbes(&container, 0, defaultProbePath)
+		if IsTrue(t.ProbesEnabled) && (portName == defaultContainerPortName || t.PortProtocol == containerPortProtocolGRPC) {
+			t.configureProbes(&container, true)
+		}
+
+		if t.isHTTP2() {
+			// Knative enables HTTP/2 for the port named h2c
+			container.Ports = append(container.Ports, corev1.ContainerPort{
+				Name:          knativeH2CPortName,
+				ContainerPort: int32(t.Port),
+			})
 		}
 
 		for _, env := range e.EnvVars {
@@ -328,8 +433,8 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 
 	// CronJob
 	if err := e.Resources.VisitCronJobE(func(cron *v1beta1.CronJob) error {
-		if IsTrue(t.ProbesEnabled) && portName == defaultContainerPortName {
-			t.configureProbes(&container, t.Port, defaultProbePath)
+		if IsTrue(t.ProbesEnabled) && (portName == defaultContainerPortName || t.PortProtocol == containerPortProtocolGRPC) {
+			t.configureProbes(&container, false)
 		}
 
 		for _, envVar := range e.EnvVars {
@@ -353,9 +458,28 @@ func (t *containerTrait) configureContainer(e *Environment) error {
 		return err
 	}
 
+	t.configureArchitecture(e)
+
 	return nil
 }
 
+// configureArchitecture schedules the integration pods onto nodes matching the architecture of the kit image,
+// when it has been built for a single architecture
+func (t *containerTrait) configureArchitecture(e *Environment) {
+	if e.IntegrationKit == nil || len(e.IntegrationKit.Status.Architectures) != 1 {
+		return
+	}
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return
+	}
+	if podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = make(map[string]string)
+	}
+	podSpec.NodeSelector[corev1.LabelOSStable] = "linux"
+	podSpec.NodeSelector[corev1.LabelArchStable] = e.IntegrationKit.Status.Architectures[0]
+}
+
 func (t *containerTrait) configureService(e *Environment, container *corev1.Container) {
 	service := e.Resources.GetServiceForIntegration(e.Integration)
 	if service == nil {
@@ -380,6 +504,24 @@ func (t *containerTrait) configureService(e *Environment, container *corev1.Cont
 		TargetPort: intstr.FromString(name),
 	}
 
+	var appProtocol string
+	switch t.PortProtocol {
+	case containerPortProtocolH2C:
+		appProtocol = "kubernetes.io/h2c"
+	case containerPortProtocolGRPC:
+		appProtocol = containerPortProtocolGRPC
+	}
+	if appProtocol != "" {
+		servicePort.AppProtocol = &appProtocol
+	}
+
+	if st, ok := e.GetTrait(serviceTraitID).(*serviceTrait); ok {
+		if appProtocol := st.appProtocolFor(servicePort.Name); appProtocol != nil {
+			servicePort.AppProtocol = appProtocol
+		}
+		container.Ports = append(container.Ports, st.containerPorts()...)
+	}
+
 	e.Integration.Status.SetCondition(
 		v1.IntegrationConditionServiceAvailable,
 		corev1.ConditionTrue,
@@ -450,51 +592,93 @@ func (t *containerTrait) configureCapabilities(e *Environment) {
 	}
 }
 
-func (t *containerTrait) configureProbes(container *corev1.Container, port int, path string) {
-	container.LivenessProbe = t.newLivenessProbe(port, path)
-	container.ReadinessProbe = t.newReadinessProbe(port, path)
+// configureProbes sets the liveness, readiness and startup probes of the container. On Knative, the probes
+// port is not set, as only the container port is allowed, and the startup probe is not supported.
+func (t *containerTrait) configureProbes(container *corev1.Container, knative bool) {
+	liveness := probe{
+		scheme:           t.LivenessScheme,
+		path:             t.LivenessPath,
+		port:             t.LivenessPort,
+		initialDelay:     t.LivenessInitialDelay,
+		timeout:          t.LivenessTimeout,
+		period:           t.LivenessPeriod,
+		successThreshold: t.LivenessSuccessThreshold,
+		failureThreshold: t.LivenessFailureThreshold,
+	}
+	readiness := probe{
+		scheme:           t.ReadinessScheme,
+		path:             t.ReadinessPath,
+		port:             t.ReadinessPort,
+		initialDelay:     t.ReadinessInitialDelay,
+		timeout:          t.ReadinessTimeout,
+		period:           t.ReadinessPeriod,
+		successThreshold: t.ReadinessSuccessThreshold,
+		failureThreshold: t.ReadinessFailureThreshold,
+	}
+
+	container.LivenessProbe = t.newProbe(liveness, knative)
+	container.ReadinessProbe = t.newProbe(readiness, knative)
+
+	if IsTrue(t.StartupProbeEnabled) {
+		if knative {
+			t.L.Info("Skipping the startup probe, that is not supported by Knative")
+			return
+		}
+		container.StartupProbe = t.newProbe(probe{
+			scheme:           t.StartupScheme,
+			path:             t.StartupPath,
+			port:             t.StartupPort,
+			initialDelay:     t.StartupInitialDelay,
+			timeout:          t.StartupTimeout,
+			period:           t.StartupPeriod,
+			successThreshold: t.StartupSuccessThreshold,
+			failureThreshold: t.StartupFailureThreshold,
+		}, false)
+	}
 }
 
-func (t *containerTrait) newLivenessProbe(port int, path string) *corev1.Probe {
-	action := corev1.HTTPGetAction{}
-	action.Path = path
-	action.Scheme = corev1.URIScheme(t.LivenessScheme)
-
-	if port > 0 {
-		action.Port = intstr.FromInt(port)
+func (t *containerTrait) newProbe(config probe, knative bool) *corev1.Probe {
+	port := config.port
+	if port == 0 {
+		port = t.Port
 	}
-
-	p := corev1.Probe{
-		Handler: corev1.Handler{
-			HTTPGet: &action,
-		},
+	if knative {
+		port = 0
 	}
 
-	p.InitialDelaySeconds = t.LivenessInitialDelay
-	p.TimeoutSeconds = t.LivenessTimeout
-	p.PeriodSeconds = t.LivenessPeriod
-	p.SuccessThreshold = t.LivenessSuccessThreshold
-	p.FailureThreshold = t.LivenessFailureThreshold
-
-	return &p
-}
-
-func (t *containerTrait) newReadinessProbe(port int, path string) *corev1.Probe {
 	p := corev1.Probe{
-		Handler: corev1.Handler{
-			HTTPGet: &corev1.HTTPGetAction{
-				Port:   intstr.FromInt(port),
-				Path:   path,
-				Scheme: corev1.URIScheme(t.ReadinessScheme),
+		InitialDelaySeconds: config.initialDelay,
+		TimeoutSeconds:      config.timeout,
+		PeriodSeconds:       config.period,
+		SuccessThreshold:    config.successThreshold,
+		FailureThreshold:    config.failureThreshold,
+	}
+
+	// gRPC services are only checked to accept TCP connections
+	if t.PortProtocol == containerPortProtocolGRPC {
+		p.Handler = corev1.Handler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(port),
 			},
-		},
+		}
+		return &p
 	}
 
-	p.InitialDelaySeconds = t.ReadinessInitialDelay
-	p.TimeoutSeconds = t.ReadinessTimeout
-	p.PeriodSeconds = t.ReadinessPeriod
-	p.SuccessThreshold = t.ReadinessSuccessThreshold
-	p.FailureThreshold = t.ReadinessFailureThreshold
+	path := config.path
+	if path == "" {
+		path = defaultProbePath
+	}
+	scheme := config.scheme
+	if scheme == "" {
+		scheme = string(corev1.URISchemeHTTP)
+	}
+	p.Handler = corev1.Handler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Port:   intstr.FromInt(port),
+			Path:   path,
+			Scheme: corev1.URIScheme(scheme),
+		},
+	}
 
 	return &p
 }
@@ -25,6 +25,7 @@ import (
 	serving "knative.dev/serving/pkg/apis/serving/v1"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -186,3 +187,132 @@ func TestProbesOnKnativeServiceWithNoHttpPort(t *testing.T) {
 	assert.Nil(t, target.Spec.Template.Spec.Containers[0].LivenessProbe)
 	assert.Nil(t, target.Spec.Template.Spec.Containers[0].ReadinessProbe)
 }
+
+func TestProbesOnDeploymentWithGRPCPort(t *testing.T) {
+	target := appsv1.Deployment{}
+
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Resources.Add(&target)
+
+	ctr := newTestContainerTrait()
+	ctr.PortName = "grpc"
+	ctr.Port = 9000
+	ctr.PortProtocol = containerPortProtocolGRPC
+	ctr.ReadinessTimeout = 1234
+
+	err := ctr.Apply(&env)
+	assert.Nil(t, err)
+	assert.Nil(t, target.Spec.Template.Spec.Containers[0].LivenessProbe.HTTPGet)
+	assert.Equal(t, int32(9000), target.Spec.Template.Spec.Containers[0].LivenessProbe.TCPSocket.Port.IntVal)
+	assert.Nil(t, target.Spec.Template.Spec.Containers[0].ReadinessProbe.HTTPGet)
+	assert.Equal(t, int32(9000), target.Spec.Template.Spec.Containers[0].ReadinessProbe.TCPSocket.Port.IntVal)
+	assert.Equal(t, int32(1234), target.Spec.Template.Spec.Containers[0].ReadinessProbe.TimeoutSeconds)
+}
+
+func TestProbesOnKnativeServiceWithH2CPort(t *testing.T) {
+	target := serving.Service{}
+
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Resources.Add(&target)
+
+	ctr := newTestContainerTrait()
+	ctr.PortProtocol = containerPortProtocolH2C
+
+	err := ctr.Apply(&env)
+	assert.Nil(t, err)
+	assert.Equal(t, []corev1.ContainerPort{{Name: "h2c", ContainerPort: int32(defaultContainerPort)}}, target.Spec.Template.Spec.Containers[0].Ports)
+	assert.Equal(t, defaultProbePath, target.Spec.Template.Spec.Containers[0].LivenessProbe.HTTPGet.Path)
+}
+
+func TestProbesOnDeploymentWithCustomPathsAndPorts(t *testing.T) {
+	target := appsv1.Deployment{}
+
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Resources.Add(&target)
+
+	ctr := newTestContainerTrait()
+	ctr.LivenessPath = "/q/health/live"
+	ctr.LivenessPort = 9000
+	ctr.ReadinessPath = "/q/health/ready"
+	ctr.ReadinessInitialDelay = 5
+
+	err := ctr.Apply(&env)
+	assert.Nil(t, err)
+
+	container := target.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "/q/health/live", container.LivenessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(9000), container.LivenessProbe.HTTPGet.Port.IntVal)
+	assert.Equal(t, "/q/health/ready", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(defaultContainerPort), container.ReadinessProbe.HTTPGet.Port.IntVal)
+	assert.Equal(t, int32(5), container.ReadinessProbe.InitialDelaySeconds)
+	assert.Nil(t, container.StartupProbe)
+}
+
+func TestStartupProbeOnDeployment(t *testing.T) {
+	target := appsv1.Deployment{}
+
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Resources.Add(&target)
+
+	ctr := newTestContainerTrait()
+	ctr.StartupProbeEnabled = BoolP(true)
+	ctr.StartupPath = "/q/health/started"
+	ctr.StartupPeriod = 2
+	ctr.StartupFailureThreshold = 30
+
+	err := ctr.Apply(&env)
+	assert.Nil(t, err)
+
+	probe := target.Spec.Template.Spec.Containers[0].StartupProbe
+	assert.NotNil(t, probe)
+	assert.Equal(t, "/q/health/started", probe.HTTPGet.Path)
+	assert.Equal(t, int32(defaultContainerPort), probe.HTTPGet.Port.IntVal)
+	assert.Equal(t, corev1.URISchemeHTTP, probe.HTTPGet.Scheme)
+	assert.Equal(t, int32(2), probe.PeriodSeconds)
+	assert.Equal(t, int32(30), probe.FailureThreshold)
+}
+
+func TestStartupProbeOnCronJob(t *testing.T) {
+	target := v1beta1.CronJob{}
+
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Resources.Add(&target)
+
+	ctr := newTestContainerTrait()
+	ctr.StartupProbeEnabled = BoolP(true)
+	ctr.StartupPort = 9000
+
+	err := ctr.Apply(&env)
+	assert.Nil(t, err)
+
+	container := target.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, defaultProbePath, container.LivenessProbe.HTTPGet.Path)
+	assert.Equal(t, defaultProbePath, container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(9000), container.StartupProbe.HTTPGet.Port.IntVal)
+}
+
+func TestStartupProbeOnKnativeService(t *testing.T) {
+	target := serving.Service{}
+
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Resources.Add(&target)
+
+	ctr := newTestContainerTrait()
+	ctr.StartupProbeEnabled = BoolP(true)
+	ctr.LivenessPort = 9000
+	ctr.LivenessPath = "/q/health/live"
+
+	err := ctr.Apply(&env)
+	assert.Nil(t, err)
+
+	container := target.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "/q/health/live", container.LivenessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(0), container.LivenessProbe.HTTPGet.Port.IntVal)
+	assert.Nil(t, container.StartupProbe)
+}
@@ -350,3 +350,81 @@ func TestContainerWithImagePullPolicy(t *testing.T) {
 	assert.False(t, ok)
 	assert.NotNil(t, err)
 }
+
+func TestContainerWithPortProtocol(t *testing.T) {
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Integration.Name = ServiceTestName
+	env.Resources.Add(&appsv1.Deployment{})
+	env.Resources.Add(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ServiceTestName,
+			Labels: map[string]string{
+				v1.IntegrationLabel: ServiceTestName,
+			},
+		},
+	})
+
+	ctr := newTestContainerTrait()
+	ctr.Expose = BoolP(true)
+	ctr.PortProtocol = containerPortProtocolGRPC
+
+	err := ctr.Apply(&env)
+	assert.Nil(t, err)
+
+	service := env.Resources.GetServiceForIntegration(env.Integration)
+	assert.NotNil(t, service)
+	assert.Len(t, service.Spec.Ports, 1)
+	assert.Equal(t, "grpc", *service.Spec.Ports[0].AppProtocol)
+
+	ctr.PortProtocol = "websocket"
+
+	ok, err := ctr.Configure(&env)
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+}
+
+func TestContainerWithSingleArchitectureKit(t *testing.T) {
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Integration.Name = ServiceTestName
+	env.IntegrationKit = &v1.IntegrationKit{
+		Status: v1.IntegrationKitStatus{
+			Phase:         v1.IntegrationKitPhaseReady,
+			Architectures: []string{"arm64"},
+		},
+	}
+	target := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ServiceTestName,
+		},
+	}
+	env.Resources.Add(&target)
+
+	err := newTestContainerTrait().Apply(&env)
+	assert.Nil(t, err)
+	assert.Equal(t, "linux", target.Spec.Template.Spec.NodeSelector[corev1.LabelOSStable])
+	assert.Equal(t, "arm64", target.Spec.Template.Spec.NodeSelector[corev1.LabelArchStable])
+}
+
+func TestContainerWithMultiArchitectureKit(t *testing.T) {
+	env := newTestProbesEnv(t, v1.RuntimeProviderQuarkus)
+	env.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	env.Integration.Name = ServiceTestName
+	env.IntegrationKit = &v1.IntegrationKit{
+		Status: v1.IntegrationKitStatus{
+			Phase:         v1.IntegrationKitPhaseReady,
+			Architectures: []string{"amd64", "arm64"},
+		},
+	}
+	target := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ServiceTestName,
+		},
+	}
+	env.Resources.Add(&target)
+
+	err := newTestContainerTrait().Apply(&env)
+	assert.Nil(t, err)
+	assert.Empty(t, target.Spec.Template.Spec.NodeSelector)
+}
@@ -20,9 +20,12 @@ package trait
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -45,6 +48,9 @@ import (
 //
 // Integrations that start from the following components are evaluated by the cron trait: `timer`, `cron`, `quartz`.
 //
+// When the routes cannot be materialized as a CronJob, e.g. because their periodic consumers have different schedules,
+// the integration falls back to a standard deployment, and the `CronJobAvailable` condition explains why.
+//
 // The rules for using a Kubernetes CronJob are the following:
 // - `timer`: when periods can be written as cron expressions. E.g. `timer:tick?period=60000`.
 // - `cron`, `quartz`: when the cron expression does not contain seconds (or the "seconds" part is set to 0). E.g.
@@ -80,6 +86,15 @@ type cronTrait struct {
 	// Optional deadline in seconds for starting the job if it misses scheduled
 	// time for any reason.  Missed jobs executions will be counted as failed ones.
 	StartingDeadlineSeconds *int64 `property:"starting-deadline-seconds" json:"startingDeadlineSeconds,omitempty"`
+	// The time zone the schedule is interpreted in, e.g. `Europe/Rome` (default to the time zone of the Kubernetes controller manager).
+	TimeZone string `property:"time-zone" json:"timeZone,omitempty"`
+	// The number of successful finished jobs to retain.
+	SuccessfulJobsHistoryLimit *int32 `property:"successful-jobs-history-limit" json:"successfulJobsHistoryLimit,omitempty"`
+	// The number of failed finished jobs to retain.
+	FailedJobsHistoryLimit *int32 `property:"failed-jobs-history-limit" json:"failedJobsHistoryLimit,omitempty"`
+
+	// Whether the schedule has been computed from the periodic consumers of the integration
+	autoSchedule bool
 }
 
 var _ ControllerStrategySelector = &cronTrait{}
@@ -101,6 +116,9 @@ const (
 var (
 	camelTimerPeriodMillis = regexp.MustCompile(`^[0-9]+$`)
 
+	// cronTimeZoneRegexp matches the IANA time zone names, e.g. America/Argentina/Buenos_Aires or Etc/GMT+3
+	cronTimeZoneRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_+\-]*(/[A-Za-z0-9_+\-]+)*$`)
+
 	supportedCamelComponents = map[string]cronExtractor{
 		"timer":  timerToCronInfo,
 		"quartz": quartzToCronInfo,
@@ -110,7 +128,7 @@ var (
 
 func newCronTrait() Trait {
 	return &cronTrait{
-		BaseTrait: NewBaseTrait("cron", 1000),
+		BaseTrait: NewBaseTrait("cron", 1000, ExecuteAfter(serviceAccountTraitID)),
 	}
 }
 
@@ -141,8 +159,22 @@ func (t *cronTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if t.ConcurrencyPolicy != "" && !isValidConcurrencyPolicy(t.ConcurrencyPolicy) {
+		return false, fmt.Errorf("unsupported concurrency policy: %s", t.ConcurrencyPolicy)
+	}
+	if t.TimeZone != "" && !cronTimeZoneRegexp.MatchString(t.TimeZone) {
+		return false, fmt.Errorf("invalid time zone: %s", t.TimeZone)
+	}
+	if strings.HasPrefix(t.Schedule, "CRON_TZ=") || strings.HasPrefix(t.Schedule, "TZ=") {
+		return false, fmt.Errorf("the time zone must be set with the time-zone option, not in the schedule: %s", t.Schedule)
+	}
+
+	// The reason why the integration cannot be materialized as a CronJob, if any
+	var unavailableReason string
+
 	if IsNilOrTrue(t.Auto) {
-		globalCron, err := t.getGlobalCron(e)
+		globalCron, reason, err := t.getGlobalCronWithReason(e)
+		unavailableReason = reason
 		if err != nil {
 			e.Integration.Status.SetErrorCondition(
 				v1.IntegrationConditionCronJobAvailable,
@@ -154,6 +186,7 @@ func (t *cronTrait) Configure(e *Environment) (bool, error) {
 
 		if t.Schedule == "" && globalCron != nil {
 			t.Schedule = globalCron.schedule
+			t.autoSchedule = true
 		}
 
 		if globalCron != nil {
@@ -190,7 +223,7 @@ func (t *cronTrait) Configure(e *Environment) (bool, error) {
 				v1.IntegrationConditionCronJobAvailable,
 				corev1.ConditionFalse,
 				v1.IntegrationConditionCronJobNotAvailableReason,
-				"fallback strategy selected",
+				withCronUnavailableReason("fallback strategy selected", unavailableReason),
 			)
 		}
 		return true, nil
@@ -212,13 +245,24 @@ func (t *cronTrait) Configure(e *Environment) (bool, error) {
 				v1.IntegrationConditionCronJobAvailable,
 				corev1.ConditionFalse,
 				v1.IntegrationConditionCronJobNotAvailableReason,
-				fmt.Sprintf("different controller strategy used (%s)", string(strategy)),
+				withCronUnavailableReason(fmt.Sprintf("different controller strategy used (%s)", string(strategy)), unavailableReason),
 			)
 		}
 		return false, nil
 	}
 
-	return t.Schedule != "", nil
+	// The CronJob strategy may be explicitly selected without any schedule
+	if t.Schedule == "" {
+		err := errors.New("the cron-job controller strategy requires a schedule, set with the cron trait schedule")
+		e.Integration.Status.SetErrorCondition(
+			v1.IntegrationConditionCronJobAvailable,
+			v1.IntegrationConditionCronJobNotAvailableReason,
+			err,
+		)
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (t *cronTrait) Apply(e *Environment) error {
@@ -266,6 +310,13 @@ func (t *cronTrait) getCronJobFor(e *Environment) *v1beta1.CronJob {
 		}
 	}
 
+	schedule := t.Schedule
+	if t.TimeZone != "" {
+		// The CronJob API has no time zone field yet, the CRON_TZ prefix is honored by the cron parser
+		// of the CronJob controller, though it is not officially supported by Kubernetes
+		schedule = fmt.Sprintf("CRON_TZ=%s %s", t.TimeZone, schedule)
+	}
+
 	cronjob := v1beta1.CronJob{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "CronJob",
@@ -280,10 +331,18 @@ func (t *cronTrait) getCronJobFor(e *Environment) *v1beta1.CronJob {
 			Annotations: e.Integration.Annotations,
 		},
 		Spec: v1beta1.CronJobSpec{
-			Schedule:                t.Schedule,
-			ConcurrencyPolicy:       v1beta1.ConcurrencyPolicy(t.ConcurrencyPolicy),
-			StartingDeadlineSeconds: t.StartingDeadlineSeconds,
+			Schedule:                   schedule,
+			ConcurrencyPolicy:          v1beta1.ConcurrencyPolicy(t.ConcurrencyPolicy),
+			StartingDeadlineSeconds:    t.StartingDeadlineSeconds,
+			SuccessfulJobsHistoryLimit: t.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     t.FailedJobsHistoryLimit,
 			JobTemplate: v1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					// The Jobs are labelled, so that the result of the last one is reported
+					Labels: map[string]string{
+						v1.IntegrationLabel: e.Integration.Name,
+					},
+				},
 				Spec: batchv1.JobSpec{
 					Template: corev1.PodTemplateSpec{
 						ObjectMeta: metav1.ObjectMeta{
@@ -291,7 +350,7 @@ func (t *cronTrait) getCronJobFor(e *Environment) *v1beta1.CronJob {
 							Annotations: annotations,
 						},
 						Spec: corev1.PodSpec{
-							ServiceAccountName: e.Integration.Spec.ServiceAccountName,
+							ServiceAccountName: e.GetIntegrationServiceAccountName(),
 							RestartPolicy:      corev1.RestartPolicyNever,
 						},
 					},
@@ -303,6 +362,21 @@ func (t *cronTrait) getCronJobFor(e *Environment) *v1beta1.CronJob {
 	return &cronjob
 }
 
+func withCronUnavailableReason(message string, reason string) string {
+	if reason == "" {
+		return message
+	}
+	return message + ": " + reason
+}
+
+func isValidConcurrencyPolicy(policy string) bool {
+	switch v1beta1.ConcurrencyPolicy(policy) {
+	case v1beta1.AllowConcurrent, v1beta1.ForbidConcurrent, v1beta1.ReplaceConcurrent:
+		return true
+	}
+	return false
+}
+
 // SelectControllerStrategy can be used to check if a CronJob can be generated given the integration and trait settings
 func (t *cronTrait) SelectControllerStrategy(e *Environment) (*ControllerStrategy, error) {
 	cronStrategy := ControllerStrategyCronJob
@@ -324,6 +398,14 @@ func (t *cronTrait) SelectControllerStrategy(e *Environment) (*ControllerStrateg
 	return nil, nil
 }
 
+func (t *cronTrait) ExplainControllerStrategy(e *Environment, strategy ControllerStrategy) (string, string) {
+	if t.Schedule != "" && !t.autoSchedule {
+		return v1.IntegrationConditionControllerStrategyScheduleReason, fmt.Sprintf("the cron trait schedule is set to %q", t.Schedule)
+	}
+	return v1.IntegrationConditionControllerStrategyScheduleReason,
+		"the integration only consumes from periodic endpoints, that can be scheduled with a CronJob"
+}
+
 func (t *cronTrait) ControllerStrategySelectorOrder() int {
 	return 1000
 }
@@ -347,9 +429,16 @@ func (c *cronInfo) withSchedule(schedule string) *cronInfo {
 }
 
 func (t *cronTrait) getGlobalCron(e *Environment) (*cronInfo, error) {
+	globalCron, _, err := t.getGlobalCronWithReason(e)
+	return globalCron, err
+}
+
+// getGlobalCronWithReason returns the cron information common to all the routes, or the reason why
+// the route consumers cannot be mapped to a single cron schedule
+func (t *cronTrait) getGlobalCronWithReason(e *Environment) (*cronInfo, string, error) {
 	fromURIs, err := t.getSourcesFromURIs(e)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	passiveComponents := make(map[string]bool)
@@ -366,12 +455,15 @@ func (t *cronTrait) getGlobalCron(e *Environment) (*cronInfo, error) {
 		if supportedCamelComponents[comp] != nil {
 			cron = append(cron, from)
 		} else if !passiveComponents[comp] {
-			return nil, nil
+			return nil, fmt.Sprintf("the %s consumer is neither periodic nor passive", comp), nil
 		}
 	}
 
 	globalCron := getCronForURIs(cron)
-	return globalCron, nil
+	if globalCron == nil {
+		return nil, getCronUnmappableReason(cron), nil
+	}
+	return globalCron, "", nil
 }
 
 func (t *cronTrait) getSourcesFromURIs(e *Environment) ([]string, error) {
@@ -402,6 +494,29 @@ func getCronForURIs(camelURIs []string) (globalCron *cronInfo) {
 	return globalCron
 }
 
+// getCronUnmappableReason explains why the given periodic consumers cannot be mapped to a single cron schedule
+func getCronUnmappableReason(camelURIs []string) string {
+	if len(camelURIs) == 0 {
+		return "no periodic consumers found"
+	}
+	// Sort the URIs so that the reason is stable across reconciliations
+	uris := append([]string(nil), camelURIs...)
+	sort.Strings(uris)
+	var schedule string
+	for _, camelURI := range uris {
+		cr := getCronForURI(camelURI)
+		if cr == nil {
+			return fmt.Sprintf("the %s consumer cannot be expressed as a cron schedule", camelURI)
+		}
+		if schedule == "" {
+			schedule = cr.schedule
+		} else if !cronEquivalent(schedule, cr.schedule) {
+			return fmt.Sprintf("the periodic consumers have different schedules (%s and %s)", schedule, cr.schedule)
+		}
+	}
+	return ""
+}
+
 func getCronForURI(camelURI string) *cronInfo {
 	comp := uri.GetComponent(camelURI)
 	extractor := supportedCamelComponents[comp]
@@ -437,3 +437,111 @@ func TestCronWithQuarkus(t *testing.T) {
 	assert.Nil(t, ct.Fallback)
 	assert.Contains(t, environment.Interceptors, "cron")
 }
+
+func TestCronUnmappableReason(t *testing.T) {
+	assert.Equal(t, "", getCronUnmappableReason([]string{"timer:tick?period=60000", "cron:tab?schedule=0/1 * * * ?"}))
+	assert.Equal(t, "no periodic consumers found", getCronUnmappableReason(nil))
+	assert.Equal(t, "the timer:tick?period=50000 consumer cannot be expressed as a cron schedule",
+		getCronUnmappableReason([]string{"timer:tick?period=50000"}))
+	assert.Equal(t, "the periodic consumers have different schedules (0/1 * * * ? and 0/2 * * * ?)",
+		getCronUnmappableReason([]string{"timer:tick?period=60000", "timer:tock?period=120000"}))
+}
+
+func TestCronInvalidConfiguration(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	environment := &Environment{
+		CamelCatalog: catalog,
+		Integration: &v1.Integration{
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+	}
+
+	trait := newCronTrait().(*cronTrait)
+	trait.ConcurrencyPolicy = "Sometimes"
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+
+	trait = newCronTrait().(*cronTrait)
+	trait.TimeZone = "Europe/Rome 0/1"
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+
+	trait = newCronTrait().(*cronTrait)
+	trait.Schedule = "CRON_TZ=Europe/Rome 0/1 * * * ?"
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestCronJobSpec(t *testing.T) {
+	environment := &Environment{
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+		},
+	}
+
+	trait := newCronTrait().(*cronTrait)
+	trait.Schedule = "0/5 * * * ?"
+	trait.ConcurrencyPolicy = "Replace"
+	trait.TimeZone = "America/Argentina/Buenos_Aires"
+	successfulJobsHistoryLimit, failedJobsHistoryLimit := int32(1), int32(5)
+	trait.SuccessfulJobsHistoryLimit = &successfulJobsHistoryLimit
+	trait.FailedJobsHistoryLimit = &failedJobsHistoryLimit
+
+	cronJob := trait.getCronJobFor(environment)
+
+	assert.Equal(t, "CRON_TZ=America/Argentina/Buenos_Aires 0/5 * * * ?", cronJob.Spec.Schedule)
+	assert.Equal(t, "Replace", string(cronJob.Spec.ConcurrencyPolicy))
+	assert.Equal(t, int32(1), *cronJob.Spec.SuccessfulJobsHistoryLimit)
+	assert.Equal(t, int32(5), *cronJob.Spec.FailedJobsHistoryLimit)
+}
+
+func TestCronFallbackToDeploymentCondition(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	environment := Environment{
+		CamelCatalog: catalog,
+		Catalog:      NewCatalog(nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name: "routes.java",
+							Content: `from("timer:tick?period=60000").to("log:tick");
+								from("timer:tock?period=120000").to("log:tock")`,
+						},
+						Language: v1.LanguageJavaSource,
+					},
+				},
+			},
+		},
+		Resources: kubernetes.NewCollection(),
+	}
+
+	trait := newCronTrait().(*cronTrait)
+	configured, err := trait.Configure(&environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionCronJobAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "different controller strategy used (deployment): "+
+		"the periodic consumers have different schedules (0/1 * * * ? and 0/2 * * * ?)", condition.Message)
+}
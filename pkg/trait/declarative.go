@@ -0,0 +1,290 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// DeclarativeTraitsEnvVariable is the environment variable of the operator holding the name of the ConfigMap,
+// in the operator namespace, the declarative traits are loaded from at startup
+const DeclarativeTraitsEnvVariable = "DECLARATIVE_TRAITS_CONFIGMAP"
+
+// DeclarativeTraitDefinition defines a trait that patches the resources generated for the integrations,
+// so that simple customizations do not require to rebuild the operator
+type DeclarativeTraitDefinition struct {
+	// The ID of the trait
+	Name string `json:"name"`
+	// The description of the trait, served by the trait catalog endpoint of the operator
+	Description string `json:"description,omitempty"`
+	// The order of the trait, relative to the traits it has no constraint with, applied last by default
+	Order *int `json:"order,omitempty"`
+	// The traits that must be executed before the trait
+	After []string `json:"after,omitempty"`
+	// The traits that must be executed after the trait
+	Before []string `json:"before,omitempty"`
+	// The profiles the trait is available in, all of them by default
+	Profiles []v1.TraitProfile `json:"profiles,omitempty"`
+	// Whether the trait is enabled by default
+	Enabled bool `json:"enabled,omitempty"`
+	// The default values of the parameters the patches are templated with
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// The patches applied to the generated resources
+	Patches []DeclarativeTraitPatch `json:"patches"`
+}
+
+// DeclarativeTraitPatch is a patch applied to the generated resources of the given kind
+type DeclarativeTraitPatch struct {
+	// The API version of the patched resources, any version by default
+	APIVersion string `json:"apiVersion,omitempty"`
+	// The kind of the patched resources
+	Kind string `json:"kind"`
+	// The YAML or JSON patch, templated with the integration as `.Integration` and the trait parameters as `.Parameters`,
+	// applied as a strategic merge patch to the typed resources, and as a JSON merge patch to the unstructured ones
+	Patch string `json:"patch"`
+}
+
+type declarativeTrait struct {
+	BaseTrait `property:",squash"`
+	// The parameters the patches are templated with
+	Parameters map[string]interface{} `json:"-"`
+	definition DeclarativeTraitDefinition
+	templates  []*template.Template
+}
+
+func newDeclarativeTrait(definition DeclarativeTraitDefinition) (*declarativeTrait, error) {
+	order := TraitOrderPostProcessResources
+	if definition.Order != nil {
+		order = *definition.Order
+	}
+	constraints := []OrderConstraint{
+		ExecuteAfter(toIDs(definition.After)...),
+		ExecuteBefore(toIDs(definition.Before)...),
+	}
+
+	t := declarativeTrait{
+		BaseTrait:  NewBaseTrait(definition.Name, order, constraints...),
+		Parameters: make(map[string]interface{}),
+		definition: definition,
+	}
+	enabled := definition.Enabled
+	t.Enabled = &enabled
+	for k, v := range definition.Parameters {
+		t.Parameters[k] = v
+	}
+
+	for i, patch := range definition.Patches {
+		if patch.Kind == "" {
+			return nil, fmt.Errorf("declarative trait %s: patch #%d has no kind", definition.Name, i+1)
+		}
+		tmpl, err := template.New(fmt.Sprintf("%s-%d", definition.Name, i+1)).Option("missingkey=error").Parse(patch.Patch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "declarative trait %s: invalid patch #%d", definition.Name, i+1)
+		}
+		t.templates = append(t.templates, tmpl)
+	}
+
+	return &t, nil
+}
+
+// UnmarshalJSON decodes the trait configuration, the properties other than `enabled` being the parameters
+func (t *declarativeTrait) UnmarshalJSON(data []byte) error {
+	config := make(map[string]interface{})
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	for k, v := range config {
+		if k != "enabled" {
+			t.Parameters[k] = v
+			continue
+		}
+		enabled, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("invalid value for property enabled of trait %s: %v", t.ID(), v)
+		}
+		t.Enabled = &enabled
+	}
+	return nil
+}
+
+// configureFromOptions configures the trait from the given options, e.g., set with annotations,
+// the options other than `enabled` being the parameters
+func (t *declarativeTrait) configureFromOptions(options map[string]string) error {
+	for k, v := range options {
+		if k != "enabled" {
+			t.Parameters[k] = v
+			continue
+		}
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid value for property enabled of trait %s: %v", t.ID(), v)
+		}
+		t.Enabled = &enabled
+	}
+	return nil
+}
+
+func (t *declarativeTrait) IsAllowedInProfile(profile v1.TraitProfile) bool {
+	if len(t.definition.Profiles) == 0 {
+		return true
+	}
+	for _, p := range t.definition.Profiles {
+		if strings.EqualFold(string(p), string(profile)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *declarativeTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *declarativeTrait) Apply(e *Environment) error {
+	data := map[string]interface{}{
+		"Integration": e.Integration,
+		"Parameters":  t.Parameters,
+	}
+
+	for i, patch := range t.definition.Patches {
+		var content bytes.Buffer
+		if err := t.templates[i].Execute(&content, data); err != nil {
+			return errors.Wrapf(err, "unable to render patch #%d of trait %s", i+1, t.ID())
+		}
+		jsonPatch, err := yaml.ToJSON(content.Bytes())
+		if err != nil {
+			return errors.Wrapf(err, "invalid patch #%d of trait %s", i+1, t.ID())
+		}
+
+		if err := e.Resources.VisitE(func(obj runtime.Object) error {
+			gvk := obj.GetObjectKind().GroupVersionKind()
+			if gvk.Kind != patch.Kind || patch.APIVersion != "" && gvk.GroupVersion().String() != patch.APIVersion {
+				return nil
+			}
+			return patchObject(obj, jsonPatch)
+		}); err != nil {
+			return errors.Wrapf(err, "unable to apply patch #%d of trait %s", i+1, t.ID())
+		}
+	}
+
+	return nil
+}
+
+// describe completes the description of the trait with its definition
+func (t *declarativeTrait) describe(d *Description) {
+	d.Description = t.definition.Description
+
+	names := make([]string, 0, len(t.definition.Parameters))
+	for k := range t.definition.Parameters {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		d.Properties = append(d.Properties, PropertyDescription{
+			Name:         name,
+			TypeName:     "string",
+			DefaultValue: t.definition.Parameters[name],
+			Description:  "A parameter of the patches of the trait.",
+		})
+	}
+}
+
+// LoadDeclarativeTraits returns the definitions of the declarative traits held by the given ConfigMap,
+// each key holding the YAML or JSON definition of a trait
+func LoadDeclarativeTraits(ctx context.Context, c ctrl.Reader, namespace, name string) ([]DeclarativeTraitDefinition, error) {
+	cm := corev1.ConfigMap{}
+	if err := c.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: name}, &cm); err != nil {
+		return nil, errors.Wrapf(err, "unable to get declarative traits ConfigMap %s", name)
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	definitions := make([]DeclarativeTraitDefinition, 0, len(keys))
+	for _, k := range keys {
+		definition := DeclarativeTraitDefinition{}
+		if err := yaml.Unmarshal([]byte(cm.Data[k]), &definition); err != nil {
+			return nil, errors.Wrapf(err, "invalid declarative trait %s in ConfigMap %s", k, name)
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
+
+// RegisterDeclarativeTraits validates the definitions of the given declarative traits, and adds them to the traits
+func RegisterDeclarativeTraits(definitions []DeclarativeTraitDefinition) error {
+	ids := make(map[ID]bool)
+	for _, factory := range FactoryList {
+		ids[factory().ID()] = true
+	}
+
+	for _, definition := range definitions {
+		definition := definition // pin
+		if definition.Name == "" {
+			return errors.New("declarative trait has no name")
+		}
+		if ids[ID(definition.Name)] {
+			return fmt.Errorf("declarative trait %s conflicts with an existing trait", definition.Name)
+		}
+		if _, err := newDeclarativeTrait(definition); err != nil {
+			return err
+		}
+		ids[ID(definition.Name)] = true
+
+		AddToTraits(func() Trait {
+			// nolint: errcheck
+			t, _ := newDeclarativeTrait(definition)
+			return t
+		})
+	}
+
+	return nil
+}
+
+func toIDs(names []string) []ID {
+	ids := make([]ID, 0, len(names))
+	for _, name := range names {
+		ids = append(ids, ID(name))
+	}
+	return ids
+}
@@ -0,0 +1,184 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+const declarativeTraitTestDefinition = `
+name: team
+description: Sets the team of the integration
+after:
+- deployment
+profiles:
+- Kubernetes
+parameters:
+  team: integration
+patches:
+- kind: Deployment
+  apiVersion: apps/v1
+  patch: |
+    metadata:
+      labels:
+        team: {{ .Parameters.team }}
+    spec:
+      template:
+        spec:
+          containers:
+          - name: integration
+            env:
+            - name: INTEGRATION
+              value: {{ .Integration.Name }}
+- kind: Monitor
+  patch: |
+    spec:
+      team: {{ .Parameters.team }}
+`
+
+func newDeclarativeTraitTest(t *testing.T) *declarativeTrait {
+	t.Helper()
+
+	c, err := test.NewFakeClient(&corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator",
+			Name:      "declarative-traits",
+		},
+		Data: map[string]string{
+			"team.yaml": declarativeTraitTestDefinition,
+		},
+	})
+	assert.Nil(t, err)
+
+	definitions, err := LoadDeclarativeTraits(context.TODO(), c, "operator", "declarative-traits")
+	assert.Nil(t, err)
+	assert.Len(t, definitions, 1)
+
+	trait, err := newDeclarativeTrait(definitions[0])
+	assert.Nil(t, err)
+	return trait
+}
+
+func TestDeclarativeTrait(t *testing.T) {
+	trait := newDeclarativeTraitTest(t)
+	assert.Equal(t, ID("team"), trait.ID())
+	assert.Equal(t, TraitOrderPostProcessResources, trait.Order())
+	assert.Equal(t, []ID{"deployment"}, trait.ExecutedAfter())
+	assert.True(t, trait.IsAllowedInProfile(v1.TraitProfileKubernetes))
+	assert.False(t, trait.IsAllowedInProfile(v1.TraitProfileKnative))
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	deployment.TypeMeta = metav1.TypeMeta{Kind: "Deployment", APIVersion: appsv1.SchemeGroupVersion.String()}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "integration", Image: "image"},
+		{Name: "sidecar", Image: "sidecar"},
+	}
+	monitor := &unstructured.Unstructured{}
+	monitor.SetAPIVersion("monitoring.acme.com/v1")
+	monitor.SetKind("Monitor")
+	monitor.SetName("integration-name")
+	monitor.Object["spec"] = map[string]interface{}{"interval": "30s"}
+	environment.Resources.Add(monitor)
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+
+	spec := test.TraitSpecFromMap(t, map[string]interface{}{
+		"enabled": true,
+		"team":    "payments",
+	})
+	assert.Nil(t, decodeTraitSpec(&spec, trait))
+	configured, err = trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+	assert.Nil(t, trait.Apply(environment))
+
+	assert.Equal(t, "payments", deployment.Labels["team"])
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 2)
+	assert.Equal(t, "image", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "INTEGRATION", Value: "integration-name"}}, deployment.Spec.Template.Spec.Containers[0].Env)
+	assert.Equal(t, map[string]interface{}{"interval": "30s", "team": "payments"}, monitor.Object["spec"])
+}
+
+func TestDeclarativeTraitFromAnnotations(t *testing.T) {
+	trait := newDeclarativeTraitTest(t)
+	catalog := &Catalog{traits: []Trait{trait}}
+
+	assert.Nil(t, catalog.configureTraitsFromAnnotations(map[string]string{
+		v1.TraitAnnotationPrefix + "team.enabled": "true",
+		v1.TraitAnnotationPrefix + "team.team":    "payments",
+	}))
+	assert.True(t, *trait.Enabled)
+	assert.Equal(t, "payments", trait.Parameters["team"])
+
+	assert.NotNil(t, catalog.configureTraitsFromAnnotations(map[string]string{
+		v1.TraitAnnotationPrefix + "team.enabled": "yes please",
+	}))
+}
+
+func TestRegisterDeclarativeTraits(t *testing.T) {
+	factories := FactoryList
+	defer func() { FactoryList = factories }()
+
+	err := RegisterDeclarativeTraits([]DeclarativeTraitDefinition{{Name: "container"}})
+	assert.EqualError(t, err, "declarative trait container conflicts with an existing trait")
+
+	err = RegisterDeclarativeTraits([]DeclarativeTraitDefinition{{Name: "invalid", Patches: []DeclarativeTraitPatch{{Patch: "{}"}}}})
+	assert.EqualError(t, err, "declarative trait invalid: patch #1 has no kind")
+
+	err = RegisterDeclarativeTraits([]DeclarativeTraitDefinition{{Name: "team", Description: "Sets the team"}})
+	assert.Nil(t, err)
+
+	catalog := NewCatalog(nil)
+	assert.NotNil(t, catalog.GetTrait("team"))
+	descriptions, err := catalog.Describe("team")
+	assert.Nil(t, err)
+	assert.Equal(t, "Sets the team", descriptions[0].Description)
+}
+
+func TestDeclarativeTraitWithMissingParameter(t *testing.T) {
+	trait, err := newDeclarativeTrait(DeclarativeTraitDefinition{
+		Name:    "team",
+		Enabled: true,
+		Patches: []DeclarativeTraitPatch{{Kind: "Deployment", Patch: "metadata: {labels: {team: '{{ .Parameters.team }}'}}"}},
+	})
+	assert.Nil(t, err)
+
+	environment, _ := createNominalDeploymentTraitTest()
+	environment.Resources = kubernetes.NewCollection()
+	err = trait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to render patch #1 of trait team")
+}
@@ -26,6 +26,11 @@ import (
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
+var fipsDependencies = []string{
+	"mvn:org.bouncycastle:bc-fips:1.0.2.3",
+	"mvn:org.bouncycastle:bctls-fips:1.0.12.2",
+}
+
 // The Dependencies trait is internally used to automatically add runtime dependencies based on the
 // integration that the user wants to run.
 //
@@ -64,6 +69,11 @@ func (t *dependenciesTrait) Apply(e *Environment) error {
 		dependencies.Add(d.GetDependencyID())
 	}
 
+	// Add the FIPS validated crypto providers
+	if e.fipsEnabled() {
+		dependencies.Add(fipsDependencies...)
+	}
+
 	sources, err := kubernetes.ResolveIntegrationSources(e.Ctx, e.Client, e.Integration, e.Resources)
 	if err != nil {
 		return err
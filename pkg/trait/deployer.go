@@ -18,27 +18,36 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/pkg/errors"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/audit"
 	"github.com/apache/camel-k/pkg/util/patch"
 )
 
 // The deployer trait is responsible for deploying the resources owned by the integration, and can be used
 // to explicitly select the underlying controller that will manage the integration pods.
 //
+// The controller is otherwise selected by the other traits, e.g., a Knative Service when the integration exposes HTTP services
+// with the Knative profile, or a CronJob when it only consumes from periodic endpoints. The selected controller, and the reason
+// why it has been selected, are reported by the `ControllerStrategySelected` integration condition.
+//
 // +camel-k:trait=deployer
 type deployerTrait struct {
 	BaseTrait `property:",squash"`
-	// Allows to explicitly select the desired deployment kind between `deployment`, `cron-job` or `knative-service` when creating the resources for running the integration.
+	// Allows to explicitly select the desired deployment kind between `deployment`, `stateful-set`, `cron-job` or `knative-service` when creating the resources for running the integration.
+	// The explicitly selected kind takes precedence over the controller selected by the other traits.
 	Kind string `property:"kind" json:"kind,omitempty"`
 }
 
@@ -46,6 +55,10 @@ var _ ControllerStrategySelector = &deployerTrait{}
 
 var hasServerSideApply = true
 
+const deployerFieldManager = "camel-k-operator"
+
+const deployerAuditReason = "apply integration resources"
+
 func newDeployerTrait() Trait {
 	return &deployerTrait{
 		BaseTrait: NewBaseTrait("deployer", 900),
@@ -53,12 +66,95 @@ func newDeployerTrait() Trait {
 }
 
 func (t *deployerTrait) Configure(e *Environment) (bool, error) {
-	return e.Integration != nil && IsNilOrTrue(t.Enabled), nil
+	if e.Integration == nil || IsFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if err := t.validateKind(e); err != nil {
+		e.Integration.Status.SetErrorCondition(
+			v1.IntegrationConditionControllerStrategySelected,
+			v1.IntegrationConditionControllerStrategyInvalidReason,
+			err,
+		)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// validateKind checks the explicitly selected kind is supported, and does not conflict with the configuration of the
+// traits creating the controller
+func (t *deployerTrait) validateKind(e *Environment) error {
+	if t.Kind == "" {
+		return nil
+	}
+	kind := ControllerStrategy(t.Kind)
+	if !IsValidControllerStrategy(kind) {
+		kinds := make([]string, 0, len(ControllerStrategies))
+		for _, s := range ControllerStrategies {
+			kinds = append(kinds, string(s))
+		}
+		return fmt.Errorf("unsupported deployer kind %q, must be one of: %s", t.Kind, strings.Join(kinds, ", "))
+	}
+	if e.Catalog == nil {
+		return nil
+	}
+
+	// The trait creating the controller for the selected kind must not be disabled
+	var creator ID
+	var enabled *bool
+	switch kind {
+	case ControllerStrategyDeployment, ControllerStrategyStatefulSet:
+		if trait, ok := e.Catalog.GetTrait("deployment").(*deploymentTrait); ok {
+			creator, enabled = trait.ID(), trait.Enabled
+		}
+	case ControllerStrategyKnativeService:
+		if trait, ok := e.Catalog.GetTrait("knative-service").(*knativeServiceTrait); ok {
+			if !trait.IsAllowedInProfile(e.DetermineProfile()) {
+				return fmt.Errorf("the %s deployer kind requires the %s profile", t.Kind, v1.TraitProfileKnative)
+			}
+			creator, enabled = trait.ID(), trait.Enabled
+		}
+	case ControllerStrategyCronJob:
+		if trait, ok := e.Catalog.GetTrait("cron").(*cronTrait); ok {
+			creator, enabled = trait.ID(), trait.Enabled
+		}
+	}
+	if IsFalse(enabled) {
+		return fmt.Errorf("the %s deployer kind conflicts with the %s trait being disabled", t.Kind, creator)
+	}
+
+	return nil
 }
 
 func (t *deployerTrait) Apply(e *Environment) error {
+	if e.IntegrationInRunningPhases() {
+		// Explain the controller strategy once all the traits are configured
+		e.PostProcessors = append(e.PostProcessors, func(env *Environment) error {
+			selection, err := env.ExplainControllerStrategy()
+			if err != nil {
+				return err
+			}
+			env.Integration.Status.SetCondition(
+				v1.IntegrationConditionControllerStrategySelected,
+				corev1.ConditionTrue,
+				selection.Reason,
+				fmt.Sprintf("%s controller strategy selected because %s", selection.Strategy, selection.Message),
+			)
+			return nil
+		})
+	}
+
 	// Register a post action that patches the resources generated by the traits
 	e.PostActions = append(e.PostActions, func(env *Environment) error {
+		// The drift of the resources is either corrected, by forcing the ownership of the conflicting fields,
+		// or reported, by keeping the resources as is, either for all the resources, or for those whose reconciliation is paused
+		reportDrift := false
+		if gc, ok := env.Catalog.GetTrait("gc").(*garbageCollectorTrait); ok {
+			reportDrift = gc.reportsDrift()
+		}
+		drifts := make([]string, 0)
+
 		for _, resource := range env.Resources.Items() {
 			// We assume that server-side apply is enabled by default.
 			// It is currently convoluted to check pro-actively whether server-side apply
@@ -68,7 +164,32 @@ func (t *deployerTrait) Apply(e *Environment) error {
 			// As a simpler solution, we fall back to client-side apply at the first
 			// 415 error, and assume server-side apply is not available globally.
 			if hasServerSideApply {
-				if err := t.serverSideApply(env, resource); err == nil {
+				// The changes made externally to the pod template are detected before they are possibly corrected
+				podDrift, err := t.podTemplateDrift(env, resource)
+				if err != nil {
+					return err
+				}
+				err = t.serverSideApply(env, resource, false)
+				if err != nil && k8serrors.IsConflict(errors.Cause(err)) {
+					drift, keep, derr := t.drift(env, resource, errors.Cause(err), reportDrift)
+					if derr != nil {
+						return derr
+					} else if keep {
+						drifts = append(drifts, drift)
+						if podDrift != "" {
+							drifts = append(drifts, podDrift)
+						}
+						continue
+					}
+					// The conflicting fields are corrected, while the fields added by other managers are kept,
+					// and reported by the next reconciliation
+					podDrift = ""
+					err = t.serverSideApply(env, resource, true)
+				}
+				if err == nil {
+					if podDrift != "" {
+						drifts = append(drifts, podDrift)
+					}
 					continue
 				} else if isIncompatibleServerError(err) {
 					t.L.Info("Fallback to client-side apply to patch resources")
@@ -82,26 +203,76 @@ func (t *deployerTrait) Apply(e *Environment) error {
 				return err
 			}
 		}
+
+		// The condition is also maintained once the drift of a resource, whose reconciliation is paused, has been reported
+		if hasServerSideApply && (reportDrift || len(drifts) > 0 || env.Integration.Status.GetCondition(v1.IntegrationConditionResourcesInSync) != nil) {
+			if len(drifts) > 0 {
+				env.Integration.Status.SetCondition(v1.IntegrationConditionResourcesInSync, corev1.ConditionFalse,
+					v1.IntegrationConditionResourcesDriftReason, strings.Join(drifts, "; "))
+			} else {
+				env.Integration.Status.SetCondition(v1.IntegrationConditionResourcesInSync, corev1.ConditionTrue,
+					v1.IntegrationConditionResourcesInSyncReason, "")
+			}
+		}
+
 		return nil
 	})
 
 	return nil
 }
 
-func (t *deployerTrait) serverSideApply(env *Environment, resource ctrl.Object) error {
+func (t *deployerTrait) serverSideApply(env *Environment, resource ctrl.Object, force bool) error {
 	target, err := patch.PositiveApplyPatch(resource)
 	if err != nil {
 		return err
 	}
-	err = env.Client.Patch(env.Ctx, target, ctrl.Apply, ctrl.ForceOwnership, ctrl.FieldOwner("camel-k-operator"))
+	options := []ctrl.PatchOption{ctrl.FieldOwner(deployerFieldManager)}
+	if force {
+		options = append(options, ctrl.ForceOwnership)
+	}
+	err = env.Client.Patch(audit.WithReason(env.Ctx, deployerAuditReason), target, ctrl.Apply, options...)
 	if err != nil {
 		return errors.Wrapf(err, "error during apply resource: %v", resource)
 	}
 	return nil
 }
 
+// drift computes the changes made to the given resource by other field managers, that conflict with the applied resource,
+// and returns whether they are kept, either because the drift is reported, or because the reconciliation of the resource
+// is paused
+func (t *deployerTrait) drift(env *Environment, resource ctrl.Object, conflict error, report bool) (string, bool, error) {
+	gvk := resource.GetObjectKind().GroupVersionKind()
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(gvk)
+	if err := env.Client.Get(env.Ctx, ctrl.ObjectKeyFromObject(resource), live); err != nil {
+		return "", false, err
+	}
+	desired, err := patch.PositiveApplyPatch(resource)
+	if err != nil {
+		return "", false, err
+	}
+
+	conflicts := patch.Conflicts(conflict)
+	messages := make([]string, 0, len(conflicts))
+	for i := range conflicts {
+		conflicts[i].Current, _ = patch.FieldValue(live.Object, conflicts[i].Field)
+		conflicts[i].Desired, _ = patch.FieldValue(desired.(*unstructured.Unstructured).Object, conflicts[i].Field)
+		messages = append(messages, conflicts[i].String())
+	}
+	if len(messages) == 0 {
+		messages = append(messages, conflict.Error())
+	}
+
+	keep := report || live.GetAnnotations()[v1.PauseReconciliationAnnotation] == "true"
+	t.L.ForIntegration(env.Integration).Info("Drift detected on integration resource",
+		"kind", gvk.Kind, "name", resource.GetName(), "conflicts", conflicts, "corrected", !keep)
+
+	return fmt.Sprintf("%s %s: %s", gvk.Kind, resource.GetName(), strings.Join(messages, ", ")), keep, nil
+}
+
 func (t *deployerTrait) clientSideApply(env *Environment, resource ctrl.Object) error {
-	err := env.Client.Create(env.Ctx, resource)
+	ctx := audit.WithReason(env.Ctx, deployerAuditReason)
+	err := env.Client.Create(ctx, resource)
 	if err == nil {
 		return nil
 	} else if !k8serrors.IsAlreadyExists(err) {
@@ -122,7 +293,7 @@ func (t *deployerTrait) clientSideApply(env *Environment, resource ctrl.Object)
 		// Avoid triggering a patch request for nothing
 		return nil
 	}
-	err = env.Client.Patch(env.Ctx, resource, ctrl.RawPatch(types.MergePatchType, p))
+	err = env.Client.Patch(ctx, resource, ctrl.RawPatch(types.MergePatchType, p))
 	if err != nil {
 		return errors.Wrapf(err, "error during patch resource: %v", resource)
 	}
@@ -155,6 +326,10 @@ func (t *deployerTrait) SelectControllerStrategy(e *Environment) (*ControllerStr
 	return nil, nil
 }
 
+func (t *deployerTrait) ExplainControllerStrategy(e *Environment, strategy ControllerStrategy) (string, string) {
+	return v1.IntegrationConditionControllerStrategyExplicitReason, "the deployer trait kind is explicitly set"
+}
+
 func (t *deployerTrait) ControllerStrategySelectorOrder() int {
 	return 0
 }
@@ -0,0 +1,167 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	serving "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// podTemplateDrift compares the pod template of the live workload with the one of the given resource, and returns the
+// changes made externally to the containers images, environment variables and volume mounts, if any. The live workload
+// is read from the API server, as the cache may not reflect the latest changes yet.
+func (t *deployerTrait) podTemplateDrift(env *Environment, resource ctrl.Object) (string, error) {
+	desiredSpec := podSpec(resource)
+	if desiredSpec == nil {
+		return "", nil
+	}
+	live, ok := reflect.New(reflect.TypeOf(resource).Elem()).Interface().(ctrl.Object)
+	if !ok {
+		return "", nil
+	}
+	if err := env.Client.GetAPIReader().Get(env.Ctx, ctrl.ObjectKeyFromObject(resource), live); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	drifts := podSpecDrift(desiredSpec, podSpec(live))
+	if len(drifts) == 0 {
+		return "", nil
+	}
+	t.L.ForIntegration(env.Integration).Info("Drift detected on integration pod template",
+		"kind", resource.GetObjectKind().GroupVersionKind().Kind, "name", resource.GetName(), "changes", drifts)
+
+	return fmt.Sprintf("%s %s: %s", resource.GetObjectKind().GroupVersionKind().Kind, resource.GetName(), strings.Join(drifts, ", ")), nil
+}
+
+func podSpec(object ctrl.Object) *corev1.PodSpec {
+	switch o := object.(type) {
+	case *appsv1.Deployment:
+		return &o.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		return &o.Spec.Template.Spec
+	case *serving.Service:
+		return &o.Spec.Template.Spec.PodSpec
+	case *v1beta1.CronJob:
+		return &o.Spec.JobTemplate.Spec.Template.Spec
+	}
+	return nil
+}
+
+// podSpecDrift returns the changes of the images, environment variables and volume mounts of the live containers,
+// compared to the desired ones. The values are not reported, as they may be sensitive.
+func podSpecDrift(desired, live *corev1.PodSpec) []string {
+	drifts := make([]string, 0)
+	for _, d := range desired.Containers {
+		var l *corev1.Container
+		for i := range live.Containers {
+			if live.Containers[i].Name == d.Name {
+				l = &live.Containers[i]
+			}
+		}
+		if l == nil {
+			drifts = append(drifts, fmt.Sprintf("container %s removed", d.Name))
+			continue
+		}
+
+		changes := make([]string, 0)
+		if d.Image != l.Image {
+			changes = append(changes, fmt.Sprintf("image changed to %s", l.Image))
+		}
+		changes = append(changes, envDrift(d.Env, l.Env)...)
+		changes = append(changes, volumeMountsDrift(d.VolumeMounts, l.VolumeMounts)...)
+		if len(changes) > 0 {
+			drifts = append(drifts, fmt.Sprintf("container %s %s", d.Name, strings.Join(changes, ", ")))
+		}
+	}
+	return drifts
+}
+func envDrift(desired, live []corev1.EnvVar) []string {
+	values := make(map[string]corev1.EnvVar, len(live))
+	for _, e := range live {
+		values[e.Name] = e
+	}
+	changes := make([]string, 0)
+	for _, d := range desired {
+		l, ok := values[d.Name]
+		delete(values, d.Name)
+		if !ok {
+			changes = append(changes, fmt.Sprintf("env %s removed", d.Name))
+		} else if !equality.Semantic.DeepEqual(normalizeEnvVar(d), normalizeEnvVar(l)) {
+			changes = append(changes, fmt.Sprintf("env %s changed", d.Name))
+		}
+	}
+	added := make([]string, 0, len(values))
+	for name := range values {
+		added = append(added, name)
+	}
+	sort.Strings(added)
+	for _, name := range added {
+		changes = append(changes, fmt.Sprintf("env %s added", name))
+	}
+	return changes
+}
+
+// normalizeEnvVar clears the field reference API version, that is defaulted by the API server
+func normalizeEnvVar(env corev1.EnvVar) corev1.EnvVar {
+	if env.ValueFrom != nil && env.ValueFrom.FieldRef != nil {
+		env = *env.DeepCopy()
+		env.ValueFrom.FieldRef.APIVersion = ""
+	}
+	return env
+}
+
+func volumeMountsDrift(desired, live []corev1.VolumeMount) []string {
+	mounts := make(map[string]corev1.VolumeMount, len(live))
+	for _, m := range live {
+		mounts[m.MountPath] = m
+	}
+	changes := make([]string, 0)
+	for _, d := range desired {
+		l, ok := mounts[d.MountPath]
+		delete(mounts, d.MountPath)
+		if !ok {
+			changes = append(changes, fmt.Sprintf("volume mount %s removed", d.MountPath))
+		} else if d.Name != l.Name || d.SubPath != l.SubPath || d.ReadOnly != l.ReadOnly {
+			changes = append(changes, fmt.Sprintf("volume mount %s changed", d.MountPath))
+		}
+	}
+	added := make([]string, 0, len(mounts))
+	for path := range mounts {
+		added = append(added, path)
+	}
+	sort.Strings(added)
+	for _, path := range added {
+		changes = append(changes, fmt.Sprintf("volume mount %s added", path))
+	}
+	return changes
+}
@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func newDriftDeployment(container corev1.Container) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "integration-name",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+				},
+			},
+		},
+	}
+}
+
+func newDriftEnvironment(t *testing.T, c client.Client) *Environment {
+	t.Helper()
+
+	_, environment := createNominalDeployerTest()
+	environment.Ctx = context.TODO()
+	environment.Client = c
+	return environment
+}
+
+func TestPodTemplateDrift(t *testing.T) {
+	desired := corev1.Container{
+		Name:  "integration",
+		Image: "my-image:1",
+		Env: []corev1.EnvVar{
+			{Name: "CAMEL_K_CONF", Value: "/etc/camel/conf/application.properties"},
+			{Name: "NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "i-source-000", MountPath: "/etc/camel/sources/i-source-000", ReadOnly: true},
+		},
+	}
+	live := *desired.DeepCopy()
+	live.Env[1].ValueFrom.FieldRef.APIVersion = "v1"
+
+	trait := newDeployerTrait().(*deployerTrait)
+	c, err := test.NewFakeClient(newDriftDeployment(live))
+	require.NoError(t, err)
+
+	// The defaulted fields are not reported
+	drift, err := trait.podTemplateDrift(newDriftEnvironment(t, c), newDriftDeployment(desired))
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+
+	live.Image = "other-image:1"
+	live.Env[0].Value = "/tmp/application.properties"
+	live.Env = append(live.Env, corev1.EnvVar{Name: "JAVA_DEBUG", Value: "true"})
+	live.VolumeMounts = []corev1.VolumeMount{{Name: "patch", MountPath: "/deployments/patch"}}
+	c, err = test.NewFakeClient(newDriftDeployment(live))
+	require.NoError(t, err)
+
+	drift, err = trait.podTemplateDrift(newDriftEnvironment(t, c), newDriftDeployment(desired))
+	require.NoError(t, err)
+	assert.Equal(t, "Deployment integration-name: container integration image changed to other-image:1, "+
+		"env CAMEL_K_CONF changed, env JAVA_DEBUG added, "+
+		"volume mount /etc/camel/sources/i-source-000 removed, volume mount /deployments/patch added", drift)
+
+	// The workload that does not exist yet has no drift
+	c, err = test.NewFakeClient()
+	require.NoError(t, err)
+	drift, err = trait.podTemplateDrift(newDriftEnvironment(t, c), newDriftDeployment(desired))
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+}
+
+func TestPodTemplateDriftRemovedContainer(t *testing.T) {
+	c, err := test.NewFakeClient(newDriftDeployment(corev1.Container{Name: "sidecar", Image: "sidecar:1"}))
+	require.NoError(t, err)
+
+	trait := newDeployerTrait().(*deployerTrait)
+	drift, err := trait.podTemplateDrift(newDriftEnvironment(t, c), newDriftDeployment(corev1.Container{Name: "integration", Image: "my-image:1"}))
+	require.NoError(t, err)
+	assert.Equal(t, "Deployment integration-name: container integration removed", drift)
+}
+
+func TestApplyDeployerTraitReportsPodTemplateDrift(t *testing.T) {
+	desired := corev1.Container{Name: "integration", Image: "my-image:1"}
+	live := *desired.DeepCopy()
+	live.Env = []corev1.EnvVar{{Name: "JAVA_DEBUG", Value: "true"}}
+
+	fake, err := test.NewFakeClient(newDriftDeployment(live))
+	require.NoError(t, err)
+	c := &applyingClient{Client: fake}
+
+	trait, environment := createNominalDeployerTest()
+	environment.Ctx = context.TODO()
+	environment.Client = c
+	environment.Resources = kubernetes.NewCollection(newDriftDeployment(desired))
+	require.NoError(t, trait.Apply(environment))
+
+	// The drift is detected before the resources are applied
+	require.NoError(t, environment.PostActions[0](environment))
+	assert.Equal(t, 1, c.applies)
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionResourcesInSync)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionResourcesDriftReason, condition.Reason)
+	assert.Equal(t, "Deployment integration-name: container integration env JAVA_DEBUG added", condition.Message)
+
+	// The condition is reset once the workload is back in sync
+	fake, err = test.NewFakeClient(newDriftDeployment(desired))
+	require.NoError(t, err)
+	environment.Client = &applyingClient{Client: fake}
+	require.NoError(t, environment.PostActions[0](environment))
+	condition = environment.Integration.Status.GetCondition(v1.IntegrationConditionResourcesInSync)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+}
+
+// applyingClient accepts the server-side apply requests without applying them
+type applyingClient struct {
+	client.Client
+	applies int
+}
+
+func (c *applyingClient) Patch(ctx context.Context, obj ctrl.Object, patch ctrl.Patch, opts ...ctrl.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	c.applies++
+	return nil
+}
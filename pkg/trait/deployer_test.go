@@ -18,12 +18,26 @@ limitations under the License.
 package trait
 
 import (
+	"context"
 	"testing"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
 	"github.com/stretchr/testify/assert"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	serving "knative.dev/serving/pkg/apis/serving/v1"
 )
 
 func TestConfigureDeployerTraitDoesSucceed(t *testing.T) {
@@ -81,3 +95,289 @@ func createNominalDeployerTest() (*deployerTrait, *Environment) {
 
 	return trait, environment
 }
+
+func TestApplyDeployerTraitCorrectsDrift(t *testing.T) {
+	environment, c := createDriftDeployerTest(t, nil)
+
+	assert.Nil(t, environment.PostActions[0](environment))
+	assert.Equal(t, []bool{false, true}, c.applies)
+	assert.Nil(t, environment.Integration.Status.GetCondition(v1.IntegrationConditionResourcesInSync))
+}
+
+func TestApplyDeployerTraitReportsDriftWhenReconciliationIsPaused(t *testing.T) {
+	environment, c := createDriftDeployerTest(t, map[string]string{v1.PauseReconciliationAnnotation: "true"})
+
+	assert.Nil(t, environment.PostActions[0](environment))
+	assert.Equal(t, []bool{false}, c.applies)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionResourcesInSync)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionResourcesDriftReason, condition.Reason)
+	assert.Equal(t, `Deployment integration-name: .spec.replicas managed by "kubectl-edit" (current: 3, desired: 1)`, condition.Message)
+}
+
+func createDriftDeployerTest(t *testing.T, annotations map[string]string) (*Environment, *conflictingClient) {
+	t.Helper()
+
+	replicas := int32(3)
+	live := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "integration-name",
+			Annotations: annotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	}
+	fake, err := test.NewFakeClient(live)
+	assert.Nil(t, err)
+	c := &conflictingClient{Client: fake}
+
+	desired := live.DeepCopy()
+	desired.Annotations = nil
+	replicas = 1
+	desired.Spec.Replicas = &replicas
+
+	trait, environment := createNominalDeployerTest()
+	environment.Ctx = context.TODO()
+	environment.Client = c
+	environment.Resources = kubernetes.NewCollection(desired)
+	assert.Nil(t, trait.Apply(environment))
+
+	return environment, c
+}
+
+// conflictingClient reports a conflict on the replicas field for the server-side apply requests that are not forced
+type conflictingClient struct {
+	client.Client
+	applies []bool
+}
+
+func (c *conflictingClient) Patch(ctx context.Context, obj ctrl.Object, patch ctrl.Patch, opts ...ctrl.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	options := ctrl.PatchOptions{}
+	options.ApplyOptions(opts)
+	force := options.Force != nil && *options.Force
+	c.applies = append(c.applies, force)
+	if force {
+		return nil
+	}
+	err := k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj.GetName(), nil)
+	err.ErrStatus.Details.Causes = []metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-edit" using apps/v1`,
+			Field:   ".spec.replicas",
+		},
+	}
+	return err
+}
+
+func TestDeployerControllerStrategyCondition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		profile  v1.TraitProfile
+		route    string
+		traits   map[string]map[string]interface{}
+		strategy ControllerStrategy
+		reason   string
+		message  string
+	}{
+		{
+			name:     "default",
+			profile:  v1.TraitProfileKubernetes,
+			route:    `from("timer:tick?period=5000").log("hello")`,
+			strategy: ControllerStrategyDeployment,
+			reason:   v1.IntegrationConditionControllerStrategyDefaultReason,
+			message:  "deployment controller strategy selected because no other trait selected a controller strategy",
+		},
+		{
+			name:     "scheduled",
+			profile:  v1.TraitProfileKubernetes,
+			route:    `from("timer:tick?period=60000").log("hello")`,
+			strategy: ControllerStrategyCronJob,
+			reason:   v1.IntegrationConditionControllerStrategyScheduleReason,
+			message: "cron-job controller strategy selected because the integration only consumes from periodic endpoints, " +
+				"that can be scheduled with a CronJob",
+		},
+		{
+			name:     "http services",
+			profile:  v1.TraitProfileKnative,
+			route:    `from("platform-http:/hello").log("hello")`,
+			strategy: ControllerStrategyKnativeService,
+			reason:   v1.IntegrationConditionControllerStrategyHTTPServicesReason,
+			message:  "knative-service controller strategy selected because the integration exposes HTTP services",
+		},
+		{
+			name:     "explicit kind",
+			profile:  v1.TraitProfileKnative,
+			route:    `from("platform-http:/hello").log("hello")`,
+			traits:   map[string]map[string]interface{}{"deployer": {"kind": "deployment"}},
+			strategy: ControllerStrategyDeployment,
+			reason:   v1.IntegrationConditionControllerStrategyExplicitReason,
+			message: "deployment controller strategy selected because the deployer trait kind is explicitly set, " +
+				"overriding the knative-service controller strategy selected by the knative-service trait (the integration exposes HTTP services)",
+		},
+		{
+			name:     "explicit kind without heuristic",
+			profile:  v1.TraitProfileKubernetes,
+			route:    `from("timer:tick?period=5000").log("hello")`,
+			traits:   map[string]map[string]interface{}{"deployer": {"kind": "stateful-set"}},
+			strategy: ControllerStrategyStatefulSet,
+			reason:   v1.IntegrationConditionControllerStrategyExplicitReason,
+			message:  "stateful-set controller strategy selected because the deployer trait kind is explicitly set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			environment := createControllerStrategyTestEnvironment(t, tc.profile, tc.route, tc.traits)
+
+			assert.Nil(t, environment.Catalog.apply(environment))
+
+			strategy, err := environment.DetermineControllerStrategy()
+			assert.Nil(t, err)
+			assert.Equal(t, tc.strategy, strategy)
+
+			condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionControllerStrategySelected)
+			assert.NotNil(t, condition)
+			assert.Equal(t, corev1.ConditionTrue, condition.Status)
+			assert.Equal(t, tc.reason, condition.Reason)
+			assert.Equal(t, tc.message, condition.Message)
+		})
+	}
+}
+
+func TestDeployerExplicitKindCreatesController(t *testing.T) {
+	environment := createControllerStrategyTestEnvironment(t, v1.TraitProfileKnative, `from("platform-http:/hello").log("hello")`,
+		map[string]map[string]interface{}{"deployer": {"kind": "deployment"}})
+
+	assert.Nil(t, environment.Catalog.apply(environment))
+
+	assert.NotNil(t, environment.Resources.GetDeploymentForIntegration(environment.Integration))
+	assert.Nil(t, environment.Resources.GetKnativeService(func(*serving.Service) bool { return true }))
+}
+
+func TestDeployerInvalidKind(t *testing.T) {
+	testCases := []struct {
+		name    string
+		profile v1.TraitProfile
+		traits  map[string]map[string]interface{}
+		err     string
+	}{
+		{
+			name:    "unsupported kind",
+			profile: v1.TraitProfileKubernetes,
+			traits:  map[string]map[string]interface{}{"deployer": {"kind": "replica-set"}},
+			err:     `unsupported deployer kind "replica-set", must be one of: deployment, stateful-set, knative-service, cron-job`,
+		},
+		{
+			name:    "knative service without knative profile",
+			profile: v1.TraitProfileKubernetes,
+			traits:  map[string]map[string]interface{}{"deployer": {"kind": "knative-service"}},
+			err:     "the knative-service deployer kind requires the Knative profile",
+		},
+		{
+			name:    "disabled cron trait",
+			profile: v1.TraitProfileKubernetes,
+			traits: map[string]map[string]interface{}{
+				"deployer": {"kind": "cron-job"},
+				"cron":     {"enabled": false},
+			},
+			err: "the cron-job deployer kind conflicts with the cron trait being disabled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			environment := createControllerStrategyTestEnvironment(t, tc.profile, `from("timer:tick?period=5000").log("hello")`, tc.traits)
+
+			err := environment.Catalog.apply(environment)
+			assert.NotNil(t, err)
+			assert.Equal(t, tc.err, err.Error())
+
+			condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionControllerStrategySelected)
+			assert.NotNil(t, condition)
+			assert.Equal(t, corev1.ConditionFalse, condition.Status)
+			assert.Equal(t, v1.IntegrationConditionControllerStrategyInvalidReason, condition.Reason)
+			assert.Equal(t, tc.err, condition.Message)
+		})
+	}
+}
+
+func TestDeployerCronJobKindWithoutSchedule(t *testing.T) {
+	environment := createControllerStrategyTestEnvironment(t, v1.TraitProfileKubernetes, `from("timer:tick?period=5000").log("hello")`,
+		map[string]map[string]interface{}{"deployer": {"kind": "cron-job"}})
+
+	err := environment.Catalog.apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "the cron-job controller strategy requires a schedule, set with the cron trait schedule", err.Error())
+}
+
+func createControllerStrategyTestEnvironment(t *testing.T, profile v1.TraitProfile, route string,
+	traits map[string]map[string]interface{}) *Environment {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	traitSpecs := make(map[string]v1.TraitSpec)
+	for id, configuration := range traits {
+		traitSpecs[id] = test.TraitSpecFromMap(t, configuration)
+	}
+
+	environment := &Environment{
+		CamelCatalog: catalog,
+		Catalog:      NewCatalog(nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: profile,
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.js",
+							Content: route,
+						},
+						Language: v1.LanguageJavaScript,
+					},
+				},
+				Traits: traitSpecs,
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterOpenShift,
+				Build: v1.IntegrationPlatformBuildSpec{
+					PublishStrategy: v1.IntegrationPlatformBuildPublishStrategyS2I,
+					Registry:        v1.IntegrationPlatformRegistrySpec{Address: "registry"},
+				},
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      kubernetes.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	return environment
+}
@@ -31,6 +31,9 @@ import (
 // The Deployment trait is responsible for generating the Kubernetes deployment that will make sure
 // the integration will run in the cluster.
 //
+// A StatefulSet is generated instead when the `stateful-set` controller strategy is selected,
+// e.g. by the persistent-state trait, so that each replica gets its own stable identity and storage.
+//
 // +camel-k:trait=deployment
 type deploymentTrait struct {
 	BaseTrait `property:",squash"`
@@ -40,7 +43,7 @@ var _ ControllerStrategySelector = &deploymentTrait{}
 
 func newDeploymentTrait() Trait {
 	return &deploymentTrait{
-		BaseTrait: NewBaseTrait("deployment", 1100),
+		BaseTrait: NewBaseTrait("deployment", 1100, ExecuteAfter(serviceAccountTraitID)),
 	}
 }
 
@@ -58,6 +61,10 @@ func (t *deploymentTrait) Configure(e *Environment) (bool, error) {
 
 	if e.IntegrationInPhase(v1.IntegrationPhaseRunning, v1.IntegrationPhaseError) {
 		condition := e.Integration.Status.GetCondition(v1.IntegrationConditionDeploymentAvailable)
+		if condition != nil && condition.Status == corev1.ConditionTrue {
+			return true, nil
+		}
+		condition = e.Integration.Status.GetCondition(v1.IntegrationConditionStatefulSetAvailable)
 		return condition != nil && condition.Status == corev1.ConditionTrue, nil
 	}
 
@@ -80,6 +87,16 @@ func (t *deploymentTrait) Configure(e *Environment) (bool, error) {
 			v1.IntegrationConditionDeploymentAvailableReason,
 			"controller strategy: "+string(strategy),
 		)
+	}
+	if strategy != ControllerStrategyStatefulSet && e.Integration.Status.GetCondition(v1.IntegrationConditionStatefulSetAvailable) != nil {
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionStatefulSetAvailable,
+			corev1.ConditionFalse,
+			v1.IntegrationConditionStatefulSetAvailableReason,
+			"controller strategy: "+string(strategy),
+		)
+	}
+	if strategy != ControllerStrategyDeployment && strategy != ControllerStrategyStatefulSet {
 		return false, nil
 	}
 
@@ -94,11 +111,29 @@ func (t *deploymentTrait) SelectControllerStrategy(e *Environment) (*ControllerS
 	return &deploymentStrategy, nil
 }
 
+func (t *deploymentTrait) ExplainControllerStrategy(e *Environment, strategy ControllerStrategy) (string, string) {
+	return v1.IntegrationConditionControllerStrategyDefaultReason, "no other trait selected a controller strategy"
+}
+
 func (t *deploymentTrait) ControllerStrategySelectorOrder() int {
 	return 10000
 }
 
 func (t *deploymentTrait) Apply(e *Environment) error {
+	if t.isStatefulSet(e) {
+		statefulSet := t.getStatefulSetFor(e)
+		e.Resources.Add(statefulSet)
+
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionStatefulSetAvailable,
+			corev1.ConditionTrue,
+			v1.IntegrationConditionStatefulSetAvailableReason,
+			fmt.Sprintf("statefulset name is %s", statefulSet.Name),
+		)
+
+		return nil
+	}
+
 	deployment := t.getDeploymentFor(e)
 	e.Resources.Add(deployment)
 
@@ -117,6 +152,39 @@ func (t *deploymentTrait) IsPlatformTrait() bool {
 	return true
 }
 
+func (t *deploymentTrait) isStatefulSet(e *Environment) bool {
+	if e.IntegrationInPhase(v1.IntegrationPhaseRunning, v1.IntegrationPhaseError) {
+		condition := e.Integration.Status.GetCondition(v1.IntegrationConditionStatefulSetAvailable)
+		return condition != nil && condition.Status == corev1.ConditionTrue
+	}
+
+	strategy, err := e.DetermineControllerStrategy()
+	return err == nil && strategy == ControllerStrategyStatefulSet
+}
+
+func (t *deploymentTrait) getStatefulSetFor(e *Environment) *appsv1.StatefulSet {
+	deployment := t.getDeploymentFor(e)
+
+	statefulSet := appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: deployment.ObjectMeta,
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: deployment.Spec.Replicas,
+			Selector: deployment.Spec.Selector,
+			Template: deployment.Spec.Template,
+			// The governing service is the one created for the integration, if any
+			ServiceName: e.Integration.Name,
+			// Replicas don't depend on each other, so there is no need to start them one after the other
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+		},
+	}
+
+	return &statefulSet
+}
+
 func (t *deploymentTrait) getDeploymentFor(e *Environment) *appsv1.Deployment {
 	// create a copy to avoid sharing the underlying annotation map
 	annotations := make(map[string]string)
@@ -152,7 +220,7 @@ func (t *deploymentTrait) getDeploymentFor(e *Environment) *appsv1.Deployment {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: e.Integration.Spec.ServiceAccountName,
+					ServiceAccountName: e.GetIntegrationServiceAccountName(),
 				},
 			},
 		},
@@ -0,0 +1,142 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The DNS trait configures how the integration pods resolve host names, such as the DNS policy,
+// the DNS resolver configuration, or additional entries in the pods `/etc/hosts` file.
+//
+// Note that Knative requires the `kubernetes.podspec-dnspolicy`, `kubernetes.podspec-dnsconfig`
+// and `kubernetes.podspec-hostaliases` features to be enabled, for these settings to be applied to Knative Services.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=dns
+type dnsTrait struct {
+	BaseTrait `property:",squash"`
+	// The DNS policy of the integration pods, either `ClusterFirst`, `ClusterFirstWithHostNet`, `Default` or `None`.
+	// The `None` policy requires the name servers to be provided.
+	Policy string `property:"policy" json:"policy,omitempty"`
+	// The IP addresses of the name servers used by the integration pods.
+	Nameservers []string `property:"nameservers" json:"nameservers,omitempty"`
+	// The DNS search domains for host names lookup.
+	Searches []string `property:"searches" json:"searches,omitempty"`
+	// The DNS resolver options, in the form of `name[=value]`, e.g. `ndots=2`.
+	Options []string `property:"options" json:"options,omitempty"`
+	// The entries added to the pods `/etc/hosts` file, in the form of `ip=hostname[,hostname...]`,
+	// e.g. `10.0.0.10=db.example.com,db`.
+	HostAliases []string `property:"host-aliases" json:"hostAliases,omitempty"`
+}
+
+func newDNSTrait() Trait {
+	return &dnsTrait{
+		BaseTrait: NewBaseTrait("dns", 1220),
+	}
+}
+
+func (t *dnsTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	switch corev1.DNSPolicy(t.Policy) {
+	case "", corev1.DNSClusterFirst, corev1.DNSClusterFirstWithHostNet, corev1.DNSDefault:
+	case corev1.DNSNone:
+		if len(t.Nameservers) == 0 {
+			return false, fmt.Errorf("no name servers were provided for the %s DNS policy", corev1.DNSNone)
+		}
+	default:
+		return false, fmt.Errorf("unsupported DNS policy: %s", t.Policy)
+	}
+	for _, ns := range t.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return false, fmt.Errorf("invalid name server IP address: %s", ns)
+		}
+	}
+	if _, err := t.getHostAliases(); err != nil {
+		return false, err
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *dnsTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	if t.Policy != "" {
+		podSpec.DNSPolicy = corev1.DNSPolicy(t.Policy)
+	}
+
+	if len(t.Nameservers) > 0 || len(t.Searches) > 0 || len(t.Options) > 0 {
+		if podSpec.DNSConfig == nil {
+			podSpec.DNSConfig = &corev1.PodDNSConfig{}
+		}
+		podSpec.DNSConfig.Nameservers = append(podSpec.DNSConfig.Nameservers, t.Nameservers...)
+		podSpec.DNSConfig.Searches = append(podSpec.DNSConfig.Searches, t.Searches...)
+		for _, option := range t.Options {
+			name, value, ok := splitOnce(option, "=")
+			o := corev1.PodDNSConfigOption{Name: name}
+			if ok {
+				o.Value = &value
+			}
+			podSpec.DNSConfig.Options = append(podSpec.DNSConfig.Options, o)
+		}
+	}
+
+	hostAliases, err := t.getHostAliases()
+	if err != nil {
+		return err
+	}
+	podSpec.HostAliases = append(podSpec.HostAliases, hostAliases...)
+
+	return nil
+}
+
+// getHostAliases parses the host aliases, grouping the host names by IP address
+func (t *dnsTrait) getHostAliases() ([]corev1.HostAlias, error) {
+	hostAliases := make([]corev1.HostAlias, 0, len(t.HostAliases))
+	indexes := make(map[string]int)
+	for _, alias := range t.HostAliases {
+		ip, hostnames, _ := splitOnce(alias, "=")
+		if net.ParseIP(ip) == nil || hostnames == "" {
+			return nil, fmt.Errorf("invalid host alias: %s, must be ip=hostname[,hostname...]", alias)
+		}
+		i, ok := indexes[ip]
+		if !ok {
+			i = len(hostAliases)
+			indexes[ip] = i
+			hostAliases = append(hostAliases, corev1.HostAlias{IP: ip})
+		}
+		for _, hostname := range strings.Split(hostnames, ",") {
+			if hostname = strings.TrimSpace(hostname); hostname != "" {
+				hostAliases[i].Hostnames = append(hostAliases[i].Hostnames, hostname)
+			}
+		}
+	}
+	return hostAliases, nil
+}
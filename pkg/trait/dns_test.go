@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConfigureDNSTraitDisabledByDefault(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	dnsTrait := newDNSTrait().(*dnsTrait)
+
+	configured, err := dnsTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureDNSTraitInvalidConfiguration(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+
+	dnsTrait := createNominalDNSTrait()
+	dnsTrait.Policy = "ClusterLast"
+	_, err := dnsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	dnsTrait = createNominalDNSTrait()
+	dnsTrait.Policy = "None"
+	_, err = dnsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	dnsTrait = createNominalDNSTrait()
+	dnsTrait.Nameservers = []string{"dns.example.com"}
+	_, err = dnsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	dnsTrait = createNominalDNSTrait()
+	dnsTrait.HostAliases = []string{"db.example.com"}
+	_, err = dnsTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestApplyDNSTraitMissingDeployment(t *testing.T) {
+	dnsTrait := createNominalDNSTrait()
+	dnsTrait.Policy = "Default"
+
+	environment := createNominalMissingDeploymentTraitTest()
+	err := dnsTrait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestApplyDNSTrait(t *testing.T) {
+	dnsTrait := createNominalDNSTrait()
+	dnsTrait.Policy = "None"
+	dnsTrait.Nameservers = []string{"10.0.0.2"}
+	dnsTrait.Searches = []string{"example.com"}
+	dnsTrait.Options = []string{"ndots=2", "edns0"}
+	dnsTrait.HostAliases = []string{"10.0.0.10=db.example.com,db", "10.0.0.11=mq.example.com", "10.0.0.10=database"}
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	testApplyDNSTrait(t, dnsTrait, environment, &deployment.Spec.Template.Spec)
+
+	environment, knativeService := createNominalKnativeServiceTraitTest()
+	testApplyDNSTrait(t, dnsTrait, environment, &knativeService.Spec.Template.Spec.PodSpec)
+
+	environment, cronJob := createNominalCronJobTraitTest()
+	testApplyDNSTrait(t, dnsTrait, environment, &cronJob.Spec.JobTemplate.Spec.Template.Spec)
+}
+
+func testApplyDNSTrait(t *testing.T, trait *dnsTrait, environment *Environment, podSpec *corev1.PodSpec) {
+	t.Helper()
+
+	err := trait.Apply(environment)
+
+	assert.Nil(t, err)
+	assert.Equal(t, corev1.DNSNone, podSpec.DNSPolicy)
+	ndots := "2"
+	assert.Equal(t, &corev1.PodDNSConfig{
+		Nameservers: []string{"10.0.0.2"},
+		Searches:    []string{"example.com"},
+		Options: []corev1.PodDNSConfigOption{
+			{Name: "ndots", Value: &ndots},
+			{Name: "edns0"},
+		},
+	}, podSpec.DNSConfig)
+	assert.Equal(t, []corev1.HostAlias{
+		{IP: "10.0.0.10", Hostnames: []string{"db.example.com", "db", "database"}},
+		{IP: "10.0.0.11", Hostnames: []string{"mq.example.com"}},
+	}, podSpec.HostAliases)
+}
+
+func createNominalDNSTrait() *dnsTrait {
+	dnsTrait := newDNSTrait().(*dnsTrait)
+	dnsTrait.Enabled = BoolP(true)
+
+	return dnsTrait
+}
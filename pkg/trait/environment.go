@@ -18,6 +18,14 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/envvar"
 	"github.com/apache/camel-k/pkg/util/property"
@@ -26,6 +34,13 @@ import (
 // The environment trait is used internally to inject standard environment variables in the integration container,
 // such as `NAMESPACE`, `POD_NAME` and others.
 //
+// It also creates the user-defined variables, either with literal values, that can reference the `{{integration.name}}`,
+// `{{integration.namespace}}`, `{{integration.generation}}`, `{{camel-k.version}}` and `{{runtime.version}}` variables,
+// the other `{{...}}` references being left as-is, or with the values of the pod fields and container resources, and injects all the entries of ConfigMaps and Secrets.
+//
+// Note that Knative Services don't support the variables set from the pod fields, except `metadata.namespace`,
+// nor from the container resources.
+//
 // +camel-k:trait=environment
 type environmentTrait struct {
 	BaseTrait `property:",squash"`
@@ -33,6 +48,14 @@ type environmentTrait struct {
 	ContainerMeta *bool `property:"container-meta" json:"containerMeta,omitempty"`
 	// A list of variables to be created on the Pod. Must have KEY=VALUE syntax (ie, MY_VAR="my value").
 	Vars []string `property:"vars" json:"vars,omitempty"`
+	// A list of ConfigMaps and Secrets, whose entries are all created as variables on the Pod.
+	// Must have `configmap:<name>` or `secret:<name>` syntax, optionally with a prefix for the variable names
+	// (ie, configmap:my-config?prefix=APP_).
+	EnvFrom []string `property:"env-from" json:"envFrom,omitempty"`
+	// A list of variables set from the Pod fields. Must have KEY=FIELD_PATH syntax (ie, NODE_NAME=spec.nodeName).
+	FieldRefs []string `property:"field-refs" json:"fieldRefs,omitempty"`
+	// A list of variables set from the container resources. Must have KEY=RESOURCE[/DIVISOR] syntax (ie, MEMORY_LIMIT=limits.memory/1Mi).
+	ResourceFieldRefs []string `property:"resource-field-refs" json:"resourceFieldRefs,omitempty"`
 }
 
 const (
@@ -52,6 +75,13 @@ const (
 	envVarMountPathSecrets = "CAMEL_K_MOUNT_PATH_SECRETS"
 )
 
+var (
+	envVarTemplateRegexp  = regexp.MustCompile(`\{\{\s*([\w.\-]+)\s*}}`)
+	envVarFieldPathRegexp = regexp.MustCompile(`^(metadata\.(name|namespace|uid)|metadata\.(labels|annotations)\['[^']+'\]|` +
+		`spec\.(nodeName|serviceAccountName)|status\.(hostIP|podIP|podIPs))$`)
+	envVarResourceRegexp = regexp.MustCompile(`^(limits|requests)\.(cpu|memory|ephemeral-storage)$`)
+)
+
 func newEnvironmentTrait() Trait {
 	return &environmentTrait{
 		BaseTrait:     NewBaseTrait("environment", 800),
@@ -61,6 +91,22 @@ func newEnvironmentTrait() Trait {
 
 func (t *environmentTrait) Configure(e *Environment) (bool, error) {
 	if IsNilOrTrue(t.Enabled) {
+		for _, ref := range t.EnvFrom {
+			if _, err := parseEnvFromSource(ref); err != nil {
+				return false, err
+			}
+		}
+		for _, ref := range t.FieldRefs {
+			if _, err := parseFieldRefEnvVar(ref); err != nil {
+				return false, err
+			}
+		}
+		for _, ref := range t.ResourceFieldRefs {
+			if _, err := parseResourceFieldRefEnvVar(ref); err != nil {
+				return false, err
+			}
+		}
+
 		return e.IntegrationInRunningPhases(), nil
 	}
 
@@ -84,13 +130,117 @@ func (t *environmentTrait) Apply(e *Environment) error {
 	if t.Vars != nil {
 		for _, env := range t.Vars {
 			k, v := property.SplitPropertyFileEntry(env)
-			envvar.SetVal(&e.EnvVars, k, v)
+			envvar.SetVal(&e.EnvVars, k, t.resolveTemplate(e, v))
 		}
 	}
 
+	for _, ref := range t.FieldRefs {
+		env, err := parseFieldRefEnvVar(ref)
+		if err != nil {
+			return err
+		}
+		envvar.SetVar(&e.EnvVars, env)
+	}
+	for _, ref := range t.ResourceFieldRefs {
+		env, err := parseResourceFieldRefEnvVar(ref)
+		if err != nil {
+			return err
+		}
+		envvar.SetVar(&e.EnvVars, env)
+	}
+	for _, ref := range t.EnvFrom {
+		source, err := parseEnvFromSource(ref)
+		if err != nil {
+			return err
+		}
+		e.EnvFrom = append(e.EnvFrom, source)
+	}
+
 	return nil
 }
 
+// resolveTemplate replaces the references to the supported `{{variable}}` in the given value. The other references
+// are left as-is, e.g., to be resolved by Camel as property placeholders.
+func (t *environmentTrait) resolveTemplate(e *Environment, value string) string {
+	variables := map[string]string{
+		"camel-k.version": defaults.Version,
+		"runtime.version": e.RuntimeVersion,
+	}
+	if e.Integration != nil {
+		variables["integration.name"] = e.Integration.Name
+		variables["integration.namespace"] = e.Integration.Namespace
+		variables["integration.generation"] = strconv.FormatInt(e.Integration.Generation, 10)
+	}
+
+	return envVarTemplateRegexp.ReplaceAllStringFunc(value, func(match string) string {
+		if v, ok := variables[envVarTemplateRegexp.FindStringSubmatch(match)[1]]; ok {
+			return v
+		}
+		return match
+	})
+}
+
+// parseEnvFromSource parses a `configmap:<name>` or `secret:<name>` reference, with an optional `prefix` parameter
+func parseEnvFromSource(ref string) (corev1.EnvFromSource, error) {
+	source := corev1.EnvFromSource{}
+	value, query, _ := splitOnce(ref, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return source, fmt.Errorf("invalid environment variables reference %s: %v", ref, err)
+	}
+	source.Prefix = params.Get("prefix")
+
+	kind, name, _ := splitOnce(value, ":")
+	switch {
+	case name == "":
+		return source, fmt.Errorf("invalid environment variables reference %s: must be configmap:<name> or secret:<name>", ref)
+	case kind == "configmap":
+		source.ConfigMapRef = &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}
+	case kind == "secret":
+		source.SecretRef = &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}
+	default:
+		return source, fmt.Errorf("unsupported environment variables reference %s: must be configmap:<name> or secret:<name>", ref)
+	}
+	return source, nil
+}
+
+// parseFieldRefEnvVar parses a `KEY=FIELD_PATH` variable definition
+func parseFieldRefEnvVar(ref string) (corev1.EnvVar, error) {
+	name, fieldPath, _ := splitOnce(ref, "=")
+	if name == "" || !envVarFieldPathRegexp.MatchString(fieldPath) {
+		return corev1.EnvVar{}, fmt.Errorf("invalid field reference %s: must be KEY=FIELD_PATH, with a supported field path", ref)
+	}
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath},
+		},
+	}, nil
+}
+
+// parseResourceFieldRefEnvVar parses a `KEY=RESOURCE[/DIVISOR]` variable definition
+func parseResourceFieldRefEnvVar(ref string) (corev1.EnvVar, error) {
+	name, value, _ := splitOnce(ref, "=")
+	res, divisor, _ := splitOnce(value, "/")
+	if name == "" || !envVarResourceRegexp.MatchString(res) {
+		return corev1.EnvVar{}, fmt.Errorf("invalid resource field reference %s: must be KEY=RESOURCE[/DIVISOR], with a supported resource", ref)
+	}
+	selector := &corev1.ResourceFieldSelector{Resource: res}
+	if divisor != "" {
+		quantity, err := resource.ParseQuantity(divisor)
+		if err != nil {
+			return corev1.EnvVar{}, fmt.Errorf("invalid resource field reference %s: %v", ref, err)
+		}
+		selector.Divisor = quantity
+	}
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			ResourceFieldRef: selector,
+		},
+	}, nil
+}
+
 // IsPlatformTrait overrides base class method
 func (t *environmentTrait) IsPlatformTrait() bool {
 	return true
@@ -24,10 +24,12 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/envvar"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/test"
 )
@@ -189,6 +191,114 @@ func TestCustomEnvVars(t *testing.T) {
 	assert.True(t, userK2)
 }
 
+func TestTemplatedEnvVars(t *testing.T) {
+	c, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	env := mockEnvironment(c)
+	env.Integration.Name = "my-integration"
+	env.Integration.Namespace = "ns"
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"environment": test.TraitSpecFromMap(t, map[string]interface{}{
+			"vars": []string{"APP_ID={{integration.namespace}}-{{ integration.name }}"},
+		}),
+	}
+	env.Platform.ResyncStatusFullConfig()
+
+	err = NewEnvironmentTestCatalog().apply(&env)
+	assert.Nil(t, err)
+
+	deployment := env.Resources.GetDeploymentForIntegration(env.Integration)
+	assert.NotNil(t, deployment)
+	appID := envvar.Get(deployment.Spec.Template.Spec.Containers[0].Env, "APP_ID")
+	assert.NotNil(t, appID)
+	assert.Equal(t, "ns-my-integration", appID.Value)
+}
+
+func TestTemplatedEnvVarsWithUnsupportedVariable(t *testing.T) {
+	c, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	env := mockEnvironment(c)
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"environment": test.TraitSpecFromMap(t, map[string]interface{}{
+			"vars": []string{"APP_ID={{integration.name}}-{{app.suffix}}"},
+		}),
+	}
+	env.Platform.ResyncStatusFullConfig()
+
+	err = NewEnvironmentTestCatalog().apply(&env)
+	assert.Nil(t, err)
+
+	deployment := env.Resources.GetDeploymentForIntegration(env.Integration)
+	assert.NotNil(t, deployment)
+	appID := envvar.Get(deployment.Spec.Template.Spec.Containers[0].Env, "APP_ID")
+	assert.NotNil(t, appID)
+	assert.Equal(t, env.Integration.Name+"-{{app.suffix}}", appID.Value)
+}
+
+func TestEnvFromAndFieldRefEnvVars(t *testing.T) {
+	c, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	env := mockEnvironment(c)
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"environment": test.TraitSpecFromMap(t, map[string]interface{}{
+			"envFrom":           []string{"configmap:my-config?prefix=APP_", "secret:my-secret"},
+			"fieldRefs":         []string{"NODE_NAME=spec.nodeName", "APP_VERSION=metadata.labels['app.kubernetes.io/version']"},
+			"resourceFieldRefs": []string{"MEMORY_LIMIT=limits.memory/1Mi"},
+		}),
+	}
+	env.Platform.ResyncStatusFullConfig()
+
+	err = NewEnvironmentTestCatalog().apply(&env)
+	assert.Nil(t, err)
+
+	deployment := env.Resources.GetDeploymentForIntegration(env.Integration)
+	assert.NotNil(t, deployment)
+	container := deployment.Spec.Template.Spec.Containers[0]
+
+	assert.Equal(t, []corev1.EnvFromSource{
+		{Prefix: "APP_", ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"}}},
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"}}},
+	}, container.EnvFrom)
+
+	nodeName := envvar.Get(container.Env, "NODE_NAME")
+	assert.NotNil(t, nodeName)
+	assert.Equal(t, "spec.nodeName", nodeName.ValueFrom.FieldRef.FieldPath)
+	version := envvar.Get(container.Env, "APP_VERSION")
+	assert.NotNil(t, version)
+	assert.Equal(t, "metadata.labels['app.kubernetes.io/version']", version.ValueFrom.FieldRef.FieldPath)
+	memory := envvar.Get(container.Env, "MEMORY_LIMIT")
+	assert.NotNil(t, memory)
+	assert.Equal(t, "limits.memory", memory.ValueFrom.ResourceFieldRef.Resource)
+	assert.Equal(t, resource.MustParse("1Mi"), memory.ValueFrom.ResourceFieldRef.Divisor)
+}
+
+func TestInvalidEnvFromAndFieldRefEnvVars(t *testing.T) {
+	env := mockEnvironment(nil)
+
+	envTrait := newEnvironmentTrait().(*environmentTrait)
+	envTrait.EnvFrom = []string{"volume:my-config"}
+	_, err := envTrait.Configure(&env)
+	assert.NotNil(t, err)
+
+	envTrait = newEnvironmentTrait().(*environmentTrait)
+	envTrait.FieldRefs = []string{"NODE_NAME=spec.containers"}
+	_, err = envTrait.Configure(&env)
+	assert.NotNil(t, err)
+
+	envTrait = newEnvironmentTrait().(*environmentTrait)
+	envTrait.ResourceFieldRefs = []string{"GPU=limits.nvidia.com/gpu"}
+	_, err = envTrait.Configure(&env)
+	assert.NotNil(t, err)
+
+	envTrait = newEnvironmentTrait().(*environmentTrait)
+	envTrait.ResourceFieldRefs = []string{"MEMORY_LIMIT=limits.memory/lots"}
+	_, err = envTrait.Configure(&env)
+	assert.NotNil(t, err)
+}
+
 func NewEnvironmentTestCatalog() *Catalog {
 	return NewCatalog(nil)
 }
@@ -19,6 +19,7 @@ package trait
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -29,11 +30,29 @@ import (
 
 // The error-handler is a platform trait used to inject Error Handler source into the integration runtime.
 //
+// Besides referencing an error handler bean, it can configure the default error handler of any integration,
+// e.g. a `dead-letter-channel` error handler, that sends the failed messages to the `dead-letter-uri` endpoint,
+// once the redelivery attempts are exhausted.
+//
 // +camel-k:trait=error-handler
 type errorHandlerTrait struct {
 	BaseTrait `property:",squash"`
 	// The error handler ref name provided or found in application properties
 	ErrorHandlerRef string `property:"ref" json:"ref,omitempty"`
+	// The type of the default error handler, either `none`, `log` or `dead-letter-channel`.
+	// It cannot be combined with a `ref` other than `defaultErrorHandler`.
+	Type string `property:"type" json:"type,omitempty"`
+	// The URI of the endpoint the failed messages are sent to, required by the `dead-letter-channel` error handler,
+	// e.g. `kamelet:my-sink` or `kafka:errors`
+	DeadLetterURI string `property:"dead-letter-uri" json:"deadLetterUri,omitempty"`
+	// The maximum number of redelivery attempts, `-1` for redelivering forever
+	MaximumRedeliveries *int `property:"maximum-redeliveries" json:"maximumRedeliveries,omitempty"`
+	// The initial delay between the redelivery attempts, in milliseconds
+	RedeliveryDelay *int64 `property:"redelivery-delay" json:"redeliveryDelay,omitempty"`
+	// Whether the delay between the redelivery attempts grows exponentially
+	UseExponentialBackOff *bool `property:"use-exponential-back-off" json:"useExponentialBackOff,omitempty"`
+	// Additional parameters of the error handler, in the form of `name=value`, e.g. `retryAttemptedLogLevel=WARN`
+	Parameters []string `property:"parameters" json:"parameters,omitempty"`
 }
 
 func newErrorHandlerTrait() Trait {
@@ -57,6 +76,15 @@ func (t *errorHandlerTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if t.Type != "" {
+		if err := t.validate(); err != nil {
+			return false, err
+		}
+		if t.ErrorHandlerRef == "" {
+			t.ErrorHandlerRef = v1alpha1.ErrorHandlerRefDefaultName
+		}
+	}
+
 	if t.ErrorHandlerRef == "" {
 		t.ErrorHandlerRef = e.Integration.Spec.GetConfigurationProperty(v1alpha1.ErrorHandlerRefName)
 	}
@@ -68,17 +96,112 @@ func (t *errorHandlerTrait) Apply(e *Environment) error {
 	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
 		// If the user configure directly the URI, we need to auto-discover the underlying component
 		// and add the related dependency
-		defaultErrorHandlerURI := e.Integration.Spec.GetConfigurationProperty(
-			fmt.Sprintf("%s.deadLetterUri", v1alpha1.ErrorHandlerAppPropertiesPrefix))
+		defaultErrorHandlerURI := t.getDeadLetterURI(e)
 		if defaultErrorHandlerURI != "" && !strings.HasPrefix(defaultErrorHandlerURI, "kamelet:") {
 			t.addErrorHandlerDependencies(e, defaultErrorHandlerURI)
 		}
 
 		return t.addErrorHandlerAsSource(e)
 	}
+
+	if t.Type != "" {
+		properties, err := t.getConfiguration()
+		if err != nil {
+			return err
+		}
+		for key, value := range properties {
+			e.ApplicationProperties[key] = value
+		}
+	}
+
 	return nil
 }
 
+func (t *errorHandlerTrait) validate() error {
+	switch v1alpha1.ErrorHandlerType(t.Type) {
+	case v1alpha1.ErrorHandlerTypeNone:
+		if t.MaximumRedeliveries != nil || t.RedeliveryDelay != nil || t.UseExponentialBackOff != nil || len(t.Parameters) > 0 {
+			return fmt.Errorf("the %s error handler does not accept any redelivery policy nor parameters", t.Type)
+		}
+	case v1alpha1.ErrorHandlerTypeLog:
+	case v1alpha1.ErrorHandlerTypeDeadLetterChannel:
+		if t.DeadLetterURI == "" {
+			return fmt.Errorf("the %s error handler requires a dead letter URI", t.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported error handler type: %s, must be one of %s, %s or %s", t.Type,
+			v1alpha1.ErrorHandlerTypeNone, v1alpha1.ErrorHandlerTypeLog, v1alpha1.ErrorHandlerTypeDeadLetterChannel)
+	}
+	if t.DeadLetterURI != "" && v1alpha1.ErrorHandlerType(t.Type) != v1alpha1.ErrorHandlerTypeDeadLetterChannel {
+		return fmt.Errorf("the dead letter URI is only supported by the %s error handler", v1alpha1.ErrorHandlerTypeDeadLetterChannel)
+	}
+	if t.ErrorHandlerRef != "" && t.ErrorHandlerRef != v1alpha1.ErrorHandlerRefDefaultName {
+		return fmt.Errorf("the error handler type cannot be combined with the %s ref", t.ErrorHandlerRef)
+	}
+	if t.MaximumRedeliveries != nil && *t.MaximumRedeliveries < -1 {
+		return fmt.Errorf("invalid maximum redeliveries: %d", *t.MaximumRedeliveries)
+	}
+	if t.RedeliveryDelay != nil && *t.RedeliveryDelay < 0 {
+		return fmt.Errorf("invalid redelivery delay: %d", *t.RedeliveryDelay)
+	}
+	_, err := qualifiedKeyValuePairArrayAsStringMap(t.Parameters)
+	return err
+}
+
+// getDeadLetterURI returns the dead letter URI, either configured on the trait or found in application properties
+func (t *errorHandlerTrait) getDeadLetterURI(e *Environment) string {
+	if t.DeadLetterURI != "" {
+		return t.DeadLetterURI
+	}
+	return e.Integration.Spec.GetConfigurationProperty(fmt.Sprintf("%s.deadLetterUri", v1alpha1.ErrorHandlerAppPropertiesPrefix))
+}
+
+// getConfiguration returns the application properties that configure the default error handler bean
+func (t *errorHandlerTrait) getConfiguration() (map[string]string, error) {
+	var errorHandler v1alpha1.ErrorHandler
+	switch v1alpha1.ErrorHandlerType(t.Type) {
+	case v1alpha1.ErrorHandlerTypeNone:
+		errorHandler = v1alpha1.ErrorHandlerNone{}
+	case v1alpha1.ErrorHandlerTypeLog:
+		errorHandler = v1alpha1.ErrorHandlerLog{}
+	case v1alpha1.ErrorHandlerTypeDeadLetterChannel:
+		errorHandler = v1alpha1.ErrorHandlerDeadLetterChannel{}
+	}
+	configuration, err := errorHandler.Configuration()
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]string, len(configuration))
+	for key, value := range configuration {
+		properties[key] = fmt.Sprintf("%v", value)
+	}
+	parameter := func(name string, value string) {
+		properties[fmt.Sprintf("%s.%s", v1alpha1.ErrorHandlerAppPropertiesPrefix, name)] = value
+	}
+	if t.DeadLetterURI != "" {
+		parameter("deadLetterUri", t.DeadLetterURI)
+	}
+	if t.MaximumRedeliveries != nil {
+		parameter("maximumRedeliveries", strconv.Itoa(*t.MaximumRedeliveries))
+	}
+	if t.RedeliveryDelay != nil {
+		parameter("redeliveryDelay", strconv.FormatInt(*t.RedeliveryDelay, 10))
+	}
+	if t.UseExponentialBackOff != nil {
+		parameter("useExponentialBackOff", strconv.FormatBool(*t.UseExponentialBackOff))
+	}
+	parameters, err := qualifiedKeyValuePairArrayAsStringMap(t.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range parameters {
+		parameter(name, value)
+	}
+
+	return properties, nil
+}
+
 func (t *errorHandlerTrait) addErrorHandlerDependencies(e *Environment, uri string) {
 	candidateComp, scheme := e.CamelCatalog.DecodeComponent(uri)
 	if candidateComp != nil {
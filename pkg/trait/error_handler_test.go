@@ -91,3 +91,106 @@ func TestErrorHandlerApplyDependency(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "camel:log", e.Integration.Spec.Dependencies[0])
 }
+
+func TestErrorHandlerConfigureInvalidType(t *testing.T) {
+	e := &Environment{
+		Catalog:     NewEnvironmentTestCatalog(),
+		Integration: &v1.Integration{},
+	}
+	e.Integration.Status.Phase = v1.IntegrationPhaseInitialization
+
+	trait := newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = "retry"
+	_, err := trait.Configure(e)
+	assert.NotNil(t, err)
+
+	trait = newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = string(v1alpha1.ErrorHandlerTypeDeadLetterChannel)
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+
+	trait = newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = string(v1alpha1.ErrorHandlerTypeLog)
+	trait.DeadLetterURI = "log:errors"
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+
+	trait = newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = string(v1alpha1.ErrorHandlerTypeNone)
+	trait.MaximumRedeliveries = intPtr(3)
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+
+	trait = newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = string(v1alpha1.ErrorHandlerTypeLog)
+	trait.ErrorHandlerRef = "myErrorHandler"
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+}
+
+func TestErrorHandlerApplyDeadLetterChannel(t *testing.T) {
+	c, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+	e := &Environment{
+		Catalog:               NewEnvironmentTestCatalog(),
+		CamelCatalog:          c,
+		Integration:           &v1.Integration{},
+		ApplicationProperties: make(map[string]string),
+	}
+	e.Integration.Status.Phase = v1.IntegrationPhaseInitialization
+
+	delay := int64(2000)
+	trait := newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = string(v1alpha1.ErrorHandlerTypeDeadLetterChannel)
+	trait.DeadLetterURI = "log:errors"
+	trait.MaximumRedeliveries = intPtr(3)
+	trait.RedeliveryDelay = &delay
+	trait.UseExponentialBackOff = BoolP(true)
+	trait.Parameters = []string{"retryAttemptedLogLevel=WARN"}
+
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	err = trait.Apply(e)
+	assert.Nil(t, err)
+	assert.Equal(t, "camel:log", e.Integration.Spec.Dependencies[0])
+	assert.Equal(t, `- error-handler:
+    ref: defaultErrorHandler
+`, e.Integration.Status.GeneratedSources[0].Content)
+
+	e.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	err = trait.Apply(e)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		v1alpha1.ErrorHandlerRefName:                             v1alpha1.ErrorHandlerRefDefaultName,
+		"camel.beans.defaultErrorHandler":                        "#class:org.apache.camel.builder.DeadLetterChannelBuilder",
+		"camel.beans.defaultErrorHandler.deadLetterUri":          "log:errors",
+		"camel.beans.defaultErrorHandler.maximumRedeliveries":    "3",
+		"camel.beans.defaultErrorHandler.redeliveryDelay":        "2000",
+		"camel.beans.defaultErrorHandler.useExponentialBackOff":  "true",
+		"camel.beans.defaultErrorHandler.retryAttemptedLogLevel": "WARN",
+	}, e.ApplicationProperties)
+}
+
+func TestErrorHandlerApplyNone(t *testing.T) {
+	e := &Environment{
+		Catalog:               NewEnvironmentTestCatalog(),
+		Integration:           &v1.Integration{},
+		ApplicationProperties: make(map[string]string),
+	}
+	e.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+
+	trait := newErrorHandlerTrait().(*errorHandlerTrait)
+	trait.Type = string(v1alpha1.ErrorHandlerTypeNone)
+
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	err = trait.Apply(e)
+	assert.Nil(t, err)
+	assert.Equal(t, "#class:org.apache.camel.builder.NoErrorHandlerBuilder", e.ApplicationProperties["camel.beans.defaultErrorHandler"])
+	assert.Len(t, e.ApplicationProperties, 2)
+}
@@ -0,0 +1,337 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/envvar"
+)
+
+// The Feature Flags trait wires an https://openfeature.dev[OpenFeature] provider into the integration, so that the
+// behavior of the routes can be toggled without redeploying the integration.
+//
+// The flags are evaluated by https://flagd.dev[flagd], either run as a sidecar container of the integration pods, that
+// reads the flag definitions from the `configmaps` and `sources` options, or reached at the remote `endpoint`.
+//
+// The flags are exposed to the routes:
+//
+// * as properties, resolved with the `feature` properties function, e.g. `{{feature:new-checkout}}`, and with a default value
+// for the flags that are not declared with the `flags` option, e.g. `{{feature:banner:welcome}}`,
+// * as headers, set on each exchange, named after the flags declared with the `flags` option and prefixed with `CamelFeatureFlag.`,
+// e.g. `${header.CamelFeatureFlag.new-checkout}`.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=feature-flags
+type featureFlagsTrait struct {
+	BaseTrait `property:",squash"`
+	// The flags set as headers on each exchange, in the form `name=default`, e.g. `new-checkout=false`. The type of the flag,
+	// i.e. boolean, integer, double or string, is inferred from its default value.
+	Flags []string `property:"flags" json:"flags,omitempty"`
+	// The address, in the form `host:port`, of the remote flagd service the flags are evaluated with.
+	// The flagd sidecar container is not run when it's set.
+	Endpoint string `property:"endpoint" json:"endpoint,omitempty"`
+	// The ConfigMaps holding the flag definitions read by the flagd sidecar, in the form `name[/key]`,
+	// the key defaulting to `flags.json`.
+	ConfigMaps []string `property:"configmaps" json:"configmaps,omitempty"`
+	// The additional sources of flag definitions read by the flagd sidecar, as flagd URIs,
+	// e.g. `https://acme.com/flags.json` or `core.openfeature.dev/my-namespace/my-flags`.
+	Sources []string `property:"sources" json:"sources,omitempty"`
+	// The image of the flagd sidecar container.
+	Image string `property:"image" json:"image,omitempty"`
+	// The port the flagd sidecar container listens on (default `8013`).
+	Port int `property:"port" json:"port,omitempty"`
+}
+
+const (
+	featureFlagsTraitID          = "feature-flags"
+	featureFlagsSourceName       = "FeatureFlags.java"
+	featureFlagsContainerName    = "flagd"
+	featureFlagsDefaultImage     = "ghcr.io/open-feature/flagd:v0.2.7"
+	featureFlagsDefaultPort      = 8013
+	featureFlagsDefaultKey       = "flags.json"
+	featureFlagsMountPath        = "/etc/flagd"
+	featureFlagsHeaderPrefix     = "CamelFeatureFlag."
+	featureFlagsPropertyFunction = "feature"
+
+	featureFlagsHostEnvVar = "FLAGD_HOST"
+	featureFlagsPortEnvVar = "FLAGD_PORT"
+
+	featureFlagsSDKDependency      = "mvn:dev.openfeature:sdk:1.2.0"
+	featureFlagsProviderDependency = "mvn:dev.openfeature.contrib.providers:flagd:0.5.7"
+)
+
+var featureFlagNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// featureFlag is a flag declared with the trait, whose type is inferred from its default value
+type featureFlag struct {
+	name string
+	// The OpenFeature client method evaluating the flag
+	method string
+	// The default value, as a Java literal
+	value string
+}
+
+func newFeatureFlagsTrait() Trait {
+	return &featureFlagsTrait{
+		BaseTrait: NewBaseTrait(featureFlagsTraitID, 1660, ExecuteAfter(containerTraitID)),
+	}
+}
+
+func (t *featureFlagsTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if _, err := t.getFlags(); err != nil {
+		return false, err
+	}
+	if t.Endpoint != "" {
+		if _, _, err := net.SplitHostPort(t.Endpoint); err != nil {
+			return false, fmt.Errorf("invalid flagd endpoint: %s, must be host:port", t.Endpoint)
+		}
+		if len(t.ConfigMaps) > 0 || len(t.Sources) > 0 {
+			return false, fmt.Errorf("the flag definitions cannot be set with a remote flagd endpoint")
+		}
+	} else if len(t.ConfigMaps) == 0 && len(t.Sources) == 0 {
+		return false, fmt.Errorf("no flag definitions provided, either the configmaps or the sources must be set, or the remote flagd endpoint")
+	}
+	if t.Port < 0 || t.Port > 65535 {
+		return false, fmt.Errorf("invalid flagd port: %d", t.Port)
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization) || e.IntegrationInRunningPhases(), nil
+}
+
+func (t *featureFlagsTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, featureFlagsSDKDependency)
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, featureFlagsProviderDependency)
+		return t.addFeatureFlagsSource(e)
+	}
+
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("unable to find the pod spec of integration %s", e.Integration.Name)
+	}
+
+	host, port := "localhost", t.port()
+	if t.Endpoint != "" {
+		var p string
+		host, p, _ = net.SplitHostPort(t.Endpoint)
+		port, _ = strconv.Atoi(p)
+	} else {
+		t.addSidecar(podSpec)
+	}
+
+	// The integration container is looked up once the sidecar is added to the containers
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return fmt.Errorf("unable to find integration container: %s", e.Integration.Name)
+	}
+	envvar.SetVal(&container.Env, featureFlagsHostEnvVar, host)
+	envvar.SetVal(&container.Env, featureFlagsPortEnvVar, strconv.Itoa(port))
+
+	return nil
+}
+
+func (t *featureFlagsTrait) port() int {
+	if t.Port == 0 {
+		return featureFlagsDefaultPort
+	}
+	return t.Port
+}
+
+// addSidecar adds the flagd container, that reads the flag definitions from the ConfigMaps mounted into it,
+// and from the other sources
+func (t *featureFlagsTrait) addSidecar(podSpec *corev1.PodSpec) {
+	image := t.Image
+	if image == "" {
+		image = featureFlagsDefaultImage
+	}
+	sidecar := corev1.Container{
+		Name:  featureFlagsContainerName,
+		Image: image,
+		Args:  []string{"start", "--port", strconv.Itoa(t.port())},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          featureFlagsContainerName,
+				ContainerPort: int32(t.port()),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+	}
+	for i, cm := range t.ConfigMaps {
+		name, key, ok := splitOnce(cm, "/")
+		if !ok || key == "" {
+			key = featureFlagsDefaultKey
+		}
+		volume := fmt.Sprintf("flagd-%03d", i)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: volume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			},
+		})
+		// The directory is mounted without sub-path, so that flagd watches the flag definitions updates
+		mountPath := fmt.Sprintf("%s/%s", featureFlagsMountPath, volume)
+		sidecar.VolumeMounts = append(sidecar.VolumeMounts, corev1.VolumeMount{
+			Name:      volume,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+		sidecar.Args = append(sidecar.Args, "--uri", fmt.Sprintf("file:%s/%s", mountPath, key))
+	}
+	for _, source := range t.Sources {
+		sidecar.Args = append(sidecar.Args, "--uri", source)
+	}
+	podSpec.Containers = append(podSpec.Containers, sidecar)
+}
+
+// getFlags parses the flags declared in the form name=default
+func (t *featureFlagsTrait) getFlags() ([]featureFlag, error) {
+	flags := make([]featureFlag, 0, len(t.Flags))
+	for _, f := range t.Flags {
+		name, value, ok := splitOnce(f, "=")
+		if !ok || !featureFlagNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid feature flag: %s, must be name=default", f)
+		}
+		flag := featureFlag{name: name}
+		if value == True || value == False {
+			flag.method, flag.value = "getBooleanValue", value
+		} else if i, err := strconv.ParseInt(value, 10, 32); err == nil {
+			// The literal is emitted from the parsed value, as leading zeros denote octal literals in Java
+			flag.method, flag.value = "getIntegerValue", strconv.Itoa(int(i))
+		} else if d, err := strconv.ParseFloat(value, 64); err == nil {
+			if math.IsNaN(d) || math.IsInf(d, 0) {
+				return nil, fmt.Errorf("invalid feature flag: %s, the default value must be a finite number", f)
+			}
+			flag.method, flag.value = "getDoubleValue", strconv.FormatFloat(d, 'g', -1, 64)+"d"
+		} else {
+			flag.method, flag.value = "getStringValue", strconv.Quote(value)
+		}
+		flags = append(flags, flag)
+	}
+	return flags, nil
+}
+
+// addFeatureFlagsSource adds the source that registers the flagd provider, the feature properties function,
+// and sets the declared flags as headers of the exchanges
+func (t *featureFlagsTrait) addFeatureFlagsSource(e *Environment) error {
+	flags, err := t.getFlags()
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`import dev.openfeature.contrib.providers.flagd.FlagdProvider;
+import dev.openfeature.sdk.Client;
+import dev.openfeature.sdk.OpenFeatureAPI;
+import org.apache.camel.Exchange;
+import org.apache.camel.builder.RouteBuilder;
+import org.apache.camel.component.properties.PropertiesComponent;
+import org.apache.camel.spi.CamelEvent;
+import org.apache.camel.spi.PropertiesFunction;
+import org.apache.camel.support.EventNotifierSupport;
+
+public class FeatureFlags extends RouteBuilder {
+    @Override
+    public void configure() throws Exception {
+        OpenFeatureAPI.getInstance().setProvider(new FlagdProvider());
+        final Client client = OpenFeatureAPI.getInstance().getClient();
+
+        ((PropertiesComponent) getContext().getPropertiesComponent()).addPropertiesFunction(new PropertiesFunction() {
+            @Override
+            public String getName() {
+                return "`)
+	sb.WriteString(featureFlagsPropertyFunction)
+	sb.WriteString(`";
+            }
+
+            @Override
+            public String apply(String remainder) {
+                String[] flag = remainder.split(":", 2);
+                switch (flag[0]) {
+`)
+	for _, f := range flags {
+		fmt.Fprintf(&sb, "                case %q:\n", f.name)
+		fmt.Fprintf(&sb, "                    return String.valueOf(client.%s(%q, %s));\n", f.method, f.name, f.value)
+	}
+	sb.WriteString(`                default:
+                    return client.getStringValue(flag[0], flag.length > 1 ? flag[1] : "");
+                }
+            }
+        });
+`)
+	if len(flags) > 0 {
+		sb.WriteString(`
+        // The headers are set on the exchanges created by the consumers of all the routes
+        EventNotifierSupport notifier = new EventNotifierSupport() {
+            @Override
+            public boolean isEnabled(CamelEvent event) {
+                return event instanceof CamelEvent.ExchangeCreatedEvent;
+            }
+
+            @Override
+            public void notify(CamelEvent event) {
+                Exchange exchange = ((CamelEvent.ExchangeCreatedEvent) event).getExchange();
+`)
+		for _, f := range flags {
+			fmt.Fprintf(&sb, "                exchange.getMessage().setHeader(%q, client.%s(%q, %s));\n",
+				featureFlagsHeaderPrefix+f.name, f.method, f.name, f.value)
+		}
+		sb.WriteString(`            }
+        };
+        getContext().getManagementStrategy().addEventNotifier(notifier);
+`)
+	}
+	sb.WriteString(`    }
+}
+`)
+
+	source := v1.SourceSpec{
+		DataSpec: v1.DataSpec{
+			Name:    featureFlagsSourceName,
+			Content: sb.String(),
+		},
+		Language: v1.LanguageJavaSource,
+	}
+	e.Integration.Status.AddOrReplaceGeneratedSources(source)
+
+	// The dependencies trait has already been executed
+	if e.CamelCatalog != nil {
+		AddSourceDependencies(source, e.CamelCatalog).Each(func(dependency string) bool {
+			util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, dependency)
+			return true
+		})
+	}
+
+	return nil
+}
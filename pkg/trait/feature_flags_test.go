@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/envvar"
+)
+
+func TestConfigureFeatureFlagsTraitDisabledByDefault(t *testing.T) {
+	environment, _ := createFeatureFlagsTestEnvironment()
+	featureFlagsTrait := newFeatureFlagsTrait().(*featureFlagsTrait)
+
+	configured, err := featureFlagsTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureFeatureFlagsTraitInvalidConfiguration(t *testing.T) {
+	environment, _ := createFeatureFlagsTestEnvironment()
+
+	featureFlagsTrait := createNominalFeatureFlagsTrait()
+	featureFlagsTrait.ConfigMaps = nil
+	_, err := featureFlagsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	featureFlagsTrait = createNominalFeatureFlagsTrait()
+	featureFlagsTrait.Endpoint = "flagd:8013"
+	_, err = featureFlagsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	featureFlagsTrait = createNominalFeatureFlagsTrait()
+	featureFlagsTrait.ConfigMaps = nil
+	featureFlagsTrait.Endpoint = "flagd"
+	_, err = featureFlagsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	featureFlagsTrait = createNominalFeatureFlagsTrait()
+	featureFlagsTrait.Flags = []string{"new-checkout"}
+	_, err = featureFlagsTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	for _, value := range []string{"nan", "NaN", "inf", "-Inf", "infinity"} {
+		featureFlagsTrait = createNominalFeatureFlagsTrait()
+		featureFlagsTrait.Flags = []string{"ratio=" + value}
+		_, err = featureFlagsTrait.Configure(environment)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestFeatureFlagsLiterals(t *testing.T) {
+	featureFlagsTrait := createNominalFeatureFlagsTrait()
+	featureFlagsTrait.Flags = []string{"retries=010", "offset=+3", "ratio=1E3"}
+
+	flags, err := featureFlagsTrait.getFlags()
+	assert.Nil(t, err)
+	assert.Equal(t, []featureFlag{
+		{name: "retries", method: "getIntegerValue", value: "10"},
+		{name: "offset", method: "getIntegerValue", value: "3"},
+		{name: "ratio", method: "getDoubleValue", value: "1000d"},
+	}, flags)
+}
+
+func TestApplyFeatureFlagsTraitWithSidecar(t *testing.T) {
+	environment, deployment := createFeatureFlagsTestEnvironment()
+	featureFlagsTrait := createNominalFeatureFlagsTrait()
+	featureFlagsTrait.ConfigMaps = []string{"my-flags", "other-flags/flags.yaml"}
+	featureFlagsTrait.Sources = []string{"https://acme.com/flags.json"}
+
+	configured, err := featureFlagsTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+	err = featureFlagsTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	flagd := findContainer(podSpec.Containers, featureFlagsContainerName)
+	assert.NotNil(t, flagd)
+	assert.Equal(t, featureFlagsDefaultImage, flagd.Image)
+	assert.Equal(t, []string{
+		"start", "--port", "8013",
+		"--uri", "file:/etc/flagd/flagd-000/flags.json",
+		"--uri", "file:/etc/flagd/flagd-001/flags.yaml",
+		"--uri", "https://acme.com/flags.json",
+	}, flagd.Args)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "flagd-000", MountPath: "/etc/flagd/flagd-000", ReadOnly: true},
+		{Name: "flagd-001", MountPath: "/etc/flagd/flagd-001", ReadOnly: true},
+	}, flagd.VolumeMounts)
+	assert.Len(t, podSpec.Volumes, 2)
+	assert.Equal(t, "other-flags", podSpec.Volumes[1].ConfigMap.Name)
+
+	container := findContainer(podSpec.Containers, defaultContainerName)
+	assert.Equal(t, "localhost", envvar.Get(container.Env, featureFlagsHostEnvVar).Value)
+	assert.Equal(t, "8013", envvar.Get(container.Env, featureFlagsPortEnvVar).Value)
+}
+
+func TestApplyFeatureFlagsTraitWithEndpoint(t *testing.T) {
+	environment, deployment := createFeatureFlagsTestEnvironment()
+	featureFlagsTrait := createNominalFeatureFlagsTrait()
+	featureFlagsTrait.ConfigMaps = nil
+	featureFlagsTrait.Endpoint = "flagd.flags.svc:9090"
+
+	configured, err := featureFlagsTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+	err = featureFlagsTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Nil(t, findContainer(podSpec.Containers, featureFlagsContainerName))
+	container := findContainer(podSpec.Containers, defaultContainerName)
+	assert.Equal(t, "flagd.flags.svc", envvar.Get(container.Env, featureFlagsHostEnvVar).Value)
+	assert.Equal(t, "9090", envvar.Get(container.Env, featureFlagsPortEnvVar).Value)
+}
+
+func TestApplyFeatureFlagsTraitSource(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	environment, _ := createFeatureFlagsTestEnvironment()
+	environment.CamelCatalog = catalog
+	environment.Integration.Status.Phase = v1.IntegrationPhaseInitialization
+	featureFlagsTrait := createNominalFeatureFlagsTrait()
+	featureFlagsTrait.Flags = []string{"new-checkout=false", "max-items=10", "discount=0.1", "banner=Welcome"}
+
+	configured, err := featureFlagsTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+	err = featureFlagsTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Contains(t, environment.Integration.Status.Dependencies, featureFlagsSDKDependency)
+	assert.Contains(t, environment.Integration.Status.Dependencies, featureFlagsProviderDependency)
+	assert.Len(t, environment.Integration.Status.GeneratedSources, 1)
+	source := environment.Integration.Status.GeneratedSources[0]
+	assert.Equal(t, featureFlagsSourceName, source.Name)
+	assert.Equal(t, v1.LanguageJavaSource, source.Language)
+	assert.Contains(t, source.Content, "public class FeatureFlags extends RouteBuilder")
+	assert.Contains(t, source.Content, `return "feature";`)
+	assert.Contains(t, source.Content, `return String.valueOf(client.getBooleanValue("new-checkout", false));`)
+	assert.Contains(t, source.Content, `exchange.getMessage().setHeader("CamelFeatureFlag.new-checkout", client.getBooleanValue("new-checkout", false));`)
+	assert.Contains(t, source.Content, `client.getIntegerValue("max-items", 10)`)
+	assert.Contains(t, source.Content, `client.getDoubleValue("discount", 0.1d)`)
+	assert.Contains(t, source.Content, `client.getStringValue("banner", "Welcome")`)
+}
+
+func createFeatureFlagsTestEnvironment() (*Environment, *appsv1.Deployment) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	environment.Catalog = NewCatalog(nil)
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: defaultContainerName},
+	}
+
+	return environment, deployment
+}
+
+func createNominalFeatureFlagsTrait() *featureFlagsTrait {
+	featureFlagsTrait := newFeatureFlagsTrait().(*featureFlagsTrait)
+	featureFlagsTrait.Enabled = BoolP(true)
+	featureFlagsTrait.ConfigMaps = []string{"my-flags"}
+
+	return featureFlagsTrait
+}
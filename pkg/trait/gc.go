@@ -18,7 +18,7 @@ limitations under the License.
 package trait
 
 import (
-	"context"
+	"fmt"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -39,6 +39,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/audit"
 )
 
 var (
@@ -58,13 +59,33 @@ const (
 
 // The GC Trait garbage-collects all resources that are no longer necessary upon integration updates.
 //
+// The integration resources are applied with server-side apply, so that the stale resources are the ones managed by the operator,
+// that are not part of the resources deployed for the current integration generation. They are pruned after each deployment,
+// e.g., when a trait that created them is disabled. When server-side apply is not supported by the cluster, the resources
+// labelled with the previous integration generations are deleted instead.
+//
+// The drift introduced by other field managers, e.g., manual changes with `kubectl`, is corrected by default, the operator taking
+// the ownership of the conflicting fields back. Alternatively, the drift can be kept and reported with the `ResourcesInSync`
+// integration condition. The drift of a single resource, e.g., a manual hotfix, can also be kept, by setting the
+// `camel.apache.org/pause-reconciliation` annotation to `true` on the resource. In any case, the conflicting fields are logged
+// by the operator, with their current and desired values, and the field managers that changed them.
+//
 // +camel-k:trait=gc
 type garbageCollectorTrait struct {
 	BaseTrait `property:",squash"`
 	// Discovery client cache to be used, either `disabled`, `disk` or `memory` (default `memory`)
 	DiscoveryCache *discoveryCacheType `property:"discovery-cache" json:"discoveryCache,omitempty"`
+	// How the drift of the integration resources is handled, either `correct` or `report` (default `correct`)
+	Drift string `property:"drift" json:"drift,omitempty"`
 }
 
+const (
+	gcDriftCorrect = "correct"
+	gcDriftReport  = "report"
+
+	generationLabel = "camel.apache.org/generation"
+)
+
 func newGarbageCollectorTrait() Trait {
 	return &garbageCollectorTrait{
 		BaseTrait: NewBaseTrait("gc", 1200),
@@ -80,6 +101,9 @@ func (t *garbageCollectorTrait) Configure(e *Environment) (bool, error) {
 		s := memoryDiscoveryCache
 		t.DiscoveryCache = &s
 	}
+	if t.Drift != "" && t.Drift != gcDriftCorrect && t.Drift != gcDriftReport {
+		return false, fmt.Errorf("unsupported drift policy: %s, must be either %s or %s", t.Drift, gcDriftCorrect, gcDriftReport)
+	}
 
 	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization) || e.IntegrationInRunningPhases(),
 		nil
@@ -89,14 +113,20 @@ func (t *garbageCollectorTrait) Apply(e *Environment) error {
 	switch e.Integration.Status.Phase {
 
 	case v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning, v1.IntegrationPhaseError:
-		// Register a post action that deletes the existing resources that are labelled
-		// with the previous integration generations.
+		// Register a post action that deletes the existing resources that are no longer
+		// part of the integration resources.
 		// TODO: this should be refined so that it's run when all the replicas for the newer generation
 		// are ready. This is to be added when the integration scale status is refined with ready replicas
 		e.PostActions = append(e.PostActions, func(env *Environment) error {
+			// The applied resources are captured before the resources collection
+			// may be changed by a subsequent reconciliation
+			applied := make(map[string]bool)
+			for _, resource := range env.Resources.Items() {
+				applied[resourceKey(resource.GetObjectKind().GroupVersionKind().GroupKind(), resource.GetName())] = true
+			}
 			// The collection and deletion are performed asynchronously to avoid blocking
 			// the reconciliation loop.
-			go t.garbageCollectResources(env)
+			go t.garbageCollectResources(env, applied)
 			return nil
 		})
 		fallthrough
@@ -108,7 +138,7 @@ func (t *garbageCollectorTrait) Apply(e *Environment) error {
 			env.Resources.VisitMetaObject(func(resource metav1.Object) {
 				labels := resource.GetLabels()
 				// Label the resource with the current integration generation
-				labels["camel.apache.org/generation"] = generation
+				labels[generationLabel] = generation
 				// Make sure the integration label is set
 				labels[v1.IntegrationLabel] = env.Integration.Name
 				resource.SetLabels(labels)
@@ -120,16 +150,25 @@ func (t *garbageCollectorTrait) Apply(e *Environment) error {
 	return nil
 }
 
-func (t *garbageCollectorTrait) garbageCollectResources(e *Environment) {
+// reportsDrift returns whether the drift of the integration resources is reported instead of being corrected
+func (t *garbageCollectorTrait) reportsDrift() bool {
+	return IsNilOrTrue(t.Enabled) && t.Drift == gcDriftReport
+}
+
+func (t *garbageCollectorTrait) garbageCollectResources(e *Environment, applied map[string]bool) {
 	integration, _ := labels.NewRequirement(v1.IntegrationLabel, selection.Equals, []string{e.Integration.Name})
-	generation, err := labels.NewRequirement("camel.apache.org/generation", selection.LessThan, []string{strconv.FormatInt(e.Integration.GetGeneration(), 10)})
-	if err != nil {
-		t.L.ForIntegration(e.Integration).Errorf(err, "cannot determine generation requirement")
-		return
-	}
 	selector := labels.NewSelector().
-		Add(*integration).
-		Add(*generation)
+		Add(*integration)
+
+	if !hasServerSideApply {
+		// Fall back to deleting the resources labelled with the previous generations
+		generation, err := labels.NewRequirement(generationLabel, selection.LessThan, []string{strconv.FormatInt(e.Integration.GetGeneration(), 10)})
+		if err != nil {
+			t.L.ForIntegration(e.Integration).Errorf(err, "cannot determine generation requirement")
+			return
+		}
+		selector = selector.Add(*generation)
+	}
 
 	deletableGVKs, err := t.getDeletableTypes(e)
 	if err != nil {
@@ -137,10 +176,10 @@ func (t *garbageCollectorTrait) garbageCollectResources(e *Environment) {
 		return
 	}
 
-	t.deleteEachOf(deletableGVKs, e, selector)
+	t.deleteEachOf(deletableGVKs, e, selector, applied)
 }
 
-func (t *garbageCollectorTrait) deleteEachOf(gvks map[schema.GroupVersionKind]struct{}, e *Environment, selector labels.Selector) {
+func (t *garbageCollectorTrait) deleteEachOf(gvks map[schema.GroupVersionKind]struct{}, e *Environment, selector labels.Selector, applied map[string]bool) {
 	for gvk := range gvks {
 		resources := unstructured.UnstructuredList{
 			Object: map[string]interface{}{
@@ -152,7 +191,7 @@ func (t *garbageCollectorTrait) deleteEachOf(gvks map[schema.GroupVersionKind]st
 			ctrl.InNamespace(e.Integration.Namespace),
 			ctrl.MatchingLabelsSelector{Selector: selector},
 		}
-		if err := t.Client.List(context.TODO(), &resources, options...); err != nil {
+		if err := t.Client.List(e.Ctx, &resources, options...); err != nil {
 			if !k8serrors.IsNotFound(err) && !k8serrors.IsForbidden(err) {
 				t.L.ForIntegration(e.Integration).Errorf(err, "cannot list child resources: %v", gvk)
 			}
@@ -161,10 +200,10 @@ func (t *garbageCollectorTrait) deleteEachOf(gvks map[schema.GroupVersionKind]st
 
 		for _, resource := range resources.Items {
 			r := resource
-			if !t.canBeDeleted(e, r) {
+			if !t.canBeDeleted(e, r, applied) {
 				continue
 			}
-			err := t.Client.Delete(context.TODO(), &r, ctrl.PropagationPolicy(metav1.DeletePropagationBackground))
+			err := t.Client.Delete(audit.WithReason(e.Ctx, "garbage collection"), &r, ctrl.PropagationPolicy(metav1.DeletePropagationBackground))
 			if err != nil {
 				// The resource may have already been deleted
 				if !k8serrors.IsNotFound(err) {
@@ -177,16 +216,40 @@ func (t *garbageCollectorTrait) deleteEachOf(gvks map[schema.GroupVersionKind]st
 	}
 }
 
-func (t *garbageCollectorTrait) canBeDeleted(e *Environment, u unstructured.Unstructured) bool {
+func (t *garbageCollectorTrait) canBeDeleted(e *Environment, u unstructured.Unstructured, applied map[string]bool) bool {
 	// Only delete direct children of the integration, otherwise we can affect the behavior of external controllers (i.e. Knative)
+	owned := false
 	for _, o := range u.GetOwnerReferences() {
 		if o.Kind == v1.IntegrationKind && strings.HasPrefix(o.APIVersion, v1.SchemeGroupVersion.Group) && o.Name == e.Integration.Name {
+			owned = true
+			break
+		}
+	}
+	if !owned || !hasServerSideApply {
+		return owned
+	}
+
+	// Keep the resources that are part of the current integration resources
+	if applied[resourceKey(u.GroupVersionKind().GroupKind(), u.GetName())] {
+		return false
+	}
+	// Keep the resources that have been applied by a reconciliation of a newer generation
+	if generation, err := strconv.ParseInt(u.GetLabels()[generationLabel], 10, 64); err == nil && generation > e.Integration.GetGeneration() {
+		return false
+	}
+	// Only prune the resources whose fields are managed by the operator
+	for _, f := range u.GetManagedFields() {
+		if f.Manager == deployerFieldManager && f.Operation == metav1.ManagedFieldsOperationApply {
 			return true
 		}
 	}
 	return false
 }
 
+func resourceKey(gk schema.GroupKind, name string) string {
+	return gk.String() + "/" + name
+}
+
 func (t *garbageCollectorTrait) getDeletableTypes(e *Environment) (map[schema.GroupVersionKind]struct{}, error) {
 	// We rely on the discovery API to retrieve all the resources GVK,
 	// that results in an unbounded set that can impact garbage collection latency when scaling up.
@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestConfigureGarbageCollectorTraitDoesSucceed(t *testing.T) {
@@ -65,6 +67,60 @@ func TestApplyGarbageCollectorTraitDuringInitializationPhaseSkipPostActions(t *t
 	assert.Len(t, environment.PostActions, 0)
 }
 
+func TestConfigureGarbageCollectorTraitInvalidDrift(t *testing.T) {
+	gcTrait, environment := createNominalGarbageCollectorTest()
+	gcTrait.Drift = "ignore"
+
+	_, err := gcTrait.Configure(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestGarbageCollectorTraitReportsDrift(t *testing.T) {
+	gcTrait, _ := createNominalGarbageCollectorTest()
+	assert.False(t, gcTrait.reportsDrift())
+
+	gcTrait.Drift = gcDriftReport
+	assert.True(t, gcTrait.reportsDrift())
+
+	gcTrait.Enabled = BoolP(false)
+	assert.False(t, gcTrait.reportsDrift())
+}
+
+func TestGarbageCollectorTraitCanBeDeleted(t *testing.T) {
+	gcTrait, environment := createNominalGarbageCollectorTest()
+	environment.Integration.Generation = 2
+	applied := map[string]bool{
+		resourceKey(schema.GroupKind{Kind: "ConfigMap"}, "current"): true,
+	}
+
+	resource := func(name string, generation string, manager string, owned bool) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetName(name)
+		u.SetLabels(map[string]string{
+			v1.IntegrationLabel: "integration-name",
+			generationLabel:     generation,
+		})
+		u.SetManagedFields([]metav1.ManagedFieldsEntry{
+			{Manager: manager, Operation: metav1.ManagedFieldsOperationApply},
+		})
+		if owned {
+			u.SetOwnerReferences([]metav1.OwnerReference{
+				{APIVersion: v1.SchemeGroupVersion.String(), Kind: v1.IntegrationKind, Name: "integration-name"},
+			})
+		}
+		return u
+	}
+
+	assert.True(t, gcTrait.canBeDeleted(environment, resource("stale", "1", deployerFieldManager, true), applied))
+	assert.False(t, gcTrait.canBeDeleted(environment, resource("current", "1", deployerFieldManager, true), applied))
+	assert.False(t, gcTrait.canBeDeleted(environment, resource("newer", "3", deployerFieldManager, true), applied))
+	assert.False(t, gcTrait.canBeDeleted(environment, resource("external", "1", "kubectl", true), applied))
+	assert.False(t, gcTrait.canBeDeleted(environment, resource("unowned", "1", deployerFieldManager, false), applied))
+}
+
 func createNominalGarbageCollectorTest() (*garbageCollectorTrait, *Environment) {
 	trait := newGarbageCollectorTrait().(*garbageCollectorTrait)
 	trait.Enabled = BoolP(true)
@@ -0,0 +1,236 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The Grafana trait provisions a Grafana dashboard for the integration, that displays the Camel exchanges metrics,
+// the JVM metrics, and the operator reconciliation metrics for the integration.
+//
+// The dashboard is either provisioned as a ConfigMap, labelled so that it's discovered by the Grafana dashboards sidecar,
+// or as a `GrafanaDashboard` resource, managed by the https://github.com/grafana-operator/grafana-operator[Grafana Operator].
+//
+// The dashboard queries rely on the `namespace` and `integration` labels, that are added to the scraped metrics
+// by the Prometheus trait, which should be enabled as well.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=grafana
+type grafanaTrait struct {
+	BaseTrait `property:",squash"`
+	// The kind of resource the dashboard is provisioned with, either `ConfigMap` or `GrafanaDashboard` (default `ConfigMap`).
+	Kind string `property:"kind" json:"kind,omitempty"`
+	// The labels of the dashboard resource, used by Grafana to discover it.
+	// Defaults to `grafana_dashboard=1` for a ConfigMap, and `app=grafana` for a `GrafanaDashboard` resource.
+	Labels []string `property:"labels" json:"labels,omitempty"`
+	// The Grafana folder the dashboard is added to.
+	Folder string `property:"folder" json:"folder,omitempty"`
+	// The name of the Grafana Prometheus datasource the dashboard queries (default `Prometheus`).
+	Datasource string `property:"datasource" json:"datasource,omitempty"`
+}
+
+const (
+	grafanaKindConfigMap        = "ConfigMap"
+	grafanaKindGrafanaDashboard = "GrafanaDashboard"
+
+	defaultGrafanaDatasource = "Prometheus"
+)
+
+func newGrafanaTrait() Trait {
+	return &grafanaTrait{
+		BaseTrait: NewBaseTrait("grafana", 1950),
+	}
+}
+
+func (t *grafanaTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.Kind != "" && t.Kind != grafanaKindConfigMap && t.Kind != grafanaKindGrafanaDashboard {
+		return false, fmt.Errorf("unsupported Grafana dashboard kind: %s, must be one of %s or %s",
+			t.Kind, grafanaKindConfigMap, grafanaKindGrafanaDashboard)
+	}
+	if _, err := keyValuePairArrayAsStringMap(t.Labels); err != nil {
+		return false, err
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *grafanaTrait) Apply(e *Environment) error {
+	dashboard, err := json.MarshalIndent(t.getDashboard(e), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	labels, err := keyValuePairArrayAsStringMap(t.Labels)
+	if err != nil {
+		return err
+	}
+	name := e.Integration.Name + "-dashboard"
+
+	if t.Kind == grafanaKindGrafanaDashboard {
+		if len(labels) == 0 {
+			labels["app"] = "grafana"
+		}
+		labels[v1.IntegrationLabel] = e.Integration.Name
+
+		spec := map[string]interface{}{
+			"name": name + ".json",
+			"json": string(dashboard),
+		}
+		if t.Folder != "" {
+			spec["customFolderName"] = t.Folder
+		}
+
+		resource := &unstructured.Unstructured{}
+		resource.SetAPIVersion("integreatly.org/v1alpha1")
+		resource.SetKind(grafanaKindGrafanaDashboard)
+		resource.SetName(name)
+		resource.SetNamespace(e.Integration.Namespace)
+		resource.SetLabels(labels)
+		if err := unstructured.SetNestedMap(resource.Object, spec, "spec"); err != nil {
+			return err
+		}
+		e.Resources.Add(resource)
+		return nil
+	}
+
+	if len(labels) == 0 {
+		labels["grafana_dashboard"] = "1"
+	}
+	labels[v1.IntegrationLabel] = e.Integration.Name
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: e.Integration.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			name + ".json": string(dashboard),
+		},
+	}
+	if t.Folder != "" {
+		cm.Annotations = map[string]string{
+			"grafana_folder": t.Folder,
+		}
+	}
+	e.Resources.Add(cm)
+
+	return nil
+}
+
+func (t *grafanaTrait) getDashboard(e *Environment) map[string]interface{} {
+	datasource := t.Datasource
+	if datasource == "" {
+		datasource = defaultGrafanaDatasource
+	}
+	selector := fmt.Sprintf(`namespace="%s",integration="%s"`, e.Integration.Namespace, e.Integration.Name)
+
+	// The panels are laid out in rows of two graphs
+	panels := make([]interface{}, 0)
+	x, y := 0, 0
+	addRow := func(title string) {
+		if x > 0 {
+			x, y = 0, y+8
+		}
+		panels = append(panels, map[string]interface{}{
+			"type":    "row",
+			"title":   title,
+			"gridPos": map[string]int{"h": 1, "w": 24, "x": 0, "y": y},
+		})
+		y++
+	}
+	addGraph := func(title string, unit string, exprs ...string) {
+		targets := make([]interface{}, 0, len(exprs))
+		for i, expr := range exprs {
+			targets = append(targets, map[string]interface{}{
+				"expr":  expr,
+				"refId": string(rune('A' + i)),
+			})
+		}
+		panels = append(panels, map[string]interface{}{
+			"type":       "timeseries",
+			"title":      title,
+			"datasource": datasource,
+			"targets":    targets,
+			"fieldConfig": map[string]interface{}{
+				"defaults": map[string]string{"unit": unit},
+			},
+			"gridPos": map[string]int{"h": 8, "w": 12, "x": x, "y": y},
+		})
+		if x == 0 {
+			x = 12
+		} else {
+			x, y = 0, y+8
+		}
+	}
+
+	addRow("Camel")
+	addGraph("Exchanges", "ops",
+		fmt.Sprintf("sum(rate(application_camel_context_exchanges_completed_total{%s}[5m]))", selector),
+		fmt.Sprintf("sum(rate(application_camel_context_exchanges_failed_total{%s}[5m]))", selector))
+	addGraph("Inflight exchanges", "short",
+		fmt.Sprintf("sum(application_camel_context_exchanges_inflight{%s})", selector))
+	addGraph("Route exchanges", "ops",
+		fmt.Sprintf("sum by (routeId) (rate(application_camel_route_exchanges_completed_total{%s}[5m]))", selector))
+	addGraph("Route processing time", "ms",
+		fmt.Sprintf("max by (routeId) (application_camel_route_processing_mean_milliseconds{%s})", selector))
+
+	addRow("JVM")
+	addGraph("Heap memory", "bytes",
+		fmt.Sprintf("sum by (pod) (base_memory_usedHeap_bytes{%s})", selector),
+		fmt.Sprintf("sum by (pod) (base_memory_maxHeap_bytes{%s})", selector))
+	addGraph("Threads", "short",
+		fmt.Sprintf("sum by (pod) (base_thread_count{%s})", selector))
+	addGraph("Garbage collection time", "ms",
+		fmt.Sprintf("sum by (pod) (rate(base_gc_time_total_seconds{%s}[5m]))", selector))
+	addGraph("CPU load", "percentunit",
+		fmt.Sprintf("avg by (pod) (base_cpu_processCpuLoad{%s})", selector))
+
+	addRow("Operator")
+	addGraph("Integration reconciliations", "ops",
+		fmt.Sprintf(`sum by (result) (rate(camel_k_reconciliation_duration_seconds_count{kind="%s",namespace="%s"}[5m]))`,
+			v1.IntegrationKind, e.Integration.Namespace))
+	addGraph("Integration first readiness", "s",
+		"histogram_quantile(0.9, sum by (le) (rate(camel_k_integration_first_readiness_seconds_bucket[5m])))")
+
+	return map[string]interface{}{
+		"title":         fmt.Sprintf("Camel K - %s/%s", e.Integration.Namespace, e.Integration.Name),
+		"tags":          []string{"camel-k", e.Integration.Name},
+		"schemaVersion": 27,
+		"time":          map[string]string{"from": "now-1h", "to": "now"},
+		"refresh":       "30s",
+		"panels":        panels,
+	}
+}
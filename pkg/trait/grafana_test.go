@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestConfigureGrafanaTraitDisabledByDefault(t *testing.T) {
+	environment := createGrafanaTestEnvironment()
+	grafanaTrait := newGrafanaTrait().(*grafanaTrait)
+
+	configured, err := grafanaTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureGrafanaTraitInvalidConfiguration(t *testing.T) {
+	environment := createGrafanaTestEnvironment()
+
+	grafanaTrait := createNominalGrafanaTrait()
+	grafanaTrait.Kind = "Secret"
+	_, err := grafanaTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	grafanaTrait = createNominalGrafanaTrait()
+	grafanaTrait.Labels = []string{"grafana_dashboard"}
+	_, err = grafanaTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestApplyGrafanaTraitWithConfigMap(t *testing.T) {
+	environment := createGrafanaTestEnvironment()
+	grafanaTrait := createNominalGrafanaTrait()
+	grafanaTrait.Folder = "Camel K"
+
+	configured, err := grafanaTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = grafanaTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	cm := environment.Resources.GetConfigMap(func(cm *corev1.ConfigMap) bool {
+		return cm.Name == "my-integration-dashboard"
+	})
+	assert.NotNil(t, cm)
+	assert.Equal(t, map[string]string{
+		"grafana_dashboard": "1",
+		v1.IntegrationLabel: "my-integration",
+	}, cm.Labels)
+	assert.Equal(t, "Camel K", cm.Annotations["grafana_folder"])
+
+	dashboard := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal([]byte(cm.Data["my-integration-dashboard.json"]), &dashboard))
+	assert.Equal(t, "Camel K - ns/my-integration", dashboard["title"])
+
+	panels := dashboard["panels"].([]interface{})
+	assert.NotEmpty(t, panels)
+	for _, p := range panels {
+		panel := p.(map[string]interface{})
+		if panel["type"] == "row" {
+			continue
+		}
+		assert.Equal(t, defaultGrafanaDatasource, panel["datasource"])
+		assert.NotEmpty(t, panel["targets"])
+	}
+	assert.Contains(t, cm.Data["my-integration-dashboard.json"], `namespace=\"ns\",integration=\"my-integration\"`)
+}
+
+func TestApplyGrafanaTraitWithGrafanaDashboard(t *testing.T) {
+	environment := createGrafanaTestEnvironment()
+	grafanaTrait := createNominalGrafanaTrait()
+	grafanaTrait.Kind = grafanaKindGrafanaDashboard
+	grafanaTrait.Labels = []string{"monitoring=camel"}
+	grafanaTrait.Folder = "Camel K"
+	grafanaTrait.Datasource = "Thanos"
+
+	err := grafanaTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	var dashboard *unstructured.Unstructured
+	environment.Resources.Visit(func(o runtime.Object) {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			dashboard = u
+		}
+	})
+	assert.NotNil(t, dashboard)
+	assert.Equal(t, "integreatly.org/v1alpha1", dashboard.GetAPIVersion())
+	assert.Equal(t, grafanaKindGrafanaDashboard, dashboard.GetKind())
+	assert.Equal(t, "my-integration-dashboard", dashboard.GetName())
+	assert.Equal(t, "ns", dashboard.GetNamespace())
+	assert.Equal(t, map[string]string{
+		"monitoring":        "camel",
+		v1.IntegrationLabel: "my-integration",
+	}, dashboard.GetLabels())
+
+	folder, _, _ := unstructured.NestedString(dashboard.Object, "spec", "customFolderName")
+	assert.Equal(t, "Camel K", folder)
+	content, _, _ := unstructured.NestedString(dashboard.Object, "spec", "json")
+	assert.Contains(t, content, `"datasource": "Thanos"`)
+}
+
+func createNominalGrafanaTrait() *grafanaTrait {
+	grafanaTrait := newGrafanaTrait().(*grafanaTrait)
+	grafanaTrait.Enabled = BoolP(true)
+	return grafanaTrait
+}
+
+func createGrafanaTestEnvironment() *Environment {
+	return &Environment{
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-integration",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(),
+	}
+}
@@ -0,0 +1,465 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The HPA trait creates a HorizontalPodAutoscaler, that scales the Integration, through its scale sub-resource,
+// based on the CPU and memory utilization of its pods, or on custom metrics.
+//
+// The Integration can also be scaled on its Camel metrics, like the number of inflight exchanges, scraped by Prometheus
+// with the Prometheus trait. The metrics are either served to the HorizontalPodAutoscaler as external metrics by the
+// https://github.com/kubernetes-sigs/prometheus-adapter[Prometheus adapter], or queried by https://keda.sh[KEDA], that
+// manages the HorizontalPodAutoscaler of the Integration with a `ScaledObject` generated by the trait.
+//
+// The conditions of the HorizontalPodAutoscaler are reported by the `HorizontalPodAutoscalerAvailable`
+// condition of the Integration.
+//
+// It's disabled by default, and is not supported with the `cron-job` and `knative-service` controller strategies,
+// that have their own scaling mechanisms.
+//
+// +camel-k:trait=hpa
+type hpaTrait struct {
+	BaseTrait `property:",squash"`
+	// The lower limit for the number of replicas to which the autoscaler can scale down (default `1`).
+	MinReplicas *int32 `property:"min-replicas" json:"minReplicas,omitempty"`
+	// The upper limit for the number of replicas to which the autoscaler can scale up (default `10`).
+	MaxReplicas int32 `property:"max-replicas" json:"maxReplicas,omitempty"`
+	// The target average CPU utilization of the pods, as a percentage of the requested CPU.
+	// It defaults to `80` when no other metric is set.
+	CPU *int32 `property:"cpu" json:"cpu,omitempty"`
+	// The target average memory utilization of the pods, as a percentage of the requested memory.
+	Memory *int32 `property:"memory" json:"memory,omitempty"`
+	// The custom metrics targets, in the form `pods:<metric>=<average value>` for a metric of the pods,
+	// or `external:<metric>=<average value>` for a metric external to the cluster,
+	// e.g. `pods:http_requests_per_second=100`.
+	Metrics []string `property:"metrics" json:"metrics,omitempty"`
+	// The Camel metrics targets, in the form `<metric>=<average value>`, e.g. `inflight-exchanges=20`.
+	// The metric is either `inflight-exchanges`, the number of exchanges being processed by the Camel context,
+	// or the name of any other metric exposed by the Integration, like a gauge of the depth of a SEDA queue.
+	// The metrics are selected with the `namespace` and `integration` labels, added by the Prometheus trait,
+	// that must be enabled, and must enrich the metrics.
+	CamelMetrics []string `property:"camel-metrics" json:"camelMetrics,omitempty"`
+	// The provider of the Camel metrics, either `prometheus-adapter`, that serves them as external metrics,
+	// and whose rule exposing them must be configured beforehand, as it's not generated by the trait,
+	// or `keda`, that scales the Integration with a KEDA `ScaledObject` instead (default `prometheus-adapter`).
+	MetricsProvider string `property:"metrics-provider" json:"metricsProvider,omitempty"`
+	// The URL of the Prometheus server KEDA queries the Camel metrics from.
+	// It defaults to the Prometheus URL configured for the hibernation of the Integrations by the platform.
+	PrometheusURL string `property:"prometheus-url" json:"prometheusURL,omitempty"`
+}
+
+const (
+	hpaDefaultMaxReplicas = 10
+	hpaDefaultCPU         = 80
+
+	hpaMetricsProviderPrometheusAdapter = "prometheus-adapter"
+	hpaMetricsProviderKeda              = "keda"
+
+	kedaScaledObjectKind = "ScaledObject"
+	// kedaHpaPrefix prefixes the name of the HorizontalPodAutoscaler created by KEDA for a ScaledObject
+	kedaHpaPrefix = "keda-hpa-"
+)
+
+// camelMetricAliases maps the aliases of the Camel metrics to the names of the metrics scraped by Prometheus
+var camelMetricAliases = map[string]string{
+	"inflight-exchanges": "application_camel_context_exchanges_inflight",
+}
+
+var prometheusMetricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// camelMetric is a target of a Camel metric of the Integration
+type camelMetric struct {
+	name  string
+	value resource.Quantity
+}
+
+func newHpaTrait() Trait {
+	return &hpaTrait{
+		BaseTrait: NewBaseTrait("hpa", 1110),
+	}
+}
+
+func (t *hpaTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	strategy, err := e.DetermineControllerStrategy()
+	if err != nil {
+		return false, err
+	}
+	if strategy == ControllerStrategyCronJob || strategy == ControllerStrategyKnativeService {
+		return false, fmt.Errorf("horizontal pod autoscaling isn't supported with %s controller strategy", strategy)
+	}
+
+	if len(t.CamelMetrics) > 0 {
+		if prometheus, ok := e.Catalog.GetTrait("prometheus").(*prometheusTrait); !ok || !IsTrue(prometheus.Enabled) || IsFalse(prometheus.Enrich) {
+			return false, fmt.Errorf("the Camel metrics are selected with the namespace and integration labels, " +
+				"that require the prometheus trait to be enabled, and to enrich the metrics")
+		}
+	}
+
+	switch t.MetricsProvider {
+	case "", hpaMetricsProviderPrometheusAdapter:
+		if _, err := t.metrics(e.Integration); err != nil {
+			return false, err
+		}
+	case hpaMetricsProviderKeda:
+		if len(t.Metrics) > 0 {
+			return false, fmt.Errorf("custom metrics are not supported by the %s metrics provider", hpaMetricsProviderKeda)
+		}
+		if _, err := t.camelMetrics(); err != nil {
+			return false, err
+		}
+		if len(t.CamelMetrics) > 0 && t.prometheusURL(e) == "" {
+			return false, fmt.Errorf("the Prometheus URL is required by the %s metrics provider", hpaMetricsProviderKeda)
+		}
+	default:
+		return false, fmt.Errorf("unsupported metrics provider: %s, must be one of %s or %s",
+			t.MetricsProvider, hpaMetricsProviderPrometheusAdapter, hpaMetricsProviderKeda)
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *hpaTrait) Apply(e *Environment) error {
+	name := e.Integration.Name
+	if t.MetricsProvider == hpaMetricsProviderKeda {
+		scaledObject, err := t.scaledObjectFor(e)
+		if err != nil {
+			return err
+		}
+		e.Resources.Add(scaledObject)
+		name = kedaHpaPrefix + scaledObject.GetName()
+	} else {
+		hpa, err := t.horizontalPodAutoscalerFor(e.Integration)
+		if err != nil {
+			return err
+		}
+		e.Resources.Add(hpa)
+	}
+
+	if e.Client == nil {
+		return nil
+	}
+	existing := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	err := e.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: name}, existing)
+	if k8serrors.IsNotFound(err) {
+		// The HorizontalPodAutoscaler is created by the deployer trait, or by KEDA
+		return nil
+	} else if err != nil {
+		return err
+	}
+	setHorizontalPodAutoscalerCondition(e.Integration, existing)
+
+	return nil
+}
+
+func (t *hpaTrait) horizontalPodAutoscalerFor(integration *v1.Integration) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+	metrics, err := t.metrics(integration)
+	if err != nil {
+		return nil, err
+	}
+
+	minReplicas, maxReplicas, err := t.replicas()
+	if err != nil {
+		return nil, err
+	}
+
+	return &autoscalingv2beta2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: autoscalingv2beta2.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      integration.Name,
+			Namespace: integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: integration.Name,
+			},
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKind,
+				Name:       integration.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: maxReplicas,
+			Metrics:     metrics,
+		},
+	}, nil
+}
+
+// replicas returns the lower and upper limits for the number of replicas
+func (t *hpaTrait) replicas() (int32, int32, error) {
+	minReplicas := int32(1)
+	if t.MinReplicas != nil {
+		minReplicas = *t.MinReplicas
+	}
+	maxReplicas := t.MaxReplicas
+	if maxReplicas == 0 {
+		maxReplicas = hpaDefaultMaxReplicas
+	}
+	if maxReplicas < minReplicas {
+		return 0, 0, fmt.Errorf("the maximum number of replicas %d is lower than the minimum %d", maxReplicas, minReplicas)
+	}
+	return minReplicas, maxReplicas, nil
+}
+
+// cpu returns the target CPU utilization, that defaults to 80% when no other metric is set
+func (t *hpaTrait) cpu() *int32 {
+	if t.CPU == nil && t.Memory == nil && len(t.Metrics) == 0 && len(t.CamelMetrics) == 0 {
+		utilization := int32(hpaDefaultCPU)
+		return &utilization
+	}
+	return t.CPU
+}
+
+// metrics returns the metric specs of the HorizontalPodAutoscaler, defaulting to the CPU utilization
+func (t *hpaTrait) metrics(integration *v1.Integration) ([]autoscalingv2beta2.MetricSpec, error) {
+	metrics := make([]autoscalingv2beta2.MetricSpec, 0)
+
+	if cpu := t.cpu(); cpu != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *cpu))
+	}
+	if t.Memory != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *t.Memory))
+	}
+
+	for _, m := range t.Metrics {
+		kv := strings.SplitN(m, "=", 2)
+		tn := strings.SplitN(kv[0], ":", 2)
+		if len(kv) != 2 || len(tn) != 2 || tn[1] == "" {
+			return nil, fmt.Errorf("invalid metric %q, it must be in the form pods:<metric>=<average value> or external:<metric>=<average value>", m)
+		}
+		value, err := resource.ParseQuantity(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid average value of metric %q: %v", m, err)
+		}
+		metric := autoscalingv2beta2.MetricIdentifier{Name: tn[1]}
+		target := autoscalingv2beta2.MetricTarget{
+			Type:         autoscalingv2beta2.AverageValueMetricType,
+			AverageValue: &value,
+		}
+		switch tn[0] {
+		case "pods":
+			metrics = append(metrics, autoscalingv2beta2.MetricSpec{
+				Type: autoscalingv2beta2.PodsMetricSourceType,
+				Pods: &autoscalingv2beta2.PodsMetricSource{Metric: metric, Target: target},
+			})
+		case "external":
+			metrics = append(metrics, autoscalingv2beta2.MetricSpec{
+				Type:     autoscalingv2beta2.ExternalMetricSourceType,
+				External: &autoscalingv2beta2.ExternalMetricSource{Metric: metric, Target: target},
+			})
+		default:
+			return nil, fmt.Errorf("unsupported type %q of metric %q, it must be either pods or external", tn[0], m)
+		}
+	}
+
+	// The Camel metrics are served by the Prometheus adapter as external metrics, selected by the integration label
+	// added by the prometheus trait
+	camelMetrics, err := t.camelMetrics()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range camelMetrics {
+		value := m.value
+		metrics = append(metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.ExternalMetricSourceType,
+			External: &autoscalingv2beta2.ExternalMetricSource{
+				Metric: autoscalingv2beta2.MetricIdentifier{
+					Name: m.name,
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"integration": integration.Name,
+						},
+					},
+				},
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:         autoscalingv2beta2.AverageValueMetricType,
+					AverageValue: &value,
+				},
+			},
+		})
+	}
+
+	return metrics, nil
+}
+
+// camelMetrics returns the targets of the Camel metrics, whose aliases are resolved to the names of the metrics
+func (t *hpaTrait) camelMetrics() ([]camelMetric, error) {
+	metrics := make([]camelMetric, 0, len(t.CamelMetrics))
+	for _, m := range t.CamelMetrics {
+		kv := strings.SplitN(m, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid Camel metric %q, it must be in the form <metric>=<average value>", m)
+		}
+		name := kv[0]
+		if alias, ok := camelMetricAliases[name]; ok {
+			name = alias
+		} else if !prometheusMetricNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid name of Camel metric %q", m)
+		}
+		value, err := resource.ParseQuantity(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid average value of Camel metric %q: %v", m, err)
+		}
+		metrics = append(metrics, camelMetric{name: name, value: value})
+	}
+	return metrics, nil
+}
+
+// prometheusURL returns the URL of the Prometheus server the Camel metrics are queried from by KEDA
+func (t *hpaTrait) prometheusURL(e *Environment) string {
+	if t.PrometheusURL != "" {
+		return t.PrometheusURL
+	}
+	if e.Platform != nil {
+		return e.Platform.Status.Hibernation.PrometheusURL
+	}
+	return ""
+}
+
+// scaledObjectFor returns the KEDA ScaledObject that scales the Integration, from which KEDA creates
+// the HorizontalPodAutoscaler of the Integration
+func (t *hpaTrait) scaledObjectFor(e *Environment) (*unstructured.Unstructured, error) {
+	minReplicas, maxReplicas, err := t.replicas()
+	if err != nil {
+		return nil, err
+	}
+	camelMetrics, err := t.camelMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	triggers := make([]interface{}, 0)
+	if cpu := t.cpu(); cpu != nil {
+		triggers = append(triggers, resourceTrigger(corev1.ResourceCPU, *cpu))
+	}
+	if t.Memory != nil {
+		triggers = append(triggers, resourceTrigger(corev1.ResourceMemory, *t.Memory))
+	}
+	for _, m := range camelMetrics {
+		triggers = append(triggers, map[string]interface{}{
+			"type": "prometheus",
+			"metadata": map[string]interface{}{
+				"serverAddress": t.prometheusURL(e),
+				"query":         fmt.Sprintf(`sum(%s{namespace=%q,integration=%q})`, m.name, e.Integration.Namespace, e.Integration.Name),
+				"threshold":     m.value.String(),
+			},
+		})
+	}
+
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetAPIVersion("keda.sh/v1alpha1")
+	scaledObject.SetKind(kedaScaledObjectKind)
+	scaledObject.SetName(e.Integration.Name)
+	scaledObject.SetNamespace(e.Integration.Namespace)
+	scaledObject.SetLabels(map[string]string{
+		v1.IntegrationLabel: e.Integration.Name,
+	})
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": v1.SchemeGroupVersion.String(),
+			"kind":       v1.IntegrationKind,
+			"name":       e.Integration.Name,
+		},
+		"minReplicaCount": int64(minReplicas),
+		"maxReplicaCount": int64(maxReplicas),
+		"triggers":        triggers,
+	}
+	if err := unstructured.SetNestedMap(scaledObject.Object, spec, "spec"); err != nil {
+		return nil, err
+	}
+
+	return scaledObject, nil
+}
+
+func resourceTrigger(name corev1.ResourceName, utilization int32) map[string]interface{} {
+	return map[string]interface{}{
+		"type":       string(name),
+		"metricType": string(autoscalingv2beta2.UtilizationMetricType),
+		"metadata": map[string]interface{}{
+			"value": strconv.Itoa(int(utilization)),
+		},
+	}
+}
+
+func resourceMetric(name corev1.ResourceName, utilization int32) autoscalingv2beta2.MetricSpec {
+	return autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:               autoscalingv2beta2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
+
+// setHorizontalPodAutoscalerCondition reflects the conditions of the given HorizontalPodAutoscaler into the Integration,
+// that is reported as not available as long as the autoscaler is not able to scale it, or cannot compute the metrics
+func setHorizontalPodAutoscalerCondition(integration *v1.Integration, hpa *autoscalingv2beta2.HorizontalPodAutoscaler) {
+	var active *autoscalingv2beta2.HorizontalPodAutoscalerCondition
+	for i := range hpa.Status.Conditions {
+		c := &hpa.Status.Conditions[i]
+		if c.Type != autoscalingv2beta2.AbleToScale && c.Type != autoscalingv2beta2.ScalingActive {
+			continue
+		}
+		if c.Status != corev1.ConditionTrue {
+			integration.Status.SetCondition(v1.IntegrationConditionHorizontalPodAutoscalerAvailable, corev1.ConditionFalse, c.Reason, c.Message)
+			return
+		}
+		if c.Type == autoscalingv2beta2.ScalingActive {
+			active = c
+		}
+	}
+	if active == nil {
+		// The autoscaler has not computed the scale of the Integration yet
+		return
+	}
+
+	message := fmt.Sprintf("%d current replicas, %d desired replicas, between %d and %d",
+		hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas, *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	for _, c := range hpa.Status.Conditions {
+		if c.Type == autoscalingv2beta2.ScalingLimited && c.Status == corev1.ConditionTrue {
+			message += ": " + c.Message
+		}
+	}
+	integration.Status.SetCondition(v1.IntegrationConditionHorizontalPodAutoscalerAvailable, corev1.ConditionTrue, active.Reason, message)
+}
@@ -0,0 +1,363 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigureHpaTraitDoesSucceed(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	configured, err := hpaTrait.Configure(environment)
+
+	assert.True(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureHpaTraitDisabledByDefault(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	hpaTrait.Enabled = nil
+	configured, err := hpaTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureHpaTraitWithInvalidMetric(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+
+	for _, metric := range []string{"requests", "pods:requests", "object:requests=10", "pods:requests=ten"} {
+		hpaTrait.Metrics = []string{metric}
+		configured, err := hpaTrait.Configure(environment)
+		assert.NotNil(t, err, metric)
+		assert.False(t, configured, metric)
+	}
+}
+
+func TestHpaIsCreatedWithDefaults(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+
+	hpa := hpaCreatedCheck(hpaTrait, environment, t)
+	assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+	assert.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32(80), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+}
+
+func TestHpaIsCreatedWithMetrics(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	minReplicas := int32(2)
+	memory := int32(60)
+	hpaTrait.MinReplicas = &minReplicas
+	hpaTrait.MaxReplicas = 5
+	hpaTrait.Memory = &memory
+	hpaTrait.Metrics = []string{"pods:http_requests_per_second=100", "external:queue_messages=30"}
+
+	hpa := hpaCreatedCheck(hpaTrait, environment, t)
+	assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	assert.Len(t, hpa.Spec.Metrics, 3)
+	assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32(60), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Equal(t, autoscalingv2beta2.PodsMetricSourceType, hpa.Spec.Metrics[1].Type)
+	assert.Equal(t, "http_requests_per_second", hpa.Spec.Metrics[1].Pods.Metric.Name)
+	assert.Equal(t, "100", hpa.Spec.Metrics[1].Pods.Target.AverageValue.String())
+	assert.Equal(t, autoscalingv2beta2.ExternalMetricSourceType, hpa.Spec.Metrics[2].Type)
+	assert.Equal(t, "queue_messages", hpa.Spec.Metrics[2].External.Metric.Name)
+}
+
+func TestHpaWithMaxReplicasLowerThanMinReplicas(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	minReplicas := int32(3)
+	hpaTrait.MinReplicas = &minReplicas
+	hpaTrait.MaxReplicas = 2
+
+	assert.NotNil(t, hpaTrait.Apply(environment))
+}
+
+func TestHpaConditionIsReflected(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+
+	minReplicas := int32(1)
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      environment.Integration.Name,
+			Namespace: environment.Integration.Namespace,
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			MinReplicas: &minReplicas,
+			MaxReplicas: 10,
+		},
+		Status: autoscalingv2beta2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 2,
+			DesiredReplicas: 3,
+			Conditions: []autoscalingv2beta2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2beta2.AbleToScale, Status: corev1.ConditionTrue, Reason: "SucceededRescale"},
+				{Type: autoscalingv2beta2.ScalingActive, Status: corev1.ConditionTrue, Reason: "ValidMetricFound"},
+			},
+		},
+	}
+	c, err := test.NewFakeClient(hpa)
+	assert.Nil(t, err)
+	environment.Client = c
+
+	assert.Nil(t, hpaTrait.Apply(environment))
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionHorizontalPodAutoscalerAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "ValidMetricFound", condition.Reason)
+	assert.Equal(t, "2 current replicas, 3 desired replicas, between 1 and 10", condition.Message)
+
+	hpa.Status.Conditions[1] = autoscalingv2beta2.HorizontalPodAutoscalerCondition{
+		Type:    autoscalingv2beta2.ScalingActive,
+		Status:  corev1.ConditionFalse,
+		Reason:  "FailedGetResourceMetric",
+		Message: "missing request for cpu",
+	}
+	assert.Nil(t, c.Update(context.TODO(), hpa))
+
+	assert.Nil(t, hpaTrait.Apply(environment))
+	condition = environment.Integration.Status.GetCondition(v1.IntegrationConditionHorizontalPodAutoscalerAvailable)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "FailedGetResourceMetric", condition.Reason)
+	assert.Equal(t, "missing request for cpu", condition.Message)
+}
+
+func TestConfigureHpaTraitWithInvalidCamelMetric(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+
+	for _, metric := range []string{"inflight-exchanges", "=10", "seda-queue-depth=10", "inflight-exchanges=ten"} {
+		hpaTrait.CamelMetrics = []string{metric}
+		configured, err := hpaTrait.Configure(environment)
+		assert.NotNil(t, err, metric)
+		assert.False(t, configured, metric)
+	}
+}
+
+func TestConfigureHpaTraitWithCamelMetricsWithoutPrometheusLabels(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	hpaTrait.CamelMetrics = []string{"inflight-exchanges=20"}
+	prometheusTrait := environment.Catalog.GetTrait("prometheus").(*prometheusTrait)
+
+	prometheusTrait.Enrich = BoolP(false)
+	configured, err := hpaTrait.Configure(environment)
+	assert.NotNil(t, err)
+	assert.False(t, configured)
+
+	prometheusTrait.Enrich = nil
+	prometheusTrait.Enabled = nil
+	configured, err = hpaTrait.Configure(environment)
+	assert.NotNil(t, err)
+	assert.False(t, configured)
+
+	prometheusTrait.Enabled = BoolP(true)
+	configured, err = hpaTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+}
+
+func TestConfigureHpaTraitWithInvalidMetricsProvider(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	hpaTrait.MetricsProvider = "datadog"
+
+	configured, err := hpaTrait.Configure(environment)
+	assert.NotNil(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureHpaTraitWithKeda(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	hpaTrait.MetricsProvider = "keda"
+	hpaTrait.CamelMetrics = []string{"inflight-exchanges=20"}
+
+	configured, err := hpaTrait.Configure(environment)
+	assert.Equal(t, "the Prometheus URL is required by the keda metrics provider", err.Error())
+	assert.False(t, configured)
+
+	environment.Platform = &v1.IntegrationPlatform{}
+	environment.Platform.Status.Hibernation.PrometheusURL = "http://prometheus:9090"
+	configured, err = hpaTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+
+	hpaTrait.Metrics = []string{"pods:http_requests_per_second=100"}
+	configured, err = hpaTrait.Configure(environment)
+	assert.Equal(t, "custom metrics are not supported by the keda metrics provider", err.Error())
+	assert.False(t, configured)
+}
+
+func TestHpaIsCreatedWithCamelMetrics(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	hpaTrait.CamelMetrics = []string{"inflight-exchanges=20", "camel_seda_queue_depth=100"}
+
+	hpa := hpaCreatedCheck(hpaTrait, environment, t)
+	assert.Len(t, hpa.Spec.Metrics, 2)
+
+	metric := hpa.Spec.Metrics[0]
+	assert.Equal(t, autoscalingv2beta2.ExternalMetricSourceType, metric.Type)
+	assert.Equal(t, "application_camel_context_exchanges_inflight", metric.External.Metric.Name)
+	assert.Equal(t, map[string]string{"integration": "integration-name"}, metric.External.Metric.Selector.MatchLabels)
+	assert.Equal(t, autoscalingv2beta2.AverageValueMetricType, metric.External.Target.Type)
+	assert.Equal(t, "20", metric.External.Target.AverageValue.String())
+
+	metric = hpa.Spec.Metrics[1]
+	assert.Equal(t, "camel_seda_queue_depth", metric.External.Metric.Name)
+	assert.Equal(t, "100", metric.External.Target.AverageValue.String())
+}
+
+func TestKedaScaledObjectIsCreated(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	cpu := int32(70)
+	hpaTrait.MetricsProvider = "keda"
+	hpaTrait.MaxReplicas = 5
+	hpaTrait.CPU = &cpu
+	hpaTrait.CamelMetrics = []string{"inflight-exchanges=20"}
+	hpaTrait.PrometheusURL = "http://prometheus:9090"
+
+	assert.Nil(t, hpaTrait.Apply(environment))
+	assert.Nil(t, findHpa(environment.Resources))
+
+	var scaledObject *unstructured.Unstructured
+	environment.Resources.Visit(func(obj runtime.Object) {
+		if u, ok := obj.(*unstructured.Unstructured); ok && u.GetKind() == "ScaledObject" {
+			scaledObject = u
+		}
+	})
+	assert.NotNil(t, scaledObject)
+	assert.Equal(t, "keda.sh/v1alpha1", scaledObject.GetAPIVersion())
+	assert.Equal(t, "integration-name", scaledObject.GetName())
+	assert.Equal(t, map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": v1.SchemeGroupVersion.String(),
+			"kind":       v1.IntegrationKind,
+			"name":       "integration-name",
+		},
+		"minReplicaCount": int64(1),
+		"maxReplicaCount": int64(5),
+		"triggers": []interface{}{
+			map[string]interface{}{
+				"type":       "cpu",
+				"metricType": "Utilization",
+				"metadata": map[string]interface{}{
+					"value": "70",
+				},
+			},
+			map[string]interface{}{
+				"type": "prometheus",
+				"metadata": map[string]interface{}{
+					"serverAddress": "http://prometheus:9090",
+					"query":         `sum(application_camel_context_exchanges_inflight{namespace="ns",integration="integration-name"})`,
+					"threshold":     "20",
+				},
+			},
+		},
+	}, scaledObject.Object["spec"])
+}
+
+func TestKedaHpaConditionIsReflected(t *testing.T) {
+	hpaTrait, environment := createHpaTest()
+	hpaTrait.MetricsProvider = "keda"
+
+	minReplicas := int32(1)
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keda-hpa-" + environment.Integration.Name,
+			Namespace: environment.Integration.Namespace,
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			MinReplicas: &minReplicas,
+			MaxReplicas: 10,
+		},
+		Status: autoscalingv2beta2.HorizontalPodAutoscalerStatus{
+			Conditions: []autoscalingv2beta2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2beta2.AbleToScale, Status: corev1.ConditionTrue, Reason: "SucceededRescale"},
+				{Type: autoscalingv2beta2.ScalingActive, Status: corev1.ConditionFalse, Reason: "FailedGetExternalMetric"},
+			},
+		},
+	}
+	c, err := test.NewFakeClient(hpa)
+	assert.Nil(t, err)
+	environment.Client = c
+
+	assert.Nil(t, hpaTrait.Apply(environment))
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionHorizontalPodAutoscalerAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "FailedGetExternalMetric", condition.Reason)
+}
+
+func hpaCreatedCheck(hpaTrait *hpaTrait, environment *Environment, t *testing.T) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	t.Helper()
+
+	err := hpaTrait.Apply(environment)
+	assert.Nil(t, err)
+	hpa := findHpa(environment.Resources)
+
+	assert.NotNil(t, hpa)
+	assert.Equal(t, environment.Integration.Name, hpa.Name)
+	assert.Equal(t, environment.Integration.Namespace, hpa.Namespace)
+	assert.Equal(t, v1.IntegrationKind, hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, environment.Integration.Name, hpa.Spec.ScaleTargetRef.Name)
+	return hpa
+}
+
+func findHpa(resources *kubernetes.Collection) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	for _, a := range resources.Items() {
+		if hpa, ok := a.(*autoscalingv2beta2.HorizontalPodAutoscaler); ok {
+			return hpa
+		}
+	}
+	return nil
+}
+
+func createHpaTest() (*hpaTrait, *Environment) {
+	trait := newHpaTrait().(*hpaTrait)
+	trait.Enabled = BoolP(true)
+
+	environment := &Environment{
+		Ctx: context.TODO(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Catalog:   NewCatalog(nil),
+		Resources: kubernetes.NewCollection(),
+	}
+	environment.Catalog.GetTrait("prometheus").(*prometheusTrait).Enabled = BoolP(true)
+
+	return trait, environment
+}
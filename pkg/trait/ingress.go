@@ -18,12 +18,17 @@ limitations under the License.
 package trait
 
 import (
-	"errors"
+	"bytes"
 	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 )
@@ -33,18 +38,62 @@ import (
 //
 // It's enabled by default whenever a Service is added to the integration (through the `service` trait).
 //
+// Hosts and paths can be templated with the name and namespace of the integration, using the
+// `{{ .Name }}` and `{{ .Namespace }}` placeholders, e.g. `{{ .Name }}.{{ .Namespace }}.example.com`.
+//
+// TLS can be enabled either by referencing an existing `secret` containing the certificate, or by
+// delegating the issuance of the certificate to https://cert-manager.io[cert-manager].
+//
 // +camel-k:trait=ingress
 type ingressTrait struct {
 	BaseTrait `property:",squash"`
 	// **Required**. To configure the host exposed by the ingress.
 	Host string `property:"host" json:"host,omitempty"`
+	// To configure additional hosts exposed by the ingress.
+	Hosts []string `property:"hosts" json:"hosts,omitempty"`
+	// To configure the path exposed by the ingress, e.g. `/{{ .Name }}`.
+	Path string `property:"path" json:"path,omitempty"`
+	// To configure additional paths exposed by the ingress, for each of its hosts.
+	Paths []string `property:"paths" json:"paths,omitempty"`
+	// The type of the path exposed by the ingress: `Exact`, `Prefix` or `ImplementationSpecific` (default `Prefix`).
+	PathType string `property:"path-type" json:"pathType,omitempty"`
+	// The name of the IngressClass the ingress is bound to.
+	IngressClassName string `property:"ingress-class-name" json:"ingressClassName,omitempty"`
+	// The name of the secret holding the TLS certificate and key for the exposed hosts.
+	TLSSecretName string `property:"tls-secret-name" json:"tlsSecretName,omitempty"`
+	// The name of the cert-manager Issuer to request the TLS certificate from.
+	// The certificate is stored in the secret set with `tls-secret-name`, or `<integration>-tls` by default.
+	CertManagerIssuer string `property:"cert-manager-issuer" json:"certManagerIssuer,omitempty"`
+	// The name of the cert-manager ClusterIssuer to request the TLS certificate from.
+	// The certificate is stored in the secret set with `tls-secret-name`, or `<integration>-tls` by default.
+	CertManagerClusterIssuer string `property:"cert-manager-cluster-issuer" json:"certManagerClusterIssuer,omitempty"`
+	// The ingress controller implementation, used to compute controller specific annotations: `nginx` or `traefik`.
+	// It defaults to the ingress class name when it matches one of them, `nginx` otherwise.
+	Controller string `property:"controller" json:"controller,omitempty"`
+	// To rewrite the path of the requests forwarded to the service, e.g. `/`.
+	RewriteTarget string `property:"rewrite-target" json:"rewriteTarget,omitempty"`
+	// The annotations added to the ingress, in the form of `key=value`.
+	Annotations []string `property:"annotations" json:"annotations,omitempty"`
 	// To automatically add an ingress whenever the integration uses a HTTP endpoint consumer.
 	Auto *bool `property:"auto" json:"auto,omitempty"`
 }
 
+const (
+	ingressControllerNginx   = "nginx"
+	ingressControllerTraefik = "traefik"
+
+	certManagerIssuerAnnotation        = "cert-manager.io/issuer"
+	certManagerClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+)
+
+var ingressRewriteTargetAnnotations = map[string]string{
+	ingressControllerNginx:   "nginx.ingress.kubernetes.io/rewrite-target",
+	ingressControllerTraefik: "traefik.ingress.kubernetes.io/rewrite-target",
+}
+
 func newIngressTrait() Trait {
 	return &ingressTrait{
-		BaseTrait: NewBaseTrait("ingress", 2400),
+		BaseTrait: NewBaseTrait("ingress", 2400, ExecuteAfter(serviceTraitID)),
 		Host:      "",
 	}
 }
@@ -71,7 +120,7 @@ func (t *ingressTrait) Configure(e *Environment) (bool, error) {
 
 	if IsNilOrTrue(t.Auto) {
 		hasService := e.Resources.GetUserServiceForIntegration(e.Integration) != nil
-		hasHost := t.Host != ""
+		hasHost := t.Host != "" || len(t.Hosts) > 0
 		enabled := hasService && hasHost
 
 		if !enabled {
@@ -86,7 +135,7 @@ func (t *ingressTrait) Configure(e *Environment) (bool, error) {
 		}
 	}
 
-	if t.Host == "" {
+	if t.Host == "" && len(t.Hosts) == 0 {
 		e.Integration.Status.SetCondition(
 			v1.IntegrationConditionExposureAvailable,
 			corev1.ConditionFalse,
@@ -97,46 +146,124 @@ func (t *ingressTrait) Configure(e *Environment) (bool, error) {
 		return false, errors.New("cannot Apply ingress trait: no host defined")
 	}
 
+	if t.PathType != "" && !isValidPathType(networking.PathType(t.PathType)) {
+		return false, fmt.Errorf("unsupported path type %s", t.PathType)
+	}
+	if t.CertManagerIssuer != "" && t.CertManagerClusterIssuer != "" {
+		return false, errors.New("cert-manager issuer and cluster issuer cannot be set at the same time")
+	}
+	if _, ok := ingressRewriteTargetAnnotations[t.getController()]; !ok {
+		return false, fmt.Errorf("unsupported ingress controller %s", t.Controller)
+	}
+
 	return true, nil
 }
 
+func isValidPathType(pathType networking.PathType) bool {
+	return pathType == networking.PathTypeExact || pathType == networking.PathTypePrefix || pathType == networking.PathTypeImplementationSpecific
+}
+
+func (t *ingressTrait) getController() string {
+	if t.Controller != "" {
+		return t.Controller
+	}
+	if _, ok := ingressRewriteTargetAnnotations[t.IngressClassName]; ok {
+		return t.IngressClassName
+	}
+	return ingressControllerNginx
+}
+
 func (t *ingressTrait) Apply(e *Environment) error {
 	service := e.Resources.GetUserServiceForIntegration(e.Integration)
 	if service == nil {
 		return errors.New("cannot Apply ingress trait: no target service")
 	}
 
+	hosts, err := t.getHosts(e)
+	if err != nil {
+		return err
+	}
+	annotations, err := t.getAnnotations()
+	if err != nil {
+		return err
+	}
+
+	backend := networking.IngressBackend{
+		Service: &networking.IngressServiceBackend{
+			Name: service.Name,
+			Port: networking.ServiceBackendPort{
+				Name: "http",
+			},
+		},
+	}
+
 	ingress := networking.Ingress{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Ingress",
 			APIVersion: networking.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      service.Name,
-			Namespace: service.Namespace,
+			Name:        service.Name,
+			Namespace:   service.Namespace,
+			Annotations: annotations,
 		},
 		Spec: networking.IngressSpec{
-			DefaultBackend: &networking.IngressBackend{
-				Service: &networking.IngressServiceBackend{
-					Name: service.Name,
-					Port: networking.ServiceBackendPort{
-						Name: "http",
-					},
-				},
-			},
-			Rules: []networking.IngressRule{
-				{
-					Host: t.Host,
-				},
-			},
+			DefaultBackend: &backend,
 		},
 	}
 
+	if t.IngressClassName != "" {
+		ingress.Spec.IngressClassName = &t.IngressClassName
+	}
+
+	var paths *networking.HTTPIngressRuleValue
+	if t.Path != "" || len(t.Paths) > 0 {
+		pathType := networking.PathTypePrefix
+		if t.PathType != "" {
+			pathType = networking.PathType(t.PathType)
+		}
+		values, err := t.getPaths(e)
+		if err != nil {
+			return err
+		}
+		paths = &networking.HTTPIngressRuleValue{}
+		for _, path := range values {
+			paths.Paths = append(paths.Paths, networking.HTTPIngressPath{
+				Path:     path,
+				PathType: &pathType,
+				Backend:  backend,
+			})
+		}
+	}
+
+	for _, host := range hosts {
+		rule := networking.IngressRule{
+			Host: host,
+		}
+		if paths != nil {
+			rule.HTTP = paths.DeepCopy()
+		}
+		ingress.Spec.Rules = append(ingress.Spec.Rules, rule)
+	}
+
+	if t.TLSSecretName != "" || t.CertManagerIssuer != "" || t.CertManagerClusterIssuer != "" {
+		secretName := t.TLSSecretName
+		if secretName == "" {
+			secretName = fmt.Sprintf("%s-tls", service.Name)
+		}
+		ingress.Spec.TLS = []networking.IngressTLS{
+			{
+				Hosts:      hosts,
+				SecretName: secretName,
+			},
+		}
+	}
+
 	e.Resources.Add(&ingress)
 
 	message := fmt.Sprintf("%s(%s) -> %s(%s)",
 		ingress.Name,
-		t.Host,
+		strings.Join(hosts, ","),
 		ingress.Spec.DefaultBackend.Service.Name,
 		ingress.Spec.DefaultBackend.Service.Port.Name)
 
@@ -149,3 +276,94 @@ func (t *ingressTrait) Apply(e *Environment) error {
 
 	return nil
 }
+
+func (t *ingressTrait) getHosts(e *Environment) ([]string, error) {
+	return t.evaluateAll(e, t.Host, t.Hosts, func(host string) error {
+		// The wildcard hosts are supported by the Ingress API
+		if errs := validation.IsDNS1123Subdomain(strings.TrimPrefix(host, "*.")); len(errs) > 0 {
+			return fmt.Errorf("invalid ingress host %q: %s", host, strings.Join(errs, ", "))
+		}
+		return nil
+	})
+}
+
+func (t *ingressTrait) getPaths(e *Environment) ([]string, error) {
+	return t.evaluateAll(e, t.Path, t.Paths, func(path string) error {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid ingress path %q: must be an absolute path", path)
+		}
+		return nil
+	})
+}
+
+// evaluateAll returns the given value, if any, followed by the given values, with their placeholders resolved,
+// and checked with the given validation function
+func (t *ingressTrait) evaluateAll(e *Environment, value string, values []string, validate func(string) error) ([]string, error) {
+	all := make([]string, 0, len(values)+1)
+	if value != "" {
+		all = append(all, value)
+	}
+	all = append(all, values...)
+
+	for i, v := range all {
+		evaluated, err := t.evaluate(e, v)
+		if err != nil {
+			return nil, err
+		}
+		if err := validate(evaluated); err != nil {
+			return nil, err
+		}
+		all[i] = evaluated
+	}
+
+	return all, nil
+}
+
+func (t *ingressTrait) getAnnotations() (map[string]string, error) {
+	annotations, err := qualifiedKeyValuePairArrayAsStringMap(t.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.CertManagerIssuer != "" {
+		annotations[certManagerIssuerAnnotation] = t.CertManagerIssuer
+	}
+	if t.CertManagerClusterIssuer != "" {
+		annotations[certManagerClusterIssuerAnnotation] = t.CertManagerClusterIssuer
+	}
+	if t.RewriteTarget != "" {
+		annotations[ingressRewriteTargetAnnotations[t.getController()]] = t.RewriteTarget
+	}
+
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+
+	return annotations, nil
+}
+
+// evaluate resolves the integration name and namespace placeholders in the given value
+func (t *ingressTrait) evaluate(e *Environment, value string) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	tmpl, err := template.New(string(t.ID())).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot parse ingress template %q", value)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, struct {
+		Name      string
+		Namespace string
+	}{
+		Name:      e.Integration.Name,
+		Namespace: e.Integration.Namespace,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot evaluate ingress template %q", value)
+	}
+
+	return buf.String(), nil
+}
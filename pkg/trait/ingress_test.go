@@ -152,6 +152,115 @@ func TestApplyIngressTraitDoesSucceed(t *testing.T) {
 	assert.Equal(t, "service-name(hostname) -> service-name(http)", conditions[0].Message)
 }
 
+func TestApplyIngressTraitWithTLSAndPathsDoesSucceed(t *testing.T) {
+	ingressTrait, environment := createNominalIngressTest()
+	ingressTrait.Host = "{{ .Name }}.example.com"
+	ingressTrait.Hosts = []string{"{{ .Name }}.{{ .Namespace }}.example.com"}
+	ingressTrait.Path = "/{{ .Name }}"
+	ingressTrait.Paths = []string{"/api/{{ .Namespace }}"}
+	ingressTrait.IngressClassName = "traefik"
+	ingressTrait.CertManagerClusterIssuer = "letsencrypt"
+	ingressTrait.RewriteTarget = "/"
+	ingressTrait.Annotations = []string{"example.com/key=value"}
+
+	configured, err := ingressTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = ingressTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	ingress := getIngress(environment)
+	assert.NotNil(t, ingress)
+	assert.Equal(t, "traefik", *ingress.Spec.IngressClassName)
+	assert.Equal(t, map[string]string{
+		"example.com/key":                              "value",
+		"cert-manager.io/cluster-issuer":               "letsencrypt",
+		"traefik.ingress.kubernetes.io/rewrite-target": "/",
+	}, ingress.Annotations)
+
+	assert.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "integration-name.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "integration-name.namespace.example.com", ingress.Spec.Rules[1].Host)
+	for _, rule := range ingress.Spec.Rules {
+		assert.Len(t, rule.HTTP.Paths, 2)
+		assert.Equal(t, "/integration-name", rule.HTTP.Paths[0].Path)
+		assert.Equal(t, "/api/namespace", rule.HTTP.Paths[1].Path)
+		for _, path := range rule.HTTP.Paths {
+			assert.Equal(t, networking.PathTypePrefix, *path.PathType)
+			assert.Equal(t, "service-name", path.Backend.Service.Name)
+		}
+	}
+
+	assert.Len(t, ingress.Spec.TLS, 1)
+	assert.Equal(t, "service-name-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, []string{"integration-name.example.com", "integration-name.namespace.example.com"}, ingress.Spec.TLS[0].Hosts)
+}
+
+func TestApplyIngressTraitWithTLSSecretDoesSucceed(t *testing.T) {
+	ingressTrait, environment := createNominalIngressTest()
+	ingressTrait.TLSSecretName = "my-tls"
+	ingressTrait.RewriteTarget = "/$2"
+
+	err := ingressTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	ingress := getIngress(environment)
+	assert.NotNil(t, ingress)
+	assert.Nil(t, ingress.Spec.IngressClassName)
+	assert.Equal(t, map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/$2"}, ingress.Annotations)
+	assert.Nil(t, ingress.Spec.Rules[0].HTTP)
+	assert.Len(t, ingress.Spec.TLS, 1)
+	assert.Equal(t, "my-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, []string{"hostname"}, ingress.Spec.TLS[0].Hosts)
+}
+
+func TestApplyIngressTraitWithInvalidHostsAndPathsDoesNotSucceed(t *testing.T) {
+	ingressTrait, environment := createNominalIngressTest()
+	ingressTrait.Hosts = []string{"{{ .Name }}_example.com"}
+	err := ingressTrait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `invalid ingress host "integration-name_example.com"`)
+
+	ingressTrait, environment = createNominalIngressTest()
+	ingressTrait.Hosts = []string{"*.example.com"}
+	ingressTrait.Paths = []string{"{{ .Name }}"}
+	err = ingressTrait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, `invalid ingress path "integration-name": must be an absolute path`, err.Error())
+}
+
+func TestConfigureIngressTraitWithInvalidConfigurationDoesNotSucceed(t *testing.T) {
+	ingressTrait, environment := createNominalIngressTest()
+	ingressTrait.PathType = "Wrong"
+	configured, err := ingressTrait.Configure(environment)
+	assert.False(t, configured)
+	assert.Equal(t, "unsupported path type Wrong", err.Error())
+
+	ingressTrait, environment = createNominalIngressTest()
+	ingressTrait.CertManagerIssuer = "issuer"
+	ingressTrait.CertManagerClusterIssuer = "cluster-issuer"
+	configured, err = ingressTrait.Configure(environment)
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+
+	ingressTrait, environment = createNominalIngressTest()
+	ingressTrait.Controller = "haproxy"
+	configured, err = ingressTrait.Configure(environment)
+	assert.False(t, configured)
+	assert.Equal(t, "unsupported ingress controller haproxy", err.Error())
+}
+
+func getIngress(e *Environment) *networking.Ingress {
+	var ingress *networking.Ingress
+	e.Resources.Visit(func(resource runtime.Object) {
+		if i, ok := resource.(*networking.Ingress); ok {
+			ingress = i
+		}
+	})
+	return ingress
+}
+
 func createNominalIngressTest() (*ingressTrait, *Environment) {
 	trait := newIngressTrait().(*ingressTrait)
 	trait.Enabled = BoolP(true)
@@ -162,7 +271,8 @@ func createNominalIngressTest() (*ingressTrait, *Environment) {
 		Catalog: NewCatalog(nil),
 		Integration: &v1.Integration{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "integration-name",
+				Name:      "integration-name",
+				Namespace: "namespace",
 			},
 			Status: v1.IntegrationStatus{
 				Phase: v1.IntegrationPhaseDeploying,
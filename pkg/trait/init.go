@@ -25,9 +25,15 @@ import (
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/dsl"
+	"github.com/apache/camel-k/pkg/util/gzip"
 )
 
-const flowsInternalSourceName = "camel-k-embedded-flow.yaml"
+const (
+	flowsInternalSourceName = "camel-k-embedded-flow.yaml"
+	// flowsCompressionThreshold is the size above which the source generated from the flows is compressed,
+	// so that the flows don't take twice their size in the Integration
+	flowsCompressionThreshold = 64 * 1024
+)
 
 type initTrait struct {
 	BaseTrait `property:",squash"`
@@ -54,12 +60,21 @@ func (t *initTrait) Apply(e *Environment) error {
 		if err != nil {
 			return err
 		}
-		e.Integration.Status.AddOrReplaceGeneratedSources(v1.SourceSpec{
+		source := v1.SourceSpec{
 			DataSpec: v1.DataSpec{
 				Name:    flowsInternalSourceName,
 				Content: string(content),
 			},
-		})
+		}
+		if len(content) > flowsCompressionThreshold {
+			compressed, err := gzip.CompressBase64(content)
+			if err != nil {
+				return err
+			}
+			source.Content = string(compressed)
+			source.Compression = true
+		}
+		e.Integration.Status.AddOrReplaceGeneratedSources(source)
 	}
 
 	// Dependencies need to be recomputed in case of a trait declares a capability but as
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/dsl"
+	"github.com/apache/camel-k/pkg/util/gzip"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func TestInitGeneratesFlowsSource(t *testing.T) {
+	e := createInitTestEnvironment(t, `- from:
+    uri: "timer:tick"
+    steps:
+    - to: "log:info"
+`)
+
+	assert.Nil(t, applyInitTrait(e))
+	assert.Len(t, e.Integration.Status.GeneratedSources, 1)
+	source := e.Integration.Status.GeneratedSources[0]
+	assert.Equal(t, flowsInternalSourceName, source.Name)
+	assert.False(t, source.Compression)
+	assert.Contains(t, source.Content, "timer:tick")
+}
+
+func TestInitCompressesLargeFlowsSource(t *testing.T) {
+	var routes strings.Builder
+	for i := 0; routes.Len() <= 2*flowsCompressionThreshold; i++ {
+		fmt.Fprintf(&routes, "- from:\n    uri: \"timer:tick-%d\"\n    steps:\n    - to: \"log:info\"\n", i)
+	}
+	e := createInitTestEnvironment(t, routes.String())
+
+	assert.Nil(t, applyInitTrait(e))
+	assert.Len(t, e.Integration.Status.GeneratedSources, 1)
+	source := e.Integration.Status.GeneratedSources[0]
+	assert.True(t, source.Compression)
+	assert.Less(t, len(source.Content), flowsCompressionThreshold)
+
+	sources, err := kubernetes.ResolveIntegrationSources(e.Ctx, nil, e.Integration, e.Resources)
+	assert.Nil(t, err)
+	assert.Contains(t, sources[0].Content, "timer:tick-0")
+
+	content, err := gzip.UncompressBase64([]byte(source.Content))
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "timer:tick-0")
+}
+
+func createInitTestEnvironment(t *testing.T, flows string) *Environment {
+	t.Helper()
+
+	f, err := dsl.FromYamlDSLString(flows)
+	assert.Nil(t, err)
+
+	return &Environment{
+		Catalog: NewCatalog(nil),
+		Integration: &v1.Integration{
+			Spec: v1.IntegrationSpec{
+				Flows: f,
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseInitialization,
+			},
+		},
+		Resources: kubernetes.NewCollection(),
+	}
+}
+
+func applyInitTrait(e *Environment) error {
+	trait := newInitTrait()
+	if _, err := trait.Configure(e); err != nil {
+		return err
+	}
+	return trait.Apply(e)
+}
@@ -0,0 +1,184 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/envvar"
+)
+
+// The JDBC trait configures the default datasource of the integration, to connect to a relational database.
+//
+// It adds the Quarkus JDBC driver extension matching the database `type` to the integration dependencies,
+// and configures the datasource URL, credentials and connection pool.
+//
+// The datasource URL is either provided explicitly, computed from the database Kubernetes `service`,
+// or read from the `url` key of the credentials `secret`, which also holds the `username` and `password` keys.
+// Alternatively, when the integration is bound to the database with the service-binding trait, the datasource
+// is configured from the binding.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=jdbc
+type jdbcTrait struct {
+	BaseTrait `property:",squash"`
+	// The type of the database, one of `postgresql`, `mysql`, `mariadb`, `mssql`, `oracle`, `db2`, `h2` or `derby`.
+	Type string `property:"type" json:"type,omitempty"`
+	// The JDBC URL of the database.
+	URL string `property:"url" json:"url,omitempty"`
+	// The name of the Kubernetes Service exposing the database, used to compute the JDBC URL.
+	Service string `property:"service" json:"service,omitempty"`
+	// The port of the database service (defaults to the standard port of the database type).
+	Port int `property:"port" json:"port,omitempty"`
+	// The name of the database, used to compute the JDBC URL.
+	Database string `property:"database" json:"database,omitempty"`
+	// The name of the Secret holding the `username` and `password` of the database, and optionally its `url`.
+	Secret string `property:"secret" json:"secret,omitempty"`
+	// The minimum size of the connection pool.
+	MinPoolSize *int `property:"min-pool-size" json:"minPoolSize,omitempty"`
+	// The maximum size of the connection pool.
+	MaxPoolSize *int `property:"max-pool-size" json:"maxPoolSize,omitempty"`
+}
+
+type jdbcDatabaseType struct {
+	// The JDBC URL format for the host, port and database
+	urlFormat string
+	port      int
+}
+
+var jdbcDatabaseTypes = map[string]jdbcDatabaseType{
+	"postgresql": {urlFormat: "jdbc:postgresql://%s:%d/%s", port: 5432},
+	"mysql":      {urlFormat: "jdbc:mysql://%s:%d/%s", port: 3306},
+	"mariadb":    {urlFormat: "jdbc:mariadb://%s:%d/%s", port: 3306},
+	"mssql":      {urlFormat: "jdbc:sqlserver://%s:%d;databaseName=%s", port: 1433},
+	"oracle":     {urlFormat: "jdbc:oracle:thin:@//%s:%d/%s", port: 1521},
+	"db2":        {urlFormat: "jdbc:db2://%s:%d/%s", port: 50000},
+	"h2":         {},
+	"derby":      {},
+}
+
+const (
+	jdbcURLEnvVar      = "CAMEL_K_JDBC_URL"
+	jdbcUsernameEnvVar = "CAMEL_K_JDBC_USERNAME"
+	// nolint: gosec
+	jdbcPasswordEnvVar = "CAMEL_K_JDBC_PASSWORD"
+
+	serviceBindingDependency = "mvn:io.quarkus:quarkus-kubernetes-service-binding"
+)
+
+func newJdbcTrait() Trait {
+	return &jdbcTrait{
+		BaseTrait: NewBaseTrait("jdbc", 820),
+	}
+}
+
+func (t *jdbcTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	databaseType, ok := jdbcDatabaseTypes[t.Type]
+	if !ok {
+		return false, fmt.Errorf("unsupported database type: %q", t.Type)
+	}
+	if t.URL != "" && t.Service != "" {
+		return false, fmt.Errorf("the database URL and service cannot be set together")
+	}
+	if t.Service != "" && databaseType.urlFormat == "" {
+		return false, fmt.Errorf("database type %q cannot be connected to a service", t.Type)
+	}
+	if t.MinPoolSize != nil && t.MaxPoolSize != nil && *t.MinPoolSize > *t.MaxPoolSize {
+		return false, fmt.Errorf("minimum pool size %d is greater than maximum pool size %d", *t.MinPoolSize, *t.MaxPoolSize)
+	}
+
+	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization) || e.IntegrationInRunningPhases(), nil
+}
+
+func (t *jdbcTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "mvn:io.quarkus:quarkus-jdbc-"+t.Type)
+		if t.isServiceBound(e) {
+			util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, serviceBindingDependency)
+		}
+		return nil
+	}
+
+	e.ApplicationProperties["quarkus.datasource.db-kind"] = t.Type
+
+	switch {
+	case t.URL != "":
+		e.ApplicationProperties["quarkus.datasource.jdbc.url"] = t.URL
+	case t.Service != "":
+		databaseType := jdbcDatabaseTypes[t.Type]
+		port := t.Port
+		if port == 0 {
+			port = databaseType.port
+		}
+		e.ApplicationProperties["quarkus.datasource.jdbc.url"] = fmt.Sprintf(databaseType.urlFormat, t.Service, port, t.Database)
+	case t.Secret != "":
+		envvar.SetVar(&e.EnvVars, secretKeyRefEnvVar(jdbcURLEnvVar, t.Secret, "url"))
+		e.ApplicationProperties["quarkus.datasource.jdbc.url"] = "${" + jdbcURLEnvVar + "}"
+	}
+
+	if t.Secret != "" {
+		envvar.SetVar(&e.EnvVars, secretKeyRefEnvVar(jdbcUsernameEnvVar, t.Secret, "username"))
+		envvar.SetVar(&e.EnvVars, secretKeyRefEnvVar(jdbcPasswordEnvVar, t.Secret, "password"))
+		e.ApplicationProperties["quarkus.datasource.username"] = "${" + jdbcUsernameEnvVar + "}"
+		e.ApplicationProperties["quarkus.datasource.password"] = "${" + jdbcPasswordEnvVar + "}"
+	}
+
+	if t.MinPoolSize != nil {
+		e.ApplicationProperties["quarkus.datasource.jdbc.min-size"] = strconv.Itoa(*t.MinPoolSize)
+	}
+	if t.MaxPoolSize != nil {
+		e.ApplicationProperties["quarkus.datasource.jdbc.max-size"] = strconv.Itoa(*t.MaxPoolSize)
+	}
+
+	return nil
+}
+
+// isServiceBound returns whether the datasource is expected to be configured by the service-binding trait
+func (t *jdbcTrait) isServiceBound(e *Environment) bool {
+	if t.URL != "" || t.Service != "" || t.Secret != "" {
+		return false
+	}
+	if sbt, ok := e.Catalog.GetTrait("service-binding").(*serviceBindingTrait); ok {
+		return IsNilOrTrue(sbt.Enabled) && (len(sbt.Services) > 0 || len(sbt.Secrets) > 0)
+	}
+	return false
+}
+
+func secretKeyRefEnvVar(name string, secret string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secret,
+				},
+				Key: key,
+			},
+		},
+	}
+}
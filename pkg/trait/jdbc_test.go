@@ -0,0 +1,183 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+)
+
+func TestConfigureJdbcTraitDisabledByDefault(t *testing.T) {
+	environment := createJdbcTestEnvironment(v1.IntegrationPhaseDeploying)
+	jdbcTrait := newJdbcTrait().(*jdbcTrait)
+
+	configured, err := jdbcTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureJdbcTraitInvalidConfiguration(t *testing.T) {
+	environment := createJdbcTestEnvironment(v1.IntegrationPhaseDeploying)
+
+	jdbcTrait := createNominalJdbcTrait("sqlite")
+	_, err := jdbcTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	jdbcTrait = createNominalJdbcTrait("h2")
+	jdbcTrait.Service = "my-database"
+	_, err = jdbcTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	jdbcTrait = createNominalJdbcTrait("postgresql")
+	jdbcTrait.Service = "my-database"
+	jdbcTrait.URL = "jdbc:postgresql://my-database:5432/db"
+	_, err = jdbcTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	jdbcTrait = createNominalJdbcTrait("postgresql")
+	jdbcTrait.MinPoolSize = intPtr(10)
+	jdbcTrait.MaxPoolSize = intPtr(5)
+	_, err = jdbcTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestApplyJdbcTraitDependencies(t *testing.T) {
+	environment := createJdbcTestEnvironment(v1.IntegrationPhaseInitialization)
+	jdbcTrait := createNominalJdbcTrait("mariadb")
+	jdbcTrait.Secret = "my-credentials"
+
+	configured, err := jdbcTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = jdbcTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"mvn:io.quarkus:quarkus-jdbc-mariadb"}, environment.Integration.Status.Dependencies)
+	assert.Empty(t, environment.ApplicationProperties)
+}
+
+func TestApplyJdbcTraitDependenciesWithServiceBinding(t *testing.T) {
+	environment := createJdbcTestEnvironment(v1.IntegrationPhaseInitialization)
+	serviceBindingTrait := environment.Catalog.GetTrait("service-binding").(*serviceBindingTrait)
+	serviceBindingTrait.Services = []string{"postgres-operator.crunchydata.com/v1beta1:PostgresCluster:my-database"}
+	jdbcTrait := createNominalJdbcTrait("postgresql")
+
+	err := jdbcTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"mvn:io.quarkus:quarkus-jdbc-postgresql",
+		"mvn:io.quarkus:quarkus-kubernetes-service-binding",
+	}, environment.Integration.Status.Dependencies)
+}
+
+func TestApplyJdbcTraitWithService(t *testing.T) {
+	environment := createJdbcTestEnvironment(v1.IntegrationPhaseDeploying)
+	jdbcTrait := createNominalJdbcTrait("postgresql")
+	jdbcTrait.Service = "my-database"
+	jdbcTrait.Database = "orders"
+	jdbcTrait.Secret = "my-credentials"
+	jdbcTrait.MinPoolSize = intPtr(2)
+	jdbcTrait.MaxPoolSize = intPtr(20)
+
+	err := jdbcTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]string{
+		"quarkus.datasource.db-kind":       "postgresql",
+		"quarkus.datasource.jdbc.url":      "jdbc:postgresql://my-database:5432/orders",
+		"quarkus.datasource.username":      "${CAMEL_K_JDBC_USERNAME}",
+		"quarkus.datasource.password":      "${CAMEL_K_JDBC_PASSWORD}",
+		"quarkus.datasource.jdbc.min-size": "2",
+		"quarkus.datasource.jdbc.max-size": "20",
+	}, environment.ApplicationProperties)
+
+	assert.Len(t, environment.EnvVars, 2)
+	username := envvar.Get(environment.EnvVars, "CAMEL_K_JDBC_USERNAME")
+	assert.NotNil(t, username)
+	assert.Equal(t, "my-credentials", username.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "username", username.ValueFrom.SecretKeyRef.Key)
+	password := envvar.Get(environment.EnvVars, "CAMEL_K_JDBC_PASSWORD")
+	assert.NotNil(t, password)
+	assert.Equal(t, "password", password.ValueFrom.SecretKeyRef.Key)
+}
+
+func TestApplyJdbcTraitWithServiceAndPort(t *testing.T) {
+	environment := createJdbcTestEnvironment(v1.IntegrationPhaseDeploying)
+	jdbcTrait := createNominalJdbcTrait("mssql")
+	jdbcTrait.Service = "my-database"
+	jdbcTrait.Port = 11433
+	jdbcTrait.Database = "orders"
+
+	err := jdbcTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "jdbc:sqlserver://my-database:11433;databaseName=orders", environment.ApplicationProperties["quarkus.datasource.jdbc.url"])
+	assert.Empty(t, environment.EnvVars)
+}
+
+func TestApplyJdbcTraitWithSecretURL(t *testing.T) {
+	environment := createJdbcTestEnvironment(v1.IntegrationPhaseRunning)
+	jdbcTrait := createNominalJdbcTrait("mysql")
+	jdbcTrait.Secret = "my-credentials"
+
+	err := jdbcTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "${CAMEL_K_JDBC_URL}", environment.ApplicationProperties["quarkus.datasource.jdbc.url"])
+	assert.Len(t, environment.EnvVars, 3)
+	url := envvar.Get(environment.EnvVars, "CAMEL_K_JDBC_URL")
+	assert.NotNil(t, url)
+	assert.Equal(t, "url", url.ValueFrom.SecretKeyRef.Key)
+}
+
+func createNominalJdbcTrait(databaseType string) *jdbcTrait {
+	jdbcTrait := newJdbcTrait().(*jdbcTrait)
+	jdbcTrait.Enabled = BoolP(true)
+	jdbcTrait.Type = databaseType
+	return jdbcTrait
+}
+
+func createJdbcTestEnvironment(phase v1.IntegrationPhase) *Environment {
+	return &Environment{
+		Catalog: NewCatalog(nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "integration-name",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: phase,
+			},
+		},
+		ApplicationProperties: make(map[string]string),
+		EnvVars:               make([]corev1.EnvVar, 0),
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
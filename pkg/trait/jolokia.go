@@ -19,10 +19,12 @@ package trait
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util"
@@ -30,6 +32,10 @@ import (
 
 // The Jolokia trait activates and configures the Jolokia Java agent.
 //
+// A read-only access policy is enforced by default, that can be replaced with a custom policy file.
+// The certificates can be loaded from Secrets, and the integration pods are annotated so that they are
+// discovered by https://github.com/hawtio/hawtio-online[Hawtio Online].
+//
 // See https://jolokia.org/reference/html/agents.html
 //
 // +camel-k:trait=jolokia
@@ -65,11 +71,44 @@ type jolokiaTrait struct {
 	// A list of additional Jolokia options as defined
 	// in https://jolokia.org/reference/html/agents.html#agent-jvm-config[JVM agent configuration options]
 	Options []string `property:"options" json:"options,omitempty"`
+	// The Secret containing the PEM encoded CA certificate used to verify client certificates, in the form `name[/key]`
+	// (default key `ca.crt`). It takes precedence over `ca-cert`.
+	CaCertSecret string `property:"ca-cert-secret" json:"CACertSecret,omitempty"`
+	// The `kubernetes.io/tls` Secret containing the certificate and the private key the Jolokia endpoint
+	// is served with, applicable when `protocol` is `https`.
+	ServerCertSecret string `property:"server-cert-secret" json:"serverCertSecret,omitempty"`
+	// Whether the read-only access policy, that only allows the `read`, `list`, `search` and `version` requests,
+	// is enforced (default `true`).
+	ReadOnly *bool `property:"read-only" json:"readOnly,omitempty"`
+	// The ConfigMap containing a custom `jolokia-access.xml` access policy file. It takes precedence over `read-only`.
+	PolicyConfigMap string `property:"policy-config-map" json:"policyConfigMap,omitempty"`
+	// Whether the integration pods are annotated to be discovered by Hawtio Online (default `true`).
+	Hawtio *bool `property:"hawtio" json:"hawtio,omitempty"`
 }
 
+const (
+	jolokiaMountPath            = "/etc/jolokia"
+	jolokiaPolicyFileName       = "jolokia-access.xml"
+	jolokiaPolicyVolumeName     = "jolokia-policy"
+	jolokiaCaCertVolumeName     = "jolokia-ca-cert"
+	jolokiaServerCertVolumeName = "jolokia-server-cert"
+
+	// The read-only access policy only allows the commands that don't mutate the MBeans
+	jolokiaReadOnlyPolicy = `<?xml version="1.0" encoding="UTF-8"?>
+<restrict>
+  <commands>
+    <command>read</command>
+    <command>list</command>
+    <command>search</command>
+    <command>version</command>
+  </commands>
+</restrict>
+`
+)
+
 func newJolokiaTrait() Trait {
 	return &jolokiaTrait{
-		BaseTrait: NewBaseTrait("jolokia", 1800),
+		BaseTrait: NewBaseTrait("jolokia", 1800, ExecuteAfter(containerTraitID)),
 		Port:      8778,
 	}
 }
@@ -134,6 +173,57 @@ func (t *jolokiaTrait) Apply(e *Environment) (err error) {
 		})
 	}
 
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("unable to find the pod spec of integration %s", e.Integration.Name)
+	}
+
+	// Load the certificates from the Secrets
+	if t.CaCertSecret != "" {
+		name, key := t.CaCertSecret, "ca.crt"
+		if i := strings.Index(name, "/"); i >= 0 {
+			name, key = name[:i], name[i+1:]
+		}
+		mountPath := path.Join(jolokiaMountPath, jolokiaCaCertVolumeName)
+		t.mountVolume(podSpec, container, jolokiaCaCertVolumeName, mountPath, corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: name,
+			},
+		})
+		caCert := path.Join(mountPath, key)
+		t.CaCert = &caCert
+	}
+	if t.ServerCertSecret != "" {
+		mountPath := path.Join(jolokiaMountPath, jolokiaServerCertVolumeName)
+		t.mountVolume(podSpec, container, jolokiaServerCertVolumeName, mountPath, corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: t.ServerCertSecret,
+			},
+		})
+		options["serverCert"] = path.Join(mountPath, corev1.TLSCertKey)
+		options["serverKey"] = path.Join(mountPath, corev1.TLSPrivateKeyKey)
+	}
+
+	// Enforce the access policy, unless a policy location is explicitly set
+	if _, ok := options["policyLocation"]; !ok {
+		policyConfigMap := t.PolicyConfigMap
+		if policyConfigMap == "" && IsNilOrTrue(t.ReadOnly) {
+			policyConfigMap = e.Integration.Name + "-jolokia-policy"
+			e.Resources.Add(t.getPolicyConfigMapFor(e, policyConfigMap))
+		}
+		if policyConfigMap != "" {
+			mountPath := path.Join(jolokiaMountPath, jolokiaPolicyVolumeName)
+			t.mountVolume(podSpec, container, jolokiaPolicyVolumeName, mountPath, corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: policyConfigMap,
+					},
+				},
+			})
+			options["policyLocation"] = "file://" + path.Join(mountPath, jolokiaPolicyFileName)
+		}
+	}
+
 	// Then add explicitly set trait configuration properties
 	t.addToJolokiaOptions(options, "caCert", t.CaCert)
 	t.addToJolokiaOptions(options, "clientPrincipal", t.ClientPrincipal)
@@ -170,9 +260,56 @@ func (t *jolokiaTrait) Apply(e *Environment) (err error) {
 
 	container.Ports = append(container.Ports, containerPort)
 
+	// Annotate the pods for discovery by Hawtio Online
+	if IsNilOrTrue(t.Hawtio) {
+		protocol := options["protocol"]
+		if protocol == "" {
+			protocol = "http"
+		}
+		e.Resources.VisitPodTemplateMeta(func(meta *metav1.ObjectMeta) {
+			if meta.Annotations == nil {
+				meta.Annotations = make(map[string]string)
+			}
+			meta.Annotations["hawt.io/jolokiaPath"] = "/jolokia/"
+			meta.Annotations["hawt.io/jolokiaPort"] = strconv.Itoa(t.Port)
+			meta.Annotations["hawt.io/protocol"] = protocol
+		})
+	}
+
 	return nil
 }
 
+func (t *jolokiaTrait) mountVolume(podSpec *corev1.PodSpec, container *corev1.Container, name string, mountPath string, source corev1.VolumeSource) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         name,
+		VolumeSource: source,
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      name,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+}
+
+func (t *jolokiaTrait) getPolicyConfigMapFor(e *Environment, name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Data: map[string]string{
+			jolokiaPolicyFileName: jolokiaReadOnlyPolicy,
+		},
+	}
+}
+
 func (t *jolokiaTrait) setDefaultJolokiaOption(options map[string]string, option interface{}, key string, value interface{}) {
 	// Do not override existing option
 	if _, ok := options[key]; ok {
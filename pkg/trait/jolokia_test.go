@@ -24,6 +24,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
@@ -50,7 +51,7 @@ func TestApplyJolokiaTraitNominalShouldSucceed(t *testing.T) {
 	assert.NotNil(t, container)
 
 	assert.Equal(t, container.Args, []string{
-		"-javaagent:dependencies/lib/main/org.jolokia.jolokia-jvm-1.7.0.jar=discoveryEnabled=false,host=*,port=8778",
+		"-javaagent:dependencies/lib/main/org.jolokia.jolokia-jvm-1.7.0.jar=discoveryEnabled=false,host=*,policyLocation=file:///etc/jolokia/jolokia-policy/jolokia-access.xml,port=8778",
 	})
 
 	assert.Len(t, container.Ports, 1)
@@ -80,7 +81,7 @@ func TestApplyJolokiaTraitForOpenShiftProfileShouldSucceed(t *testing.T) {
 		"-javaagent:dependencies/lib/main/org.jolokia.jolokia-jvm-1.7.0.jar=caCert=/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt," +
 			"clientPrincipal.1=cn=system:master-proxy,clientPrincipal.2=cn=hawtio-online.hawtio.svc," +
 			"clientPrincipal.3=cn=fuse-console.fuse.svc,discoveryEnabled=false,extendedClientCheck=true," +
-			"host=*,port=8778,protocol=https,useSslClientAuthentication=true",
+			"host=*,policyLocation=file:///etc/jolokia/jolokia-policy/jolokia-access.xml,port=8778,protocol=https,useSslClientAuthentication=true",
 	})
 
 	assert.Len(t, container.Ports, 1)
@@ -128,11 +129,109 @@ func TestApplyJolokiaTraitWithOptionShouldOverrideDefault(t *testing.T) {
 
 	assert.Equal(t, container.Args, []string{
 		"-javaagent:dependencies/lib/main/org.jolokia.jolokia-jvm-1.7.0.jar=caCert=.cacert,clientPrincipal=cn:any," +
-			"discoveryEnabled=true,extendedClientCheck=false,host=explicit-host,port=8778,protocol=http," +
+			"discoveryEnabled=true,extendedClientCheck=false,host=explicit-host," +
+			"policyLocation=file:///etc/jolokia/jolokia-policy/jolokia-access.xml,port=8778,protocol=http," +
 			"useSslClientAuthentication=false",
 	})
 }
 
+func TestApplyJolokiaTraitWithSecretsShouldMountCertificates(t *testing.T) {
+	trait, environment := createNominalJolokiaTest()
+	protocol := "https"
+	trait.Protocol = &protocol
+	trait.CaCertSecret = "my-ca/service-ca.crt"
+	trait.ServerCertSecret = "my-tls"
+
+	err := trait.Apply(environment)
+
+	assert.Nil(t, err)
+
+	container := environment.Resources.GetContainerByName(defaultContainerName)
+	assert.Equal(t, container.Args, []string{
+		"-javaagent:dependencies/lib/main/org.jolokia.jolokia-jvm-1.7.0.jar=caCert=/etc/jolokia/jolokia-ca-cert/service-ca.crt," +
+			"discoveryEnabled=false,host=*,policyLocation=file:///etc/jolokia/jolokia-policy/jolokia-access.xml,port=8778,protocol=https," +
+			"serverCert=/etc/jolokia/jolokia-server-cert/tls.crt,serverKey=/etc/jolokia/jolokia-server-cert/tls.key",
+	})
+	assert.Len(t, container.VolumeMounts, 3)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: jolokiaCaCertVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "my-ca",
+			},
+		},
+	})
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: jolokiaServerCertVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "my-tls",
+			},
+		},
+	})
+
+	deployment := environment.Resources.GetDeployment(func(*appsv1.Deployment) bool { return true })
+	assert.Equal(t, "https", deployment.Spec.Template.Annotations["hawt.io/protocol"])
+	assert.Equal(t, "8778", deployment.Spec.Template.Annotations["hawt.io/jolokiaPort"])
+}
+
+func TestApplyJolokiaTraitAccessPolicy(t *testing.T) {
+	trait, environment := createNominalJolokiaTest()
+	environment.Integration.Name = "my-integration"
+	environment.Resources = kubernetes.NewCollection(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-integration",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+
+	policy := environment.Resources.GetConfigMap(func(cm *corev1.ConfigMap) bool {
+		return cm.Name == "my-integration-jolokia-policy"
+	})
+	assert.NotNil(t, policy)
+	assert.Equal(t, jolokiaReadOnlyPolicy, policy.Data[jolokiaPolicyFileName])
+
+	trait, environment = createNominalJolokiaTest()
+	trait.PolicyConfigMap = "my-policy"
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Nil(t, environment.Resources.GetConfigMap(func(*corev1.ConfigMap) bool { return true }))
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Equal(t, "my-policy", podSpec.Volumes[0].ConfigMap.Name)
+
+	trait, environment = createNominalJolokiaTest()
+	trait.ReadOnly = BoolP(false)
+	trait.Hawtio = BoolP(false)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	container := environment.Resources.GetContainerByName(defaultContainerName)
+	assert.Equal(t, container.Args, []string{
+		"-javaagent:dependencies/lib/main/org.jolokia.jolokia-jvm-1.7.0.jar=discoveryEnabled=false,host=*,port=8778",
+	})
+	assert.Empty(t, container.VolumeMounts)
+	deployment := environment.Resources.GetDeployment(func(*appsv1.Deployment) bool { return true })
+	assert.Empty(t, deployment.Spec.Template.Annotations)
+}
+
 func TestApplyJolokiaTraitWithUnparseableOptionShouldReturnError(t *testing.T) {
 	trait, environment := createNominalJolokiaTest()
 	trait.Options = []string{"unparseable options"}
@@ -60,7 +60,7 @@ type jvmTrait struct {
 
 func newJvmTrait() Trait {
 	return &jvmTrait{
-		BaseTrait:    NewBaseTrait("jvm", 2000),
+		BaseTrait:    NewBaseTrait("jvm", 2000, ExecuteAfter(containerTraitID)),
 		DebugAddress: "*:5005",
 		PrintCommand: BoolP(true),
 	}
@@ -160,6 +160,13 @@ func (t *jvmTrait) Apply(e *Environment) error {
 		})
 	}
 
+	// Restrict the JVM to the FIPS validated crypto providers, whose configuration is added to the image
+	if e.fipsEnabled() {
+		args = append(args,
+			"-Djava.security.properties=="+path.Join(builder.DeploymentDir, builder.FIPSSecurityFile),
+			"-Dorg.bouncycastle.fips.approved_only=true")
+	}
+
 	hasHeapSizeOption := false
 	// Add JVM options
 	if len(t.Options) > 0 {
@@ -249,6 +249,34 @@ func TestApplyJvmTraitWithClasspath(t *testing.T) {
 	}, d.Spec.Template.Spec.Containers[0].Args)
 }
 
+func TestApplyJvmTraitInFIPSMode(t *testing.T) {
+	trait, environment := createNominalJvmTest(v1.IntegrationKitTypePlatform)
+	environment.Platform = &v1.IntegrationPlatform{}
+	environment.Platform.Status.FIPS = true
+
+	d := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+	environment.Resources.Add(&d)
+
+	err := trait.Apply(environment)
+	assert.Nil(t, err)
+
+	args := d.Spec.Template.Spec.Containers[0].Args
+	assert.Contains(t, args, "-Djava.security.properties==/deployments/fips.java.security")
+	assert.Contains(t, args, "-Dorg.bouncycastle.fips.approved_only=true")
+}
+
 func createNominalJvmTest(kitType string) (*jvmTrait, *Environment) {
 	catalog, _ := camel.DefaultCatalog()
 
@@ -110,6 +110,9 @@ func (t *kameletsTrait) Configure(e *Environment) (bool, error) {
 		}
 		// Check if a Kamelet is configured as default error handler URI
 		defaultErrorHandlerURI := e.Integration.Spec.GetConfigurationProperty(v1alpha1.ErrorHandlerAppPropertiesPrefix + ".deadLetterUri")
+		if eht, ok := e.Catalog.GetTrait("error-handler").(*errorHandlerTrait); ok && IsNilOrTrue(eht.Enabled) {
+			defaultErrorHandlerURI = eht.getDeadLetterURI(e)
+		}
 		if defaultErrorHandlerURI != "" {
 			if strings.HasPrefix(defaultErrorHandlerURI, "kamelet:") {
 				kamelets = append(kamelets, source.ExtractKamelet(defaultErrorHandlerURI))
@@ -75,6 +75,23 @@ func TestConfigurationWithKamelets(t *testing.T) {
 	}, trait.getConfigurationKeys())
 }
 
+func TestConfigurationWithErrorHandlerKamelet(t *testing.T) {
+	trait, environment := createKameletsTestEnvironment(`
+- from:
+    uri: timer:tick
+    steps:
+    - to: log:info
+`)
+	errorHandlerTrait := environment.Catalog.GetTrait("error-handler").(*errorHandlerTrait)
+	errorHandlerTrait.Type = string(v1alpha1.ErrorHandlerTypeDeadLetterChannel)
+	errorHandlerTrait.DeadLetterURI = "kamelet:my-sink"
+
+	enabled, err := trait.Configure(environment)
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, []string{"my-sink"}, trait.getKameletKeys())
+}
+
 func TestKameletLookup(t *testing.T) {
 	trait, environment := createKameletsTestEnvironment(`
 - from:
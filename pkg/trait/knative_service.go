@@ -24,7 +24,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	serving "knative.dev/serving/pkg/apis/serving/v1"
+	servingv1alpha1 "knative.dev/serving/pkg/apis/serving/v1alpha1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/metadata"
@@ -41,6 +43,8 @@ const (
 	knativeServingMaxScaleAnnotation = "autoscaling.knative.dev/maxScale"
 	// Rollout annotation
 	knativeServingRolloutDurationAnnotation = "serving.knative.dev/rolloutDuration"
+	// Visibility label
+	knativeServingVisibilityLabel = "networking.knative.dev/visibility"
 )
 
 // The Knative Service trait allows configuring options when running the Integration as a Knative service, instead of
@@ -78,6 +82,19 @@ type knativeServiceTrait struct {
 	// It's disabled by default and must be expressed as a Golang `time.Duration` string representation,
 	// rounded to a second precision.
 	RolloutDuration string `property:"rollout-duration" json:"rolloutDuration,omitempty"`
+	// Setting `cluster-local`, the Knative service becomes a private service, only reachable from within the cluster.
+	// Specifically, this option applies the `networking.knative.dev/visibility` label to the Knative service.
+	//
+	// Refer to the Knative documentation for more information.
+	Visibility string `property:"visibility" json:"visibility,omitempty"`
+	// The custom domains the integration is exposed on. A Knative DomainMapping is created for each of the domains,
+	// that routes the traffic to the Knative service.
+	//
+	// Refer to the Knative documentation for more information.
+	Domains []string `property:"domains" json:"domains,omitempty"`
+	// The name of the Secret, of type `kubernetes.io/tls`, containing the certificate the custom domains
+	// terminate TLS traffic with. It's only relevant when custom domains are configured.
+	TLSSecret string `property:"tls-secret" json:"tlsSecret,omitempty"`
 	// Automatically deploy the integration as Knative service when all conditions hold:
 	//
 	// * Integration is using the Knative profile
@@ -89,7 +106,7 @@ var _ ControllerStrategySelector = &knativeServiceTrait{}
 
 func newKnativeServiceTrait() Trait {
 	return &knativeServiceTrait{
-		BaseTrait: NewBaseTrait("knative-service", 1400),
+		BaseTrait: NewBaseTrait("knative-service", 1400, ExecuteAfter(serviceAccountTraitID)),
 	}
 }
 
@@ -184,6 +201,10 @@ func (t *knativeServiceTrait) Apply(e *Environment) error {
 	}
 	e.Resources.Add(ksvc)
 
+	for _, domain := range t.Domains {
+		e.Resources.Add(t.getDomainMappingFor(e, ksvc, domain))
+	}
+
 	e.Integration.Status.SetCondition(
 		v1.IntegrationConditionKnativeServiceAvailable,
 		corev1.ConditionTrue,
@@ -216,6 +237,13 @@ func (t *knativeServiceTrait) SelectControllerStrategy(e *Environment) (*Control
 	return nil, nil
 }
 
+func (t *knativeServiceTrait) ExplainControllerStrategy(e *Environment, strategy ControllerStrategy) (string, string) {
+	if IsTrue(t.Enabled) {
+		return v1.IntegrationConditionControllerStrategyTraitReason, "the knative-service trait is explicitly enabled"
+	}
+	return v1.IntegrationConditionControllerStrategyHTTPServicesReason, "the integration exposes HTTP services"
+}
+
 func (t *knativeServiceTrait) ControllerStrategySelectorOrder() int {
 	return 100
 }
@@ -232,6 +260,14 @@ func (t *knativeServiceTrait) getServiceFor(e *Environment) (*serving.Service, e
 		serviceAnnotations[knativeServingRolloutDurationAnnotation] = t.RolloutDuration
 	}
 
+	serviceLabels := map[string]string{
+		v1.IntegrationLabel: e.Integration.Name,
+	}
+	// Make the Knative service private
+	if t.Visibility != "" {
+		serviceLabels[knativeServingVisibilityLabel] = t.Visibility
+	}
+
 	revisionAnnotations := make(map[string]string)
 	if e.Integration.Annotations != nil {
 		for k, v := range filterTransferableAnnotations(e.Integration.Annotations) {
@@ -261,11 +297,9 @@ func (t *knativeServiceTrait) getServiceFor(e *Environment) (*serving.Service, e
 			APIVersion: serving.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      e.Integration.Name,
-			Namespace: e.Integration.Namespace,
-			Labels: map[string]string{
-				v1.IntegrationLabel: e.Integration.Name,
-			},
+			Name:        e.Integration.Name,
+			Namespace:   e.Integration.Namespace,
+			Labels:      serviceLabels,
 			Annotations: serviceAnnotations,
 		},
 		Spec: serving.ServiceSpec{
@@ -277,7 +311,7 @@ func (t *knativeServiceTrait) getServiceFor(e *Environment) (*serving.Service, e
 					},
 					Spec: serving.RevisionSpec{
 						PodSpec: corev1.PodSpec{
-							ServiceAccountName: e.Integration.Spec.ServiceAccountName,
+							ServiceAccountName: e.GetIntegrationServiceAccountName(),
 						},
 					},
 				},
@@ -335,3 +369,36 @@ func (t *knativeServiceTrait) getServiceFor(e *Environment) (*serving.Service, e
 
 	return &svc, nil
 }
+
+func (t *knativeServiceTrait) getDomainMappingFor(e *Environment, ksvc *serving.Service, domain string) *servingv1alpha1.DomainMapping {
+	mapping := servingv1alpha1.DomainMapping{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DomainMapping",
+			APIVersion: servingv1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			// The DomainMapping name is the domain it maps
+			Name:      domain,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Spec: servingv1alpha1.DomainMappingSpec{
+			Ref: duckv1.KReference{
+				Kind:       ksvc.Kind,
+				APIVersion: ksvc.APIVersion,
+				Name:       ksvc.Name,
+				Namespace:  ksvc.Namespace,
+			},
+		},
+	}
+
+	if t.TLSSecret != "" {
+		mapping.Spec.TLS = &servingv1alpha1.SecretTLS{
+			SecretName: t.TLSSecret,
+		}
+	}
+
+	return &mapping
+}
@@ -24,8 +24,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	serving "knative.dev/serving/pkg/apis/serving/v1"
+	servingv1alpha1 "knative.dev/serving/pkg/apis/serving/v1alpha1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/camel"
@@ -395,3 +397,87 @@ func TestKnativeServiceWithRollout(t *testing.T) {
 
 	assert.Equal(t, ksvc.Annotations[knativeServingRolloutDurationAnnotation], "60s")
 }
+
+func TestKnativeServiceWithDomains(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	traitCatalog := NewCatalog(nil)
+
+	environment := Environment{
+		CamelCatalog: catalog,
+		Catalog:      traitCatalog,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      KnativeServiceTestName,
+				Namespace: KnativeServiceTestNamespace,
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKnative,
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "rests.xml",
+							Content: `<rest path="/test"></rest>`,
+						},
+						Language: v1.LanguageXML,
+					},
+				},
+				Traits: map[string]v1.TraitSpec{
+					"knative-service": test.TraitSpecFromMap(t, map[string]interface{}{
+						"visibility": "cluster-local",
+						"domains":    []string{"api.example.com", "www.example.com"},
+						"tlsSecret":  "example-tls",
+					}),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterKubernetes,
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      kubernetes.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	err = traitCatalog.apply(&environment)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, environment.GetTrait("knative-service"))
+
+	ksvc := environment.Resources.GetKnativeService(func(service *serving.Service) bool {
+		return service.Name == KnativeServiceTestName
+	})
+	assert.NotNil(t, ksvc)
+	assert.Equal(t, "cluster-local", ksvc.Labels[knativeServingVisibilityLabel])
+	assert.Equal(t, KnativeServiceTestName, ksvc.Labels[v1.IntegrationLabel])
+
+	mappings := make(map[string]*servingv1alpha1.DomainMapping)
+	environment.Resources.Visit(func(obj runtime.Object) {
+		if mapping, ok := obj.(*servingv1alpha1.DomainMapping); ok {
+			mappings[mapping.Name] = mapping
+		}
+	})
+	assert.Len(t, mappings, 2)
+	for _, domain := range []string{"api.example.com", "www.example.com"} {
+		mapping := mappings[domain]
+		assert.NotNil(t, mapping)
+		assert.Equal(t, KnativeServiceTestNamespace, mapping.Namespace)
+		assert.Equal(t, "Service", mapping.Spec.Ref.Kind)
+		assert.Equal(t, serving.SchemeGroupVersion.String(), mapping.Spec.Ref.APIVersion)
+		assert.Equal(t, KnativeServiceTestName, mapping.Spec.Ref.Name)
+		assert.NotNil(t, mapping.Spec.TLS)
+		assert.Equal(t, "example-tls", mapping.Spec.TLS.SecretName)
+	}
+}
@@ -0,0 +1,447 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The Mount trait mounts volumes into the integration container, with a finer control over the volume content
+// and the mount than the `--config`, `--resource` and `--volume` options, that doesn't require to customize the pod template.
+//
+// Each mount is declared with the `<type>[:<name>]@<path>[?<option>=<value>&...]` syntax, where `type` is one of:
+//
+// * `configmap:<name>` and `secret:<name>`: mounts the ConfigMap or Secret. The keys are selected with the `items` option,
+// as a comma-separated list of `key[:file]`, e.g. `configmap:my-cm@/etc/config?items=app.yaml,log.xml:logback.xml`.
+// * `projected:<name>`: mounts several ConfigMaps and Secrets, and the pod metadata, into the same directory,
+// declared with the `configmaps`, `secrets` and `fields` options, e.g. `projected:config@/etc/config?configmaps=cm1,cm2&secrets=s1`.
+// * `downwardapi:<name>`: mounts the pod metadata fields, declared with the `fields` option, as a comma-separated list
+// of `fieldPath[:file]`, e.g. `downwardapi:podinfo@/etc/podinfo?fields=metadata.labels,metadata.annotations`.
+// * `emptydir`: mounts a scratch volume, whose size is limited with the `sizeLimit` option, and that is backed
+// by memory with the `medium=Memory` option, e.g. `emptydir@/tmp/scratch?sizeLimit=1Gi`.
+// * `pvc:<claim>`: mounts an existing PersistentVolumeClaim.
+// * `csi:<secret-provider-class>`: mounts the secrets of an external secret store, e.g. AWS Secrets Manager, Azure Key Vault,
+// GCP Secret Manager or HashiCorp Vault, declared with a SecretProviderClass, with the https://secrets-store-csi-driver.sigs.k8s.io[Secrets Store CSI driver],
+// so that the secrets are never stored into etcd, e.g. `csi:my-vault-secrets@/mnt/secrets`. The credentials the driver authenticates
+// to the secret store with are set with the `nodePublishSecret` option, and the driver with the `driver` option, that defaults to `secrets-store.csi.k8s.io`.
+// The pod labels required by the provider of the SecretProviderClass are added to the integration pods, i.e. the `azure.workload.identity/use`
+// label for the Azure workload identity, and the `aadpodidbinding` label, set to the `podIdentity` option, for the Azure pod identity.
+//
+// All mounts accept the `subPath` and `readOnly` options, the `csi` mounts being always read-only. The `configmap`, `secret`, `projected` and `downwardapi` mounts
+// also accept the `mode` option, that sets the permissions of the files in octal notation, e.g. `mode=0400`.
+//
+// The properties resolved from ConfigMaps and Secrets, with the `{{configmap:name/key}}` and `{{secret:name/key}}`
+// placeholders, can be opted in for hot-reload with the `hot-reload` option. Such properties are reloaded by the running
+// Camel context when the referenced resources change, rather than rolling out the integration, e.g. to tune a timer period
+// or a log level. Note that only the routes, and the components, that support reloading properties pick up the new values.
+//
+// Note that Knative requires the `kubernetes.podspec-emptydir` and `kubernetes.podspec-persistent-volume-claim` features
+// to be enabled, for the `emptydir` and `pvc` volumes to be mounted into Knative Services.
+//
+// +camel-k:trait=mount
+type mountTrait struct {
+	BaseTrait `property:",squash"`
+	// The list of mounts, e.g. `secret:my-tls@/etc/tls?items=tls.crt,tls.key&mode=0400` or `emptydir@/tmp/scratch?sizeLimit=500Mi`.
+	Volumes []string `property:"volumes" json:"volumes,omitempty"`
+	// The list of properties, resolved from ConfigMaps or Secrets, that are reloaded by the running integration
+	// when the referenced resources change, instead of rolling out the integration.
+	HotReload []string `property:"hot-reload" json:"hotReload,omitempty"`
+}
+
+const (
+	mountTypeConfigMap   = "configmap"
+	mountTypeSecret      = "secret"
+	mountTypeProjected   = "projected"
+	mountTypeDownwardAPI = "downwardapi"
+	mountTypeEmptyDir    = "emptydir"
+	mountTypePVC         = "pvc"
+	mountTypeCSI         = "csi"
+
+	secretsStoreCSIDriver       = "secrets-store.csi.k8s.io"
+	secretProviderClassVersion  = "secrets-store.csi.x-k8s.io/v1"
+	secretProviderClassKind     = "SecretProviderClass"
+	secretProviderClassProperty = "secretProviderClass"
+
+	azureWorkloadIdentityLabel = "azure.workload.identity/use"
+	azurePodIdentityLabel      = "aadpodidbinding"
+)
+
+func newMountTrait() Trait {
+	return &mountTrait{
+		BaseTrait: NewBaseTrait("mount", 1620, ExecuteAfter(containerTraitID)),
+	}
+}
+
+func (t *mountTrait) Configure(e *Environment) (bool, error) {
+	if IsFalse(t.Enabled) || (len(t.Volumes) == 0 && len(t.HotReload) == 0) {
+		return false, nil
+	}
+
+	for i, v := range t.Volumes {
+		if _, _, err := parseMount(i, v); err != nil {
+			return false, err
+		}
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *mountTrait) Apply(e *Environment) error {
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return fmt.Errorf("unable to find integration container: %s", e.Integration.Name)
+	}
+
+	for i, v := range t.Volumes {
+		volume, mount, err := parseMount(i, v)
+		if err != nil {
+			return err
+		}
+		podSpec.Volumes = append(podSpec.Volumes, *volume)
+		container.VolumeMounts = append(container.VolumeMounts, *mount)
+
+		if volume.CSI != nil && volume.CSI.Driver == secretsStoreCSIDriver {
+			labels, err := t.secretProviderClassLabels(e, volume.CSI.VolumeAttributes[secretProviderClassProperty], v)
+			if err != nil {
+				return err
+			}
+			if len(labels) > 0 {
+				e.Resources.VisitPodTemplateMeta(func(podMeta *metav1.ObjectMeta) {
+					if podMeta.Labels == nil {
+						podMeta.Labels = make(map[string]string)
+					}
+					for k, v := range labels {
+						podMeta.Labels[k] = v
+					}
+				})
+			}
+		}
+	}
+
+	e.Resources.VisitSecret(func(secret *corev1.Secret) {
+		if secret.Labels["camel.apache.org/properties.type"] != reloadablePropertiesType {
+			return
+		}
+		// The directory is mounted without sub-path, so that the kubelet updates the file when the Secret changes
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: reloadablePropertiesType + "-properties",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  "application.properties",
+							Path: reloadablePropertiesType + ".properties",
+						},
+					},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      reloadablePropertiesType + "-properties",
+			MountPath: reloadablePropertiesMountPath,
+			ReadOnly:  true,
+		})
+	})
+
+	return nil
+}
+
+// secretProviderClassLabels returns the pod labels required by the provider of the given SecretProviderClass, that's
+// mounted with the given declaration. No label is returned when the SecretProviderClass does not exist (yet).
+func (t *mountTrait) secretProviderClassLabels(e *Environment, name string, value string) (map[string]string, error) {
+	spc := &unstructured.Unstructured{}
+	spc.SetAPIVersion(secretProviderClassVersion)
+	spc.SetKind(secretProviderClassKind)
+	err := e.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: name}, spc)
+	if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		t.L.ForIntegration(e.Integration).Infof("SecretProviderClass %s not found, no pod label required by its provider is added", name)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	provider, _, _ := unstructured.NestedString(spc.Object, "spec", "provider")
+	parameters, _, _ := unstructured.NestedStringMap(spc.Object, "spec", "parameters")
+	labels := make(map[string]string)
+	switch provider {
+	case "azure":
+		if parameters["usePodIdentity"] == True {
+			_, query, _ := splitOnce(value, "?")
+			options, _ := url.ParseQuery(query)
+			identity := options.Get("podIdentity")
+			if identity == "" {
+				return nil, fmt.Errorf("invalid mount %q: the SecretProviderClass %s uses the Azure pod identity, "+
+					"whose binding must be set with the podIdentity option", value, name)
+			}
+			labels[azurePodIdentityLabel] = identity
+		} else if parameters["useVMManagedIdentity"] != True && parameters["clientID"] != "" {
+			labels[azureWorkloadIdentityLabel] = True
+		}
+	}
+	return labels, nil
+}
+
+// hotReloadProperties returns the properties opted in for hot-reload, if the trait is enabled
+func (t *mountTrait) hotReloadProperties() []string {
+	if IsFalse(t.Enabled) {
+		return nil
+	}
+	return t.HotReload
+}
+
+// parseMount parses the given mount declaration into the volume and volume mount
+func parseMount(index int, value string) (*corev1.Volume, *corev1.VolumeMount, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid mount %q: %s", value, reason)
+	}
+
+	source, target, ok := splitOnce(value, "@")
+	if !ok {
+		return nil, nil, invalid("no mount path provided, must be <type>[:<name>]@<path>")
+	}
+	mountPath, query, _ := splitOnce(target, "?")
+	if !path.IsAbs(mountPath) {
+		return nil, nil, invalid("the mount path must be absolute")
+	}
+	options, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, nil, invalid(err.Error())
+	}
+	mountType, name, _ := splitOnce(source, ":")
+	mountType = strings.ToLower(mountType)
+	if name == "" && mountType != mountTypeEmptyDir {
+		return nil, nil, invalid("no name provided")
+	}
+
+	var mode *int32
+	if m := options.Get("mode"); m != "" {
+		if mountType == mountTypeEmptyDir || mountType == mountTypePVC || mountType == mountTypeCSI {
+			return nil, nil, invalid("the mode option is not supported for " + mountType)
+		}
+		v, err := strconv.ParseInt(m, 8, 32)
+		if err != nil || v < 0 || v > 0777 {
+			return nil, nil, invalid("the mode must be in octal notation, between 0000 and 0777")
+		}
+		i := int32(v)
+		mode = &i
+	}
+
+	readOnly := true
+	volume := corev1.Volume{
+		Name: fmt.Sprintf("i-mount-%03d", index),
+	}
+	switch mountType {
+	case mountTypeConfigMap:
+		items, err := parseMountItems(options.Get("items"))
+		if err != nil {
+			return nil, nil, invalid(err.Error())
+		}
+		volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Items:                items,
+			DefaultMode:          mode,
+		}
+	case mountTypeSecret:
+		items, err := parseMountItems(options.Get("items"))
+		if err != nil {
+			return nil, nil, invalid(err.Error())
+		}
+		volume.Secret = &corev1.SecretVolumeSource{
+			SecretName:  name,
+			Items:       items,
+			DefaultMode: mode,
+		}
+	case mountTypeDownwardAPI:
+		fields, err := parseMountFields(options.Get("fields"))
+		if err != nil {
+			return nil, nil, invalid(err.Error())
+		}
+		if len(fields) == 0 {
+			return nil, nil, invalid("no fields provided")
+		}
+		volume.DownwardAPI = &corev1.DownwardAPIVolumeSource{
+			Items:       fields,
+			DefaultMode: mode,
+		}
+	case mountTypeProjected:
+		sources := make([]corev1.VolumeProjection, 0)
+		for _, cm := range splitMountOption(options.Get("configmaps")) {
+			sources = append(sources, corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: cm},
+				},
+			})
+		}
+		for _, secret := range splitMountOption(options.Get("secrets")) {
+			sources = append(sources, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				},
+			})
+		}
+		fields, err := parseMountFields(options.Get("fields"))
+		if err != nil {
+			return nil, nil, invalid(err.Error())
+		}
+		if len(fields) > 0 {
+			sources = append(sources, corev1.VolumeProjection{
+				DownwardAPI: &corev1.DownwardAPIProjection{Items: fields},
+			})
+		}
+		if len(sources) == 0 {
+			return nil, nil, invalid("no configmaps, secrets or fields provided")
+		}
+		volume.Projected = &corev1.ProjectedVolumeSource{
+			Sources:     sources,
+			DefaultMode: mode,
+		}
+	case mountTypeEmptyDir:
+		emptyDir := &corev1.EmptyDirVolumeSource{}
+		if limit := options.Get("sizeLimit"); limit != "" {
+			quantity, err := resource.ParseQuantity(limit)
+			if err != nil {
+				return nil, nil, invalid(fmt.Sprintf("invalid size limit %q: %v", limit, err))
+			}
+			emptyDir.SizeLimit = &quantity
+		}
+		switch medium := corev1.StorageMedium(options.Get("medium")); medium {
+		case corev1.StorageMediumDefault, corev1.StorageMediumMemory:
+			emptyDir.Medium = medium
+		default:
+			return nil, nil, invalid(fmt.Sprintf("unsupported medium %q", medium))
+		}
+		volume.EmptyDir = emptyDir
+		readOnly = false
+	case mountTypePVC:
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: name,
+		}
+		readOnly = false
+	case mountTypeCSI:
+		driver := options.Get("driver")
+		if driver == "" {
+			driver = secretsStoreCSIDriver
+		}
+		// The Secrets Store CSI driver only supports read-only volumes
+		csiReadOnly := true
+		volume.CSI = &corev1.CSIVolumeSource{
+			Driver:   driver,
+			ReadOnly: &csiReadOnly,
+			VolumeAttributes: map[string]string{
+				secretProviderClassProperty: name,
+			},
+		}
+		if secret := options.Get("nodePublishSecret"); secret != "" {
+			volume.CSI.NodePublishSecretRef = &corev1.LocalObjectReference{Name: secret}
+		}
+		if options.Get("readOnly") != "" {
+			if b, err := strconv.ParseBool(options.Get("readOnly")); err != nil || !b {
+				return nil, nil, invalid("the csi mounts are read-only")
+			}
+		}
+	default:
+		return nil, nil, invalid(fmt.Sprintf("unsupported type %q", mountType))
+	}
+
+	if ro := options.Get("readOnly"); ro != "" {
+		b, err := strconv.ParseBool(ro)
+		if err != nil {
+			return nil, nil, invalid(fmt.Sprintf("invalid readOnly option %q", ro))
+		}
+		readOnly = b
+	}
+
+	return &volume, &corev1.VolumeMount{
+		Name:      volume.Name,
+		MountPath: mountPath,
+		SubPath:   options.Get("subPath"),
+		ReadOnly:  readOnly,
+	}, nil
+}
+
+// parseMountItems parses the comma-separated list of `key[:file]` items
+func parseMountItems(value string) ([]corev1.KeyToPath, error) {
+	var items []corev1.KeyToPath
+	for _, item := range splitMountOption(value) {
+		key, file, ok := splitOnce(item, ":")
+		if !ok {
+			file = key
+		}
+		if key == "" || file == "" {
+			return nil, fmt.Errorf("invalid item %q, must be key[:file]", item)
+		}
+		items = append(items, corev1.KeyToPath{Key: key, Path: file})
+	}
+	return items, nil
+}
+
+// parseMountFields parses the comma-separated list of `fieldPath[:file]` downward API fields,
+// the file defaulting to the last segment of the field path
+func parseMountFields(value string) ([]corev1.DownwardAPIVolumeFile, error) {
+	var fields []corev1.DownwardAPIVolumeFile
+	for _, field := range splitMountOption(value) {
+		fieldPath, file, ok := splitOnce(field, ":")
+		if !ok {
+			file = fieldPath[strings.LastIndex(fieldPath, ".")+1:]
+		}
+		if !strings.HasPrefix(fieldPath, "metadata.") || file == "" {
+			return nil, fmt.Errorf("invalid field %q, must be metadata.<field>[:file]", field)
+		}
+		fields = append(fields, corev1.DownwardAPIVolumeFile{
+			Path:     file,
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath},
+		})
+	}
+	return fields, nil
+}
+
+func splitMountOption(value string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitOnce slices s around the first instance of sep
+func splitOnce(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
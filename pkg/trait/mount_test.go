@@ -0,0 +1,313 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigureMountTraitWithoutVolumes(t *testing.T) {
+	environment := createMountTestEnvironment()
+	mountTrait := newMountTrait().(*mountTrait)
+
+	configured, err := mountTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureMountTraitInvalidVolumes(t *testing.T) {
+	environment := createMountTestEnvironment()
+
+	for _, volume := range []string{
+		"configmap:my-cm",
+		"configmap:my-cm@etc/config",
+		"configmap@/etc/config",
+		"hostpath:/var/log@/var/log",
+		"secret:my-secret@/etc/secret?mode=999",
+		"secret:my-secret@/etc/secret?items=:tls.crt",
+		"emptydir@/tmp?mode=0600",
+		"emptydir@/tmp?sizeLimit=lots",
+		"emptydir@/tmp?medium=Disk",
+		"downwardapi:podinfo@/etc/podinfo",
+		"downwardapi:podinfo@/etc/podinfo?fields=spec.nodeName",
+		"projected:config@/etc/config",
+		"pvc:my-claim@/data?readOnly=maybe",
+		"csi@/mnt/secrets",
+		"csi:my-secrets@/mnt/secrets?readOnly=false",
+		"csi:my-secrets@/mnt/secrets?mode=0400",
+	} {
+		mountTrait := newMountTrait().(*mountTrait)
+		mountTrait.Volumes = []string{volume}
+		_, err := mountTrait.Configure(environment)
+		assert.NotNil(t, err, volume)
+	}
+}
+
+func TestApplyMountTraitWithConfigMapAndSecret(t *testing.T) {
+	environment := createMountTestEnvironment()
+	mountTrait := newMountTrait().(*mountTrait)
+	mountTrait.Volumes = []string{
+		"configmap:my-cm@/etc/config?items=app.yaml,log.xml:logback.xml",
+		"secret:my-tls@/etc/tls/tls.crt?items=tls.crt&mode=0400&subPath=tls.crt",
+	}
+
+	configured, err := mountTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = mountTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Len(t, podSpec.Volumes, 2)
+	assert.Equal(t, "i-mount-000", podSpec.Volumes[0].Name)
+	assert.Equal(t, "my-cm", podSpec.Volumes[0].ConfigMap.Name)
+	assert.Equal(t, []corev1.KeyToPath{
+		{Key: "app.yaml", Path: "app.yaml"},
+		{Key: "log.xml", Path: "logback.xml"},
+	}, podSpec.Volumes[0].ConfigMap.Items)
+	assert.Nil(t, podSpec.Volumes[0].ConfigMap.DefaultMode)
+	assert.Equal(t, "i-mount-001", podSpec.Volumes[1].Name)
+	assert.Equal(t, "my-tls", podSpec.Volumes[1].Secret.SecretName)
+	assert.Equal(t, int32(0400), *podSpec.Volumes[1].Secret.DefaultMode)
+
+	mounts := podSpec.Containers[0].VolumeMounts
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "i-mount-000", MountPath: "/etc/config", ReadOnly: true},
+		{Name: "i-mount-001", MountPath: "/etc/tls/tls.crt", ReadOnly: true, SubPath: "tls.crt"},
+	}, mounts)
+}
+
+func TestApplyMountTraitWithProjectedAndDownwardAPI(t *testing.T) {
+	environment := createMountTestEnvironment()
+	mountTrait := newMountTrait().(*mountTrait)
+	mountTrait.Volumes = []string{
+		"projected:config@/etc/config?configmaps=cm1,cm2&secrets=s1&fields=metadata.labels&mode=0440",
+		"downwardapi:podinfo@/etc/podinfo?fields=metadata.labels,metadata.annotations:meta",
+	}
+
+	err := mountTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Len(t, podSpec.Volumes, 2)
+	projected := podSpec.Volumes[0].Projected
+	assert.NotNil(t, projected)
+	assert.Len(t, projected.Sources, 4)
+	assert.Equal(t, "cm1", projected.Sources[0].ConfigMap.Name)
+	assert.Equal(t, "cm2", projected.Sources[1].ConfigMap.Name)
+	assert.Equal(t, "s1", projected.Sources[2].Secret.Name)
+	assert.Equal(t, "metadata.labels", projected.Sources[3].DownwardAPI.Items[0].FieldRef.FieldPath)
+	assert.Equal(t, int32(0440), *projected.DefaultMode)
+
+	downwardAPI := podSpec.Volumes[1].DownwardAPI
+	assert.NotNil(t, downwardAPI)
+	assert.Equal(t, []corev1.DownwardAPIVolumeFile{
+		{Path: "labels", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"}},
+		{Path: "meta", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations"}},
+	}, downwardAPI.Items)
+}
+
+func TestApplyMountTraitWithEmptyDirAndPVC(t *testing.T) {
+	environment := createMountTestEnvironment()
+	mountTrait := newMountTrait().(*mountTrait)
+	mountTrait.Volumes = []string{
+		"emptydir@/tmp/scratch?sizeLimit=500Mi&medium=Memory",
+		"pvc:my-claim@/data?subPath=orders&readOnly=true",
+	}
+
+	err := mountTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	limit := resource.MustParse("500Mi")
+	assert.Equal(t, &corev1.EmptyDirVolumeSource{
+		Medium:    corev1.StorageMediumMemory,
+		SizeLimit: &limit,
+	}, podSpec.Volumes[0].EmptyDir)
+	assert.Equal(t, "my-claim", podSpec.Volumes[1].PersistentVolumeClaim.ClaimName)
+
+	mounts := podSpec.Containers[0].VolumeMounts
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "i-mount-000", MountPath: "/tmp/scratch"},
+		{Name: "i-mount-001", MountPath: "/data", ReadOnly: true, SubPath: "orders"},
+	}, mounts)
+}
+
+func TestApplyMountTraitWithHotReload(t *testing.T) {
+	environment := createMountTestEnvironment()
+	environment.Resources.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-integration-reloadable-properties",
+			Namespace: "ns",
+			Labels: map[string]string{
+				"camel.apache.org/properties.type": "reloadable",
+			},
+		},
+	})
+	mountTrait := newMountTrait().(*mountTrait)
+	mountTrait.HotReload = []string{"timer.period"}
+
+	configured, err := mountTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"timer.period"}, mountTrait.hotReloadProperties())
+
+	err = mountTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "reloadable-properties", podSpec.Volumes[0].Name)
+	assert.Equal(t, "my-integration-reloadable-properties", podSpec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "reloadable-properties", MountPath: "/etc/camel/conf.d/_reloadable", ReadOnly: true},
+	}, podSpec.Containers[0].VolumeMounts)
+
+	mountTrait.Enabled = BoolP(false)
+	assert.Nil(t, mountTrait.hotReloadProperties())
+}
+
+func TestApplyMountTraitWithSecretStoreCSI(t *testing.T) {
+	environment := createMountTestEnvironment()
+	client, err := test.NewFakeClient(
+		newSecretProviderClass("vault-secrets", "vault", nil),
+		newSecretProviderClass("azure-secrets", "azure", map[string]interface{}{"clientID": "00000000-0000-0000-0000-000000000000"}),
+	)
+	assert.Nil(t, err)
+	environment.Client = client
+	mountTrait := newMountTrait().(*mountTrait)
+	mountTrait.Volumes = []string{
+		"csi:vault-secrets@/mnt/vault",
+		"csi:azure-secrets@/mnt/azure?nodePublishSecret=azure-credentials",
+	}
+
+	configured, err := mountTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = mountTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	readOnly := true
+	assert.Equal(t, &corev1.CSIVolumeSource{
+		Driver:           "secrets-store.csi.k8s.io",
+		ReadOnly:         &readOnly,
+		VolumeAttributes: map[string]string{"secretProviderClass": "vault-secrets"},
+	}, podSpec.Volumes[0].CSI)
+	assert.Equal(t, &corev1.CSIVolumeSource{
+		Driver:               "secrets-store.csi.k8s.io",
+		ReadOnly:             &readOnly,
+		VolumeAttributes:     map[string]string{"secretProviderClass": "azure-secrets"},
+		NodePublishSecretRef: &corev1.LocalObjectReference{Name: "azure-credentials"},
+	}, podSpec.Volumes[1].CSI)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "i-mount-000", MountPath: "/mnt/vault", ReadOnly: true},
+		{Name: "i-mount-001", MountPath: "/mnt/azure", ReadOnly: true},
+	}, podSpec.Containers[0].VolumeMounts)
+
+	d := environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.Equal(t, map[string]string{"azure.workload.identity/use": "true"}, d.Spec.Template.Labels)
+}
+
+func TestApplyMountTraitWithSecretStoreCSIPodIdentity(t *testing.T) {
+	environment := createMountTestEnvironment()
+	client, err := test.NewFakeClient(
+		newSecretProviderClass("azure-secrets", "azure", map[string]interface{}{"usePodIdentity": "true"}),
+	)
+	assert.Nil(t, err)
+	environment.Client = client
+
+	mountTrait := newMountTrait().(*mountTrait)
+	mountTrait.Volumes = []string{"csi:azure-secrets@/mnt/azure"}
+	assert.NotNil(t, mountTrait.Apply(environment))
+
+	environment = createMountTestEnvironment()
+	environment.Client = client
+	mountTrait.Volumes = []string{"csi:azure-secrets@/mnt/azure?podIdentity=my-identity"}
+	assert.Nil(t, mountTrait.Apply(environment))
+	d := environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.Equal(t, map[string]string{"aadpodidbinding": "my-identity"}, d.Spec.Template.Labels)
+
+	// The SecretProviderClass may be created after the integration
+	environment = createMountTestEnvironment()
+	environment.Client = client
+	mountTrait.Volumes = []string{"csi:missing-secrets@/mnt/secrets"}
+	assert.Nil(t, mountTrait.Apply(environment))
+	d = environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.Empty(t, d.Spec.Template.Labels)
+}
+
+func newSecretProviderClass(name string, provider string, parameters map[string]interface{}) *unstructured.Unstructured {
+	spc := &unstructured.Unstructured{}
+	spc.SetAPIVersion(secretProviderClassVersion)
+	spc.SetKind(secretProviderClassKind)
+	spc.SetNamespace("ns")
+	spc.SetName(name)
+	_ = unstructured.SetNestedField(spc.Object, provider, "spec", "provider")
+	if parameters != nil {
+		_ = unstructured.SetNestedMap(spc.Object, parameters, "spec", "parameters")
+	}
+	return spc
+}
+
+func createMountTestEnvironment() *Environment {
+	return &Environment{
+		Catalog: NewCatalog(nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-integration",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-integration",
+				Namespace: "ns",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: defaultContainerName,
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
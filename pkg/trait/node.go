@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The Node trait configures how and where the integration pods run on the cluster nodes,
+// such as the container runtime class (e.g. gVisor or Kata Containers), the scheduling priority,
+// or the nodes they are eligible to be scheduled on.
+//
+// The `node-pool` parameter is a shortcut to run the integration pods on a dedicated node pool,
+// whose nodes are labelled and tainted with `<node-pool-label>=<node-pool>`.
+//
+// Note that Knative requires the corresponding `kubernetes.podspec-*` features to be enabled,
+// for these settings to be applied to Knative Services.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=node
+type nodeTrait struct {
+	BaseTrait `property:",squash"`
+	// The name of the RuntimeClass used to run the integration pods.
+	RuntimeClassName string `property:"runtime-class-name" json:"runtimeClassName,omitempty"`
+	// The name of the PriorityClass of the integration pods.
+	PriorityClassName string `property:"priority-class-name" json:"priorityClassName,omitempty"`
+	// The labels of the nodes the integration pods can be scheduled on, in the form of `key=value`.
+	NodeSelector []string `property:"node-selector" json:"nodeSelector,omitempty"`
+	// The name of the dedicated node pool to schedule the integration pods on.
+	NodePool string `property:"node-pool" json:"nodePool,omitempty"`
+	// The key of the label and taint identifying the dedicated node pool (default `camel.apache.org/node-pool`).
+	NodePoolLabel string `property:"node-pool-label" json:"nodePoolLabel,omitempty"`
+}
+
+const defaultNodePoolLabel = "camel.apache.org/node-pool"
+
+func newNodeTrait() Trait {
+	return &nodeTrait{
+		BaseTrait:     NewBaseTrait("node", 1210),
+		NodePoolLabel: defaultNodePoolLabel,
+	}
+}
+
+func (t *nodeTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if t.NodePool != "" && t.NodePoolLabel == "" {
+		return false, fmt.Errorf("no node pool label was provided")
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *nodeTrait) Apply(e *Environment) error {
+	nodeSelector, err := qualifiedKeyValuePairArrayAsStringMap(t.NodeSelector)
+	if err != nil {
+		return err
+	}
+
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+	}
+
+	if t.RuntimeClassName != "" {
+		podSpec.RuntimeClassName = &t.RuntimeClassName
+	}
+	if t.PriorityClassName != "" {
+		podSpec.PriorityClassName = t.PriorityClassName
+	}
+
+	if t.NodePool != "" {
+		nodeSelector[t.NodePoolLabel] = t.NodePool
+		podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+			Key:      t.NodePoolLabel,
+			Operator: corev1.TolerationOpEqual,
+			Value:    t.NodePool,
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+	}
+
+	if len(nodeSelector) > 0 {
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = make(map[string]string)
+		}
+		for k, v := range nodeSelector {
+			podSpec.NodeSelector[k] = v
+		}
+	}
+
+	return nil
+}
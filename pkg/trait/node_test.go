@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConfigureNodeTraitDisabledByDefault(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	nodeTrait := newNodeTrait().(*nodeTrait)
+
+	configured, err := nodeTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureNodeTraitMissingNodePoolLabel(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	nodeTrait := createNominalNodeTrait()
+	nodeTrait.NodePool = "integrations"
+	nodeTrait.NodePoolLabel = ""
+
+	configured, err := nodeTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.NotNil(t, err)
+}
+
+func TestApplyNodeTraitMissingDeployment(t *testing.T) {
+	nodeTrait := createNominalNodeTrait()
+	nodeTrait.RuntimeClassName = "gvisor"
+
+	environment := createNominalMissingDeploymentTraitTest()
+	err := nodeTrait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestApplyNodeTraitMalformedNodeSelector(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	nodeTrait := createNominalNodeTrait()
+	nodeTrait.NodeSelector = []string{"disktype"}
+
+	err := nodeTrait.Apply(environment)
+
+	assert.NotNil(t, err)
+}
+
+func TestApplyNodeTrait(t *testing.T) {
+	nodeTrait := createNominalNodeTrait()
+	nodeTrait.RuntimeClassName = "gvisor"
+	nodeTrait.PriorityClassName = "high-priority"
+	nodeTrait.NodeSelector = []string{"disktype=ssd"}
+	nodeTrait.NodePool = "integrations"
+
+	environment, deployment := createNominalDeploymentTraitTest()
+	testApplyNodeTrait(t, nodeTrait, environment, &deployment.Spec.Template.Spec)
+
+	environment, knativeService := createNominalKnativeServiceTraitTest()
+	testApplyNodeTrait(t, nodeTrait, environment, &knativeService.Spec.Template.Spec.PodSpec)
+
+	environment, cronJob := createNominalCronJobTraitTest()
+	testApplyNodeTrait(t, nodeTrait, environment, &cronJob.Spec.JobTemplate.Spec.Template.Spec)
+}
+
+func testApplyNodeTrait(t *testing.T, trait *nodeTrait, environment *Environment, podSpec *corev1.PodSpec) {
+	t.Helper()
+
+	err := trait.Apply(environment)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "gvisor", *podSpec.RuntimeClassName)
+	assert.Equal(t, "high-priority", podSpec.PriorityClassName)
+	assert.Equal(t, map[string]string{
+		"disktype":                   "ssd",
+		"camel.apache.org/node-pool": "integrations",
+	}, podSpec.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{
+		{
+			Key:      "camel.apache.org/node-pool",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "integrations",
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	}, podSpec.Tolerations)
+}
+
+func createNominalNodeTrait() *nodeTrait {
+	nodeTrait := newNodeTrait().(*nodeTrait)
+	nodeTrait.Enabled = BoolP(true)
+
+	return nodeTrait
+}
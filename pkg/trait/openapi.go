@@ -19,11 +19,13 @@ package trait
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -32,6 +34,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -47,11 +50,27 @@ import (
 
 // The OpenAPI DSL trait is internally used to allow creating integrations from a OpenAPI specs.
 //
+// Swagger 2.0, OpenAPI 3.0 and OpenAPI 3.1 specifications are supported. The specifications can be provided
+// as integration resources, or be read from ConfigMaps, and an integration can use several of them.
+//
+// The trait can also serve the effective OpenAPI document at runtime, and enable the validation of the
+// incoming requests against the specification.
+//
 // +camel-k:trait=openapi
 type openAPITrait struct {
 	BaseTrait `property:",squash"`
+	// The list of ConfigMaps containing the OpenAPI specifications, one per data entry.
+	Configmaps []string `property:"configmaps" json:"configmaps,omitempty"`
+	// The HTTP path the effective OpenAPI document is served from at runtime, e.g. `/openapi.json`.
+	ServePath string `property:"serve-path" json:"servePath,omitempty"`
+	// Enables the validation of the incoming requests against the OpenAPI specification.
+	Validation *bool `property:"validation" json:"validation,omitempty"`
 }
 
+const openAPIJavaDependency = "mvn:org.apache.camel.quarkus:camel-quarkus-openapi-java"
+
+var openAPISupportedVersions = []string{"2.0", "3.0", "3.1"}
+
 func newOpenAPITrait() Trait {
 	return &openAPITrait{
 		BaseTrait: NewBaseTrait("openapi", 300),
@@ -77,17 +96,48 @@ func (t *openAPITrait) Configure(e *Environment) (bool, error) {
 		return false, fmt.Errorf("the runtime provider %s does not declare 'rest' capability", e.CamelCatalog.Runtime.Provider)
 	}
 
+	if t.ServePath != "" && !strings.HasPrefix(t.ServePath, "/") {
+		return false, fmt.Errorf("the OpenAPI serve path must be absolute: %s", t.ServePath)
+	}
+
+	if !t.hasSpecs(e) {
+		return false, nil
+	}
+
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		return true, nil
+	}
+
+	return e.IntegrationInRunningPhases() && (t.ServePath != "" || IsTrue(t.Validation)), nil
+}
+
+func (t *openAPITrait) hasSpecs(e *Environment) bool {
+	if len(t.Configmaps) > 0 {
+		return true
+	}
 	for _, resource := range e.Integration.Spec.Resources {
 		if resource.Type == v1.ResourceTypeOpenAPI {
-			return e.IntegrationInPhase(v1.IntegrationPhaseInitialization), nil
+			return true
 		}
 	}
-
-	return false, nil
+	return false
 }
 
 func (t *openAPITrait) Apply(e *Environment) error {
+	if e.IntegrationInRunningPhases() {
+		if t.ServePath != "" {
+			e.ApplicationProperties["camel.rest.api-context-path"] = t.ServePath
+		}
+		if IsTrue(t.Validation) {
+			e.ApplicationProperties["camel.rest.client-request-validation"] = "true"
+		}
+		return nil
+	}
+
 	util.StringSliceUniqueAdd(&e.Integration.Status.Capabilities, v1.CapabilityRest)
+	if t.ServePath != "" {
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, openAPIJavaDependency)
+	}
 
 	root := os.TempDir()
 	tmpDir, err := ioutil.TempDir(root, "openapi")
@@ -97,10 +147,21 @@ func (t *openAPITrait) Apply(e *Environment) error {
 
 	defer os.RemoveAll(tmpDir)
 
-	for i, resource := range e.Integration.Spec.Resources {
-		if resource.Type != v1.ResourceTypeOpenAPI {
-			continue
+	resources := make([]v1.ResourceSpec, 0, len(e.Integration.Spec.Resources))
+	for _, resource := range e.Integration.Spec.Resources {
+		if resource.Type == v1.ResourceTypeOpenAPI {
+			resources = append(resources, resource)
 		}
+	}
+	for _, name := range t.Configmaps {
+		cmResources, err := t.resourcesFromConfigMap(e, name)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, cmResources...)
+	}
+
+	for i, resource := range resources {
 		if resource.Name == "" {
 			return fmt.Errorf("no name defined for the openapi resource: %v", resource)
 		}
@@ -140,6 +201,63 @@ func (t *openAPITrait) Apply(e *Environment) error {
 	return nil
 }
 
+// resourcesFromConfigMap returns the OpenAPI specifications contained in the data entries of the given ConfigMap
+func (t *openAPITrait) resourcesFromConfigMap(e *Environment, name string) ([]v1.ResourceSpec, error) {
+	cm := corev1.ConfigMap{}
+	key := client.ObjectKey{
+		Namespace: e.Integration.Namespace,
+		Name:      name,
+	}
+	if err := t.Client.Get(e.Ctx, key, &cm); err != nil {
+		return nil, errors.Wrapf(err, "unable to find OpenAPI ConfigMap %s", name)
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	resources := make([]v1.ResourceSpec, 0, len(keys))
+	for _, k := range keys {
+		resources = append(resources, v1.ResourceSpec{
+			DataSpec: v1.DataSpec{
+				Name:    k,
+				Content: cm.Data[k],
+			},
+			Type: v1.ResourceTypeOpenAPI,
+		})
+	}
+	return resources, nil
+}
+
+// checkOpenAPIVersion verifies the specification declares a supported Swagger or OpenAPI version
+func checkOpenAPIVersion(name string, content []byte) error {
+	data, err := yaml.ToJSON(content)
+	if err != nil {
+		return errors.Wrapf(err, "cannot parse openapi resource %s", name)
+	}
+	spec := struct {
+		Swagger string `json:"swagger"`
+		OpenAPI string `json:"openapi"`
+	}{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return errors.Wrapf(err, "cannot parse openapi resource %s", name)
+	}
+
+	version := spec.OpenAPI
+	if version == "" {
+		version = spec.Swagger
+	}
+	for _, v := range openAPISupportedVersions {
+		if version == v || strings.HasPrefix(version, v+".") {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported OpenAPI version %q for openapi resource %s, supported versions are %s",
+		version, name, strings.Join(openAPISupportedVersions, ", "))
+}
+
 func (t *openAPITrait) generateOpenAPIConfigMap(e *Environment, resource v1.ResourceSpec, tmpDir, generatedContentName string) error {
 	cm := corev1.ConfigMap{}
 	key := client.ObjectKey{
@@ -189,6 +307,10 @@ func (t *openAPITrait) createNewOpenAPIConfigMap(e *Environment, resource v1.Res
 		}
 	}
 
+	if err := checkOpenAPIVersion(resource.Name, content); err != nil {
+		return err
+	}
+
 	in := path.Join(tmpDir, resource.Name)
 	out := path.Join(tmpDir, "openapi-dsl.xml")
 
@@ -215,6 +337,14 @@ func (t *openAPITrait) createNewOpenAPIConfigMap(e *Environment, resource v1.Res
 		mc.SettingsContent = []byte(settings)
 	}
 
+	if e.Platform.Status.Build.Maven.SettingsSecurity != nil {
+		settingsSecurity, err := kubernetes.GetSecretRefData(e.Ctx, e.Client, e.Platform.Namespace, e.Platform.Status.Build.Maven.SettingsSecurity)
+		if err != nil {
+			return err
+		}
+		mc.SettingsSecurityContent = settingsSecurity
+	}
+
 	if e.Platform.Status.Build.Maven.CASecret != nil {
 		certData, err := kubernetes.GetSecretRefData(e.Ctx, e.Client, e.Platform.Namespace, e.Platform.Status.Build.Maven.CASecret)
 		if err != nil {
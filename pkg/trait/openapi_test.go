@@ -18,9 +18,14 @@ limitations under the License.
 package trait
 
 import (
+	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 
@@ -62,3 +67,84 @@ func TestRestDslTraitApplicability(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, enabled)
 }
+
+func TestRestDslTraitApplicabilityInRunningPhases(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	e := &Environment{
+		CamelCatalog: catalog,
+		Integration: &v1.Integration{
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		ApplicationProperties: make(map[string]string),
+	}
+
+	trait := newOpenAPITrait().(*openAPITrait)
+	trait.Configmaps = []string{"my-openapi"}
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	trait.ServePath = "openapi.json"
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+
+	trait.ServePath = "/openapi.json"
+	trait.Validation = BoolP(true)
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	err = trait.Apply(e)
+	assert.Nil(t, err)
+	assert.Equal(t, "/openapi.json", e.ApplicationProperties["camel.rest.api-context-path"])
+	assert.Equal(t, "true", e.ApplicationProperties["camel.rest.client-request-validation"])
+}
+
+func TestRestDslTraitResourcesFromConfigMap(t *testing.T) {
+	client, err := test.NewFakeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-openapi",
+		},
+		Data: map[string]string{
+			"petstore.yaml":  "openapi: 3.1.0",
+			"greetings.json": `{"swagger": "2.0"}`,
+		},
+	})
+	assert.Nil(t, err)
+
+	e := &Environment{
+		Ctx: context.Background(),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+			},
+		},
+	}
+
+	trait := newOpenAPITrait().(*openAPITrait)
+	trait.Client = client
+
+	resources, err := trait.resourcesFromConfigMap(e, "my-openapi")
+	assert.Nil(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "greetings.json", resources[0].Name)
+	assert.Equal(t, v1.ResourceTypeOpenAPI, resources[0].Type)
+	assert.Equal(t, "petstore.yaml", resources[1].Name)
+	assert.Equal(t, "openapi: 3.1.0", resources[1].Content)
+
+	_, err = trait.resourcesFromConfigMap(e, "missing")
+	assert.NotNil(t, err)
+}
+
+func TestRestDslTraitOpenAPIVersion(t *testing.T) {
+	assert.Nil(t, checkOpenAPIVersion("spec.json", []byte(`{"swagger": "2.0"}`)))
+	assert.Nil(t, checkOpenAPIVersion("spec.yaml", []byte("openapi: 3.0.3")))
+	assert.Nil(t, checkOpenAPIVersion("spec.yaml", []byte("openapi: 3.1.0")))
+	assert.NotNil(t, checkOpenAPIVersion("spec.yaml", []byte("openapi: 4.0.0")))
+	assert.NotNil(t, checkOpenAPIVersion("spec.yaml", []byte("info: {}")))
+}
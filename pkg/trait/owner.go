@@ -18,7 +18,10 @@ limitations under the License.
 package trait
 
 import (
+	"strings"
+
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/batch/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	serving "knative.dev/serving/pkg/apis/serving/v1"
@@ -29,6 +32,18 @@ import (
 // The Owner trait ensures that all created resources belong to the integration being created
 // and transfers annotations and labels on the integration onto these owned resources.
 //
+// All the resources created by the traits, e.g., Knative Triggers, KEDA ScaledObjects, ServiceMonitors or NetworkPolicies,
+// are owned by the integration, and labelled with the `camel.apache.org/integration` label, so that they're garbage collected
+// along with the integration. Existing owner references, that are set on these resources, are preserved.
+//
+// The labels and annotations to be transferred are either declared with their keys, or with key prefixes ending with `*`,
+// e.g., `com.mycompany/*`. Additional labels and annotations, e.g. for cost attribution, can also be added to all the owned resources.
+// When the cost allocation is enabled on the platform, the cost-allocation labels of the integration are added as well.
+//
+// When the integration is managed by a GitOps controller, e.g., Argo CD or Flux, the GitOps mode annotates the owned resources,
+// so that they are neither reported as out of sync, nor pruned, by the GitOps controller, and labels them as managed by Camel K.
+// The Argo CD tracking label and annotation are never transferred in that mode.
+//
 // +camel-k:trait=owner
 type ownerTrait struct {
 	BaseTrait `property:",squash"`
@@ -36,8 +51,23 @@ type ownerTrait struct {
 	TargetAnnotations []string `property:"target-annotations" json:"targetAnnotations,omitempty"`
 	// The set of labels to be transferred
 	TargetLabels []string `property:"target-labels" json:"targetLabels,omitempty"`
+	// The labels added to all the owned resources, e.g., `cost-center=finance`
+	Labels []string `property:"labels" json:"labels,omitempty"`
+	// The annotations added to all the owned resources, e.g., `company.com/team=integration`
+	Annotations []string `property:"annotations" json:"annotations,omitempty"`
+	// Annotate the owned resources for GitOps controllers, e.g., Argo CD or Flux
+	GitOps *bool `property:"gitops" json:"gitops,omitempty"`
 }
 
+const (
+	argoCDCompareOptionsAnnotation = "argocd.argoproj.io/compare-options"
+	argoCDSyncOptionsAnnotation    = "argocd.argoproj.io/sync-options"
+	argoCDTrackingIDAnnotation     = "argocd.argoproj.io/tracking-id"
+	argoCDInstanceLabel            = "app.kubernetes.io/instance"
+	fluxPruneAnnotation            = "kustomize.toolkit.fluxcd.io/prune"
+	managedByLabel                 = "app.kubernetes.io/managed-by"
+)
+
 func newOwnerTrait() Trait {
 	return &ownerTrait{
 		BaseTrait: NewBaseTrait("owner", 2500),
@@ -53,29 +83,50 @@ func (t *ownerTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if _, err := qualifiedKeyValuePairArrayAsStringMap(t.Labels); err != nil {
+		return false, err
+	}
+	if _, err := qualifiedKeyValuePairArrayAsStringMap(t.Annotations); err != nil {
+		return false, err
+	}
+
 	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization) || e.IntegrationInRunningPhases(), nil
 }
 
 func (t *ownerTrait) Apply(e *Environment) error {
-	controller := true
-	blockOwnerDeletion := true
+	targetLabels, err := qualifiedKeyValuePairArrayAsStringMap(t.Labels)
+	if err != nil {
+		return err
+	}
+	for k, v := range selectByKeys(e.Integration.Labels, t.TargetLabels) {
+		targetLabels[k] = v
+	}
+
+	targetAnnotations, err := qualifiedKeyValuePairArrayAsStringMap(t.Annotations)
+	if err != nil {
+		return err
+	}
+	for k, v := range selectByKeys(e.Integration.Annotations, t.TargetAnnotations) {
+		targetAnnotations[k] = v
+	}
 
-	targetLabels := make(map[string]string)
-	if e.Integration.Labels != nil {
-		for _, k := range t.TargetLabels {
-			if v, ok := e.Integration.Labels[k]; ok {
+	if e.Platform != nil && e.Platform.Status.CostAllocation.Enabled {
+		for k, v := range e.Platform.Status.CostAllocation.LabelsFor(e.Integration) {
+			if _, ok := targetLabels[k]; !ok {
 				targetLabels[k] = v
 			}
 		}
 	}
 
-	targetAnnotations := make(map[string]string)
-	if e.Integration.Annotations != nil {
-		for _, k := range t.TargetAnnotations {
-			if v, ok := e.Integration.Annotations[k]; ok {
-				targetAnnotations[k] = v
-			}
-		}
+	if IsTrue(t.GitOps) {
+		// The owned resources must not be tracked as part of the GitOps application
+		delete(targetLabels, argoCDInstanceLabel)
+		delete(targetAnnotations, argoCDTrackingIDAnnotation)
+
+		targetLabels[managedByLabel] = "camel-k"
+		targetAnnotations[argoCDCompareOptionsAnnotation] = "IgnoreExtraneous"
+		targetAnnotations[argoCDSyncOptionsAnnotation] = "Prune=false"
+		targetAnnotations[fluxPruneAnnotation] = "disabled"
 	}
 
 	e.Resources.VisitMetaObject(func(res metav1.Object) {
@@ -83,17 +134,17 @@ func (t *ownerTrait) Apply(e *Environment) error {
 		// by the api server (sometimes no error is thrown but the resource is not created).
 		// Ref: https://github.com/kubernetes/kubernetes/issues/65200
 		if res.GetNamespace() == "" || res.GetNamespace() == e.Integration.Namespace {
-			references := []metav1.OwnerReference{
-				{
-					APIVersion:         e.Integration.APIVersion,
-					Kind:               e.Integration.Kind,
-					Name:               e.Integration.Name,
-					UID:                e.Integration.UID,
-					Controller:         &controller,
-					BlockOwnerDeletion: &blockOwnerDeletion,
-				},
+			res.SetOwnerReferences(t.ownerReferences(e, res.GetOwnerReferences()))
+
+			// Label the resource so that it's garbage collected along with the integration
+			labels := res.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			if _, ok := labels[v1.IntegrationLabel]; !ok {
+				labels[v1.IntegrationLabel] = e.Integration.Name
+				res.SetLabels(labels)
 			}
-			res.SetOwnerReferences(references)
 		}
 
 		// Transfer annotations
@@ -104,10 +155,19 @@ func (t *ownerTrait) Apply(e *Environment) error {
 		t.propagateLabelAndAnnotations(&deployment.Spec.Template, targetLabels, targetAnnotations)
 	})
 
+	e.Resources.VisitStatefulSet(func(statefulSet *appsv1.StatefulSet) {
+		t.propagateLabelAndAnnotations(&statefulSet.Spec.Template, targetLabels, targetAnnotations)
+	})
+
 	e.Resources.VisitKnativeService(func(service *serving.Service) {
 		t.propagateLabelAndAnnotations(&service.Spec.ConfigurationSpec.Template, targetLabels, targetAnnotations)
 	})
 
+	e.Resources.VisitCronJob(func(cron *v1beta1.CronJob) {
+		t.propagateLabelAndAnnotations(&cron.Spec.JobTemplate, targetLabels, targetAnnotations)
+		t.propagateLabelAndAnnotations(&cron.Spec.JobTemplate.Spec.Template, targetLabels, targetAnnotations)
+	})
+
 	return nil
 }
 
@@ -116,6 +176,33 @@ func (t *ownerTrait) IsPlatformTrait() bool {
 	return true
 }
 
+// ownerReferences returns the given references, with the integration reference set.
+// The integration is the controller of the resource, unless the resource already has another controller.
+func (t *ownerTrait) ownerReferences(e *Environment, existing []metav1.OwnerReference) []metav1.OwnerReference {
+	controller := true
+	blockOwnerDeletion := true
+
+	references := make([]metav1.OwnerReference, 0, len(existing)+1)
+	for _, ref := range existing {
+		if ref.Kind == e.Integration.Kind && ref.Name == e.Integration.Name {
+			continue
+		}
+		if ref.Controller != nil && *ref.Controller {
+			controller = false
+		}
+		references = append(references, ref)
+	}
+
+	return append(references, metav1.OwnerReference{
+		APIVersion:         e.Integration.APIVersion,
+		Kind:               e.Integration.Kind,
+		Name:               e.Integration.Name,
+		UID:                e.Integration.UID,
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	})
+}
+
 func (t *ownerTrait) propagateLabelAndAnnotations(res metav1.Object, targetLabels map[string]string, targetAnnotations map[string]string) {
 	// Transfer annotations
 	annotations := res.GetAnnotations()
@@ -143,3 +230,21 @@ func (t *ownerTrait) propagateLabelAndAnnotations(res metav1.Object, targetLabel
 	}
 	res.SetLabels(labels)
 }
+
+// selectByKeys returns the entries whose keys match either one of the given keys, or one of the given
+// prefixes ending with `*`
+func selectByKeys(values map[string]string, keys []string) map[string]string {
+	selected := make(map[string]string)
+	for _, key := range keys {
+		if prefix := strings.TrimSuffix(key, "*"); prefix != key {
+			for k, v := range values {
+				if strings.HasPrefix(k, prefix) {
+					selected[k] = v
+				}
+			}
+		} else if v, ok := values[key]; ok {
+			selected[key] = v
+		}
+	}
+	return selected
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -93,3 +94,101 @@ func ValidateLabelsAndAnnotations(t *testing.T, res metav1.Object) {
 	assert.Contains(t, res.GetAnnotations(), "com.mycompany/myannotation2")
 	assert.Equal(t, "myannotation2", res.GetAnnotations()["com.mycompany/myannotation2"])
 }
+
+func TestOwnerWithPrefixesAndAdditionalLabels(t *testing.T) {
+	env := SetUpOwnerEnvironment(t)
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"owner": test.TraitSpecFromMap(t, map[string]interface{}{
+			"targetLabels":      []string{"com.mycompany/*"},
+			"targetAnnotations": []string{"com.mycompany/myannotation2"},
+			"labels":            []string{"cost-center=finance"},
+			"annotations":       []string{"com.mycompany/team=integration"},
+		}),
+	}
+
+	processTestEnv(t, env)
+
+	env.Resources.VisitMetaObject(func(res metav1.Object) {
+		assert.Equal(t, "myvalue1", res.GetLabels()["com.mycompany/mylabel1"])
+		assert.Equal(t, "myvalue2", res.GetLabels()["com.mycompany/mylabel2"])
+		assert.NotContains(t, res.GetLabels(), "org.apache.camel/l1")
+		assert.Equal(t, "finance", res.GetLabels()["cost-center"])
+		assert.Equal(t, "integration", res.GetAnnotations()["com.mycompany/team"])
+		assert.Equal(t, "myannotation2", res.GetAnnotations()["com.mycompany/myannotation2"])
+		assert.Equal(t, env.Integration.Name, res.GetLabels()[v1.IntegrationLabel])
+	})
+}
+
+func TestOwnerWithCostAllocationLabels(t *testing.T) {
+	env := SetUpOwnerEnvironment(t)
+	env.Integration.Annotations["camel.apache.org/team"] = "payments"
+	env.Integration.Annotations["camel.apache.org/env"] = "not a label value"
+	env.Platform.Spec.CostAllocation.Enabled = true
+	env.Platform.ResyncStatusFullConfig()
+
+	processTestEnv(t, env)
+
+	assertCostAllocationLabels := func(labels map[string]string) {
+		assert.Equal(t, "payments", labels["team"])
+		assert.Equal(t, env.Integration.Name, labels["app"])
+		assert.NotContains(t, labels, "env")
+	}
+	env.Resources.VisitMetaObject(func(res metav1.Object) {
+		assertCostAllocationLabels(res.GetLabels())
+	})
+	env.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+		assertCostAllocationLabels(deployment.Spec.Template.Labels)
+	})
+}
+
+func TestOwnerInGitOpsMode(t *testing.T) {
+	env := SetUpOwnerEnvironment(t)
+	env.Integration.Labels["app.kubernetes.io/instance"] = "my-app"
+	env.Integration.Annotations["argocd.argoproj.io/tracking-id"] = "my-app:camel.apache.org/Integration:ns/name"
+	env.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"owner": test.TraitSpecFromMap(t, map[string]interface{}{
+			"targetLabels":      []string{"*"},
+			"targetAnnotations": []string{"*"},
+			"gitops":            true,
+		}),
+	}
+
+	processTestEnv(t, env)
+
+	env.Resources.VisitMetaObject(func(res metav1.Object) {
+		assert.Equal(t, "camel-k", res.GetLabels()["app.kubernetes.io/managed-by"])
+		assert.NotContains(t, res.GetLabels(), "app.kubernetes.io/instance")
+		assert.NotContains(t, res.GetAnnotations(), "argocd.argoproj.io/tracking-id")
+		assert.Equal(t, "IgnoreExtraneous", res.GetAnnotations()["argocd.argoproj.io/compare-options"])
+		assert.Equal(t, "Prune=false", res.GetAnnotations()["argocd.argoproj.io/sync-options"])
+		assert.Equal(t, "disabled", res.GetAnnotations()["kustomize.toolkit.fluxcd.io/prune"])
+		assert.Equal(t, "myvalue2", res.GetLabels()["com.mycompany/mylabel2"])
+	})
+}
+
+func TestOwnerPreservesExistingOwnerReferences(t *testing.T) {
+	env := createTestEnv(t, v1.IntegrationPlatformClusterOpenShift, "camel:core")
+	env.Integration.Kind = v1.IntegrationKind
+	controller := true
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-config",
+			Namespace: env.Integration.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "KameletBinding", Name: "my-binding", Controller: &controller},
+				{Kind: v1.IntegrationKind, Name: env.Integration.Name},
+			},
+		},
+	}
+	env.Resources.Add(cm)
+
+	ownerTrait := newOwnerTrait().(*ownerTrait)
+	err := ownerTrait.Apply(env)
+	assert.Nil(t, err)
+
+	assert.Len(t, cm.OwnerReferences, 2)
+	assert.Equal(t, "my-binding", cm.OwnerReferences[0].Name)
+	assert.Equal(t, env.Integration.Name, cm.OwnerReferences[1].Name)
+	assert.False(t, *cm.OwnerReferences[1].Controller)
+	assert.Equal(t, env.Integration.Name, cm.Labels[v1.IntegrationLabel])
+}
@@ -0,0 +1,219 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The Patch trait applies patches to the resources generated for the integration, as an escape hatch
+// to customize the resources beyond the options of the other traits. It's executed after all the other traits.
+//
+// Each patch targets the generated resources of a kind, optionally restricted to the resource with the given name,
+// and is declared with the `<kind>[/<name>]=<patch>` syntax, the kind being qualified with the API group,
+// e.g., `Service.serving.knative.dev`, when it's ambiguous. The patch is either:
+//
+// * a JSON 6902 patch, i.e., a JSON array of operations, e.g.,
+// `Deployment=[{"op": "add", "path": "/spec/template/spec/hostNetwork", "value": true}]`,
+// * a strategic merge patch, as a YAML or JSON object, e.g., `Service/my-integration={"spec": {"type": "NodePort"}}`,
+// applied as a JSON merge patch to the unstructured resources, i.e., the custom resources that have no Go type.
+//
+// The patches are applied in order, and the trait fails when a patch targets no generated resource.
+//
+// +camel-k:trait=patch
+type patchTrait struct {
+	BaseTrait `property:",squash"`
+	// The patches applied to the generated resources, in the form `<kind>[/<name>]=<patch>`.
+	Patches []string `property:"patches" json:"patches,omitempty"`
+}
+
+// resourcePatch is a patch parsed from the patch trait configuration
+type resourcePatch struct {
+	kind  string
+	group string
+	name  string
+	// operations is set for JSON 6902 patches
+	operations jsonpatch.Patch
+	// merge is set for strategic merge patches
+	merge []byte
+}
+
+func newPatchTrait() Trait {
+	return &patchTrait{
+		BaseTrait: NewBaseTrait("patch", 2600, ExecuteAfter("owner")),
+	}
+}
+
+func (t *patchTrait) Configure(e *Environment) (bool, error) {
+	if IsFalse(t.Enabled) || len(t.Patches) == 0 {
+		return false, nil
+	}
+
+	if _, err := t.parsePatches(); err != nil {
+		return false, err
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *patchTrait) Apply(e *Environment) error {
+	patches, err := t.parsePatches()
+	if err != nil {
+		return err
+	}
+
+	for i, patch := range patches {
+		patched := false
+		if err := e.Resources.VisitE(func(obj runtime.Object) error {
+			if !patch.matches(obj) {
+				return nil
+			}
+			patched = true
+			return patch.apply(obj)
+		}); err != nil {
+			return errors.Wrapf(err, "unable to apply patch #%d", i+1)
+		}
+		if !patched {
+			return fmt.Errorf("patch #%d targets no generated resource: %s", i+1, patch.target())
+		}
+	}
+
+	return nil
+}
+
+func (t *patchTrait) parsePatches() ([]resourcePatch, error) {
+	patches := make([]resourcePatch, 0, len(t.Patches))
+	for i, p := range t.Patches {
+		target, content, ok := splitOnce(p, "=")
+		if !ok || target == "" || strings.TrimSpace(content) == "" {
+			return nil, fmt.Errorf("invalid patch #%d: expected <kind>[/<name>]=<patch>, got %s", i+1, p)
+		}
+
+		patch := resourcePatch{}
+		kind, name, _ := splitOnce(target, "/")
+		patch.kind, patch.group, _ = splitOnce(kind, ".")
+		patch.name = name
+
+		data, err := yaml.ToJSON([]byte(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid patch #%d", i+1)
+		}
+		if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+			if patch.operations, err = jsonpatch.DecodePatch(data); err != nil {
+				return nil, errors.Wrapf(err, "invalid JSON patch #%d", i+1)
+			}
+		} else {
+			var fragment map[string]interface{}
+			if err := json.Unmarshal(data, &fragment); err != nil {
+				return nil, errors.Wrapf(err, "invalid merge patch #%d", i+1)
+			}
+			patch.merge = data
+		}
+		patches = append(patches, patch)
+	}
+	return patches, nil
+}
+
+func (p resourcePatch) matches(obj runtime.Object) bool {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind != p.kind || p.group != "" && gvk.Group != p.group {
+		return false
+	}
+	if p.name == "" {
+		return true
+	}
+	o, ok := obj.(ctrl.Object)
+	return ok && o.GetName() == p.name
+}
+
+func (p resourcePatch) apply(obj runtime.Object) error {
+	if p.operations == nil {
+		return patchObject(obj, p.merge)
+	}
+	return applyJSONPatch(obj, p.operations)
+}
+
+func (p resourcePatch) target() string {
+	target := p.kind
+	if p.group != "" {
+		target += "." + p.group
+	}
+	if p.name != "" {
+		target += "/" + p.name
+	}
+	return target
+}
+
+// patchObject applies the given patch to the object in place, as a strategic merge patch for the typed objects,
+// or as a JSON merge patch for the unstructured ones
+func patchObject(obj runtime.Object, patch []byte) error {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	if _, ok := obj.(runtime.Unstructured); ok {
+		patched, err = jsonpatch.MergePatch(original, patch)
+	} else {
+		patched, err = strategicpatch.StrategicMergePatch(original, patch, obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	return replaceObject(obj, patched)
+}
+
+// applyJSONPatch applies the given JSON 6902 patch to the object in place
+func applyJSONPatch(obj runtime.Object, patch jsonpatch.Patch) error {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return err
+	}
+
+	return replaceObject(obj, patched)
+}
+
+// replaceObject replaces the content of the object with the given JSON content, that is decoded into a new value,
+// so that the removed fields are reset
+func replaceObject(obj runtime.Object, content []byte) error {
+	value := reflect.New(reflect.TypeOf(obj).Elem())
+	if err := json.Unmarshal(content, value.Interface()); err != nil {
+		return err
+	}
+	reflect.ValueOf(obj).Elem().Set(value.Elem())
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func createPatchTraitTestEnvironment() (*Environment, *appsv1.Deployment, *corev1.Service) {
+	environment, deployment := createNominalDeploymentTraitTest()
+	deployment.TypeMeta = metav1.TypeMeta{Kind: "Deployment", APIVersion: appsv1.SchemeGroupVersion.String()}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: defaultContainerName, Image: "image"},
+		{Name: "sidecar", Image: "sidecar"},
+	}
+	service := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: corev1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "integration-name"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+	}
+	environment.Resources.Add(service)
+
+	return environment, deployment, service
+}
+
+func TestPatchTraitDisabledWithoutPatches(t *testing.T) {
+	environment, _, _ := createPatchTraitTestEnvironment()
+
+	configured, err := newPatchTrait().Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+}
+
+func TestPatchTraitStrategicMergePatch(t *testing.T) {
+	environment, deployment, service := createPatchTraitTestEnvironment()
+	trait, _ := newPatchTrait().(*patchTrait)
+	trait.Patches = []string{
+		`Deployment={"spec": {"template": {"spec": {"containers": [{"name": "integration", "env": [{"name": "FOO", "value": "bar"}]}]}}}}`,
+		"Service/integration-name=spec:\n  type: NodePort",
+	}
+
+	configured, err := trait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+	assert.Nil(t, trait.Apply(environment))
+
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 2)
+	assert.Equal(t, "image", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "FOO", Value: "bar"}}, deployment.Spec.Template.Spec.Containers[0].Env)
+	assert.Equal(t, corev1.ServiceTypeNodePort, service.Spec.Type)
+}
+
+func TestPatchTraitJSONPatch(t *testing.T) {
+	environment, deployment, _ := createPatchTraitTestEnvironment()
+	trait, _ := newPatchTrait().(*patchTrait)
+	trait.Patches = []string{
+		`Deployment.apps/integration-name=[{"op": "add", "path": "/spec/template/spec/hostNetwork", "value": true},` +
+			`{"op": "remove", "path": "/spec/template/spec/containers/1"}]`,
+	}
+
+	assert.Nil(t, trait.Apply(environment))
+	assert.True(t, deployment.Spec.Template.Spec.HostNetwork)
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+}
+
+func TestPatchTraitUnstructuredResource(t *testing.T) {
+	environment, _, _ := createPatchTraitTestEnvironment()
+	monitor := &unstructured.Unstructured{}
+	monitor.SetAPIVersion("monitoring.coreos.com/v1")
+	monitor.SetKind("PodMonitor")
+	monitor.SetName("integration-name")
+	monitor.Object["spec"] = map[string]interface{}{"podMetricsEndpoints": []interface{}{map[string]interface{}{"port": "http"}}}
+	environment.Resources.Add(monitor)
+
+	trait, _ := newPatchTrait().(*patchTrait)
+	trait.Patches = []string{`PodMonitor.monitoring.coreos.com={"spec": {"jobLabel": "app"}}`}
+
+	assert.Nil(t, trait.Apply(environment))
+	assert.Equal(t, "app", monitor.Object["spec"].(map[string]interface{})["jobLabel"])
+	assert.NotNil(t, monitor.Object["spec"].(map[string]interface{})["podMetricsEndpoints"])
+}
+
+func TestPatchTraitInvalidPatches(t *testing.T) {
+	environment, _, _ := createPatchTraitTestEnvironment()
+	trait, _ := newPatchTrait().(*patchTrait)
+
+	trait.Patches = []string{"Deployment"}
+	_, err := trait.Configure(environment)
+	assert.EqualError(t, err, "invalid patch #1: expected <kind>[/<name>]=<patch>, got Deployment")
+
+	trait.Patches = []string{`Deployment=[{"op": "add"`}
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid patch #1")
+
+	trait.Patches = []string{`Deployment="spec"`}
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid merge patch #1")
+
+	trait.Patches = []string{`Deployment/other={"spec": {"replicas": 2}}`}
+	assert.EqualError(t, trait.Apply(environment), "patch #1 targets no generated resource: Deployment/other")
+
+	trait.Patches = []string{`Deployment=[{"op": "remove", "path": "/spec/missing"}]`}
+	err = trait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to apply patch #1")
+}
@@ -0,0 +1,219 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// The Persistent State trait provides the integration with a persistent volume, where stateful components,
+// such as file-based idempotent repositories, aggregation repositories or file consumers, can store their state
+// across restarts.
+//
+// By default, a PersistentVolumeClaim is provisioned for the integration, and shared by all its replicas.
+// When the `stateful-set` parameter is enabled, the integration is deployed as a StatefulSet instead, and
+// each replica gets its own volume, provisioned from the claim template. Note the volumes provisioned for a
+// StatefulSet are not deleted along with the integration.
+//
+// Note that Knative requires the `kubernetes.podspec-persistent-volume-claim` feature to be enabled,
+// for the volume to be mounted into Knative Services.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=persistent-state
+type persistentStateTrait struct {
+	BaseTrait `property:",squash"`
+	// Deploys the integration as a StatefulSet, so that each replica gets its own persistent volume.
+	StatefulSet *bool `property:"stateful-set" json:"statefulSet,omitempty"`
+	// The name of an existing PersistentVolumeClaim to use, instead of provisioning a new one.
+	// It cannot be used in combination with `stateful-set`.
+	ClaimName string `property:"claim-name" json:"claimName,omitempty"`
+	// The StorageClass used to provision the persistent volume (the cluster default one is used otherwise).
+	StorageClass string `property:"storage-class" json:"storageClass,omitempty"`
+	// The size of the persistent volume (default `1Gi`).
+	Size string `property:"size" json:"size,omitempty"`
+	// The access modes of the persistent volume (default `ReadWriteOnce`).
+	AccessModes []string `property:"access-modes" json:"accessModes,omitempty"`
+	// The path where the persistent volume is mounted into the integration container (default `/var/lib/camel/state`).
+	MountPath string `property:"mount-path" json:"mountPath,omitempty"`
+}
+
+const (
+	persistentStateVolumeName       = "persistent-state"
+	defaultPersistentStateSize      = "1Gi"
+	defaultPersistentStateMountPath = "/var/lib/camel/state"
+)
+
+var _ ControllerStrategySelector = &persistentStateTrait{}
+
+func newPersistentStateTrait() Trait {
+	return &persistentStateTrait{
+		BaseTrait: NewBaseTrait("persistent-state", 1650, ExecuteAfter(containerTraitID)),
+		Size:      defaultPersistentStateSize,
+		MountPath: defaultPersistentStateMountPath,
+	}
+}
+
+func (t *persistentStateTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	if IsTrue(t.StatefulSet) && t.ClaimName != "" {
+		return false, fmt.Errorf("an existing claim cannot be used in combination with a stateful set")
+	}
+	if t.MountPath == "" {
+		return false, fmt.Errorf("no persistent state mount path was provided")
+	}
+	if _, err := resource.ParseQuantity(t.Size); err != nil {
+		return false, fmt.Errorf("invalid persistent state size %q: %v", t.Size, err)
+	}
+	for _, mode := range t.AccessModes {
+		if !isValidAccessMode(corev1.PersistentVolumeAccessMode(mode)) {
+			return false, fmt.Errorf("unsupported persistent state access mode: %s", mode)
+		}
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *persistentStateTrait) Apply(e *Environment) error {
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return fmt.Errorf("unable to find integration container: %s", e.Integration.Name)
+	}
+
+	statefulSet := e.Resources.GetStatefulSet(func(s *appsv1.StatefulSet) bool {
+		return s.Name == e.Integration.Name
+	})
+
+	switch {
+	case statefulSet != nil:
+		// Each replica gets its own claim, named after the template and the pod
+		statefulSet.Spec.VolumeClaimTemplates = append(statefulSet.Spec.VolumeClaimTemplates, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: persistentStateVolumeName,
+				Labels: map[string]string{
+					v1.IntegrationLabel: e.Integration.Name,
+				},
+			},
+			Spec: t.claimSpec(),
+		})
+	default:
+		podSpec := e.GetIntegrationPodSpec()
+		if podSpec == nil {
+			return fmt.Errorf("could not find any integration deployment for %v", e.Integration.Name)
+		}
+
+		claimName := t.ClaimName
+		if claimName == "" {
+			claim := t.getClaimFor(e)
+			e.Resources.Add(claim)
+			claimName = claim.Name
+		}
+
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: persistentStateVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		})
+	}
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      persistentStateVolumeName,
+		MountPath: t.MountPath,
+	})
+
+	return nil
+}
+
+// SelectControllerStrategy selects the StatefulSet controller strategy when requested
+func (t *persistentStateTrait) SelectControllerStrategy(e *Environment) (*ControllerStrategy, error) {
+	if IsNilOrFalse(t.Enabled) || IsNilOrFalse(t.StatefulSet) {
+		return nil, nil
+	}
+	statefulSetStrategy := ControllerStrategyStatefulSet
+	return &statefulSetStrategy, nil
+}
+
+func (t *persistentStateTrait) ExplainControllerStrategy(e *Environment, strategy ControllerStrategy) (string, string) {
+	return v1.IntegrationConditionControllerStrategyTraitReason, "the persistent-state trait requires a StatefulSet"
+}
+
+func (t *persistentStateTrait) ControllerStrategySelectorOrder() int {
+	return 500
+}
+
+func (t *persistentStateTrait) getClaimFor(e *Environment) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name + "-state",
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Spec: t.claimSpec(),
+	}
+}
+
+func (t *persistentStateTrait) claimSpec() corev1.PersistentVolumeClaimSpec {
+	accessModes := make([]corev1.PersistentVolumeAccessMode, 0, len(t.AccessModes))
+	for _, mode := range t.AccessModes {
+		accessModes = append(accessModes, corev1.PersistentVolumeAccessMode(mode))
+	}
+	if len(accessModes) == 0 {
+		accessModes = append(accessModes, corev1.ReadWriteOnce)
+	}
+
+	spec := corev1.PersistentVolumeClaimSpec{
+		AccessModes: accessModes,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(t.Size),
+			},
+		},
+	}
+	if t.StorageClass != "" {
+		spec.StorageClassName = &t.StorageClass
+	}
+
+	return spec
+}
+
+func isValidAccessMode(mode corev1.PersistentVolumeAccessMode) bool {
+	switch mode {
+	case corev1.ReadWriteOnce, corev1.ReadOnlyMany, corev1.ReadWriteMany:
+		return true
+	}
+	return false
+}
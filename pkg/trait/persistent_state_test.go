@@ -0,0 +1,223 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestPersistentStateDisabledByDefault(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+	trait := newPersistentStateTrait().(*persistentStateTrait)
+
+	configured, err := trait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestPersistentStateInvalidConfiguration(t *testing.T) {
+	environment, _ := createNominalDeploymentTraitTest()
+
+	trait := newPersistentStateTrait().(*persistentStateTrait)
+	trait.Enabled = BoolP(true)
+	trait.StatefulSet = BoolP(true)
+	trait.ClaimName = "my-claim"
+	_, err := trait.Configure(environment)
+	assert.NotNil(t, err)
+
+	trait = newPersistentStateTrait().(*persistentStateTrait)
+	trait.Enabled = BoolP(true)
+	trait.Size = "one gigabyte"
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+
+	trait = newPersistentStateTrait().(*persistentStateTrait)
+	trait.Enabled = BoolP(true)
+	trait.AccessModes = []string{"ReadWriteSometimes"}
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestPersistentStateWithDeployment(t *testing.T) {
+	environment := createPersistentStateTestEnvironment(t, map[string]interface{}{
+		"enabled":      true,
+		"storageClass": "fast",
+		"size":         "5Gi",
+	})
+
+	err := NewCatalog(nil).apply(environment)
+	assert.Nil(t, err)
+
+	assert.Nil(t, environment.Resources.GetStatefulSet(func(*appsv1.StatefulSet) bool { return true }))
+	deployment := environment.Resources.GetDeployment(func(*appsv1.Deployment) bool { return true })
+	assert.NotNil(t, deployment)
+
+	var claim *corev1.PersistentVolumeClaim
+	environment.Resources.Visit(func(o runtime.Object) {
+		if c, ok := o.(*corev1.PersistentVolumeClaim); ok {
+			claim = c
+		}
+	})
+	assert.NotNil(t, claim)
+	assert.Equal(t, "test-state", claim.Name)
+	assert.Equal(t, "fast", *claim.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claim.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("5Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: persistentStateVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "test-state",
+			},
+		},
+	})
+	assert.Contains(t, environment.getIntegrationContainer().VolumeMounts, corev1.VolumeMount{
+		Name:      persistentStateVolumeName,
+		MountPath: defaultPersistentStateMountPath,
+	})
+}
+
+func TestPersistentStateWithExistingClaim(t *testing.T) {
+	environment := createPersistentStateTestEnvironment(t, map[string]interface{}{
+		"enabled":   true,
+		"claimName": "my-claim",
+		"mountPath": "/data",
+	})
+
+	err := NewCatalog(nil).apply(environment)
+	assert.Nil(t, err)
+
+	environment.Resources.Visit(func(o runtime.Object) {
+		_, ok := o.(*corev1.PersistentVolumeClaim)
+		assert.False(t, ok)
+	})
+
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Equal(t, "my-claim", podSpec.Volumes[len(podSpec.Volumes)-1].PersistentVolumeClaim.ClaimName)
+	assert.Contains(t, environment.getIntegrationContainer().VolumeMounts, corev1.VolumeMount{
+		Name:      persistentStateVolumeName,
+		MountPath: "/data",
+	})
+}
+
+func TestPersistentStateWithStatefulSet(t *testing.T) {
+	environment := createPersistentStateTestEnvironment(t, map[string]interface{}{
+		"enabled":     true,
+		"statefulSet": true,
+		"accessModes": []string{"ReadWriteMany"},
+	})
+
+	err := NewCatalog(nil).apply(environment)
+	assert.Nil(t, err)
+
+	assert.Nil(t, environment.Resources.GetDeployment(func(*appsv1.Deployment) bool { return true }))
+	statefulSet := environment.Resources.GetStatefulSet(func(*appsv1.StatefulSet) bool { return true })
+	assert.NotNil(t, statefulSet)
+	assert.Equal(t, "test", statefulSet.Spec.ServiceName)
+	assert.Len(t, statefulSet.Spec.Template.Spec.Containers, 1)
+
+	assert.Len(t, statefulSet.Spec.VolumeClaimTemplates, 1)
+	template := statefulSet.Spec.VolumeClaimTemplates[0]
+	assert.Equal(t, persistentStateVolumeName, template.Name)
+	assert.Nil(t, template.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, template.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse(defaultPersistentStateSize), template.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	assert.Contains(t, statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      persistentStateVolumeName,
+		MountPath: defaultPersistentStateMountPath,
+	})
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionStatefulSetAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	condition = environment.Integration.Status.GetCondition(v1.IntegrationConditionDeploymentAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+}
+
+func createPersistentStateTestEnvironment(t *testing.T, configuration map[string]interface{}) *Environment {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	environment := &Environment{
+		CamelCatalog: catalog,
+		Catalog:      NewCatalog(nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.js",
+							Content: `from("file:data?idempotent=true").to("log:info")`,
+						},
+						Language: v1.LanguageJavaScript,
+					},
+				},
+				Traits: map[string]v1.TraitSpec{
+					"persistent-state": test.TraitSpecFromMap(t, configuration),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterOpenShift,
+				Build: v1.IntegrationPlatformBuildSpec{
+					PublishStrategy: v1.IntegrationPlatformBuildPublishStrategyS2I,
+					Registry:        v1.IntegrationPlatformRegistrySpec{Address: "registry"},
+				},
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      kubernetes.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	return environment
+}
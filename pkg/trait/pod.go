@@ -18,17 +18,24 @@ limitations under the License.
 package trait
 
 import (
+	stdjson "encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
 
 	serving "knative.dev/serving/pkg/apis/serving/v1"
 
-	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // The pod trait allows the customization of the Integration pods.
@@ -38,11 +45,30 @@ import (
 // This can be used to customize the container where Camel routes execute,
 // by using the `integration` container name.
 //
+// Additional template layers can be declared with the `templates` parameter, e.g., at the platform level, to provide
+// a base for all the integrations. The layers are applied in order, before the Integration `.spec.podTemplate` field,
+// that is applied last, as a user overlay. Each layer is either a YAML or JSON pod spec fragment, or a reference
+// to a ConfigMap key containing it, with the `configmap:<name>[/<key>]` syntax (the default key is `template.yaml`).
+//
+// The layers are merged using strategic merge patch semantics, i.e.:
+//
+// * the `containers`, `initContainers`, `volumes`, and the containers `env`, `ports` and `volumeMounts` lists are merged
+// by name (by `containerPort` for ports), while the other lists are replaced,
+// * the maps, e.g., `nodeSelector`, are merged by key,
+// * the `$patch: replace` and `$patch: delete` directives replace or delete the element they are declared in,
+// e.g., `{"name": "sidecar", "$patch": "delete"}` removes the `sidecar` container.
+//
 // +camel-k:trait=pod
 type podTrait struct {
 	BaseTrait `property:",squash"`
+	// The pod spec template layers, applied in order before the Integration `.spec.podTemplate` field.
+	Templates []string `property:"templates" json:"templates,omitempty"`
 }
 
+const defaultPodTemplateKey = "template.yaml"
+
+var podTemplateIndexRegexp = regexp.MustCompile(`\.(\d+)`)
+
 func newPodTrait() Trait {
 	return &podTrait{
 		BaseTrait: NewBaseTrait("pod", 1800),
@@ -54,15 +80,28 @@ func (t *podTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
-	if e.Integration != nil && e.Integration.Spec.PodTemplate == nil {
+	if e.Integration != nil && e.Integration.Spec.PodTemplate == nil && len(t.Templates) == 0 {
 		return false, nil
 	}
 
+	for i, template := range t.Templates {
+		if strings.HasPrefix(template, "configmap:") {
+			continue
+		}
+		if _, err := parsePodTemplate(i+1, []byte(template)); err != nil {
+			return false, err
+		}
+	}
+
 	return e.IntegrationInRunningPhases(), nil
 }
 
 func (t *podTrait) Apply(e *Environment) error {
-	changes := e.Integration.Spec.PodTemplate.Spec
+	patches, err := t.getPatches(e)
+	if err != nil {
+		return err
+	}
+
 	var patchedPodSpec *corev1.PodSpec
 	strategy, err := e.DetermineControllerStrategy()
 	if err != nil {
@@ -72,7 +111,7 @@ func (t *podTrait) Apply(e *Environment) error {
 	case ControllerStrategyCronJob:
 		e.Resources.VisitCronJob(func(c *v1beta1.CronJob) {
 			if c.Name == e.Integration.Name {
-				if patchedPodSpec, err = t.applyChangesTo(&c.Spec.JobTemplate.Spec.Template.Spec, changes); err == nil {
+				if patchedPodSpec, err = t.applyChangesTo(&c.Spec.JobTemplate.Spec.Template.Spec, patches); err == nil {
 					c.Spec.JobTemplate.Spec.Template.Spec = *patchedPodSpec
 				}
 			}
@@ -81,16 +120,25 @@ func (t *podTrait) Apply(e *Environment) error {
 	case ControllerStrategyDeployment:
 		e.Resources.VisitDeployment(func(d *appsv1.Deployment) {
 			if d.Name == e.Integration.Name {
-				if patchedPodSpec, err = t.applyChangesTo(&d.Spec.Template.Spec, changes); err == nil {
+				if patchedPodSpec, err = t.applyChangesTo(&d.Spec.Template.Spec, patches); err == nil {
 					d.Spec.Template.Spec = *patchedPodSpec
 				}
 			}
 		})
 
+	case ControllerStrategyStatefulSet:
+		e.Resources.VisitStatefulSet(func(s *appsv1.StatefulSet) {
+			if s.Name == e.Integration.Name {
+				if patchedPodSpec, err = t.applyChangesTo(&s.Spec.Template.Spec, patches); err == nil {
+					s.Spec.Template.Spec = *patchedPodSpec
+				}
+			}
+		})
+
 	case ControllerStrategyKnativeService:
 		e.Resources.VisitKnativeService(func(s *serving.Service) {
 			if s.Name == e.Integration.Name {
-				if patchedPodSpec, err = t.applyChangesTo(&s.Spec.Template.Spec.PodSpec, changes); err == nil {
+				if patchedPodSpec, err = t.applyChangesTo(&s.Spec.Template.Spec.PodSpec, patches); err == nil {
 					s.Spec.Template.Spec.PodSpec = *patchedPodSpec
 				}
 			}
@@ -103,22 +151,165 @@ func (t *podTrait) Apply(e *Environment) error {
 	return nil
 }
 
-func (t *podTrait) applyChangesTo(podSpec *corev1.PodSpec, changes v1.PodSpec) (patchedPodSpec *corev1.PodSpec, err error) {
-	patch, err := json.Marshal(changes)
-	if err != nil {
-		return
+// getPatches returns the template layers, followed by the Integration pod template, as strategic merge patches
+func (t *podTrait) getPatches(e *Environment) ([][]byte, error) {
+	patches := make([][]byte, 0, len(t.Templates)+1)
+	for i, template := range t.Templates {
+		content := []byte(template)
+		if ref := strings.TrimPrefix(template, "configmap:"); ref != template {
+			var err error
+			if content, err = t.getConfigMapTemplate(e, ref); err != nil {
+				return nil, err
+			}
+		}
+		patch, err := parsePodTemplate(i+1, content)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, patch)
 	}
 
-	sourceJson, err := json.Marshal(podSpec)
-	if err != nil {
-		return
+	if e.Integration.Spec.PodTemplate != nil {
+		patch, err := json.Marshal(e.Integration.Spec.PodTemplate.Spec)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, patch)
+	}
+
+	return patches, nil
+}
+
+// getConfigMapTemplate returns the template contained in the ConfigMap key referenced with the `<name>[/<key>]` syntax
+func (t *podTrait) getConfigMapTemplate(e *Environment, ref string) ([]byte, error) {
+	name, key, _ := splitOnce(ref, "/")
+	if key == "" {
+		key = defaultPodTemplateKey
+	}
+	cm := corev1.ConfigMap{}
+	if err := t.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: name}, &cm); err != nil {
+		return nil, errors.Wrapf(err, "unable to get pod template ConfigMap %s", name)
+	}
+	content, ok := cm.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("pod template ConfigMap %s has no key %s", name, key)
 	}
+	return []byte(content), nil
+}
 
-	patched, err := strategicpatch.StrategicMergePatch(sourceJson, patch, corev1.PodSpec{})
+func (t *podTrait) applyChangesTo(podSpec *corev1.PodSpec, patches [][]byte) (patchedPodSpec *corev1.PodSpec, err error) {
+	patched, err := json.Marshal(podSpec)
 	if err != nil {
 		return
 	}
 
+	for _, patch := range patches {
+		patched, err = strategicpatch.StrategicMergePatch(patched, patch, corev1.PodSpec{})
+		if err != nil {
+			return
+		}
+	}
+
 	err = json.Unmarshal(patched, &patchedPodSpec)
 	return
 }
+
+// parsePodTemplate converts the given YAML or JSON template into a JSON patch, validating its content
+// is a pod spec fragment, and returning an error pinpointing the offending path otherwise
+func parsePodTemplate(index int, template []byte) ([]byte, error) {
+	patch, err := yaml.ToJSON(template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod template #%d: %v", index, err)
+	}
+
+	var fragment map[string]interface{}
+	if err := json.Unmarshal(patch, &fragment); err != nil {
+		return nil, fmt.Errorf("invalid pod template #%d: %v", index, err)
+	}
+	var podSpec corev1.PodSpec
+	if err := json.Unmarshal(patch, &podSpec); err != nil {
+		var typeErr *stdjson.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			field := podTemplateIndexRegexp.ReplaceAllString(typeErr.Field, "[$1]")
+			return nil, fmt.Errorf("invalid pod template #%d at spec.%s: expected %s, got %s", index, field, typeErr.Type, typeErr.Value)
+		}
+		return nil, fmt.Errorf("invalid pod template #%d: %v", index, err)
+	}
+
+	// Unknown fields are dropped when the template is decoded,
+	// so they are found by comparing the template with its decoded content
+	decoded, err := json.Marshal(podSpec)
+	if err != nil {
+		return nil, err
+	}
+	var known map[string]interface{}
+	if err := json.Unmarshal(decoded, &known); err != nil {
+		return nil, err
+	}
+	if unknown := findUnknownField(fragment, known, "spec"); unknown != "" {
+		return nil, fmt.Errorf("invalid pod template #%d at %s: unknown field", index, unknown)
+	}
+
+	return patch, nil
+}
+
+// findUnknownField returns the path of the first field of the given value, that's missing in the known value
+func findUnknownField(value interface{}, known interface{}, path string) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		k, _ := known.(map[string]interface{})
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			// Strategic merge patch directives
+			if strings.HasPrefix(key, "$") {
+				continue
+			}
+			field, ok := k[key]
+			if !ok {
+				if isEmptyValue(v[key]) {
+					// Empty values are omitted when encoded
+					continue
+				}
+				return path + "." + key
+			}
+			if unknown := findUnknownField(v[key], field, path+"."+key); unknown != "" {
+				return unknown
+			}
+		}
+	case []interface{}:
+		k, _ := known.([]interface{})
+		for i, item := range v {
+			if i >= len(k) {
+				break
+			}
+			if unknown := findUnknownField(item, k[i], fmt.Sprintf("%s[%d]", path, i)); unknown != "" {
+				return unknown
+			}
+		}
+	}
+	return ""
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case int64:
+		return v == 0
+	}
+	return false
+}
@@ -30,6 +30,7 @@ import (
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
 )
 
 func TestConfigurePodTraitDoesSucceed(t *testing.T) {
@@ -87,6 +88,85 @@ func TestChangeEnvVariables(t *testing.T) {
 	assert.Equal(t, containsEnvVariables(templateSpec, "integration", "CAMEL_K_DIGEST"), "new_value")
 }
 
+func TestTemplateLayers(t *testing.T) {
+	trait, environment, deployment := createPodTest(`containers:
+  - name: integration
+    env:
+      - name: LOG_LEVEL
+        value: debug`)
+	trait.Templates = []string{
+		`{"containers": [{"name": "integration", "env": [{"name": "LOG_LEVEL", "value": "info"}, {"name": "TZ", "value": "UTC"}]}], "nodeSelector": {"disktype": "ssd"}}`,
+		`containers:
+  - name: second
+    $patch: delete
+  - name: sidecar
+    image: sidecar`,
+	}
+
+	configured, err := trait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	template := deployment.Spec.Template
+	assert.Len(t, template.Spec.Containers, 2)
+	assert.Nil(t, getContainer(template.Spec.Containers, "second"))
+	assert.Equal(t, "sidecar", getContainer(template.Spec.Containers, "sidecar").Image)
+	// The Integration pod template is applied last
+	assert.Equal(t, "debug", containsEnvVariables(template, "integration", "LOG_LEVEL"))
+	assert.Equal(t, "UTC", containsEnvVariables(template, "integration", "TZ"))
+	assert.Equal(t, "vO3wwJHC7-uGEiFFVac0jq6rZT5EZNw56Ae5gKKFZZsk", containsEnvVariables(template, "integration", "CAMEL_K_DIGEST"))
+	assert.Equal(t, map[string]string{"disktype": "ssd"}, template.Spec.NodeSelector)
+}
+
+func TestTemplateLayerFromConfigMap(t *testing.T) {
+	trait, environment, deployment := createPodTest("")
+	environment.Integration.Spec.PodTemplate = nil
+	environment.Integration.Namespace = "ns"
+	c, err := test.NewFakeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-base",
+			Namespace: "ns",
+		},
+		Data: map[string]string{
+			"template.yaml": "serviceAccountName: integrations",
+		},
+	})
+	assert.Nil(t, err)
+	trait.Client = c
+	trait.Templates = []string{"configmap:pod-base"}
+
+	configured, err := trait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, "integrations", deployment.Spec.Template.Spec.ServiceAccountName)
+
+	trait.Templates = []string{"configmap:pod-base/base.yaml"}
+	err = trait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "pod template ConfigMap pod-base has no key base.yaml", err.Error())
+}
+
+func TestInvalidTemplateLayers(t *testing.T) {
+	trait, environment, _ := createPodTest("")
+
+	trait.Templates = []string{"nodeSelector: {}", `containers: [{"name": "integration", "imagePullPolicyy": "Always"}]`}
+	_, err := trait.Configure(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid pod template #2 at spec.containers[0].imagePullPolicyy: unknown field", err.Error())
+
+	trait.Templates = []string{`containers: [{"name": "integration", "ports": [{"containerPort": "http"}]}]`}
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid pod template #1 at spec.containers")
+	assert.Contains(t, err.Error(), "containerPort: expected int32, got string")
+}
+
 func createPodTest(podSpecTemplate string) (*podTrait, *Environment, *appsv1.Deployment) {
 	trait := newPodTrait().(*podTrait)
 	trait.Enabled = BoolP(true)
@@ -0,0 +1,259 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/uri"
+)
+
+// The Preflight trait adds init containers to the integration pods, that check the services the integration
+// depends on are reachable, before the integration container starts.
+//
+// The checks are either provided explicitly, as `host:port` TCP addresses or `http(s)` URLs, or derived from the
+// integration configuration, i.e. the Kafka brokers and the JDBC datasource URL, and the `brokers` parameter of the endpoints.
+//
+// The trait also verifies the required Secrets exist, before the integration is deployed.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=preflight
+type preflightTrait struct {
+	BaseTrait `property:",squash"`
+	// The list of checks, either TCP addresses in the form `host:port`, or HTTP URLs, e.g. `http://my-service:8080/health`.
+	Checks []string `property:"checks" json:"checks,omitempty"`
+	// Automatically derive the checks from the integration configuration (default `true`).
+	Auto *bool `property:"auto" json:"auto,omitempty"`
+	// The names of the Secrets that must exist for the integration to be deployed.
+	Secrets []string `property:"secrets" json:"secrets,omitempty"`
+	// The image of the init containers, that must provide the `nc` and `wget` commands (default `busybox`).
+	Image string `property:"image" json:"image,omitempty"`
+	// The time in seconds each check waits for its target to become reachable (default `60`).
+	Timeout int `property:"timeout" json:"timeout,omitempty"`
+}
+
+const (
+	defaultPreflightImage   = "docker.io/library/busybox:1.34"
+	defaultPreflightTimeout = 60
+)
+
+var preflightJdbcURLRegexp = regexp.MustCompile(`^jdbc:[a-z0-9:]+:(?:@)?//([^/;?]+)`)
+
+func newPreflightTrait() Trait {
+	return &preflightTrait{
+		BaseTrait: NewBaseTrait("preflight", 1610),
+	}
+}
+
+func (t *preflightTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) {
+		return false, nil
+	}
+
+	for _, check := range t.Checks {
+		if _, err := parsePreflightCheck(check); err != nil {
+			return false, err
+		}
+	}
+	if t.Timeout < 0 {
+		return false, fmt.Errorf("invalid preflight timeout: %d", t.Timeout)
+	}
+
+	return e.IntegrationInRunningPhases(), nil
+}
+
+func (t *preflightTrait) Apply(e *Environment) error {
+	if err := t.checkSecrets(e); err != nil {
+		return err
+	}
+
+	checks := make([]*preflightCheck, 0, len(t.Checks))
+	for _, c := range t.Checks {
+		check, err := parsePreflightCheck(c)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, check)
+	}
+	if IsNilOrTrue(t.Auto) {
+		derived, err := t.deriveChecks(e)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, derived...)
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return fmt.Errorf("unable to find the pod spec of integration %s", e.Integration.Name)
+	}
+
+	image := t.Image
+	if image == "" {
+		image = defaultPreflightImage
+	}
+	timeout := t.Timeout
+	if timeout == 0 {
+		timeout = defaultPreflightTimeout
+	}
+
+	seen := make(map[string]bool)
+	for _, check := range checks {
+		if seen[check.target] {
+			continue
+		}
+		seen[check.target] = true
+
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+			Name:    fmt.Sprintf("preflight-%d", len(seen)),
+			Image:   image,
+			Command: check.command(timeout),
+		})
+	}
+
+	return nil
+}
+
+// checkSecrets verifies the required Secrets exist
+func (t *preflightTrait) checkSecrets(e *Environment) error {
+	missing := make([]string, 0)
+	for _, name := range t.Secrets {
+		secret := corev1.Secret{}
+		err := t.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: name}, &secret)
+		if err != nil && k8serrors.IsNotFound(err) {
+			missing = append(missing, name)
+		} else if err != nil {
+			return errors.Wrapf(err, "unable to check secret %s", name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required secrets: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// deriveChecks returns the checks for the services referenced by the integration properties and endpoints
+func (t *preflightTrait) deriveChecks(e *Environment) ([]*preflightCheck, error) {
+	properties := make(map[string]string)
+	for _, prop := range e.collectConfigurationPairs("property") {
+		properties[prop.Name] = prop.Value
+	}
+	for k, v := range e.ApplicationProperties {
+		properties[k] = v
+	}
+
+	addresses := make([]string, 0)
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := properties[k]
+		switch {
+		case strings.HasPrefix(v, "${"):
+			// Resolved at runtime
+			continue
+		case strings.HasSuffix(k, ".brokers") || strings.HasSuffix(k, ".bootstrap.servers"):
+			addresses = append(addresses, strings.Split(v, ",")...)
+		case strings.HasSuffix(k, ".jdbc.url"):
+			if match := preflightJdbcURLRegexp.FindStringSubmatch(v); match != nil {
+				addresses = append(addresses, strings.Split(match[1], ",")...)
+			}
+		}
+	}
+
+	sources, err := kubernetes.ResolveIntegrationSources(e.Ctx, t.Client, e.Integration, e.Resources)
+	if err != nil {
+		return nil, err
+	}
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+	for _, u := range append(meta.FromURIs, meta.ToURIs...) {
+		if brokers := uri.GetQueryParameter(u, "brokers"); brokers != "" && !strings.Contains(brokers, "{{") {
+			addresses = append(addresses, strings.Split(brokers, ",")...)
+		}
+	}
+
+	checks := make([]*preflightCheck, 0, len(addresses))
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			// Skip the addresses without explicit port
+			continue
+		}
+		checks = append(checks, &preflightCheck{target: address})
+	}
+	return checks, nil
+}
+
+// preflightCheck is either a TCP address check or an HTTP URL check
+type preflightCheck struct {
+	target string
+	http   bool
+}
+
+func parsePreflightCheck(check string) (*preflightCheck, error) {
+	if strings.HasPrefix(check, "http://") || strings.HasPrefix(check, "https://") {
+		u, err := url.Parse(check)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid preflight check URL: %s", check)
+		}
+		return &preflightCheck{target: check, http: true}, nil
+	}
+	host, port, err := net.SplitHostPort(check)
+	if err != nil || host == "" || port == "" {
+		return nil, fmt.Errorf("invalid preflight check: %s, must be either host:port or an HTTP URL", check)
+	}
+	return &preflightCheck{target: check}, nil
+}
+
+// preflightScript waits for the target, given as first positional parameter, to be reachable, up to the timeout
+// in seconds, given as second positional parameter, with the test command formatted with the other parameters
+const preflightScript = `i=0; until %s; do i=$((i+2)); if [ $i -ge "$2" ]; then echo "$1 is not reachable"; exit 1; fi; ` +
+	`echo "waiting for $1"; sleep 2; done`
+
+// command returns the command that waits for the target to be reachable, up to the given timeout in seconds.
+// The target is passed as positional parameters of the script, so that it's never interpreted by the shell.
+func (c *preflightCheck) command(timeout int) []string {
+	if c.http {
+		return []string{"/bin/sh", "-c", fmt.Sprintf(preflightScript, `wget -q -T 2 -O /dev/null "$3"`),
+			"preflight", c.target, strconv.Itoa(timeout), c.target}
+	}
+	host, port, _ := net.SplitHostPort(c.target)
+	return []string{"/bin/sh", "-c", fmt.Sprintf(preflightScript, `nc -z -w 2 "$3" "$4"`),
+		"preflight", c.target, strconv.Itoa(timeout), host, port}
+}
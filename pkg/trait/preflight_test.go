@@ -0,0 +1,195 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigurePreflightTraitDisabledByDefault(t *testing.T) {
+	environment := createPreflightTestEnvironment(t)
+	preflightTrait := newPreflightTrait().(*preflightTrait)
+
+	configured, err := preflightTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigurePreflightTraitInvalidChecks(t *testing.T) {
+	environment := createPreflightTestEnvironment(t)
+
+	preflightTrait := createNominalPreflightTrait(environment)
+	preflightTrait.Checks = []string{"my-service"}
+	_, err := preflightTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	preflightTrait = createNominalPreflightTrait(environment)
+	preflightTrait.Checks = []string{"http:///health"}
+	_, err = preflightTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	preflightTrait = createNominalPreflightTrait(environment)
+	preflightTrait.Timeout = -1
+	_, err = preflightTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestApplyPreflightTraitWithExplicitChecks(t *testing.T) {
+	environment := createPreflightTestEnvironment(t)
+	preflightTrait := createNominalPreflightTrait(environment)
+	preflightTrait.Checks = []string{"my-database:5432", "http://my-service:8080/health", "my-database:5432"}
+	preflightTrait.Auto = BoolP(false)
+	preflightTrait.Timeout = 30
+
+	configured, err := preflightTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = preflightTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	initContainers := environment.GetIntegrationPodSpec().InitContainers
+	assert.Len(t, initContainers, 2)
+	assert.Equal(t, "preflight-1", initContainers[0].Name)
+	assert.Equal(t, defaultPreflightImage, initContainers[0].Image)
+	assert.Equal(t, []string{"/bin/sh", "-c"}, initContainers[0].Command[:2])
+	assert.Contains(t, initContainers[0].Command[2], `nc -z -w 2 "$3" "$4"`)
+	assert.Equal(t, []string{"preflight", "my-database:5432", "30", "my-database", "5432"}, initContainers[0].Command[3:])
+	assert.Equal(t, "preflight-2", initContainers[1].Name)
+	assert.Contains(t, initContainers[1].Command[2], `wget -q -T 2 -O /dev/null "$3"`)
+	assert.Equal(t, []string{"preflight", "http://my-service:8080/health", "30", "http://my-service:8080/health"}, initContainers[1].Command[3:])
+}
+
+func TestApplyPreflightTraitWithShellMetacharacters(t *testing.T) {
+	environment := createPreflightTestEnvironment(t)
+	preflightTrait := createNominalPreflightTrait(environment)
+	preflightTrait.Checks = []string{"http://my-service:8080/health?a='$(id)'"}
+	preflightTrait.Auto = BoolP(false)
+
+	err := preflightTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	initContainers := environment.GetIntegrationPodSpec().InitContainers
+	assert.Len(t, initContainers, 1)
+	assert.NotContains(t, initContainers[0].Command[2], "$(id)")
+	assert.Equal(t, "http://my-service:8080/health?a='$(id)'", initContainers[0].Command[6])
+}
+
+func TestApplyPreflightTraitWithDerivedChecks(t *testing.T) {
+	environment := createPreflightTestEnvironment(t)
+	environment.Integration.Spec.Configuration = []v1.ConfigurationSpec{
+		{Type: "property", Value: "camel.component.kafka.brokers=broker-0:9092,broker-1:9092"},
+		{Type: "property", Value: "kafka.bootstrap.servers=${KAFKA_BOOTSTRAP_SERVERS}"},
+	}
+	environment.ApplicationProperties["quarkus.datasource.jdbc.url"] = "jdbc:postgresql://my-database:5432/orders"
+	environment.Integration.Spec.Sources = []v1.SourceSpec{
+		{
+			DataSpec: v1.DataSpec{
+				Name:    "routes.java",
+				Content: `from("kafka:topic?brokers=my-cluster:9092").to("log:info")`,
+			},
+			Language: v1.LanguageJavaSource,
+		},
+	}
+	preflightTrait := createNominalPreflightTrait(environment)
+	preflightTrait.Image = "my-image"
+
+	err := preflightTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	initContainers := environment.GetIntegrationPodSpec().InitContainers
+	assert.Len(t, initContainers, 4)
+	assert.Equal(t, "my-image", initContainers[0].Image)
+	assert.Equal(t, []string{"broker-0", "9092"}, initContainers[0].Command[6:])
+	assert.Equal(t, []string{"broker-1", "9092"}, initContainers[1].Command[6:])
+	assert.Equal(t, []string{"my-database", "5432"}, initContainers[2].Command[6:])
+	assert.Equal(t, []string{"my-cluster", "9092"}, initContainers[3].Command[6:])
+}
+
+func TestApplyPreflightTraitWithSecrets(t *testing.T) {
+	environment := createPreflightTestEnvironment(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-credentials",
+			Namespace: "ns",
+		},
+	})
+	preflightTrait := createNominalPreflightTrait(environment)
+	preflightTrait.Secrets = []string{"my-credentials"}
+
+	err := preflightTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Empty(t, environment.GetIntegrationPodSpec().InitContainers)
+
+	preflightTrait.Secrets = []string{"my-credentials", "my-certificates", "my-tokens"}
+	err = preflightTrait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "missing required secrets: my-certificates, my-tokens", err.Error())
+}
+
+func createNominalPreflightTrait(environment *Environment) *preflightTrait {
+	preflightTrait := newPreflightTrait().(*preflightTrait)
+	preflightTrait.Enabled = BoolP(true)
+	preflightTrait.Client = environment.Client
+	return preflightTrait
+}
+
+func createPreflightTestEnvironment(t *testing.T, objects ...runtime.Object) *Environment {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	c, err := test.NewFakeClient(objects...)
+	assert.Nil(t, err)
+
+	return &Environment{
+		Ctx:          context.TODO(),
+		Client:       c,
+		CamelCatalog: catalog,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-integration",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		ApplicationProperties: make(map[string]string),
+		Resources: kubernetes.NewCollection(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-integration",
+				Namespace: "ns",
+			},
+		}),
+	}
+}
@@ -19,6 +19,8 @@ package trait
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,11 +32,13 @@ import (
 )
 
 // The Prometheus trait configures a Prometheus-compatible endpoint. It also creates a `PodMonitor` resource,
-// so that the endpoint can be scraped automatically, when using the Prometheus operator.
+// or a `ServiceMonitor` resource targeting the integration Service, so that the endpoint can be scraped automatically,
+// when using the Prometheus operator.
 //
-// The metrics are exposed using MicroProfile Metrics.
+// The metrics are exposed using MicroProfile Metrics, that does not support exemplars. The scraped metrics are enriched
+// with the `namespace` and `integration` labels by default, so that they can be told apart in multi-tenant Prometheus setups.
 //
-// WARNING: The creation of the `PodMonitor` resource requires the https://github.com/coreos/prometheus-operator[Prometheus Operator]
+// WARNING: The creation of the `PodMonitor` or `ServiceMonitor` resource requires the https://github.com/coreos/prometheus-operator[Prometheus Operator]
 // custom resource definition to be installed.
 // You can set `pod-monitor` to `false` for the Prometheus trait to work without the Prometheus Operator.
 //
@@ -46,12 +50,33 @@ type prometheusTrait struct {
 	// Whether a `PodMonitor` resource is created (default `true`).
 	PodMonitor *bool `property:"pod-monitor" json:"podMonitor,omitempty"`
 	// The `PodMonitor` resource labels, applicable when `pod-monitor` is `true`.
+	// They also apply to the `ServiceMonitor` resource, when `service-monitor` is `true`.
 	PodMonitorLabels []string `property:"pod-monitor-labels" json:"podMonitorLabels,omitempty"`
+	// Whether a `ServiceMonitor` resource, targeting the integration Service, is created instead of the `PodMonitor` resource.
+	ServiceMonitor *bool `property:"service-monitor" json:"serviceMonitor,omitempty"`
+	// The interval at which the metrics are scraped, e.g. `30s`.
+	Interval string `property:"interval" json:"interval,omitempty"`
+	// The timeout of the scrape requests, e.g. `10s`.
+	ScrapeTimeout string `property:"scrape-timeout" json:"scrapeTimeout,omitempty"`
+	// The HTTP path of the metrics endpoint (default `/q/metrics`).
+	Path string `property:"path" json:"path,omitempty"`
+	// The name of the port the metrics are scraped from. Defaults to the integration container port,
+	// or to the Service port when `service-monitor` is `true`.
+	Port string `property:"port" json:"port,omitempty"`
+	// Relabelings applied to the scraped targets, in the form `target-label=source-label`.
+	Relabelings []string `property:"relabelings" json:"relabelings,omitempty"`
+	// Whether the scraped metrics are enriched with the `namespace` and `integration` labels (default `true`).
+	Enrich *bool `property:"enrich" json:"enrich,omitempty"`
 }
 
+const defaultPrometheusPath = "/q/metrics"
+
+// prometheusDurationRegexp matches the Prometheus duration format, e.g. 1m30s
+var prometheusDurationRegexp = regexp.MustCompile(`^(\d+y)?(\d+w)?(\d+d)?(\d+h)?(\d+m)?(\d+s)?(\d+ms)?$`)
+
 func newPrometheusTrait() Trait {
 	return &prometheusTrait{
-		BaseTrait:  NewBaseTrait("prometheus", 1900),
+		BaseTrait:  NewBaseTrait("prometheus", 1900, ExecuteAfter(containerTraitID)),
 		PodMonitor: BoolP(true),
 	}
 }
@@ -61,6 +86,15 @@ func (t *prometheusTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	for name, duration := range map[string]string{"interval": t.Interval, "scrape timeout": t.ScrapeTimeout} {
+		if duration != "" && !prometheusDurationRegexp.MatchString(duration) {
+			return false, fmt.Errorf("invalid Prometheus %s: %s", name, duration)
+		}
+	}
+	if _, err := keyValuePairArrayAsStringMap(t.Relabelings); err != nil {
+		return false, err
+	}
+
 	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization) || e.IntegrationInRunningPhases(), nil
 }
 
@@ -101,8 +135,26 @@ func (t *prometheusTrait) Apply(e *Environment) (err error) {
 
 	condition.Message = fmt.Sprintf("%s(%d)", container.Name, containerPort.ContainerPort)
 
-	// Add the PodMonitor resource
-	if IsTrue(t.PodMonitor) {
+	switch {
+	case IsTrue(t.ServiceMonitor):
+		// Add the ServiceMonitor resource
+		service := e.Resources.GetUserServiceForIntegration(e.Integration)
+		if service == nil {
+			return fmt.Errorf("a ServiceMonitor requires the integration %s to be exposed by a Service", e.Integration.Name)
+		}
+		portName := t.Port
+		if portName == "" && len(service.Spec.Ports) > 0 {
+			portName = service.Spec.Ports[0].Name
+		}
+
+		serviceMonitor, err := t.getServiceMonitorFor(e, portName)
+		if err != nil {
+			return err
+		}
+		e.Resources.Add(serviceMonitor)
+		condition.Message = fmt.Sprintf("ServiceMonitor (%s) -> ", serviceMonitor.Name) + condition.Message
+	case IsTrue(t.PodMonitor):
+		// Add the PodMonitor resource
 		portName := containerPort.Name
 		// Knative defaults to naming the userland container port "user-port".
 		// Let's rely on that default, granted it is not officially part of the Knative
@@ -111,6 +163,9 @@ func (t *prometheusTrait) Apply(e *Environment) (err error) {
 		if portName == "" && controller == ControllerStrategyKnativeService {
 			portName = "user-port"
 		}
+		if t.Port != "" {
+			portName = t.Port
+		}
 
 		podMonitor, err := t.getPodMonitorFor(e, portName)
 		if err != nil {
@@ -118,7 +173,7 @@ func (t *prometheusTrait) Apply(e *Environment) (err error) {
 		}
 		e.Resources.Add(podMonitor)
 		condition.Message = fmt.Sprintf("PodMonitor (%s) -> ", podMonitor.Name) + condition.Message
-	} else {
+	default:
 		condition.Message = "ContainerPort " + condition.Message
 	}
 
@@ -155,6 +210,11 @@ func (t *prometheusTrait) getPodMonitorFor(e *Environment, portName string) (*mo
 	}
 	labels[v1.IntegrationLabel] = e.Integration.Name
 
+	relabelings, err := t.getRelabelConfigs()
+	if err != nil {
+		return nil, err
+	}
+
 	podMonitor := monitoringv1.PodMonitor{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "PodMonitor",
@@ -173,8 +233,11 @@ func (t *prometheusTrait) getPodMonitorFor(e *Environment, portName string) (*mo
 			},
 			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
 				{
-					Port: portName,
-					Path: "/q/metrics",
+					Port:           portName,
+					Path:           t.getPath(),
+					Interval:       t.Interval,
+					ScrapeTimeout:  t.ScrapeTimeout,
+					RelabelConfigs: relabelings,
 				},
 			},
 		},
@@ -182,3 +245,91 @@ func (t *prometheusTrait) getPodMonitorFor(e *Environment, portName string) (*mo
 
 	return &podMonitor, nil
 }
+
+func (t *prometheusTrait) getServiceMonitorFor(e *Environment, portName string) (*monitoringv1.ServiceMonitor, error) {
+	labels, err := keyValuePairArrayAsStringMap(t.PodMonitorLabels)
+	if err != nil {
+		return nil, err
+	}
+	labels[v1.IntegrationLabel] = e.Integration.Name
+
+	relabelings, err := t.getRelabelConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceMonitor := monitoringv1.ServiceMonitor{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceMonitor",
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name,
+			Namespace: e.Integration.Namespace,
+			Labels:    labels,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					v1.IntegrationLabel: e.Integration.Name,
+				},
+			},
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port:           portName,
+					Path:           t.getPath(),
+					Interval:       t.Interval,
+					ScrapeTimeout:  t.ScrapeTimeout,
+					RelabelConfigs: relabelings,
+				},
+			},
+		},
+	}
+
+	return &serviceMonitor, nil
+}
+
+func (t *prometheusTrait) getPath() string {
+	if t.Path != "" {
+		return t.Path
+	}
+	return defaultPrometheusPath
+}
+
+func (t *prometheusTrait) getRelabelConfigs() ([]*monitoringv1.RelabelConfig, error) {
+	relabelings := make([]*monitoringv1.RelabelConfig, 0)
+
+	if IsNilOrTrue(t.Enrich) {
+		relabelings = append(relabelings,
+			&monitoringv1.RelabelConfig{
+				SourceLabels: []string{"__meta_kubernetes_namespace"},
+				TargetLabel:  "namespace",
+			},
+			&monitoringv1.RelabelConfig{
+				SourceLabels: []string{"__meta_kubernetes_pod_label_camel_apache_org_integration"},
+				TargetLabel:  "integration",
+			},
+		)
+	}
+
+	custom, err := keyValuePairArrayAsStringMap(t.Relabelings)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]string, 0, len(custom))
+	for target := range custom {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	for _, target := range targets {
+		relabelings = append(relabelings, &monitoringv1.RelabelConfig{
+			SourceLabels: []string{custom[target]},
+			TargetLabel:  target,
+		})
+	}
+
+	if len(relabelings) == 0 {
+		return nil, nil
+	}
+	return relabelings, nil
+}
@@ -110,6 +110,104 @@ func TestPrometheusTraitGetPodMonitor(t *testing.T) {
 	assert.Equal(t, "integration-name", podMonitor.Spec.Selector.MatchLabels["camel.apache.org/integration"])
 	assert.Len(t, podMonitor.Spec.PodMetricsEndpoints, 1)
 	assert.Equal(t, defaultContainerPortName, podMonitor.Spec.PodMetricsEndpoints[0].Port)
+	assert.Equal(t, "/q/metrics", podMonitor.Spec.PodMetricsEndpoints[0].Path)
+	assert.Len(t, podMonitor.Spec.PodMetricsEndpoints[0].RelabelConfigs, 2)
+	assert.Equal(t, "namespace", podMonitor.Spec.PodMetricsEndpoints[0].RelabelConfigs[0].TargetLabel)
+	assert.Equal(t, "integration", podMonitor.Spec.PodMetricsEndpoints[0].RelabelConfigs[1].TargetLabel)
+}
+
+func TestConfigurePrometheusTraitInvalidConfiguration(t *testing.T) {
+	trait, environment := createNominalPrometheusTest()
+	trait.Interval = "30 seconds"
+	_, err := trait.Configure(environment)
+	assert.NotNil(t, err)
+
+	trait, environment = createNominalPrometheusTest()
+	trait.ScrapeTimeout = "10"
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+
+	trait, environment = createNominalPrometheusTest()
+	trait.Relabelings = []string{"pod"}
+	_, err = trait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestPrometheusTraitGetPodMonitorWithCustomization(t *testing.T) {
+	trait, environment := createNominalPrometheusTest()
+	trait.Interval = "1m30s"
+	trait.ScrapeTimeout = "10s"
+	trait.Path = "/metrics"
+	trait.Relabelings = []string{"pod=__meta_kubernetes_pod_name", "node=__meta_kubernetes_pod_node_name"}
+	trait.Enrich = BoolP(false)
+
+	podMonitor, err := trait.getPodMonitorFor(environment, defaultContainerPortName)
+
+	assert.Nil(t, err)
+	endpoint := podMonitor.Spec.PodMetricsEndpoints[0]
+	assert.Equal(t, "/metrics", endpoint.Path)
+	assert.Equal(t, "1m30s", endpoint.Interval)
+	assert.Equal(t, "10s", endpoint.ScrapeTimeout)
+	assert.Equal(t, []*monitoringv1.RelabelConfig{
+		{
+			SourceLabels: []string{"__meta_kubernetes_pod_node_name"},
+			TargetLabel:  "node",
+		},
+		{
+			SourceLabels: []string{"__meta_kubernetes_pod_name"},
+			TargetLabel:  "pod",
+		},
+	}, endpoint.RelabelConfigs)
+}
+
+func TestApplyPrometheusTraitWithServiceMonitor(t *testing.T) {
+	trait, environment := createNominalPrometheusTest()
+	trait.ServiceMonitor = BoolP(true)
+	trait.PodMonitorLabels = []string{"team=integration"}
+
+	err := trait.Apply(environment)
+	assert.NotNil(t, err)
+
+	trait, environment = createNominalPrometheusTest()
+	trait.ServiceMonitor = BoolP(true)
+	trait.PodMonitorLabels = []string{"team=integration"}
+	environment.Resources.Add(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+			Labels: map[string]string{
+				v1.IntegrationLabel:             "integration-name",
+				"camel.apache.org/service.type": v1.ServiceTypeUser,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name: "http",
+					Port: 80,
+				},
+			},
+		},
+	})
+
+	err = trait.Apply(environment)
+	assert.Nil(t, err)
+
+	assert.Nil(t, environment.Resources.GetPodMonitor(func(*monitoringv1.PodMonitor) bool { return true }))
+	serviceMonitor := environment.Resources.GetServiceMonitor(func(sm *monitoringv1.ServiceMonitor) bool {
+		return sm.Name == "integration-name"
+	})
+	assert.NotNil(t, serviceMonitor)
+	assert.Equal(t, "ServiceMonitor", serviceMonitor.Kind)
+	assert.Equal(t, "integration", serviceMonitor.Labels["team"])
+	assert.Equal(t, "integration-name", serviceMonitor.Spec.Selector.MatchLabels[v1.IntegrationLabel])
+	assert.Len(t, serviceMonitor.Spec.Endpoints, 1)
+	assert.Equal(t, "http", serviceMonitor.Spec.Endpoints[0].Port)
+	assert.Equal(t, "/q/metrics", serviceMonitor.Spec.Endpoints[0].Path)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionPrometheusAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "ServiceMonitor")
 }
 
 func createNominalPrometheusTest() (*prometheusTrait, *Environment) {
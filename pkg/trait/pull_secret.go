@@ -44,6 +44,10 @@ import (
 //
 // If your registry does not need authentication for pulling images, you can disable this trait.
 //
+// When using a global operator with a shared platform, the platform registry secret can be replicated into the namespace
+// of the integration, and kept in sync with it, by enabling the `replicate` property. The replica is deleted once all
+// the integrations using it are deleted.
+//
 // +camel-k:trait=pull-secret
 type pullSecretTrait struct {
 	BaseTrait `property:",squash"`
@@ -53,11 +57,16 @@ type pullSecretTrait struct {
 	ImagePullerDelegation *bool `property:"image-puller-delegation" json:"imagePullerDelegation,omitempty"`
 	// Automatically configures the platform registry secret on the pod if it is of type `kubernetes.io/dockerconfigjson`.
 	Auto *bool `property:"auto" json:"auto,omitempty"`
+	// When using a global operator with a shared platform, this enables the replication of the platform registry secret into the integration namespace (disabled by default).
+	Replicate *bool `property:"replicate" json:"replicate,omitempty"`
+
+	// The platform registry secret that's replicated into the integration namespace
+	source *corev1.Secret
 }
 
 func newPullSecretTrait() Trait {
 	return &pullSecretTrait{
-		BaseTrait: NewBaseTrait("pull-secret", 1700),
+		BaseTrait: NewBaseTrait("pull-secret", 1700, ExecuteAfter(serviceAccountTraitID)),
 	}
 }
 
@@ -81,6 +90,9 @@ func (t *pullSecretTrait) Configure(e *Environment) (bool, error) {
 				}
 				if obj.Type == corev1.SecretTypeDockerConfigJson {
 					t.SecretName = secret
+					if e.Platform.Namespace != e.Integration.Namespace && IsTrue(t.Replicate) {
+						t.source = &obj
+					}
 				}
 			}
 		}
@@ -104,6 +116,11 @@ func (t *pullSecretTrait) Configure(e *Environment) (bool, error) {
 }
 
 func (t *pullSecretTrait) Apply(e *Environment) error {
+	if t.source != nil {
+		if err := platform.ReplicatePullSecret(e.Ctx, e.Client, t.source, e.Integration.Namespace, e.Integration); err != nil {
+			return errors.Wrap(err, "error during the replication of the platform registry secret")
+		}
+	}
 	if t.SecretName != "" {
 		e.Resources.VisitPodSpec(func(p *corev1.PodSpec) {
 			p.ImagePullSecrets = append(p.ImagePullSecrets, corev1.LocalObjectReference{
@@ -147,7 +164,7 @@ func (t *pullSecretTrait) newImagePullerRoleBinding(e *Environment) *rbacv1.Role
 			},
 		}
 	}
-	serviceAccount := e.Integration.Spec.ServiceAccountName
+	serviceAccount := e.GetIntegrationServiceAccountName()
 	if serviceAccount == "" {
 		serviceAccount = "default"
 	}
@@ -27,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -91,6 +92,82 @@ func TestPullSecretImagePullerDelegation(t *testing.T) {
 	assert.Len(t, roleBinding.Subjects, 1)
 }
 
+func TestPullSecretReplication(t *testing.T) {
+	e, deployment := getEnvironmentAndDeployment(t)
+	e.Platform = &v1.IntegrationPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator",
+			Name:      "camel-k",
+		},
+	}
+	e.Platform.Status.Build.Registry.Secret = "registry"
+	source := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator",
+			Name:      "registry",
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte("{}"),
+		},
+	}
+	assert.NoError(t, e.Client.Create(e.Ctx, &source))
+
+	trait := newPullSecretTrait().(*pullSecretTrait)
+	trait.Client = e.Client
+	trait.ImagePullerDelegation = BoolP(false)
+	trait.Replicate = BoolP(true)
+	enabled, err := trait.Configure(e)
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+
+	err = trait.Apply(e)
+	assert.NoError(t, err)
+	assert.Contains(t, deployment.Spec.Template.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: "registry"})
+
+	var replica corev1.Secret
+	err = e.Client.Get(e.Ctx, client.ObjectKey{Namespace: "test", Name: "registry"}, &replica)
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, replica.Type)
+	assert.Equal(t, "{}", string(replica.Data[corev1.DockerConfigJsonKey]))
+	assert.Equal(t, "operator", replica.Labels["camel.apache.org/pull-secret.replica-of"])
+	assert.Len(t, replica.OwnerReferences, 1)
+	assert.Equal(t, "myit", replica.OwnerReferences[0].Name)
+}
+
+func TestPullSecretReplicationDisabledByDefault(t *testing.T) {
+	e, _ := getEnvironmentAndDeployment(t)
+	e.Platform = &v1.IntegrationPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator",
+			Name:      "camel-k",
+		},
+	}
+	e.Platform.Status.Build.Registry.Secret = "registry"
+	source := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator",
+			Name:      "registry",
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+	}
+	assert.NoError(t, e.Client.Create(e.Ctx, &source))
+
+	trait := newPullSecretTrait().(*pullSecretTrait)
+	trait.Client = e.Client
+	trait.ImagePullerDelegation = BoolP(false)
+	enabled, err := trait.Configure(e)
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+
+	err = trait.Apply(e)
+	assert.NoError(t, err)
+
+	var replica corev1.Secret
+	err = e.Client.Get(e.Ctx, client.ObjectKey{Namespace: "test", Name: "registry"}, &replica)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
 func getEnvironmentAndDeployment(t *testing.T) (*Environment, *appsv1.Deployment) {
 	e := &Environment{}
 	e.Integration = &v1.Integration{
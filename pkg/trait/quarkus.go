@@ -18,18 +18,27 @@ limitations under the License.
 package trait
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/rs/xid"
+	"github.com/scylladb/go-set/strset"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/maven"
 )
 
 type quarkusPackageType string
@@ -39,11 +48,14 @@ const (
 
 	fastJarPackageType quarkusPackageType = "fast-jar"
 	nativePackageType  quarkusPackageType = "native"
+	// multiPackageType is the layout of the kits that contain both the fast-jar and native packages
+	multiPackageType quarkusPackageType = v1.IntegrationKitLayoutMulti
 )
 
 var kitPriority = map[quarkusPackageType]string{
 	fastJarPackageType: "1000",
 	nativePackageType:  "2000",
+	multiPackageType:   "2000",
 }
 
 // The Quarkus trait configures the Quarkus runtime.
@@ -66,6 +78,20 @@ type quarkusTrait struct {
 	// The kit corresponding to the first package type will be assigned to the
 	// integration in case no existing kit that matches the integration exists.
 	PackageTypes []quarkusPackageType `property:"package-type" json:"packageTypes,omitempty"`
+	// Build a single `IntegrationKit`, that contains both the `fast-jar` and `native` packages,
+	// rather than one kit per package type, when both package types are specified.
+	// The integration then chooses the package it runs with, using the `runtime-package-type` option.
+	MultiMode *bool `property:"multi-mode" json:"multiMode,omitempty"`
+	// The package type the integration runs with, when its kit contains both the `fast-jar` and `native` packages,
+	// either `fast-jar` or `native` (default `native`).
+	RuntimePackageType quarkusPackageType `property:"runtime-package-type" json:"runtimePackageType,omitempty"`
+	// The build-time properties, in the form of `key=value`, that are passed to the Maven build of the integration kit,
+	// e.g. `quarkus.native.additional-build-args=-H:+ReportExceptionStackTraces`.
+	BuildProperties []string `property:"build-properties" json:"buildProperties,omitempty"`
+	// The extensions that are excluded from the integration kit, including when they are transitive dependencies,
+	// either in the form of `groupId:artifactId`, or `camel:<name>` for Camel Quarkus extensions,
+	// e.g. `io.quarkus:quarkus-smallrye-health`.
+	ExcludedExtensions []string `property:"excluded-extensions" json:"excludedExtensions,omitempty"`
 }
 
 func newQuarkusTrait() Trait {
@@ -100,6 +126,15 @@ func (t *quarkusTrait) Matches(trait Trait) bool {
 		return false
 	}
 
+	if qt.isMultiMode() && !t.isMultiMode() {
+		return false
+	}
+
+	if !strset.New(t.BuildProperties...).IsEqual(strset.New(qt.BuildProperties...)) ||
+		!strset.New(t.ExcludedExtensions...).IsEqual(strset.New(qt.ExcludedExtensions...)) {
+		return false
+	}
+
 types:
 	for _, pt := range t.PackageTypes {
 		if pt == fastJarPackageType && len(qt.PackageTypes) == 0 {
@@ -119,6 +154,24 @@ func (t *quarkusTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if IsTrue(t.MultiMode) && !t.isMultiMode() {
+		return false, fmt.Errorf("the multi-mode kit requires both the %s and %s package types", fastJarPackageType, nativePackageType)
+	}
+	switch t.RuntimePackageType {
+	case "", fastJarPackageType, nativePackageType:
+	default:
+		return false, fmt.Errorf("unsupported runtime package type: %s", t.RuntimePackageType)
+	}
+	if e.fipsEnabled() && containsPackageType(t.PackageTypes, nativePackageType) {
+		return false, fmt.Errorf("the %s package type is not supported by platforms in FIPS mode", nativePackageType)
+	}
+	if _, err := t.getBuildProperties(); err != nil {
+		return false, err
+	}
+	if _, err := t.getExclusions(); err != nil {
+		return false, err
+	}
+
 	return e.IntegrationInPhase(v1.IntegrationPhaseBuildingKit) ||
 			e.IntegrationKitInPhase(v1.IntegrationKitPhaseBuildSubmitted) ||
 			e.IntegrationKitInPhase(v1.IntegrationKitPhaseReady) && e.IntegrationInRunningPhases(),
@@ -148,6 +201,20 @@ func (t *quarkusTrait) Apply(e *Environment) error {
 			}
 		}
 
+		if e.Platform.Status.Build.BaseKit != nil &&
+			(len(t.PackageTypes) == 0 || containsPackageType(t.PackageTypes, fastJarPackageType)) {
+			// The fast-jar kit images are layered on the base kit once it's built
+			if err := t.ensureBaseKit(e); err != nil {
+				return err
+			}
+		}
+
+		if t.isMultiMode() {
+			kit := t.newIntegrationKit(e, multiPackageType)
+			e.IntegrationKits = append(e.IntegrationKits, *kit)
+			return nil
+		}
+
 		switch len(t.PackageTypes) {
 		case 0:
 			kit := t.newIntegrationKit(e, fastJarPackageType)
@@ -198,6 +265,22 @@ func (t *quarkusTrait) Apply(e *Environment) error {
 			build.Maven.Properties = make(map[string]string)
 		}
 
+		properties, err := t.getBuildProperties()
+		if err != nil {
+			return err
+		}
+		for key, value := range properties {
+			build.Maven.Properties[key] = value
+		}
+
+		exclusions, err := t.getExclusions()
+		if err != nil {
+			return err
+		}
+		for _, exclusion := range exclusions {
+			util.StringSliceUniqueAdd(&build.Exclusions, exclusion)
+		}
+
 		steps, err := builder.StepsFrom(build.Steps...)
 		if err != nil {
 			return err
@@ -205,7 +288,15 @@ func (t *quarkusTrait) Apply(e *Environment) error {
 
 		steps = append(steps, builder.Quarkus.CommonSteps...)
 
-		if native, err := t.isNativeKit(e); err != nil {
+		if t.isMultiMode() {
+			// The native executable is built in addition to the fast-jar package, and both are assembled into the image
+			build.Maven.Properties["quarkus.package.type"] = string(fastJarPackageType)
+			steps = append(steps, builder.Quarkus.ComputeQuarkusDependencies, builder.Quarkus.BuildQuarkusNativeRunner, builder.Image.IncrementalImageContext)
+			// Spectrum does not rely on Dockerfile to assemble the image
+			if e.Platform.Status.Build.PublishStrategy != v1.IntegrationPlatformBuildPublishStrategySpectrum {
+				steps = append(steps, builder.Image.JvmDockerfile)
+			}
+		} else if native, err := t.isNativeKit(e); err != nil {
 			return err
 		} else if native {
 			build.Maven.Properties["quarkus.package.type"] = string(nativePackageType)
@@ -217,6 +308,9 @@ func (t *quarkusTrait) Apply(e *Environment) error {
 		} else {
 			build.Maven.Properties["quarkus.package.type"] = string(fastJarPackageType)
 			steps = append(steps, builder.Quarkus.ComputeQuarkusDependencies, builder.Image.IncrementalImageContext)
+			if e.fipsEnabled() {
+				steps = append(steps, builder.Image.FIPSSecurity)
+			}
 			// Spectrum does not rely on Dockerfile to assemble the image
 			if e.Platform.Status.Build.PublishStrategy != v1.IntegrationPlatformBuildPublishStrategySpectrum {
 				steps = append(steps, builder.Image.JvmDockerfile)
@@ -268,10 +362,67 @@ func (t *quarkusTrait) newIntegrationKit(e *Environment, packageType quarkusPack
 		Repositories: e.Integration.Spec.Repositories,
 		Traits:       traits,
 	}
+	if lock := e.Integration.Spec.DependencyLock; lock != nil {
+		kit.Spec.LockedDependencies = lock.Dependencies
+	}
 
 	return kit
 }
 
+// ensureBaseKit creates the base kit of the platform, for the runtime of the integration, when it does not exist yet.
+// The base kit contains the runtime dependencies, and the ones configured on the platform, so that it's shared
+// by most of the integrations, whose kit images only add their own dependencies on top of it.
+func (t *quarkusTrait) ensureBaseKit(e *Environment) error {
+	runtime := e.CamelCatalog.Runtime
+
+	set := strset.New(e.Platform.Status.Build.BaseKit.Dependencies...)
+	for _, d := range runtime.Dependencies {
+		set.Add(d.GetDependencyID())
+	}
+	dependencies := set.List()
+	sort.Strings(dependencies)
+
+	// The base kit is identified by the runtime and the dependencies it contains
+	hash := sha256.New()
+	for _, item := range append([]string{runtime.Version, string(runtime.Provider)}, dependencies...) {
+		if _, err := hash.Write([]byte(item)); err != nil {
+			return err
+		}
+	}
+	name := fmt.Sprintf("kit-base-%x", hash.Sum(nil))[:19]
+
+	kit := v1.NewIntegrationKit(e.Integration.GetIntegrationKitNamespace(e.Platform), name)
+	err := t.Client.Get(e.Ctx, ctrl.ObjectKeyFromObject(kit), kit)
+	if err == nil || !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	configuration, err := json.Marshal(map[string]string{"runtimeVersion": runtime.Version})
+	if err != nil {
+		return err
+	}
+	kit.Labels = map[string]string{
+		v1.IntegrationKitTypeLabel:          v1.IntegrationKitTypeBase,
+		"camel.apache.org/runtime.version":  runtime.Version,
+		"camel.apache.org/runtime.provider": string(runtime.Provider),
+		v1.IntegrationKitLayoutLabel:        string(fastJarPackageType),
+		v1.IntegrationKitPriorityLabel:      kitPriority[fastJarPackageType],
+	}
+	kit.Spec = v1.IntegrationKitSpec{
+		Dependencies: dependencies,
+		Traits: map[string]v1.TraitSpec{
+			"camel": {Configuration: v1.TraitConfiguration{RawMessage: configuration}},
+		},
+	}
+
+	t.L.Infof("Creating base kit %s for runtime %s", kit.Namespace+"/"+kit.Name, runtime.Version)
+	if err := t.Client.Create(e.Ctx, kit); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
 func (t *quarkusTrait) getKitTraits(e *Environment) map[string]v1.TraitSpec {
 	traits := make(map[string]v1.TraitSpec)
 	for name, spec := range e.Integration.Spec.Traits {
@@ -297,7 +448,53 @@ func (t *quarkusTrait) isNativeKit(e *Environment) (bool, error) {
 
 func (t *quarkusTrait) isNativeIntegration(e *Environment) bool {
 	// The current IntegrationKit determines the Integration runtime type
-	return e.IntegrationKit.Labels[v1.IntegrationKitLayoutLabel] == v1.IntegrationKitLayoutNative
+	switch e.IntegrationKit.Labels[v1.IntegrationKitLayoutLabel] {
+	case v1.IntegrationKitLayoutNative:
+		return true
+	case v1.IntegrationKitLayoutMulti:
+		// The kit contains both packages, so the Integration chooses the one it runs with
+		return t.RuntimePackageType != fastJarPackageType
+	default:
+		return false
+	}
+}
+
+func (t *quarkusTrait) isMultiMode() bool {
+	return IsTrue(t.MultiMode) &&
+		containsPackageType(t.PackageTypes, fastJarPackageType) &&
+		containsPackageType(t.PackageTypes, nativePackageType)
+}
+
+func (t *quarkusTrait) getBuildProperties() (map[string]string, error) {
+	properties, err := qualifiedKeyValuePairArrayAsStringMap(t.BuildProperties)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := properties["quarkus.package.type"]; ok {
+		return nil, errors.New("the quarkus.package.type build property must be set with the package-type option")
+	}
+	return properties, nil
+}
+
+// getExclusions returns the excluded extensions, in the form of groupId:artifactId
+func (t *quarkusTrait) getExclusions() ([]string, error) {
+	exclusions := make([]string, 0, len(t.ExcludedExtensions))
+	for _, extension := range t.ExcludedExtensions {
+		if strings.HasPrefix(extension, "camel:") {
+			artifactID := strings.TrimPrefix(extension, "camel:")
+			if !strings.HasPrefix(artifactID, "camel-") {
+				artifactID = "camel-quarkus-" + artifactID
+			}
+			exclusions = append(exclusions, "org.apache.camel.quarkus:"+artifactID)
+			continue
+		}
+		d, err := maven.ParseGAV(strings.TrimPrefix(extension, "mvn:"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid excluded extension: %s, must be groupId:artifactId or camel:<name>", extension)
+		}
+		exclusions = append(exclusions, d.GroupID+":"+d.ArtifactID)
+	}
+	return exclusions, nil
 }
 
 func getBuilderTask(tasks []v1.Task) *v1.BuilderTask {
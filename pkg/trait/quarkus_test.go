@@ -18,13 +18,17 @@ limitations under the License.
 package trait
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
 )
 
 func TestConfigureQuarkusTraitBuildSubmitted(t *testing.T) {
@@ -69,6 +73,121 @@ func TestApplyQuarkusTraitDefaultKitLayout(t *testing.T) {
 	assert.Equal(t, environment.IntegrationKits[0].Labels[v1.IntegrationKitLayoutLabel], v1.IntegrationKitLayoutFastJar)
 }
 
+func TestConfigureQuarkusTraitInvalidOptions(t *testing.T) {
+	for _, configure := range []func(*quarkusTrait){
+		func(q *quarkusTrait) { q.BuildProperties = []string{"quarkus.package.type=uber-jar"} },
+		func(q *quarkusTrait) { q.BuildProperties = []string{"quarkus.native.debug.enabled"} },
+		func(q *quarkusTrait) { q.ExcludedExtensions = []string{"quarkus-smallrye-health"} },
+		func(q *quarkusTrait) { q.RuntimePackageType = "uber-jar" },
+		func(q *quarkusTrait) {
+			q.PackageTypes = []quarkusPackageType{nativePackageType}
+			q.MultiMode = BoolP(true)
+		},
+	} {
+		quarkusTrait, environment := createNominalQuarkusTest()
+		configure(quarkusTrait)
+
+		_, err := quarkusTrait.Configure(environment)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestApplyQuarkusTraitBuildPropertiesAndExclusions(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	environment.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseBuildSubmitted
+	quarkusTrait.BuildProperties = []string{"quarkus.native.additional-build-args=-H:+ReportExceptionStackTraces"}
+	quarkusTrait.ExcludedExtensions = []string{
+		"io.quarkus:quarkus-smallrye-health",
+		"mvn:io.quarkus:quarkus-micrometer",
+		"camel:microprofile-health",
+		"camel:camel-quarkus-kubernetes",
+	}
+
+	configured, err := quarkusTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = quarkusTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	build := getBuilderTask(environment.BuildTasks)
+	assert.NotNil(t, build)
+	assert.Equal(t, "-H:+ReportExceptionStackTraces", build.Maven.Properties["quarkus.native.additional-build-args"])
+	assert.Equal(t, []string{
+		"io.quarkus:quarkus-smallrye-health",
+		"io.quarkus:quarkus-micrometer",
+		"org.apache.camel.quarkus:camel-quarkus-microprofile-health",
+		"org.apache.camel.quarkus:camel-quarkus-kubernetes",
+	}, build.Exclusions)
+}
+
+func TestApplyQuarkusTraitMultiMode(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	quarkusTrait.PackageTypes = []quarkusPackageType{fastJarPackageType, nativePackageType}
+	quarkusTrait.MultiMode = BoolP(true)
+	environment.Integration.Spec.Sources[0].Language = v1.LanguageYaml
+	environment.Integration.Status.Phase = v1.IntegrationPhaseBuildingKit
+
+	configured, err := quarkusTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = quarkusTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Len(t, environment.IntegrationKits, 1)
+	kit := environment.IntegrationKits[0]
+	assert.Equal(t, v1.IntegrationKitLayoutMulti, kit.Labels[v1.IntegrationKitLayoutLabel])
+
+	environment.Integration.Status.Phase = ""
+	environment.IntegrationKit = &kit
+	environment.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseBuildSubmitted
+
+	err = quarkusTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	build := getBuilderTask(environment.BuildTasks)
+	assert.NotNil(t, build)
+	assert.Equal(t, "fast-jar", build.Maven.Properties["quarkus.package.type"])
+	assert.Contains(t, build.Steps, builder.StepIDsFor(builder.Quarkus.BuildQuarkusNativeRunner)[0])
+	assert.Contains(t, build.Steps, builder.StepIDsFor(builder.Image.JvmDockerfile)[0])
+}
+
+func TestQuarkusTraitFIPS(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	environment.Platform.Status.FIPS = true
+	environment.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseBuildSubmitted
+
+	configured, err := quarkusTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	err = quarkusTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	build := getBuilderTask(environment.BuildTasks)
+	assert.NotNil(t, build)
+	assert.Contains(t, build.Steps, builder.StepIDsFor(builder.Image.FIPSSecurity)[0])
+
+	quarkusTrait.PackageTypes = []quarkusPackageType{nativePackageType}
+	_, err = quarkusTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestQuarkusTraitMultiModeRuntimePackageType(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	environment.IntegrationKit.Labels = map[string]string{
+		v1.IntegrationKitLayoutLabel: v1.IntegrationKitLayoutMulti,
+	}
+
+	assert.True(t, quarkusTrait.isNativeIntegration(environment))
+
+	quarkusTrait.RuntimePackageType = fastJarPackageType
+	assert.False(t, quarkusTrait.isNativeIntegration(environment))
+
+	quarkusTrait.RuntimePackageType = nativePackageType
+	assert.True(t, quarkusTrait.isNativeIntegration(environment))
+}
+
 func createNominalQuarkusTest() (*quarkusTrait, *Environment) {
 	trait := newQuarkusTrait().(*quarkusTrait)
 	trait.Enabled = BoolP(true)
@@ -95,3 +214,52 @@ func createNominalQuarkusTest() (*quarkusTrait, *Environment) {
 
 	return trait, environment
 }
+
+func TestApplyQuarkusTraitBaseKit(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	quarkusTrait, environment := createNominalQuarkusTest()
+	quarkusTrait.Client = c
+	environment.Ctx = context.TODO()
+	environment.Integration.Namespace = "ns"
+	environment.Integration.Status.Phase = v1.IntegrationPhaseBuildingKit
+	environment.Platform.Namespace = "platform"
+	environment.Platform.Status.Build.BaseKit = &v1.IntegrationPlatformBaseKitSpec{
+		Dependencies: []string{"camel:http", "camel:kafka"},
+	}
+	environment.CamelCatalog.Runtime = v1.RuntimeSpec{
+		Version:  "1.0.0",
+		Provider: v1.RuntimeProviderQuarkus,
+		Dependencies: []v1.MavenArtifact{
+			{GroupID: "org.apache.camel.k", ArtifactID: "camel-k-runtime"},
+		},
+	}
+
+	assert.Nil(t, quarkusTrait.Apply(environment))
+	assert.Len(t, environment.IntegrationKits, 1)
+
+	kits := v1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &kits, ctrl.InNamespace("platform")))
+	assert.Len(t, kits.Items, 1)
+	kit := kits.Items[0]
+	assert.Regexp(t, "^kit-base-[0-9a-f]{10}$", kit.Name)
+	assert.Equal(t, v1.IntegrationKitTypeBase, kit.Labels[v1.IntegrationKitTypeLabel])
+	assert.Equal(t, "1.0.0", kit.Labels["camel.apache.org/runtime.version"])
+	assert.Equal(t, v1.IntegrationKitLayoutFastJar, kit.Labels[v1.IntegrationKitLayoutLabel])
+	assert.Equal(t, []string{"camel:http", "camel:kafka", "mvn:org.apache.camel.k:camel-k-runtime"}, kit.Spec.Dependencies)
+
+	// The base kit is shared by the other integrations
+	environment.IntegrationKits = nil
+	assert.Nil(t, quarkusTrait.Apply(environment))
+	assert.Nil(t, c.List(context.TODO(), &kits, ctrl.InNamespace("platform")))
+	assert.Len(t, kits.Items, 1)
+
+	// The base kit is not created for native kits
+	environment.Platform.Status.Build.BaseKit.Dependencies = []string{"camel:jms"}
+	quarkusTrait.PackageTypes = []quarkusPackageType{nativePackageType}
+	environment.Integration.Spec.Sources[0].Language = v1.LanguageYaml
+	assert.Nil(t, quarkusTrait.Apply(environment))
+	assert.Nil(t, c.List(context.TODO(), &kits, ctrl.InNamespace("platform")))
+	assert.Len(t, kits.Items, 1)
+}
@@ -19,9 +19,12 @@ package trait
 
 import (
 	"fmt"
+	"path"
 	"reflect"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -29,6 +32,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/envvar"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 )
 
@@ -42,6 +46,11 @@ import (
 // following parameters to reference them: `tls-certificate-secret`, `tls-key-secret`, `tls-ca-certificate-secret`, `tls-destination-ca-certificate-secret`
 // See the examples section at the end of this page to see the setup options.
 //
+// With `passthrough` and `reencrypt` termination, the integration itself terminates the TLS connections.
+// The certificate and key it serves can be provided with the `tls-server-certificate-secret` and `tls-server-key-secret`
+// parameters, and client certificates can be validated against the CA certificates referenced by `tls-client-ca-certificate-secret`,
+// according to the `tls-client-auth` mode.
+//
 // +camel-k:trait=route
 type routeTrait struct {
 	BaseTrait `property:",squash"`
@@ -91,13 +100,32 @@ type routeTrait struct {
 	//
 	// Refer to the OpenShift route documentation for additional information.
 	TLSInsecureEdgeTerminationPolicy string `property:"tls-insecure-edge-termination-policy" json:"tlsInsecureEdgeTerminationPolicy,omitempty"`
+	// The secret name and key reference to the certificate served by the integration, when it terminates TLS,
+	// i.e. with `passthrough` or `reencrypt` termination. The format is "secret-name[/key-name]".
+	TLSServerCertificateSecret string `property:"tls-server-certificate-secret" json:"tlsServerCertificateSecret,omitempty"`
+	// The secret name and key reference to the key of the certificate served by the integration, when it terminates TLS,
+	// i.e. with `passthrough` or `reencrypt` termination. The format is "secret-name[/key-name]".
+	TLSServerKeySecret string `property:"tls-server-key-secret" json:"tlsServerKeySecret,omitempty"`
+	// The client certificate authentication mode of the integration, when it terminates TLS: `none`, `request` or `required`.
+	TLSClientAuth string `property:"tls-client-auth" json:"tlsClientAuth,omitempty"`
+	// The secret name and key reference to the PEM encoded CA certificates used to validate the client certificates.
+	// The format is "secret-name[/key-name]".
+	TLSClientCACertificateSecret string `property:"tls-client-ca-certificate-secret" json:"tlsClientCACertificateSecret,omitempty"`
 
 	service *corev1.Service
 }
 
+var routeTLSMountPath = path.Join(confDPath, "_route")
+
+const (
+	routeTLSClientAuthNone     = "none"
+	routeTLSClientAuthRequest  = "request"
+	routeTLSClientAuthRequired = "required"
+)
+
 func newRouteTrait() Trait {
 	return &routeTrait{
-		BaseTrait: NewBaseTrait("route", 2200),
+		BaseTrait: NewBaseTrait("route", 2200, ExecuteAfter(serviceTraitID)),
 	}
 }
 
@@ -124,6 +152,10 @@ func (t *routeTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
+	if err := t.validateTLSConfig(); err != nil {
+		return false, err
+	}
+
 	t.service = e.Resources.GetUserServiceForIntegration(e.Integration)
 	if t.service == nil {
 		if e.Integration != nil {
@@ -179,6 +211,10 @@ func (t *routeTrait) Apply(e *Environment) error {
 
 	e.Resources.Add(&route)
 
+	if err := t.configureServerTLS(e); err != nil {
+		return err
+	}
+
 	var message string
 
 	if t.Host == "" {
@@ -254,18 +290,178 @@ func (t *routeTrait) getTLSConfig(e *Environment) (*routev1.TLSConfig, error) {
 	return &config, nil
 }
 
-func (t *routeTrait) readContentIfExists(e *Environment, secretName string) (string, error) {
-	key := ""
-	strs := strings.Split(secretName, "/")
+func (t *routeTrait) validateTLSConfig() error {
+	switch routev1.TLSTerminationType(t.TLSTermination) {
+	case "", routev1.TLSTerminationEdge, routev1.TLSTerminationReencrypt:
+	case routev1.TLSTerminationPassthrough:
+		if t.TLSCertificate != "" || t.TLSCertificateSecret != "" || t.TLSKey != "" || t.TLSKeySecret != "" ||
+			t.TLSCACertificate != "" || t.TLSCACertificateSecret != "" {
+			return errors.New("passthrough termination does not support certificate, key and CA certificate, " +
+				"use the tls-server-certificate-secret and tls-server-key-secret parameters instead")
+		}
+	default:
+		return fmt.Errorf("unsupported TLS termination %s", t.TLSTermination)
+	}
+
+	if (t.TLSDestinationCACertificate != "" || t.TLSDestinationCACertificateSecret != "") &&
+		routev1.TLSTerminationType(t.TLSTermination) != routev1.TLSTerminationReencrypt {
+		return errors.New("destination CA certificate is only supported with reencrypt termination")
+	}
+
+	if !t.terminatesTLSInIntegration() {
+		if t.TLSServerCertificateSecret != "" || t.TLSServerKeySecret != "" || t.TLSClientAuth != "" || t.TLSClientCACertificateSecret != "" {
+			return errors.New("server certificate and client authentication are only supported with passthrough or reencrypt termination")
+		}
+		return nil
+	}
+
+	if (t.TLSServerCertificateSecret == "") != (t.TLSServerKeySecret == "") {
+		return errors.New("both tls-server-certificate-secret and tls-server-key-secret must be set")
+	}
+
+	switch t.TLSClientAuth {
+	case "", routeTLSClientAuthNone:
+	case routeTLSClientAuthRequest, routeTLSClientAuthRequired:
+		if t.TLSServerCertificateSecret == "" {
+			return errors.New("client authentication requires tls-server-certificate-secret and tls-server-key-secret to be set")
+		}
+		if t.TLSClientCACertificateSecret == "" {
+			return errors.New("client authentication requires tls-client-ca-certificate-secret to be set")
+		}
+	default:
+		return fmt.Errorf("unsupported TLS client authentication mode %s", t.TLSClientAuth)
+	}
+
+	return nil
+}
+
+func (t *routeTrait) terminatesTLSInIntegration() bool {
+	termination := routev1.TLSTerminationType(t.TLSTermination)
+	return termination == routev1.TLSTerminationPassthrough || termination == routev1.TLSTerminationReencrypt
+}
+
+// configureServerTLS mounts the certificates used by the integration to terminate TLS connections
+// and configures the HTTP server accordingly
+func (t *routeTrait) configureServerTLS(e *Environment) error {
+	if !t.terminatesTLSInIntegration() || t.TLSServerCertificateSecret == "" {
+		return nil
+	}
+
+	container := e.getIntegrationContainer()
+	if container == nil {
+		return errors.New("cannot configure TLS: no integration container")
+	}
+
+	podSpec := e.GetIntegrationPodSpec()
+	if podSpec == nil {
+		return errors.New("cannot configure TLS: no integration pod")
+	}
+
+	mount := func(name string, secretRef string, env string) error {
+		secretName, key := parseSecretReference(secretRef)
+		if key == "" {
+			k, err := t.lookupSecretKey(e, secretName)
+			if err != nil {
+				return err
+			}
+			key = k
+		}
+
+		volumeName := "route-tls-" + name
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretName,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  key,
+							Path: key,
+						},
+					},
+				},
+			},
+		})
+
+		mountPath := path.Join(routeTLSMountPath, name)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+
+		envvar.SetVal(&container.Env, env, path.Join(mountPath, key))
+
+		return nil
+	}
+
+	if err := mount("certificate", t.TLSServerCertificateSecret, "QUARKUS_HTTP_SSL_CERTIFICATE_FILE"); err != nil {
+		return err
+	}
+	if err := mount("key", t.TLSServerKeySecret, "QUARKUS_HTTP_SSL_CERTIFICATE_KEY_FILE"); err != nil {
+		return err
+	}
+
+	if t.TLSClientAuth != "" && t.TLSClientAuth != routeTLSClientAuthNone {
+		if err := mount("client-ca", t.TLSClientCACertificateSecret, "QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE"); err != nil {
+			return err
+		}
+		// The trust store type cannot be inferred from the extension of the mounted certificate
+		envvar.SetVal(&container.Env, "QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE_TYPE", "PEM")
+		envvar.SetVal(&container.Env, "QUARKUS_HTTP_SSL_CLIENT_AUTH", strings.ToUpper(t.TLSClientAuth))
+	}
+
+	// Serve HTTPS on the container port, that's the one targeted by the route
+	if port := e.getIntegrationContainerPort(); port != nil {
+		envvar.SetVal(&container.Env, "QUARKUS_HTTP_SSL_PORT", fmt.Sprint(port.ContainerPort))
+		// The HTTP requests are rejected, so the probes must use HTTPS
+		for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+			if probe != nil && probe.HTTPGet != nil && probe.HTTPGet.Port.IntValue() == int(port.ContainerPort) {
+				probe.HTTPGet.Scheme = corev1.URISchemeHTTPS
+			}
+		}
+	}
+	envvar.SetVal(&container.Env, "QUARKUS_HTTP_INSECURE_REQUESTS", "disabled")
+
+	return nil
+}
+
+func (t *routeTrait) lookupSecretKey(e *Environment, secretName string) (string, error) {
+	secret := kubernetes.LookupSecret(e.Ctx, t.Client, t.service.Namespace, secretName)
+	if secret == nil {
+		return "", fmt.Errorf("%s secret not found in %s namespace, make sure to provide it before the Integration can run", secretName, t.service.Namespace)
+	}
+	if len(secret.Data) == 0 {
+		return "", fmt.Errorf("secret %s in namespace %s has no data", secretName, t.service.Namespace)
+	}
+	if len(secret.Data) != 1 {
+		return "", fmt.Errorf("secret %s contains multiple data keys, but no key was provided", secretName)
+	}
+	for key := range secret.Data {
+		return key, nil
+	}
+	return "", nil
+}
+
+// parseSecretReference splits a "secret-name[/key-name]" reference
+func parseSecretReference(ref string) (string, string) {
+	strs := strings.SplitN(ref, "/", 2)
 	if len(strs) > 1 {
-		secretName = strs[0]
-		key = strs[1]
+		return strs[0], strs[1]
 	}
+	return ref, ""
+}
+
+func (t *routeTrait) readContentIfExists(e *Environment, secretName string) (string, error) {
+	secretName, key := parseSecretReference(secretName)
 
 	secret := kubernetes.LookupSecret(e.Ctx, t.Client, t.service.Namespace, secretName)
 	if secret == nil {
 		return "", fmt.Errorf("%s secret not found in %s namespace, make sure to provide it before the Integration can run", secretName, t.service.Namespace)
 	}
+	if len(secret.Data) == 0 {
+		return "", fmt.Errorf("secret %s in namespace %s has no data", secretName, t.service.Namespace)
+	}
 	if len(secret.Data) > 1 && len(key) == 0 {
 		return "", fmt.Errorf("secret %s contains multiple data keys, but no key was provided", secretName)
 	}
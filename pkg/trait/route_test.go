@@ -95,6 +95,8 @@ pxv6zFeVEkAEEkqIYi0omA9+CjanB/6Bz4n1uw8H
 	tlsMultipleSecretsCert1Key = "cert1.crt"
 	tlsMultipleSecretsCert2Key = "cert2.crt"
 	tlsMultipleSecretsCert3Key = "cert3.crt"
+
+	tlsEmptySecretName = "tls-empty-test"
 )
 
 func createTestRouteEnvironment(t *testing.T, name string) *Environment {
@@ -129,6 +131,16 @@ func createTestRouteEnvironment(t *testing.T, name string) *Environment {
 				tlsMultipleSecretsCert3Key: []byte(destinationCaCert),
 			},
 		},
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test-ns",
+				Name:      tlsEmptySecretName,
+			},
+		},
 	)
 	res := &Environment{
 		CamelCatalog: catalog,
@@ -391,6 +403,47 @@ func TestRoute_TLS_secret_missing_key(t *testing.T) {
 	assert.Nil(t, route)
 }
 
+func TestRoute_TLS_empty_secret(t *testing.T) {
+	for _, ref := range []string{tlsEmptySecretName, tlsEmptySecretName + "/tls.crt"} {
+		name := xid.New().String()
+		environment := createTestRouteEnvironment(t, name)
+		traitsCatalog := environment.Catalog
+
+		environment.Integration.Spec.Traits = map[string]v1.TraitSpec{
+			"route": test.TraitSpecFromMap(t, map[string]interface{}{
+				"tlsTermination":       string(routev1.TLSTerminationEdge),
+				"host":                 host,
+				"tlsKeySecret":         tlsKeySecretName,
+				"tlsCertificateSecret": ref,
+			}),
+		}
+		err := traitsCatalog.apply(environment)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "secret tls-empty-test in namespace test-ns has no data")
+		assert.Nil(t, environment.GetTrait("route"))
+	}
+}
+
+func TestRoute_TLS_passthrough_empty_server_secret(t *testing.T) {
+	name := xid.New().String()
+	environment := createTestRouteEnvironment(t, name)
+	traitsCatalog := environment.Catalog
+
+	environment.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"route": test.TraitSpecFromMap(t, map[string]interface{}{
+			"tlsTermination":             string(routev1.TLSTerminationPassthrough),
+			"host":                       host,
+			"tlsServerCertificateSecret": tlsEmptySecretName,
+			"tlsServerKeySecret":         tlsKeySecretName,
+		}),
+	}
+	err := traitsCatalog.apply(environment)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "secret tls-empty-test in namespace test-ns has no data")
+}
+
 func TestRoute_TLS_reencrypt(t *testing.T) {
 	name := xid.New().String()
 	environment := createTestRouteEnvironment(t, name)
@@ -523,3 +576,119 @@ func TestRoute_WithCustomServicePort(t *testing.T) {
 		route.Spec.Port.TargetPort.StrVal,
 	)
 }
+
+func TestRoute_TLS_passthrough_with_client_auth(t *testing.T) {
+	name := xid.New().String()
+	environment := createTestRouteEnvironment(t, name)
+	traitsCatalog := environment.Catalog
+
+	environment.Integration.Spec.Traits = map[string]v1.TraitSpec{
+		"route": test.TraitSpecFromMap(t, map[string]interface{}{
+			"tlsTermination":               string(routev1.TLSTerminationPassthrough),
+			"host":                         host,
+			"tlsServerCertificateSecret":   tlsMultipleSecretsName + "/" + tlsMultipleSecretsCert1Key,
+			"tlsServerKeySecret":           tlsKeySecretName,
+			"tlsClientAuth":                "required",
+			"tlsClientCACertificateSecret": tlsMultipleSecretsName + "/" + tlsMultipleSecretsCert2Key,
+		}),
+		"container": test.TraitSpecFromMap(t, map[string]interface{}{
+			"probesEnabled": true,
+		}),
+	}
+	err := traitsCatalog.apply(environment)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, environment.GetTrait("route"))
+
+	route := environment.Resources.GetRoute(func(r *routev1.Route) bool {
+		return r.ObjectMeta.Name == name
+	})
+
+	assert.NotNil(t, route)
+	assert.Equal(t, routev1.TLSTerminationPassthrough, route.Spec.TLS.Termination)
+	assert.Empty(t, route.Spec.TLS.Key)
+	assert.Empty(t, route.Spec.TLS.Certificate)
+
+	container := environment.getIntegrationContainer()
+	assert.NotNil(t, container)
+
+	env := make(map[string]string)
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	assert.Equal(t, "/etc/camel/conf.d/_route/certificate/"+tlsMultipleSecretsCert1Key, env["QUARKUS_HTTP_SSL_CERTIFICATE_FILE"])
+	assert.Equal(t, "/etc/camel/conf.d/_route/key/"+tlsKeySecretOnlyKeyName, env["QUARKUS_HTTP_SSL_CERTIFICATE_KEY_FILE"])
+	assert.Equal(t, "/etc/camel/conf.d/_route/client-ca/"+tlsMultipleSecretsCert2Key, env["QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE"])
+	assert.Equal(t, "REQUIRED", env["QUARKUS_HTTP_SSL_CLIENT_AUTH"])
+	assert.Equal(t, "8080", env["QUARKUS_HTTP_SSL_PORT"])
+	assert.Equal(t, "PEM", env["QUARKUS_HTTP_SSL_CERTIFICATE_TRUST_STORE_FILE_TYPE"])
+	assert.Equal(t, "disabled", env["QUARKUS_HTTP_INSECURE_REQUESTS"])
+	assert.NotNil(t, container.LivenessProbe)
+	assert.Equal(t, corev1.URISchemeHTTPS, container.LivenessProbe.HTTPGet.Scheme)
+	assert.NotNil(t, container.ReadinessProbe)
+	assert.Equal(t, corev1.URISchemeHTTPS, container.ReadinessProbe.HTTPGet.Scheme)
+
+	podSpec := environment.GetIntegrationPodSpec()
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: "route-tls-key",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: tlsKeySecretName,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  tlsKeySecretOnlyKeyName,
+						Path: tlsKeySecretOnlyKeyName,
+					},
+				},
+			},
+		},
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "route-tls-client-ca",
+		MountPath: "/etc/camel/conf.d/_route/client-ca",
+		ReadOnly:  true,
+	})
+}
+
+func TestRoute_TLS_invalid_configuration(t *testing.T) {
+	configurations := []map[string]interface{}{
+		{
+			"tlsTermination": string(routev1.TLSTerminationPassthrough),
+			"tlsKey":         key,
+		},
+		{
+			"tlsTermination":              string(routev1.TLSTerminationEdge),
+			"tlsDestinationCACertificate": destinationCaCert,
+		},
+		{
+			"tlsTermination": string(routev1.TLSTerminationEdge),
+			"tlsClientAuth":  "required",
+		},
+		{
+			"tlsTermination":             string(routev1.TLSTerminationReencrypt),
+			"tlsServerCertificateSecret": tlsMultipleSecretsName + "/" + tlsMultipleSecretsCert1Key,
+		},
+		{
+			"tlsTermination":             string(routev1.TLSTerminationPassthrough),
+			"tlsServerCertificateSecret": tlsMultipleSecretsName + "/" + tlsMultipleSecretsCert1Key,
+			"tlsServerKeySecret":         tlsKeySecretName,
+			"tlsClientAuth":              "required",
+		},
+		{
+			"tlsTermination": "foo",
+		},
+	}
+
+	for _, configuration := range configurations {
+		name := xid.New().String()
+		environment := createTestRouteEnvironment(t, name)
+		environment.Integration.Spec.Traits = map[string]v1.TraitSpec{
+			"route": test.TraitSpecFromMap(t, configuration),
+		}
+
+		err := environment.Catalog.apply(environment)
+
+		assert.NotNil(t, err, "%v", configuration)
+		assert.Nil(t, environment.GetTrait("route"))
+	}
+}
@@ -18,8 +18,13 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/metadata"
@@ -31,6 +36,9 @@ import (
 //
 // It's enabled by default if the integration depends on a Camel component that can expose a HTTP endpoint.
 //
+// Besides the main HTTP port, configured with the `container` trait, additional ports can be exposed,
+// e.g. for gRPC or Jolokia endpoints.
+//
 // +camel-k:trait=service
 type serviceTrait struct {
 	BaseTrait `property:",squash"`
@@ -38,10 +46,36 @@ type serviceTrait struct {
 	Auto *bool `property:"auto" json:"auto,omitempty"`
 	// Enable Service to be exposed as NodePort
 	NodePort *bool `property:"node-port" json:"nodePort,omitempty"`
+	// The type of the Service: `ClusterIP`, `NodePort` or `LoadBalancer`. It takes precedence over `node-port`.
+	Type string `property:"type" json:"type,omitempty"`
+	// Additional ports exposed by the Service, in the form `name:service-port[:container-port][/protocol]`, e.g. `grpc:9000:9000/TCP`.
+	// When the container port is omitted, the Service targets the container port with the given name, that is expected
+	// to be declared by another trait, e.g. `jolokia:8778` for the port added by the `jolokia` trait.
+	Ports []string `property:"ports" json:"ports,omitempty"`
+	// The application protocols of the Service ports, in the form `port-name=app-protocol`, e.g. `grpc=kubernetes.io/h2c`.
+	AppProtocols []string `property:"app-protocols" json:"appProtocols,omitempty"`
+	// The annotations added to the Service, in the form of `key=value`, e.g. to configure load balancers.
+	Annotations []string `property:"annotations" json:"annotations,omitempty"`
+	// Create a headless Service, that directly resolves to the integration pods addresses, including not ready ones.
+	// It can be used for peer discovery by clustered components, like Hazelcast or Infinispan.
+	Headless *bool `property:"headless" json:"headless,omitempty"`
+
+	ports        []servicePort
+	appProtocols map[string]string
+}
+
+// servicePort is an additional port exposed by the Service
+type servicePort struct {
+	Name          string
+	Port          int32
+	ContainerPort int32
+	Protocol      corev1.Protocol
 }
 
 const serviceTraitID = "service"
 
+var servicePortRegexp = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?):(\d+)(:(\d+))?(/(TCP|UDP|SCTP))?$`)
+
 func newServiceTrait() Trait {
 	return &serviceTrait{
 		BaseTrait: NewBaseTrait(serviceTraitID, 1500),
@@ -96,23 +130,139 @@ func (t *serviceTrait) Configure(e *Environment) (bool, error) {
 		}
 	}
 
+	if err := t.parsePorts(); err != nil {
+		return false, err
+	}
+
+	appProtocols, err := qualifiedKeyValuePairArrayAsStringMap(t.AppProtocols)
+	if err != nil {
+		return false, err
+	}
+	t.appProtocols = appProtocols
+
+	if t.Type != "" && !isValidServiceType(corev1.ServiceType(t.Type)) {
+		return false, fmt.Errorf("unsupported service type %s", t.Type)
+	}
+
+	if IsTrue(t.Headless) && (IsTrue(t.NodePort) || (t.Type != "" && corev1.ServiceType(t.Type) != corev1.ServiceTypeClusterIP)) {
+		return false, fmt.Errorf("a headless service must be of type %s", corev1.ServiceTypeClusterIP)
+	}
+
 	return true, nil
 }
 
+func (t *serviceTrait) parsePorts() error {
+	t.ports = make([]servicePort, 0, len(t.Ports))
+
+	for _, p := range t.Ports {
+		match := servicePortRegexp.FindStringSubmatch(p)
+		if match == nil {
+			return fmt.Errorf("unable to parse service port: %s", p)
+		}
+
+		port := servicePort{
+			Name:     match[1],
+			Protocol: corev1.ProtocolTCP,
+		}
+
+		v, err := strconv.ParseInt(match[3], 10, 32)
+		if err != nil {
+			return err
+		}
+		port.Port = int32(v)
+
+		if match[5] != "" {
+			v, err := strconv.ParseInt(match[5], 10, 32)
+			if err != nil {
+				return err
+			}
+			port.ContainerPort = int32(v)
+		}
+		if match[7] != "" {
+			port.Protocol = corev1.Protocol(match[7])
+		}
+
+		t.ports = append(t.ports, port)
+	}
+
+	return nil
+}
+
+func isValidServiceType(serviceType corev1.ServiceType) bool {
+	return serviceType == corev1.ServiceTypeClusterIP || serviceType == corev1.ServiceTypeNodePort || serviceType == corev1.ServiceTypeLoadBalancer
+}
+
 func (t *serviceTrait) Apply(e *Environment) error {
 	svc := e.Resources.GetServiceForIntegration(e.Integration)
 	// add a new service if not already created
 	if svc == nil {
 		svc = getServiceFor(e)
 
-		if IsNilOrTrue(t.NodePort) {
+		switch {
+		case t.Type != "":
+			svc.Spec.Type = corev1.ServiceType(t.Type)
+		case IsTrue(t.Headless):
+			svc.Spec.Type = corev1.ServiceTypeClusterIP
+		case IsNilOrTrue(t.NodePort):
 			svc.Spec.Type = corev1.ServiceTypeNodePort
 		}
 	}
+
+	if IsTrue(t.Headless) {
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+		svc.Spec.PublishNotReadyAddresses = true
+	}
+
+	annotations, err := qualifiedKeyValuePairArrayAsStringMap(t.Annotations)
+	if err != nil {
+		return err
+	}
+	if len(annotations) > 0 {
+		if svc.Annotations == nil {
+			svc.Annotations = make(map[string]string)
+		}
+		for k, v := range annotations {
+			svc.Annotations[k] = v
+		}
+	}
+
+	for _, port := range t.ports {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:        port.Name,
+			Port:        port.Port,
+			Protocol:    port.Protocol,
+			TargetPort:  intstr.FromString(port.Name),
+			AppProtocol: t.appProtocolFor(port.Name),
+		})
+	}
+
 	e.Resources.Add(svc)
 	return nil
 }
 
+// appProtocolFor returns the application protocol configured for the Service port with the given name
+func (t *serviceTrait) appProtocolFor(portName string) *string {
+	if protocol, ok := t.appProtocols[portName]; ok {
+		return &protocol
+	}
+	return nil
+}
+
+// containerPorts returns the additional ports the integration container has to declare
+func (t *serviceTrait) containerPorts() []corev1.ContainerPort {
+	ports := make([]corev1.ContainerPort, 0)
+	for _, port := range t.ports {
+		if port.ContainerPort != 0 {
+			ports = append(ports, corev1.ContainerPort{
+				Name:          port.Name,
+				ContainerPort: port.ContainerPort,
+				Protocol:      port.Protocol,
+			})
+		}
+	}
+	return ports
+}
+
 func getServiceFor(e *Environment) *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,220 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/uri"
+)
+
+// The Service Account trait runs the integration with a ServiceAccount dedicated to it, instead of the `default`
+// ServiceAccount of the namespace, so that integrations sharing a namespace are isolated from each other.
+//
+// The ServiceAccount is named after the integration, and is only granted the permissions the integration requires,
+// i.e., the access to the resources of the Camel Kubernetes components used by the integration, read-only for the
+// consumers, and the permissions added by the other traits, like the master trait. The ServiceAccount token is not
+// mounted into the integration pods when no permission is granted.
+//
+// The trait has no effect when the integration sets its own ServiceAccount.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=service-account
+type serviceAccountTrait struct {
+	BaseTrait `property:",squash"`
+	// Grant the ServiceAccount the permissions the Camel Kubernetes components used by the integration require (default `true`).
+	ComponentPermissions *bool `property:"component-permissions" json:"componentPermissions,omitempty"`
+}
+
+const serviceAccountTraitID = "service-account"
+
+// kubernetesResource is the API resource a Camel Kubernetes component operates on
+type kubernetesResource struct {
+	group    string
+	resource string
+}
+
+// kubernetesComponentResources maps the namespaced Camel Kubernetes components to the resources they operate on
+var kubernetesComponentResources = map[string]kubernetesResource{
+	"kubernetes-config-maps":               {group: "", resource: "configmaps"},
+	"kubernetes-deployments":               {group: "apps", resource: "deployments"},
+	"kubernetes-events":                    {group: "", resource: "events"},
+	"kubernetes-hpa":                       {group: "autoscaling", resource: "horizontalpodautoscalers"},
+	"kubernetes-job":                       {group: "batch", resource: "jobs"},
+	"kubernetes-persistent-volumes-claims": {group: "", resource: "persistentvolumeclaims"},
+	"kubernetes-pods":                      {group: "", resource: "pods"},
+	"kubernetes-replication-controllers":   {group: "", resource: "replicationcontrollers"},
+	"kubernetes-resources-quota":           {group: "", resource: "resourcequotas"},
+	"kubernetes-secrets":                   {group: "", resource: "secrets"},
+	"kubernetes-service-accounts":          {group: "", resource: "serviceaccounts"},
+	"kubernetes-services":                  {group: "", resource: "services"},
+}
+
+var (
+	consumerVerbs = []string{"get", "list", "watch"}
+	producerVerbs = []string{"create", "delete", "get", "list", "patch", "update", "watch"}
+)
+
+func newServiceAccountTrait() Trait {
+	return &serviceAccountTrait{
+		BaseTrait: NewBaseTrait(serviceAccountTraitID, 800),
+	}
+}
+
+func (t *serviceAccountTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) || !e.IntegrationInRunningPhases() {
+		return false, nil
+	}
+
+	// The ServiceAccount set on the integration is left untouched
+	return e.Integration.Spec.ServiceAccountName == "", nil
+}
+
+func (t *serviceAccountTrait) Apply(e *Environment) error {
+	name := e.Integration.Name
+	serviceAccount := &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: e.Integration.Namespace,
+			Name:      name,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+	}
+	e.Resources.Add(serviceAccount)
+
+	if IsNilOrTrue(t.ComponentPermissions) {
+		rules, err := t.componentRules(e)
+		if err != nil {
+			return err
+		}
+		if len(rules) > 0 {
+			e.Resources.Add(&rbacv1.Role{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Role",
+					APIVersion: rbacv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: e.Integration.Namespace,
+					Name:      name,
+					Labels: map[string]string{
+						v1.IntegrationLabel: e.Integration.Name,
+					},
+				},
+				Rules: rules,
+			})
+			e.Resources.Add(&rbacv1.RoleBinding{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "RoleBinding",
+					APIVersion: rbacv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: e.Integration.Namespace,
+					Name:      name,
+					Labels: map[string]string{
+						v1.IntegrationLabel: e.Integration.Name,
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "Role",
+					Name:     name,
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:      rbacv1.ServiceAccountKind,
+						Namespace: e.Integration.Namespace,
+						Name:      name,
+					},
+				},
+			})
+		}
+	}
+
+	e.PostProcessors = append(e.PostProcessors, func(env *Environment) error {
+		// Only mount the token when the ServiceAccount is granted permissions, by this trait or the other ones
+		bound := false
+		env.Resources.Visit(func(obj runtime.Object) {
+			if binding, ok := obj.(*rbacv1.RoleBinding); ok {
+				for _, subject := range binding.Subjects {
+					if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == serviceAccount.Namespace &&
+						subject.Name == serviceAccount.Name {
+						bound = true
+					}
+				}
+			}
+		})
+		automount := bound
+		serviceAccount.AutomountServiceAccountToken = &automount
+		return nil
+	})
+
+	return nil
+}
+
+// componentRules returns the rules granting access to the resources of the Camel Kubernetes components used
+// by the integration, the producers being granted write access
+func (t *serviceAccountTrait) componentRules(e *Environment) ([]rbacv1.PolicyRule, error) {
+	sources, err := kubernetes.ResolveIntegrationSources(e.Ctx, t.Client, e.Integration, e.Resources)
+	if err != nil {
+		return nil, err
+	}
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+
+	verbs := make(map[kubernetesResource][]string)
+	for _, endpoint := range meta.FromURIs {
+		if r, ok := kubernetesComponentResources[uri.GetComponent(endpoint)]; ok && verbs[r] == nil {
+			verbs[r] = consumerVerbs
+		}
+	}
+	for _, endpoint := range meta.ToURIs {
+		if r, ok := kubernetesComponentResources[uri.GetComponent(endpoint)]; ok {
+			verbs[r] = producerVerbs
+		}
+	}
+
+	rules := make([]rbacv1.PolicyRule, 0, len(verbs))
+	for r, v := range verbs {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{r.group},
+			Resources: []string{r.resource},
+			Verbs:     v,
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].APIGroups[0] != rules[j].APIGroups[0] {
+			return rules[i].APIGroups[0] < rules[j].APIGroups[0]
+		}
+		return rules[i].Resources[0] < rules[j].Resources[0]
+	})
+
+	return rules, nil
+}
@@ -0,0 +1,194 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestServiceAccountWithComponentPermissions(t *testing.T) {
+	environment := createServiceAccountTestEnvironment(t,
+		`from("kubernetes-pods:cluster").to("kubernetes-config-maps:cluster?operation=createConfigMap")`, nil)
+
+	assert.Nil(t, environment.Catalog.apply(environment))
+	assert.NotNil(t, environment.GetTrait(serviceAccountTraitID))
+
+	serviceAccount, role, roleBinding := getServiceAccountResources(environment)
+	assert.NotNil(t, serviceAccount)
+	assert.Equal(t, ServiceTestName, serviceAccount.Name)
+	assert.True(t, *serviceAccount.AutomountServiceAccountToken)
+
+	assert.NotNil(t, role)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"create", "delete", "get", "list", "patch", "update", "watch"},
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}, role.Rules)
+
+	assert.NotNil(t, roleBinding)
+	assert.Equal(t, ServiceTestName, roleBinding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{
+		{Kind: rbacv1.ServiceAccountKind, Namespace: "ns", Name: ServiceTestName},
+	}, roleBinding.Subjects)
+
+	d := environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+	assert.Equal(t, ServiceTestName, d.Spec.Template.Spec.ServiceAccountName)
+}
+
+func TestServiceAccountWithoutPermissions(t *testing.T) {
+	environment := createServiceAccountTestEnvironment(t, `from("timer:tick").log("hello")`, nil)
+
+	assert.Nil(t, environment.Catalog.apply(environment))
+
+	serviceAccount, role, roleBinding := getServiceAccountResources(environment)
+	assert.NotNil(t, serviceAccount)
+	assert.False(t, *serviceAccount.AutomountServiceAccountToken)
+	assert.Nil(t, role)
+	assert.Nil(t, roleBinding)
+
+	d := environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+	assert.Equal(t, ServiceTestName, d.Spec.Template.Spec.ServiceAccountName)
+}
+
+func TestServiceAccountSetOnIntegration(t *testing.T) {
+	environment := createServiceAccountTestEnvironment(t, `from("kubernetes-pods:cluster").log("hello")`, nil)
+	environment.Integration.Spec.ServiceAccountName = "my-service-account"
+
+	assert.Nil(t, environment.Catalog.apply(environment))
+	assert.Nil(t, environment.GetTrait(serviceAccountTraitID))
+
+	serviceAccount, role, _ := getServiceAccountResources(environment)
+	assert.Nil(t, serviceAccount)
+	assert.Nil(t, role)
+
+	d := environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+	assert.Equal(t, "my-service-account", d.Spec.Template.Spec.ServiceAccountName)
+}
+
+func TestServiceAccountWithoutComponentPermissions(t *testing.T) {
+	environment := createServiceAccountTestEnvironment(t, `from("kubernetes-pods:cluster").log("hello")`,
+		map[string]interface{}{"componentPermissions": false})
+
+	assert.Nil(t, environment.Catalog.apply(environment))
+
+	serviceAccount, role, _ := getServiceAccountResources(environment)
+	assert.NotNil(t, serviceAccount)
+	assert.False(t, *serviceAccount.AutomountServiceAccountToken)
+	assert.Nil(t, role)
+}
+
+func createServiceAccountTestEnvironment(t *testing.T, route string, configuration map[string]interface{}) *Environment {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	traitConfiguration := map[string]interface{}{"enabled": true}
+	for k, v := range configuration {
+		traitConfiguration[k] = v
+	}
+
+	environment := &Environment{
+		CamelCatalog: catalog,
+		Catalog:      NewCatalog(nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ServiceTestName,
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.js",
+							Content: route,
+						},
+						Language: v1.LanguageJavaScript,
+					},
+				},
+				Traits: map[string]v1.TraitSpec{
+					"service-account": test.TraitSpecFromMap(t, traitConfiguration),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterOpenShift,
+				Build: v1.IntegrationPlatformBuildSpec{
+					PublishStrategy: v1.IntegrationPlatformBuildPublishStrategyS2I,
+					Registry:        v1.IntegrationPlatformRegistrySpec{Address: "registry"},
+				},
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      kubernetes.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	return environment
+}
+
+func getServiceAccountResources(e *Environment) (*corev1.ServiceAccount, *rbacv1.Role, *rbacv1.RoleBinding) {
+	var serviceAccount *corev1.ServiceAccount
+	var role *rbacv1.Role
+	var roleBinding *rbacv1.RoleBinding
+	e.Resources.Visit(func(obj runtime.Object) {
+		switch o := obj.(type) {
+		case *corev1.ServiceAccount:
+			serviceAccount = o
+		case *rbacv1.Role:
+			role = o
+		case *rbacv1.RoleBinding:
+			roleBinding = o
+		}
+	})
+	return serviceAccount, role, roleBinding
+}
@@ -18,10 +18,18 @@ limitations under the License.
 package trait
 
 import (
-	"github.com/apache/camel-k/pkg/util/reference"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	sb "github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
@@ -31,16 +39,50 @@ import (
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/handler/naming"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/reference"
 )
 
 // The Service Binding trait allows users to connect to Services in Kubernetes:
 // https://github.com/k8s-service-bindings/spec#service-binding
-// As the specification is still evolving this is subject to change
+// As the specification is still evolving this is subject to change.
+//
+// The bindings can either be collected with the Service Binding Operator logic, that relies on the binding
+// annotations of the services, or be projected natively by the operator, when the Service Binding Operator
+// is not installed. The native projection supports:
+//
+// * Secrets, that are bound as-is, and must provide at least the `type` entry
+// * Provisioned Services, i.e. resources that expose their binding Secret in the `status.binding.name` field
+// * Strimzi `Kafka` clusters (`kafka.strimzi.io`)
+// * Crunchy Data `PostgresCluster` clusters (`postgres-operator.crunchydata.com`)
+//
 // +camel-k:trait=service-binding
 type serviceBindingTrait struct {
 	BaseTrait `property:",squash"`
 	// List of Services in the form [[apigroup/]version:]kind:[namespace/]name
 	Services []string `property:"services" json:"services,omitempty"`
+	// List of Secrets, from the integration namespace, that are directly bound to the integration.
+	// Each Secret must follow the Service Binding specification, i.e. provide at least the `type` entry.
+	Secrets []string `property:"secrets" json:"secrets,omitempty"`
+	// How the bindings of the Services are projected into the integration, either `operator`, to rely on the
+	// Service Binding Operator logic, `native`, to project them natively, or `auto` (default), to project them
+	// natively only when the Service Binding Operator is not installed. How the bindings are projected is reported
+	// with the `ServiceBindingAvailable` condition of the integration.
+	Mode string `property:"mode" json:"mode,omitempty"`
+}
+
+const (
+	serviceBindingModeAuto     = "auto"
+	serviceBindingModeOperator = "operator"
+	serviceBindingModeNative   = "native"
+)
+
+// nativeBindings maps the kinds of the services supported by the native projection to the function
+// that computes their binding data
+var nativeBindings = map[schema.GroupKind]func(e *Environment, service *unstructured.Unstructured) (map[string][]byte, error){
+	{Group: "kafka.strimzi.io", Kind: "Kafka"}:                            strimziKafkaBinding,
+	{Group: "postgres-operator.crunchydata.com", Kind: "PostgresCluster"}: crunchyPostgresClusterBinding,
 }
 
 func newServiceBindingTrait() Trait {
@@ -54,14 +96,90 @@ func (t *serviceBindingTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
-	if len(t.Services) == 0 {
+	if len(t.Services) == 0 && len(t.Secrets) == 0 {
 		return false, nil
 	}
 
+	switch t.Mode {
+	case "", serviceBindingModeAuto, serviceBindingModeOperator, serviceBindingModeNative:
+	default:
+		return false, fmt.Errorf("unsupported service binding mode: %s, must be one of %s, %s or %s",
+			t.Mode, serviceBindingModeAuto, serviceBindingModeOperator, serviceBindingModeNative)
+	}
+
 	return e.IntegrationInPhase(v1.IntegrationPhaseInitialization) || e.IntegrationInRunningPhases(), nil
 }
 
 func (t *serviceBindingTrait) Apply(e *Environment) error {
+	if len(t.Services) > 0 {
+		native, err := t.isNative(e)
+		if err != nil {
+			return err
+		}
+		if native {
+			err = t.bindNatively(e)
+		} else {
+			err = t.bindWithOperator(e)
+		}
+		if err != nil {
+			return err
+		}
+		t.setCondition(e, native)
+	}
+
+	for _, name := range t.Secrets {
+		secret := &corev1.Secret{}
+		if err := e.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: name}, secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return fmt.Errorf("cannot bind secret %s: not found", name)
+			}
+			return err
+		}
+		if _, ok := secret.Data["type"]; !ok {
+			if _, ok := secret.StringData["type"]; !ok {
+				return fmt.Errorf("cannot bind secret %s: missing type entry", name)
+			}
+		}
+		bindSecret(e, name)
+	}
+
+	return nil
+}
+
+// isNative returns whether the bindings of the services are projected natively
+func (t *serviceBindingTrait) isNative(e *Environment) (bool, error) {
+	switch t.Mode {
+	case serviceBindingModeNative:
+		return true, nil
+	case serviceBindingModeOperator:
+		return false, nil
+	default:
+		installed, err := kubernetes.IsAPIResourceInstalled(e.Client, sb.GroupVersion.String(), sb.GroupVersionKind.Kind)
+		if err != nil {
+			return false, err
+		}
+		return !installed, nil
+	}
+}
+
+// setCondition reports how the bindings of the services are projected, so that the fallback to the native projection
+// in `auto` mode, when the Service Binding Operator is not installed, does not go unnoticed
+func (t *serviceBindingTrait) setCondition(e *Environment, native bool) {
+	if !native {
+		e.Integration.Status.SetCondition(v1.IntegrationConditionServiceBindingAvailable, corev1.ConditionTrue,
+			v1.IntegrationConditionServiceBindingOperatorReason, "services bound with the Service Binding Operator logic")
+		return
+	}
+	message := "services bound natively"
+	if t.Mode != serviceBindingModeNative {
+		message = "services bound natively, as the Service Binding Operator is not installed"
+		t.L.Infof("The Service Binding Operator is not installed, the services of integration %s are bound natively", e.Integration.Name)
+	}
+	e.Integration.Status.SetCondition(v1.IntegrationConditionServiceBindingAvailable, corev1.ConditionTrue,
+		v1.IntegrationConditionServiceBindingNativeReason, message)
+}
+
+func (t *serviceBindingTrait) bindWithOperator(e *Environment) error {
 	ctx, err := t.getContext(e)
 	if err != nil {
 		return err
@@ -75,13 +193,160 @@ func (t *serviceBindingTrait) Apply(e *Environment) error {
 	secret := createSecret(ctx, e.Integration.Namespace)
 	if secret != nil {
 		e.Resources.Add(secret)
-		e.ApplicationProperties["quarkus.kubernetes-service-binding.enabled"] = "true"
-		e.ApplicationProperties["SERVICE_BINDING_ROOT"] = serviceBindingsMountPath
-		e.ServiceBindingSecret = secret.GetName()
+		bindSecret(e, secret.GetName())
+	}
+	return nil
+}
+
+func (t *serviceBindingTrait) bindNatively(e *Environment) error {
+	services, err := t.parseServices(e.Integration.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, service := range services {
+		if err := t.bindServiceNatively(e, service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindServiceNatively binds the given service, either by mounting its binding Secret as-is when it's available
+// in the integration namespace, or by mounting a Secret generated from its binding data otherwise
+func (t *serviceBindingTrait) bindServiceNatively(e *Environment, service sb.Service) error {
+	gvk := schema.GroupVersionKind{Group: service.Group, Version: service.Version, Kind: service.Kind}
+	if gvk.Version == "" {
+		m, err := e.Client.RESTMapper().RESTMapping(gvk.GroupKind())
+		if err != nil {
+			return err
+		}
+		gvk = m.GroupVersionKind
+	}
+	namespace := *service.Namespace
+	ref := fmt.Sprintf("%s %s/%s", gvk.Kind, namespace, service.Name)
+
+	var secretName string
+	var data map[string][]byte
+	if gvk.Group == "" && gvk.Kind == "Secret" {
+		secretName = service.Name
+	} else {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := e.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: namespace, Name: service.Name}, obj); err != nil {
+			return errors.Wrapf(err, "cannot bind %s", ref)
+		}
+		if name, ok, _ := unstructured.NestedString(obj.Object, "status", "binding", "name"); ok && name != "" {
+			secretName = name
+		} else if binding, ok := nativeBindings[gvk.GroupKind()]; ok {
+			d, err := binding(e, obj)
+			if err != nil {
+				return errors.Wrapf(err, "cannot bind %s", ref)
+			}
+			data = d
+		} else {
+			return fmt.Errorf("cannot bind %s: neither a provisioned service nor a kind supported by the native service binding", ref)
+		}
+	}
+
+	if secretName != "" {
+		if namespace == e.Integration.Namespace {
+			bindSecret(e, secretName)
+			return nil
+		}
+		// Secrets cannot be mounted across namespaces, so their content is copied
+		secret, err := getSecretData(e, namespace, secretName)
+		if err != nil {
+			return errors.Wrapf(err, "cannot bind %s", ref)
+		}
+		data = secret
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: e.Integration.Namespace,
+			Name:      fmt.Sprintf("%s-%s", e.Integration.Name, strings.ToLower(service.Name)),
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Data: data,
 	}
+	e.Resources.Add(secret)
+	bindSecret(e, secret.Name)
 	return nil
 }
 
+// bindSecret mounts the given binding Secret, and enables the Quarkus service binding extension
+func bindSecret(e *Environment, name string) {
+	e.ApplicationProperties["quarkus.kubernetes-service-binding.enabled"] = "true"
+	e.ApplicationProperties["SERVICE_BINDING_ROOT"] = serviceBindingsMountPath
+	util.StringSliceUniqueAdd(&e.ServiceBindingSecrets, name)
+}
+
+func getSecretData(e *Environment, namespace string, name string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	if err := e.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	data := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for k, v := range secret.Data {
+		data[k] = v
+	}
+	for k, v := range secret.StringData {
+		data[k] = []byte(v)
+	}
+	return data, nil
+}
+
+// strimziKafkaBinding returns the bootstrap servers of the first listener of the Strimzi Kafka cluster
+func strimziKafkaBinding(_ *Environment, service *unstructured.Unstructured) (map[string][]byte, error) {
+	listeners, _, _ := unstructured.NestedSlice(service.Object, "status", "listeners")
+	for _, l := range listeners {
+		if listener, ok := l.(map[string]interface{}); ok {
+			if servers, ok := listener["bootstrapServers"].(string); ok && servers != "" {
+				return map[string][]byte{
+					"type":             []byte("kafka"),
+					"provider":         []byte("strimzi"),
+					"bootstrapServers": []byte(servers),
+				}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no listener with bootstrap servers found in the status")
+}
+
+// crunchyPostgresClusterBinding returns the connection data from the user Secret of the Crunchy Data PostgresCluster,
+// created for the default user, named after the cluster
+func crunchyPostgresClusterBinding(e *Environment, service *unstructured.Unstructured) (map[string][]byte, error) {
+	name := fmt.Sprintf("%s-pguser-%s", service.GetName(), service.GetName())
+	secret, err := getSecretData(e, service.GetNamespace(), name)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string][]byte{
+		"type":     []byte("postgresql"),
+		"provider": []byte("crunchydata"),
+	}
+	for key, entry := range map[string]string{
+		"host":     "host",
+		"port":     "port",
+		"database": "dbname",
+		"username": "user",
+		"password": "password",
+	} {
+		v, ok := secret[entry]
+		if !ok {
+			return nil, fmt.Errorf("missing %s entry in secret %s", entry, name)
+		}
+		data[key] = v
+	}
+	return data, nil
+}
+
 func (t *serviceBindingTrait) getContext(e *Environment) (pipeline.Context, error) {
 	services, err := t.parseServices(e.Integration.Namespace)
 	if err != nil {
@@ -0,0 +1,267 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigureServiceBindingTraitWithoutBindings(t *testing.T) {
+	environment := createServiceBindingTestEnvironment(t)
+	serviceBindingTrait := newServiceBindingTrait().(*serviceBindingTrait)
+
+	configured, err := serviceBindingTrait.Configure(environment)
+
+	assert.False(t, configured)
+	assert.Nil(t, err)
+}
+
+func TestConfigureServiceBindingTraitWithSecrets(t *testing.T) {
+	environment := createServiceBindingTestEnvironment(t)
+	serviceBindingTrait := newServiceBindingTrait().(*serviceBindingTrait)
+	serviceBindingTrait.Secrets = []string{"my-binding"}
+
+	configured, err := serviceBindingTrait.Configure(environment)
+	assert.True(t, configured)
+	assert.Nil(t, err)
+
+	serviceBindingTrait.Mode = "sidecar"
+	_, err = serviceBindingTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestApplyServiceBindingTraitWithSecrets(t *testing.T) {
+	environment := createServiceBindingTestEnvironment(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-binding",
+			Namespace: "ns",
+		},
+		Data: map[string][]byte{
+			"type": []byte("mysql"),
+		},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-credentials",
+			Namespace: "ns",
+		},
+	})
+	serviceBindingTrait := newServiceBindingTrait().(*serviceBindingTrait)
+	serviceBindingTrait.Secrets = []string{"my-binding"}
+
+	err := serviceBindingTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-binding"}, environment.ServiceBindingSecrets)
+	assert.Equal(t, "true", environment.ApplicationProperties["quarkus.kubernetes-service-binding.enabled"])
+	assert.Equal(t, serviceBindingsMountPath, environment.ApplicationProperties["SERVICE_BINDING_ROOT"])
+
+	serviceBindingTrait.Secrets = []string{"my-credentials"}
+	err = serviceBindingTrait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "cannot bind secret my-credentials: missing type entry", err.Error())
+
+	serviceBindingTrait.Secrets = []string{"my-tokens"}
+	err = serviceBindingTrait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "cannot bind secret my-tokens: not found", err.Error())
+}
+
+func TestApplyServiceBindingTraitNativelyWithProvisionedService(t *testing.T) {
+	service := &unstructured.Unstructured{}
+	service.SetAPIVersion("rabbitmq.com/v1beta1")
+	service.SetKind("RabbitmqCluster")
+	service.SetNamespace("ns")
+	service.SetName("my-broker")
+	assert.Nil(t, unstructured.SetNestedField(service.Object, "my-broker-default-user", "status", "binding", "name"))
+
+	environment := createServiceBindingTestEnvironment(t, service)
+	serviceBindingTrait := newServiceBindingTrait().(*serviceBindingTrait)
+	serviceBindingTrait.Services = []string{"rabbitmq.com/v1beta1:RabbitmqCluster:my-broker"}
+	serviceBindingTrait.Mode = serviceBindingModeNative
+
+	err := serviceBindingTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-broker-default-user"}, environment.ServiceBindingSecrets)
+	assert.Equal(t, 0, environment.Resources.Size())
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionServiceBindingAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, v1.IntegrationConditionServiceBindingNativeReason, condition.Reason)
+	assert.Equal(t, "services bound natively", condition.Message)
+}
+
+func TestApplyServiceBindingTraitNativelyWithSecretInOtherNamespace(t *testing.T) {
+	environment := createServiceBindingTestEnvironment(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-binding",
+			Namespace: "shared",
+		},
+		Data: map[string][]byte{
+			"type": []byte("mysql"),
+			"host": []byte("my-database.shared"),
+		},
+	})
+	serviceBindingTrait := newServiceBindingTrait().(*serviceBindingTrait)
+	serviceBindingTrait.Services = []string{"v1:Secret:shared/my-binding"}
+	serviceBindingTrait.Mode = serviceBindingModeNative
+
+	err := serviceBindingTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-integration-my-binding"}, environment.ServiceBindingSecrets)
+
+	secret := getServiceBindingSecret(environment, "my-integration-my-binding")
+	assert.NotNil(t, secret)
+	assert.Equal(t, "ns", secret.Namespace)
+	assert.Equal(t, "my-database.shared", string(secret.Data["host"]))
+}
+
+func TestApplyServiceBindingTraitNativelyWithStrimziKafka(t *testing.T) {
+	kafka := &unstructured.Unstructured{}
+	kafka.SetAPIVersion("kafka.strimzi.io/v1beta2")
+	kafka.SetKind("Kafka")
+	kafka.SetNamespace("ns")
+	kafka.SetName("my-cluster")
+	assert.Nil(t, unstructured.SetNestedSlice(kafka.Object, []interface{}{
+		map[string]interface{}{
+			"name":             "plain",
+			"bootstrapServers": "my-cluster-kafka-bootstrap.ns.svc:9092",
+		},
+	}, "status", "listeners"))
+
+	environment := createServiceBindingTestEnvironment(t, kafka)
+	serviceBindingTrait := newServiceBindingTrait().(*serviceBindingTrait)
+	serviceBindingTrait.Services = []string{"kafka.strimzi.io/v1beta2:Kafka:my-cluster"}
+
+	err := serviceBindingTrait.Apply(environment)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-integration-my-cluster"}, environment.ServiceBindingSecrets)
+	// The Service Binding Operator is not installed, so the fallback to the native projection is reported
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionServiceBindingAvailable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionServiceBindingNativeReason, condition.Reason)
+	assert.Equal(t, "services bound natively, as the Service Binding Operator is not installed", condition.Message)
+
+	secret := getServiceBindingSecret(environment, "my-integration-my-cluster")
+	assert.NotNil(t, secret)
+	assert.Equal(t, map[string][]byte{
+		"type":             []byte("kafka"),
+		"provider":         []byte("strimzi"),
+		"bootstrapServers": []byte("my-cluster-kafka-bootstrap.ns.svc:9092"),
+	}, secret.Data)
+	assert.Equal(t, "my-integration", secret.Labels[v1.IntegrationLabel])
+}
+
+func TestApplyServiceBindingTraitNativelyWithCrunchyPostgresCluster(t *testing.T) {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetAPIVersion("postgres-operator.crunchydata.com/v1beta1")
+	cluster.SetKind("PostgresCluster")
+	cluster.SetNamespace("ns")
+	cluster.SetName("hippo")
+
+	environment := createServiceBindingTestEnvironment(t, cluster, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hippo-pguser-hippo",
+			Namespace: "ns",
+		},
+		Data: map[string][]byte{
+			"host":     []byte("hippo-primary.ns.svc"),
+			"port":     []byte("5432"),
+			"dbname":   []byte("hippo"),
+			"user":     []byte("hippo"),
+			"password": []byte("secret"),
+		},
+	})
+	serviceBindingTrait := newServiceBindingTrait().(*serviceBindingTrait)
+	serviceBindingTrait.Services = []string{"postgres-operator.crunchydata.com/v1beta1:PostgresCluster:hippo"}
+	serviceBindingTrait.Mode = serviceBindingModeNative
+
+	err := serviceBindingTrait.Apply(environment)
+	assert.Nil(t, err)
+
+	secret := getServiceBindingSecret(environment, "my-integration-hippo")
+	assert.NotNil(t, secret)
+	assert.Equal(t, map[string][]byte{
+		"type":     []byte("postgresql"),
+		"provider": []byte("crunchydata"),
+		"host":     []byte("hippo-primary.ns.svc"),
+		"port":     []byte("5432"),
+		"database": []byte("hippo"),
+		"username": []byte("hippo"),
+		"password": []byte("secret"),
+	}, secret.Data)
+}
+
+func TestApplyServiceBindingTraitNativelyWithUnsupportedService(t *testing.T) {
+	service := &unstructured.Unstructured{}
+	service.SetAPIVersion("example.com/v1")
+	service.SetKind("Database")
+	service.SetNamespace("ns")
+	service.SetName("my-database")
+
+	environment := createServiceBindingTestEnvironment(t, service)
+	serviceBindingTrait := newServiceBindingTrait().(*serviceBindingTrait)
+	serviceBindingTrait.Services = []string{"example.com/v1:Database:my-database"}
+	serviceBindingTrait.Mode = serviceBindingModeNative
+
+	err := serviceBindingTrait.Apply(environment)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "cannot bind Database ns/my-database")
+}
+
+func createServiceBindingTestEnvironment(t *testing.T, objects ...runtime.Object) *Environment {
+	t.Helper()
+
+	c, err := test.NewFakeClient(objects...)
+	assert.Nil(t, err)
+
+	return &Environment{
+		Ctx:    context.TODO(),
+		Client: c,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-integration",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		ApplicationProperties: make(map[string]string),
+		Resources:             kubernetes.NewCollection(),
+	}
+}
+
+func getServiceBindingSecret(e *Environment, name string) *corev1.Secret {
+	for _, resource := range e.Resources.Items() {
+		if secret, ok := resource.(*corev1.Secret); ok && secret.Name == name {
+			return secret
+		}
+	}
+	return nil
+}
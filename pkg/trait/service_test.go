@@ -377,3 +377,127 @@ func TestServiceWithNodePort(t *testing.T) {
 
 	assert.Equal(t, corev1.ServiceTypeNodePort, s.Spec.Type)
 }
+
+func TestServiceWithAdditionalPorts(t *testing.T) {
+	environment := createServiceTestEnvironment(t, map[string]interface{}{
+		"enabled":      true,
+		"auto":         false,
+		"type":         "LoadBalancer",
+		"ports":        []string{"grpc:9000:9000", "jolokia:8778", "dns:53:5353/UDP"},
+		"appProtocols": []string{"http=http", "grpc=kubernetes.io/h2c"},
+		"annotations":  []string{"service.beta.kubernetes.io/aws-load-balancer-type=nlb"},
+	})
+
+	err := environment.Catalog.apply(environment)
+	assert.Nil(t, err)
+
+	s := environment.Resources.GetService(func(service *corev1.Service) bool {
+		return service.Name == ServiceTestName
+	})
+	assert.NotNil(t, s)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, s.Spec.Type)
+	assert.Equal(t, "nlb", s.Annotations["service.beta.kubernetes.io/aws-load-balancer-type"])
+
+	ports := make(map[string]corev1.ServicePort)
+	for _, p := range s.Spec.Ports {
+		ports[p.Name] = p
+	}
+	assert.Len(t, ports, 4)
+	assert.Equal(t, "http", *ports["http"].AppProtocol)
+	assert.Equal(t, int32(9000), ports["grpc"].Port)
+	assert.Equal(t, "grpc", ports["grpc"].TargetPort.StrVal)
+	assert.Equal(t, "kubernetes.io/h2c", *ports["grpc"].AppProtocol)
+	assert.Equal(t, int32(8778), ports["jolokia"].Port)
+	assert.Nil(t, ports["jolokia"].AppProtocol)
+	assert.Equal(t, corev1.ProtocolUDP, ports["dns"].Protocol)
+
+	d := environment.Resources.GetDeploymentForIntegration(environment.Integration)
+	assert.NotNil(t, d)
+	assert.ElementsMatch(t, []corev1.ContainerPort{
+		{Name: "http", ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+		{Name: "grpc", ContainerPort: 9000, Protocol: corev1.ProtocolTCP},
+		{Name: "dns", ContainerPort: 5353, Protocol: corev1.ProtocolUDP},
+	}, d.Spec.Template.Spec.Containers[0].Ports)
+}
+
+func TestServiceHeadless(t *testing.T) {
+	environment := createServiceTestEnvironment(t, map[string]interface{}{
+		"enabled":  true,
+		"auto":     false,
+		"headless": true,
+	})
+
+	err := environment.Catalog.apply(environment)
+	assert.Nil(t, err)
+
+	s := environment.Resources.GetService(func(service *corev1.Service) bool {
+		return service.Name == ServiceTestName
+	})
+	assert.NotNil(t, s)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, s.Spec.Type)
+	assert.Equal(t, corev1.ClusterIPNone, s.Spec.ClusterIP)
+	assert.True(t, s.Spec.PublishNotReadyAddresses)
+}
+
+func TestServiceWithInvalidConfiguration(t *testing.T) {
+	configurations := []map[string]interface{}{
+		{"auto": false, "ports": []string{"grpc"}},
+		{"auto": false, "ports": []string{"grpc:9000/HTTP"}},
+		{"auto": false, "type": "ExternalName"},
+		{"auto": false, "headless": true, "nodePort": true},
+		{"auto": false, "headless": true, "type": "LoadBalancer"},
+	}
+
+	for _, configuration := range configurations {
+		environment := createServiceTestEnvironment(t, configuration)
+		err := environment.Catalog.apply(environment)
+		assert.NotNil(t, err, "%v", configuration)
+	}
+}
+
+func createServiceTestEnvironment(t *testing.T, configuration map[string]interface{}) *Environment {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	environment := &Environment{
+		CamelCatalog: catalog,
+		Catalog:      NewCatalog(nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ServiceTestName,
+				Namespace: ServiceTestNamespace,
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+			Spec: v1.IntegrationSpec{
+				Profile: v1.TraitProfileKubernetes,
+				Traits: map[string]v1.TraitSpec{
+					"service": test.TraitSpecFromMap(t, configuration),
+				},
+			},
+		},
+		IntegrationKit: &v1.IntegrationKit{
+			Status: v1.IntegrationKitStatus{
+				Phase: v1.IntegrationKitPhaseReady,
+			},
+		},
+		Platform: &v1.IntegrationPlatform{
+			Spec: v1.IntegrationPlatformSpec{
+				Cluster: v1.IntegrationPlatformClusterOpenShift,
+				Build: v1.IntegrationPlatformBuildSpec{
+					PublishStrategy: v1.IntegrationPlatformBuildPublishStrategyS2I,
+					Registry:        v1.IntegrationPlatformRegistrySpec{Address: "registry"},
+				},
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      kubernetes.NewCollection(),
+	}
+	environment.Platform.ResyncStatusFullConfig()
+
+	return environment
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/telemetry"
 )
 
 func Apply(ctx context.Context, c client.Client, integration *v1.Integration, kit *v1.IntegrationKit) (*Environment, error) {
@@ -37,6 +38,10 @@ func Apply(ctx context.Context, c client.Client, integration *v1.Integration, ki
 		return nil, err
 	}
 
+	ctx, span := telemetry.StartSpan(ctx, "Apply traits")
+	environment.Ctx = ctx
+	defer span.End()
+
 	catalog := NewCatalog(c)
 
 	// set the catalog
@@ -18,6 +18,7 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"strings"
@@ -28,12 +29,15 @@ import (
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/telemetry"
 )
 
 // Catalog collects all information about traits in one place
 type Catalog struct {
 	L      log.Logger
 	traits []Trait
+	// The error of the traits ordering, reported when the traits are applied
+	err error
 }
 
 // NewCatalog creates a new trait Catalog
@@ -42,17 +46,16 @@ func NewCatalog(c client.Client) *Catalog {
 	for _, factory := range FactoryList {
 		traitList = append(traitList, factory())
 	}
-	sort.Slice(traitList, func(i, j int) bool {
-		if traitList[i].Order() != traitList[j].Order() {
-			return traitList[i].Order() < traitList[j].Order()
-		}
-		return string(traitList[i].ID()) < string(traitList[j].ID())
-	})
 
 	catalog := Catalog{
-		L:      log.Log.WithName("trait"),
-		traits: traitList,
+		L: log.Log.WithName("trait"),
 	}
+	sorted, err := sortTraits(traitList)
+	if err != nil {
+		catalog.L.Error(err, "Cannot order traits")
+		catalog.err = err
+	}
+	catalog.traits = sorted
 
 	for _, t := range catalog.AllTraits() {
 		if c != nil {
@@ -88,6 +91,9 @@ func (c *Catalog) TraitsForProfile(profile v1.TraitProfile) []Trait {
 }
 
 func (c *Catalog) apply(environment *Environment) error {
+	if c.err != nil {
+		return c.err
+	}
 	if err := c.configure(environment); err != nil {
 		return err
 	}
@@ -101,43 +107,165 @@ func (c *Catalog) apply(environment *Environment) error {
 			continue
 		}
 		applicable = true
-		enabled, err := trait.Configure(environment)
+		if err := c.applyTrait(environment, trait); err != nil {
+			return err
+		}
+	}
+
+	if !applicable && environment.Platform == nil {
+		return errors.New("no trait can be executed because of no integration platform found")
+	}
+
+	for _, processor := range environment.PostProcessors {
+		err := processor(environment)
+		if err != nil {
+			return errors.Wrap(err, "error executing post processor")
+		}
+	}
+
+	return nil
+}
+
+// applyTrait configures the trait, and applies it if enabled, within a span
+func (c *Catalog) applyTrait(environment *Environment, trait Trait) (err error) {
+	ctx := environment.Ctx
+	traitCtx, span := telemetry.StartSpan(ctx, "Trait "+string(trait.ID()), telemetry.TraitKey.String(string(trait.ID())))
+	if ctx != nil {
+		environment.Ctx = traitCtx
+	}
+	defer func() {
+		environment.Ctx = ctx
+		telemetry.EndSpan(span, err)
+	}()
+
+	enabled, err := trait.Configure(environment)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(telemetry.TraitEnabledKey.Bool(enabled))
+
+	if enabled {
+		c.L.Infof("Apply trait: %s", trait.ID())
+
+		err = trait.Apply(environment)
 		if err != nil {
 			return err
 		}
 
-		if enabled {
-			c.L.Infof("Apply trait: %s", trait.ID())
+		environment.ExecutedTraits = append(environment.ExecutedTraits, trait)
 
-			err = trait.Apply(environment)
+		// execute post step processors
+		for _, processor := range environment.PostStepProcessors {
+			err := processor(environment)
 			if err != nil {
-				return err
+				return errors.Wrap(err, "error executing post step action")
 			}
+		}
+	}
 
-			environment.ExecutedTraits = append(environment.ExecutedTraits, trait)
+	return nil
+}
+
+// sortTraits returns the traits in execution order, that satisfies the constraints declared by the traits,
+// the traits with no constraint between them being ordered by their order, then by their ID.
+// An error is returned when the constraints form a cycle, along with the traits sorted by order only.
+func sortTraits(traits []Trait) ([]Trait, error) {
+	less := func(a, b Trait) bool {
+		if a.Order() != b.Order() {
+			return a.Order() < b.Order()
+		}
+		return string(a.ID()) < string(b.ID())
+	}
+
+	byOrder := append([]Trait(nil), traits...)
+	sort.Slice(byOrder, func(i, j int) bool {
+		return less(byOrder[i], byOrder[j])
+	})
 
-			// execute post step processors
-			for _, processor := range environment.PostStepProcessors {
-				err := processor(environment)
-				if err != nil {
-					return errors.Wrap(err, "error executing post step action")
-				}
+	present := make(map[ID]bool, len(traits))
+	for _, t := range byOrder {
+		present[t.ID()] = true
+	}
+	// successors of each trait, i.e., the traits that must be executed after it
+	successors := make(map[ID][]ID, len(traits))
+	predecessors := make(map[ID]int, len(traits))
+	addEdge := func(from, to ID) {
+		if !present[from] || !present[to] {
+			return
+		}
+		successors[from] = append(successors[from], to)
+		predecessors[to]++
+	}
+	for _, t := range byOrder {
+		for _, id := range t.ExecutedAfter() {
+			addEdge(id, t.ID())
+		}
+		for _, id := range t.ExecutedBefore() {
+			addEdge(t.ID(), id)
+		}
+	}
+
+	sorted := make([]Trait, 0, len(byOrder))
+	pending := append([]Trait(nil), byOrder...)
+	for len(pending) > 0 {
+		// the pending traits are kept by order, so that the first one with no predecessor left is executed next
+		next := -1
+		for i, t := range pending {
+			if predecessors[t.ID()] == 0 {
+				next = i
+				break
 			}
 		}
+		if next < 0 {
+			return byOrder, fmt.Errorf("cycle detected in the traits ordering constraints: %s", findCycle(pending, successors))
+		}
+
+		t := pending[next]
+		sorted = append(sorted, t)
+		pending = append(pending[:next], pending[next+1:]...)
+		for _, id := range successors[t.ID()] {
+			predecessors[id]--
+		}
 	}
 
-	if !applicable && environment.Platform == nil {
-		return errors.New("no trait can be executed because of no integration platform found")
+	return sorted, nil
+}
+
+// findCycle returns the description of a cycle among the given traits, each of them having a predecessor
+// among them
+func findCycle(traits []Trait, successors map[ID][]ID) string {
+	remaining := make(map[ID]bool, len(traits))
+	for _, t := range traits {
+		remaining[t.ID()] = true
+	}
+	predecessors := make(map[ID][]ID)
+	for _, t := range traits {
+		for _, to := range successors[t.ID()] {
+			if remaining[to] {
+				predecessors[to] = append(predecessors[to], t.ID())
+			}
+		}
 	}
 
-	for _, processor := range environment.PostProcessors {
-		err := processor(environment)
-		if err != nil {
-			return errors.Wrap(err, "error executing post processor")
+	// walk the predecessors from any trait until a trait is visited twice
+	position := make(map[ID]int)
+	path := make([]ID, 0)
+	id := traits[0].ID()
+	for {
+		if i, ok := position[id]; ok {
+			path = append(path[i:], id)
+			break
 		}
+		position[id] = len(path)
+		path = append(path, id)
+		id = predecessors[id][0]
 	}
 
-	return nil
+	ids := make([]string, 0, len(path))
+	for i := len(path) - 1; i >= 0; i-- {
+		ids = append(ids, string(path[i]))
+	}
+	return strings.Join(ids, " -> ")
 }
 
 // GetTrait returns the trait with the given ID
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderTestTrait struct {
+	BaseTrait
+}
+
+func (t *orderTestTrait) Configure(e *Environment) (bool, error) {
+	return false, nil
+}
+
+func (t *orderTestTrait) Apply(e *Environment) error {
+	return nil
+}
+
+func newOrderTestTrait(id string, order int, constraints ...OrderConstraint) Trait {
+	return &orderTestTrait{
+		BaseTrait: NewBaseTrait(id, order, constraints...),
+	}
+}
+
+func traitIDs(traits []Trait) []ID {
+	ids := make([]ID, 0, len(traits))
+	for _, t := range traits {
+		ids = append(ids, t.ID())
+	}
+	return ids
+}
+
+func TestSortTraits(t *testing.T) {
+	sorted, err := sortTraits([]Trait{
+		newOrderTestTrait("d", 400),
+		newOrderTestTrait("c", 300),
+		newOrderTestTrait("b", 200),
+		newOrderTestTrait("a", 200),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []ID{"a", "b", "c", "d"}, traitIDs(sorted))
+
+	sorted, err = sortTraits([]Trait{
+		newOrderTestTrait("a", 100, ExecuteAfter("c")),
+		newOrderTestTrait("b", 200),
+		newOrderTestTrait("c", 300),
+		newOrderTestTrait("d", 400, ExecuteBefore("b"), ExecuteAfter("missing")),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []ID{"c", "a", "d", "b"}, traitIDs(sorted))
+}
+
+func TestSortTraitsWithCycle(t *testing.T) {
+	sorted, err := sortTraits([]Trait{
+		newOrderTestTrait("a", 100, ExecuteAfter("c")),
+		newOrderTestTrait("b", 200, ExecuteAfter("a")),
+		newOrderTestTrait("c", 300, ExecuteAfter("b")),
+		newOrderTestTrait("d", 400, ExecuteAfter("a")),
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "cycle detected in the traits ordering constraints: a -> b -> c -> a", err.Error())
+	assert.Equal(t, []ID{"a", "b", "c", "d"}, traitIDs(sorted))
+
+	_, err = sortTraits([]Trait{
+		newOrderTestTrait("a", 100, ExecuteAfter("a")),
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "cycle detected in the traits ordering constraints: a -> a", err.Error())
+}
+
+func TestCatalogWithCycle(t *testing.T) {
+	factories := FactoryList
+	defer func() { FactoryList = factories }()
+
+	AddToTraits(func() Trait { return newOrderTestTrait("first", 1, ExecuteAfter("last")) })
+	AddToTraits(func() Trait { return newOrderTestTrait("last", 3000, ExecuteAfter("first")) })
+
+	catalog := NewCatalog(nil)
+	err := catalog.apply(&Environment{Catalog: catalog})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "cycle detected in the traits ordering constraints")
+}
+
+func TestCatalogOrderConstraints(t *testing.T) {
+	catalog := NewCatalog(nil)
+	assert.Nil(t, catalog.err)
+
+	position := make(map[ID]int)
+	for i, t := range catalog.AllTraits() {
+		position[t.ID()] = i
+	}
+	for _, trait := range catalog.AllTraits() {
+		for _, id := range trait.ExecutedAfter() {
+			if p, ok := position[id]; ok {
+				assert.Less(t, p, position[trait.ID()], "%s must be executed after %s", trait.ID(), id)
+			}
+		}
+		for _, id := range trait.ExecutedBefore() {
+			if p, ok := position[id]; ok {
+				assert.Greater(t, p, position[trait.ID()], "%s must be executed before %s", trait.ID(), id)
+			}
+		}
+	}
+	assert.Less(t, position[serviceTraitID], position[containerTraitID])
+	assert.Less(t, position[containerTraitID], position["mount"])
+}
@@ -21,12 +21,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
+	"github.com/fatih/structs"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
 )
 
 func (c *Catalog) configure(env *Environment) error {
@@ -78,6 +81,95 @@ func (c *Catalog) configureTraits(traits map[string]v1.TraitSpec) error {
 	return nil
 }
 
+// ValidateTraits checks the traits configured by the given spec and annotations exist in the catalog,
+// and that their properties can be decoded. The traits of the catalog are configured as a side effect,
+// so a dedicated catalog must be used.
+func (c *Catalog) ValidateTraits(traits map[string]v1.TraitSpec, annotations map[string]string) error {
+	ids := make([]string, 0, len(traits))
+	for id := range traits {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		t := c.GetTrait(id)
+		if t == nil {
+			return fmt.Errorf("trait %s does not exist", id)
+		}
+		spec := traits[id]
+		if err := decodeTraitSpec(&spec, t); err != nil {
+			return errors.Wrapf(err, "invalid configuration for trait %s", id)
+		}
+	}
+
+	for k := range annotations {
+		if !strings.HasPrefix(k, v1.TraitAnnotationPrefix) {
+			continue
+		}
+		id := strings.SplitN(strings.TrimPrefix(k, v1.TraitAnnotationPrefix), ".", 2)[0]
+		if c.GetTrait(id) == nil {
+			return fmt.Errorf("trait %s configured by annotation %s does not exist", id, k)
+		}
+	}
+
+	return c.configureTraitsFromAnnotations(annotations)
+}
+
+// ConfiguredTraitProperties returns the trait properties, as <trait>.<property>, set by the given spec and annotations
+func (c *Catalog) ConfiguredTraitProperties(traits map[string]v1.TraitSpec, annotations map[string]string) ([]string, error) {
+	properties := make([]string, 0)
+
+	for id, spec := range traits {
+		data, err := json.Marshal(&spec.Configuration)
+		if err != nil {
+			return nil, err
+		}
+		config := make(map[string]interface{})
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, errors.Wrapf(err, "invalid configuration for trait %s", id)
+		}
+
+		// The spec is keyed by the JSON names of the properties
+		names := make(map[string]string)
+		if t := c.GetTrait(id); t != nil {
+			propertyNames(structs.Fields(t), names)
+		}
+		for key := range config {
+			name, ok := names[key]
+			if !ok {
+				name = key
+			}
+			util.StringSliceUniqueAdd(&properties, id+"."+name)
+		}
+	}
+
+	for k := range annotations {
+		if strings.HasPrefix(k, v1.TraitAnnotationPrefix) {
+			util.StringSliceUniqueAdd(&properties, strings.TrimPrefix(k, v1.TraitAnnotationPrefix))
+		}
+	}
+
+	sort.Strings(properties)
+	return properties, nil
+}
+
+// propertyNames maps the JSON names of the given trait fields to their property names
+func propertyNames(fields []*structs.Field, names map[string]string) {
+	for _, f := range fields {
+		if f.IsEmbedded() {
+			if f.IsExported() && f.Kind() == reflect.Struct {
+				propertyNames(f.Fields(), names)
+			}
+			continue
+		}
+		property := strings.Split(f.Tag("property"), ",")[0]
+		name := strings.Split(f.Tag("json"), ",")[0]
+		if property != "" && name != "" {
+			names[name] = property
+		}
+	}
+}
+
 func decodeTraitSpec(in *v1.TraitSpec, target interface{}) error {
 	data, err := json.Marshal(&in.Configuration)
 	if err != nil {
@@ -111,7 +203,11 @@ func (c *Catalog) configureTraitsFromAnnotations(annotations map[string]string)
 func (c *Catalog) configureFromOptions(traits map[string]map[string]string) error {
 	for id, config := range traits {
 		t := c.GetTrait(id)
-		if t != nil {
+		if dt, ok := t.(*declarativeTrait); ok {
+			if err := dt.configureFromOptions(config); err != nil {
+				return err
+			}
+		} else if t != nil {
 			err := configureTrait(id, config, t)
 			if err != nil {
 				return err
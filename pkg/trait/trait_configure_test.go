@@ -18,6 +18,8 @@ limitations under the License.
 package trait
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v2alpha1"
 	"github.com/apache/camel-k/pkg/util/test"
 )
 
@@ -166,3 +169,98 @@ func TestTraitSplitConfiguration(t *testing.T) {
 	assert.NoError(t, c.configure(&env))
 	assert.Equal(t, []string{"opt1", "opt2"}, c.GetTrait("owner").(*ownerTrait).TargetLabels)
 }
+
+func TestValidateTraits(t *testing.T) {
+	assert.NoError(t, NewCatalog(nil).ValidateTraits(map[string]v1.TraitSpec{
+		"cron": test.TraitSpecFromMap(t, map[string]interface{}{
+			"fallback": true,
+		}),
+	}, map[string]string{
+		"trait.camel.apache.org/environment.container-meta": "true",
+	}))
+
+	err := NewCatalog(nil).ValidateTraits(map[string]v1.TraitSpec{
+		"unknown": test.TraitSpecFromMap(t, map[string]interface{}{}),
+	}, nil)
+	assert.EqualError(t, err, "trait unknown does not exist")
+
+	err = NewCatalog(nil).ValidateTraits(map[string]v1.TraitSpec{
+		"cron": test.TraitSpecFromMap(t, map[string]interface{}{
+			"fallback": "yes",
+		}),
+	}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid configuration for trait cron")
+
+	err = NewCatalog(nil).ValidateTraits(nil, map[string]string{
+		"trait.camel.apache.org/unknown.enabled": "true",
+	})
+	assert.EqualError(t, err, "trait unknown configured by annotation trait.camel.apache.org/unknown.enabled does not exist")
+
+	err = NewCatalog(nil).ValidateTraits(nil, map[string]string{
+		"trait.camel.apache.org/cron.unknown": "true",
+	})
+	assert.Error(t, err)
+}
+
+func TestConfiguredTraitProperties(t *testing.T) {
+	properties, err := NewCatalog(nil).ConfiguredTraitProperties(map[string]v1.TraitSpec{
+		"container": test.TraitSpecFromMap(t, map[string]interface{}{
+			"limitCPU": "500m",
+			"image":    "quay.io/my-org/my-image",
+		}),
+		"istio": test.TraitSpecFromMap(t, map[string]interface{}{
+			"enabled": false,
+		}),
+	}, map[string]string{
+		"trait.camel.apache.org/pod.enabled": "true",
+		"camel.apache.org/requester":         "user",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"container.image", "container.limit-cpu", "istio.enabled", "pod.enabled"}, properties)
+}
+
+func TestTypedTraitsMatchCatalog(t *testing.T) {
+	typed := jsonFields(reflect.TypeOf(v2alpha1.Traits{}))
+
+	for _, trait := range NewCatalog(nil).AllTraits() {
+		id := string(trait.ID())
+		if id == "init" {
+			// internal trait, that is not meant to be configured
+			continue
+		}
+		field, ok := typed[id]
+		if !assert.True(t, ok, "trait %s has no typed configuration", id) {
+			continue
+		}
+
+		properties := jsonFields(reflect.TypeOf(trait).Elem())
+		typedProperties := jsonFields(field.Type.Elem())
+		for name, property := range properties {
+			typedProperty, ok := typedProperties[name]
+			if assert.True(t, ok, "property %s of trait %s has no typed configuration", name, id) {
+				assert.Equal(t, property.Type.Kind(), typedProperty.Type.Kind(), "property %s of trait %s", name, id)
+			}
+		}
+	}
+}
+
+// jsonFields returns the serialized fields of the given struct type, including the embedded ones, keyed by JSON name
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			for k, v := range jsonFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name != "" && name != "-" {
+			fields[name] = f
+		}
+	}
+	return fields
+}
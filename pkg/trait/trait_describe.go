@@ -0,0 +1,204 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/fatih/structs"
+	"gopkg.in/yaml.v2"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/policy"
+	"github.com/apache/camel-k/pkg/resources"
+)
+
+// Description describes a trait of the catalog, so that its configuration can be completed and validated,
+// e.g., by IDEs and UIs
+type Description struct {
+	Name     ID       `json:"name" yaml:"name"`
+	Platform bool     `json:"platform" yaml:"platform"`
+	Profiles []string `json:"profiles" yaml:"profiles"`
+	// Restricted is set when the trait is restricted by the policy of the platform
+	Restricted  bool                  `json:"restricted,omitempty" yaml:"restricted,omitempty"`
+	Properties  []PropertyDescription `json:"properties" yaml:"properties"`
+	Description string                `json:"description" yaml:"description"`
+}
+
+// PropertyDescription describes a configuration property of a trait
+type PropertyDescription struct {
+	Name         string      `json:"name" yaml:"name"`
+	TypeName     string      `json:"type" yaml:"type"`
+	DefaultValue interface{} `json:"defaultValue,omitempty" yaml:"defaultValue,omitempty"`
+	// Restricted is set when the property is restricted by the policy of the platform
+	Restricted  bool   `json:"restricted,omitempty" yaml:"restricted,omitempty"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// Describe returns the descriptions of the traits of the catalog, or of the traits with the given IDs,
+// in the order they are applied for each profile
+func (c *Catalog) Describe(ids ...ID) ([]Description, error) {
+	metadata := struct {
+		Traits []Description `yaml:"traits"`
+	}{}
+	if err := yaml.Unmarshal(resources.Resource("/traits.yaml"), &metadata); err != nil {
+		return nil, err
+	}
+
+	descriptions := make([]Description, 0)
+	index := make(map[ID]int)
+	for _, profile := range v1.AllTraitProfiles {
+		for _, t := range c.TraitsForProfile(profile) {
+			if len(ids) > 0 && !containsID(ids, t.ID()) {
+				continue
+			}
+
+			i, ok := index[t.ID()]
+			if !ok {
+				d := Description{
+					Name:     t.ID(),
+					Platform: t.IsPlatformTrait(),
+					Profiles: make([]string, 0),
+				}
+				var meta *Description
+				for j := range metadata.Traits {
+					if metadata.Traits[j].Name == t.ID() {
+						meta = &metadata.Traits[j]
+						d.Description = meta.Description
+						break
+					}
+				}
+				describeProperties(structs.Fields(t), &d.Properties, meta)
+				if dt, ok := t.(*declarativeTrait); ok {
+					dt.describe(&d)
+				}
+
+				i = len(descriptions)
+				index[t.ID()] = i
+				descriptions = append(descriptions, d)
+			}
+			descriptions[i].Profiles = append(descriptions[i].Profiles, string(profile))
+		}
+	}
+
+	for _, id := range ids {
+		if _, ok := index[id]; !ok {
+			return nil, fmt.Errorf("no trait named '%s' exists", id)
+		}
+	}
+
+	return descriptions, nil
+}
+
+// RestrictDescriptions marks the traits, and the trait properties, restricted by the policy of the platform
+// of the given namespace, if any
+func RestrictDescriptions(ctx context.Context, c client.Client, namespace string, descriptions []Description) error {
+	p, err := platform.GetOrFind(ctx, c, namespace, "", true)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	restrictDescriptions(descriptions, p.Status.Policy.RestrictedTraits)
+	return nil
+}
+
+func restrictDescriptions(descriptions []Description, restricted []string) {
+	for i := range descriptions {
+		d := &descriptions[i]
+		d.Restricted = policy.IsRestricted(restricted, string(d.Name))
+		for j := range d.Properties {
+			d.Properties[j].Restricted = d.Restricted || policy.IsRestricted(restricted, string(d.Name)+"."+d.Properties[j].Name)
+		}
+	}
+}
+
+// HasProfile returns true if the trait applies to the given profile
+func (d Description) HasProfile(profile v1.TraitProfile) bool {
+	for _, p := range d.Profiles {
+		if p == string(profile) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeProperties(fields []*structs.Field, properties *[]PropertyDescription, meta *Description) {
+	for _, f := range fields {
+		if f.IsEmbedded() && f.IsExported() && f.Kind() == reflect.Struct {
+			describeProperties(f.Fields(), properties, meta)
+		}
+
+		if !f.IsExported() || f.IsEmbedded() {
+			continue
+		}
+
+		property := f.Tag("property")
+		if property == "" {
+			continue
+		}
+
+		p := PropertyDescription{
+			Name: property,
+		}
+
+		switch f.Kind() {
+		case reflect.Ptr:
+			p.TypeName = reflect.TypeOf(f.Value()).Elem().String()
+		case reflect.Slice:
+			p.TypeName = fmt.Sprintf("slice:%s", reflect.TypeOf(f.Value()).Elem().String())
+		default:
+			p.TypeName = f.Kind().String()
+		}
+
+		if f.IsZero() {
+			if p.TypeName == "bool" {
+				p.DefaultValue = false
+			}
+		} else {
+			p.DefaultValue = f.Value()
+		}
+
+		// apply the description from metadata
+		if meta != nil {
+			for _, item := range meta.Properties {
+				if item.Name == p.Name {
+					p.Description = item.Description
+				}
+			}
+		}
+
+		*properties = append(*properties, p)
+	}
+}
+
+func containsID(ids []ID, id ID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
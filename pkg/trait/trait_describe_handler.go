@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"encoding/json"
+	"net/http"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// NewDescribeHandler returns an HTTP handler that renders, in JSON format, the descriptions of the traits of the
+// catalog. The descriptions can be restricted to the traits given with the `trait` query parameter, that can be
+// repeated, or to the ones of the profile given with the `profile` query parameter. The traits and properties
+// restricted by the platform of the namespace given with the `namespace` query parameter are marked as such, in which
+// case the requests must be authenticated with a bearer token, whose user is authorized to get the platform.
+func NewDescribeHandler(c client.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		ids := make([]ID, 0, len(query["trait"]))
+		for _, id := range query["trait"] {
+			ids = append(ids, ID(id))
+		}
+
+		descriptions, err := NewCatalog(c).Describe(ids...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if namespace := query.Get("namespace"); namespace != "" {
+			p, err := platform.GetOrFind(r.Context(), c, namespace, "", true)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			// The user must be authorized to read the platform whose policy is disclosed
+			platformNamespace, platformName := namespace, ""
+			if err == nil {
+				platformNamespace, platformName = p.Namespace, p.Name
+			}
+			if !kubernetes.AuthorizeRequest(w, r, c, v1.SchemeGroupVersion.Group, "integrationplatforms", platformNamespace, platformName, "get") {
+				return
+			}
+			if err == nil {
+				restrictDescriptions(descriptions, p.Status.Policy.RestrictedTraits)
+			}
+		}
+
+		if name := query.Get("profile"); name != "" {
+			profile := v1.TraitProfileByName(name)
+			if profile == "" {
+				http.Error(w, "unknown profile: "+name, http.StatusBadRequest)
+				return
+			}
+			filtered := make([]Description, 0, len(descriptions))
+			for _, d := range descriptions {
+				if d.HasProfile(profile) {
+					filtered = append(filtered, d)
+				}
+			}
+			descriptions = filtered
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(descriptions); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestDescribe(t *testing.T) {
+	descriptions, err := NewCatalog(nil).Describe(containerTraitID, "route")
+	require.NoError(t, err)
+	require.Len(t, descriptions, 2)
+
+	container := descriptions[0]
+	assert.Equal(t, ID(containerTraitID), container.Name)
+	assert.True(t, container.Platform)
+	assert.Equal(t, []string{"Kubernetes", "Knative", "OpenShift"}, container.Profiles)
+	assert.NotEmpty(t, container.Description)
+
+	properties := make(map[string]PropertyDescription)
+	for _, p := range container.Properties {
+		properties[p.Name] = p
+	}
+	assert.Equal(t, "bool", properties["enabled"].TypeName)
+	assert.Equal(t, false, properties["enabled"].DefaultValue)
+	assert.Equal(t, "string", properties["image"].TypeName)
+	assert.NotEmpty(t, properties["image"].Description)
+
+	assert.Equal(t, []string{"OpenShift"}, descriptions[1].Profiles)
+
+	_, err = NewCatalog(nil).Describe("foobar")
+	assert.EqualError(t, err, "no trait named 'foobar' exists")
+}
+
+func TestDescribeHandler(t *testing.T) {
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Policy.RestrictedTraits = []string{"istio", "container.image"}
+	c, err := test.NewFakeClient(&pl)
+	require.NoError(t, err)
+	// alice is authorized to get the platform of the ns namespace, bob is not
+	test.AuthorizeUsers(c, func(user string, attributes authorizationv1.ResourceAttributes) bool {
+		return user == "alice" && attributes.Resource == "integrationplatforms" && attributes.Verb == "get" &&
+			attributes.Namespace == "ns" && attributes.Name == "camel-k"
+	}, "alice", "bob")
+
+	handler := NewDescribeHandler(c)
+	serve := func(target string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// The catalog is public, as long as the platform restrictions are not requested
+	recorder := serve("/traits?profile=openshift", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var descriptions []Description
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &descriptions))
+	for _, d := range descriptions {
+		assert.True(t, d.HasProfile(v1.TraitProfileOpenShift), d.Name)
+		assert.False(t, d.Restricted, d.Name)
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("/traits?namespace=ns&trait=istio", "").Code)
+	assert.Equal(t, http.StatusForbidden, serve("/traits?namespace=ns&trait=istio", "bob-token").Code)
+	assert.Equal(t, http.StatusForbidden, serve("/traits?namespace=other&trait=istio", "alice-token").Code)
+
+	recorder = serve("/traits?namespace=ns&trait=istio&trait=container", "alice-token")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	descriptions = nil
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &descriptions))
+	require.Len(t, descriptions, 2)
+	for _, d := range descriptions {
+		assert.Equal(t, d.Name == "istio", d.Restricted, d.Name)
+		for _, p := range d.Properties {
+			assert.Equal(t, d.Name == "istio" || p.Name == "image", p.Restricted, p.Name)
+		}
+	}
+
+	assert.Equal(t, http.StatusNotFound, serve("/traits?trait=foobar", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("/traits?profile=foobar", "").Code)
+}
@@ -23,16 +23,22 @@ func init() {
 	AddToTraits(newAffinityTrait)
 	AddToTraits(newBuilderTrait)
 	AddToTraits(newCamelTrait)
+	AddToTraits(newChaosTrait)
 	AddToTraits(newContainerTrait)
 	AddToTraits(newCronTrait)
 	AddToTraits(newDependenciesTrait)
 	AddToTraits(newDeployerTrait)
 	AddToTraits(newDeploymentTrait)
+	AddToTraits(newDNSTrait)
 	AddToTraits(newEnvironmentTrait)
 	AddToTraits(newErrorHandlerTrait)
+	AddToTraits(newFeatureFlagsTrait)
 	AddToTraits(newGarbageCollectorTrait)
+	AddToTraits(newGrafanaTrait)
+	AddToTraits(newHpaTrait)
 	AddToTraits(newIngressTrait)
 	AddToTraits(newIstioTrait)
+	AddToTraits(newJdbcTrait)
 	AddToTraits(newJolokiaTrait)
 	AddToTraits(newJvmTrait)
 	AddToTraits(newKameletsTrait)
@@ -40,17 +46,24 @@ func init() {
 	AddToTraits(newKnativeServiceTrait)
 	AddToTraits(newLoggingTraitTrait)
 	AddToTraits(newInitTrait)
+	AddToTraits(newMountTrait)
+	AddToTraits(newNodeTrait)
 	AddToTraits(newOpenAPITrait)
 	AddToTraits(newOwnerTrait)
+	AddToTraits(newPatchTrait)
 	AddToTraits(newPdbTrait)
+	AddToTraits(newPersistentStateTrait)
 	AddToTraits(newPlatformTrait)
 	AddToTraits(newPodTrait)
+	AddToTraits(newPreflightTrait)
 	AddToTraits(newPrometheusTrait)
 	AddToTraits(newPullSecretTrait)
 	AddToTraits(newQuarkusTrait)
 	AddToTraits(newRouteTrait)
 	AddToTraits(newServiceTrait)
+	AddToTraits(newServiceAccountTrait)
 	AddToTraits(newServiceBindingTrait)
 	AddToTraits(newTolerationTrait)
+	AddToTraits(newVpaTrait)
 	// ^^ Declaration order is not important, but let's keep them sorted for debugging.
 }
@@ -49,14 +49,15 @@ const (
 )
 
 var (
-	basePath                  = "/etc/camel"
-	confDPath                 = path.Join(basePath, "conf.d")
-	sourcesMountPath          = path.Join(basePath, "sources")
-	resourcesDefaultMountPath = path.Join(basePath, "resources")
-	configResourcesMountPath  = path.Join(confDPath, "_resources")
-	configConfigmapsMountPath = path.Join(confDPath, "_configmaps")
-	configSecretsMountPath    = path.Join(confDPath, "_secrets")
-	serviceBindingsMountPath  = path.Join(confDPath, "_servicebindings")
+	basePath                      = "/etc/camel"
+	confDPath                     = path.Join(basePath, "conf.d")
+	sourcesMountPath              = path.Join(basePath, "sources")
+	resourcesDefaultMountPath     = path.Join(basePath, "resources")
+	configResourcesMountPath      = path.Join(confDPath, "_resources")
+	configConfigmapsMountPath     = path.Join(confDPath, "_configmaps")
+	configSecretsMountPath        = path.Join(confDPath, "_secrets")
+	serviceBindingsMountPath      = path.Join(confDPath, "_servicebindings")
+	reloadablePropertiesMountPath = path.Join(confDPath, "_reloadable")
 )
 
 // Identifiable represent an identifiable type
@@ -92,6 +93,12 @@ type Trait interface {
 
 	// Order is the order in which the trait should be executed in the normal flow
 	Order() int
+
+	// ExecutedAfter returns the traits that must be executed before the trait, regardless of their order
+	ExecutedAfter() []ID
+
+	// ExecutedBefore returns the traits that must be executed after the trait, regardless of their order
+	ExecutedBefore() []ID
 }
 
 type Comparable interface {
@@ -116,12 +123,36 @@ const (
 	TraitOrderPostProcessResources = 2450
 )
 
-func NewBaseTrait(id string, order int) BaseTrait {
-	return BaseTrait{
+// OrderConstraint declares the ordering of a trait relative to other traits. The constraints on the traits that
+// are not in the catalog are ignored, so that traits can declare constraints on optional addon traits.
+type OrderConstraint func(*BaseTrait)
+
+// ExecuteAfter declares the traits that must be executed before the trait
+func ExecuteAfter(ids ...ID) OrderConstraint {
+	return func(trait *BaseTrait) {
+		trait.AfterTraits = append(trait.AfterTraits, ids...)
+	}
+}
+
+// ExecuteBefore declares the traits that must be executed after the trait
+func ExecuteBefore(ids ...ID) OrderConstraint {
+	return func(trait *BaseTrait) {
+		trait.BeforeTraits = append(trait.BeforeTraits, ids...)
+	}
+}
+
+// NewBaseTrait creates the base of a trait, executed according to the given constraints, and to the given order
+// relative to the traits it has no constraint with
+func NewBaseTrait(id string, order int, constraints ...OrderConstraint) BaseTrait {
+	trait := BaseTrait{
 		TraitID:        ID(id),
 		ExecutionOrder: order,
 		L:              log.Log.WithName("traits").WithValues("trait", id),
 	}
+	for _, constraint := range constraints {
+		constraint(&trait)
+	}
+	return trait
 }
 
 // BaseTrait is the root trait with noop implementations for hooks
@@ -131,6 +162,8 @@ type BaseTrait struct {
 	Enabled        *bool         `property:"enabled" json:"enabled,omitempty"`
 	Client         client.Client `json:"-"`
 	ExecutionOrder int           `json:"-"`
+	AfterTraits    []ID          `json:"-"`
+	BeforeTraits   []ID          `json:"-"`
 	L              log.Logger    `json:"-"`
 }
 
@@ -170,6 +203,16 @@ func (trait *BaseTrait) Order() int {
 	return trait.ExecutionOrder
 }
 
+// ExecutedAfter returns the traits that must be executed before the trait
+func (trait *BaseTrait) ExecutedAfter() []ID {
+	return trait.AfterTraits
+}
+
+// ExecutedBefore returns the traits that must be executed after the trait
+func (trait *BaseTrait) ExecutedBefore() []ID {
+	return trait.BeforeTraits
+}
+
 // ControllerStrategySelector is the interface for traits that can determine the kind of controller that will run the integration.
 type ControllerStrategySelector interface {
 	// SelectControllerStrategy tells if the trait with current configuration can select a specific controller to use
@@ -178,6 +221,21 @@ type ControllerStrategySelector interface {
 	ControllerStrategySelectorOrder() int
 }
 
+// ControllerStrategyExplainer is the interface for the controller strategy selectors that can explain their selection.
+type ControllerStrategyExplainer interface {
+	// ExplainControllerStrategy returns the reason and the message explaining why the trait selected the given controller strategy
+	ExplainControllerStrategy(*Environment, ControllerStrategy) (string, string)
+}
+
+// ControllerStrategySelection is the controller strategy selected for the integration, along with the explanation of the selection
+type ControllerStrategySelection struct {
+	Strategy ControllerStrategy
+	// The trait that selected the controller strategy, empty when the default controller strategy is used
+	Trait   ID
+	Reason  string
+	Message string
+}
+
 // An Environment provides the context for the execution of the traits
 type Environment struct {
 	CamelCatalog   *camel.RuntimeCatalog
@@ -204,9 +262,10 @@ type Environment struct {
 	ConfiguredTraits      []Trait
 	ExecutedTraits        []Trait
 	EnvVars               []corev1.EnvVar
+	EnvFrom               []corev1.EnvFromSource
 	ApplicationProperties map[string]string
 	Interceptors          []string
-	ServiceBindingSecret  string
+	ServiceBindingSecrets []string
 }
 
 // ControllerStrategy is used to determine the kind of controller that needs to be created for the integration
@@ -215,12 +274,31 @@ type ControllerStrategy string
 // List of controller strategies
 const (
 	ControllerStrategyDeployment     ControllerStrategy = "deployment"
+	ControllerStrategyStatefulSet    ControllerStrategy = "stateful-set"
 	ControllerStrategyKnativeService ControllerStrategy = "knative-service"
 	ControllerStrategyCronJob        ControllerStrategy = "cron-job"
 
 	DefaultControllerStrategy = ControllerStrategyDeployment
 )
 
+// ControllerStrategies contains the supported controller strategies
+var ControllerStrategies = []ControllerStrategy{
+	ControllerStrategyDeployment,
+	ControllerStrategyStatefulSet,
+	ControllerStrategyKnativeService,
+	ControllerStrategyCronJob,
+}
+
+// IsValidControllerStrategy returns whether the given controller strategy is supported
+func IsValidControllerStrategy(strategy ControllerStrategy) bool {
+	for _, s := range ControllerStrategies {
+		if s == strategy {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Environment) GetTrait(id ID) Trait {
 	for _, t := range e.ExecutedTraits {
 		if t.ID() == id {
@@ -231,6 +309,15 @@ func (e *Environment) GetTrait(id ID) Trait {
 	return nil
 }
 
+// GetIntegrationServiceAccountName returns the name of the ServiceAccount the integration pods run as, that is
+// either the one set on the Integration, or the one generated by the service-account trait
+func (e *Environment) GetIntegrationServiceAccountName() string {
+	if _, ok := e.GetTrait(serviceAccountTraitID).(*serviceAccountTrait); ok {
+		return e.Integration.Name
+	}
+	return e.Integration.Spec.ServiceAccountName
+}
+
 func (e *Environment) IntegrationInPhase(phases ...v1.IntegrationPhase) bool {
 	if e.Integration == nil {
 		return false
@@ -292,6 +379,11 @@ func (e *Environment) DetermineProfile() v1.TraitProfile {
 	return v1.DefaultTraitProfile
 }
 
+// fipsEnabled returns whether the Integration runs on a platform in FIPS mode
+func (e *Environment) fipsEnabled() bool {
+	return e.Platform != nil && e.Platform.Status.FIPS
+}
+
 // DetermineControllerStrategy determines the type of controller that should be used for the integration
 func (e *Environment) DetermineControllerStrategy() (ControllerStrategy, error) {
 	defaultStrategy := DefaultControllerStrategy
@@ -306,6 +398,58 @@ func (e *Environment) DetermineControllerStrategy() (ControllerStrategy, error)
 	return defaultStrategy, nil
 }
 
+// ExplainControllerStrategy determines the type of controller that should be used for the integration, and explains
+// why it has been selected. When the controller strategy is explicitly selected, the explanation also reports the controller
+// strategy that would have been selected otherwise, if it differs.
+func (e *Environment) ExplainControllerStrategy() (ControllerStrategySelection, error) {
+	var selection *ControllerStrategySelection
+	for _, selector := range e.getControllerStrategyChoosers() {
+		strategy, err := selector.SelectControllerStrategy(e)
+		if err != nil {
+			return ControllerStrategySelection{Strategy: DefaultControllerStrategy}, err
+		} else if strategy == nil {
+			continue
+		}
+		current := explainControllerStrategy(e, selector, *strategy)
+		if selection == nil {
+			selection = &current
+			if current.Reason != v1.IntegrationConditionControllerStrategyExplicitReason {
+				break
+			}
+			continue
+		}
+		if current.Strategy != selection.Strategy && current.Reason != v1.IntegrationConditionControllerStrategyDefaultReason {
+			selection.Message += fmt.Sprintf(", overriding the %s controller strategy selected by the %s trait (%s)",
+				current.Strategy, current.Trait, current.Message)
+		}
+		break
+	}
+	if selection == nil {
+		return ControllerStrategySelection{
+			Strategy: DefaultControllerStrategy,
+			Reason:   v1.IntegrationConditionControllerStrategyDefaultReason,
+			Message:  "no trait selected a controller strategy",
+		}, nil
+	}
+
+	return *selection, nil
+}
+
+func explainControllerStrategy(e *Environment, selector ControllerStrategySelector, strategy ControllerStrategy) ControllerStrategySelection {
+	selection := ControllerStrategySelection{
+		Strategy: strategy,
+		Reason:   v1.IntegrationConditionControllerStrategyTraitReason,
+	}
+	if t, ok := selector.(Trait); ok {
+		selection.Trait = t.ID()
+		selection.Message = fmt.Sprintf("selected by the %s trait configuration", t.ID())
+	}
+	if explainer, ok := selector.(ControllerStrategyExplainer); ok {
+		selection.Reason, selection.Message = explainer.ExplainControllerStrategy(e, strategy)
+	}
+	return selection
+}
+
 func (e *Environment) getControllerStrategyChoosers() (res []ControllerStrategySelector) {
 	for _, t := range e.ConfiguredTraits {
 		if cc, ok := t.(ControllerStrategySelector); ok {
@@ -328,6 +472,14 @@ func (e *Environment) GetIntegrationPodSpec() *corev1.PodSpec {
 		return &deployment.Spec.Template.Spec
 	}
 
+	// StatefulSet
+	statefulSet := e.Resources.GetStatefulSet(func(s *appsv1.StatefulSet) bool {
+		return s.Name == e.Integration.Name
+	})
+	if statefulSet != nil {
+		return &statefulSet.Spec.Template.Spec
+	}
+
 	// Knative service
 	knativeService := e.Resources.GetKnativeService(func(s *serving.Service) bool {
 		return s.Name == e.Integration.Name
@@ -451,29 +603,14 @@ func (e *Environment) configureVolumesAndMounts(vols *[]corev1.Volume, mnts *[]c
 		if s.ContentRef != "" {
 			cmName = s.ContentRef
 		}
-		cmKey := "content"
-		if s.ContentKey != "" {
-			cmKey = s.ContentKey
-		}
+		cmKey := s.GetContentKey()
 		resName := strings.TrimPrefix(s.Name, "/")
 		refName := fmt.Sprintf("i-source-%03d", i)
 		resPath := path.Join(sourcesMountPath, resName)
 
 		*vols = append(*vols, corev1.Volume{
-			Name: refName,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: cmName,
-					},
-					Items: []corev1.KeyToPath{
-						{
-							Key:  cmKey,
-							Path: resName,
-						},
-					},
-				},
-			},
+			Name:         refName,
+			VolumeSource: dataVolumeSource(s.DataSpec, cmName, cmKey, resName),
 		})
 
 		*mnts = append(*mnts, corev1.VolumeMount{
@@ -492,34 +629,19 @@ func (e *Environment) configureVolumesAndMounts(vols *[]corev1.Volume, mnts *[]c
 		cmName := fmt.Sprintf("%s-resource-%03d", e.Integration.Name, i)
 		refName := fmt.Sprintf("i-resource-%03d", i)
 		resName := strings.TrimPrefix(r.Name, "/")
-		cmKey := "content"
+		cmKey := r.GetContentKey()
 		resPath := getResourcePath(resName, r.Path, r.Type)
 
 		if r.ContentRef != "" {
 			cmName = r.ContentRef
 		}
-		if r.ContentKey != "" {
-			cmKey = r.ContentKey
-		}
 		if r.MountPath != "" {
 			resPath = r.MountPath
 		}
 
 		*vols = append(*vols, corev1.Volume{
-			Name: refName,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: cmName,
-					},
-					Items: []corev1.KeyToPath{
-						{
-							Key:  cmKey,
-							Path: resName,
-						},
-					},
-				},
-			},
+			Name:         refName,
+			VolumeSource: dataVolumeSource(r.DataSpec, cmName, cmKey, resName),
 		})
 
 		*mnts = append(*mnts, corev1.VolumeMount{
@@ -571,6 +693,37 @@ func (e *Environment) configureVolumesAndMounts(vols *[]corev1.Volume, mnts *[]c
 		})
 	}
 
+	if e.Resources != nil {
+		e.Resources.VisitSecret(func(secret *corev1.Secret) {
+			if secret.Labels["camel.apache.org/properties.type"] != resolvedPropertiesType {
+				return
+			}
+			resName := resolvedPropertiesType + ".properties"
+
+			*vols = append(*vols, corev1.Volume{
+				Name: resolvedPropertiesType + "-properties",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: secret.Name,
+						Items: []corev1.KeyToPath{
+							{
+								Key:  "application.properties",
+								Path: resName,
+							},
+						},
+					},
+				},
+			})
+
+			*mnts = append(*mnts, corev1.VolumeMount{
+				Name:      resolvedPropertiesType + "-properties",
+				MountPath: path.Join(confDPath, resName),
+				ReadOnly:  true,
+				SubPath:   resName,
+			})
+		})
+	}
+
 	//
 	// Volumes :: Additional ConfigMaps
 	//
@@ -611,8 +764,7 @@ func (e *Environment) configureVolumesAndMounts(vols *[]corev1.Volume, mnts *[]c
 	// Volumes :: Additional Secrets
 	//
 	// append Service Binding secrets
-	if len(e.ServiceBindingSecret) > 0 {
-		secret := e.ServiceBindingSecret
+	for _, secret := range e.ServiceBindingSecrets {
 		refName := kubernetes.SanitizeLabel(secret)
 
 		*vols = append(*vols, corev1.Volume{
@@ -792,3 +944,32 @@ func (e *Environment) getAllInterceptors() []string {
 	}
 	return res
 }
+
+// dataVolumeSource returns the volume source projecting the given key, of the ConfigMap, or of the Secret
+// the data is referenced from, to the given path
+func dataVolumeSource(data v1.DataSpec, name string, key string, path string) corev1.VolumeSource {
+	items := []corev1.KeyToPath{
+		{
+			Key:  key,
+			Path: path,
+		},
+	}
+
+	if data.ContentRef != "" && data.GetContentRefKind() == v1.ContentRefKindSecret {
+		return corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: name,
+				Items:      items,
+			},
+		}
+	}
+
+	return corev1.VolumeSource{
+		ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: name,
+			},
+			Items: items,
+		},
+	}
+}
@@ -33,8 +33,11 @@ import (
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/policy"
 	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/dsl"
+	"github.com/apache/camel-k/pkg/util/gzip"
 	"github.com/apache/camel-k/pkg/util/property"
 )
 
@@ -163,6 +166,24 @@ func keyValuePairArrayAsStringMap(pairs []string) (map[string]string, error) {
 	return m, nil
 }
 
+var qualifiedKeyValuePairRegexp = regexp.MustCompile(`^([\w.\-/]+)=(.*)$`)
+
+// qualifiedKeyValuePairArrayAsStringMap parses key/value pairs whose keys may be qualified names,
+// like annotations or port names
+func qualifiedKeyValuePairArrayAsStringMap(pairs []string) (map[string]string, error) {
+	m := make(map[string]string)
+
+	for _, pair := range pairs {
+		if match := qualifiedKeyValuePairRegexp.FindStringSubmatch(pair); match != nil {
+			m[match[1]] = match[2]
+		} else {
+			return nil, fmt.Errorf("unable to parse key/value pair: %s", pair)
+		}
+	}
+
+	return m, nil
+}
+
 // filterTransferableAnnotations returns a map containing annotations that are meaningful for being transferred to child resources.
 func filterTransferableAnnotations(annotations map[string]string) map[string]string {
 	res := make(map[string]string)
@@ -171,6 +192,10 @@ func filterTransferableAnnotations(annotations map[string]string) map[string]str
 			// filter out kubectl annotations
 			continue
 		}
+		if k == argoCDTrackingIDAnnotation {
+			// the tracking id identifies the resource managed by Argo CD, not the derived ones
+			continue
+		}
 		res[k] = v
 	}
 	return res
@@ -221,6 +246,168 @@ func AddSourceDependencies(source v1.SourceSpec, catalog *camel.RuntimeCatalog)
 	return dependencies
 }
 
+// NewPolicySubject returns the facts about the Integration of the given environment the platform policy is enforced on,
+// as configured by the traits of the platform, the kit and the Integration
+func NewPolicySubject(env *Environment) (policy.Subject, error) {
+	catalog := NewCatalog(nil)
+	if err := catalog.configure(env); err != nil {
+		return policy.Subject{}, err
+	}
+	container, ok := catalog.GetTrait(containerTraitID).(*containerTrait)
+	if !ok {
+		return policy.Subject{}, fmt.Errorf("unable to find %s trait", containerTraitID)
+	}
+
+	sources, err := policySources(env.Integration)
+	if err != nil {
+		return policy.Subject{}, err
+	}
+
+	dependencies := strset.New(env.Integration.Spec.Dependencies...)
+	dependencies.Add(env.Integration.Status.Dependencies...)
+	languages := strset.New()
+	kamelets := strset.New()
+	if k, ok := catalog.GetTrait("kamelets").(*kameletsTrait); ok {
+		kamelets.Add(k.getKameletKeys()...)
+	}
+	for _, s := range sources {
+		if language := s.InferLanguage(); language != "" {
+			languages.Add(string(language))
+		}
+		// The Integration may not have been initialized yet, in which case the dependencies and the Kamelets
+		// of its inline sources are computed from the catalog
+		if env.CamelCatalog == nil || s.ContentRef != "" || s.Content == "" {
+			continue
+		}
+		if s.Compression {
+			content, err := gzip.UncompressBase64([]byte(s.Content))
+			if err != nil {
+				return policy.Subject{}, err
+			}
+			s.Content = string(content)
+			s.Compression = false
+		}
+		dependencies.Merge(AddSourceDependencies(s, env.CamelCatalog))
+		for _, k := range metadata.Extract(env.CamelCatalog, s).Kamelets {
+			// Kamelets may be referenced along with a configuration id
+			kamelets.Add(strings.SplitN(k, "/", 2)[0])
+		}
+	}
+
+	components := strset.New()
+	if env.CamelCatalog != nil {
+		env.CamelCatalog.VisitArtifacts(func(_ string, a v1.CamelArtifact) bool {
+			if !dependencies.Has(a.GetDependencyID()) && !dependencies.Has("mvn:"+a.GroupID+":"+a.ArtifactID) {
+				return true
+			}
+			if len(a.Schemes) > 0 {
+				components.Add(strings.TrimPrefix(strings.TrimPrefix(a.ArtifactID, "camel-quarkus-"), "camel-"))
+			}
+			// The core languages are always available to the Integrations
+			if a.ArtifactID != "camel-base" && a.ArtifactID != "camel-quarkus-core" {
+				languages.Add(a.Languages...)
+			}
+			return true
+		})
+	}
+
+	properties, err := catalog.ConfiguredTraitProperties(env.Integration.Spec.Traits, env.Integration.Annotations)
+	if err != nil {
+		return policy.Subject{}, err
+	}
+
+	return policy.Subject{
+		Integration:     env.Integration,
+		Dependencies:    dependencies.List(),
+		Components:      components.List(),
+		Languages:       languages.List(),
+		Kamelets:        kamelets.List(),
+		Image:           container.Image,
+		LimitCPU:        container.LimitCPU,
+		LimitMemory:     container.LimitMemory,
+		RequestCPU:      container.RequestCPU,
+		RequestMemory:   container.RequestMemory,
+		TraitProperties: properties,
+	}, nil
+}
+
+// policySources returns the sources of the given Integration, including its flows when they are not yet turned
+// into a generated source
+func policySources(it *v1.Integration) ([]v1.SourceSpec, error) {
+	sources := it.Sources()
+	if len(it.Spec.Flows) == 0 {
+		return sources, nil
+	}
+	for _, s := range it.Status.GeneratedSources {
+		if s.Name == flowsInternalSourceName {
+			return sources, nil
+		}
+	}
+	content, err := dsl.ToYamlDSL(it.Spec.Flows)
+	if err != nil {
+		return nil, err
+	}
+	return append(sources, v1.NewSourceSpec(flowsInternalSourceName, string(content), v1.LanguageYaml)), nil
+}
+
+// ExplainSourceDependencies returns the dependencies required by the given source, as computed
+// by AddSourceDependencies, along with the reasons why each of them has been added
+func ExplainSourceDependencies(source v1.SourceSpec, catalog *camel.RuntimeCatalog) map[string][]string {
+	reasons := make(map[string][]string)
+	explain := func(dependency string, reason string) {
+		r := reasons[dependency]
+		util.StringSliceUniqueAdd(&r, reason)
+		reasons[dependency] = r
+	}
+
+	meta := metadata.Extract(catalog, source)
+	explainEndpoints := func(uris []string, schemeDependencies func(*v1.CamelArtifact, string) []string) {
+		for _, uri := range uris {
+			component, scheme := catalog.DecodeComponent(uri)
+			if component == nil {
+				continue
+			}
+			// Do not leak the endpoint options, that may contain credentials
+			endpoint := strings.SplitN(uri, "?", 2)[0]
+			reason := fmt.Sprintf("component used by endpoint %s in source %s", endpoint, source.Name)
+			explain(component.GetDependencyID(), reason)
+			if scheme != nil {
+				for _, d := range schemeDependencies(component, scheme.ID) {
+					explain(d, reason)
+				}
+			}
+		}
+	}
+	explainEndpoints(meta.FromURIs, (*v1.CamelArtifact).GetConsumerDependencyIDs)
+	explainEndpoints(meta.ToURIs, (*v1.CamelArtifact).GetProducerDependencyIDs)
+
+	lang := source.InferLanguage()
+	for loader, v := range catalog.Loaders {
+		var reason string
+		if source.Loader != "" && source.Loader == loader {
+			reason = fmt.Sprintf("loader %s of source %s", loader, source.Name)
+		} else if source.Loader == "" && util.StringSliceExists(v.Languages, string(lang)) {
+			reason = fmt.Sprintf("loader for the %s language of source %s", lang, source.Name)
+		} else {
+			continue
+		}
+		explain(v.GetDependencyID(), reason)
+		for _, d := range v.Dependencies {
+			explain(d.GetDependencyID(), reason)
+		}
+	}
+
+	// The remaining dependencies are inferred from the source content, e.g., expression languages or Camel types
+	AddSourceDependencies(source, catalog).Each(func(dependency string) bool {
+		if _, ok := reasons[dependency]; !ok {
+			explain(dependency, fmt.Sprintf("inferred from the content of source %s", source.Name))
+		}
+		return true
+	})
+
+	return reasons
+}
+
 // Bool pointer operations:
 
 // BoolP returns a pointer to a bool value
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
 )
 
 func TestCollectConfigurationValues(t *testing.T) {
@@ -122,3 +123,29 @@ func TestBoolPointerFunctions(t *testing.T) {
 	assert.True(t, IsNilOrFalse(falseP))
 	assert.True(t, IsNilOrFalse(nil))
 }
+
+func TestExplainSourceDependencies(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	source := v1.SourceSpec{
+		DataSpec: v1.DataSpec{
+			Name:    "routes.groovy",
+			Content: `from("timer:tick?period=1000").to("log:info?showAll=true")`,
+		},
+	}
+
+	reasons := ExplainSourceDependencies(source, catalog)
+	assert.ElementsMatch(t, AddSourceDependencies(source, catalog).List(), keys(reasons))
+	assert.Equal(t, []string{"component used by endpoint timer:tick in source routes.groovy"}, reasons["camel:timer"])
+	assert.Equal(t, []string{"component used by endpoint log:info in source routes.groovy"}, reasons["camel:log"])
+	assert.Equal(t, []string{"loader for the groovy language of source routes.groovy"}, reasons["mvn:org.apache.camel.quarkus:camel-quarkus-groovy-dsl"])
+}
+
+func keys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
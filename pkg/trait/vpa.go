@@ -0,0 +1,325 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// The VPA trait creates a VerticalPodAutoscaler in recommendation mode for the integration,
+// when the https://github.com/kubernetes/autoscaler/tree/master/vertical-pod-autoscaler[Vertical Pod Autoscaler]
+// is installed in the cluster.
+//
+// The recommended resource requests are reported in the integration status, and can be displayed
+// with `kamel describe integration <name> --recommendations`.
+//
+// The recommendations can optionally be applied to the integration container on the next deployment of
+// the integration, within the configured bounds. The pods are never evicted by the Vertical Pod Autoscaler.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=vpa
+type vpaTrait struct {
+	BaseTrait `property:",squash"`
+	// Apply the recommended resource requests to the integration container on its next deployment.
+	AutoApply *bool `property:"auto-apply" json:"autoApply,omitempty"`
+	// The minimum CPU request that is recommended, or applied, e.g. `100m`.
+	MinCPU string `property:"min-cpu" json:"minCPU,omitempty"`
+	// The maximum CPU request that is recommended, or applied, e.g. `2`.
+	MaxCPU string `property:"max-cpu" json:"maxCPU,omitempty"`
+	// The minimum memory request that is recommended, or applied, e.g. `256Mi`.
+	MinMemory string `property:"min-memory" json:"minMemory,omitempty"`
+	// The maximum memory request that is recommended, or applied, e.g. `2Gi`.
+	MaxMemory string `property:"max-memory" json:"maxMemory,omitempty"`
+}
+
+const (
+	vpaGroupVersion = "autoscaling.k8s.io/v1"
+	vpaKind         = "VerticalPodAutoscaler"
+)
+
+func newVpaTrait() Trait {
+	return &vpaTrait{
+		BaseTrait: NewBaseTrait("vpa", 1640),
+	}
+}
+
+func (t *vpaTrait) Configure(e *Environment) (bool, error) {
+	if IsNilOrFalse(t.Enabled) || !e.IntegrationInRunningPhases() {
+		return false, nil
+	}
+
+	if _, _, err := t.bounds(); err != nil {
+		return false, err
+	}
+
+	installed, err := kubernetes.IsAPIResourceInstalled(e.Client, vpaGroupVersion, vpaKind)
+	if err != nil {
+		return false, err
+	}
+	if !installed {
+		t.L.ForIntegration(e.Integration).Info("The Vertical Pod Autoscaler is not installed, skipping the vpa trait")
+	}
+
+	return installed, nil
+}
+
+func (t *vpaTrait) Apply(e *Environment) error {
+	containerName := defaultContainerName
+	if ct := e.Catalog.GetTrait(containerTraitID); ct != nil {
+		containerName = ct.(*containerTrait).Name
+	}
+
+	vpa, err := t.verticalPodAutoscalerFor(e.Integration, containerName)
+	if err != nil {
+		return err
+	}
+	e.Resources.Add(vpa)
+
+	recommendations, err := t.getRecommendations(e, vpa)
+	if err != nil {
+		return err
+	}
+
+	// Carry over the requests applied on the last deployment, so that the integration
+	// is not rolled out again every time the recommendations are updated
+	for i := range recommendations {
+		for _, r := range e.Integration.Status.Recommendations {
+			if r.Container == recommendations[i].Container {
+				recommendations[i].Applied = r.Applied
+			}
+		}
+	}
+
+	if IsNilOrFalse(t.AutoApply) {
+		for i := range recommendations {
+			recommendations[i].Applied = nil
+		}
+	} else if e.IntegrationInPhase(v1.IntegrationPhaseDeploying) {
+		for i := range recommendations {
+			if recommendations[i].Container == containerName {
+				recommendations[i].Applied, err = t.applicable(recommendations[i].Target)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	e.Integration.Status.Recommendations = recommendations
+
+	for _, r := range recommendations {
+		if r.Container != containerName || len(r.Applied) == 0 {
+			continue
+		}
+		container := e.Resources.GetContainerByName(containerName)
+		if container == nil {
+			return nil
+		}
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = make(corev1.ResourceList)
+		}
+		for name, request := range r.Applied {
+			// The request must not exceed the limit for the pod to be valid
+			if limit, ok := container.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				request = limit
+			}
+			container.Resources.Requests[name] = request
+		}
+	}
+
+	return nil
+}
+
+func (t *vpaTrait) verticalPodAutoscalerFor(integration *v1.Integration, containerName string) (*unstructured.Unstructured, error) {
+	minAllowed, maxAllowed, err := t.bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	policy := map[string]interface{}{
+		"containerName":       containerName,
+		"controlledResources": []interface{}{string(corev1.ResourceCPU), string(corev1.ResourceMemory)},
+	}
+	if len(minAllowed) > 0 {
+		policy["minAllowed"] = resourceListAsMap(minAllowed)
+	}
+	if len(maxAllowed) > 0 {
+		policy["maxAllowed"] = resourceListAsMap(maxAllowed)
+	}
+
+	vpa := &unstructured.Unstructured{}
+	vpa.SetAPIVersion(vpaGroupVersion)
+	vpa.SetKind(vpaKind)
+	vpa.SetName(integration.Name)
+	vpa.SetNamespace(integration.Namespace)
+	vpa.SetLabels(map[string]string{
+		v1.IntegrationLabel: integration.Name,
+	})
+	spec := map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"apiVersion": v1.SchemeGroupVersion.String(),
+			"kind":       v1.IntegrationKind,
+			"name":       integration.Name,
+		},
+		// The recommendation mode, the pods are never evicted nor updated by the autoscaler
+		"updatePolicy": map[string]interface{}{
+			"updateMode": "Off",
+		},
+		"resourcePolicy": map[string]interface{}{
+			"containerPolicies": []interface{}{policy},
+		},
+	}
+	if err := unstructured.SetNestedMap(vpa.Object, spec, "spec"); err != nil {
+		return nil, err
+	}
+
+	return vpa, nil
+}
+
+// getRecommendations returns the container recommendations from the status of the existing VerticalPodAutoscaler
+func (t *vpaTrait) getRecommendations(e *Environment, vpa *unstructured.Unstructured) ([]v1.ResourceRecommendation, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(vpa.GroupVersionKind())
+	err := e.Client.Get(e.Ctx, ctrl.ObjectKeyFromObject(vpa), existing)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	containers, _, err := unstructured.NestedSlice(existing.Object, "status", "recommendation", "containerRecommendations")
+	if err != nil {
+		return nil, err
+	}
+	recommendations := make([]v1.ResourceRecommendation, 0, len(containers))
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(container, "containerName")
+		r := v1.ResourceRecommendation{Container: name}
+		if r.Target, err = nestedResourceList(container, "target"); err != nil {
+			return nil, err
+		}
+		if r.LowerBound, err = nestedResourceList(container, "lowerBound"); err != nil {
+			return nil, err
+		}
+		if r.UpperBound, err = nestedResourceList(container, "upperBound"); err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, r)
+	}
+
+	return recommendations, nil
+}
+
+// applicable returns the given recommended requests, within the configured bounds
+func (t *vpaTrait) applicable(target corev1.ResourceList) (corev1.ResourceList, error) {
+	if len(target) == 0 {
+		return nil, nil
+	}
+	minAllowed, maxAllowed, err := t.bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(corev1.ResourceList)
+	for name, value := range target {
+		if min, ok := minAllowed[name]; ok && value.Cmp(min) < 0 {
+			value = min
+		}
+		if max, ok := maxAllowed[name]; ok && value.Cmp(max) > 0 {
+			value = max
+		}
+		applied[name] = value
+	}
+
+	return applied, nil
+}
+
+// bounds returns the minimum and maximum resource requests set on the trait
+func (t *vpaTrait) bounds() (corev1.ResourceList, corev1.ResourceList, error) {
+	minAllowed := make(corev1.ResourceList)
+	maxAllowed := make(corev1.ResourceList)
+
+	for _, b := range []struct {
+		property string
+		value    string
+		name     corev1.ResourceName
+		list     corev1.ResourceList
+	}{
+		{"min-cpu", t.MinCPU, corev1.ResourceCPU, minAllowed},
+		{"max-cpu", t.MaxCPU, corev1.ResourceCPU, maxAllowed},
+		{"min-memory", t.MinMemory, corev1.ResourceMemory, minAllowed},
+		{"max-memory", t.MaxMemory, corev1.ResourceMemory, maxAllowed},
+	} {
+		if b.value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(b.value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s quantity %q: %v", b.property, b.value, err)
+		}
+		b.list[b.name] = q
+	}
+
+	for name, min := range minAllowed {
+		if max, ok := maxAllowed[name]; ok && min.Cmp(max) > 0 {
+			return nil, nil, fmt.Errorf("the minimum %s request %s is greater than the maximum %s", name, min.String(), max.String())
+		}
+	}
+
+	return minAllowed, maxAllowed, nil
+}
+
+func resourceListAsMap(list corev1.ResourceList) map[string]interface{} {
+	m := make(map[string]interface{}, len(list))
+	for name, value := range list {
+		m[string(name)] = value.String()
+	}
+	return m
+}
+
+func nestedResourceList(obj map[string]interface{}, field string) (corev1.ResourceList, error) {
+	values, found, err := unstructured.NestedMap(obj, field)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	list := make(corev1.ResourceList, len(values))
+	for name, value := range values {
+		q, err := resource.ParseQuantity(fmt.Sprint(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %s recommendation: %v", field, name, err)
+		}
+		list[corev1.ResourceName(name)] = q
+	}
+
+	return list, nil
+}
@@ -0,0 +1,233 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestConfigureVpaTraitDisabledByDefault(t *testing.T) {
+	vpaTrait, environment := createVpaTest(t, true)
+	vpaTrait.Enabled = nil
+
+	configured, err := vpaTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureVpaTraitWithoutVerticalPodAutoscaler(t *testing.T) {
+	vpaTrait, environment := createVpaTest(t, false)
+
+	configured, err := vpaTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureVpaTraitWithInvalidBounds(t *testing.T) {
+	vpaTrait, environment := createVpaTest(t, true)
+
+	vpaTrait.MinCPU = "one"
+	_, err := vpaTrait.Configure(environment)
+	assert.NotNil(t, err)
+
+	vpaTrait.MinCPU = "2"
+	vpaTrait.MaxCPU = "1"
+	_, err = vpaTrait.Configure(environment)
+	assert.NotNil(t, err)
+}
+
+func TestVpaIsCreatedInRecommendationMode(t *testing.T) {
+	vpaTrait, environment := createVpaTest(t, true)
+	vpaTrait.MinMemory = "256Mi"
+
+	configured, err := vpaTrait.Configure(environment)
+	assert.Nil(t, err)
+	assert.True(t, configured)
+	assert.Nil(t, vpaTrait.Apply(environment))
+
+	var vpa *unstructured.Unstructured
+	environment.Resources.Visit(func(o runtime.Object) {
+		if u, ok := o.(*unstructured.Unstructured); ok && u.GetKind() == vpaKind {
+			vpa = u
+		}
+	})
+	assert.NotNil(t, vpa)
+	assert.Equal(t, environment.Integration.Name, vpa.GetName())
+	assert.Equal(t, environment.Integration.Name, vpa.GetLabels()[v1.IntegrationLabel])
+
+	kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+	assert.Equal(t, v1.IntegrationKind, kind)
+	mode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	assert.Equal(t, "Off", mode)
+	policies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+	assert.Len(t, policies, 1)
+	assert.Equal(t, map[string]interface{}{"memory": "256Mi"}, policies[0].(map[string]interface{})["minAllowed"])
+
+	assert.Empty(t, environment.Integration.Status.Recommendations)
+}
+
+func TestVpaRecommendationsAreReported(t *testing.T) {
+	vpaTrait, environment := createVpaTest(t, true)
+	createVpaRecommendation(t, environment)
+
+	assert.Nil(t, vpaTrait.Apply(environment))
+
+	recommendations := environment.Integration.Status.Recommendations
+	assert.Len(t, recommendations, 1)
+	assert.Equal(t, defaultContainerName, recommendations[0].Container)
+	assert.Equal(t, resource.MustParse("250m"), recommendations[0].Target[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("512Mi"), recommendations[0].Target[corev1.ResourceMemory])
+	assert.Equal(t, resource.MustParse("100m"), recommendations[0].LowerBound[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("1"), recommendations[0].UpperBound[corev1.ResourceCPU])
+	assert.Empty(t, recommendations[0].Applied)
+
+	container := environment.Resources.GetContainerByName(defaultContainerName)
+	assert.Empty(t, container.Resources.Requests)
+}
+
+func TestVpaRecommendationsAreAppliedOnDeployment(t *testing.T) {
+	vpaTrait, environment := createVpaTest(t, true)
+	vpaTrait.AutoApply = BoolP(true)
+	vpaTrait.MaxMemory = "384Mi"
+	createVpaRecommendation(t, environment)
+
+	environment.Integration.Status.Phase = v1.IntegrationPhaseDeploying
+	assert.Nil(t, vpaTrait.Apply(environment))
+
+	applied := environment.Integration.Status.Recommendations[0].Applied
+	assert.Equal(t, resource.MustParse("250m"), applied[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("384Mi"), applied[corev1.ResourceMemory])
+	container := environment.Resources.GetContainerByName(defaultContainerName)
+	assert.Equal(t, resource.MustParse("250m"), container.Resources.Requests[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("384Mi"), container.Resources.Requests[corev1.ResourceMemory])
+
+	// The applied requests are kept until the next deployment
+	environment.Integration.Status.Phase = v1.IntegrationPhaseRunning
+	environment.Resources = kubernetes.NewCollection(newVpaTestDeployment())
+	updateVpaRecommendation(t, environment, "500m")
+	assert.Nil(t, vpaTrait.Apply(environment))
+
+	recommendation := environment.Integration.Status.Recommendations[0]
+	assert.Equal(t, resource.MustParse("500m"), recommendation.Target[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("250m"), recommendation.Applied[corev1.ResourceCPU])
+	container = environment.Resources.GetContainerByName(defaultContainerName)
+	assert.Equal(t, resource.MustParse("250m"), container.Resources.Requests[corev1.ResourceCPU])
+}
+
+func createVpaRecommendation(t *testing.T, e *Environment) {
+	t.Helper()
+
+	vpa := &unstructured.Unstructured{}
+	vpa.SetAPIVersion(vpaGroupVersion)
+	vpa.SetKind(vpaKind)
+	vpa.SetName(e.Integration.Name)
+	vpa.SetNamespace(e.Integration.Namespace)
+	assert.Nil(t, unstructured.SetNestedSlice(vpa.Object, []interface{}{
+		map[string]interface{}{
+			"containerName": defaultContainerName,
+			"target":        map[string]interface{}{"cpu": "250m", "memory": "512Mi"},
+			"lowerBound":    map[string]interface{}{"cpu": "100m", "memory": "256Mi"},
+			"upperBound":    map[string]interface{}{"cpu": "1", "memory": "1Gi"},
+		},
+	}, "status", "recommendation", "containerRecommendations"))
+	assert.Nil(t, e.Client.Create(e.Ctx, vpa))
+}
+
+func updateVpaRecommendation(t *testing.T, e *Environment, cpu string) {
+	t.Helper()
+
+	vpa := &unstructured.Unstructured{}
+	vpa.SetAPIVersion(vpaGroupVersion)
+	vpa.SetKind(vpaKind)
+	assert.Nil(t, e.Client.Get(e.Ctx, ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: e.Integration.Name}, vpa))
+	recommendations, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+	recommendations[0].(map[string]interface{})["target"].(map[string]interface{})["cpu"] = cpu
+	assert.Nil(t, unstructured.SetNestedSlice(vpa.Object, recommendations, "status", "recommendation", "containerRecommendations"))
+	assert.Nil(t, e.Client.Update(e.Ctx, vpa))
+}
+
+func newVpaTestDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: defaultContainerName},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createVpaTest(t *testing.T, installed bool) (*vpaTrait, *Environment) {
+	t.Helper()
+
+	trait := newVpaTrait().(*vpaTrait)
+	trait.Enabled = BoolP(true)
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+	if installed {
+		fake := c.(*test.FakeClient).Interface.(*fakeclientset.Clientset)
+		fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+			GroupVersion: vpaGroupVersion,
+			APIResources: []metav1.APIResource{
+				{Name: "verticalpodautoscalers", Kind: vpaKind},
+			},
+		})
+	}
+
+	environment := &Environment{
+		Ctx:     context.TODO(),
+		Client:  c,
+		Catalog: NewCatalog(nil),
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "ns",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseRunning,
+			},
+		},
+		Resources: kubernetes.NewCollection(newVpaTestDeployment()),
+	}
+
+	return trait, environment
+}
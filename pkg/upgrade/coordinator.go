@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+var logger = log.WithName("upgrade")
+
+// DefaultInterval is the period the upgrade plan is reconciled with
+const DefaultInterval = 1 * time.Minute
+
+// Coordinator is started once the operator instance is elected leader. It reports the upgrade plan of the
+// Integrations built by a former version of the operator with their UpgradeAvailable condition, and rebuilds
+// the ones that are compatible and opted in, a few at a time, until all of them are upgraded or held.
+type Coordinator struct {
+	client    client.Client
+	namespace string
+	interval  time.Duration
+}
+
+var _ manager.LeaderElectionRunnable = &Coordinator{}
+
+// NewCoordinator returns a Coordinator for the Integrations of the given namespace, or of all the namespaces if it is empty
+func NewCoordinator(c client.Client, namespace string) *Coordinator {
+	return &Coordinator{
+		client:    c,
+		namespace: namespace,
+		interval:  DefaultInterval,
+	}
+}
+
+// NeedLeaderElection --
+func (r *Coordinator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start --
+func (r *Coordinator) Start(ctx context.Context) error {
+	for {
+		plan, err := r.Reconcile(ctx)
+		if err != nil {
+			logger.Error(err, "cannot reconcile the upgrade plan")
+		} else if len(plan.Integrations()) == 0 {
+			logger.Info("All integrations are up to date")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// Reconcile computes the upgrade plan, reports it with the Integration conditions, and upgrades
+// the Integrations that are allowed to, within the limit of concurrent upgrades of their platform
+func (r *Coordinator) Reconcile(ctx context.Context) (*Plan, error) {
+	plan, err := ComputePlan(ctx, r.client, r.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// The number of Integrations that are being upgraded, for each platform
+	inProgress := make(map[string]int)
+	for _, item := range plan.Integrations() {
+		if item.Action == ActionInProgress && !item.rebuilt {
+			inProgress[item.platformKey]++
+		}
+	}
+
+	for _, item := range plan.Integrations() {
+		it := v1.NewIntegration(item.Namespace, item.Name)
+		if err := r.client.Get(ctx, k8sclient.ObjectKeyFromObject(&it), &it); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return plan, err
+		}
+
+		switch item.Action {
+		case ActionInProgress:
+			if item.rebuilt {
+				err = r.complete(ctx, &it)
+			}
+		case ActionUpgrade:
+			var window *platform.MaintenanceWindow
+			if window, err = platform.LookupMaintenanceWindow(ctx, r.client, &it); err != nil {
+				return plan, err
+			}
+			if now := time.Now(); window != nil && !window.Contains(now) {
+				err = r.setCondition(ctx, &it, corev1.ConditionTrue, v1.IntegrationConditionUpgradeDeferredReason,
+					fmt.Sprintf("the integration is upgraded from version %s to %s in the maintenance window starting at %s",
+						item.FromVersion, item.ToVersion, window.Next(now).Format(time.RFC3339)))
+			} else if inProgress[item.platformKey] < item.maxConcurrent {
+				inProgress[item.platformKey]++
+				err = r.upgrade(ctx, &it, item)
+			} else {
+				err = r.setCondition(ctx, &it, corev1.ConditionTrue, v1.IntegrationConditionUpgradeScheduledReason,
+					fmt.Sprintf("the integration is upgraded from version %s to %s once the %d integrations being upgraded are running",
+						item.FromVersion, item.ToVersion, item.maxConcurrent))
+			}
+		case ActionHold:
+			err = r.setCondition(ctx, &it, corev1.ConditionTrue, v1.IntegrationConditionUpgradePendingReason,
+				fmt.Sprintf("the integration can be upgraded from version %s to %s: %s", item.FromVersion, item.ToVersion, item.Message))
+		case ActionBlocked:
+			err = r.setCondition(ctx, &it, corev1.ConditionFalse, v1.IntegrationConditionUpgradeBlockedReason,
+				fmt.Sprintf("the integration cannot be upgraded from version %s to %s: %s", item.FromVersion, item.ToVersion, item.Message))
+		}
+		if err != nil && !k8serrors.IsConflict(err) {
+			return plan, err
+		}
+		// Conflicting updates are retried on the next reconciliation
+	}
+
+	return plan, nil
+}
+
+// upgrade clears the status of the Integration, so that it is rebuilt with the current version of the operator
+func (r *Coordinator) upgrade(ctx context.Context, it *v1.Integration, item Item) error {
+	if it.Annotations == nil {
+		it.Annotations = make(map[string]string)
+	}
+	it.Annotations[UpgradedFromAnnotation] = item.FromVersion
+	if err := r.client.Update(ctx, it); err != nil {
+		return err
+	}
+
+	logger.ForIntegration(it).Infof("Upgrading integration from version %s to %s", item.FromVersion, item.ToVersion)
+	it.Status = v1.IntegrationStatus{}
+	return r.client.Status().Update(ctx, it)
+}
+
+// complete removes the annotation of an upgraded Integration, once it has been rebuilt
+func (r *Coordinator) complete(ctx context.Context, it *v1.Integration) error {
+	logger.ForIntegration(it).Infof("Integration upgraded from version %s, in phase %s",
+		it.Annotations[UpgradedFromAnnotation], it.Status.Phase)
+	delete(it.Annotations, UpgradedFromAnnotation)
+	return r.client.Update(ctx, it)
+}
+
+func (r *Coordinator) setCondition(ctx context.Context, it *v1.Integration, status corev1.ConditionStatus, reason string, message string) error {
+	if c := it.Status.GetCondition(v1.IntegrationConditionUpgradeAvailable); c != nil &&
+		c.Status == status && c.Reason == reason && c.Message == message {
+		return nil
+	}
+	it.Status.SetCondition(v1.IntegrationConditionUpgradeAvailable, status, reason, message)
+	return r.client.Status().Update(ctx, it)
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func getIntegration(t *testing.T, c client.Client, name string) *v1.Integration {
+	t.Helper()
+
+	it := v1.NewIntegration("ns", name)
+	require.NoError(t, c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(&it), &it))
+	return &it
+}
+
+func TestCoordinatorReconcile(t *testing.T) {
+	pl := newPlatform(t)
+	pl.Status.Upgrade.AutoUpgrade = true
+	pl.Status.Upgrade.MaxConcurrent = 1
+
+	c, err := test.NewFakeClient(pl,
+		newIntegration("first", "", "camel:log"),
+		newIntegration("second", "", "camel:log"),
+		newIntegration("held", `{"autoUpgrade":false}`, "camel:log"),
+		newIntegration("blocked", "", "camel:removed-component"),
+	)
+	require.NoError(t, err)
+
+	coordinator := NewCoordinator(c, "ns")
+	_, err = coordinator.Reconcile(context.TODO())
+	require.NoError(t, err)
+
+	first := getIntegration(t, c, "first")
+	assert.Equal(t, previousVersion, first.Annotations[UpgradedFromAnnotation])
+	assert.Empty(t, first.Status.Version)
+	assert.Empty(t, first.Status.Phase)
+
+	second := getIntegration(t, c, "second")
+	assert.Equal(t, previousVersion, second.Status.Version)
+	condition := second.Status.GetCondition(v1.IntegrationConditionUpgradeAvailable)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionUpgradeScheduledReason, condition.Reason)
+
+	condition = getIntegration(t, c, "held").Status.GetCondition(v1.IntegrationConditionUpgradeAvailable)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionUpgradePendingReason, condition.Reason)
+
+	condition = getIntegration(t, c, "blocked").Status.GetCondition(v1.IntegrationConditionUpgradeAvailable)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionUpgradeBlockedReason, condition.Reason)
+	assert.Contains(t, condition.Message, "camel:removed-component")
+
+	// The first Integration is rebuilt by the operator
+	first.Status.Version = currentVersion
+	first.Status.Phase = v1.IntegrationPhaseBuildingKit
+	require.NoError(t, c.Status().Update(context.TODO(), first))
+
+	_, err = coordinator.Reconcile(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, previousVersion, getIntegration(t, c, "second").Status.Version)
+
+	first = getIntegration(t, c, "first")
+	first.Status.Phase = v1.IntegrationPhaseRunning
+	require.NoError(t, c.Status().Update(context.TODO(), first))
+
+	plan, err := coordinator.Reconcile(context.TODO())
+	require.NoError(t, err)
+	assert.NotContains(t, getIntegration(t, c, "first").Annotations, UpgradedFromAnnotation)
+	assert.Equal(t, ActionUpgrade, findItem(plan, v1.IntegrationKind, "second").Action)
+	assert.Empty(t, getIntegration(t, c, "second").Status.Version)
+}
+
+func TestCoordinatorDefersUpgradeToMaintenanceWindow(t *testing.T) {
+	pl := newPlatform(t)
+	pl.Status.Upgrade.AutoUpgrade = true
+	// A daily window that starts 12 hours from now
+	pl.Status.Maintenance.Window = fmt.Sprintf("0 %d * * *", (time.Now().UTC().Hour()+12)%24)
+
+	always := newIntegration("always", "", "camel:log")
+	always.Annotations = map[string]string{v1.MaintenanceWindowAnnotation: "none"}
+
+	c, err := test.NewFakeClient(pl, newIntegration("deferred", "", "camel:log"), always)
+	require.NoError(t, err)
+
+	_, err = NewCoordinator(c, "ns").Reconcile(context.TODO())
+	require.NoError(t, err)
+
+	deferred := getIntegration(t, c, "deferred")
+	assert.Equal(t, previousVersion, deferred.Status.Version)
+	assert.NotContains(t, deferred.Annotations, UpgradedFromAnnotation)
+	condition := deferred.Status.GetCondition(v1.IntegrationConditionUpgradeAvailable)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionUpgradeDeferredReason, condition.Reason)
+	assert.Contains(t, condition.Message, "in the maintenance window starting at")
+
+	always = getIntegration(t, c, "always")
+	assert.Equal(t, previousVersion, always.Annotations[UpgradedFromAnnotation])
+	assert.Empty(t, always.Status.Version)
+}
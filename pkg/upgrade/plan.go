@@ -0,0 +1,367 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scylladb/go-set/strset"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+// UpgradedFromAnnotation records the operator version an Integration has been upgraded from, while it is rebuilt
+const UpgradedFromAnnotation = "camel.apache.org/upgraded-from"
+
+// DefaultMaxConcurrent is the default number of Integrations of a platform that are upgraded at the same time
+const DefaultMaxConcurrent = 3
+
+// Action is what happens to a resource built by a former version of the operator
+type Action string
+
+const (
+	// ActionUpgrade reports an Integration that is compatible with the current version, and is rebuilt automatically
+	ActionUpgrade Action = "Upgrade"
+	// ActionInProgress reports an Integration that is being rebuilt with the current version
+	ActionInProgress Action = "InProgress"
+	// ActionHold reports an Integration that is compatible with the current version, but must be upgraded explicitly
+	ActionHold Action = "Hold"
+	// ActionBlocked reports an Integration that is not compatible with the current version
+	ActionBlocked Action = "Blocked"
+	// ActionRetain reports a kit that is still used by Integrations that are not upgraded
+	ActionRetain Action = "Retain"
+	// ActionObsolete reports a kit that is no longer used once its Integrations are upgraded
+	ActionObsolete Action = "Obsolete"
+)
+
+// Plan reports what happens to the resources built by a former version of the operator
+type Plan struct {
+	Items []Item `json:"items,omitempty"`
+}
+
+// Item is the upgrade plan of a single resource
+type Item struct {
+	Kind               string   `json:"kind"`
+	Namespace          string   `json:"namespace"`
+	Name               string   `json:"name"`
+	Platform           string   `json:"platform,omitempty"`
+	FromVersion        string   `json:"fromVersion"`
+	ToVersion          string   `json:"toVersion,omitempty"`
+	FromRuntimeVersion string   `json:"fromRuntimeVersion,omitempty"`
+	ToRuntimeVersion   string   `json:"toRuntimeVersion,omitempty"`
+	Action             Action   `json:"action"`
+	Message            string   `json:"message,omitempty"`
+	BrokenDependencies []string `json:"brokenDependencies,omitempty"`
+
+	// the platform the Integration is upgraded with, and its limit of concurrent upgrades
+	platformKey   string
+	maxConcurrent int
+	// whether the Integration, that is upgraded, is done being rebuilt
+	rebuilt bool
+}
+
+// Integrations returns the items of the plan that are Integrations
+func (p *Plan) Integrations() []Item {
+	items := make([]Item, 0, len(p.Items))
+	for _, item := range p.Items {
+		if item.Kind == v1.IntegrationKind {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ComputePlan computes the upgrade plan of the Integrations and kits of the given namespace, or of all the
+// namespaces if it is empty, that are built by another version of the operator than the one of their platform
+func ComputePlan(ctx context.Context, c client.Client, namespace string) (*Plan, error) {
+	integrations := v1.NewIntegrationList()
+	if err := c.List(ctx, &integrations, k8sclient.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	kits := v1.NewIntegrationKitList()
+	if err := c.List(ctx, &kits, k8sclient.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	planner := planner{
+		client:    c,
+		platforms: make(map[string]*v1.IntegrationPlatform),
+		catalogs:  make(map[string]*camel.RuntimeCatalog),
+	}
+	plan := Plan{}
+	// The kits that are still referenced by the Integrations that are not upgraded
+	retained := strset.New()
+
+	for i := range integrations.Items {
+		it := &integrations.Items[i]
+		item, err := planner.planIntegration(ctx, it)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			continue
+		}
+		plan.Items = append(plan.Items, *item)
+		if kit := it.Status.IntegrationKit; kit != nil && (item.Action == ActionHold || item.Action == ActionBlocked) {
+			retained.Add(kitKey(kit.Namespace, kit.Name, it.Namespace))
+		}
+	}
+
+	for _, kit := range kits.Items {
+		pl, err := planner.platform(ctx, kit.Namespace, kit.Status.Platform)
+		if err != nil {
+			return nil, err
+		}
+		if pl == nil || kit.Status.Version == "" || kit.Status.Version == pl.Status.Version {
+			continue
+		}
+		item := Item{
+			Kind:               v1.IntegrationKitKind,
+			Namespace:          kit.Namespace,
+			Name:               kit.Name,
+			Platform:           pl.Name,
+			FromVersion:        kit.Status.Version,
+			ToVersion:          pl.Status.Version,
+			FromRuntimeVersion: kit.Status.RuntimeVersion,
+			Action:             ActionObsolete,
+		}
+		if retained.Has(kitKey(kit.Namespace, kit.Name, kit.Namespace)) {
+			item.Action = ActionRetain
+			item.Message = "the kit is used by integrations that are not upgraded"
+		}
+		plan.Items = append(plan.Items, item)
+	}
+
+	sort.SliceStable(plan.Items, func(i, j int) bool {
+		a, b := plan.Items[i], plan.Items[j]
+		if a.Kind != b.Kind {
+			// Integrations first
+			return a.Kind == v1.IntegrationKind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return &plan, nil
+}
+
+func kitKey(namespace string, name string, defaultNamespace string) string {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return namespace + "/" + name
+}
+
+// planner caches the platforms and catalogs that are looked up while the plan is computed
+type planner struct {
+	client    client.Client
+	platforms map[string]*v1.IntegrationPlatform
+	catalogs  map[string]*camel.RuntimeCatalog
+}
+
+func (p *planner) planIntegration(ctx context.Context, it *v1.Integration) (*Item, error) {
+	if it.Status.Version == "" {
+		// Not initialized yet, the Integration is built with the current version
+		return nil, nil
+	}
+	pl, err := p.platform(ctx, it.Namespace, it.Status.Platform)
+	if err != nil {
+		return nil, err
+	}
+	if pl == nil || pl.Status.Version == "" {
+		return nil, nil
+	}
+
+	item := Item{
+		Kind:               v1.IntegrationKind,
+		Namespace:          it.Namespace,
+		Name:               it.Name,
+		Platform:           pl.Name,
+		FromVersion:        it.Status.Version,
+		ToVersion:          pl.Status.Version,
+		FromRuntimeVersion: it.Status.RuntimeVersion,
+		platformKey:        pl.Namespace + "/" + pl.Name,
+		maxConcurrent:      pl.Status.Upgrade.MaxConcurrent,
+	}
+	if item.maxConcurrent <= 0 {
+		item.maxConcurrent = DefaultMaxConcurrent
+	}
+
+	if it.Status.Version == pl.Status.Version {
+		from, ok := it.Annotations[UpgradedFromAnnotation]
+		if !ok {
+			return nil, nil
+		}
+		// The Integration has been upgraded, and is being rebuilt
+		item.FromVersion = from
+		item.ToRuntimeVersion = it.Status.RuntimeVersion
+		item.Action = ActionInProgress
+		item.rebuilt = it.Status.Phase == v1.IntegrationPhaseRunning || it.Status.Phase == v1.IntegrationPhaseError
+		item.Message = fmt.Sprintf("the integration is in phase %s", it.Status.Phase)
+		if it.Status.Phase == "" {
+			item.Message = "the integration is being initialized"
+		}
+		return &item, nil
+	}
+
+	config, err := camelTraitConfiguration(it)
+	if err != nil {
+		return nil, err
+	}
+	item.ToRuntimeVersion = config.RuntimeVersion
+	if item.ToRuntimeVersion == "" {
+		item.ToRuntimeVersion = pl.Status.Build.RuntimeVersion
+	}
+
+	broken, err := p.brokenDependencies(ctx, pl, it, item.ToRuntimeVersion)
+	switch {
+	case err != nil:
+		item.Action = ActionBlocked
+		item.Message = err.Error()
+	case len(broken) > 0:
+		item.Action = ActionBlocked
+		item.BrokenDependencies = broken
+		item.Message = fmt.Sprintf("dependencies not provided by runtime version %s: %s",
+			item.ToRuntimeVersion, strings.Join(broken, ", "))
+	case autoUpgrade(config, pl):
+		item.Action = ActionUpgrade
+	default:
+		item.Action = ActionHold
+		item.Message = "set the camel.auto-upgrade trait property, or the upgrade.autoUpgrade platform setting, " +
+			"or rebuild the integration to upgrade it"
+	}
+
+	return &item, nil
+}
+
+// brokenDependencies returns the dependencies of the Integration that are not provided by the catalog
+// of the target runtime version, or an error if that catalog cannot be found
+func (p *planner) brokenDependencies(ctx context.Context, pl *v1.IntegrationPlatform, it *v1.Integration, runtimeVersion string) ([]string, error) {
+	provider := it.Status.RuntimeProvider
+	if provider == "" {
+		provider = pl.Status.Build.RuntimeProvider
+	}
+
+	to, err := p.catalog(ctx, pl.Namespace, v1.RuntimeSpec{Version: runtimeVersion, Provider: provider})
+	if err != nil {
+		return nil, err
+	}
+	if to == nil {
+		return nil, fmt.Errorf("unable to find catalog matching version requirement: runtime=%s, provider=%s",
+			runtimeVersion, provider)
+	}
+
+	broken := strset.New()
+	if it.Status.RuntimeVersion != "" && it.Status.RuntimeVersion != runtimeVersion {
+		from, err := p.catalog(ctx, pl.Namespace, v1.RuntimeSpec{Version: it.Status.RuntimeVersion, Provider: provider})
+		if err != nil {
+			return nil, err
+		}
+		if from != nil {
+			diff := camel.DiffCatalogs(from.CamelCatalogSpec, to.CamelCatalogSpec)
+			broken.Add(diff.BrokenDependencies(it.Status.Dependencies)...)
+		}
+	}
+	// The Camel components must still be provided by the target catalog, whether the source catalog is available or not
+	provided := strset.New()
+	for _, artifact := range to.Artifacts {
+		provided.Add(artifact.GetDependencyID())
+	}
+	for _, dependency := range it.Status.Dependencies {
+		if strings.HasPrefix(dependency, "camel:") && !provided.Has(dependency) {
+			broken.Add(dependency)
+		}
+	}
+
+	list := broken.List()
+	sort.Strings(list)
+	return list, nil
+}
+
+func (p *planner) catalog(ctx context.Context, namespace string, runtime v1.RuntimeSpec) (*camel.RuntimeCatalog, error) {
+	key := namespace + "/" + string(runtime.Provider) + "/" + runtime.Version
+	if catalog, ok := p.catalogs[key]; ok {
+		return catalog, nil
+	}
+	catalog, err := camel.LoadCatalog(ctx, p.client, namespace, runtime)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		// The catalog of the default runtime version is created from the embedded one when the Integrations are built
+		if catalog, err = camel.DefaultCatalog(); err != nil {
+			return nil, err
+		}
+		if catalog.Runtime.Version != runtime.Version || catalog.Runtime.Provider != runtime.Provider {
+			catalog = nil
+		}
+	}
+	p.catalogs[key] = catalog
+	return catalog, nil
+}
+
+func (p *planner) platform(ctx context.Context, namespace string, name string) (*v1.IntegrationPlatform, error) {
+	key := namespace + "/" + name
+	if pl, ok := p.platforms[key]; ok {
+		return pl, nil
+	}
+	pl, err := platform.GetOrFind(ctx, p.client, namespace, name, true)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+	p.platforms[key] = pl
+	return pl, nil
+}
+
+// camelConfiguration holds the properties of the camel trait that are relevant for the upgrade
+type camelConfiguration struct {
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	AutoUpgrade    *bool  `json:"autoUpgrade,omitempty"`
+}
+
+func camelTraitConfiguration(it *v1.Integration) (camelConfiguration, error) {
+	config := camelConfiguration{}
+	if spec, ok := it.Spec.Traits["camel"]; ok && len(spec.Configuration.RawMessage) > 0 {
+		if err := json.Unmarshal(spec.Configuration.RawMessage, &config); err != nil {
+			return config, err
+		}
+	}
+	return config, nil
+}
+
+// autoUpgrade returns whether the Integration is upgraded automatically, the camel trait property
+// taking precedence over the platform setting
+func autoUpgrade(config camelConfiguration, pl *v1.IntegrationPlatform) bool {
+	if config.AutoUpgrade != nil {
+		return *config.AutoUpgrade
+	}
+	return pl.Status.Upgrade.AutoUpgrade
+}
@@ -0,0 +1,159 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+const (
+	previousVersion = "1.7.0"
+	currentVersion  = "1.8.0"
+)
+
+func newPlatform(t *testing.T) *v1.IntegrationPlatform {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	require.NoError(t, err)
+
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Version = currentVersion
+	pl.Status.Build.RuntimeVersion = catalog.Runtime.Version
+	pl.Status.Build.RuntimeProvider = catalog.Runtime.Provider
+	return &pl
+}
+
+func newIntegration(name string, camelTrait string, dependencies ...string) *v1.Integration {
+	it := v1.NewIntegration("ns", name)
+	if camelTrait != "" {
+		it.Spec.Traits = map[string]v1.TraitSpec{
+			"camel": {Configuration: v1.TraitConfiguration{RawMessage: v1.RawMessage(camelTrait)}},
+		}
+	}
+	it.Status.Phase = v1.IntegrationPhaseRunning
+	it.Status.Version = previousVersion
+	it.Status.RuntimeVersion = "1.5.0"
+	it.Status.RuntimeProvider = v1.RuntimeProviderQuarkus
+	it.Status.Dependencies = dependencies
+	it.Status.IntegrationKit = &corev1.ObjectReference{Namespace: "ns", Name: "kit-" + name}
+	return &it
+}
+
+func newKit(name string, version string) *v1.IntegrationKit {
+	kit := v1.NewIntegrationKit("ns", name)
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	kit.Status.Version = version
+	return kit
+}
+
+func findItem(plan *Plan, kind string, name string) *Item {
+	for i := range plan.Items {
+		if plan.Items[i].Kind == kind && plan.Items[i].Name == name {
+			return &plan.Items[i]
+		}
+	}
+	return nil
+}
+
+func TestComputePlan(t *testing.T) {
+	pl := newPlatform(t)
+	upToDate := newIntegration("up-to-date", "")
+	upToDate.Status.Version = currentVersion
+
+	c, err := test.NewFakeClient(pl,
+		upToDate,
+		newIntegration("held", "", "camel:log"),
+		newIntegration("opted-in", `{"autoUpgrade":true}`, "camel:log"),
+		newIntegration("opted-out", `{"autoUpgrade":false}`, "camel:log"),
+		newIntegration("blocked", `{"autoUpgrade":true}`, "camel:log", "camel:removed-component"),
+		newKit("kit-held", previousVersion),
+		newKit("kit-opted-in", previousVersion),
+		newKit("kit-up-to-date", currentVersion),
+	)
+	require.NoError(t, err)
+
+	plan, err := ComputePlan(context.TODO(), c, "ns")
+	require.NoError(t, err)
+
+	assert.Len(t, plan.Integrations(), 4)
+	assert.Nil(t, findItem(plan, v1.IntegrationKind, "up-to-date"))
+
+	held := findItem(plan, v1.IntegrationKind, "held")
+	require.NotNil(t, held)
+	assert.Equal(t, ActionHold, held.Action)
+	assert.Equal(t, previousVersion, held.FromVersion)
+	assert.Equal(t, currentVersion, held.ToVersion)
+	assert.Equal(t, pl.Status.Build.RuntimeVersion, held.ToRuntimeVersion)
+
+	assert.Equal(t, ActionUpgrade, findItem(plan, v1.IntegrationKind, "opted-in").Action)
+	assert.Equal(t, ActionHold, findItem(plan, v1.IntegrationKind, "opted-out").Action)
+
+	blocked := findItem(plan, v1.IntegrationKind, "blocked")
+	require.NotNil(t, blocked)
+	assert.Equal(t, ActionBlocked, blocked.Action)
+	assert.Equal(t, []string{"camel:removed-component"}, blocked.BrokenDependencies)
+
+	assert.Equal(t, ActionRetain, findItem(plan, v1.IntegrationKitKind, "kit-held").Action)
+	assert.Equal(t, ActionObsolete, findItem(plan, v1.IntegrationKitKind, "kit-opted-in").Action)
+	assert.Nil(t, findItem(plan, v1.IntegrationKitKind, "kit-up-to-date"))
+}
+
+func TestComputePlanWithPlatformAutoUpgrade(t *testing.T) {
+	pl := newPlatform(t)
+	pl.Status.Upgrade.AutoUpgrade = true
+
+	c, err := test.NewFakeClient(pl,
+		newIntegration("default", "", "camel:log"),
+		newIntegration("opted-out", `{"autoUpgrade":false}`, "camel:log"),
+	)
+	require.NoError(t, err)
+
+	plan, err := ComputePlan(context.TODO(), c, "ns")
+	require.NoError(t, err)
+
+	assert.Equal(t, ActionUpgrade, findItem(plan, v1.IntegrationKind, "default").Action)
+	assert.Equal(t, ActionHold, findItem(plan, v1.IntegrationKind, "opted-out").Action)
+}
+
+func TestComputePlanWithUnknownRuntimeVersion(t *testing.T) {
+	c, err := test.NewFakeClient(newPlatform(t),
+		newIntegration("pinned", `{"autoUpgrade":true,"runtimeVersion":"0.0.1"}`, "camel:log"),
+	)
+	require.NoError(t, err)
+
+	plan, err := ComputePlan(context.TODO(), c, "ns")
+	require.NoError(t, err)
+
+	item := findItem(plan, v1.IntegrationKind, "pinned")
+	require.NotNil(t, item)
+	assert.Equal(t, ActionBlocked, item.Action)
+	assert.Equal(t, "0.0.1", item.ToRuntimeVersion)
+	assert.Contains(t, item.Message, "unable to find catalog")
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"net/http"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// NewHandler returns an HTTP handler that renders, in JSON format, the usage report of the Integrations of the
+// namespace given with the `namespace` query parameter. The report can be restricted to the Integrations given
+// with the `integration` query parameter, that can be repeated. The requests must be authenticated with a bearer token,
+// whose user is authorized to list the Integrations of the namespace.
+func NewHandler(c client.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		namespace := query.Get("namespace")
+		if namespace == "" {
+			http.Error(w, "the namespace query parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		if !kubernetes.AuthorizeRequest(w, r, c, v1.SchemeGroupVersion.Group, "integrations", namespace, "", "list") {
+			return
+		}
+
+		report, err := Build(r.Context(), c, namespace, query["integration"]...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := report.WriteJSON(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
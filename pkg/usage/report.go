@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// IntegrationUsage summarizes the resources an Integration consumes, for chargeback
+type IntegrationUsage struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// The cost-allocation labels of the Integration, when the cost allocation is enabled on the platform
+	Labels map[string]string `json:"labels,omitempty"`
+	// The number of pods of the Integration that are not terminated
+	Pods int `json:"pods"`
+	// The CPU requested by the pods of the Integration
+	RequestsCPU resource.Quantity `json:"requestsCPU"`
+	// The memory requested by the pods of the Integration
+	RequestsMemory resource.Quantity `json:"requestsMemory"`
+	// The number of builds of the Integration kits, that are still retained in the namespace
+	Builds int `json:"builds"`
+	// The total duration of these builds, in minutes
+	BuildMinutes float64 `json:"buildMinutes"`
+}
+
+// Report is the usage of the Integrations of a namespace
+type Report struct {
+	Integrations []IntegrationUsage `json:"integrations"`
+}
+
+// Build computes the usage report of the Integrations of the given namespace, restricted to the given
+// Integrations, if any
+func Build(ctx context.Context, c ctrl.Reader, namespace string, names ...string) (*Report, error) {
+	integrations := v1.NewIntegrationList()
+	if err := c.List(ctx, &integrations, ctrl.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	pods := corev1.PodList{}
+	if err := c.List(ctx, &pods, ctrl.InNamespace(namespace), ctrl.HasLabels{v1.IntegrationLabel}); err != nil {
+		return nil, err
+	}
+	builds := v1.NewBuildList()
+	if err := c.List(ctx, &builds, ctrl.InNamespace(namespace), ctrl.MatchingLabels{kubernetes.CamelCreatorLabelKind: v1.IntegrationKind}); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	report := Report{
+		Integrations: make([]IntegrationUsage, 0),
+	}
+	for i := range integrations.Items {
+		it := &integrations.Items[i]
+		if len(selected) > 0 && !selected[it.Name] {
+			continue
+		}
+
+		usage := IntegrationUsage{
+			Name:      it.Name,
+			Namespace: it.Namespace,
+		}
+		p, err := platform.GetOrFind(ctx, c, it.Namespace, it.Status.Platform, false)
+		if err == nil && p.Status.CostAllocation.Enabled {
+			usage.Labels = p.Status.CostAllocation.LabelsFor(it)
+		}
+
+		for _, pod := range pods.Items {
+			if pod.Labels[v1.IntegrationLabel] != it.Name || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			usage.Pods++
+			for _, container := range pod.Spec.Containers {
+				if q, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+					usage.RequestsCPU.Add(q)
+				}
+				if q, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+					usage.RequestsMemory.Add(q)
+				}
+			}
+		}
+
+		for _, build := range builds.Items {
+			if build.Labels[kubernetes.CamelCreatorLabelName] != it.Name {
+				continue
+			}
+			usage.Builds++
+			usage.BuildMinutes += buildDuration(build).Minutes()
+		}
+
+		report.Integrations = append(report.Integrations, usage)
+	}
+
+	sort.Slice(report.Integrations, func(i, j int) bool {
+		return report.Integrations[i].Name < report.Integrations[j].Name
+	})
+
+	return &report, nil
+}
+
+// buildDuration returns the duration of the given build, up to now if it's still running
+func buildDuration(build v1.Build) time.Duration {
+	if build.Status.Phase == v1.BuildPhaseRunning && build.Status.StartedAt != nil {
+		return time.Since(build.Status.StartedAt.Time)
+	}
+	if d, err := time.ParseDuration(build.Status.Duration); err == nil {
+		return d
+	}
+	return 0
+}
+
+// WriteJSON writes the report in JSON format
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
@@ -0,0 +1,159 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestBuild(t *testing.T) {
+	report, err := Build(context.TODO(), newFakeClient(t), "ns")
+	assert.Nil(t, err)
+	assert.Len(t, report.Integrations, 2)
+
+	usage := report.Integrations[0]
+	assert.Equal(t, "my-integration", usage.Name)
+	assert.Equal(t, map[string]string{"team": "payments", "app": "my-integration"}, usage.Labels)
+	assert.Equal(t, 2, usage.Pods)
+	assert.Equal(t, "750m", usage.RequestsCPU.String())
+	assert.Equal(t, "1Gi", usage.RequestsMemory.String())
+	assert.Equal(t, 2, usage.Builds)
+	assert.Equal(t, 4.5, usage.BuildMinutes)
+
+	usage = report.Integrations[1]
+	assert.Equal(t, "other-integration", usage.Name)
+	assert.Equal(t, 0, usage.Pods)
+	assert.True(t, usage.RequestsCPU.IsZero())
+	assert.Equal(t, 0, usage.Builds)
+}
+
+func TestBuildForIntegration(t *testing.T) {
+	report, err := Build(context.TODO(), newFakeClient(t), "ns", "other-integration")
+	assert.Nil(t, err)
+	assert.Len(t, report.Integrations, 1)
+	assert.Equal(t, "other-integration", report.Integrations[0].Name)
+}
+
+func TestHandler(t *testing.T) {
+	c := newFakeClient(t)
+	// alice is authorized to list the integrations of the ns namespace, bob is not
+	test.AuthorizeUsers(c, func(user string, attributes authorizationv1.ResourceAttributes) bool {
+		return user == "alice" && attributes.Resource == "integrations" && attributes.Verb == "list" && attributes.Namespace == "ns"
+	}, "alice", "bob")
+	handler := NewHandler(c)
+	serve := func(target string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := serve("/usage?namespace=ns&integration=my-integration", "alice-token")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	report := Report{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Len(t, report.Integrations, 1)
+	assert.Equal(t, "750m", report.Integrations[0].RequestsCPU.String())
+
+	assert.Equal(t, http.StatusBadRequest, serve("/usage", "alice-token").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("/usage?namespace=ns", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("/usage?namespace=ns", "unknown-token").Code)
+	assert.Equal(t, http.StatusForbidden, serve("/usage?namespace=ns", "bob-token").Code)
+	assert.Equal(t, http.StatusForbidden, serve("/usage?namespace=other", "alice-token").Code)
+}
+
+func newFakeClient(t *testing.T) client.Client {
+	t.Helper()
+
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.CostAllocation.Enabled = true
+
+	integration := v1.NewIntegration("ns", "my-integration")
+	integration.Annotations = map[string]string{"camel.apache.org/team": "payments"}
+	other := v1.NewIntegration("ns", "other-integration")
+
+	pod := func(name string, integration string, phase corev1.PodPhase, cpu string, memory string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				Labels:    map[string]string{v1.IntegrationLabel: integration},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "integration",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(cpu),
+								corev1.ResourceMemory: resource.MustParse(memory),
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	build := func(name string, integration string, duration string) *v1.Build {
+		build := v1.NewBuild("ns", name)
+		build.Labels = map[string]string{
+			kubernetes.CamelCreatorLabelKind: v1.IntegrationKind,
+			kubernetes.CamelCreatorLabelName: integration,
+		}
+		build.Status.Phase = v1.BuildPhaseSucceeded
+		build.Status.Duration = duration
+		return build
+	}
+
+	c, err := test.NewFakeClient(
+		&pl,
+		&integration,
+		&other,
+		pod("my-integration-1", "my-integration", corev1.PodRunning, "500m", "512Mi"),
+		pod("my-integration-2", "my-integration", corev1.PodPending, "250m", "512Mi"),
+		pod("my-integration-3", "my-integration", corev1.PodSucceeded, "1", "1Gi"),
+		build("kit-1", "my-integration", "3m0s"),
+		build("kit-2", "my-integration", "1m30s"),
+	)
+	assert.Nil(t, err)
+	return c
+}
@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the mutations the operator performs on the cluster resources
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+var auditLog = log.WithName("audit")
+
+// EventsEnvVariable is the operator environment variable that enables the audit Events
+const EventsEnvVariable = "AUDIT_EVENTS"
+
+// Operation is the kind of mutation performed on a resource
+type Operation string
+
+const (
+	// OperationCreate --
+	OperationCreate Operation = "create"
+	// OperationUpdate --
+	OperationUpdate Operation = "update"
+	// OperationPatch --
+	OperationPatch Operation = "patch"
+	// OperationDelete --
+	OperationDelete Operation = "delete"
+	// OperationDeleteAll --
+	OperationDeleteAll Operation = "delete-all"
+)
+
+// maxChanges is the maximum number of changed fields reported in the summary of a record
+const maxChanges = 10
+
+// Record describes a mutation performed by the operator
+type Record struct {
+	Operation Operation
+	Kind      string
+	Namespace string
+	Name      string
+	// Reason explains why the mutation has been performed
+	Reason string
+	// Trigger is the resource whose reconciliation performed the mutation, e.g., an Integration
+	Trigger ctrl.Object
+	// Changes lists the paths of the fields that have been set
+	Changes []string
+}
+
+// Summary returns a human readable description of the record
+func (r Record) Summary() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s %s", r.Operation, r.Kind, r.Name)
+	if r.Reason != "" {
+		fmt.Fprintf(&sb, " (%s)", r.Reason)
+	}
+	if len(r.Changes) > 0 {
+		fmt.Fprintf(&sb, ": %s", summarizeChanges(r.Changes))
+	}
+	return sb.String()
+}
+
+func summarizeChanges(changes []string) string {
+	if len(changes) <= maxChanges {
+		return strings.Join(changes, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(changes[:maxChanges], ", "), len(changes)-maxChanges)
+}
+
+type contextKey int
+
+const (
+	triggerKey contextKey = iota
+	reasonKey
+)
+
+// WithTrigger returns a context that attributes the mutations to the given resource
+func WithTrigger(ctx context.Context, trigger ctrl.Object) context.Context {
+	return context.WithValue(ctx, triggerKey, trigger)
+}
+
+// WithReason returns a context that records the given reason for the mutations
+func WithReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, reasonKey, reason)
+}
+
+func triggerFrom(ctx context.Context) ctrl.Object {
+	if trigger, ok := ctx.Value(triggerKey).(ctrl.Object); ok {
+		return trigger
+	}
+	return nil
+}
+
+func reasonFrom(ctx context.Context) string {
+	if reason, ok := ctx.Value(reasonKey).(string); ok {
+		return reason
+	}
+	return ""
+}
+
+// EventsEnabled returns whether the audit records must also be published as Events
+func EventsEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(EventsEnvVariable))
+	return err == nil && enabled
+}
+
+// changedPaths returns the sorted paths, up to the given depth, of the fields set in the given object,
+// ignoring the fields managed by the API server and the identity of the resource
+func changedPaths(object map[string]interface{}, depth int) []string {
+	paths := make(map[string]bool)
+	collectPaths(object, "", depth, paths)
+
+	for _, ignored := range []string{
+		"apiVersion", "kind", "status",
+		"metadata.name", "metadata.namespace", "metadata.uid", "metadata.resourceVersion",
+		"metadata.generation", "metadata.creationTimestamp", "metadata.managedFields", "metadata.selfLink",
+	} {
+		delete(paths, ignored)
+	}
+	for p := range paths {
+		if strings.HasPrefix(p, "status.") || strings.HasPrefix(p, "metadata.managedFields.") {
+			delete(paths, p)
+		}
+	}
+
+	result := make([]string, 0, len(paths))
+	for p := range paths {
+		result = append(result, p)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func collectPaths(value map[string]interface{}, prefix string, depth int, paths map[string]bool) {
+	for k, v := range value {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if child, ok := v.(map[string]interface{}); ok && depth > 1 && len(child) > 0 {
+			collectPaths(child, path, depth-1, paths)
+			continue
+		}
+		paths[path] = true
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// changesDepth is the depth of the paths of the changed fields reported by the records
+const changesDepth = 3
+
+// maxVersions bounds the number of resource versions tracked to detect no-op mutations
+const maxVersions = 10000
+
+const (
+	// ReasonResourceCreated --
+	ReasonResourceCreated = "ResourceCreated"
+	// ReasonResourceUpdated --
+	ReasonResourceUpdated = "ResourceUpdated"
+	// ReasonResourceDeleted --
+	ReasonResourceDeleted = "ResourceDeleted"
+)
+
+// NewClient returns a client that records the mutations performed with the given client.
+// The records are logged, and published as Events on their trigger when a recorder is provided.
+func NewClient(c ctrl.Client, recorder record.EventRecorder) ctrl.Client {
+	return &auditClient{
+		Client:   c,
+		recorder: recorder,
+		versions: make(map[types.UID]string),
+	}
+}
+
+type auditClient struct {
+	ctrl.Client
+	recorder record.EventRecorder
+
+	lock sync.Mutex
+	// versions holds the last known resource versions of the mutated resources
+	versions map[types.UID]string
+}
+
+func (c *auditClient) Create(ctx context.Context, obj ctrl.Object, opts ...ctrl.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if !isDryRun(opts) {
+		c.trackVersion(obj)
+		c.record(ctx, OperationCreate, obj, nil)
+	}
+	return nil
+}
+
+func (c *auditClient) Update(ctx context.Context, obj ctrl.Object, opts ...ctrl.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if !isDryRun(opts) && c.trackVersion(obj) {
+		var changes []string
+		if content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err == nil {
+			changes = changedPaths(content, changesDepth)
+		}
+		c.record(ctx, OperationUpdate, obj, changes)
+	}
+	return nil
+}
+
+func (c *auditClient) Patch(ctx context.Context, obj ctrl.Object, patch ctrl.Patch, opts ...ctrl.PatchOption) error {
+	// The patch data must be computed before the object is updated with the response
+	var changes []string
+	if data, err := patch.Data(obj); err == nil {
+		content := make(map[string]interface{})
+		if err := json.Unmarshal(data, &content); err == nil {
+			changes = changedPaths(content, changesDepth)
+		}
+	}
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	if !isDryRun(opts) && c.trackVersion(obj) {
+		c.record(ctx, OperationPatch, obj, changes)
+	}
+	return nil
+}
+
+func (c *auditClient) Delete(ctx context.Context, obj ctrl.Object, opts ...ctrl.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if !isDryRun(opts) {
+		c.forgetVersion(obj)
+		c.record(ctx, OperationDelete, obj, nil)
+	}
+	return nil
+}
+
+func (c *auditClient) DeleteAllOf(ctx context.Context, obj ctrl.Object, opts ...ctrl.DeleteAllOfOption) error {
+	if err := c.Client.DeleteAllOf(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if !isDryRun(opts) {
+		o := ctrl.DeleteAllOfOptions{}
+		o.ApplyOptions(opts)
+		var changes []string
+		if o.LabelSelector != nil {
+			changes = append(changes, "labels: "+o.LabelSelector.String())
+		}
+		if o.FieldSelector != nil {
+			changes = append(changes, "fields: "+o.FieldSelector.String())
+		}
+		obj.SetNamespace(o.Namespace)
+		c.record(ctx, OperationDeleteAll, obj, changes)
+	}
+	return nil
+}
+
+// trackVersion records the resource version of the given object, and returns whether it has changed,
+// so that the mutations the API server treats as no-op, e.g., re-applying the same resource, are not recorded
+func (c *auditClient) trackVersion(obj ctrl.Object) bool {
+	if obj.GetUID() == "" || obj.GetResourceVersion() == "" {
+		return true
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if version, ok := c.versions[obj.GetUID()]; ok && version == obj.GetResourceVersion() {
+		return false
+	}
+	if len(c.versions) >= maxVersions {
+		c.versions = make(map[types.UID]string)
+	}
+	c.versions[obj.GetUID()] = obj.GetResourceVersion()
+	return true
+}
+
+func (c *auditClient) forgetVersion(obj ctrl.Object) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.versions, obj.GetUID())
+}
+
+func (c *auditClient) record(ctx context.Context, operation Operation, obj ctrl.Object, changes []string) {
+	r := Record{
+		Operation: operation,
+		Kind:      c.kindOf(obj),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Reason:    reasonFrom(ctx),
+		Trigger:   triggerFrom(ctx),
+		Changes:   changes,
+	}
+
+	keysAndValues := []interface{}{
+		"operation", r.Operation,
+		"kind", r.Kind,
+		"namespace", r.Namespace,
+		"name", r.Name,
+	}
+	if r.Reason != "" {
+		keysAndValues = append(keysAndValues, "reason", r.Reason)
+	}
+	if r.Trigger != nil {
+		keysAndValues = append(keysAndValues, "trigger", c.kindOf(r.Trigger)+"/"+r.Trigger.GetName())
+	}
+	if len(r.Changes) > 0 {
+		keysAndValues = append(keysAndValues, "changes", summarizeChanges(r.Changes))
+	}
+	auditLog.Info("Resource mutated", keysAndValues...)
+
+	if c.recorder != nil && r.Trigger != nil {
+		c.recorder.Event(r.Trigger, corev1.EventTypeNormal, eventReason(operation), r.Summary())
+	}
+}
+
+func (c *auditClient) kindOf(obj ctrl.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		return gvk.Kind
+	}
+	return ""
+}
+
+func eventReason(operation Operation) string {
+	switch operation {
+	case OperationCreate:
+		return ReasonResourceCreated
+	case OperationDelete, OperationDeleteAll:
+		return ReasonResourceDeleted
+	default:
+		return ReasonResourceUpdated
+	}
+}
+
+func isDryRun(opts interface{}) bool {
+	switch o := opts.(type) {
+	case []ctrl.CreateOption:
+		options := ctrl.CreateOptions{}
+		options.ApplyOptions(o)
+		return len(options.DryRun) > 0
+	case []ctrl.UpdateOption:
+		options := ctrl.UpdateOptions{}
+		options.ApplyOptions(o)
+		return len(options.DryRun) > 0
+	case []ctrl.PatchOption:
+		options := ctrl.PatchOptions{}
+		options.ApplyOptions(o)
+		return len(options.DryRun) > 0
+	case []ctrl.DeleteOption:
+		options := ctrl.DeleteOptions{}
+		options.ApplyOptions(o)
+		return len(options.DryRun) > 0
+	case []ctrl.DeleteAllOfOption:
+		options := ctrl.DeleteAllOfOptions{}
+		options.ApplyOptions(o)
+		return len(options.DryRun) > 0
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestAuditEvents(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	c := NewClient(fake.NewClientBuilder().WithScheme(clientscheme.Scheme).Build(), recorder)
+
+	integration := v1.Integration{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"}}
+	ctx := WithReason(WithTrigger(context.Background(), &integration), "apply integration resources")
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-cm"},
+		Data:       map[string]string{"a": "b"},
+	}
+	assert.Nil(t, c.Create(ctx, &cm))
+	assert.Equal(t, "Normal ResourceCreated create ConfigMap my-cm (apply integration resources)", <-recorder.Events)
+
+	p := ctrl.RawPatch(types.MergePatchType, []byte(`{"data":{"c":"d"},"metadata":{"labels":{"app":"x"}}}`))
+	assert.Nil(t, c.Patch(ctx, &cm, p))
+	assert.Equal(t, "Normal ResourceUpdated patch ConfigMap my-cm (apply integration resources): data.c, metadata.labels.app", <-recorder.Events)
+
+	assert.Nil(t, c.Delete(WithTrigger(context.Background(), &integration), &cm))
+	assert.Equal(t, "Normal ResourceDeleted delete ConfigMap my-cm", <-recorder.Events)
+
+	// Neither dry runs nor mutations without trigger are published
+	assert.Nil(t, c.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dry-run"}}, ctrl.DryRunAll))
+	assert.Nil(t, c.Create(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other"}}))
+	assert.Empty(t, recorder.Events)
+}
+
+func TestNoOpMutationsAreNotRecorded(t *testing.T) {
+	c := &auditClient{versions: make(map[types.UID]string)}
+
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{UID: "uid", ResourceVersion: "1"}}
+	assert.True(t, c.trackVersion(&cm))
+	assert.False(t, c.trackVersion(&cm))
+
+	cm.ResourceVersion = "2"
+	assert.True(t, c.trackVersion(&cm))
+
+	c.forgetVersion(&cm)
+	assert.True(t, c.trackVersion(&cm))
+
+	assert.True(t, c.trackVersion(&corev1.ConfigMap{}))
+	assert.True(t, c.trackVersion(&corev1.ConfigMap{}))
+}
+
+func TestChangedPaths(t *testing.T) {
+	paths := changedPaths(map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":            "my-integration",
+			"resourceVersion": "1",
+			"managedFields":   []interface{}{},
+			"labels": map[string]interface{}{
+				"camel.apache.org/integration": "my-integration",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": 2,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{},
+				},
+			},
+			"selector": map[string]interface{}{},
+		},
+		"status": map[string]interface{}{
+			"replicas": 1,
+		},
+	}, 3)
+
+	assert.Equal(t, []string{
+		"metadata.labels.camel.apache.org/integration",
+		"spec.replicas",
+		"spec.selector",
+		"spec.template.spec",
+	}, paths)
+}
+
+func TestRecordSummary(t *testing.T) {
+	r := Record{
+		Operation: OperationPatch,
+		Kind:      "Deployment",
+		Name:      "my-integration",
+		Changes:   []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"},
+	}
+	assert.Equal(t, "patch Deployment my-integration: a, b, c, d, e, f, g, h, i, j and 2 more", r.Summary())
+}
@@ -18,6 +18,9 @@ limitations under the License.
 package camel
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"strings"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
@@ -66,9 +69,22 @@ func NewRuntimeCatalog(spec v1.CamelCatalogSpec) *RuntimeCatalog {
 		}
 	}
 
+	catalog.digest = computeCatalogDigest(catalog.CamelCatalogSpec)
+
 	return &catalog
 }
 
+// computeCatalogDigest fingerprints the catalog content, so that what is derived from it can be cached
+func computeCatalogDigest(spec v1.CamelCatalogSpec) string {
+	// Maps are marshalled with sorted keys, so the output is stable
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
 // RuntimeCatalog --
 type RuntimeCatalog struct {
 	v1.CamelCatalogSpec
@@ -78,6 +94,13 @@ type RuntimeCatalog struct {
 	schemesByID          map[string]v1.CamelScheme
 	languageDependencies map[string]string
 	javaTypeDependencies map[string]string
+	digest               string
+}
+
+// Digest returns a fingerprint of the catalog content, or an empty string if the catalog
+// has not been created with NewRuntimeCatalog
+func (c *RuntimeCatalog) Digest() string {
+	return c.digest
 }
 
 // HasArtifact --
@@ -83,12 +83,21 @@ func GenerateCatalog(
 		}
 	}
 
-	return GenerateCatalogCommon(ctx, settings, caCert, mvn, runtime, providerDependencies)
+	var settingsSecurity []byte
+	if mvn.SettingsSecurity != nil {
+		settingsSecurity, err = kubernetes.GetSecretRefData(ctx, client, namespace, mvn.SettingsSecurity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return GenerateCatalogCommon(ctx, settings, settingsSecurity, caCert, mvn, runtime, providerDependencies)
 }
 
 func GenerateCatalogCommon(
 	ctx context.Context,
 	settings string,
+	settingsSecurity []byte,
 	caCert []byte,
 	mvn v1.MavenSpec,
 	runtime v1.RuntimeSpec,
@@ -118,6 +127,7 @@ func GenerateCatalogCommon(
 	if settings != "" {
 		mc.SettingsContent = []byte(settings)
 	}
+	mc.SettingsSecurityContent = settingsSecurity
 
 	if caCert != nil {
 		trustStoreName := "trust.jks"
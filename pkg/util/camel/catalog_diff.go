@@ -0,0 +1,176 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package camel
+
+import (
+	"sort"
+
+	"github.com/scylladb/go-set/strset"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// CatalogDiff reports the differences between two versions of a catalog
+type CatalogDiff struct {
+	FromRuntimeVersion string           `json:"fromRuntimeVersion,omitempty"`
+	ToRuntimeVersion   string           `json:"toRuntimeVersion,omitempty"`
+	ChangedMetadata    []MetadataChange `json:"changedMetadata,omitempty"`
+	AddedArtifacts     []string         `json:"addedArtifacts,omitempty"`
+	RemovedArtifacts   []string         `json:"removedArtifacts,omitempty"`
+	ChangedArtifacts   []ArtifactChange `json:"changedArtifacts,omitempty"`
+	RemovedSchemes     []string         `json:"removedSchemes,omitempty"`
+	RemovedLanguages   []string         `json:"removedLanguages,omitempty"`
+	// RemovedDependencies are the dependency IDs, as they are resolved for integrations,
+	// that are no longer provided by the target catalog
+	RemovedDependencies []string `json:"removedDependencies,omitempty"`
+}
+
+// MetadataChange reports a runtime metadata entry whose value differs between two catalogs
+type MetadataChange struct {
+	Key  string `json:"key"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// ArtifactChange reports an artifact whose Maven coordinates differ between two catalogs
+type ArtifactChange struct {
+	ID   string           `json:"id"`
+	From v1.MavenArtifact `json:"from"`
+	To   v1.MavenArtifact `json:"to"`
+}
+
+// DiffCatalogs computes the differences from one version of a catalog to another
+func DiffCatalogs(from v1.CamelCatalogSpec, to v1.CamelCatalogSpec) CatalogDiff {
+	diff := CatalogDiff{
+		FromRuntimeVersion: from.Runtime.Version,
+		ToRuntimeVersion:   to.Runtime.Version,
+	}
+
+	keys := strset.New()
+	for k := range from.Runtime.Metadata {
+		keys.Add(k)
+	}
+	for k := range to.Runtime.Metadata {
+		keys.Add(k)
+	}
+	for _, k := range sortedList(keys) {
+		if from.Runtime.Metadata[k] != to.Runtime.Metadata[k] {
+			diff.ChangedMetadata = append(diff.ChangedMetadata, MetadataChange{
+				Key:  k,
+				From: from.Runtime.Metadata[k],
+				To:   to.Runtime.Metadata[k],
+			})
+		}
+	}
+
+	removedDependencies := strset.New()
+	for _, id := range sortedKeys(from.Artifacts) {
+		old := from.Artifacts[id]
+		artifact, ok := to.Artifacts[id]
+		if !ok {
+			diff.RemovedArtifacts = append(diff.RemovedArtifacts, id)
+			removedDependencies.Add(old.GetDependencyID())
+			continue
+		}
+		if old.MavenArtifact != artifact.MavenArtifact {
+			diff.ChangedArtifacts = append(diff.ChangedArtifacts, ArtifactChange{
+				ID:   id,
+				From: old.MavenArtifact,
+				To:   artifact.MavenArtifact,
+			})
+			// Integrations refer to the artifact with its former coordinates
+			if old.GetDependencyID() != artifact.GetDependencyID() {
+				removedDependencies.Add(old.GetDependencyID())
+			}
+		}
+	}
+	for _, id := range sortedKeys(to.Artifacts) {
+		if _, ok := from.Artifacts[id]; !ok {
+			diff.AddedArtifacts = append(diff.AddedArtifacts, id)
+		}
+	}
+
+	schemes := func(spec v1.CamelCatalogSpec) *strset.Set {
+		s := strset.New()
+		for _, artifact := range spec.Artifacts {
+			for _, scheme := range artifact.Schemes {
+				s.Add(scheme.ID)
+			}
+		}
+		return s
+	}
+	diff.RemovedSchemes = sortedList(strset.Difference(schemes(from), schemes(to)))
+
+	languages := func(spec v1.CamelCatalogSpec) *strset.Set {
+		s := strset.New()
+		for _, loader := range spec.Loaders {
+			s.Add(loader.Languages...)
+		}
+		return s
+	}
+	diff.RemovedLanguages = sortedList(strset.Difference(languages(from), languages(to)))
+
+	for id, loader := range from.Loaders {
+		if _, ok := to.Loaders[id]; !ok {
+			removedDependencies.Add(loader.GetDependencyID())
+		}
+	}
+	diff.RemovedDependencies = sortedList(removedDependencies)
+
+	return diff
+}
+
+// IsEmpty returns true if the two catalogs are equivalent
+func (d CatalogDiff) IsEmpty() bool {
+	return d.FromRuntimeVersion == d.ToRuntimeVersion &&
+		len(d.ChangedMetadata) == 0 &&
+		len(d.AddedArtifacts) == 0 &&
+		len(d.RemovedArtifacts) == 0 &&
+		len(d.ChangedArtifacts) == 0 &&
+		len(d.RemovedSchemes) == 0 &&
+		len(d.RemovedLanguages) == 0
+}
+
+// BrokenDependencies returns the given dependencies, e.g., the ones resolved for an integration,
+// that are no longer provided by the target catalog
+func (d CatalogDiff) BrokenDependencies(dependencies []string) []string {
+	removed := strset.New(d.RemovedDependencies...)
+	broken := make([]string, 0)
+	for _, dependency := range dependencies {
+		if removed.Has(dependency) {
+			broken = append(broken, dependency)
+		}
+	}
+	sort.Strings(broken)
+	return broken
+}
+
+func sortedKeys(artifacts map[string]v1.CamelArtifact) []string {
+	keys := make([]string, 0, len(artifacts))
+	for k := range artifacts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedList(s *strset.Set) []string {
+	l := s.List()
+	sort.Strings(l)
+	return l
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package camel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	from := v1.CamelCatalogSpec{
+		Runtime: v1.RuntimeSpec{
+			Version:  "1.8.0",
+			Metadata: map[string]string{"camel.version": "3.11.0", "quarkus.version": "2.2.0.Final"},
+		},
+		Artifacts: map[string]v1.CamelArtifact{
+			"camel-quarkus-log": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{GroupID: "org.apache.camel.quarkus", ArtifactID: "camel-quarkus-log"}},
+				Schemes:                 []v1.CamelScheme{{ID: "log"}},
+			},
+			"camel-quarkus-legacy": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{GroupID: "org.apache.camel.quarkus", ArtifactID: "camel-quarkus-legacy"}},
+				Schemes:                 []v1.CamelScheme{{ID: "legacy"}},
+			},
+			"camel-quarkus-acme": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{GroupID: "org.apache.camel.quarkus", ArtifactID: "camel-quarkus-acme"}},
+				Schemes:                 []v1.CamelScheme{{ID: "acme"}},
+			},
+		},
+		Loaders: map[string]v1.CamelLoader{
+			"js": {
+				MavenArtifact: v1.MavenArtifact{GroupID: "org.apache.camel.quarkus", ArtifactID: "camel-quarkus-js-dsl"},
+				Languages:     []string{"js"},
+			},
+		},
+	}
+	to := v1.CamelCatalogSpec{
+		Runtime: v1.RuntimeSpec{
+			Version:  "1.9.0",
+			Metadata: map[string]string{"camel.version": "3.11.1", "quarkus.version": "2.2.0.Final"},
+		},
+		Artifacts: map[string]v1.CamelArtifact{
+			"camel-quarkus-log": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{GroupID: "org.apache.camel.quarkus", ArtifactID: "camel-quarkus-log"}},
+				Schemes:                 []v1.CamelScheme{{ID: "log"}},
+			},
+			"camel-quarkus-acme": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{GroupID: "org.acme", ArtifactID: "camel-quarkus-acme", Version: "1.0.0"}},
+				Schemes:                 []v1.CamelScheme{{ID: "acme"}},
+			},
+			"camel-quarkus-timer": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{GroupID: "org.apache.camel.quarkus", ArtifactID: "camel-quarkus-timer"}},
+				Schemes:                 []v1.CamelScheme{{ID: "timer"}},
+			},
+		},
+	}
+
+	diff := DiffCatalogs(from, to)
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, "1.8.0", diff.FromRuntimeVersion)
+	assert.Equal(t, "1.9.0", diff.ToRuntimeVersion)
+	assert.Equal(t, []MetadataChange{{Key: "camel.version", From: "3.11.0", To: "3.11.1"}}, diff.ChangedMetadata)
+	assert.Equal(t, []string{"camel-quarkus-timer"}, diff.AddedArtifacts)
+	assert.Equal(t, []string{"camel-quarkus-legacy"}, diff.RemovedArtifacts)
+	assert.Equal(t, []ArtifactChange{{
+		ID:   "camel-quarkus-acme",
+		From: v1.MavenArtifact{GroupID: "org.apache.camel.quarkus", ArtifactID: "camel-quarkus-acme"},
+		To:   v1.MavenArtifact{GroupID: "org.acme", ArtifactID: "camel-quarkus-acme", Version: "1.0.0"},
+	}}, diff.ChangedArtifacts)
+	assert.Equal(t, []string{"legacy"}, diff.RemovedSchemes)
+	assert.Equal(t, []string{"js"}, diff.RemovedLanguages)
+	assert.Equal(t, []string{"camel:acme", "camel:legacy", "mvn:org.apache.camel.quarkus:camel-quarkus-js-dsl"}, diff.RemovedDependencies)
+
+	assert.Equal(t, []string{"camel:acme", "camel:legacy"},
+		diff.BrokenDependencies([]string{"camel:log", "camel:legacy", "mvn:org.acme:other", "camel:acme"}))
+	assert.Empty(t, diff.BrokenDependencies([]string{"camel:log"}))
+
+	assert.True(t, DiffCatalogs(from, from).IsEmpty())
+}
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package camel
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+// ValidateCatalog checks the consistency of the given catalog, e.g., a catalog authored for
+// a custom runtime, and returns an error that reports all the detected issues
+func ValidateCatalog(spec v1.CamelCatalogSpec) error {
+	var issues []string
+	report := func(format string, args ...interface{}) {
+		issues = append(issues, fmt.Sprintf(format, args...))
+	}
+	checkArtifact := func(kind string, id string, artifact v1.MavenArtifact) {
+		if artifact.GroupID == "" || artifact.ArtifactID == "" {
+			report("%s %s must have a groupId and an artifactId", kind, id)
+		}
+	}
+
+	if spec.Runtime.Version == "" {
+		report("runtime version is missing")
+	}
+	switch spec.Runtime.Provider {
+	case "":
+		report("runtime provider is missing")
+	case v1.RuntimeProviderQuarkus:
+		// The versions are used to generate the integration projects
+		for _, key := range []string{"camel-quarkus.version", "quarkus.version"} {
+			if spec.Runtime.Metadata[key] == "" {
+				report("runtime metadata %s is missing", key)
+			}
+		}
+	default:
+		report("runtime provider %s is not supported", spec.Runtime.Provider)
+	}
+	if spec.Runtime.ApplicationClass == "" {
+		report("runtime application class is missing")
+	}
+	for _, d := range spec.Runtime.Dependencies {
+		checkArtifact("runtime dependency", d.GetDependencyID(), d)
+	}
+	for name, capability := range spec.Runtime.Capabilities {
+		for _, d := range capability.Dependencies {
+			checkArtifact(fmt.Sprintf("capability %s dependency", name), d.GetDependencyID(), d)
+		}
+	}
+
+	artifactsByScheme := make(map[string][]string)
+	for id, artifact := range spec.Artifacts {
+		checkArtifact("artifact", id, artifact.MavenArtifact)
+		for _, scheme := range artifact.Schemes {
+			if scheme.ID == "" {
+				report("artifact %s has a scheme without id", id)
+				continue
+			}
+			artifactsByScheme[scheme.ID] = append(artifactsByScheme[scheme.ID], id)
+		}
+	}
+	for scheme, ids := range artifactsByScheme {
+		if len(ids) < 2 {
+			continue
+		}
+		// Duplicates are resolved in favor of the Camel Quarkus artifact, see NewRuntimeCatalog
+		quarkus := 0
+		for _, id := range ids {
+			if spec.Artifacts[id].GroupID == "org.apache.camel.quarkus" {
+				quarkus++
+			}
+		}
+		if quarkus != 1 {
+			sort.Strings(ids)
+			report("scheme %s is ambiguous, as it is provided by artifacts %s", scheme, strings.Join(ids, ", "))
+		}
+	}
+
+	for id, loader := range spec.Loaders {
+		checkArtifact("loader", id, loader.MavenArtifact)
+		if len(loader.Languages) == 0 {
+			report("loader %s has no languages", id)
+		}
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	sort.Strings(issues)
+	return errors.New(strings.Join(issues, "; "))
+}
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package camel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestValidateDefaultCatalog(t *testing.T) {
+	catalog, err := DefaultCatalog()
+	assert.Nil(t, err)
+
+	assert.Nil(t, ValidateCatalog(catalog.CamelCatalogSpec))
+}
+
+func TestValidateCatalog(t *testing.T) {
+	spec := v1.CamelCatalogSpec{
+		Runtime: v1.RuntimeSpec{
+			Provider: v1.RuntimeProviderQuarkus,
+			Metadata: map[string]string{
+				"quarkus.version": "2.2.0.Final",
+			},
+		},
+		Artifacts: map[string]v1.CamelArtifact{
+			"camel-acme-http": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{GroupID: "org.acme", ArtifactID: "camel-acme-http"}},
+				Schemes:                 []v1.CamelScheme{{ID: "http"}},
+			},
+			"camel-other-http": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{GroupID: "org.other", ArtifactID: "camel-other-http"}},
+				Schemes:                 []v1.CamelScheme{{ID: "http"}},
+			},
+			"camel-quarkus-log": {
+				CamelArtifactDependency: v1.CamelArtifactDependency{MavenArtifact: v1.MavenArtifact{ArtifactID: "camel-quarkus-log"}},
+			},
+		},
+		Loaders: map[string]v1.CamelLoader{
+			"yaml": {
+				MavenArtifact: v1.MavenArtifact{GroupID: "org.apache.camel.quarkus", ArtifactID: "camel-quarkus-yaml-dsl"},
+			},
+		},
+	}
+
+	err := ValidateCatalog(spec)
+	assert.EqualError(t, err, "artifact camel-quarkus-log must have a groupId and an artifactId; "+
+		"loader yaml has no languages; "+
+		"runtime application class is missing; "+
+		"runtime metadata camel-quarkus.version is missing; "+
+		"runtime version is missing; "+
+		"scheme http is ambiguous, as it is provided by artifacts camel-acme-http, camel-other-http")
+}
@@ -24,10 +24,18 @@ import (
 	"github.com/apache/camel-k/pkg/util/log"
 )
 
+// fipsBaseImage is the Red Hat Universal Base Image, whose crypto modules are FIPS validated
+const fipsBaseImage = "registry.access.redhat.com/ubi8/openjdk-11-runtime"
+
 func BaseImage() string {
 	return envOrDefault(baseImage, "KAMEL_BASE_IMAGE", "RELATED_IMAGE_BASE")
 }
 
+// FIPSBaseImage returns the base image used by the platforms in FIPS mode
+func FIPSBaseImage() string {
+	return envOrDefault(fipsBaseImage, "KAMEL_FIPS_BASE_IMAGE", "RELATED_IMAGE_FIPS_BASE")
+}
+
 func InstallDefaultKamelets() bool {
 	return boolEnvOrDefault(installDefaultKamelets, "KAMEL_INSTALL_DEFAULT_KAMELETS")
 }
@@ -38,6 +38,15 @@ func TestOverriddenBaseImage(t *testing.T) {
 	assert.NoError(t, os.Setenv(env, oldEnvVal))
 }
 
+func TestOverriddenFIPSBaseImage(t *testing.T) {
+	env := "KAMEL_FIPS_BASE_IMAGE"
+	oldEnvVal := os.Getenv(env)
+	overriddenImage := "xxx"
+	assert.NoError(t, os.Setenv(env, overriddenImage))
+	assert.Equal(t, overriddenImage, FIPSBaseImage())
+	assert.NoError(t, os.Setenv(env, oldEnvVal))
+}
+
 func TestOverriddenInstallDefaultKamelets(t *testing.T) {
 	env := "KAMEL_INSTALL_DEFAULT_KAMELETS"
 	oldEnvVal := os.Getenv(env)
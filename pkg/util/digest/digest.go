@@ -39,7 +39,11 @@ import (
 
 // ComputeForIntegration a digest of the fields that are relevant for the deployment
 // Produces a digest that can be used as docker image tag
-func ComputeForIntegration(integration *v1.Integration) (string, error) {
+//
+// The configuration of the given excluded traits, or trait properties as <trait>.<property>, is left out, along with
+// the ones excluded with the annotation of the Integration.
+func ComputeForIntegration(integration *v1.Integration, excludedTraits ...string) (string, error) {
+	excluded := newTraitExclusions(excludedTraits, integration.Annotations[v1.DigestExcludedTraitsAnnotation])
 	hash := sha256.New()
 	// Integration version is relevant
 	if _, err := hash.Write([]byte(integration.Status.Version)); err != nil {
@@ -56,18 +60,33 @@ func ComputeForIntegration(integration *v1.Integration) (string, error) {
 		return "", err
 	}
 
-	// Integration code
+	// Integration code.
+	// Sources, resources and dependencies are hashed in a stable order, so that
+	// re-ordering them, e.g., by server-side apply from GitOps controllers,
+	// does not change the digest and trigger a new deployment.
+	sources := make([]string, 0, len(integration.Spec.Sources))
 	for _, s := range integration.Spec.Sources {
 		if s.Content != "" {
-			if _, err := hash.Write([]byte(s.Content)); err != nil {
-				return "", err
-			}
+			sources = append(sources, s.Content)
+		} else if s.ContentRef != "" {
+			sources = append(sources, s.GetContentRefKind()+"/"+s.ContentRef+"/"+s.GetContentKey())
+		}
+	}
+	sort.Strings(sources)
+	for _, content := range sources {
+		if _, err := hash.Write([]byte(content)); err != nil {
+			return "", err
 		}
 	}
 
 	// Integration resources
+	resources := make([]string, 0, len(integration.Spec.Resources))
 	for _, item := range integration.Spec.Resources {
-		if _, err := hash.Write([]byte(item.Content)); err != nil {
+		resources = append(resources, item.Content)
+	}
+	sort.Strings(resources)
+	for _, content := range resources {
+		if _, err := hash.Write([]byte(content)); err != nil {
 			return "", err
 		}
 	}
@@ -84,7 +103,10 @@ func ComputeForIntegration(integration *v1.Integration) (string, error) {
 	}
 
 	// Integration dependencies
-	for _, item := range integration.Spec.Dependencies {
+	dependencies := make([]string, len(integration.Spec.Dependencies))
+	copy(dependencies, integration.Spec.Dependencies)
+	sort.Strings(dependencies)
+	for _, item := range dependencies {
 		if _, err := hash.Write([]byte(item)); err != nil {
 			return "", err
 		}
@@ -99,8 +121,8 @@ func ComputeForIntegration(integration *v1.Integration) (string, error) {
 
 	// Integration traits
 	for _, name := range sortedTraitSpecMapKeys(integration.Spec.Traits) {
-		if _, err := hash.Write([]byte(name + "[")); err != nil {
-			return "", err
+		if excluded.has(name) {
+			continue
 		}
 		spec, err := json.Marshal(integration.Spec.Traits[name].Configuration)
 		if err != nil {
@@ -111,7 +133,20 @@ func ComputeForIntegration(integration *v1.Integration) (string, error) {
 		if err != nil {
 			return "", err
 		}
+		props := make([]string, 0, len(trait))
 		for _, prop := range util.SortedMapKeys(trait) {
+			if !excluded.has(name + "." + prop) {
+				props = append(props, prop)
+			}
+		}
+		// The trait is left out when all its properties are excluded, as if it was not configured
+		if len(props) == 0 && len(trait) > 0 {
+			continue
+		}
+		if _, err := hash.Write([]byte(name + "[")); err != nil {
+			return "", err
+		}
+		for _, prop := range props {
 			val := trait[prop]
 			if _, err := hash.Write([]byte(fmt.Sprintf("%s=%v,", prop, val))); err != nil {
 				return "", err
@@ -121,8 +156,21 @@ func ComputeForIntegration(integration *v1.Integration) (string, error) {
 			return "", err
 		}
 	}
+	// Integration tests, so that they are run again when changed
+	if len(integration.Spec.Tests) > 0 {
+		tests, err := json.Marshal(integration.Spec.Tests)
+		if err != nil {
+			return "", err
+		}
+		if _, err := hash.Write(tests); err != nil {
+			return "", err
+		}
+	}
 	// Integration traits as annotations
 	for _, k := range sortedTraitAnnotationsKeys(integration) {
+		if excluded.has(strings.TrimPrefix(k, v1.TraitAnnotationPrefix)) {
+			continue
+		}
 		v := integration.Annotations[k]
 		if _, err := hash.Write([]byte(fmt.Sprintf("%s=%v,", k, v))); err != nil {
 			return "", err
@@ -238,6 +286,37 @@ func ComputeForSource(s v1.SourceSpec) (string, error) {
 	return digest, nil
 }
 
+// traitExclusions are the traits, or trait properties, excluded from the digest. The properties are matched regardless
+// of their case and dashes, so that both their JSON names, used in the spec, and their property names, used in the
+// annotations, match, e.g., container.limitCPU and container.limit-cpu.
+type traitExclusions map[string]bool
+
+func newTraitExclusions(excluded []string, annotation string) traitExclusions {
+	exclusions := make(traitExclusions)
+	for _, e := range excluded {
+		exclusions[normalizeTraitProperty(e)] = true
+	}
+	for _, e := range strings.Split(annotation, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			exclusions[normalizeTraitProperty(e)] = true
+		}
+	}
+	return exclusions
+}
+
+// has returns whether the given trait, or trait property, is excluded, either by itself or along with its trait
+func (e traitExclusions) has(name string) bool {
+	if len(e) == 0 {
+		return false
+	}
+	name = normalizeTraitProperty(name)
+	return e[name] || e[strings.SplitN(name, ".", 2)[0]]
+}
+
+func normalizeTraitProperty(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "-", ""))
+}
+
 func sortedTraitSpecMapKeys(m map[string]v1.TraitSpec) []string {
 	res := make([]string, len(m))
 	i := 0
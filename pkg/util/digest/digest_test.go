@@ -44,3 +44,86 @@ func TestDigestUsesAnnotations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEqual(t, digest1, digest3)
 }
+
+func TestDigestIgnoresOrdering(t *testing.T) {
+	it := v1.Integration{
+		Spec: v1.IntegrationSpec{
+			Sources: []v1.SourceSpec{
+				{DataSpec: v1.DataSpec{Name: "a.groovy", Content: "from('timer:a')"}},
+				{DataSpec: v1.DataSpec{Name: "b.groovy", Content: "from('timer:b')"}},
+			},
+			Dependencies: []string{"camel:log", "camel:timer"},
+		},
+	}
+	digest1, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+
+	it.Spec.Sources[0], it.Spec.Sources[1] = it.Spec.Sources[1], it.Spec.Sources[0]
+	it.Spec.Dependencies = []string{"camel:timer", "camel:log"}
+	digest2, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+	assert.Equal(t, digest1, digest2)
+	assert.Equal(t, []string{"camel:timer", "camel:log"}, it.Spec.Dependencies)
+
+	it.Spec.Dependencies = append(it.Spec.Dependencies, "camel:kafka")
+	digest3, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest1, digest3)
+}
+
+func TestDigestUsesTests(t *testing.T) {
+	it := v1.Integration{}
+	digest1, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+
+	it.Spec.Tests = []v1.IntegrationTest{
+		{Name: "health", HTTP: &v1.IntegrationTestHTTP{Path: "/health"}},
+	}
+	digest2, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest1, digest2)
+
+	it.Spec.Tests[0].HTTP.ExpectedStatus = 204
+	digest3, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest2, digest3)
+}
+
+func TestDigestExcludesTraits(t *testing.T) {
+	it := v1.Integration{
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"container": {Configuration: v1.TraitConfiguration{RawMessage: []byte(`{"limitCPU":"500m"}`)}},
+			},
+		},
+	}
+	digest1, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+
+	// The excluded trait properties are left out, whether they're set in the spec or with annotations
+	it.Spec.Traits["container"] = v1.TraitSpec{Configuration: v1.TraitConfiguration{RawMessage: []byte(`{"limitCPU":"500m","imagePullPolicy":"Always"}`)}}
+	it.Spec.Traits["prometheus"] = v1.TraitSpec{Configuration: v1.TraitConfiguration{RawMessage: []byte(`{"enabled":true}`)}}
+	it.Annotations = map[string]string{
+		"trait.camel.apache.org/container.image-pull-policy": "Always",
+		"trait.camel.apache.org/prometheus.pod-monitor":      "false",
+	}
+	digest2, err := ComputeForIntegration(&it, "container.image-pull-policy", "prometheus")
+	assert.NoError(t, err)
+	assert.Equal(t, digest1, digest2)
+
+	digest3, err := ComputeForIntegration(&it, "container.image-pull-policy")
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest1, digest3)
+
+	// The exclusions can be set on the Integration
+	it.Annotations[v1.DigestExcludedTraitsAnnotation] = "prometheus, container.imagePullPolicy"
+	digest4, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+	assert.Equal(t, digest1, digest4)
+
+	// The properties that are not excluded are still relevant
+	it.Spec.Traits["container"] = v1.TraitSpec{Configuration: v1.TraitConfiguration{RawMessage: []byte(`{"limitCPU":"1","imagePullPolicy":"Always"}`)}}
+	digest5, err := ComputeForIntegration(&it)
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest1, digest5)
+}
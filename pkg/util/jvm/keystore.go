@@ -31,7 +31,8 @@ import (
 
 func GenerateKeystore(ctx context.Context, keystoreDir, keystoreName, keystorePass string, data []byte) error {
 	args := strings.Fields(fmt.Sprintf("-importcert -noprompt -alias maven -storepass %s -keystore %s", keystorePass, keystoreName))
-	cmd := exec.CommandContext(ctx, "keytool", args...)
+	keytool := Keytool()
+	cmd := exec.CommandContext(ctx, keytool, args...)
 	cmd.Dir = keystoreDir
 	cmd.Stdin = bytes.NewReader(data)
 	cmd.Stderr = os.Stderr
@@ -45,11 +46,13 @@ func GenerateKeystore(ctx context.Context, keystoreDir, keystoreName, keystorePa
 	// Try to locate root CA certificates truststore, in order to import them
 	// into the newly created truststore. It avoids tempering the system-wide
 	// JVM truststore.
-	javaHome, ok := os.LookupEnv("JAVA_HOME")
-	if ok {
+	if javaHome, err := FindJavaHome(); err == nil {
 		caCertsPath := path.Join(javaHome, "lib/security/cacerts")
+		if _, err := os.Stat(caCertsPath); err != nil {
+			return nil
+		}
 		args := strings.Fields(fmt.Sprintf("-importkeystore -noprompt -srckeystore %s -srcstorepass %s -destkeystore %s -deststorepass %s", caCertsPath, "changeit", keystoreName, keystorePass))
-		cmd := exec.CommandContext(ctx, "keytool", args...)
+		cmd := exec.CommandContext(ctx, keytool, args...)
 		cmd.Dir = keystoreDir
 		cmd.Stderr = os.Stderr
 		cmd.Stdout = os.Stdout
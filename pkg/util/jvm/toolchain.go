@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jvm
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// javaHomeCandidates are the directories the JDKs are commonly installed in, by the JDK images and packages
+var javaHomeCandidates = []string{"/usr/lib/jvm/*", "/usr/java/*", "/opt/java/openjdk", "/opt/jdk*"}
+
+// FindJavaHome returns the directory of the JDK, as set with the JAVA_HOME environment variable, or otherwise
+// detected from the java executable of the PATH, or from the common installation directories, so that the builds
+// can be run in custom builder images
+func FindJavaHome() (string, error) {
+	if home := os.Getenv("JAVA_HOME"); home != "" {
+		return home, nil
+	}
+
+	if java, err := exec.LookPath("java"); err == nil {
+		// The java executable is usually a symbolic link, e.g., managed by the alternatives system
+		if resolved, err := filepath.EvalSymlinks(java); err == nil {
+			return filepath.Dir(filepath.Dir(resolved)), nil
+		}
+	}
+
+	for _, candidate := range javaHomeCandidates {
+		matches, err := filepath.Glob(candidate)
+		if err != nil {
+			return "", err
+		}
+		sort.Strings(matches)
+		for _, home := range matches {
+			if isExecutable(filepath.Join(home, "bin", "java")) {
+				return home, nil
+			}
+		}
+	}
+
+	return "", errors.New("unable to find the JDK, either the JAVA_HOME environment variable must be set, " +
+		"or the java executable must be in the PATH")
+}
+
+// Keytool returns the keytool executable, from the PATH, or otherwise from the detected JDK
+func Keytool() string {
+	if _, err := exec.LookPath("keytool"); err == nil {
+		return "keytool"
+	}
+	if home, err := FindJavaHome(); err == nil {
+		return filepath.Join(home, "bin", "keytool")
+	}
+	return "keytool"
+}
+
+func isExecutable(file string) bool {
+	info, err := os.Stat(file)
+	return err == nil && !info.IsDir() && info.Mode()&0o111 != 0
+}
@@ -160,6 +160,37 @@ func (c *Collection) RemoveDeployment(filter func(*appsv1.Deployment) bool) *app
 	return res.(*appsv1.Deployment)
 }
 
+// VisitStatefulSet executes the visitor function on all StatefulSet resources
+func (c *Collection) VisitStatefulSet(visitor func(*appsv1.StatefulSet)) {
+	c.Visit(func(res runtime.Object) {
+		if conv, ok := res.(*appsv1.StatefulSet); ok {
+			visitor(conv)
+		}
+	})
+}
+
+// VisitStatefulSetE executes the visitor function on all StatefulSet resources
+func (c *Collection) VisitStatefulSetE(visitor func(*appsv1.StatefulSet) error) error {
+	return c.VisitE(func(res runtime.Object) error {
+		if conv, ok := res.(*appsv1.StatefulSet); ok {
+			return visitor(conv)
+		}
+
+		return nil
+	})
+}
+
+// GetStatefulSet returns a StatefulSet that matches the given function
+func (c *Collection) GetStatefulSet(filter func(*appsv1.StatefulSet) bool) *appsv1.StatefulSet {
+	var retValue *appsv1.StatefulSet
+	c.VisitStatefulSet(func(re *appsv1.StatefulSet) {
+		if filter(re) {
+			retValue = re
+		}
+	})
+	return retValue
+}
+
 // VisitConfigMap executes the visitor function on all ConfigMap resources
 func (c *Collection) VisitConfigMap(visitor func(*corev1.ConfigMap)) {
 	c.Visit(func(res runtime.Object) {
@@ -194,6 +225,15 @@ func (c *Collection) RemoveConfigMap(filter func(*corev1.ConfigMap) bool) *corev
 	return res.(*corev1.ConfigMap)
 }
 
+// VisitSecret executes the visitor function on all Secret resources
+func (c *Collection) VisitSecret(visitor func(*corev1.Secret)) {
+	c.Visit(func(res runtime.Object) {
+		if conv, ok := res.(*corev1.Secret); ok {
+			visitor(conv)
+		}
+	})
+}
+
 // VisitService executes the visitor function on all Service resources
 func (c *Collection) VisitService(visitor func(*corev1.Service)) {
 	c.Visit(func(res runtime.Object) {
@@ -367,6 +407,12 @@ func (c *Collection) VisitContainer(visitor func(container *corev1.Container)) {
 			visitor(cntref)
 		}
 	})
+	c.VisitStatefulSet(func(s *appsv1.StatefulSet) {
+		for idx := range s.Spec.Template.Spec.Containers {
+			cntref := &s.Spec.Template.Spec.Containers[idx]
+			visitor(cntref)
+		}
+	})
 	c.VisitKnativeConfigurationSpec(func(cs *serving.ConfigurationSpec) {
 		for id := range cs.Template.Spec.Containers {
 			cntref := &cs.Template.Spec.Containers[id]
@@ -381,7 +427,7 @@ func (c *Collection) VisitContainer(visitor func(container *corev1.Container)) {
 	})
 }
 
-// GetController returns the controller associated with the integration (e.g. Deployment, Knative Service or CronJob)
+// GetController returns the controller associated with the integration (e.g. Deployment, StatefulSet, Knative Service or CronJob)
 func (c *Collection) GetController(filter func(object ctrl.Object) bool) ctrl.Object {
 	d := c.GetDeployment(func(deployment *appsv1.Deployment) bool {
 		return filter(deployment)
@@ -389,6 +435,12 @@ func (c *Collection) GetController(filter func(object ctrl.Object) bool) ctrl.Ob
 	if d != nil {
 		return d
 	}
+	ss := c.GetStatefulSet(func(statefulSet *appsv1.StatefulSet) bool {
+		return filter(statefulSet)
+	})
+	if ss != nil {
+		return ss
+	}
 	svc := c.GetKnativeService(func(service *serving.Service) bool {
 		return filter(service)
 	})
@@ -409,6 +461,9 @@ func (c *Collection) VisitPodSpec(visitor func(container *corev1.PodSpec)) {
 	c.VisitDeployment(func(d *appsv1.Deployment) {
 		visitor(&d.Spec.Template.Spec)
 	})
+	c.VisitStatefulSet(func(s *appsv1.StatefulSet) {
+		visitor(&s.Spec.Template.Spec)
+	})
 	c.VisitKnativeConfigurationSpec(func(cs *serving.ConfigurationSpec) {
 		visitor(&cs.Template.Spec.PodSpec)
 	})
@@ -422,6 +477,9 @@ func (c *Collection) VisitPodTemplateMeta(visitor func(meta *metav1.ObjectMeta))
 	c.VisitDeployment(func(d *appsv1.Deployment) {
 		visitor(&d.Spec.Template.ObjectMeta)
 	})
+	c.VisitStatefulSet(func(s *appsv1.StatefulSet) {
+		visitor(&s.Spec.Template.ObjectMeta)
+	})
 	c.VisitKnativeConfigurationSpec(func(cs *serving.ConfigurationSpec) {
 		visitor(&cs.Template.ObjectMeta)
 	})
@@ -493,3 +551,21 @@ func (c *Collection) GetPodMonitor(filter func(*monitoringv1.PodMonitor) bool) *
 	})
 	return retValue
 }
+
+func (c *Collection) VisitServiceMonitor(visitor func(*monitoringv1.ServiceMonitor)) {
+	c.Visit(func(res runtime.Object) {
+		if conv, ok := res.(*monitoringv1.ServiceMonitor); ok {
+			visitor(conv)
+		}
+	})
+}
+
+func (c *Collection) GetServiceMonitor(filter func(*monitoringv1.ServiceMonitor) bool) *monitoringv1.ServiceMonitor {
+	var retValue *monitoringv1.ServiceMonitor
+	c.VisitServiceMonitor(func(serviceMonitor *monitoringv1.ServiceMonitor) {
+		if filter(serviceMonitor) {
+			retValue = serviceMonitor
+		}
+	})
+	return retValue
+}
@@ -22,12 +22,15 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/pkg/client"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -35,6 +38,8 @@ import (
 func MirrorReadyCondition(ctx context.Context, c client.Client, it *v1.Integration) {
 	if IsConditionTrue(it, v1.IntegrationConditionDeploymentAvailable) || IsConditionTrue(it, v1.IntegrationConditionKnativeServiceAvailable) {
 		mirrorReadyConditionFromReplicaSet(ctx, c, it)
+	} else if IsConditionTrue(it, v1.IntegrationConditionStatefulSetAvailable) {
+		mirrorReadyConditionFromStatefulSet(ctx, c, it)
 	} else if IsConditionTrue(it, v1.IntegrationConditionCronJobAvailable) {
 		mirrorReadyConditionFromCronJob(ctx, c, it)
 	} else {
@@ -97,6 +102,36 @@ func mirrorReadyConditionFromReplicaSet(ctx context.Context, c client.Client, it
 	}
 }
 
+func mirrorReadyConditionFromStatefulSet(ctx context.Context, c client.Client, it *v1.Integration) {
+	statefulSet := appsv1.StatefulSet{}
+	if err := c.Get(ctx, runtimeclient.ObjectKey{Namespace: it.Namespace, Name: it.Name}, &statefulSet); err != nil {
+		setReadyConditionError(it, err)
+		return
+	}
+
+	var replicas int32 = 1
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	// As for ReplicaSets, the Integration is considered ready when the number
+	// of ready replicas is larger or equal to the specified number of replicas.
+	if replicas <= statefulSet.Status.ReadyReplicas {
+		it.Status.SetCondition(
+			v1.IntegrationConditionReady,
+			corev1.ConditionTrue,
+			v1.IntegrationConditionStatefulSetReadyReason,
+			"",
+		)
+	} else {
+		it.Status.SetCondition(
+			v1.IntegrationConditionReady,
+			corev1.ConditionFalse,
+			v1.IntegrationConditionStatefulSetNotReadyReason,
+			"",
+		)
+	}
+}
+
 func mirrorReadyConditionFromCronJob(ctx context.Context, c client.Client, it *v1.Integration) {
 	cronJob := v1beta1.CronJob{}
 	if err := c.Get(ctx, runtimeclient.ObjectKey{Namespace: it.Namespace, Name: it.Name}, &cronJob); err != nil {
@@ -109,7 +144,63 @@ func mirrorReadyConditionFromCronJob(ctx context.Context, c client.Client, it *v
 			v1.IntegrationConditionCronJobCreatedReason,
 			"",
 		)
+		mirrorLastJobCondition(ctx, c, it, &cronJob)
+	}
+}
+
+// mirrorLastJobCondition reports the result of the last Job of the CronJob, either scheduled or run manually,
+// along with the last schedule and successful times of the CronJob
+func mirrorLastJobCondition(ctx context.Context, c client.Client, it *v1.Integration, cronJob *v1beta1.CronJob) {
+	jobs := batchv1.JobList{}
+	if err := c.List(ctx, &jobs, runtimeclient.InNamespace(it.Namespace), runtimeclient.MatchingLabels{v1.IntegrationLabel: it.Name}); err != nil {
+		it.Status.SetErrorCondition(v1.IntegrationConditionLastJobSucceeded, v1.IntegrationConditionErrorReason, err)
+		return
+	}
+
+	var last *batchv1.Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if last == nil || jobStartTime(job).After(jobStartTime(last).Time) {
+			last = job
+		}
+	}
+	if last == nil {
+		return
+	}
+
+	schedule := "never scheduled"
+	if t := cronJob.Status.LastScheduleTime; t != nil {
+		schedule = "last scheduled at " + t.UTC().Format(time.RFC3339)
+	}
+	if t := cronJob.Status.LastSuccessfulTime; t != nil {
+		schedule += ", last successful at " + t.UTC().Format(time.RFC3339)
+	}
+
+	for _, condition := range last.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			it.Status.SetCondition(v1.IntegrationConditionLastJobSucceeded, corev1.ConditionTrue, v1.IntegrationConditionJobSucceededReason,
+				fmt.Sprintf("job %s completed at %s (%s)", last.Name, condition.LastTransitionTime.UTC().Format(time.RFC3339), schedule))
+			return
+		case batchv1.JobFailed:
+			it.Status.SetCondition(v1.IntegrationConditionLastJobSucceeded, corev1.ConditionFalse, v1.IntegrationConditionJobFailedReason,
+				fmt.Sprintf("job %s failed at %s: %s (%s)", last.Name, condition.LastTransitionTime.UTC().Format(time.RFC3339), condition.Message, schedule))
+			return
+		}
+	}
+
+	it.Status.SetCondition(v1.IntegrationConditionLastJobSucceeded, corev1.ConditionUnknown, v1.IntegrationConditionJobRunningReason,
+		fmt.Sprintf("job %s is running (%s)", last.Name, schedule))
+}
+
+func jobStartTime(job *batchv1.Job) metav1.Time {
+	if job.Status.StartTime != nil {
+		return *job.Status.StartTime
 	}
+	return job.CreationTimestamp
 }
 
 func IsConditionTrue(it *v1.Integration, conditionType v1.IntegrationConditionType) bool {
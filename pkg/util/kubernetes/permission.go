@@ -19,7 +19,11 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strings"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,3 +59,87 @@ func CheckPermission(ctx context.Context, client client.Client, group, resource,
 		return sar.Status.Allowed, nil
 	}
 }
+
+// CheckUserPermission can be used to check if the given user is allowed to execute a given operation in the cluster.
+func CheckUserPermission(ctx context.Context, client client.Client, user authenticationv1.UserInfo, group, resource, namespace, name, verb string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sarReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:     group,
+				Resource:  resource,
+				Namespace: namespace,
+				Name:      name,
+				Verb:      verb,
+			},
+		},
+	}
+
+	sar, err := client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sarReview, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
+
+// AuthenticateToken returns the user the given bearer token belongs to, or nil if the token cannot be authenticated.
+func AuthenticateToken(ctx context.Context, client client.Client, token string) (*authenticationv1.UserInfo, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+
+	review, err := client.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !review.Status.Authenticated {
+		return nil, nil
+	}
+	return &review.Status.User, nil
+}
+
+// AuthorizeRequest authenticates the given HTTP request with its bearer token, and checks the user it belongs to is
+// allowed to execute the given operation. It replies with the corresponding error, and returns false, when the request
+// is not authorized.
+func AuthorizeRequest(w http.ResponseWriter, r *http.Request, client client.Client, group, resource, namespace, name, verb string) bool {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+		return false
+	}
+	user, err := AuthenticateToken(r.Context(), client, strings.TrimPrefix(authorization, "Bearer "))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	if user == nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "the bearer token cannot be authenticated", http.StatusUnauthorized)
+		return false
+	}
+	allowed, err := CheckUserPermission(r.Context(), client, *user, group, resource, namespace, name, verb)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	if !allowed {
+		target := resource
+		if name != "" {
+			target = resource + "/" + name
+		}
+		http.Error(w, fmt.Sprintf("user %s is not authorized to %s %s in namespace %s", user.Username, verb, target, namespace),
+			http.StatusForbidden)
+		return false
+	}
+	return true
+}
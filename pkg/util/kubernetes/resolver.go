@@ -29,11 +29,12 @@ import (
 )
 
 // ResolveSources --
-func ResolveSources(elements []v1.SourceSpec, mapLookup func(string) (*corev1.ConfigMap, error)) ([]v1.SourceSpec, error) {
+func ResolveSources(elements []v1.SourceSpec, mapLookup func(string) (*corev1.ConfigMap, error),
+	secretLookup func(string) (*corev1.Secret, error)) ([]v1.SourceSpec, error) {
 	for i := 0; i < len(elements); i++ {
 		r := &elements[i]
 
-		if err := Resolve(&r.DataSpec, mapLookup); err != nil {
+		if err := Resolve(&r.DataSpec, mapLookup, secretLookup); err != nil {
 			return nil, err
 		}
 	}
@@ -42,11 +43,12 @@ func ResolveSources(elements []v1.SourceSpec, mapLookup func(string) (*corev1.Co
 }
 
 // ResolveResource --
-func ResolveResource(elements []v1.ResourceSpec, mapLookup func(string) (*corev1.ConfigMap, error)) ([]v1.ResourceSpec, error) {
+func ResolveResource(elements []v1.ResourceSpec, mapLookup func(string) (*corev1.ConfigMap, error),
+	secretLookup func(string) (*corev1.Secret, error)) ([]v1.ResourceSpec, error) {
 	for i := 0; i < len(elements); i++ {
 		r := &elements[i]
 
-		if err := Resolve(&r.DataSpec, mapLookup); err != nil {
+		if err := Resolve(&r.DataSpec, mapLookup, secretLookup); err != nil {
 			return nil, err
 		}
 	}
@@ -55,29 +57,22 @@ func ResolveResource(elements []v1.ResourceSpec, mapLookup func(string) (*corev1
 }
 
 // Resolve --
-func Resolve(data *v1.DataSpec, mapLookup func(string) (*corev1.ConfigMap, error)) error {
+func Resolve(data *v1.DataSpec, mapLookup func(string) (*corev1.ConfigMap, error),
+	secretLookup func(string) (*corev1.Secret, error)) error {
 	// if it is a reference, get the content from the
-	// referenced ConfigMap
+	// referenced ConfigMap or Secret
 	if data.ContentRef != "" {
-		//look up the ConfigMap from the kubernetes cluster
-		cm, err := mapLookup(data.ContentRef)
+		content, err := lookupContent(data, mapLookup, secretLookup)
 		if err != nil {
 			return err
 		}
 
-		if cm == nil {
-			return fmt.Errorf("unable to find a ConfigMap with name: %s ", data.ContentRef)
-		}
-
 		//
 		// Replace ref source content with real content
 		//
-		key := data.ContentKey
-		if key == "" {
-			key = "content"
-		}
-		data.Content = cm.Data[key]
+		data.Content = content
 		data.ContentRef = ""
+		data.ContentRefKind = ""
 	}
 
 	if data.Compression {
@@ -94,6 +89,49 @@ func Resolve(data *v1.DataSpec, mapLookup func(string) (*corev1.ConfigMap, error
 	return nil
 }
 
+// lookupContent returns the content of the key of the ConfigMap or Secret the given data references
+func lookupContent(data *v1.DataSpec, mapLookup func(string) (*corev1.ConfigMap, error),
+	secretLookup func(string) (*corev1.Secret, error)) (string, error) {
+	key := data.GetContentKey()
+
+	switch data.GetContentRefKind() {
+	case v1.ContentRefKindConfigMap:
+		//look up the ConfigMap from the kubernetes cluster
+		cm, err := mapLookup(data.ContentRef)
+		if err != nil {
+			return "", err
+		}
+		if cm == nil {
+			return "", fmt.Errorf("unable to find a ConfigMap with name: %s ", data.ContentRef)
+		}
+		if content, ok := cm.Data[key]; ok {
+			return content, nil
+		}
+		if content, ok := cm.BinaryData[key]; ok {
+			return string(content), nil
+		}
+		return "", fmt.Errorf("unable to find key %s in ConfigMap %s", key, data.ContentRef)
+	case v1.ContentRefKindSecret:
+		secret, err := secretLookup(data.ContentRef)
+		if err != nil {
+			return "", err
+		}
+		if secret == nil {
+			return "", fmt.Errorf("unable to find a Secret with name: %s ", data.ContentRef)
+		}
+		if content, ok := secret.Data[key]; ok {
+			return string(content), nil
+		}
+		if content, ok := secret.StringData[key]; ok {
+			return content, nil
+		}
+		return "", fmt.Errorf("unable to find key %s in Secret %s", key, data.ContentRef)
+	default:
+		return "", fmt.Errorf("unsupported content reference kind %s, must be one of %s or %s",
+			data.ContentRefKind, v1.ContentRefKindConfigMap, v1.ContentRefKindSecret)
+	}
+}
+
 // ResolveIntegrationSources --
 func ResolveIntegrationSources(
 	context context.Context,
@@ -105,19 +143,8 @@ func ResolveIntegrationSources(
 		return nil, nil
 	}
 
-	return ResolveSources(integration.Sources(), func(name string) (*corev1.ConfigMap, error) {
-		// the config map could be part of the resources created
-		// by traits
-		cm := resources.GetConfigMap(func(m *corev1.ConfigMap) bool {
-			return m.Name == name
-		})
-
-		if cm != nil {
-			return cm, nil
-		}
-
-		return GetConfigMap(context, client, name, integration.Namespace)
-	})
+	mapLookup, secretLookup := integrationLookups(context, client, integration, resources)
+	return ResolveSources(integration.Sources(), mapLookup, secretLookup)
 }
 
 // ResolveIntegrationResources --
@@ -126,13 +153,27 @@ func ResolveIntegrationResources(
 	context context.Context,
 	client controller.Reader,
 	integration *v1.Integration,
-	resources *Collection) ([]v1.ResourceSpec, error) {
+	collection *Collection) ([]v1.ResourceSpec, error) {
 
 	if integration == nil {
 		return nil, nil
 	}
 
-	return ResolveResource(integration.Spec.Resources, func(name string) (*corev1.ConfigMap, error) {
+	mapLookup, secretLookup := integrationLookups(context, client, integration, collection)
+	resources := make([]v1.ResourceSpec, 0, len(integration.Spec.Resources))
+	resources = append(resources, integration.Spec.Resources...)
+	return ResolveResource(resources, mapLookup, secretLookup)
+}
+
+// integrationLookups returns the functions looking up the ConfigMaps and the Secrets the content of the
+// given integration is referenced from
+func integrationLookups(
+	context context.Context,
+	client controller.Reader,
+	integration *v1.Integration,
+	resources *Collection) (func(string) (*corev1.ConfigMap, error), func(string) (*corev1.Secret, error)) {
+
+	mapLookup := func(name string) (*corev1.ConfigMap, error) {
 		// the config map could be part of the resources created
 		// by traits
 		cm := resources.GetConfigMap(func(m *corev1.ConfigMap) bool {
@@ -144,5 +185,10 @@ func ResolveIntegrationResources(
 		}
 
 		return GetConfigMap(context, client, name, integration.Namespace)
-	})
+	}
+	secretLookup := func(name string) (*corev1.Secret, error) {
+		return GetSecret(context, client, name, integration.Namespace)
+	}
+
+	return mapLookup, secretLookup
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/jvm"
 	"github.com/apache/camel-k/pkg/util/log"
 )
 
@@ -47,9 +48,9 @@ func (c *Command) Do(ctx context.Context) error {
 		return err
 	}
 
-	mvnCmd := "mvn"
-	if c, ok := os.LookupEnv("MAVEN_CMD"); ok {
-		mvnCmd = c
+	mvnCmd, err := findMavenCommand()
+	if err != nil {
+		return err
 	}
 
 	args := make([]string, 0)
@@ -71,6 +72,17 @@ func (c *Command) Do(ctx context.Context) error {
 		args = append(args, "--settings", settingsPath)
 	}
 
+	settingsSecurityPath := path.Join(c.context.Path, "settings-security.xml")
+	settingsSecurityExists, err := util.FileExists(settingsSecurityPath)
+	if err != nil {
+		return err
+	}
+
+	if settingsSecurityExists {
+		// The master password, used to decrypt the encrypted passwords of the settings
+		args = append(args, "-Dsettings.security="+settingsSecurityPath)
+	}
+
 	args = append(args, c.context.AdditionalArguments...)
 
 	cmd := exec.CommandContext(ctx, mvnCmd, args...)
@@ -117,6 +129,16 @@ func (c *Command) Do(ctx context.Context) error {
 		cmd.Env = env
 	}
 
+	// Maven requires the JDK, that may not be in the PATH of custom builder images
+	if os.Getenv("JAVA_HOME") == "" {
+		if javaHome, err := jvm.FindJavaHome(); err == nil {
+			if cmd.Env == nil {
+				cmd.Env = os.Environ()
+			}
+			cmd.Env = append(cmd.Env, "JAVA_HOME="+javaHome)
+		}
+	}
+
 	Log.WithValues("MAVEN_OPTS", mavenOptions).Infof("executing: %s", strings.Join(cmd.Args, " "))
 
 	stdOut, err := cmd.StdoutPipe()
@@ -150,6 +172,39 @@ func (c *Command) Do(ctx context.Context) error {
 	return cmd.Wait()
 }
 
+// mavenHomeCandidates are the directories Maven is commonly installed in, by the Maven images and packages
+var mavenHomeCandidates = []string{"/usr/share/maven", "/opt/maven", "/usr/local/maven"}
+
+// findMavenCommand returns the Maven executable, as set with the MAVEN_CMD environment variable, or otherwise
+// detected from the PATH, from the MAVEN_HOME and M2_HOME environment variables, or from the common installation
+// directories, so that the builds can be run in custom builder images
+func findMavenCommand() (string, error) {
+	if c, ok := os.LookupEnv("MAVEN_CMD"); ok {
+		return c, nil
+	}
+
+	if _, err := exec.LookPath("mvn"); err == nil {
+		return "mvn", nil
+	}
+
+	homes := make([]string, 0, len(mavenHomeCandidates)+2)
+	for _, env := range []string{"MAVEN_HOME", "M2_HOME"} {
+		if home := os.Getenv(env); home != "" {
+			homes = append(homes, home)
+		}
+	}
+	homes = append(homes, mavenHomeCandidates...)
+	for _, home := range homes {
+		mvn := path.Join(home, "bin", "mvn")
+		if info, err := os.Stat(mvn); err == nil && !info.IsDir() {
+			return mvn, nil
+		}
+	}
+
+	return "", errors.New("unable to find the Maven executable, either the MAVEN_CMD or MAVEN_HOME environment " +
+		"variable must be set, or the mvn executable must be in the PATH")
+}
+
 func NewContext(buildDir string) Context {
 	return Context{
 		Path:                buildDir,
@@ -159,12 +214,13 @@ func NewContext(buildDir string) Context {
 }
 
 type Context struct {
-	Path                string
-	ExtraMavenOpts      []string
-	SettingsContent     []byte
-	AdditionalArguments []string
-	AdditionalEntries   map[string]interface{}
-	LocalRepository     string
+	Path                    string
+	ExtraMavenOpts          []string
+	SettingsContent         []byte
+	SettingsSecurityContent []byte
+	AdditionalArguments     []string
+	AdditionalEntries       map[string]interface{}
+	LocalRepository         string
 }
 
 func (c *Context) AddEntry(id string, entry interface{}) {
@@ -202,6 +258,12 @@ func generateProjectStructure(context Context, project Project) error {
 		}
 	}
 
+	if context.SettingsSecurityContent != nil {
+		if err := util.WriteFileWithContent(context.Path, "settings-security.xml", context.SettingsSecurityContent); err != nil {
+			return err
+		}
+	}
+
 	for k, v := range context.AdditionalEntries {
 		var bytes []byte
 		var err error
@@ -234,8 +296,7 @@ func generateProjectStructure(context Context, project Project) error {
 //
 // The artifact id is in the form of:
 //
-//     <groupId>:<artifactId>[:<packagingType>[:<classifier>]]:(<version>|'?')
-//
+//	<groupId>:<artifactId>[:<packagingType>[:<classifier>]]:(<version>|'?')
 func ParseGAV(gav string) (Dependency, error) {
 	// <groupId>:<artifactId>[:<packagingType>[:<classifier>]]:(<version>|'?')
 	dep := Dependency{}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindMavenCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "maven-home")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, os.MkdirAll(path.Join(dir, "bin"), 0o755))
+	assert.Nil(t, ioutil.WriteFile(path.Join(dir, "bin", "mvn"), []byte("#!/bin/sh\n"), 0o755))
+
+	for _, env := range []string{"MAVEN_CMD", "MAVEN_HOME", "M2_HOME", "PATH"} {
+		if value, ok := os.LookupEnv(env); ok {
+			defer os.Setenv(env, value)
+		} else {
+			defer os.Unsetenv(env)
+		}
+		assert.Nil(t, os.Unsetenv(env))
+	}
+	candidates := mavenHomeCandidates
+	mavenHomeCandidates = []string{}
+	defer func() {
+		mavenHomeCandidates = candidates
+	}()
+
+	_, err = findMavenCommand()
+	assert.NotNil(t, err)
+
+	// Detected from the common installation directories
+	mavenHomeCandidates = []string{path.Join(dir, "missing"), dir}
+	mvn, err := findMavenCommand()
+	assert.Nil(t, err)
+	assert.Equal(t, path.Join(dir, "bin", "mvn"), mvn)
+
+	// Detected from the Maven home
+	mavenHomeCandidates = []string{}
+	assert.Nil(t, os.Setenv("M2_HOME", dir))
+	mvn, err = findMavenCommand()
+	assert.Nil(t, err)
+	assert.Equal(t, path.Join(dir, "bin", "mvn"), mvn)
+
+	// Detected from the PATH
+	assert.Nil(t, os.Setenv("PATH", path.Join(dir, "bin")))
+	mvn, err = findMavenCommand()
+	assert.Nil(t, err)
+	assert.Equal(t, "mvn", mvn)
+
+	// Set explicitly
+	assert.Nil(t, os.Setenv("MAVEN_CMD", "/opt/tools/mvnw"))
+	mvn, err = findMavenCommand()
+	assert.Nil(t, err)
+	assert.Equal(t, "/opt/tools/mvnw", mvn)
+}
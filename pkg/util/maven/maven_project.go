@@ -152,6 +152,38 @@ func (p *Project) AddEncodedDependencyExclusion(gav string, exclusion Exclusion)
 	}
 }
 
+// ExcludeDependency removes the given dependency from maven's dependencies,
+// and excludes it from the transitive dependencies of the remaining ones
+func (p *Project) ExcludeDependency(groupID string, artifactID string) {
+	dependencies := make([]Dependency, 0, len(p.Dependencies))
+	for _, d := range p.Dependencies {
+		if d.GroupID != groupID || d.ArtifactID != artifactID {
+			dependencies = append(dependencies, d)
+		}
+	}
+	p.Dependencies = dependencies
+
+	for _, d := range p.Dependencies {
+		p.AddDependencyExclusion(d, Exclusion{GroupID: groupID, ArtifactID: artifactID})
+	}
+}
+
+// AddManagedDependency adds a dependency to maven's dependency management, or replaces the existing one,
+// so that its version is enforced, including when it's a transitive dependency
+func (p *Project) AddManagedDependency(dep Dependency) {
+	if p.DependencyManagement == nil {
+		p.DependencyManagement = &DependencyManagement{Dependencies: make([]Dependency, 0)}
+	}
+	for i, d := range p.DependencyManagement.Dependencies {
+		if d.GroupID == dep.GroupID && d.ArtifactID == dep.ArtifactID && d.Type == dep.Type && d.Classifier == dep.Classifier {
+			p.DependencyManagement.Dependencies[i] = dep
+			return
+		}
+	}
+
+	p.DependencyManagement.Dependencies = append(p.DependencyManagement.Dependencies, dep)
+}
+
 type propertiesEntry struct {
 	XMLName xml.Name
 	Value   string `xml:",chardata"`
@@ -237,8 +269,8 @@ func NewRepository(repo string) v1.Repository {
 	return r
 }
 
-func NewMirror(repo string) Mirror {
-	m := Mirror{}
+func NewMirror(repo string) v1.Mirror {
+	m := v1.Mirror{}
 	if idx := strings.Index(repo, "@"); idx != -1 {
 		m.URL = repo[:idx]
 
@@ -137,6 +137,32 @@ func TestPomGeneration(t *testing.T) {
 	assert.Equal(t, expectedPom, string(pom))
 }
 
+func TestExcludeDependency(t *testing.T) {
+	project := NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0")
+	project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-core", "")
+	project.AddDependencyGAV("io.quarkus", "quarkus-smallrye-health", "")
+
+	project.ExcludeDependency("io.quarkus", "quarkus-smallrye-health")
+
+	assert.Len(t, project.Dependencies, 1)
+	assert.Equal(t, "camel-quarkus-core", project.Dependencies[0].ArtifactID)
+	assert.Equal(t, &[]Exclusion{
+		{GroupID: "io.quarkus", ArtifactID: "quarkus-smallrye-health"},
+	}, project.Dependencies[0].Exclusions)
+}
+
+func TestAddManagedDependency(t *testing.T) {
+	project := NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0")
+	project.AddManagedDependency(NewDependency("org.apache.camel", "camel-core", "3.11.0"))
+	project.AddManagedDependency(NewDependency("org.apache.camel", "camel-log", "3.11.1"))
+	project.AddManagedDependency(NewDependency("org.apache.camel", "camel-core", "3.11.1"))
+
+	assert.Equal(t, []Dependency{
+		NewDependency("org.apache.camel", "camel-core", "3.11.1"),
+		NewDependency("org.apache.camel", "camel-log", "3.11.1"),
+	}, project.DependencyManagement.Dependencies)
+}
+
 func TestParseSimpleGAV(t *testing.T) {
 	dep, err := ParseGAV("org.apache.camel:camel-core:2.21.1")
 
@@ -20,8 +20,14 @@ package maven
 import (
 	"bytes"
 	"encoding/xml"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -57,7 +63,7 @@ func NewSettings() Settings {
 	}
 }
 
-func NewDefaultSettings(repositories []v1.Repository, mirrors []Mirror) Settings {
+func NewDefaultSettings(repositories []v1.Repository, mirrors []v1.Mirror) Settings {
 	settings := NewSettings()
 
 	var additionalRepos []v1.Repository
@@ -86,6 +92,122 @@ func NewDefaultSettings(repositories []v1.Repository, mirrors []Mirror) Settings
 	return settings
 }
 
+// NewProxies returns the proxies that are declared with the attributes of the given repositories, e.g.:
+//
+//     https://repo.acme.com/maven2@id=acme@proxy=proxy.acme.com:3128@proxyusername=user@proxypassword={...}
+//
+// Maven uses the first active proxy, matching the protocol of a repository, whose non-proxy hosts do not
+// match the repository host. The hosts of the other repositories, including the default ones, are thus
+// added to the non-proxy hosts of each proxy, so that it's only used for the repository it's declared for.
+func NewProxies(repositories []string) []Proxy {
+	hosts := make([]string, 0)
+	for _, repo := range append(strings.Split(DefaultMavenRepositories, ","), repositories...) {
+		if u, err := url.Parse(strings.SplitN(repo, "@", 2)[0]); err == nil && u.Hostname() != "" {
+			util.StringSliceUniqueAdd(&hosts, u.Hostname())
+		}
+	}
+
+	var proxies []Proxy
+	for i, repo := range repositories {
+		idx := strings.Index(repo, "@")
+		if idx == -1 {
+			continue
+		}
+		u, err := url.Parse(repo[:idx])
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+
+		proxy := Proxy{
+			ID:       fmt.Sprintf("proxy-%03d", i),
+			Active:   true,
+			Protocol: u.Scheme,
+		}
+		nonProxyHosts := make([]string, 0)
+		for _, attribute := range strings.Split(repo[idx+1:], "@") {
+			switch {
+			case strings.HasPrefix(attribute, "id="):
+				proxy.ID = attribute[3:] + "-proxy"
+			case strings.HasPrefix(attribute, "proxy="):
+				proxy.Host = attribute[6:]
+				if host, port, err := net.SplitHostPort(proxy.Host); err == nil {
+					proxy.Host = host
+					proxy.Port = port
+				}
+			case strings.HasPrefix(attribute, "proxyusername="):
+				proxy.Username = attribute[14:]
+			case strings.HasPrefix(attribute, "proxypassword="):
+				proxy.Password = attribute[14:]
+			case strings.HasPrefix(attribute, "nonproxyhosts="):
+				nonProxyHosts = append(nonProxyHosts, strings.Split(attribute[14:], "|")...)
+			}
+		}
+		if proxy.Host == "" {
+			continue
+		}
+
+		for _, host := range hosts {
+			if host != u.Hostname() {
+				util.StringSliceUniqueAdd(&nonProxyHosts, host)
+			}
+		}
+		proxy.NonProxyHosts = strings.Join(nonProxyHosts, "|")
+
+		proxies = append(proxies, proxy)
+	}
+
+	return proxies
+}
+
+var (
+	mirrorsStartTag = regexp.MustCompile(`<mirrors\s*>`)
+	mirrorsEmptyTag = regexp.MustCompile(`<mirrors\s*/>`)
+	settingsEndTag  = regexp.MustCompile(`</settings\s*>`)
+)
+
+// AddMirrors adds the given mirrors to the Maven settings, ahead of the mirrors they already declare, so that they
+// take precedence. The settings are edited in place, so that the elements that are not modeled by Settings,
+// like the servers credentials, are preserved.
+func AddMirrors(settings []byte, mirrors []v1.Mirror) ([]byte, error) {
+	if len(mirrors) == 0 {
+		return settings, nil
+	}
+	if len(bytes.TrimSpace(settings)) == 0 {
+		s := NewSettings()
+		s.Mirrors = mirrors
+		return util.EncodeXML(s)
+	}
+
+	w := &bytes.Buffer{}
+	e := xml.NewEncoder(w)
+	for _, mirror := range mirrors {
+		if err := e.EncodeElement(mirror, xml.StartElement{Name: xml.Name{Local: "mirror"}}); err != nil {
+			return nil, err
+		}
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	elements := w.Bytes()
+
+	if loc := mirrorsStartTag.FindIndex(settings); loc != nil {
+		return concat(settings[:loc[1]], elements, settings[loc[1]:]), nil
+	}
+	if loc := mirrorsEmptyTag.FindIndex(settings); loc != nil {
+		return concat(settings[:loc[0]], []byte("<mirrors>"), elements, []byte("</mirrors>"), settings[loc[1]:]), nil
+	}
+	locs := settingsEndTag.FindAllIndex(settings, -1)
+	if len(locs) == 0 {
+		return nil, errors.New("invalid Maven settings: missing settings element")
+	}
+	loc := locs[len(locs)-1]
+	return concat(settings[:loc[0]], []byte("<mirrors>"), elements, []byte("</mirrors>"), settings[loc[0]:]), nil
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
 func SettingsConfigMap(namespace string, name string, settings Settings) (*corev1.ConfigMap, error) {
 	data, err := util.EncodeXML(settings)
 	if err != nil {
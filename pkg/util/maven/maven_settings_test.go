@@ -18,6 +18,9 @@ limitations under the License.
 package maven
 
 import (
+	"encoding/xml"
+	"io/ioutil"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,6 +33,7 @@ const expectedSettings = `<?xml version="1.0" encoding="UTF-8"?>
 <settings xmlns="http://maven.apache.org/SETTINGS/1.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ` +
 	`xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.0.0 https://maven.apache.org/xsd/settings-1.0.0.xsd">
   <localRepository>/tmp/artifacts/m2</localRepository>
+  <proxies></proxies>
   <profiles>
     <profile>
       <id>my-profile</id>
@@ -61,6 +65,7 @@ const expectedDefaultSettings = `<?xml version="1.0" encoding="UTF-8"?>
 <settings xmlns="http://maven.apache.org/SETTINGS/1.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ` +
 	`xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.0.0 https://maven.apache.org/xsd/settings-1.0.0.xsd">
   <localRepository></localRepository>
+  <proxies></proxies>
   <profiles>
     <profile>
       <id>maven-settings</id>
@@ -104,6 +109,7 @@ const expectedDefaultSettingsWithExtraRepo = `<?xml version="1.0" encoding="UTF-
 <settings xmlns="http://maven.apache.org/SETTINGS/1.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ` +
 	`xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.0.0 https://maven.apache.org/xsd/settings-1.0.0.xsd">
   <localRepository></localRepository>
+  <proxies></proxies>
   <profiles>
     <profile>
       <id>maven-settings</id>
@@ -209,7 +215,7 @@ func TestSettingsGeneration(t *testing.T) {
 }
 
 func TestDefaultSettingsGeneration(t *testing.T) {
-	settings := NewDefaultSettings([]v1.Repository{}, []Mirror{})
+	settings := NewDefaultSettings([]v1.Repository{}, []v1.Mirror{})
 
 	content, err := util.EncodeXML(settings)
 
@@ -224,7 +230,7 @@ func TestDefaultSettingsGenerationWithAdditionalRepo(t *testing.T) {
 		NewRepository("https://repo1.maven.org/maven2@id=central"),
 		NewRepository("https://foo.bar.org/repo@id=foo"),
 	}
-	mirrors := []Mirror{
+	mirrors := []v1.Mirror{
 		NewMirror("https://foo.bar.org/repo@id=foo@mirrorOf=*"),
 	}
 	settings := NewDefaultSettings(repositories, mirrors)
@@ -237,8 +243,51 @@ func TestDefaultSettingsGenerationWithAdditionalRepo(t *testing.T) {
 	assert.Equal(t, expectedDefaultSettingsWithExtraRepo, string(content))
 }
 
+func TestNewProxies(t *testing.T) {
+	proxies := NewProxies([]string{
+		"https://repo.acme.com/maven2@id=acme@proxy=proxy.acme.com:3128@proxyusername=user@proxypassword={COQLCE6DU6GtcS5P=}",
+		"http://nexus.acme.com/repository/public@nonproxyhosts=*.internal|localhost@proxy=proxy.acme.com",
+		"https://foo.bar.org/repo@id=foo",
+	})
+
+	assert.Equal(t, []Proxy{
+		{
+			ID:            "acme-proxy",
+			Active:        true,
+			Protocol:      "https",
+			Host:          "proxy.acme.com",
+			Port:          "3128",
+			Username:      "user",
+			Password:      "{COQLCE6DU6GtcS5P=}",
+			NonProxyHosts: "repo.maven.apache.org|nexus.acme.com|foo.bar.org",
+		},
+		{
+			ID:            "proxy-001",
+			Active:        true,
+			Protocol:      "http",
+			Host:          "proxy.acme.com",
+			NonProxyHosts: "*.internal|localhost|repo.maven.apache.org|repo.acme.com|foo.bar.org",
+		},
+	}, proxies)
+
+	assert.Nil(t, NewProxies([]string{"https://foo.bar.org/repo@id=foo"}))
+}
+
+func TestGenerateProjectStructureWithSettingsSecurity(t *testing.T) {
+	mc := NewContext(t.TempDir())
+	mc.SettingsContent = []byte("settings")
+	mc.SettingsSecurityContent = []byte("settings-security")
+
+	err := generateProjectStructure(mc, NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0"))
+	assert.Nil(t, err)
+
+	content, err := ioutil.ReadFile(path.Join(mc.Path, "settings-security.xml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "settings-security", string(content))
+}
+
 func TestCreateSettingsConfigMap(t *testing.T) {
-	settings := NewDefaultSettings([]v1.Repository{}, []Mirror{})
+	settings := NewDefaultSettings([]v1.Repository{}, []v1.Mirror{})
 
 	configMap, err := SettingsConfigMap("foo", "bar", settings)
 	assert.Nil(t, err)
@@ -251,3 +300,31 @@ func TestCreateSettingsConfigMap(t *testing.T) {
 
 	assert.Equal(t, string(content), configMap.Data["settings.xml"])
 }
+
+func TestAddMirrors(t *testing.T) {
+	mirrors := []v1.Mirror{
+		NewMirror("https://foo.bar.org/repo@id=foo@mirrorOf=central"),
+	}
+	mirror := `<mirror><id>foo</id><url>https://foo.bar.org/repo</url><mirrorOf>central</mirrorOf></mirror>`
+
+	content, err := AddMirrors([]byte(`<settings><mirrors><mirror><id>bar</id></mirror></mirrors></settings>`), mirrors)
+	assert.Nil(t, err)
+	assert.Equal(t, `<settings><mirrors>`+mirror+`<mirror><id>bar</id></mirror></mirrors></settings>`, string(content))
+
+	content, err = AddMirrors([]byte(`<settings><servers><server><id>bar</id></server></servers><mirrors/></settings>`), mirrors)
+	assert.Nil(t, err)
+	assert.Equal(t, `<settings><servers><server><id>bar</id></server></servers><mirrors>`+mirror+`</mirrors></settings>`, string(content))
+
+	content, err = AddMirrors([]byte(`<settings><localRepository>/tmp</localRepository></settings>`), mirrors)
+	assert.Nil(t, err)
+	assert.Equal(t, `<settings><localRepository>/tmp</localRepository><mirrors>`+mirror+`</mirrors></settings>`, string(content))
+
+	content, err = AddMirrors(nil, mirrors)
+	assert.Nil(t, err)
+	settings := Settings{}
+	assert.Nil(t, xml.Unmarshal(content, &settings))
+	assert.Equal(t, mirrors, settings.Mirrors)
+
+	_, err = AddMirrors([]byte(`<project></project>`), mirrors)
+	assert.NotNil(t, err)
+}
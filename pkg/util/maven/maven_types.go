@@ -23,13 +23,6 @@ import (
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
 )
 
-type Mirror struct {
-	ID       string `xml:"id"`
-	Name     string `xml:"name,omitempty"`
-	URL      string `xml:"url"`
-	MirrorOf string `xml:"mirrorOf"`
-}
-
 type Build struct {
 	DefaultGoal string             `xml:"defaultGoal,omitempty"`
 	Plugins     []Plugin           `xml:"plugins>plugin,omitempty"`
@@ -55,12 +48,25 @@ type Properties map[string]string
 // Settings models a Maven settings
 type Settings struct {
 	XMLName           xml.Name
-	XMLNs             string    `xml:"xmlns,attr"`
-	XMLNsXsi          string    `xml:"xmlns:xsi,attr"`
-	XsiSchemaLocation string    `xml:"xsi:schemaLocation,attr"`
-	LocalRepository   string    `xml:"localRepository"`
-	Profiles          []Profile `xml:"profiles>profile,omitempty"`
-	Mirrors           []Mirror  `xml:"mirrors>mirror,omitempty"`
+	XMLNs             string      `xml:"xmlns,attr"`
+	XMLNsXsi          string      `xml:"xmlns:xsi,attr"`
+	XsiSchemaLocation string      `xml:"xsi:schemaLocation,attr"`
+	LocalRepository   string      `xml:"localRepository"`
+	Proxies           []Proxy     `xml:"proxies>proxy,omitempty"`
+	Profiles          []Profile   `xml:"profiles>profile,omitempty"`
+	Mirrors           []v1.Mirror `xml:"mirrors>mirror,omitempty"`
+}
+
+// Proxy models a Maven settings proxy, whose password can be encrypted with the settings security master password
+type Proxy struct {
+	ID            string `xml:"id"`
+	Active        bool   `xml:"active"`
+	Protocol      string `xml:"protocol"`
+	Host          string `xml:"host"`
+	Port          string `xml:"port,omitempty"`
+	Username      string `xml:"username,omitempty"`
+	Password      string `xml:"password,omitempty"`
+	NonProxyHosts string `xml:"nonProxyHosts,omitempty"`
 }
 
 // Project models a Maven project
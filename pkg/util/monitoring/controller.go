@@ -27,6 +27,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/apache/camel-k/pkg/util/telemetry"
 )
 
 type resultLabelValue string
@@ -68,7 +70,10 @@ func NewInstrumentedReconciler(rec reconcile.Reconciler, gvk schema.GroupVersion
 func (r *instrumentedReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	timer := NewTimer()
 
+	ctx, span := telemetry.StartSpan(ctx, "Reconcile "+r.gvk.Kind,
+		telemetry.ObjectAttributes(r.gvk.Kind, request.Namespace, request.Name)...)
 	res, err := r.reconciler.Reconcile(ctx, request)
+	telemetry.EndSpan(span, err)
 
 	labels := prometheus.Labels{
 		namespaceLabel: request.Namespace,
@@ -0,0 +1,263 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oauth2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// ClientLabel marks the Secrets holding the credentials of an OAuth2 client, the operator acquires access tokens with
+	ClientLabel = "camel.apache.org/oauth2.client"
+	// TokenLabel is set on the Secrets holding the access tokens, to the name of the Secret of the OAuth2 client
+	TokenLabel = "camel.apache.org/oauth2.token"
+	// RefreshBeforeAnnotation sets how long before its expiry an access token is refreshed, as a duration
+	RefreshBeforeAnnotation = "camel.apache.org/oauth2.refresh-before"
+	// ClientVersionAnnotation records the version of the Secret of the OAuth2 client an access token has been acquired with
+	ClientVersionAnnotation = "camel.apache.org/oauth2.client-version"
+
+	// ProviderKey is the optional key of the well-known provider whose token URL is used
+	ProviderKey = "provider"
+	// TenantKey is the optional key of the Microsoft tenant, defaulting to common
+	TenantKey = "tenant"
+	// TokenURLKey is the key of the token URL, required when no provider is set
+	TokenURLKey = "token-url"
+	// ClientIDKey is the key of the client ID
+	ClientIDKey = "client-id"
+	// ClientSecretKey is the key of the client secret
+	ClientSecretKey = "client-secret"
+	// RefreshTokenKey is the optional key of the refresh token, the client credentials flow is used when missing
+	RefreshTokenKey = "refresh-token"
+	// ScopesKey is the optional key of the space separated scopes
+	ScopesKey = "scopes"
+
+	// AccessTokenKey is the key of the access token in the token Secret
+	AccessTokenKey = "access-token"
+	// TokenTypeKey is the key of the token type in the token Secret
+	TokenTypeKey = "token-type"
+	// ExpiryKey is the key of the expiry of the access token in the token Secret, in RFC 3339 format
+	ExpiryKey = "expiry"
+
+	// DefaultRefreshBefore is how long before its expiry an access token is refreshed by default
+	DefaultRefreshBefore = 5 * time.Minute
+)
+
+// providers are the token URLs of well-known providers, by name
+var providers = map[string]func(tenant string) string{
+	"salesforce": func(string) string {
+		return "https://login.salesforce.com/services/oauth2/token"
+	},
+	"google": func(string) string {
+		return "https://oauth2.googleapis.com/token"
+	},
+	"microsoft": func(tenant string) string {
+		if tenant == "" {
+			tenant = "common"
+		}
+		return fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", tenant)
+	},
+}
+
+// Credentials are the credentials of an OAuth2 client
+type Credentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	Scopes       []string
+}
+
+// CredentialsFromSecret reads the credentials of an OAuth2 client from a Secret. The token URL set with the token-url key
+// must be an https URL starting with one of the allowed token URLs.
+func CredentialsFromSecret(secret *corev1.Secret, allowedTokenURLs []string) (*Credentials, error) {
+	credentials := Credentials{
+		TokenURL:     string(secret.Data[TokenURLKey]),
+		ClientID:     string(secret.Data[ClientIDKey]),
+		ClientSecret: string(secret.Data[ClientSecretKey]),
+		RefreshToken: string(secret.Data[RefreshTokenKey]),
+		Scopes:       strings.Fields(string(secret.Data[ScopesKey])),
+	}
+
+	if provider := string(secret.Data[ProviderKey]); provider != "" {
+		tokenURL, ok := providers[provider]
+		if !ok {
+			return nil, fmt.Errorf("unknown OAuth2 provider %q in secret %s", provider, secret.Name)
+		}
+		if credentials.TokenURL == "" {
+			credentials.TokenURL = tokenURL(string(secret.Data[TenantKey]))
+		}
+	}
+
+	if credentials.TokenURL == "" {
+		return nil, fmt.Errorf("either the %s or the %s key must be set in secret %s", ProviderKey, TokenURLKey, secret.Name)
+	}
+	if _, ok := secret.Data[TokenURLKey]; ok {
+		if err := checkTokenURL(credentials.TokenURL, allowedTokenURLs); err != nil {
+			return nil, fmt.Errorf("invalid %s key in secret %s: %w", TokenURLKey, secret.Name, err)
+		}
+	}
+	if credentials.ClientID == "" {
+		return nil, fmt.Errorf("the %s key must be set in secret %s", ClientIDKey, secret.Name)
+	}
+
+	return &credentials, nil
+}
+
+// checkTokenURL checks that the token URL is an https URL, with the same host as one of the allowed token URLs
+// and a path starting with its path
+func checkTokenURL(tokenURL string, allowedTokenURLs []string) error {
+	u, err := url.Parse(tokenURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("the token URL %s is not an https URL", tokenURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("the token URL %s must not have user information", tokenURL)
+	}
+	if strings.Contains(u.Path+"/", "/../") {
+		return fmt.Errorf("the token URL %s must not have parent path segments", tokenURL)
+	}
+	for _, allowed := range allowedTokenURLs {
+		a, err := url.Parse(allowed)
+		if err != nil || a.Scheme != "https" {
+			continue
+		}
+		if strings.EqualFold(u.Host, a.Host) && strings.HasPrefix(urlPath(u), urlPath(a)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the token URL %s is not allowed by the integration platform", tokenURL)
+}
+
+func urlPath(u *url.URL) string {
+	if p := u.EscapedPath(); p != "" {
+		return p
+	}
+	return "/"
+}
+
+// Token acquires an access token, with the refresh token flow when the credentials have a refresh token,
+// with the client credentials flow otherwise
+func (c *Credentials) Token(ctx context.Context) (*oauth2.Token, error) {
+	if c.RefreshToken != "" {
+		config := oauth2.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				TokenURL: c.TokenURL,
+			},
+			Scopes: c.Scopes,
+		}
+		return config.TokenSource(ctx, &oauth2.Token{RefreshToken: c.RefreshToken}).Token()
+	}
+
+	config := clientcredentials.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		TokenURL:     c.TokenURL,
+		Scopes:       c.Scopes,
+	}
+	return config.Token(ctx)
+}
+
+// TokenSecretName returns the name of the Secret holding the access tokens acquired with the given OAuth2 client Secret
+func TokenSecretName(client *corev1.Secret) string {
+	return client.Name + "-token"
+}
+
+// NewTokenSecret returns the Secret holding the given access token, owned by the OAuth2 client Secret.
+// The refresh token is stored as well when the provider has rotated it.
+func NewTokenSecret(client *corev1.Secret, token *oauth2.Token) *corev1.Secret {
+	controller := true
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: client.Namespace,
+			Name:      TokenSecretName(client),
+			Labels: map[string]string{
+				TokenLabel: client.Name,
+			},
+			Annotations: map[string]string{
+				ClientVersionAnnotation: client.ResourceVersion,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "Secret",
+					Name:       client.Name,
+					UID:        client.UID,
+					Controller: &controller,
+				},
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			AccessTokenKey: []byte(token.AccessToken),
+			TokenTypeKey:   []byte(token.Type()),
+		},
+	}
+	if !token.Expiry.IsZero() {
+		secret.Data[ExpiryKey] = []byte(token.Expiry.UTC().Format(time.RFC3339))
+	}
+	if token.RefreshToken != "" && token.RefreshToken != string(client.Data[RefreshTokenKey]) {
+		secret.Data[RefreshTokenKey] = []byte(token.RefreshToken)
+	}
+	return &secret
+}
+
+// IsAcquiredWith returns whether the access token held by the token Secret has been acquired with the current
+// version of the OAuth2 client Secret
+func IsAcquiredWith(client *corev1.Secret, token *corev1.Secret) bool {
+	return token.Annotations[ClientVersionAnnotation] == client.ResourceVersion
+}
+
+// RefreshTime returns when the access token held by the token Secret must be refreshed, or the zero time
+// when it does not expire
+func RefreshTime(client *corev1.Secret, token *corev1.Secret) (time.Time, error) {
+	expiry, ok := token.Data[ExpiryKey]
+	if !ok {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, string(expiry))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	refreshBefore := DefaultRefreshBefore
+	if v, ok := client.Annotations[RefreshBeforeAnnotation]; ok {
+		if refreshBefore, err = time.ParseDuration(v); err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s annotation on secret %s: %w", RefreshBeforeAnnotation, client.Name, err)
+		}
+	}
+	return t.Add(-refreshBefore), nil
+}
@@ -0,0 +1,167 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oauth2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	xoauth2 "golang.org/x/oauth2"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTokenServer(t *testing.T, refreshToken string) *httptest.Server {
+	t.Helper()
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Form.Get("grant_type") {
+		case "client_credentials":
+			fmt.Fprint(w, `{"access_token":"client-token","token_type":"Bearer","expires_in":3600}`)
+		case "refresh_token":
+			assert.Equal(t, "the-refresh-token", r.Form.Get("refresh_token"))
+			fmt.Fprintf(w, `{"access_token":"refreshed-token","token_type":"Bearer","expires_in":3600,"refresh_token":%q}`, refreshToken)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+// clientContext returns a context the access tokens are requested with the client of the token server in
+func clientContext(server *httptest.Server) context.Context {
+	return context.WithValue(context.TODO(), xoauth2.HTTPClient, server.Client())
+}
+
+func TestCredentialsFromSecret(t *testing.T) {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph"},
+		Data: map[string][]byte{
+			ProviderKey:     []byte("microsoft"),
+			TenantKey:       []byte("my-tenant"),
+			ClientIDKey:     []byte("id"),
+			ClientSecretKey: []byte("secret"),
+			ScopesKey:       []byte("https://graph.microsoft.com/.default  offline_access"),
+		},
+	}
+
+	credentials, err := CredentialsFromSecret(&secret, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://login.microsoftonline.com/my-tenant/oauth2/v2.0/token", credentials.TokenURL)
+	assert.Equal(t, []string{"https://graph.microsoft.com/.default", "offline_access"}, credentials.Scopes)
+
+	secret.Data[ProviderKey] = []byte("acme")
+	_, err = CredentialsFromSecret(&secret, nil)
+	assert.EqualError(t, err, `unknown OAuth2 provider "acme" in secret graph`)
+
+	delete(secret.Data, ProviderKey)
+	_, err = CredentialsFromSecret(&secret, nil)
+	assert.EqualError(t, err, "either the provider or the token-url key must be set in secret graph")
+}
+
+func TestCredentialsFromSecretWithTokenURL(t *testing.T) {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme"},
+		Data: map[string][]byte{
+			ProviderKey: []byte("google"),
+			ClientIDKey: []byte("id"),
+		},
+	}
+	allowed := []string{"https://login.acme.com/oauth2/", "http://insecure.acme.com/"}
+
+	for tokenURL, err := range map[string]string{
+		"https://login.acme.com/oauth2/token":            "",
+		"https://LOGIN.acme.com/oauth2/v2/token":         "",
+		"http://login.acme.com/oauth2/token":             "the token URL http://login.acme.com/oauth2/token is not an https URL",
+		"http://insecure.acme.com/token":                 "the token URL http://insecure.acme.com/token is not an https URL",
+		"https://insecure.acme.com/token":                "the token URL https://insecure.acme.com/token is not allowed by the integration platform",
+		"https://login.acme.com/admin":                   "the token URL https://login.acme.com/admin is not allowed by the integration platform",
+		"https://login.acme.com.evil.io/oauth2/token":    "the token URL https://login.acme.com.evil.io/oauth2/token is not allowed by the integration platform",
+		"https://login.acme.com:8443/oauth2/token":       "the token URL https://login.acme.com:8443/oauth2/token is not allowed by the integration platform",
+		"https://user@login.acme.com/oauth2/token":       "the token URL https://user@login.acme.com/oauth2/token must not have user information",
+		"https://login.acme.com/oauth2/%2e%2e/admin":     "the token URL https://login.acme.com/oauth2/%2e%2e/admin must not have parent path segments",
+		"https://169.254.169.254/latest/meta-data/token": "the token URL https://169.254.169.254/latest/meta-data/token is not allowed by the integration platform",
+	} {
+		secret.Data[TokenURLKey] = []byte(tokenURL)
+		credentials, e := CredentialsFromSecret(&secret, allowed)
+		if err == "" {
+			assert.Nil(t, e, tokenURL)
+			assert.Equal(t, tokenURL, credentials.TokenURL)
+		} else {
+			assert.EqualError(t, e, "invalid token-url key in secret acme: "+err)
+		}
+	}
+}
+
+func TestClientCredentialsToken(t *testing.T) {
+	server := newTokenServer(t, "")
+	defer server.Close()
+
+	credentials := Credentials{TokenURL: server.URL, ClientID: "id", ClientSecret: "secret"}
+	token, err := credentials.Token(clientContext(server))
+	assert.Nil(t, err)
+	assert.Equal(t, "client-token", token.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+}
+
+func TestRefreshToken(t *testing.T) {
+	server := newTokenServer(t, "rotated-refresh-token")
+	defer server.Close()
+
+	client := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "salesforce", ResourceVersion: "7"},
+		Data: map[string][]byte{
+			TokenURLKey:     []byte(server.URL),
+			ClientIDKey:     []byte("id"),
+			RefreshTokenKey: []byte("the-refresh-token"),
+		},
+	}
+	_, err := CredentialsFromSecret(&client, nil)
+	assert.EqualError(t, err, fmt.Sprintf("invalid token-url key in secret salesforce: the token URL %s is not allowed by the integration platform", server.URL))
+
+	credentials, err := CredentialsFromSecret(&client, []string{server.URL + "/"})
+	assert.Nil(t, err)
+	token, err := credentials.Token(clientContext(server))
+	assert.Nil(t, err)
+
+	secret := NewTokenSecret(&client, token)
+	assert.Equal(t, "salesforce-token", secret.Name)
+	assert.Equal(t, "salesforce", secret.OwnerReferences[0].Name)
+	assert.Equal(t, "refreshed-token", string(secret.Data[AccessTokenKey]))
+	assert.Equal(t, "Bearer", string(secret.Data[TokenTypeKey]))
+	assert.Equal(t, "rotated-refresh-token", string(secret.Data[RefreshTokenKey]))
+	assert.True(t, IsAcquiredWith(&client, secret))
+
+	refreshTime, err := RefreshTime(&client, secret)
+	assert.Nil(t, err)
+	assert.WithinDuration(t, token.Expiry.Add(-DefaultRefreshBefore), refreshTime, time.Second)
+
+	client.Annotations = map[string]string{RefreshBeforeAnnotation: "10m"}
+	refreshTime, err = RefreshTime(&client, secret)
+	assert.Nil(t, err)
+	assert.WithinDuration(t, token.Expiry.Add(-10*time.Minute), refreshTime, time.Second)
+
+	client.ResourceVersion = "8"
+	assert.False(t, IsAcquiredWith(&client, secret))
+}
@@ -0,0 +1,193 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+)
+
+var conflictManagerRegexp = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// Conflict is a field of a resource that is owned by another field manager, with a value that differs from
+// the applied one
+type Conflict struct {
+	Field   string      `json:"field"`
+	Manager string      `json:"manager"`
+	Current interface{} `json:"current,omitempty"`
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+func (c Conflict) String() string {
+	current, _ := json.Marshal(c.Current)
+	desired, _ := json.Marshal(c.Desired)
+	return fmt.Sprintf("%s managed by %q (current: %s, desired: %s)", c.Field, c.Manager, current, desired)
+}
+
+// Conflicts returns the conflicts reported by the API server when a server-side apply request fails
+func Conflicts(err error) []Conflict {
+	var status k8serrors.APIStatus
+	if !errors.As(err, &status) || !k8serrors.IsConflict(err) {
+		return nil
+	}
+	details := status.Status().Details
+	if details == nil {
+		return nil
+	}
+
+	conflicts := make([]Conflict, 0, len(details.Causes))
+	for _, cause := range details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflict := Conflict{
+			Field: cause.Field,
+		}
+		if match := conflictManagerRegexp.FindStringSubmatch(cause.Message); match != nil {
+			conflict.Manager = match[1]
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// FieldValue returns the value of the given object at the field path, as formatted by the server-side apply
+// conflicts, e.g., `.spec.template.spec.containers[name="integration"].image`
+func FieldValue(object map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = object
+	for path != "" {
+		switch path[0] {
+		case '.':
+			fields, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			end := strings.IndexAny(path[1:], ".[") + 1
+			if end == 0 {
+				end = len(path)
+			}
+			if value, ok = fields[path[1:end]]; !ok {
+				return nil, false
+			}
+			path = path[end:]
+		case '[':
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			var matches func(int, interface{}) bool
+			if matches, path, ok = parseSelector(path[1:]); !ok {
+				return nil, false
+			}
+			found := false
+			for i, item := range items {
+				if matches(i, item) {
+					value = item
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// parseSelector parses the selector of a list item, either its index, e.g., `[0]`, its value, e.g., `[="value"]`,
+// or its keys, e.g., `[containerPort=8080,protocol="TCP"]`, and returns the remaining path
+func parseSelector(path string) (func(int, interface{}) bool, string, bool) {
+	if end := strings.IndexByte(path, ']'); end > 0 {
+		if index, err := strconv.Atoi(path[:end]); err == nil {
+			return func(i int, _ interface{}) bool {
+				return i == index
+			}, path[end+1:], true
+		}
+	}
+
+	keys := make(map[string]interface{})
+	for {
+		eq := strings.IndexByte(path, '=')
+		if eq < 0 {
+			return nil, "", false
+		}
+		key := path[:eq]
+		value, n, ok := decodeValue(path[eq+1:])
+		if !ok {
+			return nil, "", false
+		}
+		path = path[eq+1+n:]
+		if key == "" {
+			if !strings.HasPrefix(path, "]") {
+				return nil, "", false
+			}
+			return func(_ int, item interface{}) bool {
+				return reflect.DeepEqual(item, value)
+			}, path[1:], true
+		}
+		keys[key] = value
+		if strings.HasPrefix(path, "]") {
+			path = path[1:]
+			break
+		} else if !strings.HasPrefix(path, ",") {
+			return nil, "", false
+		}
+		path = path[1:]
+	}
+
+	return func(_ int, item interface{}) bool {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range keys {
+			if !reflect.DeepEqual(fields[k], v) {
+				return false
+			}
+		}
+		return true
+	}, path, true
+}
+
+// decodeValue decodes the JSON value at the beginning of the given string, and returns the number of consumed bytes
+func decodeValue(s string) (interface{}, int, bool) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, 0, false
+	}
+	// The numbers are decoded as int64 when possible, like the unstructured objects
+	var value interface{}
+	if err := utiljson.Unmarshal(raw, &value); err != nil {
+		return nil, 0, false
+	}
+	return value, int(decoder.InputOffset()), true
+}
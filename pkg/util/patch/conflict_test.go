@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestConflicts(t *testing.T) {
+	err := k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "my-integration", nil)
+	err.ErrStatus.Details.Causes = []metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-edit" using apps/v1`,
+			Field:   ".spec.replicas",
+		},
+		{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "invalid",
+			Field:   ".spec.selector",
+		},
+	}
+
+	assert.Equal(t, []Conflict{{Field: ".spec.replicas", Manager: "kubectl-edit"}}, Conflicts(err))
+	assert.Nil(t, Conflicts(k8serrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "my-integration")))
+}
+
+func TestFieldValue(t *testing.T) {
+	object := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "integration",
+							"image": "my-image",
+							"args":  []interface{}{"-c", "java"},
+							"ports": []interface{}{
+								map[string]interface{}{
+									"containerPort": int64(8080),
+									"protocol":      "TCP",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		path  string
+		value interface{}
+		found bool
+	}{
+		{path: ".spec.replicas", value: int64(1), found: true},
+		{path: `.spec.template.spec.containers[name="integration"].image`, value: "my-image", found: true},
+		{path: `.spec.template.spec.containers[name="integration"].ports[containerPort=8080,protocol="TCP"].containerPort`, value: int64(8080), found: true},
+		{path: `.spec.template.spec.containers[name="integration"].args[="java"]`, value: "java", found: true},
+		{path: `.spec.template.spec.containers[0].args[1]`, value: "java", found: true},
+		{path: `.spec.template.spec.containers[name="other"].image`},
+		{path: ".spec.paused"},
+		{path: ".spec.replicas.value"},
+		{path: `.spec.template.spec.containers[name="integration"`},
+	}
+
+	for _, test := range tests {
+		value, found := FieldValue(object, test.path)
+		assert.Equal(t, test.found, found, test.path)
+		assert.Equal(t, test.value, value, test.path)
+	}
+}
+
+func TestConflictString(t *testing.T) {
+	conflict := Conflict{
+		Field:   `.spec.template.spec.containers[name="integration"].image`,
+		Manager: "kubectl-edit",
+		Current: "hotfix",
+		Desired: "my-image",
+	}
+
+	assert.Equal(t, `.spec.template.spec.containers[name="integration"].image managed by "kubectl-edit" (current: "hotfix", desired: "my-image")`, conflict.String())
+}
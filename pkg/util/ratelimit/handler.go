@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// Throttle returns an event handler that delays the requests enqueued by the given handler according to the given
+// rate limiter. It's meant for the low priority events, e.g., the status updates of the reconciled resources, or the
+// changes to their secondary resources, while the changes made by the users are processed right away.
+func Throttle(h handler.EventHandler, limiter *RateLimiter) handler.EventHandler {
+	return &throttledHandler{
+		handler: h,
+		limiter: limiter,
+	}
+}
+
+type throttledHandler struct {
+	handler handler.EventHandler
+	limiter *RateLimiter
+}
+
+var _ handler.EventHandler = &throttledHandler{}
+
+func (t *throttledHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	t.handler.Create(e, t.queue(q))
+}
+
+func (t *throttledHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	t.handler.Update(e, t.queue(q))
+}
+
+func (t *throttledHandler) Delete(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	t.handler.Delete(e, t.queue(q))
+}
+
+func (t *throttledHandler) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	t.handler.Generic(e, t.queue(q))
+}
+
+func (t *throttledHandler) queue(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	return &throttledQueue{
+		RateLimitingInterface: q,
+		limiter:               t.limiter,
+	}
+}
+
+// throttledQueue delays the requests that are added, until the rate limiter grants them
+type throttledQueue struct {
+	workqueue.RateLimitingInterface
+	limiter *RateLimiter
+}
+
+func (q *throttledQueue) Add(item interface{}) {
+	if delay := q.limiter.Delay(item); delay > 0 {
+		q.AddAfter(item, delay)
+	} else {
+		q.RateLimitingInterface.Add(item)
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// QPSEnvVariable is the operator environment variable that sets the rate of the throttled reconcile requests,
+	// across all the namespaces
+	QPSEnvVariable = "RECONCILE_QPS"
+	// BurstEnvVariable is the operator environment variable that sets the burst of the throttled reconcile requests,
+	// across all the namespaces
+	BurstEnvVariable = "RECONCILE_BURST"
+	// NamespaceQPSEnvVariable is the operator environment variable that sets the rate of the throttled reconcile
+	// requests, per namespace
+	NamespaceQPSEnvVariable = "NAMESPACE_RECONCILE_QPS"
+	// NamespaceBurstEnvVariable is the operator environment variable that sets the burst of the throttled reconcile
+	// requests, per namespace
+	NamespaceBurstEnvVariable = "NAMESPACE_RECONCILE_BURST"
+)
+
+const (
+	defaultQPS            = 50
+	defaultBurst          = 500
+	defaultNamespaceQPS   = 10
+	defaultNamespaceBurst = 100
+
+	// The namespaces buckets are pruned once their number exceeds this limit
+	maxNamespaces = 1024
+)
+
+var throttled = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "camel_k_reconcile_requests_throttled_total",
+		Help: "Camel K reconcile requests delayed by the rate limiting",
+	},
+	[]string{
+		"controller",
+		"namespace",
+	},
+)
+
+func init() {
+	metrics.Registry.MustRegister(throttled)
+}
+
+// Options configures the rate limiting of the reconcile requests. A rate lower or equal to zero disables the
+// corresponding limit.
+type Options struct {
+	QPS            float64
+	Burst          int
+	NamespaceQPS   float64
+	NamespaceBurst int
+}
+
+// OptionsFromEnv returns the options set with the operator environment variables, or their defaults
+func OptionsFromEnv() Options {
+	return Options{
+		QPS:            floatFromEnv(QPSEnvVariable, defaultQPS),
+		Burst:          intFromEnv(BurstEnvVariable, defaultBurst),
+		NamespaceQPS:   floatFromEnv(NamespaceQPSEnvVariable, defaultNamespaceQPS),
+		NamespaceBurst: intFromEnv(NamespaceBurstEnvVariable, defaultNamespaceBurst),
+	}
+}
+
+func floatFromEnv(name string, defaultValue float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(name), 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func intFromEnv(name string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// RateLimiter limits the rate of the reconcile requests of a controller, both globally and per namespace, so that
+// the requests of a namespace with a large number of changes do not starve the other namespaces. It also backs
+// off exponentially the requests that failed.
+type RateLimiter struct {
+	name     string
+	options  Options
+	failures workqueue.RateLimiter
+
+	lock       sync.Mutex
+	global     *bucket
+	namespaces map[string]*bucket
+	now        func() time.Time
+}
+
+var _ workqueue.RateLimiter = &RateLimiter{}
+
+// NewRateLimiter returns a rate limiter for the given controller
+func NewRateLimiter(name string, options Options) *RateLimiter {
+	return &RateLimiter{
+		name:       name,
+		options:    options,
+		failures:   workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second),
+		global:     newBucket(options.QPS, options.Burst),
+		namespaces: make(map[string]*bucket),
+		now:        time.Now,
+	}
+}
+
+// When returns the delay before the given failed, or requeued, request can be processed
+func (r *RateLimiter) When(item interface{}) time.Duration {
+	delay := r.failures.When(item)
+	if d := r.Delay(item); d > delay {
+		delay = d
+	}
+	return delay
+}
+
+// Forget resets the failures of the given request
+func (r *RateLimiter) Forget(item interface{}) {
+	r.failures.Forget(item)
+}
+
+// NumRequeues returns the number of times the given request failed
+func (r *RateLimiter) NumRequeues(item interface{}) int {
+	return r.failures.NumRequeues(item)
+}
+
+// Delay reserves a slot for the given request, and returns the delay before it can be processed
+func (r *RateLimiter) Delay(item interface{}) time.Duration {
+	namespace := ""
+	if request, ok := item.(reconcile.Request); ok {
+		namespace = request.Namespace
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	delay := r.global.reserve(now)
+	if d := r.namespaceBucket(namespace, now).reserve(now); d > delay {
+		delay = d
+	}
+	if delay > 0 {
+		throttled.WithLabelValues(r.name, namespace).Inc()
+	}
+	return delay
+}
+
+func (r *RateLimiter) namespaceBucket(namespace string, now time.Time) *bucket {
+	if b, ok := r.namespaces[namespace]; ok {
+		return b
+	}
+	if len(r.namespaces) >= maxNamespaces {
+		for ns, b := range r.namespaces {
+			if b.idle(now) {
+				delete(r.namespaces, ns)
+			}
+		}
+	}
+	b := newBucket(r.options.NamespaceQPS, r.options.NamespaceBurst)
+	r.namespaces[namespace] = b
+	return b
+}
+
+// bucket is a token bucket, that tracks the theoretical arrival time of the next request,
+// rather than the number of available tokens
+type bucket struct {
+	interval time.Duration
+	burst    int
+	tat      time.Time
+}
+
+func newBucket(qps float64, burst int) *bucket {
+	if qps <= 0 {
+		return &bucket{}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		interval: time.Duration(float64(time.Second) / qps),
+		burst:    burst,
+	}
+}
+
+func (b *bucket) reserve(now time.Time) time.Duration {
+	if b.interval == 0 {
+		return 0
+	}
+	if b.tat.Before(now) {
+		b.tat = now
+	}
+	b.tat = b.tat.Add(b.interval)
+	if delay := b.tat.Sub(now) - time.Duration(b.burst)*b.interval; delay > 0 {
+		return delay
+	}
+	return 0
+}
+
+func (b *bucket) idle(now time.Time) bool {
+	return !b.tat.After(now)
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestRateLimiter(options Options) (*RateLimiter, *time.Time) {
+	now := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter("test", options)
+	limiter.now = func() time.Time {
+		return now
+	}
+	return limiter, &now
+}
+
+func request(namespace string, name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+}
+
+func TestBurstThenRate(t *testing.T) {
+	limiter, now := newTestRateLimiter(Options{NamespaceQPS: 10, NamespaceBurst: 2})
+
+	assert.Equal(t, time.Duration(0), limiter.Delay(request("ns", "a")))
+	assert.Equal(t, time.Duration(0), limiter.Delay(request("ns", "b")))
+	assert.Equal(t, 100*time.Millisecond, limiter.Delay(request("ns", "c")))
+	assert.Equal(t, 200*time.Millisecond, limiter.Delay(request("ns", "d")))
+
+	// The bucket is refilled over time
+	*now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), limiter.Delay(request("ns", "e")))
+}
+
+func TestNamespacesAreIsolated(t *testing.T) {
+	limiter, _ := newTestRateLimiter(Options{NamespaceQPS: 1, NamespaceBurst: 1})
+
+	assert.Equal(t, time.Duration(0), limiter.Delay(request("ns1", "a")))
+	assert.Equal(t, time.Second, limiter.Delay(request("ns1", "b")))
+	assert.Equal(t, time.Duration(0), limiter.Delay(request("ns2", "a")))
+}
+
+func TestGlobalLimit(t *testing.T) {
+	limiter, _ := newTestRateLimiter(Options{QPS: 1, Burst: 1, NamespaceQPS: 10, NamespaceBurst: 10})
+
+	assert.Equal(t, time.Duration(0), limiter.Delay(request("ns1", "a")))
+	assert.Equal(t, time.Second, limiter.Delay(request("ns2", "a")))
+}
+
+func TestDisabledLimits(t *testing.T) {
+	limiter, _ := newTestRateLimiter(Options{})
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, time.Duration(0), limiter.Delay(request("ns", "a")))
+	}
+}
+
+func TestFailuresBackOff(t *testing.T) {
+	limiter, _ := newTestRateLimiter(Options{})
+	item := request("ns", "a")
+
+	assert.Equal(t, 5*time.Millisecond, limiter.When(item))
+	assert.Equal(t, 10*time.Millisecond, limiter.When(item))
+	assert.Equal(t, 2, limiter.NumRequeues(item))
+
+	limiter.Forget(item)
+	assert.Equal(t, 0, limiter.NumRequeues(item))
+}
+
+func TestOptionsFromEnv(t *testing.T) {
+	assert.NoError(t, os.Setenv(NamespaceQPSEnvVariable, "2.5"))
+	assert.NoError(t, os.Setenv(NamespaceBurstEnvVariable, "invalid"))
+
+	options := OptionsFromEnv()
+
+	assert.NoError(t, os.Unsetenv(NamespaceQPSEnvVariable))
+	assert.NoError(t, os.Unsetenv(NamespaceBurstEnvVariable))
+
+	assert.Equal(t, float64(defaultQPS), options.QPS)
+	assert.Equal(t, defaultBurst, options.Burst)
+	assert.Equal(t, 2.5, options.NamespaceQPS)
+	assert.Equal(t, defaultNamespaceBurst, options.NamespaceBurst)
+}
+
+func TestThrottledHandler(t *testing.T) {
+	limiter, _ := newTestRateLimiter(Options{NamespaceQPS: 1, NamespaceBurst: 1})
+	queue := workqueue.NewRateLimitingQueue(limiter)
+	defer queue.ShutDown()
+
+	h := Throttle(&handler.EnqueueRequestForObject{}, limiter)
+	for _, name := range []string{"a", "b"} {
+		h.Generic(event.GenericEvent{
+			Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}},
+		}, queue)
+	}
+
+	// Only the first request is added right away, the second one being delayed
+	assert.Equal(t, 1, queue.Len())
+	item, _ := queue.Get()
+	assert.Equal(t, request("ns", "a"), item)
+}
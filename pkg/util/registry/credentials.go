@@ -0,0 +1,454 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+const (
+	gcrUsername = "oauth2accesstoken"
+	acrUsername = "00000000-0000-0000-0000-000000000000"
+	ibmUsername = "iambearer"
+
+	// acrRefreshTokenLifetime is the lifetime of the ACR refresh tokens, when it cannot be read from the token
+	acrRefreshTokenLifetime = 3 * time.Hour
+)
+
+var ecrRegistryRegexp = regexp.MustCompile(`^\d+\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+// CredentialsProvider retrieves short-lived credentials for a container registry
+type CredentialsProvider interface {
+	// Credentials returns the credentials for the registry with the given address, and their expiration time
+	Credentials(ctx context.Context, address string) (Auth, time.Time, error)
+}
+
+// NewCredentialsProvider returns the provider of short-lived credentials of the given kind,
+// that relies on the identity the operator runs with
+func NewCredentialsProvider(kind v1.RegistryCredentialsProvider) (CredentialsProvider, error) {
+	switch kind {
+	case v1.RegistryCredentialsProviderECR:
+		return &ecrCredentialsProvider{
+			client:               http.DefaultClient,
+			accessKeyID:          os.Getenv("AWS_ACCESS_KEY_ID"),
+			secretAccessKey:      os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			sessionToken:         os.Getenv("AWS_SESSION_TOKEN"),
+			roleARN:              os.Getenv("AWS_ROLE_ARN"),
+			webIdentityTokenFile: os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"),
+		}, nil
+	case v1.RegistryCredentialsProviderGCR:
+		host := os.Getenv("GCE_METADATA_HOST")
+		if host == "" {
+			host = "metadata.google.internal"
+		}
+		return &gcrCredentialsProvider{
+			client:   http.DefaultClient,
+			tokenURL: "http://" + host + "/computeMetadata/v1/instance/service-accounts/default/token",
+		}, nil
+	case v1.RegistryCredentialsProviderACR:
+		authorityHost := os.Getenv("AZURE_AUTHORITY_HOST")
+		if authorityHost == "" {
+			authorityHost = "https://login.microsoftonline.com/"
+		}
+		return &acrCredentialsProvider{
+			client:             http.DefaultClient,
+			authorityHost:      authorityHost,
+			tenantID:           os.Getenv("AZURE_TENANT_ID"),
+			clientID:           os.Getenv("AZURE_CLIENT_ID"),
+			federatedTokenFile: os.Getenv("AZURE_FEDERATED_TOKEN_FILE"),
+			scheme:             "https",
+		}, nil
+	case v1.RegistryCredentialsProviderIBM:
+		return &ibmCredentialsProvider{
+			client:   http.DefaultClient,
+			tokenURL: "https://iam.cloud.ibm.com/identity/token",
+			apiKey:   os.Getenv("IBMCLOUD_API_KEY"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported registry credentials provider: %s", kind)
+	}
+}
+
+// ecrCredentialsProvider retrieves the ECR authorization tokens, either with the static credentials,
+// or with the IAM role for service accounts, of the operator
+type ecrCredentialsProvider struct {
+	client               *http.Client
+	accessKeyID          string
+	secretAccessKey      string
+	sessionToken         string
+	roleARN              string
+	webIdentityTokenFile string
+	// the endpoints, that default to the regional endpoints of the registry
+	stsURL string
+	ecrURL string
+}
+
+type ecrAuthorizationTokenResponse struct {
+	AuthorizationData []struct {
+		AuthorizationToken string  `json:"authorizationToken"`
+		ExpiresAt          float64 `json:"expiresAt"`
+	} `json:"authorizationData"`
+}
+
+type stsAssumeRoleWithWebIdentityResponse struct {
+	Credentials struct {
+		AccessKeyID     string `xml:"AccessKeyId"`
+		SecretAccessKey string `xml:"SecretAccessKey"`
+		SessionToken    string `xml:"SessionToken"`
+	} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+}
+
+func (p *ecrCredentialsProvider) Credentials(ctx context.Context, address string) (Auth, time.Time, error) {
+	registry := registryHost(address)
+	match := ecrRegistryRegexp.FindStringSubmatch(registry)
+	if match == nil {
+		return Auth{}, time.Time{}, fmt.Errorf("invalid ECR registry address: %s", address)
+	}
+	region, domain := match[1], "amazonaws.com"+match[2]
+
+	accessKeyID, secretAccessKey, sessionToken := p.accessKeyID, p.secretAccessKey, p.sessionToken
+	if p.roleARN != "" && p.webIdentityTokenFile != "" {
+		stsURL := p.stsURL
+		if stsURL == "" {
+			stsURL = fmt.Sprintf("https://sts.%s.%s/", region, domain)
+		}
+		token, err := ioutil.ReadFile(p.webIdentityTokenFile)
+		if err != nil {
+			return Auth{}, time.Time{}, errors.Wrap(err, "cannot read the web identity token")
+		}
+		query := url.Values{
+			"Action":           {"AssumeRoleWithWebIdentity"},
+			"Version":          {"2011-06-15"},
+			"RoleArn":          {p.roleARN},
+			"RoleSessionName":  {"camel-k-operator"},
+			"WebIdentityToken": {strings.TrimSpace(string(token))},
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, stsURL, strings.NewReader(query.Encode()))
+		if err != nil {
+			return Auth{}, time.Time{}, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		var res stsAssumeRoleWithWebIdentityResponse
+		if err := doRequest(p.client, req, func(body []byte) error { return xml.Unmarshal(body, &res) }); err != nil {
+			return Auth{}, time.Time{}, errors.Wrap(err, "cannot assume the IAM role with the web identity token")
+		}
+		accessKeyID, secretAccessKey, sessionToken = res.Credentials.AccessKeyID, res.Credentials.SecretAccessKey, res.Credentials.SessionToken
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return Auth{}, time.Time{}, errors.New("no AWS credentials found, neither with the access key, nor with the IAM role for service accounts")
+	}
+
+	ecrURL := p.ecrURL
+	if ecrURL == "" {
+		ecrURL = fmt.Sprintf("https://api.ecr.%s.%s/", region, domain)
+	}
+	body := []byte("{}")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ecrURL, bytes.NewReader(body))
+	if err != nil {
+		return Auth{}, time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken")
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+	signRequest(req, body, accessKeyID, secretAccessKey, region, "ecr", time.Now())
+
+	var res ecrAuthorizationTokenResponse
+	if err := doRequest(p.client, req, func(body []byte) error { return json.Unmarshal(body, &res) }); err != nil {
+		return Auth{}, time.Time{}, errors.Wrap(err, "cannot get the ECR authorization token")
+	}
+	if len(res.AuthorizationData) == 0 {
+		return Auth{}, time.Time{}, errors.New("no ECR authorization token returned")
+	}
+	data := res.AuthorizationData[0]
+	decoded, err := base64.StdEncoding.DecodeString(data.AuthorizationToken)
+	if err != nil {
+		return Auth{}, time.Time{}, errors.Wrap(err, "invalid ECR authorization token")
+	}
+	username, password, ok := splitOnce(string(decoded), ":")
+	if !ok {
+		return Auth{}, time.Time{}, errors.New("invalid ECR authorization token")
+	}
+
+	return Auth{
+		Registry: registry,
+		Username: username,
+		Password: password,
+	}, time.Unix(int64(data.ExpiresAt), 0), nil
+}
+
+// signRequest signs the request with the AWS Signature Version 4
+func signRequest(req *http.Request, body []byte, accessKeyID string, secretAccessKey string, region string, service string, t time.Time) {
+	amzDate := t.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, s := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// gcrCredentialsProvider retrieves the access tokens of the Google service account, the operator runs with,
+// from the metadata server
+type gcrCredentialsProvider struct {
+	client   *http.Client
+	tokenURL string
+}
+
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Expiration   int64  `json:"expiration"`
+}
+
+func (p *gcrCredentialsProvider) Credentials(ctx context.Context, address string) (Auth, time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.tokenURL, nil)
+	if err != nil {
+		return Auth{}, time.Time{}, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	now := time.Now()
+	var res oauthTokenResponse
+	if err := doRequest(p.client, req, func(body []byte) error { return json.Unmarshal(body, &res) }); err != nil {
+		return Auth{}, time.Time{}, errors.Wrap(err, "cannot get the Google service account access token")
+	}
+
+	return Auth{
+		Registry: registryHost(address),
+		Username: gcrUsername,
+		Password: res.AccessToken,
+	}, now.Add(time.Duration(res.ExpiresIn) * time.Second), nil
+}
+
+// acrCredentialsProvider exchanges the Azure AD access token, of the workload identity the operator runs with,
+// for an ACR refresh token
+type acrCredentialsProvider struct {
+	client             *http.Client
+	authorityHost      string
+	tenantID           string
+	clientID           string
+	federatedTokenFile string
+	// the scheme of the registry token exchange endpoint
+	scheme string
+}
+
+func (p *acrCredentialsProvider) Credentials(ctx context.Context, address string) (Auth, time.Time, error) {
+	if p.tenantID == "" || p.clientID == "" || p.federatedTokenFile == "" {
+		return Auth{}, time.Time{}, errors.New("no Azure workload identity found, the AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_FEDERATED_TOKEN_FILE environment variables must be set")
+	}
+	assertion, err := ioutil.ReadFile(p.federatedTokenFile)
+	if err != nil {
+		return Auth{}, time.Time{}, errors.Wrap(err, "cannot read the federated token")
+	}
+
+	var token oauthTokenResponse
+	tokenURL := strings.TrimSuffix(p.authorityHost, "/") + "/" + p.tenantID + "/oauth2/v2.0/token"
+	if err := postForm(ctx, p.client, tokenURL, url.Values{
+		"client_id":             {p.clientID},
+		"grant_type":            {"client_credentials"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {strings.TrimSpace(string(assertion))},
+		"scope":                 {"https://management.core.windows.net/.default"},
+	}, &token); err != nil {
+		return Auth{}, time.Time{}, errors.Wrap(err, "cannot get the Azure AD access token")
+	}
+
+	registry := registryHost(address)
+	var exchange oauthTokenResponse
+	if err := postForm(ctx, p.client, p.scheme+"://"+registry+"/oauth2/exchange", url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {registry},
+		"tenant":       {p.tenantID},
+		"access_token": {token.AccessToken},
+	}, &exchange); err != nil {
+		return Auth{}, time.Time{}, errors.Wrap(err, "cannot exchange the Azure AD access token for an ACR refresh token")
+	}
+
+	expiration, err := jwtExpiration(exchange.RefreshToken)
+	if err != nil {
+		expiration = time.Now().Add(acrRefreshTokenLifetime)
+	}
+
+	return Auth{
+		Registry: registry,
+		Username: acrUsername,
+		Password: exchange.RefreshToken,
+	}, expiration, nil
+}
+
+// ibmCredentialsProvider retrieves the IBM Cloud IAM access tokens, for the API key of the operator
+type ibmCredentialsProvider struct {
+	client   *http.Client
+	tokenURL string
+	apiKey   string
+}
+
+func (p *ibmCredentialsProvider) Credentials(ctx context.Context, address string) (Auth, time.Time, error) {
+	if p.apiKey == "" {
+		return Auth{}, time.Time{}, errors.New("no IBM Cloud API key found, the IBMCLOUD_API_KEY environment variable must be set")
+	}
+
+	var res oauthTokenResponse
+	if err := postForm(ctx, p.client, p.tokenURL, url.Values{
+		"grant_type": {"urn:ibm:params:oauth:grant-type:apikey"},
+		"apikey":     {p.apiKey},
+	}, &res); err != nil {
+		return Auth{}, time.Time{}, errors.Wrap(err, "cannot get the IBM Cloud IAM access token")
+	}
+
+	return Auth{
+		Registry: registryHost(address),
+		Username: ibmUsername,
+		Password: res.AccessToken,
+	}, time.Unix(res.Expiration, 0), nil
+}
+
+func postForm(ctx context.Context, client *http.Client, endpoint string, data url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	return doRequest(client, req, func(body []byte) error { return json.Unmarshal(body, out) })
+}
+
+func doRequest(client *http.Client, req *http.Request, decode func([]byte) error) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+	return decode(body)
+}
+
+// jwtExpiration returns the expiration time of the given JWT, without verifying it
+func jwtExpiration(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("invalid JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, err
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, err
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, errors.New("no expiration claim in JWT")
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
+
+// registryHost returns the host of the given registry address, that may contain a path
+func registryHost(address string) string {
+	host, _, _ := splitOnce(address, "/")
+	return host
+}
+
+func splitOnce(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
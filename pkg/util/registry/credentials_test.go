@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+)
+
+func TestNewCredentialsProvider(t *testing.T) {
+	for _, kind := range v1.RegistryCredentialsProviders {
+		provider, err := NewCredentialsProvider(kind)
+		assert.Nil(t, err)
+		assert.NotNil(t, provider)
+	}
+
+	_, err := NewCredentialsProvider("Quay")
+	assert.EqualError(t, err, "unsupported registry credentials provider: Quay")
+}
+
+func TestSignRequest(t *testing.T) {
+	// The get-vanilla example of the AWS Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	assert.Nil(t, err)
+	date, err := time.Parse("20060102T150405Z", "20150830T123600Z")
+	assert.Nil(t, err)
+
+	signRequest(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", date)
+
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func TestECRCredentials(t *testing.T) {
+	expiration := time.Now().Add(12 * time.Hour).Truncate(time.Second)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "AssumeRoleWithWebIdentity", r.Form.Get("Action"))
+		assert.Equal(t, "arn:aws:iam::123456789012:role/camel-k", r.Form.Get("RoleArn"))
+		assert.Equal(t, "web-identity-token", r.Form.Get("WebIdentityToken"))
+		fmt.Fprint(w, `<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>`+
+			`<AccessKeyId>ASIAEXAMPLE</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>session</SessionToken>`+
+			`</Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`)
+	})
+	mux.HandleFunc("/ecr", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=ASIAEXAMPLE/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/ecr/aws4_request")
+		fmt.Fprintf(w, `{"authorizationData":[{"authorizationToken":"%s","expiresAt":%d}]}`,
+			base64.StdEncoding.EncodeToString([]byte("AWS:password")), expiration.Unix())
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tokenFile := path.Join(t.TempDir(), "token")
+	assert.Nil(t, ioutil.WriteFile(tokenFile, []byte("web-identity-token\n"), os.ModePerm))
+
+	provider := &ecrCredentialsProvider{
+		client:               server.Client(),
+		roleARN:              "arn:aws:iam::123456789012:role/camel-k",
+		webIdentityTokenFile: tokenFile,
+		stsURL:               server.URL + "/sts",
+		ecrURL:               server.URL + "/ecr",
+	}
+	auth, exp, err := provider.Credentials(context.TODO(), "123456789012.dkr.ecr.eu-west-1.amazonaws.com/camel-k")
+	assert.Nil(t, err)
+	assert.Equal(t, Auth{Registry: "123456789012.dkr.ecr.eu-west-1.amazonaws.com", Username: "AWS", Password: "password"}, auth)
+	assert.Equal(t, expiration.Unix(), exp.Unix())
+
+	_, _, err = provider.Credentials(context.TODO(), "quay.io")
+	assert.EqualError(t, err, "invalid ECR registry address: quay.io")
+}
+
+func TestGCRCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		fmt.Fprint(w, `{"access_token":"gcp-token","expires_in":3599,"token_type":"Bearer"}`)
+	}))
+	defer server.Close()
+
+	provider := &gcrCredentialsProvider{
+		client:   server.Client(),
+		tokenURL: server.URL,
+	}
+	auth, exp, err := provider.Credentials(context.TODO(), "europe-docker.pkg.dev/my-project")
+	assert.Nil(t, err)
+	assert.Equal(t, Auth{Registry: "europe-docker.pkg.dev", Username: "oauth2accesstoken", Password: "gcp-token"}, auth)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), exp, time.Minute)
+
+	conf, err := auth.GenerateDockerConfig()
+	assert.Nil(t, err)
+	assert.Contains(t, string(conf), `"europe-docker.pkg.dev"`)
+}
+
+func TestACRCredentials(t *testing.T) {
+	expiration := time.Now().Add(3 * time.Hour).Truncate(time.Second)
+	refreshToken := "header." + base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, expiration.Unix()))) + ".signature"
+
+	var registry string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "client", r.Form.Get("client_id"))
+		assert.Equal(t, "federated-token", r.Form.Get("client_assertion"))
+		fmt.Fprint(w, `{"access_token":"aad-token","expires_in":3599}`)
+	})
+	mux.HandleFunc("/oauth2/exchange", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "aad-token", r.Form.Get("access_token"))
+		assert.Equal(t, registry, r.Form.Get("service"))
+		assert.Equal(t, "tenant", r.Form.Get("tenant"))
+		fmt.Fprintf(w, `{"refresh_token":"%s"}`, refreshToken)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	registry = strings.TrimPrefix(server.URL, "http://")
+
+	tokenFile := path.Join(t.TempDir(), "token")
+	assert.Nil(t, ioutil.WriteFile(tokenFile, []byte("federated-token"), os.ModePerm))
+
+	provider := &acrCredentialsProvider{
+		client:             server.Client(),
+		authorityHost:      server.URL,
+		tenantID:           "tenant",
+		clientID:           "client",
+		federatedTokenFile: tokenFile,
+		scheme:             "http",
+	}
+	auth, exp, err := provider.Credentials(context.TODO(), registry)
+	assert.Nil(t, err)
+	assert.Equal(t, Auth{Registry: registry, Username: "00000000-0000-0000-0000-000000000000", Password: refreshToken}, auth)
+	assert.Equal(t, expiration.Unix(), exp.Unix())
+}
+
+func TestIBMCredentials(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "urn:ibm:params:oauth:grant-type:apikey", r.Form.Get("grant_type"))
+		assert.Equal(t, "api-key", r.Form.Get("apikey"))
+		fmt.Fprintf(w, `{"access_token":"iam-token","expiration":%d}`, expiration.Unix())
+	}))
+	defer server.Close()
+
+	provider := &ibmCredentialsProvider{
+		client:   server.Client(),
+		tokenURL: server.URL,
+	}
+	_, _, err := provider.Credentials(context.TODO(), "us.icr.io")
+	assert.NotNil(t, err)
+
+	provider.apiKey = "api-key"
+	auth, exp, err := provider.Credentials(context.TODO(), "us.icr.io")
+	assert.Nil(t, err)
+	assert.Equal(t, Auth{Registry: "us.icr.io", Username: "iambearer", Password: "iam-token"}, auth)
+	assert.Equal(t, expiration.Unix(), exp.Unix())
+}
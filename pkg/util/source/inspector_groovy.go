@@ -19,7 +19,6 @@ package source
 
 import (
 	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
-	"github.com/apache/camel-k/pkg/util"
 )
 
 type GroovyInspector struct {
@@ -27,40 +26,31 @@ type GroovyInspector struct {
 }
 
 func (i GroovyInspector) Extract(source v1.SourceSpec, meta *Metadata) error {
-	from := util.FindAllDistinctStringSubmatch(
-		source.Content,
-		singleQuotedFrom,
-		doubleQuotedFrom,
-		singleQuotedFromF,
-		doubleQuotedFromF,
-	)
-	to := util.FindAllDistinctStringSubmatch(
-		source.Content,
-		singleQuotedTo,
-		doubleQuotedTo,
-		singleQuotedToD,
-		doubleQuotedToD,
-		singleQuotedToF,
-		doubleQuotedToF,
-	)
+	src := parseJvmSource(source.Content, v1.LanguageGroovy)
 
-	meta.FromURIs = append(meta.FromURIs, from...)
-	meta.ToURIs = append(meta.ToURIs, to...)
+	meta.FromURIs = append(meta.FromURIs, src.fromURIs(v1.LanguageGroovy)...)
+	meta.ToURIs = append(meta.ToURIs, src.toURIs()...)
 
-	kameletEips := util.FindAllDistinctStringSubmatch(
-		source.Content,
-		singleQuotedKameletEip,
-		doubleQuotedKameletEip)
-
-	for _, k := range kameletEips {
+	for _, k := range src.kameletEips() {
 		AddKamelet(meta, "kamelet:"+k)
 	}
 
-	i.discoverCapabilities(source, meta)
-	i.discoverDependencies(source, meta)
-	i.discoverKamelets(source, meta)
+	// the patterns are matched against the code only, so that comments and
+	// string literals do not add spurious capabilities or dependencies
+	code := source
+	code.Content = src.code
+
+	i.discoverCapabilities(code, meta)
+	i.discoverDependencies(code, meta)
+	i.discoverKamelets(code, meta)
+
+	for _, language := range src.languages() {
+		if dependency, ok := i.catalog.GetLanguageDependency(language); ok {
+			i.addDependency(dependency, meta)
+		}
+	}
 
-	hasRest := restRegexp.MatchString(source.Content) || restClosureRegexp.MatchString(source.Content)
+	hasRest := restRegexp.MatchString(code.Content) || restClosureRegexp.MatchString(code.Content)
 	if hasRest {
 		meta.RequiredCapabilities.Add(v1.CapabilityRest)
 	}
@@ -0,0 +1,433 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"regexp"
+	"strings"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+var (
+	endpointURIRegexp = regexp.MustCompile(`^[a-zA-Z0-9-]+:.`)
+	kameletEipRegexp  = regexp.MustCompile(`^(?://)?([a-z0-9-.]+(/[a-z0-9-.]+)?)(?:$|[^a-z0-9-.].*)`)
+)
+
+type jvmTokenKind int
+
+const (
+	jvmIdentifier jvmTokenKind = iota
+	jvmString
+	jvmPunctuation
+)
+
+type jvmToken struct {
+	kind jvmTokenKind
+	// text is the decoded value of string literals, and the source text otherwise
+	text string
+}
+
+// jvmSource is the lexical view of a Java, Groovy or Kotlin source, that is used to
+// extract the endpoints from the method calls, rather than from the raw content,
+// so that commented out code, or URIs in log messages, are not taken into account.
+type jvmSource struct {
+	tokens []jvmToken
+	// code is the source content, with the comments and the content of the string literals blanked
+	code string
+	// constants are the string values assigned to identifiers, e.g., static final fields
+	constants map[string]string
+}
+
+func parseJvmSource(content string, language v1.Language) jvmSource {
+	s := jvmSource{
+		constants: make(map[string]string),
+	}
+	code := []byte(content)
+
+	blank := func(from int, to int) {
+		for i := from; i < to && i < len(code); i++ {
+			if code[i] != '\n' {
+				code[i] = ' '
+			}
+		}
+	}
+
+	i := 0
+	if strings.HasPrefix(content, "#!") {
+		end := lineEnd(content, 0)
+		blank(0, end)
+		i = end
+	}
+
+	for i < len(content) {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case strings.HasPrefix(content[i:], "//"):
+			end := lineEnd(content, i)
+			blank(i, end)
+			i = end
+		case strings.HasPrefix(content[i:], "/*"):
+			end := blockCommentEnd(content, i, language == v1.LanguageKotlin)
+			blank(i, end)
+			i = end
+		case c == '"' || (c == '\'' && language == v1.LanguageGroovy):
+			value, start, end := scanStringLiteral(content, i, language)
+			blank(start, end)
+			s.tokens = append(s.tokens, jvmToken{kind: jvmString, text: value})
+			i = end + (start - i)
+		case c == '\'':
+			// character literal
+			_, start, end := scanStringLiteral(content, i, language)
+			blank(start, end)
+			s.tokens = append(s.tokens, jvmToken{kind: jvmPunctuation, text: "'"})
+			i = end + 1
+		case isJvmIdentifierPart(c):
+			start := i
+			for i < len(content) && isJvmIdentifierPart(content[i]) {
+				i++
+			}
+			s.tokens = append(s.tokens, jvmToken{kind: jvmIdentifier, text: content[start:i]})
+		default:
+			n := 1
+			if i+1 < len(content) && content[i+1] == '=' && strings.IndexByte("=!<>+-*/%&|^:?", c) >= 0 {
+				n = 2
+			}
+			s.tokens = append(s.tokens, jvmToken{kind: jvmPunctuation, text: content[i : i+n]})
+			i += n
+		}
+	}
+
+	s.code = string(code)
+	s.resolveConstants()
+
+	return s
+}
+
+// scanStringLiteral scans the string literal starting at the given position, and returns its decoded
+// value, along with the bounds of its content, that exclude the delimiters
+func scanStringLiteral(content string, pos int, language v1.Language) (string, int, int) {
+	quote := content[pos : pos+1]
+	// Java text blocks, Groovy multi-line strings and Kotlin raw strings
+	if triple := strings.Repeat(quote, 3); strings.HasPrefix(content[pos:], triple) && (quote == "\"" || language == v1.LanguageGroovy) {
+		start := pos + 3
+		end := strings.Index(content[start:], triple)
+		if end < 0 {
+			return content[start:], start, len(content)
+		}
+		end += start
+		if language == v1.LanguageKotlin {
+			// Kotlin raw strings do not support escapes
+			return content[start:end], start, end
+		}
+		return unescape(content[start:end]), start, end
+	}
+
+	start := pos + 1
+	var sb strings.Builder
+	for i := start; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\n':
+			// unterminated literal
+			return sb.String(), start, i
+		case c == '\\' && i+1 < len(content):
+			sb.WriteString(unescape(content[i : i+2]))
+			i++
+		case c == quote[0]:
+			return sb.String(), start, i
+		case c == '$' && i+1 < len(content) && content[i+1] == '{' && language != v1.LanguageJavaSource:
+			// keep the template expressions verbatim, as they may contain nested string literals
+			depth := 0
+			j := i
+			for ; j < len(content) && content[j] != '\n'; j++ {
+				if content[j] == '{' {
+					depth++
+				} else if content[j] == '}' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if j >= len(content) || content[j] == '\n' {
+				sb.WriteString(content[i:j])
+				return sb.String(), start, j
+			}
+			sb.WriteString(content[i : j+1])
+			i = j
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), start, len(content)
+}
+
+var jvmEscapes = strings.NewReplacer(
+	`\n`, "\n",
+	`\t`, "\t",
+	`\r`, "\r",
+	`\b`, "\b",
+	`\f`, "\f",
+	`\"`, `"`,
+	`\'`, `'`,
+	`\$`, `$`,
+	`\\`, `\`,
+)
+
+func unescape(s string) string {
+	return jvmEscapes.Replace(s)
+}
+
+func lineEnd(content string, pos int) int {
+	if end := strings.IndexByte(content[pos:], '\n'); end >= 0 {
+		return pos + end
+	}
+	return len(content)
+}
+
+func blockCommentEnd(content string, pos int, nested bool) int {
+	depth := 0
+	for i := pos; i < len(content)-1; i++ {
+		switch {
+		case content[i] == '/' && content[i+1] == '*' && (nested || depth == 0):
+			depth++
+			i++
+		case content[i] == '*' && content[i+1] == '/':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(content)
+}
+
+func isJvmIdentifierPart(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// resolveConstants collects the string values assigned to identifiers, like:
+//
+//	static final String URI = "timer:tick?period=" + PERIOD;
+//	const val URI = "timer:tick"
+//	def uri = 'timer:tick'
+//
+// Assignments are resolved twice, so that constants may reference the ones declared after them.
+func (s *jvmSource) resolveConstants() {
+	for pass := 0; pass < 2; pass++ {
+		for i, t := range s.tokens {
+			if t.kind != jvmPunctuation || t.text != "=" || i == 0 {
+				continue
+			}
+			name := i - 1
+			// Kotlin typed declarations, e.g., val uri: String = "timer:tick"
+			if name >= 2 && s.tokens[name-1].text == ":" {
+				name -= 2
+			}
+			if s.tokens[name].kind != jvmIdentifier {
+				continue
+			}
+			if value, ok := s.resolve(s.tokens[i+1:]); ok {
+				s.constants[s.tokens[name].text] = value
+			}
+		}
+	}
+}
+
+// resolve returns the string value of the expression made of the given tokens, concatenating
+// string literals and constants, up to the first operand that cannot be resolved
+func (s *jvmSource) resolve(tokens []jvmToken) (string, bool) {
+	value := ""
+	resolved := false
+
+	for i := 0; i < len(tokens); {
+		var operand string
+		switch t := tokens[i]; t.kind {
+		case jvmString:
+			operand = t.text
+			i++
+		case jvmIdentifier:
+			// qualified references, e.g., Constants.URI, are resolved by their simple name
+			name := t.text
+			i++
+			for i+1 < len(tokens) && tokens[i].text == "." && tokens[i+1].kind == jvmIdentifier {
+				name = tokens[i+1].text
+				i += 2
+			}
+			c, ok := s.constants[name]
+			if !ok || (i < len(tokens) && tokens[i].text == "(") {
+				return value, resolved
+			}
+			operand = c
+		default:
+			return value, resolved
+		}
+
+		value += operand
+		resolved = true
+
+		if i >= len(tokens) || tokens[i].text != "+" {
+			break
+		}
+		i++
+	}
+
+	return value, resolved
+}
+
+// arguments returns the arguments of the method call whose opening parenthesis is at the given position
+func (s *jvmSource) arguments(open int) [][]jvmToken {
+	args := make([][]jvmToken, 0)
+	depth := 0
+	start := open + 1
+
+	for i := open; i < len(s.tokens); i++ {
+		t := s.tokens[i]
+		if t.kind != jvmPunctuation {
+			continue
+		}
+		switch t.text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				if i > start {
+					args = append(args, s.tokens[start:i])
+				}
+				return args
+			}
+		case ",":
+			if depth == 1 {
+				args = append(args, s.tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return args
+}
+
+// calls returns the resolved string arguments of the calls to the given methods.
+// If first is true, only the first argument is considered, e.g., the format of fromF.
+func (s *jvmSource) calls(qualified bool, first bool, methods ...string) []string {
+	values := make([]string, 0)
+
+	for i, t := range s.tokens {
+		if t.kind != jvmIdentifier || !util.StringSliceExists(methods, t.text) {
+			continue
+		}
+		if qualified && (i == 0 || s.tokens[i-1].text != ".") {
+			continue
+		}
+		if i+1 >= len(s.tokens) {
+			continue
+		}
+		if s.tokens[i+1].text != "(" {
+			continue
+		}
+		for _, arg := range s.arguments(i + 1) {
+			if value, ok := s.resolve(arg); ok {
+				values = append(values, value)
+			}
+			if first {
+				break
+			}
+		}
+	}
+
+	return values
+}
+
+// commands returns the resolved string arguments of the Groovy command expressions, e.g., from 'timer:tick'
+func (s *jvmSource) commands(methods ...string) []string {
+	values := make([]string, 0)
+
+	for i, t := range s.tokens {
+		if t.kind != jvmIdentifier || !util.StringSliceExists(methods, t.text) || i+1 >= len(s.tokens) {
+			continue
+		}
+		if s.tokens[i+1].kind != jvmString {
+			continue
+		}
+		if value, ok := s.resolve(s.tokens[i+1:]); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func (s *jvmSource) fromURIs(language v1.Language) []string {
+	uris := s.calls(false, false, "from")
+	uris = append(uris, s.calls(false, true, "fromF")...)
+	if language == v1.LanguageGroovy {
+		uris = append(uris, s.commands("from")...)
+	}
+	return distinctEndpointURIs(uris)
+}
+
+func (s *jvmSource) toURIs() []string {
+	uris := s.calls(true, false, "to", "toD")
+	uris = append(uris, s.calls(true, true, "toF")...)
+	return distinctEndpointURIs(uris)
+}
+
+func (s *jvmSource) kameletEips() []string {
+	kamelets := make([]string, 0)
+	for _, k := range s.calls(false, true, "kamelet") {
+		if match := kameletEipRegexp.FindStringSubmatch(k); match != nil {
+			util.StringSliceUniqueAdd(&kamelets, match[1])
+		}
+	}
+	return kamelets
+}
+
+// languages returns the languages used by the language expressions, e.g., language("ognl", "request.body")
+func (s *jvmSource) languages() []string {
+	languages := make([]string, 0)
+	for i, t := range s.tokens {
+		if t.kind != jvmIdentifier || t.text != "language" || i+1 >= len(s.tokens) || s.tokens[i+1].text != "(" {
+			continue
+		}
+		args := s.arguments(i + 1)
+		if len(args) < 2 {
+			continue
+		}
+		if language, ok := s.resolve(args[0]); ok && language != "" {
+			util.StringSliceUniqueAdd(&languages, language)
+		}
+	}
+	return languages
+}
+
+func distinctEndpointURIs(uris []string) []string {
+	result := make([]string, 0, len(uris))
+	for _, uri := range uris {
+		if endpointURIRegexp.MatchString(uri) {
+			util.StringSliceUniqueAdd(&result, uri)
+		}
+	}
+	return result
+}
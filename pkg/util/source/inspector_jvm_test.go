@@ -0,0 +1,175 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+const JavaSourceWithComments = `
+import org.apache.camel.builder.RouteBuilder;
+
+public class Routes extends RouteBuilder {
+    // from("kafka:topic").to("log:info");
+    /*
+     * from("jms:queue").to("aws2-s3:bucket");
+     */
+    @Override
+    public void configure() throws Exception {
+        from("timer:tick")
+            .log("Sending to http://example.com with rest() and .json()")
+            .to("log:info");
+    }
+}
+`
+
+const JavaSourceWithConstants = `
+public class Routes extends RouteBuilder {
+    private static final String PERIOD = "1000";
+    private static final String TIMER = "timer:tick?period=" + PERIOD;
+    private static final String OUT = Constants.PREFIX + "info";
+
+    @Override
+    public void configure() throws Exception {
+        from(TIMER)
+            .to(ExchangePattern.InOut,
+                "direct:" +
+                "other")
+            .to(Endpoints.LOG);
+    }
+}
+
+class Endpoints {
+    static final String LOG = "log:info";
+}
+`
+
+const JavaSourceWithTextBlock = `
+from("timer:tick")
+    .setBody().constant("""
+        from("jms:queue").to("kafka:topic");
+        """)
+    .setHeader("quote").constant('"')
+    .to("log:info");
+`
+
+const GroovyWithCommands = `
+#!/usr/bin/env groovy
+def uri = 'log:info'
+
+from 'timer:tick'
+    .log('''
+        .to("kafka:topic")
+    ''')
+    .to(uri)
+`
+
+const KotlinWithTemplates = `
+val period: String = "1000"
+
+/* nested /* comment */ from("kafka:topic") */
+from("timer:tick?period=${'$'}period")
+    .log("${body.replace("\"", "")} sent to jms:queue")
+    .to("log:info")
+`
+
+func TestJvmSourceInspector(t *testing.T) {
+	tc := []struct {
+		language     v1.Language
+		source       string
+		from         []string
+		to           []string
+		dependencies []string
+	}{
+		{
+			language:     v1.LanguageJavaSource,
+			source:       JavaSourceWithComments,
+			from:         []string{"timer:tick"},
+			to:           []string{"log:info"},
+			dependencies: []string{"camel:log", "camel:timer"},
+		},
+		{
+			language:     v1.LanguageJavaSource,
+			source:       JavaSourceWithConstants,
+			from:         []string{"timer:tick?period=1000"},
+			to:           []string{"direct:other", "log:info"},
+			dependencies: []string{"camel:direct", "camel:log", "camel:timer"},
+		},
+		{
+			language:     v1.LanguageJavaSource,
+			source:       JavaSourceWithTextBlock,
+			from:         []string{"timer:tick"},
+			to:           []string{"log:info"},
+			dependencies: []string{"camel:log", "camel:timer"},
+		},
+		{
+			language:     v1.LanguageGroovy,
+			source:       GroovyWithCommands,
+			from:         []string{"timer:tick"},
+			to:           []string{"log:info"},
+			dependencies: []string{"camel:log", "camel:timer"},
+		},
+		{
+			language:     v1.LanguageKotlin,
+			source:       KotlinWithTemplates,
+			from:         []string{"timer:tick?period=${'$'}period"},
+			to:           []string{"log:info"},
+			dependencies: []string{"camel:log", "camel:timer"},
+		},
+	}
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	for i, test := range tc {
+		t.Run(fmt.Sprintf("TestJvmSourceInspector-%d", i), func(t *testing.T) {
+			code := v1.SourceSpec{
+				DataSpec: v1.DataSpec{
+					Content: test.source,
+				},
+			}
+
+			meta := NewMetadata()
+			inspector := InspectorForLanguage(catalog, test.language)
+
+			err := inspector.Extract(code, &meta)
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, test.from, meta.FromURIs)
+			assert.ElementsMatch(t, test.to, meta.ToURIs)
+			assert.ElementsMatch(t, test.dependencies, meta.Dependencies.List())
+			assert.True(t, meta.RequiredCapabilities.IsEmpty())
+		})
+	}
+}
+
+func TestJvmSourceLanguages(t *testing.T) {
+	src := parseJvmSource(`
+		from("direct:start")
+			.transform(language("ognl", "request.body.name == 'Camel K'"))
+			// .transform(language("mvel", "request.body"))
+			.log("language(\"xquery\", \"/foo\")");
+	`, v1.LanguageJavaSource)
+
+	assert.Equal(t, []string{"ognl"}, src.languages())
+}
@@ -0,0 +1,230 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml2 "gopkg.in/yaml.v2"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+// ElementsMetadata is the metadata of the catalog loaders that lists the comma-separated top-level elements of their DSL
+const ElementsMetadata = "elements"
+
+// Validate checks the route definitions of the given source are well-formed, and only use the top-level DSL elements
+// declared by the loader of the given catalog, if any. Only the YAML and XML sources are validated, as the other
+// languages are compiled by the runtime.
+func Validate(catalog *camel.RuntimeCatalog, source v1.SourceSpec) error {
+	if source.Type != v1.SourceTypeDefault || source.Loader != "" {
+		return nil
+	}
+
+	var err error
+	switch source.InferLanguage() {
+	case v1.LanguageYaml:
+		err = validateYAML(catalog, source.Content)
+	case v1.LanguageXML:
+		err = validateXML(catalog, source.Content)
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "invalid source %s", source.Name)
+	}
+	return nil
+}
+
+func validateYAML(catalog *camel.RuntimeCatalog, content string) error {
+	var definitions interface{}
+	if err := yaml2.Unmarshal([]byte(content), &definitions); err != nil {
+		return err
+	}
+	if definitions == nil {
+		return nil
+	}
+
+	items, ok := definitions.([]interface{})
+	if !ok {
+		return errors.New("the route definitions must be a list")
+	}
+
+	for i, item := range items {
+		element, value, err := singleKeyMap(item)
+		if err != nil {
+			return errors.Wrapf(err, "definition %d", i+1)
+		}
+		if !isDSLElementSupported(catalog, v1.LanguageYaml, element) {
+			return fmt.Errorf("definition %d: unknown element %q%s", i+1, element, forCamelVersion(catalog))
+		}
+
+		switch element {
+		case "from":
+			err = validateYAMLFrom(value)
+		case "route":
+			route, ok := value.(map[interface{}]interface{})
+			if !ok {
+				err = errors.New("route must be a map")
+			} else if from, ok := route["from"]; !ok {
+				err = errors.New("route must have a from element")
+			} else {
+				err = validateYAMLFrom(from)
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "definition %d", i+1)
+		}
+	}
+
+	return nil
+}
+
+func validateYAMLFrom(value interface{}) error {
+	from, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return errors.New("from must be a map")
+	}
+	if uri, ok := from["uri"].(string); !ok || uri == "" {
+		return errors.New("from must have an uri")
+	}
+	steps, ok := from["steps"]
+	if !ok || steps == nil {
+		return nil
+	}
+	list, ok := steps.([]interface{})
+	if !ok {
+		return errors.New("steps must be a list")
+	}
+	for i, step := range list {
+		if _, _, err := singleKeyMap(step); err != nil {
+			return errors.Wrapf(err, "step %d", i+1)
+		}
+	}
+	return nil
+}
+
+// singleKeyMap returns the key and the value of the given YAML element, that is expected to be a map with a single key
+func singleKeyMap(item interface{}) (string, interface{}, error) {
+	m, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return "", nil, errors.New("must be a map")
+	}
+	if len(m) != 1 {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, fmt.Sprint(k))
+		}
+		sort.Strings(keys)
+		return "", nil, fmt.Errorf("must have exactly one element, found [%s]", strings.Join(keys, ", "))
+	}
+	for k, v := range m {
+		return fmt.Sprint(k), v, nil
+	}
+	return "", nil, nil
+}
+
+func validateXML(catalog *camel.RuntimeCatalog, content string) error {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+
+	// the local names of the open elements, and whether they have a from child
+	type element struct {
+		name    string
+		hasFrom bool
+	}
+	stack := make([]*element, 0)
+	roots := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 {
+				roots++
+				if roots > 1 {
+					return errors.New("XML documents must have a single root element")
+				}
+				if !isDSLElementSupported(catalog, v1.LanguageXML, t.Name.Local) {
+					return fmt.Errorf("unknown root element <%s>%s", t.Name.Local, forCamelVersion(catalog))
+				}
+			} else if t.Name.Local == "from" {
+				stack[len(stack)-1].hasFrom = true
+			}
+			stack = append(stack, &element{name: t.Name.Local})
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if e.name == "route" && !e.hasFrom {
+				line := strings.Count(content[:decoder.InputOffset()], "\n") + 1
+				return fmt.Errorf("route ending on line %d must have a <from> element", line)
+			}
+		}
+	}
+
+	if roots == 0 && strings.TrimSpace(content) != "" {
+		return errors.New("no route definitions found")
+	}
+
+	return nil
+}
+
+// isDSLElementSupported returns whether the given element is declared by the catalog loader of the given language,
+// or whether the loader does not declare its elements
+func isDSLElementSupported(catalog *camel.RuntimeCatalog, language v1.Language, name string) bool {
+	if catalog == nil {
+		return true
+	}
+	for _, loader := range catalog.Loaders {
+		if !util.StringSliceExists(loader.Languages, string(language)) {
+			continue
+		}
+		elements, ok := loader.Metadata[ElementsMetadata]
+		if !ok {
+			return true
+		}
+		return util.StringSliceExists(strings.Split(elements, ","), name)
+	}
+	return true
+}
+
+func camelVersion(catalog *camel.RuntimeCatalog) string {
+	if catalog == nil {
+		return ""
+	}
+	return catalog.Runtime.Metadata["camel.version"]
+}
+
+func forCamelVersion(catalog *camel.RuntimeCatalog) string {
+	if version := camelVersion(catalog); version != "" {
+		return " for Camel " + version
+	}
+	return ""
+}
@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+func TestValidate(t *testing.T) {
+	tc := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name: "routes.yaml",
+			content: `
+- from:
+    uri: "timer:tick"
+    steps:
+      - set-body:
+          constant: "Hello"
+      - to: "log:info"
+- route:
+    id: "r2"
+    from:
+      uri: "direct:start"
+- rest:
+    path: "/api"
+`,
+		},
+		{
+			name:    "routes.yaml",
+			content: "- from:\n    uri: \"timer:tick\"\n   steps: []\n",
+			err:     "invalid source routes.yaml: yaml: line 2: did not find expected key",
+		},
+		{
+			name:    "routes.yaml",
+			content: "from:\n  uri: \"timer:tick\"\n",
+			err:     "invalid source routes.yaml: the route definitions must be a list",
+		},
+		{
+			name:    "routes.yaml",
+			content: "- from:\n    steps:\n      - to: \"log:info\"\n",
+			err:     "invalid source routes.yaml: definition 1: from must have an uri",
+		},
+		{
+			name:    "routes.yaml",
+			content: "- from:\n    uri: \"timer:tick\"\n    steps:\n      - to: \"log:info\"\n        log: \"message\"\n",
+			err:     "invalid source routes.yaml: definition 1: step 1: must have exactly one element, found [log, to]",
+		},
+		{
+			name:    "routes.yaml",
+			content: "- route-configuration:\n    on-exception: []\n",
+			err:     "invalid source routes.yaml: definition 1: unknown element \"route-configuration\" for Camel 3.11.1",
+		},
+		{
+			name:    "routes.yaml",
+			content: "- route:\n    id: \"r1\"\n",
+			err:     "invalid source routes.yaml: definition 1: route must have a from element",
+		},
+		{
+			name: "routes.xml",
+			content: `
+<routes xmlns="http://camel.apache.org/schema/spring">
+    <route id="hello">
+        <from uri="timer:hello"/>
+        <to uri="log:info"/>
+    </route>
+</routes>
+`,
+		},
+		{
+			name:    "routes.xml",
+			content: "<routes>\n  <route>\n    <from uri=\"timer:hello\">\n  </route>\n</routes>\n",
+			err:     "invalid source routes.xml: XML syntax error on line 4: element <from> closed by </route>",
+		},
+		{
+			name:    "routes.xml",
+			content: "<routes>\n  <route>\n    <to uri=\"log:info\"/>\n  </route>\n</routes>\n",
+			err:     "invalid source routes.xml: route ending on line 4 must have a <from> element",
+		},
+		{
+			name:    "routes.xml",
+			content: "<camelContext>\n  <route>\n    <from uri=\"timer:hello\"/>\n  </route>\n</camelContext>\n",
+			err:     "invalid source routes.xml: unknown root element <camelContext> for Camel 3.11.1",
+		},
+		{
+			name:    "Routes.java",
+			content: "from(\"timer:tick\"",
+		},
+	}
+
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	for i, test := range tc {
+		t.Run(fmt.Sprintf("TestValidate-%d", i), func(t *testing.T) {
+			err := Validate(catalog, v1.SourceSpec{
+				DataSpec: v1.DataSpec{
+					Name:    test.name,
+					Content: test.content,
+				},
+			})
+			if test.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestValidateDependsOnCatalog(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	assert.Nil(t, err)
+
+	source := v1.SourceSpec{
+		DataSpec: v1.DataSpec{
+			Name:    "routes.xml",
+			Content: "<routeConfiguration>\n  <onException/>\n</routeConfiguration>\n",
+		},
+	}
+	assert.EqualError(t, Validate(catalog, source), "invalid source routes.xml: unknown root element <routeConfiguration> for Camel 3.11.1")
+
+	loader := catalog.Loaders["xml"]
+	loader.Metadata[ElementsMetadata] += ",routeConfiguration,routeConfigurations"
+	assert.Nil(t, Validate(catalog, source))
+
+	// The elements are not checked when the loader does not declare them
+	delete(loader.Metadata, ElementsMetadata)
+	source.Content = "<unknown>\n  <route/>\n</unknown>\n"
+	assert.EqualError(t, Validate(catalog, source), "invalid source routes.xml: route ending on line 2 must have a <from> element")
+	source.Content = "<unknown/>\n"
+	assert.Nil(t, Validate(catalog, source))
+}
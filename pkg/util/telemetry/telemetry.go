@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/apache/camel-k/pkg/util/defaults"
+)
+
+const (
+	// ServiceName is the name of the service the operator spans are reported for
+	ServiceName = "camel-k-operator"
+
+	// OTLPEndpointEnvVariable is the standard OpenTelemetry environment variable enabling the export
+	// of the operator spans to the given OTLP endpoint
+	OTLPEndpointEnvVariable = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// OTLPTracesEndpointEnvVariable is the standard OpenTelemetry environment variable enabling the export
+	// of the operator spans to the given OTLP traces endpoint
+	OTLPTracesEndpointEnvVariable = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	instrumentationName = "github.com/apache/camel-k"
+)
+
+// Span attribute keys
+const (
+	NamespaceKey    = attribute.Key("camel.apache.org/namespace")
+	NameKey         = attribute.Key("camel.apache.org/name")
+	KindKey         = attribute.Key("camel.apache.org/kind")
+	PhaseKey        = attribute.Key("camel.apache.org/phase")
+	ActionKey       = attribute.Key("camel.apache.org/action")
+	TraitKey        = attribute.Key("camel.apache.org/trait")
+	TraitEnabledKey = attribute.Key("camel.apache.org/trait-enabled")
+)
+
+// Enabled returns whether the operator spans are exported, that is when an OTLP endpoint is configured
+// with the standard OpenTelemetry environment variables
+func Enabled() bool {
+	return os.Getenv(OTLPEndpointEnvVariable) != "" || os.Getenv(OTLPTracesEndpointEnvVariable) != ""
+}
+
+// Setup registers the tracer provider exporting the operator spans over OTLP/gRPC, configured with the standard
+// OpenTelemetry environment variables. It returns the function flushing the pending spans, to be called
+// on shutdown. No span is recorded when the export is not enabled.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the OTLP trace exporter: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(ServiceName),
+			semconv.ServiceVersionKey.String(defaults.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span with the given name and attributes, as a child of the span of the given context, if any
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartActionSpan starts the span of the reconcile action with the given name, handling the resource
+// of the given kind in the given phase
+func StartActionSpan(ctx context.Context, kind string, action string, phase string) (context.Context, trace.Span) {
+	return StartSpan(ctx, kind+" "+action, ActionKey.String(action), PhaseKey.String(phase))
+}
+
+// EndSpan ends the span, recording the given error, if any
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ObjectAttributes returns the attributes identifying the resource of the given kind
+func ObjectAttributes(kind string, namespace string, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		KindKey.String(kind),
+		NamespaceKey.String(namespace),
+		NameKey.String(name),
+	}
+}
+
+// WrapConfig returns a copy of the given configuration, whose clients record a span for each API request made
+// within a span
+func WrapConfig(config *rest.Config) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &roundTripper{delegate: rt}
+	})
+	return config
+}
+
+type roundTripper struct {
+	delegate http.RoundTripper
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only the requests made while reconciling are traced, and watches are long-running requests
+	// that are not part of any reconciliation
+	if !trace.SpanFromContext(req.Context()).SpanContext().IsValid() || req.URL.Query().Get("watch") == "true" {
+		return t.delegate.RoundTrip(req)
+	}
+
+	ctx, span := otel.Tracer(instrumentationName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPTargetKey.String(req.URL.Path),
+			semconv.HTTPHostKey.String(req.URL.Host),
+		),
+	)
+	defer span.End()
+
+	res, err := t.delegate.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return res, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(res.StatusCode))
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(res.StatusCode))
+
+	return res, nil
+}
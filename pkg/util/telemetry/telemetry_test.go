@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"k8s.io/client-go/rest"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder
+}
+
+func TestEnabled(t *testing.T) {
+	assert.NoError(t, os.Unsetenv(OTLPEndpointEnvVariable))
+	assert.NoError(t, os.Unsetenv(OTLPTracesEndpointEnvVariable))
+	assert.False(t, Enabled())
+
+	shutdown, err := Setup(context.TODO())
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.TODO()))
+
+	assert.NoError(t, os.Setenv(OTLPTracesEndpointEnvVariable, "http://localhost:4317"))
+	enabled := Enabled()
+	assert.NoError(t, os.Unsetenv(OTLPTracesEndpointEnvVariable))
+	assert.True(t, enabled)
+}
+
+func TestSpans(t *testing.T) {
+	recorder := newRecorder(t)
+
+	ctx, parent := StartSpan(context.TODO(), "Reconcile Integration", ObjectAttributes("Integration", "ns", "my-it")...)
+	_, action := StartActionSpan(ctx, "Integration", "build-kit", "Building Kit")
+	EndSpan(action, errors.New("failure"))
+	EndSpan(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "Integration build-kit", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), ActionKey.String("build-kit"))
+	assert.Contains(t, spans[0].Attributes(), PhaseKey.String("Building Kit"))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "failure", spans[0].Status().Description)
+
+	assert.Equal(t, "Reconcile Integration", spans[1].Name())
+	assert.False(t, spans[1].Parent().IsValid())
+	assert.Contains(t, spans[1].Attributes(), NameKey.String("my-it"))
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestWrapConfig(t *testing.T) {
+	recorder := newRecorder(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/namespaces/ns/pods/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	transport, err := rest.TransportFor(WrapConfig(&rest.Config{Host: server.URL}))
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	ctx, parent := StartSpan(context.TODO(), "Reconcile Integration")
+	for _, path := range []string{"/api/v1/namespaces/ns/pods", "/api/v1/namespaces/ns/pods/missing", "/api/v1/pods?watch=true"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+	}
+	parent.End()
+
+	// The requests made outside any span are not traced
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, server.URL+"/api/v1/namespaces/ns/pods", nil)
+	require.NoError(t, err)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	for _, span := range spans[:2] {
+		assert.Equal(t, "HTTP GET", span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPTargetKey.String("/api/v1/namespaces/ns/pods"))
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusOK))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Contains(t, spans[1].Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusNotFound))
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+
+	// The watch request is not traced
+	assert.Equal(t, "Reconcile Integration", spans[2].Name())
+}
@@ -55,7 +55,7 @@ func NewFakeClient(initObjs ...runtime.Object) (client.Client, error) {
 		return strings.Contains(gvk.Group, "camel")
 	})...)
 	clientset := fakeclientset.NewSimpleClientset(filterObjects(scheme, initObjs, func(gvk schema.GroupVersionKind) bool {
-		return !strings.Contains(gvk.Group, "camel") && !strings.Contains(gvk.Group, "knative")
+		return !strings.Contains(gvk.Group, "camel") && !strings.Contains(gvk.Group, "knative") && !strings.Contains(gvk.Group, "strimzi")
 	})...)
 
 	return &FakeClient{
@@ -102,6 +102,10 @@ func (c *FakeClient) GetConfig() *rest.Config {
 	return nil
 }
 
+func (c *FakeClient) GetAPIReader() controller.Reader {
+	return c
+}
+
 func (c *FakeClient) GetCurrentNamespace(kubeConfig string) (string, error) {
 	return "", nil
 }
@@ -128,5 +132,13 @@ func (f *FakeDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*me
 			Group: "image.openshift.io",
 		}, "")
 	}
-	return f.DiscoveryInterface.ServerResourcesForGroupVersion(groupVersion)
+	resources, err := f.DiscoveryInterface.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil && strings.HasSuffix(err.Error(), "not found") {
+		// The fake discovery returns a plain error for unknown group versions
+		gv, _ := schema.ParseGroupVersion(groupVersion)
+		return nil, k8serrors.NewNotFound(schema.GroupResource{
+			Group: gv.Group,
+		}, "")
+	}
+	return resources, err
 }
@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v2alpha1"
+)
+
+// integrationConverter converts the Integrations between the v1 storage version, where the traits are
+// configured with raw JSON, and the v2alpha1 version, where the traits are strongly typed
+type integrationConverter struct{}
+
+func (c *integrationConverter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := apiextensionsv1.ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "missing conversion request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = c.convert(review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&review); err != nil {
+		webhookLog.Error(err, "Cannot write the conversion response")
+	}
+}
+
+func (c *integrationConverter) convert(req *apiextensionsv1.ConversionRequest) *apiextensionsv1.ConversionResponse {
+	res := apiextensionsv1.ConversionResponse{
+		UID: req.UID,
+		Result: metav1.Status{
+			Status: metav1.StatusSuccess,
+		},
+	}
+
+	for _, obj := range req.Objects {
+		converted, err := convertIntegration(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			webhookLog.Error(err, "Cannot convert the integration", "apiVersion", req.DesiredAPIVersion)
+			res.ConvertedObjects = nil
+			res.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			}
+			return &res
+		}
+		res.ConvertedObjects = append(res.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+
+	return &res
+}
+
+// convertIntegration converts the given serialized Integration to the given API version
+func convertIntegration(data []byte, apiVersion string) ([]byte, error) {
+	meta := metav1.TypeMeta{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	if meta.Kind != v1.IntegrationKind {
+		return nil, fmt.Errorf("unsupported kind %s", meta.Kind)
+	}
+	if meta.APIVersion == apiVersion {
+		return data, nil
+	}
+
+	switch {
+	case meta.APIVersion == v1.SchemeGroupVersion.String() && apiVersion == v2alpha1.SchemeGroupVersion.String():
+		src := v1.Integration{}
+		if err := json.Unmarshal(data, &src); err != nil {
+			return nil, err
+		}
+		dst := v2alpha1.Integration{}
+		if err := dst.ConvertFrom(&src); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&dst)
+	case meta.APIVersion == v2alpha1.SchemeGroupVersion.String() && apiVersion == v1.SchemeGroupVersion.String():
+		src := v2alpha1.Integration{}
+		if err := json.Unmarshal(data, &src); err != nil {
+			return nil, err
+		}
+		dst := v1.Integration{}
+		if err := src.ConvertTo(&dst); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&dst)
+	default:
+		return nil, fmt.Errorf("unsupported conversion from %s to %s", meta.APIVersion, apiVersion)
+	}
+}
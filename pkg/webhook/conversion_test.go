@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v2alpha1"
+)
+
+func TestIntegrationConversion(t *testing.T) {
+	it := v1.Integration{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: v1.IntegrationKind},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"container": {Configuration: v1.TraitConfiguration{RawMessage: []byte(`{"port":8081}`)}},
+			},
+		},
+	}
+
+	res := convertReview(t, v2alpha1.SchemeGroupVersion.String(), &it)
+	assert.Equal(t, metav1.StatusSuccess, res.Result.Status)
+	assert.Len(t, res.ConvertedObjects, 1)
+
+	converted := v2alpha1.Integration{}
+	assert.NoError(t, json.Unmarshal(res.ConvertedObjects[0].Raw, &converted))
+	assert.Equal(t, v2alpha1.SchemeGroupVersion.String(), converted.APIVersion)
+	assert.Equal(t, "my-integration", converted.Name)
+	assert.Equal(t, 8081, converted.Spec.Traits.Container.Port)
+
+	res = convertReview(t, v1.SchemeGroupVersion.String(), &converted)
+	assert.Equal(t, metav1.StatusSuccess, res.Result.Status)
+	assert.Len(t, res.ConvertedObjects, 1)
+
+	back := v1.Integration{}
+	assert.NoError(t, json.Unmarshal(res.ConvertedObjects[0].Raw, &back))
+	assert.Equal(t, v1.SchemeGroupVersion.String(), back.APIVersion)
+	assert.JSONEq(t, `{"port":8081}`, string(back.Spec.Traits["container"].Configuration.RawMessage))
+}
+
+func TestIntegrationConversionFailure(t *testing.T) {
+	it := v1.Integration{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: v1.IntegrationKind},
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"container": {Configuration: v1.TraitConfiguration{RawMessage: []byte(`{"port":8081}`)}},
+			},
+		},
+	}
+
+	res := convertReview(t, "camel.apache.org/v3", &it)
+	assert.Equal(t, metav1.StatusFailure, res.Result.Status)
+	assert.Empty(t, res.ConvertedObjects)
+	assert.Contains(t, res.Result.Message, "unsupported conversion")
+}
+
+func convertReview(t *testing.T, apiVersion string, obj runtime.Object) *apiextensionsv1.ConversionResponse {
+	t.Helper()
+
+	data, err := json.Marshal(obj)
+	assert.NoError(t, err)
+
+	review := apiextensionsv1.ConversionReview{
+		Request: &apiextensionsv1.ConversionRequest{
+			UID:               "uid",
+			DesiredAPIVersion: apiVersion,
+			Objects:           []runtime.RawExtension{{Raw: data}},
+		},
+	}
+	body, err := json.Marshal(&review)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	(&integrationConverter{}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, integrationConvertPath, bytes.NewReader(body)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	res := apiextensionsv1.ConversionReview{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Nil(t, res.Request)
+	assert.Equal(t, review.Request.UID, res.Response.UID)
+
+	return res.Response
+}
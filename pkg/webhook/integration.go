@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/client"
+)
+
+// integrationDefaulter sets the trait profile of the Integrations to the one of their platform,
+// and records the user that creates or updates them
+type integrationDefaulter struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+func (d *integrationDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	integration := v1.Integration{}
+	if err := d.decoder.Decode(req, &integration); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	changed, err := stampRequester(req, &integration)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	defaulted, err := defaultProfile(ctx, d.client, req.Namespace, &integration.Spec)
+	if err != nil {
+		webhookLog.Error(err, "Cannot default the trait profile", "namespace", req.Namespace, "name", req.Name)
+	}
+	changed = changed || defaulted
+	if !changed {
+		return admission.Allowed("")
+	}
+
+	data, err := json.Marshal(integration)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, data)
+}
+
+// integrationValidator rejects the Integrations that would fail to be reconciled
+type integrationValidator struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+func (v *integrationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	integration := v1.Integration{}
+	if err := v.decoder.Decode(req, &integration); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Update {
+		old := v1.Integration{}
+		if err := v.decoder.DecodeRaw(req.OldObject, &old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// Only the changes of the user are validated, so that the Integrations that have become invalid,
+		// e.g., because a Kamelet has been deleted, can still be updated by the operator
+		if reflect.DeepEqual(old.Spec, integration.Spec) && reflect.DeepEqual(old.Annotations, integration.Annotations) {
+			return admission.Allowed("")
+		}
+	}
+
+	return response(req, validateIntegrationSpec(ctx, v.client, req.Namespace, integration.ObjectMeta, &integration.Spec, req.UserInfo))
+}
@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// kameletBindingDefaulter sets the trait profile of the KameletBinding integrations to the one of their platform,
+// and records the user that creates or updates them
+type kameletBindingDefaulter struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+func (d *kameletBindingDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	binding := v1alpha1.KameletBinding{}
+	if err := d.decoder.Decode(req, &binding); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	changed, err := stampRequester(req, &binding)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if binding.Spec.Integration != nil {
+		defaulted, err := defaultProfile(ctx, d.client, req.Namespace, binding.Spec.Integration)
+		if err != nil {
+			webhookLog.Error(err, "Cannot default the trait profile", "namespace", req.Namespace, "name", req.Name)
+		}
+		changed = changed || defaulted
+	}
+	if !changed {
+		return admission.Allowed("")
+	}
+
+	data, err := json.Marshal(binding)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, data)
+}
+
+// kameletBindingValidator rejects the KameletBindings that would fail to be reconciled
+type kameletBindingValidator struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+func (v *kameletBindingValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	binding := v1alpha1.KameletBinding{}
+	if err := v.decoder.Decode(req, &binding); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Update {
+		old := v1alpha1.KameletBinding{}
+		if err := v.decoder.DecodeRaw(req.OldObject, &old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if reflect.DeepEqual(old.Spec, binding.Spec) && reflect.DeepEqual(old.Annotations, binding.Annotations) {
+			return admission.Allowed("")
+		}
+	}
+
+	return response(req, v.validate(ctx, req.Namespace, &binding, req.UserInfo))
+}
+
+func (v *kameletBindingValidator) validate(ctx context.Context, namespace string, binding *v1alpha1.KameletBinding, user authenticationv1.UserInfo) error {
+	// The traits may be configured by annotations only
+	spec := binding.Spec.Integration
+	if spec == nil {
+		spec = &v1.IntegrationSpec{}
+	}
+	if err := validateIntegrationSpec(ctx, v.client, namespace, binding.ObjectMeta, spec, user); err != nil {
+		return err
+	}
+
+	if binding.Spec.Source.Ref == nil && binding.Spec.Source.URI == nil {
+		return invalid("no ref or URI specified in the source endpoint")
+	}
+	if binding.Spec.Sink.Ref == nil && binding.Spec.Sink.URI == nil {
+		return invalid("no ref or URI specified in the sink endpoint")
+	}
+
+	// The Kamelets are looked up in the namespace of their reference
+	kamelets := make(map[string][]string)
+	for _, endpoint := range append([]v1alpha1.Endpoint{binding.Spec.Source, binding.Spec.Sink}, binding.Spec.Steps...) {
+		if endpoint.Ref == nil || endpoint.Ref.Kind != v1alpha1.KameletKind {
+			continue
+		}
+		if gv, err := schema.ParseGroupVersion(endpoint.Ref.APIVersion); err != nil || gv.Group != v1alpha1.SchemeGroupVersion.Group {
+			continue
+		}
+		ns := endpoint.Ref.Namespace
+		if ns == "" {
+			ns = namespace
+		}
+		names := kamelets[ns]
+		util.StringSliceUniqueAdd(&names, endpoint.Ref.Name)
+		kamelets[ns] = names
+	}
+
+	if len(kamelets) == 0 {
+		return nil
+	}
+	p, err := lookupPlatform(ctx, v.client, namespace)
+	if err != nil {
+		return err
+	}
+	for ns, names := range kamelets {
+		if err := validateKamelets(ctx, v.client, p, names, ns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
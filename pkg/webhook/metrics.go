@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespaceLabel = "namespace"
+	kindLabel      = "kind"
+	operationLabel = "operation"
+)
+
+var rejections = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "camel_k_webhook_rejections_total",
+		Help: "Camel K resources rejected by the admission webhooks",
+	},
+	[]string{
+		namespaceLabel,
+		kindLabel,
+		operationLabel,
+	},
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(rejections)
+}
+
+func observeRejection(req admission.Request) {
+	rejections.WithLabelValues(req.Namespace, req.Kind.Kind, string(req.Operation)).Inc()
+}
@@ -0,0 +1,299 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/kamelet/repository"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/policy"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+// validationError reports a resource that is not valid, as opposed to a failure to validate it
+type validationError struct {
+	error
+}
+
+func invalid(format string, args ...interface{}) error {
+	return validationError{fmt.Errorf(format, args...)}
+}
+
+// response returns the admission response for the given validation outcome of the given request
+func response(req admission.Request, err error) admission.Response {
+	if err == nil {
+		return admission.Allowed("")
+	}
+	if errors.As(err, &validationError{}) {
+		observeRejection(req)
+		return admission.Denied(err.Error())
+	}
+	return admission.Errored(http.StatusInternalServerError, err)
+}
+
+var (
+	defaultCatalog     *camel.RuntimeCatalog
+	defaultCatalogErr  error
+	defaultCatalogOnce sync.Once
+)
+
+// lookupPlatform returns the platform the resources of the given namespace are reconciled with, if any
+func lookupPlatform(ctx context.Context, c client.Client, namespace string) (*v1.IntegrationPlatform, error) {
+	p, err := platform.GetOrFind(ctx, c, namespace, "", true)
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return p, err
+}
+
+// lookupCatalog returns the catalog of the runtime configured by the given platform,
+// or the default one if the platform or its catalog cannot be found
+func lookupCatalog(ctx context.Context, c client.Client, p *v1.IntegrationPlatform) (*camel.RuntimeCatalog, bool, error) {
+	if p != nil {
+		runtime := v1.RuntimeSpec{
+			Version:  p.Status.Build.RuntimeVersion,
+			Provider: v1.RuntimeProviderQuarkus,
+		}
+		catalog, err := camel.LoadCatalog(ctx, c, p.Namespace, runtime)
+		if err != nil {
+			return nil, false, err
+		}
+		if catalog != nil {
+			return catalog, true, nil
+		}
+	}
+
+	defaultCatalogOnce.Do(func() {
+		defaultCatalog, defaultCatalogErr = camel.DefaultCatalog()
+	})
+	return defaultCatalog, false, defaultCatalogErr
+}
+
+// defaultProfile sets the trait profile of the given spec to the one of the platform, when it is not set
+func defaultProfile(ctx context.Context, c client.Client, namespace string, spec *v1.IntegrationSpec) (bool, error) {
+	if spec.Profile != "" {
+		return false, nil
+	}
+	p, err := lookupPlatform(ctx, c, namespace)
+	if err != nil || p == nil {
+		return false, err
+	}
+	profile := platform.GetProfile(p)
+	if profile == "" {
+		return false, nil
+	}
+	spec.Profile = profile
+	return true, nil
+}
+
+// validateIntegrationSpec checks the trait configuration, the languages and the Kamelets of the given spec,
+// and that the Integration with the given metadata complies with the policy and the quota of the platform
+func validateIntegrationSpec(ctx context.Context, c client.Client, namespace string, meta metav1.ObjectMeta, spec *v1.IntegrationSpec,
+	user authenticationv1.UserInfo) error {
+	annotations := meta.Annotations
+	if spec.Profile != "" && v1.TraitProfileByName(string(spec.Profile)) == "" {
+		return invalid("unknown trait profile %s", spec.Profile)
+	}
+
+	if err := trait.NewCatalog(nil).ValidateTraits(spec.Traits, annotations); err != nil {
+		return validationError{err}
+	}
+	for _, s := range spec.Sources {
+		if err := validateContentRef("source", s.DataSpec); err != nil {
+			return err
+		}
+	}
+	for _, r := range spec.Resources {
+		if err := validateContentRef("resource", r.DataSpec); err != nil {
+			return err
+		}
+	}
+
+	p, err := lookupPlatform(ctx, c, namespace)
+	if err != nil {
+		return err
+	}
+	hasPolicy := policy.Enabled(p)
+	if len(spec.Sources) == 0 && !hasPolicy {
+		return nil
+	}
+	catalog, runtimeCatalog, err := lookupCatalog(ctx, c, p)
+	if err != nil {
+		return err
+	}
+
+	kamelets := make([]string, 0)
+	for _, s := range spec.Sources {
+		if err := validateSourceLanguage(catalog, runtimeCatalog, s); err != nil {
+			return err
+		}
+		if s.ContentRef != "" || s.Compression || s.Content == "" {
+			continue
+		}
+		for _, k := range metadata.Extract(catalog, s).Kamelets {
+			// Kamelets may be referenced along with a configuration id
+			util.StringSliceUniqueAdd(&kamelets, strings.SplitN(k, "/", 2)[0])
+		}
+	}
+	if err := validateKamelets(ctx, c, p, kamelets, namespace); err != nil {
+		return err
+	}
+
+	if hasPolicy {
+		return validatePolicy(ctx, c, p, catalog, namespace, meta, spec, user)
+	}
+	return nil
+}
+
+// validatePolicy checks the given spec, configured by the given user, complies with the policy, the quota and the FIPS mode
+// of the given platform
+func validatePolicy(ctx context.Context, c client.Client, p *v1.IntegrationPlatform, catalog *camel.RuntimeCatalog, namespace string,
+	meta metav1.ObjectMeta, spec *v1.IntegrationSpec, user authenticationv1.UserInfo) error {
+	integration := v1.NewIntegration(namespace, meta.Name)
+	integration.Annotations = meta.Annotations
+	integration.CreationTimestamp = meta.CreationTimestamp
+	integration.Spec = *spec
+
+	subject, err := trait.NewPolicySubject(&trait.Environment{
+		Platform:     p,
+		Integration:  &integration,
+		CamelCatalog: catalog,
+	})
+	if err != nil {
+		return err
+	}
+
+	subject.Requester = &user
+
+	err = policy.CheckPlatform(ctx, c, p, subject)
+	var violations policy.Violations
+	if errors.As(err, &violations) {
+		return validationError{err}
+	}
+	return err
+}
+
+// validateContentRef checks the given source or resource either inlines its content, or references it from
+// a key of a ConfigMap or a Secret
+func validateContentRef(kind string, data v1.DataSpec) error {
+	if data.ContentRef == "" {
+		if data.ContentRefKind != "" {
+			return invalid("%s %s sets contentRefKind without contentRef", kind, data.Name)
+		}
+		return nil
+	}
+	if data.Name == "" {
+		return invalid("%s referenced from %s must have a name", kind, data.ContentRef)
+	}
+	if data.Content != "" || len(data.RawContent) > 0 {
+		return invalid("%s %s cannot both inline its content and reference it from %s", kind, data.Name, data.ContentRef)
+	}
+	if k := data.GetContentRefKind(); k != v1.ContentRefKindConfigMap && k != v1.ContentRefKindSecret {
+		return invalid("unsupported contentRefKind %s for %s %s, must be one of %s or %s", k, kind, data.Name,
+			v1.ContentRefKindConfigMap, v1.ContentRefKindSecret)
+	}
+	return nil
+}
+
+// validateSourceLanguage checks the language of the given source is supported, and, when the catalog
+// of the platform runtime is known, that it provides a loader for the source
+func validateSourceLanguage(catalog *camel.RuntimeCatalog, runtimeCatalog bool, s v1.SourceSpec) error {
+	if s.Loader != "" {
+		if _, ok := catalog.Loaders[s.Loader]; runtimeCatalog && !ok {
+			return invalid("loader %s of source %s is not supported by runtime %s", s.Loader, s.Name, catalog.Runtime.Version)
+		}
+		return nil
+	}
+
+	language := s.InferLanguage()
+	if language == "" {
+		return invalid("cannot infer the language of source %s, either its name must have a known extension or its language must be set", s.Name)
+	}
+	supported := false
+	for _, l := range v1.Languages {
+		if l == language {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return invalid("unsupported language %s for source %s", language, s.Name)
+	}
+
+	// Kamelet sources are not loaded directly, but as route templates
+	if !runtimeCatalog || language == v1.LanguageKamelet {
+		return nil
+	}
+	for _, loader := range catalog.Loaders {
+		if util.StringSliceExists(loader.Languages, string(language)) {
+			return nil
+		}
+	}
+	return invalid("language %s of source %s is not supported by runtime %s", language, s.Name, catalog.Runtime.Version)
+}
+
+// validateKamelets checks the given Kamelets can be found in the repositories available to the given namespace
+func validateKamelets(ctx context.Context, c client.Client, p *v1.IntegrationPlatform, kamelets []string, namespaces ...string) error {
+	if len(kamelets) == 0 {
+		return nil
+	}
+
+	repo, err := repository.NewForPlatform(ctx, c, p, append(namespaces, platform.GetOperatorNamespace())...)
+	if err != nil {
+		return err
+	}
+
+	missing := make([]string, 0)
+	for _, name := range kamelets {
+		if !v1alpha1.ValidKameletName(name) {
+			continue
+		}
+		kamelet, err := repo.Get(ctx, name)
+		if err != nil {
+			return err
+		}
+		if kamelet == nil {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return invalid("kamelets %s not found in repositories: %s", strings.Join(missing, ","), repo.String())
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook contains the admission webhooks that default and validate the Camel K resources,
+// and the conversion webhook of the Integrations
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/policy"
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+// EnabledEnvVariable is the operator environment variable that enables the admission webhooks
+const EnabledEnvVariable = "WEBHOOKS_ENABLED"
+
+const (
+	integrationMutatePath      = "/mutate-camel-apache-org-v1-integration"
+	integrationValidatePath    = "/validate-camel-apache-org-v1-integration"
+	kameletBindingMutatePath   = "/mutate-camel-apache-org-v1alpha1-kameletbinding"
+	kameletBindingValidatePath = "/validate-camel-apache-org-v1alpha1-kameletbinding"
+	integrationConvertPath     = "/convert-camel-apache-org-integration"
+)
+
+// operatorServiceAccount is the service account the operator runs with
+const operatorServiceAccount = "camel-k-operator"
+
+var webhookLog = log.WithName("webhook")
+
+// Enabled returns whether the admission webhooks must be served by the operator
+func Enabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(EnabledEnvVariable))
+	return err == nil && enabled
+}
+
+// AddToManager registers the admission webhooks to the webhook server of the given manager
+func AddToManager(mgr manager.Manager) error {
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	server := mgr.GetWebhookServer()
+	server.Register(integrationMutatePath, &webhook.Admission{Handler: &integrationDefaulter{client: c, decoder: decoder}})
+	server.Register(integrationValidatePath, &webhook.Admission{Handler: &integrationValidator{client: c, decoder: decoder}})
+	server.Register(kameletBindingMutatePath, &webhook.Admission{Handler: &kameletBindingDefaulter{client: c, decoder: decoder}})
+	server.Register(kameletBindingValidatePath, &webhook.Admission{Handler: &kameletBindingValidator{client: c, decoder: decoder}})
+	server.Register(integrationConvertPath, &integrationConverter{})
+
+	return nil
+}
+
+// requesterKeyDir is the directory the Secret that holds the keys the requester annotations are signed with is mounted
+// to. The requester is signed with the current key, and verified against both the current and the previous keys,
+// so that the keys can be rotated without invalidating the signatures of the existing resources at once.
+var requesterKeyDir = "/etc/camel-k/webhook-signing-key"
+
+const (
+	// requesterKey is the Secret entry holding the current key the requester annotations are signed with
+	requesterKey = "signing.key"
+	// previousRequesterKey is the optional Secret entry holding the key that has been rotated out
+	previousRequesterKey = "previous.key"
+)
+
+// requesterKeys returns the keys the requester annotations are verified with, starting with the one they are signed
+// with. The keys are read on every call, so that the rotations of the mounted Secret are taken into account.
+func requesterKeys() ([][]byte, error) {
+	key, err := ioutil.ReadFile(filepath.Join(requesterKeyDir, requesterKey))
+	if err != nil {
+		return nil, err
+	}
+	keys := [][]byte{key}
+	if previous, err := ioutil.ReadFile(filepath.Join(requesterKeyDir, previousRequesterKey)); err == nil && len(previous) > 0 {
+		keys = append(keys, previous)
+	} else if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// stampRequester records the user of the given request in the annotations of the given resource, so that the operator
+// can check the user is authorized to configure the restricted traits. The requester is kept when the resource is
+// changed by the operator itself, e.g., when it creates the Integration of a KameletBinding out of its annotations.
+func stampRequester(req admission.Request, obj metav1.Object) (bool, error) {
+	if req.UserInfo.Username == "" ||
+		req.UserInfo.Username == fmt.Sprintf("system:serviceaccount:%s:%s", platform.GetOperatorNamespace(), operatorServiceAccount) {
+		return false, nil
+	}
+
+	keys, err := requesterKeys()
+	if err != nil {
+		return false, err
+	}
+	traits, err := traitsDigest(obj)
+	if err != nil {
+		return false, err
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	groups := strings.Join(req.UserInfo.Groups, ",")
+	signature := signRequester(keys[0], req.Namespace, obj.GetName(), req.UserInfo.Username, groups, traits)
+	if annotations[v1.RequesterAnnotation] == req.UserInfo.Username && annotations[v1.RequesterGroupsAnnotation] == groups &&
+		annotations[v1.RequesterSignatureAnnotation] == signature {
+		return false, nil
+	}
+
+	setRequester(annotations, req.UserInfo.Username, groups, signature)
+	obj.SetAnnotations(annotations)
+	return true, nil
+}
+
+// Requester returns the user recorded in the annotations of the given resource by the admission webhooks. It returns nil
+// when the webhooks are not enabled, or the annotations have not been set by the webhooks, e.g., they have been set by
+// the user while the webhooks were not available, the traits have been changed since, or the key they are signed with
+// has been rotated out.
+func Requester(obj metav1.Object) *authenticationv1.UserInfo {
+	if !Enabled() {
+		return nil
+	}
+	annotations := obj.GetAnnotations()
+	user := policy.RequesterFromAnnotations(annotations)
+	if user == nil {
+		return nil
+	}
+
+	keys, err := requesterKeys()
+	if err != nil {
+		webhookLog.Error(err, "Cannot read the keys the requester is signed with")
+		return nil
+	}
+	traits, err := traitsDigest(obj)
+	if err != nil {
+		webhookLog.Error(err, "Cannot compute the digest of the traits", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		return nil
+	}
+	for _, key := range keys {
+		signature := signRequester(key, obj.GetNamespace(), obj.GetName(), annotations[v1.RequesterAnnotation],
+			annotations[v1.RequesterGroupsAnnotation], traits)
+		if hmac.Equal([]byte(signature), []byte(annotations[v1.RequesterSignatureAnnotation])) {
+			return user
+		}
+	}
+
+	webhookLog.Info("Ignoring the requester that has not been recorded by the admission webhooks for the current traits",
+		"namespace", obj.GetNamespace(), "name", obj.GetName(), "requester", user.Username)
+	return nil
+}
+
+// PropagateRequester signs the requester of the given resource for the given Integration the operator derives from it,
+// e.g., the Integration of a KameletBinding, whose traits also include the ones of its endpoints. The requester
+// annotations are removed from the Integration when the requester of the resource cannot be verified.
+func PropagateRequester(from metav1.Object, to *v1.Integration) error {
+	annotations := to.GetAnnotations()
+	user := Requester(from)
+	if user == nil {
+		if annotations != nil {
+			delete(annotations, v1.RequesterAnnotation)
+			delete(annotations, v1.RequesterGroupsAnnotation)
+			delete(annotations, v1.RequesterSignatureAnnotation)
+		}
+		return nil
+	}
+
+	keys, err := requesterKeys()
+	if err != nil {
+		return err
+	}
+	traits, err := traitsDigest(to)
+	if err != nil {
+		return err
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	groups := strings.Join(user.Groups, ",")
+	setRequester(annotations, user.Username, groups, signRequester(keys[0], to.Namespace, to.Name, user.Username, groups, traits))
+	to.SetAnnotations(annotations)
+	return nil
+}
+
+func setRequester(annotations map[string]string, username string, groups string, signature string) {
+	annotations[v1.RequesterAnnotation] = username
+	if groups != "" {
+		annotations[v1.RequesterGroupsAnnotation] = groups
+	} else {
+		delete(annotations, v1.RequesterGroupsAnnotation)
+	}
+	annotations[v1.RequesterSignatureAnnotation] = signature
+}
+
+// traitsDigest returns the digest of the trait configuration of the given resource, i.e., its traits and its
+// trait annotations, so that the requester signature cannot be reused once the traits have been changed
+func traitsDigest(obj metav1.Object) (string, error) {
+	var traits map[string]v1.TraitSpec
+	switch o := obj.(type) {
+	case *v1.Integration:
+		traits = o.Spec.Traits
+	case *v1alpha1.KameletBinding:
+		if o.Spec.Integration != nil {
+			traits = o.Spec.Integration.Traits
+		}
+	}
+	annotations := make(map[string]string)
+	for k, v := range obj.GetAnnotations() {
+		if strings.HasPrefix(k, v1.TraitAnnotationPrefix) {
+			annotations[k] = v
+		}
+	}
+
+	// The trait configurations are decoded and encoded again, so that the digest does not depend on the order
+	// of their properties, as the maps are encoded with sorted keys
+	data, err := json.Marshal(traits)
+	if err != nil {
+		return "", err
+	}
+	var canonical interface{}
+	if err := json.Unmarshal(data, &canonical); err != nil {
+		return "", err
+	}
+	data, err = json.Marshal(map[string]interface{}{
+		"annotations": annotations,
+		"traits":      canonical,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// signRequester returns the signature of the requester of the resource with the given namespace, name,
+// and digest of its traits
+func signRequester(key []byte, namespace string, name string, username string, groups string, traits string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join([]string{namespace, name, username, groups, traits}, "\n")))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,641 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/apache/camel-k/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestIntegrationDefaulter(t *testing.T) {
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{})
+	d := integrationDefaulter{client: c, decoder: decoder}
+
+	res := d.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+	}))
+	assert.True(t, res.Allowed)
+	assert.Len(t, res.Patches, 1)
+	assert.Equal(t, "/spec/profile", res.Patches[0].Path)
+	assert.Equal(t, string(v1.TraitProfileKubernetes), res.Patches[0].Value)
+
+	res = d.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec:       v1.IntegrationSpec{Profile: v1.TraitProfileKnative},
+	}))
+	assert.True(t, res.Allowed)
+	assert.Empty(t, res.Patches)
+}
+
+func TestIntegrationDefaulterStampsRequester(t *testing.T) {
+	key := useRequesterKey(t)
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{})
+	d := integrationDefaulter{client: c, decoder: decoder}
+
+	req := newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "my-integration",
+			Annotations: map[string]string{v1.RequesterAnnotation: "admin"},
+		},
+		Spec: v1.IntegrationSpec{Profile: v1.TraitProfileKubernetes},
+	})
+	req.UserInfo = authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev", "system:authenticated"}}
+
+	res := d.Handle(context.TODO(), req)
+	assert.True(t, res.Allowed)
+	patches := make(map[string]interface{})
+	for _, p := range res.Patches {
+		patches[p.Operation+" "+p.Path] = p.Value
+	}
+	assert.Equal(t, map[string]interface{}{
+		"replace /metadata/annotations/camel.apache.org~1requester":       "alice",
+		"add /metadata/annotations/camel.apache.org~1requester.groups":    "dev,system:authenticated",
+		"add /metadata/annotations/camel.apache.org~1requester.signature": signRequester(key, "ns", "my-integration", "alice", "dev,system:authenticated", digest(t, &v1.Integration{})),
+	}, patches)
+}
+
+func TestIntegrationDefaulterWithoutRequesterKey(t *testing.T) {
+	defer func(dir string) {
+		requesterKeyDir = dir
+	}(requesterKeyDir)
+	requesterKeyDir = path.Join(t.TempDir(), "missing")
+
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{})
+	d := integrationDefaulter{client: c, decoder: decoder}
+
+	req := newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec:       v1.IntegrationSpec{Profile: v1.TraitProfileKubernetes},
+	})
+	req.UserInfo = authenticationv1.UserInfo{Username: "alice"}
+
+	res := d.Handle(context.TODO(), req)
+	assert.False(t, res.Allowed)
+}
+
+func TestRequester(t *testing.T) {
+	key := useRequesterKey(t)
+	assert.NoError(t, os.Setenv(EnabledEnvVariable, "true"))
+	defer os.Unsetenv(EnabledEnvVariable)
+
+	integration := v1.NewIntegration("ns", "my-integration")
+	traits := digest(t, &integration)
+	integration.Annotations = map[string]string{
+		v1.RequesterAnnotation:          "alice",
+		v1.RequesterGroupsAnnotation:    "dev",
+		v1.RequesterSignatureAnnotation: signRequester(key, "ns", "my-integration", "alice", "dev", traits),
+	}
+	assert.Equal(t, &authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev"}}, Requester(&integration))
+
+	// The requester annotations set by the user are not trusted
+	integration.Annotations[v1.RequesterAnnotation] = "admin"
+	assert.Nil(t, Requester(&integration))
+	integration.Annotations[v1.RequesterSignatureAnnotation] = signRequester(key, "ns", "my-integration", "admin", "dev", traits)
+	assert.NotNil(t, Requester(&integration))
+	integration.Annotations[v1.RequesterSignatureAnnotation] = signRequester([]byte("forged"), "ns", "my-integration", "admin", "dev", traits)
+	assert.Nil(t, Requester(&integration))
+
+	// The signature of another resource cannot be reused
+	other := v1.NewIntegration("ns", "other")
+	other.Annotations = map[string]string{
+		v1.RequesterAnnotation:          "alice",
+		v1.RequesterGroupsAnnotation:    "dev",
+		v1.RequesterSignatureAnnotation: signRequester(key, "ns", "my-integration", "alice", "dev", traits),
+	}
+	assert.Nil(t, Requester(&other))
+
+	assert.NoError(t, os.Unsetenv(EnabledEnvVariable))
+	other.Annotations[v1.RequesterSignatureAnnotation] = signRequester(key, "ns", "other", "alice", "dev", traits)
+	assert.Nil(t, Requester(&other))
+}
+
+func TestRequesterWithChangedTraits(t *testing.T) {
+	key := useRequesterKey(t)
+	assert.NoError(t, os.Setenv(EnabledEnvVariable, "true"))
+	defer os.Unsetenv(EnabledEnvVariable)
+
+	integration := v1.NewIntegration("ns", "my-integration")
+	integration.Spec.Traits = map[string]v1.TraitSpec{
+		"container": test.TraitSpecFromMap(t, map[string]interface{}{"port": 8081, "name": "integration"}),
+	}
+	integration.Annotations = map[string]string{
+		"trait.camel.apache.org/jvm.enabled": "true",
+		v1.RequesterAnnotation:               "admin",
+	}
+	integration.Annotations[v1.RequesterSignatureAnnotation] = signRequester(key, "ns", "my-integration", "admin", "", digest(t, &integration))
+	assert.NotNil(t, Requester(&integration))
+
+	// The digest does not depend on the order of the trait properties
+	integration.Spec.Traits["container"] = test.TraitSpecFromMap(t, map[string]interface{}{"name": "integration", "port": 8081})
+	assert.NotNil(t, Requester(&integration))
+
+	// The signature cannot be reused once the traits have been changed by another user
+	changed := integration.DeepCopy()
+	changed.Spec.Traits["istio"] = test.TraitSpecFromMap(t, map[string]interface{}{"enabled": true})
+	assert.Nil(t, Requester(changed))
+
+	changed = integration.DeepCopy()
+	changed.Annotations["trait.camel.apache.org/jvm.enabled"] = "false"
+	assert.Nil(t, Requester(changed))
+
+	changed = integration.DeepCopy()
+	changed.Annotations["trait.camel.apache.org/istio.enabled"] = "true"
+	assert.Nil(t, Requester(changed))
+
+	// The other annotations are not signed
+	changed = integration.DeepCopy()
+	changed.Annotations["description"] = "changed"
+	assert.NotNil(t, Requester(changed))
+}
+
+func TestRequesterWithRotatedKey(t *testing.T) {
+	previous := useRequesterKey(t)
+	assert.NoError(t, os.Setenv(EnabledEnvVariable, "true"))
+	defer os.Unsetenv(EnabledEnvVariable)
+
+	integration := v1.NewIntegration("ns", "my-integration")
+	integration.Annotations = map[string]string{
+		v1.RequesterAnnotation:          "alice",
+		v1.RequesterSignatureAnnotation: signRequester(previous, "ns", "my-integration", "alice", "", digest(t, &integration)),
+	}
+
+	// The signatures made with the previous key are still valid after the rotation
+	assert.Nil(t, ioutil.WriteFile(path.Join(requesterKeyDir, previousRequesterKey), previous, 0o600))
+	assert.Nil(t, ioutil.WriteFile(path.Join(requesterKeyDir, requesterKey), []byte("rotated"), 0o600))
+	assert.NotNil(t, Requester(&integration))
+
+	// Until the previous key is removed
+	assert.Nil(t, os.Remove(path.Join(requesterKeyDir, previousRequesterKey)))
+	assert.Nil(t, Requester(&integration))
+}
+
+func TestPropagateRequester(t *testing.T) {
+	key := useRequesterKey(t)
+	assert.NoError(t, os.Setenv(EnabledEnvVariable, "true"))
+	defer os.Unsetenv(EnabledEnvVariable)
+
+	binding := v1alpha1.KameletBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-binding"},
+		Spec: v1alpha1.KameletBindingSpec{
+			Integration: &v1.IntegrationSpec{
+				Traits: map[string]v1.TraitSpec{"istio": test.TraitSpecFromMap(t, map[string]interface{}{"enabled": true})},
+			},
+		},
+	}
+	binding.Annotations = map[string]string{
+		v1.RequesterAnnotation:          "admin",
+		v1.RequesterSignatureAnnotation: signRequester(key, "ns", "my-binding", "admin", "", digest(t, &binding)),
+	}
+
+	// The Integration of the binding has the traits of its endpoints
+	integration := v1.NewIntegration("ns", "my-binding")
+	integration.Annotations = map[string]string{
+		v1.RequesterAnnotation:          "admin",
+		v1.RequesterSignatureAnnotation: binding.Annotations[v1.RequesterSignatureAnnotation],
+	}
+	integration.Spec.Traits = map[string]v1.TraitSpec{
+		"istio":   test.TraitSpecFromMap(t, map[string]interface{}{"enabled": true}),
+		"knative": test.TraitSpecFromMap(t, map[string]interface{}{"enabled": true}),
+	}
+	assert.Nil(t, Requester(&integration))
+	assert.Nil(t, PropagateRequester(&binding, &integration))
+	assert.Equal(t, &authenticationv1.UserInfo{Username: "admin"}, Requester(&integration))
+
+	// The requester of the binding is not propagated once its traits have been changed
+	binding.Spec.Integration.Traits["jvm"] = test.TraitSpecFromMap(t, map[string]interface{}{"enabled": false})
+	assert.Nil(t, PropagateRequester(&binding, &integration))
+	assert.NotContains(t, integration.Annotations, v1.RequesterAnnotation)
+	assert.NotContains(t, integration.Annotations, v1.RequesterSignatureAnnotation)
+	assert.Nil(t, Requester(&integration))
+}
+
+// useRequesterKey sets the key the requester is signed with for the duration of the test
+func useRequesterKey(t *testing.T) []byte {
+	t.Helper()
+
+	key := []byte("signing-key")
+	dir := t.TempDir()
+	assert.Nil(t, ioutil.WriteFile(path.Join(dir, requesterKey), key, 0o600))
+
+	previous := requesterKeyDir
+	requesterKeyDir = dir
+	t.Cleanup(func() {
+		requesterKeyDir = previous
+	})
+
+	return key
+}
+
+func digest(t *testing.T, obj metav1.Object) string {
+	t.Helper()
+
+	d, err := traitsDigest(obj)
+	assert.Nil(t, err)
+	return d
+}
+
+func TestIntegrationValidator(t *testing.T) {
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{})
+	v := integrationValidator{client: c, decoder: decoder}
+
+	validate := func(spec v1.IntegrationSpec) admission.Response {
+		return v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+			Spec:       spec,
+		}))
+	}
+
+	rejected := rejections.WithLabelValues("ns", v1.IntegrationKind, string(admissionv1.Create))
+	count := testutil.ToFloat64(rejected)
+
+	res := validate(v1.IntegrationSpec{
+		Sources: []v1.SourceSpec{yamlSource("kamelet:timer-source")},
+	})
+	assert.True(t, res.Allowed)
+	assert.Equal(t, count, testutil.ToFloat64(rejected))
+
+	res = validate(v1.IntegrationSpec{
+		Traits: map[string]v1.TraitSpec{"unknown": {}},
+	})
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "trait unknown does not exist", string(res.Result.Reason))
+
+	res = validate(v1.IntegrationSpec{
+		Sources: []v1.SourceSpec{{DataSpec: v1.DataSpec{Name: "routes.txt", Content: "content"}}},
+	})
+	assert.False(t, res.Allowed)
+	assert.Contains(t, string(res.Result.Reason), "cannot infer the language of source routes.txt")
+
+	res = validate(v1.IntegrationSpec{
+		Sources: []v1.SourceSpec{yamlSource("kamelet:missing-source")},
+	})
+	assert.False(t, res.Allowed)
+	assert.Contains(t, string(res.Result.Reason), "kamelets missing-source not found in repositories")
+
+	res = validate(v1.IntegrationSpec{
+		Sources: []v1.SourceSpec{
+			{DataSpec: v1.DataSpec{Name: "routes.yaml", ContentRef: "routes", ContentKey: "routes.yaml"}},
+			{DataSpec: v1.DataSpec{Name: "Secured.java", ContentRef: "routes", ContentKey: "Secured.java", ContentRefKind: v1.ContentRefKindSecret}},
+		},
+	})
+	assert.True(t, res.Allowed)
+
+	res = validate(v1.IntegrationSpec{
+		Sources: []v1.SourceSpec{{DataSpec: v1.DataSpec{Name: "routes.yaml", ContentRef: "routes", ContentRefKind: "volume"}}},
+	})
+	assert.False(t, res.Allowed)
+	assert.Contains(t, string(res.Result.Reason), "unsupported contentRefKind volume for source routes.yaml")
+
+	res = validate(v1.IntegrationSpec{
+		Sources: []v1.SourceSpec{{DataSpec: v1.DataSpec{Name: "routes.yaml", Content: "- from:", ContentRef: "routes"}}},
+	})
+	assert.False(t, res.Allowed)
+	assert.Contains(t, string(res.Result.Reason), "source routes.yaml cannot both inline its content and reference it from routes")
+
+	res = validate(v1.IntegrationSpec{
+		Resources: []v1.ResourceSpec{{DataSpec: v1.DataSpec{Name: "schema.json", ContentRefKind: v1.ContentRefKindSecret}}},
+	})
+	assert.False(t, res.Allowed)
+	assert.Contains(t, string(res.Result.Reason), "resource schema.json sets contentRefKind without contentRef")
+	assert.Equal(t, count+6, testutil.ToFloat64(rejected))
+}
+
+func TestIntegrationValidatorSkipsUnchangedSpec(t *testing.T) {
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{})
+	v := integrationValidator{client: c, decoder: decoder}
+
+	integration := v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Sources: []v1.SourceSpec{yamlSource("kamelet:missing-source")},
+		},
+	}
+	req := newRequest(t, admissionv1.Update, &integration)
+	req.OldObject = req.Object
+
+	res := v.Handle(context.TODO(), req)
+	assert.True(t, res.Allowed)
+}
+
+func TestIntegrationValidatorEnforcesPolicy(t *testing.T) {
+	maxReplicas := int32(2)
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{
+		ForbiddenComponents: []string{"camel-exec"},
+		MaxReplicas:         &maxReplicas,
+	})
+	v := integrationValidator{client: c, decoder: decoder}
+
+	replicas := int32(3)
+	res := v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Replicas: &replicas,
+			Sources:  []v1.SourceSpec{yamlSource("exec:ls")},
+		},
+	}))
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "integration does not comply with the platform policy: "+
+		"component exec is forbidden, remove the endpoints and dependencies using it; "+
+		"3 replicas exceed the maximum of 2, scale the integration down to 2 replicas at most", string(res.Result.Reason))
+
+	replicas = 2
+	res = v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec:       v1.IntegrationSpec{Replicas: &replicas},
+	}))
+	assert.True(t, res.Allowed)
+}
+
+func TestIntegrationValidatorEnforcesAllowedElements(t *testing.T) {
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{
+		AllowedComponents:  []string{"timer", "log", "kamelet"},
+		ForbiddenLanguages: []string{"groovy"},
+		ForbiddenKamelets:  []string{"timer-source"},
+	})
+	v := integrationValidator{client: c, decoder: decoder}
+
+	res := v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Sources: []v1.SourceSpec{yamlSource("exec:ls"), yamlSource("kamelet:timer-source")},
+		},
+	}))
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "integration does not comply with the platform policy: "+
+		"component exec is not allowed, only the components timer, log, kamelet can be used; "+
+		"kamelet timer-source is forbidden, remove the endpoints referencing it", string(res.Result.Reason))
+
+	res = v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Sources: []v1.SourceSpec{{
+				DataSpec: v1.DataSpec{Name: "routes.groovy", Content: "from('timer:tick').to('log:info')"},
+			}},
+		},
+	}))
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "integration does not comply with the platform policy: "+
+		"language groovy is forbidden, remove the sources and expressions using it", string(res.Result.Reason))
+
+	res = v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Sources: []v1.SourceSpec{yamlSource("timer:tick")},
+		},
+	}))
+	assert.True(t, res.Allowed)
+}
+
+func TestIntegrationValidatorEnforcesQuota(t *testing.T) {
+	maxIntegrations := int32(1)
+	created := metav1.Now()
+	c, decoder := newFakeClientWithPlatform(t,
+		v1.IntegrationPlatformSpec{
+			Quota: v1.IntegrationPlatformQuotaSpec{MaxIntegrations: &maxIntegrations, RequestsCPU: "1"},
+		},
+		&v1.Integration{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: v1.IntegrationKind},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other", CreationTimestamp: created},
+		},
+		&corev1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "other-pod",
+				Labels:    map[string]string{v1.IntegrationLabel: "other"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "integration",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					},
+				}},
+			},
+		},
+	)
+	v := integrationValidator{client: c, decoder: decoder}
+
+	replicas := int32(2)
+	res := v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+		Spec: v1.IntegrationSpec{
+			Replicas: &replicas,
+			Traits: map[string]v1.TraitSpec{
+				"container": test.TraitSpecFromMap(t, map[string]interface{}{"requestCPU": "300m"}),
+			},
+		},
+	}))
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "integration does not comply with the platform policy: "+
+		"namespace ns has reached its quota of 1 integrations, delete an integration before creating a new one; "+
+		"2 replicas requesting 300m of cpu each exceed the quota of 1 of namespace ns, where 500m are already requested by the other integrations",
+		string(res.Result.Reason))
+
+	// The existing Integration can still be updated, its own pods being excluded from the requested resources
+	res = v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other", CreationTimestamp: created},
+		Spec: v1.IntegrationSpec{
+			Traits: map[string]v1.TraitSpec{
+				"container": test.TraitSpecFromMap(t, map[string]interface{}{"requestCPU": "1"}),
+			},
+		},
+	}))
+	assert.True(t, res.Allowed)
+
+	res = v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1.Integration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other", CreationTimestamp: created},
+	}))
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "integration does not comply with the platform policy: "+
+		"namespace ns has a cpu quota, set the container.request-cpu trait property, e.g., -t container.request-cpu=...",
+		string(res.Result.Reason))
+}
+
+func TestIntegrationValidatorEnforcesRestrictedTraits(t *testing.T) {
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{
+		RestrictedTraits: []string{"istio", "container.image"},
+	})
+	// alice is only authorized to configure the container image
+	c.(*test.FakeClient).Interface.(*fakeclientset.Clientset).PrependReactor("create", "subjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			attributes := sar.Spec.ResourceAttributes
+			sar.Status.Allowed = sar.Spec.User == "alice" && attributes.Group == "trait.camel.apache.org" &&
+				attributes.Resource == "traits" && attributes.Verb == "configure" && attributes.Namespace == "ns" &&
+				attributes.Name == "container.image"
+			return true, sar, nil
+		})
+	v := integrationValidator{client: c, decoder: decoder}
+
+	validate := func(user string, traits map[string]v1.TraitSpec) admission.Response {
+		req := newRequest(t, admissionv1.Create, &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-integration"},
+			Spec:       v1.IntegrationSpec{Traits: traits},
+		})
+		req.UserInfo = authenticationv1.UserInfo{Username: user}
+		return v.Handle(context.TODO(), req)
+	}
+
+	res := validate("alice", map[string]v1.TraitSpec{
+		"container": test.TraitSpecFromMap(t, map[string]interface{}{"image": "quay.io/my-org/my-image"}),
+	})
+	assert.True(t, res.Allowed)
+
+	res = validate("bob", map[string]v1.TraitSpec{
+		"container": test.TraitSpecFromMap(t, map[string]interface{}{"image": "quay.io/my-org/my-image"}),
+	})
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "integration does not comply with the platform policy: "+
+		"user bob is not authorized to configure the restricted trait property container.image, "+
+		"the configure verb on the traits.trait.camel.apache.org resource named container or container.image must be granted",
+		string(res.Result.Reason))
+
+	res = validate("alice", map[string]v1.TraitSpec{
+		"istio": test.TraitSpecFromMap(t, map[string]interface{}{"enabled": false}),
+	})
+	assert.False(t, res.Allowed)
+	assert.Contains(t, string(res.Result.Reason), "user alice is not authorized to configure the restricted trait property istio.enabled")
+}
+
+func TestKameletBindingValidator(t *testing.T) {
+	c, decoder := newFakeClient(t, v1.IntegrationPlatformPolicySpec{})
+	v := kameletBindingValidator{client: c, decoder: decoder}
+
+	validate := func(spec v1alpha1.KameletBindingSpec) admission.Response {
+		return v.Handle(context.TODO(), newRequest(t, admissionv1.Create, &v1alpha1.KameletBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-binding"},
+			Spec:       spec,
+		}))
+	}
+	uri := "log:info"
+
+	res := validate(v1alpha1.KameletBindingSpec{
+		Source: kameletEndpoint("timer-source"),
+		Sink:   v1alpha1.Endpoint{URI: &uri},
+	})
+	assert.True(t, res.Allowed)
+
+	res = validate(v1alpha1.KameletBindingSpec{
+		Sink: v1alpha1.Endpoint{URI: &uri},
+	})
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "no ref or URI specified in the source endpoint", string(res.Result.Reason))
+
+	res = validate(v1alpha1.KameletBindingSpec{
+		Source: kameletEndpoint("timer-source"),
+		Steps:  []v1alpha1.Endpoint{kameletEndpoint("missing-action")},
+		Sink:   kameletEndpoint("missing-sink"),
+	})
+	assert.False(t, res.Allowed)
+	assert.Contains(t, string(res.Result.Reason), "kamelets missing-action,missing-sink not found in repositories")
+}
+
+func newFakeClient(t *testing.T, policy v1.IntegrationPlatformPolicySpec) (client.Client, *admission.Decoder) {
+	t.Helper()
+
+	return newFakeClientWithPlatform(t, v1.IntegrationPlatformSpec{Policy: policy})
+}
+
+func newFakeClientWithPlatform(t *testing.T, spec v1.IntegrationPlatformSpec, objects ...runtime.Object) (client.Client, *admission.Decoder) {
+	t.Helper()
+
+	spec.Cluster = v1.IntegrationPlatformClusterKubernetes
+	objects = append(objects,
+		&v1.IntegrationPlatform{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: v1.IntegrationPlatformKind},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "camel-k"},
+			Status: v1.IntegrationPlatformStatus{
+				IntegrationPlatformSpec: spec,
+				Phase:                   v1.IntegrationPlatformPhaseReady,
+			},
+		},
+		&v1alpha1.Kamelet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.KameletKind},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "timer-source"},
+		},
+	)
+	c, err := test.NewFakeClient(objects...)
+	assert.Nil(t, err)
+
+	decoder, err := admission.NewDecoder(c.GetScheme())
+	assert.Nil(t, err)
+
+	return c, decoder
+}
+
+func newRequest(t *testing.T, operation admissionv1.Operation, obj runtime.Object) admission.Request {
+	t.Helper()
+
+	data, err := json.Marshal(obj)
+	assert.Nil(t, err)
+
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Kind: reflect.TypeOf(obj).Elem().Name()},
+			Operation: operation,
+			Namespace: "ns",
+			Object:    runtime.RawExtension{Raw: data},
+		},
+	}
+}
+
+func yamlSource(uri string) v1.SourceSpec {
+	return v1.SourceSpec{
+		DataSpec: v1.DataSpec{
+			Name:    "routes.yaml",
+			Content: "- from:\n    uri: \"" + uri + "\"\n    steps:\n      - to: \"log:info\"\n",
+		},
+	}
+}
+
+func kameletEndpoint(name string) v1alpha1.Endpoint {
+	return v1alpha1.Endpoint{
+		Ref: &corev1.ObjectReference{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.KameletKind,
+			Name:       name,
+		},
+	}
+}
@@ -24,5 +24,5 @@ echo "Generating API documentation... done!"
 
 echo "Generating traits documentation..."
 cd $rootdir
-go run ./cmd/util/doc-gen --input-dirs ./pkg/trait --input-dirs ./addons/master --input-dirs ./addons/threescale --input-dirs ./addons/tracing
+go run ./cmd/util/doc-gen --input-dirs ./pkg/trait --input-dirs ./addons/master --input-dirs ./addons/strimzi --input-dirs ./addons/threescale --input-dirs ./addons/tracing
 echo "Generating traits documentation... done!"